@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"encr.dev/cli/daemon/dash"
+	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/cli/internal/bench"
+	"encr.dev/parser/encoding"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func init() {
+	var (
+		rps         int
+		duration    time.Duration
+		concurrency int
+		data        string
+		method      string
+		authToken   string
+		authData    string
+		baseURL     string
+	)
+
+	benchCmd := &cobra.Command{
+		Use:   "bench <service.Endpoint> [--rps=100] [--duration=30s] [--data=<json>]",
+		Short: "Load tests an API endpoint of your locally running app",
+		Long: `Load tests an API endpoint of your locally running app.
+
+Sends requests to the endpoint at a constant rate and reports the latency
+percentiles and response status codes. The request payload is given as a
+JSON object using --data, in the same format as when calling APIs from the
+local development dashboard; path parameters are included by name.
+
+The slowest requests are correlated with the traces captured for them,
+together with the latency measured by the app itself, to help find
+where the time was spent.
+
+The app must be running using 'encore run'.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			svc, endpoint, ok := strings.Cut(args[0], ".")
+			if !ok || svc == "" || endpoint == "" {
+				fatalf("invalid endpoint %q: expected <service>.<endpoint>", args[0])
+			}
+
+			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer cancel()
+
+			appRoot, relPath := determineAppRoot()
+			daemon := setupDaemon(ctx)
+			md := appMetadata(ctx, daemon, appRoot, relPath)
+
+			p := &dash.APICallParams{
+				Service:     svc,
+				Endpoint:    endpoint,
+				Method:      method,
+				Payload:     []byte(data),
+				AuthPayload: []byte(authData),
+				AuthToken:   authToken,
+			}
+			newRequest, err := benchRequest(ctx, strings.TrimSuffix(baseURL, "/"), md, p)
+			if err != nil {
+				fatal(err)
+			}
+
+			// Make sure the app is reachable before starting the load test.
+			req, err := newRequest(ctx)
+			if err != nil {
+				fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				fatalf("could not reach the app at %s; make sure it's running using 'encore run': %v", baseURL, err)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode >= 400 {
+				fmt.Fprintf(os.Stderr, "warning: %s %s responded with %s\n", p.Method, p.Path, resp.Status)
+			}
+
+			fmt.Fprintf(os.Stderr, "Sending %d req/s to %s.%s (%s %s) for %s...\n", rps, svc, endpoint, p.Method, p.Path, duration)
+			report, err := bench.Run(ctx, bench.Config{
+				RPS:         rps,
+				Duration:    duration,
+				Concurrency: concurrency,
+				NewRequest:  newRequest,
+			})
+			if err != nil {
+				fatal(err)
+			}
+
+			// Look up the traces of the requests. Give the app a moment to flush them first.
+			var traces map[string]*tracepb2.SpanSummary
+			if len(report.Results) > 0 {
+				time.Sleep(time.Second)
+				traces, err = benchTraces(context.Background(), appRoot, svc, endpoint, report)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "warning: could not read traces: %v\n", err)
+				}
+			}
+			printBenchReport(os.Stdout, report, traces)
+		},
+	}
+
+	benchCmd.Flags().IntVar(&rps, "rps", 100, "Number of requests to send per second")
+	benchCmd.Flags().DurationVar(&duration, "duration", 30*time.Second, "How long to send requests for")
+	benchCmd.Flags().IntVar(&concurrency, "concurrency", 100, "Maximum number of requests in flight; requests exceeding it are dropped")
+	benchCmd.Flags().StringVarP(&data, "data", "d", "", "The request payload as a JSON object, including any path parameters")
+	benchCmd.Flags().StringVarP(&method, "method", "X", "", "The HTTP method to use (defaults to the endpoint's default method)")
+	benchCmd.Flags().StringVar(&authToken, "auth-token", "", "The auth token to send, for apps with a token-based auth handler")
+	benchCmd.Flags().StringVar(&authData, "auth-data", "", "The auth data to send as a JSON object, for apps with a structured auth handler")
+	benchCmd.Flags().StringVar(&baseURL, "url", "http://localhost:4000", "The base URL of the running app")
+	rootCmd.AddCommand(benchCmd)
+}
+
+// benchRequest returns a function creating requests for the API call described by p.
+// It fills in p.Method and p.Path based on the endpoint and payload.
+func benchRequest(ctx context.Context, baseURL string, md *meta.Data, p *dash.APICallParams) (func(context.Context) (*http.Request, error), error) {
+	var rpc *meta.RPC
+	for _, svc := range md.Svcs {
+		for _, r := range svc.Rpcs {
+			if svc.Name == p.Service && r.Name == p.Endpoint {
+				rpc = r
+			}
+		}
+	}
+	if rpc == nil {
+		return nil, fmt.Errorf("unknown endpoint %s.%s", p.Service, p.Endpoint)
+	}
+
+	if p.Method == "" {
+		enc, err := encoding.DescribeRPC(md, rpc, nil)
+		if err != nil {
+			return nil, fmt.Errorf("describe endpoint: %v", err)
+		}
+		p.Method = enc.DefaultMethod
+	}
+	p.Method = strings.ToUpper(p.Method)
+
+	path, err := benchPath(rpc.Path, p.Payload)
+	if err != nil {
+		return nil, err
+	}
+	p.Path = path
+
+	tmpl, err := dash.PrepareAPIRequest(ctx, baseURL, md, p)
+	if err != nil {
+		return nil, err
+	}
+	var body []byte
+	if tmpl.Body != nil {
+		if body, err = io.ReadAll(tmpl.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(ctx context.Context) (*http.Request, error) {
+		req := tmpl.Clone(ctx)
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			req.ContentLength = int64(len(body))
+		}
+		return req, nil
+	}, nil
+}
+
+// benchPath returns the request path for the endpoint path,
+// filling in the path parameters from the JSON payload.
+func benchPath(path *meta.Path, payload []byte) (string, error) {
+	var params map[string]json.RawMessage
+	if len(bytes.TrimSpace(payload)) > 0 {
+		if err := json.Unmarshal(payload, &params); err != nil {
+			return "", fmt.Errorf("invalid --data: expected a JSON object: %v", err)
+		}
+	}
+
+	var b strings.Builder
+	for _, seg := range path.Segments {
+		b.WriteByte('/')
+		if seg.Type == meta.PathSegment_LITERAL {
+			b.WriteString(seg.Value)
+			continue
+		}
+
+		raw, ok := params[seg.Value]
+		if !ok {
+			if seg.Type == meta.PathSegment_FALLBACK {
+				continue
+			}
+			return "", fmt.Errorf("missing path parameter %q in --data", seg.Value)
+		}
+		var val string
+		if err := json.Unmarshal(raw, &val); err != nil {
+			// Not a string; use the JSON value as is, like for numbers and booleans.
+			val = string(raw)
+		}
+		if seg.Type == meta.PathSegment_PARAM {
+			val = url.PathEscape(val)
+		}
+		b.WriteString(val)
+	}
+	if b.Len() == 0 {
+		return "/", nil
+	}
+	return b.String(), nil
+}
+
+// benchTraces returns the root spans of the traces captured for the requests
+// in the report, keyed by trace id.
+func benchTraces(ctx context.Context, appRoot, svc, endpoint string, report *bench.Report) (map[string]*tracepb2.SpanSummary, error) {
+	store, appID, closeStore, err := openTraceStore(appRoot)
+	if err != nil {
+		return nil, err
+	}
+	defer closeStore()
+
+	ids := make(map[string]bool, len(report.Results))
+	start := time.Now()
+	for _, res := range report.Results {
+		if res.TraceID != "" {
+			ids[res.TraceID] = true
+		}
+		if res.Start.Before(start) {
+			start = res.Start
+		}
+	}
+
+	traces := make(map[string]*tracepb2.SpanSummary)
+	testFilter := false
+	err = store.List(ctx, &trace2.Query{
+		AppID:      appID,
+		Service:    svc,
+		Endpoint:   endpoint,
+		StartTime:  start.Add(-time.Second),
+		TestFilter: &testFilter,
+		Limit:      len(report.Results) * 2,
+	}, func(s *tracepb2.SpanSummary) bool {
+		if ids[s.TraceId] {
+			traces[s.TraceId] = s
+		}
+		return true
+	})
+	return traces, err
+}
+
+// printBenchReport prints a summary of the load test, together with the
+// slowest requests and the traces captured for them.
+func printBenchReport(w io.Writer, report *bench.Report, traces map[string]*tracepb2.SpanSummary) {
+	completed := len(report.Completed())
+	fmt.Fprintf(w, "\nRequests:    %d sent, %d completed, %d failed, %d dropped\n",
+		len(report.Results), completed, report.Errors(), report.Dropped)
+	fmt.Fprintf(w, "Throughput:  %.1f req/s\n", report.Throughput())
+	if completed == 0 {
+		for _, res := range report.Results {
+			if res.Err != nil {
+				fmt.Fprintf(w, "Error:       %v\n", res.Err)
+				break
+			}
+		}
+		return
+	}
+
+	codes := report.StatusCodes()
+	statuses := make([]int, 0, len(codes))
+	for code := range codes {
+		statuses = append(statuses, code)
+	}
+	sort.Ints(statuses)
+	var statusStrs []string
+	for _, code := range statuses {
+		statusStrs = append(statusStrs, fmt.Sprintf("%d: %d", code, codes[code]))
+	}
+	fmt.Fprintf(w, "Status:      %s\n", strings.Join(statusStrs, ", "))
+
+	// Server-side latencies are based on the durations of the captured traces.
+	var server []time.Duration
+	for _, tr := range traces {
+		server = append(server, time.Duration(tr.DurationNanos))
+	}
+	sort.Slice(server, func(i, j int) bool { return server[i] < server[j] })
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w)
+	fmt.Fprintln(tw, "Latency\tp50\tp90\tp95\tp99\tmax\t")
+	printRow := func(name string, durs []time.Duration) {
+		row := name
+		for _, p := range []float64{50, 90, 95, 99, 100} {
+			row += "\t" + fmtLatency(bench.Percentile(durs, p))
+		}
+		fmt.Fprintln(tw, row+"\t")
+	}
+	printRow("client", report.Latencies())
+	if len(server) > 0 {
+		printRow(fmt.Sprintf("server (%d traces)", len(server)), server)
+	}
+	_ = tw.Flush()
+
+	p99 := bench.Percentile(report.Latencies(), 99)
+	var outliers []bench.Result
+	for _, res := range report.Slowest(5) {
+		if res.Latency >= p99 {
+			outliers = append(outliers, res)
+		}
+	}
+	fmt.Fprintf(w, "\nSlowest requests:\n")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  STARTED\tLATENCY\tSERVER\tSTATUS\tTRACE ID")
+	for _, res := range outliers {
+		serverLat, traceID := "-", "-"
+		if res.TraceID != "" {
+			traceID = res.TraceID
+		}
+		if tr := traces[res.TraceID]; tr != nil {
+			serverLat = fmtLatency(time.Duration(tr.DurationNanos))
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", res.Start.Format("15:04:05.000"), fmtLatency(res.Latency),
+			serverLat, strconv.Itoa(res.StatusCode), traceID)
+	}
+	_ = tw.Flush()
+	if len(traces) > 0 {
+		fmt.Fprintf(w, "\nOpen the traces in the local development dashboard to see where the time was spent.\n")
+	} else {
+		fmt.Fprintf(w, "\nNo traces were found for the requests.\n")
+	}
+}
+
+// fmtLatency formats a latency with a precision suitable for display.
+func fmtLatency(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"encr.dev/cli/daemon/apps"
 	"encr.dev/cli/daemon/dash"
 	"encr.dev/cli/daemon/engine"
+	"encr.dev/cli/daemon/engine/profiles"
 	"encr.dev/cli/daemon/engine/trace2"
 	"encr.dev/cli/daemon/engine/trace2/sqlite"
 	"encr.dev/cli/daemon/namespace"
@@ -110,6 +111,7 @@ type Daemon struct {
 	ObjectsMgr    *objects.ClusterManager
 	PublicBuckets *objects.PublicBucketServer
 	Trace         trace2.Store
+	Profiles      *profiles.Store
 	Server        *daemon.Server
 
 	dev bool // whether we're in development mode
@@ -153,6 +155,7 @@ func (d *Daemon) init(ctx context.Context) {
 	d.PublicBuckets = objects.NewPublicBucketServer("http://"+d.ObjectStorage.ClientAddr(), d.ObjectsMgr.PersistentStoreFallback)
 
 	d.Trace = sqlite.New(ctx, d.EncoreDB)
+	d.Profiles = profiles.NewStore()
 	d.Secret = secret.New()
 	d.RunMgr = &run.Manager{
 		RuntimePort:   d.Runtime.Port(),
@@ -247,7 +250,7 @@ func (d *Daemon) serveDaemon() {
 func (d *Daemon) serveRuntime() {
 	log.Info().Stringer("addr", d.Runtime.Addr()).Msg("serving runtime")
 	rec := trace2.NewRecorder(d.Trace)
-	srv := engine.NewServer(d.RunMgr, rec)
+	srv := engine.NewServer(d.RunMgr, rec, d.Profiles)
 	d.exit <- http.Serve(d.Runtime, srv)
 }
 
@@ -263,7 +266,7 @@ func (d *Daemon) serveObjects() {
 
 func (d *Daemon) serveDash() {
 	log.Info().Stringer("addr", d.Dash.Addr()).Msg("serving dash")
-	srv := dash.NewServer(d.Apps, d.RunMgr, d.NS, d.Trace, d.Dash.Port())
+	srv := dash.NewServer(d.Apps, d.RunMgr, d.NS, d.Trace, d.Profiles, d.Dash.Port())
 	d.exit <- http.Serve(d.Dash, srv)
 }
 
@@ -0,0 +1,2 @@
+ALTER TABLE trace_span_index ADD COLUMN queue_name TEXT NULL;
+ALTER TABLE trace_span_index ADD COLUMN job_id TEXT NULL;
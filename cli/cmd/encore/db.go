@@ -4,10 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
-	"runtime"
 	"strings"
 
 	"github.com/rs/zerolog/log"
@@ -16,7 +14,6 @@ import (
 	"google.golang.org/grpc/status"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
-	"encr.dev/cli/daemon/sqldb/docker"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
 
@@ -142,25 +139,7 @@ when using tools like Prisma.
 			fatalf("could not connect to the database for service %s: %v", dbName, err)
 		}
 
-		// If we have the psql binary, use that.
-		// Otherwise fall back to docker.
-		var cmd *exec.Cmd
-		if p, err := exec.LookPath("psql"); err == nil {
-			cmd = exec.Command(p, resp.Dsn)
-		} else {
-			fmt.Fprintln(os.Stderr, "encore: no 'psql' executable found in $PATH; using docker to run 'psql' instead.\n\nNote: install psql to hide this message.")
-			dsn := resp.Dsn
-
-			if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
-				// Docker for {Mac, Windows}'s networking setup requires
-				// using "host.docker.internal" instead of "localhost"
-				for _, rep := range []string{"localhost", "127.0.0.1"} {
-					dsn = strings.Replace(dsn, rep, "host.docker.internal", -1)
-				}
-			}
-
-			cmd = exec.Command("docker", "run", "-it", "--rm", "--network=host", docker.Image, "psql", dsn)
-		}
+		cmd := pgToolCmd("psql", []string{"-it"}, resp.Dsn)
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 		cmd.Stdin = os.Stdin
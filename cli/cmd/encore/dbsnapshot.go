@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/cli/daemon/sqldb/docker"
+	"encr.dev/cli/internal/manifest"
+	"encr.dev/internal/conf"
+	"encr.dev/pkg/appfile"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+var (
+	snapshotDBs   []string
+	restoreForce  bool
+	validSnapshot = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+)
+
+var dbSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <name> [--env=<name>] [--test|--shadow] [--db=<database-names>]",
+	Short: "Takes a snapshot of the databases, to restore later using 'encore db restore'",
+	Long: `Takes a snapshot of the databases in an environment, so it can be restored later
+using 'encore db restore'. Snapshots of a given name replace any earlier snapshot
+with the same name.
+
+Defaults to taking a snapshot of all databases in your local environment.
+Specify --env to take a snapshot of another environment, and --db to only include
+some databases.
+
+Use --test to snapshot databases used for integration testing.
+Use --shadow to snapshot the shadow database, used for database drift detection
+when using tools like Prisma.
+
+--test and --shadow imply --env=local.
+`,
+	Args: cobra.ExactArgs(1),
+
+	DisableFlagsInUseLine: true,
+	Run: func(command *cobra.Command, args []string) {
+		appRoot, workingDir := determineAppRoot()
+		name := args[0]
+		if !validSnapshot.MatchString(name) {
+			fatalf("invalid snapshot name %q: must only contain letters, digits, '.', '-' and '_'", name)
+		}
+		if testDB || shadowDB {
+			dbEnv = "local"
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		daemon := setupDaemon(ctx)
+
+		dbNames := snapshotDBs
+		if len(dbNames) == 0 {
+			dbNames = appDatabases(ctx, daemon, appRoot, workingDir)
+			if len(dbNames) == 0 {
+				fatal("the app has no databases to take a snapshot of")
+			}
+		}
+
+		dir, err := snapshotDir(appRoot, name)
+		if err != nil {
+			fatal("could not determine snapshot directory: ", err)
+		}
+
+		// Look up the databases first, since failing to do so exits
+		// and would leave the temporary directory behind.
+		dsns := make([]string, len(dbNames))
+		for i, dbName := range dbNames {
+			dsns[i] = dbConnectDSN(ctx, daemon, appRoot, dbName, daemonpb.DBRole_DB_ROLE_READ)
+		}
+
+		// Write the snapshot to a temporary directory first, so a failed snapshot
+		// doesn't leave behind a partial snapshot or remove an existing one.
+		if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+			fatal("could not create snapshot directory: ", err)
+		}
+		tmpDir, err := os.MkdirTemp(filepath.Dir(dir), "."+name+"-")
+		if err != nil {
+			fatal("could not create snapshot directory: ", err)
+		}
+		if err := writeSnapshot(tmpDir, dir, dbNames, dsns); err != nil {
+			_ = os.RemoveAll(tmpDir)
+			fatal(err)
+		}
+		fmt.Fprintf(os.Stderr, "encore: created snapshot %s\n", name)
+	},
+}
+
+var dbRestoreCmd = &cobra.Command{
+	Use:   "restore <name> [--env=<name>] [--test|--shadow] [--db=<database-names>]",
+	Short: "Restores the databases from a snapshot taken with 'encore db snapshot'",
+	Long: `Restores the databases in an environment from a snapshot taken using
+'encore db snapshot'. Restoring a local database first resets it, so it contains
+exactly the data in the snapshot.
+
+Defaults to restoring all databases in the snapshot to your local environment.
+Specify --env to restore to another environment (which requires --force),
+and --db to only restore some databases.
+
+Use --test to restore databases used for integration testing.
+Use --shadow to restore the shadow database, used for database drift detection
+when using tools like Prisma.
+
+--test and --shadow imply --env=local.
+`,
+	Args: cobra.ExactArgs(1),
+
+	DisableFlagsInUseLine: true,
+	Run: func(command *cobra.Command, args []string) {
+		appRoot, _ := determineAppRoot()
+		name := args[0]
+		if !validSnapshot.MatchString(name) {
+			fatalf("invalid snapshot name %q: must only contain letters, digits, '.', '-' and '_'", name)
+		}
+		if testDB || shadowDB {
+			dbEnv = "local"
+		}
+		if dbEnv != "local" && !restoreForce {
+			fatalf("restoring a snapshot overwrites the data in the %s environment.\n\n"+
+				"Note: to restore the snapshot anyway, specify the --force flag.", dbEnv)
+		}
+
+		dir, err := snapshotDir(appRoot, name)
+		if err != nil {
+			fatal("could not determine snapshot directory: ", err)
+		}
+		files, err := filepath.Glob(filepath.Join(dir, "*.dump"))
+		if err != nil {
+			fatal("could not read snapshot: ", err)
+		} else if len(files) == 0 {
+			fatalf("no snapshot named %s found", name)
+		}
+
+		dbNames := make([]string, 0, len(files))
+		for _, f := range files {
+			dbNames = append(dbNames, strings.TrimSuffix(filepath.Base(f), ".dump"))
+		}
+		if len(snapshotDBs) > 0 {
+			for _, dbName := range snapshotDBs {
+				if !slices.Contains(dbNames, dbName) {
+					fatalf("snapshot %s does not contain database %s", name, dbName)
+				}
+			}
+			dbNames = snapshotDBs
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		daemon := setupDaemon(ctx)
+
+		// Reset local databases first so objects created after the snapshot was taken are removed.
+		if dbEnv == "local" {
+			stream, err := daemon.DBReset(ctx, &daemonpb.DBResetRequest{
+				AppRoot:       appRoot,
+				DatabaseNames: dbNames,
+				ClusterType:   dbClusterType(),
+				Namespace:     nonZeroPtr(nsName),
+			})
+			if err != nil {
+				fatal("reset databases: ", err)
+			}
+			if code := cmdutil.StreamCommandOutput(stream, nil); code != 0 {
+				os.Exit(code)
+			}
+		}
+
+		for _, dbName := range dbNames {
+			dsn := dbConnectDSN(ctx, daemon, appRoot, dbName, daemonpb.DBRole_DB_ROLE_ADMIN)
+			fmt.Fprintf(os.Stderr, "encore: restoring database %s\n", dbName)
+
+			if err := restoreDatabase(dsn, filepath.Join(dir, dbName+".dump")); err != nil {
+				fatalf("could not restore database %s: %v", dbName, err)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "encore: restored snapshot %s\n", name)
+	},
+}
+
+// dumpDatabase streams a pg_dump of the database into the file at path.
+func dumpDatabase(dsn, path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	cmd := pgToolCmd("pg_dump", nil, dsn, "--format=custom", "--no-owner", "--no-privileges")
+	cmd.Stdout = f
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// restoreDatabase streams the dump in the file at path into pg_restore.
+func restoreDatabase(dsn, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	cmd := pgToolCmd("pg_restore", []string{"-i"}, dsn,
+		"--clean", "--if-exists", "--no-owner", "--no-privileges", "--single-transaction", "--dbname")
+	cmd.Stdin = f
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// appDatabases returns the names of the databases defined by the app.
+func appDatabases(ctx context.Context, daemon daemonpb.DaemonClient, appRoot, workingDir string) []string {
+	md := appMetadata(ctx, daemon, appRoot, workingDir)
+	names := make([]string, 0, len(md.SqlDatabases))
+	for _, db := range md.SqlDatabases {
+		names = append(names, db.Name)
+	}
+	return names
+}
+
+// appMetadata parses the app and returns its metadata.
+func appMetadata(ctx context.Context, daemon daemonpb.DaemonClient, appRoot, workingDir string) *meta.Data {
+	resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+		AppRoot:    appRoot,
+		WorkingDir: workingDir,
+		Environ:    os.Environ(),
+		Format:     daemonpb.DumpMetaRequest_FORMAT_PROTO,
+	})
+	if err != nil {
+		fatal("could not parse app: ", err)
+	}
+	var md meta.Data
+	if err := proto.Unmarshal(resp.Meta, &md); err != nil {
+		fatal("could not parse app metadata: ", err)
+	}
+	return &md
+}
+
+// dbConnectDSN returns the DSN for connecting to the database through the daemon's database proxy.
+func dbConnectDSN(ctx context.Context, daemon daemonpb.DaemonClient, appRoot, dbName string, role daemonpb.DBRole) string {
+	resp, err := daemon.DBConnect(ctx, &daemonpb.DBConnectRequest{
+		AppRoot:     appRoot,
+		DbName:      dbName,
+		EnvName:     dbEnv,
+		ClusterType: dbClusterType(),
+		Namespace:   nonZeroPtr(nsName),
+		Role:        role,
+	})
+	if err != nil {
+		fatalf("could not connect to the database %s: %v", dbName, err)
+	}
+	return resp.Dsn
+}
+
+// writeSnapshot dumps the databases to tmpDir, and then replaces
+// the snapshot in dir with it.
+func writeSnapshot(tmpDir, dir string, dbNames, dsns []string) error {
+	for i, dbName := range dbNames {
+		fmt.Fprintf(os.Stderr, "encore: taking snapshot of database %s\n", dbName)
+		if err := dumpDatabase(dsns[i], filepath.Join(tmpDir, dbName+".dump")); err != nil {
+			return fmt.Errorf("could not take snapshot of database %s: %v", dbName, err)
+		}
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("could not replace existing snapshot: %v", err)
+	} else if err := os.Rename(tmpDir, dir); err != nil {
+		return fmt.Errorf("could not write snapshot: %v", err)
+	}
+	return nil
+}
+
+// snapshotDir returns the directory where the snapshot with the given name is stored.
+// Snapshots are stored per app but not per environment, so a snapshot of one
+// environment can be restored to another.
+func snapshotDir(appRoot, name string) (string, error) {
+	appID, err := appfile.Slug(appRoot)
+	if err != nil {
+		return "", err
+	} else if appID == "" {
+		man, err := manifest.ReadOrCreate(appRoot)
+		if err != nil {
+			return "", err
+		}
+		appID = man.LocalID
+	}
+
+	dataDir, err := conf.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "snapshots", appID, name), nil
+}
+
+// pgToolCmd returns a command running the given Postgres client tool,
+// with the DSN as the last argument. If the tool isn't installed it's run
+// using docker instead, with the given additional flags to 'docker run'.
+func pgToolCmd(tool string, dockerFlags []string, dsn string, args ...string) *exec.Cmd {
+	if p, err := exec.LookPath(tool); err == nil {
+		return exec.Command(p, append(args, dsn)...)
+	}
+
+	fmt.Fprintf(os.Stderr, "encore: no '%s' executable found in $PATH; using docker to run '%s' instead.\n\nNote: install %s to hide this message.\n", tool, tool, tool)
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		// Docker for {Mac, Windows}'s networking setup requires
+		// using "host.docker.internal" instead of "localhost"
+		for _, rep := range []string{"localhost", "127.0.0.1"} {
+			dsn = strings.Replace(dsn, rep, "host.docker.internal", -1)
+		}
+	}
+
+	dockerArgs := append([]string{"run", "--rm", "--network=host"}, dockerFlags...)
+	dockerArgs = append(dockerArgs, docker.Image, tool)
+	dockerArgs = append(dockerArgs, args...)
+	return exec.Command("docker", append(dockerArgs, dsn)...)
+}
+
+func init() {
+	dbSnapshotCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
+	dbSnapshotCmd.Flags().StringVarP(&dbEnv, "env", "e", "local", "Environment name to take a snapshot of (such as \"prod\")")
+	dbSnapshotCmd.Flags().StringSliceVar(&snapshotDBs, "db", nil, "Databases to include in the snapshot (defaults to all databases)")
+	dbSnapshotCmd.Flags().BoolVarP(&testDB, "test", "t", false, "Take a snapshot of the integration test databases (implies --env=local)")
+	dbSnapshotCmd.Flags().BoolVar(&shadowDB, "shadow", false, "Take a snapshot of the shadow databases (implies --env=local)")
+	dbCmd.AddCommand(dbSnapshotCmd)
+
+	dbRestoreCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
+	dbRestoreCmd.Flags().StringVarP(&dbEnv, "env", "e", "local", "Environment name to restore to (such as \"prod\")")
+	dbRestoreCmd.Flags().StringSliceVar(&snapshotDBs, "db", nil, "Databases to restore (defaults to all databases in the snapshot)")
+	dbRestoreCmd.Flags().BoolVarP(&testDB, "test", "t", false, "Restore the integration test databases (implies --env=local)")
+	dbRestoreCmd.Flags().BoolVar(&shadowDB, "shadow", false, "Restore the shadow databases (implies --env=local)")
+	dbRestoreCmd.Flags().BoolVar(&restoreForce, "force", false, "Restore the snapshot even if the environment is not local")
+	dbCmd.AddCommand(dbRestoreCmd)
+}
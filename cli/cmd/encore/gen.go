@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -12,6 +13,7 @@ import (
 	"encr.dev/cli/cmd/encore/cmdutil"
 	"encr.dev/cli/internal/manifest"
 	"encr.dev/internal/clientgen"
+	"encr.dev/internal/openapiimport"
 	"encr.dev/pkg/appfile"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
@@ -46,6 +48,8 @@ Supported language codes are:
   typescript: A TypeScript client using the Fetch API
   javascript: A JavaScript client using the Fetch API
   go: A Go client using net/http"
+  python: A Python client with sync and asyncio support using httpx
+  rust: A Rust client using reqwest and serde
   openapi: An OpenAPI specification (EXPERIMENTAL)
 
 By default all services with a non-private API endpoint are included.
@@ -96,7 +100,7 @@ To further narrow down the services to generate, use the '--services' flag.
 				// Validate the user input for the language
 				l, err := clientgen.GetLang(lang)
 				if err != nil {
-					fatal(fmt.Sprintf("%s: supported languages are `typescript`, `javascript`, `go` and `openapi`", err))
+					fatal(fmt.Sprintf("%s: supported languages are `typescript`, `javascript`, `go`, `python`, `rust` and `openapi`", err))
 				}
 				lang = string(l)
 			}
@@ -161,19 +165,75 @@ which may require the user-facing wrapper code to be manually generated.`,
 		},
 	}
 
+	var fromOpenAPI string
+	genServiceCmd := &cobra.Command{
+		Use:   "service <name> --from-openapi=<spec>",
+		Short: "Generates a service from an OpenAPI specification",
+		Long: `Generates a service from an OpenAPI specification.
+
+The service is written to a new directory named <name> in the current directory.
+It contains typed request and response structs for the schemas in the
+specification, and an endpoint stub for every operation that must be implemented.
+
+Only Go apps are supported.
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if fromOpenAPI == "" {
+				fatal("specify the OpenAPI specification to generate the service from using --from-openapi.")
+			}
+			appRoot, relPath := determineAppRoot()
+			if lang, err := appfile.AppLang(appRoot); err != nil {
+				fatal(err)
+			} else if lang != appfile.LangGo {
+				fatalf("generating services is only supported for Go apps, not %s apps.", lang)
+			}
+
+			svcName := args[0]
+			dir := filepath.Join(appRoot, relPath, svcName)
+			if _, err := os.Stat(dir); err == nil {
+				fatalf("the directory %s already exists.", dir)
+			}
+
+			spec, err := openapiimport.Load(cmd.Context(), fromOpenAPI)
+			if err != nil {
+				fatal(err)
+			}
+			files, err := openapiimport.Generate(spec, svcName)
+			if err != nil {
+				fatal(err)
+			}
+
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				fatal(err)
+			}
+			for name, data := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+					fatal(err)
+				}
+			}
+			fmt.Printf("successfully generated service %s in %s.\n", svcName, dir)
+		},
+	}
+	genServiceCmd.Flags().StringVar(&fromOpenAPI, "from-openapi", "", "The OpenAPI specification (JSON or YAML) to generate the service from")
+	_ = genServiceCmd.MarkFlagFilename("from-openapi", "json", "yaml", "yml")
+
 	genCmd.AddCommand(genClientCmd)
 	genCmd.AddCommand(genWrappersCmd)
+	genCmd.AddCommand(genServiceCmd)
 
-	genClientCmd.Flags().StringVarP(&lang, "lang", "l", "", "The language to generate code for (\"typescript\", \"javascript\", \"go\", and \"openapi\" are supported)")
+	genClientCmd.Flags().StringVarP(&lang, "lang", "l", "", "The language to generate code for (\"typescript\", \"javascript\", \"go\", \"python\", \"rust\", and \"openapi\" are supported)")
 	_ = genClientCmd.RegisterFlagCompletionFunc("lang", cmdutil.AutoCompleteFromStaticList(
 		"typescript\tA TypeScript client using the in-browser Fetch API",
 		"javascript\tA JavaScript client using the in-browser Fetch API",
 		"go\tA Go client using net/http",
+		"python\tA Python client using httpx",
+		"rust\tA Rust client using reqwest",
 		"openapi\tAn OpenAPI specification",
 	))
 
 	genClientCmd.Flags().StringVarP(&output, "output", "o", "", "The filename to write the generated client code to")
-	_ = genClientCmd.MarkFlagFilename("output", "go", "ts", "tsx", "js", "jsx")
+	_ = genClientCmd.MarkFlagFilename("output", "go", "ts", "tsx", "js", "jsx", "py", "rs")
 
 	genClientCmd.Flags().StringVarP(&envName, "env", "e", "local", "The environment to fetch the API for (defaults to the local environment)")
 	_ = genClientCmd.RegisterFlagCompletionFunc("env", cmdutil.AutoCompleteEnvSlug)
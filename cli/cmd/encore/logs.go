@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -15,28 +16,49 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 
+	"encr.dev/cli/cmd/encore/cmdutil"
 	"encr.dev/cli/internal/platform"
 	"encr.dev/pkg/appfile"
 )
 
 var (
-	logsEnv   string
-	logsJSON  bool
-	logsQuiet bool
+	logsEnv     string
+	logsJSON    bool
+	logsQuiet   bool
+	logsService string
+	logsTrace   string
+	logsFollow  bool
+	logsLevel   = cmdutil.Oneof{
+		Value:    "",
+		Allowed:  []string{"trace", "debug", "info", "warn", "error"},
+		Flag:     "level",
+		Desc:     "Only include logs at this level or above",
+		TypeDesc: "string",
+	}
 )
 
 var logsCmd = &cobra.Command{
-	Use:   "logs [--env=prod] [--json]",
+	Use:   "logs [--env=prod] [--service=name] [--level=error] [--trace=<id>] [--json]",
 	Short: "Streams logs from your application",
+	Long: `Streams logs from your application.
+
+Use --service, --level and --trace to only include the logs from a service,
+at a minimum level, or written while handling a single request, identified
+by its trace id.`,
 
 	DisableFlagsInUseLine: true,
 	Run: func(cmd *cobra.Command, args []string) {
 		appRoot, _ := determineAppRoot()
-		streamLogs(appRoot, logsEnv)
+		streamLogs(appRoot, logsEnv, platform.LogFilter{
+			Service:  logsService,
+			MinLevel: logsLevel.Value,
+			TraceID:  logsTrace,
+			NoFollow: !logsFollow,
+		})
 	},
 }
 
-func streamLogs(appRoot, envName string) {
+func streamLogs(appRoot, envName string, filter platform.LogFilter) {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
 	appSlug, err := appfile.Slug(appRoot)
@@ -49,7 +71,7 @@ func streamLogs(appRoot, envName string) {
 	if envName == "" {
 		envName = "@primary"
 	}
-	logs, err := platform.EnvLogs(ctx, appSlug, envName)
+	logs, err := platform.EnvLogs(ctx, appSlug, envName, filter)
 	if err != nil {
 		var e platform.Error
 		if errors.As(err, &e) {
@@ -85,6 +107,10 @@ func streamLogs(appRoot, envName string) {
 
 		lines := bytes.Split(message, []byte("\n"))
 		for _, line := range lines {
+			if !matchLog(filter, line) {
+				continue
+			}
+
 			// Pretty-print logs if requested and it looks like a JSON log line
 			if !logsJSON && bytes.HasPrefix(line, []byte{'{'}) {
 				if _, err := cw.Write(mapCloudFieldNamesToExpected(line)); err != nil {
@@ -100,6 +126,58 @@ func streamLogs(appRoot, envName string) {
 	}
 }
 
+// matchLog reports whether the log line matches the filter.
+// The logs are filtered server-side, but the filter is applied to the received
+// lines as well in case the server doesn't support some of the filters.
+// Lines that are not structured logs never match a filter.
+func matchLog(filter platform.LogFilter, line []byte) bool {
+	if filter.Service == "" && filter.MinLevel == "" && filter.TraceID == "" {
+		return true
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return false
+	}
+	str := func(key string) string {
+		s, _ := fields[key].(string)
+		return s
+	}
+
+	if filter.Service != "" && str("service") != filter.Service {
+		return false
+	}
+	if filter.TraceID != "" && str("trace_id") != filter.TraceID {
+		return false
+	}
+	if filter.MinLevel != "" {
+		level := str(zerolog.LevelFieldName)
+		if level == "" {
+			level = str("severity") // GCP style logging
+		}
+		if parseLogLevel(level) < parseLogLevel(filter.MinLevel) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLogLevel parses a zerolog or GCP style log level.
+// Unknown levels are treated as info.
+func parseLogLevel(s string) zerolog.Level {
+	s = strings.ToLower(s)
+	switch s {
+	case "warning":
+		return zerolog.WarnLevel
+	case "critical", "alert", "emergency":
+		return zerolog.FatalLevel
+	}
+	if level, err := zerolog.ParseLevel(s); err == nil && level != zerolog.NoLevel {
+		return level
+	}
+	return zerolog.InfoLevel
+}
+
 // mapCloudFieldNamesToExpected detects if we're logging with GCP style logging and then swaps
 // the field names to what is expected by zerolog
 func mapCloudFieldNamesToExpected(jsonBytes []byte) []byte {
@@ -137,4 +215,8 @@ func init() {
 	logsCmd.Flags().StringVarP(&logsEnv, "env", "e", "", "Environment name to stream logs from (defaults to the primary environment)")
 	logsCmd.Flags().BoolVar(&logsJSON, "json", false, "Whether to print logs in raw JSON format")
 	logsCmd.Flags().BoolVarP(&logsQuiet, "quiet", "q", false, "Whether to print initial message when the command is waiting for logs")
+	logsCmd.Flags().StringVarP(&logsService, "service", "s", "", "Only include logs from this service")
+	logsCmd.Flags().StringVar(&logsTrace, "trace", "", "Only include logs for the request with this trace id")
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", true, "Keep streaming new logs; use --follow=false to exit after the recent logs")
+	logsLevel.AddFlag(logsCmd)
 }
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+
+	"github.com/logrusorgru/aurora/v3"
+	"github.com/spf13/cobra"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/cli/internal/platform"
+	"encr.dev/pkg/appfile"
+)
+
+var (
+	profileSeconds int
+	profileOutput  string
+	profilePort    int
+	profileType    = cmdutil.Oneof{
+		Value:     "cpu",
+		Allowed:   []string{"cpu", "heap", "allocs", "goroutine", "block", "mutex", "threadcreate", "trace"},
+		Flag:      "type",
+		FlagShort: "t",
+		Desc:      "The kind of profile to capture",
+		TypeDesc:  "string",
+	}
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile <env> <service> [--type=cpu] [--seconds=30] [--output=file]",
+	Short: "Captures a pprof profile from a running service",
+	Long: `Captures a pprof profile from a running instance of a service.
+
+The profile is written to a file that can be inspected using "go tool pprof",
+or "go tool trace" for execution traces.
+
+Use "local" as the environment to profile the app running with "encore run".`,
+
+	Args:                  cobra.ExactArgs(2),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		envName, service := args[0], args[1]
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+
+		// The runtime names the CPU profile "profile", like net/http/pprof.
+		profile := profileType.Value
+		if profile == "cpu" {
+			profile = "profile"
+		}
+
+		var body io.ReadCloser
+		var err error
+		if envName == "local" {
+			body, err = localProfile(ctx, profile, profileSeconds)
+		} else {
+			appRoot, _ := determineAppRoot()
+			appSlug, slugErr := appfile.Slug(appRoot)
+			if slugErr != nil {
+				fatal(slugErr)
+			} else if appSlug == "" {
+				fatal("app is not linked with Encore Cloud")
+			}
+			body, err = platform.ServiceProfile(ctx, appSlug, envName, service, profile, profileSeconds)
+		}
+		if err != nil {
+			var e platform.Error
+			if errors.As(err, &e) {
+				switch e.Code {
+				case "env_not_found":
+					fatalf("environment %q not found", envName)
+				case "service_not_found":
+					fatalf("service %q not found in environment %q", service, envName)
+				}
+			}
+			fatal(err)
+		}
+		defer func() { _ = body.Close() }()
+
+		output := profileOutput
+		if output == "" {
+			output = fmt.Sprintf("%s-%s.pprof", service, profileType.Value)
+			if profileType.Value == "trace" {
+				output = fmt.Sprintf("%s-trace.out", service)
+			}
+		}
+		if err := writeProfile(output, body); err != nil {
+			fatal(err)
+		}
+
+		tool := "pprof"
+		if profileType.Value == "trace" {
+			tool = "trace"
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s profile to %s\n", profileType.Value, output)
+		fmt.Fprintln(os.Stderr, aurora.Gray(12, fmt.Sprintf("Inspect it using: go tool %s %s", tool, output)))
+	},
+}
+
+// localProfile captures a profile from the app running locally.
+func localProfile(ctx context.Context, profile string, seconds int) (io.ReadCloser, error) {
+	url := fmt.Sprintf("http://localhost:%d/__encore/debug/pprof/%s", profilePort, profile)
+	if seconds > 0 {
+		url += "?seconds=" + strconv.Itoa(seconds)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not reach the local app, is it running? %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer func() { _ = resp.Body.Close() }()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("capturing profile failed: %s: %s", resp.Status, msg)
+	}
+	return resp.Body, nil
+}
+
+func writeProfile(path string, body io.Reader) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return fmt.Errorf("capturing profile failed: %v", err)
+	}
+	return f.Close()
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.Flags().IntVarP(&profileSeconds, "seconds", "s", 0, "Duration to capture CPU profiles, execution traces and block or mutex profiles for")
+	profileCmd.Flags().StringVarP(&profileOutput, "output", "o", "", "File to write the profile to (defaults to <service>-<type>.pprof)")
+	profileCmd.Flags().IntVarP(&profilePort, "port", "p", 4000, "Port the local app is listening on, when profiling the local environment")
+	profileType.AddFlag(profileCmd)
+}
@@ -30,11 +30,14 @@ var (
 		Desc:        "Compile for debugging (disables some optimizations)",
 		TypeDesc:    "string",
 	}
-	watch    bool
-	listen   string
-	port     uint
-	jsonLogs bool
-	browser  = cmdutil.Oneof{
+	watch       bool
+	listen      string
+	port        uint
+	jsonLogs    bool
+	runServices []string
+	proxyRestTo string
+	traceExport string
+	browser     = cmdutil.Oneof{
 		Value:     "auto",
 		Allowed:   []string{"auto", "never", "always"},
 		Flag:      "browser",
@@ -56,6 +59,9 @@ func init() {
 			if !cmd.Flag("watch").Changed && debug.Value != "" {
 				watch = false
 			}
+			if (len(runServices) > 0) != (proxyRestTo != "") {
+				fatal("--services and --proxy-rest-to must be used together")
+			}
 			runApp(appRoot, wd)
 		},
 	}
@@ -70,6 +76,9 @@ func init() {
 	runCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
 	runCmd.Flags().BoolVar(&color, "color", isTerm, "Whether to display colorized output")
 	runCmd.Flags().BoolVar(&noColor, "no-color", false, "Equivalent to --color=false")
+	runCmd.Flags().StringSliceVar(&runServices, "services", nil, "Only run the given services locally (comma-separated)")
+	runCmd.Flags().StringVar(&proxyRestTo, "proxy-rest-to", "", "Environment name or base URL to proxy calls to the services not run locally to")
+	runCmd.Flags().StringVar(&traceExport, "trace-export", "", "OTLP/HTTP endpoint to also send traces to, such as a local Grafana Tempo or Jaeger (for example \"http://localhost:4318\")")
 	runCmd.Flags().MarkHidden("no-color")
 	debug.AddFlag(runCmd)
 	browser.AddFlag(runCmd)
@@ -115,15 +124,18 @@ func runApp(appRoot, wd string) {
 
 	daemon := setupDaemon(ctx)
 	stream, err := daemon.Run(ctx, &daemonpb.RunRequest{
-		AppRoot:    appRoot,
-		DebugMode:  debugMode,
-		Watch:      watch,
-		WorkingDir: wd,
-		ListenAddr: listenAddr,
-		Environ:    os.Environ(),
-		TraceFile:  root.TraceFile,
-		Namespace:  nonZeroPtr(nsName),
-		Browser:    browserMode,
+		AppRoot:     appRoot,
+		DebugMode:   debugMode,
+		Watch:       watch,
+		WorkingDir:  wd,
+		ListenAddr:  listenAddr,
+		Environ:     os.Environ(),
+		TraceFile:   root.TraceFile,
+		Namespace:   nonZeroPtr(nsName),
+		Browser:     browserMode,
+		Services:    runServices,
+		ProxyRestTo: proxyRestTo,
+		TraceExport: traceExport,
 	})
 	if err != nil {
 		fatal(err)
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // for "sqlite3" driver
+	"github.com/spf13/cobra"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/cli/daemon/engine/trace2/export"
+	"encr.dev/cli/daemon/engine/trace2/sqlite"
+	"encr.dev/cli/internal/manifest"
+	"encr.dev/internal/conf"
+	"encr.dev/pkg/appfile"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+var traceCmd = &cobra.Command{
+	Use:   "trace",
+	Short: "Commands for working with locally captured traces",
+}
+
+func init() {
+	format := cmdutil.Oneof{
+		Value:     "json",
+		Allowed:   []string{"json", "otlp"},
+		Flag:      "format",
+		FlagShort: "f",
+		Desc:      "Output format",
+	}
+
+	var (
+		since  time.Duration
+		limit  int
+		output string
+		tests  bool
+	)
+
+	exportCmd := &cobra.Command{
+		Use:   "export [--format=json|otlp] [--since=1h] [--output=file]",
+		Short: "Exports locally captured traces",
+		Long: `Exports the traces captured while running the app locally.
+
+The json format writes the traces and all their events using the protobuf JSON
+mapping of Encore's trace format. The otlp format writes the spans in the OTLP
+JSON encoding, which can be imported into OpenTelemetry-compatible tools.`,
+		Args:                  cobra.NoArgs,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			var testFilter *bool
+			if !tests {
+				testFilter = &tests
+			}
+
+			traces, err := loadTraces(cmd.Context(), appRoot, &trace2.Query{
+				StartTime:  time.Now().Add(-since),
+				TestFilter: testFilter,
+				Limit:      limit,
+			})
+			if err != nil {
+				fatal("export traces: ", err)
+			}
+
+			var w io.Writer = os.Stdout
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					fatal(err)
+				}
+				defer func() {
+					if err := f.Close(); err != nil {
+						fatal(err)
+					}
+				}()
+				w = f
+			}
+
+			write := export.WriteJSON
+			if format.Value == "otlp" {
+				write = export.WriteOTLP
+			}
+			if err := write(w, traces); err != nil {
+				fatal("export traces: ", err)
+			}
+		},
+	}
+
+	format.AddFlag(exportCmd)
+	exportCmd.Flags().DurationVar(&since, "since", time.Hour, "Export traces started within this duration")
+	exportCmd.Flags().IntVar(&limit, "limit", 1000, "Maximum number of traces to export")
+	exportCmd.Flags().StringVarP(&output, "output", "o", "", "File to write the traces to (defaults to stdout)")
+	exportCmd.Flags().BoolVar(&tests, "tests", false, "Include traces captured when running tests")
+	traceCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(traceCmd)
+}
+
+// loadTraces reads the traces matching the query for the app
+// from the trace store of the daemon.
+func loadTraces(ctx context.Context, appRoot string, q *trace2.Query) ([]*export.Trace, error) {
+	store, appID, closeStore, err := openTraceStore(appRoot)
+	if err != nil {
+		return nil, err
+	}
+	defer closeStore()
+	q.AppID = appID
+
+	var traces []*export.Trace
+	err = store.List(ctx, q, func(s *tracepb2.SpanSummary) bool {
+		traces = append(traces, &export.Trace{Root: s})
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tr := range traces {
+		err := store.Get(ctx, appID, tr.Root.TraceId, func(ev *tracepb2.TraceEvent) bool {
+			tr.Events = append(tr.Events, ev)
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get trace %s: %v", tr.Root.TraceId, err)
+		}
+	}
+	return traces, nil
+}
+
+// openTraceStore opens the trace store of the daemon for reading.
+// It returns the store, the id the traces of the app at appRoot are stored under,
+// and a function to close the store.
+func openTraceStore(appRoot string) (store trace2.Store, appID string, closeStore func(), err error) {
+	appID, err = appfile.Slug(appRoot)
+	if err != nil {
+		return nil, "", nil, err
+	} else if appID == "" {
+		man, err := manifest.ReadOrCreate(appRoot)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		appID = man.LocalID
+	}
+
+	dir, err := conf.Dir()
+	if err != nil {
+		return nil, "", nil, err
+	}
+	dbPath := filepath.Join(dir, "encore.db")
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, "", nil, fmt.Errorf("no traces found; run your app using 'encore run' to capture traces: %v", err)
+	}
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro&_journal=wal", dbPath))
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return sqlite.NewReader(db), appID, func() { _ = db.Close() }, nil
+}
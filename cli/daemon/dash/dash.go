@@ -252,7 +252,9 @@ func (h *handler) Handle(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2
 			return true
 		}
 		err := h.tr.Get(ctx, params.AppID, params.TraceID, iter)
-		if err != nil {
+		if errors.Is(err, trace2.ErrNotFound) {
+			err = nil
+		} else if err != nil {
 			log.Error().Err(err).Msg("dash: could not list trace events")
 		}
 		return reply(ctx, events, err)
@@ -319,7 +321,7 @@ func (h *handler) Handle(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2
 		return reply(ctx, status, nil)
 	case "api-call":
 		telemetry.Send("api.call")
-		var params apiCallParams
+		var params APICallParams
 		if err := unmarshal(&params); err != nil {
 			return reply(ctx, nil, err)
 		}
@@ -570,10 +572,12 @@ func (h *handler) Handle(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2
 	return jsonrpc2.MethodNotFound(ctx, reply, r)
 }
 
-type apiCallParams struct {
-	AppID         string
-	Service       string
-	Endpoint      string
+// APICallParams describes an API call to make to a running app.
+type APICallParams struct {
+	AppID    string
+	Service  string
+	Endpoint string
+	// Path is the request path, with any path parameters filled in.
 	Path          string
 	Method        string
 	Payload       []byte
@@ -582,7 +586,7 @@ type apiCallParams struct {
 	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
-func (h *handler) apiCall(ctx context.Context, reply jsonrpc2.Replier, p *apiCallParams) error {
+func (h *handler) apiCall(ctx context.Context, reply jsonrpc2.Replier, p *APICallParams) error {
 	log := log.With().Str("app_id", p.AppID).Str("path", p.Path).Str("service", p.Service).Str("endpoint", p.Endpoint).Logger()
 	run := h.run.FindRunByAppID(p.AppID)
 	if run == nil {
@@ -596,7 +600,7 @@ func (h *handler) apiCall(ctx context.Context, reply jsonrpc2.Replier, p *apiCal
 	}
 
 	baseURL := "http://" + run.ListenAddr
-	req, err := prepareRequest(ctx, baseURL, proc.Meta, p)
+	req, err := PrepareAPIRequest(ctx, baseURL, proc.Meta, p)
 	if err != nil {
 		log.Error().Err(err).Msg("dash: unable to prepare request")
 		return reply(ctx, nil, err)
@@ -800,8 +804,10 @@ func findRPC(md *meta.Data, service, endpoint string) *meta.RPC {
 	return nil
 }
 
-// prepareRequest prepares a request for sending based on the given apiCallParams.
-func prepareRequest(ctx context.Context, baseURL string, md *meta.Data, p *apiCallParams) (*http.Request, error) {
+// PrepareAPIRequest prepares a request for sending based on the given APICallParams.
+// The payload is encoded into headers, query string and body according to the endpoint's
+// request encoding, like when calling APIs from the dashboard.
+func PrepareAPIRequest(ctx context.Context, baseURL string, md *meta.Data, p *APICallParams) (*http.Request, error) {
 	reqSpec := newHTTPRequestSpec()
 	rpc := findRPC(md, p.Service, p.Endpoint)
 	if rpc == nil {
@@ -868,7 +874,7 @@ func prepareRequest(ctx context.Context, baseURL string, md *meta.Data, p *apiCa
 	return req, nil
 }
 
-func handleResponse(md *meta.Data, p *apiCallParams, headers http.Header, body []byte) []byte {
+func handleResponse(md *meta.Data, p *APICallParams, headers http.Header, body []byte) []byte {
 	rpc := findRPC(md, p.Service, p.Endpoint)
 	if rpc == nil {
 		return body
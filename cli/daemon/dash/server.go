@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httputil"
+	"strings"
 	"sync"
 
 	"github.com/gorilla/websocket"
@@ -15,6 +16,7 @@ import (
 	"encr.dev/cli/daemon/dash/ai"
 	"encr.dev/cli/daemon/dash/apiproxy"
 	"encr.dev/cli/daemon/dash/dashproxy"
+	"encr.dev/cli/daemon/engine/profiles"
 	"encr.dev/cli/daemon/engine/trace2"
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/run"
@@ -28,7 +30,7 @@ var upgrader = websocket.Upgrader{
 }
 
 // NewServer starts a new server and returns it.
-func NewServer(appsMgr *apps.Manager, runMgr *run.Manager, nsMgr *namespace.Manager, tr trace2.Store, dashPort int) *Server {
+func NewServer(appsMgr *apps.Manager, runMgr *run.Manager, nsMgr *namespace.Manager, tr trace2.Store, profs *profiles.Store, dashPort int) *Server {
 	proxy, err := dashproxy.New(conf.DevDashURL)
 	if err != nil {
 		log.Fatal().Err(err).Msg("could not create dash proxy")
@@ -52,6 +54,7 @@ func NewServer(appsMgr *apps.Manager, runMgr *run.Manager, nsMgr *namespace.Mana
 		traceCh:  make(chan trace2.NewSpanEvent, 10),
 		clients:  make(map[chan<- *notification]struct{}),
 		ai:       aiMgr,
+		traceAPI: newTraceAPI(tr, profs),
 	}
 
 	runMgr.AddListener(s)
@@ -71,6 +74,7 @@ type Server struct {
 	dashPort int
 	traceCh  chan trace2.NewSpanEvent
 	ai       *ai.Manager
+	traceAPI http.Handler
 
 	mu      sync.Mutex
 	clients map[chan<- *notification]struct{}
@@ -83,6 +87,10 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	case "/__graphql":
 		s.apiProxy.ServeHTTP(w, req)
 	default:
+		if strings.HasPrefix(req.URL.Path, traceAPIPrefix) {
+			s.traceAPI.ServeHTTP(w, req)
+			return
+		}
 		s.proxy.ServeHTTP(w, req)
 	}
 }
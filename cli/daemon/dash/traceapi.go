@@ -0,0 +1,244 @@
+package dash
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/engine/profiles"
+	"encr.dev/cli/daemon/engine/trace2"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// traceAPIPrefix is the path prefix of the trace query API.
+const traceAPIPrefix = "/__encore/api/"
+
+// newTraceAPI returns a handler serving the trace query API,
+// which exposes the traces in the local trace store as JSON over HTTP,
+// along with the CPU profiles continuously captured by running apps.
+//
+// The API consists of the endpoints:
+//
+//	GET /__encore/api/apps/{app_id}/traces
+//	GET /__encore/api/apps/{app_id}/traces/{trace_id}
+//	GET /__encore/api/apps/{app_id}/traces/{trace_id}/profile
+//	GET /__encore/api/apps/{app_id}/profiles
+//	GET /__encore/api/apps/{app_id}/profiles/{profile_id}
+//
+// See parseTraceQuery for the filters supported when listing traces.
+// The profiles are served in the pprof format, so they can be read using
+// "go tool pprof" directly from the API.
+func newTraceAPI(tr trace2.Store, profs *profiles.Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+traceAPIPrefix+"apps/{app_id}/traces", func(w http.ResponseWriter, req *http.Request) {
+		q, err := parseTraceQuery(req)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		traces := []*tracepb2.SpanSummary{}
+		err = tr.List(req.Context(), q, func(s *tracepb2.SpanSummary) bool {
+			traces = append(traces, s)
+			return true
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("dash: could not list traces")
+			writeAPIError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeAPIResponse(w, struct {
+			Traces []*tracepb2.SpanSummary `json:"traces"`
+		}{traces})
+	})
+
+	mux.HandleFunc("GET "+traceAPIPrefix+"apps/{app_id}/traces/{trace_id}", func(w http.ResponseWriter, req *http.Request) {
+		appID, traceID := req.PathValue("app_id"), req.PathValue("trace_id")
+		events := []*tracepb2.TraceEvent{}
+		err := tr.Get(req.Context(), appID, traceID, func(ev *tracepb2.TraceEvent) bool {
+			events = append(events, ev)
+			return true
+		})
+		if errors.Is(err, trace2.ErrNotFound) {
+			writeAPIError(w, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			log.Error().Err(err).Msg("dash: could not list trace events")
+			writeAPIError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeAPIResponse(w, struct {
+			TraceID string                 `json:"trace_id"`
+			Events  []*tracepb2.TraceEvent `json:"events"`
+		}{traceID, events})
+	})
+
+	// The profile of a trace consists of the samples labeled with the trace
+	// in the stored profiles, optionally narrowed down to a span using the
+	// span_id query string parameter.
+	mux.HandleFunc("GET "+traceAPIPrefix+"apps/{app_id}/traces/{trace_id}/profile", func(w http.ResponseWriter, req *http.Request) {
+		appID, traceID := req.PathValue("app_id"), req.PathValue("trace_id")
+		prof, err := profs.ForTrace(appID, traceID, req.URL.Query().Get("span_id"))
+		if errors.Is(err, profiles.ErrNotFound) {
+			writeAPIError(w, http.StatusNotFound, errors.New("no profile samples found for the trace"))
+			return
+		} else if err != nil {
+			log.Error().Err(err).Msg("dash: could not get trace profile")
+			writeAPIError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeProfile(w, prof, traceID+".pprof")
+	})
+
+	mux.HandleFunc("GET "+traceAPIPrefix+"apps/{app_id}/profiles", func(w http.ResponseWriter, req *http.Request) {
+		type profileInfo struct {
+			ID            int64     `json:"id"`
+			StartTime     time.Time `json:"start_time"`
+			DurationNanos int64     `json:"duration_nanos"`
+			Samples       int       `json:"samples"`
+		}
+		infos := []profileInfo{}
+		for _, p := range profs.List(req.PathValue("app_id")) {
+			infos = append(infos, profileInfo{
+				ID:            p.ID,
+				StartTime:     p.StartTime,
+				DurationNanos: int64(p.Duration),
+				Samples:       p.Samples,
+			})
+		}
+		writeAPIResponse(w, struct {
+			Profiles []profileInfo `json:"profiles"`
+		}{infos})
+	})
+
+	mux.HandleFunc("GET "+traceAPIPrefix+"apps/{app_id}/profiles/{profile_id}", func(w http.ResponseWriter, req *http.Request) {
+		id, err := strconv.ParseInt(req.PathValue("profile_id"), 10, 64)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid profile id %q", req.PathValue("profile_id")))
+			return
+		}
+		p, err := profs.Get(req.PathValue("app_id"), id)
+		if err != nil {
+			writeAPIError(w, http.StatusNotFound, err)
+			return
+		}
+		writeProfile(w, p.Profile(), fmt.Sprintf("profile-%d.pprof", id))
+	})
+
+	mux.HandleFunc(traceAPIPrefix, func(w http.ResponseWriter, req *http.Request) {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("unknown endpoint %s %s", req.Method, req.URL.Path))
+	})
+	return mux
+}
+
+// parseTraceQuery parses the query for listing traces from the request.
+// It supports the query string parameters:
+//
+//   - service, endpoint, topic, subscription, message_id: only include traces
+//     with the given service, endpoint, Pub/Sub topic, subscription or message id
+//   - error: "true" to only include failed traces, "false" for successful ones
+//   - test: "true" to only include traces from tests, "false" to exclude them
+//   - since: only include traces started within the given duration, like "1h"
+//   - start, end: only include traces started within the given time range (RFC 3339)
+//   - min_duration, max_duration: only include traces with a duration within the given range, like "250ms"
+//   - limit: the maximum number of traces to return (defaults to 100)
+func parseTraceQuery(req *http.Request) (*trace2.Query, error) {
+	params := req.URL.Query()
+	q := &trace2.Query{
+		AppID:        req.PathValue("app_id"),
+		Service:      params.Get("service"),
+		Endpoint:     params.Get("endpoint"),
+		Topic:        params.Get("topic"),
+		Subscription: params.Get("subscription"),
+		MessageID:    params.Get("message_id"),
+	}
+
+	var err error
+	parseBool := func(name string) *bool {
+		if v := params.Get(name); v != "" && err == nil {
+			b, e := strconv.ParseBool(v)
+			if e != nil {
+				err = fmt.Errorf("invalid %s parameter %q: must be true or false", name, v)
+			}
+			return &b
+		}
+		return nil
+	}
+	parseDur := func(name string) time.Duration {
+		if v := params.Get(name); v != "" && err == nil {
+			d, e := time.ParseDuration(v)
+			if e != nil || d < 0 {
+				err = fmt.Errorf("invalid %s parameter %q: must be a duration like \"1h\" or \"250ms\"", name, v)
+			}
+			return d
+		}
+		return 0
+	}
+	parseTime := func(name string) time.Time {
+		if v := params.Get(name); v != "" && err == nil {
+			t, e := time.Parse(time.RFC3339Nano, v)
+			if e != nil {
+				err = fmt.Errorf("invalid %s parameter %q: must be a RFC 3339 timestamp", name, v)
+			}
+			return t
+		}
+		return time.Time{}
+	}
+
+	q.IsError = parseBool("error")
+	q.TestFilter = parseBool("test")
+	q.StartTime = parseTime("start")
+	q.EndTime = parseTime("end")
+	if since := parseDur("since"); since > 0 {
+		q.StartTime = time.Now().Add(-since)
+	}
+	q.MinDurNanos = uint64(parseDur("min_duration"))
+	q.MaxDurNanos = uint64(parseDur("max_duration"))
+	if v := params.Get("limit"); v != "" && err == nil {
+		q.Limit, err = strconv.Atoi(v)
+		if err != nil || q.Limit <= 0 {
+			err = fmt.Errorf("invalid limit parameter %q: must be a positive integer", v)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// writeAPIResponse writes resp as JSON, encoding any protobuf messages using protojson.
+func writeAPIResponse(w http.ResponseWriter, resp any) {
+	data, err := protoEncoder.Marshal(resp)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// writeProfile writes the profile in the pprof format as an attachment with the given filename.
+func writeProfile(w http.ResponseWriter, p *profile.Profile, filename string) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if err := p.Write(w); err != nil {
+		log.Error().Err(err).Msg("dash: could not write profile")
+	}
+}
+
+// writeAPIError writes the error as a JSON response with the given status code.
+func writeAPIError(w http.ResponseWriter, code int, err error) {
+	data, _ := protoEncoder.Marshal(struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}{strings.ToLower(strings.ReplaceAll(http.StatusText(code), " ", "_")), err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,218 @@
+package dash
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/pprof/profile"
+
+	"encr.dev/cli/daemon/engine/profiles"
+	"encr.dev/cli/daemon/engine/trace2"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+type fakeTraceStore struct {
+	trace2.Store
+	query  *trace2.Query
+	traces map[string][]*tracepb2.TraceEvent
+}
+
+func (s *fakeTraceStore) List(ctx context.Context, q *trace2.Query, iter trace2.ListEntryIterator) error {
+	s.query = q
+	for id := range s.traces {
+		if !iter(&tracepb2.SpanSummary{TraceId: id, ServiceName: "svc"}) {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *fakeTraceStore) Get(ctx context.Context, appID, traceID string, iter trace2.EventIterator) error {
+	events, ok := s.traces[traceID]
+	if !ok || appID != "app" {
+		return trace2.ErrNotFound
+	}
+	for _, ev := range events {
+		if !iter(ev) {
+			break
+		}
+	}
+	return nil
+}
+
+func TestTraceAPI(t *testing.T) {
+	store := &fakeTraceStore{traces: map[string][]*tracepb2.TraceEvent{
+		"trace1": {
+			{TraceId: &tracepb2.TraceID{Low: 1}, SpanId: 2, EventId: 1},
+			{TraceId: &tracepb2.TraceID{Low: 1}, SpanId: 2, EventId: 2},
+		},
+	}}
+	api := newTraceAPI(store, profiles.NewStore())
+
+	get := func(path string) (int, map[string]any) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		api.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		var body map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("GET %s: invalid json response %q: %v", path, w.Body.String(), err)
+		}
+		return w.Code, body
+	}
+
+	t.Run("list", func(t *testing.T) {
+		code, body := get("/__encore/api/apps/app/traces?service=svc&endpoint=Foo&error=true&min_duration=250ms&since=1h&limit=10")
+		if code != http.StatusOK {
+			t.Fatalf("got status %d, want 200: %v", code, body)
+		}
+		traces := body["traces"].([]any)
+		if len(traces) != 1 || traces[0].(map[string]any)["trace_id"] != "trace1" {
+			t.Errorf("got traces %v, want trace1", traces)
+		}
+
+		q := store.query
+		if q.AppID != "app" || q.Service != "svc" || q.Endpoint != "Foo" || q.Limit != 10 {
+			t.Errorf("got query %+v", q)
+		}
+		if q.IsError == nil || !*q.IsError || q.TestFilter != nil {
+			t.Errorf("got error filter %v and test filter %v, want true and nil", q.IsError, q.TestFilter)
+		}
+		if q.MinDurNanos != uint64(250*time.Millisecond) || q.MaxDurNanos != 0 {
+			t.Errorf("got duration range [%d, %d]", q.MinDurNanos, q.MaxDurNanos)
+		}
+		if d := time.Since(q.StartTime); d < time.Hour || d > time.Hour+time.Minute {
+			t.Errorf("got start time %v, want 1h ago", q.StartTime)
+		}
+	})
+
+	t.Run("list_invalid", func(t *testing.T) {
+		for _, params := range []string{"error=maybe", "since=yesterday", "limit=-1", "start=today"} {
+			code, body := get("/__encore/api/apps/app/traces?" + params)
+			if code != http.StatusBadRequest || body["code"] != "bad_request" {
+				t.Errorf("%s: got status %d and body %v, want 400", params, code, body)
+			}
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		code, body := get("/__encore/api/apps/app/traces/trace1")
+		if code != http.StatusOK {
+			t.Fatalf("got status %d, want 200: %v", code, body)
+		}
+		events := body["events"].([]any)
+		if body["trace_id"] != "trace1" || len(events) != 2 {
+			t.Fatalf("got %v, want the two events of trace1", body)
+		}
+		// Events are encoded using protojson, which encodes 64-bit integers as strings.
+		if id := events[1].(map[string]any)["event_id"]; id != "2" {
+			t.Errorf("got event_id %v, want \"2\"", id)
+		}
+	})
+
+	t.Run("get_not_found", func(t *testing.T) {
+		code, body := get("/__encore/api/apps/app/traces/unknown")
+		if code != http.StatusNotFound || body["code"] != "not_found" {
+			t.Errorf("got status %d and body %v, want 404", code, body)
+		}
+	})
+
+	t.Run("unknown_endpoint", func(t *testing.T) {
+		code, _ := get("/__encore/api/apps/app/foo")
+		if code != http.StatusNotFound {
+			t.Errorf("got status %d, want 404", code)
+		}
+	})
+}
+
+func TestTraceAPI_Profiles(t *testing.T) {
+	fn := &profile.Function{ID: 1, Name: "svc.Slow"}
+	loc := &profile.Location{ID: 1, Line: []profile.Line{{Function: fn}}}
+	var buf bytes.Buffer
+	err := (&profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "cpu", Unit: "nanoseconds"}},
+		Sample: []*profile.Sample{
+			{Location: []*profile.Location{loc}, Value: []int64{10}, Label: map[string][]string{"trace_id": {"trace1"}, "span_id": {"span1"}}},
+			{Location: []*profile.Location{loc}, Value: []int64{20}, Label: map[string][]string{"trace_id": {"trace2"}, "span_id": {"span2"}}},
+		},
+		Location: []*profile.Location{loc},
+		Function: []*profile.Function{fn},
+	}).Write(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profs := profiles.NewStore()
+	stored, err := profs.Add("app", buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := newTraceAPI(&fakeTraceStore{}, profs)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		api.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	t.Run("list", func(t *testing.T) {
+		w := get("/__encore/api/apps/app/profiles")
+		var body struct {
+			Profiles []struct {
+				ID      int64 `json:"id"`
+				Samples int   `json:"samples"`
+			} `json:"profiles"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("invalid json response %q: %v", w.Body.String(), err)
+		}
+		if len(body.Profiles) != 1 || body.Profiles[0].ID != stored.ID || body.Profiles[0].Samples != 2 {
+			t.Errorf("got profiles %+v, want the stored profile", body.Profiles)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		w := get(fmt.Sprintf("/__encore/api/apps/app/profiles/%d", stored.ID))
+		if w.Code != http.StatusOK {
+			t.Fatalf("got status %d, want 200: %s", w.Code, w.Body.String())
+		}
+		p, err := profile.Parse(w.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(p.Sample) != 2 {
+			t.Errorf("got %d samples, want 2", len(p.Sample))
+		}
+
+		if w := get("/__encore/api/apps/app/profiles/123"); w.Code != http.StatusNotFound {
+			t.Errorf("got status %d for unknown profile, want 404", w.Code)
+		}
+	})
+
+	t.Run("trace", func(t *testing.T) {
+		for _, path := range []string{"/__encore/api/apps/app/traces/trace2/profile", "/__encore/api/apps/app/traces/trace2/profile?span_id=span2"} {
+			w := get(path)
+			if w.Code != http.StatusOK {
+				t.Fatalf("%s: got status %d, want 200: %s", path, w.Code, w.Body.String())
+			}
+			p, err := profile.Parse(w.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(p.Sample) != 1 || p.Sample[0].Value[0] != 20 {
+				t.Errorf("%s: got samples %v, want the sample of trace2", path, p.Sample)
+			}
+		}
+
+		for _, path := range []string{"/__encore/api/apps/app/traces/trace3/profile", "/__encore/api/apps/app/traces/trace2/profile?span_id=span1"} {
+			if w := get(path); w.Code != http.StatusNotFound {
+				t.Errorf("%s: got status %d, want 404", path, w.Code)
+			}
+		}
+	})
+}
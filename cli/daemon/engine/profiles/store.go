@@ -0,0 +1,119 @@
+// Package profiles stores the CPU profiles continuously captured by running apps.
+package profiles
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/pprof/profile"
+)
+
+// maxPerApp is the number of profiles kept for each app.
+// With the default profiling interval it's the last hour of profiles.
+const maxPerApp = 60
+
+// ErrNotFound is reported when a profile, or the samples of a trace, can't be found.
+var ErrNotFound = errors.New("profile not found")
+
+// Profile is a CPU profile captured by an app.
+type Profile struct {
+	ID        int64
+	AppID     string
+	StartTime time.Time
+	Duration  time.Duration
+	Samples   int
+
+	prof *profile.Profile
+}
+
+// Profile returns the parsed profile.
+// It must not be modified.
+func (p *Profile) Profile() *profile.Profile {
+	return p.prof
+}
+
+// Store keeps the most recent profiles of each app in memory.
+type Store struct {
+	mu     sync.Mutex
+	lastID int64
+	byApp  map[string][]*Profile // oldest first
+}
+
+func NewStore() *Store {
+	return &Store{byApp: make(map[string][]*Profile)}
+}
+
+// Add parses the pprof-encoded profile data and adds it to the profiles of appID,
+// dropping the oldest profile if there are too many.
+func (s *Store) Add(appID string, data []byte) (*Profile, error) {
+	prof, err := profile.ParseData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastID++
+	p := &Profile{
+		ID:        s.lastID,
+		AppID:     appID,
+		StartTime: time.Unix(0, prof.TimeNanos),
+		Duration:  time.Duration(prof.DurationNanos),
+		Samples:   len(prof.Sample),
+		prof:      prof,
+	}
+
+	profs := append(s.byApp[appID], p)
+	if len(profs) > maxPerApp {
+		profs = slices.Delete(profs, 0, len(profs)-maxPerApp)
+	}
+	s.byApp[appID] = profs
+	return p, nil
+}
+
+// List returns the profiles of appID, newest first.
+func (s *Store) List(appID string) []*Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	profs := slices.Clone(s.byApp[appID])
+	slices.Reverse(profs)
+	return profs
+}
+
+// Get returns the profile of appID with the given id.
+func (s *Store) Get(appID string, id int64) (*Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range s.byApp[appID] {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// ForTrace returns a profile of the samples of appID's profiles that were
+// taken while running the given trace, or just the given span if spanID is set.
+// The samples are labeled with the trace and span ids by the Encore runtime.
+func (s *Store) ForTrace(appID, traceID, spanID string) (*profile.Profile, error) {
+	var matched []*profile.Profile
+	for _, p := range s.List(appID) {
+		prof := p.prof.Copy()
+		prof.Sample = slices.DeleteFunc(prof.Sample, func(sample *profile.Sample) bool {
+			return !hasLabel(sample, "trace_id", traceID) || (spanID != "" && !hasLabel(sample, "span_id", spanID))
+		})
+		if len(prof.Sample) > 0 {
+			matched = append(matched, prof)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, ErrNotFound
+	}
+	return profile.Merge(matched)
+}
+
+func hasLabel(s *profile.Sample, key, value string) bool {
+	return slices.Contains(s.Label[key], value)
+}
@@ -0,0 +1,112 @@
+package profiles
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/pprof/profile"
+)
+
+// testProfile returns a pprof-encoded CPU profile with a sample
+// in a function of each of the given names, labeled with the given span.
+func testProfile(t *testing.T, start time.Time, spans map[string][2]string) []byte {
+	t.Helper()
+	p := &profile.Profile{
+		SampleType:    []*profile.ValueType{{Type: "samples", Unit: "count"}, {Type: "cpu", Unit: "nanoseconds"}},
+		PeriodType:    &profile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+		Period:        10000000,
+		TimeNanos:     start.UnixNano(),
+		DurationNanos: int64(10 * time.Second),
+	}
+	for fn, span := range spans {
+		f := &profile.Function{ID: uint64(len(p.Function) + 1), Name: fn}
+		loc := &profile.Location{ID: uint64(len(p.Location) + 1), Line: []profile.Line{{Function: f}}}
+		p.Function = append(p.Function, f)
+		p.Location = append(p.Location, loc)
+		p.Sample = append(p.Sample, &profile.Sample{
+			Location: []*profile.Location{loc},
+			Value:    []int64{1, 10000000},
+			Label:    map[string][]string{"trace_id": {span[0]}, "span_id": {span[1]}},
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := p.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func funcNames(p *profile.Profile) []string {
+	var names []string
+	for _, s := range p.Sample {
+		names = append(names, s.Location[0].Line[0].Function.Name)
+	}
+	return names
+}
+
+func TestStore(t *testing.T) {
+	c := qt.New(t)
+	s := NewStore()
+	start := time.Unix(1700000000, 0)
+
+	_, err := s.Add("app", []byte("not a profile"))
+	c.Assert(err, qt.IsNotNil)
+
+	first, err := s.Add("app", testProfile(t, start, map[string][2]string{
+		"svc.A": {"trace1", "span1"},
+		"svc.B": {"trace2", "span2"},
+	}))
+	c.Assert(err, qt.IsNil)
+	c.Assert(first.StartTime.Equal(start), qt.IsTrue)
+	c.Assert(first.Duration, qt.Equals, 10*time.Second)
+	c.Assert(first.Samples, qt.Equals, 2)
+
+	second, err := s.Add("app", testProfile(t, start.Add(time.Minute), map[string][2]string{
+		"svc.C": {"trace1", "span3"},
+	}))
+	c.Assert(err, qt.IsNil)
+
+	list := s.List("app")
+	c.Assert(list, qt.HasLen, 2)
+	c.Assert(list[0].ID, qt.Equals, second.ID)
+	c.Assert(s.List("other"), qt.HasLen, 0)
+
+	got, err := s.Get("app", first.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.Equals, first)
+	_, err = s.Get("other", first.ID)
+	c.Assert(err, qt.Equals, ErrNotFound)
+
+	// The samples of a trace are merged across profiles.
+	prof, err := s.ForTrace("app", "trace1", "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(funcNames(prof), qt.ContentEquals, []string{"svc.A", "svc.C"})
+
+	prof, err = s.ForTrace("app", "trace1", "span3")
+	c.Assert(err, qt.IsNil)
+	c.Assert(funcNames(prof), qt.DeepEquals, []string{"svc.C"})
+
+	_, err = s.ForTrace("app", "trace3", "")
+	c.Assert(err, qt.Equals, ErrNotFound)
+
+	// The stored profiles are not modified.
+	c.Assert(first.Profile().Sample, qt.HasLen, 2)
+}
+
+func TestStore_MaxPerApp(t *testing.T) {
+	c := qt.New(t)
+	s := NewStore()
+	data := testProfile(t, time.Now(), nil)
+	for i := 0; i < maxPerApp+5; i++ {
+		_, err := s.Add("app", data)
+		c.Assert(err, qt.IsNil)
+	}
+
+	list := s.List("app")
+	c.Assert(list, qt.HasLen, maxPerApp)
+	c.Assert(list[0].ID, qt.Equals, int64(maxPerApp+5))
+	c.Assert(list[maxPerApp-1].ID, qt.Equals, int64(6))
+}
@@ -3,23 +3,29 @@ package engine
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/cockroachdb/errors"
 
 	tracemodel "encore.dev/appruntime/exported/trace2"
+	"encr.dev/cli/daemon/engine/profiles"
 	"encr.dev/cli/daemon/engine/trace2"
 	"encr.dev/cli/daemon/run"
 )
 
+// maxProfileSize is the maximum size of a profile sent by an app.
+const maxProfileSize = 32 << 20
+
 type server struct {
-	runMgr *run.Manager
-	rec    *trace2.Recorder
+	runMgr   *run.Manager
+	rec      *trace2.Recorder
+	profiles *profiles.Store
 }
 
-func NewServer(runMgr *run.Manager, rec *trace2.Recorder) http.Handler {
-	s := &server{runMgr: runMgr, rec: rec}
+func NewServer(runMgr *run.Manager, rec *trace2.Recorder, profiles *profiles.Store) http.Handler {
+	s := &server{runMgr: runMgr, rec: rec, profiles: profiles}
 	return s
 }
 
@@ -27,18 +33,28 @@ func NewServer(runMgr *run.Manager, rec *trace2.Recorder) http.Handler {
 func (s *server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	switch req.URL.Path {
 	case "/trace":
+		// Report the newest trace protocol version we can parse,
+		// so newer runtimes can downgrade the traces they send us.
+		w.Header().Set("X-Encore-Trace-Max-Version", strconv.Itoa(int(tracemodel.CurrentVersion)))
+		if req.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
 		s.RecordTrace(w, req)
+	case "/profile":
+		s.RecordProfile(w, req)
 	default:
 		http.Error(w, "Not Found", http.StatusNotFound)
 	}
 }
 
 func (s *server) RecordTrace(w http.ResponseWriter, req *http.Request) {
-	data, err := s.parseTraceData(req)
+	data, body, err := s.parseTraceData(req)
 	if err != nil {
 		http.Error(w, "unable to parse trace header: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	defer func() { _ = body.Close() }()
 
 	err = s.rec.RecordTrace(data)
 	if err != nil {
@@ -47,12 +63,41 @@ func (s *server) RecordTrace(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
-func (s *server) parseTraceData(req *http.Request) (d trace2.RecordData, err error) {
+// RecordProfile records a CPU profile continuously captured by a running app.
+func (s *server) RecordProfile(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pid := req.Header.Get("X-Encore-Env-ID")
+	proc := s.runMgr.FindProc(pid)
+	if proc == nil {
+		http.Error(w, fmt.Sprintf("process %q is not running", pid), http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxProfileSize))
+	if err != nil {
+		http.Error(w, "unable to read profile: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := s.profiles.Add(proc.Run.App.PlatformOrLocalID(), data); err != nil {
+		http.Error(w, "unable to parse profile: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+}
+
+// parseTraceData parses the trace headers of req. The returned body
+// decodes the trace data and must be closed once it's been recorded.
+func (s *server) parseTraceData(req *http.Request) (d trace2.RecordData, body io.ReadCloser, err error) {
 	// Parse trace version
 	traceVersion := req.Header.Get("X-Encore-Trace-Version")
 	version, err := strconv.Atoi(traceVersion)
 	if err != nil || version <= 0 {
-		return d, fmt.Errorf("bad trace protocol version %q", traceVersion)
+		return d, nil, fmt.Errorf("bad trace protocol version %q", traceVersion)
+	} else if version > int(tracemodel.CurrentVersion) {
+		return d, nil, fmt.Errorf("unsupported trace protocol version %d (newest supported is %d)", version, tracemodel.CurrentVersion)
 	}
 	d.TraceVersion = tracemodel.Version(version)
 
@@ -60,30 +105,43 @@ func (s *server) parseTraceData(req *http.Request) (d trace2.RecordData, err err
 	if pid == "test" {
 		appID := req.Header.Get("X-Encore-App-ID")
 		if appID == "" {
-			return d, errors.New("missing X-Encore-App-ID header")
+			return d, nil, errors.New("missing X-Encore-App-ID header")
 		}
 		d.Meta = &trace2.Meta{AppID: appID}
 	} else {
 		if pid == "" {
-			return d, errors.New("missing X-Encore-Env-ID header")
+			return d, nil, errors.New("missing X-Encore-Env-ID header")
 		}
 		proc := s.runMgr.FindProc(pid)
 		if proc == nil {
-			return d, errors.Newf("process %q is not running", pid)
+			return d, nil, errors.Newf("process %q is not running", pid)
 		}
 		d.Meta = &trace2.Meta{AppID: proc.Run.App.PlatformOrLocalID()}
+		if fwd := proc.Run.TraceForwarder; fwd != nil {
+			d.Forwarder = fwd
+		}
 	}
 
 	// Parse time anchor
 	timeAnchor := req.Header.Get("X-Encore-Trace-TimeAnchor")
 	if timeAnchor == "" {
-		return d, errors.New("missing X-Encore-Trace-TimeAnchor header")
+		return d, nil, errors.New("missing X-Encore-Trace-TimeAnchor header")
 	}
 
 	if err := d.Anchor.UnmarshalText([]byte(timeAnchor)); err != nil {
-		return d, errors.Wrap(err, "unable to parse X-Encore-Trace-TimeAnchor header")
+		return d, nil, errors.Wrap(err, "unable to parse X-Encore-Trace-TimeAnchor header")
+	}
+
+	// Parse the encoding, if the trace data is compressed.
+	enc, err := tracemodel.ParseEncoding(req.Header.Get("X-Encore-Trace-Encoding"))
+	if err != nil {
+		return d, nil, err
+	}
+	body, err = tracemodel.NewDecoder(enc, req.Body)
+	if err != nil {
+		return d, nil, errors.Wrap(err, "unable to decode trace data")
 	}
 
-	d.Buf = bufio.NewReader(req.Body)
-	return d, nil
+	d.Buf = bufio.NewReader(body)
+	return d, body, nil
 }
@@ -0,0 +1,102 @@
+// Package export writes locally captured traces in formats suitable
+// for archiving and offline analysis.
+package export
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// Trace is a captured trace.
+type Trace struct {
+	// Root summarizes the root span of the trace.
+	Root *tracepb2.SpanSummary
+
+	// Events are the events of the trace, in no particular order.
+	Events []*tracepb2.TraceEvent
+}
+
+// WriteJSON writes the traces as a single JSON object of the form:
+//
+//	{"traces": [{"root": <SpanSummary>, "events": [<TraceEvent>, ...]}, ...]}
+//
+// The root spans and events are encoded using the protobuf JSON mapping of the
+// encore.engine.trace2 SpanSummary and TraceEvent messages, with field names in
+// snake_case. The events of each trace are sorted by event time.
+func WriteJSON(w io.Writer, traces []*Trace) error {
+	marshal := protojson.MarshalOptions{UseProtoNames: true}
+
+	type jsonTrace struct {
+		Root   json.RawMessage   `json:"root"`
+		Events []json.RawMessage `json:"events"`
+	}
+	out := struct {
+		Traces []jsonTrace `json:"traces"`
+	}{Traces: make([]jsonTrace, 0, len(traces))}
+
+	for _, tr := range traces {
+		root, err := marshal.Marshal(tr.Root)
+		if err != nil {
+			return err
+		}
+		jt := jsonTrace{Root: root, Events: make([]json.RawMessage, 0, len(tr.Events))}
+		for _, ev := range sortedEvents(tr.Events) {
+			data, err := marshal.Marshal(ev)
+			if err != nil {
+				return err
+			}
+			jt.Events = append(jt.Events, data)
+		}
+		out.Traces = append(out.Traces, jt)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// sortedEvents returns the events sorted by event time, then event id.
+func sortedEvents(events []*tracepb2.TraceEvent) []*tracepb2.TraceEvent {
+	sorted := slices.Clone(events)
+	slices.SortStableFunc(sorted, func(a, b *tracepb2.TraceEvent) int {
+		if c := a.EventTime.AsTime().Compare(b.EventTime.AsTime()); c != 0 {
+			return c
+		}
+		switch {
+		case a.EventId < b.EventId:
+			return -1
+		case a.EventId > b.EventId:
+			return 1
+		}
+		return 0
+	})
+	return sorted
+}
+
+// traceIDHex returns the trace id as the hex encoding of the original 16 bytes.
+func traceIDHex(id *tracepb2.TraceID) string {
+	var b [16]byte
+	binary.LittleEndian.PutUint64(b[0:8], id.GetLow())
+	binary.LittleEndian.PutUint64(b[8:16], id.GetHigh())
+	return hex.EncodeToString(b[:])
+}
+
+// spanIDHex returns the span id as the hex encoding of the original 8 bytes.
+func spanIDHex(id uint64) string {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], id)
+	return hex.EncodeToString(b[:])
+}
+
+// nanos formats t as a decimal string, as OTLP JSON encodes 64-bit integers as strings.
+func nanos(t int64) string {
+	return strconv.FormatInt(t, 10)
+}
@@ -0,0 +1,339 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+func testTrace() *Trace {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	traceID := &tracepb2.TraceID{Low: 1, High: 2}
+	parent := uint64(10)
+	ev := func(spanID, eventID uint64, d time.Duration) *tracepb2.TraceEvent {
+		return &tracepb2.TraceEvent{TraceId: traceID, SpanId: spanID, EventId: eventID, EventTime: timestamppb.New(t0.Add(d))}
+	}
+
+	reqStart := ev(10, 1, 0)
+	reqStart.Event = &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+		Data: &tracepb2.SpanStart_Request{Request: &tracepb2.RequestSpanStart{
+			ServiceName: "svc", EndpointName: "Hello", HttpMethod: "GET", Path: "/hello",
+		}},
+	}}
+	log := ev(10, 2, time.Millisecond)
+	log.Event = &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+		Data: &tracepb2.SpanEvent_LogMessage{LogMessage: &tracepb2.LogMessage{
+			Level: tracepb2.LogMessage_INFO,
+			Msg:   "hello",
+			Fields: []*tracepb2.LogField{
+				{Key: "n", Value: &tracepb2.LogField_Int{Int: 3}},
+			},
+		}},
+	}}
+	msgStart := ev(20, 3, 2*time.Millisecond)
+	msgStart.Event = &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+		ParentSpanId: &parent,
+		Data: &tracepb2.SpanStart_PubsubMessage{PubsubMessage: &tracepb2.PubsubMessageSpanStart{
+			ServiceName: "other", TopicName: "events", SubscriptionName: "sub", MessageId: "1", Attempt: 1,
+		}},
+	}}
+	msgEnd := ev(20, 4, 3*time.Millisecond)
+	msgEnd.Event = &tracepb2.TraceEvent_SpanEnd{SpanEnd: &tracepb2.SpanEnd{
+		Error:   &tracepb2.Error{Msg: "boom"},
+		Baggage: map[string]string{"tenant": "acme"},
+	}}
+	reqEnd := ev(10, 5, 4*time.Millisecond)
+	reqEnd.Event = &tracepb2.TraceEvent_SpanEnd{SpanEnd: &tracepb2.SpanEnd{
+		Data: &tracepb2.SpanEnd_Request{Request: &tracepb2.RequestSpanEnd{HttpStatusCode: 200}},
+	}}
+
+	return &Trace{
+		Root: &tracepb2.SpanSummary{TraceId: "trace", SpanId: "span", ServiceName: "svc"},
+		// Events are stored in no particular order.
+		Events: []*tracepb2.TraceEvent{reqEnd, msgEnd, log, reqStart, msgStart},
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	c := qt.New(t)
+	var buf bytes.Buffer
+	c.Assert(WriteJSON(&buf, []*Trace{testTrace()}), qt.IsNil)
+
+	var out struct {
+		Traces []struct {
+			Root   map[string]any   `json:"root"`
+			Events []map[string]any `json:"events"`
+		} `json:"traces"`
+	}
+	c.Assert(json.Unmarshal(buf.Bytes(), &out), qt.IsNil)
+	c.Assert(out.Traces, qt.HasLen, 1)
+	c.Assert(out.Traces[0].Root["service_name"], qt.Equals, "svc")
+
+	var ids []any
+	for _, ev := range out.Traces[0].Events {
+		ids = append(ids, ev["event_id"])
+	}
+	c.Assert(ids, qt.DeepEquals, []any{"1", "2", "3", "4", "5"})
+}
+
+func TestWriteOTLP(t *testing.T) {
+	c := qt.New(t)
+	var buf bytes.Buffer
+	c.Assert(WriteOTLP(&buf, []*Trace{testTrace()}), qt.IsNil)
+
+	var out otlpTraces
+	c.Assert(json.Unmarshal(buf.Bytes(), &out), qt.IsNil)
+	c.Assert(out.ResourceSpans, qt.HasLen, 2)
+
+	req := out.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	c.Assert(*out.ResourceSpans[0].Resource.Attributes[0].Value.StringValue, qt.Equals, "svc")
+	c.Assert(req.Name, qt.Equals, "svc.Hello")
+	c.Assert(req.Kind, qt.Equals, spanKindServer)
+	c.Assert(req.TraceID, qt.Equals, "01000000000000000200000000000000")
+	c.Assert(req.SpanID, qt.Equals, "0a00000000000000")
+	c.Assert(req.StartTimeUnixNano, qt.Equals, "1704067200000000000")
+	c.Assert(req.EndTimeUnixNano, qt.Equals, "1704067200004000000")
+	c.Assert(req.Status.Code, qt.Equals, statusCodeOk)
+	c.Assert(req.Events, qt.HasLen, 1)
+	c.Assert(req.Events[0].Name, qt.Equals, "hello")
+
+	msg := out.ResourceSpans[1].ScopeSpans[0].Spans[0]
+	c.Assert(msg.Name, qt.Equals, "events process")
+	c.Assert(msg.ParentSpanID, qt.Equals, req.SpanID)
+	c.Assert(msg.Status, qt.Equals, otlpStatus{Code: statusCodeError, Message: "boom"})
+	c.Assert(msg.Attributes[len(msg.Attributes)-1].Key, qt.Equals, "baggage.tenant")
+	c.Assert(*msg.Attributes[len(msg.Attributes)-1].Value.StringValue, qt.Equals, "acme")
+}
+
+func TestWriteOTLP_CustomSpans(t *testing.T) {
+	c := qt.New(t)
+	tr := testTrace()
+	spanEvent := func(eventID uint64, d time.Duration, correlationID *uint64, se *tracepb2.SpanEvent) *tracepb2.TraceEvent {
+		se.CorrelationEventId = correlationID
+		return &tracepb2.TraceEvent{
+			TraceId:   tr.Events[0].TraceId,
+			SpanId:    10,
+			EventId:   eventID,
+			EventTime: timestamppb.New(tr.Events[0].EventTime.AsTime().Add(d)),
+			Event:     &tracepb2.TraceEvent_SpanEvent{SpanEvent: se},
+		}
+	}
+	startID := uint64(6)
+	tr.Events = append(tr.Events,
+		spanEvent(startID, 0, nil, &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_CustomSpanStart{
+			CustomSpanStart: &tracepb2.CustomSpanStart{Name: "charge card"},
+		}}),
+		spanEvent(7, 0, &startID, &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_CustomEvent{
+			CustomEvent: &tracepb2.CustomEvent{Name: "card declined"},
+		}}),
+		spanEvent(8, time.Millisecond, &startID, &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_CustomSpanEnd{
+			CustomSpanEnd: &tracepb2.CustomSpanEnd{Err: &tracepb2.Error{Msg: "declined"}},
+		}}),
+	)
+
+	var buf bytes.Buffer
+	c.Assert(WriteOTLP(&buf, []*Trace{tr}), qt.IsNil)
+	var out otlpTraces
+	c.Assert(json.Unmarshal(buf.Bytes(), &out), qt.IsNil)
+
+	spans := out.ResourceSpans[0].ScopeSpans[0].Spans
+	c.Assert(spans, qt.HasLen, 2)
+	req, custom := spans[0], spans[1]
+	c.Assert(custom.Name, qt.Equals, "charge card")
+	c.Assert(custom.ParentSpanID, qt.Equals, req.SpanID)
+	c.Assert(custom.Status, qt.Equals, otlpStatus{Code: statusCodeError, Message: "declined"})
+	c.Assert(custom.Events, qt.HasLen, 1)
+	c.Assert(custom.Events[0].Name, qt.Equals, "card declined")
+	c.Assert(req.Events, qt.HasLen, 1) // the log message
+}
+
+func TestWriteOTLP_SpanLink(t *testing.T) {
+	c := qt.New(t)
+	tr := testTrace()
+	link := &tracepb2.TraceEvent{
+		TraceId:   tr.Events[0].TraceId,
+		SpanId:    20,
+		EventId:   6,
+		EventTime: timestamppb.New(tr.Events[0].EventTime.AsTime()),
+		Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+			Data: &tracepb2.SpanEvent_SpanLink{SpanLink: &tracepb2.SpanLink{
+				LinkedTraceId: &tracepb2.TraceID{Low: 3, High: 4},
+				LinkedSpanId:  30,
+			}},
+		}},
+	}
+	tr.Events = append(tr.Events, link)
+
+	var buf bytes.Buffer
+	c.Assert(WriteOTLP(&buf, []*Trace{tr}), qt.IsNil)
+	var out otlpTraces
+	c.Assert(json.Unmarshal(buf.Bytes(), &out), qt.IsNil)
+
+	msg := out.ResourceSpans[1].ScopeSpans[0].Spans[0]
+	c.Assert(msg.Links, qt.DeepEquals, []otlpLink{{TraceID: "03000000000000000400000000000000", SpanID: "1e00000000000000"}})
+	c.Assert(msg.Events, qt.HasLen, 0)
+}
+
+func TestWriteOTLP_GoroutineSnapshot(t *testing.T) {
+	c := qt.New(t)
+	tr := testTrace()
+	tr.Events = append(tr.Events, &tracepb2.TraceEvent{
+		TraceId:   tr.Events[0].TraceId,
+		SpanId:    10,
+		EventId:   6,
+		EventTime: timestamppb.New(tr.Events[0].EventTime.AsTime()),
+		Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+			Data: &tracepb2.SpanEvent_GoroutineSnapshot{GoroutineSnapshot: &tracepb2.GoroutineSnapshot{
+				TotalGoroutines: 3,
+				Stacks: []*tracepb2.GoroutineStack{{
+					Count: 2,
+					Stack: &tracepb2.StackTrace{Frames: []*tracepb2.StackFrame{{Filename: "/app/svc.go", Func: "svc.Slow", Line: 12}}},
+				}},
+			}},
+		}},
+	})
+
+	var buf bytes.Buffer
+	c.Assert(WriteOTLP(&buf, []*Trace{tr}), qt.IsNil)
+	var out otlpTraces
+	c.Assert(json.Unmarshal(buf.Bytes(), &out), qt.IsNil)
+
+	req := out.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	c.Assert(req.Events, qt.HasLen, 2)
+	snap := req.Events[1]
+	c.Assert(snap.Name, qt.Equals, "goroutine snapshot")
+	c.Assert(*snap.Attributes[1].Value.StringValue, qt.Equals, "2 @\n#\tsvc.Slow\n#\t\t/app/svc.go:12\n\n")
+}
+
+func TestWriteOTLP_DBQueryPlan(t *testing.T) {
+	c := qt.New(t)
+	tr := testTrace()
+	tr.Events = append(tr.Events, &tracepb2.TraceEvent{
+		TraceId:   tr.Events[0].TraceId,
+		SpanId:    10,
+		EventId:   6,
+		EventTime: timestamppb.New(tr.Events[0].EventTime.AsTime()),
+		Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+			Data: &tracepb2.SpanEvent_DbQueryPlan{DbQueryPlan: &tracepb2.DBQueryPlan{
+				Plan: `[{"Plan": {"Node Type": "Seq Scan"}}]`,
+			}},
+		}},
+	})
+
+	var buf bytes.Buffer
+	c.Assert(WriteOTLP(&buf, []*Trace{tr}), qt.IsNil)
+	var out otlpTraces
+	c.Assert(json.Unmarshal(buf.Bytes(), &out), qt.IsNil)
+
+	req := out.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	c.Assert(req.Events, qt.HasLen, 2)
+	plan := req.Events[1]
+	c.Assert(plan.Name, qt.Equals, "query plan")
+	c.Assert(*plan.Attributes[0].Value.StringValue, qt.Equals, `[{"Plan": {"Node Type": "Seq Scan"}}]`)
+}
+
+func TestWriteOTLP_WebSocket(t *testing.T) {
+	c := qt.New(t)
+	tr := testTrace()
+	start := tr.Events[0].EventTime.AsTime()
+	for i, ev := range []*tracepb2.SpanEvent{
+		{Data: &tracepb2.SpanEvent_WebsocketConnect{WebsocketConnect: &tracepb2.WebSocketConnect{Protocol: "encore-ws"}}},
+		{Data: &tracepb2.SpanEvent_WebsocketMessage{WebsocketMessage: &tracepb2.WebSocketMessage{Inbound: true, Data: []byte(`{"text":"hi"}`)}}},
+		{Data: &tracepb2.SpanEvent_WebsocketClose{WebsocketClose: &tracepb2.WebSocketClose{Code: 1000, Reason: "bye"}}},
+	} {
+		tr.Events = append(tr.Events, &tracepb2.TraceEvent{
+			TraceId:   tr.Events[0].TraceId,
+			SpanId:    10,
+			EventId:   uint64(6 + i),
+			EventTime: timestamppb.New(start),
+			Event:     &tracepb2.TraceEvent_SpanEvent{SpanEvent: ev},
+		})
+	}
+
+	var buf bytes.Buffer
+	c.Assert(WriteOTLP(&buf, []*Trace{tr}), qt.IsNil)
+	var out otlpTraces
+	c.Assert(json.Unmarshal(buf.Bytes(), &out), qt.IsNil)
+
+	req := out.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	c.Assert(req.Events, qt.HasLen, 4)
+	c.Assert(req.Events[1].Name, qt.Equals, "websocket connect")
+	msg := req.Events[2]
+	c.Assert(msg.Name, qt.Equals, "websocket message")
+	c.Assert(*msg.Attributes[0].Value.StringValue, qt.Equals, "RECEIVED")
+	cl := req.Events[3]
+	c.Assert(cl.Name, qt.Equals, "websocket close")
+	c.Assert(*cl.Attributes[1].Value.StringValue, qt.Equals, "bye")
+}
+
+func TestWriteOTLP_FaultInjected(t *testing.T) {
+	c := qt.New(t)
+	tr := testTrace()
+	tr.Events = append(tr.Events, &tracepb2.TraceEvent{
+		TraceId:   tr.Events[0].TraceId,
+		SpanId:    10,
+		EventId:   6,
+		EventTime: timestamppb.New(tr.Events[0].EventTime.AsTime()),
+		Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+			Data: &tracepb2.SpanEvent_FaultInjected{FaultInjected: &tracepb2.FaultInjected{
+				Target:       "sqldb_query",
+				Resource:     "orders",
+				LatencyNanos: uint64(250 * time.Millisecond),
+				ErrorCode:    "unavailable",
+			}},
+		}},
+	})
+
+	var buf bytes.Buffer
+	c.Assert(WriteOTLP(&buf, []*Trace{tr}), qt.IsNil)
+	var out otlpTraces
+	c.Assert(json.Unmarshal(buf.Bytes(), &out), qt.IsNil)
+
+	req := out.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	c.Assert(req.Events, qt.HasLen, 2)
+	fault := req.Events[1]
+	c.Assert(fault.Name, qt.Equals, "fault injected")
+	c.Assert(fault.Attributes, qt.HasLen, 4)
+	c.Assert(*fault.Attributes[0].Value.StringValue, qt.Equals, "sqldb_query")
+	c.Assert(*fault.Attributes[1].Value.StringValue, qt.Equals, "orders")
+	c.Assert(*fault.Attributes[2].Value.StringValue, qt.Equals, "unavailable")
+	c.Assert(*fault.Attributes[3].Value.IntValue, qt.Equals, "250")
+}
+
+func TestWriteOTLP_SecretRead(t *testing.T) {
+	c := qt.New(t)
+	tr := testTrace()
+	tr.Events = append(tr.Events, &tracepb2.TraceEvent{
+		TraceId:   tr.Events[0].TraceId,
+		SpanId:    10,
+		EventId:   6,
+		EventTime: timestamppb.New(tr.Events[0].EventTime.AsTime()),
+		Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+			Data: &tracepb2.SpanEvent_SecretRead{SecretRead: &tracepb2.SecretRead{
+				Service:  "svc",
+				Endpoint: "Charge",
+				Secret:   "Token",
+			}},
+		}},
+	})
+
+	var buf bytes.Buffer
+	c.Assert(WriteOTLP(&buf, []*Trace{tr}), qt.IsNil)
+	var out otlpTraces
+	c.Assert(json.Unmarshal(buf.Bytes(), &out), qt.IsNil)
+
+	req := out.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	c.Assert(req.Events, qt.HasLen, 2)
+	read := req.Events[1]
+	c.Assert(read.Name, qt.Equals, "secret read")
+	c.Assert(read.Attributes, qt.HasLen, 3)
+	c.Assert(*read.Attributes[0].Value.StringValue, qt.Equals, "svc")
+	c.Assert(*read.Attributes[1].Value.StringValue, qt.Equals, "Token")
+	c.Assert(*read.Attributes[2].Value.StringValue, qt.Equals, "Charge")
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// OTLPForwarder forwards traces to a tracing backend accepting OTLP/HTTP
+// with JSON encoding, such as Grafana Tempo, Jaeger or an OpenTelemetry collector.
+type OTLPForwarder struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewOTLPForwarder returns a forwarder sending traces to the given endpoint,
+// such as "http://localhost:4318/v1/traces". If the endpoint has no path
+// the standard OTLP/HTTP path for traces, /v1/traces, is used.
+func NewOTLPForwarder(endpoint string) (*OTLPForwarder, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trace export endpoint: %v", err)
+	} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid trace export endpoint %q: must be an http or https URL", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/v1/traces"
+	}
+	return &OTLPForwarder{
+		endpoint: u.String(),
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Endpoint reports the endpoint traces are forwarded to.
+func (f *OTLPForwarder) Endpoint() string {
+	return f.endpoint
+}
+
+// Forward sends the spans made up by the given events of a trace to the backend.
+func (f *OTLPForwarder) Forward(ctx context.Context, events []*tracepb2.TraceEvent) error {
+	tr := &Trace{Events: events}
+	if len(otlpSpans(tr)) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOTLP(&buf, []*Trace{tr}); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.endpoint, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("forward trace: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("forward trace: %s responded with %s: %s", f.endpoint, resp.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
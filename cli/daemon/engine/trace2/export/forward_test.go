@@ -0,0 +1,71 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestNewOTLPForwarder(t *testing.T) {
+	c := qt.New(t)
+	for endpoint, want := range map[string]string{
+		"http://localhost:4318":                    "http://localhost:4318/v1/traces",
+		"http://localhost:4318/":                   "http://localhost:4318/v1/traces",
+		"https://tempo.example.com/otlp/v1/traces": "https://tempo.example.com/otlp/v1/traces",
+	} {
+		f, err := NewOTLPForwarder(endpoint)
+		c.Assert(err, qt.IsNil)
+		c.Check(f.Endpoint(), qt.Equals, want)
+	}
+
+	for _, endpoint := range []string{"localhost:4318", "grpc://localhost:4317", "http://"} {
+		_, err := NewOTLPForwarder(endpoint)
+		c.Check(err, qt.IsNotNil, qt.Commentf("endpoint %q", endpoint))
+	}
+}
+
+func TestOTLPForwarder_Forward(t *testing.T) {
+	c := qt.New(t)
+	var (
+		gotPath, gotType string
+		got              otlpTraces
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotPath, gotType = req.URL.Path, req.Header.Get("Content-Type")
+		body, _ := io.ReadAll(req.Body)
+		c.Check(json.Unmarshal(body, &got), qt.IsNil)
+	}))
+	defer srv.Close()
+
+	f, err := NewOTLPForwarder(srv.URL)
+	c.Assert(err, qt.IsNil)
+	c.Assert(f.Forward(context.Background(), testTrace().Events), qt.IsNil)
+	c.Check(gotPath, qt.Equals, "/v1/traces")
+	c.Check(gotType, qt.Equals, "application/json")
+	c.Assert(got.ResourceSpans, qt.HasLen, 2)
+	c.Check(got.ResourceSpans[0].ScopeSpans[0].Spans[0].Name, qt.Equals, "svc.Hello")
+}
+
+func TestOTLPForwarder_Errors(t *testing.T) {
+	c := qt.New(t)
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		http.Error(w, "bad payload", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	f, err := NewOTLPForwarder(srv.URL)
+	c.Assert(err, qt.IsNil)
+	err = f.Forward(context.Background(), testTrace().Events)
+	c.Assert(err, qt.ErrorMatches, `forward trace: .* responded with 400 Bad Request: bad payload`)
+
+	// Traces without any spans aren't sent.
+	c.Assert(f.Forward(context.Background(), nil), qt.IsNil)
+	c.Check(calls, qt.Equals, 1)
+}
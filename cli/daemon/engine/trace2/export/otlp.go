@@ -0,0 +1,479 @@
+package export
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// OTLP span kinds, as defined by the OpenTelemetry protocol.
+const (
+	spanKindInternal = 1
+	spanKindServer   = 2
+	spanKindConsumer = 5
+)
+
+// OTLP status codes, as defined by the OpenTelemetry protocol.
+const (
+	statusCodeOk    = 1
+	statusCodeError = 2
+)
+
+type otlpTraces struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope   `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Links             []otlpLink     `json:"links,omitempty"`
+	Status            otlpStatus     `json:"status"`
+
+	service string
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpLink struct {
+	TraceID string `json:"traceId"`
+	SpanID  string `json:"spanId"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+func stringAttr(key, val string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpValue{StringValue: &val}}
+}
+
+func boolAttr(key string, val bool) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpValue{BoolValue: &val}}
+}
+
+func intAttr(key string, val int64) otlpKeyValue {
+	s := strconv.FormatInt(val, 10)
+	return otlpKeyValue{Key: key, Value: otlpValue{IntValue: &s}}
+}
+
+// WriteOTLP writes the traces in the OTLP JSON encoding of an
+// ExportTraceServiceRequest, as accepted by OpenTelemetry collectors.
+//
+// Spans are grouped into resources by service name. Custom spans are
+// included as child spans, and log messages and custom events as span events;
+// other trace events, such as database queries, are only included in the
+// output of WriteJSON.
+func WriteOTLP(w io.Writer, traces []*Trace) error {
+	var (
+		out       otlpTraces
+		resources = make(map[string]*otlpResourceSpans)
+	)
+	for _, tr := range traces {
+		for _, span := range otlpSpans(tr) {
+			rs, ok := resources[span.service]
+			if !ok {
+				rs = &otlpResourceSpans{
+					Resource: otlpResource{Attributes: []otlpKeyValue{
+						stringAttr("service.name", span.service),
+					}},
+					ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "encore"}}},
+				}
+				resources[span.service] = rs
+				out.ResourceSpans = append(out.ResourceSpans, rs)
+			}
+			rs.ScopeSpans[0].Spans = append(rs.ScopeSpans[0].Spans, span)
+		}
+	}
+	if out.ResourceSpans == nil {
+		out.ResourceSpans = []*otlpResourceSpans{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// otlpSpans converts the events of a trace into OTLP spans,
+// in the order the spans started.
+func otlpSpans(tr *Trace) []*otlpSpan {
+	var spans []*otlpSpan
+	byID := make(map[uint64]*otlpSpan)
+	customByID := make(map[uint64]*otlpSpan) // keyed by start event id
+
+	for _, ev := range sortedEvents(tr.Events) {
+		evTime := ev.EventTime.AsTime()
+		switch e := ev.Event.(type) {
+		case *tracepb2.TraceEvent_SpanStart:
+			span := newOTLPSpan(ev.TraceId, ev.SpanId, e.SpanStart)
+			span.StartTimeUnixNano = nanos(evTime.UnixNano())
+			span.EndTimeUnixNano = span.StartTimeUnixNano
+			spans = append(spans, span)
+			byID[ev.SpanId] = span
+
+		case *tracepb2.TraceEvent_SpanEnd:
+			span, ok := byID[ev.SpanId]
+			if !ok {
+				continue
+			}
+			span.EndTimeUnixNano = nanos(evTime.UnixNano())
+			if err := e.SpanEnd.Error; err != nil {
+				span.Status = otlpStatus{Code: statusCodeError, Message: err.Msg}
+			} else {
+				span.Status = otlpStatus{Code: statusCodeOk}
+			}
+			if req := e.SpanEnd.GetRequest(); req != nil && req.HttpStatusCode != 0 {
+				span.Attributes = append(span.Attributes, intAttr("http.response.status_code", int64(req.HttpStatusCode)))
+			}
+			if test := e.SpanEnd.GetTest(); test != nil && test.Skipped {
+				span.Attributes = append(span.Attributes, boolAttr("test.skipped", true))
+			}
+			for _, k := range slices.Sorted(maps.Keys(e.SpanEnd.Baggage)) {
+				span.Attributes = append(span.Attributes, stringAttr("baggage."+k, e.SpanEnd.Baggage[k]))
+			}
+
+		case *tracepb2.TraceEvent_SpanEvent:
+			span, ok := byID[ev.SpanId]
+			if !ok {
+				continue
+			}
+			switch data := e.SpanEvent.Data.(type) {
+			case *tracepb2.SpanEvent_LogMessage:
+				span.Events = append(span.Events, logEvent(evTime, data.LogMessage))
+
+			case *tracepb2.SpanEvent_CustomSpanStart:
+				custom := newCustomSpan(span, ev.EventId, data.CustomSpanStart)
+				custom.StartTimeUnixNano = nanos(evTime.UnixNano())
+				custom.EndTimeUnixNano = custom.StartTimeUnixNano
+				spans = append(spans, custom)
+				customByID[ev.EventId] = custom
+
+			case *tracepb2.SpanEvent_CustomSpanEnd:
+				custom, ok := customByID[e.SpanEvent.GetCorrelationEventId()]
+				if !ok {
+					continue
+				}
+				custom.EndTimeUnixNano = nanos(evTime.UnixNano())
+				if err := data.CustomSpanEnd.Err; err != nil {
+					custom.Status = otlpStatus{Code: statusCodeError, Message: err.Msg}
+				} else {
+					custom.Status = otlpStatus{Code: statusCodeOk}
+				}
+				for _, f := range data.CustomSpanEnd.Fields {
+					custom.Attributes = append(custom.Attributes, logFieldAttr(f))
+				}
+
+			case *tracepb2.SpanEvent_CustomEvent:
+				// Add the event to the custom span it belongs to, if any.
+				if custom, ok := customByID[e.SpanEvent.GetCorrelationEventId()]; ok {
+					span = custom
+				}
+				span.Events = append(span.Events, customEvent(evTime, data.CustomEvent))
+
+			case *tracepb2.SpanEvent_SpanLink:
+				span.Links = append(span.Links, otlpLink{
+					TraceID: traceIDHex(data.SpanLink.LinkedTraceId),
+					SpanID:  spanIDHex(data.SpanLink.LinkedSpanId),
+				})
+
+			case *tracepb2.SpanEvent_GoroutineSnapshot:
+				span.Events = append(span.Events, goroutineSnapshotEvent(evTime, data.GoroutineSnapshot))
+
+			case *tracepb2.SpanEvent_DbQueryPlan:
+				span.Events = append(span.Events, otlpEvent{
+					TimeUnixNano: nanos(evTime.UnixNano()),
+					Name:         "query plan",
+					Attributes:   []otlpKeyValue{stringAttr("db.query.plan", data.DbQueryPlan.Plan)},
+				})
+
+			case *tracepb2.SpanEvent_WebsocketConnect, *tracepb2.SpanEvent_WebsocketMessage, *tracepb2.SpanEvent_WebsocketClose:
+				span.Events = append(span.Events, webSocketEvent(evTime, e.SpanEvent))
+
+			case *tracepb2.SpanEvent_FaultInjected:
+				span.Events = append(span.Events, faultEvent(evTime, data.FaultInjected))
+
+			case *tracepb2.SpanEvent_SecretRead:
+				span.Events = append(span.Events, secretReadEvent(evTime, data.SecretRead))
+			}
+		}
+	}
+	return spans
+}
+
+func newOTLPSpan(traceID *tracepb2.TraceID, spanID uint64, start *tracepb2.SpanStart) *otlpSpan {
+	span := &otlpSpan{
+		TraceID: traceIDHex(traceID),
+		SpanID:  spanIDHex(spanID),
+		Kind:    spanKindInternal,
+	}
+	// Only link to the parent span if it's part of the same trace.
+	if start.ParentSpanId != nil && (start.ParentTraceId == nil || traceIDHex(start.ParentTraceId) == span.TraceID) {
+		span.ParentSpanID = spanIDHex(*start.ParentSpanId)
+	}
+
+	switch data := start.Data.(type) {
+	case *tracepb2.SpanStart_Request:
+		req := data.Request
+		span.service = req.ServiceName
+		span.Name = req.ServiceName + "." + req.EndpointName
+		span.Kind = spanKindServer
+		span.Attributes = []otlpKeyValue{
+			stringAttr("encore.endpoint", req.EndpointName),
+			stringAttr("http.request.method", req.HttpMethod),
+			stringAttr("url.path", req.Path),
+		}
+		if req.Uid != nil {
+			span.Attributes = append(span.Attributes, stringAttr("enduser.id", *req.Uid))
+		}
+	case *tracepb2.SpanStart_Auth:
+		span.service = data.Auth.ServiceName
+		span.Name = data.Auth.ServiceName + "." + data.Auth.EndpointName
+	case *tracepb2.SpanStart_PubsubMessage:
+		msg := data.PubsubMessage
+		span.service = msg.ServiceName
+		span.Name = msg.TopicName + " process"
+		span.Kind = spanKindConsumer
+		span.Attributes = []otlpKeyValue{
+			stringAttr("messaging.destination.name", msg.TopicName),
+			stringAttr("messaging.consumer.group.name", msg.SubscriptionName),
+			stringAttr("messaging.message.id", msg.MessageId),
+			intAttr("encore.pubsub.attempt", int64(msg.Attempt)),
+		}
+	case *tracepb2.SpanStart_Job:
+		job := data.Job
+		span.service = job.ServiceName
+		span.Name = job.QueueName + " process"
+		span.Kind = spanKindConsumer
+		span.Attributes = []otlpKeyValue{
+			stringAttr("encore.worker.queue", job.QueueName),
+			stringAttr("encore.worker.job_id", job.JobId),
+			intAttr("encore.worker.attempt", int64(job.Attempt)),
+		}
+	case *tracepb2.SpanStart_Test:
+		test := data.Test
+		span.service = test.ServiceName
+		span.Name = test.TestName
+		span.Attributes = []otlpKeyValue{
+			stringAttr("code.filepath", test.TestFile),
+			intAttr("code.lineno", int64(test.TestLine)),
+		}
+	}
+	return span
+}
+
+// newCustomSpan returns the span for a custom span started within parent.
+// Its span id is the id of its start event, which is unique within the trace.
+func newCustomSpan(parent *otlpSpan, eventID uint64, start *tracepb2.CustomSpanStart) *otlpSpan {
+	span := &otlpSpan{
+		TraceID:      parent.TraceID,
+		SpanID:       spanIDHex(eventID),
+		ParentSpanID: parent.SpanID,
+		Name:         start.Name,
+		Kind:         spanKindInternal,
+		service:      parent.service,
+	}
+	for _, f := range start.Fields {
+		span.Attributes = append(span.Attributes, logFieldAttr(f))
+	}
+	return span
+}
+
+func customEvent(t time.Time, ev *tracepb2.CustomEvent) otlpEvent {
+	out := otlpEvent{
+		TimeUnixNano: nanos(t.UnixNano()),
+		Name:         ev.Name,
+	}
+	for _, f := range ev.Fields {
+		out.Attributes = append(out.Attributes, logFieldAttr(f))
+	}
+	return out
+}
+
+// goroutineSnapshotEvent returns an event with the stacks of the snapshot
+// formatted as text, in the style of a goroutine profile.
+func goroutineSnapshotEvent(t time.Time, snap *tracepb2.GoroutineSnapshot) otlpEvent {
+	var stacks strings.Builder
+	for _, s := range snap.Stacks {
+		fmt.Fprintf(&stacks, "%d @\n", s.Count)
+		for _, f := range s.GetStack().GetFrames() {
+			fmt.Fprintf(&stacks, "#\t%s\n#\t\t%s:%d\n", f.Func, f.Filename, f.Line)
+		}
+		stacks.WriteByte('\n')
+	}
+	return otlpEvent{
+		TimeUnixNano: nanos(t.UnixNano()),
+		Name:         "goroutine snapshot",
+		Attributes: []otlpKeyValue{
+			intAttr("encore.goroutines.total", int64(snap.TotalGoroutines)),
+			stringAttr("encore.goroutines.stacks", stacks.String()),
+		},
+	}
+}
+
+// webSocketEvent returns an event for a WebSocket connection being
+// established, closed or passing a message. Messages are recorded
+// without their payload, like other request and response bodies.
+func webSocketEvent(t time.Time, ev *tracepb2.SpanEvent) otlpEvent {
+	e := otlpEvent{TimeUnixNano: nanos(t.UnixNano())}
+	switch {
+	case ev.GetWebsocketConnect() != nil:
+		e.Name = "websocket connect"
+		if p := ev.GetWebsocketConnect().Protocol; p != "" {
+			e.Attributes = append(e.Attributes, stringAttr("websocket.protocol", p))
+		}
+	case ev.GetWebsocketMessage() != nil:
+		msg := ev.GetWebsocketMessage()
+		e.Name = "websocket message"
+		typ := "SENT"
+		if msg.Inbound {
+			typ = "RECEIVED"
+		}
+		e.Attributes = append(e.Attributes,
+			stringAttr("message.type", typ),
+			intAttr("message.uncompressed_size", int64(len(msg.Data))),
+		)
+	case ev.GetWebsocketClose() != nil:
+		cl := ev.GetWebsocketClose()
+		e.Name = "websocket close"
+		e.Attributes = append(e.Attributes, intAttr("websocket.close.code", int64(cl.Code)))
+		if cl.Reason != "" {
+			e.Attributes = append(e.Attributes, stringAttr("websocket.close.reason", cl.Reason))
+		}
+		if cl.Err != nil {
+			e.Attributes = append(e.Attributes, stringAttr("exception.message", cl.Err.Msg))
+		}
+	}
+	return e
+}
+
+func faultEvent(t time.Time, f *tracepb2.FaultInjected) otlpEvent {
+	e := otlpEvent{
+		TimeUnixNano: nanos(t.UnixNano()),
+		Name:         "fault injected",
+		Attributes:   []otlpKeyValue{stringAttr("encore.fault.target", f.Target)},
+	}
+	for _, attr := range []struct{ key, val string }{
+		{"encore.fault.service", f.Service},
+		{"encore.fault.endpoint", f.Endpoint},
+		{"encore.fault.resource", f.Resource},
+		{"encore.fault.error_code", f.ErrorCode},
+	} {
+		if attr.val != "" {
+			e.Attributes = append(e.Attributes, stringAttr(attr.key, attr.val))
+		}
+	}
+	if f.LatencyNanos > 0 {
+		e.Attributes = append(e.Attributes, intAttr("encore.fault.latency_ms", int64(time.Duration(f.LatencyNanos).Milliseconds())))
+	}
+	if f.Drop {
+		e.Attributes = append(e.Attributes, boolAttr("encore.fault.drop", true))
+	}
+	return e
+}
+
+func secretReadEvent(t time.Time, r *tracepb2.SecretRead) otlpEvent {
+	e := otlpEvent{
+		TimeUnixNano: nanos(t.UnixNano()),
+		Name:         "secret read",
+		Attributes: []otlpKeyValue{
+			stringAttr("encore.secret.service", r.Service),
+			stringAttr("encore.secret.name", r.Secret),
+		},
+	}
+	if r.Endpoint != "" {
+		e.Attributes = append(e.Attributes, stringAttr("encore.secret.endpoint", r.Endpoint))
+	}
+	return e
+}
+
+func logEvent(t time.Time, log *tracepb2.LogMessage) otlpEvent {
+	ev := otlpEvent{
+		TimeUnixNano: nanos(t.UnixNano()),
+		Name:         log.Msg,
+		Attributes:   []otlpKeyValue{stringAttr("log.level", strings.ToLower(log.Level.String()))},
+	}
+	for _, f := range log.Fields {
+		ev.Attributes = append(ev.Attributes, logFieldAttr(f))
+	}
+	return ev
+}
+
+func logFieldAttr(f *tracepb2.LogField) otlpKeyValue {
+	switch v := f.Value.(type) {
+	case *tracepb2.LogField_Error:
+		return stringAttr(f.Key, v.Error.GetMsg())
+	case *tracepb2.LogField_Str:
+		return stringAttr(f.Key, v.Str)
+	case *tracepb2.LogField_Bool:
+		return boolAttr(f.Key, v.Bool)
+	case *tracepb2.LogField_Time:
+		return stringAttr(f.Key, v.Time.AsTime().Format(time.RFC3339Nano))
+	case *tracepb2.LogField_Dur:
+		return stringAttr(f.Key, time.Duration(v.Dur).String())
+	case *tracepb2.LogField_Uuid:
+		if b := v.Uuid; len(b) == 16 {
+			return stringAttr(f.Key, fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]))
+		}
+		return stringAttr(f.Key, hex.EncodeToString(v.Uuid))
+	case *tracepb2.LogField_Json:
+		return stringAttr(f.Key, string(v.Json))
+	case *tracepb2.LogField_Int:
+		return intAttr(f.Key, v.Int)
+	case *tracepb2.LogField_Uint:
+		return stringAttr(f.Key, strconv.FormatUint(v.Uint, 10))
+	case *tracepb2.LogField_Float32:
+		return stringAttr(f.Key, strconv.FormatFloat(float64(v.Float32), 'g', -1, 32))
+	case *tracepb2.LogField_Float64:
+		return stringAttr(f.Key, strconv.FormatFloat(v.Float64, 'g', -1, 64))
+	default:
+		return stringAttr(f.Key, fmt.Sprint(f.Value))
+	}
+}
@@ -27,6 +27,15 @@ type RecordData struct {
 	TraceVersion trace2.Version
 	Buf          *bufio.Reader
 	Anchor       trace2.TimeAnchor
+
+	// Forwarder, if non-nil, receives the events of the trace
+	// once they've all been recorded.
+	Forwarder Forwarder
+}
+
+// A Forwarder forwards traces to another tracing backend.
+type Forwarder interface {
+	Forward(ctx context.Context, events []*tracepb2.TraceEvent) error
 }
 
 func (h *Recorder) RecordTrace(data RecordData) error {
@@ -61,6 +70,9 @@ func (h *Recorder) RecordTrace(data RecordData) error {
 	// that have not yet been written to the store.
 	pendingWrites := make([]*tracepb2.TraceEvent, 0, 100)
 
+	// forwarded are all the events parsed so far, if the trace is forwarded.
+	var forwarded []*tracepb2.TraceEvent
+
 	flushWrites := func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -86,10 +98,16 @@ func (h *Recorder) RecordTrace(data RecordData) error {
 			if !ok {
 				// No more events.
 				flushWrites()
+				if data.Forwarder != nil {
+					go forward(data.Forwarder, forwarded)
+				}
 				return nil
 			}
 			debounce.Reset(500 * time.Millisecond)
 			pendingWrites = append(pendingWrites, ev)
+			if data.Forwarder != nil {
+				forwarded = append(forwarded, ev)
+			}
 
 			// Flush immediately if we've accumulated a bunch of events
 			// since the debounce may never run in a high throughput scenario.
@@ -102,3 +120,13 @@ func (h *Recorder) RecordTrace(data RecordData) error {
 		}
 	}
 }
+
+// forward forwards the events of a trace using fwd, logging any error.
+// It's done in the background to not hold up the app streaming the trace.
+func forward(fwd Forwarder, events []*tracepb2.TraceEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := fwd.Forward(ctx, events); err != nil {
+		log.Warn().Err(err).Msg("unable to forward trace")
+	}
+}
@@ -28,9 +28,41 @@ func (s *Store) List(ctx context.Context, q *trace2.Query, iter trace2.ListEntry
 
 	extraWhereClause := ""
 
-	if q.MessageID != "" {
-		args = append(args, q.MessageID)
-		extraWhereClause += " AND message_id = $" + strconv.Itoa(len(args))
+	// Add a filter for each of the string fields that are set.
+	for _, f := range []struct{ col, val string }{
+		{"service_name", q.Service},
+		{"endpoint_name", q.Endpoint},
+		{"topic_name", q.Topic},
+		{"subscription_name", q.Subscription},
+		{"trace_id", q.TraceID},
+		{"message_id", q.MessageID},
+	} {
+		if f.val != "" {
+			args = append(args, f.val)
+			extraWhereClause += " AND " + f.col + " = $" + strconv.Itoa(len(args))
+		}
+	}
+
+	if !q.StartTime.IsZero() {
+		args = append(args, q.StartTime.UnixNano())
+		extraWhereClause += " AND started_at >= $" + strconv.Itoa(len(args))
+	}
+	if !q.EndTime.IsZero() {
+		args = append(args, q.EndTime.UnixNano())
+		extraWhereClause += " AND started_at < $" + strconv.Itoa(len(args))
+	}
+
+	if q.IsError != nil {
+		args = append(args, *q.IsError)
+		extraWhereClause += " AND is_error = $" + strconv.Itoa(len(args))
+	}
+	if q.MinDurNanos > 0 {
+		args = append(args, q.MinDurNanos)
+		extraWhereClause += " AND duration_nanos >= $" + strconv.Itoa(len(args))
+	}
+	if q.MaxDurNanos > 0 {
+		args = append(args, q.MaxDurNanos)
+		extraWhereClause += " AND duration_nanos <= $" + strconv.Itoa(len(args))
 	}
 
 	// If we're filter for tests / not tests, add the extra where clause
@@ -46,7 +78,7 @@ func (s *Store) List(ctx context.Context, q *trace2.Query, iter trace2.ListEntry
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT
 		    trace_id, span_id, started_at, span_type, is_root, service_name, endpoint_name,
-		    topic_name, subscription_name, message_id, is_error, test_skipped, duration_nanos, src_file, src_line
+		    topic_name, subscription_name, message_id, queue_name, job_id, is_error, test_skipped, duration_nanos, src_file, src_line
 		FROM trace_span_index
 		WHERE app_id = $1 AND has_response AND is_root AND span_type != $2 `+extraWhereClause+`
 		ORDER BY started_at DESC
@@ -68,7 +100,7 @@ func (s *Store) List(ctx context.Context, q *trace2.Query, iter trace2.ListEntry
 		var startedAt int64
 		err := rows.Scan(
 			&t.TraceId, &t.SpanId, &startedAt, &t.Type, &t.IsRoot, &t.ServiceName, &t.EndpointName,
-			&t.TopicName, &t.SubscriptionName, &t.MessageId, &t.IsError, &t.TestSkipped, &t.DurationNanos, &t.SrcFile, &t.SrcLine)
+			&t.TopicName, &t.SubscriptionName, &t.MessageId, &t.QueueName, &t.JobId, &t.IsError, &t.TestSkipped, &t.DurationNanos, &t.SrcFile, &t.SrcLine)
 		if err != nil {
 			return errors.Wrap(err, "scan trace")
 		}
@@ -91,13 +123,13 @@ func (s *Store) emitCompleteSpanToListeners(ctx context.Context, appID, traceID,
 	err := s.db.QueryRowContext(ctx, `
 		SELECT
 			trace_id, span_id, started_at, span_type, is_root, service_name, endpoint_name,
-			topic_name, subscription_name, message_id, is_error, test_skipped, duration_nanos, src_file, src_line
+			topic_name, subscription_name, message_id, queue_name, job_id, is_error, test_skipped, duration_nanos, src_file, src_line
 		FROM trace_span_index
 		WHERE app_id = ? AND trace_id = ? AND span_id = ? AND has_response AND is_root AND span_type != ?
 		ORDER BY started_at DESC
 	`, appID, traceID, spanID, tracepb2.SpanSummary_AUTH).Scan(
 		&t.TraceId, &t.SpanId, &startedAt, &t.Type, &t.IsRoot, &t.ServiceName, &t.EndpointName,
-		&t.TopicName, &t.SubscriptionName, &t.MessageId, &t.IsError, &t.TestSkipped, &t.DurationNanos, &t.SrcFile, &t.SrcLine)
+		&t.TopicName, &t.SubscriptionName, &t.MessageId, &t.QueueName, &t.JobId, &t.IsError, &t.TestSkipped, &t.DurationNanos, &t.SrcFile, &t.SrcLine)
 	if errors.Is(err, sql.ErrNoRows) {
 		return
 	} else if err != nil {
@@ -127,7 +159,9 @@ func (s *Store) Get(ctx context.Context, appID, traceID string, iter trace2.Even
 	}
 
 	defer fns.CloseIgnore(rows)
+	found := false
 	for rows.Next() {
+		found = true
 		var data []byte
 		err := rows.Scan(&data)
 		if err != nil {
@@ -143,5 +177,10 @@ func (s *Store) Get(ctx context.Context, appID, traceID string, iter trace2.Even
 		}
 	}
 
-	return errors.Wrap(rows.Err(), "iterate events")
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "iterate events")
+	} else if !found {
+		return trace2.ErrNotFound
+	}
+	return nil
 }
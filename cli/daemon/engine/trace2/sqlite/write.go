@@ -28,6 +28,12 @@ func New(ctx context.Context, db *sql.DB) *Store {
 	return s
 }
 
+// NewReader creates a store backed by the given db for reading traces,
+// such as from outside the daemon. Unlike New it doesn't clean up old traces.
+func NewReader(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
 type Store struct {
 	db        *sql.DB
 	listeners []chan<- trace2.NewSpanEvent
@@ -233,6 +239,26 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 		return nil
 	}
 
+	if job := start.GetJob(); job != nil {
+		_, err := s.db.ExecContext(ctx, `
+			INSERT INTO trace_span_index (
+				app_id, trace_id, span_id, span_type, started_at, is_root, service_name,
+				queue_name, job_id, has_response, test_skipped
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, false, false)
+			ON CONFLICT (trace_id, span_id) DO UPDATE SET
+				is_root = excluded.is_root,
+				service_name = excluded.service_name,
+				queue_name = excluded.queue_name,
+				job_id = excluded.job_id
+		`, meta.AppID, encodeTraceID(ev.TraceId), encodeSpanID(ev.SpanId),
+			tracepbcli.SpanSummary_JOB, ev.EventTime.AsTime().UnixNano(),
+			isRoot, job.ServiceName, job.QueueName, job.JobId)
+		if err != nil {
+			return errors.Wrap(err, "insert trace span event")
+		}
+		return nil
+	}
+
 	if msg := start.GetTest(); msg != nil {
 		_, err := s.db.ExecContext(ctx, `
 			INSERT INTO trace_span_index (
@@ -321,6 +347,24 @@ func (s *Store) updateSpanEndIndex(ctx context.Context, meta *trace2.Meta, ev *t
 		return nil
 	}
 
+	if job := end.GetJob(); job != nil {
+		_, err := s.db.ExecContext(ctx, `
+			INSERT INTO trace_span_index (
+				app_id, trace_id, span_id, span_type, has_response, is_error, duration_nanos
+			) VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (trace_id, span_id) DO UPDATE SET
+				has_response = excluded.has_response,
+				is_error = excluded.is_error,
+				duration_nanos = excluded.duration_nanos
+		`, meta.AppID, traceID, spanID,
+			tracepbcli.SpanSummary_JOB, true,
+			end.Error != nil, end.DurationNanos)
+		if err != nil {
+			return errors.Wrap(err, "insert trace span event")
+		}
+		return nil
+	}
+
 	if msg := end.GetTest(); msg != nil {
 		_, err := s.db.ExecContext(ctx, `
 			INSERT INTO trace_span_index (
@@ -134,16 +134,19 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 	}
 
 	runInstance, err := s.mgr.Start(ctx, run.StartParams{
-		App:        app,
-		NS:         ns,
-		WorkingDir: req.WorkingDir,
-		Listener:   ln,
-		ListenAddr: displayListenAddr,
-		Watch:      req.Watch,
-		Environ:    req.Environ,
-		OpsTracker: ops,
-		Browser:    browser,
-		Debug:      run.DebugModeFromProto(req.DebugMode),
+		App:         app,
+		NS:          ns,
+		WorkingDir:  req.WorkingDir,
+		Listener:    ln,
+		ListenAddr:  displayListenAddr,
+		Watch:       req.Watch,
+		Environ:     req.Environ,
+		OpsTracker:  ops,
+		Browser:     browser,
+		Debug:       run.DebugModeFromProto(req.DebugMode),
+		Services:    req.Services,
+		ProxyRestTo: req.ProxyRestTo,
+		TraceExport: req.TraceExport,
 	})
 	if err != nil {
 		s.mu.Unlock()
@@ -174,6 +177,13 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 	if ns := runInstance.NS; !ns.Active || ns.Name != "default" {
 		_, _ = fmt.Fprintf(stderr, "  Namespace:                  %s\n", aurora.Cyan(ns.Name))
 	}
+	if len(req.Services) > 0 {
+		_, _ = fmt.Fprintf(stderr, "  Running services:           %s (proxying others to %s)\n",
+			aurora.Cyan(strings.Join(req.Services, ", ")), aurora.Cyan(req.ProxyRestTo))
+	}
+	if fwd := runInstance.TraceForwarder; fwd != nil {
+		_, _ = fmt.Fprintf(stderr, "  Forwarding traces to:       %s\n", aurora.Cyan(fwd.Endpoint()))
+	}
 	if req.DebugMode == daemonpb.RunRequest_DEBUG_ENABLED {
 		// Print the pid for debugging. Currently we only support this if we have a default gateway.
 		if gw, ok := runInstance.ProcGroup().Gateways["api-gateway"]; ok {
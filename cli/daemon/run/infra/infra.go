@@ -286,13 +286,20 @@ func (rm *ResourceManager) UpdateConfig(cfg *config.Runtime, md *meta.Data, dbPr
 		cfg.SQLServers = append(cfg.SQLServers, srv)
 
 		for _, db := range md.SqlDatabases {
-			cfg.SQLDatabases = append(cfg.SQLDatabases, &config.SQLDatabase{
+			dbCfg := &config.SQLDatabase{
 				ServerID:     serverID,
 				EncoreName:   db.Name,
 				DatabaseName: db.Name,
 				User:         "encore",
 				Password:     cluster.Password,
-			})
+			}
+
+			// When running tests, have each test process create its own database
+			// from the template, so packages can be tested in parallel.
+			if clusterDB, ok := cluster.GetDB(db.Name); rm.forTests && ok {
+				dbCfg.TemplateDatabaseName = clusterDB.TemplateCloudName().GetOrElse("")
+			}
+			cfg.SQLDatabases = append(cfg.SQLDatabases, dbCfg)
 		}
 
 		// Configure max connections based on 96 connections
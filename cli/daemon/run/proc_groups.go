@@ -1,7 +1,9 @@
 package run
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net"
 	"net/http"
@@ -11,6 +13,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -42,6 +45,7 @@ type procGroupOptions struct {
 	Logger      RunLogger
 	WorkingDir  string
 	ConfigGen   *RuntimeConfigGenerator
+	Parse       *builder.ParseResult // the parse result the group was built from
 }
 
 func newProcGroup(opts procGroupOptions) *ProcGroup {
@@ -55,6 +59,7 @@ func newProcGroup(opts procGroupOptions) *ProcGroup {
 		logger:      opts.Logger,
 		log:         opts.Run.log.With().Str("proc_id", opts.ProcID).Logger(),
 		ConfigGen:   opts.ConfigGen,
+		parse:       opts.Parse,
 
 		symParsed: make(chan struct{}),
 		Services:  make(map[string]*Proc),
@@ -81,6 +86,9 @@ type ProcGroup struct {
 
 	ConfigGen *RuntimeConfigGenerator // generates runtime configuration
 
+	parse *builder.ParseResult // the parse result the group was built from, for reloading config
+	cfgMu sync.Mutex           // serializes config reloads
+
 	procMu       sync.Mutex // protects both allProcesses and runningProcs
 	procCond     sync.Cond  // used to signal a change in runningProcs
 	allProcesses []*Proc    // all processes in the group
@@ -368,6 +376,48 @@ func (pg *ProcGroup) Warnings() (rtn []warning) {
 	return rtn
 }
 
+// pushConfig sends the given service configs to all processes in the group,
+// which apply them without restarting.
+//
+// It reports errConfigNotReloadable if a process can't apply the changes.
+func (pg *ProcGroup) pushConfig(ctx context.Context, configs map[string]json.RawMessage) error {
+	body, err := json.Marshal(configs)
+	if err != nil {
+		return err
+	}
+
+	pg.procMu.Lock()
+	procs := slices.Clone(pg.allProcesses)
+	pg.procMu.Unlock()
+
+	for _, p := range procs {
+		url := "http://" + p.listenAddr.String() + "/__encore/config"
+		req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		addAuthKeyToRequest(req, pg.authKey)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return errors.Wrap(err, "push config")
+		}
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		_ = resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusNoContent:
+		case http.StatusConflict, http.StatusNotFound:
+			// The app was built with a runtime that doesn't support reloading config
+			// if the route doesn't exist.
+			return errors.Wrap(errConfigNotReloadable, strings.TrimSpace(string(msg)))
+		default:
+			return errors.Newf("push config: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+		}
+	}
+	return nil
+}
+
 // Proc represents a single Encore process running within a [ProcGroup].
 type Proc struct {
 	group *ProcGroup     // The group this process belongs to
@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -30,12 +31,14 @@ import (
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/experiments"
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/engine/trace2/export"
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/run/infra"
 	"encr.dev/cli/daemon/secret"
 	"encr.dev/internal/optracker"
 	"encr.dev/internal/userconfig"
 	"encr.dev/internal/version"
+	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/builder/builderimpl"
 	"encr.dev/pkg/cueutil"
@@ -56,6 +59,10 @@ type Run struct {
 	ResourceManager *infra.ResourceManager
 	NS              *namespace.Namespace
 
+	// TraceForwarder forwards the app's traces, if configured
+	// using StartParams.TraceExport. It's nil otherwise.
+	TraceForwarder *export.OTLPForwarder
+
 	Builder builder.Impl
 	log     zerolog.Logger
 	Mgr     *Manager
@@ -98,6 +105,17 @@ type StartParams struct {
 
 	// Debug specifies to compile the application for debugging.
 	Debug builder.DebugMode
+
+	// Services, if non-empty, restricts the run to the given services.
+	Services []string
+
+	// ProxyRestTo is the environment name or base URL to proxy calls
+	// to the services not included in Services to.
+	ProxyRestTo string
+
+	// TraceExport, if non-empty, is the OTLP/HTTP endpoint to forward
+	// the app's traces to, in addition to recording them locally.
+	TraceExport string
 }
 
 // BrowserMode specifies how to open the browser when starting 'encore run'.
@@ -151,6 +169,13 @@ func DebugModeFromProto(d daemonpb.RunRequest_DebugMode) builder.DebugMode {
 func (mgr *Manager) Start(ctx context.Context, params StartParams) (run *Run, err error) {
 	logger := log.With().Str("app_id", params.App.PlatformOrLocalID()).Logger()
 
+	var fwd *export.OTLPForwarder
+	if params.TraceExport != "" {
+		if fwd, err = export.NewOTLPForwarder(params.TraceExport); err != nil {
+			return nil, err
+		}
+	}
+
 	svcProxy, err := svcproxy.New(ctx, logger)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create service proxy")
@@ -163,6 +188,7 @@ func (mgr *Manager) Start(ctx context.Context, params StartParams) (run *Run, er
 		ResourceManager: infra.NewResourceManager(params.App, mgr.ClusterMgr, mgr.ObjectsMgr, mgr.PublicBuckets, params.NS, params.Environ, mgr.DBProxyPort, false),
 		ListenAddr:      params.ListenAddr,
 		SvcProxy:        svcProxy,
+		TraceForwarder:  fwd,
 		log:             logger,
 		Mgr:             mgr,
 		Params:          &params,
@@ -256,6 +282,68 @@ func (r *Run) Reload() error {
 	return nil
 }
 
+// errConfigNotReloadable is reported by ReloadConfig when the config
+// changes can't be applied to the running app without restarting it.
+var errConfigNotReloadable = errors.New("config changes require a restart")
+
+// ReloadConfig recomputes the service configs from the app's CUE files
+// and pushes any changes to the running app, without restarting it.
+//
+// It reports errConfigNotReloadable if the changes can't be applied in place,
+// in which case the app must be restarted using Reload.
+func (r *Run) ReloadConfig(ctx context.Context) error {
+	pg := r.ProcGroup()
+	if pg == nil || pg.parse == nil || r.App.Lang() != appfile.LangGo {
+		return errConfigNotReloadable
+	}
+	pg.cfgMu.Lock()
+	defer pg.cfgMu.Unlock()
+
+	res, err := r.Builder.ServiceConfigs(ctx, builder.ServiceConfigsParams{
+		Parse:   pg.parse,
+		CueMeta: r.cueMeta(),
+	})
+	if err != nil {
+		return err
+	}
+
+	prev := pg.ConfigGen.SvcConfigs
+	if len(res.Configs) != len(prev) {
+		// Configs that fail to compute are left out, so let a full reload report the error.
+		return errConfigNotReloadable
+	}
+	changed := make(map[string]json.RawMessage)
+	for svc, cfg := range res.Configs {
+		prevCfg, ok := prev[svc]
+		if !ok {
+			return errConfigNotReloadable
+		} else if cfg != prevCfg {
+			changed[svc] = json.RawMessage(cfg)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := pg.pushConfig(ctx, changed); err != nil {
+		return err
+	}
+	pg.ConfigGen.SvcConfigs = res.Configs
+	return nil
+}
+
+// cueMeta returns the metadata to provide to the app's CUE files.
+func (r *Run) cueMeta() *cueutil.Meta {
+	return &cueutil.Meta{
+		APIBaseURL: fmt.Sprintf("http://%s", r.ListenAddr),
+		EnvName:    "local",
+		EnvType:    cueutil.EnvType_Development,
+		CloudType:  cueutil.CloudType_Local,
+	}
+}
+
 // start starts the application and serves requests over HTTP using ln.
 func (r *Run) start(ln net.Listener, tracker *optracker.OpTracker) (err error) {
 	defer func() {
@@ -400,13 +488,8 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 
 	configProm := promise.New(func() (*builder.ServiceConfigsResult, error) {
 		return r.Builder.ServiceConfigs(ctx, builder.ServiceConfigsParams{
-			Parse: parse,
-			CueMeta: &cueutil.Meta{
-				APIBaseURL: fmt.Sprintf("http://%s", r.ListenAddr),
-				EnvName:    "local",
-				EnvType:    cueutil.EnvType_Development,
-				CloudType:  cueutil.CloudType_Local,
-			},
+			Parse:   parse,
+			CueMeta: r.cueMeta(),
 		})
 	})
 
@@ -453,6 +536,7 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		Ctx:            ctx,
 		Outputs:        build.Outputs,
 		Meta:           parse.Meta,
+		Parse:          parse,
 		Logger:         r.Mgr,
 		Secrets:        secrets,
 		ServiceConfigs: svcCfg.Configs,
@@ -502,6 +586,7 @@ type StartProcGroupParams struct {
 	Ctx            context.Context
 	Outputs        []builder.BuildOutput
 	Meta           *meta.Data
+	Parse          *builder.ParseResult
 	Secrets        map[string]string
 	ServiceConfigs map[string]string
 	Logger         RunLogger
@@ -536,27 +621,35 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 		}
 	}
 
+	remoteSvcs, err := r.remoteServices(params.Meta)
+	if err != nil {
+		return nil, err
+	}
+
 	authKey := genAuthKey()
 	p = newProcGroup(procGroupOptions{
 		ProcID:  pid,
 		Run:     r,
 		AuthKey: authKey,
 		ConfigGen: &RuntimeConfigGenerator{
-			app:            r.App,
-			infraManager:   r.ResourceManager,
-			md:             params.Meta,
-			AppID:          option.Some(r.ID),
-			EnvID:          option.Some(pid),
-			TraceEndpoint:  option.Some(fmt.Sprintf("http://localhost:%d/trace", r.Mgr.RuntimePort)),
-			AuthKey:        authKey,
-			Gateways:       gateways,
-			DefinedSecrets: params.Secrets,
-			SvcConfigs:     params.ServiceConfigs,
-			DeployID:       option.Some(fmt.Sprintf("run_%s", xid.New().String())),
-			IncludeMetaEnv: r.Builder.NeedsMeta(),
+			app:             r.App,
+			infraManager:    r.ResourceManager,
+			md:              params.Meta,
+			AppID:           option.Some(r.ID),
+			EnvID:           option.Some(pid),
+			TraceEndpoint:   option.Some(fmt.Sprintf("http://localhost:%d/trace", r.Mgr.RuntimePort)),
+			ProfileEndpoint: option.Some(fmt.Sprintf("http://localhost:%d/profile", r.Mgr.RuntimePort)),
+			AuthKey:         authKey,
+			Gateways:        gateways,
+			DefinedSecrets:  params.Secrets,
+			SvcConfigs:      params.ServiceConfigs,
+			RemoteServices:  remoteSvcs,
+			DeployID:        option.Some(fmt.Sprintf("run_%s", xid.New().String())),
+			IncludeMetaEnv:  r.Builder.NeedsMeta(),
 		},
 		Experiments: params.Experiments,
 		Meta:        params.Meta,
+		Parse:       params.Parse,
 		Ctx:         params.Ctx,
 		WorkingDir:  params.WorkingDir,
 		Logger:      params.Logger,
@@ -607,6 +700,9 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 				cmd := ep.Cmd.Expand(o.GetArtifactDir())
 				// create a process for each service
 				for _, svcName := range ep.Services {
+					if _, ok := remoteSvcs[svcName]; ok {
+						continue
+					}
 					// Generate the environmental variables for the process
 					procConf, ok := svcConfs[svcName]
 					if !ok {
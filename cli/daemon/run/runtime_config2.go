@@ -78,10 +78,17 @@ type RuntimeConfigGenerator struct {
 	// Whether to include the metadata as an environment variable.
 	IncludeMetaEnv bool
 
+	// Where the processes send their continuously captured CPU profiles.
+	// If None the processes don't capture profiles.
+	ProfileEndpoint option.Option[string]
+
 	// The values of defined secrets.
 	DefinedSecrets map[string]string
 	// The configs, per service.
 	SvcConfigs map[string]string
+	// The base URLs of the services not run locally, by service name.
+	// If nil all services are run locally.
+	RemoteServices map[string]string
 
 	conf     *rtconfgen.Builder
 	authKeys []*runtimev1.EncoreAuthKey
@@ -438,6 +445,10 @@ func (g *RuntimeConfigGenerator) ProcPerService(proxy *svcproxy.SvcProxy) (servi
 
 	svcListenAddr := make(map[string]netip.AddrPort)
 	for _, svc := range g.md.Svcs {
+		if baseURL, ok := g.RemoteServices[svc.Name]; ok {
+			sd.Services[svc.Name] = remoteServiceLocation(baseURL)
+			continue
+		}
 		listenAddr, err := freeLocalhostAddress()
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "failed to find free localhost address")
@@ -459,6 +470,9 @@ func (g *RuntimeConfigGenerator) ProcPerService(proxy *svcproxy.SvcProxy) (servi
 
 	// Set up the service processes.
 	for _, svc := range g.md.Svcs {
+		if _, ok := g.RemoteServices[svc.Name]; ok {
+			continue
+		}
 		conf, err := g.conf.Deployment(newRid()).
 			ServiceDiscovery(sd).
 			HostsServices(svc.Name).
@@ -514,8 +528,14 @@ func (g *RuntimeConfigGenerator) AllInOneProc() (*ProcConfig, error) {
 	for _, gw := range g.md.Gateways {
 		d.HostsGateways(gw.EncoreName)
 	}
+	var hosted []string
 	for _, svc := range g.md.Svcs {
-		d.HostsServices(svc.Name)
+		if baseURL, ok := g.RemoteServices[svc.Name]; ok {
+			sd.Services[svc.Name] = remoteServiceLocation(baseURL)
+		} else {
+			d.HostsServices(svc.Name)
+			hosted = append(hosted, svc.Name)
+		}
 	}
 
 	conf, err := d.ReduceWithMeta(g.md).BuildRuntimeConfig()
@@ -528,7 +548,7 @@ func (g *RuntimeConfigGenerator) AllInOneProc() (*ProcConfig, error) {
 		return nil, errors.Wrap(err, "failed to find free localhost address")
 	}
 
-	configEnvs := g.encodeConfigs(fns.Map(g.md.Svcs, func(svc *meta.Service) string { return svc.Name })...)
+	configEnvs := g.encodeConfigs(hosted...)
 
 	return &ProcConfig{
 		Runtime:    option.Some(conf),
@@ -558,6 +578,10 @@ func (g *RuntimeConfigGenerator) ProcPerServiceWithNewRuntimeConfig(proxy *svcpr
 	svcListenAddr := make(map[string]netip.AddrPort)
 	var svcNames []string
 	for _, svc := range g.md.Svcs {
+		if baseURL, ok := g.RemoteServices[svc.Name]; ok {
+			sd.Services[svc.Name] = remoteServiceLocation(baseURL)
+			continue
+		}
 		svcNames = append(svcNames, svc.Name)
 		listenAddr, err := freeLocalhostAddress()
 		if err != nil {
@@ -579,6 +603,9 @@ func (g *RuntimeConfigGenerator) ProcPerServiceWithNewRuntimeConfig(proxy *svcpr
 	}
 
 	for _, svc := range g.md.Svcs {
+		if _, ok := g.RemoteServices[svc.Name]; ok {
+			continue
+		}
 		conf, err = g.conf.Deployment(newRid()).
 			ServiceDiscovery(sd).
 			HostsServices(svc.Name).
@@ -733,6 +760,10 @@ func (g *RuntimeConfigGenerator) ProcEnvs(proc *ProcConfig, useRuntimeConfigV2 b
 		env = append(env, fmt.Sprintf("%s=%s", runtimeCfgEnvVar, runtimeCfgStr))
 	}
 
+	if endpoint, ok := g.ProfileEndpoint.Get(); ok {
+		env = append(env, "ENCORE_PROFILE_ENDPOINT="+endpoint)
+	}
+
 	if g.IncludeMetaEnv {
 		metaBytes, err := proto.Marshal(g.md)
 		if err != nil {
@@ -765,6 +796,21 @@ func (g *RuntimeConfigGenerator) MissingSecrets() []string {
 	return missing
 }
 
+// remoteServiceLocation returns the service discovery location
+// for a service that isn't run locally.
+func remoteServiceLocation(baseURL string) *runtimev1.ServiceDiscovery_Location {
+	return &runtimev1.ServiceDiscovery_Location{
+		BaseUrl: baseURL,
+		AuthMethods: []*runtimev1.ServiceAuth{
+			{
+				AuthMethod: &runtimev1.ServiceAuth_Noop{
+					Noop: &runtimev1.ServiceAuth_NoopAuth{},
+				},
+			},
+		},
+	}
+}
+
 func (g *RuntimeConfigGenerator) encodeSecrets(secretNames map[string]bool) string {
 	vals := make(map[string]string)
 	for name := range secretNames {
@@ -0,0 +1,63 @@
+package run
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/apps"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// remoteServices validates the services to run locally, and registers the
+// remaining services with the service proxy so that calls to them are proxied
+// to the environment given by Params.ProxyRestTo.
+//
+// It returns the base URLs of the remote services by service name,
+// or nil if all services are run locally.
+func (r *Run) remoteServices(md *meta.Data) (map[string]string, error) {
+	if len(r.Params.Services) == 0 {
+		return nil, nil
+	}
+
+	for _, name := range r.Params.Services {
+		if !slices.ContainsFunc(md.Svcs, func(svc *meta.Service) bool { return svc.Name == name }) {
+			return nil, errors.Newf("unknown service %q", name)
+		}
+	}
+
+	target, err := proxyTarget(r.App, r.Params.ProxyRestTo)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := make(map[string]string)
+	for _, svc := range md.Svcs {
+		if !slices.Contains(r.Params.Services, svc.Name) {
+			remote[svc.Name] = r.SvcProxy.RegisterRemoteService(svc.Name, target)
+		}
+	}
+	return remote, nil
+}
+
+// proxyTarget returns the base URL to proxy calls to the services
+// not run locally to. The target is either a base URL,
+// or the name of one of the app's environments.
+func proxyTarget(app *apps.Instance, target string) (*url.URL, error) {
+	if strings.Contains(target, "://") {
+		u, err := url.Parse(target)
+		if err != nil || u.Host == "" {
+			return nil, errors.Newf("invalid proxy URL %q", target)
+		}
+		return u, nil
+	}
+
+	slug := app.PlatformID()
+	if slug == "" {
+		return nil, errors.New("the app is not linked with Encore Cloud, so calls can only be proxied to a base URL")
+	}
+	return url.Parse(fmt.Sprintf("https://%s-%s.encr.app", target, slug))
+}
@@ -16,6 +16,16 @@ func (mgr *Manager) watch(run *Run) error {
 			return
 		}
 
+		if onlyConfigEvents(event) {
+			mgr.RunStdout(run, []byte("Config changes detected, reloading config...\n"))
+			err := run.ReloadConfig(run.ctx)
+			if err == nil {
+				mgr.RunStdout(run, []byte("Reloaded config successfully.\n"))
+				return
+			}
+			run.log.Debug().Err(err).Msg("could not reload config, restarting app")
+		}
+
 		mgr.RunStdout(run, []byte("Changes detected, recompiling...\n"))
 		if err := run.Reload(); err != nil {
 			if errList := AsErrorList(err); errList != nil {
@@ -54,6 +64,22 @@ func IgnoreEvents(events []watcher.Event) bool {
 	return true
 }
 
+// onlyConfigEvents reports whether all the events are on config files,
+// whose changes can usually be applied to the running app without restarting it.
+func onlyConfigEvents(events []watcher.Event) bool {
+	for _, event := range events {
+		if ignoreEvent(event) {
+			continue
+		}
+		// Secrets are only read when the app starts.
+		filename := filepath.Base(event.Path)
+		if filepath.Ext(filename) != ".cue" || filename == ".secrets.local.cue" {
+			return false
+		}
+	}
+	return true
+}
+
 func ignoreEvent(ev watcher.Event) bool {
 	filename := filepath.Base(ev.Path)
 	if strings.HasPrefix(strings.ToLower(filename), "encore.gen.") {
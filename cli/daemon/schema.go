@@ -142,6 +142,8 @@ func (r *schemaRenderer) renderBuiltin(b schema.Builtin) {
 		r.WriteObjectEnd()
 	case schema.Builtin_USER_ID:
 		r.WriteString("userID")
+	case schema.Builtin_FILE:
+		r.WriteString("<file>")
 	default:
 		r.WriteString("<unknown>")
 	}
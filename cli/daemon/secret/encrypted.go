@@ -0,0 +1,158 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/apps"
+)
+
+const (
+	// ageSecretsFile is the name of the age-encrypted local secrets file.
+	// The plaintext uses the same CUE format as the .secrets.local.cue file.
+	ageSecretsFile = ".secrets.local.age"
+
+	// sopsSecretsFile is the name of the sops-encrypted local secrets file,
+	// containing a flat JSON object of secret keys to values.
+	sopsSecretsFile = ".secrets.local.sops.json"
+
+	// ageIdentityEnv is the environment variable that can be set to
+	// the path of the age identity file used to decrypt local secrets.
+	ageIdentityEnv = "ENCORE_SECRETS_AGE_KEY_FILE"
+)
+
+// errCannotDecrypt is returned when an encrypted secrets file can't be decrypted
+// on this machine, because the age identity or the sops binary is missing.
+var errCannotDecrypt = errors.New("cannot decrypt")
+
+// readEncryptedOverrides reads and decrypts the encrypted local secrets files
+// for the app, if any, and returns the decrypted secret values.
+//
+// Both files may exist at the same time, in which case values
+// from the sops file take precedence.
+//
+// A file that can't be decrypted on this machine is skipped with a warning,
+// so the remaining secret sources keep working.
+func readEncryptedOverrides(app *apps.Instance) (map[string]string, error) {
+	values := make(map[string]string)
+
+	if data, err := os.ReadFile(filepath.Join(app.Root(), ageSecretsFile)); err == nil {
+		plaintext, err := decryptAge(data)
+		if errors.Is(err, errCannotDecrypt) {
+			log.Warn().Err(err).Msgf("skipping %s", ageSecretsFile)
+		} else if err != nil {
+			return nil, fmt.Errorf("decrypt %s: %v", ageSecretsFile, err)
+		} else {
+			vals, err := parseCUESecrets(plaintext)
+			if err != nil {
+				return nil, fmt.Errorf("parse %s: %v", ageSecretsFile, err)
+			}
+			for k, v := range vals {
+				values[k] = v
+			}
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	sopsPath := filepath.Join(app.Root(), sopsSecretsFile)
+	if _, err := os.Stat(sopsPath); err == nil {
+		vals, err := decryptSops(sopsPath)
+		if errors.Is(err, errCannotDecrypt) {
+			log.Warn().Err(err).Msgf("skipping %s", sopsSecretsFile)
+		} else if err != nil {
+			return nil, fmt.Errorf("decrypt %s: %v", sopsSecretsFile, err)
+		}
+		for k, v := range vals {
+			values[k] = v
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// decryptAge decrypts the given age-encrypted data, which may be ASCII armored,
+// using the developer's age identities.
+func decryptAge(data []byte) ([]byte, error) {
+	identities, err := loadAgeIdentities()
+	if err != nil {
+		return nil, err
+	}
+
+	var src io.Reader = bytes.NewReader(data)
+	if bytes.HasPrefix(data, []byte(armor.Header)) {
+		src = armor.NewReader(src)
+	}
+	r, err := age.Decrypt(src, identities...)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+// loadAgeIdentities loads the age identities from the file specified by
+// ENCORE_SECRETS_AGE_KEY_FILE, falling back to the default location
+// in the user's config directory.
+func loadAgeIdentities() ([]age.Identity, error) {
+	path := os.Getenv(ageIdentityEnv)
+	if path == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(dir, "encore", "age", "keys.txt")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: no age identity found at %s (set %s to override)", errCannotDecrypt, path, ageIdentityEnv)
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return age.ParseIdentities(f)
+}
+
+// decryptSops decrypts the sops file at path using the sops binary,
+// which handles key management (age, PGP, cloud KMS) on its own.
+func decryptSops(path string) (map[string]string, error) {
+	if _, err := exec.LookPath("sops"); err != nil {
+		return nil, fmt.Errorf("%w: sops is not installed", errCannotDecrypt)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("sops", "--decrypt", "--output-type", "json", path)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(raw))
+	for k, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("secret key %s is not a string", k)
+		}
+		values[k] = s
+	}
+	return values, nil
+}
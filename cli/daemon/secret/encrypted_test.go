@@ -0,0 +1,65 @@
+package secret
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+
+	"encr.dev/cli/daemon/apps"
+)
+
+func TestDecryptAge(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "keys.txt")
+	if err := os.WriteFile(keyFile, []byte(id.String()+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(ageIdentityEnv, keyFile)
+
+	var buf bytes.Buffer
+	aw := armor.NewWriter(&buf)
+	w, err := age.Encrypt(aw, id.Recipient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte(`Foo: "bar"` + "\n" + `Baz: "qux"`))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := aw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext, err := decryptAge(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := parseCUESecrets(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["Foo"] != "bar" || values["Baz"] != "qux" || len(values) != 2 {
+		t.Errorf("got values %v", values)
+	}
+}
+
+func TestReadEncryptedOverrides_MissingIdentity(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ageSecretsFile), []byte("encrypted"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(ageIdentityEnv, filepath.Join(t.TempDir(), "missing.txt"))
+
+	values, err := readEncryptedOverrides(apps.NewInstance(root, "local", ""))
+	if err != nil {
+		t.Fatalf("got error %v, want the file to be skipped", err)
+	} else if len(values) != 0 {
+		t.Errorf("got values %v, want none", values)
+	}
+}
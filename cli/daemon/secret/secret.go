@@ -277,19 +277,28 @@ func (mgr *Manager) secretsPath(appSlug string) (string, error) {
 	return filepath.Join(dir, "encore", "secrets", appSlug+".json"), nil
 }
 
-// applyLocalOverrides parses the local secrets override file, if any,
+// applyLocalOverrides parses the local secrets override files, if any,
 // and returns a new Data object with the overrides applied.
 //
+// Values from the encrypted override files are applied first,
+// followed by the plaintext .secrets.local.cue file.
+//
 // If there are no overrides src is returned directly.
 // The original src data object is never modified.
 func applyLocalOverrides(app *apps.Instance, src *Data) (*Data, error) {
 	const name = ".secrets.local.cue"
+	encrypted, err := readEncryptedOverrides(app)
+	if err != nil {
+		return nil, err
+	}
+
 	data, err := os.ReadFile(filepath.Join(app.Root(), name))
 	if err != nil {
-		if errors.Is(err, fs.ErrNotExist) {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		} else if len(encrypted) == 0 {
 			return src, nil
 		}
-		return nil, err
 	}
 
 	updated := &Data{
@@ -299,7 +308,24 @@ func applyLocalOverrides(app *apps.Instance, src *Data) (*Data, error) {
 	for k, v := range src.Values {
 		updated.Values[k] = v
 	}
+	for k, v := range encrypted {
+		updated.Values[k] = v
+	}
+
+	if data != nil {
+		local, err := parseCUESecrets(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse local secrets: %v", err)
+		}
+		for k, v := range local {
+			updated.Values[k] = v
+		}
+	}
+	return updated, nil
+}
 
+// parseCUESecrets parses a CUE document of secret keys to string values.
+func parseCUESecrets(data []byte) (map[string]string, error) {
 	ctx := cuecontext.New()
 	loadCfg := &load.Config{
 		Stdin: bytes.NewReader(data),
@@ -307,24 +333,25 @@ func applyLocalOverrides(app *apps.Instance, src *Data) (*Data, error) {
 
 	inst := load.Instances([]string{"-"}, loadCfg)[0]
 	if inst.Err != nil {
-		return nil, fmt.Errorf("parse local secrets: %v", inst.Err)
+		return nil, inst.Err
 	}
 	secrets := ctx.BuildInstance(inst)
 	if err := secrets.Err(); err != nil {
-		return nil, fmt.Errorf("parse local secrets: %v", err)
+		return nil, err
 	}
 
 	it, err := secrets.Fields(cue.Hidden(false), cue.Concrete(true))
 	if err != nil {
-		return nil, fmt.Errorf("parse local secrets: %v", err)
+		return nil, err
 	}
+	values := make(map[string]string)
 	for it.Next() {
 		key := it.Selector().String()
 		val, err := it.Value().String()
 		if err != nil {
-			return nil, fmt.Errorf("parse local secrets: secret key %s is not a string", key)
+			return nil, fmt.Errorf("secret key %s is not a string", key)
 		}
-		updated.Values[key] = val
+		values[key] = val
 	}
-	return updated, nil
+	return values, nil
 }
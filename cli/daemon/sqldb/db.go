@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/fs"
 	"path/filepath"
+	"regexp"
 	"sync"
 	"time"
 
@@ -401,6 +402,36 @@ func (db *DB) drop(ctx context.Context) error {
 		if err := db.doDrop(ctx, name); err != nil {
 			return errors.Wrapf(err, "drop database %s", name)
 		}
+		if err := db.dropClones(ctx); err != nil {
+			return errors.Wrap(err, "drop databases created from template")
+		}
+	}
+	return nil
+}
+
+// dropClones drops the databases created from the template database by
+// earlier test runs, which are named after the application database
+// followed by an underscore and a 20-character xid.
+func (db *DB) dropClones(ctx context.Context) error {
+	adm, err := db.connectSuperuser(ctx)
+	if err != nil {
+		return err
+	}
+	pattern := "^" + regexp.QuoteMeta(db.ApplicationCloudName()) + "_[0-9a-v]{20}$"
+	rows, err := adm.Query(ctx, "SELECT datname FROM pg_database WHERE datname ~ $1", pattern)
+	var names []string
+	if err == nil {
+		names, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	}
+	_ = adm.Close(context.Background())
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if err := db.doDrop(ctx, name); err != nil {
+			return errors.Wrapf(err, "drop database %s", name)
+		}
 	}
 	return nil
 }
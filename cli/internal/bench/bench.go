@@ -0,0 +1,213 @@
+// Package bench implements a simple HTTP load generator,
+// used by "encore bench" to measure the latency of API endpoints under load.
+package bench
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Config configures a load test.
+type Config struct {
+	// RPS is the number of requests to send per second.
+	RPS int
+	// Duration is how long to send requests for.
+	Duration time.Duration
+	// Concurrency is the maximum number of requests in flight at once.
+	// Requests that would exceed it are dropped rather than queued,
+	// so that slow responses don't lower the request rate unnoticed.
+	Concurrency int
+	// NewRequest returns a new request to send.
+	NewRequest func(ctx context.Context) (*http.Request, error)
+	// Client is the HTTP client to send requests with.
+	// If nil a client is created based on the concurrency.
+	Client *http.Client
+}
+
+// Result is the outcome of a single request.
+type Result struct {
+	// Start is when the request was sent.
+	Start time.Time
+	// Latency is the time until the response body was fully read.
+	Latency time.Duration
+	// StatusCode is the response status code, or 0 if the request failed.
+	StatusCode int
+	// TraceID is the id of the trace captured for the request, if any.
+	TraceID string
+	// Err is the error if the request failed.
+	Err error
+}
+
+// Report summarizes a load test.
+type Report struct {
+	// Results are the results of the requests, in the order they completed.
+	Results []Result
+	// Elapsed is the duration of the load test.
+	Elapsed time.Duration
+	// Dropped is the number of requests not sent because the maximum
+	// concurrency was reached.
+	Dropped int
+}
+
+// Run runs a load test, sending requests at the configured rate until
+// the duration has elapsed or ctx is canceled.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if cfg.RPS <= 0 || cfg.Duration <= 0 || cfg.Concurrency <= 0 {
+		return nil, errors.New("bench: rps, duration and concurrency must be positive")
+	}
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Transport: &http.Transport{
+			MaxIdleConns:        cfg.Concurrency,
+			MaxIdleConnsPerHost: cfg.Concurrency,
+		}}
+	}
+
+	var (
+		mu     sync.Mutex
+		report = &Report{}
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, cfg.Concurrency)
+	)
+	send := func() {
+		defer func() {
+			<-sem
+			wg.Done()
+		}()
+		res := do(ctx, client, cfg.NewRequest)
+		mu.Lock()
+		report.Results = append(report.Results, res)
+		mu.Unlock()
+	}
+
+	interval := time.Second / time.Duration(cfg.RPS)
+	start := time.Now()
+	deadline := start.Add(cfg.Duration)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+loop:
+	for i := 0; ; i++ {
+		next := start.Add(time.Duration(i) * interval)
+		if !next.Before(deadline) {
+			break
+		}
+		timer.Reset(time.Until(next))
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-timer.C:
+		}
+
+		select {
+		case sem <- struct{}{}:
+			wg.Add(1)
+			go send()
+		default:
+			mu.Lock()
+			report.Dropped++
+			mu.Unlock()
+		}
+	}
+
+	wg.Wait()
+	report.Elapsed = time.Since(start)
+	return report, nil
+}
+
+func do(ctx context.Context, client *http.Client, newRequest func(context.Context) (*http.Request, error)) Result {
+	req, err := newRequest(ctx)
+	if err != nil {
+		return Result{Start: time.Now(), Err: err}
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{Start: start, Latency: time.Since(start), Err: err}
+	}
+	_, err = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return Result{
+		Start:      start,
+		Latency:    time.Since(start),
+		StatusCode: resp.StatusCode,
+		TraceID:    resp.Header.Get("X-Encore-Trace-Id"),
+		Err:        err,
+	}
+}
+
+// Completed returns the results of the requests that got a response.
+func (r *Report) Completed() []Result {
+	var completed []Result
+	for _, res := range r.Results {
+		if res.Err == nil {
+			completed = append(completed, res)
+		}
+	}
+	return completed
+}
+
+// Errors returns the number of requests that did not get a response.
+func (r *Report) Errors() int {
+	return len(r.Results) - len(r.Completed())
+}
+
+// Throughput returns the number of completed requests per second.
+func (r *Report) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(len(r.Completed())) / r.Elapsed.Seconds()
+}
+
+// StatusCodes returns the number of responses per status code.
+func (r *Report) StatusCodes() map[int]int {
+	codes := make(map[int]int)
+	for _, res := range r.Completed() {
+		codes[res.StatusCode]++
+	}
+	return codes
+}
+
+// Latencies returns the latencies of the completed requests, in ascending order.
+func (r *Report) Latencies() []time.Duration {
+	completed := r.Completed()
+	lat := make([]time.Duration, len(completed))
+	for i, res := range completed {
+		lat[i] = res.Latency
+	}
+	sort.Slice(lat, func(i, j int) bool { return lat[i] < lat[j] })
+	return lat
+}
+
+// Slowest returns the n slowest completed requests, slowest first.
+func (r *Report) Slowest(n int) []Result {
+	completed := r.Completed()
+	sort.SliceStable(completed, func(i, j int) bool { return completed[i].Latency > completed[j].Latency })
+	if len(completed) > n {
+		completed = completed[:n]
+	}
+	return completed
+}
+
+// Percentile returns the p-th percentile (0-100) of the sorted durations,
+// using the nearest-rank method. It returns 0 if there are no durations.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	} else if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
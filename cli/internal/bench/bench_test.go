@@ -0,0 +1,101 @@
+package bench
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	var n atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		i := n.Add(1)
+		w.Header().Set("X-Encore-Trace-Id", "trace"+strconv.Itoa(int(i)))
+		switch i {
+		case 3:
+			time.Sleep(50 * time.Millisecond)
+		case 5:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	report, err := Run(context.Background(), Config{
+		RPS:         100,
+		Duration:    200 * time.Millisecond,
+		Concurrency: 10,
+		NewRequest: func(ctx context.Context) (*http.Request, error) {
+			return http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(report.Results); got != 20 || report.Dropped != 0 || report.Errors() != 0 {
+		t.Fatalf("got %d results, %d dropped and %d errors; want 20 results", got, report.Dropped, report.Errors())
+	}
+	if codes := report.StatusCodes(); codes[200] != 19 || codes[500] != 1 {
+		t.Errorf("got status codes %v, want 19x200 and 1x500", codes)
+	}
+	if slowest := report.Slowest(1); len(slowest) != 1 || slowest[0].TraceID != "trace3" {
+		t.Errorf("got slowest %+v, want trace3", slowest)
+	}
+	if lat := report.Latencies(); Percentile(lat, 100) < 50*time.Millisecond {
+		t.Errorf("got max latency %v, want at least 50ms", Percentile(lat, 100))
+	}
+	if report.Elapsed < 190*time.Millisecond || report.Throughput() <= 0 {
+		t.Errorf("got elapsed %v and throughput %v", report.Elapsed, report.Throughput())
+	}
+}
+
+func TestRun_Dropped(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	report, err := Run(context.Background(), Config{
+		RPS:         100,
+		Duration:    100 * time.Millisecond,
+		Concurrency: 1,
+		NewRequest: func(ctx context.Context) (*http.Request, error) {
+			return http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Results) != 1 || report.Dropped != 9 {
+		t.Errorf("got %d results and %d dropped, want 1 and 9", len(report.Results), report.Dropped)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var durs []time.Duration
+	for i := 1; i <= 100; i++ {
+		durs = append(durs, time.Duration(i)*time.Millisecond)
+	}
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 1 * time.Millisecond},
+		{50, 50 * time.Millisecond},
+		{99, 99 * time.Millisecond},
+		{99.9, 100 * time.Millisecond},
+		{100, 100 * time.Millisecond},
+	}
+	for _, test := range tests {
+		if got := Percentile(durs, test.p); got != test.want {
+			t.Errorf("Percentile(%v) = %v, want %v", test.p, got, test.want)
+		}
+	}
+	if got := Percentile(nil, 50); got != 0 {
+		t.Errorf("Percentile(nil) = %v, want 0", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -146,11 +147,47 @@ func DBConnect(ctx context.Context, appSlug, envSlug, dbName, role string, start
 	})
 }
 
-func EnvLogs(ctx context.Context, appSlug, envSlug string) (*websocket.Conn, error) {
+// LogFilter filters the logs streamed by EnvLogs.
+// The zero value matches all logs.
+type LogFilter struct {
+	Service  string // only include logs from this service
+	MinLevel string // only include logs at this level or above, like "warn"
+	TraceID  string // only include logs for this trace
+	NoFollow bool   // stop once the recent logs have been sent, instead of streaming new logs
+}
+
+func EnvLogs(ctx context.Context, appSlug, envSlug string, filter LogFilter) (*websocket.Conn, error) {
 	path := escapef("/apps/%s/envs/%s/log", appSlug, envSlug)
+	query := make(url.Values)
+	if filter.Service != "" {
+		query.Set("service", filter.Service)
+	}
+	if filter.MinLevel != "" {
+		query.Set("level", filter.MinLevel)
+	}
+	if filter.TraceID != "" {
+		query.Set("trace_id", filter.TraceID)
+	}
+	if filter.NoFollow {
+		query.Set("follow", "false")
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
 	return wsDial(ctx, path, true, nil)
 }
 
+// ServiceProfile captures a pprof profile from a running instance of the given service.
+// For CPU profiles and execution traces seconds is the duration to capture for.
+// The returned body must be closed by the caller.
+func ServiceProfile(ctx context.Context, appSlug, envSlug, service, profile string, seconds int) (io.ReadCloser, error) {
+	path := escapef("/apps/%s/envs/%s/services/%s/debug/pprof/%s", appSlug, envSlug, service, profile)
+	if seconds > 0 {
+		path += "?seconds=" + strconv.Itoa(seconds)
+	}
+	return rawCall(ctx, "GET", path, nil, true)
+}
+
 func KubernetesClusters(ctx context.Context, appSlug string, envName string) (string, string, []KubeCtlConfig, error) {
 	type K8SClusterConfigs struct {
 		AppSlug  string          `json:"app"`
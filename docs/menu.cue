@@ -256,11 +256,31 @@
 					text: "Raw Endpoints"
 					path: "/go/primitives/raw-endpoints"
 					file: "go/primitives/raw-endpoints"
+				}, {
+					kind: "basic"
+					text: "File Uploads"
+					path: "/go/primitives/file-uploads"
+					file: "go/primitives/file-uploads"
+				}, {
+					kind: "basic"
+					text: "Server-Sent Events"
+					path: "/go/primitives/server-sent-events"
+					file: "go/primitives/server-sent-events"
 				}, {
 					kind: "basic"
 					text: "Service Structs"
 					path: "/go/primitives/service-structs"
 					file: "go/primitives/service-structs"
+				}, {
+					kind: "basic"
+					text: "Streaming Bodies"
+					path: "/go/primitives/streaming-bodies"
+					file: "go/primitives/streaming-bodies"
+				}, {
+					kind: "basic"
+					text: "WebSockets"
+					path: "/go/primitives/websockets"
+					file: "go/primitives/websockets"
 				}, {
 					kind: "basic"
 					text: "API Errors"
@@ -321,6 +341,21 @@
 				text: "Pub/Sub"
 				path: "/go/primitives/pubsub"
 				file: "go/primitives/pubsub"
+			}, {
+				kind: "basic"
+				text: "Worker Queues"
+				path: "/go/primitives/worker-queues"
+				file: "go/primitives/worker-queues"
+			}, {
+				kind: "basic"
+				text: "Distributed Locks"
+				path: "/go/primitives/distributed-locks"
+				file: "go/primitives/distributed-locks"
+			}, {
+				kind: "basic"
+				text: "Leader Election"
+				path: "/go/primitives/leader-election"
+				file: "go/primitives/leader-election"
 			}, {
 				kind: "basic"
 				text: "Caching"
@@ -365,6 +400,11 @@
 				text: "Middleware"
 				path: "/go/develop/middleware"
 				file: "go/develop/middleware"
+			}, {
+				kind: "basic"
+				text: "Request Data"
+				path: "/go/develop/request-data"
+				file: "go/develop/request-data"
 			}, {
 				kind: "basic"
 				text: "Testing"
@@ -8,6 +8,7 @@ require (
 	cloud.google.com/go/storage v1.43.0
 	cuelang.org/go v0.4.3
 	encore.dev v1.1.0
+	filippo.io/age v1.1.1
 	github.com/agnivade/levenshtein v1.1.1
 	github.com/alecthomas/chroma v0.10.0
 	github.com/alicebob/miniredis/v2 v2.23.0
@@ -34,6 +35,7 @@ require (
 	github.com/google/btree v1.1.3
 	github.com/google/go-cmp v0.6.0
 	github.com/google/go-containerregistry v0.20.2
+	github.com/google/pprof v0.0.0-20221118152302-e6195bd50e26
 	github.com/google/renameio/v2 v2.0.0
 	github.com/gorilla/mux v1.8.1
 	github.com/gorilla/websocket v1.5.0
@@ -23,6 +23,8 @@ const (
 	LangTypeScript Lang = "typescript"
 	LangJavascript Lang = "javascript"
 	LangGo         Lang = "go"
+	LangPython     Lang = "python"
+	LangRust       Lang = "rust"
 	LangOpenAPI    Lang = "openapi"
 )
 
@@ -44,6 +46,10 @@ func Detect(path string) (lang Lang, ok bool) {
 		return LangJavascript, true
 	case ".go":
 		return LangGo, true
+	case ".py":
+		return LangPython, true
+	case ".rs":
+		return LangRust, true
 	default:
 		return LangUnknown, false
 	}
@@ -74,6 +80,10 @@ func Client(
 		gen = &javascript{generatorVersion: javascriptGenLatestVersion}
 	case LangGo:
 		gen = &golang{generatorVersion: goGenLatestVersion}
+	case LangPython:
+		gen = &python{generatorVersion: pythonGenLatestVersion}
+	case LangRust:
+		gen = &rust{generatorVersion: rustGenLatestVersion}
 	case LangOpenAPI:
 		gen = openapi.New(openapi.LatestVersion)
 	default:
@@ -96,6 +106,12 @@ func Client(
 	return buf.Bytes(), nil
 }
 
+// skippedMultipartNote returns the comment written in place of an endpoint
+// accepting files, in clients for languages that can't send them yet.
+func skippedMultipartNote(rpc *meta.RPC, lang string) string {
+	return fmt.Sprintf("%s is not included, as the %s client can't send requests containing files yet.", rpc.Name, lang)
+}
+
 // GetLang returns the language specified by the given string, allowing for case insensitivity and common aliases.
 func GetLang(lang string) (Lang, error) {
 	switch strings.TrimSpace(strings.ToLower(lang)) {
@@ -105,6 +121,10 @@ func GetLang(lang string) (Lang, error) {
 		return LangJavascript, nil
 	case "go", "golang":
 		return LangGo, nil
+	case "python", "py":
+		return LangPython, nil
+	case "rust", "rs":
+		return LangRust, nil
 	case "openapi", "swagger", "oas":
 		return LangOpenAPI, nil
 	default:
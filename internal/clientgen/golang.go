@@ -336,8 +336,14 @@ func (g *golang) generateServiceClient(file *File, service *meta.Service, tags c
 			continue
 		}
 
-		// streaming endpoints not supported yet
-		if rpc.StreamingRequest || rpc.StreamingResponse {
+		// streaming, server-sent events and streamed body endpoints not supported yet
+		if rpc.StreamingRequest || rpc.StreamingResponse || rpc.ServerSentEvents || rpc.StreamedRequestBody || rpc.StreamedResponseBody {
+			continue
+		} else if rpc.MultipartRequest {
+			if len(interfaceMethods) > 0 {
+				interfaceMethods = append(interfaceMethods, Line())
+			}
+			interfaceMethods = append(interfaceMethods, Comment(skippedMultipartNote(rpc, "Go")))
 			continue
 		}
 
@@ -373,8 +379,8 @@ func (g *golang) generateServiceClient(file *File, service *meta.Service, tags c
 			continue
 		}
 
-		// streaming endpoints not supported yet
-		if rpc.StreamingRequest || rpc.StreamingResponse {
+		// streaming, server-sent events, streamed body and multipart endpoints not supported yet
+		if rpc.StreamingRequest || rpc.StreamingResponse || rpc.ServerSentEvents || rpc.StreamedRequestBody || rpc.StreamedResponseBody || rpc.MultipartRequest {
 			continue
 		}
 
@@ -857,7 +863,7 @@ func (g *golang) getType(typ *schema.Type) Code {
 			return Float64()
 		case schema.Builtin_STRING:
 			return String()
-		case schema.Builtin_BYTES:
+		case schema.Builtin_BYTES, schema.Builtin_FILE:
 			return Index().Byte()
 		case schema.Builtin_TIME:
 			return Qual("time", "Time")
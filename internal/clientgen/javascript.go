@@ -60,6 +60,8 @@ type javascript struct {
 
 	seenJSON           bool // true if a JSON type was seen
 	seenHeaderResponse bool // true if we've seen a header used in a response object
+	seenEvents         bool // true if we've seen an endpoint streaming server-sent events
+	seenMultipart      bool // true if we've seen an endpoint accepting file uploads
 	hasAuth            bool // true if we've seen an authentication handler
 	authIsComplexType  bool // true if the auth type is a complex type
 }
@@ -158,7 +160,13 @@ func (js *javascript) writeService(svc *meta.Service, set clientgentypes.Service
 
 		// Signature
 		indent()
-		fmt.Fprintf(js, "async %s(", js.memberName(rpc.Name))
+		if rpc.ServerSentEvents {
+			// The events are yielded by an async generator.
+			js.seenEvents = true
+			fmt.Fprintf(js, "async *%s(", js.memberName(rpc.Name))
+		} else {
+			fmt.Fprintf(js, "async %s(", js.memberName(rpc.Name))
+		}
 
 		if rpc.Proto == meta.RPC_RAW {
 			js.WriteString("method, ")
@@ -202,6 +210,14 @@ func (js *javascript) writeService(svc *meta.Service, set clientgentypes.Service
 			js.WriteString("body, options")
 		}
 
+		// Streamed request bodies are sent as is.
+		if rpc.StreamedRequestBody {
+			if nParams > 0 || rpc.RequestSchema != nil {
+				js.WriteString(", ")
+			}
+			js.WriteString("body")
+		}
+
 		js.WriteString(") {\n")
 
 		if isStream {
@@ -410,7 +426,20 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 
 		// Generate the body
 		if len(reqEnc.BodyParameters) > 0 {
-			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
+			if rpc.MultipartRequest {
+				// Requests containing files are sent as a multipart form
+				js.seenMultipart = true
+				body = "form"
+
+				dict := make(map[string]string)
+				for _, field := range reqEnc.BodyParameters {
+					dict[field.WireFormat] = js.Dot("params", field.SrcName)
+				}
+
+				w.WriteString("// Construct a multipart form with the fields which we want encoded within the body (excluding query string or header fields)\nconst form = encodeForm(")
+				js.Values(w, dict)
+				w.WriteString(")\n\n")
+			} else if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
 				// In the simple case we can just encode the params as the body directly
 				body = "JSON.stringify(params)"
 			} else {
@@ -430,9 +459,20 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 		}
 	}
 
-	// Build the call to callTypedAPI
+	// Build the call to callTypedAPI, callEventsAPI for server-sent events,
+	// or callAPI for streamed request bodies and multipart forms, whose content type is set by fetch
+	apiFn := "callTypedAPI"
+	if rpc.ServerSentEvents {
+		apiFn = "callEventsAPI"
+	} else if rpc.StreamedRequestBody {
+		apiFn = "callAPI"
+		body = "body"
+	} else if rpc.MultipartRequest {
+		apiFn = "callAPI"
+	}
 	callAPI := fmt.Sprintf(
-		"this.baseClient.callTypedAPI(\"%s\", `%s`",
+		"this.baseClient.%s(\"%s\", `%s`",
+		apiFn,
 		rpcEncoding.DefaultMethod,
 		rpcPath,
 	)
@@ -460,6 +500,18 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 	}
 	callAPI += ")"
 
+	// Server-sent events are yielded as they're received
+	if rpc.ServerSentEvents {
+		w.WriteStringf("yield* %s\n", callAPI)
+		return nil
+	}
+
+	// Streamed response bodies are read from the response by the caller
+	if rpc.StreamedResponseBody {
+		w.WriteStringf("return await %s\n", callAPI)
+		return nil
+	}
+
 	// If there's no response schema, we can just return the call to the API directly
 	if rpc.ResponseSchema == nil {
 		w.WriteStringf("await %s\n", callAPI)
@@ -919,7 +971,67 @@ class BaseClient {`)
             headers: { "Content-Type": "application/json", ...params?.headers }
         });
     }
+`)
+
+	if js.seenEvents {
+		js.WriteString(`
+    // callEventsAPI makes a call to an API streaming server-sent events,
+    // and yields the data of each event as it's received
+    async *callEventsAPI(method, path, body, params) {
+        const response = await this.callTypedAPI(method, path, body, {
+            ...params,
+            headers: { "Accept": "text/event-stream", ...params?.headers }
+        })
+        if (response.body === null) {
+            return
+        }
+
+        const reader = response.body.pipeThrough(new TextDecoderStream()).getReader()
+        let buffer = ""
+        try {
+            while (true) {
+                const { done, value } = await reader.read()
+                if (done) {
+                    return
+                }
+                buffer = (buffer + value).replace(/\r\n?/g, "\n")
+
+                // Events are separated by blank lines
+                let end
+                while ((end = buffer.indexOf("\n\n")) >= 0) {
+                    let name = "message"
+                    let data
+                    for (const line of buffer.slice(0, end).split("\n")) {
+                        const sep = line.indexOf(":")
+                        const field = sep < 0 ? line : line.slice(0, sep)
+                        const value = sep < 0 ? "" : line.slice(line[sep + 1] === " " ? sep + 2 : sep + 1)
+                        if (field === "event") {
+                            name = value
+                        } else if (field === "data") {
+                            data = data === undefined ? value : data + "\n" + value
+                        }
+                    }
+                    buffer = buffer.slice(end + 2)
+
+                    if (data === undefined) {
+                        continue
+                    } else if (name === "error") {
+                        // The API failed after the stream was established
+                        const err = JSON.parse(data)
+                        throw new APIError(response.status, isAPIErrorResponse(err) ? err : { code: ErrCode.Unknown, message: data })
+                    }
+                    yield JSON.parse(data)
+                }
+            }
+        } finally {
+            // Close the connection if the caller stops iterating early
+            reader.cancel().catch(() => {})
+        }
+    }
+`)
+	}
 
+	js.WriteString(`
     // callAPI is used by each generated API method to actually make the request
     async callAPI(method, path, body, params) {
         let { query, headers, ...rest } = params ?? {}
@@ -1008,6 +1120,26 @@ function makeRecord(record) {
 }
 `)
 
+	if js.seenMultipart {
+		js.WriteString(`
+// encodeForm builds a multipart form from the given fields, skipping undefined values.
+// Files are appended as they are, and any other values are converted to strings.
+function encodeForm(fields) {
+    const form = new FormData()
+    for (const key in fields) {
+        const val = fields[key]
+        if (val === undefined) {
+            continue
+        }
+        for (const v of Array.isArray(val) ? val : [val]) {
+            form.append(key, v instanceof Blob ? v : String(v))
+        }
+    }
+    return form
+}
+`)
+	}
+
 	if js.seenHeaderResponse {
 		js.WriteString(`
 // mustBeSet will throw an APIError with the Data Loss code if value is null or undefined
@@ -58,6 +58,9 @@ func (g *Generator) Generate(p clientgentypes.GenerateParams) (err error) {
 
 	g.md = p.Meta
 	g.spec = newSpec(p.AppSlug)
+	if err := g.addSecuritySchemes(); err != nil {
+		return err
+	}
 
 	for _, svc := range p.Meta.Svcs {
 		if p.Services.Has(svc.Name) {
@@ -78,8 +81,8 @@ func (g *Generator) Generate(p clientgentypes.GenerateParams) (err error) {
 
 func (g *Generator) addService(svc *meta.Service, tags clientgentypes.TagSet, opts clientgentypes.Options) error {
 	for _, rpc := range svc.Rpcs {
-		// streaming endpoints not supported yet
-		if rpc.StreamingRequest || rpc.StreamingResponse {
+		// streaming, server-sent events and streamed body endpoints not supported yet
+		if rpc.StreamingRequest || rpc.StreamingResponse || rpc.ServerSentEvents || rpc.StreamedRequestBody || rpc.StreamedResponseBody {
 			continue
 		}
 
@@ -122,6 +125,74 @@ func (g *Generator) addRPC(rpc *meta.RPC) error {
 	return nil
 }
 
+// addSecuritySchemes describes the auth handler's parameters as security schemes.
+// They're only added for apps with optional auth endpoints, which need them to
+// describe that the endpoint can be called both with and without auth.
+func (g *Generator) addSecuritySchemes() error {
+	if g.md.AuthHandler == nil || !hasOptionalAuth(g.md) {
+		return nil
+	}
+	authEnc, err := encoding.DescribeAuth(g.md, g.md.AuthHandler.Params, &encoding.Options{})
+	if err != nil {
+		return errors.Wrap(err, "describe auth handler")
+	} else if authEnc == nil {
+		return nil
+	}
+
+	schemes := g.spec.Components.SecuritySchemes
+	if authEnc.LegacyTokenFormat {
+		schemes["bearerAuth"] = &openapi3.SecuritySchemeRef{
+			Value: openapi3.NewSecurityScheme().WithType("http").WithScheme("bearer"),
+		}
+		return nil
+	}
+
+	addParams := func(in string, params []*encoding.ParameterEncoding) {
+		for _, p := range params {
+			s := openapi3.NewSecurityScheme().WithType("apiKey").WithIn(in).WithName(p.WireFormat)
+			s.Description = p.Doc
+			schemes[p.SrcName] = &openapi3.SecuritySchemeRef{Value: s}
+		}
+	}
+	addParams("header", authEnc.HeaderParameters)
+	addParams("query", authEnc.QueryParameters)
+	addParams("cookie", authEnc.CookieParameters)
+	return nil
+}
+
+// rpcSecurity returns the security requirements for calling rpc,
+// for endpoints with optional auth. They accept requests both with
+// and without the auth handler's parameters.
+func (g *Generator) rpcSecurity(rpc *meta.RPC) *openapi3.SecurityRequirements {
+	schemes := g.spec.Components.SecuritySchemes
+	if !isOptionalAuth(rpc) || len(schemes) == 0 {
+		return nil
+	}
+
+	req := openapi3.NewSecurityRequirement()
+	for name := range schemes {
+		req.Authenticate(name)
+	}
+	return openapi3.NewSecurityRequirements().With(req).With(openapi3.NewSecurityRequirement())
+}
+
+// isOptionalAuth reports whether rpc is an endpoint with optional auth.
+func isOptionalAuth(rpc *meta.RPC) bool {
+	return rpc.AccessType == meta.RPC_AUTH && rpc.AllowUnauthenticated
+}
+
+// hasOptionalAuth reports whether md has any endpoints with optional auth.
+func hasOptionalAuth(md *meta.Data) bool {
+	for _, svc := range md.Svcs {
+		for _, rpc := range svc.Rpcs {
+			if isOptionalAuth(rpc) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (g *Generator) getOrCreatePath(rpc *meta.RPC) *openapi3.PathItem {
 	path := rpcPath(rpc)
 	if existing, ok := g.spec.Paths[path]; ok {
@@ -142,6 +213,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 		Description: desc,
 		OperationID: method + ":" + rpc.ServiceName + "." + rpc.Name,
 		Responses:   make(openapi3.Responses),
+		Security:    g.rpcSecurity(rpc),
 	}
 
 	// Add path parameters
@@ -211,13 +283,17 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 		})
 	}
 
-	// Add request body
+	// Add request body, which is a multipart form if the request contains files
 	if len(reqEnc.BodyParameters) > 0 {
+		mediaType := "application/json"
+		if rpc.MultipartRequest {
+			mediaType = "multipart/form-data"
+		}
 		op.RequestBody = &openapi3.RequestBodyRef{
 			Value: &openapi3.RequestBody{
 				Description: "",
 				Required:    false,
-				Content:     g.bodyContent(reqEnc.BodyParameters),
+				Content:     g.bodyContent(mediaType, reqEnc.BodyParameters),
 			},
 		}
 	}
@@ -250,7 +326,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 			}
 
 			if len(respEnc.BodyParameters) > 0 {
-				resp.Content = g.bodyContent(respEnc.BodyParameters)
+				resp.Content = g.bodyContent("application/json", respEnc.BodyParameters)
 			}
 		}
 
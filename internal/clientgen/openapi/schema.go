@@ -3,6 +3,7 @@ package openapi
 import (
 	"fmt"
 	"math"
+	"regexp"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -13,7 +14,7 @@ import (
 	schema "encr.dev/proto/encore/parser/schema/v1"
 )
 
-func (g *Generator) bodyContent(params []*encoding.ParameterEncoding) openapi3.Content {
+func (g *Generator) bodyContent(mediaType string, params []*encoding.ParameterEncoding) openapi3.Content {
 	if len(params) == 0 {
 		return nil
 	}
@@ -36,7 +37,7 @@ func (g *Generator) bodyContent(params []*encoding.ParameterEncoding) openapi3.C
 	s.Required = required
 
 	return openapi3.Content{
-		"application/json": &openapi3.MediaType{
+		mediaType: &openapi3.MediaType{
 			Schema:   s.NewRef(),
 			Example:  nil,
 			Examples: nil,
@@ -46,6 +47,15 @@ func (g *Generator) bodyContent(params []*encoding.ParameterEncoding) openapi3.C
 }
 
 func (g *Generator) schemaType(typ *schema.Type) *openapi3.SchemaRef {
+	ref := g.unvalidatedSchemaType(typ)
+	// Constraints can't be added to references to other schemas.
+	if v := typ.GetValidation(); v != nil && ref.Value != nil {
+		applyValidation(ref.Value, v)
+	}
+	return ref
+}
+
+func (g *Generator) unvalidatedSchemaType(typ *schema.Type) *openapi3.SchemaRef {
 	switch t := typ.Typ.(type) {
 	// A type switch for all the different schema types we support
 	case *schema.Type_Named:
@@ -200,6 +210,56 @@ func (g *Generator) schemaType(typ *schema.Type) *openapi3.SchemaRef {
 	}
 }
 
+// applyValidation adds the constraints of the validation expression to s.
+// Alternatives can't be described as constraints, so they are ignored.
+func applyValidation(s *openapi3.Schema, expr *schema.ValidationExpr) {
+	switch e := expr.Expr.(type) {
+	case *schema.ValidationExpr_And_:
+		for _, ee := range e.And.Exprs {
+			applyValidation(s, ee)
+		}
+
+	case *schema.ValidationExpr_Rule:
+		switch r := e.Rule.Rule.(type) {
+		case *schema.ValidationRule_MinLen:
+			switch s.Type {
+			case openapi3.TypeArray:
+				s.MinItems = r.MinLen
+			case openapi3.TypeObject:
+				s.MinProps = r.MinLen
+			default:
+				s.MinLength = r.MinLen
+			}
+		case *schema.ValidationRule_MaxLen:
+			switch s.Type {
+			case openapi3.TypeArray:
+				s.MaxItems = ptr(r.MaxLen)
+			case openapi3.TypeObject:
+				s.MaxProps = ptr(r.MaxLen)
+			default:
+				s.MaxLength = ptr(r.MaxLen)
+			}
+		case *schema.ValidationRule_MinVal:
+			s.Min = ptr(r.MinVal)
+		case *schema.ValidationRule_MaxVal:
+			s.Max = ptr(r.MaxVal)
+		case *schema.ValidationRule_StartsWith:
+			s.Pattern = "^" + regexp.QuoteMeta(r.StartsWith)
+		case *schema.ValidationRule_EndsWith:
+			s.Pattern = regexp.QuoteMeta(r.EndsWith) + "$"
+		case *schema.ValidationRule_MatchesRegexp:
+			s.Pattern = r.MatchesRegexp
+		case *schema.ValidationRule_Is_:
+			switch r.Is {
+			case schema.ValidationRule_EMAIL:
+				s.Format = "email"
+			case schema.ValidationRule_URL:
+				s.Format = "uri"
+			}
+		}
+	}
+}
+
 func (g *Generator) builtinSchemaType(t schema.Builtin) *openapi3.Schema {
 	switch t {
 	case schema.Builtin_BOOL:
@@ -234,6 +294,8 @@ func (g *Generator) builtinSchemaType(t schema.Builtin) *openapi3.Schema {
 		return openapi3.NewObjectSchema()
 	case schema.Builtin_USER_ID:
 		return openapi3.NewStringSchema()
+	case schema.Builtin_FILE:
+		return openapi3.NewStringSchema().WithFormat("binary")
 	default:
 		doBailout(errors.Newf("unknown builtin type %v", t))
 		panic("unreachable")
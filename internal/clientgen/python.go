@@ -0,0 +1,1287 @@
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/internal/clientgen/clientgentypes"
+	"encr.dev/internal/version"
+	"encr.dev/parser/encoding"
+	"encr.dev/pkg/idents"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+/* The Python generator generates code that looks like this:
+@dataclasses.dataclass(kw_only=True)
+class TaskAddParams:
+    description: str
+
+class TaskServiceClient:
+    def add(self, params: TaskAddParams) -> TaskAddResponse:
+        # ...
+
+class AsyncTaskServiceClient:
+    async def add(self, params: TaskAddParams) -> TaskAddResponse:
+        # ...
+
+*/
+
+// pyGenVersion allows us to introduce breaking changes in the generated code but behind a switch
+// meaning that people with client code reliant on the old behaviour can continue to generate the
+// old code.
+type pyGenVersion int
+
+const (
+	// PyInitial is the originally released python generator
+	PyInitial pyGenVersion = iota
+
+	// PyExperimental can be used to lock experimental or uncompleted features in the generated code
+	// It should always be the last item in the enum
+	PyExperimental
+)
+
+const pythonGenLatestVersion = PyExperimental - 1
+
+type python struct {
+	*bytes.Buffer
+	md               *meta.Data
+	appSlug          string
+	typs             *typeRegistry
+	currDecl         *schema.Decl
+	generatorVersion pyGenVersion
+
+	seenJSON           bool // true if a JSON type was seen
+	seenHeaderResponse bool // true if we've seen a header used in a response object
+	hasAuth            bool // true if we've seen an authentication handler
+	authIsComplexType  bool // true if the auth type is a complex type
+
+	// inAlias is true while rendering the definition of a type alias,
+	// and aliasDeps tracks the aliases it references by name.
+	inAlias   bool
+	aliasDeps []uint32
+}
+
+func (py *python) Version() int {
+	return int(py.generatorVersion)
+}
+
+func (py *python) Generate(p clientgentypes.GenerateParams) (err error) {
+	defer py.handleBailout(&err)
+
+	py.Buffer = p.Buf
+	py.md = p.Meta
+	py.appSlug = p.AppSlug
+	py.typs = getNamedTypes(p.Meta, p.Services)
+
+	if py.md.AuthHandler != nil {
+		py.hasAuth = true
+		py.authIsComplexType = py.md.AuthHandler.Params.GetBuiltin() != schema.Builtin_STRING
+	}
+
+	// Render the type definitions up front, as we need to know
+	// which helper types they use before writing them out.
+	types := py.typeDefinitions()
+
+	py.WriteString("# " + doNotEditHeader() + "\n")
+	py.WriteString(`
+from __future__ import annotations
+
+import dataclasses
+import enum
+import inspect
+import json
+import typing
+import urllib.parse
+
+import httpx
+
+`)
+	if py.seenJSON {
+		py.WriteString(`
+# JSONValue represents an arbitrary JSON value.
+JSONValue = typing.Any
+`)
+	}
+	py.writeClient(p.Services)
+	py.WriteString(types)
+
+	for _, svc := range p.Meta.Svcs {
+		if !hasPublicRPC(svc) || !p.Services.Has(svc.Name) {
+			continue
+		}
+		for _, async := range []bool{false, true} {
+			if err := py.writeService(svc, p.Tags, async); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := py.writeBaseClient(p.AppSlug); err != nil {
+		return err
+	}
+	py.writeHelpers()
+	py.writeErrorType()
+	return nil
+}
+
+// typeDefinitions renders the type variables, dataclasses and type aliases
+// for all the named types used by the included services.
+func (py *python) typeDefinitions() string {
+	var (
+		decls     []*schema.Decl
+		typeVars  = make(map[string]bool)
+		classes   bytes.Buffer
+		aliases   = make(map[uint32]string)
+		aliasDeps = make(map[uint32][]uint32)
+		aliasIDs  []uint32
+	)
+	for _, ns := range py.typs.Namespaces() {
+		nsDecls := py.typs.Decls(ns)
+		sort.Slice(nsDecls, func(i, j int) bool {
+			return nsDecls[i].Name < nsDecls[j].Name
+		})
+		decls = append(decls, nsDecls...)
+	}
+
+	prev := py.Buffer
+	defer func() { py.Buffer = prev }()
+	for _, decl := range decls {
+		for _, tp := range decl.TypeParams {
+			typeVars[tp.Name] = true
+		}
+
+		py.currDecl = decl
+		if decl.Type.GetStruct() != nil {
+			py.Buffer = &classes
+			py.writeDataclass(decl)
+			continue
+		}
+
+		var buf bytes.Buffer
+		py.Buffer = &buf
+		py.inAlias, py.aliasDeps = true, nil
+		py.writeAlias(decl)
+		py.inAlias = false
+		aliases[decl.Id] = buf.String()
+		aliasDeps[decl.Id] = py.aliasDeps
+		aliasIDs = append(aliasIDs, decl.Id)
+	}
+
+	var out strings.Builder
+	if len(typeVars) > 0 {
+		names := make([]string, 0, len(typeVars))
+		for name := range typeVars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out.WriteString("\n\n")
+		for _, name := range names {
+			fmt.Fprintf(&out, "%s = typing.TypeVar(%q)\n", name, name)
+		}
+	}
+	out.Write(classes.Bytes())
+
+	// Type aliases are evaluated when the module is loaded, so any alias
+	// they reference by name must be defined before them.
+	written := make(map[uint32]bool)
+	var writeAlias func(id uint32)
+	writeAlias = func(id uint32) {
+		if written[id] {
+			return
+		}
+		written[id] = true
+		for _, dep := range aliasDeps[id] {
+			if _, ok := aliases[dep]; ok {
+				writeAlias(dep)
+			}
+		}
+		out.WriteString(aliases[id])
+	}
+	for _, id := range aliasIDs {
+		writeAlias(id)
+	}
+	return out.String()
+}
+
+func (py *python) writeDataclass(decl *schema.Decl) {
+	w := py.newIdentWriter(0)
+	w.WriteString("\n\n@dataclasses.dataclass(kw_only=True)\n")
+	w.WriteStringf("class %s", py.declName(decl))
+	if len(decl.TypeParams) > 0 {
+		names := make([]string, len(decl.TypeParams))
+		for i, tp := range decl.TypeParams {
+			names[i] = tp.Name
+		}
+		w.WriteStringf("(typing.Generic[%s])", strings.Join(names, ", "))
+	}
+	w.WriteString(":\n")
+
+	w = w.Indent()
+	py.writeDocString(w, decl.Doc)
+
+	fields := make([]*schema.Field, 0, len(decl.Type.GetStruct().Fields))
+	for _, f := range decl.Type.GetStruct().Fields {
+		if !encoding.IgnoreField(f) {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == 0 {
+		if decl.Doc == "" {
+			w.WriteString("pass\n")
+		}
+		return
+	}
+
+	for i, field := range fields {
+		if i > 0 || decl.Doc != "" {
+			w.WriteString("\n")
+		}
+		jsonName := py.fieldNameInStruct(field)
+		name := py.fieldName(jsonName)
+		typ := py.typ(field.Typ)
+
+		var opts []string
+		if field.Optional || py.isRecursive(field.Typ) {
+			typ = "typing.Optional[" + typ + "]"
+			opts = append(opts, "default=None")
+		}
+		if name != jsonName {
+			opts = append(opts, fmt.Sprintf("metadata={\"json\": %s}", py.quote(jsonName)))
+		}
+
+		w.WriteStringf("%s: %s", name, typ)
+		switch {
+		case len(opts) == 1 && opts[0] == "default=None":
+			w.WriteString(" = None")
+		case len(opts) > 0:
+			w.WriteStringf(" = dataclasses.field(%s)", strings.Join(opts, ", "))
+		}
+		w.WriteString("\n")
+		py.writeDocString(w, field.Doc)
+	}
+}
+
+func (py *python) writeAlias(decl *schema.Decl) {
+	py.WriteString("\n\n")
+	if doc := strings.TrimSpace(decl.Doc); doc != "" {
+		for _, line := range strings.Split(doc, "\n") {
+			py.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+		}
+	}
+
+	// The alias itself must not be a forward reference,
+	// so write any directly referenced alias as-is.
+	typ := decl.Type
+	if n := typ.GetNamed(); n != nil && len(n.TypeArguments) == 0 {
+		py.aliasDeps = append(py.aliasDeps, n.Id)
+		fmt.Fprintf(py, "%s = %s\n", py.declName(decl), py.declName(py.md.Decls[n.Id]))
+		return
+	}
+	fmt.Fprintf(py, "%s = %s\n", py.declName(decl), py.typ(typ))
+}
+
+func (py *python) writeClient(set clientgentypes.ServiceSet) {
+	w := py.newIdentWriter(0)
+	w.WriteString(`
+# BaseURL is the base URL for calling the Encore application's API.
+BaseURL = str
+
+LOCAL: BaseURL = "http://localhost:4000"
+
+
+def environment(name: str) -> BaseURL:
+    """environment returns a BaseURL for calling the cloud environment with the given name."""
+    return f"https://{name}-` + py.appSlug + `.encr.app"
+
+
+def preview_env(pr: typing.Union[int, str]) -> BaseURL:
+    """preview_env returns a BaseURL for calling the preview environment with the given PR number."""
+    return environment(f"pr{pr}")
+`)
+
+	for _, async := range []bool{false, true} {
+		className, baseClient, prefix := "Client", "_BaseClient", ""
+		if async {
+			className, baseClient, prefix = "AsyncClient", "_AsyncBaseClient", "Async"
+		}
+
+		w.WriteStringf("\n\nclass %s:\n", className)
+		w := w.Indent()
+		if async {
+			w.WriteStringf("\"\"\"AsyncClient is an asyncio API client for the %s Encore application.\"\"\"\n\n", py.appSlug)
+		} else {
+			w.WriteStringf("\"\"\"Client is an API client for the %s Encore application.\"\"\"\n\n", py.appSlug)
+		}
+
+		for _, svc := range py.md.Svcs {
+			if hasPublicRPC(svc) && set.Has(svc.Name) {
+				w.WriteStringf("%s: %s%sServiceClient\n", py.memberName(svc.Name), prefix, py.typeName(svc.Name))
+			}
+		}
+
+		w.WriteString(`
+def __init__(self, target: BaseURL, options: typing.Optional[ClientOptions] = None) -> None:
+    """
+    Creates a client for calling the public and authenticated APIs of your Encore application.
+
+    target is the base URL the client should be configured to use. See LOCAL and environment for options.
+    options allows you to override the default behaviour of the client.
+    """
+`)
+		{
+			w := w.Indent()
+			w.WriteStringf("self._base = %s(target, options or ClientOptions())\n", baseClient)
+			for _, svc := range py.md.Svcs {
+				if hasPublicRPC(svc) && set.Has(svc.Name) {
+					w.WriteStringf("self.%s = %s%sServiceClient(self._base)\n", py.memberName(svc.Name), prefix, py.typeName(svc.Name))
+				}
+			}
+		}
+
+		if async {
+			w.WriteString(`
+async def aclose(self) -> None:
+    """aclose closes the underlying HTTP client, unless it was provided in the options."""
+    await self._base.aclose()
+
+async def __aenter__(self) -> AsyncClient:
+    return self
+
+async def __aexit__(self, *exc_info: typing.Any) -> None:
+    await self.aclose()
+`)
+		} else {
+			w.WriteString(`
+def close(self) -> None:
+    """close closes the underlying HTTP client, unless it was provided in the options."""
+    self._base.close()
+
+def __enter__(self) -> Client:
+    return self
+
+def __exit__(self, *exc_info: typing.Any) -> None:
+    self.close()
+`)
+		}
+	}
+
+	w.WriteString(`
+
+@dataclasses.dataclass(kw_only=True)
+class ClientOptions:
+    """ClientOptions allows you to override any default behaviour within the generated Encore client."""
+
+    headers: dict[str, str] = dataclasses.field(default_factory=dict)
+    """Headers to send with each request."""
+
+    http_client: typing.Optional[httpx.Client] = None
+    """
+    The HTTP client used by Client to make requests. If not set, the client creates its own,
+    which is closed when the client is closed.
+    """
+
+    async_http_client: typing.Optional[httpx.AsyncClient] = None
+    """
+    The HTTP client used by AsyncClient to make requests. If not set, the client creates its own,
+    which is closed when the client is closed.
+    """
+`)
+
+	if py.hasAuth {
+		w := w.Indent()
+		w.WriteStringf("\nauth: typing.Union[%s, AuthDataGenerator, None] = None\n", py.typ(py.md.AuthHandler.Params))
+		if !py.authIsComplexType {
+			w.WriteString(`"""
+Allows you to set the auth token to be used for each request
+either by passing in a static token string or by passing in a function
+which returns the auth token.
+
+These tokens will be sent as bearer tokens in the Authorization header.
+"""
+`)
+		} else {
+			w.WriteString(`"""
+Allows you to set the authentication data to be used for each
+request either by passing in a static object or by passing in
+a function which returns a new object for each request.
+"""
+`)
+		}
+	}
+}
+
+func (py *python) writeService(svc *meta.Service, tags clientgentypes.TagSet, async bool) error {
+	prefix := ""
+	if async {
+		prefix = "Async"
+	}
+
+	w := py.newIdentWriter(0)
+	w.WriteStringf("\n\nclass %s%sServiceClient:\n", prefix, py.typeName(svc.Name))
+	w = w.Indent()
+	if async {
+		w.WriteStringf("def __init__(self, base: _AsyncBaseClient) -> None:\n")
+	} else {
+		w.WriteStringf("def __init__(self, base: _BaseClient) -> None:\n")
+	}
+	w.Indent().WriteString("self._base = base\n")
+
+	for _, rpc := range svc.Rpcs {
+		if rpc.AccessType == meta.RPC_PRIVATE || !tags.IsRPCIncluded(rpc) {
+			continue
+		}
+		// Streaming, server-sent events and streamed body endpoints are not supported by the Python client yet.
+		if rpc.StreamingRequest || rpc.StreamingResponse || rpc.ServerSentEvents || rpc.StreamedRequestBody || rpc.StreamedResponseBody {
+			continue
+		} else if rpc.MultipartRequest {
+			w.WriteStringf("\n# %s\n", skippedMultipartNote(rpc, "Python"))
+			continue
+		}
+
+		w.WriteString("\n")
+		if async {
+			w.WriteString("async ")
+		}
+		w.WriteStringf("def %s(self", py.memberName(rpc.Name))
+
+		if rpc.Proto == meta.RPC_RAW {
+			w.WriteString(", method: str")
+		}
+
+		var rpcPath strings.Builder
+		hasPathParams := false
+		for _, s := range rpc.Path.Segments {
+			rpcPath.WriteByte('/')
+			if s.Type == meta.PathSegment_LITERAL {
+				rpcPath.WriteString(strings.NewReplacer("{", "{{", "}", "}}").Replace(s.Value))
+				continue
+			}
+
+			hasPathParams = true
+			id := py.nonReservedId(s.Value)
+			var typ string
+			switch s.ValueType {
+			case meta.PathSegment_STRING, meta.PathSegment_UUID:
+				typ = "str"
+			case meta.PathSegment_BOOL:
+				typ = "bool"
+			case meta.PathSegment_INT8, meta.PathSegment_INT16, meta.PathSegment_INT32, meta.PathSegment_INT64, meta.PathSegment_INT,
+				meta.PathSegment_UINT8, meta.PathSegment_UINT16, meta.PathSegment_UINT32, meta.PathSegment_UINT64, meta.PathSegment_UINT:
+				typ = "int"
+			default:
+				panic(fmt.Sprintf("unhandled PathSegment type %s", s.ValueType))
+			}
+			if s.Type == meta.PathSegment_WILDCARD || s.Type == meta.PathSegment_FALLBACK {
+				w.WriteStringf(", %s: list[%s]", id, typ)
+				rpcPath.WriteString("{'/'.join(_quote(v) for v in " + id + ")}")
+			} else {
+				w.WriteStringf(", %s: %s", id, typ)
+				rpcPath.WriteString("{_quote(" + id + ")}")
+			}
+		}
+
+		path := strconv.Quote(rpcPath.String())
+		if hasPathParams {
+			path = "f" + path
+		}
+
+		if rpc.RequestSchema != nil {
+			w.WriteStringf(", params: %s", py.typ(rpc.RequestSchema))
+		} else if rpc.Proto == meta.RPC_RAW {
+			w.WriteString(", body: typing.Optional[bytes] = None, headers: typing.Optional[dict[str, str]] = None, query: typing.Optional[dict[str, typing.Union[str, list[str]]]] = None")
+		}
+
+		w.WriteString(") -> ")
+		switch {
+		case rpc.ResponseSchema != nil:
+			w.WriteString(py.typ(rpc.ResponseSchema))
+		case rpc.Proto == meta.RPC_RAW:
+			w.WriteString("httpx.Response")
+		default:
+			w.WriteString("None")
+		}
+		w.WriteString(":\n")
+
+		body := w.Indent()
+		if rpc.Doc != nil {
+			py.writeDocString(body, *rpc.Doc)
+		}
+		if err := py.rpcCallSite(body, rpc, path, async); err != nil {
+			return errors.Wrapf(err, "unable to write RPC call site for %s.%s", rpc.ServiceName, rpc.Name)
+		}
+	}
+	return nil
+}
+
+func (py *python) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string, async bool) error {
+	await := ""
+	if async {
+		await = "await "
+	}
+
+	// Raw end points just pass through the request
+	// and need no further code generation
+	if rpc.Proto == meta.RPC_RAW {
+		w.WriteStringf("return %sself._base.call_api(method, %s, body, headers=headers, query=query)\n", await, rpcPath)
+		return nil
+	}
+
+	// Work out how we're going to encode and call this RPC
+	rpcEncoding, err := encoding.DescribeRPC(py.md, rpc, &encoding.Options{SrcNameTag: "json"})
+	if err != nil {
+		return errors.Wrapf(err, "rpc %s", rpc.Name)
+	}
+
+	var args []string
+	if rpc.RequestSchema != nil {
+		reqEnc := rpcEncoding.DefaultRequestEncoding
+		w.WriteString("data = _encode(params)\n")
+
+		if len(reqEnc.HeaderParameters) > 0 {
+			args = append(args, "headers=headers")
+			w.WriteString("headers = _make_record(")
+			py.values(w, py.stringParams(reqEnc.HeaderParameters, "data"))
+			w.WriteString(")\n")
+		}
+		if len(reqEnc.QueryParameters) > 0 {
+			args = append(args, "query=query")
+			w.WriteString("query = _make_record(")
+			py.values(w, py.stringParams(reqEnc.QueryParameters, "data"))
+			w.WriteString(")\n")
+		}
+
+		if len(reqEnc.BodyParameters) > 0 {
+			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
+				// In the simple case we can just encode the params as the body directly
+				args = append([]string{"body=data"}, args...)
+			} else {
+				// Else we need to only include the body fields
+				args = append([]string{"body=body"}, args...)
+				dict := make(map[string]string)
+				for _, field := range reqEnc.BodyParameters {
+					dict[field.WireFormat] = py.quote(field.SrcName)
+				}
+				w.WriteString("body = _pick(data, ")
+				py.values(w, dict)
+				w.WriteString(")\n")
+			}
+		}
+	}
+
+	callAPI := fmt.Sprintf("%sself._base.call_typed_api(%q, %s", await, rpcEncoding.DefaultMethod, rpcPath)
+	for _, arg := range args {
+		callAPI += ", " + arg
+	}
+	callAPI += ")"
+
+	// If there's no response schema, we can just make the call
+	if rpc.ResponseSchema == nil {
+		w.WriteStringf("%s\n", callAPI)
+		return nil
+	}
+
+	w.WriteStringf("resp = %s\n", callAPI)
+	respType := py.typ(rpc.ResponseSchema)
+	respEnc := rpcEncoding.ResponseEncoding
+	if len(respEnc.HeaderParameters) == 0 {
+		w.WriteStringf("return _decode(%s, resp.json())\n", respType)
+		return nil
+	}
+
+	// Otherwise, we need to add the header fields to the response
+	py.seenHeaderResponse = true
+	w.WriteString("rtn = resp.json()\n")
+	for _, field := range respEnc.HeaderParameters {
+		value := fmt.Sprintf("_must_be_set(resp, %s)", py.quote(field.WireFormat))
+		w.WriteStringf("rtn[%s] = %s\n", py.quote(field.SrcName), py.convertStringToBuiltin(field.Type.GetBuiltin(), value))
+	}
+	w.WriteStringf("return _decode(%s, rtn)\n", respType)
+	return nil
+}
+
+// stringParams returns the expressions for converting the given
+// header or query parameters of the encoded value to strings.
+func (py *python) stringParams(params []*encoding.ParameterEncoding, data string) map[string]string {
+	dict := make(map[string]string)
+	for _, field := range params {
+		conv := "_to_str"
+		if field.Type.GetBuiltin() == schema.Builtin_JSON {
+			conv = "_json_str"
+		}
+		dict[field.WireFormat] = fmt.Sprintf("%s(%s.get(%s))", conv, data, py.quote(field.SrcName))
+	}
+	return dict
+}
+
+func (py *python) writeBaseClient(appSlug string) error {
+	userAgent := fmt.Sprintf("%s-Generated-Python-Client (Encore/%s)", appSlug, version.Version)
+
+	if py.hasAuth {
+		authType := py.typ(py.md.AuthHandler.Params)
+		fmt.Fprintf(py, `
+
+# AuthDataGenerator is a function that returns a new instance of the authentication data required by this API
+AuthDataGenerator = typing.Callable[
+    [], typing.Union[%s, None, typing.Awaitable[typing.Optional[%s]]]
+]
+
+
+def _auth_params(auth: %s) -> tuple[dict[str, str], dict[str, typing.Any]]:
+    """_auth_params returns the headers and query parameters to send for the authentication data."""
+`, authType, authType, authType)
+
+		w := py.newIdentWriter(1)
+		if py.authIsComplexType {
+			authData, err := encoding.DescribeAuth(py.md, py.md.AuthHandler.Params, &encoding.Options{SrcNameTag: "json"})
+			if err != nil {
+				return errors.Wrap(err, "unable to describe auth data")
+			}
+			w.WriteString("data = _encode(auth)\n")
+			w.WriteString("headers = _make_record(")
+			py.values(w, py.stringParams(authData.HeaderParameters, "data"))
+			w.WriteString(")\n")
+			w.WriteString("query = _make_record(")
+			py.values(w, py.stringParams(authData.QueryParameters, "data"))
+			w.WriteString(")\n")
+			w.WriteString("return headers, query\n")
+		} else {
+			w.WriteString("return {\"Authorization\": \"Bearer \" + auth}, {}\n")
+		}
+	}
+
+	for _, async := range []bool{false, true} {
+		var (
+			className  = "_BaseClient"
+			httpClient = "httpx.Client"
+			optionName = "http_client"
+			def        = "def"
+			await      = ""
+			closeFunc  = "close"
+		)
+		if async {
+			className, httpClient, optionName = "_AsyncBaseClient", "httpx.AsyncClient", "async_http_client"
+			def, await, closeFunc = "async def", "await ", "aclose"
+		}
+
+		w := py.newIdentWriter(0)
+		w.WriteStringf("\n\nclass %s:\n", className)
+		w = w.Indent()
+		w.WriteString("def __init__(self, base_url: str, options: ClientOptions) -> None:\n")
+		{
+			w := w.Indent()
+			w.WriteString("self.base_url = base_url\n")
+			w.WriteStringf("self.headers = {\"User-Agent\": %s, **options.headers}\n", py.quote(userAgent))
+			if py.hasAuth {
+				w.WriteString("self.auth = options.auth\n")
+			}
+			w.WriteStringf("self.owns_http = options.%s is None\n", optionName)
+			w.WriteStringf("self.http = options.%s or %s()\n", optionName, httpClient)
+		}
+
+		w.WriteStringf(`
+%s %s(self) -> None:
+    if self.owns_http:
+        %sself.http.%s()
+`, def, closeFunc, await, closeFunc)
+
+		if py.hasAuth {
+			authType := py.typ(py.md.AuthHandler.Params)
+			w.WriteStringf("\n%s get_auth_data(self) -> typing.Optional[%s]:\n", def, authType)
+			w.WriteString(`    auth = self.auth
+    if callable(auth):
+        auth = auth()
+`)
+			if async {
+				w.WriteString(`        if inspect.isawaitable(auth):
+            auth = await auth
+`)
+			} else {
+				w.WriteString(`        if inspect.isawaitable(auth):
+            raise TypeError("auth data generators returning awaitables require AsyncClient")
+`)
+			}
+			w.WriteString("    return auth\n")
+		}
+
+		w.WriteStringf(`
+%s call_typed_api(
+    self,
+    method: str,
+    path: str,
+    body: typing.Any = None,
+    headers: typing.Optional[dict[str, str]] = None,
+    query: typing.Optional[dict[str, typing.Any]] = None,
+) -> httpx.Response:
+    """call_typed_api makes an API call, encoding the body as JSON."""
+    content = None if body is None else json.dumps(body).encode()
+    headers = {"Content-Type": "application/json", **(headers or {})}
+    return %sself.call_api(method, path, content, headers=headers, query=query)
+
+%s call_api(
+    self,
+    method: str,
+    path: str,
+    body: typing.Optional[bytes] = None,
+    headers: typing.Optional[dict[str, str]] = None,
+    query: typing.Optional[dict[str, typing.Any]] = None,
+) -> httpx.Response:
+    """call_api is used by each generated API method to actually make the request."""
+    headers = {**self.headers, **(headers or {})}
+    query = dict(query or {})
+`, def, await, def)
+
+		if py.hasAuth {
+			w.WriteStringf(`
+    # If we have authentication data, add it to the request
+    auth = %sself.get_auth_data()
+    if auth is not None:
+        auth_headers, auth_query = _auth_params(auth)
+        headers.update(auth_headers)
+        query.update(auth_query)
+`, await)
+		}
+
+		w.WriteStringf(`
+    resp = %sself.http.request(method, self.base_url + path, content=body, headers=headers, params=query)
+    if resp.is_error:
+        raise _api_error(resp)
+    return resp
+`, await)
+	}
+	return nil
+}
+
+func (py *python) writeHelpers() {
+	py.WriteString(`
+
+def _encode(value: typing.Any) -> typing.Any:
+    """_encode converts a value into its JSON representation, omitting unset optional fields."""
+    if dataclasses.is_dataclass(value) and not isinstance(value, type):
+        out = {}
+        for f in dataclasses.fields(value):
+            v = getattr(value, f.name)
+            if v is None and f.default is None:
+                continue
+            out[f.metadata.get("json", f.name)] = _encode(v)
+        return out
+    if isinstance(value, (list, tuple)):
+        return [_encode(v) for v in value]
+    if isinstance(value, dict):
+        return {k: _encode(v) for k, v in value.items()}
+    return value
+
+
+def _decode(tp: typing.Any, value: typing.Any) -> typing.Any:
+    """_decode converts a JSON value into the given type."""
+    if isinstance(tp, typing.ForwardRef):
+        tp = tp.__forward_arg__
+    if isinstance(tp, str):
+        tp = globals()[tp]
+    if tp is typing.Any or value is None:
+        return value
+
+    origin, args = typing.get_origin(tp), typing.get_args(tp)
+    if origin is typing.Union:
+        for arg in args:
+            if _matches(arg, value):
+                return _decode(arg, value)
+        return value
+    if origin is list:
+        return [_decode(args[0], v) for v in value]
+    if origin is dict:
+        return {(int(k) if args[0] is int else k): _decode(args[1], v) for k, v in value.items()}
+
+    cls = origin or tp
+    if dataclasses.is_dataclass(cls):
+        typevars = dict(zip(getattr(cls, "__parameters__", ()), args))
+        hints = typing.get_type_hints(cls)
+        kwargs = {}
+        for f in dataclasses.fields(cls):
+            field_type = _subst(hints[f.name], typevars)
+            name = f.metadata.get("json", f.name)
+            if name in value:
+                kwargs[f.name] = _decode(field_type, value[name])
+            elif f.default is dataclasses.MISSING:
+                kwargs[f.name] = _zero(field_type)
+        return cls(**kwargs)
+    if tp is float and isinstance(value, int):
+        return float(value)
+    return value
+
+
+def _subst(tp: typing.Any, typevars: dict[typing.Any, typing.Any]) -> typing.Any:
+    """_subst replaces the type variables in tp with their values."""
+    if isinstance(tp, typing.TypeVar):
+        return typevars.get(tp, typing.Any)
+    params = getattr(tp, "__parameters__", ())
+    if params and typing.get_origin(tp) is not None:
+        return tp[tuple(typevars.get(p, typing.Any) for p in params)]
+    return tp
+
+
+def _matches(tp: typing.Any, value: typing.Any) -> bool:
+    """_matches reports whether value could be a JSON representation of tp."""
+    if isinstance(tp, (str, typing.ForwardRef)):
+        return _matches(_decode_type(tp), value)
+    if tp is typing.Any:
+        return True
+    if tp is None or tp is type(None):
+        return value is None
+    origin, args = typing.get_origin(tp), typing.get_args(tp)
+    if origin is typing.Literal:
+        return value in args
+    if origin is typing.Union:
+        return any(_matches(arg, value) for arg in args)
+    cls = origin or tp
+    if dataclasses.is_dataclass(cls) or cls is dict:
+        return isinstance(value, dict)
+    if cls is list:
+        return isinstance(value, list)
+    if cls is bool:
+        return isinstance(value, bool)
+    if cls is int:
+        return isinstance(value, int) and not isinstance(value, bool)
+    if cls is float:
+        return isinstance(value, (int, float)) and not isinstance(value, bool)
+    if isinstance(cls, type):
+        return isinstance(value, cls)
+    return True
+
+
+def _decode_type(tp: typing.Any) -> typing.Any:
+    if isinstance(tp, typing.ForwardRef):
+        tp = tp.__forward_arg__
+    return globals()[tp] if isinstance(tp, str) else tp
+
+
+def _zero(tp: typing.Any) -> typing.Any:
+    """_zero returns the value to use for a required field missing from a response."""
+    cls = typing.get_origin(tp) or tp
+    if cls in (list, dict, str, int, float, bool):
+        return cls()
+    return None
+
+
+def _to_str(value: typing.Any) -> typing.Any:
+    """_to_str converts an encoded value to its string form for use in paths, headers and query strings."""
+    if value is None or isinstance(value, str):
+        return value
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    if isinstance(value, (int, float)):
+        return str(value)
+    if isinstance(value, list):
+        return [_to_str(v) for v in value]
+    return json.dumps(value)
+
+
+def _json_str(value: typing.Any) -> typing.Optional[str]:
+    return None if value is None else json.dumps(value)
+
+
+def _quote(value: typing.Any) -> str:
+    return urllib.parse.quote(_to_str(value), safe="")
+
+
+def _make_record(record: dict[str, typing.Any]) -> dict[str, typing.Any]:
+    """_make_record returns the record without any keys set to None."""
+    return {k: v for k, v in record.items() if v is not None}
+
+
+def _pick(data: dict[str, typing.Any], fields: dict[str, str]) -> dict[str, typing.Any]:
+    """_pick returns the fields of data to include, keyed by their name on the wire."""
+    return {wire: data[src] for wire, src in fields.items() if src in data}
+`)
+
+	if py.seenHeaderResponse {
+		py.WriteString(`
+
+def _must_be_set(resp: httpx.Response, header: str) -> str:
+    """_must_be_set returns the value of the response header, raising an APIError with the Data Loss code if it's not set."""
+    value = resp.headers.get(header)
+    if value is None:
+        raise APIError(500, ErrCode.DATA_LOSS, f"Header ` + "`{header}`" + ` was unexpectedly None")
+    return value
+`)
+	}
+}
+
+func (py *python) writeErrorType() {
+	py.WriteString(`
+
+class ErrCode(str, enum.Enum):
+    """ErrCode is the error code of an APIError."""
+
+    OK = "ok"
+    """OK indicates the operation was successful."""
+
+    CANCELED = "canceled"
+    """Canceled indicates the operation was canceled (typically by the caller)."""
+
+    UNKNOWN = "unknown"
+    """Unknown error."""
+
+    INVALID_ARGUMENT = "invalid_argument"
+    """InvalidArgument indicates client specified an invalid argument."""
+
+    DEADLINE_EXCEEDED = "deadline_exceeded"
+    """DeadlineExceeded means operation expired before completion."""
+
+    NOT_FOUND = "not_found"
+    """NotFound means some requested entity (e.g., file or directory) was not found."""
+
+    ALREADY_EXISTS = "already_exists"
+    """AlreadyExists means an attempt to create an entity failed because one already exists."""
+
+    PERMISSION_DENIED = "permission_denied"
+    """PermissionDenied indicates the caller does not have permission to execute the specified operation."""
+
+    RESOURCE_EXHAUSTED = "resource_exhausted"
+    """ResourceExhausted indicates some resource has been exhausted."""
+
+    FAILED_PRECONDITION = "failed_precondition"
+    """FailedPrecondition indicates the system is not in a state required for the operation's execution."""
+
+    ABORTED = "aborted"
+    """Aborted indicates the operation was aborted, typically due to a concurrency issue."""
+
+    OUT_OF_RANGE = "out_of_range"
+    """OutOfRange means operation was attempted past the valid range."""
+
+    UNIMPLEMENTED = "unimplemented"
+    """Unimplemented indicates operation is not implemented or not supported/enabled in this service."""
+
+    INTERNAL = "internal"
+    """Internal errors. Means some invariants expected by underlying system has been broken."""
+
+    UNAVAILABLE = "unavailable"
+    """Unavailable indicates the service is currently unavailable."""
+
+    DATA_LOSS = "data_loss"
+    """DataLoss indicates unrecoverable data loss or corruption."""
+
+    UNAUTHENTICATED = "unauthenticated"
+    """Unauthenticated indicates the request does not have valid authentication credentials for the operation."""
+
+
+class APIError(Exception):
+    """APIError represents a structured error as returned from an Encore application."""
+
+    def __init__(self, status: int, code: ErrCode, message: str, details: typing.Any = None) -> None:
+        super().__init__(message)
+        self.status = status
+        """The HTTP status code associated with the error."""
+        self.code = code
+        """The Encore error code."""
+        self.message = message
+        """The error message."""
+        self.details = details
+        """The error details."""
+
+
+def _api_error(resp: httpx.Response) -> APIError:
+    """_api_error returns the APIError for an error response."""
+    code, message, details = ErrCode.UNKNOWN, f"request failed: status {resp.status_code}", None
+    try:
+        body = resp.json()
+    except ValueError:
+        if resp.text:
+            message += ": " + resp.text
+        return APIError(resp.status_code, code, message)
+
+    codes = {c.value for c in ErrCode}
+    if isinstance(body, dict) and body.get("code") in codes and isinstance(body.get("message"), str):
+        code, message, details = ErrCode(body["code"]), body["message"], body.get("details")
+    else:
+        message += ": " + json.dumps(body)
+    return APIError(resp.status_code, code, message, details)
+`)
+}
+
+func (py *python) declName(decl *schema.Decl) string {
+	name := decl.Name
+	if name != "" {
+		name = strings.ToUpper(name[:1]) + name[1:]
+	}
+	return py.typeName(decl.Loc.PkgName) + name
+}
+
+func (py *python) builtinType(typ schema.Builtin) string {
+	switch typ {
+	case schema.Builtin_ANY:
+		return "typing.Any"
+	case schema.Builtin_BOOL:
+		return "bool"
+	case schema.Builtin_INT, schema.Builtin_INT8, schema.Builtin_INT16, schema.Builtin_INT32, schema.Builtin_INT64,
+		schema.Builtin_UINT, schema.Builtin_UINT8, schema.Builtin_UINT16, schema.Builtin_UINT32, schema.Builtin_UINT64:
+		return "int"
+	case schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		return "float"
+	case schema.Builtin_STRING:
+		return "str"
+	case schema.Builtin_BYTES:
+		return "str" // base64 encoded
+	case schema.Builtin_TIME:
+		return "str" // RFC 3339 encoded
+	case schema.Builtin_JSON:
+		py.seenJSON = true
+		return "JSONValue"
+	case schema.Builtin_UUID:
+		return "str"
+	case schema.Builtin_USER_ID:
+		return "str"
+	case schema.Builtin_FILE:
+		return "bytes"
+	default:
+		py.errorf("unknown builtin type %v", typ)
+		return "typing.Any"
+	}
+}
+
+func (py *python) convertStringToBuiltin(typ schema.Builtin, val string) string {
+	switch typ {
+	case schema.Builtin_BOOL:
+		return fmt.Sprintf("%s.lower() == \"true\"", val)
+	case schema.Builtin_INT, schema.Builtin_INT8, schema.Builtin_INT16, schema.Builtin_INT32, schema.Builtin_INT64,
+		schema.Builtin_UINT, schema.Builtin_UINT8, schema.Builtin_UINT16, schema.Builtin_UINT32, schema.Builtin_UINT64:
+		return fmt.Sprintf("int(%s)", val)
+	case schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		return fmt.Sprintf("float(%s)", val)
+	case schema.Builtin_JSON:
+		py.seenJSON = true
+		return fmt.Sprintf("json.loads(%s)", val)
+	default:
+		return val
+	}
+}
+
+// typ returns the Python type annotation for typ.
+func (py *python) typ(typ *schema.Type) string {
+	switch typ := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl := py.md.Decls[typ.Named.Id]
+		name := py.declName(decl)
+		if len(typ.Named.TypeArguments) == 0 {
+			// Within type aliases, reference other aliases by name
+			// as they may not have been defined yet.
+			if py.inAlias && decl.Type.GetStruct() == nil {
+				return py.quote(name)
+			}
+			return name
+		}
+		if py.inAlias && decl.Type.GetStruct() == nil {
+			py.aliasDeps = append(py.aliasDeps, decl.Id)
+		}
+
+		args := make([]string, len(typ.Named.TypeArguments))
+		for i, arg := range typ.Named.TypeArguments {
+			args[i] = py.typ(arg)
+		}
+		return name + "[" + strings.Join(args, ", ") + "]"
+
+	case *schema.Type_List:
+		return "list[" + py.typ(typ.List.Elem) + "]"
+
+	case *schema.Type_Map:
+		return "dict[" + py.typ(typ.Map.Key) + ", " + py.typ(typ.Map.Value) + "]"
+
+	case *schema.Type_Builtin:
+		return py.builtinType(typ.Builtin)
+
+	case *schema.Type_Pointer:
+		return py.typ(typ.Pointer.Base)
+
+	case *schema.Type_Literal:
+		switch lit := typ.Literal.Value.(type) {
+		case *schema.Literal_Str:
+			return "typing.Literal[" + py.quote(lit.Str) + "]"
+		case *schema.Literal_Int:
+			return "typing.Literal[" + strconv.FormatInt(lit.Int, 10) + "]"
+		case *schema.Literal_Float:
+			// Python doesn't support float literal types.
+			return "float"
+		case *schema.Literal_Boolean:
+			if lit.Boolean {
+				return "typing.Literal[True]"
+			}
+			return "typing.Literal[False]"
+		case *schema.Literal_Null:
+			return "None"
+		default:
+			py.errorf("unknown literal type %T", lit)
+			return ""
+		}
+
+	case *schema.Type_Union:
+		types := make([]string, len(typ.Union.Types))
+		for i, t := range typ.Union.Types {
+			types[i] = py.typ(t)
+		}
+		return "typing.Union[" + strings.Join(types, ", ") + "]"
+
+	case *schema.Type_Struct:
+		// Anonymous structs have no name to generate a dataclass for.
+		return "dict[str, typing.Any]"
+
+	case *schema.Type_TypeParameter:
+		decl := py.md.Decls[typ.TypeParameter.DeclId]
+		return decl.TypeParams[typ.TypeParameter.ParamIdx].Name
+
+	case *schema.Type_Config:
+		// Config type is transparent
+		return py.typ(typ.Config.Elem)
+
+	default:
+		py.errorf("unknown type %+v", reflect.TypeOf(typ))
+		return ""
+	}
+}
+
+func (py *python) errorf(format string, args ...interface{}) {
+	panic(bailout{fmt.Errorf(format, args...)})
+}
+
+func (py *python) handleBailout(dst *error) {
+	if err := recover(); err != nil {
+		if bail, ok := err.(bailout); ok {
+			*dst = bail.err
+		} else {
+			panic(err)
+		}
+	}
+}
+
+func (py *python) newIdentWriter(indent int) *indentWriter {
+	return &indentWriter{
+		w:                py.Buffer,
+		depth:            indent,
+		indent:           "    ",
+		firstWriteOnLine: true,
+	}
+}
+
+// writeDocString writes doc as a docstring.
+func (py *python) writeDocString(w *indentWriter, doc string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+	doc = strings.ReplaceAll(doc, `\`, `\\`)
+	doc = strings.ReplaceAll(doc, `"""`, `\"\"\"`)
+	if strings.HasSuffix(doc, `"`) {
+		doc += " "
+	}
+
+	if !strings.Contains(doc, "\n") {
+		w.WriteString(`"""` + doc + `"""` + "\n")
+		return
+	}
+	w.WriteString(`"""` + "\n")
+	for _, line := range strings.Split(doc, "\n") {
+		w.WriteString(strings.TrimSpace(line) + "\n")
+	}
+	w.WriteString(`"""` + "\n")
+}
+
+func (py *python) quote(s string) string {
+	return strconv.Quote(s)
+}
+
+func (py *python) values(w *indentWriter, dict map[string]string) {
+	keys := make([]string, 0, len(dict))
+	for key := range dict {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if len(keys) == 0 {
+		w.WriteString("{}")
+		return
+	}
+	w.WriteString("{\n")
+	{
+		w := w.Indent()
+		for _, key := range keys {
+			w.WriteStringf("%s: %s,\n", py.quote(key), dict[key])
+		}
+	}
+	w.WriteString("}")
+}
+
+func (py *python) typeName(identifier string) string {
+	return idents.Convert(identifier, idents.PascalCase)
+}
+
+func (py *python) memberName(identifier string) string {
+	return py.escapeKeyword(idents.Convert(identifier, idents.SnakeCase))
+}
+
+// fieldName returns the Python attribute name for the struct field with the given JSON name.
+func (py *python) fieldName(jsonName string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, idents.Convert(jsonName, idents.SnakeCase))
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+
+	// Fields with default values become class attributes, which take precedence
+	// over module level names when resolving the type hints of the dataclass.
+	switch name {
+	case "bool", "bytes", "dict", "float", "int", "list", "str",
+		"dataclasses", "enum", "inspect", "json", "typing", "urllib", "httpx":
+		return name + "_"
+	}
+	return py.escapeKeyword(name)
+}
+
+func (py *python) fieldNameInStruct(field *schema.Field) string {
+	name := field.Name
+	if field.JsonName != "" {
+		name = field.JsonName
+	}
+	return name
+}
+
+// nonReservedId returns the given ID, unless we have it a reserved within the client function _or_ it's a reserved Python keyword
+func (py *python) nonReservedId(id string) string {
+	switch id {
+	// our reserved identifiers (or ID's we use within the generated client functions)
+	case "self", "method", "params", "data", "headers", "query", "body", "resp", "rtn":
+		return "_" + id
+	default:
+		return py.escapeKeyword(id)
+	}
+}
+
+// escapeKeyword returns the given ID, unless it's a Python keyword.
+func (py *python) escapeKeyword(id string) string {
+	switch id {
+	case "False", "None", "True", "and", "as", "assert", "async", "await", "break", "class", "continue", "def",
+		"del", "elif", "else", "except", "finally", "for", "from", "global", "if", "import", "in", "is", "lambda",
+		"nonlocal", "not", "or", "pass", "raise", "return", "try", "while", "with", "yield":
+		return id + "_"
+	default:
+		return id
+	}
+}
+
+func (py *python) isRecursive(typ *schema.Type) bool {
+	// Treat recursively seen types as if they are optional
+	recursiveType := false
+	if n := typ.GetNamed(); n != nil {
+		recursiveType = py.typs.IsRecursiveRef(py.currDecl.Id, n.Id)
+	}
+	return recursiveType
+}
@@ -0,0 +1,1189 @@
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/internal/clientgen/clientgentypes"
+	"encr.dev/internal/version"
+	"encr.dev/parser/encoding"
+	"encr.dev/pkg/idents"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+/* The Rust generator generates code that looks like this:
+pub mod task {
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct AddParams {
+        pub description: String,
+    }
+
+    pub struct ServiceClient {
+        base: std::sync::Arc<super::BaseClient>,
+    }
+
+    impl ServiceClient {
+        pub async fn add(&self, params: &AddParams) -> Result<AddResponse, super::Error> {
+            // ...
+        }
+    }
+}
+
+*/
+
+// rustGenVersion allows us to introduce breaking changes in the generated code but behind a switch
+// meaning that people with client code reliant on the old behaviour can continue to generate the
+// old code.
+type rustGenVersion int
+
+const (
+	// RustInitial is the originally released rust generator
+	RustInitial rustGenVersion = iota
+
+	// RustExperimental can be used to lock experimental or uncompleted features in the generated code
+	// It should always be the last item in the enum
+	RustExperimental
+)
+
+const rustGenLatestVersion = RustExperimental - 1
+
+type rust struct {
+	*bytes.Buffer
+	md               *meta.Data
+	appSlug          string
+	typs             *typeRegistry
+	currDecl         *schema.Decl
+	generatorVersion rustGenVersion
+
+	hasAuth bool // true if we've seen an authentication handler
+}
+
+func (rs *rust) Version() int {
+	return int(rs.generatorVersion)
+}
+
+func (rs *rust) Generate(p clientgentypes.GenerateParams) (err error) {
+	defer rs.handleBailout(&err)
+
+	rs.Buffer = p.Buf
+	rs.md = p.Meta
+	rs.appSlug = p.AppSlug
+	rs.typs = getNamedTypes(p.Meta, p.Services)
+	rs.hasAuth = rs.md.AuthHandler != nil
+
+	rs.WriteString("// " + doNotEditHeader() + "\n\n")
+	rs.WriteString("// Disable lints for this file.\n")
+	rs.WriteString("#![allow(clippy::all, dead_code, unused_imports)]\n")
+
+	rs.writeClient(p.Services)
+
+	seenNs := make(map[string]bool)
+	for _, svc := range p.Meta.Svcs {
+		if err := rs.writeService(svc, p.Services, p.Tags); err != nil {
+			return err
+		}
+		seenNs[svc.Name] = true
+	}
+	for _, ns := range rs.typs.Namespaces() {
+		if !seenNs[ns] {
+			rs.writeNamespace(ns, false)
+		}
+	}
+
+	if err := rs.writeBaseClient(p.AppSlug); err != nil {
+		return err
+	}
+	rs.writeHelpers()
+	rs.writeErrorType()
+	return nil
+}
+
+func (rs *rust) writeClient(set clientgentypes.ServiceSet) {
+	w := rs.newIdentWriter(0)
+	w.WriteString(`
+/// BaseURL is the base URL for calling the Encore application's API.
+pub type BaseURL = String;
+
+/// LOCAL is the BaseURL of the locally running application.
+pub const LOCAL: &str = "http://localhost:4000";
+
+/// environment returns a BaseURL for calling the cloud environment with the given name.
+pub fn environment(name: &str) -> BaseURL {
+    format!("https://{}-` + rs.appSlug + `.encr.app", name)
+}
+
+/// preview_env returns a BaseURL for calling the preview environment with the given PR number.
+pub fn preview_env(pr: impl std::fmt::Display) -> BaseURL {
+    environment(&format!("pr{}", pr))
+}
+
+/// Client is an API client for the ` + rs.appSlug + ` Encore application.
+pub struct Client {
+`)
+	for _, svc := range rs.md.Svcs {
+		if hasPublicRPC(svc) && set.Has(svc.Name) {
+			w.Indent().WriteStringf("pub %s: %s::ServiceClient,\n", rs.moduleName(svc.Name), rs.moduleName(svc.Name))
+		}
+	}
+	w.WriteString(`}
+
+impl Client {
+    /// Creates a Client for calling the public and authenticated APIs of your Encore application.
+    ///
+    /// target is the base URL the client should be configured to use. See LOCAL and environment for options.
+    pub fn new(target: impl Into<BaseURL>, options: ClientOptions) -> Self {
+        let base = std::sync::Arc::new(BaseClient::new(target.into(), options));
+        Client {
+`)
+	for _, svc := range rs.md.Svcs {
+		if hasPublicRPC(svc) && set.Has(svc.Name) {
+			w.Indent().Indent().Indent().WriteStringf("%s: %s::ServiceClient::new(base.clone()),\n", rs.moduleName(svc.Name), rs.moduleName(svc.Name))
+		}
+	}
+	w.WriteString(`        }
+    }
+}
+`)
+
+	if rs.hasAuth {
+		w.WriteString("\n/// AuthDataGenerator returns the authentication data to use for each request.\n")
+		w.WriteStringf("pub type AuthDataGenerator = std::sync::Arc<dyn Fn() -> Option<%s> + Send + Sync>;\n", rs.typ("", rs.md.AuthHandler.Params))
+	}
+
+	w.WriteString(`
+/// ClientOptions allows you to override any default behaviour within the generated Encore client.
+#[derive(Clone, Default)]
+pub struct ClientOptions {
+    /// The HTTP client used to make requests. If not set, a new client is created.
+    pub http_client: Option<reqwest::Client>,
+
+    /// Headers to send with each request.
+    pub headers: Vec<(String, String)>,
+`)
+	if rs.hasAuth {
+		w.WriteString(`
+    /// Generates the authentication data to send with each request.
+    pub auth: Option<AuthDataGenerator>,
+`)
+	}
+	w.WriteString(`}
+
+impl ClientOptions {
+    /// with_http_client sets the HTTP client used to make requests.
+    pub fn with_http_client(mut self, client: reqwest::Client) -> Self {
+        self.http_client = Some(client);
+        self
+    }
+
+    /// with_header adds a header to send with each request.
+    pub fn with_header(mut self, name: impl Into<String>, value: impl Into<String>) -> Self {
+        self.headers.push((name.into(), value.into()));
+        self
+    }
+`)
+	if rs.hasAuth {
+		authType := rs.typ("", rs.md.AuthHandler.Params)
+		if rs.md.AuthHandler.Params.GetBuiltin() == schema.Builtin_STRING {
+			w.WriteString(`
+    /// with_auth sets the auth token to be sent as a bearer token in the Authorization header of each request.
+`)
+		} else {
+			w.WriteString(`
+    /// with_auth sets the authentication data to send with each request.
+`)
+		}
+		w.WriteStringf(`    pub fn with_auth(mut self, auth: %s) -> Self {
+        self.auth = Some(std::sync::Arc::new(move || Some(auth.clone())));
+        self
+    }
+
+    /// with_auth_generator sets a function which is called before each request
+    /// to generate the authentication data to send with it.
+    pub fn with_auth_generator(mut self, generator: impl Fn() -> Option<%s> + Send + Sync + 'static) -> Self {
+        self.auth = Some(std::sync::Arc::new(generator));
+        self
+    }
+`, authType, authType)
+	}
+	w.WriteString("}\n")
+}
+
+func (rs *rust) writeService(svc *meta.Service, set clientgentypes.ServiceSet, tags clientgentypes.TagSet) error {
+	// Determine if we have anything worth exposing.
+	// Either a public RPC or a named type.
+	isIncluded := hasPublicRPC(svc) && set.Has(svc.Name)
+	if !isIncluded {
+		rs.writeNamespace(svc.Name, false)
+		return nil
+	}
+
+	rs.writeNamespace(svc.Name, true)
+	ns := svc.Name
+	w := rs.newIdentWriter(1)
+	w.WriteString(`
+pub struct ServiceClient {
+    base: std::sync::Arc<super::BaseClient>,
+}
+
+impl ServiceClient {
+    pub(super) fn new(base: std::sync::Arc<super::BaseClient>) -> Self {
+        ServiceClient { base }
+    }
+`)
+
+	w = w.Indent()
+	for _, rpc := range svc.Rpcs {
+		if rpc.AccessType == meta.RPC_PRIVATE || !tags.IsRPCIncluded(rpc) {
+			continue
+		}
+		// Streaming, server-sent events and streamed body endpoints are not supported by the Rust client yet.
+		if rpc.StreamingRequest || rpc.StreamingResponse || rpc.ServerSentEvents || rpc.StreamedRequestBody || rpc.StreamedResponseBody {
+			continue
+		} else if rpc.MultipartRequest {
+			w.WriteStringf("\n// %s\n", skippedMultipartNote(rpc, "Rust"))
+			continue
+		}
+
+		w.WriteString("\n")
+		if rpc.Doc != nil {
+			rs.writeDocComment(w, *rpc.Doc)
+		}
+		w.WriteStringf("pub async fn %s(&self", rs.memberName(rpc.Name))
+		if rpc.Proto == meta.RPC_RAW {
+			w.WriteString(", method: &str")
+		}
+
+		var (
+			pathFmt  strings.Builder
+			pathArgs []string
+		)
+		for _, s := range rpc.Path.Segments {
+			pathFmt.WriteByte('/')
+			if s.Type == meta.PathSegment_LITERAL {
+				pathFmt.WriteString(strings.NewReplacer("{", "{{", "}", "}}").Replace(s.Value))
+				continue
+			}
+
+			id := rs.nonReservedId(s.Value)
+			var typ string
+			switch s.ValueType {
+			case meta.PathSegment_STRING, meta.PathSegment_UUID:
+				typ = "str"
+			case meta.PathSegment_BOOL:
+				typ = "bool"
+			case meta.PathSegment_INT8:
+				typ = "i8"
+			case meta.PathSegment_INT16:
+				typ = "i16"
+			case meta.PathSegment_INT32:
+				typ = "i32"
+			case meta.PathSegment_INT64, meta.PathSegment_INT:
+				typ = "i64"
+			case meta.PathSegment_UINT8:
+				typ = "u8"
+			case meta.PathSegment_UINT16:
+				typ = "u16"
+			case meta.PathSegment_UINT32:
+				typ = "u32"
+			case meta.PathSegment_UINT64, meta.PathSegment_UINT:
+				typ = "u64"
+			default:
+				panic(fmt.Sprintf("unhandled PathSegment type %s", s.ValueType))
+			}
+
+			pathFmt.WriteString("{}")
+			if s.Type == meta.PathSegment_WILDCARD || s.Type == meta.PathSegment_FALLBACK {
+				if typ == "str" {
+					typ = "String"
+				}
+				w.WriteStringf(", %s: &[%s]", id, typ)
+				pathArgs = append(pathArgs, fmt.Sprintf("%s.iter().map(super::encode_path).collect::<Vec<_>>().join(\"/\")", id))
+			} else {
+				if typ == "str" {
+					typ = "&str"
+				}
+				w.WriteStringf(", %s: %s", id, typ)
+				pathArgs = append(pathArgs, fmt.Sprintf("super::encode_path(%s)", id))
+			}
+		}
+
+		path := strconv.Quote(pathFmt.String())
+		if len(pathArgs) > 0 {
+			path = fmt.Sprintf("&format!(%s, %s)", path, strings.Join(pathArgs, ", "))
+		}
+
+		if rpc.RequestSchema != nil {
+			w.WriteStringf(", params: &%s", rs.typ(ns, rpc.RequestSchema))
+		} else if rpc.Proto == meta.RPC_RAW {
+			w.WriteString(", body: Option<Vec<u8>>, headers: Vec<(String, String)>, query: Vec<(String, String)>")
+		}
+
+		w.WriteString(") -> Result<")
+		switch {
+		case rpc.ResponseSchema != nil:
+			w.WriteString(rs.typ(ns, rpc.ResponseSchema))
+		case rpc.Proto == meta.RPC_RAW:
+			w.WriteString("reqwest::Response")
+		default:
+			w.WriteString("()")
+		}
+		w.WriteString(", super::Error> {\n")
+
+		if err := rs.rpcCallSite(w.Indent(), rpc, path); err != nil {
+			return errors.Wrapf(err, "unable to write RPC call site for %s.%s", rpc.ServiceName, rpc.Name)
+		}
+		w.WriteString("}\n")
+	}
+
+	rs.WriteString("    }\n}\n")
+	return nil
+}
+
+func (rs *rust) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string) error {
+	// Raw end points just pass through the request
+	// and need no further code generation
+	if rpc.Proto == meta.RPC_RAW {
+		w.WriteStringf("self.base.call_api(method, %s, body, headers, query).await\n", rpcPath)
+		return nil
+	}
+
+	// Work out how we're going to encode and call this RPC
+	rpcEncoding, err := encoding.DescribeRPC(rs.md, rpc, &encoding.Options{SrcNameTag: "json"})
+	if err != nil {
+		return errors.Wrapf(err, "rpc %s", rpc.Name)
+	}
+
+	body, headers, query := "None", "Vec::new()", "Vec::new()"
+	if rpc.RequestSchema != nil {
+		reqEnc := rpcEncoding.DefaultRequestEncoding
+		w.WriteString("let data = serde_json::to_value(params)?;\n")
+
+		if len(reqEnc.HeaderParameters) > 0 {
+			headers = "headers"
+			w.WriteString("let headers = super::make_params(&data, &")
+			rs.params(w, reqEnc.HeaderParameters)
+			w.WriteString(");\n")
+		}
+		if len(reqEnc.QueryParameters) > 0 {
+			query = "query"
+			w.WriteString("let query = super::make_params(&data, &")
+			rs.params(w, reqEnc.QueryParameters)
+			w.WriteString(");\n")
+		}
+
+		if len(reqEnc.BodyParameters) > 0 {
+			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
+				// In the simple case we can just encode the params as the body directly
+				body = "Some(data)"
+			} else {
+				// Else we need to only include the body fields
+				body = "Some(body)"
+				fields := make([]string, 0, len(reqEnc.BodyParameters))
+				for _, field := range reqEnc.BodyParameters {
+					fields = append(fields, fmt.Sprintf("(%s, %s)", strconv.Quote(field.WireFormat), strconv.Quote(field.SrcName)))
+				}
+				sort.Strings(fields)
+				w.WriteString("let body = super::pick(&data, &[\n")
+				for _, f := range fields {
+					w.Indent().WriteString(f + ",\n")
+				}
+				w.WriteString("]);\n")
+			}
+		}
+	}
+
+	callAPI := fmt.Sprintf("self.base.call_typed_api(%s, %s, %s, %s, %s).await?",
+		strconv.Quote(rpcEncoding.DefaultMethod), rpcPath, body, headers, query)
+
+	// If there's no response schema, we can just make the call
+	if rpc.ResponseSchema == nil {
+		w.WriteStringf("%s;\n", callAPI)
+		w.WriteString("Ok(())\n")
+		return nil
+	}
+
+	w.WriteStringf("let resp = %s;\n", callAPI)
+	respEnc := rpcEncoding.ResponseEncoding
+	if len(respEnc.HeaderParameters) == 0 {
+		w.WriteString("Ok(serde_json::from_slice(&resp.bytes().await?)?)\n")
+		return nil
+	}
+
+	// Otherwise, we need to add the header fields to the response
+	w.WriteString("let resp_headers = resp.headers().clone();\n")
+	w.WriteString("let mut rtn: serde_json::Value = serde_json::from_slice(&resp.bytes().await?)?;\n")
+	for _, field := range respEnc.HeaderParameters {
+		w.WriteStringf("rtn[%s] = %s;\n", strconv.Quote(field.SrcName), rs.headerValue(field.Type.GetBuiltin(), field.WireFormat))
+	}
+	w.WriteString("Ok(serde_json::from_value(rtn)?)\n")
+	return nil
+}
+
+// params writes the (wire name, field name) pairs of the given parameters.
+func (rs *rust) params(w *indentWriter, params []*encoding.ParameterEncoding) {
+	fields := make([]string, 0, len(params))
+	for _, field := range params {
+		isJSON := field.Type.GetBuiltin() == schema.Builtin_JSON
+		fields = append(fields, fmt.Sprintf("(%s, %s, %t)", strconv.Quote(field.WireFormat), strconv.Quote(field.SrcName), isJSON))
+	}
+	sort.Strings(fields)
+	if len(fields) == 0 {
+		w.WriteString("[]")
+		return
+	}
+	w.WriteString("[\n")
+	for _, f := range fields {
+		w.Indent().WriteString(f + ",\n")
+	}
+	w.WriteString("]")
+}
+
+// headerValue returns the expression converting the response header to a JSON value.
+func (rs *rust) headerValue(typ schema.Builtin, header string) string {
+	value := fmt.Sprintf("super::must_be_set(&resp_headers, %s)?", strconv.Quote(header))
+	switch typ {
+	case schema.Builtin_BOOL:
+		return fmt.Sprintf("serde_json::Value::Bool(%s.eq_ignore_ascii_case(\"true\"))", value)
+	case schema.Builtin_INT, schema.Builtin_INT8, schema.Builtin_INT16, schema.Builtin_INT32, schema.Builtin_INT64:
+		return fmt.Sprintf("super::parse_header::<i64>(&resp_headers, %s)?.into()", strconv.Quote(header))
+	case schema.Builtin_UINT, schema.Builtin_UINT8, schema.Builtin_UINT16, schema.Builtin_UINT32, schema.Builtin_UINT64:
+		return fmt.Sprintf("super::parse_header::<u64>(&resp_headers, %s)?.into()", strconv.Quote(header))
+	case schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		return fmt.Sprintf("super::parse_header::<f64>(&resp_headers, %s)?.into()", strconv.Quote(header))
+	case schema.Builtin_JSON, schema.Builtin_ANY:
+		return fmt.Sprintf("serde_json::from_str(&%s)?", value)
+	default:
+		return fmt.Sprintf("serde_json::Value::String(%s)", value)
+	}
+}
+
+// writeNamespace writes the module for the namespace with its type declarations.
+// If open is true the module is left open for the service client to be written.
+func (rs *rust) writeNamespace(ns string, open bool) {
+	decls := rs.typs.Decls(ns)
+	if len(decls) == 0 && !open {
+		return
+	}
+
+	fmt.Fprintf(rs, "\npub mod %s {\n", rs.moduleName(ns))
+	sort.Slice(decls, func(i, j int) bool {
+		return decls[i].Name < decls[j].Name
+	})
+	for i, d := range decls {
+		if i > 0 {
+			rs.WriteString("\n")
+		}
+		rs.writeDeclDef(ns, d)
+	}
+	if !open {
+		rs.WriteString("}\n")
+	}
+}
+
+func (rs *rust) writeDeclDef(ns string, decl *schema.Decl) {
+	w := rs.newIdentWriter(1)
+	rs.writeDocComment(w, decl.Doc)
+	rs.currDecl = decl
+
+	var typeParams string
+	if len(decl.TypeParams) > 0 {
+		names := make([]string, len(decl.TypeParams))
+		for i, tp := range decl.TypeParams {
+			names[i] = tp.Name
+		}
+		typeParams = "<" + strings.Join(names, ", ") + ">"
+	}
+
+	st := decl.Type.GetStruct()
+	if st == nil {
+		w.WriteStringf("pub type %s%s = %s;\n", rs.typeName(decl.Name), typeParams, rs.typ(ns, decl.Type))
+		return
+	}
+
+	w.WriteString("#[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]\n")
+	w.WriteStringf("pub struct %s%s {\n", rs.typeName(decl.Name), typeParams)
+	fw := w.Indent()
+
+	fields := make([]*schema.Field, 0, len(st.Fields))
+	for _, f := range st.Fields {
+		if !encoding.IgnoreField(f) {
+			fields = append(fields, f)
+		}
+	}
+	prevMultiline := false
+	for i, field := range fields {
+		jsonName := rs.fieldNameInStruct(field)
+		name := rs.fieldName(jsonName)
+		typ := rs.typ(ns, field.Typ)
+
+		var attrs []string
+		if name != jsonName {
+			attrs = append(attrs, "rename = "+strconv.Quote(jsonName))
+		}
+		if rs.isRecursive(field.Typ) {
+			typ = "Box<" + typ + ">"
+		}
+		if field.Optional || rs.isRecursive(field.Typ) {
+			typ = "Option<" + typ + ">"
+			attrs = append(attrs, "default", `skip_serializing_if = "Option::is_none"`)
+		} else if rs.isOmitEmpty(field) {
+			// The field is left out of responses when it has the zero value.
+			attrs = append(attrs, "default")
+		}
+
+		// Separate fields with blank lines if they have docs or attributes.
+		multiline := strings.TrimSpace(field.Doc) != "" || len(attrs) > 0
+		if i > 0 && (multiline || prevMultiline) {
+			fw.WriteString("\n")
+		}
+		prevMultiline = multiline
+
+		rs.writeDocComment(fw, field.Doc)
+		if len(attrs) > 0 {
+			fw.WriteStringf("#[serde(%s)]\n", strings.Join(attrs, ", "))
+		}
+		fw.WriteStringf("pub %s: %s,\n", name, typ)
+	}
+	w.WriteString("}\n")
+}
+
+func (rs *rust) writeBaseClient(appSlug string) error {
+	userAgent := fmt.Sprintf("%s-Generated-Rust-Client (Encore/%s)", appSlug, version.Version)
+
+	var authData *encoding.AuthEncoding
+	if rs.hasAuth && rs.md.AuthHandler.Params.GetBuiltin() != schema.Builtin_STRING {
+		var err error
+		authData, err = encoding.DescribeAuth(rs.md, rs.md.AuthHandler.Params, &encoding.Options{SrcNameTag: "json"})
+		if err != nil {
+			return errors.Wrap(err, "unable to describe auth data")
+		}
+	}
+
+	w := rs.newIdentWriter(0)
+	w.WriteString(`
+struct BaseClient {
+    base_url: String,
+    http: reqwest::Client,
+    headers: Vec<(String, String)>,
+`)
+	if rs.hasAuth {
+		w.WriteString("    auth: Option<AuthDataGenerator>,\n")
+	}
+	w.WriteStringf(`}
+
+impl BaseClient {
+    fn new(base_url: String, options: ClientOptions) -> Self {
+        let mut headers = vec![("User-Agent".to_string(), %s.to_string())];
+        headers.extend(options.headers);
+        BaseClient {
+            base_url,
+            http: options.http_client.unwrap_or_default(),
+            headers,
+`, strconv.Quote(userAgent))
+	if rs.hasAuth {
+		w.WriteString("            auth: options.auth,\n")
+	}
+	// Only the authentication data needs to modify the headers and query string.
+	mutHeaders, mutQuery := "", ""
+	if rs.hasAuth {
+		mutHeaders = "mut "
+		if authData != nil && len(authData.QueryParameters) > 0 {
+			mutQuery = "mut "
+		}
+	}
+	w.WriteStringf(`        }
+    }
+
+    /// call_typed_api makes an API call, encoding the body as JSON.
+    async fn call_typed_api(
+        &self,
+        method: &str,
+        path: &str,
+        body: Option<serde_json::Value>,
+        mut headers: Vec<(String, String)>,
+        query: Vec<(String, String)>,
+    ) -> Result<reqwest::Response, Error> {
+        let body = body.map(|b| serde_json::to_vec(&b)).transpose()?;
+        headers.push(("Content-Type".to_string(), "application/json".to_string()));
+        self.call_api(method, path, body, headers, query).await
+    }
+
+    /// call_api is used by each generated API method to actually make the request.
+    async fn call_api(
+        &self,
+        method: &str,
+        path: &str,
+        body: Option<Vec<u8>>,
+        headers: Vec<(String, String)>,
+        %squery: Vec<(String, String)>,
+    ) -> Result<reqwest::Response, Error> {
+        let method = reqwest::Method::from_bytes(method.as_bytes())
+            .map_err(|_| Error::InvalidRequest(format!("invalid HTTP method {:?}", method)))?;
+
+        let %sheaders: Vec<(String, String)> = self.headers.iter().cloned().chain(headers).collect();
+`, mutQuery, mutHeaders)
+
+	if rs.hasAuth {
+		w.WriteString(`
+        // If we have authentication data, add it to the request
+        if let Some(auth) = self.auth.as_ref().and_then(|generate| generate()) {
+`)
+		if authData == nil {
+			w.WriteString("            headers.push((\"Authorization\".to_string(), format!(\"Bearer {}\", auth)));\n")
+		} else {
+			aw := rs.newIdentWriter(3)
+			aw.WriteString("let data = serde_json::to_value(&auth)?;\n")
+			if len(authData.HeaderParameters) > 0 {
+				aw.WriteString("headers.extend(make_params(&data, &")
+				rs.params(aw, authData.HeaderParameters)
+				aw.WriteString("));\n")
+			}
+			if len(authData.QueryParameters) > 0 {
+				aw.WriteString("query.extend(make_params(&data, &")
+				rs.params(aw, authData.QueryParameters)
+				aw.WriteString("));\n")
+			}
+		}
+		w.WriteString("        }\n")
+	}
+
+	w.WriteString(`
+        let mut req = self.http.request(method, format!("{}{}", self.base_url, path));
+        for (name, value) in &headers {
+            req = req.header(name.as_str(), value.as_str());
+        }
+        if !query.is_empty() {
+            req = req.query(&query);
+        }
+        if let Some(body) = body {
+            req = req.body(body);
+        }
+
+        let resp = req.send().await?;
+        if !resp.status().is_success() {
+            return Err(Error::Api(APIError::from_response(resp).await));
+        }
+        Ok(resp)
+    }
+}
+`)
+	return nil
+}
+
+func (rs *rust) writeHelpers() {
+	rs.WriteString(`
+/// make_params returns the (name, value) pairs for the given (name, field, is_json) parameters,
+/// skipping any fields that are not set.
+fn make_params(data: &serde_json::Value, params: &[(&str, &str, bool)]) -> Vec<(String, String)> {
+    let mut out = Vec::new();
+    for (name, field, is_json) in params {
+        match &data[*field] {
+            serde_json::Value::Null => {}
+            serde_json::Value::Array(values) if !is_json => {
+                for v in values {
+                    out.push((name.to_string(), param_string(v)));
+                }
+            }
+            v if *is_json => out.push((name.to_string(), v.to_string())),
+            v => out.push((name.to_string(), param_string(v))),
+        }
+    }
+    out
+}
+
+/// param_string converts a JSON value to its string form for use in paths, headers and query strings.
+fn param_string(value: &serde_json::Value) -> String {
+    match value {
+        serde_json::Value::String(s) => s.clone(),
+        v => v.to_string(),
+    }
+}
+
+/// pick returns the fields of data to include in the request body, keyed by their name on the wire.
+fn pick(data: &serde_json::Value, fields: &[(&str, &str)]) -> serde_json::Value {
+    let mut out = serde_json::Map::new();
+    for (name, field) in fields {
+        if let Some(v) = data.get(*field) {
+            out.insert(name.to_string(), v.clone());
+        }
+    }
+    serde_json::Value::Object(out)
+}
+
+/// encode_path percent-encodes a path segment.
+fn encode_path(value: impl std::fmt::Display) -> String {
+    let mut out = String::new();
+    for b in value.to_string().bytes() {
+        match b {
+            b'A'..=b'Z' | b'a'..=b'z' | b'0'..=b'9' | b'-' | b'_' | b'.' | b'~' => out.push(b as char),
+            _ => out.push_str(&format!("%{:02X}", b)),
+        }
+    }
+    out
+}
+
+/// must_be_set returns the value of the response header, or an error with the DataLoss code if it's not set.
+fn must_be_set(headers: &reqwest::header::HeaderMap, name: &str) -> Result<String, Error> {
+    match headers.get(name).and_then(|v| v.to_str().ok()) {
+        Some(v) => Ok(v.to_string()),
+        None => Err(Error::Api(APIError {
+            status: 500,
+            code: ErrCode::DataLoss,
+            message: format!("Header ` + "`{}`" + ` was unexpectedly not set", name),
+            details: None,
+        })),
+    }
+}
+
+/// parse_header parses the value of the response header.
+fn parse_header<T: std::str::FromStr>(headers: &reqwest::header::HeaderMap, name: &str) -> Result<T, Error> {
+    let value = must_be_set(headers, name)?;
+    value
+        .parse()
+        .map_err(|_| Error::InvalidResponse(format!("invalid value for header {}: {:?}", name, value)))
+}
+`)
+}
+
+func (rs *rust) writeErrorType() {
+	type errCode struct{ variant, code, doc string }
+	codes := []errCode{
+		{"OK", "ok", "OK indicates the operation was successful."},
+		{"Canceled", "canceled", "Canceled indicates the operation was canceled (typically by the caller)."},
+		{"Unknown", "unknown", "Unknown error."},
+		{"InvalidArgument", "invalid_argument", "InvalidArgument indicates client specified an invalid argument."},
+		{"DeadlineExceeded", "deadline_exceeded", "DeadlineExceeded means operation expired before completion."},
+		{"NotFound", "not_found", "NotFound means some requested entity (e.g., file or directory) was not found."},
+		{"AlreadyExists", "already_exists", "AlreadyExists means an attempt to create an entity failed because one already exists."},
+		{"PermissionDenied", "permission_denied", "PermissionDenied indicates the caller does not have permission to execute the specified operation."},
+		{"ResourceExhausted", "resource_exhausted", "ResourceExhausted indicates some resource has been exhausted."},
+		{"FailedPrecondition", "failed_precondition", "FailedPrecondition indicates the system is not in a state required for the operation's execution."},
+		{"Aborted", "aborted", "Aborted indicates the operation was aborted, typically due to a concurrency issue."},
+		{"OutOfRange", "out_of_range", "OutOfRange means operation was attempted past the valid range."},
+		{"Unimplemented", "unimplemented", "Unimplemented indicates operation is not implemented or not supported/enabled in this service."},
+		{"Internal", "internal", "Internal errors. Means some invariants expected by underlying system has been broken."},
+		{"Unavailable", "unavailable", "Unavailable indicates the service is currently unavailable."},
+		{"DataLoss", "data_loss", "DataLoss indicates unrecoverable data loss or corruption."},
+		{"Unauthenticated", "unauthenticated", "Unauthenticated indicates the request does not have valid authentication credentials for the operation."},
+	}
+
+	rs.WriteString(`
+/// Error is the error returned when calling an API fails.
+#[derive(Debug)]
+pub enum Error {
+    /// The API returned an error.
+    Api(APIError),
+    /// The request could not be sent, or the response could not be read.
+    Http(reqwest::Error),
+    /// The request could not be encoded, or the response could not be decoded.
+    Json(serde_json::Error),
+    /// The request was invalid.
+    InvalidRequest(String),
+    /// The response was invalid.
+    InvalidResponse(String),
+}
+
+impl Error {
+    /// code returns the Encore error code of the error,
+    /// or ErrCode::Unknown if it's not an API error.
+    pub fn code(&self) -> ErrCode {
+        match self {
+            Error::Api(err) => err.code,
+            _ => ErrCode::Unknown,
+        }
+    }
+}
+
+impl std::fmt::Display for Error {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        match self {
+            Error::Api(err) => err.fmt(f),
+            Error::Http(err) => write!(f, "http error: {}", err),
+            Error::Json(err) => write!(f, "json error: {}", err),
+            Error::InvalidRequest(msg) => write!(f, "invalid request: {}", msg),
+            Error::InvalidResponse(msg) => write!(f, "invalid response: {}", msg),
+        }
+    }
+}
+
+impl std::error::Error for Error {
+    fn source(&self) -> Option<&(dyn std::error::Error + 'static)> {
+        match self {
+            Error::Api(err) => Some(err),
+            Error::Http(err) => Some(err),
+            Error::Json(err) => Some(err),
+            _ => None,
+        }
+    }
+}
+
+impl From<reqwest::Error> for Error {
+    fn from(err: reqwest::Error) -> Self {
+        Error::Http(err)
+    }
+}
+
+impl From<serde_json::Error> for Error {
+    fn from(err: serde_json::Error) -> Self {
+        Error::Json(err)
+    }
+}
+
+/// APIError represents a structured error as returned from an Encore application.
+#[derive(Debug, Clone)]
+pub struct APIError {
+    /// The HTTP status code associated with the error.
+    pub status: u16,
+    /// The Encore error code.
+    pub code: ErrCode,
+    /// The error message.
+    pub message: String,
+    /// The error details.
+    pub details: Option<serde_json::Value>,
+}
+
+impl APIError {
+    async fn from_response(resp: reqwest::Response) -> APIError {
+        let status = resp.status().as_u16();
+        let mut err = APIError {
+            status,
+            code: ErrCode::Unknown,
+            message: format!("request failed: status {}", status),
+            details: None,
+        };
+
+        let text = match resp.text().await {
+            Ok(text) => text,
+            Err(e) => {
+                err.message = format!("{}: {}", err.message, e);
+                return err;
+            }
+        };
+        match serde_json::from_str::<serde_json::Value>(&text) {
+            Ok(body) => {
+                let code = body.get("code").and_then(|c| c.as_str()).and_then(ErrCode::parse);
+                let message = body.get("message").and_then(|m| m.as_str());
+                if let (Some(code), Some(message)) = (code, message) {
+                    err.code = code;
+                    err.message = message.to_string();
+                    err.details = body.get("details").filter(|d| !d.is_null()).cloned();
+                } else {
+                    err.message = format!("{}: {}", err.message, body);
+                }
+            }
+            Err(_) => err.message = format!("{}: {}", err.message, text),
+        }
+        err
+    }
+}
+
+impl std::fmt::Display for APIError {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        write!(f, "{}: {}", self.code, self.message)
+    }
+}
+
+impl std::error::Error for APIError {}
+
+/// ErrCode is the error code of an APIError.
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash)]
+pub enum ErrCode {
+`)
+	for i, c := range codes {
+		if i > 0 {
+			rs.WriteString("\n")
+		}
+		fmt.Fprintf(rs, "    /// %s\n    %s,\n", c.doc, c.variant)
+	}
+	rs.WriteString(`}
+
+impl ErrCode {
+    /// as_str returns the string representation of the error code.
+    pub fn as_str(&self) -> &'static str {
+        match self {
+`)
+	for _, c := range codes {
+		fmt.Fprintf(rs, "            ErrCode::%s => %q,\n", c.variant, c.code)
+	}
+	rs.WriteString(`        }
+    }
+
+    /// parse parses the string representation of an error code.
+    pub fn parse(code: &str) -> Option<ErrCode> {
+        match code {
+`)
+	for _, c := range codes {
+		fmt.Fprintf(rs, "            %q => Some(ErrCode::%s),\n", c.code, c.variant)
+	}
+	rs.WriteString(`            _ => None,
+        }
+    }
+}
+
+impl std::fmt::Display for ErrCode {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        f.write_str(self.as_str())
+    }
+}
+`)
+}
+
+func (rs *rust) builtinType(typ schema.Builtin) string {
+	switch typ {
+	case schema.Builtin_ANY:
+		return "serde_json::Value"
+	case schema.Builtin_BOOL:
+		return "bool"
+	case schema.Builtin_INT8:
+		return "i8"
+	case schema.Builtin_INT16:
+		return "i16"
+	case schema.Builtin_INT32:
+		return "i32"
+	case schema.Builtin_INT64, schema.Builtin_INT:
+		return "i64"
+	case schema.Builtin_UINT8:
+		return "u8"
+	case schema.Builtin_UINT16:
+		return "u16"
+	case schema.Builtin_UINT32:
+		return "u32"
+	case schema.Builtin_UINT64, schema.Builtin_UINT:
+		return "u64"
+	case schema.Builtin_FLOAT32:
+		return "f32"
+	case schema.Builtin_FLOAT64:
+		return "f64"
+	case schema.Builtin_STRING:
+		return "String"
+	case schema.Builtin_BYTES:
+		return "String" // base64 encoded
+	case schema.Builtin_TIME:
+		return "String" // RFC 3339 encoded
+	case schema.Builtin_JSON:
+		return "serde_json::Value"
+	case schema.Builtin_UUID:
+		return "String"
+	case schema.Builtin_USER_ID:
+		return "String"
+	case schema.Builtin_FILE:
+		return "Vec<u8>"
+	default:
+		rs.errorf("unknown builtin type %v", typ)
+		return "serde_json::Value"
+	}
+}
+
+// typ returns the Rust type for typ, when referenced from within the module for ns.
+func (rs *rust) typ(ns string, typ *schema.Type) string {
+	switch typ := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl := rs.md.Decls[typ.Named.Id]
+		var name string
+		switch decl.Loc.PkgName {
+		case ns:
+			name = rs.typeName(decl.Name)
+		default:
+			if ns == "" {
+				name = rs.moduleName(decl.Loc.PkgName) + "::" + rs.typeName(decl.Name)
+			} else {
+				name = "super::" + rs.moduleName(decl.Loc.PkgName) + "::" + rs.typeName(decl.Name)
+			}
+		}
+		if len(typ.Named.TypeArguments) == 0 {
+			return name
+		}
+		args := make([]string, len(typ.Named.TypeArguments))
+		for i, arg := range typ.Named.TypeArguments {
+			args[i] = rs.typ(ns, arg)
+		}
+		return name + "<" + strings.Join(args, ", ") + ">"
+
+	case *schema.Type_List:
+		return "Vec<" + rs.typ(ns, typ.List.Elem) + ">"
+
+	case *schema.Type_Map:
+		return "std::collections::HashMap<" + rs.typ(ns, typ.Map.Key) + ", " + rs.typ(ns, typ.Map.Value) + ">"
+
+	case *schema.Type_Builtin:
+		return rs.builtinType(typ.Builtin)
+
+	case *schema.Type_Pointer:
+		return rs.typ(ns, typ.Pointer.Base)
+
+	case *schema.Type_Literal:
+		switch lit := typ.Literal.Value.(type) {
+		case *schema.Literal_Str:
+			return "String"
+		case *schema.Literal_Int:
+			return "i64"
+		case *schema.Literal_Float:
+			return "f64"
+		case *schema.Literal_Boolean:
+			return "bool"
+		case *schema.Literal_Null:
+			return "()"
+		default:
+			rs.errorf("unknown literal type %T", lit)
+			return ""
+		}
+
+	case *schema.Type_Union:
+		// Unions of a single type and null are optional values;
+		// other unions have no named type to generate an enum for.
+		var nonNull []*schema.Type
+		for _, t := range typ.Union.Types {
+			if lit := t.GetLiteral(); lit == nil || !lit.GetNull() {
+				nonNull = append(nonNull, t)
+			}
+		}
+		if len(nonNull) == 1 {
+			inner := rs.typ(ns, nonNull[0])
+			if len(nonNull) < len(typ.Union.Types) {
+				return "Option<" + inner + ">"
+			}
+			return inner
+		}
+		return "serde_json::Value"
+
+	case *schema.Type_Struct:
+		// Anonymous structs have no name to generate a struct for.
+		return "serde_json::Value"
+
+	case *schema.Type_TypeParameter:
+		decl := rs.md.Decls[typ.TypeParameter.DeclId]
+		return decl.TypeParams[typ.TypeParameter.ParamIdx].Name
+
+	case *schema.Type_Config:
+		// Config type is transparent
+		return rs.typ(ns, typ.Config.Elem)
+
+	default:
+		rs.errorf("unknown type %+v", reflect.TypeOf(typ))
+		return ""
+	}
+}
+
+func (rs *rust) errorf(format string, args ...interface{}) {
+	panic(bailout{fmt.Errorf(format, args...)})
+}
+
+func (rs *rust) handleBailout(dst *error) {
+	if err := recover(); err != nil {
+		if bail, ok := err.(bailout); ok {
+			*dst = bail.err
+		} else {
+			panic(err)
+		}
+	}
+}
+
+func (rs *rust) newIdentWriter(indent int) *indentWriter {
+	return &indentWriter{
+		w:                rs.Buffer,
+		depth:            indent,
+		indent:           "    ",
+		firstWriteOnLine: true,
+	}
+}
+
+func (rs *rust) writeDocComment(w *indentWriter, doc string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		w.WriteString(strings.TrimRight("/// "+strings.TrimSpace(line), " ") + "\n")
+	}
+}
+
+func (rs *rust) typeName(identifier string) string {
+	return idents.Convert(identifier, idents.PascalCase)
+}
+
+func (rs *rust) moduleName(identifier string) string {
+	return rs.escapeKeyword(idents.Convert(identifier, idents.SnakeCase))
+}
+
+func (rs *rust) memberName(identifier string) string {
+	return rs.escapeKeyword(idents.Convert(identifier, idents.SnakeCase))
+}
+
+// fieldName returns the Rust field name for the struct field with the given JSON name.
+func (rs *rust) fieldName(jsonName string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, idents.Convert(jsonName, idents.SnakeCase))
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return rs.escapeKeyword(name)
+}
+
+// isOmitEmpty reports whether the field's JSON tag has the omitempty option.
+func (rs *rust) isOmitEmpty(field *schema.Field) bool {
+	for _, tag := range field.Tags {
+		if tag.Key == "json" && slices.Contains(tag.Options, "omitempty") {
+			return true
+		}
+	}
+	return false
+}
+
+func (rs *rust) fieldNameInStruct(field *schema.Field) string {
+	name := field.Name
+	if field.JsonName != "" {
+		name = field.JsonName
+	}
+	return name
+}
+
+// nonReservedId returns the given ID, unless we have it a reserved within the client function _or_ it's a reserved Rust keyword
+func (rs *rust) nonReservedId(id string) string {
+	id = idents.Convert(id, idents.SnakeCase)
+	switch id {
+	// our reserved identifiers (or ID's we use within the generated client functions)
+	case "method", "params", "data", "headers", "query", "body", "resp", "resp_headers", "rtn":
+		return "_" + id
+	default:
+		return rs.escapeKeyword(id)
+	}
+}
+
+// escapeKeyword returns the given ID, unless it's a Rust keyword.
+func (rs *rust) escapeKeyword(id string) string {
+	switch id {
+	case "as", "async", "await", "break", "const", "continue", "crate", "dyn", "else", "enum", "extern", "false",
+		"fn", "for", "if", "impl", "in", "let", "loop", "match", "mod", "move", "mut", "pub", "ref", "return",
+		"self", "static", "struct", "super", "trait", "true", "type", "unsafe", "use", "where", "while",
+		"abstract", "become", "box", "do", "final", "macro", "override", "priv", "try", "typeof", "unsized",
+		"virtual", "yield", "gen":
+		return id + "_"
+	default:
+		return id
+	}
+}
+
+func (rs *rust) isRecursive(typ *schema.Type) bool {
+	// Treat recursively seen types as if they are optional
+	recursiveType := false
+	for typ.GetPointer() != nil {
+		typ = typ.GetPointer().Base
+	}
+	if n := typ.GetNamed(); n != nil {
+		recursiveType = rs.typs.IsRecursiveRef(rs.currDecl.Id, n.Id)
+	}
+	return recursiveType
+}
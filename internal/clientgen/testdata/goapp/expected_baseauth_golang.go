@@ -103,6 +103,9 @@ type SvcClient interface {
 	// DummyAPI is a dummy endpoint.
 	DummyAPI(ctx context.Context, params SvcRequest) error
 
+	// Optional is an endpoint with optional auth.
+	Optional(ctx context.Context, params SvcRequest) error
+
 	// Private is a basic auth endpoint.
 	Private(ctx context.Context, params SvcRequest) error
 }
@@ -119,6 +122,12 @@ func (c *svcClient) DummyAPI(ctx context.Context, params SvcRequest) error {
 	return err
 }
 
+// Optional is an endpoint with optional auth.
+func (c *svcClient) Optional(ctx context.Context, params SvcRequest) error {
+	_, err := callAPI(ctx, c.base, "POST", "/svc.Optional", nil, params, nil)
+	return err
+}
+
 // Private is a basic auth endpoint.
 func (c *svcClient) Private(ctx context.Context, params SvcRequest) error {
 	_, err := callAPI(ctx, c.base, "POST", "/svc.Private", nil, params, nil)
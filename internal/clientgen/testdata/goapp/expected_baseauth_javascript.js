@@ -61,6 +61,13 @@ class SvcServiceClient {
         await this.baseClient.callTypedAPI("POST", `/svc.DummyAPI`, JSON.stringify(params))
     }
 
+    /**
+     * Optional is an endpoint with optional auth.
+     */
+    async Optional(params) {
+        await this.baseClient.callTypedAPI("POST", `/svc.Optional`, JSON.stringify(params))
+    }
+
     /**
      * Private is a basic auth endpoint.
      */
@@ -104,6 +104,13 @@ export namespace svc {
             await this.baseClient.callTypedAPI("POST", `/svc.DummyAPI`, JSON.stringify(params))
         }
 
+        /**
+         * Optional is an endpoint with optional auth.
+         */
+        public async Optional(params: Request): Promise<void> {
+            await this.baseClient.callTypedAPI("POST", `/svc.Optional`, JSON.stringify(params))
+        }
+
         /**
          * Private is a basic auth endpoint.
          */
@@ -0,0 +1,471 @@
+// Code generated by the Encore v0.0.0-develop client generator. DO NOT EDIT.
+
+// Disable lints for this file.
+#![allow(clippy::all, dead_code, unused_imports)]
+
+/// BaseURL is the base URL for calling the Encore application's API.
+pub type BaseURL = String;
+
+/// LOCAL is the BaseURL of the locally running application.
+pub const LOCAL: &str = "http://localhost:4000";
+
+/// environment returns a BaseURL for calling the cloud environment with the given name.
+pub fn environment(name: &str) -> BaseURL {
+    format!("https://{}-app.encr.app", name)
+}
+
+/// preview_env returns a BaseURL for calling the preview environment with the given PR number.
+pub fn preview_env(pr: impl std::fmt::Display) -> BaseURL {
+    environment(&format!("pr{}", pr))
+}
+
+/// Client is an API client for the app Encore application.
+pub struct Client {
+    pub svc: svc::ServiceClient,
+}
+
+impl Client {
+    /// Creates a Client for calling the public and authenticated APIs of your Encore application.
+    ///
+    /// target is the base URL the client should be configured to use. See LOCAL and environment for options.
+    pub fn new(target: impl Into<BaseURL>, options: ClientOptions) -> Self {
+        let base = std::sync::Arc::new(BaseClient::new(target.into(), options));
+        Client {
+            svc: svc::ServiceClient::new(base.clone()),
+        }
+    }
+}
+
+/// ClientOptions allows you to override any default behaviour within the generated Encore client.
+#[derive(Clone, Default)]
+pub struct ClientOptions {
+    /// The HTTP client used to make requests. If not set, a new client is created.
+    pub http_client: Option<reqwest::Client>,
+
+    /// Headers to send with each request.
+    pub headers: Vec<(String, String)>,
+}
+
+impl ClientOptions {
+    /// with_http_client sets the HTTP client used to make requests.
+    pub fn with_http_client(mut self, client: reqwest::Client) -> Self {
+        self.http_client = Some(client);
+        self
+    }
+
+    /// with_header adds a header to send with each request.
+    pub fn with_header(mut self, name: impl Into<String>, value: impl Into<String>) -> Self {
+        self.headers.push((name.into(), value.into()));
+        self
+    }
+}
+
+pub mod svc {
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct File {
+        pub id: String,
+    }
+
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct ImportParams {
+        pub data: Vec<u8>,
+    }
+
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct UploadParams {
+        #[serde(rename = "Folder")]
+        pub folder: String,
+
+        #[serde(rename = "Tag", default, skip_serializing_if = "Option::is_none")]
+        pub tag: Option<String>,
+
+        pub avatar: Vec<u8>,
+
+        #[serde(default, skip_serializing_if = "Option::is_none")]
+        pub attachments: Option<Vec<Vec<u8>>>,
+
+        pub description: String,
+        pub public: bool,
+    }
+
+    pub struct ServiceClient {
+        base: std::sync::Arc<super::BaseClient>,
+    }
+
+    impl ServiceClient {
+        pub(super) fn new(base: std::sync::Arc<super::BaseClient>) -> Self {
+            ServiceClient { base }
+        }
+
+        pub async fn get(&self, id: &str) -> Result<File, super::Error> {
+            let resp = self.base.call_typed_api("GET", &format!("/files/{}", super::encode_path(id)), None, Vec::new(), Vec::new()).await?;
+            Ok(serde_json::from_slice(&resp.bytes().await?)?)
+        }
+
+        // Import is not included, as the Rust client can't send requests containing files yet.
+
+        // Upload is not included, as the Rust client can't send requests containing files yet.
+    }
+}
+
+struct BaseClient {
+    base_url: String,
+    http: reqwest::Client,
+    headers: Vec<(String, String)>,
+}
+
+impl BaseClient {
+    fn new(base_url: String, options: ClientOptions) -> Self {
+        let mut headers = vec![("User-Agent".to_string(), "app-Generated-Rust-Client (Encore/v0.0.0-develop)".to_string())];
+        headers.extend(options.headers);
+        BaseClient {
+            base_url,
+            http: options.http_client.unwrap_or_default(),
+            headers,
+        }
+    }
+
+    /// call_typed_api makes an API call, encoding the body as JSON.
+    async fn call_typed_api(
+        &self,
+        method: &str,
+        path: &str,
+        body: Option<serde_json::Value>,
+        mut headers: Vec<(String, String)>,
+        query: Vec<(String, String)>,
+    ) -> Result<reqwest::Response, Error> {
+        let body = body.map(|b| serde_json::to_vec(&b)).transpose()?;
+        headers.push(("Content-Type".to_string(), "application/json".to_string()));
+        self.call_api(method, path, body, headers, query).await
+    }
+
+    /// call_api is used by each generated API method to actually make the request.
+    async fn call_api(
+        &self,
+        method: &str,
+        path: &str,
+        body: Option<Vec<u8>>,
+        headers: Vec<(String, String)>,
+        query: Vec<(String, String)>,
+    ) -> Result<reqwest::Response, Error> {
+        let method = reqwest::Method::from_bytes(method.as_bytes())
+            .map_err(|_| Error::InvalidRequest(format!("invalid HTTP method {:?}", method)))?;
+
+        let headers: Vec<(String, String)> = self.headers.iter().cloned().chain(headers).collect();
+
+        let mut req = self.http.request(method, format!("{}{}", self.base_url, path));
+        for (name, value) in &headers {
+            req = req.header(name.as_str(), value.as_str());
+        }
+        if !query.is_empty() {
+            req = req.query(&query);
+        }
+        if let Some(body) = body {
+            req = req.body(body);
+        }
+
+        let resp = req.send().await?;
+        if !resp.status().is_success() {
+            return Err(Error::Api(APIError::from_response(resp).await));
+        }
+        Ok(resp)
+    }
+}
+
+/// make_params returns the (name, value) pairs for the given (name, field, is_json) parameters,
+/// skipping any fields that are not set.
+fn make_params(data: &serde_json::Value, params: &[(&str, &str, bool)]) -> Vec<(String, String)> {
+    let mut out = Vec::new();
+    for (name, field, is_json) in params {
+        match &data[*field] {
+            serde_json::Value::Null => {}
+            serde_json::Value::Array(values) if !is_json => {
+                for v in values {
+                    out.push((name.to_string(), param_string(v)));
+                }
+            }
+            v if *is_json => out.push((name.to_string(), v.to_string())),
+            v => out.push((name.to_string(), param_string(v))),
+        }
+    }
+    out
+}
+
+/// param_string converts a JSON value to its string form for use in paths, headers and query strings.
+fn param_string(value: &serde_json::Value) -> String {
+    match value {
+        serde_json::Value::String(s) => s.clone(),
+        v => v.to_string(),
+    }
+}
+
+/// pick returns the fields of data to include in the request body, keyed by their name on the wire.
+fn pick(data: &serde_json::Value, fields: &[(&str, &str)]) -> serde_json::Value {
+    let mut out = serde_json::Map::new();
+    for (name, field) in fields {
+        if let Some(v) = data.get(*field) {
+            out.insert(name.to_string(), v.clone());
+        }
+    }
+    serde_json::Value::Object(out)
+}
+
+/// encode_path percent-encodes a path segment.
+fn encode_path(value: impl std::fmt::Display) -> String {
+    let mut out = String::new();
+    for b in value.to_string().bytes() {
+        match b {
+            b'A'..=b'Z' | b'a'..=b'z' | b'0'..=b'9' | b'-' | b'_' | b'.' | b'~' => out.push(b as char),
+            _ => out.push_str(&format!("%{:02X}", b)),
+        }
+    }
+    out
+}
+
+/// must_be_set returns the value of the response header, or an error with the DataLoss code if it's not set.
+fn must_be_set(headers: &reqwest::header::HeaderMap, name: &str) -> Result<String, Error> {
+    match headers.get(name).and_then(|v| v.to_str().ok()) {
+        Some(v) => Ok(v.to_string()),
+        None => Err(Error::Api(APIError {
+            status: 500,
+            code: ErrCode::DataLoss,
+            message: format!("Header `{}` was unexpectedly not set", name),
+            details: None,
+        })),
+    }
+}
+
+/// parse_header parses the value of the response header.
+fn parse_header<T: std::str::FromStr>(headers: &reqwest::header::HeaderMap, name: &str) -> Result<T, Error> {
+    let value = must_be_set(headers, name)?;
+    value
+        .parse()
+        .map_err(|_| Error::InvalidResponse(format!("invalid value for header {}: {:?}", name, value)))
+}
+
+/// Error is the error returned when calling an API fails.
+#[derive(Debug)]
+pub enum Error {
+    /// The API returned an error.
+    Api(APIError),
+    /// The request could not be sent, or the response could not be read.
+    Http(reqwest::Error),
+    /// The request could not be encoded, or the response could not be decoded.
+    Json(serde_json::Error),
+    /// The request was invalid.
+    InvalidRequest(String),
+    /// The response was invalid.
+    InvalidResponse(String),
+}
+
+impl Error {
+    /// code returns the Encore error code of the error,
+    /// or ErrCode::Unknown if it's not an API error.
+    pub fn code(&self) -> ErrCode {
+        match self {
+            Error::Api(err) => err.code,
+            _ => ErrCode::Unknown,
+        }
+    }
+}
+
+impl std::fmt::Display for Error {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        match self {
+            Error::Api(err) => err.fmt(f),
+            Error::Http(err) => write!(f, "http error: {}", err),
+            Error::Json(err) => write!(f, "json error: {}", err),
+            Error::InvalidRequest(msg) => write!(f, "invalid request: {}", msg),
+            Error::InvalidResponse(msg) => write!(f, "invalid response: {}", msg),
+        }
+    }
+}
+
+impl std::error::Error for Error {
+    fn source(&self) -> Option<&(dyn std::error::Error + 'static)> {
+        match self {
+            Error::Api(err) => Some(err),
+            Error::Http(err) => Some(err),
+            Error::Json(err) => Some(err),
+            _ => None,
+        }
+    }
+}
+
+impl From<reqwest::Error> for Error {
+    fn from(err: reqwest::Error) -> Self {
+        Error::Http(err)
+    }
+}
+
+impl From<serde_json::Error> for Error {
+    fn from(err: serde_json::Error) -> Self {
+        Error::Json(err)
+    }
+}
+
+/// APIError represents a structured error as returned from an Encore application.
+#[derive(Debug, Clone)]
+pub struct APIError {
+    /// The HTTP status code associated with the error.
+    pub status: u16,
+    /// The Encore error code.
+    pub code: ErrCode,
+    /// The error message.
+    pub message: String,
+    /// The error details.
+    pub details: Option<serde_json::Value>,
+}
+
+impl APIError {
+    async fn from_response(resp: reqwest::Response) -> APIError {
+        let status = resp.status().as_u16();
+        let mut err = APIError {
+            status,
+            code: ErrCode::Unknown,
+            message: format!("request failed: status {}", status),
+            details: None,
+        };
+
+        let text = match resp.text().await {
+            Ok(text) => text,
+            Err(e) => {
+                err.message = format!("{}: {}", err.message, e);
+                return err;
+            }
+        };
+        match serde_json::from_str::<serde_json::Value>(&text) {
+            Ok(body) => {
+                let code = body.get("code").and_then(|c| c.as_str()).and_then(ErrCode::parse);
+                let message = body.get("message").and_then(|m| m.as_str());
+                if let (Some(code), Some(message)) = (code, message) {
+                    err.code = code;
+                    err.message = message.to_string();
+                    err.details = body.get("details").filter(|d| !d.is_null()).cloned();
+                } else {
+                    err.message = format!("{}: {}", err.message, body);
+                }
+            }
+            Err(_) => err.message = format!("{}: {}", err.message, text),
+        }
+        err
+    }
+}
+
+impl std::fmt::Display for APIError {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        write!(f, "{}: {}", self.code, self.message)
+    }
+}
+
+impl std::error::Error for APIError {}
+
+/// ErrCode is the error code of an APIError.
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash)]
+pub enum ErrCode {
+    /// OK indicates the operation was successful.
+    OK,
+
+    /// Canceled indicates the operation was canceled (typically by the caller).
+    Canceled,
+
+    /// Unknown error.
+    Unknown,
+
+    /// InvalidArgument indicates client specified an invalid argument.
+    InvalidArgument,
+
+    /// DeadlineExceeded means operation expired before completion.
+    DeadlineExceeded,
+
+    /// NotFound means some requested entity (e.g., file or directory) was not found.
+    NotFound,
+
+    /// AlreadyExists means an attempt to create an entity failed because one already exists.
+    AlreadyExists,
+
+    /// PermissionDenied indicates the caller does not have permission to execute the specified operation.
+    PermissionDenied,
+
+    /// ResourceExhausted indicates some resource has been exhausted.
+    ResourceExhausted,
+
+    /// FailedPrecondition indicates the system is not in a state required for the operation's execution.
+    FailedPrecondition,
+
+    /// Aborted indicates the operation was aborted, typically due to a concurrency issue.
+    Aborted,
+
+    /// OutOfRange means operation was attempted past the valid range.
+    OutOfRange,
+
+    /// Unimplemented indicates operation is not implemented or not supported/enabled in this service.
+    Unimplemented,
+
+    /// Internal errors. Means some invariants expected by underlying system has been broken.
+    Internal,
+
+    /// Unavailable indicates the service is currently unavailable.
+    Unavailable,
+
+    /// DataLoss indicates unrecoverable data loss or corruption.
+    DataLoss,
+
+    /// Unauthenticated indicates the request does not have valid authentication credentials for the operation.
+    Unauthenticated,
+}
+
+impl ErrCode {
+    /// as_str returns the string representation of the error code.
+    pub fn as_str(&self) -> &'static str {
+        match self {
+            ErrCode::OK => "ok",
+            ErrCode::Canceled => "canceled",
+            ErrCode::Unknown => "unknown",
+            ErrCode::InvalidArgument => "invalid_argument",
+            ErrCode::DeadlineExceeded => "deadline_exceeded",
+            ErrCode::NotFound => "not_found",
+            ErrCode::AlreadyExists => "already_exists",
+            ErrCode::PermissionDenied => "permission_denied",
+            ErrCode::ResourceExhausted => "resource_exhausted",
+            ErrCode::FailedPrecondition => "failed_precondition",
+            ErrCode::Aborted => "aborted",
+            ErrCode::OutOfRange => "out_of_range",
+            ErrCode::Unimplemented => "unimplemented",
+            ErrCode::Internal => "internal",
+            ErrCode::Unavailable => "unavailable",
+            ErrCode::DataLoss => "data_loss",
+            ErrCode::Unauthenticated => "unauthenticated",
+        }
+    }
+
+    /// parse parses the string representation of an error code.
+    pub fn parse(code: &str) -> Option<ErrCode> {
+        match code {
+            "ok" => Some(ErrCode::OK),
+            "canceled" => Some(ErrCode::Canceled),
+            "unknown" => Some(ErrCode::Unknown),
+            "invalid_argument" => Some(ErrCode::InvalidArgument),
+            "deadline_exceeded" => Some(ErrCode::DeadlineExceeded),
+            "not_found" => Some(ErrCode::NotFound),
+            "already_exists" => Some(ErrCode::AlreadyExists),
+            "permission_denied" => Some(ErrCode::PermissionDenied),
+            "resource_exhausted" => Some(ErrCode::ResourceExhausted),
+            "failed_precondition" => Some(ErrCode::FailedPrecondition),
+            "aborted" => Some(ErrCode::Aborted),
+            "out_of_range" => Some(ErrCode::OutOfRange),
+            "unimplemented" => Some(ErrCode::Unimplemented),
+            "internal" => Some(ErrCode::Internal),
+            "unavailable" => Some(ErrCode::Unavailable),
+            "data_loss" => Some(ErrCode::DataLoss),
+            "unauthenticated" => Some(ErrCode::Unauthenticated),
+            _ => None,
+        }
+    }
+}
+
+impl std::fmt::Display for ErrCode {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        f.write_str(self.as_str())
+    }
+}
@@ -0,0 +1,436 @@
+# Code generated by the Encore v0.0.0-develop client generator. DO NOT EDIT.
+
+from __future__ import annotations
+
+import dataclasses
+import enum
+import inspect
+import json
+import typing
+import urllib.parse
+
+import httpx
+
+
+# BaseURL is the base URL for calling the Encore application's API.
+BaseURL = str
+
+LOCAL: BaseURL = "http://localhost:4000"
+
+
+def environment(name: str) -> BaseURL:
+    """environment returns a BaseURL for calling the cloud environment with the given name."""
+    return f"https://{name}-app.encr.app"
+
+
+def preview_env(pr: typing.Union[int, str]) -> BaseURL:
+    """preview_env returns a BaseURL for calling the preview environment with the given PR number."""
+    return environment(f"pr{pr}")
+
+
+class Client:
+    """Client is an API client for the app Encore application."""
+
+    svc: SvcServiceClient
+
+    def __init__(self, target: BaseURL, options: typing.Optional[ClientOptions] = None) -> None:
+        """
+        Creates a client for calling the public and authenticated APIs of your Encore application.
+
+        target is the base URL the client should be configured to use. See LOCAL and environment for options.
+        options allows you to override the default behaviour of the client.
+        """
+        self._base = _BaseClient(target, options or ClientOptions())
+        self.svc = SvcServiceClient(self._base)
+
+    def close(self) -> None:
+        """close closes the underlying HTTP client, unless it was provided in the options."""
+        self._base.close()
+
+    def __enter__(self) -> Client:
+        return self
+
+    def __exit__(self, *exc_info: typing.Any) -> None:
+        self.close()
+
+
+class AsyncClient:
+    """AsyncClient is an asyncio API client for the app Encore application."""
+
+    svc: AsyncSvcServiceClient
+
+    def __init__(self, target: BaseURL, options: typing.Optional[ClientOptions] = None) -> None:
+        """
+        Creates a client for calling the public and authenticated APIs of your Encore application.
+
+        target is the base URL the client should be configured to use. See LOCAL and environment for options.
+        options allows you to override the default behaviour of the client.
+        """
+        self._base = _AsyncBaseClient(target, options or ClientOptions())
+        self.svc = AsyncSvcServiceClient(self._base)
+
+    async def aclose(self) -> None:
+        """aclose closes the underlying HTTP client, unless it was provided in the options."""
+        await self._base.aclose()
+
+    async def __aenter__(self) -> AsyncClient:
+        return self
+
+    async def __aexit__(self, *exc_info: typing.Any) -> None:
+        await self.aclose()
+
+
+@dataclasses.dataclass(kw_only=True)
+class ClientOptions:
+    """ClientOptions allows you to override any default behaviour within the generated Encore client."""
+
+    headers: dict[str, str] = dataclasses.field(default_factory=dict)
+    """Headers to send with each request."""
+
+    http_client: typing.Optional[httpx.Client] = None
+    """
+    The HTTP client used by Client to make requests. If not set, the client creates its own,
+    which is closed when the client is closed.
+    """
+
+    async_http_client: typing.Optional[httpx.AsyncClient] = None
+    """
+    The HTTP client used by AsyncClient to make requests. If not set, the client creates its own,
+    which is closed when the client is closed.
+    """
+
+
+@dataclasses.dataclass(kw_only=True)
+class SvcRequest:
+    message: str = dataclasses.field(metadata={"json": "Message"})
+
+
+class SvcServiceClient:
+    def __init__(self, base: _BaseClient) -> None:
+        self._base = base
+
+    def dummy_api(self, params: SvcRequest) -> None:
+        """DummyAPI is a dummy endpoint."""
+        data = _encode(params)
+        self._base.call_typed_api("POST", "/svc.DummyAPI", body=data)
+
+
+class AsyncSvcServiceClient:
+    def __init__(self, base: _AsyncBaseClient) -> None:
+        self._base = base
+
+    async def dummy_api(self, params: SvcRequest) -> None:
+        """DummyAPI is a dummy endpoint."""
+        data = _encode(params)
+        await self._base.call_typed_api("POST", "/svc.DummyAPI", body=data)
+
+
+class _BaseClient:
+    def __init__(self, base_url: str, options: ClientOptions) -> None:
+        self.base_url = base_url
+        self.headers = {"User-Agent": "app-Generated-Python-Client (Encore/v0.0.0-develop)", **options.headers}
+        self.owns_http = options.http_client is None
+        self.http = options.http_client or httpx.Client()
+
+    def close(self) -> None:
+        if self.owns_http:
+            self.http.close()
+
+    def call_typed_api(
+        self,
+        method: str,
+        path: str,
+        body: typing.Any = None,
+        headers: typing.Optional[dict[str, str]] = None,
+        query: typing.Optional[dict[str, typing.Any]] = None,
+    ) -> httpx.Response:
+        """call_typed_api makes an API call, encoding the body as JSON."""
+        content = None if body is None else json.dumps(body).encode()
+        headers = {"Content-Type": "application/json", **(headers or {})}
+        return self.call_api(method, path, content, headers=headers, query=query)
+
+    def call_api(
+        self,
+        method: str,
+        path: str,
+        body: typing.Optional[bytes] = None,
+        headers: typing.Optional[dict[str, str]] = None,
+        query: typing.Optional[dict[str, typing.Any]] = None,
+    ) -> httpx.Response:
+        """call_api is used by each generated API method to actually make the request."""
+        headers = {**self.headers, **(headers or {})}
+        query = dict(query or {})
+
+        resp = self.http.request(method, self.base_url + path, content=body, headers=headers, params=query)
+        if resp.is_error:
+            raise _api_error(resp)
+        return resp
+
+
+class _AsyncBaseClient:
+    def __init__(self, base_url: str, options: ClientOptions) -> None:
+        self.base_url = base_url
+        self.headers = {"User-Agent": "app-Generated-Python-Client (Encore/v0.0.0-develop)", **options.headers}
+        self.owns_http = options.async_http_client is None
+        self.http = options.async_http_client or httpx.AsyncClient()
+
+    async def aclose(self) -> None:
+        if self.owns_http:
+            await self.http.aclose()
+
+    async def call_typed_api(
+        self,
+        method: str,
+        path: str,
+        body: typing.Any = None,
+        headers: typing.Optional[dict[str, str]] = None,
+        query: typing.Optional[dict[str, typing.Any]] = None,
+    ) -> httpx.Response:
+        """call_typed_api makes an API call, encoding the body as JSON."""
+        content = None if body is None else json.dumps(body).encode()
+        headers = {"Content-Type": "application/json", **(headers or {})}
+        return await self.call_api(method, path, content, headers=headers, query=query)
+
+    async def call_api(
+        self,
+        method: str,
+        path: str,
+        body: typing.Optional[bytes] = None,
+        headers: typing.Optional[dict[str, str]] = None,
+        query: typing.Optional[dict[str, typing.Any]] = None,
+    ) -> httpx.Response:
+        """call_api is used by each generated API method to actually make the request."""
+        headers = {**self.headers, **(headers or {})}
+        query = dict(query or {})
+
+        resp = await self.http.request(method, self.base_url + path, content=body, headers=headers, params=query)
+        if resp.is_error:
+            raise _api_error(resp)
+        return resp
+
+
+def _encode(value: typing.Any) -> typing.Any:
+    """_encode converts a value into its JSON representation, omitting unset optional fields."""
+    if dataclasses.is_dataclass(value) and not isinstance(value, type):
+        out = {}
+        for f in dataclasses.fields(value):
+            v = getattr(value, f.name)
+            if v is None and f.default is None:
+                continue
+            out[f.metadata.get("json", f.name)] = _encode(v)
+        return out
+    if isinstance(value, (list, tuple)):
+        return [_encode(v) for v in value]
+    if isinstance(value, dict):
+        return {k: _encode(v) for k, v in value.items()}
+    return value
+
+
+def _decode(tp: typing.Any, value: typing.Any) -> typing.Any:
+    """_decode converts a JSON value into the given type."""
+    if isinstance(tp, typing.ForwardRef):
+        tp = tp.__forward_arg__
+    if isinstance(tp, str):
+        tp = globals()[tp]
+    if tp is typing.Any or value is None:
+        return value
+
+    origin, args = typing.get_origin(tp), typing.get_args(tp)
+    if origin is typing.Union:
+        for arg in args:
+            if _matches(arg, value):
+                return _decode(arg, value)
+        return value
+    if origin is list:
+        return [_decode(args[0], v) for v in value]
+    if origin is dict:
+        return {(int(k) if args[0] is int else k): _decode(args[1], v) for k, v in value.items()}
+
+    cls = origin or tp
+    if dataclasses.is_dataclass(cls):
+        typevars = dict(zip(getattr(cls, "__parameters__", ()), args))
+        hints = typing.get_type_hints(cls)
+        kwargs = {}
+        for f in dataclasses.fields(cls):
+            field_type = _subst(hints[f.name], typevars)
+            name = f.metadata.get("json", f.name)
+            if name in value:
+                kwargs[f.name] = _decode(field_type, value[name])
+            elif f.default is dataclasses.MISSING:
+                kwargs[f.name] = _zero(field_type)
+        return cls(**kwargs)
+    if tp is float and isinstance(value, int):
+        return float(value)
+    return value
+
+
+def _subst(tp: typing.Any, typevars: dict[typing.Any, typing.Any]) -> typing.Any:
+    """_subst replaces the type variables in tp with their values."""
+    if isinstance(tp, typing.TypeVar):
+        return typevars.get(tp, typing.Any)
+    params = getattr(tp, "__parameters__", ())
+    if params and typing.get_origin(tp) is not None:
+        return tp[tuple(typevars.get(p, typing.Any) for p in params)]
+    return tp
+
+
+def _matches(tp: typing.Any, value: typing.Any) -> bool:
+    """_matches reports whether value could be a JSON representation of tp."""
+    if isinstance(tp, (str, typing.ForwardRef)):
+        return _matches(_decode_type(tp), value)
+    if tp is typing.Any:
+        return True
+    if tp is None or tp is type(None):
+        return value is None
+    origin, args = typing.get_origin(tp), typing.get_args(tp)
+    if origin is typing.Literal:
+        return value in args
+    if origin is typing.Union:
+        return any(_matches(arg, value) for arg in args)
+    cls = origin or tp
+    if dataclasses.is_dataclass(cls) or cls is dict:
+        return isinstance(value, dict)
+    if cls is list:
+        return isinstance(value, list)
+    if cls is bool:
+        return isinstance(value, bool)
+    if cls is int:
+        return isinstance(value, int) and not isinstance(value, bool)
+    if cls is float:
+        return isinstance(value, (int, float)) and not isinstance(value, bool)
+    if isinstance(cls, type):
+        return isinstance(value, cls)
+    return True
+
+
+def _decode_type(tp: typing.Any) -> typing.Any:
+    if isinstance(tp, typing.ForwardRef):
+        tp = tp.__forward_arg__
+    return globals()[tp] if isinstance(tp, str) else tp
+
+
+def _zero(tp: typing.Any) -> typing.Any:
+    """_zero returns the value to use for a required field missing from a response."""
+    cls = typing.get_origin(tp) or tp
+    if cls in (list, dict, str, int, float, bool):
+        return cls()
+    return None
+
+
+def _to_str(value: typing.Any) -> typing.Any:
+    """_to_str converts an encoded value to its string form for use in paths, headers and query strings."""
+    if value is None or isinstance(value, str):
+        return value
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    if isinstance(value, (int, float)):
+        return str(value)
+    if isinstance(value, list):
+        return [_to_str(v) for v in value]
+    return json.dumps(value)
+
+
+def _json_str(value: typing.Any) -> typing.Optional[str]:
+    return None if value is None else json.dumps(value)
+
+
+def _quote(value: typing.Any) -> str:
+    return urllib.parse.quote(_to_str(value), safe="")
+
+
+def _make_record(record: dict[str, typing.Any]) -> dict[str, typing.Any]:
+    """_make_record returns the record without any keys set to None."""
+    return {k: v for k, v in record.items() if v is not None}
+
+
+def _pick(data: dict[str, typing.Any], fields: dict[str, str]) -> dict[str, typing.Any]:
+    """_pick returns the fields of data to include, keyed by their name on the wire."""
+    return {wire: data[src] for wire, src in fields.items() if src in data}
+
+
+class ErrCode(str, enum.Enum):
+    """ErrCode is the error code of an APIError."""
+
+    OK = "ok"
+    """OK indicates the operation was successful."""
+
+    CANCELED = "canceled"
+    """Canceled indicates the operation was canceled (typically by the caller)."""
+
+    UNKNOWN = "unknown"
+    """Unknown error."""
+
+    INVALID_ARGUMENT = "invalid_argument"
+    """InvalidArgument indicates client specified an invalid argument."""
+
+    DEADLINE_EXCEEDED = "deadline_exceeded"
+    """DeadlineExceeded means operation expired before completion."""
+
+    NOT_FOUND = "not_found"
+    """NotFound means some requested entity (e.g., file or directory) was not found."""
+
+    ALREADY_EXISTS = "already_exists"
+    """AlreadyExists means an attempt to create an entity failed because one already exists."""
+
+    PERMISSION_DENIED = "permission_denied"
+    """PermissionDenied indicates the caller does not have permission to execute the specified operation."""
+
+    RESOURCE_EXHAUSTED = "resource_exhausted"
+    """ResourceExhausted indicates some resource has been exhausted."""
+
+    FAILED_PRECONDITION = "failed_precondition"
+    """FailedPrecondition indicates the system is not in a state required for the operation's execution."""
+
+    ABORTED = "aborted"
+    """Aborted indicates the operation was aborted, typically due to a concurrency issue."""
+
+    OUT_OF_RANGE = "out_of_range"
+    """OutOfRange means operation was attempted past the valid range."""
+
+    UNIMPLEMENTED = "unimplemented"
+    """Unimplemented indicates operation is not implemented or not supported/enabled in this service."""
+
+    INTERNAL = "internal"
+    """Internal errors. Means some invariants expected by underlying system has been broken."""
+
+    UNAVAILABLE = "unavailable"
+    """Unavailable indicates the service is currently unavailable."""
+
+    DATA_LOSS = "data_loss"
+    """DataLoss indicates unrecoverable data loss or corruption."""
+
+    UNAUTHENTICATED = "unauthenticated"
+    """Unauthenticated indicates the request does not have valid authentication credentials for the operation."""
+
+
+class APIError(Exception):
+    """APIError represents a structured error as returned from an Encore application."""
+
+    def __init__(self, status: int, code: ErrCode, message: str, details: typing.Any = None) -> None:
+        super().__init__(message)
+        self.status = status
+        """The HTTP status code associated with the error."""
+        self.code = code
+        """The Encore error code."""
+        self.message = message
+        """The error message."""
+        self.details = details
+        """The error details."""
+
+
+def _api_error(resp: httpx.Response) -> APIError:
+    """_api_error returns the APIError for an error response."""
+    code, message, details = ErrCode.UNKNOWN, f"request failed: status {resp.status_code}", None
+    try:
+        body = resp.json()
+    except ValueError:
+        if resp.text:
+            message += ": " + resp.text
+        return APIError(resp.status_code, code, message)
+
+    codes = {c.value for c in ErrCode}
+    if isinstance(body, dict) and body.get("code") in codes and isinstance(body.get("message"), str):
+        code, message, details = ErrCode(body["code"]), body["message"], body.get("details")
+    else:
+        message += ": " + json.dumps(body)
+    return APIError(resp.status_code, code, message, details)
@@ -0,0 +1,837 @@
+// Code generated by the Encore v0.0.0-develop client generator. DO NOT EDIT.
+
+// Disable lints for this file.
+#![allow(clippy::all, dead_code, unused_imports)]
+
+/// BaseURL is the base URL for calling the Encore application's API.
+pub type BaseURL = String;
+
+/// LOCAL is the BaseURL of the locally running application.
+pub const LOCAL: &str = "http://localhost:4000";
+
+/// environment returns a BaseURL for calling the cloud environment with the given name.
+pub fn environment(name: &str) -> BaseURL {
+    format!("https://{}-app.encr.app", name)
+}
+
+/// preview_env returns a BaseURL for calling the preview environment with the given PR number.
+pub fn preview_env(pr: impl std::fmt::Display) -> BaseURL {
+    environment(&format!("pr{}", pr))
+}
+
+/// Client is an API client for the app Encore application.
+pub struct Client {
+    pub authentication: authentication::ServiceClient,
+    pub products: products::ServiceClient,
+    pub svc: svc::ServiceClient,
+}
+
+impl Client {
+    /// Creates a Client for calling the public and authenticated APIs of your Encore application.
+    ///
+    /// target is the base URL the client should be configured to use. See LOCAL and environment for options.
+    pub fn new(target: impl Into<BaseURL>, options: ClientOptions) -> Self {
+        let base = std::sync::Arc::new(BaseClient::new(target.into(), options));
+        Client {
+            authentication: authentication::ServiceClient::new(base.clone()),
+            products: products::ServiceClient::new(base.clone()),
+            svc: svc::ServiceClient::new(base.clone()),
+        }
+    }
+}
+
+/// AuthDataGenerator returns the authentication data to use for each request.
+pub type AuthDataGenerator = std::sync::Arc<dyn Fn() -> Option<authentication::AuthData> + Send + Sync>;
+
+/// ClientOptions allows you to override any default behaviour within the generated Encore client.
+#[derive(Clone, Default)]
+pub struct ClientOptions {
+    /// The HTTP client used to make requests. If not set, a new client is created.
+    pub http_client: Option<reqwest::Client>,
+
+    /// Headers to send with each request.
+    pub headers: Vec<(String, String)>,
+
+    /// Generates the authentication data to send with each request.
+    pub auth: Option<AuthDataGenerator>,
+}
+
+impl ClientOptions {
+    /// with_http_client sets the HTTP client used to make requests.
+    pub fn with_http_client(mut self, client: reqwest::Client) -> Self {
+        self.http_client = Some(client);
+        self
+    }
+
+    /// with_header adds a header to send with each request.
+    pub fn with_header(mut self, name: impl Into<String>, value: impl Into<String>) -> Self {
+        self.headers.push((name.into(), value.into()));
+        self
+    }
+
+    /// with_auth sets the authentication data to send with each request.
+    pub fn with_auth(mut self, auth: authentication::AuthData) -> Self {
+        self.auth = Some(std::sync::Arc::new(move || Some(auth.clone())));
+        self
+    }
+
+    /// with_auth_generator sets a function which is called before each request
+    /// to generate the authentication data to send with it.
+    pub fn with_auth_generator(mut self, generator: impl Fn() -> Option<authentication::AuthData> + Send + Sync + 'static) -> Self {
+        self.auth = Some(std::sync::Arc::new(generator));
+        self
+    }
+}
+
+pub mod authentication {
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct AuthData {
+        #[serde(rename = "APIKey")]
+        pub api_key: String,
+    }
+
+    /// BarType docs
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct BarType {
+        /// Baz docs
+        #[serde(rename = "Baz")]
+        pub baz: String,
+    }
+
+    /// FooType docs
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct FooType {
+        /// Moo docs
+        #[serde(rename = "Moo")]
+        pub moo: String,
+
+        /// Bar docs
+        #[serde(rename = "Bar")]
+        pub bar: BarType,
+    }
+
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct User {
+        pub id: i64,
+        pub name: String,
+    }
+
+    pub struct ServiceClient {
+        base: std::sync::Arc<super::BaseClient>,
+    }
+
+    impl ServiceClient {
+        pub(super) fn new(base: std::sync::Arc<super::BaseClient>) -> Self {
+            ServiceClient { base }
+        }
+
+        pub async fn docs(&self, params: &FooType) -> Result<(), super::Error> {
+            let data = serde_json::to_value(params)?;
+            self.base.call_typed_api("POST", "/authentication.Docs", Some(data), Vec::new(), Vec::new()).await?;
+            Ok(())
+        }
+    }
+}
+
+pub mod products {
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct CreateProductRequest {
+        #[serde(rename = "IdempotencyKey")]
+        pub idempotency_key: String,
+
+        pub name: String,
+
+        #[serde(default)]
+        pub description: String,
+    }
+
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct Product {
+        pub id: String,
+        pub name: String,
+
+        #[serde(default)]
+        pub description: String,
+
+        pub created_at: String,
+        pub created_by: super::authentication::User,
+    }
+
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct ProductListing {
+        pub products: Vec<Product>,
+        pub previous: serde_json::Value,
+        pub next: serde_json::Value,
+    }
+
+    pub struct ServiceClient {
+        base: std::sync::Arc<super::BaseClient>,
+    }
+
+    impl ServiceClient {
+        pub(super) fn new(base: std::sync::Arc<super::BaseClient>) -> Self {
+            ServiceClient { base }
+        }
+
+        pub async fn create(&self, params: &CreateProductRequest) -> Result<Product, super::Error> {
+            let data = serde_json::to_value(params)?;
+            let headers = super::make_params(&data, &[
+                ("idempotency-key", "IdempotencyKey", false),
+            ]);
+            let body = super::pick(&data, &[
+                ("description", "description"),
+                ("name", "name"),
+            ]);
+            let resp = self.base.call_typed_api("POST", "/products.Create", Some(body), headers, Vec::new()).await?;
+            Ok(serde_json::from_slice(&resp.bytes().await?)?)
+        }
+
+        pub async fn list(&self) -> Result<ProductListing, super::Error> {
+            let resp = self.base.call_typed_api("GET", "/products.List", None, Vec::new(), Vec::new()).await?;
+            Ok(serde_json::from_slice(&resp.bytes().await?)?)
+        }
+    }
+}
+
+pub mod svc {
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct AllInputTypes<A> {
+        /// Specify this comes from a header field
+        #[serde(rename = "A")]
+        pub a: String,
+
+        /// Specify this comes from a query string
+        #[serde(rename = "B")]
+        pub b: Vec<i64>,
+
+        /// This can come from anywhere, but if it comes from the payload in JSON it must be called Charile
+        #[serde(rename = "Charlies-Bool", default)]
+        pub charlies_bool: bool,
+
+        /// This generic type complicates the whole thing 🙈
+        #[serde(rename = "Dave")]
+        pub dave: A,
+    }
+
+    pub type Foo = i64;
+
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct GetRequest {
+        #[serde(rename = "Baz")]
+        pub baz: i64,
+    }
+
+    /// HeaderOnlyStruct contains all types we support in headers
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct HeaderOnlyStruct {
+        #[serde(rename = "Boolean")]
+        pub boolean: bool,
+
+        #[serde(rename = "Int")]
+        pub int: i64,
+
+        #[serde(rename = "Float")]
+        pub float: f64,
+
+        #[serde(rename = "String")]
+        pub string: String,
+
+        #[serde(rename = "Bytes")]
+        pub bytes: String,
+
+        #[serde(rename = "Time")]
+        pub time: String,
+
+        #[serde(rename = "Json")]
+        pub json: serde_json::Value,
+
+        #[serde(rename = "UUID")]
+        pub uuid: String,
+
+        #[serde(rename = "UserID")]
+        pub user_id: String,
+    }
+
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct Recursive {
+        #[serde(rename = "Optional", default, skip_serializing_if = "Option::is_none")]
+        pub optional: Option<Box<Recursive>>,
+
+        #[serde(rename = "Slice")]
+        pub slice: Vec<Recursive>,
+
+        #[serde(rename = "Map")]
+        pub map: std::collections::HashMap<String, Recursive>,
+    }
+
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct Request {
+        /// Foo is good
+        #[serde(rename = "Foo", default, skip_serializing_if = "Option::is_none")]
+        pub foo: Option<Foo>,
+
+        /// Baz is better
+        pub boo: String,
+
+        #[serde(rename = "QueryFoo", default, skip_serializing_if = "Option::is_none")]
+        pub query_foo: Option<bool>,
+
+        #[serde(rename = "QueryBar", default, skip_serializing_if = "Option::is_none")]
+        pub query_bar: Option<String>,
+
+        #[serde(rename = "HeaderBaz", default, skip_serializing_if = "Option::is_none")]
+        pub header_baz: Option<String>,
+
+        #[serde(rename = "HeaderInt", default, skip_serializing_if = "Option::is_none")]
+        pub header_int: Option<i64>,
+
+        /// This is a multiline
+        /// comment on the raw message!
+        #[serde(rename = "Raw")]
+        pub raw: serde_json::Value,
+    }
+
+    /// Tuple is a generic type which allows us to
+    /// return two values of two different types
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct Tuple<A, B> {
+        #[serde(rename = "A")]
+        pub a: A,
+
+        #[serde(rename = "B")]
+        pub b: B,
+    }
+
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct WithNested {
+        #[serde(rename = "Nested")]
+        pub nested: super::nested::Type,
+    }
+
+    pub type WrappedRequest = Wrapper<Request>;
+
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct Wrapper<T> {
+        #[serde(rename = "Value")]
+        pub value: T,
+    }
+
+    pub struct ServiceClient {
+        base: std::sync::Arc<super::BaseClient>,
+    }
+
+    impl ServiceClient {
+        pub(super) fn new(base: std::sync::Arc<super::BaseClient>) -> Self {
+            ServiceClient { base }
+        }
+
+        /// DummyAPI is a dummy endpoint.
+        pub async fn dummy_api(&self, params: &Request) -> Result<(), super::Error> {
+            let data = serde_json::to_value(params)?;
+            let headers = super::make_params(&data, &[
+                ("baz", "HeaderBaz", false),
+                ("int", "HeaderInt", false),
+            ]);
+            let query = super::make_params(&data, &[
+                ("bar", "QueryBar", false),
+                ("foo", "QueryFoo", false),
+            ]);
+            let body = super::pick(&data, &[
+                ("Foo", "Foo"),
+                ("Raw", "Raw"),
+                ("boo", "boo"),
+            ]);
+            self.base.call_typed_api("POST", "/svc.DummyAPI", Some(body), headers, query).await?;
+            Ok(())
+        }
+
+        pub async fn fallback_path(&self, a: &str, b: &[String]) -> Result<(), super::Error> {
+            self.base.call_typed_api("POST", &format!("/fallbackPath/{}/{}", super::encode_path(a), b.iter().map(super::encode_path).collect::<Vec<_>>().join("/")), None, Vec::new(), Vec::new()).await?;
+            Ok(())
+        }
+
+        pub async fn get(&self, params: &GetRequest) -> Result<(), super::Error> {
+            let data = serde_json::to_value(params)?;
+            let query = super::make_params(&data, &[
+                ("boo", "Baz", false),
+            ]);
+            self.base.call_typed_api("GET", "/svc.Get", None, Vec::new(), query).await?;
+            Ok(())
+        }
+
+        pub async fn get_request_with_all_input_types(&self, params: &AllInputTypes<i64>) -> Result<HeaderOnlyStruct, super::Error> {
+            let data = serde_json::to_value(params)?;
+            let headers = super::make_params(&data, &[
+                ("x-alice", "A", false),
+            ]);
+            let query = super::make_params(&data, &[
+                ("Bob", "B", false),
+                ("c", "Charlies-Bool", false),
+                ("dave", "Dave", false),
+            ]);
+            let resp = self.base.call_typed_api("GET", "/svc.GetRequestWithAllInputTypes", None, headers, query).await?;
+            let resp_headers = resp.headers().clone();
+            let mut rtn: serde_json::Value = serde_json::from_slice(&resp.bytes().await?)?;
+            rtn["Boolean"] = serde_json::Value::Bool(super::must_be_set(&resp_headers, "x-boolean")?.eq_ignore_ascii_case("true"));
+            rtn["Int"] = super::parse_header::<i64>(&resp_headers, "x-int")?.into();
+            rtn["Float"] = super::parse_header::<f64>(&resp_headers, "x-float")?.into();
+            rtn["String"] = serde_json::Value::String(super::must_be_set(&resp_headers, "x-string")?);
+            rtn["Bytes"] = serde_json::Value::String(super::must_be_set(&resp_headers, "x-bytes")?);
+            rtn["Time"] = serde_json::Value::String(super::must_be_set(&resp_headers, "x-time")?);
+            rtn["Json"] = serde_json::from_str(&super::must_be_set(&resp_headers, "x-json")?)?;
+            rtn["UUID"] = serde_json::Value::String(super::must_be_set(&resp_headers, "x-uuid")?);
+            rtn["UserID"] = serde_json::Value::String(super::must_be_set(&resp_headers, "x-user-id")?);
+            Ok(serde_json::from_value(rtn)?)
+        }
+
+        pub async fn header_only_request(&self, params: &HeaderOnlyStruct) -> Result<(), super::Error> {
+            let data = serde_json::to_value(params)?;
+            let headers = super::make_params(&data, &[
+                ("x-boolean", "Boolean", false),
+                ("x-bytes", "Bytes", false),
+                ("x-float", "Float", false),
+                ("x-int", "Int", false),
+                ("x-json", "Json", true),
+                ("x-string", "String", false),
+                ("x-time", "Time", false),
+                ("x-user-id", "UserID", false),
+                ("x-uuid", "UUID", false),
+            ]);
+            self.base.call_typed_api("GET", "/svc.HeaderOnlyRequest", None, headers, Vec::new()).await?;
+            Ok(())
+        }
+
+        pub async fn nested(&self, params: &WithNested) -> Result<WithNested, super::Error> {
+            let data = serde_json::to_value(params)?;
+            let resp = self.base.call_typed_api("POST", "/svc.Nested", Some(data), Vec::new(), Vec::new()).await?;
+            Ok(serde_json::from_slice(&resp.bytes().await?)?)
+        }
+
+        pub async fn rest_path(&self, a: &str, b: i64) -> Result<(), super::Error> {
+            self.base.call_typed_api("POST", &format!("/path/{}/{}", super::encode_path(a), super::encode_path(b)), None, Vec::new(), Vec::new()).await?;
+            Ok(())
+        }
+
+        pub async fn rec(&self, params: &Recursive) -> Result<Recursive, super::Error> {
+            let data = serde_json::to_value(params)?;
+            let resp = self.base.call_typed_api("POST", "/svc.Rec", Some(data), Vec::new(), Vec::new()).await?;
+            Ok(serde_json::from_slice(&resp.bytes().await?)?)
+        }
+
+        pub async fn request_with_all_input_types(&self, params: &AllInputTypes<String>) -> Result<AllInputTypes<f64>, super::Error> {
+            let data = serde_json::to_value(params)?;
+            let headers = super::make_params(&data, &[
+                ("x-alice", "A", false),
+            ]);
+            let query = super::make_params(&data, &[
+                ("Bob", "B", false),
+            ]);
+            let body = super::pick(&data, &[
+                ("Charlies-Bool", "Charlies-Bool"),
+                ("Dave", "Dave"),
+            ]);
+            let resp = self.base.call_typed_api("POST", "/svc.RequestWithAllInputTypes", Some(body), headers, query).await?;
+            let resp_headers = resp.headers().clone();
+            let mut rtn: serde_json::Value = serde_json::from_slice(&resp.bytes().await?)?;
+            rtn["A"] = serde_json::Value::String(super::must_be_set(&resp_headers, "x-alice")?);
+            Ok(serde_json::from_value(rtn)?)
+        }
+
+        /// TupleInputOutput tests the usage of generics in the client generator
+        /// and this comment is also multiline, so multiline comments get tested as well.
+        pub async fn tuple_input_output(&self, params: &Tuple<String, WrappedRequest>) -> Result<Tuple<bool, Foo>, super::Error> {
+            let data = serde_json::to_value(params)?;
+            let resp = self.base.call_typed_api("POST", "/svc.TupleInputOutput", Some(data), Vec::new(), Vec::new()).await?;
+            Ok(serde_json::from_slice(&resp.bytes().await?)?)
+        }
+
+        pub async fn webhook(&self, method: &str, a: &str, b: &[String], body: Option<Vec<u8>>, headers: Vec<(String, String)>, query: Vec<(String, String)>) -> Result<reqwest::Response, super::Error> {
+            self.base.call_api(method, &format!("/webhook/{}/{}", super::encode_path(a), b.iter().map(super::encode_path).collect::<Vec<_>>().join("/")), body, headers, query).await
+        }
+
+        pub async fn webhook2(&self, a: &str, b: &[String]) -> Result<(), super::Error> {
+            self.base.call_typed_api("POST", &format!("/webhook2/{}/{}", super::encode_path(a), b.iter().map(super::encode_path).collect::<Vec<_>>().join("/")), None, Vec::new(), Vec::new()).await?;
+            Ok(())
+        }
+    }
+}
+
+pub mod nested {
+    #[derive(Debug, Clone, serde::Serialize, serde::Deserialize)]
+    pub struct Type {
+        #[serde(rename = "Message")]
+        pub message: String,
+    }
+}
+
+struct BaseClient {
+    base_url: String,
+    http: reqwest::Client,
+    headers: Vec<(String, String)>,
+    auth: Option<AuthDataGenerator>,
+}
+
+impl BaseClient {
+    fn new(base_url: String, options: ClientOptions) -> Self {
+        let mut headers = vec![("User-Agent".to_string(), "app-Generated-Rust-Client (Encore/v0.0.0-develop)".to_string())];
+        headers.extend(options.headers);
+        BaseClient {
+            base_url,
+            http: options.http_client.unwrap_or_default(),
+            headers,
+            auth: options.auth,
+        }
+    }
+
+    /// call_typed_api makes an API call, encoding the body as JSON.
+    async fn call_typed_api(
+        &self,
+        method: &str,
+        path: &str,
+        body: Option<serde_json::Value>,
+        mut headers: Vec<(String, String)>,
+        query: Vec<(String, String)>,
+    ) -> Result<reqwest::Response, Error> {
+        let body = body.map(|b| serde_json::to_vec(&b)).transpose()?;
+        headers.push(("Content-Type".to_string(), "application/json".to_string()));
+        self.call_api(method, path, body, headers, query).await
+    }
+
+    /// call_api is used by each generated API method to actually make the request.
+    async fn call_api(
+        &self,
+        method: &str,
+        path: &str,
+        body: Option<Vec<u8>>,
+        headers: Vec<(String, String)>,
+        query: Vec<(String, String)>,
+    ) -> Result<reqwest::Response, Error> {
+        let method = reqwest::Method::from_bytes(method.as_bytes())
+            .map_err(|_| Error::InvalidRequest(format!("invalid HTTP method {:?}", method)))?;
+
+        let mut headers: Vec<(String, String)> = self.headers.iter().cloned().chain(headers).collect();
+
+        // If we have authentication data, add it to the request
+        if let Some(auth) = self.auth.as_ref().and_then(|generate| generate()) {
+            let data = serde_json::to_value(&auth)?;
+            headers.extend(make_params(&data, &[
+                ("x-api-key", "APIKey", false),
+            ]));
+        }
+
+        let mut req = self.http.request(method, format!("{}{}", self.base_url, path));
+        for (name, value) in &headers {
+            req = req.header(name.as_str(), value.as_str());
+        }
+        if !query.is_empty() {
+            req = req.query(&query);
+        }
+        if let Some(body) = body {
+            req = req.body(body);
+        }
+
+        let resp = req.send().await?;
+        if !resp.status().is_success() {
+            return Err(Error::Api(APIError::from_response(resp).await));
+        }
+        Ok(resp)
+    }
+}
+
+/// make_params returns the (name, value) pairs for the given (name, field, is_json) parameters,
+/// skipping any fields that are not set.
+fn make_params(data: &serde_json::Value, params: &[(&str, &str, bool)]) -> Vec<(String, String)> {
+    let mut out = Vec::new();
+    for (name, field, is_json) in params {
+        match &data[*field] {
+            serde_json::Value::Null => {}
+            serde_json::Value::Array(values) if !is_json => {
+                for v in values {
+                    out.push((name.to_string(), param_string(v)));
+                }
+            }
+            v if *is_json => out.push((name.to_string(), v.to_string())),
+            v => out.push((name.to_string(), param_string(v))),
+        }
+    }
+    out
+}
+
+/// param_string converts a JSON value to its string form for use in paths, headers and query strings.
+fn param_string(value: &serde_json::Value) -> String {
+    match value {
+        serde_json::Value::String(s) => s.clone(),
+        v => v.to_string(),
+    }
+}
+
+/// pick returns the fields of data to include in the request body, keyed by their name on the wire.
+fn pick(data: &serde_json::Value, fields: &[(&str, &str)]) -> serde_json::Value {
+    let mut out = serde_json::Map::new();
+    for (name, field) in fields {
+        if let Some(v) = data.get(*field) {
+            out.insert(name.to_string(), v.clone());
+        }
+    }
+    serde_json::Value::Object(out)
+}
+
+/// encode_path percent-encodes a path segment.
+fn encode_path(value: impl std::fmt::Display) -> String {
+    let mut out = String::new();
+    for b in value.to_string().bytes() {
+        match b {
+            b'A'..=b'Z' | b'a'..=b'z' | b'0'..=b'9' | b'-' | b'_' | b'.' | b'~' => out.push(b as char),
+            _ => out.push_str(&format!("%{:02X}", b)),
+        }
+    }
+    out
+}
+
+/// must_be_set returns the value of the response header, or an error with the DataLoss code if it's not set.
+fn must_be_set(headers: &reqwest::header::HeaderMap, name: &str) -> Result<String, Error> {
+    match headers.get(name).and_then(|v| v.to_str().ok()) {
+        Some(v) => Ok(v.to_string()),
+        None => Err(Error::Api(APIError {
+            status: 500,
+            code: ErrCode::DataLoss,
+            message: format!("Header `{}` was unexpectedly not set", name),
+            details: None,
+        })),
+    }
+}
+
+/// parse_header parses the value of the response header.
+fn parse_header<T: std::str::FromStr>(headers: &reqwest::header::HeaderMap, name: &str) -> Result<T, Error> {
+    let value = must_be_set(headers, name)?;
+    value
+        .parse()
+        .map_err(|_| Error::InvalidResponse(format!("invalid value for header {}: {:?}", name, value)))
+}
+
+/// Error is the error returned when calling an API fails.
+#[derive(Debug)]
+pub enum Error {
+    /// The API returned an error.
+    Api(APIError),
+    /// The request could not be sent, or the response could not be read.
+    Http(reqwest::Error),
+    /// The request could not be encoded, or the response could not be decoded.
+    Json(serde_json::Error),
+    /// The request was invalid.
+    InvalidRequest(String),
+    /// The response was invalid.
+    InvalidResponse(String),
+}
+
+impl Error {
+    /// code returns the Encore error code of the error,
+    /// or ErrCode::Unknown if it's not an API error.
+    pub fn code(&self) -> ErrCode {
+        match self {
+            Error::Api(err) => err.code,
+            _ => ErrCode::Unknown,
+        }
+    }
+}
+
+impl std::fmt::Display for Error {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        match self {
+            Error::Api(err) => err.fmt(f),
+            Error::Http(err) => write!(f, "http error: {}", err),
+            Error::Json(err) => write!(f, "json error: {}", err),
+            Error::InvalidRequest(msg) => write!(f, "invalid request: {}", msg),
+            Error::InvalidResponse(msg) => write!(f, "invalid response: {}", msg),
+        }
+    }
+}
+
+impl std::error::Error for Error {
+    fn source(&self) -> Option<&(dyn std::error::Error + 'static)> {
+        match self {
+            Error::Api(err) => Some(err),
+            Error::Http(err) => Some(err),
+            Error::Json(err) => Some(err),
+            _ => None,
+        }
+    }
+}
+
+impl From<reqwest::Error> for Error {
+    fn from(err: reqwest::Error) -> Self {
+        Error::Http(err)
+    }
+}
+
+impl From<serde_json::Error> for Error {
+    fn from(err: serde_json::Error) -> Self {
+        Error::Json(err)
+    }
+}
+
+/// APIError represents a structured error as returned from an Encore application.
+#[derive(Debug, Clone)]
+pub struct APIError {
+    /// The HTTP status code associated with the error.
+    pub status: u16,
+    /// The Encore error code.
+    pub code: ErrCode,
+    /// The error message.
+    pub message: String,
+    /// The error details.
+    pub details: Option<serde_json::Value>,
+}
+
+impl APIError {
+    async fn from_response(resp: reqwest::Response) -> APIError {
+        let status = resp.status().as_u16();
+        let mut err = APIError {
+            status,
+            code: ErrCode::Unknown,
+            message: format!("request failed: status {}", status),
+            details: None,
+        };
+
+        let text = match resp.text().await {
+            Ok(text) => text,
+            Err(e) => {
+                err.message = format!("{}: {}", err.message, e);
+                return err;
+            }
+        };
+        match serde_json::from_str::<serde_json::Value>(&text) {
+            Ok(body) => {
+                let code = body.get("code").and_then(|c| c.as_str()).and_then(ErrCode::parse);
+                let message = body.get("message").and_then(|m| m.as_str());
+                if let (Some(code), Some(message)) = (code, message) {
+                    err.code = code;
+                    err.message = message.to_string();
+                    err.details = body.get("details").filter(|d| !d.is_null()).cloned();
+                } else {
+                    err.message = format!("{}: {}", err.message, body);
+                }
+            }
+            Err(_) => err.message = format!("{}: {}", err.message, text),
+        }
+        err
+    }
+}
+
+impl std::fmt::Display for APIError {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        write!(f, "{}: {}", self.code, self.message)
+    }
+}
+
+impl std::error::Error for APIError {}
+
+/// ErrCode is the error code of an APIError.
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash)]
+pub enum ErrCode {
+    /// OK indicates the operation was successful.
+    OK,
+
+    /// Canceled indicates the operation was canceled (typically by the caller).
+    Canceled,
+
+    /// Unknown error.
+    Unknown,
+
+    /// InvalidArgument indicates client specified an invalid argument.
+    InvalidArgument,
+
+    /// DeadlineExceeded means operation expired before completion.
+    DeadlineExceeded,
+
+    /// NotFound means some requested entity (e.g., file or directory) was not found.
+    NotFound,
+
+    /// AlreadyExists means an attempt to create an entity failed because one already exists.
+    AlreadyExists,
+
+    /// PermissionDenied indicates the caller does not have permission to execute the specified operation.
+    PermissionDenied,
+
+    /// ResourceExhausted indicates some resource has been exhausted.
+    ResourceExhausted,
+
+    /// FailedPrecondition indicates the system is not in a state required for the operation's execution.
+    FailedPrecondition,
+
+    /// Aborted indicates the operation was aborted, typically due to a concurrency issue.
+    Aborted,
+
+    /// OutOfRange means operation was attempted past the valid range.
+    OutOfRange,
+
+    /// Unimplemented indicates operation is not implemented or not supported/enabled in this service.
+    Unimplemented,
+
+    /// Internal errors. Means some invariants expected by underlying system has been broken.
+    Internal,
+
+    /// Unavailable indicates the service is currently unavailable.
+    Unavailable,
+
+    /// DataLoss indicates unrecoverable data loss or corruption.
+    DataLoss,
+
+    /// Unauthenticated indicates the request does not have valid authentication credentials for the operation.
+    Unauthenticated,
+}
+
+impl ErrCode {
+    /// as_str returns the string representation of the error code.
+    pub fn as_str(&self) -> &'static str {
+        match self {
+            ErrCode::OK => "ok",
+            ErrCode::Canceled => "canceled",
+            ErrCode::Unknown => "unknown",
+            ErrCode::InvalidArgument => "invalid_argument",
+            ErrCode::DeadlineExceeded => "deadline_exceeded",
+            ErrCode::NotFound => "not_found",
+            ErrCode::AlreadyExists => "already_exists",
+            ErrCode::PermissionDenied => "permission_denied",
+            ErrCode::ResourceExhausted => "resource_exhausted",
+            ErrCode::FailedPrecondition => "failed_precondition",
+            ErrCode::Aborted => "aborted",
+            ErrCode::OutOfRange => "out_of_range",
+            ErrCode::Unimplemented => "unimplemented",
+            ErrCode::Internal => "internal",
+            ErrCode::Unavailable => "unavailable",
+            ErrCode::DataLoss => "data_loss",
+            ErrCode::Unauthenticated => "unauthenticated",
+        }
+    }
+
+    /// parse parses the string representation of an error code.
+    pub fn parse(code: &str) -> Option<ErrCode> {
+        match code {
+            "ok" => Some(ErrCode::OK),
+            "canceled" => Some(ErrCode::Canceled),
+            "unknown" => Some(ErrCode::Unknown),
+            "invalid_argument" => Some(ErrCode::InvalidArgument),
+            "deadline_exceeded" => Some(ErrCode::DeadlineExceeded),
+            "not_found" => Some(ErrCode::NotFound),
+            "already_exists" => Some(ErrCode::AlreadyExists),
+            "permission_denied" => Some(ErrCode::PermissionDenied),
+            "resource_exhausted" => Some(ErrCode::ResourceExhausted),
+            "failed_precondition" => Some(ErrCode::FailedPrecondition),
+            "aborted" => Some(ErrCode::Aborted),
+            "out_of_range" => Some(ErrCode::OutOfRange),
+            "unimplemented" => Some(ErrCode::Unimplemented),
+            "internal" => Some(ErrCode::Internal),
+            "unavailable" => Some(ErrCode::Unavailable),
+            "data_loss" => Some(ErrCode::DataLoss),
+            "unauthenticated" => Some(ErrCode::Unauthenticated),
+            _ => None,
+        }
+    }
+}
+
+impl std::fmt::Display for ErrCode {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        f.write_str(self.as_str())
+    }
+}
@@ -31,6 +31,12 @@ func Private(ctx context.Context, req *Request) error {
 	return nil
 }
 
+// Optional is an endpoint with optional auth.
+//encore:api auth optional
+func Optional(ctx context.Context, req *Request) error {
+	return nil
+}
+
 -- authentication/auth.go --
 package authentication
 
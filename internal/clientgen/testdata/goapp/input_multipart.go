@@ -0,0 +1,48 @@
+-- go.mod --
+module app
+
+require encore.dev v1.13.4
+
+-- encore.app --
+{"id": ""}
+
+-- svc/api.go --
+package svc
+
+import (
+    "context"
+    "mime/multipart"
+)
+
+type UploadParams struct {
+    Folder      string                  `query:"folder"`
+    Tag         string                  `header:"X-Tag" encore:"optional"`
+    Avatar      *multipart.FileHeader   `json:"avatar" validate:"maxsize=1MB,accept=image/png image/jpeg"`
+    Attachments []*multipart.FileHeader `json:"attachments" encore:"optional"`
+    Description string                  `json:"description"`
+    Public      bool                    `json:"public"`
+}
+
+type File struct {
+    ID string `json:"id"`
+}
+
+// Upload stores the uploaded files.
+//encore:api public method=POST path=/files
+func Upload(ctx context.Context, p *UploadParams) (*File, error) {
+    return nil, nil
+}
+
+type ImportParams struct {
+    Data *multipart.FileHeader `json:"data"`
+}
+
+//encore:api public method=PUT path=/import/:id
+func Import(ctx context.Context, id string, p *ImportParams) error {
+    return nil
+}
+
+//encore:api public method=GET path=/files/:id
+func Get(ctx context.Context, id string) (*File, error) {
+    return nil, nil
+}
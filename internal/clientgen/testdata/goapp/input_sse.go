@@ -0,0 +1,41 @@
+-- go.mod --
+module app
+
+require encore.dev v1.13.4
+
+-- encore.app --
+{"id": ""}
+
+-- svc/api.go --
+package svc
+
+import (
+    "context"
+
+    "encore.dev/sse"
+)
+
+type Params struct {
+    Topic  string `query:"topic"`
+    Cursor string `header:"X-Cursor" encore:"optional"`
+}
+
+type Event struct {
+    Message string `json:"message"`
+}
+
+// Events streams the events of a room.
+//encore:api public path=/events/:room
+func Events(ctx context.Context, room string, p *Params, w *sse.Writer[*Event]) error {
+    return nil
+}
+
+//encore:api public
+func Ticks(ctx context.Context, w *sse.Writer[*Event]) error {
+    return nil
+}
+
+//encore:api public method=POST
+func Send(ctx context.Context, p *Event) error {
+    return nil
+}
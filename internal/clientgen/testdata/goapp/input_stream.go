@@ -0,0 +1,51 @@
+-- go.mod --
+module app
+
+require encore.dev v1.13.4
+
+-- encore.app --
+{"id": ""}
+
+-- svc/api.go --
+package svc
+
+import (
+    "context"
+
+    "encore.dev/stream"
+)
+
+type UploadParams struct {
+    Name string `query:"name"`
+    Tag  string `header:"X-Tag" encore:"optional"`
+}
+
+type File struct {
+    ID string `json:"id"`
+}
+
+// Upload stores an uploaded file.
+//encore:api public method=PUT path=/files/:id
+func Upload(ctx context.Context, id string, p *UploadParams, body *stream.Body) (*File, error) {
+    return nil, nil
+}
+
+type DownloadResponse struct {
+    Disposition string `header:"Content-Disposition"`
+}
+
+// Download streams a stored file.
+//encore:api public method=GET path=/files/:id
+func Download(ctx context.Context, id string) (*DownloadResponse, *stream.Body, error) {
+    return nil, nil, nil
+}
+
+//encore:api public
+func Convert(ctx context.Context, body *stream.Body) (*stream.Body, error) {
+    return body, nil
+}
+
+//encore:api public method=GET
+func Get(ctx context.Context) (*File, error) {
+    return nil, nil
+}
@@ -0,0 +1,34 @@
+-- go.mod --
+module app
+
+require encore.dev v1.13.4
+
+-- encore.app --
+{"id": ""}
+
+-- svc/api.go --
+package svc
+
+import (
+    "context"
+)
+
+type CreateParams struct {
+    // Name is the name of the user.
+    Name    string            `json:"name" validate:"required,min=1,max=100"`
+    Email   string            `json:"email" validate:"email"`
+    Website string            `json:"website" validate:"url,startswith=https://"`
+    Age     int               `json:"age" validate:"min=18,max=130"`
+    Tags    []string          `json:"tags" validate:"max=10"`
+    Labels  map[string]string `json:"labels" validate:"len=2"`
+    Code    string            `json:"code" validate:"endswith=.v1,oneof=a.v1 b.v1"`
+    Limit   int               `query:"limit" validate:"max=50"`
+    Nick    string            `json:"nick" validate:"omitempty,min=3"`
+    Emails  []string          `json:"emails" validate:"max=5,dive,email"`
+    Contact string            `json:"contact" validate:"email|url"`
+}
+
+//encore:api public method=POST
+func Create(ctx context.Context, p *CreateParams) error {
+    return nil
+}
@@ -0,0 +1,46 @@
+-- go.mod --
+module app
+
+require encore.dev v1.13.4
+
+-- encore.app --
+{"id": ""}
+
+-- svc/api.go --
+package svc
+
+import (
+    "context"
+
+    "encore.dev/websocket"
+)
+
+type Params struct {
+    Nickname string `query:"nickname"`
+    Token    string `header:"X-Token" encore:"optional"`
+}
+
+type ChatMessage struct {
+    Text string `json:"text"`
+}
+
+type ChatEvent struct {
+    From string `json:"from"`
+    Text string `json:"text"`
+}
+
+// Chat joins the chat of a room.
+//encore:api public path=/chat/:room
+func Chat(ctx context.Context, room string, p *Params, conn *websocket.Conn[*ChatMessage, *ChatEvent]) error {
+    return nil
+}
+
+//encore:api public
+func Echo(ctx context.Context, conn *websocket.Conn[*ChatMessage, *ChatMessage]) error {
+    return nil
+}
+
+//encore:api public method=POST
+func Send(ctx context.Context, p *ChatMessage) error {
+    return nil
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -61,6 +62,8 @@ type typescript struct {
 
 	seenJSON           bool // true if a JSON type was seen
 	seenHeaderResponse bool // true if we've seen a header used in a response object
+	seenEvents         bool // true if we've seen an endpoint streaming server-sent events
+	seenMultipart      bool // true if we've seen an endpoint accepting file uploads
 	hasAuth            bool // true if we've seen an authentication handler
 	authIsComplexType  bool // true if the auth type is a complex type
 }
@@ -188,7 +191,13 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 
 		// Signature
 		indent()
-		fmt.Fprintf(ts, "public async %s(", ts.memberName(rpc.Name))
+		if rpc.ServerSentEvents {
+			// The events are yielded by an async generator.
+			ts.seenEvents = true
+			fmt.Fprintf(ts, "public async *%s(", ts.memberName(rpc.Name))
+		} else {
+			fmt.Fprintf(ts, "public async %s(", ts.memberName(rpc.Name))
+		}
 
 		if rpc.Proto == meta.RPC_RAW {
 			ts.WriteString("method: ")
@@ -263,6 +272,14 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 			ts.WriteString("body?: BodyInit, options?: CallParameters")
 		}
 
+		// Streamed request bodies are sent as is.
+		if rpc.StreamedRequestBody {
+			if nParams > 0 || rpc.RequestSchema != nil {
+				ts.WriteString(", ")
+			}
+			ts.WriteString("body: BodyInit")
+		}
+
 		var direction streamDirection
 
 		if rpc.StreamingRequest && rpc.StreamingResponse {
@@ -281,7 +298,11 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 			}
 		}
 
-		ts.WriteString("): Promise<")
+		if rpc.ServerSentEvents {
+			ts.WriteString("): AsyncGenerator<")
+		} else {
+			ts.WriteString("): Promise<")
+		}
 		if isStream {
 			switch direction {
 			case InOut:
@@ -301,6 +322,9 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 				writeTypOrVoid(ns, rpc.ResponseSchema, 0)
 				ts.WriteString(">")
 			}
+		} else if rpc.StreamedResponseBody {
+			// The body is streamed from the response, whose headers hold the rest of it.
+			ts.WriteString("globalThis.Response")
 		} else if rpc.ResponseSchema != nil {
 			ts.writeTyp(ns, rpc.ResponseSchema, 0)
 		} else if rpc.Proto == meta.RPC_RAW {
@@ -497,7 +521,20 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 
 		// Generate the body
 		if len(reqEnc.BodyParameters) > 0 {
-			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
+			if rpc.MultipartRequest {
+				// Requests containing files are sent as a multipart form
+				ts.seenMultipart = true
+				body = "form"
+
+				dict := make(map[string]string)
+				for _, field := range reqEnc.BodyParameters {
+					dict[field.WireFormat] = ts.Dot("params", field.SrcName)
+				}
+
+				w.WriteString("// Construct a multipart form with the fields which we want encoded within the body (excluding query string or header fields)\nconst form = encodeForm(")
+				ts.Values(w, dict)
+				w.WriteString(")\n\n")
+			} else if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
 				// In the simple case we can just encode the params as the body directly
 				body = "JSON.stringify(params)"
 			} else {
@@ -516,9 +553,20 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 		}
 	}
 
-	// Build the call to callTypedAPI
+	// Build the call to callTypedAPI, callEventsAPI for server-sent events,
+	// or callAPI for streamed request bodies and multipart forms, whose content type is set by fetch
+	apiFn := "callTypedAPI"
+	if rpc.ServerSentEvents {
+		apiFn = "callEventsAPI"
+	} else if rpc.StreamedRequestBody {
+		apiFn = "callAPI"
+		body = "body"
+	} else if rpc.MultipartRequest {
+		apiFn = "callAPI"
+	}
 	callAPI := fmt.Sprintf(
-		"this.baseClient.callTypedAPI(\"%s\", `%s`",
+		"this.baseClient.%s(\"%s\", `%s`",
+		apiFn,
 		rpcEncoding.DefaultMethod,
 		rpcPath,
 	)
@@ -546,6 +594,18 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 	}
 	callAPI += ")"
 
+	// Server-sent events are yielded as they're received
+	if rpc.ServerSentEvents {
+		w.WriteStringf("yield* %s\n", callAPI)
+		return nil
+	}
+
+	// Streamed response bodies are read from the response by the caller
+	if rpc.StreamedResponseBody {
+		w.WriteStringf("return await %s\n", callAPI)
+		return nil
+	}
+
 	// If there's no response schema, we can just return the call to the API directly
 	if rpc.ResponseSchema == nil {
 		w.WriteStringf("await %s\n", callAPI)
@@ -1187,7 +1247,67 @@ class BaseClient {
             headers: { "Content-Type": "application/json", ...params?.headers }
         });
     }
+`)
 
+	if ts.seenEvents {
+		ts.WriteString(`
+    // callEventsAPI makes a call to an API streaming server-sent events,
+    // and yields the data of each event as it's received
+    public async *callEventsAPI(method: string, path: string, body?: BodyInit, params?: CallParameters): AsyncGenerator<any> {
+        const response = await this.callTypedAPI(method, path, body, {
+            ...params,
+            headers: { "Accept": "text/event-stream", ...params?.headers }
+        })
+        if (response.body === null) {
+            return
+        }
+
+        const reader = response.body.pipeThrough(new TextDecoderStream()).getReader()
+        let buffer = ""
+        try {
+            while (true) {
+                const { done, value } = await reader.read()
+                if (done) {
+                    return
+                }
+                buffer = (buffer + value).replace(/\r\n?/g, "\n")
+
+                // Events are separated by blank lines
+                let end: number
+                while ((end = buffer.indexOf("\n\n")) >= 0) {
+                    let name = "message"
+                    let data: string | undefined
+                    for (const line of buffer.slice(0, end).split("\n")) {
+                        const sep = line.indexOf(":")
+                        const field = sep < 0 ? line : line.slice(0, sep)
+                        const value = sep < 0 ? "" : line.slice(line[sep + 1] === " " ? sep + 2 : sep + 1)
+                        if (field === "event") {
+                            name = value
+                        } else if (field === "data") {
+                            data = data === undefined ? value : data + "\n" + value
+                        }
+                    }
+                    buffer = buffer.slice(end + 2)
+
+                    if (data === undefined) {
+                        continue
+                    } else if (name === "error") {
+                        // The API failed after the stream was established
+                        const err = JSON.parse(data)
+                        throw new APIError(response.status, isAPIErrorResponse(err) ? err : { code: ErrCode.Unknown, message: data })
+                    }
+                    yield JSON.parse(data)
+                }
+            }
+        } finally {
+            // Close the connection if the caller stops iterating early
+            reader.cancel().catch(() => {})
+        }
+    }
+`)
+	}
+
+	ts.WriteString(`
     // callAPI is used by each generated API method to actually make the request
     public async callAPI(method: string, path: string, body?: BodyInit, params?: CallParameters): Promise<Response> {
         let { query, headers, ...rest } = params ?? {}
@@ -1284,6 +1404,27 @@ function makeRecord<K extends string | number | symbol, V>(record: Record<K, V |
 }
 `)
 
+	if ts.seenMultipart {
+		ts.WriteString(`
+
+// encodeForm builds a multipart form from the given fields, skipping undefined values.
+// Files are appended as they are, and any other values are converted to strings.
+function encodeForm(fields: Record<string, any>): FormData {
+    const form = new FormData()
+    for (const key in fields) {
+        const val = fields[key]
+        if (val === undefined) {
+            continue
+        }
+        for (const v of Array.isArray(val) ? val : [val]) {
+            form.append(key, v instanceof Blob ? v : String(v))
+        }
+    }
+    return form
+}
+`)
+	}
+
 	if ts.seenHeaderResponse {
 		ts.WriteString(`
 
@@ -1334,6 +1475,8 @@ func (ts *typescript) builtinType(typ schema.Builtin) string {
 		return "string"
 	case schema.Builtin_USER_ID:
 		return "string"
+	case schema.Builtin_FILE:
+		return "Blob"
 	default:
 		ts.errorf("unknown builtin type %v", typ)
 		return "any"
@@ -1477,7 +1620,9 @@ func (ts *typescript) writeTyp(ns string, typ *schema.Type, numIndents int) {
 		}
 
 		for i, field := range fields {
-			if field.Doc != "" {
+			validationTags := tsValidationTags(field.Typ)
+			hasDoc := field.Doc != "" || len(validationTags) > 0
+			if hasDoc {
 				scanner := bufio.NewScanner(strings.NewReader(field.Doc))
 				indent()
 				ts.WriteString("/**\n")
@@ -1487,6 +1632,13 @@ func (ts *typescript) writeTyp(ns string, typ *schema.Type, numIndents int) {
 					ts.WriteString(scanner.Text())
 					ts.WriteByte('\n')
 				}
+				for _, tag := range validationTags {
+					indent()
+					ts.WriteString(" * ")
+					// Patterns may contain "*/", which would end the comment.
+					ts.WriteString(strings.ReplaceAll(tag, "*/", "*\\/"))
+					ts.WriteByte('\n')
+				}
 				indent()
 				ts.WriteString(" */\n")
 			}
@@ -1503,7 +1655,7 @@ func (ts *typescript) writeTyp(ns string, typ *schema.Type, numIndents int) {
 
 			// Add another empty line if we have a doc comment
 			// and this was not the last field.
-			if field.Doc != "" && i < len(fields)-1 {
+			if hasDoc && i < len(fields)-1 {
 				ts.WriteByte('\n')
 			}
 		}
@@ -1919,3 +2071,60 @@ func stringIsOnly(str string, predicate func(r rune) bool) bool {
 	}
 	return true
 }
+
+// tsValidationTags returns the JSDoc tags describing the validation rules
+// of a field of the given type, using the annotations understood by
+// tools generating JSON Schemas from TypeScript types.
+func tsValidationTags(typ *schema.Type) []string {
+	expr := typ.GetValidation()
+	if expr == nil {
+		return nil
+	}
+
+	lenTag := "Length"
+	for typ.GetPointer() != nil {
+		typ = typ.GetPointer().Base
+	}
+	if typ.GetList() != nil {
+		lenTag = "Items"
+	} else if typ.GetMap() != nil {
+		lenTag = "Properties"
+	}
+
+	var tags []string
+	var addTags func(expr *schema.ValidationExpr)
+	addTags = func(expr *schema.ValidationExpr) {
+		if and := expr.GetAnd(); and != nil {
+			for _, e := range and.Exprs {
+				addTags(e)
+			}
+			return
+		}
+
+		switch r := expr.GetRule().GetRule().(type) {
+		case *schema.ValidationRule_MinLen:
+			tags = append(tags, fmt.Sprintf("@min%s %d", lenTag, r.MinLen))
+		case *schema.ValidationRule_MaxLen:
+			tags = append(tags, fmt.Sprintf("@max%s %d", lenTag, r.MaxLen))
+		case *schema.ValidationRule_MinVal:
+			tags = append(tags, "@minimum "+strconv.FormatFloat(r.MinVal, 'g', -1, 64))
+		case *schema.ValidationRule_MaxVal:
+			tags = append(tags, "@maximum "+strconv.FormatFloat(r.MaxVal, 'g', -1, 64))
+		case *schema.ValidationRule_StartsWith:
+			tags = append(tags, "@pattern ^"+regexp.QuoteMeta(r.StartsWith))
+		case *schema.ValidationRule_EndsWith:
+			tags = append(tags, "@pattern "+regexp.QuoteMeta(r.EndsWith)+"$")
+		case *schema.ValidationRule_MatchesRegexp:
+			tags = append(tags, "@pattern "+r.MatchesRegexp)
+		case *schema.ValidationRule_Is_:
+			switch r.Is {
+			case schema.ValidationRule_EMAIL:
+				tags = append(tags, "@format email")
+			case schema.ValidationRule_URL:
+				tags = append(tags, "@format uri")
+			}
+		}
+	}
+	addTags(expr)
+	return tags
+}
@@ -0,0 +1,792 @@
+// Package openapiimport generates Encore services from OpenAPI specifications,
+// making it easier to migrate existing APIs to Encore.
+//
+// The generated service contains typed request and response structs
+// for the schemas in the specification, and endpoint stubs for every operation.
+package openapiimport
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	. "github.com/dave/jennifer/jen"
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"encr.dev/pkg/idents"
+)
+
+// Load loads the OpenAPI specification at the given path, resolving any references.
+func Load(ctx context.Context, path string) (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	loader.Context = ctx
+	loader.IsExternalRefsAllowed = true
+	spec, err := loader.LoadFromFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load openapi spec")
+	}
+	if err := spec.Validate(ctx, openapi3.DisableExamplesValidation()); err != nil {
+		return nil, errors.Wrap(err, "invalid openapi spec")
+	}
+	return spec, nil
+}
+
+// Generate generates an Encore service named svcName implementing the API described by spec.
+// It returns the contents of the generated files, keyed by file name.
+func Generate(spec *openapi3.T, svcName string) (files map[string][]byte, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = errors.Newf("could not generate service: %v", e)
+		}
+	}()
+
+	pkgName := strings.ToLower(idents.Convert(svcName, idents.CamelCase))
+	if !token.IsIdentifier(pkgName) || token.IsKeyword(pkgName) {
+		return nil, errors.Newf("invalid service name %q", svcName)
+	}
+
+	g := &generator{
+		spec:       spec,
+		types:      NewFile(pkgName),
+		api:        NewFile(pkgName),
+		names:      make(map[string]bool),
+		components: make(map[*openapi3.Schema]string),
+		inline:     make(map[*openapi3.Schema]string),
+	}
+	for _, line := range strings.Split(g.packageDoc(pkgName), "\n") {
+		g.api.PackageComment(strings.TrimSpace("// " + line))
+	}
+	g.genComponents()
+	if err := g.genEndpoints(); err != nil {
+		return nil, err
+	}
+
+	files = make(map[string][]byte)
+	for name, f := range map[string]*File{"types.go": g.types, pkgName + ".go": g.api} {
+		var buf strings.Builder
+		if err := f.Render(&buf); err != nil {
+			return nil, errors.Wrapf(err, "render %s", name)
+		}
+		files[name] = []byte(buf.String())
+	}
+	return files, nil
+}
+
+type generator struct {
+	spec  *openapi3.T
+	types *File // types.go
+	api   *File // <svc>.go
+
+	// names are the identifiers declared in the package so far.
+	names map[string]bool
+	// components maps the component schemas to their type names.
+	components map[*openapi3.Schema]string
+	// inline maps inline object schemas to the type names declared for them.
+	inline map[*openapi3.Schema]string
+	// pending are the inline types yet to be declared.
+	pending []pendingDecl
+}
+
+type pendingDecl struct {
+	name string
+	s    *openapi3.Schema
+}
+
+const (
+	encodingJSON = "encoding/json"
+	errsPkg      = "encore.dev/beta/errs"
+	uuidPkg      = "encore.dev/types/uuid"
+)
+
+func (g *generator) packageDoc(pkgName string) string {
+	doc := "Package " + pkgName + " implements"
+	if info := g.spec.Info; info != nil && info.Title != "" {
+		doc += " the " + info.Title + " API"
+		if info.Version != "" {
+			doc += " (version " + info.Version + ")"
+		}
+	} else {
+		doc += " an API"
+	}
+	doc += ".\n\nIt was generated from an OpenAPI specification; the endpoints are stubs\nthat must be implemented."
+	return doc
+}
+
+// genComponents declares a type for every schema in the components section.
+func (g *generator) genComponents() {
+	if g.spec.Components == nil {
+		return
+	}
+	keys := sortedKeys(g.spec.Components.Schemas)
+
+	// Allocate all names up front, so the schemas can reference each other.
+	for _, key := range keys {
+		if ref := g.spec.Components.Schemas[key]; ref != nil && ref.Value != nil {
+			if _, ok := g.components[ref.Value]; !ok {
+				g.components[ref.Value] = g.alloc(goName(key))
+			}
+		}
+	}
+	for _, key := range keys {
+		ref := g.spec.Components.Schemas[key]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		name := g.components[ref.Value]
+		g.declare(name, ref.Value)
+	}
+}
+
+// declare declares the named type name for the schema s.
+func (g *generator) declare(name string, s *openapi3.Schema) {
+	decl := g.types.Null()
+	if s.Description != "" {
+		desc := formatDoc(s.Description)
+		if !strings.HasPrefix(desc, name+" ") {
+			desc = name + " " + desc
+		}
+		decl.Add(comment(desc))
+	}
+	defer g.flush()
+
+	if isStruct(s) {
+		decl.Type().Id(name).Struct(g.structFields(name, s)...)
+		g.types.Line()
+		return
+	}
+
+	decl.Type().Id(name).Add(g.typeOf(&openapi3.SchemaRef{Value: s}, name))
+	g.types.Line()
+
+	// Declare constants for string enums.
+	if s.Type == openapi3.TypeString && len(s.Enum) > 0 {
+		var consts []Code
+		seen := make(map[string]bool)
+		for _, v := range s.Enum {
+			str, ok := v.(string)
+			if !ok || seen[str] {
+				continue
+			}
+			seen[str] = true
+			consts = append(consts, Id(g.alloc(name+goName(str))).Id(name).Op("=").Lit(str))
+		}
+		if len(consts) > 0 {
+			g.types.Const().Defs(consts...)
+			g.types.Line()
+		}
+	}
+}
+
+// structFields returns the fields for the object schema s,
+// declared as part of the type with the given name.
+func (g *generator) structFields(name string, s *openapi3.Schema) []Code {
+	props, required := objectProps(s)
+	var fields []Code
+	used := make(map[string]bool)
+	for _, key := range sortedKeys(props) {
+		prop := props[key]
+		fieldName := uniqueName(used, goName(key))
+		fields = append(fields, g.field(fieldName, prop, name+fieldName, required[key], map[string]string{"json": key}))
+	}
+	return fields
+}
+
+// field returns a struct field with the given name and the type of the schema ref.
+// The tags map contains the wire name for each tag.
+func (g *generator) field(name string, ref *openapi3.SchemaRef, typeHint string, required bool, tags map[string]string) Code {
+	typ := g.typeOf(ref, typeHint)
+	if ref.Value != nil && ref.Value.Nullable && !isReferenceType(ref.Value) {
+		typ = Op("*").Add(typ)
+	}
+	if !required {
+		if v, ok := tags["json"]; ok {
+			tags["json"] = v + ",omitempty"
+		}
+		tags["encore"] = "optional"
+	}
+
+	f := Id(name).Add(typ).Tag(tags)
+	if ref.Value != nil && ref.Value.Description != "" {
+		return comment(formatDoc(ref.Value.Description)).Add(f)
+	}
+	return f
+}
+
+// typeOf returns the Go type for the schema ref.
+// If the schema is an inline object, a type named nameHint is declared for it.
+func (g *generator) typeOf(ref *openapi3.SchemaRef, nameHint string) *Statement {
+	if ref == nil || ref.Value == nil {
+		return Qual(encodingJSON, "RawMessage")
+	}
+	s := ref.Value
+	if name, ok := g.components[s]; ok && ref.Ref != "" {
+		return Id(name)
+	}
+
+	switch {
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0:
+		// There is no Go equivalent of union types; leave it to the implementation to decode.
+		return Qual(encodingJSON, "RawMessage")
+	case isObject(s) && !isStruct(s):
+		if ap := s.AdditionalProperties.Schema; ap != nil {
+			return Map(String()).Add(g.typeOf(ap, nameHint+"Value"))
+		}
+		return Map(String()).Qual(encodingJSON, "RawMessage")
+	case isStruct(s):
+		if name, ok := g.inline[s]; ok {
+			return Id(name)
+		}
+		name := g.alloc(nameHint)
+		g.inline[s] = name
+		g.pending = append(g.pending, pendingDecl{name: name, s: s})
+		return Id(name)
+	}
+
+	switch s.Type {
+	case openapi3.TypeString:
+		switch s.Format {
+		case "date-time":
+			return Qual("time", "Time")
+		case "uuid":
+			return Qual(uuidPkg, "UUID")
+		case "byte", "binary":
+			return Index().Byte()
+		}
+		return String()
+	case openapi3.TypeInteger:
+		switch s.Format {
+		case "int32":
+			return Int32()
+		case "int64":
+			return Int64()
+		}
+		return Int()
+	case openapi3.TypeNumber:
+		if s.Format == "float" {
+			return Float32()
+		}
+		return Float64()
+	case openapi3.TypeBoolean:
+		return Bool()
+	case openapi3.TypeArray:
+		return Index().Add(g.typeOf(s.Items, nameHint+"Item"))
+	}
+	return Qual(encodingJSON, "RawMessage")
+}
+
+// flush declares the pending inline types.
+func (g *generator) flush() {
+	for len(g.pending) > 0 {
+		d := g.pending[0]
+		g.pending = g.pending[1:]
+		g.declare(d.name, d.s)
+	}
+}
+
+// alloc allocates a unique identifier in the package, based on name.
+func (g *generator) alloc(name string) string {
+	return uniqueName(g.names, name)
+}
+
+// genEndpoints generates an endpoint for every operation in the spec.
+func (g *generator) genEndpoints() error {
+	type op struct {
+		path, method string
+		item         *openapi3.PathItem
+		op           *openapi3.Operation
+		name         string
+	}
+
+	// Allocate the endpoint names before generating their request and response types,
+	// so the endpoints get the names without a numeric suffix.
+	var ops []*op
+	for _, path := range sortedKeys(g.spec.Paths) {
+		item := g.spec.Paths[path]
+		operations := item.Operations()
+		for _, method := range methods {
+			if o := operations[method]; o != nil {
+				ops = append(ops, &op{path: path, method: method, item: item, op: o})
+			}
+		}
+	}
+	for _, o := range ops {
+		name := o.op.OperationID
+		if name == "" {
+			name = strings.ToLower(o.method) + " " + o.path
+		}
+		o.name = g.alloc(goName(name))
+	}
+
+	for i, o := range ops {
+		if i > 0 {
+			g.api.Line()
+		}
+		if err := g.genEndpoint(o.name, o.method, o.path, o.item, o.op); err != nil {
+			return errors.Wrapf(err, "%s %s", o.method, o.path)
+		}
+	}
+	return nil
+}
+
+// methods are the HTTP methods supported by Encore, in the order endpoints are generated.
+var methods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+	http.MethodPatch, http.MethodDelete, http.MethodOptions, http.MethodTrace,
+}
+
+func (g *generator) genEndpoint(name, method, path string, item *openapi3.PathItem, op *openapi3.Operation) error {
+	params := mergeParams(item.Parameters, op.Parameters)
+
+	// Rewrite the path parameters into Encore syntax.
+	var (
+		pathArgs []Code
+		segments = strings.Split(path, "/")
+		argNames = make(map[string]bool)
+	)
+	for i, seg := range segments {
+		if !strings.HasPrefix(seg, "{") || !strings.HasSuffix(seg, "}") {
+			if strings.ContainsAny(seg, "{}") {
+				return errors.Newf("unsupported path segment %q", seg)
+			}
+			continue
+		}
+		paramName := seg[1 : len(seg)-1]
+		arg := uniqueName(argNames, paramIdent(paramName))
+		segments[i] = ":" + arg
+
+		typ := String()
+		if p := params.GetByInAndName(openapi3.ParameterInPath, paramName); p != nil {
+			if s := paramSchema(p); s.Value.Type != openapi3.TypeObject && s.Value.Type != openapi3.TypeArray {
+				typ = g.typeOf(s, name+goName(paramName))
+			}
+		}
+		pathArgs = append(pathArgs, Id(arg).Add(typ))
+	}
+	encorePath := strings.Join(segments, "/")
+
+	var doc []string
+	if op.Summary != "" {
+		doc = append(doc, formatDoc(op.Summary))
+	}
+	if op.Description != "" && op.Description != op.Summary {
+		doc = append(doc, formatDoc(op.Description))
+	}
+	if op.Deprecated {
+		doc = append(doc, "Deprecated: this endpoint is deprecated.")
+	}
+	if len(doc) == 0 {
+		doc = append(doc, fmt.Sprintf("%s implements %s %s.", name, method, path))
+	}
+	for _, p := range params {
+		if p.Value.In == openapi3.ParameterInCookie {
+			doc = append(doc, fmt.Sprintf("The cookie parameter %q is not supported and must be handled separately.", p.Value.Name))
+		}
+	}
+
+	access := "public"
+	if g.requiresAuth(op) {
+		access = "auth"
+	}
+
+	body := op.RequestBody
+	if body != nil && body.Value != nil && len(body.Value.Content) > 0 && jsonMediaType(body.Value.Content) == nil {
+		// The request body is not JSON; generate a raw endpoint so the implementation
+		// has full control over the request.
+		doc = append(doc, "It is a raw endpoint since the request body is not JSON.")
+		g.api.Add(comment(strings.Join(doc, "\n\n"))).
+			Comment(fmt.Sprintf("//encore:api %s raw method=%s path=%s", access, method, encorePath))
+		g.api.Func().Id(name).Params(
+			Id("w").Qual("net/http", "ResponseWriter"),
+			Id("req").Op("*").Qual("net/http", "Request"),
+		).Block(
+			Comment("TODO: implement"),
+			Qual("net/http", "Error").Call(Id("w"), Lit("not implemented"), Qual("net/http", "StatusNotImplemented")),
+		)
+		return nil
+	}
+
+	args := pathArgs
+	if reqType := g.requestType(name, params, body); reqType != nil {
+		args = append(args, Id("p").Op("*").Id(reqType.name))
+		if reqType.note != "" {
+			doc = append(doc, reqType.note)
+		}
+	}
+	g.flush()
+
+	var results []Code
+	if respType := g.responseType(name, op); respType != nil {
+		results = append(results, Op("*").Id(respType.name))
+		if respType.note != "" {
+			doc = append(doc, respType.note)
+		}
+	}
+	g.flush()
+	results = append(results, Error())
+
+	g.api.Add(comment(strings.Join(doc, "\n\n"))).
+		Comment(fmt.Sprintf("//encore:api %s method=%s path=%s", access, method, encorePath))
+
+	notImplemented := Op("&").Qual(errsPkg, "Error").Values(Dict{
+		Id("Code"):    Qual(errsPkg, "Unimplemented"),
+		Id("Message"): Lit("not implemented"),
+	})
+	var ret *Statement
+	if len(results) == 2 {
+		ret = Return(Nil(), notImplemented)
+	} else {
+		ret = Return(notImplemented)
+	}
+	g.api.Func().Id(name).Params(append([]Code{Id("ctx").Qual("context", "Context")}, args...)...).
+		Params(results...).Block(Comment("TODO: implement"), ret)
+	return nil
+}
+
+// requiresAuth reports whether the operation requires authentication.
+func (g *generator) requiresAuth(op *openapi3.Operation) bool {
+	reqs := g.spec.Security
+	if op.Security != nil {
+		reqs = *op.Security
+	}
+	for _, req := range reqs {
+		// An empty requirement means authentication is optional.
+		if len(req) == 0 {
+			return false
+		}
+	}
+	return len(reqs) > 0
+}
+
+// namedType describes a request or response type used by an endpoint.
+type namedType struct {
+	name string
+	// note is a note about the type to add to the endpoint documentation, if any.
+	note string
+}
+
+// requestType returns the request type for an endpoint,
+// or nil if the endpoint takes no request data.
+func (g *generator) requestType(endpoint string, params openapi3.Parameters, body *openapi3.RequestBodyRef) *namedType {
+	var fields []Code
+	used := make(map[string]bool)
+	for _, p := range params {
+		p := p.Value
+		var tag string
+		switch p.In {
+		case openapi3.ParameterInQuery:
+			tag = "query"
+		case openapi3.ParameterInHeader:
+			tag = "header"
+		default:
+			continue
+		}
+		fieldName := uniqueName(used, goName(p.Name))
+		f := g.field(fieldName, paramSchema(p), endpoint+fieldName, p.Required, map[string]string{tag: p.Name})
+		if p.Description != "" && (p.Schema == nil || p.Schema.Value == nil || p.Schema.Value.Description == "") {
+			f = comment(formatDoc(p.Description)).Add(f)
+		}
+		fields = append(fields, f)
+	}
+
+	var bodySchema *openapi3.SchemaRef
+	if body != nil && body.Value != nil {
+		if mt := jsonMediaType(body.Value.Content); mt != nil {
+			bodySchema = mt.Schema
+		}
+	}
+
+	if bodySchema == nil || bodySchema.Value == nil {
+		if len(fields) == 0 {
+			return nil
+		}
+		name := g.alloc(endpoint + "Params")
+		g.types.Add(comment(fmt.Sprintf("%s contains the parameters for %s.", name, endpoint))).
+			Type().Id(name).Struct(fields...)
+		g.types.Line()
+		return &namedType{name: name}
+	}
+
+	s := bodySchema.Value
+	if len(fields) == 0 && isStruct(s) {
+		// Use the body type directly, if possible.
+		g.typeOf(bodySchema, endpoint+"Request")
+		if name := g.nameOf(s); name != "" {
+			return &namedType{name: name}
+		}
+	}
+
+	name := g.alloc(endpoint + "Request")
+	var note string
+	if isStruct(s) {
+		props, required := objectProps(s)
+		for _, key := range sortedKeys(props) {
+			fieldName := uniqueName(used, goName(key))
+			fields = append(fields, g.field(fieldName, props[key], name+fieldName, required[key], map[string]string{"json": key}))
+		}
+	} else {
+		fields = append(fields, g.field("Body", bodySchema, name+"Body", true, map[string]string{"json": "body"}))
+		note = fmt.Sprintf("The request body is wrapped in the Body field of %s since Encore requests must be structs;\nclients must be updated accordingly.", name)
+	}
+
+	g.types.Add(comment(fmt.Sprintf("%s is the request for %s.", name, endpoint))).
+		Type().Id(name).Struct(fields...)
+	g.types.Line()
+	return &namedType{name: name, note: note}
+}
+
+// responseType returns the response type for an endpoint,
+// or nil if the endpoint returns no data.
+func (g *generator) responseType(endpoint string, op *openapi3.Operation) *namedType {
+	ref := successResponse(op.Responses)
+	if ref == nil || ref.Value == nil {
+		return nil
+	}
+	mt := jsonMediaType(ref.Value.Content)
+	if mt == nil || mt.Schema == nil || mt.Schema.Value == nil {
+		return nil
+	}
+
+	s := mt.Schema.Value
+	if isStruct(s) {
+		g.typeOf(mt.Schema, endpoint+"Response")
+		if name := g.nameOf(s); name != "" {
+			return &namedType{name: name}
+		}
+	}
+
+	name := g.alloc(endpoint + "Response")
+	field := g.field("Body", mt.Schema, name+"Body", true, map[string]string{"json": "body"})
+	g.types.Add(comment(fmt.Sprintf("%s is the response for %s.", name, endpoint))).
+		Type().Id(name).Struct(field)
+	g.types.Line()
+	return &namedType{
+		name: name,
+		note: fmt.Sprintf("The response body is wrapped in the Body field of %s since Encore responses must be structs;\nclients must be updated accordingly.", name),
+	}
+}
+
+// nameOf returns the name of the struct type declared for s, or "" if there is none.
+func (g *generator) nameOf(s *openapi3.Schema) string {
+	if name, ok := g.components[s]; ok && isStruct(s) {
+		return name
+	}
+	return g.inline[s]
+}
+
+// successResponse returns the response for the first successful status code.
+func successResponse(responses openapi3.Responses) *openapi3.ResponseRef {
+	var codes []int
+	for code := range responses {
+		if n, err := strconv.Atoi(code); err == nil && n >= 200 && n < 300 {
+			codes = append(codes, n)
+		}
+	}
+	sort.Ints(codes)
+	if len(codes) > 0 {
+		return responses[strconv.Itoa(codes[0])]
+	}
+	if r := responses["2XX"]; r != nil {
+		return r
+	}
+	return responses["default"]
+}
+
+// jsonMediaType returns the JSON media type in content, if any.
+func jsonMediaType(content openapi3.Content) *openapi3.MediaType {
+	for _, key := range sortedKeys(content) {
+		mt := strings.ToLower(strings.TrimSpace(strings.Split(key, ";")[0]))
+		if mt == "application/json" || strings.HasSuffix(mt, "+json") || mt == "*/*" {
+			return content[key]
+		}
+	}
+	return nil
+}
+
+// mergeParams merges the path item parameters with the operation parameters,
+// where the operation parameters take precedence.
+func mergeParams(itemParams, opParams openapi3.Parameters) openapi3.Parameters {
+	var result openapi3.Parameters
+	for _, p := range itemParams {
+		if p.Value != nil && opParams.GetByInAndName(p.Value.In, p.Value.Name) == nil {
+			result = append(result, p)
+		}
+	}
+	for _, p := range opParams {
+		if p.Value != nil {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// paramSchema returns the schema of the parameter, defaulting to a string.
+// References are dropped, since Encore only supports builtin types for parameters.
+func paramSchema(p *openapi3.Parameter) *openapi3.SchemaRef {
+	if p.Schema != nil && p.Schema.Value != nil {
+		return p.Schema.Value.NewRef()
+	}
+	for _, mt := range p.Content {
+		if mt.Schema != nil && mt.Schema.Value != nil {
+			return mt.Schema.Value.NewRef()
+		}
+	}
+	return openapi3.NewStringSchema().NewRef()
+}
+
+// isObject reports whether s describes an object with properties, or is composed using allOf.
+func isObject(s *openapi3.Schema) bool {
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		return false
+	}
+	return s.Type == openapi3.TypeObject || (s.Type == "" && (len(s.Properties) > 0 || len(s.AllOf) > 0))
+}
+
+// isStruct reports whether s is an object schema with a fixed set of properties,
+// represented as a struct.
+func isStruct(s *openapi3.Schema) bool {
+	if !isObject(s) {
+		return false
+	}
+	props, _ := objectProps(s)
+	return len(props) > 0 || len(s.AllOf) > 0
+}
+
+// isReferenceType reports whether the Go type for s can already represent a missing value.
+func isReferenceType(s *openapi3.Schema) bool {
+	switch {
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0:
+		return true
+	case isObject(s):
+		return !isStruct(s)
+	case s.Type == openapi3.TypeString:
+		return s.Format == "byte" || s.Format == "binary"
+	}
+	return s.Type != openapi3.TypeInteger && s.Type != openapi3.TypeNumber && s.Type != openapi3.TypeBoolean
+}
+
+// objectProps returns the properties of the object schema s, merging any allOf schemas,
+// together with the set of required properties.
+func objectProps(s *openapi3.Schema) (props openapi3.Schemas, required map[string]bool) {
+	props = make(openapi3.Schemas)
+	required = make(map[string]bool)
+	var merge func(s *openapi3.Schema)
+	merge = func(s *openapi3.Schema) {
+		for _, sub := range s.AllOf {
+			if sub.Value != nil {
+				merge(sub.Value)
+			}
+		}
+		for k, v := range s.Properties {
+			props[k] = v
+		}
+		for _, k := range s.Required {
+			required[k] = true
+		}
+	}
+	merge(s)
+	return props, required
+}
+
+// initialisms are the words written in all caps in Go identifiers.
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true,
+	"EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "QPS": true, "RAM": true, "RPC": true, "SLA": true,
+	"SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true,
+	"UDP": true, "UI": true, "UID": true, "URI": true, "URL": true, "UUID": true,
+	"XML": true, "XSRF": true, "XSS": true,
+}
+
+// words splits s into lowercase words.
+func words(s string) []string {
+	var parts []string
+	for _, w := range strings.Split(idents.Convert(s, idents.SnakeCase), "_") {
+		if w != "" {
+			parts = append(parts, strings.ToLower(w))
+		}
+	}
+	return parts
+}
+
+// goName converts s into an exported Go identifier, using Go naming conventions.
+func goName(s string) string {
+	var b strings.Builder
+	for _, w := range words(s) {
+		if up := strings.ToUpper(w); initialisms[up] {
+			b.WriteString(up)
+		} else {
+			b.WriteString(up[:1] + w[1:])
+		}
+	}
+	name := b.String()
+	if name == "" {
+		return "X"
+	} else if !token.IsIdentifier(name) {
+		return "X" + name
+	}
+	return name
+}
+
+// paramIdent converts s into an unexported Go identifier, suitable as a function parameter.
+func paramIdent(s string) string {
+	parts := words(s)
+	if len(parts) == 0 {
+		return "param"
+	}
+	name := parts[0]
+	if len(parts) > 1 {
+		name += goName(strings.Join(parts[1:], "_"))
+	}
+	switch {
+	case token.IsKeyword(name):
+		name += "_"
+	case !token.IsIdentifier(name):
+		name = "p" + name
+	case name == "ctx" || name == "p" || name == "w" || name == "req":
+		name += "Param"
+	}
+	return name
+}
+
+// uniqueName returns name, suffixed with a number if it is already used.
+func uniqueName(used map[string]bool, name string) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// formatDoc normalizes an OpenAPI description for use in a Go comment.
+func formatDoc(desc string) string {
+	return strings.TrimSpace(strings.ReplaceAll(desc, "\r\n", "\n"))
+}
+
+// comment returns a line comment containing text, which may span multiple lines.
+// It is followed by a newline.
+func comment(text string) *Statement {
+	c := Null()
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimRight(line, " \t"); line == "" {
+			c.Comment("//").Line()
+		} else {
+			c.Comment("// " + line).Line()
+		}
+	}
+	return c
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
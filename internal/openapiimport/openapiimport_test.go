@@ -0,0 +1,66 @@
+package openapiimport
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/golden"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func TestGenerate(t *testing.T) {
+	c := qt.New(t)
+	spec, err := Load(context.Background(), "testdata/petstore.yaml")
+	c.Assert(err, qt.IsNil)
+
+	files, err := Generate(spec, "petstore")
+	c.Assert(err, qt.IsNil)
+	c.Assert(files, qt.HasLen, 2)
+	for name, data := range files {
+		_, err := parser.ParseFile(token.NewFileSet(), name, data, parser.ParseComments)
+		c.Assert(err, qt.IsNil, qt.Commentf("generated %s is not valid Go", name))
+		golden.TestAgainst(c, "petstore/"+name+".golden", string(data))
+	}
+}
+
+func TestGenerate_InvalidServiceName(t *testing.T) {
+	c := qt.New(t)
+	spec, err := Load(context.Background(), "testdata/petstore.yaml")
+	c.Assert(err, qt.IsNil)
+
+	for _, name := range []string{"", "1svc", "type"} {
+		_, err := Generate(spec, name)
+		c.Assert(err, qt.ErrorMatches, `invalid service name .*`, qt.Commentf("name %q", name))
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		in, want, param string
+	}{
+		{"petId", "PetID", "petID"},
+		{"homepage_url", "HomepageURL", "homepageURL"},
+		{"X-Request-ID", "XRequestID", "xRequestID"},
+		{"list pets", "ListPets", "listPets"},
+		{"get /pets/{petId}", "GetPetsPetID", "getPetsPetID"},
+		{"type", "Type", "type_"},
+		{"200", "X200", "p200"},
+		{"req", "Req", "reqParam"},
+		{"id", "ID", "id"},
+	}
+	for _, test := range tests {
+		if got := goName(test.in); got != test.want {
+			t.Errorf("goName(%q) = %q, want %q", test.in, got, test.want)
+		}
+		if got := paramIdent(test.in); got != test.param {
+			t.Errorf("paramIdent(%q) = %q, want %q", test.in, got, test.param)
+		}
+	}
+}
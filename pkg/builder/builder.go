@@ -27,6 +27,7 @@ var LocalBuildTags = []string{
 	"encore_local",
 	"encore_no_gcp", "encore_no_aws", "encore_no_azure",
 	"encore_no_datadog", "encore_no_prometheus",
+	"encore_no_otlp", "encore_no_statsd",
 }
 
 // DebugMode specifies how to compile the application for debugging.
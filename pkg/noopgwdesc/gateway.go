@@ -43,7 +43,7 @@ func Describe(md *meta.Data, serviceDiscovery map[noopgateway.ServiceName]string
 			desc.Routes = append(desc.Routes, &noopgateway.Route{
 				Methods:      methods,
 				Dest:         svcName,
-				RequiresAuth: ep.AccessType == meta.RPC_AUTH,
+				RequiresAuth: ep.AccessType == meta.RPC_AUTH && !ep.AllowUnauthenticated,
 				Path:         pathToString(ep.Path),
 			})
 		}
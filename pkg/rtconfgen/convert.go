@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"reflect"
 	"slices"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/rs/zerolog"
@@ -150,6 +151,17 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 			currLevel = zerolog.TraceLevel
 		}
 		cfg.LogConfig = currLevel.String()
+
+		// Keep the less verbose services at their requested level.
+		var levelRules []string
+		for _, svc := range deployment.HostedServices {
+			if svc.LogConfig != nil {
+				if level, err := zerolog.ParseLevel(*svc.LogConfig); err == nil && level != currLevel {
+					levelRules = append(levelRules, svc.Name+"="+level.String())
+				}
+			}
+		}
+		cfg.LogLevels = strings.Join(levelRules, ",")
 	}
 
 	// Infrastructure handling.
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/netip"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -89,6 +90,34 @@ func (p *SvcProxy) RegisterService(name string, addr netip.AddrPort) string {
 	return fmt.Sprintf("http://%s/service/%s", p.listener.Addr().String(), name)
 }
 
+// RegisterRemoteService registers a service running outside of the app, such as in
+// another environment, and returns the BaseURL to be used to access the service.
+//
+// Requests are forwarded to baseURL without Encore's internal call metadata,
+// which is only valid within the app, so they are handled as external requests.
+func (p *SvcProxy) RegisterRemoteService(name string, baseURL *url.URL) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	prefix := fmt.Sprintf("/service/%s", name)
+	p.services[name] = &httputil.ReverseProxy{
+		Rewrite: func(request *httputil.ProxyRequest) {
+			request.Out.URL.Path = strings.TrimPrefix(request.In.URL.Path, prefix)
+			request.Out.URL.RawPath = ""
+			request.SetURL(baseURL)
+
+			for key := range request.Out.Header {
+				if strings.HasPrefix(key, "X-Encore-Meta-") {
+					request.Out.Header.Del(key)
+				}
+			}
+		},
+		ErrorLog: logging.NewZeroLogAdapter(p.logger.With().Str("service", name).Logger(), zerolog.ErrorLevel),
+	}
+
+	return fmt.Sprintf("http://%s%s", p.listener.Addr().String(), prefix)
+}
+
 func (p *SvcProxy) createReverseProxy(what, name string, listener netip.AddrPort) *httputil.ReverseProxy {
 	return &httputil.ReverseProxy{
 		// This transport is copied from the default transport in the http package just with the dial context
@@ -79,6 +79,7 @@ type spanEndEvent struct {
 	PanicStack    option.Option[*tracepb2.StackTrace]
 	ParentTraceID option.Option[*tracepb2.TraceID]
 	ParentSpanID  option.Option[uint64]
+	Baggage       map[string]string
 }
 
 type traceParser struct {
@@ -133,6 +134,10 @@ func (tp *traceParser) parseEvent(h header) (ev *tracepb2.TraceEvent, err error)
 		ev.Event = &tracepb2.TraceEvent_SpanStart{SpanStart: tp.pubsubMessageSpanStart()}
 	case trace2.PubsubMessageSpanEnd:
 		ev.Event = &tracepb2.TraceEvent_SpanEnd{SpanEnd: tp.pubsubMessageSpanEnd()}
+	case trace2.JobSpanStart:
+		ev.Event = &tracepb2.TraceEvent_SpanStart{SpanStart: tp.jobSpanStart()}
+	case trace2.JobSpanEnd:
+		ev.Event = &tracepb2.TraceEvent_SpanEnd{SpanEnd: tp.jobSpanEnd()}
 	case trace2.TestStart:
 		ev.Event = &tracepb2.TraceEvent_SpanStart{SpanStart: tp.testSpanStart()}
 	case trace2.TestEnd:
@@ -175,11 +180,17 @@ func (tp *traceParser) spanEndEvent() spanEndEvent {
 	parentTraceID := tp.traceID()
 	parentSpanID := tp.Uint64()
 
+	var baggage map[string]string
+	if tp.version >= 18 {
+		baggage = tp.headers()
+	}
+
 	ev := spanEndEvent{
 		DurationNanos: uint64(dur),
 		Err:           err,
 		PanicStack:    option.AsOptional(panicStack),
 		ParentSpanID:  option.AsOptional(parentSpanID),
+		Baggage:       baggage,
 	}
 	if !parentTraceID.IsZero() {
 		ev.ParentTraceID = option.Some(parentTraceID)
@@ -209,6 +220,8 @@ func (tp *traceParser) spanEvent(eventType trace2.EventType) *tracepb2.SpanEvent
 		ev.Data = &tracepb2.SpanEvent_DbQueryStart{DbQueryStart: tp.dbQueryStart()}
 	case trace2.DBQueryEnd:
 		ev.Data = &tracepb2.SpanEvent_DbQueryEnd{DbQueryEnd: tp.dbQueryEnd()}
+	case trace2.DBQueryPlan:
+		ev.Data = &tracepb2.SpanEvent_DbQueryPlan{DbQueryPlan: tp.dbQueryPlan()}
 	case trace2.DBTransactionStart:
 		ev.Data = &tracepb2.SpanEvent_DbTransactionStart{DbTransactionStart: tp.dbTransactionStart()}
 	case trace2.DBTransactionEnd:
@@ -233,6 +246,12 @@ func (tp *traceParser) spanEvent(eventType trace2.EventType) *tracepb2.SpanEvent
 		ev.Data = &tracepb2.SpanEvent_CacheCallEnd{CacheCallEnd: tp.cacheCallEnd()}
 	case trace2.BodyStream:
 		ev.Data = &tracepb2.SpanEvent_BodyStream{BodyStream: tp.bodyStream()}
+	case trace2.WebSocketConnect:
+		ev.Data = &tracepb2.SpanEvent_WebsocketConnect{WebsocketConnect: tp.webSocketConnect()}
+	case trace2.WebSocketMessage:
+		ev.Data = &tracepb2.SpanEvent_WebsocketMessage{WebsocketMessage: tp.webSocketMessage()}
+	case trace2.WebSocketClose:
+		ev.Data = &tracepb2.SpanEvent_WebsocketClose{WebsocketClose: tp.webSocketClose()}
 	case trace2.BucketObjectUploadStart:
 		ev.Data = &tracepb2.SpanEvent_BucketObjectUploadStart{BucketObjectUploadStart: tp.bucketObjectUploadStart()}
 	case trace2.BucketObjectUploadEnd:
@@ -253,6 +272,20 @@ func (tp *traceParser) spanEvent(eventType trace2.EventType) *tracepb2.SpanEvent
 		ev.Data = &tracepb2.SpanEvent_BucketDeleteObjectsStart{BucketDeleteObjectsStart: tp.bucketDeleteObjectsStart()}
 	case trace2.BucketDeleteObjectsEnd:
 		ev.Data = &tracepb2.SpanEvent_BucketDeleteObjectsEnd{BucketDeleteObjectsEnd: tp.bucketDeleteObjectsEnd()}
+	case trace2.CustomSpanStart:
+		ev.Data = &tracepb2.SpanEvent_CustomSpanStart{CustomSpanStart: tp.customSpanStart()}
+	case trace2.CustomSpanEnd:
+		ev.Data = &tracepb2.SpanEvent_CustomSpanEnd{CustomSpanEnd: tp.customSpanEnd()}
+	case trace2.CustomEvent:
+		ev.Data = &tracepb2.SpanEvent_CustomEvent{CustomEvent: tp.customEvent()}
+	case trace2.SpanLink:
+		ev.Data = &tracepb2.SpanEvent_SpanLink{SpanLink: tp.spanLink()}
+	case trace2.GoroutineSnapshot:
+		ev.Data = &tracepb2.SpanEvent_GoroutineSnapshot{GoroutineSnapshot: tp.goroutineSnapshot()}
+	case trace2.FaultInjected:
+		ev.Data = &tracepb2.SpanEvent_FaultInjected{FaultInjected: tp.faultInjected()}
+	case trace2.SecretRead:
+		ev.Data = &tracepb2.SpanEvent_SecretRead{SecretRead: tp.secretRead()}
 
 	default:
 		tp.bailout(fmt.Errorf("unknown event %v", eventType))
@@ -308,6 +341,7 @@ func (tp *traceParser) requestSpanEnd() *tracepb2.SpanEnd {
 		PanicStack:    spanEnd.PanicStack.GetOrElse(nil),
 		ParentTraceId: spanEnd.ParentTraceID.GetOrElse(nil),
 		ParentSpanId:  spanEnd.ParentSpanID.PtrOrNil(),
+		Baggage:       spanEnd.Baggage,
 		Data: &tracepb2.SpanEnd_Request{
 			Request: &tracepb2.RequestSpanEnd{
 				ServiceName:     tp.String(),
@@ -348,6 +382,7 @@ func (tp *traceParser) authSpanEnd() *tracepb2.SpanEnd {
 		PanicStack:    spanEnd.PanicStack.GetOrElse(nil),
 		ParentTraceId: spanEnd.ParentTraceID.GetOrElse(nil),
 		ParentSpanId:  spanEnd.ParentSpanID.PtrOrNil(),
+		Baggage:       spanEnd.Baggage,
 		Data: &tracepb2.SpanEnd_Auth{
 			Auth: &tracepb2.AuthSpanEnd{
 				ServiceName:  tp.String(),
@@ -391,6 +426,7 @@ func (tp *traceParser) pubsubMessageSpanEnd() *tracepb2.SpanEnd {
 		PanicStack:    spanEnd.PanicStack.GetOrElse(nil),
 		ParentTraceId: spanEnd.ParentTraceID.GetOrElse(nil),
 		ParentSpanId:  spanEnd.ParentSpanID.PtrOrNil(),
+		Baggage:       spanEnd.Baggage,
 		Data: &tracepb2.SpanEnd_PubsubMessage{
 			PubsubMessage: &tracepb2.PubsubMessageSpanEnd{
 				ServiceName:      tp.String(),
@@ -401,6 +437,47 @@ func (tp *traceParser) pubsubMessageSpanEnd() *tracepb2.SpanEnd {
 	}
 }
 
+func (tp *traceParser) jobSpanStart() *tracepb2.SpanStart {
+	spanStart := tp.spanStartEvent()
+
+	return &tracepb2.SpanStart{
+		Goid:                  spanStart.Goid,
+		ParentTraceId:         spanStart.ParentTraceID.GetOrElse(nil),
+		ParentSpanId:          spanStart.ParentSpanID.PtrOrNil(),
+		DefLoc:                spanStart.DefLoc.PtrOrNil(),
+		CallerEventId:         (*uint64)(spanStart.CallerEventID.PtrOrNil()),
+		ExternalCorrelationId: spanStart.ExtCorrelationID.PtrOrNil(),
+		Data: &tracepb2.SpanStart_Job{
+			Job: &tracepb2.JobSpanStart{
+				ServiceName: tp.String(),
+				QueueName:   tp.String(),
+				JobId:       tp.String(),
+				Attempt:     uint32(tp.UVarint()),
+				EnqueueTime: tp.Time(),
+				JobPayload:  tp.ByteString(),
+			},
+		},
+	}
+}
+
+func (tp *traceParser) jobSpanEnd() *tracepb2.SpanEnd {
+	spanEnd := tp.spanEndEvent()
+	return &tracepb2.SpanEnd{
+		DurationNanos: spanEnd.DurationNanos,
+		Error:         spanEnd.Err,
+		PanicStack:    spanEnd.PanicStack.GetOrElse(nil),
+		ParentTraceId: spanEnd.ParentTraceID.GetOrElse(nil),
+		ParentSpanId:  spanEnd.ParentSpanID.PtrOrNil(),
+		Baggage:       spanEnd.Baggage,
+		Data: &tracepb2.SpanEnd_Job{
+			Job: &tracepb2.JobSpanEnd{
+				ServiceName: tp.String(),
+				QueueName:   tp.String(),
+			},
+		},
+	}
+}
+
 func (tp *traceParser) testSpanStart() *tracepb2.SpanStart {
 	spanStart := tp.spanStartEvent()
 
@@ -431,6 +508,7 @@ func (tp *traceParser) testSpanEnd() *tracepb2.SpanEnd {
 		PanicStack:    spanEnd.PanicStack.GetOrElse(nil),
 		ParentTraceId: spanEnd.ParentTraceID.GetOrElse(nil),
 		ParentSpanId:  spanEnd.ParentSpanID.PtrOrNil(),
+		Baggage:       spanEnd.Baggage,
 		Data: &tracepb2.SpanEnd_Test{
 			Test: &tracepb2.TestSpanEnd{
 				ServiceName: tp.String(),
@@ -469,6 +547,12 @@ func (tp *traceParser) dbQueryEnd() *tracepb2.DBQueryEnd {
 	}
 }
 
+func (tp *traceParser) dbQueryPlan() *tracepb2.DBQueryPlan {
+	return &tracepb2.DBQueryPlan{
+		Plan: tp.String(),
+	}
+}
+
 func (tp *traceParser) dbTransactionStart() *tracepb2.DBTransactionStart {
 	return &tracepb2.DBTransactionStart{
 		Stack: tp.stack(),
@@ -692,6 +776,27 @@ func (tp *traceParser) bodyStream() *tracepb2.BodyStream {
 	}
 }
 
+func (tp *traceParser) webSocketConnect() *tracepb2.WebSocketConnect {
+	return &tracepb2.WebSocketConnect{
+		Protocol: tp.String(),
+	}
+}
+
+func (tp *traceParser) webSocketMessage() *tracepb2.WebSocketMessage {
+	return &tracepb2.WebSocketMessage{
+		Inbound: tp.Bool(),
+		Data:    tp.ByteString(),
+	}
+}
+
+func (tp *traceParser) webSocketClose() *tracepb2.WebSocketClose {
+	return &tracepb2.WebSocketClose{
+		Code:   uint32(tp.UVarint()),
+		Reason: tp.String(),
+		Err:    tp.errWithStack(),
+	}
+}
+
 func (tp *traceParser) headers() map[string]string {
 	n := tp.UVarint()
 	if n == 0 {
@@ -850,19 +955,86 @@ func (tp *traceParser) logMessage() *tracepb2.LogMessage {
 				return tracepb2.LogMessage_TRACE
 			}
 		})(),
-		Msg: tp.String(),
-		Fields: (func() []*tracepb2.LogField {
-			n := int(tp.UVarint())
-			if n > 64 {
-				// TODO bailout
-			}
-			fields := make([]*tracepb2.LogField, 0, n)
-			for i := 0; i < n; i++ {
-				fields = append(fields, tp.logField())
-			}
-			return fields
-		})(),
-		Stack: tp.stack(),
+		Msg:    tp.String(),
+		Fields: tp.logFields(),
+		Stack:  tp.stack(),
+	}
+}
+
+func (tp *traceParser) logFields() []*tracepb2.LogField {
+	n := int(tp.UVarint())
+	if n > 64 {
+		// TODO bailout
+	}
+	fields := make([]*tracepb2.LogField, 0, n)
+	for i := 0; i < n; i++ {
+		fields = append(fields, tp.logField())
+	}
+	return fields
+}
+
+func (tp *traceParser) customSpanStart() *tracepb2.CustomSpanStart {
+	return &tracepb2.CustomSpanStart{
+		Name:   tp.String(),
+		Fields: tp.logFields(),
+		Stack:  tp.stack(),
+	}
+}
+
+func (tp *traceParser) customSpanEnd() *tracepb2.CustomSpanEnd {
+	return &tracepb2.CustomSpanEnd{
+		Err:    tp.errWithStack(),
+		Fields: tp.logFields(),
+	}
+}
+
+func (tp *traceParser) customEvent() *tracepb2.CustomEvent {
+	return &tracepb2.CustomEvent{
+		Name:   tp.String(),
+		Fields: tp.logFields(),
+		Stack:  tp.stack(),
+	}
+}
+
+func (tp *traceParser) spanLink() *tracepb2.SpanLink {
+	return &tracepb2.SpanLink{
+		LinkedTraceId: tp.traceID(),
+		LinkedSpanId:  tp.spanID(),
+	}
+}
+
+func (tp *traceParser) goroutineSnapshot() *tracepb2.GoroutineSnapshot {
+	ev := &tracepb2.GoroutineSnapshot{
+		ElapsedNanos:    uint64(tp.Duration()),
+		TotalGoroutines: uint32(tp.UVarint()),
+	}
+	n := int(tp.UVarint())
+	for i := 0; i < n; i++ {
+		ev.Stacks = append(ev.Stacks, &tracepb2.GoroutineStack{
+			Count: uint32(tp.UVarint()),
+			Stack: tp.formattedStack(),
+		})
+	}
+	return ev
+}
+
+func (tp *traceParser) faultInjected() *tracepb2.FaultInjected {
+	return &tracepb2.FaultInjected{
+		Target:       tp.String(),
+		Service:      tp.String(),
+		Endpoint:     tp.String(),
+		Resource:     tp.String(),
+		LatencyNanos: uint64(tp.Duration()),
+		ErrorCode:    tp.String(),
+		Drop:         tp.Bool(),
+	}
+}
+
+func (tp *traceParser) secretRead() *tracepb2.SecretRead {
+	return &tracepb2.SecretRead{
+		Service:  tp.String(),
+		Endpoint: tp.String(),
+		Secret:   tp.String(),
 	}
 }
 
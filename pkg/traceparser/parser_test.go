@@ -4,11 +4,14 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -270,6 +273,7 @@ func TestParse(t *testing.T) {
 							Topic:        "topic",
 							Subscription: "subscription",
 						},
+						Baggage: model.NewBaggage(map[string]string{"tenant": "acme"}),
 					},
 					Resp: &model.Response{Err: err},
 				})
@@ -278,7 +282,8 @@ func TestParse(t *testing.T) {
 				TraceId: pbTraceID,
 				SpanId:  pbSpanID,
 				Event: &tracepb2.TraceEvent_SpanEnd{SpanEnd: &tracepb2.SpanEnd{
-					Error: pbErr,
+					Error:   pbErr,
+					Baggage: map[string]string{"tenant": "acme"},
 					Data: &tracepb2.SpanEnd_PubsubMessage{
 						PubsubMessage: &tracepb2.PubsubMessageSpanEnd{
 							ServiceName:      "service",
@@ -290,6 +295,72 @@ func TestParse(t *testing.T) {
 			},
 		},
 
+		{
+			Name: "JobSpanStart",
+			Emit: func(l *trace2.Log) {
+				l.JobSpanStart(&model.Request{
+					Type:    model.WorkerJob,
+					TraceID: traceID,
+					SpanID:  spanID,
+					Start:   now,
+					Traced:  true,
+					DefLoc:  defLoc,
+					JobData: &model.JobData{
+						Service:    "service",
+						Queue:      "queue",
+						JobID:      "job-id",
+						EnqueuedAt: now,
+						Attempt:    2,
+						Payload:    []byte("payload"),
+					},
+				}, goid)
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+					DefLoc: &udefLoc,
+					Goid:   goid,
+					Data: &tracepb2.SpanStart_Job{
+						Job: &tracepb2.JobSpanStart{
+							ServiceName: "service",
+							QueueName:   "queue",
+							JobId:       "job-id",
+							Attempt:     2,
+							EnqueueTime: pbNow,
+							JobPayload:  []byte("payload"),
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "JobSpanEnd",
+			Emit: func(l *trace2.Log) {
+				l.JobSpanEnd(trace2.JobSpanEndParams{
+					EventParams: ep,
+					Req: &model.Request{
+						JobData: &model.JobData{Service: "service", Queue: "queue"},
+					},
+					Resp: &model.Response{Err: err},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEnd{SpanEnd: &tracepb2.SpanEnd{
+					Error: pbErr,
+					Data: &tracepb2.SpanEnd_Job{
+						Job: &tracepb2.JobSpanEnd{
+							ServiceName: "service",
+							QueueName:   "queue",
+						},
+					},
+				}},
+			},
+		},
+
 		{
 			Name: "RPCCallStart",
 			Emit: func(l *trace2.Log) {
@@ -388,6 +459,110 @@ func TestParse(t *testing.T) {
 			},
 		},
 
+		{
+			Name: "DBQueryPlan",
+			Emit: func(l *trace2.Log) {
+				l.DBQueryPlan(trace2.DBQueryPlanParams{
+					EventParams: ep,
+					QueryID:     1,
+					Plan:        []byte(`[{"Plan": {"Node Type": "Seq Scan"}}]`),
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_DbQueryPlan{
+						DbQueryPlan: &tracepb2.DBQueryPlan{
+							Plan: `[{"Plan": {"Node Type": "Seq Scan"}}]`,
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "WebSocketConnect",
+			Emit: func(l *trace2.Log) {
+				l.WebSocketConnect(trace2.WebSocketConnectParams{
+					EventParams: ep,
+					Protocol:    "encore-ws",
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_WebsocketConnect{
+						WebsocketConnect: &tracepb2.WebSocketConnect{
+							Protocol: "encore-ws",
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "WebSocketMessage",
+			Emit: func(l *trace2.Log) {
+				l.WebSocketMessage(trace2.WebSocketMessageParams{
+					EventParams: ep,
+					ConnID:      1,
+					Inbound:     true,
+					Data:        []byte(`{"text":"hello"}`),
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_WebsocketMessage{
+						WebsocketMessage: &tracepb2.WebSocketMessage{
+							Inbound: true,
+							Data:    []byte(`{"text":"hello"}`),
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "WebSocketClose",
+			Emit: func(l *trace2.Log) {
+				l.WebSocketClose(trace2.WebSocketCloseParams{
+					EventParams: ep,
+					ConnID:      1,
+					Code:        1011,
+					Reason:      "internal error",
+					Err:         err,
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_WebsocketClose{
+						WebsocketClose: &tracepb2.WebSocketClose{
+							Code:   1011,
+							Reason: "internal error",
+							Err:    pbErr,
+						},
+					},
+				}},
+			},
+		},
+
 		{
 			Name: "DBTransactionStart",
 			Emit: func(l *trace2.Log) {
@@ -659,6 +834,240 @@ func TestParse(t *testing.T) {
 				}},
 			},
 		},
+
+		{
+			Name: "LogMessage_Truncated",
+			Emit: func(l *trace2.Log) {
+				fields := []trace2.LogField{
+					{Key: "string", Value: strings.Repeat("é", 3000)},
+					{Key: "json", Value: []string{strings.Repeat("a", 5000)}},
+				}
+				for i := 0; i < 70; i++ {
+					fields = append(fields, trace2.LogField{Key: "int", Value: i})
+				}
+				l.LogMessage(trace2.LogMessageParams{
+					EventParams: ep,
+					Level:       model.LevelInfo,
+					Msg:         strings.Repeat("m", 5000),
+					Stack:       stack.Stack{},
+					Fields:      fields,
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_LogMessage{
+						LogMessage: &tracepb2.LogMessage{
+							Level: tracepb2.LogMessage_INFO,
+							Msg:   strings.Repeat("m", 4096),
+							Stack: nil,
+							Fields: append([]*tracepb2.LogField{
+								{Key: "string", Value: &tracepb2.LogField_Str{Str: strings.Repeat("é", 2048)}},
+								{Key: "json", Value: &tracepb2.LogField_Error{Error: &tracepb2.Error{Msg: "value too large to include in trace"}}},
+							}, func() []*tracepb2.LogField {
+								var fields []*tracepb2.LogField
+								for i := 0; i < 62; i++ {
+									fields = append(fields, &tracepb2.LogField{Key: "int", Value: &tracepb2.LogField_Int{Int: int64(i)}})
+								}
+								return fields
+							}()...),
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "CustomSpanStart",
+			Emit: func(l *trace2.Log) {
+				l.CustomSpanStart(trace2.CustomSpanStartParams{
+					EventParams: ep,
+					Name:        "charge card",
+					Fields:      []trace2.LogField{{Key: "amount", Value: 100}},
+					Stack:       stack.Stack{},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_CustomSpanStart{
+						CustomSpanStart: &tracepb2.CustomSpanStart{
+							Name:   "charge card",
+							Fields: []*tracepb2.LogField{{Key: "amount", Value: &tracepb2.LogField_Int{Int: 100}}},
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "CustomSpanEnd",
+			Emit: func(l *trace2.Log) {
+				l.CustomSpanEnd(trace2.CustomSpanEndParams{
+					EventParams: ep,
+					StartID:     1,
+					Err:         err,
+					Fields:      []trace2.LogField{{Key: "declined", Value: true}},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_CustomSpanEnd{
+						CustomSpanEnd: &tracepb2.CustomSpanEnd{
+							Err:    pbErr,
+							Fields: []*tracepb2.LogField{{Key: "declined", Value: &tracepb2.LogField_Bool{Bool: true}}},
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "CustomEvent",
+			Emit: func(l *trace2.Log) {
+				l.CustomEvent(trace2.CustomEventParams{
+					EventParams: ep,
+					SpanStartID: 1,
+					Name:        "card declined",
+					Fields:      []trace2.LogField{{Key: "reason", Value: "insufficient funds"}},
+					Stack:       stack.Stack{},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:               goid,
+					DefLoc:             &udefLoc,
+					CorrelationEventId: ptr[uint64](1),
+					Data: &tracepb2.SpanEvent_CustomEvent{
+						CustomEvent: &tracepb2.CustomEvent{
+							Name:   "card declined",
+							Fields: []*tracepb2.LogField{{Key: "reason", Value: &tracepb2.LogField_Str{Str: "insufficient funds"}}},
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "SpanLink",
+			Emit: func(l *trace2.Log) {
+				l.SpanLink(trace2.SpanLinkParams{
+					EventParams:   ep,
+					LinkedTraceID: traceID,
+					LinkedSpanID:  spanID,
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_SpanLink{
+						SpanLink: &tracepb2.SpanLink{
+							LinkedTraceId: pbTraceID,
+							LinkedSpanId:  pbSpanID,
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "GoroutineSnapshot",
+			Emit: func(l *trace2.Log) {
+				l.GoroutineSnapshot(trace2.GoroutineSnapshotParams{
+					EventParams: ep,
+					Elapsed:     2 * time.Second,
+					Total:       5,
+					Stacks:      []trace2.GoroutineStack{{Count: 3}, {Count: 2}},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_GoroutineSnapshot{
+						GoroutineSnapshot: &tracepb2.GoroutineSnapshot{
+							ElapsedNanos:    uint64(2 * time.Second),
+							TotalGoroutines: 5,
+							Stacks:          []*tracepb2.GoroutineStack{{Count: 3}, {Count: 2}},
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "FaultInjected",
+			Emit: func(l *trace2.Log) {
+				l.FaultInjected(trace2.FaultInjectedParams{
+					EventParams: ep,
+					Target:      "sqldb_query",
+					Resource:    "orders",
+					Latency:     time.Second,
+					ErrorCode:   "unavailable",
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_FaultInjected{
+						FaultInjected: &tracepb2.FaultInjected{
+							Target:       "sqldb_query",
+							Resource:     "orders",
+							LatencyNanos: uint64(time.Second),
+							ErrorCode:    "unavailable",
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "SecretRead",
+			Emit: func(l *trace2.Log) {
+				l.SecretRead(trace2.SecretReadParams{
+					EventParams: ep,
+					Service:     "svc",
+					Endpoint:    "Charge",
+					Secret:      "Token",
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+					Goid:   goid,
+					DefLoc: &udefLoc,
+					Data: &tracepb2.SpanEvent_SecretRead{
+						SecretRead: &tracepb2.SecretRead{
+							Service:  "svc",
+							Endpoint: "Charge",
+							Secret:   "Token",
+						},
+					},
+				}},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -681,10 +1090,95 @@ func TestParse(t *testing.T) {
 			if diff := cmp.Diff(tt.Want, got, opt...); diff != "" {
 				t.Errorf("ParseEvent() mismatch (-want +got):\n%s", diff)
 			}
+
+			// Events downgraded to older versions parse the same,
+			// but for the fields added since.
+			for v := trace2.MinVersion; v < trace2.CurrentVersion; v++ {
+				downgraded, err := trace2.Downgrade(nil, data, v)
+				if err != nil {
+					t.Fatalf("downgrade to version %d: %v", v, err)
+				}
+				if v < eventAddedIn(tt.Want.GetSpanEvent()) {
+					// The event type is unknown to older versions.
+					if len(downgraded) != 0 {
+						t.Errorf("version %d: got %d bytes, want the event to be dropped", v, len(downgraded))
+					}
+					continue
+				}
+				r := bufio.NewReader(bytes.NewReader(downgraded))
+				got, err := ParseEvent(r, ta, v)
+				if err != nil {
+					t.Fatalf("version %d: %v", v, err)
+				}
+				if _, err := r.Peek(1); err != io.EOF {
+					t.Errorf("version %d: event data was not fully parsed", v)
+				}
+
+				want := proto.Clone(tt.Want).(*tracepb2.TraceEvent)
+				if end := want.GetSpanEnd(); end != nil && v < 18 {
+					end.Baggage = nil
+				}
+				if v < 22 {
+					jobAsPubsubMessage(want)
+				}
+				if diff := cmp.Diff(want, got, opt...); diff != "" {
+					t.Errorf("version %d: ParseEvent() mismatch (-want +got):\n%s", v, diff)
+				}
+			}
 		})
 	}
 }
 
+// jobAsPubsubMessage converts the job span data in ev to the
+// Pub/Sub message data job spans are downgraded to.
+func jobAsPubsubMessage(ev *tracepb2.TraceEvent) {
+	if job := ev.GetSpanStart().GetJob(); job != nil {
+		ev.GetSpanStart().Data = &tracepb2.SpanStart_PubsubMessage{
+			PubsubMessage: &tracepb2.PubsubMessageSpanStart{
+				ServiceName:      job.ServiceName,
+				TopicName:        job.QueueName,
+				SubscriptionName: "worker",
+				MessageId:        job.JobId,
+				Attempt:          job.Attempt,
+				PublishTime:      job.EnqueueTime,
+				MessagePayload:   job.JobPayload,
+			},
+		}
+	}
+	if job := ev.GetSpanEnd().GetJob(); job != nil {
+		ev.GetSpanEnd().Data = &tracepb2.SpanEnd_PubsubMessage{
+			PubsubMessage: &tracepb2.PubsubMessageSpanEnd{
+				ServiceName:      job.ServiceName,
+				TopicName:        job.QueueName,
+				SubscriptionName: "worker",
+			},
+		}
+	}
+}
+
 func ptr[T any](val T) *T {
 	return &val
 }
+
+// eventAddedIn returns the trace protocol version the span event was added in,
+// or trace2.MinVersion if it's supported by all versions.
+func eventAddedIn(ev *tracepb2.SpanEvent) trace2.Version {
+	switch ev.GetData().(type) {
+	case *tracepb2.SpanEvent_CustomSpanStart, *tracepb2.SpanEvent_CustomSpanEnd, *tracepb2.SpanEvent_CustomEvent:
+		return 16
+	case *tracepb2.SpanEvent_SpanLink:
+		return 17
+	case *tracepb2.SpanEvent_GoroutineSnapshot:
+		return 19
+	case *tracepb2.SpanEvent_DbQueryPlan:
+		return 20
+	case *tracepb2.SpanEvent_WebsocketConnect, *tracepb2.SpanEvent_WebsocketMessage, *tracepb2.SpanEvent_WebsocketClose:
+		return 21
+	case *tracepb2.SpanEvent_FaultInjected:
+		return 23
+	case *tracepb2.SpanEvent_SecretRead:
+		return 24
+	default:
+		return trace2.MinVersion
+	}
+}
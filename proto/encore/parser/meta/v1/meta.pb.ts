@@ -388,6 +388,7 @@ export enum Metric_MetricKind {
   COUNTER = "COUNTER",
   GAUGE = "GAUGE",
   HISTOGRAM = "HISTOGRAM",
+  SUMMARY = "SUMMARY",
   UNRECOGNIZED = "UNRECOGNIZED",
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 	"sync"
 
 	jsoniter "github.com/json-iterator/go"
@@ -52,6 +53,11 @@ func (d *AuthHandlerDesc[Params]) Authenticate(c IncomingContext) (model.AuthInf
 		return model.AuthInfo{}, err
 	}
 
+	handler, err := d.handler(c.server)
+	if err != nil {
+		return model.AuthInfo{}, err
+	}
+
 	done := make(chan struct{})
 	call, err := c.server.beginAuth(d.DefLoc)
 	if err != nil {
@@ -75,6 +81,7 @@ func (d *AuthHandlerDesc[Params]) Authenticate(c IncomingContext) (model.AuthInf
 				RequestHeaders:     c.req.Header,
 				FromEncorePlatform: platformauth.IsEncorePlatformRequest(c.req.Context()),
 			},
+			Baggage:             c.callMeta.baggage(),
 			ExtCorrelationID:    clampTo64Chars(c.req.Header.Get("X-Correlation-ID")),
 			AdditionalLogFields: cloudtrace.StructuredLogFields(c.req),
 		})
@@ -96,7 +103,11 @@ func (d *AuthHandlerDesc[Params]) Authenticate(c IncomingContext) (model.AuthInf
 			return
 		}
 
-		info, authErr = d.AuthHandler(c.req.Context(), param)
+		info, authErr = handler(c.req.Context(), param)
+		if curr := c.server.rt.Current(); curr.Req != nil {
+			info.Values = curr.Req.Values
+			info.Baggage = curr.Req.Baggage
+		}
 
 		if authErr != nil {
 			authErr = errs.RoundTrip(authErr)
@@ -111,6 +122,68 @@ func (d *AuthHandlerDesc[Params]) Authenticate(c IncomingContext) (model.AuthInf
 	return info, authErr
 }
 
+// handler returns the auth handler to call, which is the
+// mocked auth handler if we're inside a test that set one.
+func (d *AuthHandlerDesc[Params]) handler(s *Server) (func(context.Context, Params) (model.AuthInfo, error), error) {
+	if s.static.Testing {
+		if mock, found := s.testingMgr.GetAuthHandlerMock(); found {
+			handler, err := d.mockAuthHandler(mock)
+			if err != nil {
+				return nil, errs.B().Code(errs.Internal).Cause(err).Msg("unable to call mocked auth handler").Err()
+			}
+			return handler, nil
+		}
+	}
+	return d.AuthHandler, nil
+}
+
+// authenticateParams runs the auth handler with the given params
+// within the current request, for use by tests.
+func (d *AuthHandlerDesc[Params]) authenticateParams(ctx context.Context, s *Server, params any) (model.AuthInfo, error) {
+	p, ok := params.(Params)
+	if !ok {
+		return model.AuthInfo{}, fmt.Errorf("auth params of type %T do not match the auth handler's params of type %s",
+			params, reflect.TypeOf((*Params)(nil)).Elem())
+	}
+	handler, err := d.handler(s)
+	if err != nil {
+		return model.AuthInfo{}, err
+	}
+	if err := runValidate(p); err != nil {
+		return model.AuthInfo{}, err
+	}
+	return handler(ctx, p)
+}
+
+// mockAuthHandler returns an auth handler that calls mock, which must have
+// the same signature as the application's auth handler.
+func (d *AuthHandlerDesc[Params]) mockAuthHandler(mock any) (func(context.Context, Params) (model.AuthInfo, error), error) {
+	numOut := 2
+	if d.HasAuthData {
+		numOut = 3
+	}
+
+	fn := reflect.ValueOf(mock)
+	typ := fn.Type()
+	if typ.Kind() != reflect.Func || typ.NumIn() != 2 || typ.NumOut() != numOut ||
+		typ.In(0) != reflect.TypeOf((*context.Context)(nil)).Elem() ||
+		typ.In(1) != reflect.TypeOf((*Params)(nil)).Elem() ||
+		typ.Out(0) != reflect.TypeOf(model.UID("")) ||
+		typ.Out(numOut-1) != reflect.TypeOf((*error)(nil)).Elem() {
+		return nil, fmt.Errorf("mock of type %s does not match the signature of the auth handler %s", typ, d.Endpoint)
+	}
+
+	return func(ctx context.Context, params Params) (info model.AuthInfo, err error) {
+		out := fn.Call([]reflect.Value{reflect.ValueOf(&ctx).Elem(), reflect.ValueOf(&params).Elem()})
+		info.UID = out[0].Interface().(model.UID)
+		if d.HasAuthData {
+			info.UserData = out[1].Interface()
+		}
+		err, _ = out[numOut-1].Interface().(error)
+		return info, err
+	}, nil
+}
+
 func (d *AuthHandlerDesc[Params]) HostedByService() string {
 	return d.Service
 }
@@ -123,9 +196,10 @@ func (d *AuthHandlerDesc[Params]) ParseAuthData(c IncomingContext) error {
 // runAuthHandler runs the auth handler, if provided.
 // It reports whether to proceed with calling the handler.
 func (s *Server) runAuthHandler(h Handler, c IncomingContext) (info model.AuthInfo, proceed bool) {
-	requiresAuth := h.AccessType() == RequiresAuth
+	access := h.AccessType()
+	requiresAuth := access == RequiresAuth
 	if s.authHandler == nil {
-		if requiresAuth {
+		if requiresAuth || access == OptionalAuth {
 			panic(fmt.Sprintf("internal error: API %s.%s requires auth but no auth handler set",
 				h.ServiceName(), h.EndpointName()))
 		}
@@ -144,6 +218,20 @@ func (s *Server) runAuthHandler(h Handler, c IncomingContext) (info model.AuthIn
 		return c.auth, true
 	}
 
+	// For optional auth, requests without credentials proceed unauthenticated,
+	// while requests with credentials must pass the auth handler.
+	if access == OptionalAuth {
+		if s.authHandler.ParseAuthData(c) != nil {
+			return model.AuthInfo{}, true
+		}
+		info, err := s.authHandler.Authenticate(c)
+		if err != nil {
+			returnError(c, err, 0)
+			return model.AuthInfo{}, false
+		}
+		return info, true
+	}
+
 	var err error
 	info, err = s.authHandler.Authenticate(c)
 	if err != nil {
@@ -20,7 +20,6 @@ import (
 type remoteAuthHandler struct {
 	server         *Server        // The server we're running in
 	hostingService config.Service // The service name of the remote auth handler
-	authURL        string         // The URL of the remote auth handler
 	original       AuthHandler
 	logger         zerolog.Logger
 	traceLogs      bool
@@ -28,7 +27,7 @@ type remoteAuthHandler struct {
 
 var _ AuthHandler = (*remoteAuthHandler)(nil)
 
-func (r *remoteAuthHandler) Authenticate(c IncomingContext) (model.AuthInfo, error) {
+func (r *remoteAuthHandler) Authenticate(c IncomingContext) (info model.AuthInfo, err error) {
 	// Quickly check if the auth data is parsable here, if it isn't we can return early
 	// without making a remote call.
 	if err := r.original.ParseAuthData(c); err != nil {
@@ -39,8 +38,16 @@ func (r *remoteAuthHandler) Authenticate(c IncomingContext) (model.AuthInfo, err
 		r.logger.Trace().Msg("calling auth handler")
 	}
 
+	// Resolve where the auth handler is hosted
+	baseURL, doneCall, err := r.server.serviceBaseURL(c.ctx, r.hostingService)
+	if err != nil {
+		r.logger.Err(err).Msg("unable to resolve auth handler service")
+		return model.AuthInfo{}, errs.Wrap(err, "unable to resolve auth handler service")
+	}
+	defer func() { doneCall(err) }()
+
 	// Create the auth request
-	authReq, err := http.NewRequestWithContext(c.ctx, http.MethodPost, r.authURL, nil)
+	authReq, err := http.NewRequestWithContext(c.ctx, http.MethodPost, baseURL+"/__encore/authhandler", nil)
 	if err != nil {
 		r.logger.Err(err).Msg("unable to create auth request")
 		return model.AuthInfo{}, errs.Wrap(err, "unable to create auth request")
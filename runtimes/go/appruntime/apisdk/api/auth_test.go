@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
+)
+
+func TestAuthHandlerDesc_MockAuthHandler(t *testing.T) {
+	d := &AuthHandlerDesc[string]{Endpoint: "AuthHandler", HasAuthData: true}
+	ctx := context.Background()
+
+	handler, err := d.mockAuthHandler(func(ctx context.Context, token string) (model.UID, *testAuthData, error) {
+		if token == "" {
+			return "", nil, errors.New("no token")
+		}
+		return model.UID("user-" + token), &testAuthData{roles: []string{"admin"}}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := handler(ctx, "1")
+	if err != nil || info.UID != "user-1" {
+		t.Fatalf("got %+v, %v", info, err)
+	}
+	if data, ok := info.UserData.(*testAuthData); !ok || !data.HasRole("admin") {
+		t.Errorf("got auth data %#v", info.UserData)
+	}
+	if _, err := handler(ctx, ""); err == nil || err.Error() != "no token" {
+		t.Errorf("got err %v, want no token", err)
+	}
+
+	// The mock must match the auth handler's signature.
+	for _, mock := range []any{
+		func(ctx context.Context, token string) (model.UID, error) { return "", nil },
+		func(ctx context.Context, token int) (model.UID, *testAuthData, error) { return "", nil, nil },
+		"not a function",
+	} {
+		if _, err := d.mockAuthHandler(mock); err == nil {
+			t.Errorf("got no error for mock of type %T", mock)
+		}
+	}
+}
+
+func TestServer_AuthenticateInTest(t *testing.T) {
+	static := &config.Static{Testing: true}
+	s := &Server{
+		static:     static,
+		testingMgr: testsupport.NewManager(static, reqtrack.New(zerolog.Nop(), nil, nil), zerolog.Nop()),
+	}
+	ctx := context.Background()
+
+	if _, err := s.AuthenticateInTest(ctx, "token"); err == nil {
+		t.Fatal("got no error without an auth handler")
+	}
+
+	s.authHandler = &AuthHandlerDesc[string]{
+		Endpoint: "AuthHandler",
+		AuthHandler: func(ctx context.Context, token string) (model.AuthInfo, error) {
+			return model.AuthInfo{UID: model.UID("real-" + token)}, nil
+		},
+	}
+	if info, err := s.AuthenticateInTest(ctx, "token"); err != nil || info.UID != "real-token" {
+		t.Errorf("got %+v, %v", info, err)
+	}
+	if _, err := s.AuthenticateInTest(ctx, 5); err == nil {
+		t.Error("got no error for params of the wrong type")
+	}
+
+	s.testingMgr.SetAuthHandlerMock(func(ctx context.Context, token string) (model.UID, error) {
+		return model.UID("mock-" + token), nil
+	})
+	if info, err := s.AuthenticateInTest(ctx, "token"); err != nil || info.UID != "mock-token" {
+		t.Errorf("got %+v, %v", info, err)
+	}
+}
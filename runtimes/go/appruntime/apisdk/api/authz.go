@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encore.dev/beta/errs"
+)
+
+// scopeChecker and roleChecker are implemented by auth data types
+// that support endpoints declaring required scopes and roles.
+// They mirror auth.ScopeChecker and auth.RoleChecker.
+type scopeChecker interface {
+	HasScope(scope string) bool
+}
+
+type roleChecker interface {
+	HasRole(role string) bool
+}
+
+// authorize checks that the auth data has the scopes and roles required by the endpoint.
+func (d *Desc[Req, Resp]) authorize(authData any) error {
+	if len(d.RequiredScopes) == 0 && len(d.RequiredRoles) == 0 {
+		return nil
+	}
+
+	denied := func(msg string) error {
+		return errs.B().
+			Code(errs.PermissionDenied).
+			Meta("service", d.Service, "endpoint", d.Endpoint).
+			Msg(msg).
+			Err()
+	}
+
+	if len(d.RequiredScopes) > 0 {
+		sc, ok := authData.(scopeChecker)
+		if !ok {
+			return denied("endpoint requires scopes but the auth data does not implement auth.ScopeChecker")
+		}
+		for _, scope := range d.RequiredScopes {
+			if !sc.HasScope(scope) {
+				return denied("missing required scope " + scope)
+			}
+		}
+	}
+
+	if len(d.RequiredRoles) > 0 {
+		rc, ok := authData.(roleChecker)
+		if !ok {
+			return denied("endpoint requires roles but the auth data does not implement auth.RoleChecker")
+		}
+		for _, role := range d.RequiredRoles {
+			if rc.HasRole(role) {
+				return nil
+			}
+		}
+		return denied("missing required role")
+	}
+
+	return nil
+}
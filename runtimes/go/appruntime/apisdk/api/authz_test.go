@@ -0,0 +1,55 @@
+package api
+
+import (
+	"testing"
+
+	"encore.dev/beta/errs"
+)
+
+type testAuthData struct {
+	scopes []string
+	roles  []string
+}
+
+func (d *testAuthData) HasScope(scope string) bool { return contains(d.scopes, scope) }
+func (d *testAuthData) HasRole(role string) bool   { return contains(d.roles, role) }
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDesc_Authorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopes   []string
+		roles    []string
+		authData any
+		ok       bool
+	}{
+		{name: "no_requirements", authData: nil, ok: true},
+		{name: "all_scopes", scopes: []string{"a", "b"}, authData: &testAuthData{scopes: []string{"a", "b", "c"}}, ok: true},
+		{name: "missing_scope", scopes: []string{"a", "b"}, authData: &testAuthData{scopes: []string{"a"}}},
+		{name: "any_role", roles: []string{"admin", "support"}, authData: &testAuthData{roles: []string{"support"}}, ok: true},
+		{name: "missing_role", roles: []string{"admin"}, authData: &testAuthData{roles: []string{"support"}}},
+		{name: "scopes_and_roles", scopes: []string{"a"}, roles: []string{"admin"}, authData: &testAuthData{scopes: []string{"a"}}},
+		{name: "unsupported_auth_data", scopes: []string{"a"}, authData: struct{}{}},
+		{name: "nil_auth_data", roles: []string{"admin"}, authData: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := &Desc[struct{}, struct{}]{Service: "svc", Endpoint: "Foo", RequiredScopes: test.scopes, RequiredRoles: test.roles}
+			err := d.authorize(test.authData)
+			if test.ok && err != nil {
+				t.Fatalf("got err %v, want nil", err)
+			} else if !test.ok && errs.Code(err) != errs.PermissionDenied {
+				t.Fatalf("got err %v, want PermissionDenied", err)
+			}
+		})
+	}
+}
@@ -28,8 +28,9 @@ var (
 )
 
 const (
-	callerMetaName = "Caller"
-	calleeMetaName = "Callee"
+	callerMetaName  = "Caller"
+	calleeMetaName  = "Callee"
+	baggageMetaName = "Baggage"
 )
 
 type metaContextKey string
@@ -61,6 +62,8 @@ type InternalCallMeta struct {
 	Caller   Caller // The name of the service which is making the call
 	AuthUID  string // The UID of the authenticated user
 	AuthData any    // The data of the authenticated user
+
+	Baggage map[string]string // The baggage of the calling request
 }
 
 // addInternalCallMeta adds internal metadata to the external request
@@ -109,6 +112,9 @@ func (s *Server) metaFromAPICall(call *model.APICall) (meta CallMeta, err error)
 		AuthUID:  string(call.UserID),
 		AuthData: call.AuthData,
 	}
+	if call != nil && call.Source != nil {
+		meta.Internal.Baggage = call.Source.Baggage.All()
+	}
 
 	return meta, nil
 }
@@ -167,6 +173,11 @@ func (meta CallMeta) AddToRequest(server *Server, targetService config.Service,
 			}
 		}
 
+		// Propagate the baggage of the calling request
+		if len(meta.Internal.Baggage) > 0 {
+			req.SetMeta(baggageMetaName, model.EncodeBaggage(meta.Internal.Baggage))
+		}
+
 		// If we're making an internal call, sign the request
 		targetAuth := server.outboundSvcAuth[targetService.ServiceAuth.Method]
 		if targetAuth == nil {
@@ -180,6 +191,14 @@ func (meta CallMeta) AddToRequest(server *Server, targetService config.Service,
 	return nil
 }
 
+// baggage returns the baggage propagated by the caller, or nil if there is none.
+func (meta CallMeta) baggage() *model.Baggage {
+	if meta.Internal == nil || len(meta.Internal.Baggage) == 0 {
+		return nil
+	}
+	return model.NewBaggage(meta.Internal.Baggage)
+}
+
 func (meta CallMeta) IsServiceToService() bool {
 	return meta.Internal != nil && meta.Internal.Caller != nil
 }
@@ -227,6 +246,14 @@ func (s *Server) MetaFromRequest(req transport.Transport) (meta CallMeta, err er
 				}
 			}
 		}
+
+		if data, found := req.ReadMeta(baggageMetaName); found {
+			baggage, ok := model.ParseBaggage(data)
+			if !ok {
+				return CallMeta{}, errors.New("failed to parse baggage")
+			}
+			meta.Internal.Baggage = baggage
+		}
 	}
 
 	// If we where tracing read the trace ID, span ID
@@ -274,6 +301,32 @@ func (s *Server) MetaFromRequest(req transport.Transport) (meta CallMeta, err er
 	return meta, nil
 }
 
+// joinExternalTrace makes the request part of the trace given by the traceparent
+// and tracestate headers of a request made from outside of Encore, such as from
+// a service instrumented with OpenTelemetry. It does nothing if the headers
+// are missing or invalid, or if the request is an internal call.
+func (meta *CallMeta) joinExternalTrace(req transport.Transport) {
+	if meta.Internal != nil {
+		return
+	}
+	traceParent, found := req.ReadMeta(transport.TraceParentKey)
+	if !found {
+		return
+	}
+	traceID, parentSpanID, sampled, ok := parseTraceParent(traceParent)
+	if !ok || traceID.IsZero() {
+		return
+	}
+	meta.TraceID, meta.ParentSpanID, meta.TraceSampled = traceID, parentSpanID, sampled
+
+	// Calls made from other Encore apps include the event id of the call.
+	if traceState, found := req.ReadMetaValues(transport.TraceStateKey); found {
+		if parentEventID, _, ok := parseTraceState(traceState); ok {
+			meta.ParentEventID = parentEventID
+		}
+	}
+}
+
 // parseTraceParent parses the trace and span ids from s, which is assumed
 // to be in the format of the traceparent header (see https://www.w3.org/TR/trace-context/).
 // If it's not a valid traceparent header it returns zero ids and ok == false.
@@ -312,13 +365,13 @@ func parseTraceParent(s string) (traceID model.TraceID, spanID model.SpanID, sam
 		return model.TraceID{}, model.SpanID{}, false, false
 	}
 
-	var flags [2]byte
+	var flags [1]byte
 	_, err = hex.Decode(flags[:], []byte(s[flagsStart:flagsEnd]))
 	if err != nil {
 		return model.TraceID{}, model.SpanID{}, false, false
 	}
 
-	sampled = flags[1]&1 == 1
+	sampled = flags[0]&1 == 1
 
 	return traceID, spanID, sampled, true
 }
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"encore.dev/appruntime/apisdk/api/transport"
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestCallMeta_JoinExternalTrace(t *testing.T) {
+	traceID := model.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	spanID := model.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := []struct {
+		name   string
+		header http.Header
+		meta   CallMeta
+		want   CallMeta
+	}{
+		{
+			name:   "traceparent",
+			header: http.Header{"Traceparent": {traceParent}, "Tracestate": {"vendor=foo"}},
+			want:   CallMeta{TraceID: traceID, ParentSpanID: spanID, TraceSampled: true},
+		},
+		{
+			name:   "encore_event_id",
+			header: http.Header{"Traceparent": {traceParent}, "Tracestate": {"encore/event-id=a"}},
+			want:   CallMeta{TraceID: traceID, ParentSpanID: spanID, TraceSampled: true, ParentEventID: 10},
+		},
+		{
+			name:   "invalid",
+			header: http.Header{"Traceparent": {"00-invalid"}},
+			want:   CallMeta{},
+		},
+		{
+			name:   "internal",
+			header: http.Header{"Traceparent": {traceParent}},
+			meta:   CallMeta{Internal: &InternalCallMeta{}},
+			want:   CallMeta{Internal: &InternalCallMeta{}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &http.Request{Header: test.header}
+			meta := test.meta
+			meta.joinExternalTrace(transport.HTTPRequest(req))
+			if meta.TraceID != test.want.TraceID || meta.ParentSpanID != test.want.ParentSpanID ||
+				meta.TraceSampled != test.want.TraceSampled || meta.ParentEventID != test.want.ParentEventID {
+				t.Errorf("got %+v, want %+v", meta, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/internal/platformauth"
+)
+
+// maxProfileDuration is the longest duration a CPU profile,
+// execution trace or sampled profile can be captured for.
+const maxProfileDuration = 5 * time.Minute
+
+// registerDebugRoutes registers the routes for capturing profiles
+// and reading the exported variables of the running process.
+//
+// The runtime/pprof package is used directly rather than net/http/pprof,
+// since importing the latter exposes the profiles on http.DefaultServeMux
+// without any authentication.
+func (s *Server) registerDebugRoutes() {
+	s.encore.Handler("GET", "/debug/vars", s.debugAuth(expvar.Handler()))
+	s.encore.Handler("GET", "/debug/pprof/*profile", s.debugAuth(http.HandlerFunc(s.handleProfile)))
+}
+
+// debugAuth only allows requests that are authorized to use the runtime's
+// admin routes, as the profiles and variables of the process can expose
+// sensitive data.
+func (s *Server) debugAuth(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if platformauth.AuthorizeAdmin(w, req, s.runtime) {
+			h.ServeHTTP(w, req)
+		}
+	})
+}
+
+// handleProfile writes the requested profile, in the same format
+// as net/http/pprof so it can be read using "go tool pprof".
+func (s *Server) handleProfile(w http.ResponseWriter, req *http.Request) {
+	name := httprouter.ParamsFromContext(req.Context()).ByName("profile")
+	if len(name) > 0 && name[0] == '/' {
+		name = name[1:]
+	}
+
+	seconds, err := profileSeconds(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch name {
+	case "":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, p := range pprof.Profiles() {
+			_, _ = fmt.Fprintf(w, "%s %d\n", p.Name(), p.Count())
+		}
+		_, _ = fmt.Fprintln(w, "profile")
+		_, _ = fmt.Fprintln(w, "trace")
+
+	case "profile":
+		if seconds == 0 {
+			seconds = 30
+		}
+		setProfileHeaders(w, name)
+		if err := pprof.StartCPUProfile(w); err != nil {
+			http.Error(w, "could not start cpu profile: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sleep(req, seconds)
+		pprof.StopCPUProfile()
+
+	case "trace":
+		if seconds == 0 {
+			seconds = 1
+		}
+		setProfileHeaders(w, name)
+		if err := trace.Start(w); err != nil {
+			http.Error(w, "could not start trace: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sleep(req, seconds)
+		trace.Stop()
+
+	default:
+		p := pprof.Lookup(name)
+		if p == nil {
+			http.Error(w, "unknown profile", http.StatusNotFound)
+			return
+		}
+
+		// The block and mutex profiles are not sampled by default,
+		// so sample them for the requested duration.
+		if seconds > 0 && (name == "block" || name == "mutex") {
+			stop := sampling.start(name)
+			sleep(req, seconds)
+			defer stop()
+		}
+
+		debug, _ := strconv.Atoi(req.FormValue("debug"))
+		if debug > 0 {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		} else {
+			setProfileHeaders(w, name)
+		}
+		if name == "heap" && req.FormValue("gc") != "" {
+			runtime.GC()
+		}
+		_ = p.WriteTo(w, debug)
+	}
+}
+
+func profileSeconds(req *http.Request) (int, error) {
+	str := req.FormValue("seconds")
+	if str == "" {
+		return 0, nil
+	}
+	seconds, err := strconv.Atoi(str)
+	if err != nil || seconds <= 0 {
+		return 0, fmt.Errorf("invalid seconds %q", str)
+	} else if time.Duration(seconds)*time.Second > maxProfileDuration {
+		return 0, fmt.Errorf("seconds must be at most %d", int(maxProfileDuration.Seconds()))
+	}
+	return seconds, nil
+}
+
+func setProfileHeaders(w http.ResponseWriter, name string) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+}
+
+// sleep sleeps for the given number of seconds,
+// or until the request is canceled.
+func sleep(req *http.Request, seconds int) {
+	select {
+	case <-time.After(time.Duration(seconds) * time.Second):
+	case <-req.Context().Done():
+	}
+}
+
+// sampling enables block and mutex profile sampling
+// while any profile of that kind is being captured.
+var sampling profileSampling
+
+type profileSampling struct {
+	mu     sync.Mutex
+	active map[string]int
+}
+
+func (ps *profileSampling) start(name string) (stop func()) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.active == nil {
+		ps.active = make(map[string]int)
+	}
+	if ps.active[name] == 0 {
+		setSampling(name, true)
+	}
+	ps.active[name]++
+
+	return func() {
+		ps.mu.Lock()
+		defer ps.mu.Unlock()
+		ps.active[name]--
+		if ps.active[name] == 0 {
+			setSampling(name, false)
+		}
+	}
+}
+
+func setSampling(name string, enabled bool) {
+	switch name {
+	case "block":
+		rate := 0
+		if enabled {
+			rate = 1
+		}
+		runtime.SetBlockProfileRate(rate)
+	case "mutex":
+		fraction := 0
+		if enabled {
+			fraction = 1
+		}
+		runtime.SetMutexProfileFraction(fraction)
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/internal/platformauth"
+)
+
+func TestDebugRoutes(t *testing.T) {
+	s := &Server{runtime: &config.Runtime{EnvCloud: "local"}, encore: httprouter.New()}
+	s.registerDebugRoutes()
+
+	tests := []struct {
+		path     string
+		code     int
+		contains string
+	}{
+		{"/debug/pprof/", http.StatusOK, "goroutine"},
+		{"/debug/pprof/heap?debug=1", http.StatusOK, "heap profile"},
+		{"/debug/pprof/goroutine?debug=1", http.StatusOK, "goroutine profile"},
+		{"/debug/pprof/block?seconds=1", http.StatusOK, ""},
+		{"/debug/pprof/unknown", http.StatusNotFound, "unknown profile"},
+		{"/debug/pprof/profile?seconds=0", http.StatusBadRequest, "invalid seconds"},
+		{"/debug/pprof/profile?seconds=3600", http.StatusBadRequest, "at most"},
+		{"/debug/vars", http.StatusOK, `"memstats"`},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		s.encore.ServeHTTP(w, httptest.NewRequest("GET", test.path, nil))
+		if w.Code != test.code {
+			t.Errorf("%s: got code %d, want %d", test.path, w.Code, test.code)
+		} else if !strings.Contains(w.Body.String(), test.contains) {
+			t.Errorf("%s: got body %q, want it to contain %q", test.path, w.Body.String(), test.contains)
+		}
+	}
+}
+
+func TestDebugRoutes_Auth(t *testing.T) {
+	s := &Server{runtime: &config.Runtime{EnvCloud: "aws", AdminBearerToken: "s3cret"}, encore: httprouter.New()}
+	s.registerDebugRoutes()
+
+	for _, path := range []string{"/debug/pprof/heap", "/debug/vars"} {
+		w := httptest.NewRecorder()
+		s.encore.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: got code %d, want %d", path, w.Code, http.StatusUnauthorized)
+		}
+
+		// Requests from the Encore Platform are allowed.
+		req := httptest.NewRequest("GET", path, nil)
+		req = req.WithContext(platformauth.WithEncorePlatformSealOfApproval(req.Context()))
+		w = httptest.NewRecorder()
+		s.encore.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: got code %d for platform request, want %d", path, w.Code, http.StatusOK)
+		}
+
+		// So are requests with the admin token.
+		for token, want := range map[string]int{"wrong": http.StatusUnauthorized, "s3cret": http.StatusOK} {
+			req = httptest.NewRequest("GET", path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w = httptest.NewRecorder()
+			s.encore.ServeHTTP(w, req)
+			if w.Code != want {
+				t.Errorf("%s: got code %d for token %q, want %d", path, w.Code, token, want)
+			}
+		}
+	}
+}
@@ -13,6 +13,17 @@ func (s *Server) registerEncoreRoutes() {
 	s.encore.HandlerFunc(wildcardMethod, "/healthz", s.handleHealthz)
 	s.encore.Handle("POST", "/pubsub/push/:subscription_id", s.handlePubsubPush)
 	s.encore.Handle("POST", "/authhandler", s.handleRemoteAuthCall)
+	s.registerDebugRoutes()
+}
+
+// RegisterEncoreRoute registers an additional handler on the Encore internal router,
+// making it reachable under the "/__encore" path prefix.
+//
+// The routes are not authenticated, so callers are responsible for only registering
+// handlers that are safe to expose in the current environment.
+// It must be called before the server starts serving requests.
+func (s *Server) RegisterEncoreRoute(method, path string, h http.Handler) {
+	s.encore.Handler(method, path, h)
 }
 
 // handleHealthz returns the current health and deployment details of the running Encore application
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -24,35 +25,44 @@ func (s *Server) IsGateway() bool {
 // createGatewayHandlerAdapter creates a httprouter.Handle that proxies requests
 // on top of the given handler to the service that is hosting the handler.
 func (s *Server) createGatewayHandlerAdapter(h Handler) httprouter.Handle {
-	service, found := s.runtime.ServiceDiscovery[h.ServiceName()]
+	service, found := s.serviceRoute(h.ServiceName())
 	if !found {
 		panic(fmt.Sprintf("service %q not found in service discovery when hosted in gateway", h.ServiceName()))
 	}
 
-	serviceBaseURL, err := url.Parse(service.URL)
-	if err != nil {
-		panic(fmt.Sprintf("failed to parse service URL %q: %v", service.URL, err))
-	}
-
 	// On cloud environments, we want to log the proxying of requests to services
 	// but locally we don't want the overhead of logging every request.
-	logger := s.rootLogger.With().Str("service", service.Name).Str("endpoint", h.EndpointName()).Str("base_url", serviceBaseURL.String()).Logger()
+	logger := s.rootLogger.With().Str("service", service.Name).Str("endpoint", h.EndpointName()).Str("base_url", service.URL).Logger()
 
-	proxy := s.createProxyToService(service, h.EndpointName(), serviceBaseURL, logger)
+	proxy, err := s.createProxyToService(service, h.EndpointName(), logger)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse service URL %q: %v", service.URL, err))
+	}
 	return func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		s.beginOperation()
 		defer s.finishOperation()
 
 		meta := CallMetaFromContext(req.Context())
 
-		info, proceed := s.runAuthHandler(h, s.NewIncomingContext(w, req, toUnnamedParams(ps), meta))
+		c := s.NewIncomingContext(w, req, toUnnamedParams(ps), meta)
+		if !s.checkIPFilter(h, c) {
+			return
+		}
+
+		info, proceed := s.runAuthHandler(h, c)
 		if proceed {
+			c.auth = info
+			if !s.checkRateLimit(h, c) {
+				return
+			}
+
 			meta.Internal = &InternalCallMeta{
 				Caller: GatewayCaller{
 					GatewayName: "api-gateway",
 				},
 				AuthUID:  string(info.UID),
 				AuthData: info.UserData,
+				Baggage:  info.Baggage.All(),
 			}
 			req = req.WithContext(SetCallMetaInContext(req.Context(), meta))
 
@@ -64,14 +74,18 @@ func (s *Server) createGatewayHandlerAdapter(h Handler) httprouter.Handle {
 	}
 }
 
-// createProxyToService creates a httputil.ReverseProxy that proxies requests onto the target service.
-func (s *Server) createProxyToService(service config.Service, endpointName string, serviceBaseURL *url.URL, logger zerolog.Logger) *httputil.ReverseProxy {
+// createProxyToService creates a serviceProxy that proxies requests onto the target service.
+func (s *Server) createProxyToService(service config.Service, endpointName string, logger zerolog.Logger) (*serviceProxy, error) {
 	callee := fmt.Sprintf("%s.%s", service.Name, endpointName)
 
-	proxy := &httputil.ReverseProxy{
+	p, err := s.newServiceProxy(service)
+	if err != nil {
+		return nil, err
+	}
+	p.proxy = &httputil.ReverseProxy{
 		// Rewrite the inbound request
 		Rewrite: func(req *httputil.ProxyRequest) {
-			req.SetURL(serviceBaseURL)
+			req.SetURL(proxyTarget(req.In))
 
 			t := transport.HTTPRequest(req.Out)
 			t.SetMeta(calleeMetaName, callee) // required by the Handler which verifies we wanted to call this endpoint
@@ -84,18 +98,86 @@ func (s *Server) createProxyToService(service config.Service, endpointName strin
 		// Have the reverse proxy log errors to our logger.
 		ErrorLog: newZeroLogAdapter(logger, zerolog.ErrorLevel),
 		// Handle proxy errors using our error handler output
-		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
-			logger.Err(err).Msg("error proxying request to service")
-			errs.HTTPError(w, errs.B().Cause(err).Code(errs.Unavailable).Err())
-		},
+		ErrorHandler: p.errorHandler(logger),
 	}
 
 	// If the service is served without TLS, we need to configure the proxy to allow forwarding
 	// HTTP2 in clear text to make sure grpc requests are forwarded correctly.
-	if serviceBaseURL.Scheme == "http" {
-		proxy.Transport = transport.NewH2CTransport(http.DefaultTransport)
+	// Otherwise use the client's transport, which presents the certificate
+	// for mutual TLS if configured.
+	if p.scheme == "http" {
+		p.proxy.Transport = transport.NewH2CTransport(http.DefaultTransport)
+	} else if s.httpClient.Transport != nil {
+		p.proxy.Transport = s.httpClient.Transport
+	}
+	return p, nil
+}
+
+// serviceProxy proxies requests onto a service that isn't hosted by this process.
+//
+// The service's base URL is resolved for each request, so that
+// services resolved using xDS are proxied to their current endpoints.
+type serviceProxy struct {
+	server  *Server
+	service config.Service
+	scheme  string // the scheme of the service's base URLs
+	proxy   *httputil.ReverseProxy
+}
+
+// newServiceProxy returns a serviceProxy for service, whose proxy must be set by the caller.
+func (s *Server) newServiceProxy(service config.Service) (*serviceProxy, error) {
+	scheme := xdsScheme(service)
+	if service.URL != "" {
+		u, err := url.Parse(service.URL)
+		if err != nil {
+			return nil, err
+		}
+		scheme = u.Scheme
+	}
+	return &serviceProxy{server: s, service: service, scheme: scheme}, nil
+}
+
+func (p *serviceProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	baseURL, done, err := p.server.serviceBaseURL(req.Context(), p.service)
+	if err != nil {
+		p.proxy.ErrorHandler(w, req, err)
+		return
+	}
+	target, err := url.Parse(baseURL)
+	if err != nil {
+		p.proxy.ErrorHandler(w, req, err)
+		done(err)
+		return
+	}
+
+	call := &proxyCall{target: target}
+	p.proxy.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), proxyCallKey{}, call)))
+	done(call.err)
+}
+
+// errorHandler returns the proxy's error handler, which records the error
+// for the proxied call and responds with it.
+func (p *serviceProxy) errorHandler(logger zerolog.Logger) func(http.ResponseWriter, *http.Request, error) {
+	return func(w http.ResponseWriter, req *http.Request, err error) {
+		if call, ok := req.Context().Value(proxyCallKey{}).(*proxyCall); ok {
+			call.err = err
+		}
+		logger.Err(err).Msg("error proxying request to service")
+		errs.HTTPError(w, errs.B().Cause(err).Code(errs.Unavailable).Err())
 	}
-	return proxy
+}
+
+// proxyCall is a request being proxied by a serviceProxy.
+type proxyCall struct {
+	target *url.URL // the base URL the request is proxied to
+	err    error    // the error proxying the request, if any
+}
+
+type proxyCallKey struct{}
+
+// proxyTarget returns the base URL to proxy req to.
+func proxyTarget(req *http.Request) *url.URL {
+	return req.Context().Value(proxyCallKey{}).(*proxyCall).target
 }
 
 type zeroLogWriter struct {
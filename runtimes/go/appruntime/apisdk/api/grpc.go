@@ -0,0 +1,291 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"encore.dev/appruntime/apisdk/api/errmarshalling"
+	"encore.dev/appruntime/apisdk/api/protoenc"
+	"encore.dev/appruntime/apisdk/api/transport"
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/beta/errs"
+)
+
+// Service-to-service calls to services configured with the gRPC protocol
+// are made as unary gRPC calls, with the request and response encoded as
+// protobuf messages by protoenc. The calls are served by the same server
+// as HTTP requests, and go through the same auth, rate limiting and
+// middleware as service-to-service calls made over HTTP.
+//
+// See https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-HTTP2.md.
+
+const (
+	grpcContentType = "application/grpc"
+
+	// grpcErrorBinHeader carries the error of a failed call in the
+	// format of errmarshalling, so it can be returned to the caller
+	// without loss of information like for calls made over HTTP.
+	grpcErrorBinHeader = "Encore-Error-Bin"
+
+	// grpcFrameHeaderLen is the length of the header of a gRPC message:
+	// a compression flag followed by the message length as a big-endian uint32.
+	grpcFrameHeaderLen = 5
+)
+
+// grpcMethod returns the gRPC method path of an API.
+func grpcMethod(service, endpoint string) string {
+	return "/" + service + "/" + endpoint
+}
+
+// isGRPCRequest reports whether req is a gRPC call with protobuf encoded messages.
+func isGRPCRequest(req *http.Request) bool {
+	if req.ProtoMajor != 2 || req.Method != http.MethodPost {
+		return false
+	}
+	ct := req.Header.Get("Content-Type")
+	return ct == grpcContentType || ct == grpcContentType+"+proto"
+}
+
+// grpcHandler is implemented by handlers of APIs that can be called over gRPC.
+type grpcHandler interface {
+	supportsGRPC() bool
+}
+
+// supportsGRPC reports whether the API can be called over gRPC,
+// which isn't the case for streaming, multipart and raw endpoints.
+// The compiler generates the protobuf messages of the other endpoints,
+// and fails the build if their field numbers conflict.
+func (d *Desc[Req, Resp]) supportsGRPC() bool {
+	return !d.Raw && !d.SSE && !d.WebSocket && !d.Fallback && !d.StreamRequest && !d.StreamResponse && !d.Multipart
+}
+
+// registerGRPCEndpoint registers h to be called over gRPC, if supported.
+func (s *Server) registerGRPCEndpoint(h Handler) {
+	if g, ok := h.(grpcHandler); ok && g.supportsGRPC() {
+		s.grpcHandlers[grpcMethod(h.ServiceName(), h.EndpointName())] = h
+	}
+}
+
+// handleGRPC handles a gRPC call made by another service.
+func (s *Server) handleGRPC(w http.ResponseWriter, req *http.Request, internalCaller Caller) {
+	c := s.NewIncomingContext(w, req, nil, CallMetaFromContext(req.Context()))
+	c.grpc = true
+
+	// Only service-to-service calls are made over gRPC.
+	if internalCaller == nil || !internalCaller.PrivateAPIAccess() {
+		returnError(c, errs.B().Code(errs.PermissionDenied).Msg("grpc calls are only accepted from other services").Err(), 0)
+		return
+	}
+
+	h, found := s.grpcHandlers[req.URL.Path]
+	if !found {
+		returnError(c, errs.B().Code(errs.Unimplemented).Msg("endpoint not found").Err(), 0)
+		return
+	}
+	s.processRequest(h, c)
+}
+
+// decodeGRPCReq decodes the request message of a gRPC call.
+func (d *Desc[Req, Resp]) decodeGRPCReq(req *http.Request) (reqData Req, params UnnamedParams, err error) {
+	msg, err := readGRPCMessage(req.Body)
+	if err != nil {
+		return reqData, nil, err
+	}
+	if err := protoenc.Unmarshal(msg, &reqData, d.ProtoReq); err != nil {
+		return reqData, nil, err
+	}
+	_, params, err = d.ReqPath(reqData)
+	return reqData, params, err
+}
+
+// writeGRPCResp writes the response message of a successful gRPC call.
+func writeGRPCResp[Resp any](c IncomingContext, respData Resp, schema *protoenc.Schema) error {
+	msg, err := protoenc.Marshal(respData, schema)
+	if err != nil {
+		err = errs.B().Cause(err).Code(errs.Internal).Msg("unable to marshal response").Err()
+		returnError(c, err, 0)
+		return err
+	}
+
+	c.w.Header().Set("Content-Type", grpcContentType)
+	c.w.WriteHeader(http.StatusOK)
+	_, err = c.w.Write(appendGRPCFrame(nil, msg))
+	c.w.Header().Set(http.TrailerPrefix+"Grpc-Status", "0")
+	return err
+}
+
+// writeGRPCError writes err as the status of a failed gRPC call.
+// The status is sent in the headers of a response without messages,
+// which gRPC calls a Trailers-Only response.
+func writeGRPCError(w http.ResponseWriter, err error) {
+	h := w.Header()
+	h.Set("Content-Type", grpcContentType)
+	h.Set("Grpc-Status", strconv.Itoa(int(errs.Code(err))))
+	msg := err.Error()
+	if e, ok := err.(*errs.Error); ok {
+		msg = e.Message
+	}
+	if msg != "" {
+		h.Set("Grpc-Message", url.PathEscape(msg))
+	}
+	h.Set(grpcErrorBinHeader, base64.RawStdEncoding.EncodeToString(errmarshalling.Marshal(err)))
+	w.WriteHeader(http.StatusOK)
+}
+
+// grpcCall calls the API on a service configured with the gRPC protocol.
+func (d *Desc[Req, Resp]) grpcCall(c CallContext, service config.Service, req Req) (respData Resp, respErr error) {
+	msg, err := protoenc.Marshal(req, d.ProtoReq)
+	if err != nil {
+		c.server.rootLogger.Err(err).Msg("unable to marshal request")
+		respErr = errs.Convert(err)
+		return
+	}
+
+	baseURL, doneCall, err := c.server.serviceBaseURL(c.ctx, service)
+	if err != nil {
+		respErr = errs.Convert(err)
+		return
+	}
+	defer func() { doneCall(respErr) }()
+
+	reqURL := baseURL + grpcMethod(d.Service, d.Endpoint)
+	httpReq, err := http.NewRequestWithContext(c.ctx, http.MethodPost, reqURL, bytes.NewReader(appendGRPCFrame(nil, msg)))
+	if err != nil {
+		c.server.rootLogger.Err(err).Msg("unable to create gRPC request")
+		respErr = errs.Convert(err)
+		return
+	}
+	httpReq.Proto, httpReq.ProtoMajor, httpReq.ProtoMinor = "HTTP/2", 2, 0
+	httpReq.Header.Set("Content-Type", grpcContentType)
+	httpReq.Header.Set("TE", "trailers")
+	reqTransport := transport.HTTPRequest(httpReq)
+
+	// Set the name of the API we want to call
+	reqTransport.SetMeta(calleeMetaName, fmt.Sprintf("%s.%s", d.Service, d.Endpoint))
+
+	call, meta, err := c.server.beginCall(c.ctx, d.Service, d.Endpoint, d.DefLoc)
+	if err != nil {
+		c.server.rootLogger.Err(err).Msg("unable to begin call")
+		respErr = errs.Convert(err)
+		return
+	}
+
+	if err := meta.AddToRequest(c.server, service, reqTransport); err != nil {
+		c.server.rootLogger.Err(err).Msg("unable to add metadata to request")
+		respErr = errs.Convert(err)
+		return
+	}
+
+	respErr = (func() error {
+		httpResp, err := c.server.grpcClient.Do(httpReq)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = httpResp.Body.Close() }()
+		return readGRPCResp(httpResp, &respData, d.ProtoResp)
+	})()
+
+	if respErr != nil {
+		c.server.rootLogger.Err(respErr).Str("target", fmt.Sprintf("%s.%s", d.Service, d.Endpoint)).Str("url", reqURL).Msg("call failed")
+	}
+
+	c.server.finishCall(call, respErr)
+	return
+}
+
+// readGRPCResp reads the response of a gRPC call, decoding its message described by schema into respData.
+func readGRPCResp(httpResp *http.Response, respData any, schema *protoenc.Schema) error {
+	if httpResp.StatusCode != http.StatusOK {
+		return unmarshalErrorResponse(httpResp)
+	}
+
+	// Trailers-Only responses carry the status in the headers.
+	if httpResp.Header.Get("Grpc-Status") != "" {
+		return grpcStatusError(httpResp.Header)
+	}
+
+	msg, err := readGRPCMessage(httpResp.Body)
+	if err == nil {
+		// Read the rest of the body so the trailers are received.
+		_, err = io.Copy(io.Discard, httpResp.Body)
+	}
+	if err != nil && httpResp.Trailer.Get("Grpc-Status") == "" {
+		return errs.B().Cause(err).Code(errs.Internal).Msg("request failed: unable to read response").Err()
+	} else if statusErr := grpcStatusError(httpResp.Trailer); statusErr != nil {
+		return statusErr
+	}
+
+	if err := protoenc.Unmarshal(msg, respData, schema); err != nil {
+		return errs.B().Cause(err).Code(errs.Internal).Msg("request failed: unable to unmarshal response").Err()
+	}
+	return nil
+}
+
+// grpcStatusError returns the error described by the gRPC status in h,
+// or nil if the call succeeded.
+func grpcStatusError(h http.Header) error {
+	status := h.Get("Grpc-Status")
+	if status == "" {
+		return errs.B().Code(errs.Internal).Msg("request failed: missing grpc status").Err()
+	}
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		return errs.B().Code(errs.Internal).Msgf("request failed: invalid grpc status %q", status).Err()
+	} else if code == 0 {
+		return nil
+	}
+
+	if bin := h.Get(grpcErrorBinHeader); bin != "" {
+		if data, err := base64.RawStdEncoding.DecodeString(bin); err == nil {
+			if respErr, err := errmarshalling.Unmarshal(data); err == nil {
+				return respErr
+			}
+		}
+	}
+
+	// The call was made to a server that doesn't encode Encore errors.
+	errCode := errs.ErrCode(code)
+	if code < 0 || code > int(errs.Unauthenticated) {
+		errCode = errs.Unknown
+	}
+	msg, err := url.PathUnescape(h.Get("Grpc-Message"))
+	if err != nil {
+		msg = h.Get("Grpc-Message")
+	}
+	return errs.B().Code(errCode).Msg(msg).Err()
+}
+
+// appendGRPCFrame appends msg to b, prefixed by the header of an uncompressed gRPC message.
+func appendGRPCFrame(b, msg []byte) []byte {
+	b = append(b, 0)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(msg)))
+	return append(b, msg...)
+}
+
+// readGRPCMessage reads a single gRPC message from r.
+func readGRPCMessage(r io.Reader) ([]byte, error) {
+	var hdr [grpcFrameHeaderLen]byte
+	if _, err := io.ReadFull(r, hdr[:]); errors.Is(err, io.EOF) {
+		return nil, errors.New("missing grpc message")
+	} else if err != nil {
+		return nil, err
+	} else if hdr[0] != 0 {
+		return nil, errors.New("compressed grpc messages are not supported")
+	}
+
+	size := int64(binary.BigEndian.Uint32(hdr[1:]))
+	msg, err := io.ReadAll(io.LimitReader(r, size))
+	if err != nil {
+		return nil, err
+	} else if int64(len(msg)) != size {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return msg, nil
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog"
+
+	encore "encore.dev"
+	"encore.dev/appruntime/apisdk/api/protoenc"
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/health"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/appruntime/shared/traceprovider"
+	"encore.dev/beta/errs"
+	"encore.dev/metrics"
+	"encore.dev/pubsub"
+)
+
+type grpcTestReq struct {
+	ID      string
+	Payload *grpcTestParams
+}
+
+type grpcTestParams struct {
+	Tags    []string
+	Created time.Time
+}
+
+type grpcTestResp struct {
+	Message string
+	Count   int
+}
+
+func newGRPCTestDesc(endpoint string) *Desc[*grpcTestReq, *grpcTestResp] {
+	return &Desc[*grpcTestReq, *grpcTestResp]{
+		Service:        "svc",
+		Endpoint:       endpoint,
+		Methods:        []string{"GET"},
+		Path:           "/items/:id",
+		RawPath:        "/items/:id",
+		PathParamNames: []string{"id"},
+		Access:         Private,
+		CloneReq: func(req *grpcTestReq) (*grpcTestReq, error) {
+			clone := *req
+			return &clone, nil
+		},
+		ReqPath: func(req *grpcTestReq) (string, UnnamedParams, error) {
+			return "/items/" + req.ID, UnnamedParams{req.ID}, nil
+		},
+		ReqUserPayload: func(req *grpcTestReq) any { return req.Payload },
+		ProtoReq: &protoenc.Schema{Root: "grpcTestReq", Messages: map[string]protoenc.Message{
+			"grpcTestReq": {Fields: []protoenc.Field{
+				{Name: "ID", Number: 1},
+				{Name: "Payload", Number: 2, Message: "grpcTestParams"},
+			}},
+			"grpcTestParams": {Fields: []protoenc.Field{
+				{Name: "Tags", Number: 20001},
+				{Name: "Created", Number: 20002},
+			}},
+		}},
+		AppHandler: func(ctx context.Context, req *grpcTestReq) (*grpcTestResp, error) {
+			if req.ID == "missing" {
+				return nil, errs.B().Code(errs.NotFound).Meta("id", req.ID).Msg("item not found").Err()
+			}
+			if req.Payload.Created.IsZero() {
+				return nil, errors.New("missing creation time")
+			}
+			return &grpcTestResp{Message: req.ID + ":" + strings.Join(req.Payload.Tags, ","), Count: len(req.Payload.Tags)}, nil
+		},
+		CloneResp: func(resp *grpcTestResp) (*grpcTestResp, error) {
+			clone := *resp
+			return &clone, nil
+		},
+		ProtoResp: &protoenc.Schema{Root: "grpcTestResp", Messages: map[string]protoenc.Message{
+			"grpcTestResp": {Fields: []protoenc.Field{
+				{Name: "Message", Number: 1},
+				{Name: "Count", Number: 2},
+			}},
+		}},
+	}
+}
+
+func TestGRPC_EndToEnd(t *testing.T) {
+	desc := newGRPCTestDesc("Get")
+	callee := newGRPCTestServer(&config.Runtime{
+		HostedServices: []string{"svc"},
+		ServiceAuth:    []config.ServiceAuth{{Method: "noop"}},
+	})
+	callee.registerEndpoint(desc, nil)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = callee.Serve(ln) }()
+	t.Cleanup(func() { _ = callee.httpsrv.Close() })
+	baseURL := "http://" + ln.Addr().String()
+
+	caller := newGRPCTestServer(&config.Runtime{
+		HostedServices: []string{"other"},
+		ServiceAuth:    []config.ServiceAuth{{Method: "noop"}},
+		ServiceDiscovery: map[string]config.Service{
+			"svc": {Name: "svc", URL: baseURL, Protocol: config.GRPC, ServiceAuth: config.ServiceAuth{Method: "noop"}},
+		},
+	})
+	ctx := caller.NewCallContext(context.Background())
+
+	t.Run("success", func(t *testing.T) {
+		resp, err := desc.Call(ctx, &grpcTestReq{
+			ID:      "foo",
+			Payload: &grpcTestParams{Tags: []string{"a", "b"}, Created: time.Now()},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Message != "foo:a,b" || resp.Count != 2 {
+			t.Errorf("got response %+v, want {Message:foo:a,b Count:2}", resp)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := desc.Call(ctx, &grpcTestReq{ID: "missing", Payload: &grpcTestParams{}})
+		var e *errs.Error
+		if !errors.As(err, &e) {
+			t.Fatalf("got error %v, want *errs.Error", err)
+		}
+		if e.Code != errs.NotFound || e.Message != "item not found" || e.Meta["id"] != "missing" {
+			t.Errorf("got error %+v, want not_found error with metadata", e)
+		}
+	})
+
+	t.Run("unknown_error", func(t *testing.T) {
+		_, err := desc.Call(ctx, &grpcTestReq{ID: "foo", Payload: &grpcTestParams{}})
+		if got := errs.Code(err); got != errs.Unknown {
+			t.Errorf("got code %s, want %s", got, errs.Unknown)
+		}
+	})
+
+	// gRPC calls must be made by other services.
+	t.Run("unauthenticated_caller", func(t *testing.T) {
+		err := rawGRPCCall(t, caller, baseURL+grpcMethod("svc", "Get"))
+		if got := errs.Code(err); got != errs.PermissionDenied {
+			t.Errorf("got error %v, want %s", err, errs.PermissionDenied)
+		}
+	})
+
+	t.Run("unknown_method", func(t *testing.T) {
+		_, err := newGRPCTestDesc("Unknown").Call(ctx, &grpcTestReq{ID: "foo", Payload: &grpcTestParams{}})
+		if got := errs.Code(err); got != errs.Unimplemented {
+			t.Errorf("got error %v, want %s", err, errs.Unimplemented)
+		}
+	})
+}
+
+func TestGRPC_Unsupported(t *testing.T) {
+	s := newGRPCTestServer(&config.Runtime{HostedServices: []string{"svc"}})
+	s.registerGRPCEndpoint(newGRPCTestDesc("Get"))
+
+	// Multipart requests contain files, which are only sent over HTTP.
+	multipart := newGRPCTestDesc("Upload")
+	multipart.Multipart = true
+	s.registerGRPCEndpoint(multipart)
+
+	if _, ok := s.grpcHandlers[grpcMethod("svc", "Get")]; !ok {
+		t.Error("endpoint not registered for grpc")
+	}
+	if _, ok := s.grpcHandlers[grpcMethod("svc", "Upload")]; ok {
+		t.Error("multipart endpoint registered for grpc")
+	}
+}
+
+// rawGRPCCall makes a gRPC call without any Encore call metadata.
+func rawGRPCCall(t *testing.T, s *Server, url string) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(appendGRPCFrame(nil, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2", 2, 0
+	req.Header.Set("Content-Type", grpcContentType)
+	resp, err := s.grpcClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var respData grpcTestResp
+	return readGRPCResp(resp, &respData, nil)
+}
+
+func TestGRPCStatusError(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   http.Header
+		wantCode errs.ErrCode
+		wantMsg  string
+	}{
+		{
+			name:     "ok",
+			header:   http.Header{"Grpc-Status": {"0"}},
+			wantCode: errs.OK,
+		},
+		{
+			name:     "status",
+			header:   http.Header{"Grpc-Status": {"5"}, "Grpc-Message": {"item%20not%20found"}},
+			wantCode: errs.NotFound,
+			wantMsg:  "item not found",
+		},
+		{
+			name:     "unknown_status",
+			header:   http.Header{"Grpc-Status": {"42"}},
+			wantCode: errs.Unknown,
+			wantMsg:  "unknown error",
+		},
+		{
+			name:     "missing",
+			header:   http.Header{},
+			wantCode: errs.Internal,
+			wantMsg:  "request failed: missing grpc status",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := grpcStatusError(test.header)
+			if got := errs.Code(err); got != test.wantCode {
+				t.Errorf("got code %s, want %s", got, test.wantCode)
+			}
+			if e, ok := err.(*errs.Error); ok && e.Message != test.wantMsg {
+				t.Errorf("got message %q, want %q", e.Message, test.wantMsg)
+			}
+		})
+	}
+}
+
+func newGRPCTestServer(runtime *config.Runtime) *Server {
+	static := &config.Static{}
+	logger := zerolog.Nop()
+	rt := reqtrack.New(logger, nil, &traceprovider.DefaultFactory{})
+	reg := metrics.NewRegistry(rt, 0)
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	encoreMgr := encore.NewManager(static, runtime, rt)
+	testingMgr := testsupport.NewManager(static, rt, logger)
+	pubsubMgr := pubsub.NewManager(static, runtime, rt, testingMgr, logger, json, reg)
+	return NewServer(static, runtime, rt, nil, encoreMgr, pubsubMgr, nil, logger, reg, health.NewCheckRegistry(), testingMgr, json, clock.New())
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 
 	encore "encore.dev"
 	"encore.dev/appruntime/apisdk/api/errmarshalling"
+	"encore.dev/appruntime/apisdk/api/protoenc"
 	"encore.dev/appruntime/apisdk/api/transport"
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/experiments"
@@ -22,10 +24,14 @@ import (
 	"encore.dev/appruntime/exported/stack"
 	"encore.dev/appruntime/shared/cfgutil"
 	"encore.dev/appruntime/shared/cloudtrace"
+	"encore.dev/appruntime/shared/faults"
 	"encore.dev/appruntime/shared/jsonapi"
 	"encore.dev/beta/errs"
 	"encore.dev/internal/platformauth"
 	"encore.dev/middleware"
+	"encore.dev/sse"
+	"encore.dev/validate"
+	"encore.dev/websocket"
 )
 
 // NamedParams are named path parameters.
@@ -76,13 +82,49 @@ type Desc[Req, Resp any] struct {
 	// Access describes the access type for this API.
 	Access Access
 
+	// RequiredScopes are the scopes the auth data must have, all of which are required.
+	// RequiredRoles are the roles the auth data must have, any one of which is sufficient.
+	// They are only set for APIs with Access == RequiresAuth.
+	RequiredScopes []string
+	RequiredRoles  []string
+
+	// TraceSampleRate is the rate at which to sample traces of requests
+	// to this API, between [0, 1]. If nil, the app's sampling rate is used.
+	TraceSampleRate *float64
+
 	// If raw is true, RawHandler is set and AppHandler and EncodeResp are nil.
 	Raw bool
 
+	// If SSE is true, the endpoint streams server-sent events of type Resp,
+	// SSEHandler is set and AppHandler and EncodeResp are nil.
+	SSE bool
+
+	// If WebSocket is true, the endpoint accepts WebSocket connections
+	// sending messages of type Resp, WebSocketHandler is set and
+	// AppHandler and EncodeResp are nil.
+	WebSocket bool
+
 	// If Fallback is true, the handler is a fallback handler
 	// for when other routes don't match.
 	Fallback bool
 
+	// If StreamRequest is true, DecodeReq leaves the request body
+	// unread for the handler to stream. If StreamResponse is true,
+	// EncodeResp streams the response body and sets its content type.
+	StreamRequest  bool
+	StreamResponse bool
+
+	// If Multipart is true, requests may contain files and are decoded
+	// from multipart forms. MaxMultipartSize is the largest body accepted,
+	// or 0 to use the limit set by the runtime config.
+	Multipart        bool
+	MaxMultipartSize int64
+
+	// ProtoReq and ProtoResp describe the protobuf messages the request
+	// and response are encoded as, for calls made over gRPC.
+	ProtoReq  *protoenc.Schema
+	ProtoResp *protoenc.Schema
+
 	DecodeReq      func(*http.Request, UnnamedParams, jsoniter.API) (Req, UnnamedParams, error)
 	CloneReq       func(Req) (Req, error)
 	ReqPath        func(Req) (path string, params UnnamedParams, err error)
@@ -90,6 +132,9 @@ type Desc[Req, Resp any] struct {
 
 	AppHandler func(context.Context, Req) (Resp, error)
 	RawHandler func(http.ResponseWriter, *http.Request)
+	SSEHandler func(context.Context, Req, *sse.Writer[Resp]) error
+
+	WebSocketHandler func(context.Context, Req, websocket.Socket) error
 
 	EncodeResp func(http.ResponseWriter, jsoniter.API, Resp) error
 	CloneResp  func(Resp) (Resp, error)
@@ -130,6 +175,20 @@ func (d *Desc[Req, Resp]) Handle(c IncomingContext) {
 		c.capturer = newRawRequestBodyCapturer(c.req)
 		c.req.Body = c.capturer
 		defer c.capturer.Dispose()
+
+		// Raw endpoints are commonly called by non-Encore services,
+		// so they join the caller's trace if it's propagated.
+		c.callMeta.joinExternalTrace(transport.HTTPRequest(c.req))
+	}
+
+	if d.Multipart {
+		// Large uploads are spooled to temporary files, which net/http only removes
+		// for forms parsed on the original request and not the copy handlers receive.
+		defer func() {
+			if form := c.req.MultipartForm; form != nil {
+				_ = form.RemoveAll()
+			}
+		}()
 	}
 
 	// If this is an internal encore-to-encore call, we need to verify the caller is allowed to make this call.
@@ -143,25 +202,71 @@ func (d *Desc[Req, Resp]) Handle(c IncomingContext) {
 		}
 	}
 
+	// Browsers don't apply CORS to WebSocket connections.
+	if d.WebSocket && !c.server.allowWebSocketOrigin(c.req) {
+		returnError(c, errs.B().Code(errs.PermissionDenied).Msg("websocket origin not allowed").Err(), 0)
+		return
+	}
+
 	reqData, beginErr := d.begin(c)
 	if beginErr != nil {
 		returnError(c, beginErr, 0)
 		return
 	}
 
-	resp, respData := d.handleIncoming(c, reqData)
+	var events *sse.Writer[Resp]
+	var sock *webSocket
+	if d.SSE {
+		events = sse.NewWriter[Resp](c.w, c.server.json, c.req.Header.Get("Last-Event-ID"))
+	} else if d.WebSocket {
+		sock = newWebSocket(c, d.Service, d.Endpoint)
+	}
+
+	// Faults are injected once the request has begun, so they're part of its trace.
+	fault := c.server.faults.Inject(c.ctx, faults.Op{Target: config.FaultEndpoint, Service: d.Service, Endpoint: d.Endpoint})
+	var resp *model.Response
+	var respData Resp
+	if fault.Err != nil {
+		resp = newErrResp(fault.Err, 0)
+	} else {
+		resp, respData = d.handleIncoming(c, reqData, events, sock)
+	}
+
+	// Dropping the response aborts the connection without writing it.
+	// Raw endpoints have already written their response, so it can't be dropped.
+	if fault.Drop && !d.Raw {
+		if resp.Err == nil {
+			resp.Err = faults.DroppedError()
+		}
+		c.server.finishRequest(resp)
+		panic(http.ErrAbortHandler)
+	}
+
 	if resp.Err != nil {
 		c.server.finishRequest(resp)
 
 		// If the endpoint is raw it has already written its response;
-		// don't write another.
-		if !d.Raw {
+		// don't write another. Once an event stream has started,
+		// the error can only be sent as an event, and once a WebSocket
+		// connection has been accepted it's closed with the error.
+		if d.SSE && events.Started() {
+			_ = events.SendError(resp.Err)
+		} else if d.WebSocket && sock.responded {
+			// Nothing more to write.
+		} else if !d.Raw {
 			returnError(c, resp.Err, resp.HTTPStatus)
 		}
 		return
 	}
 
-	if !d.Raw {
+	if d.SSE {
+		// Establish the stream even if the handler sent no events.
+		resp.Err = events.Start()
+	} else if c.grpc {
+		resp.Err = writeGRPCResp(c, respData, d.ProtoResp)
+	} else if d.StreamResponse {
+		resp.Err = d.EncodeResp(c.w, c.server.json, respData)
+	} else if !d.Raw && !d.WebSocket {
 		c.w.Header().Set("Content-Type", "application/json")
 		c.w.Header().Set("X-Content-Type-Options", "nosniff")
 		resp.Err = d.EncodeResp(c.w, c.server.json, respData)
@@ -183,7 +288,11 @@ func (d *Desc[Req, Resp]) Handle(c IncomingContext) {
 // If statusCodeToUse is 0, we will use the default status code for the error using
 // the [errs] package.
 func returnError(c IncomingContext, err error, statusCodeToUse int) {
-	if c.callMeta.PrivateAPIAccess() {
+	if c.grpc {
+		// gRPC calls are only made by other services,
+		// and always get the full error.
+		writeGRPCError(c.w, err)
+	} else if c.callMeta.PrivateAPIAccess() {
 		// If this is an internal service to service call, we want to return the full error
 		// we'll add a header to the response to indicate that the error is a full error
 		// and the calling code can use this to determine how to unmarshal the response object.
@@ -208,7 +317,16 @@ func returnError(c IncomingContext, err error, statusCodeToUse int) {
 }
 
 func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error) {
-	reqData, params, decodeErr := d.DecodeReq(c.req, c.ps, c.server.json)
+	var params UnnamedParams
+	var decodeErr error
+	if c.grpc {
+		reqData, params, decodeErr = d.decodeGRPCReq(c.req)
+	} else {
+		if d.Multipart {
+			c.req.Body = http.MaxBytesReader(c.w, c.req.Body, d.maxMultipartSize(c.server))
+		}
+		reqData, params, decodeErr = d.DecodeReq(c.req, c.ps, c.server.json)
+	}
 
 	if d.Access == RequiresAuth && c.auth.UID == "" {
 		beginErr = errs.B().
@@ -217,8 +335,13 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 			Msg("endpoint requires auth but none provided").
 			Err()
 		return
+	} else if err := d.authorize(c.auth.UserData); err != nil {
+		beginErr = err
+		return
 	}
 
+	clientIP, _ := c.server.clientIP(c.req)
+
 	// Only compute inputs and payload if we have valid reqData.
 	var payload any
 	var nonRawPayload []byte
@@ -229,7 +352,7 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 		}
 	}
 
-	_, err := c.server.beginRequest(c.ctx, &beginRequestParams{
+	req, err := c.server.beginRequest(c.ctx, &beginRequestParams{
 		Type:          model.RPCCall,
 		DefLoc:        d.DefLoc,
 		TraceID:       c.callMeta.TraceID,
@@ -247,10 +370,13 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 			UserID:               c.auth.UID,
 			AuthData:             c.auth.UserData,
 			RequestHeaders:       c.req.Header,
+			ClientIP:             clientIP,
 			FromEncorePlatform:   platformauth.IsEncorePlatformRequest(c.req.Context()),
 			ServiceToServiceCall: c.callMeta.IsServiceToService(),
 		},
 
+		Values:              c.auth.Values,
+		Baggage:             c.auth.Baggage,
 		ExtRequestID:        clampTo64Chars(c.req.Header.Get("X-Request-ID")),
 		ExtCorrelationID:    clampTo64Chars(c.req.Header.Get("X-Correlation-ID")),
 		AdditionalLogFields: cloudtrace.StructuredLogFields(c.req),
@@ -259,6 +385,7 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 		beginErr = errs.B().Code(errs.Internal).Msg("internal error").Err()
 		return
 	}
+	c.server.setTraceIDHeaders(c.w, req)
 
 	// If we fail after having begun the request, mark it as completed.
 	defer func() {
@@ -267,7 +394,11 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 		}
 	}()
 
-	if decodeErr != nil {
+	if maxErr := (*http.MaxBytesError)(nil); errors.As(decodeErr, &maxErr) {
+		beginErr = errs.B().Code(errs.InvalidArgument).
+			Msgf("request body too large, the limit is %d bytes", maxErr.Limit).Err()
+		return
+	} else if decodeErr != nil {
 		beginErr = errs.WrapCode(decodeErr, errs.InvalidArgument, "decode request")
 		return
 	}
@@ -275,8 +406,24 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 	return reqData, nil
 }
 
+// defaultMaxMultipartSize is the largest multipart body accepted by default
+// when the size of the files isn't limited by maxsize rules.
+const defaultMaxMultipartSize = 64 << 20
+
+// maxMultipartSize returns the largest multipart request body to accept.
+func (d *Desc[Req, Resp]) maxMultipartSize(s *Server) int64 {
+	if d.MaxMultipartSize > 0 {
+		return d.MaxMultipartSize
+	} else if n := s.runtime.MaxMultipartSize; n > 0 {
+		return n
+	}
+	return defaultMaxMultipartSize
+}
+
 // handleIncoming executes the given handler, running middleware in the process.
-func (d *Desc[Req, Resp]) handleIncoming(c IncomingContext, reqData Req) (resp *model.Response, respData Resp) {
+// For SSE endpoints, events is the writer the handler streams events to,
+// and for WebSocket endpoints sock is the connection to accept.
+func (d *Desc[Req, Resp]) handleIncoming(c IncomingContext, reqData Req, events *sse.Writer[Resp], sock *webSocket) (resp *model.Response, respData Resp) {
 	if err := d.validate(reqData); err != nil {
 		return newErrResp(err, 0), respData
 	}
@@ -287,19 +434,35 @@ func (d *Desc[Req, Resp]) handleIncoming(c IncomingContext, reqData Req) (resp *
 		if d.Raw {
 			respCapturer = newRawResponseCapturer(c.w, c.req)
 			return d.invokeHandlerRaw(mwReq, c, respCapturer)
+		} else if d.SSE {
+			return d.invokeHandlerSSE(mwReq, reqData, events)
+		} else if d.WebSocket {
+			return d.invokeHandlerWebSocket(mwReq, reqData, sock)
 		} else {
 			return d.invokeHandlerNonRaw(mwReq, reqData, d.AppHandler)
 		}
 	}
 
-	respData, httpStatus, err := d.executeEndpoint(c.execContext, invokeHandler)
+	// Raw endpoints have already written their response headers by the time
+	// middleware returns, so only other endpoints get the middleware's headers.
+	var respHeader http.Header
+	if !d.Raw {
+		respHeader = c.w.Header()
+	}
+
+	respData, httpStatus, err := d.executeEndpoint(c.execContext, respHeader, invokeHandler)
+	if d.SSE || d.WebSocket {
+		// The events or messages are streamed, so there's no response payload.
+		return &model.Response{HTTPStatus: httpStatus, Err: err}, respData
+	}
 
 	resp = newResp(respData, httpStatus, err, d.Raw, c.capturer, respCapturer, c.server.json)
 	return resp, respData
 }
 
 // executeEndpoint executes the given handler, running middleware in the process.
-func (d *Desc[Req, Resp]) executeEndpoint(c execContext, invokeHandler func(middleware.Request) middleware.Response) (resp Resp, httpStatus int, respErr error) {
+// If respHeader is non-nil the headers set by middleware are added to it.
+func (d *Desc[Req, Resp]) executeEndpoint(c execContext, respHeader http.Header, invokeHandler func(middleware.Request) middleware.Response) (resp Resp, httpStatus int, respErr error) {
 	var counter int
 	var nextFn middleware.Next
 
@@ -370,11 +533,16 @@ func (d *Desc[Req, Resp]) executeEndpoint(c execContext, invokeHandler func(midd
 		return c.server.encoreMgr.CurrentRequest()
 	})
 	mwResp := nextFn(mwReq)
+	if respHeader != nil {
+		for k, v := range mwResp.Header {
+			respHeader[k] = append(respHeader[k], v...)
+		}
+	}
 
 	if mwResp.Err != nil {
 		return resp, mwResp.HTTPStatus, mwResp.Err
 	} else {
-		if resp, ok := mwResp.Payload.(Resp); ok || isVoid[Resp]() {
+		if resp, ok := mwResp.Payload.(Resp); ok || isVoid[Resp]() || d.SSE || d.WebSocket {
 			return resp, mwResp.HTTPStatus, mwResp.Err
 		}
 	}
@@ -405,6 +573,50 @@ func (d *Desc[Req, Resp]) invokeHandlerNonRaw(mwReq middleware.Request, reqData
 	return mwResp
 }
 
+// invokeHandlerSSE invokes the handler for an SSE endpoint. If the endpoint is not SSE, it panics.
+func (d *Desc[Req, Resp]) invokeHandlerSSE(mwReq middleware.Request, reqData Req, events *sse.Writer[Resp]) (mwResp middleware.Response) {
+	if !d.SSE {
+		panic("invokeHandlerSSE called on non-SSE endpoint")
+	}
+	if err := d.SSEHandler(mwReq.Context(), reqData, events); err != nil {
+		mwResp.Err = errs.Convert(err)
+		mwResp.HTTPStatus = errs.HTTPStatus(mwResp.Err)
+	} else {
+		mwResp.HTTPStatus = 200
+	}
+	return mwResp
+}
+
+// invokeHandlerWebSocket accepts the WebSocket connection and invokes the handler
+// for a WebSocket endpoint, closing the connection once it returns.
+// If the endpoint is not a WebSocket endpoint, it panics.
+func (d *Desc[Req, Resp]) invokeHandlerWebSocket(mwReq middleware.Request, reqData Req, sock *webSocket) (mwResp middleware.Response) {
+	if !d.WebSocket {
+		panic("invokeHandlerWebSocket called on non-WebSocket endpoint")
+	}
+
+	ctx, cancel := context.WithCancel(mwReq.Context())
+	defer cancel()
+	if err := sock.accept(ctx); err != nil {
+		mwResp.Err = err
+		mwResp.HTTPStatus = errs.HTTPStatus(err)
+		return mwResp
+	}
+
+	// Close the connection even if the handler panics.
+	defer sock.close(errs.B().Code(errs.Internal).Msg("internal error").Err())
+
+	err := d.WebSocketHandler(ctx, reqData, sock)
+	sock.close(err)
+	if err != nil {
+		mwResp.Err = errs.Convert(err)
+		mwResp.HTTPStatus = errs.HTTPStatus(mwResp.Err)
+	} else {
+		mwResp.HTTPStatus = http.StatusSwitchingProtocols
+	}
+	return mwResp
+}
+
 // invokeHandlerRaw invokes the handler for a raw endpoint. If the endpoint is not raw, it panics.
 func (d *Desc[Req, Resp]) invokeHandlerRaw(mwReq middleware.Request, c IncomingContext, capturer *rawResponseCapturer) (mwResp middleware.Response) {
 	if !d.Raw {
@@ -447,6 +659,21 @@ type CallContext struct {
 }
 
 func (d *Desc[Req, Resp]) Call(c CallContext, req Req) (respData Resp, respErr error) {
+	fault := c.server.faults.Inject(c.ctx, faults.Op{Target: config.FaultCall, Service: d.Service, Endpoint: d.Endpoint})
+	if fault.Err != nil {
+		return respData, fault.Err
+	}
+
+	respData, respErr = d.route(c, req)
+	if fault.Drop && respErr == nil {
+		var zero Resp
+		return zero, faults.DroppedError()
+	}
+	return respData, respErr
+}
+
+// route routes the call to the API, depending on where it's hosted.
+func (d *Desc[Req, Resp]) route(c CallContext, req Req) (respData Resp, respErr error) {
 	// If we're inside a test, we need to check if the target service has been mocked
 	// and if it has, we need to route the call to the mock, otherwise
 	// we'll make an internal call to the API
@@ -475,7 +702,7 @@ func (d *Desc[Req, Resp]) Call(c CallContext, req Req) (respData Resp, respErr e
 	}
 
 	// Otherwise we need to route via the service discovery mechanism
-	service, found := c.server.runtime.ServiceDiscovery[d.Service]
+	service, found := c.server.serviceRoute(d.Service)
 	if !found {
 		// Any service we need to talk to should be in the service discovery map, if it is not
 		// that implies the code is doing something unexpected and we should fail fast.
@@ -563,7 +790,7 @@ func (d *Desc[Req, Resp]) mockedCall(c CallContext, mock reflectedAPIMethod[Req,
 		// If we want to run middleware, use the same code path as internalCall but switch out the handler
 		// to our mock.
 		if runMiddleware {
-			return d.executeEndpoint(ec, func(mwReq middleware.Request) (mwResp middleware.Response) {
+			return d.executeEndpoint(ec, nil, func(mwReq middleware.Request) (mwResp middleware.Response) {
 				return d.invokeHandlerNonRaw(mwReq, req, mock)
 			})
 		}
@@ -578,7 +805,7 @@ func (d *Desc[Req, Resp]) mockedCall(c CallContext, mock reflectedAPIMethod[Req,
 
 func (d *Desc[Req, Resp]) internalCall(c CallContext, req Req) (respData Resp, respErr error) {
 	return d.runCall(c, req, false, func(ec execContext, req Req) (Resp, int, error) {
-		return d.executeEndpoint(ec, func(mwReq middleware.Request) middleware.Response {
+		return d.executeEndpoint(ec, nil, func(mwReq middleware.Request) middleware.Response {
 			return d.invokeHandlerNonRaw(mwReq, req, d.AppHandler)
 		})
 	})
@@ -722,8 +949,14 @@ func (d *Desc[Req, Resp]) externalCall(c CallContext, service config.Service, re
 	}
 
 	// Lookup the service
-	if service.Protocol != config.Http {
-		// For now we only support HTTP services
+	switch service.Protocol {
+	case config.Http:
+	case config.GRPC:
+		// APIs that can't be called over gRPC are called over HTTP.
+		if d.supportsGRPC() {
+			return d.grpcCall(c, service, req)
+		}
+	default:
 		respErr = errs.B().Code(errs.Internal).Msg("internal encore error: unsupported service protocol").Err()
 		return
 	}
@@ -755,7 +988,14 @@ func (d *Desc[Req, Resp]) externalCall(c CallContext, service config.Service, re
 		return
 	}
 
-	reqURL := service.URL + path
+	baseURL, doneCall, err := c.server.serviceBaseURL(c.ctx, service)
+	if err != nil {
+		respErr = errs.Convert(err)
+		return
+	}
+	defer func() { doneCall(respErr) }()
+
+	reqURL := baseURL + path
 	if len(queryString) > 0 {
 		reqURL += "?" + queryString.Encode()
 	}
@@ -845,9 +1085,13 @@ func (d *Desc[Req, Resp]) validate(req Req) error {
 	return runValidate(d.ReqUserPayload(req))
 }
 
-// runValidate validates the request, and returns a validation error on failure.
-// If the user payload does not implement Validator, it returns nil.
+// runValidate validates the request against the rules in its validate tags,
+// and then using its Validate method if it implements Validator.
+// It returns a validation error on failure.
 func runValidate(userPayload any) error {
+	if err := validate.Struct(userPayload); err != nil {
+		return err
+	}
 	if v, ok := userPayload.(Validator); ok {
 		if err := v.Validate(); err != nil {
 			// If we already have an *errs.Error, return it directly.
@@ -872,6 +1116,8 @@ func (d *Desc[Req, Resp]) rpcDesc() *model.RPCDesc {
 			Raw:         d.Raw,
 			RequestType: reflect.TypeOf(reqTyp),
 			Tags:        d.Tags,
+
+			TraceSampleRate: d.TraceSampleRate,
 		}
 
 		if !isVoid[Resp]() {
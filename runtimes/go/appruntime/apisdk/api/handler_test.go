@@ -1,10 +1,15 @@
 package api_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
+	"net/url"
 	"os"
 	"reflect"
 	"strings"
@@ -17,12 +22,14 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/rs/zerolog"
+	ws "nhooyr.io/websocket"
 
 	encore "encore.dev"
 	"encore.dev/appruntime/apisdk/api"
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/etype"
 	"encore.dev/appruntime/shared/health"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/testsupport"
@@ -30,7 +37,11 @@ import (
 	"encore.dev/appruntime/shared/traceprovider/mock_trace"
 	"encore.dev/beta/errs"
 	usermetrics "encore.dev/metrics"
+	"encore.dev/middleware"
 	"encore.dev/pubsub"
+	"encore.dev/sse"
+	"encore.dev/stream"
+	"encore.dev/websocket"
 )
 
 type mockReq struct {
@@ -153,6 +164,400 @@ func TestDesc_EndToEnd(t *testing.T) {
 	}
 }
 
+func TestDesc_MiddlewareHeader(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+
+	tests := []struct {
+		name   string
+		reject bool
+		status int
+	}{
+		{name: "success", status: 200},
+		{name: "error", reject: true, status: 429},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			desc := newMockAPIDesc(api.Public)
+			desc.ServiceMiddleware = []*api.Middleware{{
+				Invoke: func(req middleware.Request, next middleware.Next) middleware.Response {
+					var resp middleware.Response
+					if test.reject {
+						resp.Err = errs.B().Code(errs.ResourceExhausted).Msg("rate limit exceeded").Err()
+					} else {
+						resp = next(req)
+					}
+					resp.Header = http.Header{"Retry-After": {"10"}}
+					return resp
+				},
+			}}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", strings.NewReader(`{"Body": "foo"}`))
+			desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+			if w.Code != test.status {
+				t.Fatalf("got code %d, want %d", w.Code, test.status)
+			} else if got := w.Header().Get("Retry-After"); got != "10" {
+				t.Fatalf("got Retry-After %q, want 10", got)
+			}
+		})
+	}
+}
+
+func TestDesc_SSE(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+
+	tests := []struct {
+		name    string
+		reqBody string
+		handler func(ctx context.Context, req *mockReq, events *sse.Writer[*mockResp]) error
+		status  int
+		body    string
+	}{
+		{
+			name:    "events",
+			reqBody: `{"Body": "foo"}`,
+			handler: func(ctx context.Context, req *mockReq, events *sse.Writer[*mockResp]) error {
+				if events.LastEventID() != "1" {
+					return errs.B().Code(errs.InvalidArgument).Msg("missing last event id").Err()
+				}
+				_ = events.Send(&mockResp{Message: req.Body})
+				return events.SendEvent(sse.Event[*mockResp]{ID: "2", Data: &mockResp{Message: "bar"}})
+			},
+			status: 200,
+			body:   "data: {\"Message\":\"foo\"}\n\nid: 2\ndata: {\"Message\":\"bar\"}\n\n",
+		},
+		{
+			name:    "no_events",
+			reqBody: `{}`,
+			handler: func(ctx context.Context, req *mockReq, events *sse.Writer[*mockResp]) error {
+				return nil
+			},
+			status: 200,
+			body:   "",
+		},
+		{
+			name:    "error_before_events",
+			reqBody: `{}`,
+			handler: func(ctx context.Context, req *mockReq, events *sse.Writer[*mockResp]) error {
+				return errs.B().Code(errs.NotFound).Msg("no such stream").Err()
+			},
+			status: 404,
+		},
+		{
+			name:    "error_after_events",
+			reqBody: `{"Body": "foo"}`,
+			handler: func(ctx context.Context, req *mockReq, events *sse.Writer[*mockResp]) error {
+				_ = events.Send(&mockResp{Message: req.Body})
+				return errs.B().Code(errs.Unavailable).Msg("shutting down").Err()
+			},
+			status: 200,
+			body:   "data: {\"Message\":\"foo\"}\n\nevent: error\ndata: {\"code\":\"unavailable\",\"message\":\"shutting down\",\"details\":null}\n\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", strings.NewReader(test.reqBody))
+			req.Header.Set("Last-Event-ID", "1")
+			desc := newMockAPIDesc(api.Public)
+			desc.SSE = true
+			desc.AppHandler, desc.EncodeResp = nil, nil
+			desc.SSEHandler = test.handler
+			desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+
+			if w.Code != test.status {
+				t.Fatalf("got code %d, want %d", w.Code, test.status)
+			}
+			if test.status == 200 {
+				if got := w.Header().Get("Content-Type"); got != "text/event-stream" {
+					t.Errorf("got content type %q, want text/event-stream", got)
+				}
+				if got := w.Body.String(); got != test.body {
+					t.Errorf("got body %q, want %q", got, test.body)
+				}
+			}
+		})
+	}
+}
+
+func TestDesc_WebSocket(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+
+	echo := func(ctx context.Context, req *mockReq, s websocket.Socket) error {
+		conn := websocket.NewConn[*mockReq, *mockResp](s)
+		for {
+			msg, err := conn.Recv()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if msg.Body == "fail" {
+				return errs.B().Code(errs.PermissionDenied).Msg("not allowed").Err()
+			}
+			if err := conn.Send(&mockResp{Message: msg.Body}); err != nil {
+				return err
+			}
+		}
+	}
+	newDesc := func() *api.Desc[*mockReq, *mockResp] {
+		desc := newMockAPIDesc(api.Public)
+		desc.WebSocket = true
+		desc.AppHandler, desc.EncodeResp = nil, nil
+		desc.WebSocketHandler = echo
+		desc.DecodeReq = func(req *http.Request, ps api.UnnamedParams, json jsoniter.API) (*mockReq, api.UnnamedParams, error) {
+			return &mockReq{}, ps, nil
+		}
+		return desc
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		newDesc().Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("echo", func(t *testing.T) {
+		conn, _, err := ws.Dial(ctx, srv.URL, &ws.DialOptions{Subprotocols: []string{"encore-ws"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := conn.Subprotocol(); got != "encore-ws" {
+			t.Errorf("got subprotocol %q, want encore-ws", got)
+		}
+		if err := conn.Write(ctx, ws.MessageText, []byte(`{"Body":"foo"}`)); err != nil {
+			t.Fatal(err)
+		}
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(data), `{"Message":"foo"}`; got != want {
+			t.Errorf("got message %q, want %q", got, want)
+		}
+		if err := conn.Close(ws.StatusNormalClosure, ""); err != nil {
+			t.Errorf("close: %v", err)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		conn, _, err := ws.Dial(ctx, srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close(ws.StatusNormalClosure, "")
+		if err := conn.Write(ctx, ws.MessageText, []byte(`{"Body":"fail"}`)); err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = conn.Read(ctx)
+		var closeErr ws.CloseError
+		if !errors.As(err, &closeErr) {
+			t.Fatalf("got err %v, want close error", err)
+		}
+		if closeErr.Code != ws.StatusPolicyViolation || closeErr.Reason != "not allowed" {
+			t.Errorf("got close %d %q, want %d %q", closeErr.Code, closeErr.Reason, ws.StatusPolicyViolation, "not allowed")
+		}
+	})
+
+	t.Run("not_upgrade", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/", nil)
+		newDesc().Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+		if w.Code != 400 {
+			t.Errorf("got code %d, want 400", w.Code)
+		}
+	})
+
+	t.Run("cross_origin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Origin", "https://evil.example.com")
+		req.Header.Set("Cookie", "session=secret")
+		newDesc().Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+		if w.Code != 403 {
+			t.Errorf("got code %d, want 403", w.Code)
+		}
+	})
+}
+
+type streamReq struct {
+	Body *stream.Body
+}
+
+type streamResp struct {
+	Body *stream.Body
+}
+
+func TestDesc_Stream(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+
+	// The endpoint uppercases the uploaded body, streaming it back.
+	desc := &api.Desc[*streamReq, *streamResp]{
+		Service:        "service",
+		Endpoint:       "stream",
+		Path:           "/path/:one",
+		Access:         api.Public,
+		PathParamNames: []string{"one"},
+		StreamRequest:  true,
+		StreamResponse: true,
+
+		DecodeReq: func(req *http.Request, ps api.UnnamedParams, json jsoniter.API) (*streamReq, api.UnnamedParams, error) {
+			return &streamReq{Body: stream.NewBody(req.Body, req.Header.Get("Content-Type"), req.ContentLength)}, ps, nil
+		},
+		CloneReq: func(req *streamReq) (*streamReq, error) { return req, nil },
+		ReqPath: func(req *streamReq) (string, api.UnnamedParams, error) {
+			return "/path/TODO", nil, nil
+		},
+		ReqUserPayload: func(req *streamReq) any { return nil },
+		AppHandler: func(ctx context.Context, req *streamReq) (*streamResp, error) {
+			if req.Body.ContentType != "text/plain" {
+				return nil, errs.B().Code(errs.InvalidArgument).Msg("unsupported content type").Err()
+			}
+			data, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			upper := strings.ToUpper(string(data))
+			return &streamResp{Body: stream.NewBody(strings.NewReader(upper), "text/plain", int64(len(upper)))}, nil
+		},
+		EncodeResp: func(w http.ResponseWriter, json jsoniter.API, resp *streamResp) error {
+			return stream.Write(w, resp.Body)
+		},
+		CloneResp: func(resp *streamResp) (*streamResp, error) { return resp, nil },
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		status      int
+		body        string
+	}{
+		{
+			name:        "echo",
+			contentType: "text/plain",
+			status:      200,
+			body:        "HELLO",
+		},
+		{
+			name:        "error",
+			contentType: "image/png",
+			status:      400,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", strings.NewReader("hello"))
+			req.Header.Set("Content-Type", test.contentType)
+			desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+
+			if w.Code != test.status {
+				t.Fatalf("got code %d, want %d", w.Code, test.status)
+			}
+			if test.status == 200 {
+				if got := w.Header().Get("Content-Type"); got != test.contentType {
+					t.Errorf("got content type %q, want %q", got, test.contentType)
+				}
+				if got := w.Header().Get("Content-Length"); got != "5" {
+					t.Errorf("got content length %q, want 5", got)
+				}
+				if got := w.Body.String(); got != test.body {
+					t.Errorf("got body %q, want %q", got, test.body)
+				}
+			}
+		})
+	}
+}
+
+func TestDesc_MultipartLimit(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+
+	desc := newMockAPIDesc(api.Public)
+	desc.Multipart = true
+	desc.MaxMultipartSize = 1024
+	desc.DecodeReq = func(req *http.Request, ps api.UnnamedParams, json jsoniter.API) (*mockReq, api.UnnamedParams, error) {
+		dec := &etype.Unmarshaller{}
+		form := dec.ReadMultipartForm(req)
+		return &mockReq{Body: url.Values(form.Value).Get("body")}, ps, dec.Error
+	}
+
+	tests := []struct {
+		name   string
+		size   int
+		status int
+	}{
+		{name: "within_limit", size: 512, status: 200},
+		{name: "too_large", size: 2048, status: 400},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			_ = mw.WriteField("body", "hello")
+			fw, _ := mw.CreateFormFile("file", "file.txt")
+			_, _ = fw.Write(bytes.Repeat([]byte("a"), test.size))
+			_ = mw.Close()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", &body)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+
+			if w.Code != test.status {
+				t.Fatalf("got code %d, want %d: %s", w.Code, test.status, w.Body.String())
+			} else if test.status != 200 && !strings.Contains(w.Body.String(), "request body too large, the limit is 1024 bytes") {
+				t.Fatalf("got body %s, want the limit to be reported", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDesc_MultipartTempFiles(t *testing.T) {
+	server, _, _ := testServer(t, clock.New(), false)
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+
+	var spooled int
+	desc := newMockAPIDesc(api.Public)
+	desc.Multipart = true
+	desc.MaxMultipartSize = 64 << 20
+	desc.DecodeReq = func(req *http.Request, ps api.UnnamedParams, json jsoniter.API) (*mockReq, api.UnnamedParams, error) {
+		dec := &etype.Unmarshaller{}
+		form := dec.ReadMultipartForm(req)
+		entries, _ := os.ReadDir(tmpDir)
+		spooled = len(entries)
+		return &mockReq{Body: url.Values(form.Value).Get("body")}, ps, dec.Error
+	}
+
+	// Files beyond the in-memory limit of 32 MiB are written to temporary files.
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("body", "hello")
+	fw, _ := mw.CreateFormFile("file", "file.bin")
+	_, _ = fw.Write(bytes.Repeat([]byte("a"), 33<<20))
+	_ = mw.Close()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	desc.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, api.CallMeta{}))
+
+	if w.Code != 200 {
+		t.Fatalf("got code %d, want 200: %s", w.Code, w.Body.String())
+	} else if spooled == 0 {
+		t.Fatal("got no temporary files while handling the request, want the file to be spooled to disk")
+	}
+	if entries, _ := os.ReadDir(tmpDir); len(entries) != 0 {
+		t.Fatalf("got %d temporary files after the request, want them removed", len(entries))
+	}
+}
+
 func findMetric(collected []usermetrics.CollectedMetric, name string, labels []usermetrics.KeyValue) *usermetrics.CollectedMetric {
 	for _, metric := range collected {
 		if metric.Info.Name() == name &&
@@ -188,6 +593,8 @@ func TestDescGeneratesTrace(t *testing.T) {
 				ParentSpanID: model.SpanID{},
 				Start:        klock.Now(),
 				Traced:       true,
+				Values:       &model.RequestValues{},
+				Baggage:      &model.Baggage{},
 				RPCData: &model.RPCData{
 					Desc: &model.RPCDesc{
 						Service:      "service",
@@ -204,6 +611,7 @@ func TestDescGeneratesTrace(t *testing.T) {
 					TypedPayload:   &mockReq{Body: "foo"},
 					NonRawPayload:  []byte(`{"Body":"foo"}`),
 					RequestHeaders: http.Header{"Content-Type": []string{"application/json"}},
+					ClientIP:       netip.MustParseAddr("192.0.2.1"),
 				},
 			},
 		},
@@ -218,6 +626,8 @@ func TestDescGeneratesTrace(t *testing.T) {
 				ParentSpanID: model.SpanID{},
 				Start:        klock.Now(),
 				Traced:       true,
+				Values:       &model.RequestValues{},
+				Baggage:      &model.Baggage{},
 				RPCData: &model.RPCData{
 					Desc: &model.RPCDesc{
 						Service:      "service",
@@ -233,6 +643,7 @@ func TestDescGeneratesTrace(t *testing.T) {
 					AuthData:       nil,
 					TypedPayload:   nil,
 					RequestHeaders: nil,
+					ClientIP:       netip.MustParseAddr("192.0.2.1"),
 				},
 			},
 		},
@@ -255,6 +666,8 @@ func TestDescGeneratesTrace(t *testing.T) {
 				ParentSpanID: model.SpanID{},
 				Start:        klock.Now(),
 				Traced:       true,
+				Values:       &model.RequestValues{},
+				Baggage:      &model.Baggage{},
 				RPCData: &model.RPCData{
 					Desc: &model.RPCDesc{
 						Service:      "service",
@@ -270,6 +683,7 @@ func TestDescGeneratesTrace(t *testing.T) {
 					AuthData:       nil,
 					TypedPayload:   nil,
 					RequestHeaders: http.Header{"Content-Type": []string{"application/json"}},
+					ClientIP:       netip.MustParseAddr("192.0.2.1"),
 				},
 			},
 		},
@@ -277,7 +691,10 @@ func TestDescGeneratesTrace(t *testing.T) {
 
 	opts := []cmp.Option{
 		cmpopts.IgnoreFields(model.Request{}, "Logger"),
+		cmpopts.IgnoreUnexported(model.RequestValues{}),
+		cmpopts.IgnoreUnexported(model.Baggage{}),
 		cmp.Comparer(func(a, b reflect.Type) bool { return a == b }),
+		cmpopts.EquateComparable(netip.Addr{}),
 	}
 
 	for _, test := range tests {
@@ -420,10 +837,10 @@ func testServer(t *testing.T, klock clock.Clock, mockTraces bool) (*api.Server,
 	json := jsoniter.ConfigCompatibleWithStandardLibrary
 	encoreMgr := encore.NewManager(static, runtime, rt)
 	tsMgr := testsupport.NewManager(static, rt, logger)
-	pubsubMgr := pubsub.NewManager(static, runtime, rt, tsMgr, logger, json)
+	pubsubMgr := pubsub.NewManager(static, runtime, rt, tsMgr, logger, json, metricsRegistry)
 	healthMgr := health.NewCheckRegistry()
 	testingMgr := testsupport.NewManager(static, rt, logger)
-	server := api.NewServer(static, runtime, rt, nil, encoreMgr, pubsubMgr, logger, metricsRegistry, healthMgr, testingMgr, json, klock)
+	server := api.NewServer(static, runtime, rt, nil, encoreMgr, pubsubMgr, nil, logger, metricsRegistry, healthMgr, testingMgr, json, klock)
 	return server, traceMock, metricsRegistry
 }
 
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/beta/errs"
+)
+
+// ipFilter restricts access to APIs based on the client's IP address.
+type ipFilter struct {
+	trustedProxies []netip.Prefix
+	rules          []ipFilterRule
+}
+
+type ipFilterRule struct {
+	ruleScope
+	allow []netip.Prefix
+	deny  []netip.Prefix
+}
+
+// ruleScope describes which endpoints a rule applies to.
+// An empty service matches all services, and an empty endpoint
+// matches all endpoints in the service.
+type ruleScope struct {
+	service  string
+	endpoint string
+}
+
+// specificity reports how specifically the scope matches the given endpoint,
+// from 0 (matches all services) to 2 (matches the endpoint), or -1 if it does not match.
+func (r ruleScope) specificity(service, endpoint string) int {
+	switch {
+	case r.service == "":
+		return 0
+	case r.service != service:
+		return -1
+	case r.endpoint == "":
+		return 1
+	case r.endpoint != endpoint:
+		return -1
+	default:
+		return 2
+	}
+}
+
+// newIPFilter parses the given configuration.
+// It returns nil if cfg is nil.
+func newIPFilter(cfg *config.IPFilter) (*ipFilter, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	f := &ipFilter{}
+	var err error
+	if f.trustedProxies, err = parsePrefixes(cfg.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("trusted_proxies: %w", err)
+	}
+	for _, r := range cfg.Rules {
+		rule := ipFilterRule{ruleScope: ruleScope{service: r.Service, endpoint: r.Endpoint}}
+		if rule.allow, err = parsePrefixes(r.Allow); err != nil {
+			return nil, fmt.Errorf("allow: %w", err)
+		} else if rule.deny, err = parsePrefixes(r.Deny); err != nil {
+			return nil, fmt.Errorf("deny: %w", err)
+		}
+		f.rules = append(f.rules, rule)
+	}
+	return f, nil
+}
+
+// ruleFor returns the most specific rule for the given endpoint, or nil if none match.
+func (f *ipFilter) ruleFor(service, endpoint string) *ipFilterRule {
+	var best *ipFilterRule
+	bestScore := -1
+	for i := range f.rules {
+		r := &f.rules[i]
+		if score := r.specificity(service, endpoint); score > bestScore {
+			best, bestScore = r, score
+		}
+	}
+	return best
+}
+
+// allows reports whether the rule permits requests from ip.
+func (r *ipFilterRule) allows(ip netip.Addr) bool {
+	if containsAddr(r.deny, ip) {
+		return false
+	}
+	return len(r.allow) == 0 || containsAddr(r.allow, ip)
+}
+
+// clientIP determines the IP address of the client that made the request,
+// taking the trusted proxies configured for IP filtering into account.
+func (s *Server) clientIP(req *http.Request) (netip.Addr, bool) {
+	var trustedProxies []netip.Prefix
+	if s.ipFilter != nil {
+		trustedProxies = s.ipFilter.trustedProxies
+	}
+	return clientIP(req, trustedProxies)
+}
+
+// clientIP determines the IP address of the client that made the request.
+//
+// If the request was received from a trusted proxy, the X-Forwarded-For header
+// is walked from right to left, skipping trusted proxies, to find the first
+// untrusted address which is taken to be the client.
+func clientIP(req *http.Request, trustedProxies []netip.Prefix) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	ip = ip.Unmap()
+
+	if !containsAddr(trustedProxies, ip) {
+		return ip, true
+	}
+
+	var hops []string
+	for _, v := range req.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			// Don't trust anything to the left of a malformed entry.
+			return netip.Addr{}, false
+		}
+		ip = hop.Unmap()
+		if !containsAddr(trustedProxies, ip) {
+			return ip, true
+		}
+	}
+
+	// Every hop is a trusted proxy, so use the leftmost one.
+	return ip, true
+}
+
+// checkIPFilter checks whether the request is permitted by the configured
+// IP filter, writing an error response if not. It reports whether to proceed.
+func (s *Server) checkIPFilter(h Handler, c IncomingContext) bool {
+	if s.ipFilter == nil || c.callMeta.IsServiceToService() {
+		// Service-to-service calls are authenticated separately.
+		return true
+	}
+
+	rule := s.ipFilter.ruleFor(h.ServiceName(), h.EndpointName())
+	if rule == nil {
+		return true
+	}
+	if ip, ok := s.clientIP(c.req); ok && rule.allows(ip) {
+		return true
+	}
+
+	returnError(c, errs.B().Code(errs.PermissionDenied).Msg("access denied").Err(), 0)
+	return false
+}
+
+func parsePrefixes(vals []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(vals))
+	for _, v := range vals {
+		if p, err := netip.ParsePrefix(v); err == nil {
+			prefixes = append(prefixes, p.Masked())
+		} else if addr, err := netip.ParseAddr(v); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+		} else {
+			return nil, fmt.Errorf("invalid CIDR range or IP address %q", v)
+		}
+	}
+	return prefixes, nil
+}
+
+func containsAddr(prefixes []netip.Prefix, ip netip.Addr) bool {
+	for _, p := range prefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
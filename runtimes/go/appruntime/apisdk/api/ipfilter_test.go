@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func TestIPFilter_ClientIP(t *testing.T) {
+	f, err := newIPFilter(&config.IPFilter{TrustedProxies: []string{"10.0.0.0/8", "192.168.1.1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		remote string
+		xff    []string
+		want   string
+	}{
+		{name: "direct", remote: "1.2.3.4:1234", want: "1.2.3.4"},
+		{name: "untrusted_proxy_ignored", remote: "1.2.3.4:1234", xff: []string{"5.6.7.8"}, want: "1.2.3.4"},
+		{name: "trusted_proxy", remote: "10.0.0.1:1234", xff: []string{"5.6.7.8"}, want: "5.6.7.8"},
+		{name: "spoofed_prefix", remote: "10.0.0.1:1234", xff: []string{"9.9.9.9, 5.6.7.8, 192.168.1.1"}, want: "5.6.7.8"},
+		{name: "multiple_headers", remote: "10.0.0.1:1234", xff: []string{"9.9.9.9", "5.6.7.8"}, want: "5.6.7.8"},
+		{name: "ipv6", remote: "[2001:db8::1]:1234", want: "2001:db8::1"},
+		{name: "all_trusted", remote: "10.0.0.1:1234", xff: []string{"10.0.0.2"}, want: "10.0.0.2"},
+		{name: "malformed", remote: "10.0.0.1:1234", xff: []string{"garbage"}, want: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = test.remote
+			for _, v := range test.xff {
+				req.Header.Add("X-Forwarded-For", v)
+			}
+
+			got, ok := clientIP(req, f.trustedProxies)
+			if test.want == "" {
+				if ok {
+					t.Fatalf("got %v, want no client ip", got)
+				}
+				return
+			}
+			if !ok || got != netip.MustParseAddr(test.want) {
+				t.Fatalf("got %v (ok=%v), want %s", got, ok, test.want)
+			}
+		})
+	}
+}
+
+func TestIPFilter_Rules(t *testing.T) {
+	f, err := newIPFilter(&config.IPFilter{Rules: []*config.IPFilterRule{
+		{Deny: []string{"6.6.6.0/24"}},
+		{Service: "admin", Allow: []string{"10.0.0.0/8"}},
+		{Service: "admin", Endpoint: "Health"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		service, endpoint, ip string
+		want                  bool
+	}{
+		{"users", "Get", "1.2.3.4", true},
+		{"users", "Get", "6.6.6.6", false},
+		{"admin", "Reset", "10.1.2.3", true},
+		{"admin", "Reset", "1.2.3.4", false},
+		// The endpoint rule is more specific and has no restrictions.
+		{"admin", "Health", "1.2.3.4", true},
+	}
+	for _, test := range tests {
+		rule := f.ruleFor(test.service, test.endpoint)
+		if got := rule.allows(netip.MustParseAddr(test.ip)); got != test.want {
+			t.Errorf("%s.%s from %s: got allowed=%v, want %v", test.service, test.endpoint, test.ip, got, test.want)
+		}
+	}
+
+	if _, err := newIPFilter(&config.IPFilter{Rules: []*config.IPFilterRule{{Allow: []string{"not-an-ip"}}}}); err == nil {
+		t.Fatal("expected error for invalid range")
+	}
+}
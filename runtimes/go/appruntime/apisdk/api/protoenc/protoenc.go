@@ -0,0 +1,854 @@
+// Package protoenc encodes Go values in the protobuf wire format,
+// for service-to-service calls made over gRPC.
+//
+// The messages are described by the request and response types themselves,
+// along with a Schema generated by the Encore compiler holding the numbers of
+// their fields. Each exported field of a struct is a message field, except for
+// fields ignored by JSON with `json:"-"` that aren't sent as headers, query
+// strings or cookies.
+//
+// Types are encoded as follows:
+//
+//   - bool, uint and its variants as varints, and int and its variants as zigzag varints (sint64)
+//   - float32 and float64 as fixed32 and fixed64
+//   - string, []byte and [N]byte as length-delimited bytes
+//   - structs as nested messages, and pointers as their element with explicit presence
+//   - slices and arrays as repeated fields, packed for numeric elements
+//   - maps as repeated entry messages with the key in field 1 and the value in field 2
+//   - time.Time as a google.protobuf.Timestamp
+//   - types implementing encoding.TextMarshaler as strings, and json.Marshaler as JSON bytes
+//   - interfaces as JSON bytes
+//
+// Slices and maps nested directly in other slices or maps, and top-level
+// values that aren't structs, are wrapped in a message with the value in field 1.
+package protoenc
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Schema describes the messages a type is encoded as,
+// with the field numbers assigned by the Encore compiler.
+type Schema struct {
+	// Root is the name of the message of the type itself.
+	Root string
+
+	// Messages are the messages of the struct types making up the type, keyed by name.
+	Messages map[string]Message
+}
+
+// Message describes the message of a struct type.
+type Message struct {
+	Fields []Field
+}
+
+// Field describes a field of a message.
+type Field struct {
+	Name   string // name of the struct field
+	Number int32
+
+	// Message is the name of the message of the field's struct type,
+	// or of its elements' for pointers, slices, arrays and maps.
+	Message string
+}
+
+// Marshal returns the protobuf encoding of v, whose messages are described by s.
+func Marshal(v any, s *Schema) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	m, err := messageFor(rv.Type(), s)
+	if err != nil {
+		return nil, err
+	}
+	return m.marshal(nil, rv)
+}
+
+// Unmarshal decodes the protobuf message data into v, which must be a non-nil pointer,
+// and whose messages are described by s.
+func Unmarshal(data []byte, v any, s *Schema) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("protoenc: cannot unmarshal into %T", v)
+	}
+	rv = rv.Elem()
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+	m, err := messageFor(rv.Type(), s)
+	if err != nil {
+		return err
+	}
+	return m.unmarshal(data, rv)
+}
+
+// codec encodes and decodes values of a single type as the value of a field.
+type codec struct {
+	wire protowire.Type
+
+	// packable is whether repeated values can be packed,
+	// which is only the case for numeric types.
+	packable bool
+
+	// append appends the encoding of v, without a tag.
+	append func(b []byte, v reflect.Value) ([]byte, error)
+
+	// consume decodes a value from b into v, returning the number of bytes read.
+	consume func(b []byte, v reflect.Value) (int, error)
+
+	// omit reports whether v can be left out of the message,
+	// as it decodes the same as a missing field.
+	omit func(v reflect.Value) bool
+}
+
+type fieldKind int
+
+const (
+	single fieldKind = iota
+	repeated
+	mapped
+)
+
+// field is a field of a message.
+type field struct {
+	num   protowire.Number
+	index int // index of the struct field, or -1 if the message wraps a value
+	kind  fieldKind
+
+	// c is the codec of the value, or of the elements for repeated fields.
+	c *codec
+
+	// key and val are the codecs of the keys and values of mapped fields.
+	key, val *codec
+}
+
+// message encodes and decodes structs, and values wrapped in a message.
+type message struct {
+	fields []*field                    // ordered by field number
+	byNum  map[protowire.Number]*field // fields keyed by number
+}
+
+var (
+	cacheMu  sync.Mutex
+	messages = make(map[reflect.Type]*message)
+	codecs   = make(map[reflect.Type]*codec)
+)
+
+func messageFor(t reflect.Type, s *Schema) (*message, error) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	var root string
+	if s != nil {
+		root = s.Root
+	}
+	m, err := buildMessage(t, s, root)
+	if err != nil {
+		return nil, fmt.Errorf("protoenc: %w", err)
+	}
+	return m, nil
+}
+
+// buildMessage returns the message describing t, whose fields are numbered
+// by the message named name in s. It must be called with cacheMu held.
+//
+// Messages are cached by type, as the compiler numbers the fields of
+// a struct type the same way wherever it's used.
+func buildMessage(t reflect.Type, s *Schema, name string) (*message, error) {
+	if m, ok := messages[t]; ok {
+		return m, nil
+	}
+
+	m := &message{}
+	if t.Kind() != reflect.Struct || isSpecial(t) {
+		f, err := buildField(t, s, name)
+		if err != nil {
+			return nil, err
+		}
+		f.num, f.index = 1, -1
+		m.fields = []*field{f}
+		m.byNum = map[protowire.Number]*field{1: f}
+		messages[t] = m
+		return m, nil
+	}
+
+	var desc map[string]Field
+	if s != nil {
+		if msg, ok := s.Messages[name]; ok {
+			desc = make(map[string]Field, len(msg.Fields))
+			for _, fd := range msg.Fields {
+				desc[fd.Name] = fd
+			}
+		}
+	}
+
+	// Cache the message before building its fields to support recursive types.
+	messages[t] = m
+	m.byNum = make(map[protowire.Number]*field)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() || isIgnored(sf) {
+			continue
+		}
+		fd, ok := desc[sf.Name]
+		num := protowire.Number(fd.Number)
+		var err error
+		if !ok {
+			err = fmt.Errorf("no field number in message %q", name)
+		} else if !num.IsValid() {
+			err = fmt.Errorf("invalid field number %d", num)
+		} else if other, ok := m.byNum[num]; ok {
+			err = fmt.Errorf("same field number %d as field %s", num, t.Field(other.index).Name)
+		}
+		var f *field
+		if err == nil {
+			f, err = buildField(sf.Type, s, fd.Message)
+		}
+		if err != nil {
+			delete(messages, t)
+			return nil, fmt.Errorf("field %s.%s: %w", t, sf.Name, err)
+		}
+		f.num, f.index = num, i
+		m.fields = append(m.fields, f)
+		m.byNum[num] = f
+	}
+	slices.SortFunc(m.fields, func(a, b *field) int { return int(a.num - b.num) })
+	return m, nil
+}
+
+// isIgnored reports whether a struct field is left out of the message.
+func isIgnored(sf reflect.StructField) bool {
+	if sf.Tag.Get("json") != "-" {
+		return false
+	}
+	for _, tag := range []string{"header", "query", "qs", "cookie"} {
+		if _, ok := sf.Tag.Lookup(tag); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// buildField returns the field for values of type t, where name is the message
+// of the struct type of the values or of their elements, if any.
+func buildField(t reflect.Type, s *Schema, name string) (*field, error) {
+	switch {
+	case isSpecial(t) || isBytes(t):
+		// Encoded as a single value.
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		c, err := buildCodec(t.Elem(), s, name)
+		if err != nil {
+			return nil, err
+		}
+		return &field{kind: repeated, c: c}, nil
+	case t.Kind() == reflect.Map:
+		key, err := buildCodec(t.Key(), s, "")
+		if err != nil {
+			return nil, err
+		}
+		val, err := buildCodec(t.Elem(), s, name)
+		if err != nil {
+			return nil, err
+		}
+		return &field{kind: mapped, key: key, val: val}, nil
+	}
+
+	c, err := buildCodec(t, s, name)
+	if err != nil {
+		return nil, err
+	}
+	return &field{kind: single, c: c}, nil
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	jsonMarshalerType   = reflect.TypeFor[json.Marshaler]()
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// isSpecial reports whether t has a custom encoding rather than one based on its kind.
+func isSpecial(t reflect.Type) bool {
+	return t == timeType || isText(t) || isJSON(t)
+}
+
+func isText(t reflect.Type) bool {
+	return implements(t, textMarshalerType) && reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+func isJSON(t reflect.Type) bool {
+	return implements(t, jsonMarshalerType) && reflect.PointerTo(t).Implements(jsonUnmarshalerType)
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Kind() != reflect.Pointer && (t.Implements(iface) || reflect.PointerTo(t).Implements(iface))
+}
+
+func isBytes(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
+
+// buildCodec returns the codec for values of type t, where name is the message
+// of their struct type, if any. It must be called with cacheMu held.
+func buildCodec(t reflect.Type, s *Schema, name string) (*codec, error) {
+	if c, ok := codecs[t]; ok {
+		return c, nil
+	}
+
+	var c *codec
+	switch {
+	case t.Kind() == reflect.Pointer:
+		// Cache the codec before building the element's to support recursive types.
+		c = &codec{}
+		codecs[t] = c
+		elem, err := buildCodec(t.Elem(), s, name)
+		if err != nil {
+			delete(codecs, t)
+			return nil, err
+		}
+		*c = *pointerCodec(t, elem)
+		return c, nil
+	case t == timeType:
+		c = timeCodec
+	case isText(t):
+		c = textCodec(t)
+	case isJSON(t):
+		c = jsonCodec(t)
+	case isBytes(t):
+		c = bytesCodec(t)
+	case t.Kind() == reflect.Struct || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map:
+		m, err := buildMessage(t, s, name)
+		if err != nil {
+			return nil, err
+		}
+		c = messageCodec(m)
+	case t.Kind() == reflect.Interface:
+		c = jsonCodec(t)
+	default:
+		var ok bool
+		if c, ok = scalarCodec(t.Kind()); !ok {
+			return nil, fmt.Errorf("unsupported type %s", t)
+		}
+	}
+	codecs[t] = c
+	return c, nil
+}
+
+func isZero(v reflect.Value) bool { return v.IsZero() }
+
+func scalarCodec(k reflect.Kind) (*codec, bool) {
+	switch k {
+	case reflect.Bool:
+		return &codec{
+			wire:     protowire.VarintType,
+			packable: true,
+			omit:     isZero,
+			append: func(b []byte, v reflect.Value) ([]byte, error) {
+				return protowire.AppendVarint(b, protowire.EncodeBool(v.Bool())), nil
+			},
+			consume: func(b []byte, v reflect.Value) (int, error) {
+				x, n := protowire.ConsumeVarint(b)
+				v.SetBool(protowire.DecodeBool(x))
+				return n, protowire.ParseError(n)
+			},
+		}, true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &codec{
+			wire:     protowire.VarintType,
+			packable: true,
+			omit:     isZero,
+			append: func(b []byte, v reflect.Value) ([]byte, error) {
+				return protowire.AppendVarint(b, protowire.EncodeZigZag(v.Int())), nil
+			},
+			consume: func(b []byte, v reflect.Value) (int, error) {
+				x, n := protowire.ConsumeVarint(b)
+				if n < 0 {
+					return n, protowire.ParseError(n)
+				}
+				i := protowire.DecodeZigZag(x)
+				if v.OverflowInt(i) {
+					return n, fmt.Errorf("protoenc: value %d overflows %s", i, v.Type())
+				}
+				v.SetInt(i)
+				return n, nil
+			},
+		}, true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &codec{
+			wire:     protowire.VarintType,
+			packable: true,
+			omit:     isZero,
+			append: func(b []byte, v reflect.Value) ([]byte, error) {
+				return protowire.AppendVarint(b, v.Uint()), nil
+			},
+			consume: func(b []byte, v reflect.Value) (int, error) {
+				x, n := protowire.ConsumeVarint(b)
+				if n < 0 {
+					return n, protowire.ParseError(n)
+				}
+				if v.OverflowUint(x) {
+					return n, fmt.Errorf("protoenc: value %d overflows %s", x, v.Type())
+				}
+				v.SetUint(x)
+				return n, nil
+			},
+		}, true
+
+	case reflect.Float32:
+		return &codec{
+			wire:     protowire.Fixed32Type,
+			packable: true,
+			omit:     isZero,
+			append: func(b []byte, v reflect.Value) ([]byte, error) {
+				return protowire.AppendFixed32(b, math.Float32bits(float32(v.Float()))), nil
+			},
+			consume: func(b []byte, v reflect.Value) (int, error) {
+				x, n := protowire.ConsumeFixed32(b)
+				v.SetFloat(float64(math.Float32frombits(x)))
+				return n, protowire.ParseError(n)
+			},
+		}, true
+
+	case reflect.Float64:
+		return &codec{
+			wire:     protowire.Fixed64Type,
+			packable: true,
+			omit:     isZero,
+			append: func(b []byte, v reflect.Value) ([]byte, error) {
+				return protowire.AppendFixed64(b, math.Float64bits(v.Float())), nil
+			},
+			consume: func(b []byte, v reflect.Value) (int, error) {
+				x, n := protowire.ConsumeFixed64(b)
+				v.SetFloat(math.Float64frombits(x))
+				return n, protowire.ParseError(n)
+			},
+		}, true
+
+	case reflect.String:
+		return &codec{
+			wire: protowire.BytesType,
+			omit: isZero,
+			append: func(b []byte, v reflect.Value) ([]byte, error) {
+				return protowire.AppendString(b, v.String()), nil
+			},
+			consume: func(b []byte, v reflect.Value) (int, error) {
+				s, n := protowire.ConsumeString(b)
+				v.SetString(s)
+				return n, protowire.ParseError(n)
+			},
+		}, true
+	}
+	return nil, false
+}
+
+func bytesCodec(t reflect.Type) *codec {
+	return &codec{
+		wire: protowire.BytesType,
+		omit: func(v reflect.Value) bool { return v.Len() == 0 || (t.Kind() == reflect.Array && v.IsZero()) },
+		append: func(b []byte, v reflect.Value) ([]byte, error) {
+			if t.Kind() == reflect.Array {
+				b = protowire.AppendVarint(b, uint64(v.Len()))
+				for i := 0; i < v.Len(); i++ {
+					b = append(b, byte(v.Index(i).Uint()))
+				}
+				return b, nil
+			}
+			return protowire.AppendBytes(b, v.Bytes()), nil
+		},
+		consume: func(b []byte, v reflect.Value) (int, error) {
+			data, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, protowire.ParseError(n)
+			}
+			if t.Kind() == reflect.Array {
+				if len(data) != v.Len() {
+					return n, fmt.Errorf("protoenc: cannot decode %d bytes into %s", len(data), t)
+				}
+				reflect.Copy(v, reflect.ValueOf(data))
+			} else {
+				v.SetBytes(append([]byte(nil), data...))
+			}
+			return n, nil
+		},
+	}
+}
+
+// timeCodec encodes times as google.protobuf.Timestamp messages.
+var timeCodec = &codec{
+	wire: protowire.BytesType,
+	omit: isZero,
+	append: func(b []byte, v reflect.Value) ([]byte, error) {
+		t := v.Interface().(time.Time)
+		var msg []byte
+		if secs := t.Unix(); secs != 0 {
+			msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+			msg = protowire.AppendVarint(msg, uint64(secs))
+		}
+		if nanos := t.Nanosecond(); nanos != 0 {
+			msg = protowire.AppendTag(msg, 2, protowire.VarintType)
+			msg = protowire.AppendVarint(msg, uint64(nanos))
+		}
+		return protowire.AppendBytes(b, msg), nil
+	},
+	consume: func(b []byte, v reflect.Value) (int, error) {
+		msg, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, protowire.ParseError(n)
+		}
+		var secs, nanos int64
+		for len(msg) > 0 {
+			num, typ, m := protowire.ConsumeTag(msg)
+			if m < 0 {
+				return n, protowire.ParseError(m)
+			}
+			msg = msg[m:]
+			if typ != protowire.VarintType || (num != 1 && num != 2) {
+				if m = protowire.ConsumeFieldValue(num, typ, msg); m < 0 {
+					return n, protowire.ParseError(m)
+				}
+				msg = msg[m:]
+				continue
+			}
+			x, m := protowire.ConsumeVarint(msg)
+			if m < 0 {
+				return n, protowire.ParseError(m)
+			}
+			msg = msg[m:]
+			if num == 1 {
+				secs = int64(x)
+			} else {
+				nanos = int64(int32(x))
+			}
+		}
+		v.Set(reflect.ValueOf(time.Unix(secs, nanos).UTC()))
+		return n, nil
+	},
+}
+
+func textCodec(t reflect.Type) *codec {
+	return &codec{
+		wire: protowire.BytesType,
+		omit: isZero,
+		append: func(b []byte, v reflect.Value) ([]byte, error) {
+			text, err := addressable(v).Addr().Interface().(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			return protowire.AppendBytes(b, text), nil
+		},
+		consume: func(b []byte, v reflect.Value) (int, error) {
+			text, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, protowire.ParseError(n)
+			}
+			return n, v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(text)
+		},
+	}
+}
+
+// jsonCodec encodes values of type t as JSON, for types
+// implementing json.Marshaler and for interfaces.
+func jsonCodec(t reflect.Type) *codec {
+	return &codec{
+		wire: protowire.BytesType,
+		omit: isZero,
+		append: func(b []byte, v reflect.Value) ([]byte, error) {
+			data, err := json.Marshal(addressable(v).Addr().Interface())
+			if err != nil {
+				return nil, err
+			}
+			return protowire.AppendBytes(b, data), nil
+		},
+		consume: func(b []byte, v reflect.Value) (int, error) {
+			data, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, protowire.ParseError(n)
+			}
+			return n, json.Unmarshal(data, v.Addr().Interface())
+		},
+	}
+}
+
+// addressable returns v if it's addressable, and otherwise an addressable copy of it,
+// so methods with pointer receivers can be called on it.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p.Elem()
+}
+
+func pointerCodec(t reflect.Type, elem *codec) *codec {
+	return &codec{
+		wire:     elem.wire,
+		packable: elem.packable,
+		omit:     func(v reflect.Value) bool { return v.IsNil() },
+		append: func(b []byte, v reflect.Value) ([]byte, error) {
+			return elem.append(b, v.Elem())
+		},
+		consume: func(b []byte, v reflect.Value) (int, error) {
+			if v.IsNil() {
+				v.Set(reflect.New(t.Elem()))
+			}
+			return elem.consume(b, v.Elem())
+		},
+	}
+}
+
+func messageCodec(m *message) *codec {
+	return &codec{
+		wire: protowire.BytesType,
+		omit: func(v reflect.Value) bool {
+			if v.Kind() == reflect.Slice || v.Kind() == reflect.Map {
+				return v.Len() == 0
+			}
+			return v.IsZero()
+		},
+		append: func(b []byte, v reflect.Value) ([]byte, error) {
+			msg, err := m.marshal(nil, v)
+			if err != nil {
+				return nil, err
+			}
+			return protowire.AppendBytes(b, msg), nil
+		},
+		consume: func(b []byte, v reflect.Value) (int, error) {
+			msg, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, protowire.ParseError(n)
+			}
+			return n, m.unmarshal(msg, v)
+		},
+	}
+}
+
+func (m *message) fieldValue(f *field, v reflect.Value) reflect.Value {
+	if f.index < 0 {
+		return v
+	}
+	return v.Field(f.index)
+}
+
+func (m *message) marshal(b []byte, v reflect.Value) (out []byte, err error) {
+	for _, f := range m.fields {
+		fv := m.fieldValue(f, v)
+		switch f.kind {
+		case single:
+			if f.c.omit(fv) {
+				continue
+			}
+			b = protowire.AppendTag(b, f.num, f.c.wire)
+			if b, err = f.c.append(b, fv); err != nil {
+				return nil, err
+			}
+
+		case repeated:
+			if fv.Len() == 0 || (fv.Kind() == reflect.Array && fv.IsZero()) {
+				continue
+			}
+			if f.c.packable {
+				var packed []byte
+				for i := 0; i < fv.Len(); i++ {
+					if packed, err = f.c.append(packed, fv.Index(i)); err != nil {
+						return nil, err
+					}
+				}
+				b = protowire.AppendTag(b, f.num, protowire.BytesType)
+				b = protowire.AppendBytes(b, packed)
+				continue
+			}
+			for i := 0; i < fv.Len(); i++ {
+				b = protowire.AppendTag(b, f.num, f.c.wire)
+				if b, err = f.c.append(b, fv.Index(i)); err != nil {
+					return nil, err
+				}
+			}
+
+		case mapped:
+			iter := fv.MapRange()
+			for iter.Next() {
+				var entry []byte
+				if k := iter.Key(); !f.key.omit(k) {
+					entry = protowire.AppendTag(entry, 1, f.key.wire)
+					if entry, err = f.key.append(entry, k); err != nil {
+						return nil, err
+					}
+				}
+				if val := iter.Value(); !f.val.omit(val) {
+					entry = protowire.AppendTag(entry, 2, f.val.wire)
+					if entry, err = f.val.append(entry, val); err != nil {
+						return nil, err
+					}
+				}
+				b = protowire.AppendTag(b, f.num, protowire.BytesType)
+				b = protowire.AppendBytes(b, entry)
+			}
+		}
+	}
+	return b, nil
+}
+
+func (m *message) unmarshal(b []byte, v reflect.Value) error {
+	// arrayLens tracks how many elements have been decoded into repeated array fields.
+	var arrayLens map[*field]int
+
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		f, ok := m.byNum[num]
+		if !ok {
+			// Skip unknown fields, such as those added by a newer version of the message.
+			if n = protowire.ConsumeFieldValue(num, typ, b); n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+
+		fv := m.fieldValue(f, v)
+		var err error
+		switch f.kind {
+		case single:
+			if typ != f.c.wire {
+				return wireTypeError(num, typ, fv.Type())
+			}
+			n, err = f.c.consume(b, fv)
+
+		case repeated:
+			decodeElem := func(b []byte) (int, error) {
+				if fv.Kind() == reflect.Array {
+					if arrayLens == nil {
+						arrayLens = make(map[*field]int)
+					}
+					i := arrayLens[f]
+					if i >= fv.Len() {
+						return 0, fmt.Errorf("protoenc: too many elements for %s", fv.Type())
+					}
+					arrayLens[f]++
+					return f.c.consume(b, fv.Index(i))
+				}
+				elem := reflect.New(fv.Type().Elem()).Elem()
+				n, err := f.c.consume(b, elem)
+				if err == nil {
+					fv.Set(reflect.Append(fv, elem))
+				}
+				return n, err
+			}
+
+			if typ == protowire.BytesType && f.c.packable {
+				packed, m := protowire.ConsumeBytes(b)
+				if m < 0 {
+					return protowire.ParseError(m)
+				}
+				for len(packed) > 0 && err == nil {
+					var k int
+					if k, err = decodeElem(packed); err == nil {
+						packed = packed[k:]
+					}
+				}
+				n = m
+			} else if typ != f.c.wire {
+				return wireTypeError(num, typ, fv.Type())
+			} else {
+				n, err = decodeElem(b)
+			}
+
+		case mapped:
+			if typ != protowire.BytesType {
+				return wireTypeError(num, typ, fv.Type())
+			}
+			var entry []byte
+			if entry, n = protowire.ConsumeBytes(b); n < 0 {
+				return protowire.ParseError(n)
+			}
+			if fv.IsNil() {
+				fv.Set(reflect.MakeMap(fv.Type()))
+			}
+			key := reflect.New(fv.Type().Key()).Elem()
+			val := reflect.New(fv.Type().Elem()).Elem()
+			if err = decodeEntry(entry, f, key, val); err == nil {
+				fv.SetMapIndex(key, val)
+			}
+		}
+		if err != nil {
+			return err
+		} else if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func decodeEntry(b []byte, f *field, key, val reflect.Value) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var err error
+		switch {
+		case num == 1 && typ == f.key.wire:
+			n, err = f.key.consume(b, key)
+		case num == 2 && typ == f.val.wire:
+			n, err = f.val.consume(b, val)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if err != nil {
+			return err
+		} else if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func wireTypeError(num protowire.Number, typ protowire.Type, t reflect.Type) error {
+	return fmt.Errorf("protoenc: field %d has wire type %s, which can't be decoded into %s", num, wireTypeName(typ), t)
+}
+
+func wireTypeName(typ protowire.Type) string {
+	switch typ {
+	case protowire.VarintType:
+		return "varint"
+	case protowire.Fixed32Type:
+		return "fixed32"
+	case protowire.Fixed64Type:
+		return "fixed64"
+	case protowire.BytesType:
+		return "bytes"
+	default:
+		return strings.ToLower(fmt.Sprintf("type(%d)", typ))
+	}
+}
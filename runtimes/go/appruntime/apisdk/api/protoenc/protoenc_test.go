@@ -0,0 +1,288 @@
+package protoenc
+
+import (
+	"encoding/json"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type inner struct {
+	Name string
+	Tags []string
+}
+
+type node struct {
+	Value    int
+	Children []*node
+}
+
+type allTypes struct {
+	Bool     bool
+	Int      int
+	Int8     int8
+	Uint32   uint32
+	Float32  float32
+	Float64  float64
+	String   string
+	Bytes    []byte
+	Array    [4]byte
+	Time     time.Time
+	Addr     netip.Addr
+	Raw      json.RawMessage
+	Any      any
+	Ptr      *int
+	Inner    inner
+	InnerPtr *inner
+	Ints     []int
+	Fixed    [3]float64
+	Inners   []inner
+	Nested   [][]string
+	Map      map[string]int
+	MapPtr   map[int]*inner
+	Tree     *node
+	Header   string `header:"X-Header" json:"-"`
+	Ignored  string `json:"-"`
+	private  string
+}
+
+// schemaOf returns a schema for t numbering the fields of its struct types
+// in declaration order.
+func schemaOf(t reflect.Type) *Schema {
+	s := &Schema{Messages: make(map[string]Message)}
+	s.Root = addMessages(s, t)
+	return s
+}
+
+// addMessages adds the messages of the struct type of t, or of its elements,
+// returning the name of its message.
+func addMessages(s *Schema, t reflect.Type) string {
+	for {
+		switch {
+		case t.Kind() == reflect.Pointer, t.Kind() == reflect.Slice, t.Kind() == reflect.Array, t.Kind() == reflect.Map:
+			t = t.Elem()
+			continue
+		case t.Kind() != reflect.Struct || isSpecial(t):
+			return ""
+		}
+		break
+	}
+
+	name := t.String()
+	if _, ok := s.Messages[name]; ok {
+		return name
+	}
+	s.Messages[name] = Message{}
+	var msg Message
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		msg.Fields = append(msg.Fields, Field{Name: sf.Name, Number: int32(i + 1), Message: addMessages(s, sf.Type)})
+	}
+	s.Messages[name] = msg
+	return name
+}
+
+// cmpOpts compares decoded values, which like in protobuf don't
+// distinguish between empty and nil slices and maps.
+var cmpOpts = []cmp.Option{
+	cmpopts.EquateEmpty(),
+	cmpopts.IgnoreUnexported(allTypes{}),
+	cmpopts.EquateComparable(netip.Addr{}),
+}
+
+func TestRoundTrip(t *testing.T) {
+	c := qt.New(t)
+	zero := 0
+	in := &allTypes{
+		Bool:     true,
+		Int:      -42,
+		Int8:     -8,
+		Uint32:   32,
+		Float32:  1.5,
+		Float64:  -2.25,
+		String:   "hello",
+		Bytes:    []byte{1, 2, 3},
+		Array:    [4]byte{4, 3, 2, 1},
+		Time:     time.Date(2024, 2, 3, 4, 5, 6, 7, time.UTC),
+		Addr:     netip.MustParseAddr("10.0.0.1"),
+		Raw:      json.RawMessage(`{"a":1}`),
+		Any:      map[string]any{"b": "c"},
+		Ptr:      &zero,
+		Inner:    inner{Name: "a", Tags: []string{"x", "y"}},
+		InnerPtr: &inner{},
+		Ints:     []int{1, -1, 0, 300},
+		Fixed:    [3]float64{1, 0, 3},
+		Inners:   []inner{{Name: "b"}, {}},
+		Nested:   [][]string{{"a"}, {}, {"b", "c"}},
+		Map:      map[string]int{"one": 1, "zero": 0},
+		MapPtr:   map[int]*inner{1: {Name: "c"}, 2: nil},
+		Tree:     &node{Value: 1, Children: []*node{{Value: 2}, {Value: 3, Children: []*node{{}}}}},
+		Header:   "header",
+		Ignored:  "ignored",
+		private:  "private",
+	}
+
+	s := schemaOf(reflect.TypeFor[allTypes]())
+	data, err := Marshal(in, s)
+	c.Assert(err, qt.IsNil)
+
+	var out *allTypes
+	c.Assert(Unmarshal(data, &out, s), qt.IsNil)
+
+	want := *in
+	want.Ignored = ""
+	c.Assert(out, qt.CmpEquals(cmpOpts...), &want)
+}
+
+func TestEmpty(t *testing.T) {
+	c := qt.New(t)
+	s := schemaOf(reflect.TypeFor[allTypes]())
+	data, err := Marshal(&allTypes{}, s)
+	c.Assert(err, qt.IsNil)
+	c.Assert(data, qt.HasLen, 0)
+
+	data, err = Marshal((*allTypes)(nil), s)
+	c.Assert(err, qt.IsNil)
+	c.Assert(data, qt.HasLen, 0)
+
+	var out allTypes
+	c.Assert(Unmarshal(nil, &out, s), qt.IsNil)
+	c.Assert(out, qt.CmpEquals(cmpOpts...), allTypes{})
+}
+
+func TestNonStruct(t *testing.T) {
+	c := qt.New(t)
+	data, err := Marshal([]string{"a", "b"}, nil)
+	c.Assert(err, qt.IsNil)
+
+	var out []string
+	c.Assert(Unmarshal(data, &out, nil), qt.IsNil)
+	c.Assert(out, qt.DeepEquals, []string{"a", "b"})
+}
+
+func TestProtobufCompatibility(t *testing.T) {
+	c := qt.New(t)
+
+	type stringValue struct {
+		Value string
+	}
+	data, err := Marshal(stringValue{Value: "hello"}, schemaOf(reflect.TypeFor[stringValue]()))
+	c.Assert(err, qt.IsNil)
+	want, err := proto.Marshal(wrapperspb.String("hello"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(data, qt.DeepEquals, want)
+
+	// Times are encoded as google.protobuf.Timestamp messages.
+	type timestamp struct {
+		Time time.Time
+	}
+	ts := time.Date(2024, 2, 3, 4, 5, 6, 7, time.UTC)
+	data, err = Marshal(timestamp{Time: ts}, schemaOf(reflect.TypeFor[timestamp]()))
+	c.Assert(err, qt.IsNil)
+	msg, n := protowire.ConsumeBytes(data[1:])
+	c.Assert(n, qt.Equals, len(data)-1)
+	var got timestamppb.Timestamp
+	c.Assert(proto.Unmarshal(msg, &got), qt.IsNil)
+	c.Assert(got.AsTime(), qt.Equals, ts)
+}
+
+func TestUnknownFields(t *testing.T) {
+	c := qt.New(t)
+
+	type v2 struct {
+		Name  string
+		Extra []inner
+		Count int
+	}
+	type v1 struct {
+		Name string
+	}
+	data, err := Marshal(v2{Name: "a", Extra: []inner{{Name: "b"}}, Count: 3}, schemaOf(reflect.TypeFor[v2]()))
+	c.Assert(err, qt.IsNil)
+
+	var out v1
+	c.Assert(Unmarshal(data, &out, schemaOf(reflect.TypeFor[v1]())), qt.IsNil)
+	c.Assert(out, qt.Equals, v1{Name: "a"})
+}
+
+func TestFieldNumbers(t *testing.T) {
+	c := qt.New(t)
+
+	// Fields are numbered by the schema, so they can be reordered and renamed in Go.
+	type v1 struct {
+		Name  string
+		Count int
+		ID    string
+	}
+	type v2 struct {
+		ID     string
+		Amount int
+		Name   string
+	}
+	s1 := &Schema{Root: "v", Messages: map[string]Message{"v": {Fields: []Field{
+		{Name: "Name", Number: 30000},
+		{Name: "Count", Number: 25000},
+		{Name: "ID", Number: 1},
+	}}}}
+	s2 := &Schema{Root: "v", Messages: map[string]Message{"v": {Fields: []Field{
+		{Name: "ID", Number: 1},
+		{Name: "Amount", Number: 25000},
+		{Name: "Name", Number: 30000},
+	}}}}
+	data, err := Marshal(v1{Name: "a", Count: 3, ID: "x"}, s1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(data[:3], qt.DeepEquals, []byte{0x0a, 0x01, 'x'}) // fields are encoded in number order
+
+	var out v2
+	c.Assert(Unmarshal(data, &out, s2), qt.IsNil)
+	c.Assert(out, qt.Equals, v2{ID: "x", Amount: 3, Name: "a"})
+
+	// Fields must be numbered by the schema.
+	_, err = Marshal(struct{ A, B string }{}, &Schema{Root: "v", Messages: map[string]Message{"v": {Fields: []Field{
+		{Name: "A", Number: 1},
+	}}}})
+	c.Assert(err, qt.ErrorMatches, `protoenc: field struct { A string; B string }.B: no field number in message "v"`)
+	_, err = Marshal(struct{ C, D string }{}, &Schema{Root: "v", Messages: map[string]Message{"v": {Fields: []Field{
+		{Name: "C", Number: 2},
+		{Name: "D", Number: 2},
+	}}}})
+	c.Assert(err, qt.ErrorMatches, `.*\.D: same field number 2 as field C`)
+	_, err = Marshal(struct{ E string }{}, &Schema{Root: "v", Messages: map[string]Message{"v": {Fields: []Field{
+		{Name: "E", Number: 0},
+	}}}})
+	c.Assert(err, qt.ErrorMatches, `.*\.E: invalid field number 0`)
+
+	// Structs without fields don't need a message.
+	_, err = Marshal(struct{}{}, nil)
+	c.Assert(err, qt.IsNil)
+}
+
+func TestErrors(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := Marshal(struct{ Ch chan int }{}, schemaOf(reflect.TypeFor[struct{ Ch chan int }]()))
+	c.Assert(err, qt.ErrorMatches, `protoenc: field struct { Ch chan int }.Ch: unsupported type chan int`)
+
+	s := &Schema{Root: "v", Messages: map[string]Message{"v": {Fields: []Field{{Name: "Value", Number: 1}}}}}
+	var small struct{ Value int8 }
+	data, err := Marshal(struct{ Value int }{Value: 1000}, s)
+	c.Assert(err, qt.IsNil)
+	c.Assert(Unmarshal(data, &small, s), qt.ErrorMatches, `protoenc: value 1000 overflows int8`)
+
+	var str struct{ Value string }
+	c.Assert(Unmarshal(data, &str, s), qt.ErrorMatches, `protoenc: field \d+ has wire type varint, which can't be decoded into string`)
+
+	data, err = Marshal(struct{ Value string }{Value: "hello"}, s)
+	c.Assert(err, qt.IsNil)
+	c.Assert(Unmarshal(data[:len(data)-1], &str, s), qt.ErrorMatches, `.*unexpected EOF`)
+	c.Assert(Unmarshal(data, str, s), qt.ErrorMatches, `protoenc: cannot unmarshal into struct { Value string }`)
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/fixedwindow"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/cache"
+)
+
+// rateLimiter limits the rate of incoming requests using fixed windows.
+type rateLimiter struct {
+	rules []rateLimitRule
+	store fixedwindow.Store
+}
+
+type rateLimitRule struct {
+	ruleScope
+	key    config.RateLimitKey
+	limit  int
+	window time.Duration
+}
+
+// newRateLimiter creates a rate limiter from the given configuration.
+// It returns nil if cfg is nil.
+func newRateLimiter(cfg *config.RateLimit, cacheMgr *cache.Manager) (*rateLimiter, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	l := &rateLimiter{}
+	for _, r := range cfg.Rules {
+		switch r.Key {
+		case config.RateLimitByIP, config.RateLimitByUser:
+		default:
+			return nil, fmt.Errorf("invalid rate limit key %q", r.Key)
+		}
+		if r.Limit > 0 && r.Window <= 0 {
+			return nil, fmt.Errorf("invalid rate limit window %v", r.Window)
+		}
+		l.rules = append(l.rules, rateLimitRule{
+			ruleScope: ruleScope{service: r.Service, endpoint: r.Endpoint},
+			key:       r.Key,
+			limit:     r.Limit,
+			window:    r.Window,
+		})
+	}
+
+	if cfg.CacheCluster != "" {
+		if cacheMgr == nil {
+			return nil, fmt.Errorf("cache cluster %q is not available", cfg.CacheCluster)
+		}
+		l.store = fixedwindow.NewRedis(cacheMgr.RedisClient(cfg.CacheCluster), "__encore/ratelimit/")
+	} else {
+		l.store = fixedwindow.NewMemory()
+	}
+	return l, nil
+}
+
+// ruleFor returns the most specific rule for the given endpoint, or nil if none match.
+func (l *rateLimiter) ruleFor(service, endpoint string) *rateLimitRule {
+	var best *rateLimitRule
+	bestScore := -1
+	for i := range l.rules {
+		r := &l.rules[i]
+		if score := r.specificity(service, endpoint); score > bestScore {
+			best, bestScore = r, score
+		}
+	}
+	return best
+}
+
+// bucket returns the key identifying the caller's bucket for the rule.
+// Requests matching a service-wide or global rule share the same bucket
+// across all the endpoints the rule applies to.
+func (r *rateLimitRule) bucket(c IncomingContext, s *Server) (string, bool) {
+	scope := r.service + "." + r.endpoint
+	if r.key == config.RateLimitByUser && c.auth.UID != "" {
+		return scope + "/uid/" + string(c.auth.UID), true
+	}
+	if ip, ok := s.clientIP(c.req); ok {
+		return scope + "/ip/" + ip.String(), true
+	}
+	return "", false
+}
+
+// checkRateLimit checks whether the request is within the configured
+// rate limit, writing an error response if not. It reports whether to proceed.
+//
+// It must be called after the auth handler has run so that requests
+// can be limited per user.
+func (s *Server) checkRateLimit(h Handler, c IncomingContext) bool {
+	if s.rateLimiter == nil || c.callMeta.IsServiceToService() {
+		// Service-to-service calls were rate limited when they entered the system.
+		return true
+	}
+
+	rule := s.rateLimiter.ruleFor(h.ServiceName(), h.EndpointName())
+	if rule == nil || rule.limit <= 0 {
+		return true
+	}
+	key, ok := rule.bucket(c, s)
+	if !ok {
+		return true
+	}
+
+	now := s.clock.Now()
+	start := now.Truncate(rule.window)
+	count, err := s.rateLimiter.store.Increment(c.req.Context(), key, start, rule.window)
+	if err != nil {
+		// Don't take down the API if the rate limiting state is unavailable.
+		s.rootLogger.Error().Err(err).Str("service", h.ServiceName()).Str("endpoint", h.EndpointName()).
+			Msg("unable to check rate limit, allowing request")
+		return true
+	} else if count <= int64(rule.limit) {
+		return true
+	}
+
+	retryAfter := math.Ceil(start.Add(rule.window).Sub(now).Seconds())
+	c.w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter)))
+	returnError(c, errs.B().Code(errs.ResourceExhausted).Msg("rate limit exceeded").Err(), 0)
+	return false
+}
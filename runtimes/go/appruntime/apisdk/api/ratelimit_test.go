@@ -0,0 +1,104 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+)
+
+type rateLimitTestHandler struct {
+	Handler
+	service, endpoint string
+}
+
+func (h rateLimitTestHandler) ServiceName() string  { return h.service }
+func (h rateLimitTestHandler) EndpointName() string { return h.endpoint }
+
+func TestRateLimit_PerUser(t *testing.T) {
+	rl, err := newRateLimiter(&config.RateLimit{Rules: []*config.RateLimitRule{
+		{Key: config.RateLimitByUser, Limit: 2, Window: time.Minute},
+		{Service: "svc", Endpoint: "Unlimited", Key: config.RateLimitByUser},
+	}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	klock := clock.NewMock()
+	klock.Set(time.Date(2024, 1, 1, 0, 0, 10, 0, time.UTC))
+	s := &Server{rateLimiter: rl, clock: klock, rootLogger: zerolog.Nop()}
+
+	call := func(endpoint string, uid model.UID, ip string) int {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		c := IncomingContext{w: w, req: req}
+		c.auth.UID = uid
+		if s.checkRateLimit(rateLimitTestHandler{service: "svc", endpoint: endpoint}, c) {
+			return 200
+		}
+		return w.Code
+	}
+
+	// Each user has their own bucket, regardless of IP.
+	for i, want := range []int{200, 200, 429} {
+		if got := call("Get", "alice", "1.1.1.1"); got != want {
+			t.Fatalf("alice call %d: got %d, want %d", i, got, want)
+		}
+	}
+	if got := call("Get", "bob", "1.1.1.1"); got != 200 {
+		t.Fatalf("bob: got %d, want 200", got)
+	}
+
+	// Anonymous requests fall back to the client IP.
+	for i, want := range []int{200, 200, 429} {
+		if got := call("Get", "", "2.2.2.2"); got != want {
+			t.Fatalf("anonymous call %d: got %d, want %d", i, got, want)
+		}
+	}
+	if got := call("Get", "", "3.3.3.3"); got != 200 {
+		t.Fatalf("other ip: got %d, want 200", got)
+	}
+
+	// The more specific rule without a limit takes precedence.
+	for i := 0; i < 5; i++ {
+		if got := call("Unlimited", "alice", "1.1.1.1"); got != 200 {
+			t.Fatalf("unlimited call %d: got %d, want 200", i, got)
+		}
+	}
+
+	// The limit resets with the next window.
+	klock.Add(time.Minute)
+	if got := call("Get", "alice", "1.1.1.1"); got != 200 {
+		t.Fatalf("next window: got %d, want 200", got)
+	}
+}
+
+func TestRateLimit_RetryAfter(t *testing.T) {
+	rl, err := newRateLimiter(&config.RateLimit{Rules: []*config.RateLimitRule{
+		{Key: config.RateLimitByIP, Limit: 1, Window: time.Minute},
+	}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	klock := clock.NewMock()
+	klock.Set(time.Date(2024, 1, 1, 0, 0, 15, 500, time.UTC))
+	s := &Server{rateLimiter: rl, clock: klock, rootLogger: zerolog.Nop()}
+
+	var w *httptest.ResponseRecorder
+	for i := 0; i < 2; i++ {
+		w = httptest.NewRecorder()
+		s.checkRateLimit(rateLimitTestHandler{service: "svc", endpoint: "Get"}, IncomingContext{w: w, req: httptest.NewRequest("GET", "/", nil)})
+	}
+	if w.Code != 429 {
+		t.Fatalf("got status %d, want 429", w.Code)
+	} else if got := w.Header().Get("Retry-After"); got != "45" {
+		t.Fatalf("got Retry-After %q, want 45", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/stack"
 	"encore.dev/appruntime/exported/trace2"
@@ -59,6 +60,14 @@ type beginRequestParams struct {
 	// to facilitate request correlation.
 	ExtCorrelationID string
 
+	// Values are the request-scoped values to begin the request with.
+	// If nil the request begins without any values.
+	Values *model.RequestValues
+
+	// Baggage is the baggage to begin the request with.
+	// If nil the request begins with the baggage of the parent request, if any.
+	Baggage *model.Baggage
+
 	// AdditionalLogFields is a map of additional fields to be added to all the log message.
 	// This is mainly used to add the trace identifiers to the log messages
 	// so the clouds logging can correlate the logs with the trace.
@@ -86,7 +95,7 @@ func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*mode
 
 	var traced bool
 	if p.ParentSpanID.IsZero() {
-		traced = s.rt.SampleTrace()
+		traced = s.sampleTrace(p.Data.Desc)
 	} else {
 		traced = p.ParentSampled
 	}
@@ -104,6 +113,8 @@ func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*mode
 		Start:            s.clock.Now(),
 		Traced:           traced,
 		RPCData:          p.Data,
+		Values:           p.Values,
+		Baggage:          p.Baggage,
 	}
 
 	data := req.RPCData
@@ -130,6 +141,11 @@ func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*mode
 		case model.PubSubMessage:
 			curr.Trace.PubsubMessageSpanStart(req, curr.Goctr)
 		}
+
+		if s.snapshots.Enabled() {
+			ep := trace2.EventParams{TraceID: req.TraceID, SpanID: req.SpanID, Goid: curr.Goctr}
+			s.snapshotStops.Store(req, trace2.StartGoroutineSnapshots(curr.Trace, s.snapshots, ep, time.Now()))
+		}
 	}
 
 	// Now that we have up-to-date information in req (possibly copied from
@@ -176,6 +192,18 @@ func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*mode
 	return req, nil
 }
 
+// goroutineSnapshots returns the configured goroutine snapshot settings.
+func goroutineSnapshots(cfg *config.TraceGoroutineSnapshots) trace2.GoroutineSnapshots {
+	if cfg == nil {
+		return trace2.GoroutineSnapshots{}
+	}
+	return trace2.GoroutineSnapshots{
+		LatencyThreshold: cfg.LatencyThreshold,
+		Interval:         cfg.Interval,
+		MaxStacks:        cfg.MaxStacks,
+	}
+}
+
 func (s *Server) finishRequest(resp *model.Response) {
 	curr := s.rt.Current()
 	req := curr.Req
@@ -224,6 +252,10 @@ func (s *Server) finishRequest(resp *model.Response) {
 		}
 	}
 
+	if stop, ok := s.snapshotStops.LoadAndDelete(req); ok {
+		stop.(func())()
+	}
+
 	if curr.Trace != nil {
 		// Capture the recorded bytes from the request and response body, if any.
 		if len(resp.RawRequestPayload) > 0 {
@@ -276,6 +308,7 @@ func (s *Server) finishRequest(resp *model.Response) {
 	s.requestsTotal.With(requestsTotalLabels{
 		endpoint: req.RPCData.Desc.Endpoint,
 		code:     Code(resp.Err, resp.HTTPStatus),
+		extra:    s.metricsReg.RequestLabels(),
 	}).Increment()
 	s.rt.FinishRequest(false)
 }
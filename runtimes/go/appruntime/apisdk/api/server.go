@@ -2,10 +2,10 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"reflect"
 	"slices"
@@ -26,8 +26,11 @@ import (
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/experiments"
 	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/infrasdk/xds"
 	"encore.dev/appruntime/shared/cfgutil"
 	"encore.dev/appruntime/shared/cloudtrace"
+	"encore.dev/appruntime/shared/faults"
 	"encore.dev/appruntime/shared/health"
 	"encore.dev/appruntime/shared/platform"
 	"encore.dev/appruntime/shared/reqtrack"
@@ -37,6 +40,7 @@ import (
 	"encore.dev/internal/platformauth"
 	"encore.dev/metrics"
 	"encore.dev/pubsub"
+	"encore.dev/storage/cache"
 )
 
 type Access string
@@ -45,6 +49,11 @@ const (
 	Public       Access = "public"
 	RequiresAuth Access = "auth"
 	Private      Access = "private"
+
+	// OptionalAuth is like Public, except the auth handler's result is
+	// respected if credentials are provided: invalid credentials are
+	// rejected rather than treated as an unauthenticated request.
+	OptionalAuth Access = "optional-auth"
 )
 
 const (
@@ -77,6 +86,10 @@ type IncomingContext struct {
 	// capturer is set in handleIncoming for raw requests
 	// to capture the request body
 	capturer *rawRequestBodyCapturer
+
+	// grpc is whether the request is a gRPC call from another service,
+	// with the request and response encoded as protobuf messages.
+	grpc bool
 }
 
 type Handler interface {
@@ -93,6 +106,7 @@ type Handler interface {
 type requestsTotalLabels struct {
 	endpoint string // Endpoint name.
 	code     string // Human-readable HTTP status code.
+	extra    string // Application-defined labels, encoded by metrics.Registry.RequestLabels.
 }
 
 type Server struct {
@@ -102,8 +116,10 @@ type Server struct {
 	pc             *platform.Client // if nil, requests are not authenticated against platform
 	encoreMgr      *encore.Manager
 	pubsubMgr      *pubsub.Manager
+	metricsReg     *metrics.Registry
 	requestsTotal  *metrics.CounterGroup[requestsTotalLabels, uint64]
 	httpClient     *http.Client
+	grpcClient     *http.Client // used for calls to services configured with the gRPC protocol
 	clock          clock.Clock
 	rootLogger     zerolog.Logger
 	json           jsoniter.API
@@ -115,6 +131,7 @@ type Server struct {
 	globalMiddleware    map[string]*Middleware
 	registeredHandlers  []Handler
 	functionsToHandlers map[uintptr]Handler
+	grpcHandlers        map[string]Handler // keyed by gRPC method path
 
 	public           *httprouter.Router
 	publicFallback   *httprouter.Router
@@ -123,11 +140,19 @@ type Server struct {
 	encore           *httprouter.Router
 	inboundSvcAuth   map[string]svcauth.ServiceAuth // auth methods used to accept inbound service-to-service calls
 	outboundSvcAuth  map[string]svcauth.ServiceAuth // auth methods used to make outbound service-to-service calls
+	ipFilter         *ipFilter                      // nil if no IP filtering is configured
+	rateLimiter      *rateLimiter                   // nil if no rate limiting is configured
+	traceSampler     *traceSampler                  // nil if no trace sampling rules are configured
+	snapshots        trace2.GoroutineSnapshots      // zero if goroutine snapshots are disabled
+	snapshotStops    sync.Map                       // *model.Request -> func() stopping its goroutine snapshots
+	xds              *xds.Client                    // nil if xDS service discovery is not configured
+	faults           *faults.Injector               // nil if no fault injection is configured
 	httpsrv          *http.Server
+	mtlssrv          *http.Server // serves service-to-service calls over mutual TLS; nil if not configured
 	httpCtx          context.Context
 	httpCtxCancel    context.CancelFunc
 	runningHandlers  sync.WaitGroup
-	remotePubSubPush map[string]*httputil.ReverseProxy
+	remotePubSubPush map[string]*serviceProxy
 
 	callCtr uint64
 
@@ -136,13 +161,13 @@ type Server struct {
 	testingMgr          *testsupport.Manager
 }
 
-func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker, pc *platform.Client, encoreMgr *encore.Manager, pubsubMgr *pubsub.Manager, rootLogger zerolog.Logger, reg *metrics.Registry, healthMgr *health.CheckRegistry, testingMgr *testsupport.Manager, json jsoniter.API, clock clock.Clock) *Server {
+func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker, pc *platform.Client, encoreMgr *encore.Manager, pubsubMgr *pubsub.Manager, cacheMgr *cache.Manager, rootLogger zerolog.Logger, reg *metrics.Registry, healthMgr *health.CheckRegistry, testingMgr *testsupport.Manager, json jsoniter.API, clock clock.Clock) *Server {
 	requestsTotal := metrics.NewCounterGroupInternal[requestsTotalLabels, uint64](reg, "e_requests_total", metrics.CounterConfig{
 		EncoreInternal_LabelMapper: func(labels requestsTotalLabels) []metrics.KeyValue {
-			return []metrics.KeyValue{
+			return append([]metrics.KeyValue{
 				{Key: "endpoint", Value: labels.endpoint},
 				{Key: "code", Value: labels.code},
-			}
+			}, reg.DecodeRequestLabels(labels.extra)...)
 		},
 	})
 
@@ -154,11 +179,34 @@ func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.Requ
 		return router
 	}
 
-	inboundSvcAuth, outboundSvcAuth, err := svcauth.LoadMethods(clock, runtime)
+	inboundSvcAuth, outboundSvcAuth, err := svcauth.LoadMethods(clock, static, runtime)
 	if err != nil {
 		panic(fmt.Errorf("error loading service auth methods: %w", err))
 	}
 
+	ipFilter, err := newIPFilter(runtime.IPFilter)
+	if err != nil {
+		panic(fmt.Errorf("error loading ip filter: %w", err))
+	}
+
+	rateLimiter, err := newRateLimiter(runtime.RateLimit, cacheMgr)
+	if err != nil {
+		panic(fmt.Errorf("error loading rate limits: %w", err))
+	}
+
+	faultInjector, err := faults.New(runtime.FaultInjection, rt)
+	if err != nil {
+		panic(fmt.Errorf("error loading fault injection rules: %w", err))
+	}
+
+	var xdsClient *xds.Client
+	if runtime.XDS != nil {
+		xdsClient, err = xds.NewClient(runtime.XDS, rootLogger)
+		if err != nil {
+			panic(fmt.Errorf("error loading xds service discovery: %w", err))
+		}
+	}
+
 	s := &Server{
 		static:              static,
 		runtime:             runtime,
@@ -168,6 +216,7 @@ func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.Requ
 		pubsubMgr:           pubsubMgr,
 		healthMgr:           healthMgr,
 		testingMgr:          testingMgr,
+		metricsReg:          reg,
 		requestsTotal:       requestsTotal,
 		httpClient:          &http.Client{},
 		clock:               clock,
@@ -176,6 +225,7 @@ func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.Requ
 		tracingEnabled:      rt.TracingEnabled(),
 		experiments:         experiments.FromConfig(static, runtime),
 		functionsToHandlers: make(map[uintptr]Handler),
+		grpcHandlers:        make(map[string]Handler),
 
 		public:           newRouter(),
 		publicFallback:   newRouter(),
@@ -184,7 +234,13 @@ func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.Requ
 		encore:           newRouter(),
 		inboundSvcAuth:   inboundSvcAuth,
 		outboundSvcAuth:  outboundSvcAuth,
-		remotePubSubPush: make(map[string]*httputil.ReverseProxy),
+		ipFilter:         ipFilter,
+		rateLimiter:      rateLimiter,
+		traceSampler:     newTraceSampler(runtime.TraceSamplingRules),
+		snapshots:        goroutineSnapshots(runtime.TraceGoroutineSnapshots),
+		xds:              xdsClient,
+		faults:           faultInjector,
+		remotePubSubPush: make(map[string]*serviceProxy),
 	}
 
 	// Create our HTTP server handler chain
@@ -244,6 +300,29 @@ func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.Requ
 		},
 	}
 
+	// Use mutual TLS for service-to-service calls if configured.
+	// They're served on a separate listener so that external requests
+	// can keep being served without TLS on the main listener.
+	if tlsCfg := svcauth.ServerTLSConfig(inboundSvcAuth); tlsCfg != nil {
+		s.mtlssrv = &http.Server{
+			Handler:     s.httpsrv.Handler,
+			BaseContext: s.httpsrv.BaseContext,
+			TLSConfig:   tlsCfg,
+		}
+	}
+	if tlsCfg := svcauth.ClientTLSConfig(outboundSvcAuth); tlsCfg != nil {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.TLSClientConfig = tlsCfg
+		s.httpClient.Transport = t
+	}
+
+	// gRPC calls are made over HTTP/2, using h2c for unencrypted connections.
+	grpcTransport := http.DefaultTransport
+	if s.httpClient.Transport != nil {
+		grpcTransport = s.httpClient.Transport
+	}
+	s.grpcClient = &http.Client{Transport: transport.NewH2CTransport(grpcTransport)}
+
 	s.configureRemotePubsubPush()
 	s.registerEncoreRoutes()
 
@@ -269,7 +348,7 @@ func (s *Server) configureRemotePubsubPush() {
 			if slices.Contains(s.runtime.HostedServices, statSub.Service) {
 				continue
 			}
-			service, found := s.runtime.ServiceDiscovery[statSub.Service]
+			service, found := s.serviceRoute(statSub.Service)
 			if !found {
 				panic(fmt.Errorf("service %q not found in service discovery, but needed for the remote push handler", statSub.Service))
 			}
@@ -288,19 +367,16 @@ func (s *Server) setAuthHandler(h AuthHandler) {
 	authService := h.HostedByService()
 
 	if !cfgutil.IsHostedService(s.runtime, authService) {
-		service, found := s.runtime.ServiceDiscovery[authService]
+		service, found := s.serviceRoute(authService)
 		if !found {
 			panic(fmt.Errorf("service %q not found in service discovery, but needed for the auth handler", authService))
 		}
 
-		authURL := fmt.Sprintf("%s/__encore/authhandler", service.URL)
-
 		s.authHandler = &remoteAuthHandler{
 			server:         s,
 			hostingService: service,
-			authURL:        authURL,
 			original:       h,
-			logger:         s.rootLogger.With().Str("auth_url", authURL).Logger(),
+			logger:         s.rootLogger.With().Str("auth_service", authService).Logger(),
 			traceLogs:      s.runtime.EnvCloud != "local", // log auth calls in prod containers only
 		}
 	} else {
@@ -308,6 +384,18 @@ func (s *Server) setAuthHandler(h AuthHandler) {
 	}
 }
 
+// AuthenticateInTest runs the auth handler, or its mock if one is set, with the given
+// auth params in the current test, for tests authenticating using credentials.
+func (s *Server) AuthenticateInTest(ctx context.Context, params any) (model.AuthInfo, error) {
+	h, ok := s.authHandler.(interface {
+		authenticateParams(ctx context.Context, s *Server, params any) (model.AuthInfo, error)
+	})
+	if !ok {
+		return model.AuthInfo{}, errors.New("the application has no auth handler")
+	}
+	return h.authenticateParams(ctx, s, params)
+}
+
 func (s *Server) RegisteredHandlers() []Handler {
 	return s.registeredHandlers
 }
@@ -329,6 +417,7 @@ func (s *Server) registerEndpoint(h Handler, function any) {
 	switch {
 	case cfgutil.IsHostedService(s.runtime, h.ServiceName()):
 		adapter = s.createServiceHandlerAdapter(h)
+		s.registerGRPCEndpoint(h)
 
 	case s.IsGateway():
 		adapter = s.createGatewayHandlerAdapter(h)
@@ -347,7 +436,7 @@ func (s *Server) registerEndpoint(h Handler, function any) {
 		}
 
 		private.Handle(m, routerPath, adapter)
-		if access := h.AccessType(); access == Public || access == RequiresAuth {
+		if access := h.AccessType(); access == Public || access == RequiresAuth || access == OptionalAuth {
 			public.Handle(m, routerPath, adapter)
 		}
 	}
@@ -408,9 +497,33 @@ func (s *Server) Serve(ln net.Listener) error {
 	if s.runtime.EnvCloud != "local" || s.IsGateway() {
 		s.rootLogger.Info().Msg("listening for incoming HTTP requests")
 	}
-	return s.httpsrv.Serve(ln)
+	if s.mtlssrv == nil {
+		return s.httpsrv.Serve(ln)
+	}
+
+	addr := s.runtime.MTLS.ListenAddr
+	if addr == "" {
+		addr = defaultMTLSListenAddr
+	}
+	mtlsLn, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen for service-to-service calls: %w", err)
+	}
+
+	// Serve until either server stops.
+	serveErr := make(chan error, 2)
+	go func() {
+		// The certificate is provided by the TLS config.
+		serveErr <- s.mtlssrv.ServeTLS(mtlsLn, "", "")
+	}()
+	go func() { serveErr <- s.httpsrv.Serve(ln) }()
+	return <-serveErr
 }
 
+// defaultMTLSListenAddr is the address service-to-service calls
+// are accepted on when using mutual TLS, unless configured otherwise.
+const defaultMTLSListenAddr = ":8443"
+
 // Shutdown gracefully shuts down the server.
 func (s *Server) Shutdown(p *shutdown.Process) error {
 	// Once it's time to force-close tasks, cancel the base context.
@@ -422,15 +535,32 @@ func (s *Server) Shutdown(p *shutdown.Process) error {
 	// Begin shutting down the server
 	shutdownErr := make(chan error, 1)
 	go func() { shutdownErr <- s.httpsrv.Shutdown(p.ForceShutdown) }()
+	mtlsShutdownErr := make(chan error, 1)
+	if s.mtlssrv != nil {
+		go func() { mtlsShutdownErr <- s.mtlssrv.Shutdown(p.ForceShutdown) }()
+	} else {
+		mtlsShutdownErr <- nil
+	}
 
 	// Wait for the running handlers to finish.
 	s.runningHandlers.Wait()
 	p.MarkOutstandingRequestsCompleted()
 
-	return <-shutdownErr
+	if s.xds != nil {
+		_ = s.xds.Close()
+	}
+
+	return errors.Join(<-shutdownErr, <-mtlsShutdownErr)
 }
 
 func (s *Server) handler(w http.ResponseWriter, req *http.Request) {
+	// Headers of WebSocket connections from browsers are passed in the handshake's
+	// subprotocols, so they must be decoded before the request is authenticated.
+	if err := decodeWebSocketHeaders(req); err != nil {
+		errs.HTTPError(w, err)
+		return
+	}
+
 	// Select a router based on access
 	router, fallbackRouter := s.public, s.publicFallback
 
@@ -457,6 +587,12 @@ func (s *Server) handler(w http.ResponseWriter, req *http.Request) {
 		// extractCallMeta has already written the response
 		return
 	}
+	// gRPC calls are routed by their method rather than the API's path.
+	if isGRPCRequest(req) {
+		s.handleGRPC(w, req, internalCaller)
+		return
+	}
+
 	if internalCaller != nil && internalCaller.PrivateAPIAccess() {
 		// If this request is from another service running in this app, allow it access to the private API routes
 		router, fallbackRouter = s.private, s.privateFallback
@@ -551,6 +687,7 @@ func (s *Server) newExecContext(ctx context.Context, ps UnnamedParams, callMeta
 		auth = model.AuthInfo{
 			UID:      model.UID(callMeta.Internal.AuthUID),
 			UserData: callMeta.Internal.AuthData,
+			Baggage:  callMeta.baggage(),
 		}
 	}
 	return execContext{s, ctx, ps, auth, callMeta}
@@ -558,7 +695,7 @@ func (s *Server) newExecContext(ctx context.Context, ps UnnamedParams, callMeta
 
 func (s *Server) NewIncomingContext(w http.ResponseWriter, req *http.Request, ps UnnamedParams, callMeta CallMeta) IncomingContext {
 	ec := s.newExecContext(req.Context(), ps, callMeta)
-	return IncomingContext{ec, w, req, nil}
+	return IncomingContext{execContext: ec, w: w, req: req}
 }
 
 func (s *Server) NewCallContext(ctx context.Context) CallContext {
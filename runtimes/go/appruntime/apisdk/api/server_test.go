@@ -7,6 +7,9 @@ import (
 	"testing"
 
 	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
 )
 
 func Test_handleTrailingSlashRedirect(t *testing.T) {
@@ -82,3 +85,34 @@ func Test_determineRequestPath(t *testing.T) {
 		}
 	}
 }
+
+func TestServer_setTraceIDHeaders(t *testing.T) {
+	req := &model.Request{
+		TraceID: model.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  model.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		Traced:  true,
+	}
+	traceResponse := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := []struct {
+		header      string
+		encore, w3c string
+	}{
+		{"", req.TraceID.String(), ""},
+		{"encore", req.TraceID.String(), ""},
+		{"w3c", "", traceResponse},
+		{"both", req.TraceID.String(), traceResponse},
+		{"none", "", ""},
+	}
+	for _, tt := range tests {
+		s := &Server{runtime: &config.Runtime{TraceResponseHeader: tt.header}}
+		w := httptest.NewRecorder()
+		s.setTraceIDHeaders(w, req)
+		if got := w.Header().Get("X-Encore-Trace-ID"); got != tt.encore {
+			t.Errorf("%q: got X-Encore-Trace-ID %q, want %q", tt.header, got, tt.encore)
+		}
+		if got := w.Header().Get("traceresponse"); got != tt.w3c {
+			t.Errorf("%q: got traceresponse %q, want %q", tt.header, got, tt.w3c)
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/appruntime/exported/model"
 )
 
 func (s *Server) createServiceHandlerAdapter(h Handler) httprouter.Handle {
@@ -16,21 +19,26 @@ func (s *Server) createServiceHandlerAdapter(h Handler) httprouter.Handle {
 		// Extract metadata from the request.
 		meta := CallMetaFromContext(req.Context())
 
-		// Always send the trace id back.
+		// Send the trace id back, unless configured not to.
 		traceIDStr := meta.TraceID.String()
-		w.Header().Set("X-Encore-Trace-ID", traceIDStr)
+		encoreHeader, _ := s.traceResponseHeaders()
+		if encoreHeader {
+			w.Header().Set("X-Encore-Trace-ID", traceIDStr)
+		}
 
 		// Echo the X-Request-ID back to the caller if present,
 		// otherwise send back the trace id.
 		reqID := req.Header.Get("X-Request-ID")
-		if reqID == "" {
+		if reqID == "" && s.runtime.TraceResponseHeader != "none" {
 			reqID = traceIDStr
 		} else if len(reqID) > 64 {
 			// Don't allow arbitrarily long request IDs.
 			s.rootLogger.Warn().Int("length", len(reqID)).Msg("X-Request-ID was too long and is being truncated to 64 characters")
 			reqID = reqID[:64]
 		}
-		w.Header().Set("X-Request-ID", reqID)
+		if reqID != "" {
+			w.Header().Set("X-Request-ID", reqID)
+		}
 
 		// Read the correlation ID from the request.
 		if meta.CorrelationID != "" {
@@ -45,9 +53,53 @@ func (s *Server) processRequest(h Handler, c IncomingContext) {
 	c.server.beginOperation()
 	defer c.server.finishOperation()
 
+	if !s.checkIPFilter(h, c) {
+		return
+	}
+
 	info, proceed := s.runAuthHandler(h, c)
 	if proceed {
 		c.auth = info
-		h.Handle(c)
+		if s.checkRateLimit(h, c) {
+			h.Handle(c)
+		}
+	}
+}
+
+// traceResponseHeaders reports which headers the trace id of a request
+// is sent back to the caller in: X-Encore-Trace-ID and/or the W3C
+// traceresponse header, as configured by the runtime.
+func (s *Server) traceResponseHeaders() (encore, w3c bool) {
+	switch s.runtime.TraceResponseHeader {
+	case "w3c":
+		return false, true
+	case "both":
+		return true, true
+	case "none":
+		return false, false
+	default:
+		return true, false
+	}
+}
+
+// setTraceIDHeaders sets the trace id headers of the response once the
+// request has begun, as raw endpoints may have joined an external trace
+// since the headers were first set. The W3C traceresponse header is only
+// set here, as it includes the span id and whether the request is traced.
+// See https://www.w3.org/TR/trace-context-2/#traceresponse-header.
+func (s *Server) setTraceIDHeaders(w http.ResponseWriter, req *model.Request) {
+	if w == nil {
+		return
+	}
+	encoreHeader, w3cHeader := s.traceResponseHeaders()
+	if encoreHeader {
+		w.Header().Set("X-Encore-Trace-ID", req.TraceID.String())
+	}
+	if w3cHeader {
+		sampled := "00"
+		if req.Traced {
+			sampled = "01"
+		}
+		w.Header().Set("traceresponse", fmt.Sprintf("00-%x-%x-%s", req.TraceID[:], req.SpanID[:], sampled))
 	}
 }
@@ -0,0 +1,314 @@
+package svcauth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+
+	"encore.dev/appruntime/apisdk/api/transport"
+	"encore.dev/appruntime/exported/config"
+)
+
+// certReloadInterval is how often certificate files are checked for changes.
+const certReloadInterval = 10 * time.Second
+
+// mtlsAuth is a ServiceAuth implementation that authenticates requests using
+// the client certificate presented during the TLS handshake.
+//
+// Certificates identify the services they may act as using URI SANs of the form
+// "encore://<app-slug>/<env-name>/<service>", and the gateways they may act as
+// using URI SANs of the form "encore://<app-slug>/<env-name>/gateway/<gateway>".
+// A certificate for a process hosting multiple services or gateways should
+// include one such SAN for each of them.
+type mtlsAuth struct {
+	appSlug string
+	envName string
+	cert    *certLoader
+	roots   *x509.CertPool
+
+	// subscriptionService returns the service a pubsub subscription belongs to.
+	subscriptionService func(topic, subscription string) (service string, ok bool)
+}
+
+var _ ServiceAuth = (*mtlsAuth)(nil)
+
+func newMTLSAuth(clock clock.Clock, appSlug string, envName string, cfg *config.MTLS, subscriptionService func(topic, subscription string) (string, bool)) (*mtlsAuth, error) {
+	if cfg == nil {
+		return nil, errors.New("mtls: no certificates configured")
+	}
+
+	caPEM := []byte(cfg.CAPEM)
+	if cfg.CAFile != "" {
+		data, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("mtls: read ca file: %w", err)
+		}
+		caPEM = data
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("mtls: no valid certificate authority configured")
+	}
+
+	cert := &certLoader{
+		clock:    clock,
+		certPEM:  []byte(cfg.CertPEM),
+		keyPEM:   []byte(cfg.KeyPEM),
+		certFile: cfg.CertFile,
+		keyFile:  cfg.KeyFile,
+	}
+	if _, err := cert.get(); err != nil {
+		return nil, err
+	}
+
+	return &mtlsAuth{
+		appSlug: appSlug,
+		envName: envName,
+		cert:    cert,
+		roots:   roots,
+
+		subscriptionService: subscriptionService,
+	}, nil
+}
+
+func (m *mtlsAuth) method() string {
+	return "mtls"
+}
+
+func (m *mtlsAuth) sign(transport.Transport) error {
+	// The client certificate is presented during the TLS handshake,
+	// so there is nothing to add to the request itself.
+	return nil
+}
+
+func (m *mtlsAuth) verify(req transport.Transport) error {
+	state := transport.TLSConnectionState(req)
+	if state == nil || len(state.VerifiedChains) == 0 {
+		return errors.New("no verified client certificate")
+	}
+
+	id := m.peerIdentity(state.VerifiedChains[0][0])
+	if len(id.services) == 0 && len(id.gateways) == 0 {
+		return errors.New("client certificate has no service identity for this environment")
+	}
+
+	// Ensure the certificate was issued to the caller the request claims to be made by,
+	// since the caller determines the access and auth data the request is trusted with.
+	// The metadata key and formats match the ones used by the api package for the caller.
+	caller, found := req.ReadMeta("Caller")
+	if !found {
+		return errors.New("no caller")
+	}
+	kind, rest, _ := strings.Cut(caller, ":")
+	switch kind {
+	case "api":
+		svc, endpoint, _ := strings.Cut(rest, ".")
+		if svc == "gateway" && endpoint == remoteAuthHandlerEndpoint {
+			// Gateways call auth handlers hosted by other services as an API.
+			if len(id.gateways) == 0 {
+				return errors.New("client certificate is not valid for a gateway")
+			}
+			return nil
+		}
+		return id.requireService(svc)
+
+	case "gateway":
+		// Older callers suffix the gateway name with ".none".
+		gateway, _, _ := strings.Cut(rest, ".")
+		if !slices.Contains(id.gateways, gateway) {
+			return fmt.Errorf("client certificate is not valid for gateway %q", gateway)
+		}
+		return nil
+
+	case "pubsub":
+		topic, rest, _ := strings.Cut(rest, ":")
+		subscription, _, _ := strings.Cut(rest, ":")
+		svc, ok := "", false
+		if m.subscriptionService != nil {
+			svc, ok = m.subscriptionService(topic, subscription)
+		}
+		if !ok {
+			return fmt.Errorf("unknown pubsub subscription %q for topic %q", subscription, topic)
+		}
+		return id.requireService(svc)
+
+	case "app":
+		// Calls made outside of a request, such as during service initialization,
+		// can be made by any service but not by a gateway.
+		if len(id.services) == 0 {
+			return errors.New("client certificate is not valid for a service")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("caller %q is not allowed to make service-to-service calls", caller)
+	}
+}
+
+// remoteAuthHandlerEndpoint is the endpoint gateways claim to call from
+// when calling an auth handler hosted by another service.
+const remoteAuthHandlerEndpoint = "__encore/authhandler"
+
+// peerIdentity is the identity of a client certificate
+// within this app and environment.
+type peerIdentity struct {
+	services []string
+	gateways []string
+}
+
+func (id peerIdentity) requireService(svc string) error {
+	if !slices.Contains(id.services, svc) {
+		return fmt.Errorf("client certificate is not valid for service %q", svc)
+	}
+	return nil
+}
+
+// peerIdentity returns the services and gateways the certificate
+// identifies within this app and environment.
+func (m *mtlsAuth) peerIdentity(cert *x509.Certificate) peerIdentity {
+	var id peerIdentity
+	for _, u := range cert.URIs {
+		if u.Scheme != "encore" || u.Host != m.appSlug {
+			continue
+		}
+		env, name, ok := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+		if !ok || env != m.envName || name == "" {
+			continue
+		}
+		if gateway, isGateway := strings.CutPrefix(name, "gateway/"); isGateway {
+			if gateway != "" && !strings.Contains(gateway, "/") {
+				id.gateways = append(id.gateways, gateway)
+			}
+		} else if !strings.Contains(name, "/") {
+			id.services = append(id.services, name)
+		}
+	}
+	return id
+}
+
+func (m *mtlsAuth) serverConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return m.cert.get()
+		},
+		// Only service-to-service calls are served with this config,
+		// so every client must present a certificate.
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  m.roots,
+	}
+}
+
+func (m *mtlsAuth) clientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return m.cert.get()
+		},
+		RootCAs: m.roots,
+	}
+}
+
+// certLoader loads a certificate and key, either from PEM data or from files.
+// Files are reloaded when they change to support certificate rotation.
+type certLoader struct {
+	clock    clock.Clock
+	certPEM  []byte
+	keyPEM   []byte
+	certFile string
+	keyFile  string
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+func (l *certLoader) get() (*tls.Certificate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.certFile == "" && l.keyFile == "" {
+		if l.cert == nil {
+			cert, err := tls.X509KeyPair(l.certPEM, l.keyPEM)
+			if err != nil {
+				return nil, fmt.Errorf("mtls: parse certificate: %w", err)
+			}
+			l.cert = &cert
+		}
+		return l.cert, nil
+	}
+
+	now := l.clock.Now()
+	if l.cert != nil && now.Sub(l.checkedAt) < certReloadInterval {
+		return l.cert, nil
+	}
+	l.checkedAt = now
+
+	modTime, err := l.latestModTime()
+	if err == nil && l.cert != nil && modTime.Equal(l.modTime) {
+		return l.cert, nil
+	}
+	if err == nil {
+		err = l.load(modTime)
+	}
+	if err != nil {
+		if l.cert != nil {
+			// Keep serving the previous certificate, which may
+			// be mid-rotation, rather than failing handshakes.
+			return l.cert, nil
+		}
+		return nil, err
+	}
+	return l.cert, nil
+}
+
+func (l *certLoader) load(modTime time.Time) error {
+	certPEM, keyPEM := l.certPEM, l.keyPEM
+	if l.certFile != "" {
+		data, err := os.ReadFile(l.certFile)
+		if err != nil {
+			return fmt.Errorf("mtls: read cert file: %w", err)
+		}
+		certPEM = data
+	}
+	if l.keyFile != "" {
+		data, err := os.ReadFile(l.keyFile)
+		if err != nil {
+			return fmt.Errorf("mtls: read key file: %w", err)
+		}
+		keyPEM = data
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("mtls: parse certificate: %w", err)
+	}
+	l.cert, l.modTime = &cert, modTime
+	return nil
+}
+
+func (l *certLoader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, path := range []string{l.certFile, l.keyFile} {
+		if path == "" {
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,231 @@
+package svcauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+
+	"encore.dev/appruntime/apisdk/api/transport"
+	"encore.dev/appruntime/exported/config"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key, pem: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))}
+}
+
+// issue issues a certificate for the given service identity URIs,
+// valid for both client and server use on localhost.
+func (ca *testCA) issue(t *testing.T, uris ...string) (certPEM, keyPEM string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	for _, u := range uris {
+		parsed, _ := url.Parse(u)
+		tmpl.URIs = append(tmpl.URIs, parsed)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+// testSubscriptionService is the subscription lookup used by the tests,
+// with a single subscription in the orders service.
+func testSubscriptionService(topic, subscription string) (string, bool) {
+	if topic == "order-placed" && subscription == "ship-order" {
+		return "orders", true
+	}
+	return "", false
+}
+
+func TestMTLS_Verify(t *testing.T) {
+	ca := newTestCA(t)
+	serverCert, serverKey := ca.issue(t, "encore://app/prod/billing")
+	server, err := newMTLSAuth(clock.New(), "app", "prod", &config.MTLS{CertPEM: serverCert, KeyPEM: serverKey, CAPEM: ca.pem}, testSubscriptionService)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inbound := map[string]ServiceAuth{"mtls": server}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{
+		TLSConfig: ServerTLSConfig(inbound),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if _, err := Verify(transport.HTTPRequest(req), inbound); err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+			}
+		}),
+	}
+	go func() { _ = srv.ServeTLS(ln, "", "") }()
+	defer func() { _ = srv.Close() }()
+	srvURL := "https://" + ln.Addr().String()
+
+	tests := []struct {
+		name   string
+		uris   []string // nil means no client certificate
+		caller string
+		ok     bool
+	}{
+		{name: "matching_service", uris: []string{"encore://app/prod/orders"}, caller: "api:orders.Create", ok: true},
+		{name: "multiple_services", uris: []string{"encore://app/prod/users", "encore://app/prod/orders"}, caller: "api:orders.Create", ok: true},
+		{name: "gateway", uris: []string{"encore://app/prod/gateway/api-gateway"}, caller: "gateway:api-gateway.none", ok: true},
+		{name: "gateway_auth_handler", uris: []string{"encore://app/prod/gateway/api-gateway"}, caller: "api:gateway.__encore/authhandler", ok: true},
+		{name: "pubsub", uris: []string{"encore://app/prod/orders"}, caller: "pubsub:order-placed:ship-order:msg1", ok: true},
+		{name: "app", uris: []string{"encore://app/prod/orders"}, caller: "app:deploy1", ok: true},
+		{name: "service_as_gateway", uris: []string{"encore://app/prod/orders"}, caller: "gateway:api-gateway"},
+		{name: "service_as_auth_handler_caller", uris: []string{"encore://app/prod/gateway"}, caller: "api:gateway.__encore/authhandler"},
+		{name: "wrong_gateway", uris: []string{"encore://app/prod/gateway/internal"}, caller: "gateway:api-gateway"},
+		{name: "gateway_as_service", uris: []string{"encore://app/prod/gateway/api-gateway"}, caller: "api:orders.Create"},
+		{name: "gateway_as_app", uris: []string{"encore://app/prod/gateway/api-gateway"}, caller: "app:deploy1"},
+		{name: "wrong_subscription_service", uris: []string{"encore://app/prod/users"}, caller: "pubsub:order-placed:ship-order:msg1"},
+		{name: "unknown_subscription", uris: []string{"encore://app/prod/orders"}, caller: "pubsub:order-placed:other:msg1"},
+		{name: "encore_caller", uris: []string{"encore://app/prod/orders"}, caller: "encore:user"},
+		{name: "unknown_caller", uris: []string{"encore://app/prod/orders"}, caller: "other:orders"},
+		{name: "no_caller", uris: []string{"encore://app/prod/orders"}},
+		{name: "wrong_service", uris: []string{"encore://app/prod/users"}, caller: "api:orders.Create"},
+		{name: "wrong_env", uris: []string{"encore://app/staging/orders"}, caller: "api:orders.Create"},
+		{name: "no_identity", uris: []string{}, caller: "api:orders.Create"},
+		{name: "no_client_cert", caller: "api:orders.Create"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &config.MTLS{CAPEM: ca.pem}
+			cfg.CertPEM, cfg.KeyPEM = serverCert, serverKey
+			if test.uris != nil {
+				cfg.CertPEM, cfg.KeyPEM = ca.issue(t, test.uris...)
+			}
+			client, err := newMTLSAuth(clock.New(), "app", "prod", cfg, testSubscriptionService)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tlsCfg := ClientTLSConfig(map[string]ServiceAuth{"mtls": client})
+			if test.uris == nil {
+				tlsCfg.GetClientCertificate = nil
+			}
+
+			req, _ := http.NewRequest("GET", srvURL, nil)
+			if test.caller != "" {
+				req.Header.Set("X-Encore-Meta-Caller", test.caller)
+			}
+			if err := Sign(client, transport.HTTPRequest(req)); err != nil {
+				t.Fatal(err)
+			}
+			resp, err := (&http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}).Do(req)
+			if err != nil {
+				// The handshake fails without a client certificate.
+				if test.ok || test.uris != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			_ = resp.Body.Close()
+			if got := resp.StatusCode == http.StatusOK; got != test.ok || test.uris == nil {
+				t.Fatalf("got status %d, want ok=%v", resp.StatusCode, test.ok)
+			}
+		})
+	}
+}
+
+func TestMTLS_Rotation(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	write := func(modTime time.Time, uri string) {
+		certPEM, keyPEM := ca.issue(t, uri)
+		for path, data := range map[string]string{certFile: certPEM, keyFile: keyPEM} {
+			if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(path, modTime, modTime); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	start := time.Now()
+	write(start, "encore://app/prod/v1")
+	clk := clock.NewMock()
+	m, err := newMTLSAuth(clk, "app", "prod", &config.MTLS{CertFile: certFile, KeyFile: keyFile, CAPEM: ca.pem}, testSubscriptionService)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leaf := func() string {
+		cert, err := m.cert.get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, _ := x509.ParseCertificate(cert.Certificate[0])
+		return m.peerIdentity(parsed).services[0]
+	}
+
+	write(start.Add(time.Minute), "encore://app/prod/v2")
+	if got := leaf(); got != "v1" {
+		t.Fatalf("got %q before reload interval, want v1", got)
+	}
+	clk.Add(certReloadInterval)
+	if got := leaf(); got != "v2" {
+		t.Fatalf("got %q after rotation, want v2", got)
+	}
+
+	// A broken file keeps the previous certificate.
+	if err := os.WriteFile(certFile, []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	clk.Add(certReloadInterval)
+	if got := leaf(); got != "v2" {
+		t.Fatalf("got %q after failed reload, want v2", got)
+	}
+}
@@ -1,6 +1,7 @@
 package svcauth
 
 import (
+	"crypto/tls"
 	"fmt"
 
 	"github.com/benbjohnson/clock"
@@ -44,16 +45,26 @@ func Verify(req transport.Transport, loadedAuthMethods map[string]ServiceAuth) (
 }
 
 // LoadMethods loads the service to service authentication methods from the given config.
-func LoadMethods(clock clock.Clock, cfg *config.Runtime) (inbound, outbound map[string]ServiceAuth, err error) {
+func LoadMethods(clock clock.Clock, static *config.Static, cfg *config.Runtime) (inbound, outbound map[string]ServiceAuth, err error) {
 	inbound = make(map[string]ServiceAuth)
 	outbound = make(map[string]ServiceAuth)
 
+	var mtls *mtlsAuth
 	load := func(authCfg config.ServiceAuth) (ServiceAuth, error) {
 		switch authCfg.Method {
 		case "noop":
 			return &noop{}, nil
 		case "encore-auth":
 			return newEncoreAuth(clock, cfg.AppSlug, cfg.EnvName, cfg.AuthKeys), nil
+		case "mtls":
+			// Share the certificate loader between inbound and outbound use.
+			if mtls == nil {
+				var err error
+				if mtls, err = newMTLSAuth(clock, cfg.AppSlug, cfg.EnvName, cfg.MTLS, staticSubscriptionService(static)); err != nil {
+					return nil, err
+				}
+			}
+			return mtls, nil
 		default:
 			return nil, fmt.Errorf("unknown service to service authentication method: %s", authCfg.Method)
 		}
@@ -79,3 +90,37 @@ func LoadMethods(clock clock.Clock, cfg *config.Runtime) (inbound, outbound map[
 
 	return inbound, outbound, nil
 }
+
+// ServerTLSConfig returns the TLS configuration the server must use to accept
+// inbound calls using the given authentication methods, or nil if none require TLS.
+func ServerTLSConfig(inbound map[string]ServiceAuth) *tls.Config {
+	if m, ok := inbound["mtls"].(*mtlsAuth); ok {
+		return m.serverConfig()
+	}
+	return nil
+}
+
+// ClientTLSConfig returns the TLS configuration to use when making outbound calls
+// using the given authentication methods, or nil if none require TLS.
+func ClientTLSConfig(outbound map[string]ServiceAuth) *tls.Config {
+	if m, ok := outbound["mtls"].(*mtlsAuth); ok {
+		return m.clientConfig()
+	}
+	return nil
+}
+
+// staticSubscriptionService returns a function looking up the service
+// a pubsub subscription belongs to in the static config.
+func staticSubscriptionService(static *config.Static) func(topic, subscription string) (string, bool) {
+	return func(topic, subscription string) (string, bool) {
+		if static == nil {
+			return "", false
+		}
+		if t, ok := static.PubsubTopics[topic]; ok {
+			if sub, ok := t.Subscriptions[subscription]; ok {
+				return sub.Service, true
+			}
+		}
+		return "", false
+	}
+}
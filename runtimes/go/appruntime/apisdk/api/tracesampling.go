@@ -0,0 +1,73 @@
+package api
+
+import (
+	"math/rand/v2"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+)
+
+// traceSampler decides which requests to trace based on per-endpoint sampling rates.
+type traceSampler struct {
+	rules []traceSamplingRule
+}
+
+type traceSamplingRule struct {
+	ruleScope
+	rate float64
+}
+
+// newTraceSampler creates a trace sampler from the given rules.
+// It returns nil if there are no rules.
+func newTraceSampler(rules []*config.TraceSamplingRule) *traceSampler {
+	if len(rules) == 0 {
+		return nil
+	}
+	s := &traceSampler{}
+	for _, r := range rules {
+		s.rules = append(s.rules, traceSamplingRule{
+			ruleScope: ruleScope{service: r.Service, endpoint: r.Endpoint},
+			rate:      r.SampleRate,
+		})
+	}
+	return s
+}
+
+// rate returns the rate at which to sample traces of requests to desc,
+// or false if the app-wide sampling rate applies.
+//
+// A rule for the endpoint itself takes precedence over the rate declared
+// in the endpoint's API directive, which in turn takes precedence over
+// rules for the whole service or all services.
+func (s *traceSampler) rate(desc *model.RPCDesc) (rate float64, ok bool) {
+	var best *traceSamplingRule
+	bestScore := -1
+	if s != nil {
+		for i := range s.rules {
+			r := &s.rules[i]
+			if score := r.specificity(desc.Service, desc.Endpoint); score > bestScore {
+				best, bestScore = r, score
+			}
+		}
+	}
+
+	switch {
+	case bestScore == 2:
+		return best.rate, true
+	case desc.TraceSampleRate != nil:
+		return *desc.TraceSampleRate, true
+	case best != nil:
+		return best.rate, true
+	default:
+		return 0, false
+	}
+}
+
+// sampleTrace reports whether to trace a request to desc
+// that isn't part of an existing trace.
+func (s *Server) sampleTrace(desc *model.RPCDesc) bool {
+	if rate, ok := s.traceSampler.rate(desc); ok {
+		return s.tracingEnabled && rand.Float64() < rate
+	}
+	return s.rt.SampleTrace()
+}
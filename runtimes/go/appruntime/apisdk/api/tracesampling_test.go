@@ -0,0 +1,55 @@
+package api
+
+import (
+	"testing"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestTraceSampler_Rate(t *testing.T) {
+	declared := 0.5
+	s := newTraceSampler([]*config.TraceSamplingRule{
+		{SampleRate: 0.1},
+		{Service: "products", SampleRate: 0.2},
+		{Service: "products", Endpoint: "Get", SampleRate: 0.01},
+	})
+
+	tests := []struct {
+		service, endpoint string
+		declared          *float64
+		want              float64
+	}{
+		{"orders", "Create", nil, 0.1},
+		{"products", "List", nil, 0.2},
+		{"products", "Get", nil, 0.01},
+		// The declared rate overrides service-wide and global rules,
+		// but not rules for the endpoint itself.
+		{"products", "List", &declared, 0.5},
+		{"orders", "Create", &declared, 0.5},
+		{"products", "Get", &declared, 0.01},
+	}
+	for _, tt := range tests {
+		desc := &model.RPCDesc{Service: tt.service, Endpoint: tt.endpoint, TraceSampleRate: tt.declared}
+		if got, ok := s.rate(desc); !ok || got != tt.want {
+			t.Errorf("rate(%s.%s, declared=%v) = %v, %v, want %v", tt.service, tt.endpoint, tt.declared != nil, got, ok, tt.want)
+		}
+	}
+}
+
+func TestTraceSampler_NoRules(t *testing.T) {
+	s := newTraceSampler(nil)
+	if _, ok := s.rate(&model.RPCDesc{Service: "svc", Endpoint: "Get"}); ok {
+		t.Errorf("got a rate without rules or a declared rate")
+	}
+
+	declared := 0.0
+	srv := &Server{tracingEnabled: true, traceSampler: s}
+	if srv.sampleTrace(&model.RPCDesc{Service: "svc", Endpoint: "Get", TraceSampleRate: &declared}) {
+		t.Errorf("sampled a trace with a declared rate of 0")
+	}
+	declared = 1
+	if !srv.sampleTrace(&model.RPCDesc{Service: "svc", Endpoint: "Get", TraceSampleRate: &declared}) {
+		t.Errorf("did not sample a trace with a declared rate of 1")
+	}
+}
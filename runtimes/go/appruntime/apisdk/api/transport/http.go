@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"crypto/tls"
 	"net/http"
 	"sort"
 	"strings"
@@ -8,7 +9,7 @@ import (
 
 // HTTPRequest returns a Transport implementation for the given HTTP request.
 func HTTPRequest(req *http.Request) Transport {
-	return &httpHeaders{headers: req.Header}
+	return &httpHeaders{headers: req.Header, tls: req.TLS}
 }
 
 // HTTPResponse returns a Transport implementation for the given HTTP response.
@@ -26,6 +27,7 @@ func HTTPResponseWriter(w http.ResponseWriter) Transport {
 // a [http.Request] or a [http.ResponseWriter].
 type httpHeaders struct {
 	headers http.Header
+	tls     *tls.ConnectionState // nil if not an incoming TLS request
 }
 
 var _ Transport = (*httpHeaders)(nil)
@@ -80,3 +82,12 @@ func (h *httpHeaders) ListMetaKeys() []string {
 
 	return rtn
 }
+
+// TLSConnectionState returns the TLS connection state of an incoming request,
+// or nil if the request was not received over TLS.
+func TLSConnectionState(t Transport) *tls.ConnectionState {
+	if h, ok := t.(*httpHeaders); ok {
+		return h.tls
+	}
+	return nil
+}
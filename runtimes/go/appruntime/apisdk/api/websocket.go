@@ -0,0 +1,254 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	ws "nhooyr.io/websocket"
+
+	"encore.dev/appruntime/apisdk/cors"
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/beta/errs"
+)
+
+const (
+	// webSocketProtocol is the subprotocol the generated clients request.
+	webSocketProtocol = "encore-ws"
+
+	// webSocketHeadersPrefix prefixes the subprotocol the generated clients
+	// pass headers in, as browsers can't set headers on WebSocket connections.
+	// The headers are encoded as unpadded URL-safe base64 JSON.
+	webSocketHeadersPrefix = "encore.dev.headers."
+)
+
+// isWebSocketUpgrade reports whether req asks to upgrade to a WebSocket connection.
+func isWebSocketUpgrade(req *http.Request) bool {
+	return strings.EqualFold(req.Header.Get("Upgrade"), "websocket")
+}
+
+// decodeWebSocketHeaders adds the headers passed in the subprotocols of
+// a WebSocket handshake to the request, so they're available to the auth
+// handler and the request decoding like any other headers. Headers the
+// request already has, and Encore's own headers, can't be set this way.
+func decodeWebSocketHeaders(req *http.Request) error {
+	if !isWebSocketUpgrade(req) {
+		return nil
+	}
+	for _, val := range req.Header.Values("Sec-WebSocket-Protocol") {
+		for _, proto := range strings.Split(val, ",") {
+			proto = strings.TrimSpace(proto)
+			encoded, ok := strings.CutPrefix(proto, webSocketHeadersPrefix)
+			if !ok {
+				continue
+			}
+			data, err := base64.RawURLEncoding.DecodeString(encoded)
+			if err != nil {
+				return errs.B().Code(errs.InvalidArgument).Cause(err).Msg("invalid websocket headers").Err()
+			}
+			var headers map[string]string
+			if err := json.Unmarshal(data, &headers); err != nil {
+				return errs.B().Code(errs.InvalidArgument).Cause(err).Msg("invalid websocket headers").Err()
+			}
+			for k, v := range headers {
+				k = http.CanonicalHeaderKey(k)
+				if strings.HasPrefix(k, "X-Encore-") || req.Header.Get(k) != "" {
+					continue
+				}
+				req.Header.Set(k, v)
+			}
+		}
+	}
+	return nil
+}
+
+// allowWebSocketOrigin reports whether a WebSocket connection is allowed
+// from the origin of req. Browsers don't apply CORS to WebSocket connections,
+// so cross-origin connections are checked against the CORS configuration here.
+func (s *Server) allowWebSocketOrigin(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		// Not a browser.
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, req.Host) {
+		return true
+	}
+
+	corsCfg := &config.CORS{}
+	if s.runtime.CORS != nil {
+		corsCfg = s.runtime.CORS
+	}
+	opts := cors.Options(corsCfg, s.static.CORSAllowHeaders, s.static.CORSExposeHeaders)
+	return opts.AllowOriginRequestFunc(req, origin)
+}
+
+// webSocket is the connection of a request to a WebSocket endpoint.
+// It implements websocket.Socket.
+type webSocket struct {
+	c        IncomingContext
+	service  string
+	endpoint string
+
+	// ctx is the context of the connection,
+	// canceled once the handler returns.
+	ctx context.Context
+
+	conn   *ws.Conn
+	connID trace2.EventID // the WebSocketConnect event, if traced
+
+	// responded is whether a response has been written to the request,
+	// by accepting the connection or failing to.
+	responded bool
+	closed    bool
+}
+
+func newWebSocket(c IncomingContext, service, endpoint string) *webSocket {
+	return &webSocket{c: c, service: service, endpoint: endpoint}
+}
+
+// accept accepts the connection.
+func (s *webSocket) accept(ctx context.Context) error {
+	if !isWebSocketUpgrade(s.c.req) {
+		return errs.B().Code(errs.InvalidArgument).Msg("endpoint requires a websocket connection").Err()
+	}
+
+	s.responded = true
+	// The origin has already been checked by allowWebSocketOrigin.
+	conn, err := ws.Accept(s.c.w, s.c.req, &ws.AcceptOptions{
+		Subprotocols:       []string{webSocketProtocol},
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return errs.B().Code(errs.InvalidArgument).Cause(err).Msg("could not accept websocket connection").Err()
+	}
+	s.conn = conn
+	s.ctx = ctx
+
+	if curr := s.c.server.rt.Current(); curr.Trace != nil && curr.Req != nil {
+		s.connID = curr.Trace.WebSocketConnect(trace2.WebSocketConnectParams{
+			EventParams: s.eventParams(curr),
+			Protocol:    conn.Subprotocol(),
+		})
+	}
+	return nil
+}
+
+// close closes the connection once the handler has returned err.
+// Only the first call has an effect.
+func (s *webSocket) close(err error) {
+	if s.conn == nil || s.closed {
+		return
+	}
+	s.closed = true
+
+	code, reason := ws.StatusNormalClosure, ""
+	if err != nil {
+		e := errs.Convert(err).(*errs.Error)
+		code, reason = ws.StatusInternalError, closeReason(e.Message)
+		if e.Code == errs.PermissionDenied || e.Code == errs.Unauthenticated {
+			code = ws.StatusPolicyViolation
+		}
+	}
+	closeErr := s.conn.Close(code, reason)
+	if ws.CloseStatus(closeErr) != -1 {
+		// The client closed the connection first.
+		closeErr = nil
+	}
+
+	if err == nil {
+		err = closeErr
+	}
+	s.traceClose(int(code), reason, err)
+}
+
+// closeReason truncates reason to the 123 bytes a close frame allows.
+func closeReason(reason string) string {
+	const maxLen = 123
+	if len(reason) <= maxLen {
+		return reason
+	}
+	reason = reason[:maxLen]
+	for !utf8.ValidString(reason) {
+		reason = reason[:len(reason)-1]
+	}
+	return reason
+}
+
+func (s *webSocket) Read() ([]byte, error) {
+	_, data, err := s.conn.Read(s.ctx)
+	if err != nil {
+		switch ws.CloseStatus(err) {
+		case ws.StatusNormalClosure, ws.StatusGoingAway, ws.StatusNoStatusRcvd:
+			return nil, io.EOF
+		}
+		if errors.Is(err, context.Canceled) {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	s.traceMessage(true, data)
+	return data, nil
+}
+
+func (s *webSocket) Write(data []byte) error {
+	if err := s.conn.Write(s.ctx, ws.MessageText, data); err != nil {
+		return err
+	}
+	s.traceMessage(false, data)
+	return nil
+}
+
+func (s *webSocket) Marshal(v any) ([]byte, error) {
+	return s.c.server.json.Marshal(v)
+}
+
+func (s *webSocket) Unmarshal(data []byte, v any) error {
+	if err := s.c.server.json.Unmarshal(data, v); err != nil {
+		return errs.B().Code(errs.InvalidArgument).Cause(err).Msg("invalid websocket message").Err()
+	}
+	return nil
+}
+
+func (s *webSocket) traceMessage(inbound bool, data []byte) {
+	if s.connID == 0 {
+		return
+	}
+	if curr := s.c.server.rt.Current(); curr.Trace != nil && curr.Req != nil {
+		curr.Trace.WebSocketMessage(trace2.WebSocketMessageParams{
+			EventParams: s.eventParams(curr),
+			ConnID:      s.connID,
+			Service:     s.service,
+			Endpoint:    s.endpoint,
+			Inbound:     inbound,
+			Data:        data,
+		})
+	}
+}
+
+func (s *webSocket) traceClose(code int, reason string, err error) {
+	if s.connID == 0 {
+		return
+	}
+	if curr := s.c.server.rt.Current(); curr.Trace != nil && curr.Req != nil {
+		curr.Trace.WebSocketClose(trace2.WebSocketCloseParams{
+			EventParams: s.eventParams(curr),
+			ConnID:      s.connID,
+			Code:        code,
+			Reason:      reason,
+			Err:         err,
+		})
+	}
+}
+
+func (s *webSocket) eventParams(curr reqtrack.Current) trace2.EventParams {
+	return trace2.EventParams{TraceID: curr.Req.TraceID, SpanID: curr.Req.SpanID, Goid: curr.Goctr}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/base64"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDecodeWebSocketHeaders(t *testing.T) {
+	encode := func(s string) string {
+		return webSocketHeadersPrefix + base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("X-Existing", "kept")
+	req.Header.Set("Sec-WebSocket-Protocol", "encore-ws, "+encode(`{"authorization":"Bearer tok","x-existing":"replaced","x-encore-meta-svc":"spoofed"}`))
+	if err := decodeWebSocketHeaders(req); err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer tok" {
+		t.Errorf("got Authorization %q, want %q", got, "Bearer tok")
+	}
+	if got := req.Header.Get("X-Existing"); got != "kept" {
+		t.Errorf("got X-Existing %q, want %q", got, "kept")
+	}
+	if got := req.Header.Get("X-Encore-Meta-Svc"); got != "" {
+		t.Errorf("got X-Encore-Meta-Svc %q, want it unset", got)
+	}
+
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Protocol", webSocketHeadersPrefix+"!!!")
+	if err := decodeWebSocketHeaders(req); err == nil {
+		t.Error("got nil error for invalid headers, want error")
+	}
+
+	// Only WebSocket handshakes have their subprotocols decoded.
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Sec-WebSocket-Protocol", encode(`{"authorization":"Bearer tok"}`))
+	if err := decodeWebSocketHeaders(req); err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("got Authorization %q, want it unset", got)
+	}
+}
+
+func TestCloseReason(t *testing.T) {
+	if got := closeReason("short"); got != "short" {
+		t.Errorf("got %q, want %q", got, "short")
+	}
+	long := strings.Repeat("é", 100)
+	got := closeReason(long)
+	if len(got) > 123 || !utf8.ValidString(got) || !strings.HasPrefix(long, got) {
+		t.Errorf("got invalid truncated reason %q (len %d)", got, len(got))
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+// serviceRoute returns the configuration for calling the given service
+// when it's not hosted by this process.
+//
+// Services in the static service discovery map take precedence.
+// Otherwise, if xDS is configured, the service is resolved using it.
+func (s *Server) serviceRoute(service string) (config.Service, bool) {
+	if svc, found := s.runtime.ServiceDiscovery[service]; found {
+		return svc, true
+	} else if s.xds == nil {
+		return config.Service{}, false
+	}
+
+	// Services resolved using xDS are authenticated using
+	// the first service auth method, like local services.
+	svcAuth := config.ServiceAuth{Method: "noop"}
+	if len(s.runtime.ServiceAuth) > 0 {
+		svcAuth = s.runtime.ServiceAuth[0]
+	}
+	return config.Service{
+		Name:        service,
+		Protocol:    config.Http,
+		ServiceAuth: svcAuth,
+	}, true
+}
+
+// serviceBaseURL returns the base URL to make a call to the given service,
+// and a function to call with the call's result once it completes.
+func (s *Server) serviceBaseURL(ctx context.Context, service config.Service) (baseURL string, done func(error), err error) {
+	if service.URL != "" || s.xds == nil {
+		return service.URL, func(error) {}, nil
+	}
+
+	ep, err := s.xds.Pick(ctx, service.Name)
+	if err != nil {
+		return "", nil, err
+	}
+	return xdsScheme(service) + "://" + ep.Addr, ep.Done, nil
+}
+
+// xdsScheme returns the URL scheme to call a service resolved using xDS.
+// Services authenticated using mutual TLS are called over TLS,
+// in which case the endpoints must be their mutual TLS listeners.
+func xdsScheme(service config.Service) string {
+	if service.ServiceAuth.Method == "mtls" {
+		return "https"
+	}
+	return "http"
+}
@@ -11,6 +11,8 @@ import (
 
 	// Initialize the metric subsystem
 	_ "encore.dev/appruntime/infrasdk/metrics"
+	// Begin continuous profiling, if enabled
+	_ "encore.dev/appruntime/infrasdk/profiling"
 )
 
 type App struct {
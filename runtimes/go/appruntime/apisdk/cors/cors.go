@@ -50,6 +50,7 @@ func Options(cfg *config.CORS, staticAllowedHeaders, staticExposedHeaders []stri
 		"X-Request-ID",
 		"X-Correlation-ID",
 		"X-Encore-Trace-ID",
+		"traceresponse",
 	}
 	exposedHeaders = append(exposedHeaders, cfg.ExtraExposedHeaders...)
 	exposedHeaders = append(exposedHeaders, staticExposedHeaders...)
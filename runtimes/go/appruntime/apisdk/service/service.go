@@ -46,6 +46,10 @@ type Decl[T any] struct {
 	// It is 0 if Setup is nil.
 	SetupDefLoc uint32
 
+	// DependsOn are the names of the services that must be
+	// initialized before this service is set up.
+	DependsOn []string
+
 	holder InstanceHolder[T]
 }
 
@@ -59,6 +63,17 @@ func (g *Decl[T]) ServiceName() string {
 }
 
 func doSetupService[T any](mgr *Manager, decl *Decl[T], holder *InstanceHolder[T]) (err error) {
+	// Initialize the services this service depends on first.
+	// Services hosted by other processes are initialized there.
+	// The parser rejects dependency cycles, so this can't deadlock.
+	for _, dep := range decl.DependsOn {
+		if svc, ok := mgr.GetService(dep); ok {
+			if _, err := svc.GetDecl(); err != nil {
+				return errs.B().Code(errs.Internal).Msgf("service %s: dependency %s failed to initialize", decl.Service, dep).Err()
+			}
+		}
+	}
+
 	curr := mgr.rt.Current()
 	if curr.Trace != nil && curr.Req != nil && decl.SetupDefLoc != 0 {
 		eventParams := trace2.EventParams{
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -48,6 +49,7 @@ type Runtime struct {
 	DeployedAt        time.Time       `json:"deploy_time"`
 	TraceEndpoint     string          `json:"trace_endpoint,omitempty"`
 	TraceSamplingRate *float64        `json:"trace_sampling_rate,omitempty"`
+	TraceEncoding     string          `json:"trace_encoding,omitempty"` // "zstd", "gzip" or "" for uncompressed
 	AuthKeys          []EncoreAuthKey `json:"auth_keys,omitempty"`
 	CORS              *CORS           `json:"cors,omitempty"`
 	EncoreCloudAPI    *EncoreCloudAPI `json:"ec_api,omitempty"` // If nil, the app is not running in Encore Cloud
@@ -72,6 +74,30 @@ type Runtime struct {
 	// An empty slice means that no service-to-service calls can be made
 	ServiceAuth []ServiceAuth `json:"service_auth,omitempty"`
 
+	// MTLS configures the certificates used by the "mtls" service auth method.
+	MTLS *MTLS `json:"mtls,omitempty"`
+
+	// IPFilter restricts which client IP addresses may call public APIs.
+	IPFilter *IPFilter `json:"ip_filter,omitempty"`
+
+	// RateLimit limits the rate of requests to public APIs.
+	RateLimit *RateLimit `json:"rate_limit,omitempty"`
+
+	// FaultInjection injects faults into API calls and infrastructure
+	// operations, for testing how the application handles failures.
+	// If nil, no faults are injected.
+	FaultInjection *FaultInjection `json:"fault_injection,omitempty"`
+
+	// AdminBearerToken, if set, authorizes requests carrying it in the
+	// Authorization header to use the runtime's admin routes, such as
+	// for changing log levels, when self-hosting.
+	AdminBearerToken string `json:"admin_bearer_token,omitempty"`
+
+	// XDS configures resolving the endpoints of other services
+	// from an xDS management server, such as Istio or another Envoy
+	// control plane. If nil, the static ServiceDiscovery is used.
+	XDS *XDS `json:"xds,omitempty"`
+
 	// ShutdownTimeout is the duration before non-graceful shutdown is initiated,
 	// meaning connections are closed even if outstanding requests are still in flight.
 	// If zero, it shuts down immediately.
@@ -92,6 +118,86 @@ type Runtime struct {
 	// Log configuration to set for the application.
 	// If empty it defaults to "trace".
 	LogConfig string `json:"log_config"`
+
+	// LogLevels sets the log levels of specific services and packages,
+	// as a comma-separated list of rules such as "orders=debug,*=info".
+	// See ParseLogLevels for the syntax. A default level set by the
+	// rules takes precedence over LogConfig.
+	LogLevels string `json:"log_levels,omitempty"`
+
+	// LogFormat is the format to write logs in: "json", "logfmt" or "console".
+	// If empty, logs are written as JSON, or for the console if the
+	// static config says to pretty-print logs.
+	LogFormat string `json:"log_format,omitempty"`
+
+	// LogTimeFormat is the format of log timestamps: "rfc3339", "rfc3339nano",
+	// "unix", "unixms", "unixmicro", or a Go time layout.
+	// If empty, it depends on the cloud the application runs in.
+	LogTimeFormat string `json:"log_time_format,omitempty"`
+
+	// LogExport configures exporting logs written using rlog
+	// to an external backend, in addition to writing them to stderr.
+	LogExport *LogExport `json:"log_export,omitempty"`
+
+	// TraceExport configures exporting traces to an external backend,
+	// instead of streaming them to the Encore Platform.
+	TraceExport *TraceExport `json:"trace_export,omitempty"`
+
+	// TraceSamplingRules override TraceSamplingRate for requests to specific
+	// services and endpoints. For each request the most specific matching rule
+	// applies, in the same way as for IPFilter.
+	TraceSamplingRules []*TraceSamplingRule `json:"trace_sampling_rules,omitempty"`
+
+	// TraceTailSampling configures deciding which sampled traces
+	// to keep once their requests have completed.
+	TraceTailSampling *TraceTailSampling `json:"trace_tail_sampling,omitempty"`
+
+	// TraceBuffer limits the memory used to buffer each trace's data
+	// before it's sent. The limits can be overridden using the
+	// ENCORE_TRACE_BUFFER_* environment variables.
+	TraceBuffer *TraceBuffer `json:"trace_buffer,omitempty"`
+
+	// TraceFilter configures which events to leave out of traces.
+	TraceFilter *TraceFilter `json:"trace_filter,omitempty"`
+
+	// TraceGoroutineSnapshots configures capturing the stacks of the
+	// process's goroutines into the traces of slow requests.
+	TraceGoroutineSnapshots *TraceGoroutineSnapshots `json:"trace_goroutine_snapshots,omitempty"`
+
+	// TraceQueryPlans configures capturing the plans of slow database queries.
+	TraceQueryPlans *TraceQueryPlans `json:"trace_query_plans,omitempty"`
+
+	// TraceResponseHeader is the header the trace id of a request is sent
+	// back to the caller in, so it can be correlated with the trace:
+	// "encore" for X-Encore-Trace-ID, "w3c" for the W3C traceresponse
+	// header, "both", or "none". If empty it defaults to "encore".
+	TraceResponseHeader string `json:"trace_response_header,omitempty"`
+
+	// MaxMultipartSize is the largest request body in bytes accepted by
+	// APIs receiving files whose size isn't limited by maxsize rules.
+	// If zero it defaults to 64MB.
+	MaxMultipartSize int64 `json:"max_multipart_size,omitempty"`
+
+	// ContinuousProfiling configures periodically capturing CPU profiles
+	// and sending them to a profile collector.
+	ContinuousProfiling *ContinuousProfiling `json:"continuous_profiling,omitempty"`
+
+	// LogRedaction configures redacting sensitive data
+	// from logs written using rlog.
+	LogRedaction *LogRedaction `json:"log_redaction,omitempty"`
+
+	// LogErrorReport configures periodically logging a report
+	// of the most frequent errors logged using rlog.
+	LogErrorReport *LogErrorReport `json:"log_error_report,omitempty"`
+
+	// FeatureFlags overrides the values of feature flags declared
+	// using the featureflags package, keyed by flag name.
+	// Overridden flags bypass all targeting rules.
+	FeatureFlags map[string]json.RawMessage `json:"feature_flags,omitempty"`
+
+	// RemoteConfig configures config values that are sourced from
+	// remote providers at runtime, rather than from the CUE config files.
+	RemoteConfig *RemoteConfig `json:"remote_config,omitempty"`
 }
 
 // GracefulShutdownTimings defines the timings for the graceful shutdown process.
@@ -152,6 +258,10 @@ type SvcProtocol string
 
 const (
 	Http SvcProtocol = "http"
+
+	// GRPC is used for services that are called over gRPC,
+	// with requests and responses encoded as protobuf messages.
+	GRPC SvcProtocol = "grpc"
 )
 
 type ServiceAuth struct {
@@ -239,6 +349,182 @@ type EncoreCloudAPI struct {
 	AuthKeys []auth.Key `json:"auth_keys"`
 }
 
+// MTLS configures mutual TLS for service-to-service calls.
+//
+// Each certificate and key can be given either inline as PEM data or as a file path.
+// Certificates loaded from files are reloaded when the files change, which
+// allows them to be rotated by updating a mounted secret.
+type MTLS struct {
+	CertPEM  string `json:"cert_pem,omitempty"`
+	KeyPEM   string `json:"key_pem,omitempty"`
+	CertFile string `json:"cert_file,omitempty"`
+	KeyFile  string `json:"key_file,omitempty"`
+
+	// CAPEM and CAFile specify the certificate authority
+	// that peer certificates must be signed by.
+	CAPEM  string `json:"ca_pem,omitempty"`
+	CAFile string `json:"ca_file,omitempty"`
+
+	// ListenAddr is the address to accept service-to-service calls on,
+	// such as ":8443". If empty, ":8443" is used.
+	ListenAddr string `json:"listen_addr,omitempty"`
+}
+
+// IPFilter restricts access to APIs based on the client's IP address.
+type IPFilter struct {
+	// TrustedProxies are the CIDR ranges of proxies whose X-Forwarded-For
+	// header is trusted when determining the client IP address.
+	TrustedProxies []string `json:"trusted_proxies,omitempty"`
+
+	// Rules are the filtering rules. For each request the most specific
+	// matching rule applies: a rule for the endpoint, then a rule for the
+	// service, and finally a rule matching all services.
+	Rules []*IPFilterRule `json:"rules,omitempty"`
+}
+
+type IPFilterRule struct {
+	// Service and Endpoint select which APIs the rule applies to.
+	// An empty Service matches all services, and an empty Endpoint
+	// matches all endpoints in the service.
+	Service  string `json:"service,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Allow and Deny are CIDR ranges or individual IP addresses.
+	// If Allow is non-empty, only clients within an allowed range are permitted.
+	// Clients within a denied range are always rejected.
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// RateLimit limits the rate of incoming API requests.
+type RateLimit struct {
+	// CacheCluster is the Encore name of the cache cluster used to share
+	// rate limiting state between instances. If empty, each instance
+	// enforces the limits separately.
+	CacheCluster string `json:"cache_cluster,omitempty"`
+
+	// Rules are the rate limiting rules. For each request the most specific
+	// matching rule applies, in the same way as for IPFilter.
+	Rules []*RateLimitRule `json:"rules,omitempty"`
+}
+
+// RateLimitKey determines how requests are grouped for rate limiting.
+type RateLimitKey string
+
+const (
+	// RateLimitByIP limits requests per client IP address.
+	RateLimitByIP RateLimitKey = "ip"
+
+	// RateLimitByUser limits requests per authenticated user,
+	// falling back to the client IP address for unauthenticated requests.
+	RateLimitByUser RateLimitKey = "user"
+)
+
+type RateLimitRule struct {
+	// Service and Endpoint select which APIs the rule applies to.
+	// An empty Service matches all services, and an empty Endpoint
+	// matches all endpoints in the service.
+	Service  string `json:"service,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	Key    RateLimitKey  `json:"key"`
+	Limit  int           `json:"limit"`  // the number of requests allowed per window; zero means no limit
+	Window time.Duration `json:"window"` // the length of the window
+}
+
+// FaultInjection configures the faults to inject.
+type FaultInjection struct {
+	// Rules are the faults to inject. All rules matching
+	// an operation apply, in the order they are listed.
+	Rules []*FaultRule `json:"rules,omitempty"`
+}
+
+// FaultTarget is the kind of operation a fault is injected into.
+type FaultTarget string
+
+const (
+	// FaultEndpoint injects faults into the handling of incoming API requests.
+	FaultEndpoint FaultTarget = "endpoint"
+
+	// FaultCall injects faults into API calls made to other services.
+	FaultCall FaultTarget = "call"
+
+	// FaultPubSubPublish injects faults into publishing messages to topics.
+	FaultPubSubPublish FaultTarget = "pubsub_publish"
+
+	// FaultSQLDBQuery injects faults into queries to SQL databases.
+	FaultSQLDBQuery FaultTarget = "sqldb_query"
+
+	// FaultCache injects faults into cache operations.
+	FaultCache FaultTarget = "cache"
+
+	// FaultObjects injects faults into object storage operations.
+	FaultObjects FaultTarget = "objects"
+)
+
+// CanDrop reports whether the responses of the target's operations can be dropped.
+func (t FaultTarget) CanDrop() bool {
+	return t == FaultEndpoint || t == FaultCall
+}
+
+type FaultRule struct {
+	Target FaultTarget `json:"target"`
+
+	// Service and Endpoint select the APIs the rule applies to,
+	// for the endpoint and call targets. An empty Service matches
+	// all services, and an empty Endpoint all endpoints in the service.
+	Service  string `json:"service,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Resource is the name of the infrastructure resource the rule applies to:
+	// the topic for the pubsub_publish target, the database for the sqldb_query target,
+	// the cluster for the cache target and the bucket for the objects target.
+	// Empty matches all resources.
+	Resource string `json:"resource,omitempty"`
+
+	// Probability is the probability of injecting the fault into a matching
+	// operation, between 0 and 1. If nil, it's injected into every operation,
+	// while a probability of 0 disables the rule.
+	Probability *float64 `json:"probability,omitempty"`
+
+	// Latency is the delay to add before the operation.
+	Latency time.Duration `json:"latency,omitempty"`
+
+	// ErrorCode is the errs.ErrCode of the error to fail the operation with,
+	// like "unavailable". If empty, the operation isn't failed.
+	ErrorCode string `json:"error_code,omitempty"`
+
+	// Drop drops the response of the operation after it has completed.
+	// It's only supported by the targets for which CanDrop reports true.
+	Drop bool `json:"drop,omitempty"`
+}
+
+// XDS configures service discovery using an xDS management server.
+type XDS struct {
+	// ServerAddr is the address of the management server, as host:port.
+	ServerAddr string `json:"server_addr"`
+
+	// TLS specifies whether to connect to the management server using TLS.
+	TLS bool `json:"tls,omitempty"`
+
+	// NodeID and NodeCluster identify this instance to the management server.
+	NodeID      string `json:"node_id"`
+	NodeCluster string `json:"node_cluster,omitempty"`
+
+	// ClusterNames maps service names to the xDS cluster serving them.
+	// Services not listed use ClusterTemplate.
+	ClusterNames map[string]string `json:"cluster_names,omitempty"`
+
+	// ClusterTemplate is the cluster name for services not in ClusterNames,
+	// where "{service}" is replaced by the service name.
+	// If empty, the service name is used as the cluster name.
+	ClusterTemplate string `json:"cluster_template,omitempty"`
+
+	// LoadReporting specifies whether to report the load of calls
+	// to other services back to the management server.
+	LoadReporting bool `json:"load_reporting,omitempty"`
+}
+
 type EncoreAuthKey struct {
 	KeyID uint32 `json:"kid"`
 	Data  []byte `json:"data"`
@@ -353,6 +639,12 @@ type SQLDatabase struct {
 	// MaxConnections is the maximum number of open connections to use
 	// for this database. If zero it defaults to 30.
 	MaxConnections int `json:"max_connections"`
+
+	// TemplateDatabaseName is the name of a fully migrated template database
+	// for this database, set when running tests. If set, each test process
+	// creates its own database from the template on first use, so test packages
+	// running in parallel don't interfere with each other.
+	TemplateDatabaseName string `json:"template_database_name,omitempty"`
 }
 
 type RedisServer struct {
@@ -444,13 +736,50 @@ type Bucket struct {
 }
 
 type Metrics struct {
-	CollectionInterval time.Duration                  `json:"collection_interval,omitempty"`
-	EncoreCloud        *GCPCloudMonitoringProvider    `json:"encore_cloud,omitempty"`
-	CloudMonitoring    *GCPCloudMonitoringProvider    `json:"gcp_cloud_monitoring,omitempty"`
-	CloudWatch         *AWSCloudWatchMetricsProvider  `json:"aws_cloud_watch,omitempty"`
-	LogsBased          *LogsBasedMetricsProvider      `json:"logs_based,omitempty"`
-	Prometheus         *PrometheusRemoteWriteProvider `json:"prometheus,omitempty"`
-	Datadog            *DatadogProvider               `json:"datadog,omitempty"`
+	CollectionInterval    time.Duration                  `json:"collection_interval,omitempty"`
+	EncoreCloud           *GCPCloudMonitoringProvider    `json:"encore_cloud,omitempty"`
+	CloudMonitoring       *GCPCloudMonitoringProvider    `json:"gcp_cloud_monitoring,omitempty"`
+	CloudWatch            *AWSCloudWatchMetricsProvider  `json:"aws_cloud_watch,omitempty"`
+	LogsBased             *LogsBasedMetricsProvider      `json:"logs_based,omitempty"`
+	Prometheus            *PrometheusRemoteWriteProvider `json:"prometheus,omitempty"`
+	Datadog               *DatadogProvider               `json:"datadog,omitempty"`
+	PrometheusScrape      *PrometheusScrapeProvider      `json:"prometheus_scrape,omitempty"`
+	OTLP                  *OTLPMetricsProvider           `json:"otlp,omitempty"`
+	StatsD                *StatsDProvider                `json:"statsd,omitempty"`
+	PrometheusPushgateway *PrometheusPushgatewayProvider `json:"prometheus_pushgateway,omitempty"`
+	CloudWatchEMF         *CloudWatchEMFProvider         `json:"aws_cloudwatch_emf,omitempty"`
+
+	// Views modify how metrics are exported, such as by renaming them
+	// or dropping labels. For each metric the first matching view is applied.
+	Views []*MetricView `json:"views,omitempty"`
+
+	// Prefix is prepended to the names of all exported metrics, such as "myapp_".
+	// It's applied after the views, which match the unprefixed names.
+	Prefix string `json:"prefix,omitempty"`
+
+	// Labels are constant labels added to all exported metrics.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ServiceLabels are constant labels added to the metrics of specific
+	// services, keyed by service name. They take precedence over Labels.
+	ServiceLabels map[string]map[string]string `json:"service_labels,omitempty"`
+}
+
+// MetricView modifies how the metrics matching it are exported.
+type MetricView struct {
+	// Match is the name of the metrics the view applies to.
+	// It may contain "*" wildcards, such as "e_requests_*".
+	Match string `json:"match"`
+
+	// Drop, if true, excludes the matching metrics from the export.
+	Drop bool `json:"drop,omitempty"`
+
+	// Rename, if set, is the name to export the matching metrics as.
+	Rename string `json:"rename,omitempty"`
+
+	// DropLabels are the labels to remove from the matching metrics.
+	// Time series that become identical are combined by summing their values.
+	DropLabels []string `json:"drop_labels,omitempty"`
 }
 
 type GCPCloudMonitoringProvider struct {
@@ -480,6 +809,296 @@ type PrometheusRemoteWriteProvider struct {
 	RemoteWriteURL string
 }
 
+// PrometheusScrapeProvider serves metrics in the Prometheus text exposition
+// format, for Prometheus to scrape, rather than pushing them to a provider.
+type PrometheusScrapeProvider struct {
+	// ListenAddr is the address to serve metrics on, such as ":9090".
+	// It must differ from the address the app listens on.
+	ListenAddr string `json:"listen_addr"`
+
+	// Path is the HTTP path to serve metrics on.
+	// If empty it defaults to "/metrics".
+	Path string `json:"path,omitempty"`
+
+	// BearerToken, if set, is required in the Authorization header
+	// of scrape requests.
+	BearerToken string `json:"bearer_token,omitempty"`
+}
+
+// OTLPMetricsProvider exports metrics to an OpenTelemetry collector
+// using the OTLP/HTTP protocol.
+type OTLPMetricsProvider struct {
+	// Endpoint is the URL to send metrics to,
+	// such as "http://otel-collector:4318/v1/metrics".
+	Endpoint string `json:"endpoint"`
+
+	// Headers are additional HTTP headers to send with each request,
+	// such as for authentication.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// LogRedaction configures which data to redact from logs.
+type LogRedaction struct {
+	// Fields are patterns of the names of fields whose values to redact,
+	// such as "*password*". Patterns are matched case-insensitively
+	// and may contain "*" wildcards.
+	Fields []string `json:"fields,omitempty"`
+
+	// Matchers are the names of built-in matchers of sensitive values
+	// to redact from messages and string field values.
+	// The supported matchers are "email", "card_number" and "bearer_token".
+	Matchers []string `json:"matchers,omitempty"`
+
+	// Patterns are regular expressions of additional values to redact
+	// from messages and string field values.
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// LogErrorReport configures the report of the most frequent errors,
+// grouped by their error fingerprint.
+type LogErrorReport struct {
+	// Interval is how often to log the report.
+	// If zero it defaults to one minute.
+	Interval time.Duration `json:"interval,omitempty"`
+
+	// Top is the number of errors to include in the report.
+	// If zero it defaults to 10.
+	Top int `json:"top,omitempty"`
+}
+
+// LogExport configures where to export logs to.
+type LogExport struct {
+	OTLP *OTLPLogsProvider `json:"otlp,omitempty"`
+}
+
+// OTLPLogsProvider exports logs to an OpenTelemetry collector
+// using the OTLP/HTTP protocol.
+type OTLPLogsProvider struct {
+	// Endpoint is the URL to send logs to,
+	// such as "http://otel-collector:4318/v1/logs".
+	Endpoint string `json:"endpoint"`
+
+	// Headers are additional HTTP headers to send with each request,
+	// such as for authentication.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// FlushInterval is how often to send the buffered logs.
+	// If zero it defaults to 5 seconds.
+	FlushInterval time.Duration `json:"flush_interval,omitempty"`
+}
+
+type TraceSamplingRule struct {
+	// Service and Endpoint select which APIs the rule applies to.
+	// An empty Service matches all services, and an empty Endpoint
+	// matches all endpoints in the service.
+	Service  string `json:"service,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// SampleRate is the rate at which to sample traces, between [0, 1].
+	SampleRate float64 `json:"sample_rate"`
+}
+
+// TraceTailSampling configures tail-based sampling of traces, where whether
+// to keep a trace is decided once its requests have completed.
+// Traces of requests that failed or were slow are always kept.
+type TraceTailSampling struct {
+	// SampleRate is the rate at which to keep the traces of requests
+	// that neither failed nor were slow, between [0, 1].
+	SampleRate float64 `json:"sample_rate"`
+
+	// LatencyThreshold is the duration at which a request is considered slow.
+	// If zero, traces are not kept based on their latency.
+	LatencyThreshold time.Duration `json:"latency_threshold,omitempty"`
+}
+
+// TraceBuffer configures the buffering of trace data.
+type TraceBuffer struct {
+	// InitialSize is the initial capacity of a replaced buffer, in bytes.
+	// If zero it defaults to 10 MiB.
+	InitialSize int `json:"initial_size,omitempty"`
+
+	// MaxSize is the maximum size of the buffered data, in bytes.
+	// If zero the buffered data is not limited.
+	MaxSize int `json:"max_size,omitempty"`
+
+	// DropPolicy is which events to drop when the buffer is full,
+	// "drop_new" or "drop_oldest". If empty it defaults to "drop_new".
+	DropPolicy string `json:"drop_policy,omitempty"`
+
+	// SpillToDisk writes the events that don't fit in the buffer
+	// to a temporary file instead of dropping them.
+	SpillToDisk bool `json:"spill_to_disk,omitempty"`
+
+	// MaxSpillSize is the maximum size of a temporary file, in bytes.
+	// Once reached new events are dropped. If zero it defaults to 1 GiB.
+	MaxSpillSize int `json:"max_spill_size,omitempty"`
+
+	// SpillDir is the directory to write the temporary files to.
+	// If empty it defaults to the system's temporary directory.
+	SpillDir string `json:"spill_dir,omitempty"`
+}
+
+// TraceFilter configures which events to leave out of traces,
+// to reduce the volume of trace data.
+type TraceFilter struct {
+	// SuppressEvents are the categories of events to leave out,
+	// such as "cache_call" or "log_message".
+	SuppressEvents []string `json:"suppress_events,omitempty"`
+}
+
+// TraceGoroutineSnapshots configures capturing goroutine snapshots
+// into the traces of requests that are still running after a while,
+// to show where they're stuck. Capturing a snapshot briefly stops
+// the process, so it should be used with a generous threshold.
+type TraceGoroutineSnapshots struct {
+	// LatencyThreshold is how long a request must run before a snapshot is
+	// captured. If zero, the first snapshot is captured after Interval.
+	LatencyThreshold time.Duration `json:"latency_threshold,omitempty"`
+
+	// Interval is the interval at which further snapshots are captured
+	// while the request is running. If zero, at most one snapshot is captured.
+	Interval time.Duration `json:"interval,omitempty"`
+
+	// MaxStacks is the maximum number of distinct stacks in a snapshot.
+	// If zero it defaults to 100.
+	MaxStacks int `json:"max_stacks,omitempty"`
+}
+
+// TraceQueryPlans configures capturing the plans of traced database queries
+// that are slow, using EXPLAIN, into the traces of the requests running them.
+type TraceQueryPlans struct {
+	// LatencyThreshold is how long a query must take for its plan to be captured.
+	LatencyThreshold time.Duration `json:"latency_threshold"`
+}
+
+// ContinuousProfiling configures capturing CPU profiles of the process
+// at regular intervals. The samples of traced requests are labeled with
+// their trace and span ids, so the profiles can be narrowed down to a trace.
+type ContinuousProfiling struct {
+	// Endpoint is the URL of the collector to send the profiles to.
+	Endpoint string `json:"endpoint"`
+
+	// Interval is the interval at which profiles are captured.
+	// If zero it defaults to one minute.
+	Interval time.Duration `json:"interval,omitempty"`
+
+	// Duration is how long each profile is captured for.
+	// If zero it defaults to 10 seconds.
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// TraceExport configures where to export traces to.
+// Traces are exported to each of the configured destinations.
+type TraceExport struct {
+	OTLP *OTLPTraceProvider `json:"otlp,omitempty"`
+	File *FileTraceProvider `json:"file,omitempty"`
+}
+
+// OTLPTraceProvider exports traces to an OpenTelemetry collector
+// using the OTLP protocol, over either HTTP or gRPC.
+type OTLPTraceProvider struct {
+	// Endpoint is where to send traces to. For the HTTP protocol it's
+	// a URL such as "http://otel-collector:4318/v1/traces", and for
+	// the gRPC protocol an address such as "otel-collector:4317".
+	Endpoint string `json:"endpoint"`
+
+	// Protocol is the OTLP transport to use, "http" or "grpc".
+	// If empty it defaults to "http".
+	Protocol string `json:"protocol,omitempty"`
+
+	// Insecure disables TLS when using the gRPC protocol.
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Headers are additional headers (or gRPC metadata) to send
+	// with each request, such as for authentication.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// FlushInterval is how often to send the buffered spans.
+	// If zero it defaults to 5 seconds.
+	FlushInterval time.Duration `json:"flush_interval,omitempty"`
+}
+
+// FileTraceProvider appends the binary trace events to a local file,
+// for processing them with custom tooling.
+type FileTraceProvider struct {
+	// Path is the path of the file to append to.
+	Path string `json:"path"`
+}
+
+// PrometheusPushgatewayProvider pushes metrics to a Prometheus Pushgateway,
+// for instances that may terminate before they can be scraped.
+type PrometheusPushgatewayProvider struct {
+	// URL is the base URL of the Pushgateway, such as "http://pushgateway:9091".
+	URL string `json:"url"`
+
+	// Job is the job label to group the metrics by.
+	// If empty it defaults to the app slug.
+	Job string `json:"job,omitempty"`
+
+	// Headers are additional HTTP headers to send with each request,
+	// such as for authentication.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// StatsDProvider sends metrics to a StatsD agent over UDP,
+// with labels encoded as DogStatsD tags.
+type StatsDProvider struct {
+	// Addr is the host:port of the agent, such as "localhost:8125".
+	Addr string `json:"addr"`
+
+	// Prefix is prepended to the name of each metric, such as "myapp.".
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// CloudWatchEMFProvider writes metrics to stdout as log lines in the
+// CloudWatch Embedded Metric Format, which CloudWatch Logs extracts
+// metrics from. It requires the logs to be sent to CloudWatch Logs,
+// as is the default on AWS Lambda and with the awslogs log driver on ECS.
+type CloudWatchEMFProvider struct {
+	// Namespace is the CloudWatch namespace to report the metrics in.
+	Namespace string `json:"namespace"`
+}
+
+type RemoteConfig struct {
+	// CacheTTL is how long a fetched value is cached before it is refreshed.
+	// If zero it defaults to one minute.
+	CacheTTL time.Duration `json:"cache_ttl,omitempty"`
+
+	// Values are the config values to source remotely.
+	Values []*RemoteConfigValue `json:"values,omitempty"`
+}
+
+// RemoteConfigValue describes a single config value that is sourced
+// from a remote provider. Exactly one provider must be set.
+type RemoteConfigValue struct {
+	// Service is the name of the service whose config the value belongs to.
+	Service string `json:"service"`
+
+	// Path is the path to the value within the service's config,
+	// such as ["Database", "MaxConns"].
+	Path []string `json:"path"`
+
+	SSM    *SSMParameterSource `json:"ssm,omitempty"`
+	Consul *ConsulKVSource     `json:"consul,omitempty"`
+}
+
+type SSMParameterSource struct {
+	// Name is the name of the parameter in AWS Systems Manager Parameter Store.
+	Name string `json:"name"`
+	// Region is the AWS region the parameter is stored in.
+	// If empty the default region is used.
+	Region string `json:"region,omitempty"`
+}
+
+type ConsulKVSource struct {
+	// Address is the address of the Consul agent, such as "http://localhost:8500".
+	Address string `json:"address"`
+	// Key is the key in the Consul KV store.
+	Key string `json:"key"`
+	// Token is the ACL token to use, if any.
+	Token string `json:"token,omitempty"`
+}
+
 type DatadogProvider struct {
 	Site   string
 	APIKey string
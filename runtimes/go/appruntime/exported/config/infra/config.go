@@ -7,24 +7,64 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
 )
 
 type InfraConfig struct {
-	Metadata         Metadata                     `json:"metadata,omitempty"`
-	GracefulShutdown *GracefulShutdown            `json:"graceful_shutdown,omitempty"`
-	Auth             []*Auth                      `json:"auth,omitempty"`
-	ServiceDiscovery map[string]*ServiceDiscovery `json:"service_discovery,omitempty"`
-	Metrics          *Metrics                     `json:"metrics,omitempty"`
-	SQLServers       []*SQLServer                 `json:"sql_servers,omitempty"`
-	Redis            map[string]*Redis            `json:"redis,omitempty"`
-	PubSub           []*PubSub                    `json:"pubsub,omitempty"`
-	Secrets          Secrets                      `json:"secrets,omitempty"`
-	ObjectStorage    []*ObjectStorage             `json:"object_storage,omitempty"`
+	Metadata           Metadata                     `json:"metadata,omitempty"`
+	GracefulShutdown   *GracefulShutdown            `json:"graceful_shutdown,omitempty"`
+	Auth               []*Auth                      `json:"auth,omitempty"`
+	ServiceDiscovery   map[string]*ServiceDiscovery `json:"service_discovery,omitempty"`
+	Metrics            *Metrics                     `json:"metrics,omitempty"`
+	LogExport          *LogExport                   `json:"log_export,omitempty"`
+	TraceExport        *TraceExport                 `json:"trace_export,omitempty"`
+	TraceSampling      *TraceSampling               `json:"trace_sampling,omitempty"`
+	TraceSamplingRules []*TraceSamplingRule         `json:"trace_sampling_rules,omitempty"`
+	TraceBuffer        *TraceBuffer                 `json:"trace_buffer,omitempty"`
+	TraceFilter        *TraceFilter                 `json:"trace_filter,omitempty"`
+	GoroutineSnapshots *GoroutineSnapshots          `json:"goroutine_snapshots,omitempty"`
+	QueryPlans         *QueryPlans                  `json:"query_plans,omitempty"`
+	LogRedaction       *LogRedaction                `json:"log_redaction,omitempty"`
+	LogErrorReport     *LogErrorReport              `json:"log_error_report,omitempty"`
+	SQLServers         []*SQLServer                 `json:"sql_servers,omitempty"`
+	Redis              map[string]*Redis            `json:"redis,omitempty"`
+	PubSub             []*PubSub                    `json:"pubsub,omitempty"`
+	Secrets            Secrets                      `json:"secrets,omitempty"`
+	ObjectStorage      []*ObjectStorage             `json:"object_storage,omitempty"`
+	IPFilter           *IPFilter                    `json:"ip_filter,omitempty"`
+	RateLimit          *RateLimit                   `json:"rate_limit,omitempty"`
+	XDS                *XDS                         `json:"xds,omitempty"`
+	FaultInjection     *FaultInjection              `json:"fault_injection,omitempty"`
+	Admin              *Admin                       `json:"admin,omitempty"`
 
 	// Log configuration for the application.
 	// If empty it defaults to "trace".
 	LogConfig string `json:"log_config,omitemty"`
 
+	// Log levels for specific services and packages,
+	// such as "orders=debug,*=info".
+	LogLevels string `json:"log_levels,omitempty"`
+
+	// Format to write logs in: "json", "logfmt" or "console".
+	// If empty it defaults to "json".
+	LogFormat string `json:"log_format,omitempty"`
+
+	// Format of log timestamps: "rfc3339", "rfc3339nano", "unix",
+	// "unixms", "unixmicro", or a Go time layout.
+	LogTimeFormat string `json:"log_time_format,omitempty"`
+
+	// Header to send the trace id of requests back to the caller in:
+	// "encore", "w3c", "both" or "none". If empty it defaults to "encore".
+	TraceResponseHeader string `json:"trace_response_header,omitempty"`
+
+	// Largest request body in bytes accepted by APIs receiving files,
+	// unless the maxsize rules of their files allow larger ones.
+	// If zero it defaults to 64MB.
+	MaxMultipartSize int64 `json:"max_multipart_size,omitempty"`
+
 	// Number of worker threads to use for the application.
 	// If unset it defaults to a single worker thread.
 	// If set to 0 it defaults to the number of CPUs.
@@ -108,7 +148,7 @@ func (p *ObjectStorage) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON custom unmarshaller for PubSub.
 func (p *ObjectStorage) UnmarshalJSON(data []byte) error {
-	// Anonymous struct to capture the "type" field first.
+	// Anonymous struct to capture the "type" and "views" fields first.
 	var aux struct {
 		Type string `json:"type,omitempty"`
 	}
@@ -209,10 +249,154 @@ func (i *InfraConfig) Validate(v *validator) {
 	ValidateChildMap(v, "service_discovery", i.ServiceDiscovery)
 	ValidateChildList(v, "object_storage", i.ObjectStorage)
 	v.ValidateChild("metrics", i.Metrics)
+	v.ValidateChild("log_export", i.LogExport)
+	v.ValidateChild("trace_export", i.TraceExport)
+	v.ValidateChild("trace_sampling", i.TraceSampling)
+	ValidateChildList(v, "trace_sampling_rules", i.TraceSamplingRules)
+	v.ValidateChild("trace_buffer", i.TraceBuffer)
+	v.ValidateChild("trace_filter", i.TraceFilter)
+	v.ValidateChild("goroutine_snapshots", i.GoroutineSnapshots)
+	v.ValidateChild("query_plans", i.QueryPlans)
+	v.ValidateChild("log_redaction", i.LogRedaction)
+	v.ValidateChild("log_error_report", i.LogErrorReport)
+	if i.LogFormat != "" {
+		v.ValidateField("log_format", OneOf(i.LogFormat, "json", "logfmt", "console"))
+	}
+	if i.TraceResponseHeader != "" {
+		v.ValidateField("trace_response_header", OneOf(i.TraceResponseHeader, "encore", "w3c", "both", "none"))
+	}
+	v.ValidateField("max_multipart_size", GreaterOrEqual[int64](0)(i.MaxMultipartSize))
 	ValidateChildList(v, "sql_servers", i.SQLServers)
 	ValidateChildMap(v, "redis", i.Redis)
 	ValidateChildList(v, "pubsub", i.PubSub)
 	v.ValidateChild("secrets", i.Secrets)
+	v.ValidateChild("ip_filter", i.IPFilter)
+	v.ValidateChild("rate_limit", i.RateLimit)
+	v.ValidateChild("xds", i.XDS)
+	v.ValidateChild("fault_injection", i.FaultInjection)
+	v.ValidateChild("admin", i.Admin)
+}
+
+// Admin configures access to the runtime's admin routes.
+type Admin struct {
+	BearerToken *EnvString `json:"bearer_token,omitempty"`
+}
+
+func (a *Admin) Validate(v *validator) {
+	v.ValidatePtrEnvRef("bearer_token", a.BearerToken, "Admin Bearer Token", NotZero[string])
+}
+
+type IPFilter struct {
+	TrustedProxies []string        `json:"trusted_proxies,omitempty"`
+	Rules          []*IPFilterRule `json:"rules,omitempty"`
+}
+
+func (f *IPFilter) Validate(v *validator) {
+	v.ValidateField("trusted_proxies", All(f.TrustedProxies, ValidCIDR))
+	ValidateChildList(v, "rules", f.Rules)
+}
+
+type IPFilterRule struct {
+	Service  string   `json:"service,omitempty"`
+	Endpoint string   `json:"endpoint,omitempty"`
+	Allow    []string `json:"allow,omitempty"`
+	Deny     []string `json:"deny,omitempty"`
+}
+
+func (r *IPFilterRule) Validate(v *validator) {
+	if r.Endpoint != "" {
+		v.ValidateField("service", NotZero(r.Service))
+	}
+	v.ValidateField("allow", All(r.Allow, ValidCIDR))
+	v.ValidateField("deny", All(r.Deny, ValidCIDR))
+}
+
+type RateLimit struct {
+	CacheCluster string           `json:"cache_cluster,omitempty"`
+	Rules        []*RateLimitRule `json:"rules,omitempty"`
+}
+
+func (r *RateLimit) Validate(v *validator) {
+	if r.CacheCluster != "" {
+		v.ValidateField("cache_cluster", func() error {
+			if cfg := Ancestor[*InfraConfig](v); cfg != nil && cfg.Redis[r.CacheCluster] == nil {
+				return fmt.Errorf("No redis configuration found for cache cluster %q", r.CacheCluster)
+			}
+			return nil
+		})
+	}
+	ValidateChildList(v, "rules", r.Rules)
+}
+
+type RateLimitRule struct {
+	Service  string `json:"service,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+	Key      string `json:"key"`
+	Limit    int    `json:"limit"`
+	// Window is the length of the window in seconds.
+	// If unset it defaults to 60 seconds.
+	Window int `json:"window,omitempty"`
+}
+
+func (r *RateLimitRule) Validate(v *validator) {
+	if r.Endpoint != "" {
+		v.ValidateField("service", NotZero(r.Service))
+	}
+	v.ValidateField("key", OneOf(r.Key, "ip", "user"))
+	v.ValidateField("limit", GreaterOrEqual(0)(r.Limit))
+	v.ValidateField("window", GreaterOrEqual(0)(r.Window))
+}
+
+type XDS struct {
+	ServerAddr      string            `json:"server_addr"`
+	TLS             bool              `json:"tls,omitempty"`
+	NodeID          string            `json:"node_id"`
+	NodeCluster     string            `json:"node_cluster,omitempty"`
+	ClusterNames    map[string]string `json:"cluster_names,omitempty"`
+	ClusterTemplate string            `json:"cluster_template,omitempty"`
+	LoadReporting   bool              `json:"load_reporting,omitempty"`
+}
+
+func (x *XDS) Validate(v *validator) {
+	v.ValidateField("server_addr", NotZero(x.ServerAddr))
+	v.ValidateField("node_id", NotZero(x.NodeID))
+}
+
+type FaultInjection struct {
+	Rules []*FaultRule `json:"rules,omitempty"`
+}
+
+func (f *FaultInjection) Validate(v *validator) {
+	ValidateChildList(v, "rules", f.Rules)
+}
+
+type FaultRule struct {
+	Target      string   `json:"target"`
+	Service     string   `json:"service,omitempty"`
+	Endpoint    string   `json:"endpoint,omitempty"`
+	Resource    string   `json:"resource,omitempty"`
+	Probability *float64 `json:"probability,omitempty"`
+	// LatencyMs is the delay to add in milliseconds.
+	LatencyMs int    `json:"latency_ms,omitempty"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Drop      bool   `json:"drop,omitempty"`
+}
+
+func (r *FaultRule) Validate(v *validator) {
+	v.ValidateField("target", OneOf(r.Target, "endpoint", "call", "pubsub_publish", "sqldb_query", "cache", "objects"))
+	if r.Drop {
+		v.ValidateField("drop", func() error {
+			if r.Target != "endpoint" && r.Target != "call" {
+				return errors.New("Only supported for the endpoint and call targets")
+			}
+			return nil
+		})
+	}
+	if r.Endpoint != "" {
+		v.ValidateField("service", NotZero(r.Service))
+	}
+	v.ValidateField("probability", NilOr(r.Probability, Between(0.0, 1.0)))
+	v.ValidateField("latency_ms", GreaterOrEqual(0)(r.LatencyMs))
 }
 
 type Secrets struct {
@@ -286,31 +470,71 @@ type Auth struct {
 	Type string    `json:"type,omitempty"`
 	ID   int       `json:"id,omitempty"`
 	Key  EnvString `json:"key,omitempty"`
+
+	// Fields used by the "mtls" type. The certificate, key and CA are given
+	// either as PEM data (Cert, Key and CA) or as file paths.
+	Cert     EnvString `json:"cert,omitempty"`
+	CA       EnvString `json:"ca,omitempty"`
+	CertFile string    `json:"cert_file,omitempty"`
+	KeyFile  string    `json:"key_file,omitempty"`
+	CAFile   string    `json:"ca_file,omitempty"`
+
+	// ListenAddr is the address the "mtls" type accepts service-to-service calls on.
+	ListenAddr string `json:"listen_addr,omitempty"`
 }
 
 func (a *Auth) Validate(v *validator) {
-	v.ValidateField("type", OneOf(a.Type, "key"))
-	v.ValidateEnvString("key", a.Key, "Service Authorization Key", NotZero[string])
+	v.ValidateField("type", OneOf(a.Type, "key", "mtls"))
+	switch a.Type {
+	case "key":
+		v.ValidateEnvString("key", a.Key, "Service Authorization Key", NotZero[string])
+	case "mtls":
+		if a.CertFile == "" {
+			v.ValidateEnvString("cert", a.Cert, "mTLS Certificate", NotZero[string])
+		}
+		if a.KeyFile == "" {
+			v.ValidateEnvString("key", a.Key, "mTLS Private Key", NotZero[string])
+		}
+		if a.CAFile == "" {
+			v.ValidateEnvString("ca", a.CA, "mTLS Certificate Authority", NotZero[string])
+		}
+	}
 }
 
 type ServiceDiscovery struct {
 	BaseURL string  `json:"base_url,omitempty"`
 	Auth    []*Auth `json:"auth,omitempty"`
+
+	// Protocol is the protocol used to call the service,
+	// either "http" (the default) or "grpc".
+	Protocol string `json:"protocol,omitempty"`
 }
 
 func (s *ServiceDiscovery) Validate(v *validator) {
 	v.ValidateField("base_url", NotZero(s.BaseURL))
+	if s.Protocol != "" {
+		v.ValidateField("protocol", OneOf(s.Protocol, "http", "grpc"))
+	}
 	ValidateChildList(v, "auth", s.Auth)
 }
 
 // Main Metrics struct which embeds the different metric types.
 type Metrics struct {
-	Type               string `json:"type,omitempty"`
-	CollectionInterval int    `json:"collection_interval,omitempty"`
-	Prometheus         *Prometheus
-	Datadog            *Datadog
-	GCPCloudMonitoring *GCPCloudMonitoring
-	AWSCloudWatch      *AWSCloudWatch
+	Type                  string `json:"type,omitempty"`
+	CollectionInterval    int    `json:"collection_interval,omitempty"`
+	Prometheus            *Prometheus
+	Datadog               *Datadog
+	GCPCloudMonitoring    *GCPCloudMonitoring
+	AWSCloudWatch         *AWSCloudWatch
+	PrometheusScrape      *PrometheusScrape
+	OTLP                  *OTLPMetrics
+	StatsD                *StatsD
+	PrometheusPushgateway *PrometheusPushgateway
+	AWSCloudWatchEMF      *AWSCloudWatchEMF
+	Views                 []*MetricView                `json:"views,omitempty"`
+	Prefix                string                       `json:"prefix,omitempty"`
+	Labels                map[string]string            `json:"labels,omitempty"`
+	ServiceLabels         map[string]map[string]string `json:"service_labels,omitempty"`
 }
 
 // MarshalJSON custom marshaller to handle dynamic types in Metrics.
@@ -320,6 +544,18 @@ func (m *Metrics) MarshalJSON() ([]byte, error) {
 
 	data["type"] = m.Type
 	data["collection_interval"] = m.CollectionInterval
+	if len(m.Views) > 0 {
+		data["views"] = m.Views
+	}
+	if m.Prefix != "" {
+		data["prefix"] = m.Prefix
+	}
+	if len(m.Labels) > 0 {
+		data["labels"] = m.Labels
+	}
+	if len(m.ServiceLabels) > 0 {
+		data["service_labels"] = m.ServiceLabels
+	}
 
 	switch m.Type {
 	case "prometheus":
@@ -346,6 +582,36 @@ func (m *Metrics) MarshalJSON() ([]byte, error) {
 				data[k] = v
 			}
 		}
+	case "prometheus_scrape":
+		if m.PrometheusScrape != nil {
+			for k, v := range structToMap(m.PrometheusScrape) {
+				data[k] = v
+			}
+		}
+	case "otlp":
+		if m.OTLP != nil {
+			for k, v := range structToMap(m.OTLP) {
+				data[k] = v
+			}
+		}
+	case "statsd":
+		if m.StatsD != nil {
+			for k, v := range structToMap(m.StatsD) {
+				data[k] = v
+			}
+		}
+	case "prometheus_pushgateway":
+		if m.PrometheusPushgateway != nil {
+			for k, v := range structToMap(m.PrometheusPushgateway) {
+				data[k] = v
+			}
+		}
+	case "aws_cloudwatch_emf":
+		if m.AWSCloudWatchEMF != nil {
+			for k, v := range structToMap(m.AWSCloudWatchEMF) {
+				data[k] = v
+			}
+		}
 	default:
 		return nil, errors.New("unsupported metrics type")
 	}
@@ -355,16 +621,24 @@ func (m *Metrics) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON custom unmarshaller to handle dynamic types in Metrics.
 func (m *Metrics) UnmarshalJSON(data []byte) error {
-	// Anonymous struct to capture the "type" field first
+	// Anonymous struct to capture the fields common to all types first
 	var aux struct {
-		Type string `json:"type,omitempty"`
+		Type          string                       `json:"type,omitempty"`
+		Views         []*MetricView                `json:"views,omitempty"`
+		Prefix        string                       `json:"prefix,omitempty"`
+		Labels        map[string]string            `json:"labels,omitempty"`
+		ServiceLabels map[string]map[string]string `json:"service_labels,omitempty"`
 	}
 	if err := json.Unmarshal(data, &aux); err != nil {
 		return err
 	}
 
-	// Set the Type field
+	// Set the common fields
 	m.Type = aux.Type
+	m.Views = aux.Views
+	m.Prefix = aux.Prefix
+	m.Labels = aux.Labels
+	m.ServiceLabels = aux.ServiceLabels
 
 	// Unmarshal based on the "type" field
 	switch aux.Type {
@@ -392,6 +666,36 @@ func (m *Metrics) UnmarshalJSON(data []byte) error {
 			return err
 		}
 		m.AWSCloudWatch = &a
+	case "prometheus_scrape":
+		var p PrometheusScrape
+		if err := json.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		m.PrometheusScrape = &p
+	case "otlp":
+		var o OTLPMetrics
+		if err := json.Unmarshal(data, &o); err != nil {
+			return err
+		}
+		m.OTLP = &o
+	case "statsd":
+		var s StatsD
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		m.StatsD = &s
+	case "prometheus_pushgateway":
+		var p PrometheusPushgateway
+		if err := json.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		m.PrometheusPushgateway = &p
+	case "aws_cloudwatch_emf":
+		var a AWSCloudWatchEMF
+		if err := json.Unmarshal(data, &a); err != nil {
+			return err
+		}
+		m.AWSCloudWatchEMF = &a
 	default:
 		return errors.New("unsupported metrics type")
 	}
@@ -409,9 +713,72 @@ func (m *Metrics) Validate(v *validator) {
 		m.GCPCloudMonitoring.Validate(v)
 	case "aws_cloudwatch":
 		m.AWSCloudWatch.Validate(v)
+	case "prometheus_scrape":
+		m.PrometheusScrape.Validate(v)
+	case "otlp":
+		m.OTLP.Validate(v)
+	case "statsd":
+		m.StatsD.Validate(v)
+	case "prometheus_pushgateway":
+		m.PrometheusPushgateway.Validate(v)
+	case "aws_cloudwatch_emf":
+		m.AWSCloudWatchEMF.Validate(v)
 	default:
 		v.ValidateField("type", Err("unsupported metrics type"))
 	}
+	ValidateChildList(v, "views", m.Views)
+	v.ValidateField("prefix", func() error {
+		if m.Prefix != "" && !metricNameRegexp.MatchString(m.Prefix) {
+			return errors.New("Must only contain letters, digits and underscores, and not start with a digit")
+		}
+		return nil
+	})
+	validateMetricLabels(v, "labels", m.Labels)
+	for svc, labels := range m.ServiceLabels {
+		validateMetricLabels(v, "service_labels."+svc, labels)
+	}
+}
+
+var metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// validateMetricLabels validates constant metric labels.
+func validateMetricLabels(v *validator, name string, labels map[string]string) {
+	for key := range labels {
+		v.ValidateField(name+"."+key, func() error {
+			if !metricNameRegexp.MatchString(key) {
+				return errors.New("Must only contain letters, digits and underscores, and not start with a digit")
+			} else if key == "service" {
+				return errors.New("The service label is reserved")
+			}
+			return nil
+		})
+	}
+}
+
+// MetricView modifies how the metrics matching it are exported.
+type MetricView struct {
+	Match      string   `json:"match,omitempty"`
+	Drop       bool     `json:"drop,omitempty"`
+	Rename     string   `json:"rename,omitempty"`
+	DropLabels []string `json:"drop_labels,omitempty"`
+}
+
+func (mv *MetricView) Validate(v *validator) {
+	v.ValidateField("match", NotZero(mv.Match))
+	v.ValidateField("match", func() error {
+		if _, err := path.Match(mv.Match, ""); err != nil {
+			return errors.New("Invalid pattern")
+		}
+		return nil
+	})
+	if mv.Drop {
+		v.ValidateField("rename", func() error {
+			if mv.Rename != "" || len(mv.DropLabels) > 0 {
+				return errors.New("Must not be set for dropped metrics")
+			}
+			return nil
+		})
+	}
 }
 
 // Prometheus-specific metric configuration.
@@ -456,6 +823,259 @@ func (a *AWSCloudWatch) Validate(v *validator) {
 	v.ValidateField("namespace", NotZero(a.Namespace))
 }
 
+// Prometheus scrape endpoint configuration.
+type PrometheusScrape struct {
+	ListenAddr  string     `json:"listen_addr,omitempty"`
+	Path        string     `json:"path,omitempty"`
+	BearerToken *EnvString `json:"bearer_token,omitempty"`
+}
+
+func (p *PrometheusScrape) Validate(v *validator) {
+	v.ValidateField("listen_addr", NotZero(p.ListenAddr))
+	v.ValidatePtrEnvRef("bearer_token", p.BearerToken, "Prometheus Scrape Bearer Token", NotZero[string])
+}
+
+// OTLP-specific metric configuration.
+type OTLPMetrics struct {
+	Endpoint string               `json:"endpoint,omitempty"`
+	Headers  map[string]EnvString `json:"headers,omitempty"`
+}
+
+func (o *OTLPMetrics) Validate(v *validator) {
+	v.ValidateField("endpoint", NotZero(o.Endpoint))
+	for name, value := range o.Headers {
+		v.ValidateEnvString("headers."+name, value, "OTLP Header", nil)
+	}
+}
+
+// LogExport configures exporting logs to an external backend.
+type LogExport struct {
+	OTLP *OTLPLogs `json:"otlp,omitempty"`
+}
+
+func (l *LogExport) Validate(v *validator) {
+	v.ValidateChild("otlp", l.OTLP)
+}
+
+// LogRedaction configures which data to redact from logs.
+type LogRedaction struct {
+	Fields   []string `json:"fields,omitempty"`
+	Matchers []string `json:"matchers,omitempty"`
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// LogErrorReport configures periodically logging the most frequent errors.
+type LogErrorReport struct {
+	Interval int `json:"interval,omitempty"` // in seconds
+	Top      int `json:"top,omitempty"`
+}
+
+func (l *LogErrorReport) Validate(v *validator) {
+	v.ValidateField("interval", GreaterOrEqual(0)(l.Interval))
+	v.ValidateField("top", GreaterOrEqual(0)(l.Top))
+}
+
+// logRedactionMatchers are the names of the built-in redaction matchers.
+var logRedactionMatchers = []string{"email", "card_number", "bearer_token"}
+
+func (l *LogRedaction) Validate(v *validator) {
+	v.ValidateField("fields", All(l.Fields, func(f string) Predicate {
+		return func() error {
+			if _, err := path.Match(f, ""); err != nil || f == "" {
+				return fmt.Errorf("Invalid field pattern %q", f)
+			}
+			return nil
+		}
+	}))
+	v.ValidateField("matchers", All(l.Matchers, func(m string) Predicate {
+		return func() error {
+			if !slices.Contains(logRedactionMatchers, m) {
+				return fmt.Errorf("Unknown matcher %q, must be one of %s", m, strings.Join(logRedactionMatchers, ", "))
+			}
+			return nil
+		}
+	}))
+	v.ValidateField("patterns", All(l.Patterns, func(p string) Predicate {
+		return func() error {
+			if _, err := regexp.Compile(p); err != nil {
+				return fmt.Errorf("Invalid pattern %q: %v", p, err)
+			}
+			return nil
+		}
+	}))
+}
+
+// OTLPLogs exports logs to an OpenTelemetry collector.
+type OTLPLogs struct {
+	Endpoint      string               `json:"endpoint,omitempty"`
+	Headers       map[string]EnvString `json:"headers,omitempty"`
+	FlushInterval int                  `json:"flush_interval,omitempty"` // in seconds
+}
+
+func (o *OTLPLogs) Validate(v *validator) {
+	v.ValidateField("endpoint", NotZero(o.Endpoint))
+	for name, value := range o.Headers {
+		v.ValidateEnvString("headers."+name, value, "OTLP Header", nil)
+	}
+}
+
+// TraceExport configures exporting traces to an external backend.
+type TraceExport struct {
+	OTLP *OTLPTraces `json:"otlp,omitempty"`
+	File *FileTraces `json:"file,omitempty"`
+}
+
+func (t *TraceExport) Validate(v *validator) {
+	v.ValidateChild("otlp", t.OTLP)
+	v.ValidateChild("file", t.File)
+}
+
+// OTLPTraces exports traces to an OpenTelemetry collector.
+type OTLPTraces struct {
+	Endpoint      string               `json:"endpoint,omitempty"`
+	Protocol      string               `json:"protocol,omitempty"`
+	Insecure      bool                 `json:"insecure,omitempty"`
+	Headers       map[string]EnvString `json:"headers,omitempty"`
+	FlushInterval int                  `json:"flush_interval,omitempty"` // in seconds
+	SamplingRate  *float64             `json:"sampling_rate,omitempty"`
+}
+
+func (o *OTLPTraces) Validate(v *validator) {
+	v.ValidateField("endpoint", NotZero(o.Endpoint))
+	if o.Protocol != "" {
+		v.ValidateField("protocol", OneOf(o.Protocol, "http", "grpc"))
+	}
+	v.ValidateField("flush_interval", GreaterOrEqual(0)(o.FlushInterval))
+	if o.SamplingRate != nil {
+		v.ValidateField("sampling_rate", Between(0.0, 1.0)(*o.SamplingRate))
+	}
+	for name, value := range o.Headers {
+		v.ValidateEnvString("headers."+name, value, "OTLP Header", nil)
+	}
+}
+
+// FileTraces appends the binary trace events to a local file.
+type FileTraces struct {
+	Path string `json:"path,omitempty"`
+}
+
+func (f *FileTraces) Validate(v *validator) {
+	v.ValidateField("path", NotZero(f.Path))
+}
+
+// TraceSampling configures tail-based sampling of traces.
+type TraceSampling struct {
+	SampleRate         float64 `json:"sample_rate"`
+	LatencyThresholdMs int     `json:"latency_threshold_ms,omitempty"`
+}
+
+func (t *TraceSampling) Validate(v *validator) {
+	v.ValidateField("sample_rate", Between(0.0, 1.0)(t.SampleRate))
+	v.ValidateField("latency_threshold_ms", GreaterOrEqual(0)(t.LatencyThresholdMs))
+}
+
+// TraceSamplingRule sets the trace sampling rate of a service or endpoint.
+type TraceSamplingRule struct {
+	Service    string  `json:"service,omitempty"`
+	Endpoint   string  `json:"endpoint,omitempty"`
+	SampleRate float64 `json:"sample_rate"`
+}
+
+func (r *TraceSamplingRule) Validate(v *validator) {
+	if r.Endpoint != "" {
+		v.ValidateField("service", NotZero(r.Service))
+	}
+	v.ValidateField("sample_rate", Between(0.0, 1.0)(r.SampleRate))
+}
+
+// TraceBuffer limits the memory used to buffer trace data.
+type TraceBuffer struct {
+	InitialSizeBytes int    `json:"initial_size_bytes,omitempty"`
+	MaxSizeBytes     int    `json:"max_size_bytes,omitempty"`
+	DropPolicy       string `json:"drop_policy,omitempty"`
+	SpillToDisk      bool   `json:"spill_to_disk,omitempty"`
+	SpillDir         string `json:"spill_dir,omitempty"`
+	MaxSpillBytes    int    `json:"max_spill_bytes,omitempty"`
+}
+
+func (t *TraceBuffer) Validate(v *validator) {
+	v.ValidateField("initial_size_bytes", GreaterOrEqual(0)(t.InitialSizeBytes))
+	v.ValidateField("max_size_bytes", GreaterOrEqual(0)(t.MaxSizeBytes))
+	v.ValidateField("max_spill_bytes", GreaterOrEqual(0)(t.MaxSpillBytes))
+	if t.DropPolicy != "" {
+		v.ValidateField("drop_policy", OneOf(t.DropPolicy, "drop_new", "drop_oldest"))
+	}
+}
+
+// TraceFilter configures which events to leave out of traces.
+type TraceFilter struct {
+	SuppressEvents []string `json:"suppress_events,omitempty"`
+}
+
+func (t *TraceFilter) Validate(v *validator) {
+	for i, c := range t.SuppressEvents {
+		v.ValidateField(fmt.Sprintf("suppress_events[%d]", i), OneOf(c,
+			"body_stream", "bucket_op", "cache_call", "custom_event", "custom_span", "db_query",
+			"db_transaction", "goroutine_snapshot", "http_call", "log_message", "pubsub_publish",
+			"rpc_call", "service_init", "websocket"))
+	}
+}
+
+// GoroutineSnapshots configures capturing goroutine snapshots into the traces of slow requests.
+type GoroutineSnapshots struct {
+	LatencyThresholdMs int `json:"latency_threshold_ms,omitempty"`
+	IntervalMs         int `json:"interval_ms,omitempty"`
+	MaxStacks          int `json:"max_stacks,omitempty"`
+}
+
+func (g *GoroutineSnapshots) Validate(v *validator) {
+	v.ValidateField("latency_threshold_ms", GreaterOrEqual(0)(g.LatencyThresholdMs))
+	v.ValidateField("interval_ms", GreaterOrEqual(0)(g.IntervalMs))
+	v.ValidateField("max_stacks", GreaterOrEqual(0)(g.MaxStacks))
+}
+
+// QueryPlans configures capturing the plans of slow database queries into traces.
+type QueryPlans struct {
+	LatencyThresholdMs int `json:"latency_threshold_ms"`
+}
+
+func (q *QueryPlans) Validate(v *validator) {
+	v.ValidateField("latency_threshold_ms", GreaterOrEqual(1)(q.LatencyThresholdMs))
+}
+
+// StatsD-specific metric configuration.
+type StatsD struct {
+	Addr   string `json:"addr,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+func (s *StatsD) Validate(v *validator) {
+	v.ValidateField("addr", NotZero(s.Addr))
+}
+
+// Prometheus Pushgateway-specific metric configuration.
+type PrometheusPushgateway struct {
+	URL     string               `json:"url,omitempty"`
+	Job     string               `json:"job,omitempty"`
+	Headers map[string]EnvString `json:"headers,omitempty"`
+}
+
+func (p *PrometheusPushgateway) Validate(v *validator) {
+	v.ValidateField("url", NotZero(p.URL))
+	for name, value := range p.Headers {
+		v.ValidateEnvString("headers."+name, value, "Prometheus Pushgateway Header", nil)
+	}
+}
+
+// AWS CloudWatch Embedded Metric Format-specific metric configuration.
+type AWSCloudWatchEMF struct {
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (a *AWSCloudWatchEMF) Validate(v *validator) {
+	v.ValidateField("namespace", NotZero(a.Namespace))
+}
+
 type SQLServer struct {
 	Host      string                  `json:"host,omitempty"`
 	TLSConfig *TLSConfig              `json:"tls_config,omitempty"`
@@ -812,7 +1432,7 @@ func structToMap(v interface{}) map[string]interface{} {
 
 // UnmarshalJSON custom unmarshaller for PubSub.
 func (p *PubSub) UnmarshalJSON(data []byte) error {
-	// Anonymous struct to capture the "type" field first.
+	// Anonymous struct to capture the "type" and "views" fields first.
 	var aux struct {
 		Type string `json:"type,omitempty"`
 	}
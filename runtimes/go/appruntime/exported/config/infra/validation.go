@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"errors"
 	"fmt"
+	"net/netip"
 	"slices"
 
 	"github.com/modern-go/reflect2"
@@ -105,6 +106,30 @@ func GreaterOrEqual[T cmp.Ordered](than T) func(T) Predicate {
 	}
 }
 
+// All validates each value in the list using predFn.
+func All[T any](vals []T, predFn func(T) Predicate) Predicate {
+	return func() error {
+		for _, val := range vals {
+			if err := predFn(val)(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// ValidCIDR validates that s is a CIDR range or an IP address.
+func ValidCIDR(s string) Predicate {
+	return func() error {
+		if _, err := netip.ParsePrefix(s); err == nil {
+			return nil
+		} else if _, err := netip.ParseAddr(s); err == nil {
+			return nil
+		}
+		return fmt.Errorf("Invalid CIDR range or IP address %q", s)
+	}
+}
+
 type EnvDesc struct {
 	Name        string `json:"name,omitempty"`
 	Description string `json:"description,omitempty"`
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// LogLevels is a parsed log level specification.
+type LogLevels struct {
+	// Default is the level to log at when no rule matches.
+	// It's nil if the specification doesn't set it.
+	Default *zerolog.Level
+
+	// Rules are the rules for specific services and packages,
+	// in the order they were specified.
+	Rules []LogLevelRule
+}
+
+// LogLevelRule sets the minimum level of the logs
+// written by a service or package.
+type LogLevelRule struct {
+	// Name is the name of a service, or a package path pattern.
+	// Package path patterns may contain "*" wildcards, and
+	// may end in "/..." to also match all subpackages.
+	Name  string
+	Level zerolog.Level
+}
+
+// ParseLogLevels parses a log level specification: a comma-separated
+// list of "name=level" rules, such as "orders=debug,*=info".
+// A rule named "*", or a level without a name, sets the default level.
+func ParseLogLevels(spec string) (LogLevels, error) {
+	var levels LogLevels
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, levelStr, found := strings.Cut(part, "=")
+		if !found {
+			name, levelStr = "*", name
+		}
+		name, levelStr = strings.TrimSpace(name), strings.TrimSpace(levelStr)
+
+		level, err := zerolog.ParseLevel(levelStr)
+		if err != nil || levelStr == "" {
+			return LogLevels{}, fmt.Errorf("invalid log level %q for %q", levelStr, name)
+		}
+
+		switch {
+		case name == "*":
+			levels.Default = &level
+		case name == "":
+			return LogLevels{}, fmt.Errorf("missing name in log level rule %q", part)
+		default:
+			if _, err := path.Match(strings.TrimSuffix(name, "/..."), ""); err != nil {
+				return LogLevels{}, fmt.Errorf("invalid package pattern %q", name)
+			}
+			levels.Rules = append(levels.Rules, LogLevelRule{Name: name, Level: level})
+		}
+	}
+	return levels, nil
+}
+
+// MatchesPackage reports whether the rule matches the given package path.
+func (r LogLevelRule) MatchesPackage(pkgPath string) bool {
+	if prefix, ok := strings.CutSuffix(r.Name, "/..."); ok {
+		// Match the package and its subpackages by matching
+		// the prefix against the package path and its ancestors.
+		for p := pkgPath; p != "." && p != "/" && p != ""; p = path.Dir(p) {
+			if ok, _ := path.Match(prefix, p); ok {
+				return true
+			}
+		}
+		return false
+	}
+	ok, _ := path.Match(r.Name, pkgPath)
+	return ok
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rs/zerolog"
+)
+
+func TestParseLogLevels(t *testing.T) {
+	c := qt.New(t)
+
+	levels, err := ParseLogLevels(" orders=debug, encore.app/billing/...=warn ,*=info")
+	c.Assert(err, qt.IsNil)
+	c.Assert(*levels.Default, qt.Equals, zerolog.InfoLevel)
+	c.Assert(levels.Rules, qt.DeepEquals, []LogLevelRule{
+		{Name: "orders", Level: zerolog.DebugLevel},
+		{Name: "encore.app/billing/...", Level: zerolog.WarnLevel},
+	})
+
+	levels, err = ParseLogLevels("error")
+	c.Assert(err, qt.IsNil)
+	c.Assert(*levels.Default, qt.Equals, zerolog.ErrorLevel)
+	c.Assert(levels.Rules, qt.HasLen, 0)
+
+	levels, err = ParseLogLevels("")
+	c.Assert(err, qt.IsNil)
+	c.Assert(levels.Default, qt.IsNil)
+
+	_, err = ParseLogLevels("orders=loud")
+	c.Assert(err, qt.ErrorMatches, `invalid log level "loud" for "orders"`)
+	_, err = ParseLogLevels("=debug")
+	c.Assert(err, qt.ErrorMatches, `missing name in log level rule "=debug"`)
+	_, err = ParseLogLevels("orders=")
+	c.Assert(err, qt.ErrorMatches, `invalid log level "" for "orders"`)
+	_, err = ParseLogLevels("[=debug")
+	c.Assert(err, qt.ErrorMatches, `invalid package pattern "\["`)
+}
+
+func TestLogLevelRule_MatchesPackage(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		name string
+		pkg  string
+		want bool
+	}{
+		{"encore.app/orders", "encore.app/orders", true},
+		{"encore.app/orders", "encore.app/orders/db", false},
+		{"encore.app/orders/...", "encore.app/orders", true},
+		{"encore.app/orders/...", "encore.app/orders/db", true},
+		{"encore.app/orders/...", "encore.app/ordersv2", false},
+		{"encore.app/*/db", "encore.app/orders/db", true},
+		{"encore.app/*/db", "encore.app/orders/db/x", false},
+	}
+	for _, tt := range tests {
+		r := LogLevelRule{Name: tt.name}
+		c.Assert(r.MatchesPackage(tt.pkg), qt.Equals, tt.want, qt.Commentf("%s matching %s", tt.name, tt.pkg))
+	}
+}
@@ -138,6 +138,159 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 	cfg.EnvCloud = infraCfg.Metadata.Cloud
 	cfg.APIBaseURL = infraCfg.Metadata.BaseURL
 	cfg.LogConfig = infraCfg.LogConfig
+	cfg.LogLevels = infraCfg.LogLevels
+	cfg.LogFormat = infraCfg.LogFormat
+	cfg.LogTimeFormat = infraCfg.LogTimeFormat
+	if r := infraCfg.LogRedaction; r != nil {
+		cfg.LogRedaction = &LogRedaction{
+			Fields:   r.Fields,
+			Matchers: r.Matchers,
+			Patterns: r.Patterns,
+		}
+	}
+	if r := infraCfg.LogErrorReport; r != nil {
+		cfg.LogErrorReport = &LogErrorReport{
+			Interval: time.Duration(r.Interval) * time.Second,
+			Top:      r.Top,
+		}
+	}
+	if o := infraCfg.LogExport; o != nil && o.OTLP != nil {
+		cfg.LogExport = &LogExport{
+			OTLP: &OTLPLogsProvider{
+				Endpoint:      o.OTLP.Endpoint,
+				Headers:       infra.MapValues(o.OTLP.Headers, func(_ string, v infra.EnvString) string { return v.Value() }),
+				FlushInterval: time.Duration(o.OTLP.FlushInterval) * time.Second,
+			},
+		}
+	}
+
+	if o := infraCfg.TraceExport; o != nil && (o.OTLP != nil || o.File != nil) {
+		cfg.TraceExport = &TraceExport{}
+		if o.OTLP != nil {
+			cfg.TraceExport.OTLP = &OTLPTraceProvider{
+				Endpoint:      o.OTLP.Endpoint,
+				Protocol:      o.OTLP.Protocol,
+				Insecure:      o.OTLP.Insecure,
+				Headers:       infra.MapValues(o.OTLP.Headers, func(_ string, v infra.EnvString) string { return v.Value() }),
+				FlushInterval: time.Duration(o.OTLP.FlushInterval) * time.Second,
+			}
+			cfg.TraceSamplingRate = o.OTLP.SamplingRate
+		}
+		if o.File != nil {
+			cfg.TraceExport.File = &FileTraceProvider{Path: o.File.Path}
+		}
+	}
+
+	for _, rule := range infraCfg.TraceSamplingRules {
+		cfg.TraceSamplingRules = append(cfg.TraceSamplingRules, &TraceSamplingRule{
+			Service:    rule.Service,
+			Endpoint:   rule.Endpoint,
+			SampleRate: rule.SampleRate,
+		})
+	}
+
+	if s := infraCfg.TraceSampling; s != nil {
+		cfg.TraceTailSampling = &TraceTailSampling{
+			SampleRate:       s.SampleRate,
+			LatencyThreshold: time.Duration(s.LatencyThresholdMs) * time.Millisecond,
+		}
+	}
+
+	if b := infraCfg.TraceBuffer; b != nil {
+		cfg.TraceBuffer = &TraceBuffer{
+			InitialSize:  b.InitialSizeBytes,
+			MaxSize:      b.MaxSizeBytes,
+			DropPolicy:   b.DropPolicy,
+			SpillToDisk:  b.SpillToDisk,
+			SpillDir:     b.SpillDir,
+			MaxSpillSize: b.MaxSpillBytes,
+		}
+	}
+
+	if f := infraCfg.TraceFilter; f != nil {
+		cfg.TraceFilter = &TraceFilter{SuppressEvents: f.SuppressEvents}
+	}
+
+	if g := infraCfg.GoroutineSnapshots; g != nil {
+		cfg.TraceGoroutineSnapshots = &TraceGoroutineSnapshots{
+			LatencyThreshold: time.Duration(g.LatencyThresholdMs) * time.Millisecond,
+			Interval:         time.Duration(g.IntervalMs) * time.Millisecond,
+			MaxStacks:        g.MaxStacks,
+		}
+	}
+
+	if q := infraCfg.QueryPlans; q != nil {
+		cfg.TraceQueryPlans = &TraceQueryPlans{
+			LatencyThreshold: time.Duration(q.LatencyThresholdMs) * time.Millisecond,
+		}
+	}
+	cfg.TraceResponseHeader = infraCfg.TraceResponseHeader
+	cfg.MaxMultipartSize = infraCfg.MaxMultipartSize
+
+	// Map IP filtering configuration
+	if infraCfg.IPFilter != nil {
+		cfg.IPFilter = &IPFilter{TrustedProxies: infraCfg.IPFilter.TrustedProxies}
+		for _, rule := range infraCfg.IPFilter.Rules {
+			cfg.IPFilter.Rules = append(cfg.IPFilter.Rules, &IPFilterRule{
+				Service:  rule.Service,
+				Endpoint: rule.Endpoint,
+				Allow:    rule.Allow,
+				Deny:     rule.Deny,
+			})
+		}
+	}
+
+	// Map rate limiting configuration
+	if infraCfg.RateLimit != nil {
+		cfg.RateLimit = &RateLimit{CacheCluster: infraCfg.RateLimit.CacheCluster}
+		for _, rule := range infraCfg.RateLimit.Rules {
+			window := 60 * time.Second
+			if rule.Window > 0 {
+				window = time.Duration(rule.Window) * time.Second
+			}
+			cfg.RateLimit.Rules = append(cfg.RateLimit.Rules, &RateLimitRule{
+				Service:  rule.Service,
+				Endpoint: rule.Endpoint,
+				Key:      RateLimitKey(rule.Key),
+				Limit:    rule.Limit,
+				Window:   window,
+			})
+		}
+	}
+
+	// Map fault injection configuration
+	if infraCfg.FaultInjection != nil {
+		cfg.FaultInjection = &FaultInjection{}
+		for _, rule := range infraCfg.FaultInjection.Rules {
+			cfg.FaultInjection.Rules = append(cfg.FaultInjection.Rules, &FaultRule{
+				Target:      FaultTarget(rule.Target),
+				Service:     rule.Service,
+				Endpoint:    rule.Endpoint,
+				Resource:    rule.Resource,
+				Probability: rule.Probability,
+				Latency:     time.Duration(rule.LatencyMs) * time.Millisecond,
+				ErrorCode:   rule.ErrorCode,
+				Drop:        rule.Drop,
+			})
+		}
+	}
+
+	if a := infraCfg.Admin; a != nil && a.BearerToken != nil {
+		cfg.AdminBearerToken = a.BearerToken.Value()
+	}
+
+	// Map xDS service discovery configuration
+	if x := infraCfg.XDS; x != nil {
+		cfg.XDS = &XDS{
+			ServerAddr:      x.ServerAddr,
+			TLS:             x.TLS,
+			NodeID:          x.NodeID,
+			NodeCluster:     x.NodeCluster,
+			ClusterNames:    x.ClusterNames,
+			ClusterTemplate: x.ClusterTemplate,
+			LoadReporting:   x.LoadReporting,
+		}
+	}
 
 	// Map graceful shutdown configuration
 	if infraCfg.GracefulShutdown != nil {
@@ -168,6 +321,19 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 				KeyID: uint32(auth.ID),
 				Data:  []byte(auth.Key.Value()),
 			})
+		case "mtls":
+			cfg.ServiceAuth[i] = ServiceAuth{
+				Method: "mtls",
+			}
+			cfg.MTLS = &MTLS{
+				CertPEM:    auth.Cert.Value(),
+				KeyPEM:     auth.Key.Value(),
+				CAPEM:      auth.CA.Value(),
+				CertFile:   auth.CertFile,
+				KeyFile:    auth.KeyFile,
+				CAFile:     auth.CAFile,
+				ListenAddr: auth.ListenAddr,
+			}
 		default:
 			log.Fatalf("encore runtime: fatal error: unsupported auth type %q", auth.Type)
 		}
@@ -177,6 +343,17 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 	if infraCfg.Metrics != nil {
 		cfg.Metrics = &Metrics{
 			CollectionInterval: time.Duration(infraCfg.Metrics.CollectionInterval) * time.Second,
+			Prefix:             infraCfg.Metrics.Prefix,
+			Labels:             infraCfg.Metrics.Labels,
+			ServiceLabels:      infraCfg.Metrics.ServiceLabels,
+		}
+		for _, v := range infraCfg.Metrics.Views {
+			cfg.Metrics.Views = append(cfg.Metrics.Views, &MetricView{
+				Match:      v.Match,
+				Drop:       v.Drop,
+				Rename:     v.Rename,
+				DropLabels: v.DropLabels,
+			})
 		}
 		switch infraCfg.Metrics.Type {
 		case "prometheus":
@@ -207,6 +384,44 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 					infraCfg.Metrics.AWSCloudWatch.Namespace,
 				}
 			}
+		case "prometheus_scrape":
+			if p := infraCfg.Metrics.PrometheusScrape; p != nil {
+				cfg.Metrics.PrometheusScrape = &PrometheusScrapeProvider{
+					ListenAddr: p.ListenAddr,
+					Path:       p.Path,
+				}
+				if p.BearerToken != nil {
+					cfg.Metrics.PrometheusScrape.BearerToken = p.BearerToken.Value()
+				}
+			}
+		case "otlp":
+			if o := infraCfg.Metrics.OTLP; o != nil {
+				cfg.Metrics.OTLP = &OTLPMetricsProvider{
+					Endpoint: o.Endpoint,
+					Headers:  infra.MapValues(o.Headers, func(_ string, v infra.EnvString) string { return v.Value() }),
+				}
+			}
+		case "statsd":
+			if s := infraCfg.Metrics.StatsD; s != nil {
+				cfg.Metrics.StatsD = &StatsDProvider{
+					Addr:   s.Addr,
+					Prefix: s.Prefix,
+				}
+			}
+		case "prometheus_pushgateway":
+			if p := infraCfg.Metrics.PrometheusPushgateway; p != nil {
+				cfg.Metrics.PrometheusPushgateway = &PrometheusPushgatewayProvider{
+					URL:     p.URL,
+					Job:     p.Job,
+					Headers: infra.MapValues(p.Headers, func(_ string, v infra.EnvString) string { return v.Value() }),
+				}
+			}
+		case "aws_cloudwatch_emf":
+			if a := infraCfg.Metrics.AWSCloudWatchEMF; a != nil {
+				cfg.Metrics.CloudWatchEMF = &CloudWatchEMFProvider{
+					Namespace: a.Namespace,
+				}
+			}
 		}
 	}
 
@@ -356,10 +571,14 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 	// Map Service Discovery configuration
 	cfg.ServiceDiscovery = make(map[string]Service)
 	for name, service := range infraCfg.ServiceDiscovery {
+		protocol := Http
+		if service.Protocol == "grpc" {
+			protocol = GRPC
+		}
 		cfg.ServiceDiscovery[name] = Service{
 			Name:        name,
 			URL:         service.BaseURL,
-			Protocol:    Http,
+			Protocol:    protocol,
 			ServiceAuth: cfg.ServiceAuth[0],
 		}
 	}
@@ -0,0 +1,115 @@
+package model
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Baggage holds the request-scoped key-value pairs set using the baggage package,
+// which propagate to the API calls made and Pub/Sub messages published
+// while handling the request. It's safe for concurrent use.
+//
+// The methods reading the baggage can be called on a nil *Baggage.
+type Baggage struct {
+	mu   sync.RWMutex
+	vals map[string]string
+}
+
+// NewBaggage returns baggage holding a copy of vals.
+func NewBaggage(vals map[string]string) *Baggage {
+	return &Baggage{vals: maps.Clone(vals)}
+}
+
+// Get returns the value of key, if any.
+func (b *Baggage) Get(key string) (val string, ok bool) {
+	if b == nil {
+		return "", false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	val, ok = b.vals[key]
+	return val, ok
+}
+
+// Set sets the value of key.
+func (b *Baggage) Set(key, val string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.vals == nil {
+		b.vals = make(map[string]string)
+	}
+	b.vals[key] = val
+}
+
+// Delete removes key.
+func (b *Baggage) Delete(key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.vals, key)
+}
+
+// Len reports the number of keys.
+func (b *Baggage) Len() int {
+	if b == nil {
+		return 0
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.vals)
+}
+
+// All returns a copy of the key-value pairs, or nil if there are none.
+func (b *Baggage) All() map[string]string {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.vals) == 0 {
+		return nil
+	}
+	return maps.Clone(b.vals)
+}
+
+// Clone returns a copy of the baggage, which can be modified independently.
+func (b *Baggage) Clone() *Baggage {
+	return NewBaggage(b.All())
+}
+
+// EncodeBaggage encodes the key-value pairs for propagating them
+// in the format of the W3C baggage header, "key1=value1,key2=value2",
+// with the keys sorted and both keys and values percent-encoded.
+func EncodeBaggage(vals map[string]string) string {
+	var sb strings.Builder
+	for i, k := range slices.Sorted(maps.Keys(vals)) {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(url.QueryEscape(k))
+		sb.WriteByte('=')
+		sb.WriteString(url.QueryEscape(vals[k]))
+	}
+	return sb.String()
+}
+
+// ParseBaggage parses key-value pairs encoded using EncodeBaggage.
+// It reports false if s is not valid.
+func ParseBaggage(s string) (vals map[string]string, ok bool) {
+	if s == "" {
+		return nil, true
+	}
+	vals = make(map[string]string)
+	for _, member := range strings.Split(s, ",") {
+		rawKey, rawVal, found := strings.Cut(member, "=")
+		key, err1 := url.QueryUnescape(rawKey)
+		val, err2 := url.QueryUnescape(rawVal)
+		if !found || key == "" || err1 != nil || err2 != nil {
+			return nil, false
+		}
+		vals[key] = val
+	}
+	return vals, true
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodeBaggage(t *testing.T) {
+	vals := map[string]string{"tenant": "acme", "flags": "a=1,b", "empty": "", "ünicode": "ok"}
+	s := EncodeBaggage(vals)
+	if want := "empty=,flags=a%3D1%2Cb,tenant=acme,%C3%BCnicode=ok"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	got, ok := ParseBaggage(s)
+	if !ok || !reflect.DeepEqual(got, vals) {
+		t.Errorf("got (%v, %v), want %v", got, ok, vals)
+	}
+
+	for _, s := range []string{"tenant", "=acme", "a=%zz", "a=1,,b=2"} {
+		if _, ok := ParseBaggage(s); ok {
+			t.Errorf("ParseBaggage(%q) succeeded, want failure", s)
+		}
+	}
+}
@@ -2,7 +2,10 @@ package model
 
 import (
 	"context"
+	"encoding/binary"
+	"math/rand/v2"
 	"net/http"
+	"net/netip"
 	"reflect"
 	"sync"
 	"testing"
@@ -20,6 +23,7 @@ const (
 	AuthHandler   RequestType = 0x02
 	PubSubMessage RequestType = 0x03
 	Test          RequestType = 0x04
+	WorkerJob     RequestType = 0x05
 )
 
 type RPCDesc struct {
@@ -31,6 +35,10 @@ type RPCDesc struct {
 	RequestType  reflect.Type // nil if no payload
 	ResponseType reflect.Type // nil if no payload
 	Tags         []string
+
+	// TraceSampleRate is the rate at which to sample traces of requests
+	// to the endpoint, as declared in its API directive, or nil if not declared.
+	TraceSampleRate *float64
 }
 
 type PathParams []PathParam
@@ -65,8 +73,20 @@ type Request struct {
 	// Set if Type == PubSubMessage
 	MsgData *PubSubMsgData
 
+	// Set if Type == WorkerJob
+	JobData *JobData
+
 	// If we're running a test, this contains the test information.
 	Test *TestData
+
+	// Values are the request-scoped values set using the reqdata package.
+	// It's set when the request begins.
+	Values *RequestValues
+
+	// Baggage is the request-scoped baggage set using the baggage package,
+	// including the baggage propagated from the caller or publisher.
+	// It's set when the request begins.
+	Baggage *Baggage
 }
 
 // Service reports the current service, if any.
@@ -77,6 +97,8 @@ func (req *Request) Service() string {
 		return req.RPCData.Desc.Service
 	case PubSubMessage:
 		return req.MsgData.Service
+	case WorkerJob:
+		return req.JobData.Service
 	default:
 		if req.Test != nil {
 			return req.Test.Service
@@ -111,6 +133,10 @@ type RPCData struct {
 	// RequestHeaders contains the HTTP headers from the incoming request.
 	RequestHeaders http.Header
 
+	// ClientIP is the IP address of the client that made the request,
+	// taking trusted proxies into account. It is the zero value if unknown.
+	ClientIP netip.Addr
+
 	// FromEncorePlatform specifies whether the request was an
 	// authenticated request from the Encore Platform.
 	FromEncorePlatform bool
@@ -135,6 +161,17 @@ type PubSubMsgData struct {
 	Payload []byte
 }
 
+type JobData struct {
+	Service        string // the service that enqueued the job, if any
+	Queue          string
+	JobID          string
+	EnqueuedAt     time.Time
+	Attempt        int
+	DecodedPayload any
+	// Payload is the JSON-encoded payload.
+	Payload []byte
+}
+
 type TestData struct {
 	Ctx     context.Context    // The context we're running for this test
 	Cancel  context.CancelFunc // The function to cancel this tests context
@@ -173,6 +210,50 @@ type TestConfig struct {
 	APIMocks         map[string]map[string]ApiMock
 	IsolatedServices *bool                // Whether to isolate services for this test
 	EndCallbacks     []func(t *testing.T) // Callbacks to run when the test ends
+	Clock            *TestClock           // The time as set for this test, if any
+	HTTPCassette     any                  // Records or replays outbound HTTP calls, if set
+	AuthHandlerMock  any                  // Replaces the auth handler, if set
+	IDs              *TestIDs             // Generates deterministic IDs, if set
+}
+
+// TestIDs is a seeded source of random bytes, used to generate
+// deterministic IDs in tests that have set an ID seed using et.SetIDSeed.
+// It's safe for concurrent use.
+type TestIDs struct {
+	Seed uint64
+
+	mu  sync.Mutex
+	rng *rand.ChaCha8
+}
+
+// NewTestIDs returns a new source of random bytes, seeded with seed.
+func NewTestIDs(seed uint64) *TestIDs {
+	var s [32]byte
+	binary.LittleEndian.PutUint64(s[:], seed)
+	return &TestIDs{Seed: seed, rng: rand.NewChaCha8(s)}
+}
+
+// Read fills p with bytes from the seeded sequence. It never returns an error.
+func (ids *TestIDs) Read(p []byte) (int, error) {
+	ids.mu.Lock()
+	defer ids.mu.Unlock()
+	return ids.rng.Read(p)
+}
+
+// TestClock is the time as set by a test using et.SetTime or et.AdvanceTime.
+// It's immutable; changing the time replaces it.
+type TestClock struct {
+	Frozen bool          // Whether the time is frozen at Time
+	Time   time.Time     // The time, if frozen
+	Offset time.Duration // The offset from the actual time, if not frozen
+}
+
+// Now returns the current time according to the clock.
+func (c *TestClock) Now() time.Time {
+	if c.Frozen {
+		return c.Time
+	}
+	return time.Now().Add(c.Offset)
 }
 
 type ServiceMock struct {
@@ -249,6 +330,55 @@ type UID string
 type AuthInfo struct {
 	UID      UID
 	UserData any
+
+	// Values are the request-scoped values set by the auth handler,
+	// which carry over to the request being authenticated.
+	Values *RequestValues
+
+	// Baggage is the baggage of the auth handler's request,
+	// which carries over to the request being authenticated.
+	Baggage *Baggage
+}
+
+// RequestValues holds request-scoped values, keyed by their type.
+// It's safe for concurrent use.
+type RequestValues struct {
+	mu   sync.RWMutex
+	vals map[reflect.Type]any
+}
+
+// Get returns the value of the given type, if any.
+func (v *RequestValues) Get(typ reflect.Type) (val any, ok bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	val, ok = v.vals[typ]
+	return val, ok
+}
+
+// Set sets the value of the given type.
+func (v *RequestValues) Set(typ reflect.Type, val any) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.vals == nil {
+		v.vals = make(map[reflect.Type]any)
+	}
+	v.vals[typ] = val
+}
+
+// LoadOrStore returns the existing value of the given type if present.
+// Otherwise it stores and returns val. The loaded result is true
+// if the value was loaded, false if stored.
+func (v *RequestValues) LoadOrStore(typ reflect.Type, val any) (actual any, loaded bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if existing, ok := v.vals[typ]; ok {
+		return existing, true
+	}
+	if v.vals == nil {
+		v.vals = make(map[reflect.Type]any)
+	}
+	v.vals[typ] = val
+	return val, false
 }
 
 type LogLevel byte
@@ -3,6 +3,8 @@ package model
 import (
 	"crypto/rand"
 	"encoding/base32"
+	"fmt"
+	"io"
 	"testing"
 	_ "unsafe"
 )
@@ -42,14 +44,22 @@ var b32 = base32.NewEncoding(encodeHex).WithPadding(base32.NoPadding)
 // to always generate the constant {0, 0, 0, ..., 1} byte sequence for testing.
 var GenerateConstantValsForTests = false
 
+// IDRand is the source of random bytes used by GenTraceID and GenSpanID.
+// It's replaced when running tests, so tests can generate deterministic IDs.
+var IDRand io.Reader = rand.Reader
+
 // GenTraceID generates a new trace id.
 func GenTraceID() (TraceID, error) {
 	if GenerateConstantValsForTests {
 		return TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, nil
 	}
+	return ReadTraceID(IDRand)
+}
 
+// ReadTraceID generates a new trace id using random bytes read from r.
+func ReadTraceID(r io.Reader) (TraceID, error) {
 	var traceID TraceID
-	_, err := rand.Read(traceID[:])
+	_, err := io.ReadFull(r, traceID[:])
 	return traceID, err
 }
 
@@ -65,12 +75,26 @@ func GenSpanID() (SpanID, error) {
 	if GenerateConstantValsForTests {
 		return SpanID{0, 0, 0, 0, 0, 0, 0, 1}, nil
 	}
+	return ReadSpanID(IDRand)
+}
 
+// ReadSpanID generates a span id using random bytes read from r.
+func ReadSpanID(r io.Reader) (SpanID, error) {
 	var span SpanID
-	_, err := rand.Read(span[:])
+	_, err := io.ReadFull(r, span[:])
 	return span, err
 }
 
+// ParseSpanID parses the string form of a span id, as returned by SpanID.String.
+func ParseSpanID(str string) (SpanID, error) {
+	var spanID SpanID
+	if b32.DecodedLen(len(str)) != len(spanID) {
+		return spanID, fmt.Errorf("invalid span id %q", str)
+	}
+	_, err := b32.Decode(spanID[:], []byte(str))
+	return spanID, err
+}
+
 // EnableTestMode enables generation of sequential trace/span ids for the duration of the test.
 func EnableTestMode(t *testing.T) {
 	GenerateConstantValsForTests = true
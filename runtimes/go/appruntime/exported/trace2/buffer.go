@@ -0,0 +1,119 @@
+package trace2
+
+import "encoding/binary"
+
+// DropPolicy determines which events are dropped
+// when a log's buffer is full.
+type DropPolicy string
+
+const (
+	// DropNew drops the events added while the buffer is full.
+	DropNew DropPolicy = "drop_new"
+	// DropOldest drops the oldest buffered events to make room for new ones.
+	DropOldest DropPolicy = "drop_oldest"
+)
+
+const (
+	defaultMaxBufferSize     = 100 << 20 // 100 MiB
+	defaultInitialBufferSize = 10 << 20  // 10 MiB
+)
+
+// BufferLimits configures how much memory a log uses
+// to buffer trace data that has yet to be read.
+type BufferLimits struct {
+	// InitialSize is the capacity to allocate when the buffer is replaced,
+	// after having grown beyond MaxSize. If zero it defaults to 10 MiB.
+	InitialSize int
+
+	// MaxSize is the maximum size of the buffered data. If zero, the buffered
+	// data is not limited, and the buffer is replaced once its capacity
+	// exceeds 100 MiB to allow the memory to be reclaimed.
+	MaxSize int
+
+	// DropPolicy is the policy for dropping events when adding one would
+	// make the buffered data exceed MaxSize. If empty it defaults to DropNew.
+	DropPolicy DropPolicy
+
+	// SpillToDisk, if true, writes the events that would make the buffered
+	// data exceed MaxSize to a temporary file instead of dropping them.
+	// Events are only dropped if the file cannot be written or would
+	// exceed MaxSpillSize.
+	SpillToDisk bool
+
+	// MaxSpillSize is the maximum size of the temporary file, which is
+	// only removed once all its events have been read. Once reached, new
+	// events are dropped until then. If zero it defaults to 1 GiB.
+	MaxSpillSize int
+
+	// SpillDir is the directory to create the temporary file in.
+	// If empty it defaults to os.TempDir().
+	SpillDir string
+}
+
+// WithBufferLimits sets the buffer limits of the log and returns it.
+// It must be called before any events are added.
+func (l *Log) WithBufferLimits(limits BufferLimits) *Log {
+	l.limits = limits
+	return l
+}
+
+// appendLocked appends one or more events to the buffered data,
+// dropping events according to the buffer limits.
+// l.mu must be held.
+func (l *Log) appendLocked(events []byte) {
+	if l.spill != nil {
+		// Keep spilling until the spilled events have been read,
+		// so that events are read in the order they were added.
+		if !l.spillLocked(events) {
+			l.droppedLocked(countEvents(events))
+		}
+		return
+	}
+
+	maxSize := l.limits.MaxSize
+	if maxSize <= 0 || len(l.data)+len(events) <= maxSize {
+		l.growLocked(events)
+		return
+	} else if l.limits.SpillToDisk && l.spillLocked(events) {
+		return
+	}
+
+	switch l.limits.DropPolicy {
+	case DropOldest:
+		l.growLocked(events)
+		// Drop whole events from the front until the data fits.
+		var dropped uint64
+		for len(l.data) > maxSize {
+			n := min(eventHeaderSize+int(binary.LittleEndian.Uint32(l.data[eventHeaderSize-4:])), len(l.data))
+			l.data = l.data[n:]
+			stats.bytesBuffered.Add(-int64(n))
+			dropped++
+		}
+		l.droppedLocked(dropped)
+	default:
+		// The new events are discarded.
+		l.droppedLocked(countEvents(events))
+	}
+}
+
+// growLocked appends events to the buffered data.
+// l.mu must be held.
+func (l *Log) growLocked(events []byte) {
+	prevCap := cap(l.data)
+	l.data = append(l.data, events...)
+	if cap(l.data) != prevCap {
+		stats.bufferReallocs.Add(1)
+	}
+	stats.bytesBuffered.Add(int64(len(events)))
+}
+
+// droppedLocked records that n events have been dropped,
+// reporting it the first time it happens.
+// l.mu must be held.
+func (l *Log) droppedLocked(n uint64) {
+	stats.eventsDropped.Add(n)
+	if !l.droppedEvents {
+		l.droppedEvents = true
+		println("encore.traceEvent: trace buffer full, dropping events")
+	}
+}
@@ -0,0 +1,150 @@
+package trace2
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestLog_BufferLimits(t *testing.T) {
+	// Each event is the header followed by 10 bytes of data,
+	// so the buffer fits three of them.
+	const eventSize = eventHeaderSize + 10
+	tests := []struct {
+		policy DropPolicy
+		want   []byte // the first data byte of the buffered events
+	}{
+		{policy: DropNew, want: []byte{0, 1, 2}},
+		{policy: DropOldest, want: []byte{2, 3, 4}},
+		{policy: "", want: []byte{0, 1, 2}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.policy), func(t *testing.T) {
+			log := NewLog().WithBufferLimits(BufferLimits{
+				MaxSize:    3*eventSize + eventSize/2,
+				DropPolicy: test.policy,
+			})
+			before := ReadStats()
+			for i := range 5 {
+				buf := NewEventBuffer(10)
+				buf.Byte(byte(i))
+				buf.Bytes(make([]byte, 9))
+				log.Add(Event{Type: LogMessage, TraceID: model.TraceID{1}, Data: buf})
+			}
+
+			after := ReadStats()
+			if written, dropped := after.EventsWritten-before.EventsWritten, after.EventsDropped-before.EventsDropped; written != 5 || dropped != 2 {
+				t.Errorf("got stats of %d events written and %d dropped, want 5 and 2", written, dropped)
+			}
+
+			data, _ := log.GetAndClear()
+			events, rest := readEvents(data)
+			if len(rest) != 0 {
+				t.Fatalf("got %d bytes of incomplete events", len(rest))
+			}
+			var got []byte
+			for _, ev := range events {
+				got = append(got, ev.Data[0])
+			}
+			if string(got) != string(test.want) {
+				t.Errorf("got events %v, want %v", got, test.want)
+			}
+
+			// Reading the data makes room for new events.
+			log.Add(Event{Type: LogMessage, TraceID: model.TraceID{1}})
+			if data, _ := log.GetAndClear(); len(data) != eventHeaderSize {
+				t.Errorf("got %d bytes after clearing, want %d", len(data), eventHeaderSize)
+			} else if ln := binary.LittleEndian.Uint32(data[eventHeaderSize-4:]); ln != 0 {
+				t.Errorf("got event data length %d, want 0", ln)
+			}
+		})
+	}
+}
+
+func TestLog_SpillToDisk(t *testing.T) {
+	const eventSize = eventHeaderSize + 10
+	dir := t.TempDir()
+	log := NewLog().WithBufferLimits(BufferLimits{
+		MaxSize:     2 * eventSize,
+		SpillToDisk: true,
+		SpillDir:    dir,
+	})
+	addEvent := func(i int) {
+		buf := NewEventBuffer(10)
+		buf.Byte(byte(i))
+		buf.Bytes(make([]byte, 9))
+		log.Add(Event{Type: LogMessage, TraceID: model.TraceID{1}, Data: buf})
+	}
+
+	for i := range 5 {
+		addEvent(i)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Fatalf("got %d spill files, want 1", len(entries))
+	}
+
+	// The in-memory events are read first, and events added
+	// while the spilled ones are being read are kept in order.
+	var data []byte
+	chunk, done := log.GetAndClear()
+	data = append(data, chunk...)
+	addEvent(5)
+	log.MarkDone()
+	for !done {
+		chunk, done = log.WaitAndClear()
+		if len(chunk) > 2*eventSize {
+			t.Errorf("got %d bytes, want at most %d", len(chunk), 2*eventSize)
+		}
+		data = append(data, chunk...)
+	}
+
+	events, rest := readEvents(data)
+	if len(rest) != 0 {
+		t.Fatalf("got %d bytes of incomplete events", len(rest))
+	}
+	var got []byte
+	for _, ev := range events {
+		got = append(got, ev.Data[0])
+	}
+	if want := []byte{0, 1, 2, 3, 4, 5}; string(got) != string(want) {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("got %d spill files after reading the log, want none", len(entries))
+	}
+}
+
+func TestLog_MaxSpillSize(t *testing.T) {
+	const eventSize = eventHeaderSize + 10
+	log := NewLog().WithBufferLimits(BufferLimits{
+		MaxSize:      eventSize,
+		SpillToDisk:  true,
+		SpillDir:     t.TempDir(),
+		MaxSpillSize: 2 * eventSize,
+	})
+	for i := range 5 {
+		buf := NewEventBuffer(10)
+		buf.Byte(byte(i))
+		buf.Bytes(make([]byte, 9))
+		log.Add(Event{Type: LogMessage, TraceID: model.TraceID{1}, Data: buf})
+	}
+	log.MarkDone()
+
+	// One event fits in memory and two in the spill file; the rest are dropped.
+	var data []byte
+	for done := false; !done; {
+		var chunk []byte
+		chunk, done = log.WaitAndClear()
+		data = append(data, chunk...)
+	}
+	events, _ := readEvents(data)
+	var got []byte
+	for _, ev := range events {
+		got = append(got, ev.Data[0])
+	}
+	if want := []byte{0, 1, 2}; string(got) != string(want) {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+// Package decoder decodes the binary trace data recorded by trace2.Log
+// into typed events, for building custom tooling on top of Encore's traces.
+//
+// The data must be unencoded: data compressed using a trace2.Encoding
+// can be decompressed using trace2.NewDecoder first.
+//
+// Only the current version of the trace format, trace2.CurrentVersion, is supported.
+package decoder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+)
+
+// headerSize is the size of the header preceding each event's data.
+// See (*trace2.Log).Add for the layout.
+const headerSize = 1 + 8 + 8 + 16 + 8 + 4
+
+// Event is a decoded trace event.
+type Event struct {
+	Type trace2.EventType
+	ID   trace2.EventID
+
+	// Nanotime is the monotonic time the event was recorded at, in nanoseconds.
+	// It can be converted to wall clock time using the trace's trace2.TimeAnchor.
+	Nanotime int64
+
+	TraceID model.TraceID
+	SpanID  model.SpanID
+
+	// Data is the decoded event data: a pointer to the struct in this package
+	// named after the event type, such as *RequestSpanStart for
+	// trace2.RequestSpanStart events. It's nil for unknown event types.
+	Data any
+}
+
+// Decoder decodes events from a stream of trace data.
+type Decoder struct {
+	r   io.Reader
+	hdr [headerSize]byte
+}
+
+// NewDecoder returns a decoder reading trace data from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Next decodes the next event. It returns io.EOF once all events have been
+// decoded, and io.ErrUnexpectedEOF if the data ends partway through an event.
+//
+// Events of unknown types are returned without data, so that data recorded by
+// newer versions of the runtime can still be decoded in part.
+func (d *Decoder) Next() (*Event, error) {
+	if _, err := io.ReadFull(d.r, d.hdr[:]); err != nil {
+		return nil, err
+	}
+	ev := &Event{
+		Type:     trace2.EventType(d.hdr[0]),
+		ID:       trace2.EventID(binary.LittleEndian.Uint64(d.hdr[1:])),
+		Nanotime: unsignedToSigned(binary.LittleEndian.Uint64(d.hdr[9:])),
+	}
+	copy(ev.TraceID[:], d.hdr[17:33])
+	copy(ev.SpanID[:], d.hdr[33:41])
+
+	data := make([]byte, binary.LittleEndian.Uint32(d.hdr[41:]))
+	if _, err := io.ReadFull(d.r, data); err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return nil, err
+	}
+
+	r := &reader{buf: data}
+	ev.Data = decodeData(ev.Type, r)
+	if r.err != nil {
+		return nil, fmt.Errorf("decoder: decode %s event: %v", ev.Type, r.err)
+	}
+	return ev, nil
+}
+
+// DecodeAll decodes all the events in data.
+func DecodeAll(data []byte) ([]*Event, error) {
+	var events []*Event
+	d := NewDecoder(bytes.NewReader(data))
+	for {
+		ev, err := d.Next()
+		if err == io.EOF {
+			return events, nil
+		} else if err != nil {
+			return events, err
+		}
+		events = append(events, ev)
+	}
+}
@@ -0,0 +1,228 @@
+package decoder
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+)
+
+func TestDecodeAll(t *testing.T) {
+	traceID, spanID := model.TraceID{1, 2, 3}, model.SpanID{4, 5}
+	parentSpanID := model.SpanID{6}
+	ep := trace2.EventParams{TraceID: traceID, SpanID: spanID, Goid: 3}
+	req := &model.Request{
+		TraceID:          traceID,
+		SpanID:           spanID,
+		ParentTraceID:    traceID,
+		ParentSpanID:     parentSpanID,
+		ExtCorrelationID: "corr",
+		RPCData: &model.RPCData{
+			Desc:          &model.RPCDesc{Service: "users", Endpoint: "Get"},
+			HTTPMethod:    "GET",
+			Path:          "/users/1",
+			PathParams:    model.PathParams{{Name: "id", Value: "1"}},
+			NonRawPayload: []byte(`{"id":1}`),
+			UserID:        "uid",
+		},
+		Baggage: model.NewBaggage(map[string]string{"tenant": "acme"}),
+	}
+
+	log := trace2.NewLog()
+	log.RequestSpanStart(req, 1)
+	queryID := log.DBQueryStart(trace2.DBQueryStartParams{EventParams: ep, Query: "SELECT 1"})
+	log.DBQueryEnd(ep, queryID, errors.New("no rows"))
+	log.DBQueryPlan(trace2.DBQueryPlanParams{EventParams: ep, QueryID: queryID, Plan: []byte(`[{"Plan":{}}]`)})
+	log.LogMessage(trace2.LogMessageParams{
+		EventParams: ep,
+		Level:       model.LevelWarn,
+		Msg:         "user not found",
+		Fields: []trace2.LogField{
+			{Key: "id", Value: 1},
+			{Key: "name", Value: "alice"},
+			{Key: "took", Value: time.Second},
+			{Key: "tags", Value: []string{"a"}},
+		},
+	})
+	log.CacheCallStart(trace2.CacheCallStartParams{EventParams: ep, Operation: "get", Keys: []string{"k1", "k2"}})
+	log.SpanLink(trace2.SpanLinkParams{EventParams: ep, LinkedTraceID: model.TraceID{7}, LinkedSpanID: model.SpanID{8}})
+	log.GoroutineSnapshot(trace2.GoroutineSnapshotParams{EventParams: ep, Elapsed: time.Second, Total: 3, Stacks: []trace2.GoroutineStack{{Count: 2}}})
+	connID := log.WebSocketConnect(trace2.WebSocketConnectParams{EventParams: ep, Protocol: "encore-ws"})
+	log.WebSocketMessage(trace2.WebSocketMessageParams{EventParams: ep, ConnID: connID, Inbound: true, Data: []byte(`{"text":"hi"}`)})
+	log.WebSocketClose(trace2.WebSocketCloseParams{EventParams: ep, ConnID: connID, Code: 1000, Reason: "bye"})
+	log.FaultInjected(trace2.FaultInjectedParams{EventParams: ep, Target: "cache", Resource: "sessions", Latency: time.Second, ErrorCode: "unavailable"})
+	log.SecretRead(trace2.SecretReadParams{EventParams: ep, Service: "svc", Endpoint: "Charge", Secret: "Token"})
+	log.RequestSpanEnd(trace2.RequestSpanEndParams{
+		EventParams: ep,
+		Req:         req,
+		Resp:        &model.Response{HTTPStatus: 404, Duration: time.Millisecond, Err: errors.New("not found")},
+	})
+	data, _ := log.GetAndClear()
+
+	events, err := DecodeAll(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []trace2.EventType
+	for _, ev := range events {
+		types = append(types, ev.Type)
+		if ev.TraceID != traceID || ev.SpanID != spanID {
+			t.Errorf("%s event: got trace %v span %v, want %v %v", ev.Type, ev.TraceID, ev.SpanID, traceID, spanID)
+		}
+	}
+	wantTypes := []trace2.EventType{trace2.RequestSpanStart, trace2.DBQueryStart, trace2.DBQueryEnd, trace2.DBQueryPlan, trace2.LogMessage, trace2.CacheCallStart, trace2.SpanLink, trace2.GoroutineSnapshot, trace2.WebSocketConnect, trace2.WebSocketMessage, trace2.WebSocketClose, trace2.FaultInjected, trace2.SecretRead, trace2.RequestSpanEnd}
+	if diff := cmp.Diff(wantTypes, types); diff != "" {
+		t.Fatalf("event types mismatch (-want +got):\n%s", diff)
+	}
+
+	want := []any{
+		&RequestSpanStart{
+			SpanStart: SpanStart{
+				Goid:             1,
+				ParentTraceID:    traceID,
+				ParentSpanID:     parentSpanID,
+				ExtCorrelationID: "corr",
+			},
+			Service:    "users",
+			Endpoint:   "Get",
+			HTTPMethod: "GET",
+			Path:       "/users/1",
+			PathParams: []string{"1"},
+			Payload:    []byte(`{"id":1}`),
+			UserID:     "uid",
+		},
+		&DBQueryStart{SpanEvent: SpanEvent{Goid: 3}, Query: "SELECT 1"},
+		&DBQueryEnd{SpanEvent: SpanEvent{Goid: 3, CorrelationEventID: queryID}, Err: &Error{Msg: "no rows"}},
+		&DBQueryPlan{SpanEvent: SpanEvent{Goid: 3, CorrelationEventID: queryID}, Plan: json.RawMessage(`[{"Plan":{}}]`)},
+		&LogMessage{
+			SpanEvent: SpanEvent{Goid: 3},
+			Level:     model.LevelWarn,
+			Msg:       "user not found",
+			Fields: []LogField{
+				{Key: "id", Value: int64(1)},
+				{Key: "name", Value: "alice"},
+				{Key: "took", Value: time.Second},
+				{Key: "tags", Value: json.RawMessage(`["a"]`)},
+			},
+		},
+		&CacheCallStart{SpanEvent: SpanEvent{Goid: 3}, Operation: "get", Keys: []string{"k1", "k2"}},
+		&SpanLink{SpanEvent: SpanEvent{Goid: 3}, LinkedTraceID: model.TraceID{7}, LinkedSpanID: model.SpanID{8}},
+		&GoroutineSnapshot{SpanEvent: SpanEvent{Goid: 3}, Elapsed: time.Second, Total: 3, Stacks: []GoroutineStack{{Count: 2}}},
+		&WebSocketConnect{SpanEvent: SpanEvent{Goid: 3}, Protocol: "encore-ws"},
+		&WebSocketMessage{SpanEvent: SpanEvent{Goid: 3, CorrelationEventID: connID}, Inbound: true, Data: []byte(`{"text":"hi"}`)},
+		&WebSocketClose{SpanEvent: SpanEvent{Goid: 3, CorrelationEventID: connID}, Code: 1000, Reason: "bye"},
+		&FaultInjected{SpanEvent: SpanEvent{Goid: 3}, Target: "cache", Resource: "sessions", Latency: time.Second, ErrorCode: "unavailable"},
+		&SecretRead{SpanEvent: SpanEvent{Goid: 3}, Service: "svc", Endpoint: "Charge", Secret: "Token"},
+		&RequestSpanEnd{
+			SpanEnd: SpanEnd{
+				Duration:      time.Millisecond,
+				Err:           &Error{Msg: "not found"},
+				ParentTraceID: traceID,
+				ParentSpanID:  parentSpanID,
+				Baggage:       map[string]string{"tenant": "acme"},
+			},
+			Service:    "users",
+			Endpoint:   "Get",
+			HTTPStatus: 404,
+		},
+	}
+	for i, ev := range events {
+		if diff := cmp.Diff(want[i], ev.Data); diff != "" {
+			t.Errorf("%s event mismatch (-want +got):\n%s", ev.Type, diff)
+		}
+	}
+}
+
+func TestDecodeAll_JobSpan(t *testing.T) {
+	traceID, spanID := model.TraceID{1}, model.SpanID{2}
+	enqueuedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := &model.Request{
+		TraceID: traceID,
+		SpanID:  spanID,
+		JobData: &model.JobData{
+			Service:    "users",
+			Queue:      "emails",
+			JobID:      "job1",
+			EnqueuedAt: enqueuedAt,
+			Attempt:    2,
+			Payload:    []byte(`{"email":"a@b.c"}`),
+		},
+	}
+
+	log := trace2.NewLog()
+	log.JobSpanStart(req, 1)
+	log.JobSpanEnd(trace2.JobSpanEndParams{
+		EventParams: trace2.EventParams{TraceID: traceID, SpanID: spanID},
+		Req:         req,
+		Resp:        &model.Response{Duration: time.Second, Err: errors.New("boom")},
+	})
+	data, _ := log.GetAndClear()
+
+	events, err := DecodeAll(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []any{
+		&JobSpanStart{
+			SpanStart:  SpanStart{Goid: 1},
+			Service:    "users",
+			Queue:      "emails",
+			JobID:      "job1",
+			Attempt:    2,
+			EnqueuedAt: enqueuedAt,
+			Payload:    []byte(`{"email":"a@b.c"}`),
+		},
+		&JobSpanEnd{
+			SpanEnd: SpanEnd{Duration: time.Second, Err: &Error{Msg: "boom"}},
+			Service: "users",
+			Queue:   "emails",
+		},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, ev := range events {
+		if diff := cmp.Diff(want[i], ev.Data); diff != "" {
+			t.Errorf("%s event mismatch (-want +got):\n%s", ev.Type, diff)
+		}
+	}
+}
+
+func TestDecoder_Truncated(t *testing.T) {
+	log := trace2.NewLog()
+	log.ServiceInitStart(trace2.ServiceInitStartParams{Service: "users"})
+	data, _ := log.GetAndClear()
+
+	for _, n := range []int{headerSize - 1, len(data) - 1} {
+		events, err := DecodeAll(data[:n])
+		if len(events) != 0 || err != io.ErrUnexpectedEOF {
+			t.Errorf("decoding %d of %d bytes: got %d events, err=%v, want io.ErrUnexpectedEOF", n, len(data), len(events), err)
+		}
+	}
+}
+
+func TestDecoder_UnknownEvent(t *testing.T) {
+	log := trace2.NewLog()
+	e := trace2.Event{Type: trace2.EventType(0xFF), Data: trace2.NewEventBuffer(4)}
+	e.Data.String("future")
+	log.Add(e)
+	log.ServiceInitStart(trace2.ServiceInitStartParams{Service: "users"})
+	data, _ := log.GetAndClear()
+
+	events, err := DecodeAll(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Data != nil {
+		t.Fatalf("got %d events, want the unknown event without data followed by another", len(events))
+	}
+	if got, ok := events[1].Data.(*ServiceInitStart); !ok || got.Service != "users" {
+		t.Errorf("got %#v, want service init of users", events[1].Data)
+	}
+}
@@ -0,0 +1,753 @@
+package decoder
+
+import (
+	"encoding/json"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/types/uuid"
+)
+
+// Stack is a stack trace, as the program counters of its frames
+// relative to the start of the binary's text segment.
+type Stack []uint64
+
+// StackFrame is a frame of a formatted stack trace.
+type StackFrame struct {
+	File string
+	Line int
+	Func string
+}
+
+// Error is an error recorded in a trace.
+type Error struct {
+	Msg   string
+	Stack Stack
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+// LogField is a key-value pair recorded with a log message or custom span.
+//
+// Value is one of *Error, string, bool, time.Time, time.Duration, uuid.UUID,
+// json.RawMessage, int64, uint64, float32 or float64, depending on the type
+// of the value recorded. Values that failed to marshal to JSON are recorded
+// as an *Error.
+type LogField struct {
+	Key   string
+	Value any
+}
+
+// SpanStart is the data common to all span start events.
+type SpanStart struct {
+	Goid             uint32
+	ParentTraceID    model.TraceID // zero if the span has no parent
+	ParentSpanID     model.SpanID  // zero if the span has no parent
+	DefLoc           uint32
+	CallerEventID    trace2.EventID // zero if unknown
+	ExtCorrelationID string
+}
+
+// SpanEnd is the data common to all span end events.
+type SpanEnd struct {
+	Duration      time.Duration
+	Err           *Error       // nil if the span succeeded
+	PanicStack    []StackFrame // nil unless the span panicked
+	ParentTraceID model.TraceID
+	ParentSpanID  model.SpanID
+	Baggage       map[string]string // the baggage of the span's request, if any
+}
+
+// SpanEvent is the data common to all events recorded within a span.
+type SpanEvent struct {
+	DefLoc uint32
+	Goid   uint32
+
+	// CorrelationEventID is the id of the related event, such as
+	// the start event of the operation an end event completes.
+	CorrelationEventID trace2.EventID
+}
+
+type RequestSpanStart struct {
+	SpanStart
+	Service, Endpoint string
+	HTTPMethod, Path  string
+	PathParams        []string
+	Headers           map[string]string
+	Payload           []byte
+	UserID            string
+	Mocked            bool
+}
+
+type RequestSpanEnd struct {
+	SpanEnd
+	Service, Endpoint string
+	HTTPStatus        int
+	Headers           map[string]string
+	Payload           []byte
+}
+
+type AuthSpanStart struct {
+	SpanStart
+	Service, Endpoint string
+	Payload           []byte
+}
+
+type AuthSpanEnd struct {
+	SpanEnd
+	Service, Endpoint string
+	UserID            string
+	UserData          []byte
+}
+
+type PubsubMessageSpanStart struct {
+	SpanStart
+	Service, Topic, Subscription string
+	MessageID                    string
+	Attempt                      int
+	Published                    time.Time
+	Payload                      []byte
+}
+
+type PubsubMessageSpanEnd struct {
+	SpanEnd
+	Service, Topic, Subscription string
+}
+
+type JobSpanStart struct {
+	SpanStart
+	Service, Queue string
+	JobID          string
+	Attempt        int
+	EnqueuedAt     time.Time
+	Payload        []byte
+}
+
+type JobSpanEnd struct {
+	SpanEnd
+	Service, Queue string
+}
+
+type TestSpanStart struct {
+	SpanStart
+	Service, TestName string
+	UserID            string
+	TestFile          string
+	TestLine          uint32
+}
+
+type TestSpanEnd struct {
+	SpanEnd
+	Service, TestName string
+	Failed, Skipped   bool
+}
+
+type RPCCallStart struct {
+	SpanEvent
+	TargetService, TargetEndpoint string
+	Stack                         Stack
+}
+
+type RPCCallEnd struct {
+	SpanEvent
+	Err *Error
+}
+
+type DBQueryStart struct {
+	SpanEvent
+	Query string
+	Stack Stack
+}
+
+type DBQueryEnd struct {
+	SpanEvent
+	Err *Error
+}
+
+// DBQueryPlan is the plan of a slow query, correlated
+// with the query's DBQueryStart event.
+type DBQueryPlan struct {
+	SpanEvent
+	Plan json.RawMessage // in PostgreSQL's JSON EXPLAIN format
+}
+
+type DBTransactionStart struct {
+	SpanEvent
+	Stack Stack
+}
+
+type DBTransactionEnd struct {
+	SpanEvent
+	Commit bool // false if the transaction was rolled back
+	Stack  Stack
+	Err    *Error
+}
+
+type PubsubPublishStart struct {
+	SpanEvent
+	Topic   string
+	Message []byte
+	Stack   Stack
+}
+
+type PubsubPublishEnd struct {
+	SpanEvent
+	MessageID string
+	Err       *Error
+}
+
+type ServiceInitStart struct {
+	SpanEvent
+	Service string
+}
+
+type ServiceInitEnd struct {
+	SpanEvent
+	Err *Error
+}
+
+type CacheCallStart struct {
+	SpanEvent
+	Operation string
+	Write     bool
+	Stack     Stack
+	Keys      []string
+}
+
+type CacheCallEnd struct {
+	SpanEvent
+	Result trace2.CacheCallResult
+	Err    *Error
+}
+
+type BodyStream struct {
+	SpanEvent
+	IsResponse bool
+	Overflowed bool // whether Data was truncated
+	Data       []byte
+}
+
+// WebSocketConnect is a WebSocket connection established by the request.
+// The connection's messages and close events are correlated with it.
+type WebSocketConnect struct {
+	SpanEvent
+	Protocol string
+}
+
+type WebSocketMessage struct {
+	SpanEvent
+	Inbound bool // whether the message was received from the client
+	Data    []byte
+}
+
+type WebSocketClose struct {
+	SpanEvent
+	Code   int
+	Reason string
+	Err    *Error
+}
+
+type HTTPCallStart struct {
+	SpanEvent
+	CorrelationParentSpanID model.SpanID
+	Method, URL             string
+	Stack                   Stack
+	StartNanotime           int64
+}
+
+type HTTPCallEnd struct {
+	SpanEvent
+	StatusCode int // zero if no response was received
+	Err        *Error
+	Events     []HTTPTraceEvent
+}
+
+// HTTPTraceEvent is a low-level event of an HTTP call, such as
+// a DNS lookup or TLS handshake. Which fields are set depends on Code.
+type HTTPTraceEvent struct {
+	Code     trace2.HTTPEventCode
+	Nanotime int64
+
+	HostPort string // GetConn
+
+	Reused, WasIdle bool          // GotConn
+	IdleTime        time.Duration // GotConn
+
+	StatusCode int // Got1xxResponse
+
+	Host  string   // DNSStart
+	Addrs [][]byte // DNSDone, as IP addresses
+
+	Network, Addr string // ConnectStart, ConnectDone
+
+	TLSVersion, CipherSuite        uint32 // TLSHandshakeDone
+	ServerName, NegotiatedProtocol string // TLSHandshakeDone
+
+	// Err is the error message of DNSDone, ConnectDone,
+	// TLSHandshakeDone, WroteRequest and ClosedBody events.
+	Err string
+}
+
+type LogMessage struct {
+	SpanEvent
+	Level  model.LogLevel
+	Msg    string
+	Fields []LogField
+	Stack  Stack
+}
+
+// BucketObjectAttrs are the attributes of an object storage object.
+// Zero values mean the attribute is unknown.
+type BucketObjectAttrs struct {
+	Size        uint64
+	Version     string
+	ETag        string
+	ContentType string
+}
+
+type BucketObjectUploadStart struct {
+	SpanEvent
+	Bucket, Object string
+	Attrs          BucketObjectAttrs
+	Stack          Stack
+}
+
+type BucketObjectUploadEnd struct {
+	SpanEvent
+	Size    uint64
+	Version string
+	Err     *Error
+}
+
+type BucketObjectDownloadStart struct {
+	SpanEvent
+	Bucket, Object, Version string
+	Stack                   Stack
+}
+
+type BucketObjectDownloadEnd struct {
+	SpanEvent
+	Size uint64
+	Err  *Error
+}
+
+type BucketObjectGetAttrsStart struct {
+	SpanEvent
+	Bucket, Object, Version string
+	Stack                   Stack
+}
+
+type BucketObjectGetAttrsEnd struct {
+	SpanEvent
+	Err   *Error
+	Attrs *BucketObjectAttrs // nil if Err is non-nil
+}
+
+type BucketListObjectsStart struct {
+	SpanEvent
+	Bucket, Prefix string
+	Stack          Stack
+}
+
+type BucketListObjectsEnd struct {
+	SpanEvent
+	Err      *Error
+	Observed uint64
+	HasMore  bool
+}
+
+type BucketDeleteObjectsStart struct {
+	SpanEvent
+	Bucket  string
+	Stack   Stack
+	Objects []BucketDeleteObject
+}
+
+// BucketDeleteObject is an object deleted by a BucketDeleteObjectsStart event.
+type BucketDeleteObject struct {
+	Object, Version string
+}
+
+type BucketDeleteObjectsEnd struct {
+	SpanEvent
+	Err *Error
+}
+
+type CustomSpanStart struct {
+	SpanEvent
+	Name   string
+	Fields []LogField
+	Stack  Stack
+}
+
+type CustomSpanEnd struct {
+	SpanEvent
+	Err    *Error
+	Fields []LogField
+}
+
+type CustomEvent struct {
+	SpanEvent
+	Name   string
+	Fields []LogField
+	Stack  Stack
+}
+
+// SpanLink links the span to a causally related span, such as the
+// span that published the Pub/Sub message the span is processing.
+type SpanLink struct {
+	SpanEvent
+	LinkedTraceID model.TraceID
+	LinkedSpanID  model.SpanID
+}
+
+// FaultInjected records that a fault was injected into an operation
+// by the configured fault injection rules.
+type FaultInjected struct {
+	SpanEvent
+	Target    string // the kind of operation, such as "endpoint" or "sqldb_query"
+	Service   string // for the endpoint and call targets
+	Endpoint  string // for the endpoint and call targets
+	Resource  string // the infrastructure resource, for the other targets
+	Latency   time.Duration
+	ErrorCode string // the code of the injected error, or "" if none
+	Drop      bool
+}
+
+// SecretRead records that a secret was read while handling a request.
+type SecretRead struct {
+	SpanEvent
+	Service  string
+	Endpoint string // the endpoint handling the request, or "" if none
+	Secret   string // the name of the secret
+}
+
+// GoroutineSnapshot is a snapshot of the process's goroutines,
+// captured while the span was running.
+type GoroutineSnapshot struct {
+	SpanEvent
+	Elapsed time.Duration // how long the span had been running
+	Total   int           // number of goroutines in the process
+	Stacks  []GoroutineStack
+}
+
+// GoroutineStack is a stack shared by Count goroutines.
+type GoroutineStack struct {
+	Count int
+	Stack []StackFrame
+}
+
+// decodeData decodes the data of an event of type typ.
+func decodeData(typ trace2.EventType, r *reader) any {
+	switch typ {
+	case trace2.RequestSpanStart:
+		ev := &RequestSpanStart{SpanStart: spanStart(r)}
+		ev.Service, ev.Endpoint = r.String(), r.String()
+		ev.HTTPMethod, ev.Path = r.String(), r.String()
+		ev.PathParams = r.Strings()
+		ev.Headers = r.Headers()
+		ev.Payload = r.ByteString()
+		_ = r.String() // external correlation id, also part of the span start
+		ev.UserID = r.String()
+		ev.Mocked = r.Bool()
+		return ev
+	case trace2.RequestSpanEnd:
+		ev := &RequestSpanEnd{SpanEnd: spanEnd(r)}
+		ev.Service, ev.Endpoint = r.String(), r.String()
+		ev.HTTPStatus = int(r.UVarint())
+		ev.Headers = r.Headers()
+		ev.Payload = r.ByteString()
+		return ev
+	case trace2.AuthSpanStart:
+		ev := &AuthSpanStart{SpanStart: spanStart(r)}
+		ev.Service, ev.Endpoint = r.String(), r.String()
+		ev.Payload = r.ByteString()
+		return ev
+	case trace2.AuthSpanEnd:
+		ev := &AuthSpanEnd{SpanEnd: spanEnd(r)}
+		ev.Service, ev.Endpoint = r.String(), r.String()
+		ev.UserID = r.String()
+		ev.UserData = r.ByteString()
+		return ev
+	case trace2.PubsubMessageSpanStart:
+		ev := &PubsubMessageSpanStart{SpanStart: spanStart(r)}
+		ev.Service, ev.Topic, ev.Subscription = r.String(), r.String(), r.String()
+		ev.MessageID = r.String()
+		ev.Attempt = int(r.UVarint())
+		ev.Published = r.Time()
+		ev.Payload = r.ByteString()
+		return ev
+	case trace2.PubsubMessageSpanEnd:
+		ev := &PubsubMessageSpanEnd{SpanEnd: spanEnd(r)}
+		ev.Service, ev.Topic, ev.Subscription = r.String(), r.String(), r.String()
+		return ev
+	case trace2.JobSpanStart:
+		ev := &JobSpanStart{SpanStart: spanStart(r)}
+		ev.Service, ev.Queue = r.String(), r.String()
+		ev.JobID = r.String()
+		ev.Attempt = int(r.UVarint())
+		ev.EnqueuedAt = r.Time()
+		ev.Payload = r.ByteString()
+		return ev
+	case trace2.JobSpanEnd:
+		ev := &JobSpanEnd{SpanEnd: spanEnd(r)}
+		ev.Service, ev.Queue = r.String(), r.String()
+		return ev
+	case trace2.TestStart:
+		ev := &TestSpanStart{SpanStart: spanStart(r)}
+		ev.Service, ev.TestName = r.String(), r.String()
+		ev.UserID = r.String()
+		ev.TestFile = r.String()
+		ev.TestLine = r.Uint32()
+		return ev
+	case trace2.TestEnd:
+		ev := &TestSpanEnd{SpanEnd: spanEnd(r)}
+		ev.Service, ev.TestName = r.String(), r.String()
+		ev.Failed, ev.Skipped = r.Bool(), r.Bool()
+		return ev
+	}
+
+	se := spanEvent(r)
+	switch typ {
+	case trace2.RPCCallStart:
+		return &RPCCallStart{SpanEvent: se, TargetService: r.String(), TargetEndpoint: r.String(), Stack: r.Stack()}
+	case trace2.RPCCallEnd:
+		return &RPCCallEnd{SpanEvent: se, Err: r.ErrWithStack()}
+	case trace2.DBQueryStart:
+		return &DBQueryStart{SpanEvent: se, Query: r.String(), Stack: r.Stack()}
+	case trace2.DBQueryEnd:
+		return &DBQueryEnd{SpanEvent: se, Err: r.ErrWithStack()}
+	case trace2.DBQueryPlan:
+		return &DBQueryPlan{SpanEvent: se, Plan: r.ByteString()}
+	case trace2.DBTransactionStart:
+		return &DBTransactionStart{SpanEvent: se, Stack: r.Stack()}
+	case trace2.DBTransactionEnd:
+		return &DBTransactionEnd{SpanEvent: se, Commit: r.Bool(), Stack: r.Stack(), Err: r.ErrWithStack()}
+	case trace2.PubsubPublishStart:
+		return &PubsubPublishStart{SpanEvent: se, Topic: r.String(), Message: r.ByteString(), Stack: r.Stack()}
+	case trace2.PubsubPublishEnd:
+		return &PubsubPublishEnd{SpanEvent: se, MessageID: r.String(), Err: r.ErrWithStack()}
+	case trace2.ServiceInitStart:
+		return &ServiceInitStart{SpanEvent: se, Service: r.String()}
+	case trace2.ServiceInitEnd:
+		return &ServiceInitEnd{SpanEvent: se, Err: r.ErrWithStack()}
+	case trace2.CacheCallStart:
+		return &CacheCallStart{SpanEvent: se, Operation: r.String(), Write: r.Bool(), Stack: r.Stack(), Keys: r.Strings()}
+	case trace2.CacheCallEnd:
+		return &CacheCallEnd{SpanEvent: se, Result: trace2.CacheCallResult(r.Byte()), Err: r.ErrWithStack()}
+	case trace2.BodyStream:
+		flags := r.Byte()
+		return &BodyStream{SpanEvent: se, IsResponse: flags&0b01 != 0, Overflowed: flags&0b10 != 0, Data: r.ByteString()}
+	case trace2.WebSocketConnect:
+		return &WebSocketConnect{SpanEvent: se, Protocol: r.String()}
+	case trace2.WebSocketMessage:
+		return &WebSocketMessage{SpanEvent: se, Inbound: r.Bool(), Data: r.ByteString()}
+	case trace2.WebSocketClose:
+		return &WebSocketClose{SpanEvent: se, Code: int(r.UVarint()), Reason: r.String(), Err: r.ErrWithStack()}
+	case trace2.HTTPCallStart:
+		return &HTTPCallStart{SpanEvent: se, CorrelationParentSpanID: r.SpanID(), Method: r.String(), URL: r.String(), Stack: r.Stack(), StartNanotime: r.Int64()}
+	case trace2.HTTPCallEnd:
+		ev := &HTTPCallEnd{SpanEvent: se, StatusCode: int(r.UVarint()), Err: r.ErrWithStack()}
+		ev.Events = httpEvents(r)
+		return ev
+	case trace2.LogMessage:
+		return &LogMessage{SpanEvent: se, Level: model.LogLevel(r.Byte()), Msg: r.String(), Fields: logFields(r), Stack: r.Stack()}
+	case trace2.BucketObjectUploadStart:
+		return &BucketObjectUploadStart{SpanEvent: se, Bucket: r.String(), Object: r.String(), Attrs: bucketObjectAttrs(r), Stack: r.Stack()}
+	case trace2.BucketObjectUploadEnd:
+		return &BucketObjectUploadEnd{SpanEvent: se, Size: r.UVarint(), Version: r.String(), Err: r.ErrWithStack()}
+	case trace2.BucketObjectDownloadStart:
+		return &BucketObjectDownloadStart{SpanEvent: se, Bucket: r.String(), Object: r.String(), Version: r.String(), Stack: r.Stack()}
+	case trace2.BucketObjectDownloadEnd:
+		return &BucketObjectDownloadEnd{SpanEvent: se, Size: r.UVarint(), Err: r.ErrWithStack()}
+	case trace2.BucketObjectGetAttrsStart:
+		return &BucketObjectGetAttrsStart{SpanEvent: se, Bucket: r.String(), Object: r.String(), Version: r.String(), Stack: r.Stack()}
+	case trace2.BucketObjectGetAttrsEnd:
+		ev := &BucketObjectGetAttrsEnd{SpanEvent: se, Err: r.ErrWithStack()}
+		if ev.Err == nil {
+			attrs := bucketObjectAttrs(r)
+			ev.Attrs = &attrs
+		}
+		return ev
+	case trace2.BucketListObjectsStart:
+		return &BucketListObjectsStart{SpanEvent: se, Bucket: r.String(), Prefix: r.String(), Stack: r.Stack()}
+	case trace2.BucketListObjectsEnd:
+		return &BucketListObjectsEnd{SpanEvent: se, Err: r.ErrWithStack(), Observed: r.UVarint(), HasMore: r.Bool()}
+	case trace2.BucketDeleteObjectsStart:
+		ev := &BucketDeleteObjectsStart{SpanEvent: se, Bucket: r.String(), Stack: r.Stack()}
+		for n := r.UVarint(); n > 0 && r.err == nil; n-- {
+			ev.Objects = append(ev.Objects, BucketDeleteObject{Object: r.String(), Version: r.String()})
+		}
+		return ev
+	case trace2.BucketDeleteObjectsEnd:
+		return &BucketDeleteObjectsEnd{SpanEvent: se, Err: r.ErrWithStack()}
+	case trace2.CustomSpanStart:
+		return &CustomSpanStart{SpanEvent: se, Name: r.String(), Fields: logFields(r), Stack: r.Stack()}
+	case trace2.CustomSpanEnd:
+		return &CustomSpanEnd{SpanEvent: se, Err: r.ErrWithStack(), Fields: logFields(r)}
+	case trace2.CustomEvent:
+		return &CustomEvent{SpanEvent: se, Name: r.String(), Fields: logFields(r), Stack: r.Stack()}
+	case trace2.SpanLink:
+		return &SpanLink{SpanEvent: se, LinkedTraceID: r.TraceID(), LinkedSpanID: r.SpanID()}
+	case trace2.FaultInjected:
+		return &FaultInjected{
+			SpanEvent: se,
+			Target:    r.String(),
+			Service:   r.String(),
+			Endpoint:  r.String(),
+			Resource:  r.String(),
+			Latency:   r.Duration(),
+			ErrorCode: r.String(),
+			Drop:      r.Bool(),
+		}
+	case trace2.SecretRead:
+		return &SecretRead{SpanEvent: se, Service: r.String(), Endpoint: r.String(), Secret: r.String()}
+	case trace2.GoroutineSnapshot:
+		ev := &GoroutineSnapshot{SpanEvent: se, Elapsed: r.Duration(), Total: int(r.UVarint())}
+		for n := r.UVarint(); n > 0 && r.err == nil; n-- {
+			ev.Stacks = append(ev.Stacks, GoroutineStack{Count: int(r.UVarint()), Stack: r.FormattedStack()})
+		}
+		return ev
+	default:
+		// Unknown event types aren't an error, so don't report
+		// a failure to read the common event data either.
+		r.err = nil
+		return nil
+	}
+}
+
+func spanStart(r *reader) SpanStart {
+	return SpanStart{
+		Goid:             uint32(r.UVarint()),
+		ParentTraceID:    r.TraceID(),
+		ParentSpanID:     r.SpanID(),
+		DefLoc:           uint32(r.UVarint()),
+		CallerEventID:    r.EventID(),
+		ExtCorrelationID: r.String(),
+	}
+}
+
+func spanEnd(r *reader) SpanEnd {
+	return SpanEnd{
+		Duration:      max(r.Duration(), 0),
+		Err:           r.ErrWithStack(),
+		PanicStack:    r.FormattedStack(),
+		ParentTraceID: r.TraceID(),
+		ParentSpanID:  r.SpanID(),
+		Baggage:       r.Headers(),
+	}
+}
+
+func spanEvent(r *reader) SpanEvent {
+	return SpanEvent{
+		DefLoc:             uint32(r.UVarint()),
+		Goid:               uint32(r.UVarint()),
+		CorrelationEventID: r.EventID(),
+	}
+}
+
+func bucketObjectAttrs(r *reader) BucketObjectAttrs {
+	return BucketObjectAttrs{
+		Size:        r.UVarint(),
+		Version:     r.String(),
+		ETag:        r.String(),
+		ContentType: r.String(),
+	}
+}
+
+func httpEvents(r *reader) []HTTPTraceEvent {
+	n := int(r.UVarint())
+	if n == 0 || r.err != nil {
+		return nil
+	}
+	events := make([]HTTPTraceEvent, 0, min(n, len(r.buf)))
+	for i := 0; i < n && r.err == nil; i++ {
+		ev := HTTPTraceEvent{Code: trace2.HTTPEventCode(r.Byte()), Nanotime: r.Int64()}
+		switch ev.Code {
+		case trace2.GetConn:
+			ev.HostPort = r.String()
+		case trace2.GotConn:
+			ev.Reused, ev.WasIdle = r.Bool(), r.Bool()
+			ev.IdleTime = time.Duration(r.Int64())
+		case trace2.Got1xxResponse:
+			ev.StatusCode = int(r.Varint())
+		case trace2.DNSStart:
+			ev.Host = r.String()
+		case trace2.DNSDone:
+			ev.Err = r.String()
+			for n := r.UVarint(); n > 0 && r.err == nil; n-- {
+				ev.Addrs = append(ev.Addrs, r.ByteString())
+			}
+		case trace2.ConnectStart:
+			ev.Network, ev.Addr = r.String(), r.String()
+		case trace2.ConnectDone:
+			ev.Network, ev.Addr, ev.Err = r.String(), r.String(), r.String()
+		case trace2.TLSHandshakeDone:
+			ev.Err = r.String()
+			ev.TLSVersion, ev.CipherSuite = r.Uint32(), r.Uint32()
+			ev.ServerName, ev.NegotiatedProtocol = r.String(), r.String()
+		case trace2.WroteRequest, trace2.ClosedBody:
+			ev.Err = r.String()
+		case trace2.GotFirstResponseByte, trace2.TLSHandshakeStart, trace2.WroteHeaders, trace2.Wait100Continue:
+			// No data.
+		default:
+			// The data of unknown events can't be skipped,
+			// so ignore the remaining events.
+			return events
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+func logFields(r *reader) []LogField {
+	n := int(r.UVarint())
+	if n == 0 || r.err != nil {
+		return nil
+	}
+	fields := make([]LogField, 0, min(n, len(r.buf)))
+	for i := 0; i < n && r.err == nil; i++ {
+		typ := model.LogFieldType(r.Byte())
+		f := LogField{Key: r.String()}
+		switch typ {
+		case model.ErrField:
+			f.Value = r.ErrWithStack()
+		case model.StringField:
+			f.Value = r.String()
+		case model.BoolField:
+			f.Value = r.Bool()
+		case model.TimeField:
+			f.Value = r.Time()
+		case model.DurationField:
+			f.Value = time.Duration(r.Int64())
+		case model.UUIDField:
+			var u uuid.UUID
+			copy(u[:], r.Bytes(len(u)))
+			f.Value = u
+		case model.JSONField:
+			data := r.ByteString()
+			if err := r.ErrWithStack(); err != nil {
+				f.Value = err
+			} else {
+				f.Value = json.RawMessage(data)
+			}
+		case model.IntField:
+			f.Value = r.Varint()
+		case model.UintField:
+			f.Value = r.UVarint()
+		case model.Float32Field:
+			f.Value = r.Float32()
+		case model.Float64Field:
+			f.Value = r.Float64()
+		default:
+			// The data of unknown fields can't be skipped,
+			// so ignore the remaining fields.
+			return fields
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
@@ -0,0 +1,200 @@
+package decoder
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+)
+
+var errShortEvent = errors.New("event data too short")
+
+// reader reads the values written to a trace2.EventBuffer.
+// Reading past the end of the data sets err and returns zero values.
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) Bytes(n int) []byte {
+	if r.err != nil || n < 0 || len(r.buf) < n {
+		r.err = errShortEvent
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *reader) Byte() byte {
+	if b := r.Bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *reader) Bool() bool {
+	return r.Byte() != 0
+}
+
+func (r *reader) Uint32() uint32 {
+	if b := r.Bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *reader) Int32() int32 {
+	return int32(unsignedToSigned(uint64(r.Uint32())))
+}
+
+func (r *reader) Uint64() uint64 {
+	if b := r.Bytes(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *reader) Int64() int64 {
+	return unsignedToSigned(r.Uint64())
+}
+
+func (r *reader) UVarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	u, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errShortEvent
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return u
+}
+
+func (r *reader) Varint() int64 {
+	return unsignedToSigned(r.UVarint())
+}
+
+func (r *reader) Float32() float32 {
+	return math.Float32frombits(r.Uint32())
+}
+
+func (r *reader) Float64() float64 {
+	return math.Float64frombits(r.Uint64())
+}
+
+func (r *reader) String() string {
+	return string(r.Bytes(int(r.UVarint())))
+}
+
+// ByteString reads a byte string, returning nil if it's empty.
+func (r *reader) ByteString() []byte {
+	n := int(r.UVarint())
+	if n == 0 {
+		return nil
+	}
+	return append([]byte(nil), r.Bytes(n)...)
+}
+
+func (r *reader) Strings() []string {
+	n := int(r.UVarint())
+	if n == 0 || r.err != nil {
+		return nil
+	}
+	ss := make([]string, 0, min(n, len(r.buf)))
+	for i := 0; i < n && r.err == nil; i++ {
+		ss = append(ss, r.String())
+	}
+	return ss
+}
+
+func (r *reader) Time() time.Time {
+	sec := r.Int64()
+	nsec := r.Int32()
+	return time.Unix(sec, int64(nsec)).UTC()
+}
+
+func (r *reader) Duration() time.Duration {
+	return time.Duration(r.Varint())
+}
+
+func (r *reader) EventID() trace2.EventID {
+	return trace2.EventID(r.UVarint())
+}
+
+func (r *reader) TraceID() (id model.TraceID) {
+	copy(id[:], r.Bytes(len(id)))
+	return id
+}
+
+func (r *reader) SpanID() (id model.SpanID) {
+	copy(id[:], r.Bytes(len(id)))
+	return id
+}
+
+// Stack reads a stack written using (*trace2.EventBuffer).Stack.
+func (r *reader) Stack() Stack {
+	n := int(r.Byte())
+	if n == 0 {
+		return nil
+	}
+	s := make(Stack, 0, n)
+	var pc int64
+	for i := 0; i < n && r.err == nil; i++ {
+		pc += r.Varint()
+		s = append(s, uint64(pc))
+	}
+	return s
+}
+
+// FormattedStack reads a stack written using (*trace2.EventBuffer).FormattedStack.
+func (r *reader) FormattedStack() []StackFrame {
+	n := int(r.Byte())
+	if n == 0 {
+		return nil
+	}
+	frames := make([]StackFrame, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		frames = append(frames, StackFrame{
+			File: r.String(),
+			Line: int(r.UVarint()),
+			Func: r.String(),
+		})
+	}
+	return frames
+}
+
+// ErrWithStack reads an error written using (*trace2.EventBuffer).ErrWithStack,
+// returning nil if there was no error.
+func (r *reader) ErrWithStack() *Error {
+	msg := r.String()
+	if msg == "" {
+		return nil
+	}
+	return &Error{Msg: msg, Stack: r.Stack()}
+}
+
+func (r *reader) Headers() map[string]string {
+	n := int(r.UVarint())
+	if n == 0 || r.err != nil {
+		return nil
+	}
+	headers := make(map[string]string, min(n, len(r.buf)))
+	for i := 0; i < n && r.err == nil; i++ {
+		k := r.String()
+		headers[k] = r.String()
+	}
+	return headers
+}
+
+func unsignedToSigned(u uint64) int64 {
+	x := int64(u >> 1)
+	if u&1 != 0 {
+		x = ^x
+	}
+	return x
+}
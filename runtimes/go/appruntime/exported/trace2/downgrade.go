@@ -0,0 +1,200 @@
+package trace2
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+// Downgrade converts whole events recorded in CurrentVersion to the layout
+// of version v, appending them to dst. Fields added in versions newer than v
+// are left out.
+//
+// Any change to the layout of the events, including their header written
+// by (*Log).Add, must bump CurrentVersion and be undone here.
+func Downgrade(dst, events []byte, v Version) ([]byte, error) {
+	if v < MinVersion || v > CurrentVersion {
+		return dst, fmt.Errorf("%w %d", ErrUnsupportedVersion, v)
+	}
+	evs, rest := readEvents(events)
+	if len(rest) > 0 {
+		return dst, errors.New("trace2: downgrade: incomplete event")
+	}
+
+	for _, ev := range evs {
+		header := events[:eventHeaderSize]
+		events = events[eventHeaderSize+len(ev.Data):]
+		typ, data := ev.Type, ev.Data
+		if typ.addedIn() > v {
+			var ok bool
+			typ, data, ok = asOlderEvent(typ, data)
+			if !ok {
+				// The collector doesn't know about the event type.
+				continue
+			}
+		}
+
+		data, err := downgradeEvent(typ, data, v)
+		if err != nil {
+			return dst, fmt.Errorf("trace2: downgrade %s event: %v", ev.Type, err)
+		}
+
+		// The header is the same in all supported versions but for
+		// the event type and data length.
+		n := len(dst)
+		dst = append(dst, header...)
+		dst[n] = byte(typ)
+		binary.LittleEndian.PutUint32(dst[n+eventHeaderSize-4:], uint32(len(data)))
+		dst = append(dst, data...)
+	}
+	return dst, nil
+}
+
+// jobSubscription is the subscription jobs are recorded as being
+// received on when they are recorded as Pub/Sub messages.
+const jobSubscription = "worker"
+
+// asOlderEvent converts an event of a type added after MinVersion to an
+// equivalent event of an older type, if there is one. Job spans are recorded
+// as the processing of a message published to a topic named after the queue.
+func asOlderEvent(typ EventType, data []byte) (EventType, []byte, bool) {
+	switch typ {
+	case JobSpanStart:
+		// Insert the subscription after the service and queue.
+		r := &eventReader{buf: data}
+		skipSpanStart(r)
+		r.SkipString() // service
+		r.SkipString() // queue
+		if r.err != nil {
+			return 0, nil, false
+		}
+		n := len(data) - len(r.buf)
+		out := make([]byte, 0, len(data)+len(jobSubscription)+1)
+		out = append(out, data[:n]...)
+		out = appendEventString(out, jobSubscription)
+		out = append(out, data[n:]...)
+		return PubsubMessageSpanStart, out, true
+
+	case JobSpanEnd:
+		// The subscription follows the service and queue at the end.
+		out := make([]byte, 0, len(data)+len(jobSubscription)+1)
+		out = append(out, data...)
+		return PubsubMessageSpanEnd, appendEventString(out, jobSubscription), true
+	}
+	return 0, nil, false
+}
+
+// appendEventString appends s to b as written by (*EventBuffer).String.
+func appendEventString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// addedIn returns the version the event type was added in,
+// or MinVersion if it's supported by all versions.
+func (te EventType) addedIn() Version {
+	switch te {
+	case CustomSpanStart, CustomSpanEnd, CustomEvent:
+		return 16
+	case SpanLink:
+		return 17
+	case GoroutineSnapshot:
+		return 19
+	case DBQueryPlan:
+		return 20
+	case WebSocketConnect, WebSocketMessage, WebSocketClose:
+		return 21
+	case JobSpanStart, JobSpanEnd:
+		return 22
+	case FaultInjected:
+		return 23
+	case SecretRead:
+		return 24
+	default:
+		return MinVersion
+	}
+}
+
+// StreamDowngraded calls stream with a log receiving the events of log,
+// converted to version v as they're added. It's used to report traces to
+// collectors that only support older versions. It returns once log is done
+// and stream has returned.
+func StreamDowngraded(log Logger, v Version, stream func(Logger) error) error {
+	if v == CurrentVersion {
+		return stream(log)
+	}
+
+	out := NewEncodedLog(log.Encoding())
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- stream(out)
+	}()
+
+	var buf []byte
+	var downgradeErr error
+	err := readBatches(log, func(events []byte) {
+		var err error
+		buf, err = Downgrade(buf[:0], events, v)
+		if err != nil {
+			// Drop the batch rather than sending events the collector can't parse.
+			downgradeErr = cmp.Or(downgradeErr, err)
+			return
+		}
+		out.addEvents(buf)
+	})
+	out.MarkDone()
+	return errors.Join(<-streamErr, err, downgradeErr)
+}
+
+// downgradeEvent returns the data of an event of type typ in the layout of version v.
+// The returned data may share memory with data.
+func downgradeEvent(typ EventType, data []byte, v Version) ([]byte, error) {
+	switch typ {
+	case RequestSpanStart:
+		// Version 15 added whether the request was mocked, at the end.
+		if v < 15 && len(data) > 0 {
+			data = data[:len(data)-1]
+		}
+
+	case RequestSpanEnd, AuthSpanEnd, PubsubMessageSpanEnd, TestEnd:
+		// Version 18 added the baggage, after the common span end data.
+		if v < 18 {
+			r := &eventReader{buf: data}
+			skipSpanEnd(r)
+			start := len(data) - len(r.buf)
+			for n := r.UVarint(); n > 0 && r.err == nil; n-- {
+				r.SkipString()
+				r.SkipString()
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			end := len(data) - len(r.buf)
+			data = append(data[:start:start], data[end:]...)
+		}
+	}
+	return data, nil
+}
+
+// skipSpanStart skips the span start data written by (*Log).newSpanStartEvent.
+func skipSpanStart(r *eventReader) {
+	r.UVarint()                                         // goid
+	r.Bytes(len(model.TraceID{}) + len(model.SpanID{})) // parent ids
+	r.UVarint()                                         // def loc
+	r.UVarint()                                         // caller event id
+	r.SkipString()                                      // external correlation id
+}
+
+// skipSpanEnd skips the span end data written by (*Log).newSpanEndEvent
+// that precedes the baggage.
+func skipSpanEnd(r *eventReader) {
+	r.Varint() // duration
+	if msg := r.String(); msg != "" {
+		r.SkipStack()
+	}
+	r.SkipFormattedStack()
+	r.Bytes(len(model.TraceID{}) + len(model.SpanID{})) // parent ids
+}
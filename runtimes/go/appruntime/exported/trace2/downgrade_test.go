@@ -0,0 +1,206 @@
+package trace2
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestNegotiateVersion(t *testing.T) {
+	for max, want := range map[Version]Version{
+		CurrentVersion + 1: CurrentVersion,
+		CurrentVersion:     CurrentVersion,
+		MinVersion:         MinVersion,
+	} {
+		if got, err := NegotiateVersion(max); got != want || err != nil {
+			t.Errorf("NegotiateVersion(%d) = %d, %v, want %d", max, got, err, want)
+		}
+	}
+	if _, err := NegotiateVersion(MinVersion - 1); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("got err %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestDowngrade(t *testing.T) {
+	req := &model.Request{
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "ep"}, Mocked: true},
+		Baggage: model.NewBaggage(map[string]string{"tenant": "acme"}),
+	}
+	resp := &model.Response{Err: errors.New("boom")}
+	log := NewLog()
+	log.RequestSpanStart(req, 1)
+	log.RequestSpanEnd(RequestSpanEndParams{Req: req, Resp: resp})
+	req.Baggage = nil
+	log.RequestSpanEnd(RequestSpanEndParams{Req: req, Resp: resp})
+	data, _ := log.GetAndClear()
+	current, _ := readEvents(data)
+
+	got, err := Downgrade(nil, data, CurrentVersion)
+	if err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("downgrading to the current version changed the data (err=%v)", err)
+	}
+
+	for _, v := range []Version{15, 14} {
+		got, err := Downgrade(nil, data, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events, rest := readEvents(got)
+		if len(events) != 3 || len(rest) != 0 {
+			t.Fatalf("version %d: got %d events and %d trailing bytes, want 3 events", v, len(events), len(rest))
+		}
+
+		// The span ends without baggage only differ by their ids and timestamps.
+		if !bytes.Equal(events[1].Data, events[2].Data) {
+			t.Errorf("version %d: span end with baggage = %x, want %x", v, events[1].Data, events[2].Data)
+		}
+		// The empty baggage is left out.
+		if want := len(current[2].Data) - 1; len(events[2].Data) != want {
+			t.Errorf("version %d: got span end of %d bytes, want %d", v, len(events[2].Data), want)
+		}
+
+		wantStart := current[0].Data
+		if v < 15 {
+			wantStart = wantStart[:len(wantStart)-1] // no mocked flag
+		}
+		if !bytes.Equal(events[0].Data, wantStart) {
+			t.Errorf("version %d: span start = %x, want %x", v, events[0].Data, wantStart)
+		}
+	}
+
+	if _, err := Downgrade(nil, data, MinVersion-1); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("got err %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestDowngrade_NewEventTypes(t *testing.T) {
+	// The versions the event types added after version 15 were added in.
+	addedIn := map[EventType]Version{
+		CustomSpanStart:   16,
+		CustomSpanEnd:     16,
+		CustomEvent:       16,
+		SpanLink:          17,
+		GoroutineSnapshot: 19,
+		DBQueryPlan:       20,
+		WebSocketConnect:  21,
+		WebSocketMessage:  21,
+		WebSocketClose:    21,
+		JobSpanStart:      22,
+		JobSpanEnd:        22,
+		FaultInjected:     23,
+		SecretRead:        24,
+	}
+	for typ := CustomSpanStart; !strings.HasPrefix(typ.String(), "Unknown"); typ++ {
+		if _, ok := addedIn[typ]; !ok {
+			t.Errorf("event type %s is not covered by the test", typ)
+		} else if got := typ.addedIn(); got != addedIn[typ] {
+			t.Errorf("%s.addedIn() = %d, want %d", typ, got, addedIn[typ])
+		}
+	}
+
+	log := NewLog()
+	log.LogMessage(LogMessageParams{Msg: "before"})
+	spanID := log.CustomSpanStart(CustomSpanStartParams{Name: "span"})
+	log.CustomEvent(CustomEventParams{SpanStartID: spanID, Name: "event"})
+	log.CustomSpanEnd(CustomSpanEndParams{StartID: spanID})
+	log.SpanLink(SpanLinkParams{})
+	log.GoroutineSnapshot(GoroutineSnapshotParams{Total: 1, Stacks: []GoroutineStack{{Count: 1}}})
+	log.DBQueryPlan(DBQueryPlanParams{Plan: []byte("[]")})
+	connID := log.WebSocketConnect(WebSocketConnectParams{})
+	log.WebSocketMessage(WebSocketMessageParams{ConnID: connID, Data: []byte("hi")})
+	log.WebSocketClose(WebSocketCloseParams{ConnID: connID, Code: 1000})
+	log.FaultInjected(FaultInjectedParams{Target: "sqldb_query", Resource: "orders", ErrorCode: "unavailable"})
+	log.SecretRead(SecretReadParams{Service: "svc", Endpoint: "Charge", Secret: "Token"})
+	log.LogMessage(LogMessageParams{Msg: "after"})
+	data, _ := log.GetAndClear()
+	all, _ := readEvents(data)
+
+	for v := MinVersion; v <= CurrentVersion; v++ {
+		var want []EventType
+		for _, ev := range all {
+			if addedIn[ev.Type] <= v {
+				want = append(want, ev.Type)
+			}
+		}
+
+		got, err := Downgrade(nil, data, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events, rest := readEvents(got)
+		if len(rest) != 0 {
+			t.Fatalf("version %d: got %d trailing bytes", v, len(rest))
+		}
+		var gotTypes []EventType
+		for _, ev := range events {
+			gotTypes = append(gotTypes, ev.Type)
+		}
+		if !slices.Equal(gotTypes, want) {
+			t.Errorf("version %d: got events %v, want %v", v, gotTypes, want)
+		}
+		if last := events[len(events)-1]; last.Type != LogMessage || !bytes.Contains(last.Data, []byte("after")) {
+			t.Errorf("version %d: got last event %s, want the second log message", v, last.Type)
+		}
+	}
+}
+
+func TestDowngrade_JobSpans(t *testing.T) {
+	job := &model.Request{
+		JobData: &model.JobData{Service: "svc", Queue: "emails", JobID: "job", Attempt: 2, Payload: []byte(`{"a":1}`)},
+	}
+	msg := &model.Request{
+		MsgData: &model.PubSubMsgData{Service: "svc", Topic: "emails", Subscription: jobSubscription, MessageID: "job", Attempt: 2, Payload: []byte(`{"a":1}`)},
+	}
+	resp := &model.Response{Err: errors.New("boom")}
+
+	log := NewLog()
+	log.JobSpanStart(job, 1)
+	log.JobSpanEnd(JobSpanEndParams{Req: job, Resp: resp})
+	log.PubsubMessageSpanStart(msg, 1)
+	log.PubsubMessageSpanEnd(PubsubMessageSpanEndParams{Req: msg, Resp: resp})
+	data, _ := log.GetAndClear()
+	all, _ := readEvents(data)
+
+	got, err := Downgrade(nil, data, JobSpanStart.addedIn()-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, rest := readEvents(got)
+	if len(events) != 4 || len(rest) != 0 {
+		t.Fatalf("got %d events and %d trailing bytes, want 4 events", len(events), len(rest))
+	}
+	// The job spans are recorded as the processing of a message.
+	for i := range 2 {
+		if job, msg := events[i], all[i+2]; job.Type != msg.Type || !bytes.Equal(job.Data, msg.Data) {
+			t.Errorf("event %d: got %s %x, want %s %x", i, job.Type, job.Data, msg.Type, msg.Data)
+		}
+	}
+}
+
+func TestStreamDowngraded(t *testing.T) {
+	log := NewEncodedLog(EncodingZstd)
+	req := &model.Request{
+		MsgData: &model.PubSubMsgData{Service: "svc", Topic: "topic", Subscription: "sub"},
+		Baggage: model.NewBaggage(map[string]string{"tenant": "acme"}),
+	}
+	go func() {
+		log.PubsubMessageSpanEnd(PubsubMessageSpanEndParams{Req: req, Resp: &model.Response{}})
+		log.MarkDone()
+	}()
+
+	sink := &collectSink{}
+	if err := StreamDowngraded(log, 15, sink.StreamTrace); err != nil {
+		t.Fatal(err)
+	}
+	if sink.enc != EncodingZstd {
+		t.Errorf("got encoding %q, want zstd", sink.enc)
+	}
+	events, _ := readEvents(sink.collected())
+	if len(events) != 1 || bytes.Contains(events[0].Data, []byte("acme")) {
+		t.Errorf("got events %+v, want a single span end without baggage", events)
+	}
+}
@@ -0,0 +1,101 @@
+package trace2
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Encoding is the encoding of the trace data returned by a Log.
+// It's sent in the X-Encore-Trace-Encoding header when streaming
+// a trace so the receiver knows how to decode it.
+type Encoding string
+
+const (
+	EncodingNone Encoding = ""
+	EncodingZstd Encoding = "zstd"
+	EncodingGzip Encoding = "gzip"
+)
+
+// ParseEncoding parses an encoding name.
+func ParseEncoding(s string) (Encoding, error) {
+	switch enc := Encoding(s); enc {
+	case EncodingNone, EncodingZstd, EncodingGzip:
+		return enc, nil
+	case "identity":
+		return EncodingNone, nil
+	default:
+		return "", fmt.Errorf("unknown trace encoding %q", s)
+	}
+}
+
+// encode compresses a batch of trace data into a self-contained frame.
+// Frames can be concatenated: both zstd and gzip decode a sequence
+// of frames as a single stream.
+func (enc Encoding) encode(data []byte) []byte {
+	if len(data) == 0 {
+		return data
+	}
+
+	switch enc {
+	case EncodingZstd:
+		return zstdEncoder().EncodeAll(data, make([]byte, 0, len(data)/4))
+
+	case EncodingGzip:
+		var buf bytes.Buffer
+		buf.Grow(len(data) / 4)
+		w := gzipWriters.Get().(*gzip.Writer)
+		w.Reset(&buf)
+		// Writing to a bytes.Buffer cannot fail.
+		_, _ = w.Write(data)
+		_ = w.Close()
+		gzipWriters.Put(w)
+		return buf.Bytes()
+
+	default:
+		return data
+	}
+}
+
+var zstdEncoder = sync.OnceValue(func() *zstd.Encoder {
+	// EncodeAll is safe for concurrent use, so a single encoder is shared.
+	// Trace data is highly repetitive so the fastest level compresses it well.
+	enc, err := zstd.NewWriter(nil,
+		zstd.WithEncoderLevel(zstd.SpeedFastest),
+		zstd.WithEncoderConcurrency(1),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("trace2: could not create zstd encoder: %v", err))
+	}
+	return enc
+})
+
+var gzipWriters = sync.Pool{
+	New: func() any {
+		w, _ := gzip.NewWriterLevel(nil, gzip.BestSpeed)
+		return w
+	},
+}
+
+// NewDecoder returns a reader decoding trace data
+// with the given encoding read from r.
+func NewDecoder(enc Encoding, r io.Reader) (io.ReadCloser, error) {
+	switch enc {
+	case EncodingNone:
+		return io.NopCloser(r), nil
+	case EncodingZstd:
+		dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	case EncodingGzip:
+		return gzip.NewReader(r)
+	default:
+		return nil, fmt.Errorf("unknown trace encoding %q", enc)
+	}
+}
@@ -0,0 +1,83 @@
+package trace2
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestEncodedLog(t *testing.T) {
+	for _, enc := range []Encoding{EncodingNone, EncodingZstd, EncodingGzip} {
+		t.Run(string(enc), func(t *testing.T) {
+			log := NewEncodedLog(enc)
+			raw := NewLog()
+
+			// Stream the log in several batches, as the streaming trace does.
+			var stream, want []byte
+			for batch := 0; batch < 3; batch++ {
+				for i := 0; i < 100; i++ {
+					e := Event{Type: LogMessage, Data: NewEventBuffer(32)}
+					e.Data.String("a fairly repetitive log message")
+					log.Add(e)
+					raw.Add(e)
+				}
+				data, _ := log.GetAndClear()
+				stream = append(stream, data...)
+				data, _ = raw.GetAndClear()
+				want = append(want, data...)
+			}
+			log.MarkDone()
+			if data, done := log.WaitAndClear(); len(data) != 0 || !done {
+				t.Fatalf("got %d bytes, done=%v after completion, want 0 bytes, done=true", len(data), done)
+			}
+			if enc != EncodingNone && len(stream) >= len(want) {
+				t.Errorf("got %d compressed bytes, want fewer than %d", len(stream), len(want))
+			}
+
+			r, err := NewDecoder(enc, bytes.NewReader(stream))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = r.Close() }()
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalIgnoringIDs(got, want) {
+				t.Errorf("decoded data does not match the logged events")
+			}
+		})
+	}
+}
+
+// equalIgnoringIDs reports whether a and b contain the same events,
+// ignoring their event ids and timestamps which differ between logs.
+func equalIgnoringIDs(a, b []byte) bool {
+	const headerSize = 1 + 8 + 8 + 16 + 8 + 4
+	if len(a) != len(b) {
+		return false
+	}
+	for len(a) > 0 {
+		if len(a) < headerSize || a[0] != b[0] || !bytes.Equal(a[17:headerSize], b[17:headerSize]) {
+			return false
+		}
+		ln := int(a[headerSize-4]) | int(a[headerSize-3])<<8 | int(a[headerSize-2])<<16 | int(a[headerSize-1])<<24
+		end := headerSize + ln
+		if !bytes.Equal(a[headerSize:end], b[headerSize:end]) {
+			return false
+		}
+		a, b = a[end:], b[end:]
+	}
+	return true
+}
+
+func TestParseEncoding(t *testing.T) {
+	for in, want := range map[string]Encoding{"": EncodingNone, "identity": EncodingNone, "zstd": EncodingZstd, "gzip": EncodingGzip} {
+		if got, err := ParseEncoding(in); err != nil || got != want {
+			t.Errorf("ParseEncoding(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseEncoding("brotli"); err == nil {
+		t.Error("ParseEncoding(\"brotli\"): expected an error")
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"time"
+	"unicode/utf8"
 
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/stack"
@@ -50,6 +53,19 @@ const (
 	BucketListObjectsEnd      EventType = 0x20
 	BucketDeleteObjectsStart  EventType = 0x21
 	BucketDeleteObjectsEnd    EventType = 0x22
+	CustomSpanStart           EventType = 0x23
+	CustomSpanEnd             EventType = 0x24
+	CustomEvent               EventType = 0x25
+	SpanLink                  EventType = 0x26
+	GoroutineSnapshot         EventType = 0x27
+	DBQueryPlan               EventType = 0x28
+	WebSocketConnect          EventType = 0x29
+	WebSocketMessage          EventType = 0x2A
+	WebSocketClose            EventType = 0x2B
+	JobSpanStart              EventType = 0x2C
+	JobSpanEnd                EventType = 0x2D
+	FaultInjected             EventType = 0x2E
+	SecretRead                EventType = 0x2F
 )
 
 func (te EventType) String() string {
@@ -122,6 +138,32 @@ func (te EventType) String() string {
 		return "BucketDeleteObjectsStart"
 	case BucketDeleteObjectsEnd:
 		return "BucketDeleteObjectsEnd"
+	case CustomSpanStart:
+		return "CustomSpanStart"
+	case CustomSpanEnd:
+		return "CustomSpanEnd"
+	case CustomEvent:
+		return "CustomEvent"
+	case SpanLink:
+		return "SpanLink"
+	case GoroutineSnapshot:
+		return "GoroutineSnapshot"
+	case DBQueryPlan:
+		return "DBQueryPlan"
+	case WebSocketConnect:
+		return "WebSocketConnect"
+	case WebSocketMessage:
+		return "WebSocketMessage"
+	case WebSocketClose:
+		return "WebSocketClose"
+	case JobSpanStart:
+		return "JobSpanStart"
+	case JobSpanEnd:
+		return "JobSpanEnd"
+	case FaultInjected:
+		return "FaultInjected"
+	case SecretRead:
+		return "SecretRead"
 
 	default:
 		return fmt.Sprintf("Unknown(%x)", byte(te))
@@ -147,7 +189,7 @@ type spanStartEventData struct {
 }
 
 func (l *Log) newSpanStartEvent(data spanStartEventData) EventBuffer {
-	tb := NewEventBuffer(4 + 16 + 8 + 4 + len(data.ExtCorrelationID) + 2 + data.ExtraSpace)
+	tb := AcquireEventBuffer(4 + 16 + 8 + 4 + len(data.ExtCorrelationID) + 2 + data.ExtraSpace)
 	tb.UVarint(uint64(data.Goid))
 	tb.Bytes(data.ParentTraceID[:])
 	tb.Bytes(data.ParentSpanID[:])
@@ -162,11 +204,12 @@ type spanEndEventData struct {
 	Err           error
 	ParentTraceID model.TraceID
 	ParentSpanID  model.SpanID
+	Baggage       map[string]string
 	ExtraSpace    int
 }
 
 func (l *Log) newSpanEndEvent(data spanEndEventData) EventBuffer {
-	tb := NewEventBuffer(8 + 12 + 8 + data.ExtraSpace)
+	tb := AcquireEventBuffer(8 + 12 + 8 + data.ExtraSpace)
 	tb.Duration(data.Duration)
 	tb.ErrWithStack(data.Err)
 	if panicStack, ok := errs.Meta(data.Err)["panic_stack"].(stack.Stack); ok {
@@ -177,6 +220,12 @@ func (l *Log) newSpanEndEvent(data spanEndEventData) EventBuffer {
 
 	tb.Bytes(data.ParentTraceID[:])
 	tb.Bytes(data.ParentSpanID[:])
+
+	tb.UVarint(uint64(len(data.Baggage)))
+	for _, k := range slices.Sorted(maps.Keys(data.Baggage)) {
+		tb.String(k)
+		tb.String(data.Baggage[k])
+	}
 	return tb
 }
 
@@ -187,7 +236,7 @@ type eventData struct {
 }
 
 func (l *Log) newEvent(data eventData) EventBuffer {
-	tb := NewEventBuffer(4 + 4 + data.ExtraSpace)
+	tb := AcquireEventBuffer(4 + 4 + data.ExtraSpace)
 	tb.UVarint(uint64(data.Common.DefLoc))
 	tb.UVarint(uint64(data.Common.Goid))
 	tb.EventID(data.CorrelationEventID)
@@ -217,13 +266,13 @@ func (l *Log) RequestSpanStart(req *model.Request, goid uint32) {
 		tb.String(pp.Value)
 	}
 
-	l.logHeaders(&tb, data.RequestHeaders)
-	tb.ByteString(data.NonRawPayload)
+	l.logHeaders(&tb, data.RequestHeaders, Payload{Kind: RequestHeader, Service: desc.Service, Endpoint: desc.Endpoint})
+	tb.ByteString(redact(Payload{Kind: RequestBody, Service: desc.Service, Endpoint: desc.Endpoint}, data.NonRawPayload))
 	tb.String(req.ExtCorrelationID)
 	tb.String(string(data.UserID))
 	tb.Bool(data.Mocked)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    RequestSpanStart,
 		TraceID: req.TraceID,
 		SpanID:  req.SpanID,
@@ -244,6 +293,7 @@ func (l *Log) RequestSpanEnd(p RequestSpanEndParams) {
 		Err:           p.Resp.Err,
 		ParentTraceID: p.Req.ParentTraceID,
 		ParentSpanID:  p.Req.ParentSpanID,
+		Baggage:       p.Req.Baggage.All(),
 		ExtraSpace:    len(desc.Service) + len(desc.Endpoint) + 64 + len(p.Resp.Payload),
 	})
 
@@ -251,15 +301,16 @@ func (l *Log) RequestSpanEnd(p RequestSpanEndParams) {
 	tb.String(desc.Endpoint)
 
 	tb.UVarint(uint64(p.Resp.HTTPStatus))
-	l.logHeaders(&tb, p.Resp.RawResponseHeaders)
-	tb.ByteString(p.Resp.Payload)
+	l.logHeaders(&tb, p.Resp.RawResponseHeaders, Payload{Kind: ResponseHeader, Service: desc.Service, Endpoint: desc.Endpoint})
+	tb.ByteString(redact(Payload{Kind: ResponseBody, Service: desc.Service, Endpoint: desc.Endpoint}, p.Resp.Payload))
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    RequestSpanEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
 		Data:    tb,
 	})
+	l.spanEnded(p.Resp.Duration, p.Resp.Err)
 }
 
 func (l *Log) AuthSpanStart(req *model.Request, goid uint32) {
@@ -277,9 +328,9 @@ func (l *Log) AuthSpanStart(req *model.Request, goid uint32) {
 
 	tb.String(desc.Service)
 	tb.String(desc.Endpoint)
-	tb.ByteString(data.NonRawPayload)
+	tb.ByteString(redact(Payload{Kind: RequestBody, Service: desc.Service, Endpoint: desc.Endpoint}, data.NonRawPayload))
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    AuthSpanStart,
 		TraceID: req.TraceID,
 		SpanID:  req.SpanID,
@@ -300,20 +351,22 @@ func (l *Log) AuthSpanEnd(p AuthSpanEndParams) {
 		Err:           p.Resp.Err,
 		ParentTraceID: p.Req.ParentTraceID,
 		ParentSpanID:  p.Req.ParentSpanID,
+		Baggage:       p.Req.Baggage.All(),
 		ExtraSpace:    len(desc.Service) + len(desc.Endpoint) + 64 + len(p.Resp.Payload),
 	})
 
 	tb.String(desc.Service)
 	tb.String(desc.Endpoint)
 	tb.String(string(p.Resp.AuthUID))
-	tb.ByteString(p.Resp.Payload)
+	tb.ByteString(redact(Payload{Kind: ResponseBody, Service: desc.Service, Endpoint: desc.Endpoint}, p.Resp.Payload))
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    AuthSpanEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
 		Data:    tb,
 	})
+	l.spanEnded(p.Resp.Duration, p.Resp.Err)
 }
 
 func (l *Log) PubsubMessageSpanStart(req *model.Request, goid uint32) {
@@ -334,9 +387,9 @@ func (l *Log) PubsubMessageSpanStart(req *model.Request, goid uint32) {
 	tb.String(data.MessageID)
 	tb.UVarint(uint64(data.Attempt))
 	tb.Time(data.Published)
-	tb.ByteString(data.Payload)
+	tb.ByteString(redact(Payload{Kind: MessageBody, Service: data.Service, Topic: data.Topic}, data.Payload))
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    PubsubMessageSpanStart,
 		TraceID: req.TraceID,
 		SpanID:  req.SpanID,
@@ -357,6 +410,7 @@ func (l *Log) PubsubMessageSpanEnd(p PubsubMessageSpanEndParams) {
 		Err:           p.Resp.Err,
 		ParentTraceID: p.Req.ParentTraceID,
 		ParentSpanID:  p.Req.ParentSpanID,
+		Baggage:       p.Req.Baggage.All(),
 		ExtraSpace:    len(msg.Service) + len(msg.Topic) + len(msg.Subscription) + 4,
 	})
 
@@ -364,12 +418,71 @@ func (l *Log) PubsubMessageSpanEnd(p PubsubMessageSpanEndParams) {
 	tb.String(msg.Topic)
 	tb.String(msg.Subscription)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    PubsubMessageSpanEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
 		Data:    tb,
 	})
+	l.spanEnded(p.Resp.Duration, p.Resp.Err)
+}
+
+func (l *Log) JobSpanStart(req *model.Request, goid uint32) {
+	data := req.JobData
+	tb := l.newSpanStartEvent(spanStartEventData{
+		ParentTraceID:    req.ParentTraceID,
+		ParentSpanID:     req.ParentSpanID,
+		DefLoc:           req.DefLoc,
+		Goid:             goid,
+		CallerEventID:    req.CallerEventID,
+		ExtCorrelationID: req.ExtCorrelationID,
+		ExtraSpace:       len(data.Service) + len(data.Queue) + len(data.JobID) + len(data.Payload) + 20,
+	})
+
+	// The layout matches PubsubMessageSpanStart without the subscription,
+	// so that Downgrade can record jobs as messages for older collectors.
+	tb.String(data.Service)
+	tb.String(data.Queue)
+	tb.String(data.JobID)
+	tb.UVarint(uint64(data.Attempt))
+	tb.Time(data.EnqueuedAt)
+	tb.ByteString(redact(Payload{Kind: JobPayload, Service: data.Service, Queue: data.Queue}, data.Payload))
+
+	l.addAndRelease(Event{
+		Type:    JobSpanStart,
+		TraceID: req.TraceID,
+		SpanID:  req.SpanID,
+		Data:    tb,
+	})
+}
+
+type JobSpanEndParams struct {
+	EventParams
+	Req  *model.Request
+	Resp *model.Response
+}
+
+func (l *Log) JobSpanEnd(p JobSpanEndParams) {
+	job := p.Req.JobData
+	tb := l.newSpanEndEvent(spanEndEventData{
+		Duration:      p.Resp.Duration,
+		Err:           p.Resp.Err,
+		ParentTraceID: p.Req.ParentTraceID,
+		ParentSpanID:  p.Req.ParentSpanID,
+		Baggage:       p.Req.Baggage.All(),
+		ExtraSpace:    len(job.Service) + len(job.Queue) + 4,
+	})
+
+	tb.String(job.Service)
+	tb.String(job.Queue)
+
+	l.addAndRelease(Event{
+		Type:    JobSpanEnd,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+	l.spanEnded(p.Resp.Duration, p.Resp.Err)
 }
 
 func (l *Log) TestSpanStart(req *model.Request, goid uint32) {
@@ -390,7 +503,7 @@ func (l *Log) TestSpanStart(req *model.Request, goid uint32) {
 	tb.String(data.TestFile)
 	tb.Uint32(data.TestLine)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    TestStart,
 		TraceID: req.TraceID,
 		SpanID:  req.SpanID,
@@ -416,6 +529,7 @@ func (l *Log) TestSpanEnd(p TestSpanEndParams) {
 		Err:           err,
 		ParentTraceID: p.Req.ParentTraceID,
 		ParentSpanID:  p.Req.ParentSpanID,
+		Baggage:       p.Req.Baggage.All(),
 		ExtraSpace:    len(desc.Service) + len(desc.Current.Name()) + 20,
 	})
 
@@ -424,7 +538,7 @@ func (l *Log) TestSpanEnd(p TestSpanEndParams) {
 	tb.Bool(p.Failed)
 	tb.Bool(p.Skipped)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    TestEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -443,7 +557,7 @@ func (l *Log) RPCCallStart(call *model.APICall, goid uint32) EventID {
 	tb.String(call.TargetServiceName)
 	tb.String(call.TargetEndpointName)
 	tb.Stack(stack.Build(3))
-	return l.Add(Event{
+	return l.addAndRelease(Event{
 		Type:    RPCCallStart,
 		TraceID: call.Source.TraceID,
 		SpanID:  call.Source.SpanID,
@@ -460,7 +574,7 @@ func (l *Log) RPCCallEnd(call *model.APICall, goid uint32, err error) {
 
 	tb.ErrWithStack(err)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    RPCCallEnd,
 		TraceID: call.Source.TraceID,
 		SpanID:  call.Source.SpanID,
@@ -485,7 +599,7 @@ func (l *Log) DBQueryStart(p DBQueryStartParams) EventID {
 	tb.String(p.Query)
 	tb.Stack(p.Stack)
 
-	return l.Add(Event{
+	return l.addAndRelease(Event{
 		Type:    DBQueryStart,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -500,7 +614,7 @@ func (l *Log) DBQueryEnd(p EventParams, startID EventID, err error) {
 		CorrelationEventID: startID,
 	})
 	tb.ErrWithStack(err)
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    DBQueryEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -508,6 +622,32 @@ func (l *Log) DBQueryEnd(p EventParams, startID EventID, err error) {
 	})
 }
 
+type DBQueryPlanParams struct {
+	EventParams
+
+	// QueryID is the start event of the query.
+	QueryID EventID
+
+	// Plan is the query plan, in PostgreSQL's JSON EXPLAIN format.
+	Plan []byte
+}
+
+// DBQueryPlan records the plan of a completed query.
+func (l *Log) DBQueryPlan(p DBQueryPlanParams) {
+	tb := l.newEvent(eventData{
+		Common:             p.EventParams,
+		CorrelationEventID: p.QueryID,
+		ExtraSpace:         len(p.Plan),
+	})
+	tb.ByteString(p.Plan)
+	l.addAndRelease(Event{
+		Type:    DBQueryPlan,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
 func (l *Log) DBTransactionStart(p EventParams, stack stack.Stack) EventID {
 	tb := l.newEvent(eventData{
 		Common:     p,
@@ -516,7 +656,7 @@ func (l *Log) DBTransactionStart(p EventParams, stack stack.Stack) EventID {
 
 	tb.Stack(stack)
 
-	return l.Add(Event{
+	return l.addAndRelease(Event{
 		Type:    DBTransactionStart,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -543,7 +683,7 @@ func (l *Log) DBTransactionEnd(p DBTransactionEndParams) {
 	tb.Stack(p.Stack)
 	tb.ErrWithStack(p.Err)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    DBTransactionEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -565,10 +705,10 @@ func (l *Log) PubsubPublishStart(p PubsubPublishStartParams) EventID {
 	})
 
 	tb.String(p.Topic)
-	tb.ByteString(p.Message)
+	tb.ByteString(redact(Payload{Kind: MessageBody, Topic: p.Topic}, p.Message))
 	tb.Stack(p.Stack)
 
-	return l.Add(Event{
+	return l.addAndRelease(Event{
 		Type:    PubsubPublishStart,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -593,7 +733,7 @@ func (l *Log) PubsubPublishEnd(p PubsubPublishEndParams) {
 	tb.String(p.MessageID)
 	tb.ErrWithStack(p.Err)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    PubsubPublishEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -613,7 +753,7 @@ func (l *Log) ServiceInitStart(p ServiceInitStartParams) EventID {
 	})
 	tb.String(p.Service)
 
-	return l.Add(Event{
+	return l.addAndRelease(Event{
 		Type:    ServiceInitStart,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -630,7 +770,7 @@ func (l *Log) ServiceInitEnd(p EventParams, start EventID, err error) {
 
 	tb.ErrWithStack(err)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    ServiceInitEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -661,7 +801,7 @@ func (l *Log) CacheCallStart(p CacheCallStartParams) EventID {
 		tb.String(k)
 	}
 
-	return l.Add(Event{
+	return l.addAndRelease(Event{
 		Type:    CacheCallStart,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -686,7 +826,7 @@ func (l *Log) CacheCallEnd(p CacheCallEndParams) {
 	tb.Byte(byte(p.Res))
 	tb.ErrWithStack(p.Err)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    CacheCallEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -731,9 +871,13 @@ func (l *Log) BodyStream(p BodyStreamParams) {
 		flags |= 1 << 1
 	}
 	tb.Byte(flags)
-	tb.ByteString(p.Data)
+	kind := RequestBody
+	if p.IsResponse {
+		kind = ResponseBody
+	}
+	tb.ByteString(redact(Payload{Kind: kind}, p.Data))
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    BodyStream,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -741,6 +885,103 @@ func (l *Log) BodyStream(p BodyStreamParams) {
 	})
 }
 
+type WebSocketConnectParams struct {
+	EventParams
+
+	// Protocol is the subprotocol negotiated with the client, if any.
+	Protocol string
+}
+
+// WebSocketConnect records a WebSocket connection being established
+// by the request. The connection's other events are correlated with it.
+func (l *Log) WebSocketConnect(p WebSocketConnectParams) EventID {
+	tb := l.newEvent(eventData{
+		Common:     p.EventParams,
+		ExtraSpace: len(p.Protocol) + 8,
+	})
+	tb.String(p.Protocol)
+
+	return l.addAndRelease(Event{
+		Type:    WebSocketConnect,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type WebSocketMessageParams struct {
+	EventParams
+
+	// ConnID is the connection's WebSocketConnect event.
+	ConnID EventID
+
+	// Service and Endpoint are the API the connection belongs to.
+	Service, Endpoint string
+
+	// Inbound specifies whether the message was received from the client,
+	// as opposed to sent to it.
+	Inbound bool
+
+	// Data is the message.
+	Data []byte
+}
+
+func (l *Log) WebSocketMessage(p WebSocketMessageParams) {
+	tb := l.newEvent(eventData{
+		Common:             p.EventParams,
+		CorrelationEventID: p.ConnID,
+		ExtraSpace:         len(p.Data) + 8,
+	})
+
+	// Inbound messages are redacted like request bodies,
+	// and outbound ones like response bodies.
+	kind := ResponseBody
+	if p.Inbound {
+		kind = RequestBody
+	}
+	tb.Bool(p.Inbound)
+	tb.ByteString(redact(Payload{Kind: kind, Service: p.Service, Endpoint: p.Endpoint}, p.Data))
+
+	l.addAndRelease(Event{
+		Type:    WebSocketMessage,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type WebSocketCloseParams struct {
+	EventParams
+
+	// ConnID is the connection's WebSocketConnect event.
+	ConnID EventID
+
+	// Code and Reason are the close status code and reason, if known.
+	Code   int
+	Reason string
+
+	// Err is the error the connection failed with, if any.
+	Err error
+}
+
+func (l *Log) WebSocketClose(p WebSocketCloseParams) {
+	tb := l.newEvent(eventData{
+		Common:             p.EventParams,
+		CorrelationEventID: p.ConnID,
+		ExtraSpace:         len(p.Reason) + 64,
+	})
+	tb.UVarint(uint64(p.Code))
+	tb.String(p.Reason)
+	tb.ErrWithStack(p.Err)
+
+	l.addAndRelease(Event{
+		Type:    WebSocketClose,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
 type BucketObjectUploadStartParams struct {
 	EventParams
 	Bucket string
@@ -767,7 +1008,7 @@ func (l *Log) BucketObjectUploadStart(p BucketObjectUploadStartParams) EventID {
 	tb.bucketObjectAttrs(&p.Attrs)
 	tb.Stack(p.Stack)
 
-	return l.Add(Event{
+	return l.addAndRelease(Event{
 		Type:    BucketObjectUploadStart,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -803,7 +1044,7 @@ func (l *Log) BucketObjectUploadEnd(p BucketObjectUploadEndParams) {
 	tb.OptString(p.Version)
 	tb.ErrWithStack(p.Err)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    BucketObjectUploadEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -830,7 +1071,7 @@ func (l *Log) BucketObjectDownloadStart(p BucketObjectDownloadStartParams) Event
 	tb.OptString(p.Version)
 	tb.Stack(p.Stack)
 
-	return l.Add(Event{
+	return l.addAndRelease(Event{
 		Type:    BucketObjectDownloadStart,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -857,7 +1098,7 @@ func (l *Log) BucketObjectDownloadEnd(p BucketObjectDownloadEndParams) {
 	tb.UVarint(p.Size)
 	tb.ErrWithStack(p.Err)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    BucketObjectDownloadEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -884,7 +1125,7 @@ func (l *Log) BucketObjectGetAttrsStart(p BucketObjectGetAttrsStartParams) Event
 	tb.OptString(p.Version)
 	tb.Stack(p.Stack)
 
-	return l.Add(Event{
+	return l.addAndRelease(Event{
 		Type:    BucketObjectGetAttrsStart,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -913,7 +1154,7 @@ func (l *Log) BucketObjectGetAttrsEnd(p BucketObjectGetAttrsEndParams) {
 		tb.bucketObjectAttrs(p.Attrs)
 	}
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    BucketObjectGetAttrsEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -938,7 +1179,7 @@ func (l *Log) BucketListObjectsStart(p BucketListObjectsStartParams) EventID {
 	tb.OptString(p.Prefix)
 	tb.Stack(p.Stack)
 
-	return l.Add(Event{
+	return l.addAndRelease(Event{
 		Type:    BucketListObjectsStart,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -967,7 +1208,7 @@ func (l *Log) BucketListObjectsEnd(p BucketListObjectsEndParams) {
 	tb.UVarint(p.Observed)
 	tb.Bool(p.HasMore)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    BucketListObjectsEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -1001,7 +1242,7 @@ func (l *Log) BucketDeleteObjectsStart(p BucketDeleteObjectsStartParams) EventID
 		tb.OptString(e.Version)
 	}
 
-	return l.Add(Event{
+	return l.addAndRelease(Event{
 		Type:    BucketDeleteObjectsStart,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -1025,7 +1266,7 @@ func (l *Log) BucketDeleteObjectsEnd(p BucketDeleteObjectsEndParams) {
 
 	tb.ErrWithStack(p.Err)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    BucketDeleteObjectsEnd,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -1033,12 +1274,247 @@ func (l *Log) BucketDeleteObjectsEnd(p BucketDeleteObjectsEndParams) {
 	})
 }
 
-func (l *Log) logHeaders(tb *EventBuffer, headers http.Header) {
+type CustomSpanStartParams struct {
+	EventParams
+	Name   string
+	Fields []LogField
+	Stack  stack.Stack
+}
+
+func (l *Log) CustomSpanStart(p CustomSpanStartParams) EventID {
+	name := truncateLogString(p.Name)
+	tb := l.newEvent(eventData{
+		Common:     p.EventParams,
+		ExtraSpace: len(name) + 64*len(p.Fields),
+	})
+
+	tb.String(name)
+	addLogFields(&tb, p.Fields)
+	tb.Stack(p.Stack)
+
+	return l.addAndRelease(Event{
+		Type:    CustomSpanStart,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type CustomSpanEndParams struct {
+	EventParams
+	StartID EventID
+	Err     error
+	Fields  []LogField
+}
+
+func (l *Log) CustomSpanEnd(p CustomSpanEndParams) {
+	tb := l.newEvent(eventData{
+		Common:             p.EventParams,
+		CorrelationEventID: p.StartID,
+		ExtraSpace:         64 * len(p.Fields),
+	})
+
+	tb.ErrWithStack(p.Err)
+	addLogFields(&tb, p.Fields)
+
+	l.addAndRelease(Event{
+		Type:    CustomSpanEnd,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type CustomEventParams struct {
+	EventParams
+
+	// SpanStartID is the start event of the custom span
+	// the event belongs to, or 0 if it doesn't belong to one.
+	SpanStartID EventID
+
+	Name   string
+	Fields []LogField
+	Stack  stack.Stack
+}
+
+func (l *Log) CustomEvent(p CustomEventParams) {
+	name := truncateLogString(p.Name)
+	tb := l.newEvent(eventData{
+		Common:             p.EventParams,
+		CorrelationEventID: p.SpanStartID,
+		ExtraSpace:         len(name) + 64*len(p.Fields),
+	})
+
+	tb.String(name)
+	addLogFields(&tb, p.Fields)
+	tb.Stack(p.Stack)
+
+	l.addAndRelease(Event{
+		Type:    CustomEvent,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type SpanLinkParams struct {
+	EventParams
+
+	// LinkedTraceID and LinkedSpanID identify the span being linked to,
+	// such as the span that published the Pub/Sub message being processed.
+	LinkedTraceID model.TraceID
+	LinkedSpanID  model.SpanID
+}
+
+// SpanLink records that the span is causally related to another span,
+// typically in a different trace, without being its child.
+func (l *Log) SpanLink(p SpanLinkParams) {
+	tb := l.newEvent(eventData{
+		Common:     p.EventParams,
+		ExtraSpace: 16 + 8,
+	})
+
+	tb.Bytes(p.LinkedTraceID[:])
+	tb.Bytes(p.LinkedSpanID[:])
+
+	l.addAndRelease(Event{
+		Type:    SpanLink,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type FaultInjectedParams struct {
+	EventParams
+
+	// Target is the kind of operation the fault was injected into,
+	// such as "endpoint" or "sqldb_query".
+	Target string
+
+	// Service and Endpoint identify the endpoint, for the endpoint and call targets.
+	Service  string
+	Endpoint string
+
+	// Resource is the infrastructure resource, for the other targets.
+	Resource string
+
+	// Latency is the latency injected, or 0.
+	Latency time.Duration
+
+	// ErrorCode is the code of the error injected, or "" if none was.
+	ErrorCode string
+
+	// Drop is whether the response of the operation was dropped.
+	Drop bool
+}
+
+// FaultInjected records that a fault was injected into an operation
+// by the configured fault injection rules.
+func (l *Log) FaultInjected(p FaultInjectedParams) {
+	tb := l.newEvent(eventData{
+		Common:     p.EventParams,
+		ExtraSpace: len(p.Target) + len(p.Service) + len(p.Endpoint) + len(p.Resource) + len(p.ErrorCode) + 16,
+	})
+
+	tb.String(p.Target)
+	tb.String(p.Service)
+	tb.String(p.Endpoint)
+	tb.String(p.Resource)
+	tb.Duration(p.Latency)
+	tb.String(p.ErrorCode)
+	tb.Bool(p.Drop)
+
+	l.addAndRelease(Event{
+		Type:    FaultInjected,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type SecretReadParams struct {
+	EventParams
+
+	// Service is the service the secret belongs to.
+	Service string
+
+	// Endpoint is the endpoint handling the request, or "" if none.
+	Endpoint string
+
+	// Secret is the name of the secret. Secret values are never recorded.
+	Secret string
+}
+
+// SecretRead records that a secret was read while handling a request,
+// for auditing which endpoints use which secrets.
+//
+// It's an audit event rather than a log message, so it's not subject
+// to log sampling or redaction and can't be suppressed.
+func (l *Log) SecretRead(p SecretReadParams) {
+	tb := l.newEvent(eventData{
+		Common:     p.EventParams,
+		ExtraSpace: len(p.Service) + len(p.Endpoint) + len(p.Secret) + 3,
+	})
+
+	tb.String(p.Service)
+	tb.String(p.Endpoint)
+	tb.String(p.Secret)
+
+	l.addAndRelease(Event{
+		Type:    SecretRead,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+type GoroutineSnapshotParams struct {
+	EventParams
+
+	// Elapsed is how long the span had been running when the snapshot was taken.
+	Elapsed time.Duration
+
+	// Total is the number of goroutines in the process.
+	Total int
+
+	// Stacks are the goroutine stacks, as returned by CaptureGoroutines.
+	Stacks []GoroutineStack
+}
+
+// GoroutineSnapshot records the stacks of the process's goroutines
+// while the span is running, to show where a slow request is stuck.
+func (l *Log) GoroutineSnapshot(p GoroutineSnapshotParams) {
+	tb := l.newEvent(eventData{
+		Common:     p.EventParams,
+		ExtraSpace: 8 + 256*len(p.Stacks),
+	})
+
+	tb.Duration(p.Elapsed)
+	tb.UVarint(uint64(p.Total))
+	tb.UVarint(uint64(len(p.Stacks)))
+	for _, s := range p.Stacks {
+		tb.UVarint(uint64(s.Count))
+		tb.FormattedStack(s.Stack)
+	}
+
+	l.addAndRelease(Event{
+		Type:    GoroutineSnapshot,
+		TraceID: p.TraceID,
+		SpanID:  p.SpanID,
+		Data:    tb,
+	})
+}
+
+// logHeaders writes the first value of each header,
+// redacted as described by p with the header name set.
+func (l *Log) logHeaders(tb *EventBuffer, headers http.Header, p Payload) {
 	tb.UVarint(uint64(len(headers)))
 	for k, v := range headers {
 		firstVal := ""
 		if len(v) > 0 {
-			firstVal = v[0]
+			p.Header = k
+			firstVal = redactHeader(p, v[0])
 		}
 		tb.String(k)
 		tb.String(firstVal)
@@ -1058,22 +1534,34 @@ type LogField struct {
 	Value any
 }
 
+// Limits on the log messages recorded in traces, to keep the trace events
+// compact when logging large values. Messages and string values exceeding
+// maxLogStringLen are truncated, fields beyond maxLogFields are left out,
+// and JSON values exceeding maxLogStringLen are replaced by an error.
+const (
+	maxLogStringLen = 4096
+	maxLogFields    = 64
+)
+
 func (l *Log) LogMessage(p LogMessageParams) {
+	fields := p.Fields
+	if len(fields) > maxLogFields {
+		fields = fields[:maxLogFields]
+	}
+	msg := truncateLogString(p.Msg)
+
 	tb := l.newEvent(eventData{
 		Common:     p.EventParams,
-		ExtraSpace: len(p.Msg) + 1 + 64*len(p.Fields),
+		ExtraSpace: len(msg) + 1 + 64*len(fields),
 	})
 
 	tb.Byte(byte(p.Level))
-	tb.String(p.Msg)
+	tb.String(msg)
 
-	tb.UVarint(uint64(len(p.Fields)))
-	for _, f := range p.Fields {
-		addLogField(&tb, f.Key, f.Value)
-	}
+	addLogFields(&tb, fields)
 	tb.Stack(p.Stack)
 
-	l.Add(Event{
+	l.addAndRelease(Event{
 		Type:    LogMessage,
 		TraceID: p.TraceID,
 		SpanID:  p.SpanID,
@@ -1081,6 +1569,32 @@ func (l *Log) LogMessage(p LogMessageParams) {
 	})
 }
 
+var errLogValueTooLarge = errors.New("value too large to include in trace")
+
+// truncateLogString truncates s to at most maxLogStringLen bytes,
+// without splitting a multi-byte character.
+func truncateLogString(s string) string {
+	if len(s) <= maxLogStringLen {
+		return s
+	}
+	n := maxLogStringLen
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// addLogFields adds the fields, leaving out those beyond maxLogFields.
+func addLogFields(tb *EventBuffer, fields []LogField) {
+	if len(fields) > maxLogFields {
+		fields = fields[:maxLogFields]
+	}
+	tb.UVarint(uint64(len(fields)))
+	for _, f := range fields {
+		addLogField(tb, f.Key, f.Value)
+	}
+}
+
 func addLogField(tb *EventBuffer, key string, val any) {
 	switch val := val.(type) {
 	case error:
@@ -1090,7 +1604,7 @@ func addLogField(tb *EventBuffer, key string, val any) {
 	case string:
 		tb.Byte(byte(model.StringField))
 		tb.String(key)
-		tb.String(val)
+		tb.String(truncateLogString(val))
 	case bool:
 		tb.Byte(byte(model.BoolField))
 		tb.String(key)
@@ -1112,6 +1626,9 @@ func addLogField(tb *EventBuffer, key string, val any) {
 		tb.Byte(byte(model.JSONField))
 		tb.String(key)
 		data, err := json.Marshal(val)
+		if err == nil && len(data) > maxLogStringLen {
+			err = errLogValueTooLarge
+		}
 		if err != nil {
 			tb.ByteString(nil)
 			tb.ErrWithStack(err)
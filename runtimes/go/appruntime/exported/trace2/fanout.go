@@ -0,0 +1,177 @@
+package trace2
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// A Sink consumes trace logs, such as by sending them to a tracing backend.
+type Sink interface {
+	// StreamTrace consumes the log of a single trace until it's done.
+	// It's called concurrently for different traces.
+	StreamTrace(log Logger) error
+}
+
+var (
+	sinksMu sync.Mutex             // serializes RegisterSink
+	sinks   atomic.Pointer[[]Sink] // copied on write
+)
+
+// RegisterSink registers a sink that receives all traces recorded from then on,
+// in addition to the sinks the runtime is configured with. The logs it receives
+// are unencoded.
+func RegisterSink(s Sink) {
+	if s == nil {
+		return
+	}
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	var ss []Sink
+	if old := sinks.Load(); old != nil {
+		ss = append(ss, *old...)
+	}
+	ss = append(ss, s)
+	sinks.Store(&ss)
+}
+
+// Fanout is a Sink that streams each trace to several sinks,
+// each of which receives its own copy of the trace log.
+type Fanout struct {
+	mu    sync.Mutex
+	sinks []fanoutSink
+}
+
+// Ensure Fanout implements Sink.
+var _ Sink = (*Fanout)(nil)
+
+type fanoutSink struct {
+	sink Sink
+	enc  Encoding // encoding of the logs the sink receives
+}
+
+// NewFanout returns a fanout streaming to no sinks but the registered ones.
+func NewFanout() *Fanout {
+	return &Fanout{}
+}
+
+// Add adds a sink receiving logs with the given encoding.
+func (f *Fanout) Add(s Sink, enc Encoding) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sinks = append(f.sinks, fanoutSink{sink: s, enc: enc})
+}
+
+// Encoding reports the encoding the logs streamed to f should use.
+// It's the encoding of the sink if there's a single one, so the log can be
+// passed on as is. Otherwise it's EncodingNone, since the data must be decoded
+// to be copied to each sink anyway.
+func (f *Fanout) Encoding() Encoding {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.sinks) == 1 {
+		return f.sinks[0].enc
+	}
+	return EncodingNone
+}
+
+// StreamTrace implements Sink by copying the events of log
+// to a new log for each sink, as they're added.
+func (f *Fanout) StreamTrace(log Logger) error {
+	f.mu.Lock()
+	targets := append([]fanoutSink(nil), f.sinks...)
+	f.mu.Unlock()
+	if registered := sinks.Load(); registered != nil {
+		for _, s := range *registered {
+			targets = append(targets, fanoutSink{sink: s, enc: EncodingNone})
+		}
+	}
+
+	switch {
+	case len(targets) == 0:
+		return readBatches(log, func([]byte) {})
+	case len(targets) == 1 && targets[0].enc == log.Encoding():
+		return targets[0].sink.StreamTrace(log)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(targets))
+		logs = make([]*Log, len(targets))
+	)
+	for i, t := range targets {
+		logs[i] = NewEncodedLog(t.enc)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = t.sink.StreamTrace(logs[i])
+		}()
+	}
+
+	err := readBatches(log, func(events []byte) {
+		for _, l := range logs {
+			l.addEvents(events)
+		}
+	})
+	for _, l := range logs {
+		l.MarkDone()
+	}
+	wg.Wait()
+	return errors.Join(append(errs, err)...)
+}
+
+// addEvents adds already encoded events to the log.
+func (l *Log) addEvents(events []byte) {
+	l.mu.Lock()
+	l.appendLocked(events)
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+// readBatches reads the decoded data of log until it's done,
+// calling fn with each batch of whole events read.
+// The batch is only valid until fn returns.
+func readBatches(log Logger, fn func(events []byte)) error {
+	r := log.Reader()
+	defer func() { _ = r.Close() }()
+	dec, err := NewDecoder(log.Encoding(), r)
+	if err == io.EOF {
+		return nil // no trace data
+	} else if err != nil {
+		return fmt.Errorf("unable to decode trace data: %v", err)
+	}
+	defer func() { _ = dec.Close() }()
+
+	var buf []byte
+	chunk := make([]byte, 32<<10)
+	for {
+		n, err := dec.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if whole := wholeEventsLen(buf); whole > 0 {
+			fn(buf[:whole])
+			buf = append(buf[:0], buf[whole:]...)
+		}
+
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("unable to decode trace data: %v", err)
+		}
+	}
+}
+
+// wholeEventsLen returns the length of the whole events at the start of data.
+func wholeEventsLen(data []byte) int {
+	n := 0
+	for len(data)-n >= eventHeaderSize {
+		ln := eventHeaderSize + int(binary.LittleEndian.Uint32(data[n+eventHeaderSize-4:]))
+		if len(data)-n < ln {
+			break
+		}
+		n += ln
+	}
+	return n
+}
@@ -0,0 +1,164 @@
+package trace2
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// collectSink is a Sink that collects the decoded data of the traces it receives.
+type collectSink struct {
+	mu   sync.Mutex
+	enc  Encoding // encoding of the received logs
+	data []byte
+}
+
+func (s *collectSink) StreamTrace(log Logger) error {
+	s.mu.Lock()
+	s.enc = log.Encoding()
+	s.mu.Unlock()
+	return readBatches(log, func(events []byte) {
+		s.mu.Lock()
+		s.data = append(s.data, events...)
+		s.mu.Unlock()
+	})
+}
+
+func (s *collectSink) collected() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data
+}
+
+// writeEvents adds events to log concurrently, returning
+// the unencoded data of the events added once log is done.
+func writeEvents(log *Log) <-chan []byte {
+	ch := make(chan []byte, 1)
+	go func() {
+		raw := NewLog()
+		for i := 0; i < 200; i++ {
+			e := Event{Type: LogMessage, Data: NewEventBuffer(32)}
+			e.Data.String("a fairly repetitive log message")
+			log.Add(e)
+			raw.Add(e)
+		}
+		log.MarkDone()
+		data, _ := raw.GetAndClear()
+		ch <- data
+	}()
+	return ch
+}
+
+func TestFanout(t *testing.T) {
+	plain, zstd := &collectSink{}, &collectSink{}
+	f := NewFanout()
+	f.Add(plain, EncodingNone)
+	f.Add(zstd, EncodingZstd)
+	if got := f.Encoding(); got != EncodingNone {
+		t.Fatalf("got encoding %q, want none", got)
+	}
+
+	log := NewEncodedLog(f.Encoding())
+	added := writeEvents(log)
+	if err := f.StreamTrace(log); err != nil {
+		t.Fatal(err)
+	}
+	want := <-added
+
+	for _, s := range []*collectSink{plain, zstd} {
+		if !equalIgnoringIDs(s.collected(), want) {
+			t.Errorf("sink with encoding %q: data does not match the logged events", s.enc)
+		}
+	}
+	if zstd.enc != EncodingZstd {
+		t.Errorf("got encoding %q, want zstd", zstd.enc)
+	}
+}
+
+func TestFanout_Single(t *testing.T) {
+	sink := &collectSink{}
+	f := NewFanout()
+	f.Add(sink, EncodingGzip)
+	if got := f.Encoding(); got != EncodingGzip {
+		t.Fatalf("got encoding %q, want gzip", got)
+	}
+
+	// The log is passed to the sink as is.
+	log := NewEncodedLog(EncodingGzip)
+	added := writeEvents(log)
+	if err := f.StreamTrace(log); err != nil {
+		t.Fatal(err)
+	}
+	if !equalIgnoringIDs(sink.collected(), <-added) {
+		t.Errorf("data does not match the logged events")
+	}
+}
+
+func TestFanout_Errors(t *testing.T) {
+	ok, failing := &collectSink{}, &failingSink{err: errors.New("sink failed")}
+	f := NewFanout()
+	f.Add(ok, EncodingNone)
+	f.Add(failing, EncodingNone)
+
+	log := NewLog()
+	added := writeEvents(log)
+	if err := f.StreamTrace(log); !errors.Is(err, failing.err) {
+		t.Fatalf("got err=%v, want %v", err, failing.err)
+	}
+	// The other sinks still receive the trace.
+	if !equalIgnoringIDs(ok.collected(), <-added) {
+		t.Errorf("data does not match the logged events")
+	}
+}
+
+// failingSink is a Sink that consumes the log and fails.
+type failingSink struct{ err error }
+
+func (s *failingSink) StreamTrace(log Logger) error {
+	_ = readBatches(log, func([]byte) {})
+	return s.err
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.bin")
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log := NewEncodedLog(EncodingZstd)
+	added := writeEvents(log)
+	if err := sink.StreamTrace(log); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalIgnoringIDs(got, <-added) {
+		t.Errorf("file does not match the logged events")
+	}
+}
+
+func TestWholeEventsLen(t *testing.T) {
+	log := NewLog()
+	for i := 0; i < 3; i++ {
+		e := Event{Type: LogMessage, Data: NewEventBuffer(8)}
+		e.Data.String("message")
+		log.Add(e)
+	}
+	data, _ := log.GetAndClear()
+	event := len(data) / 3
+
+	for n, want := range map[int]int{0: 0, eventHeaderSize: 0, event: event, event + 1: event, len(data): len(data)} {
+		if got := wholeEventsLen(data[:n]); got != want {
+			t.Errorf("wholeEventsLen of %d bytes = %d, want %d", n, got, want)
+		}
+	}
+}
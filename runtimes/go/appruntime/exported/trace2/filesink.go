@@ -0,0 +1,53 @@
+package trace2
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileSink is a Sink that appends the events of all traces to a file,
+// in the binary format they're recorded in. Since each event includes
+// the trace and span it belongs to, the events of concurrent traces
+// can be told apart even though they're interleaved.
+type FileSink struct {
+	mu sync.Mutex // guards writes to f
+	f  *os.File
+}
+
+// NewFileSink returns a sink appending to the file at path,
+// creating it if it doesn't exist.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open trace file: %v", err)
+	}
+	return &FileSink{f: f}, nil
+}
+
+// StreamTrace implements Sink by writing the events
+// of log to the file as they're added.
+func (s *FileSink) StreamTrace(log Logger) error {
+	var writeErr error
+	err := readBatches(log, func(events []byte) {
+		// Write whole batches at a time so that events
+		// of concurrent traces are never split.
+		s.mu.Lock()
+		if _, err := s.f.Write(events); err != nil && writeErr == nil {
+			writeErr = fmt.Errorf("unable to write trace file: %v", err)
+		}
+		s.mu.Unlock()
+	})
+	if err != nil {
+		return err
+	}
+	return writeErr
+}
+
+// Close closes the file. Traces streamed after
+// the sink has been closed fail to be written.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
@@ -0,0 +1,81 @@
+package trace2
+
+import (
+	"fmt"
+	"sort"
+)
+
+// EventTypeSet is a set of event types.
+type EventTypeSet [256 / 64]uint64
+
+// Add adds the event types to the set.
+func (s *EventTypeSet) Add(types ...EventType) {
+	for _, t := range types {
+		s[t/64] |= 1 << (t % 64)
+	}
+}
+
+// Has reports whether t is in the set.
+func (s *EventTypeSet) Has(t EventType) bool {
+	return s[t/64]&(1<<(t%64)) != 0
+}
+
+// eventCategories are the categories of events that can be suppressed,
+// keyed by name. Start and end events are suppressed together so that
+// traces never contain an operation that doesn't complete.
+//
+// Span events can't be suppressed since the other events belong to them.
+var eventCategories = map[string][]EventType{
+	"db_query":       {DBQueryStart, DBQueryEnd, DBQueryPlan},
+	"db_transaction": {DBTransactionStart, DBTransactionEnd},
+	"rpc_call":       {RPCCallStart, RPCCallEnd},
+	"http_call":      {HTTPCallStart, HTTPCallEnd},
+	"log_message":    {LogMessage},
+	"pubsub_publish": {PubsubPublishStart, PubsubPublishEnd},
+	"service_init":   {ServiceInitStart, ServiceInitEnd},
+	"cache_call":     {CacheCallStart, CacheCallEnd},
+	"body_stream":    {BodyStream},
+	"websocket":      {WebSocketConnect, WebSocketMessage, WebSocketClose},
+	"bucket_op": {
+		BucketObjectUploadStart, BucketObjectUploadEnd,
+		BucketObjectDownloadStart, BucketObjectDownloadEnd,
+		BucketObjectGetAttrsStart, BucketObjectGetAttrsEnd,
+		BucketListObjectsStart, BucketListObjectsEnd,
+		BucketDeleteObjectsStart, BucketDeleteObjectsEnd,
+	},
+	"custom_span":        {CustomSpanStart, CustomSpanEnd},
+	"custom_event":       {CustomEvent},
+	"goroutine_snapshot": {GoroutineSnapshot},
+}
+
+// ParseEventCategories returns the set of event types
+// in the named event categories, such as "cache_call".
+func ParseEventCategories(names []string) (EventTypeSet, error) {
+	var set EventTypeSet
+	for _, name := range names {
+		types, ok := eventCategories[name]
+		if !ok {
+			return EventTypeSet{}, fmt.Errorf("unknown trace event category %q (expected one of %v)", name, EventCategories())
+		}
+		set.Add(types...)
+	}
+	return set, nil
+}
+
+// EventCategories returns the names of the event categories, sorted.
+func EventCategories() []string {
+	names := make([]string, 0, len(eventCategories))
+	for name := range eventCategories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithSuppressedEvents sets the event types the log discards
+// instead of adding, and returns the log.
+// It must be called before any events are added.
+func (l *Log) WithSuppressedEvents(types EventTypeSet) *Log {
+	l.suppressed = types
+	return l
+}
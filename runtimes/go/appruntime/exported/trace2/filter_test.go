@@ -0,0 +1,43 @@
+package trace2
+
+import (
+	"testing"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestLog_SuppressedEvents(t *testing.T) {
+	suppressed, err := ParseEventCategories([]string{"cache_call", "log_message"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := NewLog().WithSuppressedEvents(suppressed)
+	ep := EventParams{TraceID: model.TraceID{1}, SpanID: model.SpanID{1}}
+
+	startID := log.CacheCallStart(CacheCallStartParams{EventParams: ep, Operation: "get"})
+	if startID != 0 {
+		t.Errorf("got event id %v for a suppressed event, want 0", startID)
+	}
+	log.CacheCallEnd(CacheCallEndParams{EventParams: ep, StartID: startID})
+	log.LogMessage(LogMessageParams{EventParams: ep, Msg: "hello"})
+	log.CustomEvent(CustomEventParams{EventParams: ep, Name: "kept"})
+
+	data, _ := log.GetAndClear()
+	events, _ := readEvents(data)
+	if len(events) != 1 || events[0].Type != CustomEvent {
+		t.Errorf("got %d events, want only the custom event", len(events))
+	}
+}
+
+func TestParseEventCategories(t *testing.T) {
+	set, err := ParseEventCategories([]string{"db_query"})
+	if err != nil {
+		t.Fatal(err)
+	} else if !set.Has(DBQueryStart) || !set.Has(DBQueryEnd) || set.Has(DBTransactionStart) {
+		t.Errorf("got %v, want the query events", set)
+	}
+
+	if _, err := ParseEventCategories([]string{"db_query", "unknown"}); err == nil {
+		t.Error("got no error for an unknown category")
+	}
+}
@@ -0,0 +1,141 @@
+package trace2
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/exported/stack"
+)
+
+// defaultMaxGoroutineStacks is the default maximum number
+// of distinct stacks recorded in a goroutine snapshot.
+const defaultMaxGoroutineStacks = 100
+
+// GoroutineStack is a stack shared by one or more goroutines.
+type GoroutineStack struct {
+	Count int // number of goroutines with the stack
+	Stack stack.Stack
+}
+
+// CaptureGoroutines returns the number of goroutines in the process
+// along with their stacks, grouping goroutines with identical stacks.
+// The stacks are ordered by the number of goroutines sharing them,
+// and at most max are returned (or defaultMaxGoroutineStacks if max <= 0).
+//
+// Like other goroutine profiles it briefly stops the world.
+func CaptureGoroutines(max int) (total int, stacks []GoroutineStack) {
+	if max <= 0 {
+		max = defaultMaxGoroutineStacks
+	}
+
+	// Goroutines may be started between the calls, so retry with some headroom.
+	n, _ := runtime.GoroutineProfile(nil)
+	var records []runtime.StackRecord
+	for {
+		records = make([]runtime.StackRecord, n+10)
+		var ok bool
+		if n, ok = runtime.GoroutineProfile(records); ok {
+			records = records[:n]
+			break
+		}
+	}
+
+	index := make(map[[32]uintptr]int, len(records))
+	for _, rec := range records {
+		if i, ok := index[rec.Stack0]; ok {
+			stacks[i].Count++
+			continue
+		}
+		index[rec.Stack0] = len(stacks)
+		stacks = append(stacks, GoroutineStack{Count: 1, Stack: stack.Stack{Frames: rec.Stack()}})
+	}
+
+	slices.SortStableFunc(stacks, func(a, b GoroutineStack) int {
+		return b.Count - a.Count
+	})
+	if len(stacks) > max {
+		stacks = stacks[:max]
+	}
+	return len(records), stacks
+}
+
+// GoroutineSnapshots configures capturing goroutine snapshots
+// into the traces of requests that are running for a long time.
+type GoroutineSnapshots struct {
+	// LatencyThreshold is how long a request must run before a snapshot
+	// is captured. If zero, the first snapshot is captured after Interval.
+	LatencyThreshold time.Duration
+
+	// Interval is the interval at which further snapshots are captured
+	// while the request is running. If zero, at most one snapshot is captured.
+	Interval time.Duration
+
+	// MaxStacks is the maximum number of distinct stacks in each snapshot.
+	// If zero it defaults to 100.
+	MaxStacks int
+}
+
+// Enabled reports whether any snapshots are captured.
+func (c GoroutineSnapshots) Enabled() bool {
+	return c.LatencyThreshold > 0 || c.Interval > 0
+}
+
+// StartGoroutineSnapshots begins capturing goroutine snapshots into log,
+// as configured by cfg, for the span described by ep that started at start.
+// The returned function stops capturing snapshots; once it has returned
+// no more snapshots are added to log.
+func StartGoroutineSnapshots(log Logger, cfg GoroutineSnapshots, ep EventParams, start time.Time) (stop func()) {
+	if !cfg.Enabled() {
+		return func() {}
+	}
+
+	s := &snapshotter{log: log, cfg: cfg, ep: ep, start: start}
+	first := cfg.LatencyThreshold
+	if first <= 0 {
+		first = cfg.Interval
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timer = time.AfterFunc(first-time.Since(start), s.capture)
+	return s.stop
+}
+
+type snapshotter struct {
+	log   Logger
+	cfg   GoroutineSnapshots
+	ep    EventParams
+	start time.Time
+
+	mu    sync.Mutex
+	timer *time.Timer
+	done  bool
+}
+
+func (s *snapshotter) capture() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done {
+		return
+	}
+
+	total, stacks := CaptureGoroutines(s.cfg.MaxStacks)
+	s.log.GoroutineSnapshot(GoroutineSnapshotParams{
+		EventParams: s.ep,
+		Elapsed:     time.Since(s.start),
+		Total:       total,
+		Stacks:      stacks,
+	})
+	if s.cfg.Interval > 0 {
+		s.timer.Reset(s.cfg.Interval)
+	}
+}
+
+func (s *snapshotter) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done = true
+	s.timer.Stop()
+}
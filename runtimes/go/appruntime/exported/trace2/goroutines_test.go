@@ -0,0 +1,88 @@
+package trace2
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/stack"
+)
+
+func blockedGoroutine(wg *sync.WaitGroup, unblock <-chan struct{}) {
+	wg.Done()
+	<-unblock
+}
+
+func TestCaptureGoroutines(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go blockedGoroutine(&wg, unblock)
+	}
+	wg.Wait()
+
+	total, stacks := CaptureGoroutines(0)
+	if total < 6 {
+		t.Fatalf("got %d goroutines, want at least 6", total)
+	}
+	var found bool
+	for i, s := range stacks {
+		if i > 0 && s.Count > stacks[i-1].Count {
+			t.Errorf("stacks not ordered by count: %d after %d", s.Count, stacks[i-1].Count)
+		}
+		for _, f := range stack.Format(s.Stack) {
+			if strings.HasSuffix(f.Func, ".blockedGoroutine") {
+				found = true
+				if s.Count != 5 {
+					t.Errorf("got %d blocked goroutines, want 5", s.Count)
+				}
+			}
+		}
+	}
+	if !found {
+		t.Error("blocked goroutines not found")
+	}
+
+	if _, stacks := CaptureGoroutines(1); len(stacks) != 1 {
+		t.Errorf("got %d stacks, want 1", len(stacks))
+	}
+}
+
+func TestStartGoroutineSnapshots(t *testing.T) {
+	log := NewLog()
+	ep := EventParams{TraceID: model.TraceID{1}, SpanID: model.SpanID{1}}
+	cfg := GoroutineSnapshots{LatencyThreshold: 10 * time.Millisecond, Interval: 10 * time.Millisecond, MaxStacks: 3}
+	stop := StartGoroutineSnapshots(log, cfg, ep, time.Now())
+	time.Sleep(50 * time.Millisecond)
+	stop()
+
+	data, _ := log.GetAndClear()
+	events, _ := readEvents(data)
+	if len(events) < 2 {
+		t.Fatalf("got %d events, want at least 2", len(events))
+	}
+	for _, ev := range events {
+		if ev.Type != GoroutineSnapshot || ev.SpanID != ep.SpanID {
+			t.Errorf("got %s event for span %v, want a snapshot of span %v", ev.Type, ev.SpanID, ep.SpanID)
+		}
+	}
+
+	// No snapshots are captured once stopped.
+	time.Sleep(20 * time.Millisecond)
+	if data, _ := log.GetAndClear(); len(data) != 0 {
+		t.Errorf("got %d bytes after stopping, want none", len(data))
+	}
+}
+
+func TestStartGoroutineSnapshots_Disabled(t *testing.T) {
+	log := NewLog()
+	stop := StartGoroutineSnapshots(log, GoroutineSnapshots{}, EventParams{}, time.Now().Add(-time.Hour))
+	stop()
+	if data, _ := log.GetAndClear(); len(data) != 0 {
+		t.Errorf("got %d bytes, want none", len(data))
+	}
+}
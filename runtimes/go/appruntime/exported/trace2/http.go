@@ -3,10 +3,13 @@ package trace2
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptrace"
 	"net/textproto"
+	"strconv"
+	"strings"
 	"sync"
 	_ "unsafe" // for go:linkname
 
@@ -37,7 +40,7 @@ func (l *Log) HTTPBeginRoundTrip(httpReq *http.Request, req *model.Request, goid
 	tb.Stack(stack.Build(4))
 	tb.Int64(nanotime())
 
-	eventID := l.Add(Event{
+	eventID := l.addAndRelease(Event{
 		Type:    HTTPCallStart,
 		TraceID: req.TraceID,
 		SpanID:  req.SpanID,
@@ -50,8 +53,15 @@ func (l *Log) HTTPBeginRoundTrip(httpReq *http.Request, req *model.Request, goid
 		StartID:                 eventID,
 		CorrelationParentSpanID: callCorrelationParentSpanID,
 		log:                     l,
+		req:                     httpReq,
+		header:                  httpReq.Header,
 	}
 
+	// Send the trace context headers on a copy of the headers for this attempt,
+	// which is swapped back for the caller's headers once the round trip completes.
+	httpReq.Header = httpReq.Header.Clone()
+	injectTraceContext(httpReq, req.TraceID, callCorrelationParentSpanID, eventID, req.Traced && l.sampled())
+
 	ctx := context.WithValue(httpReq.Context(), rtKey, rt)
 	tr := &httptrace.ClientTrace{
 		GetConn:              rt.getConn,
@@ -76,6 +86,7 @@ func (l *Log) HTTPCompleteRoundTrip(req *http.Request, resp *http.Response, goid
 	if !ok {
 		return
 	}
+	rt.req.Header = rt.header
 
 	tb := l.newEvent(eventData{
 		Common:             EventParams{Goid: goid},
@@ -97,12 +108,39 @@ func (l *Log) HTTPCompleteRoundTrip(req *http.Request, resp *http.Response, goid
 		SpanID:  rt.SpanID,
 		Data:    tb,
 	})
+	tb.Release()
 
 	if req.Method != "HEAD" && resp != nil {
 		resp.Body = wrapRespBody(resp.Body, rt)
 	}
 }
 
+// injectTraceContext adds the W3C trace context headers (see https://www.w3.org/TR/trace-context/)
+// to an outbound request, so that the services it calls can join the trace.
+// The call's correlation span id is used as the parent span.
+//
+// Trace context headers set by the caller, such as for calls between
+// Encore services, are left unchanged. Headers set by Encore for a previous
+// attempt, such as when a request is reused or copied for a redirect, are replaced.
+func injectTraceContext(req *http.Request, traceID model.TraceID, spanID model.SpanID, eventID EventID, sampled bool) {
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	} else if req.Header.Get("traceparent") != "" && !strings.HasPrefix(req.Header.Get("tracestate"), eventIDKey) {
+		return
+	}
+
+	flags := "00"
+	if sampled {
+		flags = "01"
+	}
+	req.Header.Set("traceparent", fmt.Sprintf("00-%x-%x-%s", traceID[:], spanID[:], flags))
+	req.Header.Set("tracestate", eventIDKey+strconv.FormatUint(uint64(eventID), 36))
+}
+
+// eventIDKey prefixes the call's event id in the tracestate header set by Encore.
+// Must match the event id key read by the api package.
+const eventIDKey = "encore/event-id="
+
 type httpRoundTrip struct {
 	TraceID                 model.TraceID
 	SpanID                  model.SpanID
@@ -111,6 +149,9 @@ type httpRoundTrip struct {
 
 	log Logger
 
+	req    *http.Request // the request passed to HTTPBeginRoundTrip
+	header http.Header   // the caller's headers, restored once the round trip completes
+
 	mu     sync.Mutex
 	events []httpEvent
 }
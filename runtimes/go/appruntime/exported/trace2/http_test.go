@@ -0,0 +1,83 @@
+package trace2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestLog_HTTPBeginRoundTrip_TraceContext(t *testing.T) {
+	req := &model.Request{TraceID: model.TraceID{1}, SpanID: model.SpanID{2}, Traced: true}
+
+	log := NewLog()
+	httpReq := httptest.NewRequest("GET", "https://example.com", nil)
+	callerHeader := httpReq.Header
+	ctx, err := log.HTTPBeginRoundTrip(httpReq, req, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parent := httpReq.Header.Get("traceparent")
+	if want := "00-01000000000000000000000000000000-"; len(parent) != 55 || parent[:len(want)] != want || parent[len(parent)-3:] != "-01" {
+		t.Errorf("got traceparent %q, want one for trace %x", parent, req.TraceID[:])
+	}
+	if state := httpReq.Header.Get("tracestate"); !strings.HasPrefix(state, "encore/event-id=") {
+		t.Errorf("got tracestate %q, want the call's event id", state)
+	}
+	if len(callerHeader) != 0 {
+		t.Errorf("got caller headers %v, want them unchanged", callerHeader)
+	}
+
+	// The caller's headers are restored once the round trip completes.
+	log.HTTPCompleteRoundTrip(httpReq.WithContext(ctx), nil, 1, nil)
+	if len(httpReq.Header) != 0 {
+		t.Errorf("got headers %v after the round trip, want the caller's", httpReq.Header)
+	}
+
+	// Headers set by Encore for a previous attempt are replaced.
+	httpReq.Header = http.Header{}
+	if _, err := log.HTTPBeginRoundTrip(httpReq, req, 1); err != nil {
+		t.Fatal(err)
+	}
+	stale := httpReq.Header.Clone()
+	httpReq.Header = stale
+	if _, err := log.HTTPBeginRoundTrip(httpReq, req, 1); err != nil {
+		t.Fatal(err)
+	}
+	if httpReq.Header.Get("traceparent") == stale.Get("traceparent") || httpReq.Header.Get("tracestate") == stale.Get("tracestate") {
+		t.Errorf("got trace context %v, want it replaced", httpReq.Header)
+	}
+
+	// Trace context headers set by the caller are left unchanged.
+	httpReq = httptest.NewRequest("GET", "https://example.com", nil)
+	httpReq.Header = http.Header{"Traceparent": {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}}
+	if _, err := log.HTTPBeginRoundTrip(httpReq, req, 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := httpReq.Header.Get("traceparent"); got != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Errorf("got traceparent %q, want it unchanged", got)
+	}
+	if got := httpReq.Header.Get("tracestate"); got != "" {
+		t.Errorf("got tracestate %q, want none", got)
+	}
+}
+
+func TestLog_HTTPBeginRoundTrip_SampledFlag(t *testing.T) {
+	req := &model.Request{TraceID: model.TraceID{1}, SpanID: model.SpanID{2}, Traced: true}
+
+	log := NewTailSampledLog(EncodingNone, TailSampling{rand: func() float64 { return 0.5 }})
+	log.mu.Lock()
+	log.sampleLocked()
+	log.mu.Unlock()
+
+	httpReq := httptest.NewRequest("GET", "https://example.com", nil)
+	if _, err := log.HTTPBeginRoundTrip(httpReq, req, 1); err != nil {
+		t.Fatal(err)
+	}
+	if parent := httpReq.Header.Get("traceparent"); !strings.HasSuffix(parent, "-00") {
+		t.Errorf("got traceparent %q, want the trace flagged as not sampled", parent)
+	}
+}
@@ -18,7 +18,13 @@ type EventID = model.TraceEventID
 var nextEventID atomic.Uint64
 
 func NewLog() *Log {
-	l := &Log{}
+	return NewEncodedLog(EncodingNone)
+}
+
+// NewEncodedLog returns a log that compresses each batch of data
+// returned by GetAndClear and WaitAndClear using the given encoding.
+func NewEncodedLog(enc Encoding) *Log {
+	l := &Log{enc: enc}
 	l.cond = sync.NewCond(&l.mu)
 	return l
 }
@@ -28,6 +34,26 @@ type Log struct {
 	data []byte
 	done bool
 	cond *sync.Cond
+	enc  Encoding // immutable
+
+	// sampling is the tail sampling policy, or nil if the log isn't tail sampled.
+	// Until a sampling decision is made events are buffered in pending.
+	sampling *TailSampling // immutable
+	decision sampleDecision
+	pending  []byte
+
+	limits        BufferLimits // immutable once events are added
+	droppedEvents bool         // whether events have been dropped due to the limits
+
+	// spill holds the events that didn't fit in data, if spilling to disk.
+	// While it's non-nil new events are added to it to preserve their order,
+	// and its events are returned once data has been read.
+	spill *spillFile
+
+	suppressed EventTypeSet // event types to discard; immutable once events are added
+
+	// tees are the logs the added events are also copied to; see Tee.
+	tees atomic.Pointer[[]*Log] // copied on write
 }
 
 // Ensure Log implements Logger.
@@ -43,7 +69,7 @@ type Event struct {
 // Add adds a new event in the trace log.
 // If l is nil, it does nothing.
 func (l *Log) Add(e Event) EventID {
-	if l == nil {
+	if l == nil || l.suppressed.Has(e.Type) {
 		return 0
 	}
 
@@ -51,8 +77,10 @@ func (l *Log) Add(e Event) EventID {
 	ln := len(eventData)
 	if ln > (1<<32 - 1) {
 		println("encore.traceEvent: event too large, dropping")
+		stats.eventsDropped.Add(1)
 		return 0
 	}
+	stats.eventsWritten.Add(1)
 
 	eventID := nextEventID.Add(1)
 	if eventID == 0 {
@@ -61,6 +89,8 @@ func (l *Log) Add(e Event) EventID {
 		eventID = nextEventID.Add(1)
 	}
 
+	// Changes to the header layout must bump CurrentVersion,
+	// and be undone by Downgrade for collectors of older versions.
 	ts := signedToUnsigned(nanotime())
 	header := [...]byte{
 		// Event type, 1 byte
@@ -103,14 +133,48 @@ func (l *Log) Add(e Event) EventID {
 		byte(ln >> 24),
 	}
 
+	ev := AcquireEventBuffer(len(header) + ln)
+	ev.Bytes(header[:])
+	ev.Bytes(eventData)
+
 	l.mu.Lock()
-	l.data = append(l.data, append(header[:], eventData...)...)
+	switch l.decision {
+	case sampleUndecided:
+		l.pending = append(l.pending, ev.Buf()...)
+		if len(l.pending) > maxPendingSize {
+			// Keep traces too large to buffer rather than growing without bound.
+			l.keepLocked()
+		}
+	case sampleKept:
+		l.appendLocked(ev.Buf())
+	case sampleDropped:
+	}
 	l.mu.Unlock()
 	l.cond.Broadcast()
+	if tees := l.tees.Load(); tees != nil {
+		for _, dst := range *tees {
+			dst.addEvents(ev.Buf())
+		}
+	}
+	ev.Release()
 
 	return EventID(eventID)
 }
 
+// Tee makes the events added to l from then on be added to dst as well,
+// regardless of whether l's trace is sampled. Events already added to l
+// are not copied.
+func (l *Log) Tee(dst *Log) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var tees []*Log
+	if old := l.tees.Load(); old != nil {
+		tees = append(tees, *old...)
+	}
+	tees = append(tees, dst)
+	l.tees.Store(&tees)
+}
+
 func (l *Log) WaitUntilDone() {
 	l.mu.Lock()
 	for !l.done {
@@ -137,37 +201,67 @@ func (l *Log) WaitAtLeast(dur time.Duration) (done bool) {
 // and whether the log has been completed. It also clears the log from
 // any data it returns.
 func (l *Log) WaitAndClear() (data []byte, done bool) {
+	return l.waitAndClear(nil)
+}
+
+// waitAndClear is like WaitAndClear, but also stops waiting
+// once stop is set, if non-nil. It must be set while holding l.mu.
+func (l *Log) waitAndClear(stop *atomic.Bool) (data []byte, done bool) {
 	l.mu.Lock()
-	for len(l.data) == 0 && !l.done {
+	for len(l.data) == 0 && !l.spillReadyLocked() && !(l.done && l.spill == nil) && (stop == nil || !stop.Load()) {
 		l.cond.Wait()
 	}
-	done = l.done
-	data = l.data
-	l.clearDataBuf()
+	data, read, done := l.takeLocked()
 	l.mu.Unlock()
-	return data, done
+
+	// Read spilled events and compress outside the lock so events can keep being added.
+	if read != nil {
+		data = read()
+	}
+	return l.enc.encode(data), done
 }
 
 // MarkDone marks the log as done.
 func (l *Log) MarkDone() {
 	l.mu.Lock()
+	if l.decision == sampleUndecided {
+		l.sampleLocked()
+	}
 	l.done = true
 	l.mu.Unlock()
 	l.cond.Broadcast()
 }
 
-const (
-	maxBufferSize     = 100 * (10 << 20) // 100 MiB
-	initialBufferSize = 10 * (10 << 20)  // 10 MiB
-)
-
 // GetAndClear gets the data and clears the buffer.
 func (l *Log) GetAndClear() (data []byte, done bool) {
 	l.mu.Lock()
-	data, done = l.data, l.done
-	l.clearDataBuf()
+	data, read, done := l.takeLocked()
 	l.mu.Unlock()
-	return data, done
+	if read != nil {
+		data = read()
+	}
+	return l.enc.encode(data), done
+}
+
+// Encoding reports the encoding of the data returned by the log.
+func (l *Log) Encoding() Encoding {
+	return l.enc
+}
+
+// takeLocked takes the next batch of data from the log. Once the in-memory
+// data has been taken, it instead returns a function reading the next batch of
+// spilled events, to be called without holding l.mu. The log is reported as done
+// only once all its data has been taken.
+// l.mu must be held.
+func (l *Log) takeLocked() (data []byte, read func() []byte, done bool) {
+	if len(l.data) > 0 || l.spill == nil {
+		data = l.data
+		stats.bytesBuffered.Add(-int64(len(data)))
+		l.clearDataBuf()
+	} else {
+		read = l.readSpillLocked()
+	}
+	return data, read, l.done && l.spill == nil
 }
 
 // clearDataBuf clears the data buf, either allocating a new buffer
@@ -175,8 +269,16 @@ func (l *Log) GetAndClear() (data []byte, done bool) {
 func (l *Log) clearDataBuf() {
 	// Determine if we should keep growing the buffer or if it's time to
 	// create a new one to allow the old one to be GC'd.
-	if cap(l.data) > maxBufferSize {
-		l.data = make([]byte, 0, initialBufferSize)
+	maxSize, initialSize := l.limits.MaxSize, l.limits.InitialSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxBufferSize
+	}
+	if initialSize <= 0 {
+		initialSize = defaultInitialBufferSize
+	}
+	if cap(l.data) > maxSize {
+		l.data = make([]byte, 0, min(initialSize, maxSize))
+		stats.bufferReallocs.Add(1)
 	} else {
 		l.data = l.data[len(l.data):]
 	}
@@ -190,6 +292,7 @@ func (l *Log) clearDataBuf() {
 type EventBuffer struct {
 	scratch [10]byte
 	buf     []byte
+	pooled  *[]byte // set if acquired using AcquireEventBuffer
 }
 
 func NewEventBuffer(size int) EventBuffer {
@@ -2,6 +2,7 @@ package trace2
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"time"
 
@@ -19,6 +20,8 @@ type Logger interface {
 	WaitAtLeast(time.Duration) bool
 	GetAndClear() (data []byte, done bool)
 	WaitAndClear() (data []byte, done bool)
+	Reader() io.ReadCloser
+	Encoding() Encoding
 
 	RequestSpanStart(req *model.Request, goid uint32)
 	RequestSpanEnd(params RequestSpanEndParams)
@@ -26,12 +29,15 @@ type Logger interface {
 	AuthSpanEnd(params AuthSpanEndParams)
 	PubsubMessageSpanStart(req *model.Request, goid uint32)
 	PubsubMessageSpanEnd(params PubsubMessageSpanEndParams)
+	JobSpanStart(req *model.Request, goid uint32)
+	JobSpanEnd(params JobSpanEndParams)
 	TestSpanStart(req *model.Request, goid uint32)
 	TestSpanEnd(params TestSpanEndParams)
 	RPCCallStart(call *model.APICall, goid uint32) EventID
 	RPCCallEnd(call *model.APICall, goid uint32, err error)
 	DBQueryStart(p DBQueryStartParams) EventID
 	DBQueryEnd(EventParams, EventID, error)
+	DBQueryPlan(DBQueryPlanParams)
 	DBTransactionStart(EventParams, stack.Stack) EventID
 	DBTransactionEnd(DBTransactionEndParams)
 	PubsubPublishStart(PubsubPublishStartParams) EventID
@@ -41,7 +47,17 @@ type Logger interface {
 	CacheCallStart(CacheCallStartParams) EventID
 	CacheCallEnd(CacheCallEndParams)
 	BodyStream(BodyStreamParams)
+	WebSocketConnect(WebSocketConnectParams) EventID
+	WebSocketMessage(WebSocketMessageParams)
+	WebSocketClose(WebSocketCloseParams)
 	LogMessage(LogMessageParams)
+	CustomSpanStart(CustomSpanStartParams) EventID
+	CustomSpanEnd(CustomSpanEndParams)
+	CustomEvent(CustomEventParams)
+	SpanLink(SpanLinkParams)
+	FaultInjected(FaultInjectedParams)
+	SecretRead(SecretReadParams)
+	GoroutineSnapshot(GoroutineSnapshotParams)
 	HTTPBeginRoundTrip(httpReq *http.Request, req *model.Request, goid uint32) (context.Context, error)
 	HTTPCompleteRoundTrip(req *http.Request, resp *http.Response, goid uint32, err error)
 
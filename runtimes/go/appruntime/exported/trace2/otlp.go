@@ -0,0 +1,258 @@
+package trace2
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+const (
+	// maxBufferedSpans is the maximum number of spans to buffer.
+	// Spans completed while the buffer is full are dropped.
+	maxBufferedSpans = 10000
+
+	// maxSpanBatchSize is the maximum number of spans to export at once.
+	// A flush is triggered early when this many spans are buffered.
+	maxSpanBatchSize = 1000
+
+	otlpExportMethod = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+)
+
+// OTLPExporter exports traces to an OpenTelemetry collector.
+//
+// It implements trace streaming by converting the events of each trace log
+// into OTLP spans. Requests, auth handlers, Pub/Sub messages and tests
+// become spans, and the other events recorded during them become span events.
+type OTLPExporter struct {
+	ctx    context.Context
+	cancel func()
+
+	cfg      *config.OTLPTraceProvider
+	logger   zerolog.Logger
+	interval time.Duration
+
+	httpClient *http.Client     // for the "http" protocol
+	grpcConn   *grpc.ClientConn // for the "grpc" protocol
+
+	mu      sync.Mutex
+	buf     []*otlpSpan
+	dropped int
+	full    chan struct{} // signalled when a batch is ready to export
+
+	exportMu sync.Mutex // serializes exports
+}
+
+// NewOTLPExporter returns an exporter exporting traces as configured by cfg.
+func NewOTLPExporter(cfg *config.OTLPTraceProvider, logger zerolog.Logger) (*OTLPExporter, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	x := &OTLPExporter{
+		ctx:      ctx,
+		cancel:   cancel,
+		cfg:      cfg,
+		logger:   logger,
+		interval: 5 * time.Second,
+		full:     make(chan struct{}, 1),
+	}
+	if cfg.FlushInterval > 0 {
+		x.interval = cfg.FlushInterval
+	}
+
+	switch cfg.Protocol {
+	case "", "http":
+		x.httpClient = &http.Client{}
+	case "grpc":
+		creds := credentials.NewTLS(&tls.Config{})
+		if cfg.Insecure {
+			creds = insecure.NewCredentials()
+		}
+		conn, err := grpc.NewClient(cfg.Endpoint, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("unable to create gRPC client: %v", err)
+		}
+		x.grpcConn = conn
+	default:
+		cancel()
+		return nil, fmt.Errorf("unknown OTLP protocol %q", cfg.Protocol)
+	}
+	return x, nil
+}
+
+// StreamTrace consumes the trace log until it's done,
+// queueing the spans it contains to be exported.
+func (x *OTLPExporter) StreamTrace(log Logger) error {
+	conv := newSpanConverter(NewTimeAnchorNow())
+	var parseErr error
+	err := readBatches(log, func(data []byte) {
+		events, _ := readEvents(data)
+		for _, ev := range events {
+			if parseErr != nil {
+				// Keep consuming the log, but ignore the rest of the trace.
+				return
+			}
+			if sp, err := conv.add(ev); err != nil {
+				parseErr = fmt.Errorf("unable to parse %s event: %v", ev.Type, err)
+			} else if sp != nil {
+				x.queue(sp)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return parseErr
+}
+
+// queue queues a completed span to be exported.
+func (x *OTLPExporter) queue(sp *otlpSpan) {
+	x.mu.Lock()
+	if len(x.buf) >= maxBufferedSpans {
+		x.dropped++
+		x.mu.Unlock()
+		return
+	}
+	x.buf = append(x.buf, sp)
+	ready := len(x.buf) >= maxSpanBatchSize
+	x.mu.Unlock()
+
+	if ready {
+		select {
+		case x.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// BeginFlushing periodically exports the buffered spans until shut down.
+func (x *OTLPExporter) BeginFlushing() {
+	ticker := time.NewTicker(x.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-x.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-x.full:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), x.interval)
+		x.Flush(ctx)
+		cancel()
+	}
+}
+
+// Shutdown stops the periodic flushing and exports the remaining spans.
+func (x *OTLPExporter) Shutdown(ctx context.Context) error {
+	x.cancel()
+	x.Flush(ctx)
+	if x.grpcConn != nil {
+		return x.grpcConn.Close()
+	}
+	x.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// Flush exports the buffered spans.
+func (x *OTLPExporter) Flush(ctx context.Context) {
+	x.exportMu.Lock()
+	defer x.exportMu.Unlock()
+
+	x.mu.Lock()
+	spans, dropped := x.buf, x.dropped
+	x.buf, x.dropped = nil, 0
+	x.mu.Unlock()
+
+	if dropped > 0 {
+		x.logger.Warn().Int("dropped", dropped).Msg("trace export buffer full, dropped spans")
+	}
+
+	for len(spans) > 0 {
+		n := min(len(spans), maxSpanBatchSize)
+		start := time.Now()
+		if err := x.export(ctx, newExportRequest(spans[:n])); err != nil {
+			x.logger.Err(err).Int("spans", n).Msg("unable to export traces")
+		}
+		RecordFlush(time.Since(start))
+		spans = spans[n:]
+	}
+}
+
+// newExportRequest groups the spans by service, each being its own resource.
+func newExportRequest(spans []*otlpSpan) *otlpExportRequest {
+	var order []string
+	bySvc := make(map[string][]*otlpSpan)
+	for _, sp := range spans {
+		if _, ok := bySvc[sp.service]; !ok {
+			order = append(order, sp.service)
+		}
+		bySvc[sp.service] = append(bySvc[sp.service], sp)
+	}
+
+	req := &otlpExportRequest{ResourceSpans: make([]otlpResourceSpans, 0, len(order))}
+	for _, svc := range order {
+		var res otlpResource
+		if svc != "" {
+			res.Attributes = []otlpKeyValue{otlpStringAttr("service.name", svc)}
+		}
+		req.ResourceSpans = append(req.ResourceSpans, otlpResourceSpans{
+			Resource: res,
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "encore.dev"},
+				Spans: bySvc[svc],
+			}},
+		})
+	}
+	return req
+}
+
+func (x *OTLPExporter) export(ctx context.Context, req *otlpExportRequest) error {
+	if x.grpcConn != nil {
+		if len(x.cfg.Headers) > 0 {
+			ctx = metadata.NewOutgoingContext(ctx, metadata.New(x.cfg.Headers))
+		}
+		var resp otlpExportResponse
+		if err := x.grpcConn.Invoke(ctx, otlpExportMethod, req, &resp, grpc.ForceCodec(otlpCodec{})); err != nil {
+			return fmt.Errorf("unable to send traces to OTLP endpoint: %v", err)
+		}
+		return nil
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("unable to marshal traces: %v", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, x.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create HTTP request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "encore")
+	for k, v := range x.cfg.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := x.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("unable to send traces to OTLP endpoint: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unable to send traces to OTLP endpoint: %s: %s", resp.Status, msg)
+	}
+	return nil
+}
@@ -0,0 +1,469 @@
+package trace2
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+// eventHeaderSize is the size of the header preceding each event's data.
+// See (*Log).Add for the layout.
+const eventHeaderSize = 1 + 8 + 8 + 16 + 8 + 4
+
+// rawEvent is an event read from the binary trace log.
+type rawEvent struct {
+	Type    EventType
+	ID      EventID
+	Nano    int64 // nanotime() timestamp
+	TraceID model.TraceID
+	SpanID  model.SpanID
+	Data    []byte
+}
+
+// readEvents reads the complete events in buf,
+// returning them along with any incomplete trailing data.
+func readEvents(buf []byte) (events []rawEvent, rest []byte) {
+	for len(buf) >= eventHeaderSize {
+		ln := int(binary.LittleEndian.Uint32(buf[eventHeaderSize-4:]))
+		if len(buf) < eventHeaderSize+ln {
+			break
+		}
+
+		ev := rawEvent{
+			Type: EventType(buf[0]),
+			ID:   EventID(binary.LittleEndian.Uint64(buf[1:])),
+			Nano: unsignedToSigned(binary.LittleEndian.Uint64(buf[9:])),
+			Data: buf[eventHeaderSize : eventHeaderSize+ln],
+		}
+		copy(ev.TraceID[:], buf[17:33])
+		copy(ev.SpanID[:], buf[33:41])
+		events = append(events, ev)
+		buf = buf[eventHeaderSize+ln:]
+	}
+	return events, buf
+}
+
+func unsignedToSigned(u uint64) int64 {
+	x := int64(u >> 1)
+	if u&1 != 0 {
+		x = ^x
+	}
+	return x
+}
+
+// spanConverter converts the events of a trace log into OTLP spans.
+type spanConverter struct {
+	anchor TimeAnchor
+	open   map[model.SpanID]*otlpSpan
+	custom map[EventID]*customSpan // open custom spans, keyed by start event
+}
+
+// customSpan is a span started using the encore.dev/trace package.
+type customSpan struct {
+	*otlpSpan
+	parent model.SpanID
+}
+
+func newSpanConverter(anchor TimeAnchor) *spanConverter {
+	return &spanConverter{
+		anchor: anchor,
+		open:   make(map[model.SpanID]*otlpSpan),
+		custom: make(map[EventID]*customSpan),
+	}
+}
+
+// add adds an event to the span it belongs to, and returns
+// the span if the event completed it.
+func (c *spanConverter) add(ev rawEvent) (completed *otlpSpan, err error) {
+	r := &eventReader{buf: ev.Data}
+	switch ev.Type {
+	case RequestSpanStart, AuthSpanStart, PubsubMessageSpanStart, JobSpanStart, TestStart:
+		c.open[ev.SpanID] = c.startSpan(ev, r)
+	case RequestSpanEnd, AuthSpanEnd, PubsubMessageSpanEnd, JobSpanEnd, TestEnd:
+		if sp := c.open[ev.SpanID]; sp != nil {
+			delete(c.open, ev.SpanID)
+			c.endSpan(sp, ev, r)
+			completed = sp
+		}
+		// Custom spans that were never ended are not exported.
+		for id, sp := range c.custom {
+			if sp.parent == ev.SpanID {
+				delete(c.custom, id)
+			}
+		}
+	case CustomSpanStart:
+		if parent := c.open[ev.SpanID]; parent != nil {
+			c.custom[ev.ID] = c.startCustomSpan(parent, ev, r)
+		}
+	case CustomSpanEnd:
+		r.UVarint() // def loc
+		r.UVarint() // goid
+		startID := EventID(r.UVarint())
+		if sp := c.custom[startID]; sp != nil {
+			delete(c.custom, startID)
+			sp.EndTimeUnixNano = c.unixNano(ev.Nano)
+			if msg := r.String(); msg != "" {
+				sp.Status = otlpStatus{Code: otlpStatusError, Message: msg}
+			}
+			completed = sp.otlpSpan
+		}
+	case CustomEvent:
+		r.UVarint() // def loc
+		r.UVarint() // goid
+		spanStartID := EventID(r.UVarint())
+		e := otlpEvent{TimeUnixNano: c.unixNano(ev.Nano), Name: r.String()}
+		if sp := c.custom[spanStartID]; sp != nil {
+			sp.Events = append(sp.Events, e)
+		} else if sp := c.open[ev.SpanID]; sp != nil {
+			sp.Events = append(sp.Events, e)
+		}
+	case SpanLink:
+		r.UVarint() // def loc
+		r.UVarint() // goid
+		r.UVarint() // correlation event id
+		linkedTraceID, linkedSpanID := r.Bytes(len(model.TraceID{})), r.Bytes(len(model.SpanID{}))
+		if sp := c.open[ev.SpanID]; sp != nil && r.err == nil {
+			sp.Links = append(sp.Links, otlpLink{TraceID: otlpID(linkedTraceID), SpanID: otlpID(linkedSpanID)})
+		}
+	default:
+		if sp := c.open[ev.SpanID]; sp != nil {
+			sp.Events = append(sp.Events, c.spanEvent(ev, r))
+		}
+	}
+	return completed, r.err
+}
+
+func (c *spanConverter) startSpan(ev rawEvent, r *eventReader) *otlpSpan {
+	sp := &otlpSpan{
+		TraceID:           otlpID(ev.TraceID[:]),
+		SpanID:            otlpID(ev.SpanID[:]),
+		StartTimeUnixNano: c.unixNano(ev.Nano),
+	}
+
+	// Common span start data; see (*Log).newSpanStartEvent.
+	r.UVarint() // goid
+	var parentTraceID model.TraceID
+	var parentSpanID model.SpanID
+	copy(parentTraceID[:], r.Bytes(len(parentTraceID)))
+	copy(parentSpanID[:], r.Bytes(len(parentSpanID)))
+	r.UVarint()    // def loc
+	r.UVarint()    // caller event id
+	r.SkipString() // external correlation id
+
+	if !parentSpanID.IsZero() {
+		if parentTraceID == ev.TraceID {
+			sp.ParentSpanID = otlpID(parentSpanID[:])
+		} else {
+			sp.Links = append(sp.Links, otlpLink{TraceID: otlpID(parentTraceID[:]), SpanID: otlpID(parentSpanID[:])})
+		}
+	}
+
+	switch ev.Type {
+	case RequestSpanStart:
+		svc, ep, method, path := r.String(), r.String(), r.String(), r.String()
+		sp.Name, sp.Kind, sp.service = svc+"."+ep, otlpSpanKindServer, svc
+		sp.Attributes = append(sp.Attributes,
+			otlpStringAttr("encore.service", svc),
+			otlpStringAttr("encore.endpoint", ep),
+		)
+		if method != "" {
+			sp.Attributes = append(sp.Attributes,
+				otlpStringAttr("http.request.method", method),
+				otlpStringAttr("url.path", path),
+			)
+		}
+
+	case AuthSpanStart:
+		svc, ep := r.String(), r.String()
+		sp.Name, sp.Kind, sp.service = svc+"."+ep, otlpSpanKindInternal, svc
+		sp.Attributes = append(sp.Attributes,
+			otlpStringAttr("encore.service", svc),
+			otlpStringAttr("encore.endpoint", ep),
+			otlpBoolAttr("encore.auth_handler", true),
+		)
+
+	case PubsubMessageSpanStart:
+		svc, topic, sub, msgID := r.String(), r.String(), r.String(), r.String()
+		attempt := r.UVarint()
+		sp.Name, sp.Kind, sp.service = topic+" process", otlpSpanKindConsumer, svc
+		sp.Attributes = append(sp.Attributes,
+			otlpStringAttr("encore.service", svc),
+			otlpStringAttr("messaging.system", "encore"),
+			otlpStringAttr("messaging.destination.name", topic),
+			otlpStringAttr("messaging.consumer.group.name", sub),
+			otlpStringAttr("messaging.message.id", msgID),
+			otlpIntAttr("encore.pubsub.attempt", int64(attempt)),
+		)
+
+	case JobSpanStart:
+		svc, queue, jobID := r.String(), r.String(), r.String()
+		attempt := r.UVarint()
+		sp.Name, sp.Kind, sp.service = queue+" process", otlpSpanKindConsumer, svc
+		sp.Attributes = append(sp.Attributes,
+			otlpStringAttr("encore.worker.queue", queue),
+			otlpStringAttr("encore.worker.job_id", jobID),
+			otlpIntAttr("encore.worker.attempt", int64(attempt)),
+		)
+		if svc != "" {
+			sp.Attributes = append(sp.Attributes, otlpStringAttr("encore.service", svc))
+		}
+
+	case TestStart:
+		svc, name := r.String(), r.String()
+		r.SkipString() // user id
+		file, line := r.String(), r.Uint32()
+		sp.Name, sp.Kind, sp.service = name, otlpSpanKindInternal, svc
+		sp.Attributes = append(sp.Attributes,
+			otlpStringAttr("code.filepath", file),
+			otlpIntAttr("code.lineno", int64(line)),
+		)
+		if svc != "" {
+			sp.Attributes = append(sp.Attributes, otlpStringAttr("encore.service", svc))
+		}
+	}
+	return sp
+}
+
+// startCustomSpan starts a custom span as a child of the span it was started in.
+// Its span id is the id of its start event, which is unique within the process.
+func (c *spanConverter) startCustomSpan(parent *otlpSpan, ev rawEvent, r *eventReader) *customSpan {
+	r.UVarint() // def loc
+	r.UVarint() // goid
+	r.UVarint() // correlation event id
+
+	spanID := binary.LittleEndian.AppendUint64(nil, uint64(ev.ID))
+	sp := &otlpSpan{
+		TraceID:           otlpID(ev.TraceID[:]),
+		SpanID:            otlpID(spanID),
+		ParentSpanID:      otlpID(ev.SpanID[:]),
+		Name:              r.String(),
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: c.unixNano(ev.Nano),
+		service:           parent.service,
+	}
+	if sp.service != "" {
+		sp.Attributes = append(sp.Attributes, otlpStringAttr("encore.service", sp.service))
+	}
+	return &customSpan{otlpSpan: sp, parent: ev.SpanID}
+}
+
+func (c *spanConverter) endSpan(sp *otlpSpan, ev rawEvent, r *eventReader) {
+	sp.EndTimeUnixNano = c.unixNano(ev.Nano)
+
+	// Common span end data; see (*Log).newSpanEndEvent.
+	r.Varint() // duration
+	if msg := r.String(); msg != "" {
+		r.SkipStack()
+		sp.Status = otlpStatus{Code: otlpStatusError, Message: msg}
+	}
+
+	r.SkipFormattedStack()
+	r.Bytes(len(model.TraceID{}) + len(model.SpanID{})) // parent ids
+	for n := r.UVarint(); n > 0 && r.err == nil; n-- {
+		key, val := r.String(), r.String()
+		sp.Attributes = append(sp.Attributes, otlpStringAttr("baggage."+key, val))
+	}
+
+	if ev.Type == RequestSpanEnd {
+		// Skip the service and endpoint.
+		r.SkipString()
+		r.SkipString()
+		if status := r.UVarint(); status != 0 {
+			sp.Attributes = append(sp.Attributes, otlpIntAttr("http.response.status_code", int64(status)))
+		}
+	}
+}
+
+func (c *spanConverter) spanEvent(ev rawEvent, r *eventReader) otlpEvent {
+	e := otlpEvent{TimeUnixNano: c.unixNano(ev.Nano), Name: ev.Type.String()}
+	if ev.Type == LogMessage {
+		// Common event data; see (*Log).newEvent.
+		r.UVarint() // def loc
+		r.UVarint() // goid
+		r.UVarint() // correlation event id
+
+		level := model.LogLevel(r.Byte())
+		if msg := r.String(); r.err == nil {
+			e.Name = msg
+			e.Attributes = append(e.Attributes, otlpStringAttr("log.level", logLevelName(level)))
+		}
+	} else if ev.Type == DBQueryPlan {
+		r.UVarint() // def loc
+		r.UVarint() // goid
+		r.UVarint() // correlation event id
+
+		if plan := r.Bytes(int(r.UVarint())); r.err == nil {
+			e.Name = "query plan"
+			e.Attributes = append(e.Attributes, otlpStringAttr("db.query.plan", string(plan)))
+		}
+	} else if ev.Type == FaultInjected {
+		r.UVarint() // def loc
+		r.UVarint() // goid
+		r.UVarint() // correlation event id
+
+		target, service, endpoint, resource := r.String(), r.String(), r.String(), r.String()
+		latency, code, drop := time.Duration(r.Varint()), r.String(), r.Byte() != 0
+		if r.err == nil {
+			e.Name = "fault injected"
+			e.Attributes = append(e.Attributes, otlpStringAttr("encore.fault.target", target))
+			for _, attr := range []struct{ key, val string }{
+				{"encore.fault.service", service},
+				{"encore.fault.endpoint", endpoint},
+				{"encore.fault.resource", resource},
+				{"encore.fault.error_code", code},
+			} {
+				if attr.val != "" {
+					e.Attributes = append(e.Attributes, otlpStringAttr(attr.key, attr.val))
+				}
+			}
+			if latency > 0 {
+				e.Attributes = append(e.Attributes, otlpIntAttr("encore.fault.latency_ms", latency.Milliseconds()))
+			}
+			if drop {
+				e.Attributes = append(e.Attributes, otlpBoolAttr("encore.fault.drop", true))
+			}
+		}
+	} else if ev.Type == SecretRead {
+		r.UVarint() // def loc
+		r.UVarint() // goid
+		r.UVarint() // correlation event id
+
+		service, endpoint, secret := r.String(), r.String(), r.String()
+		if r.err == nil {
+			e.Name = "secret read"
+			e.Attributes = append(e.Attributes,
+				otlpStringAttr("encore.secret.service", service),
+				otlpStringAttr("encore.secret.name", secret))
+			if endpoint != "" {
+				e.Attributes = append(e.Attributes, otlpStringAttr("encore.secret.endpoint", endpoint))
+			}
+		}
+	} else if ev.Type == GoroutineSnapshot {
+		r.UVarint() // def loc
+		r.UVarint() // goid
+		r.UVarint() // correlation event id
+
+		r.Varint() // elapsed
+		total := r.UVarint()
+		if stacks := readGoroutineStacks(r); r.err == nil {
+			e.Name = "goroutine snapshot"
+			e.Attributes = append(e.Attributes,
+				otlpIntAttr("encore.goroutines.total", int64(total)),
+				otlpStringAttr("encore.goroutines.stacks", stacks),
+			)
+		}
+	}
+	return e
+}
+
+// readGoroutineStacks reads the stacks of a GoroutineSnapshot event,
+// formatting them as text in the style of a goroutine profile.
+func readGoroutineStacks(r *eventReader) string {
+	var b strings.Builder
+	for n := r.UVarint(); n > 0 && r.err == nil; n-- {
+		fmt.Fprintf(&b, "%d @\n", r.UVarint())
+		for frames := r.Byte(); frames > 0 && r.err == nil; frames-- {
+			file, line, fn := r.String(), r.UVarint(), r.String()
+			fmt.Fprintf(&b, "#\t%s\n#\t\t%s:%d\n", fn, file, line)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func (c *spanConverter) unixNano(nano int64) otlpUint64 {
+	return otlpUint64(c.anchor.ToReal(nano).UnixNano())
+}
+
+func logLevelName(level model.LogLevel) string {
+	switch level {
+	case model.LevelDebug:
+		return "debug"
+	case model.LevelInfo:
+		return "info"
+	case model.LevelWarn:
+		return "warn"
+	case model.LevelError:
+		return "error"
+	default:
+		return "trace"
+	}
+}
+
+var errShortEvent = errors.New("trace2: event data too short")
+
+// eventReader reads the values written to an EventBuffer.
+// Reading past the end of the data sets err and returns zero values.
+type eventReader struct {
+	buf []byte
+	err error
+}
+
+func (r *eventReader) Bytes(n int) []byte {
+	if r.err != nil || n < 0 || len(r.buf) < n {
+		r.err = errShortEvent
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *eventReader) Byte() byte {
+	if b := r.Bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *eventReader) Uint32() uint32 {
+	if b := r.Bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *eventReader) UVarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	u, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errShortEvent
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return u
+}
+
+func (r *eventReader) Varint() int64 {
+	return unsignedToSigned(r.UVarint())
+}
+
+func (r *eventReader) String() string {
+	return string(r.Bytes(int(r.UVarint())))
+}
+
+func (r *eventReader) SkipString() {
+	r.Bytes(int(r.UVarint()))
+}
+
+// SkipStack skips a stack written using (*EventBuffer).Stack.
+func (r *eventReader) SkipStack() {
+	for n := r.Byte(); n > 0 && r.err == nil; n-- {
+		r.Varint()
+	}
+}
+
+// SkipFormattedStack skips a stack written using (*EventBuffer).FormattedStack.
+func (r *eventReader) SkipFormattedStack() {
+	for n := r.Byte(); n > 0 && r.err == nil; n-- {
+		r.SkipString() // file
+		r.UVarint()    // line
+		r.SkipString() // func
+	}
+}
@@ -0,0 +1,492 @@
+package trace2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/beta/errs"
+)
+
+// writeTestTrace writes a trace with a request span
+// calling an endpoint that logs a message and fails.
+func writeTestTrace(log *Log) {
+	traceID, parentSpan, spanID := model.TraceID{1}, model.SpanID{1}, model.SpanID{2}
+	req := &model.Request{
+		Type:          model.RPCCall,
+		TraceID:       traceID,
+		SpanID:        spanID,
+		ParentTraceID: traceID,
+		ParentSpanID:  parentSpan,
+		RPCData: &model.RPCData{
+			Desc:       &model.RPCDesc{Service: "users", Endpoint: "Get"},
+			HTTPMethod: "GET",
+			Path:       "/users/1",
+		},
+	}
+	log.RequestSpanStart(req, 1)
+	log.LogMessage(LogMessageParams{
+		EventParams: EventParams{TraceID: traceID, SpanID: spanID},
+		Level:       model.LevelWarn,
+		Msg:         "user not found",
+	})
+	log.RequestSpanEnd(RequestSpanEndParams{
+		EventParams: EventParams{TraceID: traceID, SpanID: spanID},
+		Req:         req,
+		Resp: &model.Response{
+			HTTPStatus: 404,
+			Err:        errs.B().Code(errs.NotFound).Msg("user not found").Err(),
+			Duration:   time.Millisecond,
+		},
+	})
+
+	// Spans that are never completed are not exported.
+	log.AuthSpanStart(&model.Request{
+		TraceID: traceID,
+		SpanID:  model.SpanID{3},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "auth", Endpoint: "Auth"}},
+	}, 2)
+	log.MarkDone()
+}
+
+func TestOTLPExporter_HTTP(t *testing.T) {
+	requests := make(chan map[string]any, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if got := req.Header.Get("Authorization"); got != "secret" {
+			t.Errorf("got authorization header %q, want %q", got, "secret")
+		}
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		requests <- body
+	}))
+	defer srv.Close()
+
+	exp, err := NewOTLPExporter(&config.OTLPTraceProvider{
+		Endpoint: srv.URL,
+		Headers:  map[string]string{"Authorization": "secret"},
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = exp.Shutdown(context.Background()) }()
+
+	// Compressed trace data is decoded before it's parsed.
+	log := NewEncodedLog(EncodingZstd)
+	writeTestTrace(log)
+	if err := exp.StreamTrace(log); err != nil {
+		t.Fatal(err)
+	}
+	exp.Flush(context.Background())
+
+	var body map[string]any
+	select {
+	case body = <-requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no spans exported")
+	}
+
+	var got struct {
+		ResourceSpans []struct {
+			Resource   struct{ Attributes []map[string]any }
+			ScopeSpans []struct{ Spans []map[string]any }
+		}
+	}
+	data, _ := json.Marshal(body)
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.ResourceSpans) != 1 || len(got.ResourceSpans[0].ScopeSpans) != 1 || len(got.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("got %s, want a single span", data)
+	}
+	if attrs := got.ResourceSpans[0].Resource.Attributes; len(attrs) != 1 || attrs[0]["key"] != "service.name" {
+		t.Errorf("got resource attributes %v, want service.name", attrs)
+	}
+
+	sp := got.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	for key, want := range map[string]any{
+		"traceId":      "01000000000000000000000000000000",
+		"spanId":       "0200000000000000",
+		"parentSpanId": "0100000000000000",
+		"name":         "users.Get",
+		"kind":         float64(otlpSpanKindServer),
+	} {
+		if sp[key] != want {
+			t.Errorf("got span %s %v, want %v", key, sp[key], want)
+		}
+	}
+	if status := sp["status"].(map[string]any); status["code"] != float64(otlpStatusError) || status["message"] != "not_found: user not found" {
+		t.Errorf("got span status %v", status)
+	}
+	if events := sp["events"].([]any); len(events) != 1 || events[0].(map[string]any)["name"] != "user not found" {
+		t.Errorf("got span events %v", events)
+	}
+}
+
+// rawMessage captures the raw protobuf message received by a gRPC server.
+type rawMessage struct{ data []byte }
+
+type rawCodec struct{}
+
+func (rawCodec) Name() string { return "proto" }
+
+func (rawCodec) Marshal(v any) ([]byte, error) { return nil, nil }
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(*rawMessage)
+	if !ok {
+		return errors.New("unexpected message")
+	}
+	m.data = append([]byte(nil), data...)
+	return nil
+}
+
+func TestOTLPExporter_GRPC(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := make(chan []byte, 1)
+	srv := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}), grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+		if method, _ := grpc.MethodFromServerStream(stream); method != otlpExportMethod {
+			return errors.New("unknown method")
+		}
+		if md, _ := metadata.FromIncomingContext(stream.Context()); len(md.Get("x-api-key")) != 1 {
+			t.Errorf("got metadata %v, want x-api-key", md)
+		}
+		var msg rawMessage
+		if err := stream.RecvMsg(&msg); err != nil {
+			return err
+		}
+		requests <- msg.data
+		return stream.SendMsg(&otlpExportResponse{})
+	}))
+	go func() { _ = srv.Serve(ln) }()
+	defer srv.Stop()
+
+	exp, err := NewOTLPExporter(&config.OTLPTraceProvider{
+		Endpoint: ln.Addr().String(),
+		Protocol: "grpc",
+		Insecure: true,
+		Headers:  map[string]string{"X-Api-Key": "secret"},
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = exp.Shutdown(context.Background()) }()
+
+	log := NewLog()
+	writeTestTrace(log)
+	if err := exp.StreamTrace(log); err != nil {
+		t.Fatal(err)
+	}
+	exp.Flush(context.Background())
+
+	var data []byte
+	select {
+	case data = <-requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no spans exported")
+	}
+
+	// ExportTraceServiceRequest.resource_spans[0].scope_spans[0].spans[0].name
+	span := protoField(t, protoField(t, protoField(t, data, 1), 2), 2)
+	if name := string(protoField(t, span, 5)); name != "users.Get" {
+		t.Errorf("got span name %q, want %q", name, "users.Get")
+	}
+}
+
+// protoField returns the first length-delimited field with the given number in b.
+func protoField(t *testing.T, b []byte, num protowire.Number) []byte {
+	t.Helper()
+	for len(b) > 0 {
+		n, typ, tagLen := protowire.ConsumeTag(b)
+		if tagLen < 0 {
+			t.Fatalf("invalid tag: %v", protowire.ParseError(tagLen))
+		}
+		b = b[tagLen:]
+		valLen := protowire.ConsumeFieldValue(n, typ, b)
+		if valLen < 0 {
+			t.Fatalf("invalid field: %v", protowire.ParseError(valLen))
+		}
+		if n == num && typ == protowire.BytesType {
+			val, _ := protowire.ConsumeBytes(b)
+			return val
+		}
+		b = b[valLen:]
+	}
+	t.Fatalf("field %d not found", num)
+	return nil
+}
+
+func TestReadEvents_Partial(t *testing.T) {
+	log := NewLog()
+	writeTestTrace(log)
+	data, _ := log.GetAndClear()
+
+	all, rest := readEvents(data)
+	if len(all) != 4 || len(rest) != 0 {
+		t.Fatalf("got %d events and %d remaining bytes, want 4 and 0", len(all), len(rest))
+	}
+
+	// Incomplete events are returned as the remaining data.
+	events, rest := readEvents(data[:len(data)-1])
+	if len(events) != 3 || len(rest) == 0 {
+		t.Fatalf("got %d events and %d remaining bytes, want 3 and some", len(events), len(rest))
+	}
+}
+
+func TestSpanConverter_CustomSpans(t *testing.T) {
+	log := NewLog()
+	req := &model.Request{
+		TraceID: model.TraceID{1},
+		SpanID:  model.SpanID{1},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "billing", Endpoint: "Charge"}},
+	}
+	ep := EventParams{TraceID: req.TraceID, SpanID: req.SpanID}
+	log.RequestSpanStart(req, 1)
+	startID := log.CustomSpanStart(CustomSpanStartParams{EventParams: ep, Name: "charge card"})
+	log.CustomEvent(CustomEventParams{EventParams: ep, SpanStartID: startID, Name: "card declined"})
+	log.CustomSpanEnd(CustomSpanEndParams{EventParams: ep, StartID: startID, Err: errors.New("declined")})
+	log.RequestSpanEnd(RequestSpanEndParams{EventParams: ep, Req: req, Resp: &model.Response{}})
+
+	data, _ := log.GetAndClear()
+	events, _ := readEvents(data)
+	conv := newSpanConverter(NewTimeAnchorNow())
+	var spans []*otlpSpan
+	for _, ev := range events {
+		sp, err := conv.add(ev)
+		if err != nil {
+			t.Fatal(err)
+		} else if sp != nil {
+			spans = append(spans, sp)
+		}
+	}
+
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	custom, parent := spans[0], spans[1]
+	if custom.Name != "charge card" || string(custom.ParentSpanID) != string(parent.SpanID) || custom.service != "billing" {
+		t.Errorf("got custom span %+v, want a child of the request span", custom)
+	}
+	if custom.Status.Code != otlpStatusError || custom.Status.Message != "declined" {
+		t.Errorf("got custom span status %+v", custom.Status)
+	}
+	if len(custom.Events) != 1 || custom.Events[0].Name != "card declined" || len(parent.Events) != 0 {
+		t.Errorf("got events %+v and %+v, want the custom event in the custom span", custom.Events, parent.Events)
+	}
+}
+
+func TestSpanConverter_SpanLink(t *testing.T) {
+	log := NewLog()
+	req := &model.Request{
+		TraceID:       model.TraceID{2},
+		SpanID:        model.SpanID{2},
+		ParentTraceID: model.TraceID{1},
+		MsgData:       &model.PubSubMsgData{Service: "email", Topic: "signups", Subscription: "welcome"},
+		Baggage:       model.NewBaggage(map[string]string{"tenant": "acme"}),
+	}
+	ep := EventParams{TraceID: req.TraceID, SpanID: req.SpanID}
+	log.PubsubMessageSpanStart(req, 1)
+	log.SpanLink(SpanLinkParams{EventParams: ep, LinkedTraceID: model.TraceID{1}, LinkedSpanID: model.SpanID{1}})
+	log.PubsubMessageSpanEnd(PubsubMessageSpanEndParams{EventParams: ep, Req: req, Resp: &model.Response{}})
+
+	data, _ := log.GetAndClear()
+	events, _ := readEvents(data)
+	conv := newSpanConverter(NewTimeAnchorNow())
+	var sp *otlpSpan
+	for _, ev := range events {
+		completed, err := conv.add(ev)
+		if err != nil {
+			t.Fatal(err)
+		} else if completed != nil {
+			sp = completed
+		}
+	}
+
+	if sp == nil || len(sp.Links) != 1 || len(sp.Events) != 0 {
+		t.Fatalf("got span %+v, want a span with one link and no events", sp)
+	}
+	link := sp.Links[0]
+	if string(link.TraceID) != string(req.ParentTraceID[:]) || string(link.SpanID) != "\x01\x00\x00\x00\x00\x00\x00\x00" {
+		t.Errorf("got link %+v, want the publishing span", link)
+	}
+	if len(sp.ParentSpanID) != 0 {
+		t.Errorf("got parent span %x, want none", sp.ParentSpanID)
+	}
+	if attr := sp.Attributes[len(sp.Attributes)-1]; attr.Key != "baggage.tenant" || attr.Value.StringValue == nil || *attr.Value.StringValue != "acme" {
+		t.Errorf("got attribute %+v, want the baggage", attr)
+	}
+}
+
+func TestSpanConverter_GoroutineSnapshot(t *testing.T) {
+	log := NewLog()
+	req := &model.Request{
+		TraceID: model.TraceID{1},
+		SpanID:  model.SpanID{1},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Slow"}},
+	}
+	ep := EventParams{TraceID: req.TraceID, SpanID: req.SpanID}
+	log.RequestSpanStart(req, 1)
+	_, stacks := CaptureGoroutines(1)
+	log.GoroutineSnapshot(GoroutineSnapshotParams{EventParams: ep, Elapsed: time.Second, Total: 4, Stacks: stacks})
+	log.RequestSpanEnd(RequestSpanEndParams{EventParams: ep, Req: req, Resp: &model.Response{}})
+
+	data, _ := log.GetAndClear()
+	events, _ := readEvents(data)
+	conv := newSpanConverter(NewTimeAnchorNow())
+	var sp *otlpSpan
+	for _, ev := range events {
+		completed, err := conv.add(ev)
+		if err != nil {
+			t.Fatal(err)
+		} else if completed != nil {
+			sp = completed
+		}
+	}
+
+	if sp == nil || len(sp.Events) != 1 {
+		t.Fatalf("got span %+v, want a span with one event", sp)
+	}
+	e := sp.Events[0]
+	if e.Name != "goroutine snapshot" || len(e.Attributes) != 2 {
+		t.Fatalf("got event %+v, want a goroutine snapshot", e)
+	}
+	if got := e.Attributes[1].Value.StringValue; got == nil || !strings.Contains(*got, " @\n#\t") {
+		t.Errorf("got stacks %v, want the formatted stacks", got)
+	}
+}
+
+func TestSpanConverter_DBQueryPlan(t *testing.T) {
+	log := NewLog()
+	req := &model.Request{
+		TraceID: model.TraceID{1},
+		SpanID:  model.SpanID{1},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Slow"}},
+	}
+	ep := EventParams{TraceID: req.TraceID, SpanID: req.SpanID}
+	log.RequestSpanStart(req, 1)
+	queryID := log.DBQueryStart(DBQueryStartParams{EventParams: ep, Query: "SELECT 1"})
+	log.DBQueryEnd(ep, queryID, nil)
+	log.DBQueryPlan(DBQueryPlanParams{EventParams: ep, QueryID: queryID, Plan: []byte(`[{"Plan":{"Node Type":"Result"}}]`)})
+	log.RequestSpanEnd(RequestSpanEndParams{EventParams: ep, Req: req, Resp: &model.Response{}})
+
+	data, _ := log.GetAndClear()
+	events, _ := readEvents(data)
+	conv := newSpanConverter(NewTimeAnchorNow())
+	var sp *otlpSpan
+	for _, ev := range events {
+		completed, err := conv.add(ev)
+		if err != nil {
+			t.Fatal(err)
+		} else if completed != nil {
+			sp = completed
+		}
+	}
+
+	if sp == nil || len(sp.Events) != 3 {
+		t.Fatalf("got span %+v, want a span with three events", sp)
+	}
+	e := sp.Events[2]
+	if e.Name != "query plan" || len(e.Attributes) != 1 || *e.Attributes[0].Value.StringValue != `[{"Plan":{"Node Type":"Result"}}]` {
+		t.Errorf("got event %+v, want the query plan", e)
+	}
+}
+
+func TestSpanConverter_FaultInjected(t *testing.T) {
+	log := NewLog()
+	req := &model.Request{
+		TraceID: model.TraceID{1},
+		SpanID:  model.SpanID{1},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Place"}},
+	}
+	ep := EventParams{TraceID: req.TraceID, SpanID: req.SpanID}
+	log.RequestSpanStart(req, 1)
+	log.FaultInjected(FaultInjectedParams{EventParams: ep, Target: "endpoint", Service: "svc", Endpoint: "Place", Latency: 2 * time.Second, Drop: true})
+	log.RequestSpanEnd(RequestSpanEndParams{EventParams: ep, Req: req, Resp: &model.Response{}})
+
+	data, _ := log.GetAndClear()
+	events, _ := readEvents(data)
+	conv := newSpanConverter(NewTimeAnchorNow())
+	var sp *otlpSpan
+	for _, ev := range events {
+		completed, err := conv.add(ev)
+		if err != nil {
+			t.Fatal(err)
+		} else if completed != nil {
+			sp = completed
+		}
+	}
+
+	if sp == nil || len(sp.Events) != 1 {
+		t.Fatalf("got span %+v, want a span with one event", sp)
+	}
+	e := sp.Events[0]
+	attrs := make(map[string]otlpAnyValue)
+	for _, a := range e.Attributes {
+		attrs[a.Key] = a.Value
+	}
+	if e.Name != "fault injected" || len(attrs) != 5 ||
+		*attrs["encore.fault.target"].StringValue != "endpoint" ||
+		*attrs["encore.fault.endpoint"].StringValue != "Place" ||
+		*attrs["encore.fault.latency_ms"].IntValue != 2000 ||
+		!*attrs["encore.fault.drop"].BoolValue {
+		t.Errorf("got event %+v, want the injected fault", e)
+	}
+}
+
+func TestSpanConverter_SecretRead(t *testing.T) {
+	log := NewLog()
+	req := &model.Request{
+		TraceID: model.TraceID{1},
+		SpanID:  model.SpanID{1},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Charge"}},
+	}
+	ep := EventParams{TraceID: req.TraceID, SpanID: req.SpanID}
+	log.RequestSpanStart(req, 1)
+	log.SecretRead(SecretReadParams{EventParams: ep, Service: "svc", Endpoint: "Charge", Secret: "Token"})
+	log.RequestSpanEnd(RequestSpanEndParams{EventParams: ep, Req: req, Resp: &model.Response{}})
+
+	data, _ := log.GetAndClear()
+	events, _ := readEvents(data)
+	conv := newSpanConverter(NewTimeAnchorNow())
+	var sp *otlpSpan
+	for _, ev := range events {
+		completed, err := conv.add(ev)
+		if err != nil {
+			t.Fatal(err)
+		} else if completed != nil {
+			sp = completed
+		}
+	}
+
+	if sp == nil || len(sp.Events) != 1 {
+		t.Fatalf("got span %+v, want a span with one event", sp)
+	}
+	e := sp.Events[0]
+	attrs := make(map[string]otlpAnyValue)
+	for _, a := range e.Attributes {
+		attrs[a.Key] = a.Value
+	}
+	if e.Name != "secret read" || len(attrs) != 3 ||
+		*attrs["encore.secret.service"].StringValue != "svc" ||
+		*attrs["encore.secret.endpoint"].StringValue != "Charge" ||
+		*attrs["encore.secret.name"].StringValue != "Token" {
+		t.Errorf("got event %+v, want the secret read", e)
+	}
+}
@@ -0,0 +1,297 @@
+package trace2
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The types below mirror the OTLP trace protobuf messages. They are encoded
+// either as JSON, for OTLP/HTTP, or as protobuf using hand-written marshalers,
+// for OTLP/gRPC, to avoid depending on the generated OpenTelemetry types.
+// The JSON encoding follows the OTLP specification: ids are hex-encoded
+// and 64-bit integers are encoded as strings.
+
+type otlpExportRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+func (r *otlpExportRequest) marshal(b []byte) []byte {
+	for i := range r.ResourceSpans {
+		b = appendMessage(b, 1, r.ResourceSpans[i].marshal)
+	}
+	return b
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+func (r *otlpResourceSpans) marshal(b []byte) []byte {
+	b = appendMessage(b, 1, r.Resource.marshal)
+	for i := range r.ScopeSpans {
+		b = appendMessage(b, 2, r.ScopeSpans[i].marshal)
+	}
+	return b
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+func (r *otlpResource) marshal(b []byte) []byte {
+	return appendAttrs(b, 1, r.Attributes)
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope   `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+func (s *otlpScopeSpans) marshal(b []byte) []byte {
+	b = appendMessage(b, 1, s.Scope.marshal)
+	for _, sp := range s.Spans {
+		b = appendMessage(b, 2, sp.marshal)
+	}
+	return b
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+func (s *otlpScope) marshal(b []byte) []byte {
+	return appendString(b, 1, s.Name)
+}
+
+// otlpSpanKind is the kind of span, as defined by OTLP.
+type otlpSpanKind int
+
+const (
+	otlpSpanKindInternal otlpSpanKind = 1
+	otlpSpanKindServer   otlpSpanKind = 2
+	otlpSpanKindConsumer otlpSpanKind = 5
+)
+
+type otlpSpan struct {
+	TraceID           otlpID         `json:"traceId"`
+	SpanID            otlpID         `json:"spanId"`
+	ParentSpanID      otlpID         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              otlpSpanKind   `json:"kind"`
+	StartTimeUnixNano otlpUint64     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   otlpUint64     `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Links             []otlpLink     `json:"links,omitempty"`
+	Status            otlpStatus     `json:"status"`
+
+	service string // the service the span belongs to, if any
+}
+
+func (s *otlpSpan) marshal(b []byte) []byte {
+	b = appendBytes(b, 1, s.TraceID)
+	b = appendBytes(b, 2, s.SpanID)
+	b = appendBytes(b, 4, s.ParentSpanID)
+	b = appendString(b, 5, s.Name)
+	b = appendVarint(b, 6, uint64(s.Kind))
+	b = appendFixed64(b, 7, uint64(s.StartTimeUnixNano))
+	b = appendFixed64(b, 8, uint64(s.EndTimeUnixNano))
+	b = appendAttrs(b, 9, s.Attributes)
+	for i := range s.Events {
+		b = appendMessage(b, 11, s.Events[i].marshal)
+	}
+	for i := range s.Links {
+		b = appendMessage(b, 13, s.Links[i].marshal)
+	}
+	b = appendMessage(b, 15, s.Status.marshal)
+	return b
+}
+
+type otlpEvent struct {
+	TimeUnixNano otlpUint64     `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+func (e *otlpEvent) marshal(b []byte) []byte {
+	b = appendFixed64(b, 1, uint64(e.TimeUnixNano))
+	b = appendString(b, 2, e.Name)
+	b = appendAttrs(b, 3, e.Attributes)
+	return b
+}
+
+type otlpLink struct {
+	TraceID otlpID `json:"traceId"`
+	SpanID  otlpID `json:"spanId"`
+}
+
+func (l *otlpLink) marshal(b []byte) []byte {
+	b = appendBytes(b, 1, l.TraceID)
+	b = appendBytes(b, 2, l.SpanID)
+	return b
+}
+
+// otlpStatusCode is the status of a span, as defined by OTLP.
+type otlpStatusCode int
+
+const (
+	otlpStatusUnset otlpStatusCode = 0
+	otlpStatusOK    otlpStatusCode = 1
+	otlpStatusError otlpStatusCode = 2
+)
+
+type otlpStatus struct {
+	Message string         `json:"message,omitempty"`
+	Code    otlpStatusCode `json:"code,omitempty"`
+}
+
+func (s *otlpStatus) marshal(b []byte) []byte {
+	b = appendString(b, 2, s.Message)
+	b = appendVarint(b, 3, uint64(s.Code))
+	return b
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+func (kv *otlpKeyValue) marshal(b []byte) []byte {
+	b = appendString(b, 1, kv.Key)
+	b = appendMessage(b, 2, kv.Value.marshal)
+	return b
+}
+
+// otlpAnyValue is an OTLP value, of which exactly one field is set.
+type otlpAnyValue struct {
+	StringValue *string    `json:"stringValue,omitempty"`
+	BoolValue   *bool      `json:"boolValue,omitempty"`
+	IntValue    *otlpInt64 `json:"intValue,omitempty"`
+}
+
+func (v *otlpAnyValue) marshal(b []byte) []byte {
+	switch {
+	case v.StringValue != nil:
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, *v.StringValue)
+	case v.BoolValue != nil:
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(*v.BoolValue))
+	case v.IntValue != nil:
+		b = protowire.AppendTag(b, 3, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(*v.IntValue))
+	}
+	return b
+}
+
+func otlpStringAttr(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func otlpIntAttr(key string, value int64) otlpKeyValue {
+	v := otlpInt64(value)
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{IntValue: &v}}
+}
+
+func otlpBoolAttr(key string, value bool) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{BoolValue: &value}}
+}
+
+// otlpID is a trace or span id, hex-encoded in JSON.
+type otlpID []byte
+
+func (id otlpID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + hex.EncodeToString(id) + `"`), nil
+}
+
+// otlpUint64 is a 64-bit unsigned integer, encoded as a string in JSON.
+type otlpUint64 uint64
+
+func (u otlpUint64) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + strconv.FormatUint(uint64(u), 10) + `"`), nil
+}
+
+// otlpInt64 is a 64-bit integer, encoded as a string in JSON.
+type otlpInt64 int64
+
+func (i otlpInt64) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + strconv.FormatInt(int64(i), 10) + `"`), nil
+}
+
+// otlpExportResponse is the response to an OTLP/gRPC export.
+// Its contents are ignored.
+type otlpExportResponse struct{}
+
+// otlpMessage is implemented by the messages sent and received over OTLP/gRPC.
+type otlpMessage interface {
+	marshal(b []byte) []byte
+}
+
+// otlpCodec is a gRPC codec for OTLP messages.
+type otlpCodec struct{}
+
+func (otlpCodec) Name() string { return "proto" }
+
+func (otlpCodec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(otlpMessage)
+	if !ok {
+		return nil, fmt.Errorf("otlp: cannot marshal %T", v)
+	}
+	return m.marshal(nil), nil
+}
+
+func (otlpCodec) Unmarshal(data []byte, v any) error {
+	if _, ok := v.(*otlpExportResponse); !ok {
+		return fmt.Errorf("otlp: cannot unmarshal into %T", v)
+	}
+	return nil
+}
+
+func appendMessage(b []byte, num protowire.Number, marshal func([]byte) []byte) []byte {
+	msg := marshal(nil)
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func appendAttrs(b []byte, num protowire.Number, attrs []otlpKeyValue) []byte {
+	for i := range attrs {
+		b = appendMessage(b, num, attrs[i].marshal)
+	}
+	return b
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendFixed64(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, v)
+}
@@ -0,0 +1,55 @@
+package trace2
+
+import "sync"
+
+// eventBufferPool pools the backing slices of event buffers,
+// which are only needed until the event has been added to a log.
+// It stores pointers so that putting a slice doesn't allocate.
+var eventBufferPool = sync.Pool{
+	New: func() any { return new([]byte) },
+}
+
+// maxPooledBufferSize is the largest capacity of a buffer kept in the pool,
+// so that the occasional large event doesn't keep its memory alive.
+const maxPooledBufferSize = 64 << 10
+
+// AcquireEventBuffer returns an empty EventBuffer with a capacity of at
+// least size bytes, reusing the memory of released buffers when possible.
+//
+// Once the buffer is no longer needed, typically once the event has been
+// added to a log, it should be returned using Release.
+func AcquireEventBuffer(size int) EventBuffer {
+	p := eventBufferPool.Get().(*[]byte)
+	buf := (*p)[:0]
+	if cap(buf) < size {
+		buf = make([]byte, 0, size)
+	}
+	return EventBuffer{buf: buf, pooled: p}
+}
+
+// Release returns the memory of a buffer acquired using AcquireEventBuffer
+// to the pool. The buffer is empty afterwards, and the data previously
+// returned by Buf must no longer be used.
+//
+// Releasing a buffer that wasn't acquired using AcquireEventBuffer,
+// or that has already been released, does nothing.
+func (tb *EventBuffer) Release() {
+	p := tb.pooled
+	if p == nil {
+		return
+	}
+	if cap(tb.buf) <= maxPooledBufferSize {
+		*p = tb.buf[:0]
+	} else {
+		*p = nil
+	}
+	eventBufferPool.Put(p)
+	tb.buf, tb.pooled = nil, nil
+}
+
+// addAndRelease adds the event to the log and releases its data.
+func (l *Log) addAndRelease(e Event) EventID {
+	id := l.Add(e)
+	e.Data.Release()
+	return id
+}
@@ -0,0 +1,109 @@
+package trace2
+
+import (
+	"bytes"
+	"testing"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestEventBuffer_Release(t *testing.T) {
+	tb := AcquireEventBuffer(16)
+	if len(tb.Buf()) != 0 || cap(tb.Buf()) < 16 {
+		t.Fatalf("got buffer of len %d and cap %d, want empty with cap >= 16", len(tb.Buf()), cap(tb.Buf()))
+	}
+	tb.String("hello")
+	tb.Release()
+	if tb.Buf() != nil {
+		t.Errorf("got data %q after release, want nil", tb.Buf())
+	}
+	// Releasing again, or releasing buffers not from the pool, does nothing.
+	tb.Release()
+	other := NewEventBuffer(8)
+	other.String("hello")
+	other.Release()
+	if string(other.Buf()) != "\x05hello" {
+		t.Errorf("got data %q, want the unpooled buffer to be left as is", other.Buf())
+	}
+
+	// Released buffers can be reused without being affected by the previous data.
+	tb = AcquireEventBuffer(0)
+	tb.String("bye")
+	if string(tb.Buf()) != "\x03bye" {
+		t.Errorf("got data %q, want the new data only", tb.Buf())
+	}
+	tb.Release()
+}
+
+func TestLog_PooledEvents(t *testing.T) {
+	ep := EventParams{TraceID: model.TraceID{1}, SpanID: model.SpanID{2}, Goid: 3}
+	log := NewLog()
+	for _, msg := range []string{"first", "second", "third"} {
+		log.LogMessage(LogMessageParams{EventParams: ep, Level: model.LevelInfo, Msg: msg})
+	}
+	data, _ := log.GetAndClear()
+
+	// The data of each event is kept despite the buffers being reused.
+	events, rest := readEvents(data)
+	if len(events) != 3 || len(rest) != 0 {
+		t.Fatalf("got %d events and %d remaining bytes, want 3 and 0", len(events), len(rest))
+	}
+	for i, msg := range []string{"first", "second", "third"} {
+		if !bytes.Contains(events[i].Data, []byte(msg)) {
+			t.Errorf("event %d: got data %q, want it to contain %q", i, events[i].Data, msg)
+		}
+	}
+}
+
+func BenchmarkLogMessage(b *testing.B) {
+	ep := EventParams{TraceID: model.TraceID{1}, SpanID: model.SpanID{2}, Goid: 3}
+	p := LogMessageParams{
+		EventParams: ep,
+		Level:       model.LevelInfo,
+		Msg:         "processed order",
+		Fields:      []LogField{{Key: "order_id", Value: 123}, {Key: "status", Value: "shipped"}},
+	}
+	log := NewLog()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.LogMessage(p)
+		if i%1024 == 0 {
+			log.GetAndClear()
+		}
+	}
+}
+
+func BenchmarkDBQuery(b *testing.B) {
+	ep := EventParams{TraceID: model.TraceID{1}, SpanID: model.SpanID{2}, Goid: 3}
+	log := NewLog()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		id := log.DBQueryStart(DBQueryStartParams{EventParams: ep, Query: "SELECT id FROM orders WHERE status = $1"})
+		log.DBQueryEnd(ep, id, nil)
+		if i%1024 == 0 {
+			log.GetAndClear()
+		}
+	}
+}
+
+func BenchmarkEventBuffer(b *testing.B) {
+	b.Run("New", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			tb := NewEventBuffer(128)
+			tb.String("processed order")
+			tb.UVarint(uint64(i))
+		}
+	})
+	b.Run("Acquire", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			tb := AcquireEventBuffer(128)
+			tb.String("processed order")
+			tb.UVarint(uint64(i))
+			tb.Release()
+		}
+	})
+}
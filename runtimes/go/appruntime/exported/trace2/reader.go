@@ -0,0 +1,53 @@
+package trace2
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// errReaderClosed is returned when reading from a closed log reader.
+var errReaderClosed = errors.New("trace2: read from closed log reader")
+
+// Reader returns a reader of the log's data, in the log's encoding.
+// Reads block until data is available, and return io.EOF once the log
+// is done and all its data has been read.
+//
+// Closing the reader unblocks any pending read. Like GetAndClear,
+// the data read is cleared from the log, so a log should only be
+// read by a single reader.
+func (l *Log) Reader() io.ReadCloser {
+	return &logReader{l: l}
+}
+
+// logReader implements io.ReadCloser by reading from a log.
+type logReader struct {
+	l      *Log
+	data   []byte // current batch
+	done   bool   // whether the log has no more data
+	closed atomic.Bool
+}
+
+func (r *logReader) Read(b []byte) (int, error) {
+	for len(r.data) == 0 {
+		if r.closed.Load() {
+			return 0, errReaderClosed
+		} else if r.done {
+			return 0, io.EOF
+		}
+		r.data, r.done = r.l.waitAndClear(&r.closed)
+	}
+	n := copy(b, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func (r *logReader) Close() error {
+	// Set closed while holding the lock so a concurrent
+	// waitAndClear either observes it or is woken up.
+	r.l.mu.Lock()
+	r.closed.Store(true)
+	r.l.mu.Unlock()
+	r.l.cond.Broadcast()
+	return nil
+}
@@ -0,0 +1,76 @@
+package trace2
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestLogReader(t *testing.T) {
+	for _, enc := range []Encoding{EncodingNone, EncodingZstd, EncodingGzip} {
+		t.Run(string(enc), func(t *testing.T) {
+			log := NewEncodedLog(enc)
+			raw := NewLog()
+
+			// Add events concurrently with reading, so reads block for data.
+			go func() {
+				for batch := 0; batch < 3; batch++ {
+					for i := 0; i < 50; i++ {
+						e := Event{Type: LogMessage, Data: NewEventBuffer(32)}
+						e.Data.String("a fairly repetitive log message")
+						log.Add(e)
+						raw.Add(e)
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+				log.MarkDone()
+			}()
+
+			r, err := NewDecoder(enc, log.Reader())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = r.Close() }()
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, _ := raw.GetAndClear()
+			if !equalIgnoringIDs(got, want) {
+				t.Errorf("read data does not match the logged events")
+			}
+		})
+	}
+}
+
+func TestLogReader_Done(t *testing.T) {
+	log := NewLog()
+	log.MarkDone()
+	r := log.Reader()
+	if n, err := r.Read(make([]byte, 10)); n != 0 || err != io.EOF {
+		t.Fatalf("got n=%d, err=%v, want n=0, err=io.EOF", n, err)
+	}
+}
+
+func TestLogReader_Close(t *testing.T) {
+	log := NewLog()
+	r := log.Reader()
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := r.Read(make([]byte, 10))
+		errCh <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	_ = r.Close()
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, errReaderClosed) {
+			t.Fatalf("got err=%v, want errReaderClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close did not unblock Read")
+	}
+}
@@ -0,0 +1,108 @@
+package trace2
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// PayloadKind is the kind of data passed to a Redactor.
+type PayloadKind uint8
+
+const (
+	RequestBody    PayloadKind = iota + 1 // an API request body
+	ResponseBody                          // an API response body
+	RequestHeader                         // an API request header value
+	ResponseHeader                        // an API response header value
+	MessageBody                           // a published or received Pub/Sub message
+	JobPayload                            // the payload of a background job
+)
+
+func (k PayloadKind) String() string {
+	switch k {
+	case RequestBody:
+		return "request_body"
+	case ResponseBody:
+		return "response_body"
+	case RequestHeader:
+		return "request_header"
+	case ResponseHeader:
+		return "response_header"
+	case MessageBody:
+		return "message_body"
+	case JobPayload:
+		return "job_payload"
+	default:
+		return "unknown"
+	}
+}
+
+// Payload describes data about to be recorded in a trace.
+type Payload struct {
+	Kind PayloadKind
+
+	// Service and Endpoint are the API the payload belongs to, if known.
+	Service, Endpoint string
+
+	// Topic is the Pub/Sub topic, for message bodies.
+	Topic string
+
+	// Queue is the worker queue, for job payloads.
+	Queue string
+
+	// Header is the header name, for header values.
+	Header string
+}
+
+// A Redactor scrubs sensitive data before it's recorded in a trace.
+// It returns the data to record in place of data, which it must not modify.
+// Returning nil records no data at all.
+//
+// Redactors are called concurrently and should be fast,
+// as they're called while the request is being handled.
+type Redactor func(p Payload, data []byte) []byte
+
+var (
+	redactorsMu sync.Mutex                 // serializes RegisterRedactor
+	redactors   atomic.Pointer[[]Redactor] // copied on write
+)
+
+// RegisterRedactor registers a redactor that is called for all request and
+// response bodies, header values and Pub/Sub messages recorded in traces.
+// Redactors are called in the order they were registered, each given the
+// data returned by the previous one.
+//
+// It's typically called from an init function, so that no data
+// is recorded before the redactor is registered.
+func RegisterRedactor(r Redactor) {
+	if r == nil {
+		return
+	}
+	redactorsMu.Lock()
+	defer redactorsMu.Unlock()
+	var rs []Redactor
+	if old := redactors.Load(); old != nil {
+		rs = append(rs, *old...)
+	}
+	rs = append(rs, r)
+	redactors.Store(&rs)
+}
+
+// redact returns data with the registered redactors applied.
+func redact(p Payload, data []byte) []byte {
+	rs := redactors.Load()
+	if rs == nil || len(data) == 0 {
+		return data
+	}
+	for _, r := range *rs {
+		data = r(p, data)
+	}
+	return data
+}
+
+// redactHeader returns the header value with the registered redactors applied.
+func redactHeader(p Payload, value string) string {
+	if redactors.Load() == nil {
+		return value
+	}
+	return string(redact(p, []byte(value)))
+}
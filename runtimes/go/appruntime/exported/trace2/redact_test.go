@@ -0,0 +1,50 @@
+package trace2
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestRegisterRedactor(t *testing.T) {
+	old := redactors.Load()
+	t.Cleanup(func() { redactors.Store(old) })
+	redactors.Store(nil)
+
+	var got []Payload
+	RegisterRedactor(func(p Payload, data []byte) []byte {
+		got = append(got, p)
+		return bytes.ReplaceAll(data, []byte("secret"), []byte("[REDACTED]"))
+	})
+
+	log := NewLog()
+	req := &model.Request{
+		TraceID: model.TraceID{1},
+		SpanID:  model.SpanID{1},
+		RPCData: &model.RPCData{
+			Desc:           &model.RPCDesc{Service: "users", Endpoint: "Create"},
+			RequestHeaders: http.Header{"Authorization": {"secret"}},
+			NonRawPayload:  []byte(`{"password":"secret"}`),
+		},
+	}
+	log.RequestSpanStart(req, 1)
+	data, _ := log.GetAndClear()
+	if bytes.Contains(data, []byte("secret")) {
+		t.Errorf("trace data contains sensitive data: %q", data)
+	}
+
+	want := []Payload{
+		{Kind: RequestHeader, Service: "users", Endpoint: "Create", Header: "Authorization"},
+		{Kind: RequestBody, Service: "users", Endpoint: "Create"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("redactor called with %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("redactor call %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package trace2
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// TailSampling configures tail-based sampling of a trace log, where the
+// decision of whether to keep the trace is made once its spans complete
+// rather than when the trace starts.
+//
+// A trace is kept if any of its request, auth or Pub/Sub message spans
+// failed or took at least LatencyThreshold. Other traces are kept
+// at SampleRate once the log is done.
+type TailSampling struct {
+	// SampleRate is the rate at which to keep traces
+	// that neither failed nor were slow, between [0, 1].
+	SampleRate float64
+
+	// LatencyThreshold is the duration at which a span is considered slow.
+	// If zero, traces are not kept based on their latency.
+	LatencyThreshold time.Duration
+
+	// rand returns a random number in [0, 1). If nil, rand.Float64 is used.
+	rand func() float64
+}
+
+// sampleDecision is the tail sampling decision made for a log.
+type sampleDecision uint8
+
+const (
+	sampleKept      sampleDecision = iota // events are added to the log
+	sampleUndecided                       // events are buffered until a decision is made
+	sampleDropped                         // events are discarded
+)
+
+// maxPendingSize is the maximum size of the events buffered
+// while waiting for a sampling decision.
+const maxPendingSize = 10 << 20 // 10 MiB
+
+// NewTailSampledLog returns a log that buffers its events until the trace
+// is known to be worth keeping according to sampling, and discards them
+// otherwise. No data is returned by GetAndClear and WaitAndClear until then.
+func NewTailSampledLog(enc Encoding, sampling TailSampling) *Log {
+	l := NewEncodedLog(enc)
+	l.sampling = &sampling
+	l.decision = sampleUndecided
+	return l
+}
+
+// spanEnded records that a span ended, keeping the trace
+// if the span failed or was slow.
+func (l *Log) spanEnded(dur time.Duration, err error) {
+	if l == nil || l.sampling == nil {
+		return
+	}
+
+	l.mu.Lock()
+	keep := l.decision == sampleUndecided &&
+		(err != nil || (l.sampling.LatencyThreshold > 0 && dur >= l.sampling.LatencyThreshold))
+	if keep {
+		l.keepLocked()
+	}
+	l.mu.Unlock()
+	if keep {
+		l.cond.Broadcast()
+	}
+}
+
+// sampleLocked decides whether to keep a trace
+// that neither failed nor was slow, based on the sample rate.
+// l.mu must be held.
+func (l *Log) sampleLocked() {
+	rnd := l.sampling.rand
+	if rnd == nil {
+		rnd = rand.Float64
+	}
+	if rnd() < l.sampling.SampleRate {
+		l.keepLocked()
+	} else {
+		l.decision = sampleDropped
+		l.pending = nil
+	}
+}
+
+// keepLocked keeps the trace, making the buffered events available.
+// l.mu must be held.
+func (l *Log) keepLocked() {
+	l.decision = sampleKept
+	l.appendLocked(l.pending)
+	l.pending = nil
+}
+
+// sampled reports whether the trace may be kept,
+// which is the case unless the sampling decision was to drop it.
+func (l *Log) sampled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.decision != sampleDropped
+}
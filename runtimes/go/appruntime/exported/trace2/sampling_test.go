@@ -0,0 +1,85 @@
+package trace2
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestTailSampledLog(t *testing.T) {
+	tests := []struct {
+		name string
+		dur  time.Duration
+		err  error
+		roll float64
+		keep bool
+	}{
+		{name: "fast", dur: time.Millisecond, roll: 0.5, keep: false},
+		{name: "sampled", dur: time.Millisecond, roll: 0.05, keep: true},
+		{name: "slow", dur: time.Second, roll: 0.5, keep: true},
+		{name: "failed", dur: time.Millisecond, err: errors.New("boom"), roll: 0.5, keep: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			log := NewTailSampledLog(EncodingNone, TailSampling{
+				SampleRate:       0.1,
+				LatencyThreshold: 100 * time.Millisecond,
+				rand:             func() float64 { return test.roll },
+			})
+
+			req := &model.Request{
+				TraceID: model.TraceID{1},
+				SpanID:  model.SpanID{1},
+				RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Endpoint"}},
+			}
+			log.RequestSpanStart(req, 1)
+			if data, _ := log.GetAndClear(); len(data) != 0 {
+				t.Fatalf("got %d bytes before the span ended, want none", len(data))
+			}
+			log.RequestSpanEnd(RequestSpanEndParams{
+				EventParams: EventParams{TraceID: req.TraceID, SpanID: req.SpanID},
+				Req:         req,
+				Resp:        &model.Response{Duration: test.dur, Err: test.err},
+			})
+			log.MarkDone()
+
+			data, done := log.WaitAndClear()
+			if !done {
+				t.Fatal("log not done")
+			}
+			events, _ := readEvents(data)
+			if test.keep && len(events) != 2 {
+				t.Errorf("got %d events, want the trace to be kept", len(events))
+			} else if !test.keep && len(events) != 0 {
+				t.Errorf("got %d events, want the trace to be dropped", len(events))
+			}
+		})
+	}
+}
+
+func TestTailSampledLog_KeptBeforeDone(t *testing.T) {
+	log := NewTailSampledLog(EncodingNone, TailSampling{rand: func() float64 { return 0.5 }})
+	req := &model.Request{
+		TraceID: model.TraceID{1},
+		SpanID:  model.SpanID{1},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Endpoint"}},
+	}
+	log.RequestSpanStart(req, 1)
+	log.RequestSpanEnd(RequestSpanEndParams{
+		EventParams: EventParams{TraceID: req.TraceID, SpanID: req.SpanID},
+		Req:         req,
+		Resp:        &model.Response{Err: errors.New("boom")},
+	})
+
+	// Failed traces are kept as soon as the span ends,
+	// and the following events are added to the log directly.
+	if data, done := log.GetAndClear(); done || len(data) == 0 {
+		t.Fatalf("got %d bytes, done=%v, want the buffered events", len(data), done)
+	}
+	log.Add(Event{Type: LogMessage, TraceID: req.TraceID, SpanID: req.SpanID})
+	if data, _ := log.GetAndClear(); len(data) == 0 {
+		t.Fatal("got no data for events added after the trace was kept")
+	}
+}
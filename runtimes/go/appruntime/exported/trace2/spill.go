@@ -0,0 +1,147 @@
+package trace2
+
+import "os"
+
+// defaultMaxSpillSize is the default maximum size of a spill file.
+const defaultMaxSpillSize = 1 << 30 // 1 GiB
+
+// spillFile is a temporary file holding events that didn't fit
+// in a log's in-memory buffer, until they're read.
+//
+// Events are queued in memory under the log's mutex and written to the file
+// by a separate goroutine, so adding events never waits for disk I/O.
+// Likewise the file is read without holding the log's mutex.
+type spillFile struct {
+	f       *os.File
+	size    int64  // size of the events written, which can be read
+	off     int64  // offset of the first unread byte
+	queued  []byte // events yet to be written, following the written ones
+	writing int    // number of bytes being written by the writer goroutine, or 0
+	reading int    // number of reads in progress
+	err     error  // the first write error, after which no events are written
+}
+
+// total reports the size of the file once the queued events have been written.
+func (s *spillFile) total() int64 {
+	return s.size + int64(s.writing) + int64(len(s.queued))
+}
+
+// drained reports whether all the spilled events have been read.
+func (s *spillFile) drained() bool {
+	return s.off >= s.size && s.writing == 0 && len(s.queued) == 0 && s.reading == 0
+}
+
+// spillLocked queues events to be written to the log's spill file, creating it if needed.
+// It reports whether the events were queued, which they aren't if writing to the file
+// has failed or the file would exceed the maximum spill size.
+// l.mu must be held.
+func (l *Log) spillLocked(events []byte) bool {
+	if l.spill == nil {
+		f, err := os.CreateTemp(l.limits.SpillDir, "encore-trace-*")
+		if err != nil {
+			println("encore.traceEvent: unable to create trace spill file:", err.Error())
+			return false
+		}
+		l.spill = &spillFile{f: f}
+	}
+
+	s := l.spill
+	maxSize := int64(l.limits.MaxSpillSize)
+	if maxSize <= 0 {
+		maxSize = defaultMaxSpillSize
+	}
+	if s.err != nil || s.total()+int64(len(events)) > maxSize {
+		return false
+	}
+
+	s.queued = append(s.queued, events...)
+	if s.writing == 0 {
+		s.writing = len(s.queued)
+		go l.writeSpill(s, s.queued, s.size)
+		s.queued = nil
+	}
+	return true
+}
+
+// writeSpill writes batch to the spill file at off, and then any events
+// queued in the meantime, until there are none left.
+func (l *Log) writeSpill(s *spillFile, batch []byte, off int64) {
+	for {
+		_, err := s.f.WriteAt(batch, off)
+
+		l.mu.Lock()
+		s.writing = 0
+		if err != nil {
+			// Only complete writes are counted so partially written events are never read.
+			if s.err == nil {
+				println("encore.traceEvent: unable to write trace spill file:", err.Error())
+			}
+			s.err = err
+			l.droppedLocked(countEvents(batch) + countEvents(s.queued))
+			s.queued = nil
+		} else {
+			s.size += int64(len(batch))
+		}
+
+		batch, off = s.queued, s.size
+		s.writing, s.queued = len(batch), nil
+		l.mu.Unlock()
+		l.cond.Broadcast()
+
+		if len(batch) == 0 {
+			return
+		}
+	}
+}
+
+// spillReadyLocked reports whether there are spilled events to read,
+// or a drained spill file to remove.
+// l.mu must be held.
+func (l *Log) spillReadyLocked() bool {
+	s := l.spill
+	return s != nil && (s.off < s.size || s.drained())
+}
+
+// readSpillLocked reserves the next chunk of written events for reading,
+// and returns a function reading them that must be called without holding l.mu.
+// Once all the events have been read the spill file is removed.
+// l.mu must be held.
+func (l *Log) readSpillLocked() (read func() []byte) {
+	s := l.spill
+	if s.drained() {
+		l.closeSpillLocked()
+		return nil
+	}
+
+	// Read at most MaxSize bytes at a time to stay within the memory limit.
+	off := s.off
+	n := min(s.size-s.off, int64(l.limits.MaxSize))
+	s.off += n
+	s.reading++
+	return func() []byte {
+		data := make([]byte, n)
+		read, err := s.f.ReadAt(data, off)
+		if err != nil && int64(read) < n {
+			// Discard the unreadable events.
+			println("encore.traceEvent: unable to read trace spill file:", err.Error())
+		}
+
+		l.mu.Lock()
+		s.reading--
+		l.mu.Unlock()
+		l.cond.Broadcast()
+		return data[:read]
+	}
+}
+
+// closeSpillLocked closes and removes the spill file, if any.
+// l.mu must be held.
+func (l *Log) closeSpillLocked() {
+	if s := l.spill; s != nil {
+		l.spill = nil
+		if err := s.f.Close(); err != nil {
+			println("encore.traceEvent: unable to close trace spill file:", err.Error())
+		}
+		_ = os.Remove(s.f.Name())
+	}
+}
@@ -0,0 +1,72 @@
+package trace2
+
+import (
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	"encore.dev/appruntime/shared/explicithist"
+)
+
+// FlushBuckets are the bucket bounds, in seconds, of the flush latency histogram.
+var FlushBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// stats are the process-wide statistics of the trace pipeline.
+var stats = struct {
+	eventsWritten  atomic.Uint64
+	eventsDropped  atomic.Uint64
+	bytesBuffered  atomic.Int64
+	bufferReallocs atomic.Uint64
+	flushDuration  *explicithist.Histogram
+}{
+	flushDuration: explicithist.New(FlushBuckets),
+}
+
+// Stats are statistics of the trace pipeline, for monitoring
+// whether traces are being recorded and sent as expected.
+type Stats struct {
+	// EventsWritten is the number of events added to trace logs.
+	EventsWritten uint64
+
+	// EventsDropped is the number of events dropped because they
+	// didn't fit in their log's buffer or were too large.
+	EventsDropped uint64
+
+	// BytesBuffered is the number of bytes of trace data
+	// currently buffered in memory, waiting to be sent.
+	BytesBuffered int64
+
+	// BufferReallocs is the number of times a log's buffer was reallocated,
+	// either to grow it or to replace one that had grown too large.
+	BufferReallocs uint64
+
+	// FlushDuration is the histogram of the time taken to send
+	// trace data, in seconds. It's shared and must not be modified.
+	FlushDuration *explicithist.Histogram
+}
+
+// ReadStats returns the statistics of the trace pipeline.
+func ReadStats() Stats {
+	return Stats{
+		EventsWritten:  stats.eventsWritten.Load(),
+		EventsDropped:  stats.eventsDropped.Load(),
+		BytesBuffered:  stats.bytesBuffered.Load(),
+		BufferReallocs: stats.bufferReallocs.Load(),
+		FlushDuration:  stats.flushDuration,
+	}
+}
+
+// RecordFlush records that sending trace data took dur.
+func RecordFlush(dur time.Duration) {
+	stats.flushDuration.Observe(dur.Seconds())
+}
+
+// countEvents returns the number of events in data.
+func countEvents(data []byte) (n uint64) {
+	for len(data) >= eventHeaderSize {
+		ln := eventHeaderSize + int(binary.LittleEndian.Uint32(data[eventHeaderSize-4:]))
+		data = data[min(ln, len(data)):]
+		n++
+	}
+	return n
+}
@@ -0,0 +1,115 @@
+package tracetest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/exported/trace2/decoder"
+)
+
+// events returns the events recorded by r, failing the test if they can't be decoded.
+func events(t testing.TB, r *Recorder) []*decoder.Event {
+	t.Helper()
+	events, err := r.Events()
+	if err != nil {
+		t.Fatalf("tracetest: unable to decode the recorded events: %v", err)
+	}
+	return events
+}
+
+// AssertEventCount reports a test failure unless exactly want
+// events of the given type have been recorded by r.
+func AssertEventCount(t testing.TB, r *Recorder, typ trace2.EventType, want int) bool {
+	t.Helper()
+	all := events(t, r)
+	got := 0
+	for _, ev := range all {
+		if ev.Type == typ {
+			got++
+		}
+	}
+	if got != want {
+		t.Errorf("got %d %s events, want %d\n%s", got, typ, want, formatEvents(all))
+		return false
+	}
+	return true
+}
+
+// AssertEvent reports a test failure unless an event whose data is of type T,
+// such as *decoder.DBQueryStart, and matching matcher has been recorded by r,
+// and returns the data of the first such event. A nil matcher matches any event.
+func AssertEvent[T any](t testing.TB, r *Recorder, matcher func(data T) bool) T {
+	t.Helper()
+	all := events(t, r)
+	for _, ev := range all {
+		if data, ok := ev.Data.(T); ok && (matcher == nil || matcher(data)) {
+			return data
+		}
+	}
+	var zero T
+	t.Errorf("no matching %T event was recorded\n%s", zero, formatEvents(all))
+	return zero
+}
+
+// AssertSpan reports a test failure unless a request to the given endpoint
+// has been recorded by r, and returns the start event of the first such request.
+func AssertSpan(t testing.TB, r *Recorder, service, endpoint string) *decoder.RequestSpanStart {
+	t.Helper()
+	return AssertEvent(t, r, func(ev *decoder.RequestSpanStart) bool {
+		return ev.Service == service && ev.Endpoint == endpoint
+	})
+}
+
+// AssertDBQuery reports a test failure unless a database query containing
+// query has been recorded by r, and returns the first such query.
+func AssertDBQuery(t testing.TB, r *Recorder, query string) *decoder.DBQueryStart {
+	t.Helper()
+	return AssertEvent(t, r, func(ev *decoder.DBQueryStart) bool {
+		return strings.Contains(ev.Query, query)
+	})
+}
+
+// AssertPublish reports a test failure unless a message published to the
+// given topic has been recorded by r, and returns the first such publish.
+func AssertPublish(t testing.TB, r *Recorder, topic string) *decoder.PubsubPublishStart {
+	t.Helper()
+	return AssertEvent(t, r, func(ev *decoder.PubsubPublishStart) bool {
+		return ev.Topic == topic
+	})
+}
+
+// AssertMessage reports a test failure unless the processing of a message by the
+// given subscription has been recorded by r, and returns the first such span.
+func AssertMessage(t testing.TB, r *Recorder, topic, subscription string) *decoder.PubsubMessageSpanStart {
+	t.Helper()
+	return AssertEvent(t, r, func(ev *decoder.PubsubMessageSpanStart) bool {
+		return ev.Topic == topic && ev.Subscription == subscription
+	})
+}
+
+// formatEvents describes the recorded events for failure messages.
+func formatEvents(events []*decoder.Event) string {
+	if len(events) == 0 {
+		return "no events were recorded"
+	}
+	var b strings.Builder
+	b.WriteString("recorded events:")
+	for _, ev := range events {
+		fmt.Fprintf(&b, "\n\t%s", ev.Type)
+		switch data := ev.Data.(type) {
+		case *decoder.RequestSpanStart:
+			fmt.Fprintf(&b, " %s.%s", data.Service, data.Endpoint)
+		case *decoder.DBQueryStart:
+			fmt.Fprintf(&b, " %q", data.Query)
+		case *decoder.PubsubPublishStart:
+			fmt.Fprintf(&b, " %s", data.Topic)
+		case *decoder.PubsubMessageSpanStart:
+			fmt.Fprintf(&b, " %s/%s", data.Topic, data.Subscription)
+		case *decoder.JobSpanStart:
+			fmt.Fprintf(&b, " %s/%s", data.Queue, data.JobID)
+		}
+	}
+	return b.String()
+}
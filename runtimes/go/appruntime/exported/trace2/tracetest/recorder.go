@@ -0,0 +1,61 @@
+// Package tracetest records trace events in memory and provides assertions
+// on them, for regression tests verifying that code is instrumented as expected.
+//
+// Unlike et.Trace, which captures a structured summary of a test's trace,
+// a Recorder records the events exactly as they're written to the trace,
+// decoded using the decoder package.
+package tracetest
+
+import (
+	"sync"
+
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/exported/trace2/decoder"
+)
+
+// Recorder records trace events in memory.
+type Recorder struct {
+	log *trace2.Log
+
+	mu     sync.Mutex
+	events []*decoder.Event
+	err    error // first decoding error, if any
+}
+
+// NewRecorder returns a recorder with no events recorded.
+func NewRecorder() *Recorder {
+	return &Recorder{log: trace2.NewLog()}
+}
+
+// Logger returns a trace logger whose events are recorded by r.
+// It can be passed to the code under test in place of a real trace logger.
+func (r *Recorder) Logger() trace2.Logger {
+	return r.log
+}
+
+// Record makes r record the events added to log from then on,
+// in addition to log's own handling of them.
+func (r *Recorder) Record(log *trace2.Log) {
+	log.Tee(r.log)
+}
+
+// Events returns the events recorded so far, in the order they were added.
+// It reports an error if the recorded data could not be decoded.
+func (r *Recorder) Events() ([]*decoder.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if data, _ := r.log.GetAndClear(); len(data) > 0 && r.err == nil {
+		events, err := decoder.DecodeAll(data)
+		r.events = append(r.events, events...)
+		r.err = err
+	}
+	return append([]*decoder.Event(nil), r.events...), r.err
+}
+
+// Reset discards the events recorded so far.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, _ = r.log.GetAndClear()
+	r.events, r.err = nil, nil
+}
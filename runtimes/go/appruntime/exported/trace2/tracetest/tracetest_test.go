@@ -0,0 +1,92 @@
+package tracetest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/exported/trace2/decoder"
+)
+
+// recordingTB records the failures reported by assertions.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (tb *recordingTB) Helper() {}
+
+func (tb *recordingTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	rec := NewRecorder()
+	log := rec.Logger()
+	req := &model.Request{
+		SpanID:  model.SpanID{1},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "users", Endpoint: "Get"}},
+	}
+	ep := trace2.EventParams{SpanID: req.SpanID}
+	log.RequestSpanStart(req, 1)
+	log.DBQueryStart(trace2.DBQueryStartParams{EventParams: ep, Query: "SELECT name FROM users"})
+	log.DBQueryStart(trace2.DBQueryStartParams{EventParams: ep, Query: "UPDATE users SET seen = now()"})
+	log.PubsubPublishStart(trace2.PubsubPublishStartParams{EventParams: ep, Topic: "user-seen"})
+	log.PubsubMessageSpanStart(&model.Request{
+		SpanID:  model.SpanID{2},
+		MsgData: &model.PubSubMsgData{Service: "mail", Topic: "user-seen", Subscription: "send-mail"},
+	}, 2)
+
+	tb := &recordingTB{TB: t}
+	AssertSpan(tb, rec, "users", "Get")
+	if q := AssertDBQuery(tb, rec, "UPDATE users"); q == nil || q.Query != "UPDATE users SET seen = now()" {
+		t.Errorf("got query %+v, want the update", q)
+	}
+	AssertPublish(tb, rec, "user-seen")
+	AssertMessage(tb, rec, "user-seen", "send-mail")
+	AssertEventCount(tb, rec, trace2.DBQueryStart, 2)
+	AssertEventCount(tb, rec, trace2.RPCCallStart, 0)
+	AssertEvent(tb, rec, (func(*decoder.PubsubPublishStart) bool)(nil))
+	if len(tb.errors) > 0 {
+		t.Fatalf("got failures %q, want none", tb.errors)
+	}
+
+	if AssertSpan(tb, rec, "users", "List") != nil {
+		t.Error("got span for unrecorded request")
+	}
+	AssertDBQuery(tb, rec, "DELETE")
+	AssertPublish(tb, rec, "user-deleted")
+	AssertMessage(tb, rec, "user-seen", "audit")
+	AssertEventCount(tb, rec, trace2.DBQueryStart, 1)
+	if len(tb.errors) != 5 {
+		t.Fatalf("got %d failures %q, want 5", len(tb.errors), tb.errors)
+	}
+	if msg := tb.errors[1]; !strings.Contains(msg, `"SELECT name FROM users"`) {
+		t.Errorf("failure %q does not list the recorded queries", msg)
+	}
+}
+
+func TestRecorder_Record(t *testing.T) {
+	log := trace2.NewLog()
+	ep := trace2.EventParams{SpanID: model.SpanID{1}}
+	log.LogMessage(trace2.LogMessageParams{EventParams: ep, Msg: "before"})
+
+	rec := NewRecorder()
+	rec.Record(log)
+	log.LogMessage(trace2.LogMessageParams{EventParams: ep, Msg: "after"})
+	AssertEventCount(t, rec, trace2.LogMessage, 1)
+	AssertEvent(t, rec, func(ev *decoder.LogMessage) bool { return ev.Msg == "after" })
+
+	// The events are still added to the log itself.
+	data, _ := log.GetAndClear()
+	if events, err := decoder.DecodeAll(data); err != nil || len(events) != 2 {
+		t.Errorf("got %d events in the log, err=%v, want 2", len(events), err)
+	}
+
+	rec.Reset()
+	AssertEventCount(t, rec, trace2.LogMessage, 0)
+	log.LogMessage(trace2.LogMessageParams{EventParams: ep, Msg: "reset"})
+	AssertEventCount(t, rec, trace2.LogMessage, 1)
+}
@@ -1,6 +1,32 @@
 package trace2
 
+import (
+	"errors"
+	"fmt"
+)
+
 type Version int
 
 // CurrentVersion is the trace protocol version this package produces traces in.
-const CurrentVersion Version = 15
+const CurrentVersion Version = 24
+
+// MinVersion is the oldest trace protocol version traces can be downgraded to,
+// for reporting them to collectors that don't support CurrentVersion.
+const MinVersion Version = 14
+
+// ErrUnsupportedVersion is reported when negotiating a trace protocol version
+// with a collector that only supports versions older than MinVersion.
+var ErrUnsupportedVersion = errors.New("trace2: unsupported trace protocol version")
+
+// NegotiateVersion returns the version to report traces in
+// to a collector supporting versions up to max.
+func NegotiateVersion(max Version) (Version, error) {
+	switch {
+	case max >= CurrentVersion:
+		return CurrentVersion, nil
+	case max >= MinVersion:
+		return max, nil
+	default:
+		return 0, fmt.Errorf("%w %d (want at least %d)", ErrUnsupportedVersion, max, MinVersion)
+	}
+}
@@ -0,0 +1,189 @@
+// Package logexport exports logs written using rlog to external backends.
+package logexport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+const (
+	// maxBuffered is the maximum number of records to buffer.
+	// Records logged while the buffer is full are dropped.
+	maxBuffered = 10000
+
+	// maxBatchSize is the maximum number of records to export at once.
+	// A flush is triggered early when this many records are buffered.
+	maxBatchSize = 1000
+)
+
+// Record is a log message to export.
+type Record struct {
+	Time    time.Time
+	Level   model.LogLevel
+	Msg     string
+	Fields  []trace2.LogField
+	TraceID model.TraceID // zero if not logged during a request
+	SpanID  model.SpanID  // zero if not logged during a request
+	SvcNum  uint16        // 0 if not logged from within a service
+}
+
+type exporter interface {
+	Export(ctx context.Context, records []Record) error
+	Shutdown(p *shutdown.Process) error
+}
+
+type providerDesc struct {
+	name        string
+	matches     func(cfg *config.LogExport) bool
+	newExporter func(*Manager) exporter
+}
+
+var providerRegistry []providerDesc
+
+func registerProvider(p providerDesc) {
+	providerRegistry = append(providerRegistry, p)
+}
+
+type Manager struct {
+	ctx    context.Context
+	cancel func()
+
+	static     *config.Static
+	runtime    *config.Runtime
+	rootLogger zerolog.Logger
+	exp        exporter // nil if logs are not exported
+
+	mu      sync.Mutex
+	buf     []Record
+	dropped int
+	full    chan struct{} // signalled when a batch is ready to export
+
+	exportMu sync.Mutex // serializes exports
+}
+
+func NewManager(static *config.Static, runtime *config.Runtime, rootLogger zerolog.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr := &Manager{
+		ctx:        ctx,
+		cancel:     cancel,
+		static:     static,
+		runtime:    runtime,
+		rootLogger: rootLogger,
+		full:       make(chan struct{}, 1),
+	}
+
+	// Log export isn't configured, or we're running tests.
+	if runtime.LogExport == nil || runtime.EnvType == "test" {
+		return mgr
+	}
+
+	for _, desc := range providerRegistry {
+		if desc.matches(runtime.LogExport) {
+			mgr.exp = desc.newExporter(mgr)
+			break
+		}
+	}
+	return mgr
+}
+
+// Enabled reports whether logs are exported.
+func (mgr *Manager) Enabled() bool {
+	return mgr != nil && mgr.exp != nil
+}
+
+// Export queues the record to be exported.
+// It's a no-op if logs are not exported.
+func (mgr *Manager) Export(r Record) {
+	if !mgr.Enabled() {
+		return
+	}
+
+	mgr.mu.Lock()
+	if len(mgr.buf) >= maxBuffered {
+		mgr.dropped++
+		mgr.mu.Unlock()
+		return
+	}
+	mgr.buf = append(mgr.buf, r)
+	ready := len(mgr.buf) >= maxBatchSize
+	mgr.mu.Unlock()
+
+	if ready {
+		select {
+		case mgr.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (mgr *Manager) BeginFlushing() {
+	if mgr.exp == nil {
+		return
+	}
+
+	interval := 5 * time.Second
+	if otlp := mgr.runtime.LogExport.OTLP; otlp != nil && otlp.FlushInterval > 0 {
+		interval = otlp.FlushInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-mgr.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-mgr.full:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		mgr.flush(ctx)
+		cancel()
+	}
+}
+
+func (mgr *Manager) Shutdown(p *shutdown.Process) error {
+	if mgr.exp == nil {
+		return nil
+	}
+
+	// Wait for all services and all tasks to shut down before we stop exporting logs,
+	// so the logs they write while shutting down are exported.
+	<-p.ServicesShutdownCompleted.Done()
+	<-p.OutstandingTasks.Done()
+
+	mgr.cancel()
+	mgr.flush(p.ForceShutdown)
+	return mgr.exp.Shutdown(p)
+}
+
+// flush exports the buffered records.
+func (mgr *Manager) flush(ctx context.Context) {
+	mgr.exportMu.Lock()
+	defer mgr.exportMu.Unlock()
+
+	mgr.mu.Lock()
+	records, dropped := mgr.buf, mgr.dropped
+	mgr.buf, mgr.dropped = nil, 0
+	mgr.mu.Unlock()
+
+	if dropped > 0 {
+		mgr.rootLogger.Warn().Int("dropped", dropped).Msg("log export buffer full, dropped log records")
+	}
+
+	for len(records) > 0 {
+		n := min(len(records), maxBatchSize)
+		if err := mgr.exp.Export(ctx, records[:n]); err != nil {
+			mgr.rootLogger.Err(err).Int("records", n).Msg("unable to export logs")
+		}
+		records = records[n:]
+	}
+}
@@ -0,0 +1,92 @@
+package logexport
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+type fakeExporter struct {
+	mu      sync.Mutex
+	batches [][]Record
+}
+
+func (f *fakeExporter) Export(ctx context.Context, records []Record) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.batches = append(f.batches, append([]Record(nil), records...))
+	return nil
+}
+
+func (f *fakeExporter) Shutdown(p *shutdown.Process) error { return nil }
+
+func newTestManager(exp exporter) *Manager {
+	mgr := NewManager(&config.Static{}, &config.Runtime{}, zerolog.Nop())
+	mgr.exp = exp
+	return mgr
+}
+
+func TestManager_Flush(t *testing.T) {
+	c := qt.New(t)
+	exp := &fakeExporter{}
+	mgr := newTestManager(exp)
+
+	for i := 0; i < maxBatchSize+1; i++ {
+		mgr.Export(Record{Msg: "hello"})
+	}
+
+	// A full batch signals that the records should be flushed.
+	select {
+	case <-mgr.full:
+	default:
+		c.Fatal("expected flush to be signalled")
+	}
+
+	mgr.flush(context.Background())
+	c.Assert(exp.batches, qt.HasLen, 2)
+	c.Assert(exp.batches[0], qt.HasLen, maxBatchSize)
+	c.Assert(exp.batches[1], qt.HasLen, 1)
+
+	// The buffer is emptied by flushing.
+	mgr.flush(context.Background())
+	c.Assert(exp.batches, qt.HasLen, 2)
+}
+
+func TestManager_Dropped(t *testing.T) {
+	c := qt.New(t)
+	exp := &fakeExporter{}
+	mgr := newTestManager(exp)
+
+	for i := 0; i < maxBuffered+5; i++ {
+		mgr.Export(Record{Msg: "hello"})
+	}
+	c.Assert(mgr.dropped, qt.Equals, 5)
+
+	mgr.flush(context.Background())
+	c.Assert(mgr.dropped, qt.Equals, 0)
+	total := 0
+	for _, b := range exp.batches {
+		total += len(b)
+	}
+	c.Assert(total, qt.Equals, maxBuffered)
+}
+
+func TestManager_Disabled(t *testing.T) {
+	c := qt.New(t)
+	mgr := NewManager(&config.Static{}, &config.Runtime{
+		EnvType:   "test",
+		LogExport: &config.LogExport{OTLP: &config.OTLPLogsProvider{Endpoint: "http://localhost"}},
+	}, zerolog.Nop())
+	c.Assert(mgr.Enabled(), qt.IsFalse)
+
+	// Must not panic.
+	var nilMgr *Manager
+	c.Assert(nilMgr.Enabled(), qt.IsFalse)
+	nilMgr.Export(Record{Msg: "hello"})
+}
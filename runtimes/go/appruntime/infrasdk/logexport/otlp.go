@@ -0,0 +1,294 @@
+//go:build !encore_no_otlp
+
+package logexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/types/uuid"
+)
+
+func init() {
+	registerProvider(providerDesc{
+		name: "otlp",
+		matches: func(cfg *config.LogExport) bool {
+			return cfg.OTLP != nil
+		},
+		newExporter: func(m *Manager) exporter {
+			containerMetadata, err := metadata.GetContainerMetadata(m.runtime)
+			if err != nil {
+				m.rootLogger.Err(err).Msg("unable to initialize log exporter: error getting container metadata")
+				return nil
+			}
+			return newOTLPExporter(m.static.BundledServices, m.runtime.LogExport.OTLP, containerMetadata)
+		},
+	})
+}
+
+// otlpExporter exports logs to an OpenTelemetry collector
+// using OTLP/HTTP with the JSON encoding.
+type otlpExporter struct {
+	svcs                   []string
+	cfg                    *config.OTLPLogsProvider
+	containerMetadataAttrs []keyValue
+	client                 *http.Client
+}
+
+func newOTLPExporter(svcs []string, cfg *config.OTLPLogsProvider, meta *metadata.ContainerMetadata) *otlpExporter {
+	return &otlpExporter{
+		svcs: svcs,
+		cfg:  cfg,
+		containerMetadataAttrs: metadata.MapMetadataLabels(meta, func(k, v string) keyValue {
+			return stringAttr(k, v)
+		}),
+		client: &http.Client{},
+	}
+}
+
+func (x *otlpExporter) Shutdown(p *shutdown.Process) error {
+	x.client.CloseIdleConnections()
+	return nil
+}
+
+func (x *otlpExporter) Export(ctx context.Context, records []Record) error {
+	body, err := json.Marshal(x.getLogData(time.Now(), records))
+	if err != nil {
+		return fmt.Errorf("unable to marshal logs: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, x.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "encore")
+	for k, v := range x.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := x.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send logs to OTLP endpoint: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unable to send logs to OTLP endpoint: %s: %s", resp.Status, msg)
+	}
+	return nil
+}
+
+func (x *otlpExporter) getLogData(now time.Time, records []Record) *exportLogsRequest {
+	observed := strconv.FormatInt(now.UnixNano(), 10)
+
+	// Records are grouped by service, each being its own resource.
+	var order []uint16
+	bySvc := make(map[uint16][]logRecord)
+	for _, r := range records {
+		svcNum := r.SvcNum
+		if int(svcNum) > len(x.svcs) {
+			svcNum = 0
+		}
+		if _, ok := bySvc[svcNum]; !ok {
+			order = append(order, svcNum)
+		}
+		bySvc[svcNum] = append(bySvc[svcNum], toLogRecord(r, observed))
+	}
+
+	req := &exportLogsRequest{ResourceLogs: make([]resourceLogs, 0, len(order))}
+	for _, svcNum := range order {
+		attrs := make([]keyValue, 0, len(x.containerMetadataAttrs)+1)
+		attrs = append(attrs, x.containerMetadataAttrs...)
+		if svcNum > 0 {
+			attrs = append(attrs, stringAttr("service.name", x.svcs[svcNum-1]))
+		}
+		req.ResourceLogs = append(req.ResourceLogs, resourceLogs{
+			Resource: resource{Attributes: attrs},
+			ScopeLogs: []scopeLogs{{
+				Scope:      instrumentationScope{Name: "encore.dev/rlog"},
+				LogRecords: bySvc[svcNum],
+			}},
+		})
+	}
+	return req
+}
+
+func toLogRecord(r Record, observed string) logRecord {
+	num, text := severity(r.Level)
+	lr := logRecord{
+		TimeUnixNano:         strconv.FormatInt(r.Time.UnixNano(), 10),
+		ObservedTimeUnixNano: observed,
+		SeverityNumber:       num,
+		SeverityText:         text,
+		Body:                 anyValue{StringValue: &r.Msg},
+		Attributes:           make([]keyValue, 0, len(r.Fields)),
+	}
+	for _, f := range r.Fields {
+		lr.Attributes = append(lr.Attributes, keyValue{Key: f.Key, Value: attrValue(f.Value)})
+	}
+	if !r.TraceID.IsZero() {
+		lr.TraceID = hex.EncodeToString(r.TraceID[:])
+	}
+	if !r.SpanID.IsZero() {
+		lr.SpanID = hex.EncodeToString(r.SpanID[:])
+	}
+	return lr
+}
+
+// severity maps the log level to the OTLP severity number and text.
+func severity(level model.LogLevel) (int, string) {
+	switch level {
+	case model.LevelTrace:
+		return 1, "TRACE"
+	case model.LevelDebug:
+		return 5, "DEBUG"
+	case model.LevelInfo:
+		return 9, "INFO"
+	case model.LevelWarn:
+		return 13, "WARN"
+	case model.LevelError:
+		return 17, "ERROR"
+	default:
+		return 0, ""
+	}
+}
+
+// attrValue converts a log field value to an OTLP value.
+// Values without a corresponding OTLP type are encoded as JSON strings.
+func attrValue(val any) anyValue {
+	str := func(s string) anyValue { return anyValue{StringValue: &s} }
+	num := func(n int64) anyValue { return anyValue{IntValue: strconv.FormatInt(n, 10)} }
+
+	switch val := val.(type) {
+	case error:
+		return str(val.Error())
+	case string:
+		return str(val)
+	case bool:
+		return anyValue{BoolValue: &val}
+	case time.Time:
+		return str(val.Format(time.RFC3339Nano))
+	case time.Duration:
+		return str(val.String())
+	case uuid.UUID:
+		return str(val.String())
+
+	case int8:
+		return num(int64(val))
+	case int16:
+		return num(int64(val))
+	case int32:
+		return num(int64(val))
+	case int64:
+		return num(val)
+	case int:
+		return num(int64(val))
+
+	case uint8:
+		return num(int64(val))
+	case uint16:
+		return num(int64(val))
+	case uint32:
+		return num(int64(val))
+	case uint64:
+		if val > math.MaxInt64 {
+			return str(strconv.FormatUint(val, 10))
+		}
+		return num(int64(val))
+	case uint:
+		if uint64(val) > math.MaxInt64 {
+			return str(strconv.FormatUint(uint64(val), 10))
+		}
+		return num(int64(val))
+
+	case float32:
+		return double(float64(val))
+	case float64:
+		return double(val)
+
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return str(fmt.Sprint(val))
+		}
+		return str(string(data))
+	}
+}
+
+func double(f float64) anyValue {
+	// NaN and infinities can't be represented in JSON.
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		return anyValue{StringValue: &s}
+	}
+	return anyValue{DoubleValue: &f}
+}
+
+// The types below mirror the OTLP protobuf messages,
+// using the JSON encoding defined by the OTLP specification.
+// 64-bit integers are encoded as strings.
+
+type exportLogsRequest struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource  resource    `json:"resource"`
+	ScopeLogs []scopeLogs `json:"scopeLogs"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeLogs struct {
+	Scope      instrumentationScope `json:"scope"`
+	LogRecords []logRecord          `json:"logRecords"`
+}
+
+type instrumentationScope struct {
+	Name string `json:"name"`
+}
+
+// logRecord is an OTLP log record.
+// Trace and span ids are hex-encoded.
+type logRecord struct {
+	TimeUnixNano         string     `json:"timeUnixNano"`
+	ObservedTimeUnixNano string     `json:"observedTimeUnixNano"`
+	SeverityNumber       int        `json:"severityNumber,omitempty"`
+	SeverityText         string     `json:"severityText,omitempty"`
+	Body                 anyValue   `json:"body"`
+	Attributes           []keyValue `json:"attributes,omitempty"`
+	TraceID              string     `json:"traceId,omitempty"`
+	SpanID               string     `json:"spanId,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+// anyValue is an OTLP value, of which exactly one field is set.
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    string   `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func stringAttr(key, value string) keyValue {
+	return keyValue{Key: key, Value: anyValue{StringValue: &value}}
+}
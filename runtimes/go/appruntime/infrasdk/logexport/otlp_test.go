@@ -0,0 +1,117 @@
+package logexport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/infrasdk/metadata"
+)
+
+func TestOTLPExport(t *testing.T) {
+	c := qt.New(t)
+
+	var (
+		gotBody   []byte
+		gotHeader http.Header
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotHeader = req.Header
+		gotBody, _ = io.ReadAll(req.Body)
+	}))
+	defer srv.Close()
+
+	x := newOTLPExporter([]string{"foo", "bar"}, &config.OTLPLogsProvider{
+		Endpoint: srv.URL,
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	}, &metadata.ContainerMetadata{})
+
+	ts := time.Unix(1700000000, 5)
+	records := []Record{
+		{
+			Time:    ts,
+			Level:   model.LevelInfo,
+			Msg:     "hello",
+			Fields:  []trace2.LogField{{Key: "user", Value: "alice"}, {Key: "n", Value: 3}},
+			TraceID: model.TraceID{1, 2, 3},
+			SpanID:  model.SpanID{4, 5, 6},
+			SvcNum:  2,
+		},
+		{Time: ts, Level: model.LevelError, Msg: "startup failed"},
+	}
+	c.Assert(x.Export(context.Background(), records), qt.IsNil)
+	c.Assert(gotHeader.Get("Authorization"), qt.Equals, "Bearer token")
+	c.Assert(gotHeader.Get("Content-Type"), qt.Equals, "application/json")
+
+	var req map[string]any
+	c.Assert(json.Unmarshal(gotBody, &req), qt.IsNil)
+	resLogs := req["resourceLogs"].([]any)
+	c.Assert(resLogs, qt.HasLen, 2)
+
+	bar := resLogs[0].(map[string]any)
+	c.Assert(bar["resource"], qt.DeepEquals, map[string]any{
+		"attributes": []any{map[string]any{"key": "service.name", "value": map[string]any{"stringValue": "bar"}}},
+	})
+	rec := bar["scopeLogs"].([]any)[0].(map[string]any)["logRecords"].([]any)[0].(map[string]any)
+	c.Assert(rec["timeUnixNano"], qt.Equals, "1700000000000000005")
+	c.Assert(rec["severityNumber"], qt.Equals, 9.0)
+	c.Assert(rec["severityText"], qt.Equals, "INFO")
+	c.Assert(rec["body"], qt.DeepEquals, map[string]any{"stringValue": "hello"})
+	c.Assert(rec["traceId"], qt.Equals, "01020300000000000000000000000000")
+	c.Assert(rec["spanId"], qt.Equals, "0405060000000000")
+	c.Assert(rec["attributes"], qt.DeepEquals, []any{
+		map[string]any{"key": "user", "value": map[string]any{"stringValue": "alice"}},
+		map[string]any{"key": "n", "value": map[string]any{"intValue": "3"}},
+	})
+
+	// Records logged outside of a service have no service name.
+	other := resLogs[1].(map[string]any)
+	c.Assert(other["resource"], qt.DeepEquals, map[string]any{})
+	rec = other["scopeLogs"].([]any)[0].(map[string]any)["logRecords"].([]any)[0].(map[string]any)
+	c.Assert(rec["severityText"], qt.Equals, "ERROR")
+	c.Assert(rec["traceId"], qt.IsNil)
+}
+
+func TestOTLPExport_Error(t *testing.T) {
+	c := qt.New(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	x := newOTLPExporter(nil, &config.OTLPLogsProvider{Endpoint: srv.URL}, &metadata.ContainerMetadata{})
+	err := x.Export(context.Background(), []Record{{Time: time.Now(), Msg: "hello"}})
+	c.Assert(err, qt.ErrorMatches, `unable to send logs to OTLP endpoint: 400 Bad Request: bad request\n`)
+}
+
+func TestAttrValue(t *testing.T) {
+	c := qt.New(t)
+	marshal := func(v any) string {
+		data, err := json.Marshal(attrValue(v))
+		c.Assert(err, qt.IsNil)
+		return string(data)
+	}
+
+	c.Assert(marshal("str"), qt.Equals, `{"stringValue":"str"}`)
+	c.Assert(marshal(""), qt.Equals, `{"stringValue":""}`)
+	c.Assert(marshal(false), qt.Equals, `{"boolValue":false}`)
+	c.Assert(marshal(int8(-3)), qt.Equals, `{"intValue":"-3"}`)
+	c.Assert(marshal(0), qt.Equals, `{"intValue":"0"}`)
+	c.Assert(marshal(uint64(math.MaxUint64)), qt.Equals, `{"stringValue":"18446744073709551615"}`)
+	c.Assert(marshal(1.5), qt.Equals, `{"doubleValue":1.5}`)
+	c.Assert(marshal(math.Inf(1)), qt.Equals, `{"stringValue":"+Inf"}`)
+	c.Assert(marshal(errors.New("boom")), qt.Equals, `{"stringValue":"boom"}`)
+	c.Assert(marshal(time.Second), qt.Equals, `{"stringValue":"1s"}`)
+	c.Assert(marshal(map[string]int{"a": 1}), qt.Equals, `{"stringValue":"{\"a\":1}"}`)
+}
@@ -0,0 +1,21 @@
+//go:build encore_app
+
+package logexport
+
+import (
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+// This file is named "zzz_singleton_internal.go" so that it is the last file
+// in the package, to ensure all providers are registered before
+// we instantiate the manager.
+
+var Singleton *Manager
+
+func init() {
+	Singleton = NewManager(appconf.Static, appconf.Runtime, logging.RootLogger)
+	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+	go Singleton.BeginFlushing()
+}
@@ -17,7 +17,9 @@ import (
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/infrasdk/metadata"
 	"encore.dev/appruntime/infrasdk/metrics/system"
+	"encore.dev/appruntime/shared/explicithist"
 	"encore.dev/appruntime/shared/nativehist"
+	"encore.dev/appruntime/shared/quantile"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/metrics"
 )
@@ -149,7 +151,7 @@ func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMet
 					}
 				}
 			}
-		case []*nativehist.Histogram:
+		case []*nativehist.Histogram, []*explicithist.Histogram, []*quantile.Summary:
 			// TODO implement support
 		default:
 			x.rootLogger.Error().Msgf("encore: internal error: unknown value type %T for metric %s",
@@ -161,21 +163,17 @@ func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMet
 }
 
 func (x *Exporter) getSysMetrics(now time.Time) []types.MetricDatum {
-	sysMetrics := system.ReadSysMetrics(x.rootLogger)
-	return []types.MetricDatum{
-		{
-			MetricName: aws.String(system.MetricNameHeapObjectsBytes),
-			Timestamp:  aws.Time(now),
-			Value:      aws.Float64(float64(sysMetrics[system.MetricNameHeapObjectsBytes])),
-			Dimensions: x.containerMetadataDims,
-		},
-		{
-			MetricName: aws.String(system.MetricNameGoroutines),
+	sysMetrics := system.Read(x.rootLogger)
+	data := make([]types.MetricDatum, 0, len(sysMetrics))
+	for _, m := range sysMetrics {
+		data = append(data, types.MetricDatum{
+			MetricName: aws.String(m.Name),
 			Timestamp:  aws.Time(now),
-			Value:      aws.Float64(float64(sysMetrics[system.MetricNameGoroutines])),
+			Value:      aws.Float64(m.Value),
 			Dimensions: x.containerMetadataDims,
-		},
+		})
 	}
+	return data
 }
 
 func (x *Exporter) getClient() *cloudwatch.Client {
@@ -34,6 +34,8 @@ func New(svcs []string, cfg *config.DatadogProvider, meta *metadata.ContainerMet
 		rootLogger: rootLogger,
 		lastExport: time.Now().Unix(),
 		lastValue:  map[tsSvcKey]float64{},
+
+		lastSysValue: map[string]float64{},
 	}
 }
 
@@ -50,6 +52,7 @@ type Exporter struct {
 	rootLogger              zerolog.Logger
 	lastExport              int64
 	lastValue               map[tsSvcKey]float64
+	lastSysValue            map[string]float64 // last value of system counters, by name
 }
 
 func (x *Exporter) Shutdown(p *shutdown.Process) error {
@@ -172,27 +175,29 @@ func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMet
 }
 
 func (x *Exporter) getSysMetrics(now time.Time) []datadogV2.MetricSeries {
-	sysMetrics := system.ReadSysMetrics(x.rootLogger)
-	return []datadogV2.MetricSeries{
-		{
-			Metric: system.MetricNameHeapObjectsBytes,
+	sysMetrics := system.Read(x.rootLogger)
+	data := make([]datadogV2.MetricSeries, 0, len(sysMetrics))
+	for _, m := range sysMetrics {
+		series := datadogV2.MetricSeries{
+			Metric: m.Name,
 			Points: []datadogV2.MetricPoint{{
 				Timestamp: datadog.PtrInt64(now.Unix()),
-				Value:     datadog.PtrFloat64(float64(sysMetrics[system.MetricNameHeapObjectsBytes])),
+				Value:     datadog.PtrFloat64(m.Value),
 			}},
 			Tags: x.containerMetadataLabels,
 			Type: datadogV2.METRICINTAKETYPE_GAUGE.Ptr(),
-		},
-		{
-			Metric: system.MetricNameGoroutines,
-			Points: []datadogV2.MetricPoint{{
-				Timestamp: datadog.PtrInt64(now.Unix()),
-				Value:     datadog.PtrFloat64(float64(sysMetrics[system.MetricNameGoroutines])),
-			}},
-			Tags: x.containerMetadataLabels,
-			Type: datadogV2.METRICINTAKETYPE_GAUGE.Ptr(),
-		},
+		}
+		if m.Kind == system.Counter {
+			// Datadog counts are deltas, so report the change since the last export.
+			lastVal := x.lastSysValue[m.Name]
+			x.lastSysValue[m.Name] = m.Value
+			series.Points[0].Value = datadog.PtrFloat64(m.Value - lastVal)
+			series.Interval = datadog.PtrInt64(now.Unix() - x.lastExport)
+			series.Type = datadogV2.METRICINTAKETYPE_COUNT.Ptr()
+		}
+		data = append(data, series)
 	}
+	return data
 }
 
 func (x *Exporter) newContext(parent context.Context) context.Context {
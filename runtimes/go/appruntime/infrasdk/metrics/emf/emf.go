@@ -0,0 +1,254 @@
+//go:build !encore_no_aws
+
+// Package emf writes metrics as log lines in the CloudWatch Embedded Metric Format,
+// from which CloudWatch Logs extracts the metrics asynchronously.
+//
+// See https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch_Embedded_Metric_Format_Specification.html
+package emf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/infrasdk/metrics/system"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/metrics"
+)
+
+// maxMetricsPerDocument is the maximum number of metrics
+// a single EMF document may contain.
+const maxMetricsPerDocument = 100
+
+func New(svcs []string, cfg *config.CloudWatchEMFProvider, meta *metadata.ContainerMetadata, rootLogger zerolog.Logger) *Exporter {
+	// Precompute container metadata dimensions.
+	return &Exporter{
+		svcs: svcs,
+		cfg:  cfg,
+		containerMetadataDims: metadata.MapMetadataLabels(meta, func(k, v string) dimension {
+			return dimension{name: k, value: v}
+		}),
+		rootLogger:  rootLogger,
+		out:         os.Stdout,
+		lastSent:    make(map[tsSvcKey]float64),
+		lastSysSent: make(map[string]float64),
+	}
+}
+
+type tsSvcKey struct {
+	tsID uint64
+	svc  uint16
+}
+
+type Exporter struct {
+	svcs                  []string
+	cfg                   *config.CloudWatchEMFProvider
+	containerMetadataDims []dimension
+	rootLogger            zerolog.Logger
+
+	mu          sync.Mutex
+	out         io.Writer
+	lastSent    map[tsSvcKey]float64 // last reported value of counters
+	lastSysSent map[string]float64   // last reported value of system counters, by name
+}
+
+type dimension struct {
+	name, value string
+}
+
+// document is a set of metrics sharing the same dimensions,
+// written as a single log line.
+type document struct {
+	dims    []dimension
+	metrics []value
+}
+
+type value struct {
+	name string
+	unit string
+	val  float64
+}
+
+func (x *Exporter) Shutdown(p *shutdown.Process) error {
+	return nil
+}
+
+func (x *Exporter) Export(ctx context.Context, collected []metrics.CollectedMetric) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	now := time.Now()
+	for _, doc := range x.getDocuments(collected) {
+		line, err := x.encode(now, doc)
+		if err != nil {
+			return fmt.Errorf("unable to encode metrics: %v", err)
+		}
+		// Write each document in a single call so it isn't
+		// interleaved with other output.
+		if _, err := x.out.Write(line); err != nil {
+			return fmt.Errorf("unable to write metrics: %v", err)
+		}
+	}
+	return nil
+}
+
+// getDocuments groups the collected metrics into documents by their dimensions.
+// It must be called with x.mu held.
+func (x *Exporter) getDocuments(collected []metrics.CollectedMetric) []*document {
+	var docs []*document
+	byDims := make(map[string]*document)
+
+	add := func(dims []dimension, v value) {
+		var key strings.Builder
+		for _, d := range dims {
+			key.WriteString(d.name)
+			key.WriteByte('=')
+			key.WriteString(d.value)
+			key.WriteByte(0)
+		}
+		doc := byDims[key.String()]
+		if doc == nil || len(doc.metrics) == maxMetricsPerDocument {
+			doc = &document{dims: dims}
+			byDims[key.String()] = doc
+			docs = append(docs, doc)
+		}
+		doc.metrics = append(doc.metrics, v)
+	}
+
+	for _, m := range collected {
+		var unit string
+		switch m.Info.Type() {
+		case metrics.CounterType:
+			unit = "Count"
+		case metrics.GaugeType:
+			unit = "None"
+		default:
+			// TODO support histograms and summaries.
+			// EMF only supports individual observations.
+			continue
+		}
+
+		dims := make([]dimension, len(x.containerMetadataDims), len(x.containerMetadataDims)+len(m.Labels)+1)
+		copy(dims, x.containerMetadataDims)
+		for _, label := range m.Labels {
+			if label.Value == "" {
+				x.rootLogger.Warn().Str("label", label.Key).Msg("metrics: aws cloudwatch does not support empty label values, skipping")
+				continue
+			}
+			dims = append(dims, dimension{name: label.Key, value: label.Value})
+		}
+
+		doAdd := func(val float64, svcIdx uint16) {
+			// Counters are cumulative, while CloudWatch sums the reported values,
+			// so report the change since the last export.
+			if m.Info.Type() == metrics.CounterType {
+				key := tsSvcKey{tsID: m.TimeSeriesID, svc: svcIdx}
+				last := x.lastSent[key]
+				x.lastSent[key] = val
+				val -= last
+			}
+			if math.IsNaN(val) || math.IsInf(val, 0) {
+				// Not representable in JSON.
+				return
+			}
+			svcDims := append(dims[:len(dims):len(dims)], dimension{name: "service", value: x.svcs[svcIdx]})
+			add(svcDims, value{name: m.Info.Name(), unit: unit, val: val})
+		}
+
+		forEach := func(n int, val func(i int) float64) {
+			if svcNum := m.Info.SvcNum(); svcNum > 0 {
+				if m.Valid[0].Load() {
+					doAdd(val(0), svcNum-1)
+				}
+			} else {
+				for i := 0; i < n; i++ {
+					if m.Valid[i].Load() {
+						doAdd(val(i), uint16(i))
+					}
+				}
+			}
+		}
+
+		switch vals := m.Val.(type) {
+		case []float64:
+			forEach(len(vals), func(i int) float64 { return vals[i] })
+		case []int64:
+			forEach(len(vals), func(i int) float64 { return float64(vals[i]) })
+		case []uint64:
+			forEach(len(vals), func(i int) float64 { return float64(vals[i]) })
+		case []time.Duration:
+			forEach(len(vals), func(i int) float64 { return vals[i].Seconds() })
+		default:
+			x.rootLogger.Error().Msgf("encore: internal error: unknown value type %T for metric %s",
+				m.Val, m.Info.Name())
+		}
+	}
+
+	for _, m := range system.Read(x.rootLogger) {
+		unit, val := "None", m.Value
+		if m.Kind == system.Counter {
+			unit = "Count"
+			val -= x.lastSysSent[m.Name]
+			x.lastSysSent[m.Name] = m.Value
+		}
+		add(x.containerMetadataDims, value{name: m.Name, unit: unit, val: val})
+	}
+
+	return docs
+}
+
+type metadataJSON struct {
+	Timestamp         int64           `json:"Timestamp"`
+	CloudWatchMetrics []directiveJSON `json:"CloudWatchMetrics"`
+}
+
+type directiveJSON struct {
+	Namespace  string           `json:"Namespace"`
+	Dimensions [][]string       `json:"Dimensions"`
+	Metrics    []definitionJSON `json:"Metrics"`
+}
+
+type definitionJSON struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+// encode encodes the document as a newline-terminated EMF log line.
+func (x *Exporter) encode(now time.Time, doc *document) ([]byte, error) {
+	dimNames := make([]string, len(doc.dims))
+	defs := make([]definitionJSON, len(doc.metrics))
+
+	obj := make(map[string]any, len(doc.dims)+len(doc.metrics)+1)
+	for i, d := range doc.dims {
+		dimNames[i] = d.name
+		obj[d.name] = d.value
+	}
+	for i, m := range doc.metrics {
+		defs[i] = definitionJSON{Name: m.name, Unit: m.unit}
+		obj[m.name] = m.val
+	}
+	obj["_aws"] = metadataJSON{
+		Timestamp: now.UnixMilli(),
+		CloudWatchMetrics: []directiveJSON{{
+			Namespace:  x.cfg.Namespace,
+			Dimensions: [][]string{dimNames},
+			Metrics:    defs,
+		}},
+	}
+
+	line, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
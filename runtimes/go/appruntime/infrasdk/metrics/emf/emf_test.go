@@ -0,0 +1,144 @@
+package emf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/metrics"
+)
+
+type metricInfo struct {
+	name   string
+	typ    metrics.MetricType
+	svcNum uint16
+}
+
+func (m metricInfo) Name() string             { return m.name }
+func (m metricInfo) Type() metrics.MetricType { return m.typ }
+func (m metricInfo) SvcNum() uint16           { return m.svcNum }
+
+func valid(n int) []atomic.Bool {
+	v := make([]atomic.Bool, n)
+	for i := range v {
+		v[i].Store(true)
+	}
+	return v
+}
+
+func TestExport(t *testing.T) {
+	c := qt.New(t)
+
+	counter := []uint64{5, 2}
+	collected := []metrics.CollectedMetric{
+		{
+			Info:         metricInfo{"requests", metrics.CounterType, 0},
+			TimeSeriesID: 1,
+			Labels:       []metrics.KeyValue{{Key: "endpoint", Value: "Get"}},
+			Val:          counter,
+			Valid:        valid(2),
+		},
+		{
+			Info:         metricInfo{"queue_depth", metrics.GaugeType, 2},
+			TimeSeriesID: 2,
+			Labels:       []metrics.KeyValue{{Key: "endpoint", Value: "Get"}},
+			Val:          []float64{1.5},
+			Valid:        valid(1),
+		},
+		{
+			Info:         metricInfo{"latency", metrics.HistogramType, 1},
+			TimeSeriesID: 3,
+			Val:          []float64{1},
+			Valid:        valid(1),
+		},
+	}
+
+	var out bytes.Buffer
+	x := New([]string{"foo", "bar"}, &config.CloudWatchEMFProvider{Namespace: "my-app"}, &metadata.ContainerMetadata{}, zerolog.Nop())
+	x.out = &out
+
+	export := func() map[string]map[string]any {
+		t.Helper()
+		out.Reset()
+		c.Assert(x.Export(context.Background(), collected), qt.IsNil)
+
+		// Index the documents by service, ignoring system metrics.
+		docs := make(map[string]map[string]any)
+		for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+			var doc map[string]any
+			c.Assert(json.Unmarshal([]byte(line), &doc), qt.IsNil)
+			if svc, ok := doc["service"].(string); ok {
+				docs[svc] = doc
+			}
+		}
+		return docs
+	}
+
+	docs := export()
+	c.Assert(docs, qt.HasLen, 2)
+
+	foo := docs["foo"]
+	c.Assert(foo["endpoint"], qt.Equals, "Get")
+	c.Assert(foo["requests"], qt.Equals, 5.0)
+	c.Assert(foo["latency"], qt.IsNil)
+
+	aws := foo["_aws"].(map[string]any)
+	ts := int64(aws["Timestamp"].(float64))
+	c.Assert(time.Since(time.UnixMilli(ts)) < time.Minute, qt.IsTrue)
+	c.Assert(aws["CloudWatchMetrics"], qt.DeepEquals, []any{
+		map[string]any{
+			"Namespace":  "my-app",
+			"Dimensions": []any{[]any{"endpoint", "service"}},
+			"Metrics":    []any{map[string]any{"Name": "requests", "Unit": "Count"}},
+		},
+	})
+
+	// Metrics with the same dimensions are reported together.
+	bar := docs["bar"]
+	c.Assert(bar["requests"], qt.Equals, 2.0)
+	c.Assert(bar["queue_depth"], qt.Equals, 1.5)
+	c.Assert(bar["_aws"].(map[string]any)["CloudWatchMetrics"].([]any)[0].(map[string]any)["Metrics"], qt.DeepEquals, []any{
+		map[string]any{"Name": "requests", "Unit": "Count"},
+		map[string]any{"Name": "queue_depth", "Unit": "None"},
+	})
+
+	// Counters are reported as the change since the last export.
+	counter[0] = 8
+	docs = export()
+	c.Assert(docs["foo"]["requests"], qt.Equals, 3.0)
+	c.Assert(docs["bar"]["requests"], qt.Equals, 0.0)
+}
+
+func TestGetDocuments_Split(t *testing.T) {
+	c := qt.New(t)
+
+	var collected []metrics.CollectedMetric
+	for i := 0; i < maxMetricsPerDocument+1; i++ {
+		collected = append(collected, metrics.CollectedMetric{
+			Info:         metricInfo{"gauge_" + strings.Repeat("x", i), metrics.GaugeType, 1},
+			TimeSeriesID: uint64(i),
+			Val:          []int64{int64(i)},
+			Valid:        valid(1),
+		})
+	}
+
+	x := New([]string{"foo"}, &config.CloudWatchEMFProvider{Namespace: "my-app"}, &metadata.ContainerMetadata{}, zerolog.Nop())
+	var svcDocs []*document
+	for _, doc := range x.getDocuments(collected) {
+		if len(doc.dims) > 0 {
+			svcDocs = append(svcDocs, doc)
+		}
+	}
+	c.Assert(svcDocs, qt.HasLen, 2)
+	c.Assert(svcDocs[0].metrics, qt.HasLen, maxMetricsPerDocument)
+	c.Assert(svcDocs[1].metrics, qt.HasLen, 1)
+}
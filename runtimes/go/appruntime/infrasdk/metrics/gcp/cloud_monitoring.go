@@ -19,7 +19,9 @@ import (
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/infrasdk/metadata"
 	"encore.dev/appruntime/infrasdk/metrics/system"
+	"encore.dev/appruntime/shared/explicithist"
 	"encore.dev/appruntime/shared/nativehist"
+	"encore.dev/appruntime/shared/quantile"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/metrics"
 )
@@ -207,7 +209,7 @@ func (x *Exporter) getMetricData(newCounterStart, endTime time.Time, collected [
 				}
 			}
 
-		case []*nativehist.Histogram:
+		case []*nativehist.Histogram, []*explicithist.Histogram, []*quantile.Summary:
 			// TODO implement support
 
 		default:
@@ -300,28 +302,18 @@ func (x *Exporter) getSysMetrics(now time.Time) []*monitoringpb.TimeSeries {
 		Type:   x.cfg.MonitoredResourceType,
 		Labels: x.cfg.MonitoredResourceLabels,
 	}
-	sysMetrics := system.ReadSysMetrics(x.rootLogger)
-
-	if cloudMetricName, ok := x.metricNames[system.MetricNameHeapObjectsBytes]; !ok {
-		x.rootLogger.Error().Msgf("encore: internal error: metric %s not found in config", system.MetricNameHeapObjectsBytes)
-	} else {
-		output = append(output, &monitoringpb.TimeSeries{
-			MetricKind: metricpb.MetricDescriptor_GAUGE,
-			Metric: &metricpb.Metric{
-				Type:   "custom.googleapis.com/" + cloudMetricName,
-				Labels: x.containerMetadataLabels,
-			},
-			Resource: monitoredResource,
-			Points: []*monitoringpb.Point{{
-				Interval: &monitoringpb.TimeInterval{EndTime: timestamppb.New(now)},
-				Value:    uint64Val(sysMetrics[system.MetricNameHeapObjectsBytes]),
-			}},
-		})
+	sysMetrics := make(map[string]float64)
+	for _, m := range system.Read(x.rootLogger) {
+		sysMetrics[m.Name] = m.Value
 	}
 
-	if cloudMetricName, ok := x.metricNames[system.MetricNameGoroutines]; !ok {
-		x.rootLogger.Error().Msgf("encore: internal error: metric %s not found in config", system.MetricNameGoroutines)
-	} else {
+	// Only the system metrics with provisioned metric descriptors are exported.
+	for _, name := range []string{system.MetricNameHeapObjectsBytes, system.MetricNameGoroutines} {
+		cloudMetricName, ok := x.metricNames[name]
+		if !ok {
+			x.rootLogger.Error().Msgf("encore: internal error: metric %s not found in config", name)
+			continue
+		}
 		output = append(output, &monitoringpb.TimeSeries{
 			MetricKind: metricpb.MetricDescriptor_GAUGE,
 			Metric: &metricpb.Metric{
@@ -331,7 +323,7 @@ func (x *Exporter) getSysMetrics(now time.Time) []*monitoringpb.TimeSeries {
 			Resource: monitoredResource,
 			Points: []*monitoringpb.Point{{
 				Interval: &monitoringpb.TimeInterval{EndTime: timestamppb.New(now)},
-				Value:    uint64Val(sysMetrics[system.MetricNameGoroutines]),
+				Value:    uint64Val(uint64(sysMetrics[name])),
 			}},
 		})
 	}
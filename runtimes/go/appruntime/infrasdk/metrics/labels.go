@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/metrics"
+)
+
+// constLabels adds the configured name prefix and constant labels
+// to the collected metrics, so that multiple applications can
+// export metrics to the same backend without colliding.
+type constLabels struct {
+	prefix string
+	labels []metrics.KeyValue
+
+	// svcLabels are the labels to add to the metrics of each service,
+	// indexed by service number - 1. It's nil if no service has
+	// labels of its own, in which case labels are used for all services.
+	svcLabels [][]metrics.KeyValue
+}
+
+// newConstLabels returns the constLabels for the given config,
+// or nil if no prefix or labels are configured.
+func newConstLabels(cfg *config.Metrics, svcs []string) *constLabels {
+	if cfg.Prefix == "" && len(cfg.Labels) == 0 && len(cfg.ServiceLabels) == 0 {
+		return nil
+	}
+
+	c := &constLabels{
+		prefix: cfg.Prefix,
+		labels: sortedLabels(cfg.Labels),
+	}
+	if len(cfg.ServiceLabels) > 0 {
+		c.svcLabels = make([][]metrics.KeyValue, len(svcs))
+		for i, svc := range svcs {
+			merged := make(map[string]string, len(cfg.Labels)+len(cfg.ServiceLabels[svc]))
+			for k, v := range cfg.Labels {
+				merged[k] = v
+			}
+			for k, v := range cfg.ServiceLabels[svc] {
+				merged[k] = v
+			}
+			c.svcLabels[i] = sortedLabels(merged)
+		}
+	}
+	return c
+}
+
+func sortedLabels(labels map[string]string) []metrics.KeyValue {
+	kvs := make([]metrics.KeyValue, 0, len(labels))
+	for k, v := range labels {
+		kvs = append(kvs, metrics.KeyValue{Key: k, Value: v})
+	}
+	slices.SortFunc(kvs, func(a, b metrics.KeyValue) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return kvs
+}
+
+// serviceInfo overrides the service of a metric.
+type serviceInfo struct {
+	metrics.MetricInfo
+	svcNum uint16
+}
+
+func (i serviceInfo) SvcNum() uint16 { return i.svcNum }
+
+// apply adds the prefix and constant labels to the collected metrics.
+//
+// When services have labels of their own, time series shared by all services
+// are split into one time series per service so each can be labelled.
+// The split time series keep the original id, which together with the
+// service number still identifies them uniquely.
+func (c *constLabels) apply(collected []metrics.CollectedMetric) []metrics.CollectedMetric {
+	if c == nil {
+		return collected
+	}
+
+	result := make([]metrics.CollectedMetric, 0, len(collected))
+	for _, m := range collected {
+		if c.prefix != "" {
+			m.Info = renamedInfo{MetricInfo: m.Info, name: c.prefix + m.Info.Name()}
+		}
+
+		if c.svcLabels == nil {
+			m.Labels = addLabels(m.Labels, c.labels)
+			result = append(result, m)
+			continue
+		} else if svcNum := m.Info.SvcNum(); svcNum > 0 {
+			m.Labels = addLabels(m.Labels, c.svcLabels[svcNum-1])
+			result = append(result, m)
+			continue
+		}
+
+		vals := reflect.ValueOf(m.Val)
+		for i := range m.Valid {
+			if i >= len(c.svcLabels) || !m.Valid[i].Load() {
+				continue
+			}
+			result = append(result, metrics.CollectedMetric{
+				Info:         serviceInfo{MetricInfo: m.Info, svcNum: uint16(i + 1)},
+				TimeSeriesID: m.TimeSeriesID,
+				Labels:       addLabels(m.Labels, c.svcLabels[i]),
+				Val:          vals.Slice(i, i+1).Interface(),
+				Valid:        m.Valid[i : i+1],
+			})
+		}
+	}
+	return result
+}
+
+// addLabels returns the labels with the constant labels added,
+// except for those whose key is already in use by the metric.
+func addLabels(labels, constant []metrics.KeyValue) []metrics.KeyValue {
+	if len(constant) == 0 {
+		return labels
+	}
+	result := make([]metrics.KeyValue, len(labels), len(labels)+len(constant))
+	copy(result, labels)
+	for _, kv := range constant {
+		if !slices.ContainsFunc(labels, func(l metrics.KeyValue) bool { return l.Key == kv.Key }) {
+			result = append(result, kv)
+		}
+	}
+	return result
+}
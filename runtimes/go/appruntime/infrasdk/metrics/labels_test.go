@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/metrics"
+)
+
+func TestConstLabels(t *testing.T) {
+	c := qt.New(t)
+
+	requests := &testInfo{name: "e_requests_total", typ: metrics.CounterType}
+	endpoint := []metrics.KeyValue{{Key: "endpoint", Value: "Get"}}
+	collected := []metrics.CollectedMetric{
+		series[uint64](requests, 1, endpoint, 3, 4),
+	}
+
+	c.Assert(newConstLabels(&config.Metrics{}, []string{"foo", "bar"}), qt.IsNil)
+
+	consts := newConstLabels(&config.Metrics{
+		Prefix: "myapp_",
+		Labels: map[string]string{"env": "prod", "endpoint": "ignored"},
+	}, []string{"foo", "bar"})
+	got := consts.apply(collected)
+	c.Assert(got, qt.HasLen, 1)
+	c.Assert(got[0].Info.Name(), qt.Equals, "myapp_e_requests_total")
+	c.Assert(got[0].Info.SvcNum(), qt.Equals, uint16(0))
+	c.Assert(got[0].Labels, qt.DeepEquals, []metrics.KeyValue{
+		{Key: "endpoint", Value: "Get"},
+		{Key: "env", Value: "prod"},
+	})
+	c.Assert(collected[0].Labels, qt.HasLen, 1)
+
+	// Service labels split the time series per service.
+	consts = newConstLabels(&config.Metrics{
+		Labels:        map[string]string{"env": "prod", "team": "core"},
+		ServiceLabels: map[string]map[string]string{"bar": {"team": "payments"}},
+	}, []string{"foo", "bar"})
+	got = consts.apply(collected)
+	c.Assert(got, qt.HasLen, 2)
+	for i, want := range []struct {
+		team string
+		val  uint64
+	}{{"core", 3}, {"payments", 4}} {
+		c.Assert(got[i].Info.Name(), qt.Equals, "e_requests_total")
+		c.Assert(got[i].Info.SvcNum(), qt.Equals, uint16(i+1))
+		c.Assert(got[i].TimeSeriesID, qt.Equals, uint64(1))
+		c.Assert(got[i].Labels, qt.DeepEquals, []metrics.KeyValue{
+			{Key: "endpoint", Value: "Get"},
+			{Key: "env", Value: "prod"},
+			{Key: "team", Value: want.team},
+		})
+		c.Assert(got[i].Val, qt.DeepEquals, []uint64{want.val})
+		c.Assert(got[i].Valid, qt.HasLen, 1)
+	}
+
+	// Invalid values are not exported.
+	collected[0].Valid[0].Store(false)
+	got = consts.apply(collected)
+	c.Assert(got, qt.HasLen, 1)
+	c.Assert(got[0].Info.SvcNum(), qt.Equals, uint16(2))
+}
@@ -20,6 +20,8 @@ type Manager struct {
 	reg        *metrics.Registry
 	rootLogger zerolog.Logger
 	exp        exporter
+	views      []*view
+	consts     *constLabels
 
 	logsEmitter *logsBasedEmitter
 }
@@ -40,6 +42,8 @@ func NewManager(reg *metrics.Registry, static *config.Static, rtConf *config.Run
 		return mgr
 	}
 
+	mgr.views = newViews(rtConf.Metrics.Views)
+	mgr.consts = newConstLabels(rtConf.Metrics, static.BundledServices)
 	for _, desc := range providerRegistry {
 		if desc.matches(rtConf.Metrics) {
 			mgr.exp = desc.newExporter(mgr)
@@ -62,11 +66,26 @@ func (mgr *Manager) Shutdown(p *shutdown.Process) error {
 	mgr.cancel()
 
 	if mgr.exp != nil {
+		mgr.flush(p.ForceShutdown)
 		return mgr.exp.Shutdown(p)
 	}
 	return nil
 }
 
+// flush exports the metrics one last time before shutting down,
+// so metrics recorded since the last collection aren't lost.
+// This is especially important for short-lived instances,
+// which may not live long enough for metrics to be collected periodically.
+func (mgr *Manager) flush(ctx context.Context) {
+	if mgr.runtime.EnvType == "test" {
+		return
+	} else if _, ok := mgr.exp.(server); ok {
+		// Metrics are collected when requested.
+		return
+	}
+	mgr.collectNow(ctx)
+}
+
 func (mgr *Manager) BeginCollection() {
 	if mgr.exp == nil {
 		return
@@ -75,6 +94,13 @@ func (mgr *Manager) BeginCollection() {
 		return
 	}
 
+	if srv, ok := mgr.exp.(server); ok {
+		// Metrics are collected when requested, so there's
+		// no need to collect them periodically.
+		srv.Serve()
+		return
+	}
+
 	interval := mgr.runtime.Metrics.CollectionInterval
 	if interval <= 0 {
 		interval = time.Minute
@@ -86,6 +112,7 @@ func (mgr *Manager) BeginCollection() {
 		select {
 		case <-mgr.ctx.Done():
 			ticker.Stop()
+			return
 		case <-ticker.C:
 			ctx, cancel := context.WithTimeout(context.Background(), timeoutDur)
 			mgr.collectNow(ctx)
@@ -99,7 +126,7 @@ func (mgr *Manager) collectNow(ctx context.Context) {
 		return
 	}
 
-	m := mgr.reg.Collect()
+	m := mgr.collect()
 	if err := mgr.exp.Export(ctx, m); err != nil {
 		mgr.rootLogger.Error().Err(err).Msg("unable to emit metrics")
 	} else {
@@ -107,11 +134,24 @@ func (mgr *Manager) collectNow(ctx context.Context) {
 	}
 }
 
+// collect collects the metrics to export, with the configured views,
+// prefix and constant labels applied.
+func (mgr *Manager) collect() []metrics.CollectedMetric {
+	return mgr.consts.apply(applyViews(mgr.views, mgr.reg.Collect()))
+}
+
 type exporter interface {
 	Export(context.Context, []metrics.CollectedMetric) error
 	Shutdown(p *shutdown.Process) error
 }
 
+// server is implemented by exporters that serve metrics on request
+// rather than pushing them to a provider.
+type server interface {
+	exporter
+	Serve()
+}
+
 type providerDesc struct {
 	name        string
 	matches     func(cfg *config.Metrics) bool
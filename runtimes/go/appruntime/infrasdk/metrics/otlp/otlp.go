@@ -0,0 +1,436 @@
+//go:build !encore_no_otlp
+
+// Package otlp exports metrics to an OpenTelemetry collector using
+// OTLP/HTTP with the JSON encoding.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/infrasdk/metrics/system"
+	"encore.dev/appruntime/shared/exemplar"
+	"encore.dev/appruntime/shared/explicithist"
+	"encore.dev/appruntime/shared/nativehist"
+	"encore.dev/appruntime/shared/quantile"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/metrics"
+)
+
+func New(svcs []string, cfg *config.OTLPMetricsProvider, meta *metadata.ContainerMetadata, rootLogger zerolog.Logger) *Exporter {
+	// Precompute container metadata attributes.
+	return &Exporter{
+		svcs: svcs,
+		cfg:  cfg,
+		containerMetadataAttrs: metadata.MapMetadataLabels(meta, func(k, v string) keyValue {
+			return stringAttr(k, v)
+		}),
+		rootLogger: rootLogger,
+		client:     &http.Client{},
+		firstSeen:  make(map[tsSvcKey]int64),
+	}
+}
+
+type tsSvcKey struct {
+	tsID uint64
+	svc  uint16
+}
+
+type Exporter struct {
+	svcs                   []string
+	cfg                    *config.OTLPMetricsProvider
+	containerMetadataAttrs []keyValue
+	rootLogger             zerolog.Logger
+	client                 *http.Client
+
+	mu        sync.Mutex
+	firstSeen map[tsSvcKey]int64 // start time of cumulative time series, in unix nanoseconds
+	sysStart  int64              // start time of cumulative system metrics, in unix nanoseconds
+}
+
+func (x *Exporter) Shutdown(p *shutdown.Process) error {
+	x.client.CloseIdleConnections()
+	return nil
+}
+
+func (x *Exporter) Export(ctx context.Context, collected []metrics.CollectedMetric) error {
+	body, err := json.Marshal(x.getMetricData(time.Now(), collected))
+	if err != nil {
+		return fmt.Errorf("unable to marshal metrics: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, x.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "encore")
+	for k, v := range x.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := x.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send metrics to OTLP endpoint: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unable to send metrics to OTLP endpoint: %s: %s", resp.Status, msg)
+	}
+	return nil
+}
+
+func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMetric) *exportRequest {
+	nowNano := now.UnixNano()
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	// Metrics are grouped by service, each being its own resource.
+	bySvc := make(map[uint16]map[string]*metric)
+	getMetric := func(svcIdx uint16, name string) *metric {
+		byName := bySvc[svcIdx]
+		if byName == nil {
+			byName = make(map[string]*metric)
+			bySvc[svcIdx] = byName
+		}
+		m := byName[name]
+		if m == nil {
+			m = &metric{Name: name}
+			byName[name] = m
+		}
+		return m
+	}
+
+	startTime := func(tsID uint64, svcIdx uint16) int64 {
+		key := tsSvcKey{tsID: tsID, svc: svcIdx}
+		start, ok := x.firstSeen[key]
+		if !ok {
+			start = nowNano
+			x.firstSeen[key] = start
+		}
+		return start
+	}
+
+	for _, m := range collected {
+		attrs := make([]keyValue, 0, len(m.Labels))
+		for _, label := range m.Labels {
+			attrs = append(attrs, stringAttr(label.Key, label.Value))
+		}
+
+		doAdd := func(svcIdx uint16, point numberDataPoint) {
+			point.Attributes = attrs
+			point.TimeUnixNano = strconv.FormatInt(nowNano, 10)
+			out := getMetric(svcIdx, m.Info.Name())
+			switch m.Info.Type() {
+			case metrics.CounterType:
+				point.StartTimeUnixNano = strconv.FormatInt(startTime(m.TimeSeriesID, svcIdx), 10)
+				if out.Sum == nil {
+					out.Sum = &sum{AggregationTemporality: aggregationTemporalityCumulative, IsMonotonic: true}
+				}
+				out.Sum.DataPoints = append(out.Sum.DataPoints, point)
+			default:
+				if out.Gauge == nil {
+					out.Gauge = &gauge{}
+				}
+				out.Gauge.DataPoints = append(out.Gauge.DataPoints, point)
+			}
+		}
+
+		forEach := func(n int, fn func(i int, svcIdx uint16)) {
+			if svcNum := m.Info.SvcNum(); svcNum > 0 {
+				if m.Valid[0].Load() {
+					fn(0, svcNum-1)
+				}
+			} else {
+				for i := 0; i < n; i++ {
+					if m.Valid[i].Load() {
+						fn(i, uint16(i))
+					}
+				}
+			}
+		}
+
+		// Histograms and summaries don't track whether they're valid,
+		// so the data points without observations are skipped instead.
+		forEachHist := func(n int, fn func(i int, svcIdx uint16)) {
+			if svcNum := m.Info.SvcNum(); svcNum > 0 {
+				fn(0, svcNum-1)
+			} else {
+				for i := 0; i < n; i++ {
+					fn(i, uint16(i))
+				}
+			}
+		}
+
+		switch vals := m.Val.(type) {
+		case []float64:
+			forEach(len(vals), func(i int, svcIdx uint16) {
+				doAdd(svcIdx, numberDataPoint{AsDouble: ptr(vals[i])})
+			})
+		case []int64:
+			forEach(len(vals), func(i int, svcIdx uint16) {
+				doAdd(svcIdx, numberDataPoint{AsInt: strconv.FormatInt(vals[i], 10)})
+			})
+		case []uint64:
+			forEach(len(vals), func(i int, svcIdx uint16) {
+				doAdd(svcIdx, numberDataPoint{AsInt: strconv.FormatUint(vals[i], 10)})
+			})
+		case []time.Duration:
+			forEach(len(vals), func(i int, svcIdx uint16) {
+				doAdd(svcIdx, numberDataPoint{AsDouble: ptr(vals[i].Seconds())})
+			})
+		case []*nativehist.Histogram:
+			forEachHist(len(vals), func(i int, svcIdx uint16) {
+				point := exponentialDataPoint(vals[i])
+				if point == nil {
+					return
+				}
+				point.Attributes = attrs
+				point.StartTimeUnixNano = strconv.FormatInt(startTime(m.TimeSeriesID, svcIdx), 10)
+				point.TimeUnixNano = strconv.FormatInt(nowNano, 10)
+
+				out := getMetric(svcIdx, m.Info.Name())
+				if out.ExponentialHistogram == nil {
+					out.ExponentialHistogram = &exponentialHistogram{AggregationTemporality: aggregationTemporalityCumulative}
+				}
+				out.ExponentialHistogram.DataPoints = append(out.ExponentialHistogram.DataPoints, *point)
+			})
+		case []*explicithist.Histogram:
+			forEachHist(len(vals), func(i int, svcIdx uint16) {
+				point := explicitDataPoint(vals[i])
+				if point == nil {
+					return
+				}
+				point.Attributes = attrs
+				point.StartTimeUnixNano = strconv.FormatInt(startTime(m.TimeSeriesID, svcIdx), 10)
+				point.TimeUnixNano = strconv.FormatInt(nowNano, 10)
+
+				out := getMetric(svcIdx, m.Info.Name())
+				if out.Histogram == nil {
+					out.Histogram = &histogram{AggregationTemporality: aggregationTemporalityCumulative}
+				}
+				out.Histogram.DataPoints = append(out.Histogram.DataPoints, *point)
+			})
+		case []*quantile.Summary:
+			forEachHist(len(vals), func(i int, svcIdx uint16) {
+				point := summaryPoint(vals[i])
+				if point == nil {
+					return
+				}
+				point.Attributes = attrs
+				point.StartTimeUnixNano = strconv.FormatInt(startTime(m.TimeSeriesID, svcIdx), 10)
+				point.TimeUnixNano = strconv.FormatInt(nowNano, 10)
+
+				out := getMetric(svcIdx, m.Info.Name())
+				if out.Summary == nil {
+					out.Summary = &summary{}
+				}
+				out.Summary.DataPoints = append(out.Summary.DataPoints, *point)
+			})
+		default:
+			x.rootLogger.Error().Msgf("encore: internal error: unknown value type %T for metric %s",
+				m.Val, m.Info.Name())
+		}
+	}
+
+	req := &exportRequest{ResourceMetrics: make([]resourceMetrics, 0, len(bySvc)+1)}
+	svcIdxs := make([]uint16, 0, len(bySvc))
+	for svcIdx := range bySvc {
+		svcIdxs = append(svcIdxs, svcIdx)
+	}
+	sort.Slice(svcIdxs, func(i, j int) bool { return svcIdxs[i] < svcIdxs[j] })
+	for _, svcIdx := range svcIdxs {
+		byName := bySvc[svcIdx]
+		ms := make([]metric, 0, len(byName))
+		for _, m := range byName {
+			ms = append(ms, *m)
+		}
+		sort.Slice(ms, func(i, j int) bool { return ms[i].Name < ms[j].Name })
+		req.ResourceMetrics = append(req.ResourceMetrics, x.resourceMetrics(x.svcs[svcIdx], ms))
+	}
+
+	req.ResourceMetrics = append(req.ResourceMetrics, x.getSysMetrics(nowNano))
+	return req
+}
+
+func (x *Exporter) getSysMetrics(nowNano int64) resourceMetrics {
+	if x.sysStart == 0 {
+		x.sysStart = nowNano
+	}
+
+	sysMetrics := system.Read(x.rootLogger)
+	ms := make([]metric, 0, len(sysMetrics))
+	for _, m := range sysMetrics {
+		point := numberDataPoint{
+			TimeUnixNano: strconv.FormatInt(nowNano, 10),
+			AsDouble:     ptr(m.Value),
+		}
+		out := metric{Name: m.Name}
+		if m.Kind == system.Counter {
+			point.StartTimeUnixNano = strconv.FormatInt(x.sysStart, 10)
+			out.Sum = &sum{
+				DataPoints:             []numberDataPoint{point},
+				AggregationTemporality: aggregationTemporalityCumulative,
+				IsMonotonic:            true,
+			}
+		} else {
+			out.Gauge = &gauge{DataPoints: []numberDataPoint{point}}
+		}
+		ms = append(ms, out)
+	}
+	return x.resourceMetrics("", ms)
+}
+
+func (x *Exporter) resourceMetrics(svc string, ms []metric) resourceMetrics {
+	attrs := make([]keyValue, 0, len(x.containerMetadataAttrs)+1)
+	attrs = append(attrs, x.containerMetadataAttrs...)
+	if svc != "" {
+		attrs = append(attrs, stringAttr("service.name", svc))
+	}
+	return resourceMetrics{
+		Resource: resource{Attributes: attrs},
+		ScopeMetrics: []scopeMetrics{{
+			Scope:   instrumentationScope{Name: "encore.dev"},
+			Metrics: ms,
+		}},
+	}
+}
+
+// explicitDataPoint converts h to a histogram data point,
+// or nil if h has no observations.
+func explicitDataPoint(h *explicithist.Histogram) *histogramDataPoint {
+	snap := h.Snapshot()
+	if snap.Count == 0 {
+		return nil
+	}
+	counts := make([]string, len(snap.BucketCounts))
+	for i, n := range snap.BucketCounts {
+		counts[i] = strconv.FormatUint(n, 10)
+	}
+	var exemplars []exemplarPoint
+	for _, e := range snap.Exemplars {
+		if e != nil {
+			exemplars = append(exemplars, toExemplarPoint(*e))
+		}
+	}
+	return &histogramDataPoint{
+		Count:          strconv.FormatUint(snap.Count, 10),
+		Sum:            snap.Sum,
+		BucketCounts:   counts,
+		ExplicitBounds: snap.Bounds,
+		Exemplars:      exemplars,
+	}
+}
+
+// summaryPoint converts s to a summary data point,
+// or nil if s has no observations.
+func summaryPoint(s *quantile.Summary) *summaryDataPoint {
+	snap := s.Snapshot()
+	if snap.Count == 0 {
+		return nil
+	}
+	var values []quantileValue
+	for _, q := range snap.Quantiles {
+		// The quantiles are NaN when there are no recent observations,
+		// which can't be represented in JSON.
+		if !math.IsNaN(q.Value) {
+			values = append(values, quantileValue{Quantile: q.Quantile, Value: q.Value})
+		}
+	}
+	return &summaryDataPoint{
+		Count:          strconv.FormatUint(snap.Count, 10),
+		Sum:            snap.Sum,
+		QuantileValues: values,
+	}
+}
+
+// exponentialDataPoint converts h to an exponential histogram data point,
+// or nil if h has no observations.
+//
+// The bucket schema of native histograms is the same as the scale of
+// exponential histograms, but native histogram bucket k covers the range
+// (base^(k-1), base^k] while exponential histogram bucket k covers (base^k, base^(k+1)].
+func exponentialDataPoint(h *nativehist.Histogram) *exponentialHistogramDataPoint {
+	positive, posCount := histogramBuckets(&h.PositiveVals)
+	negative, negCount := histogramBuckets(&h.NegativeVals)
+	zeroCount := atomic.LoadUint64(&h.NumZeroValues)
+	count := posCount + negCount + zeroCount
+	if count == 0 {
+		return nil
+	}
+	var exemplars []exemplarPoint
+	for _, e := range h.Exemplars.All() {
+		exemplars = append(exemplars, toExemplarPoint(e))
+	}
+	return &exponentialHistogramDataPoint{
+		Count:     strconv.FormatUint(count, 10),
+		Scale:     h.Schema,
+		ZeroCount: strconv.FormatUint(zeroCount, 10),
+		Positive:  positive,
+		Negative:  negative,
+		Exemplars: exemplars,
+	}
+}
+
+func toExemplarPoint(e exemplar.Exemplar) exemplarPoint {
+	p := exemplarPoint{
+		TimeUnixNano: strconv.FormatInt(e.Time.UnixNano(), 10),
+		AsDouble:     e.Value,
+	}
+	if !e.TraceID.IsZero() {
+		p.TraceID = hex.EncodeToString(e.TraceID[:])
+	}
+	if !e.SpanID.IsZero() {
+		p.SpanID = hex.EncodeToString(e.SpanID[:])
+	}
+	return p
+}
+
+func histogramBuckets(m interface {
+	Range(func(k, v any) bool)
+}) (b buckets, total uint64) {
+	counts := make(map[int]uint64)
+	minKey, maxKey := 0, 0
+	m.Range(func(k, v any) bool {
+		key := k.(int) - 1
+		n := uint64(atomic.LoadInt64(v.(*int64)))
+		if len(counts) == 0 || key < minKey {
+			minKey = key
+		}
+		if len(counts) == 0 || key > maxKey {
+			maxKey = key
+		}
+		counts[key] = n
+		total += n
+		return true
+	})
+	if len(counts) == 0 {
+		return buckets{}, 0
+	}
+
+	b.Offset = int32(minKey)
+	b.BucketCounts = make([]string, maxKey-minKey+1)
+	for i := range b.BucketCounts {
+		b.BucketCounts[i] = strconv.FormatUint(counts[minKey+i], 10)
+	}
+	return b, total
+}
+
+func ptr[T any](v T) *T { return &v }
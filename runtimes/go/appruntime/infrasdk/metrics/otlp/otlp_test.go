@@ -0,0 +1,190 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/shared/exemplar"
+	"encore.dev/appruntime/shared/explicithist"
+	"encore.dev/appruntime/shared/nativehist"
+	"encore.dev/appruntime/shared/quantile"
+	"encore.dev/metrics"
+)
+
+type metricInfo struct {
+	name   string
+	typ    metrics.MetricType
+	svcNum uint16
+}
+
+func (m metricInfo) Name() string             { return m.name }
+func (m metricInfo) Type() metrics.MetricType { return m.typ }
+func (m metricInfo) SvcNum() uint16           { return m.svcNum }
+
+func valid(n int) []atomic.Bool {
+	v := make([]atomic.Bool, n)
+	for i := range v {
+		v[i].Store(true)
+	}
+	return v
+}
+
+func TestGetMetricData(t *testing.T) {
+	hist := nativehist.New(2)
+	for _, v := range []float64{0, 3, 3, 100} {
+		hist.Observe(v)
+	}
+	explicit := explicithist.New([]float64{0.001, 0.01})
+	for _, v := range []float64{0.0002, 0.0004} {
+		explicit.Observe(v)
+	}
+	explicit.ObserveWithExemplar(0.5, exemplar.Exemplar{
+		Value:   0.5,
+		Time:    time.Unix(90, 0),
+		TraceID: model.TraceID{0xab, 0xcd},
+	})
+	summary := quantile.New(map[float64]float64{0.5: 0.05}, time.Minute, 1)
+	for _, v := range []float64{1, 2, 3} {
+		summary.Observe(v)
+	}
+
+	collected := []metrics.CollectedMetric{
+		{
+			Info:         metricInfo{"test_counter", metrics.CounterType, 0},
+			TimeSeriesID: 1,
+			Labels:       []metrics.KeyValue{{Key: "key", Value: "value"}},
+			Val:          []int64{1, 2},
+			Valid:        valid(2),
+		},
+		{
+			Info:         metricInfo{"test_gauge", metrics.GaugeType, 2},
+			TimeSeriesID: 2,
+			Val:          []float64{0.5},
+			Valid:        valid(1),
+		},
+		{
+			Info:         metricInfo{"test_hist", metrics.HistogramType, 1},
+			TimeSeriesID: 3,
+			Val:          []*nativehist.Histogram{hist},
+		},
+		{
+			Info:         metricInfo{"test_empty_hist", metrics.HistogramType, 1},
+			TimeSeriesID: 4,
+			Val:          []*nativehist.Histogram{nativehist.New(2)},
+		},
+		{
+			Info:         metricInfo{"test_explicit_hist", metrics.HistogramType, 2},
+			TimeSeriesID: 5,
+			Val:          []*explicithist.Histogram{explicit},
+		},
+		{
+			Info:         metricInfo{"test_summary", metrics.SummaryType, 1},
+			TimeSeriesID: 6,
+			Val:          []*quantile.Summary{summary},
+		},
+	}
+
+	x := New([]string{"foo", "bar"}, &config.OTLPMetricsProvider{}, &metadata.ContainerMetadata{}, zerolog.Nop())
+	start := time.Unix(100, 0)
+	req := x.getMetricData(start, collected)
+
+	// Two services plus the system metrics.
+	if len(req.ResourceMetrics) != 3 {
+		t.Fatalf("got %d resources, want 3", len(req.ResourceMetrics))
+	}
+	foo, bar := req.ResourceMetrics[0], req.ResourceMetrics[1]
+	if got := foo.Resource.Attributes; len(got) != 1 || got[0] != stringAttr("service.name", "foo") {
+		t.Fatalf("got resource attributes %+v", got)
+	}
+
+	fooMetrics := foo.ScopeMetrics[0].Metrics
+	if len(fooMetrics) != 3 || fooMetrics[0].Name != "test_counter" || fooMetrics[1].Name != "test_hist" {
+		t.Fatalf("got metrics %+v", fooMetrics)
+	}
+	counter := fooMetrics[0].Sum
+	if counter == nil || !counter.IsMonotonic || counter.AggregationTemporality != aggregationTemporalityCumulative {
+		t.Fatalf("got counter %+v", counter)
+	} else if p := counter.DataPoints[0]; p.AsInt != "1" || p.StartTimeUnixNano != "100000000000" || p.Attributes[0] != stringAttr("key", "value") {
+		t.Fatalf("got data point %+v", p)
+	}
+
+	// With a scale of zero, 3 and 100 are in the buckets
+	// (2, 4] and (64, 128], with indices 1 and 6.
+	h := fooMetrics[1].ExponentialHistogram.DataPoints[0]
+	if h.Count != "4" || h.ZeroCount != "1" || h.Scale != 0 || h.Positive.Offset != 1 ||
+		strings.Join(h.Positive.BucketCounts, ",") != "2,0,0,0,0,1" {
+		t.Fatalf("got histogram %+v", h)
+	}
+
+	if s := fooMetrics[2].Summary; s == nil || len(s.DataPoints) != 1 {
+		t.Fatalf("got summary %+v", fooMetrics[2])
+	} else if p := s.DataPoints[0]; p.Count != "3" || p.Sum != 6 ||
+		len(p.QuantileValues) != 1 || p.QuantileValues[0] != (quantileValue{Quantile: 0.5, Value: 2}) {
+		t.Fatalf("got data point %+v", p)
+	}
+
+	barMetrics := bar.ScopeMetrics[0].Metrics
+	if len(barMetrics) != 3 || barMetrics[0].Name != "test_counter" || barMetrics[2].Gauge == nil {
+		t.Fatalf("got metrics %+v", barMetrics)
+	}
+	eh := barMetrics[1].Histogram
+	if eh == nil || eh.AggregationTemporality != aggregationTemporalityCumulative {
+		t.Fatalf("got histogram %+v", barMetrics[1])
+	} else if p := eh.DataPoints[0]; p.Count != "3" || p.Sum < 0.5005 || p.Sum > 0.5007 ||
+		strings.Join(p.BucketCounts, ",") != "2,0,1" || len(p.ExplicitBounds) != 2 {
+		t.Fatalf("got data point %+v", p)
+	} else if len(p.Exemplars) != 1 || p.Exemplars[0] != (exemplarPoint{
+		TimeUnixNano: "90000000000",
+		AsDouble:     0.5,
+		TraceID:      "abcd0000000000000000000000000000",
+	}) {
+		t.Fatalf("got exemplars %+v", p.Exemplars)
+	}
+
+	// The start time of cumulative metrics is kept between exports.
+	req = x.getMetricData(start.Add(time.Minute), collected)
+	if p := req.ResourceMetrics[0].ScopeMetrics[0].Metrics[0].Sum.DataPoints[0]; p.StartTimeUnixNano != "100000000000" {
+		t.Fatalf("got start time %s, want 100000000000", p.StartTimeUnixNano)
+	}
+}
+
+func TestExport(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Content-Type") != "application/json" || req.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(data, &body)
+	}))
+	defer srv.Close()
+
+	cfg := &config.OTLPMetricsProvider{
+		Endpoint: srv.URL + "/v1/metrics",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	}
+	x := New([]string{"foo"}, cfg, &metadata.ContainerMetadata{}, zerolog.Nop())
+	err := x.Export(context.Background(), []metrics.CollectedMetric{{
+		Info:  metricInfo{"test_counter", metrics.CounterType, 1},
+		Val:   []uint64{5},
+		Valid: valid(1),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	} else if _, ok := body["resourceMetrics"]; !ok {
+		t.Fatalf("got body %v", body)
+	}
+}
@@ -0,0 +1,136 @@
+//go:build !encore_no_otlp
+
+package otlp
+
+// The types below mirror the OTLP protobuf messages,
+// using the JSON encoding defined by the OTLP specification.
+// 64-bit integers are encoded as strings.
+
+type exportRequest struct {
+	ResourceMetrics []resourceMetrics `json:"resourceMetrics"`
+}
+
+type resourceMetrics struct {
+	Resource     resource       `json:"resource"`
+	ScopeMetrics []scopeMetrics `json:"scopeMetrics"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeMetrics struct {
+	Scope   instrumentationScope `json:"scope"`
+	Metrics []metric             `json:"metrics"`
+}
+
+type instrumentationScope struct {
+	Name string `json:"name"`
+}
+
+type metric struct {
+	Name                 string                `json:"name"`
+	Gauge                *gauge                `json:"gauge,omitempty"`
+	Sum                  *sum                  `json:"sum,omitempty"`
+	Histogram            *histogram            `json:"histogram,omitempty"`
+	ExponentialHistogram *exponentialHistogram `json:"exponentialHistogram,omitempty"`
+	Summary              *summary              `json:"summary,omitempty"`
+}
+
+const aggregationTemporalityCumulative = 2
+
+type gauge struct {
+	DataPoints []numberDataPoint `json:"dataPoints"`
+}
+
+type sum struct {
+	DataPoints             []numberDataPoint `json:"dataPoints"`
+	AggregationTemporality int               `json:"aggregationTemporality"`
+	IsMonotonic            bool              `json:"isMonotonic"`
+}
+
+type histogram struct {
+	DataPoints             []histogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                  `json:"aggregationTemporality"`
+}
+
+type exponentialHistogram struct {
+	DataPoints             []exponentialHistogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                             `json:"aggregationTemporality"`
+}
+
+type summary struct {
+	DataPoints []summaryDataPoint `json:"dataPoints"`
+}
+
+type numberDataPoint struct {
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string     `json:"timeUnixNano"`
+	AsDouble          *float64   `json:"asDouble,omitempty"`
+	AsInt             string     `json:"asInt,omitempty"`
+}
+
+type histogramDataPoint struct {
+	Attributes        []keyValue      `json:"attributes,omitempty"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	TimeUnixNano      string          `json:"timeUnixNano"`
+	Count             string          `json:"count"`
+	Sum               float64         `json:"sum"`
+	BucketCounts      []string        `json:"bucketCounts"`
+	ExplicitBounds    []float64       `json:"explicitBounds"`
+	Exemplars         []exemplarPoint `json:"exemplars,omitempty"`
+}
+
+type exponentialHistogramDataPoint struct {
+	Attributes        []keyValue      `json:"attributes,omitempty"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	TimeUnixNano      string          `json:"timeUnixNano"`
+	Count             string          `json:"count"`
+	Scale             int32           `json:"scale"`
+	ZeroCount         string          `json:"zeroCount"`
+	Positive          buckets         `json:"positive"`
+	Negative          buckets         `json:"negative"`
+	Exemplars         []exemplarPoint `json:"exemplars,omitempty"`
+}
+
+type summaryDataPoint struct {
+	Attributes        []keyValue      `json:"attributes,omitempty"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	TimeUnixNano      string          `json:"timeUnixNano"`
+	Count             string          `json:"count"`
+	Sum               float64         `json:"sum"`
+	QuantileValues    []quantileValue `json:"quantileValues,omitempty"`
+}
+
+type quantileValue struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+// exemplarPoint is an OTLP exemplar.
+// Trace and span ids are hex-encoded.
+type exemplarPoint struct {
+	TimeUnixNano string  `json:"timeUnixNano"`
+	AsDouble     float64 `json:"asDouble"`
+	TraceID      string  `json:"traceId,omitempty"`
+	SpanID       string  `json:"spanId,omitempty"`
+}
+
+type buckets struct {
+	Offset       int32    `json:"offset"`
+	BucketCounts []string `json:"bucketCounts,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+func stringAttr(key, value string) keyValue {
+	return keyValue{Key: key, Value: anyValue{StringValue: value}}
+}
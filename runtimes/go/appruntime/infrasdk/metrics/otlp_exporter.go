@@ -0,0 +1,27 @@
+//go:build !encore_no_otlp
+
+package metrics
+
+import (
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/infrasdk/metrics/otlp"
+)
+
+func init() {
+	registerProvider(providerDesc{
+		name: "otlp",
+		matches: func(cfg *config.Metrics) bool {
+			return cfg.OTLP != nil
+		},
+		newExporter: func(m *Manager) exporter {
+			containerMetadata, err := metadata.GetContainerMetadata(m.runtime)
+			if err != nil {
+				m.rootLogger.Err(err).Msg("unable to initialize metrics exporter: error getting container metadata")
+				return nil
+			}
+
+			return otlp.New(m.static.BundledServices, m.runtime.Metrics.OTLP, containerMetadata, m.rootLogger)
+		},
+	})
+}
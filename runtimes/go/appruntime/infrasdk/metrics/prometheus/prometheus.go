@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -17,6 +18,9 @@ import (
 	"encore.dev/appruntime/infrasdk/metadata"
 	"encore.dev/appruntime/infrasdk/metrics/prometheus/prompb"
 	"encore.dev/appruntime/infrasdk/metrics/system"
+	"encore.dev/appruntime/shared/exemplar"
+	"encore.dev/appruntime/shared/explicithist"
+	"encore.dev/appruntime/shared/quantile"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/metrics"
 )
@@ -73,12 +77,12 @@ func (x *Exporter) Export(ctx context.Context, collected []metrics.CollectedMetr
 func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMetric) []*prompb.TimeSeries {
 	data := make([]*prompb.TimeSeries, 0, len(collected))
 
-	doAdd := func(val float64, metricName string, baseLabels []*prompb.Label, svcIdx uint16) {
+	doAdd := func(val float64, metricName string, baseLabels []*prompb.Label, svcIdx uint16) *prompb.TimeSeries {
 		labels := make([]*prompb.Label, len(baseLabels)+2)
 		copy(labels, baseLabels)
 		labels[len(baseLabels)] = &prompb.Label{Name: "__name__", Value: metricName}
 		labels[len(baseLabels)+1] = &prompb.Label{Name: "service", Value: x.svcs[svcIdx]}
-		data = append(data, &prompb.TimeSeries{
+		ts := &prompb.TimeSeries{
 			Labels: labels,
 			Samples: []*prompb.Sample{
 				{
@@ -86,7 +90,9 @@ func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMet
 					Timestamp: FromTime(now),
 				},
 			},
-		})
+		}
+		data = append(data, ts)
+		return ts
 	}
 
 	for _, m := range collected {
@@ -149,6 +155,55 @@ func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMet
 					}
 				}
 			}
+		case []*explicithist.Histogram:
+			// Explicit bucket histograms are written as classic Prometheus histograms.
+			for i, h := range vals {
+				svcIdx := uint16(i)
+				if svcNum > 0 {
+					svcIdx = svcNum - 1
+				}
+				snap := h.Snapshot()
+				if snap.Count == 0 {
+					continue
+				}
+
+				var cumulative uint64
+				for j, n := range snap.BucketCounts {
+					cumulative += n
+					le := "+Inf"
+					if j < len(snap.Bounds) {
+						le = strconv.FormatFloat(snap.Bounds[j], 'g', -1, 64)
+					}
+					bucketLabels := append(labels[:len(labels):len(labels)], &prompb.Label{Name: "le", Value: le})
+					ts := doAdd(float64(cumulative), m.Info.Name()+"_bucket", bucketLabels, svcIdx)
+					if e := snap.Exemplars[j]; e != nil {
+						ts.Exemplars = []*prompb.Exemplar{toExemplar(e)}
+					}
+				}
+				doAdd(snap.Sum, m.Info.Name()+"_sum", labels, svcIdx)
+				doAdd(float64(snap.Count), m.Info.Name()+"_count", labels, svcIdx)
+			}
+		case []*quantile.Summary:
+			for i, sum := range vals {
+				svcIdx := uint16(i)
+				if svcNum > 0 {
+					svcIdx = svcNum - 1
+				}
+				snap := sum.Snapshot()
+				if snap.Count == 0 {
+					continue
+				}
+
+				for _, q := range snap.Quantiles {
+					quantileLabels := append(labels[:len(labels):len(labels)], &prompb.Label{
+						Name:  "quantile",
+						Value: strconv.FormatFloat(q.Quantile, 'g', -1, 64),
+					})
+					doAdd(q.Value, m.Info.Name(), quantileLabels, svcIdx)
+				}
+				doAdd(snap.Sum, m.Info.Name()+"_sum", labels, svcIdx)
+				doAdd(float64(snap.Count), m.Info.Name()+"_count", labels, svcIdx)
+			}
 		default:
 			x.rootLogger.Error().Msgf("encore: internal error: unknown value type %T for metric %s",
 				m.Val, m.Info.Name())
@@ -158,6 +213,23 @@ func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMet
 	return data
 }
 
+// toExemplar converts e to a Prometheus exemplar, identifying the trace
+// the same way as Encore's logs do.
+func toExemplar(e *exemplar.Exemplar) *prompb.Exemplar {
+	var labels []*prompb.Label
+	if !e.TraceID.IsZero() {
+		labels = append(labels, &prompb.Label{Name: "trace_id", Value: e.TraceID.String()})
+	}
+	if !e.SpanID.IsZero() {
+		labels = append(labels, &prompb.Label{Name: "span_id", Value: e.SpanID.String()})
+	}
+	return &prompb.Exemplar{
+		Labels:    labels,
+		Value:     e.Value,
+		Timestamp: FromTime(e.Time),
+	}
+}
+
 func (x *Exporter) getSysMetrics(now time.Time) []*prompb.TimeSeries {
 	addMetricNameLabel := func(metricName string) []*prompb.Label {
 		labels := make([]*prompb.Label, len(x.containerMetadataLabels)+1)
@@ -169,23 +241,18 @@ func (x *Exporter) getSysMetrics(now time.Time) []*prompb.TimeSeries {
 		return labels
 	}
 
-	sysMetrics := system.ReadSysMetrics(x.rootLogger)
-	return []*prompb.TimeSeries{
-		{
-			Labels: addMetricNameLabel(system.MetricNameHeapObjectsBytes),
-			Samples: []*prompb.Sample{{
-				Value:     float64(sysMetrics[system.MetricNameHeapObjectsBytes]),
-				Timestamp: FromTime(now),
-			}},
-		},
-		{
-			Labels: addMetricNameLabel(system.MetricNameGoroutines),
+	sysMetrics := system.Read(x.rootLogger)
+	data := make([]*prompb.TimeSeries, 0, len(sysMetrics))
+	for _, m := range sysMetrics {
+		data = append(data, &prompb.TimeSeries{
+			Labels: addMetricNameLabel(m.Name),
 			Samples: []*prompb.Sample{{
-				Value:     float64(sysMetrics[system.MetricNameGoroutines]),
+				Value:     m.Value,
 				Timestamp: FromTime(now),
 			}},
-		},
+		})
 	}
+	return data
 }
 
 // FromTime returns a new millisecond timestamp from a time.
@@ -6,7 +6,10 @@ import (
 	"testing"
 	"time"
 
+	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/infrasdk/metrics/prometheus/prompb"
+	"encore.dev/appruntime/shared/exemplar"
+	"encore.dev/appruntime/shared/explicithist"
 	"encore.dev/metrics"
 )
 
@@ -180,6 +183,111 @@ func TestGetMetricData(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "histogram",
+			metric: metrics.CollectedMetric{
+				Info: metricInfo{"test_hist", metrics.HistogramType, 1},
+				Val: func() []*explicithist.Histogram {
+					h := explicithist.New([]float64{1})
+					h.ObserveWithExemplar(0.5, exemplar.Exemplar{Value: 0.5, Time: now, TraceID: model.TraceID{1}})
+					return []*explicithist.Histogram{h}
+				}(),
+			},
+			data: []*prompb.TimeSeries{
+				{
+					Labels: []*prompb.Label{
+						{
+							Name:  "le",
+							Value: "1",
+						},
+						{
+							Name:  "__name__",
+							Value: "test_hist_bucket",
+						},
+						{
+							Name:  "service",
+							Value: "foo",
+						},
+					},
+					Samples: []*prompb.Sample{
+						{
+							Value:     1,
+							Timestamp: FromTime(now),
+						},
+					},
+					Exemplars: []*prompb.Exemplar{
+						{
+							Labels: []*prompb.Label{
+								{
+									Name:  "trace_id",
+									Value: model.TraceID{1}.String(),
+								},
+							},
+							Value:     0.5,
+							Timestamp: FromTime(now),
+						},
+					},
+				},
+				{
+					Labels: []*prompb.Label{
+						{
+							Name:  "le",
+							Value: "+Inf",
+						},
+						{
+							Name:  "__name__",
+							Value: "test_hist_bucket",
+						},
+						{
+							Name:  "service",
+							Value: "foo",
+						},
+					},
+					Samples: []*prompb.Sample{
+						{
+							Value:     1,
+							Timestamp: FromTime(now),
+						},
+					},
+				},
+				{
+					Labels: []*prompb.Label{
+						{
+							Name:  "__name__",
+							Value: "test_hist_sum",
+						},
+						{
+							Name:  "service",
+							Value: "foo",
+						},
+					},
+					Samples: []*prompb.Sample{
+						{
+							Value:     0.5,
+							Timestamp: FromTime(now),
+						},
+					},
+				},
+				{
+					Labels: []*prompb.Label{
+						{
+							Name:  "__name__",
+							Value: "test_hist_count",
+						},
+						{
+							Name:  "service",
+							Value: "foo",
+						},
+					},
+					Samples: []*prompb.Sample{
+						{
+							Value:     1,
+							Timestamp: FromTime(now),
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -0,0 +1,97 @@
+//go:build !encore_no_prometheus
+
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/metrics"
+)
+
+// NewPushgateway returns an exporter that pushes metrics to a Prometheus Pushgateway.
+//
+// Metrics are grouped by job and instance, so that each instance of the app
+// replaces only its own metrics when pushing.
+func NewPushgateway(svcs []string, cfg *config.PrometheusPushgatewayProvider, appSlug string, meta *metadata.ContainerMetadata, rootLogger zerolog.Logger) *Pushgateway {
+	job := cfg.Job
+	if job == "" {
+		job = appSlug
+	}
+	instance := meta.InstanceID
+	if instance == "" {
+		instance, _ = os.Hostname()
+	}
+
+	return &Pushgateway{
+		textWriter: newTextWriter(svcs, meta, rootLogger),
+		cfg:        cfg,
+		url:        strings.TrimSuffix(cfg.URL, "/") + "/metrics" + groupingKeyPath("job", job) + groupingKeyPath("instance", instance),
+	}
+}
+
+type Pushgateway struct {
+	*textWriter
+	cfg *config.PrometheusPushgatewayProvider
+	url string // the URL of the metrics group to push to
+}
+
+func (x *Pushgateway) Export(ctx context.Context, collected []metrics.CollectedMetric) error {
+	var body bytes.Buffer
+	if err := x.WriteText(&body, collected); err != nil {
+		return fmt.Errorf("unable to encode metrics: %v", err)
+	}
+
+	// Use PUT to replace all the metrics in the group,
+	// so metrics that are no longer reported don't linger.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, x.url, &body)
+	if err != nil {
+		return fmt.Errorf("unable to create HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", textContentType)
+	req.Header.Set("User-Agent", "encore")
+	for k, v := range x.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send metrics to Prometheus Pushgateway: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unable to send metrics to Prometheus Pushgateway: got status %s: %s",
+			resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+func (x *Pushgateway) Shutdown(p *shutdown.Process) error {
+	return nil
+}
+
+// groupingKeyPath returns the URL path segment for a grouping key label,
+// base64-encoding values that can't be used in a path as-is.
+func groupingKeyPath(name, value string) string {
+	if value == "" || strings.Contains(value, "/") {
+		// An empty value is encoded as "=", since the path segment can't be empty.
+		encoded := base64.RawURLEncoding.EncodeToString([]byte(value))
+		if encoded == "" {
+			encoded = "="
+		}
+		return "/" + name + "@base64/" + encoded
+	}
+	return "/" + name + "/" + value
+}
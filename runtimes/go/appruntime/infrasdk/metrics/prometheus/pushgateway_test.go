@@ -0,0 +1,81 @@
+package prometheus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/metrics"
+)
+
+func TestPushgateway(t *testing.T) {
+	var method, path, auth, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, _ := io.ReadAll(req.Body)
+		method, path, auth, body = req.Method, req.URL.EscapedPath(), req.Header.Get("Authorization"), string(data)
+	}))
+	defer srv.Close()
+
+	cfg := &config.PrometheusPushgatewayProvider{
+		URL:     srv.URL + "/",
+		Headers: map[string]string{"Authorization": "Basic secret"},
+	}
+	meta := &metadata.ContainerMetadata{InstanceID: "pod/1"}
+	x := NewPushgateway([]string{"foo"}, cfg, "my-app", meta, zerolog.Nop())
+
+	valid := make([]atomic.Bool, 1)
+	valid[0].Store(true)
+	err := x.Export(context.Background(), []metrics.CollectedMetric{{
+		Info:  metricInfo{"test_counter", metrics.CounterType, 1},
+		Val:   []int64{3},
+		Valid: valid,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if method != http.MethodPut || auth != "Basic secret" {
+		t.Fatalf("got method %s and authorization %q", method, auth)
+	} else if want := "/metrics/job/my-app/instance@base64/cG9kLzE"; path != want {
+		t.Fatalf("got path %s, want %s", path, want)
+	} else if want := "test_counter{instance_id=\"pod/1\",service=\"foo\"} 3\n"; !strings.Contains(body, want) {
+		t.Fatalf("missing %q in body:\n%s", want, body)
+	}
+}
+
+func TestPushgateway_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "bad metrics", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	cfg := &config.PrometheusPushgatewayProvider{URL: srv.URL, Job: "job"}
+	x := NewPushgateway([]string{"foo"}, cfg, "my-app", &metadata.ContainerMetadata{InstanceID: "i"}, zerolog.Nop())
+	err := x.Export(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "bad metrics") {
+		t.Fatalf("got error %v, want the response message", err)
+	}
+}
+
+func TestGroupingKeyPath(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{"foo", "/instance/foo"},
+		{"a/b", "/instance@base64/YS9i"},
+		{"", "/instance@base64/="},
+	}
+	for _, test := range tests {
+		if got := groupingKeyPath("instance", test.value); got != test.want {
+			t.Errorf("groupingKeyPath(%q) = %q, want %q", test.value, got, test.want)
+		}
+	}
+}
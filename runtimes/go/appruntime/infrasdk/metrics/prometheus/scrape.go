@@ -0,0 +1,293 @@
+//go:build !encore_no_prometheus
+
+package prometheus
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/infrasdk/metrics/system"
+	"encore.dev/appruntime/shared/explicithist"
+	"encore.dev/appruntime/shared/nativehist"
+	"encore.dev/appruntime/shared/quantile"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/metrics"
+)
+
+// textContentType is the content type of the Prometheus text exposition format.
+const textContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// NewScrapeServer returns a server that serves metrics collected by collect
+// in the Prometheus text exposition format.
+func NewScrapeServer(svcs []string, cfg *config.PrometheusScrapeProvider, meta *metadata.ContainerMetadata, collect func() []metrics.CollectedMetric, rootLogger zerolog.Logger) *ScrapeServer {
+	path := cfg.Path
+	if path == "" {
+		path = "/metrics"
+	}
+
+	s := &ScrapeServer{
+		textWriter: newTextWriter(svcs, meta, rootLogger),
+		cfg:        cfg,
+		collect:    collect,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, s.handleScrape)
+	s.srv = &http.Server{Addr: cfg.ListenAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	return s
+}
+
+type ScrapeServer struct {
+	*textWriter
+	cfg     *config.PrometheusScrapeProvider
+	collect func() []metrics.CollectedMetric
+	srv     *http.Server
+}
+
+// Serve serves scrape requests until the server is shut down.
+func (s *ScrapeServer) Serve() {
+	ln, err := net.Listen("tcp", s.cfg.ListenAddr)
+	if err != nil {
+		s.rootLogger.Error().Err(err).Str("addr", s.cfg.ListenAddr).Msg("unable to listen for prometheus scrape requests")
+		return
+	}
+
+	if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.rootLogger.Error().Err(err).Msg("prometheus scrape server failed")
+	}
+}
+
+// Export does nothing, as metrics are collected when scraped.
+func (s *ScrapeServer) Export(ctx context.Context, collected []metrics.CollectedMetric) error {
+	return nil
+}
+
+func (s *ScrapeServer) Shutdown(p *shutdown.Process) error {
+	return s.srv.Close()
+}
+
+func (s *ScrapeServer) handleScrape(w http.ResponseWriter, req *http.Request) {
+	if s.cfg.BearerToken != "" {
+		token, _ := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.BearerToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", textContentType)
+	if err := s.WriteText(w, s.collect()); err != nil {
+		s.rootLogger.Error().Err(err).Msg("unable to write metrics")
+	}
+}
+
+func newTextWriter(svcs []string, meta *metadata.ContainerMetadata, rootLogger zerolog.Logger) *textWriter {
+	return &textWriter{
+		svcs: svcs,
+		containerMetadataLabels: metadata.MapMetadataLabels(meta, func(k, v string) metrics.KeyValue {
+			return metrics.KeyValue{Key: k, Value: v}
+		}),
+		rootLogger: rootLogger,
+	}
+}
+
+// textWriter writes metrics in the Prometheus text exposition format.
+type textWriter struct {
+	svcs                    []string
+	containerMetadataLabels []metrics.KeyValue
+	rootLogger              zerolog.Logger
+}
+
+// WriteText writes the collected metrics, along with system metrics,
+// in the Prometheus text exposition format.
+func (t *textWriter) WriteText(w io.Writer, collected []metrics.CollectedMetric) error {
+	type sample struct {
+		suffix string // for histograms, the series name suffix
+		labels []metrics.KeyValue
+		value  float64
+	}
+	type family struct {
+		typ     string
+		samples []sample
+	}
+	families := make(map[string]*family)
+
+	addSample := func(name, typ string, smp sample) {
+		f := families[name]
+		if f == nil {
+			f = &family{typ: typ}
+			families[name] = f
+		}
+		f.samples = append(f.samples, smp)
+	}
+	add := func(name, typ string, labels []metrics.KeyValue, value float64) {
+		addSample(name, typ, sample{labels: labels, value: value})
+	}
+
+	for _, m := range collected {
+		var typ string
+		switch m.Info.Type() {
+		case metrics.CounterType:
+			typ = "counter"
+		case metrics.GaugeType:
+			typ = "gauge"
+		case metrics.HistogramType:
+			typ = "histogram"
+		case metrics.SummaryType:
+			typ = "summary"
+		default:
+			continue
+		}
+
+		svcNum := m.Info.SvcNum()
+		svcLabels := func(svcIdx uint16) []metrics.KeyValue {
+			labels := make([]metrics.KeyValue, 0, len(t.containerMetadataLabels)+len(m.Labels)+1)
+			labels = append(labels, t.containerMetadataLabels...)
+			labels = append(labels, m.Labels...)
+			labels = append(labels, metrics.KeyValue{Key: "service", Value: t.svcs[svcIdx]})
+			return labels
+		}
+		doAdd := func(val float64, svcIdx uint16) {
+			add(m.Info.Name(), typ, svcLabels(svcIdx), val)
+		}
+		forEach := func(n int, val func(i int) float64) {
+			if svcNum > 0 {
+				if m.Valid[0].Load() {
+					doAdd(val(0), svcNum-1)
+				}
+			} else {
+				for i := 0; i < n; i++ {
+					if m.Valid[i].Load() {
+						doAdd(val(i), uint16(i))
+					}
+				}
+			}
+		}
+
+		switch vals := m.Val.(type) {
+		case []float64:
+			forEach(len(vals), func(i int) float64 { return vals[i] })
+		case []int64:
+			forEach(len(vals), func(i int) float64 { return float64(vals[i]) })
+		case []uint64:
+			forEach(len(vals), func(i int) float64 { return float64(vals[i]) })
+		case []time.Duration:
+			forEach(len(vals), func(i int) float64 { return vals[i].Seconds() })
+		case []*explicithist.Histogram:
+			for i, h := range vals {
+				svcIdx := uint16(i)
+				if svcNum > 0 {
+					svcIdx = svcNum - 1
+				}
+				snap := h.Snapshot()
+				if snap.Count == 0 {
+					continue
+				}
+
+				labels := svcLabels(svcIdx)
+				var cumulative uint64
+				for j, n := range snap.BucketCounts {
+					cumulative += n
+					le := "+Inf"
+					if j < len(snap.Bounds) {
+						le = strconv.FormatFloat(snap.Bounds[j], 'g', -1, 64)
+					}
+					bucketLabels := append(labels[:len(labels):len(labels)], metrics.KeyValue{Key: "le", Value: le})
+					addSample(m.Info.Name(), typ, sample{suffix: "_bucket", labels: bucketLabels, value: float64(cumulative)})
+				}
+				addSample(m.Info.Name(), typ, sample{suffix: "_sum", labels: labels, value: snap.Sum})
+				addSample(m.Info.Name(), typ, sample{suffix: "_count", labels: labels, value: float64(snap.Count)})
+			}
+		case []*quantile.Summary:
+			for i, sum := range vals {
+				svcIdx := uint16(i)
+				if svcNum > 0 {
+					svcIdx = svcNum - 1
+				}
+				snap := sum.Snapshot()
+				if snap.Count == 0 {
+					continue
+				}
+
+				labels := svcLabels(svcIdx)
+				for _, q := range snap.Quantiles {
+					quantileLabels := append(labels[:len(labels):len(labels)], metrics.KeyValue{
+						Key:   "quantile",
+						Value: strconv.FormatFloat(q.Quantile, 'g', -1, 64),
+					})
+					addSample(m.Info.Name(), typ, sample{labels: quantileLabels, value: q.Value})
+				}
+				addSample(m.Info.Name(), typ, sample{suffix: "_sum", labels: labels, value: snap.Sum})
+				addSample(m.Info.Name(), typ, sample{suffix: "_count", labels: labels, value: float64(snap.Count)})
+			}
+		case []*nativehist.Histogram:
+			// Native histograms can't be represented in the text format.
+		default:
+			t.rootLogger.Error().Msgf("encore: internal error: unknown value type %T for metric %s",
+				m.Val, m.Info.Name())
+		}
+	}
+
+	for _, m := range system.Read(t.rootLogger) {
+		typ := "gauge"
+		if m.Kind == system.Counter {
+			typ = "counter"
+		}
+		add(m.Name, typ, t.containerMetadataLabels, m.Value)
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	for _, name := range names {
+		f := families[name]
+		fmt.Fprintf(bw, "# TYPE %s %s\n", name, f.typ)
+		for _, smp := range f.samples {
+			bw.WriteString(name)
+			bw.WriteString(smp.suffix)
+			writeLabels(bw, smp.labels)
+			bw.WriteByte(' ')
+			bw.WriteString(strconv.FormatFloat(smp.value, 'g', -1, 64))
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+func writeLabels(w *bufio.Writer, labels []metrics.KeyValue) {
+	if len(labels) == 0 {
+		return
+	}
+	w.WriteByte('{')
+	for i, l := range labels {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.WriteString(l.Key)
+		w.WriteString(`="`)
+		w.WriteString(labelValueEscaper.Replace(l.Value))
+		w.WriteByte('"')
+	}
+	w.WriteByte('}')
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
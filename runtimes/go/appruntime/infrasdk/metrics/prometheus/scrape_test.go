@@ -0,0 +1,117 @@
+package prometheus
+
+import (
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/shared/explicithist"
+	"encore.dev/appruntime/shared/quantile"
+	"encore.dev/metrics"
+)
+
+func TestScrape(t *testing.T) {
+	valid := func(n int) []atomic.Bool {
+		v := make([]atomic.Bool, n)
+		for i := range v {
+			v[i].Store(true)
+		}
+		return v
+	}
+	hist := explicithist.New([]float64{0.001, 0.01})
+	for _, v := range []float64{0.0005, 0.005, 1} {
+		hist.Observe(v)
+	}
+	summary := quantile.New(map[float64]float64{0.5: 0.05}, time.Minute, 1)
+	for _, v := range []float64{1, 2, 3} {
+		summary.Observe(v)
+	}
+
+	collected := []metrics.CollectedMetric{
+		{
+			Info:  metricInfo{"test_counter", metrics.CounterType, 0},
+			Val:   []int64{1, 2},
+			Valid: valid(2),
+		},
+		{
+			Info:   metricInfo{"test_gauge", metrics.GaugeType, 2},
+			Labels: []metrics.KeyValue{{Key: "key", Value: "a \"quoted\"\nvalue"}},
+			Val:    []float64{0.5},
+			Valid:  valid(1),
+		},
+		{
+			Info:  metricInfo{"test_duration", metrics.GaugeType, 1},
+			Val:   []time.Duration{1500 * time.Millisecond},
+			Valid: valid(1),
+		},
+		{
+			Info:  metricInfo{"test_unset", metrics.CounterType, 1},
+			Val:   []int64{0},
+			Valid: make([]atomic.Bool, 1),
+		},
+		{
+			Info: metricInfo{"test_hist", metrics.HistogramType, 1},
+			Val:  []*explicithist.Histogram{hist},
+		},
+		{
+			Info: metricInfo{"test_empty_hist", metrics.HistogramType, 1},
+			Val:  []*explicithist.Histogram{explicithist.New([]float64{1})},
+		},
+		{
+			Info: metricInfo{"test_summary", metrics.SummaryType, 2},
+			Val:  []*quantile.Summary{summary},
+		},
+	}
+
+	cfg := &config.PrometheusScrapeProvider{ListenAddr: ":0", BearerToken: "secret"}
+	s := NewScrapeServer([]string{"foo", "bar"}, cfg, &metadata.ContainerMetadata{}, func() []metrics.CollectedMetric {
+		return collected
+	}, zerolog.Nop())
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	s.srv.Handler.ServeHTTP(w, req)
+	if w.Code != 401 {
+		t.Fatalf("got status %d without token, want 401", w.Code)
+	}
+
+	req.Header.Set("Authorization", "Bearer secret")
+	w = httptest.NewRecorder()
+	s.srv.Handler.ServeHTTP(w, req)
+	if w.Code != 200 {
+		t.Fatalf("got status %d, want 200", w.Code)
+	} else if ct := w.Header().Get("Content-Type"); ct != textContentType {
+		t.Fatalf("got content type %q, want %q", ct, textContentType)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		"# TYPE test_counter counter\ntest_counter{service=\"foo\"} 1\ntest_counter{service=\"bar\"} 2\n",
+		"# TYPE test_gauge gauge\ntest_gauge{key=\"a \\\"quoted\\\"\\nvalue\",service=\"bar\"} 0.5\n",
+		"test_duration{service=\"foo\"} 1.5\n",
+		"# TYPE e_sys_sched_goroutines gauge\n",
+		"# TYPE test_hist histogram\n" +
+			"test_hist_bucket{service=\"foo\",le=\"0.001\"} 1\n" +
+			"test_hist_bucket{service=\"foo\",le=\"0.01\"} 2\n" +
+			"test_hist_bucket{service=\"foo\",le=\"+Inf\"} 3\n" +
+			"test_hist_sum{service=\"foo\"} 1.0055\n" +
+			"test_hist_count{service=\"foo\"} 3\n",
+		"# TYPE test_summary summary\n" +
+			"test_summary{service=\"bar\",quantile=\"0.5\"} 2\n" +
+			"test_summary_sum{service=\"bar\"} 6\n" +
+			"test_summary_count{service=\"bar\"} 3\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in output:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "test_unset") || strings.Contains(body, "test_empty_hist") {
+		t.Errorf("unexpected unset metric in output:\n%s", body)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build !encore_no_prometheus
+
+package metrics
+
+import (
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/infrasdk/metrics/prometheus"
+)
+
+func init() {
+	registerProvider(providerDesc{
+		name: "prometheus_pushgateway",
+		matches: func(cfg *config.Metrics) bool {
+			return cfg.PrometheusPushgateway != nil
+		},
+		newExporter: func(m *Manager) exporter {
+			containerMetadata, err := metadata.GetContainerMetadata(m.runtime)
+			if err != nil {
+				m.rootLogger.Err(err).Msg("unable to initialize metrics exporter: error getting container metadata")
+				return nil
+			}
+
+			return prometheus.NewPushgateway(m.static.BundledServices, m.runtime.Metrics.PrometheusPushgateway, m.runtime.AppSlug, containerMetadata, m.rootLogger)
+		},
+	})
+}
@@ -0,0 +1,221 @@
+//go:build !encore_no_statsd
+
+// Package statsd sends metrics to a StatsD agent over UDP,
+// using the DogStatsD extensions for tags.
+package statsd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/infrasdk/metrics/system"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/metrics"
+)
+
+// maxPacketSize is the maximum size of a UDP packet sent to the agent.
+// It's the default of the Datadog agent, chosen to fit in a single
+// Ethernet frame without fragmentation.
+const maxPacketSize = 1432
+
+func New(svcs []string, cfg *config.StatsDProvider, meta *metadata.ContainerMetadata, rootLogger zerolog.Logger) *Exporter {
+	// Precompute container metadata tags.
+	return &Exporter{
+		svcs: svcs,
+		cfg:  cfg,
+		containerMetadataTags: metadata.MapMetadataLabels(meta, func(k, v string) string {
+			return tag(k, v)
+		}),
+		rootLogger:  rootLogger,
+		lastSent:    make(map[tsSvcKey]float64),
+		lastSysSent: make(map[string]float64),
+	}
+}
+
+type tsSvcKey struct {
+	tsID uint64
+	svc  uint16
+}
+
+type Exporter struct {
+	svcs                  []string
+	cfg                   *config.StatsDProvider
+	containerMetadataTags []string
+	rootLogger            zerolog.Logger
+
+	mu          sync.Mutex
+	conn        net.Conn
+	lastSent    map[tsSvcKey]float64 // last reported value of counters
+	lastSysSent map[string]float64   // last reported value of system counters, by name
+}
+
+func (x *Exporter) Shutdown(p *shutdown.Process) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.conn != nil {
+		return x.conn.Close()
+	}
+	return nil
+}
+
+func (x *Exporter) Export(ctx context.Context, collected []metrics.CollectedMetric) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if x.conn == nil {
+		conn, err := net.Dial("udp", x.cfg.Addr)
+		if err != nil {
+			return fmt.Errorf("unable to connect to statsd agent: %v", err)
+		}
+		x.conn = conn
+	}
+
+	for _, packet := range packets(x.getMetricData(collected), maxPacketSize) {
+		if _, err := x.conn.Write(packet); err != nil {
+			return fmt.Errorf("unable to send metrics to statsd agent: %v", err)
+		}
+	}
+	return nil
+}
+
+// getMetricData returns the lines to send for the collected metrics.
+// It must be called with x.mu held.
+func (x *Exporter) getMetricData(collected []metrics.CollectedMetric) []string {
+	var lines []string
+
+	for _, m := range collected {
+		var statsdType string
+		switch m.Info.Type() {
+		case metrics.CounterType:
+			statsdType = "c"
+		case metrics.GaugeType:
+			statsdType = "g"
+		default:
+			// Histograms and summaries can't be reported as StatsD histograms,
+			// since those are computed by the agent from individual observations.
+			continue
+		}
+
+		tags := make([]string, 0, len(x.containerMetadataTags)+len(m.Labels)+1)
+		tags = append(tags, x.containerMetadataTags...)
+		for _, label := range m.Labels {
+			tags = append(tags, tag(label.Key, label.Value))
+		}
+
+		doAdd := func(val float64, svcIdx uint16) {
+			// Counters are cumulative, while StatsD counters are deltas
+			// aggregated by the agent, so report the change since the last export.
+			if m.Info.Type() == metrics.CounterType {
+				key := tsSvcKey{tsID: m.TimeSeriesID, svc: svcIdx}
+				last := x.lastSent[key]
+				x.lastSent[key] = val
+				if val -= last; val == 0 {
+					return
+				}
+			}
+
+			svcTags := append(tags[:len(tags):len(tags)], tag("service", x.svcs[svcIdx]))
+			lines = append(lines, x.line(m.Info.Name(), val, statsdType, svcTags))
+		}
+
+		forEach := func(n int, val func(i int) float64) {
+			if svcNum := m.Info.SvcNum(); svcNum > 0 {
+				if m.Valid[0].Load() {
+					doAdd(val(0), svcNum-1)
+				}
+			} else {
+				for i := 0; i < n; i++ {
+					if m.Valid[i].Load() {
+						doAdd(val(i), uint16(i))
+					}
+				}
+			}
+		}
+
+		switch vals := m.Val.(type) {
+		case []float64:
+			forEach(len(vals), func(i int) float64 { return vals[i] })
+		case []int64:
+			forEach(len(vals), func(i int) float64 { return float64(vals[i]) })
+		case []uint64:
+			forEach(len(vals), func(i int) float64 { return float64(vals[i]) })
+		case []time.Duration:
+			forEach(len(vals), func(i int) float64 { return vals[i].Seconds() })
+		default:
+			x.rootLogger.Error().Msgf("encore: internal error: unknown value type %T for metric %s",
+				m.Val, m.Info.Name())
+		}
+	}
+
+	for _, m := range system.Read(x.rootLogger) {
+		statsdType, val := "g", m.Value
+		if m.Kind == system.Counter {
+			statsdType = "c"
+			val -= x.lastSysSent[m.Name]
+			x.lastSysSent[m.Name] = m.Value
+			if val == 0 {
+				continue
+			}
+		}
+		lines = append(lines, x.line(m.Name, val, statsdType, x.containerMetadataTags))
+	}
+
+	return lines
+}
+
+// line formats a single metric in the DogStatsD format:
+// <prefix><name>:<value>|<type>|#<tag>,<tag>
+func (x *Exporter) line(name string, val float64, statsdType string, tags []string) string {
+	var b strings.Builder
+	b.WriteString(x.cfg.Prefix)
+	b.WriteString(name)
+	b.WriteByte(':')
+	b.WriteString(strconv.FormatFloat(val, 'f', -1, 64))
+	b.WriteByte('|')
+	b.WriteString(statsdType)
+	if len(tags) > 0 {
+		b.WriteString("|#")
+		b.WriteString(strings.Join(tags, ","))
+	}
+	return b.String()
+}
+
+// tag formats a DogStatsD tag, replacing the characters
+// that are reserved by the protocol.
+func tag(key, value string) string {
+	return tagEscaper.Replace(key) + ":" + tagEscaper.Replace(value)
+}
+
+var tagEscaper = strings.NewReplacer(",", "_", "|", "_", "#", "_", "\n", "_")
+
+// packets groups lines into newline-separated packets of at most size bytes.
+// Lines longer than size are sent in a packet of their own.
+func packets(lines []string, size int) [][]byte {
+	var (
+		result [][]byte
+		curr   []byte
+	)
+	for _, line := range lines {
+		if len(curr) > 0 && len(curr)+1+len(line) > size {
+			result = append(result, curr)
+			curr = nil
+		}
+		if len(curr) > 0 {
+			curr = append(curr, '\n')
+		}
+		curr = append(curr, line...)
+	}
+	if len(curr) > 0 {
+		result = append(result, curr)
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package statsd
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/metrics"
+)
+
+type metricInfo struct {
+	name   string
+	typ    metrics.MetricType
+	svcNum uint16
+}
+
+func (m metricInfo) Name() string             { return m.name }
+func (m metricInfo) Type() metrics.MetricType { return m.typ }
+func (m metricInfo) SvcNum() uint16           { return m.svcNum }
+
+func valid(n int) []atomic.Bool {
+	v := make([]atomic.Bool, n)
+	for i := range v {
+		v[i].Store(true)
+	}
+	return v
+}
+
+func TestGetMetricData(t *testing.T) {
+	counter := []int64{5, 2}
+	collected := []metrics.CollectedMetric{
+		{
+			Info:         metricInfo{"test_counter", metrics.CounterType, 0},
+			TimeSeriesID: 1,
+			Labels:       []metrics.KeyValue{{Key: "key", Value: "a,b"}},
+			Val:          counter,
+			Valid:        valid(2),
+		},
+		{
+			Info:         metricInfo{"test_gauge", metrics.GaugeType, 2},
+			TimeSeriesID: 2,
+			Val:          []time.Duration{1500 * time.Millisecond},
+			Valid:        valid(1),
+		},
+	}
+
+	x := New([]string{"foo", "bar"}, &config.StatsDProvider{Prefix: "app."}, &metadata.ContainerMetadata{}, zerolog.Nop())
+	got := x.getMetricData(collected)
+	want := []string{
+		"app.test_counter:5|c|#key:a_b,service:foo",
+		"app.test_counter:2|c|#key:a_b,service:bar",
+		"app.test_gauge:1.5|g|#service:bar",
+	}
+	if len(got) < len(want) || strings.Join(got[:len(want)], "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got lines:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Counters report the change since the last export,
+	// and are skipped when unchanged.
+	counter[0] = 8
+	got = x.getMetricData(collected)
+	if got[0] != "app.test_counter:3|c|#key:a_b,service:foo" || got[1] != "app.test_gauge:1.5|g|#service:bar" {
+		t.Fatalf("got lines:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestPackets(t *testing.T) {
+	got := packets([]string{"aaaa", "bbbb", "cccc", "dddddddddddd"}, 10)
+	want := []string{"aaaa\nbbbb", "cccc", "dddddddddddd"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d packets, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if string(got[i]) != want[i] {
+			t.Errorf("packet %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	x := New([]string{"foo"}, &config.StatsDProvider{Addr: conn.LocalAddr().String()}, &metadata.ContainerMetadata{}, zerolog.Nop())
+	err = x.Export(context.Background(), []metrics.CollectedMetric{{
+		Info:  metricInfo{"test_gauge", metrics.GaugeType, 1},
+		Val:   []float64{0.5},
+		Valid: valid(1),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, maxPacketSize)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	} else if got := string(buf[:n]); !strings.HasPrefix(got, "test_gauge:0.5|g|#service:foo\n") {
+		t.Fatalf("got packet %q", got)
+	}
+}
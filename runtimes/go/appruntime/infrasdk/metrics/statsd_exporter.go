@@ -0,0 +1,27 @@
+//go:build !encore_no_statsd
+
+package metrics
+
+import (
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/infrasdk/metrics/statsd"
+)
+
+func init() {
+	registerProvider(providerDesc{
+		name: "statsd",
+		matches: func(cfg *config.Metrics) bool {
+			return cfg.StatsD != nil
+		},
+		newExporter: func(m *Manager) exporter {
+			containerMetadata, err := metadata.GetContainerMetadata(m.runtime)
+			if err != nil {
+				m.rootLogger.Err(err).Msg("unable to initialize metrics exporter: error getting container metadata")
+				return nil
+			}
+
+			return statsd.New(m.static.BundledServices, m.runtime.Metrics.StatsD, containerMetadata, m.rootLogger)
+		},
+	})
+}
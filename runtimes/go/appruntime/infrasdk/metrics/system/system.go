@@ -1,50 +1,149 @@
 package system
 
 import (
+	"math"
 	"runtime/metrics"
+	"sync"
 
 	"github.com/rs/zerolog"
 )
 
-// These are the metrics exposed by Go we currently track. Once we update our
-// fork to Go 1.20, we should add:
-//
-// - /cpu/classes/gc/pause:cpu-seconds
-// - /cpu/classes/idle:cpu-seconds
-// - /cpu/classes/total:cpu-seconds
-// - /sync/mutex/wait/total:seconds
 const (
-	MetricNameHeapObjectsBytes = "e_sys_memory_heap_objects_bytes"
-	MetricNameGoroutines       = "e_sys_sched_goroutines"
+	MetricNameHeapObjectsBytes       = "e_sys_memory_heap_objects_bytes"
+	MetricNameHeapInUseBytes         = "e_sys_memory_heap_in_use_bytes"
+	MetricNameGoroutines             = "e_sys_sched_goroutines"
+	MetricNameSchedLatencyP99        = "e_sys_sched_latency_p99_seconds"
+	MetricNameGCCycles               = "e_sys_gc_cycles_total"
+	MetricNameGCPauseCPUSecondsTotal = "e_sys_gc_pause_cpu_seconds_total"
 
 	goMetricHeapObjectsBytes = "/memory/classes/heap/objects:bytes"
+	goMetricHeapUnusedBytes  = "/memory/classes/heap/unused:bytes"
 	goMetricGoroutines       = "/sched/goroutines:goroutines"
+	goMetricSchedLatencies   = "/sched/latencies:seconds"
+	goMetricGCCycles         = "/gc/cycles/total:gc-cycles"
+	goMetricGCPauseCPU       = "/cpu/classes/gc/pause:cpu-seconds"
 )
 
-var encoreMetricNames = map[string]string{
-	goMetricHeapObjectsBytes: MetricNameHeapObjectsBytes,
-	goMetricGoroutines:       MetricNameGoroutines,
+// Kind describes how the value of a system metric changes over time.
+type Kind int
+
+const (
+	// Gauge is a value that can go up and down.
+	Gauge Kind = iota
+	// Counter is a cumulative value that only increases
+	// for the lifetime of the process.
+	Counter
+)
+
+type Metric struct {
+	Name  string
+	Kind  Kind
+	Value float64
 }
 
-func ReadSysMetrics(logger zerolog.Logger) map[string]uint64 {
+// Read reads the current values of the system metrics.
+func Read(logger zerolog.Logger) []Metric {
 	samples := []metrics.Sample{
 		{Name: goMetricHeapObjectsBytes},
+		{Name: goMetricHeapUnusedBytes},
 		{Name: goMetricGoroutines},
+		{Name: goMetricSchedLatencies},
+		{Name: goMetricGCCycles},
+		{Name: goMetricGCPauseCPU},
 	}
 	metrics.Read(samples)
 
-	output := make(map[string]uint64, len(samples))
+	values := make(map[string]metrics.Value, len(samples))
 	for _, sample := range samples {
 		switch sample.Value.Kind() {
-		case metrics.KindUint64:
-			output[encoreMetricNames[sample.Name]] = sample.Value.Uint64()
 		case metrics.KindBad:
 			// This means the metric is unsupported. It's expected to happen very rarely
 			// possibly due to a large change in a particular Go implementation.
 			logger.Warn().Str("metric", sample.Name).Msg("metric no longer supported")
 		default:
-			logger.Warn().Str("metric", sample.Name).Msg("unexpected metric kind")
+			values[sample.Name] = sample.Value
+		}
+	}
+
+	var output []Metric
+	add := func(name string, kind Kind, goMetrics ...string) {
+		var total float64
+		for _, goMetric := range goMetrics {
+			val, ok := values[goMetric]
+			if !ok {
+				return
+			}
+			switch val.Kind() {
+			case metrics.KindUint64:
+				total += float64(val.Uint64())
+			case metrics.KindFloat64:
+				total += val.Float64()
+			default:
+				logger.Warn().Str("metric", goMetric).Msg("unexpected metric kind")
+				return
+			}
 		}
+		output = append(output, Metric{Name: name, Kind: kind, Value: total})
+	}
+
+	add(MetricNameHeapObjectsBytes, Gauge, goMetricHeapObjectsBytes)
+	add(MetricNameHeapInUseBytes, Gauge, goMetricHeapObjectsBytes, goMetricHeapUnusedBytes)
+	add(MetricNameGoroutines, Gauge, goMetricGoroutines)
+	add(MetricNameGCCycles, Counter, goMetricGCCycles)
+	add(MetricNameGCPauseCPUSecondsTotal, Counter, goMetricGCPauseCPU)
+
+	if val, ok := values[goMetricSchedLatencies]; ok && val.Kind() == metrics.KindFloat64Histogram {
+		output = append(output, Metric{
+			Name:  MetricNameSchedLatencyP99,
+			Kind:  Gauge,
+			Value: schedLatency.p99(val.Float64Histogram()),
+		})
 	}
+
 	return output
 }
+
+// schedLatency tracks the scheduler latency histogram between reads,
+// since the Go runtime reports it as cumulative over the process lifetime.
+var schedLatency latencyTracker
+
+type latencyTracker struct {
+	mu   sync.Mutex
+	prev []uint64
+}
+
+// p99 returns the 99th percentile of the observations in h since the previous call,
+// or 0 if there are none. Values are approximated by the upper bound of their bucket.
+func (t *latencyTracker) p99(h *metrics.Float64Histogram) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	counts := make([]uint64, len(h.Counts))
+	var total uint64
+	for i, n := range h.Counts {
+		if i < len(t.prev) {
+			n -= t.prev[i]
+		}
+		counts[i] = n
+		total += n
+	}
+	t.prev = append(t.prev[:0], h.Counts...)
+	if total == 0 {
+		return 0
+	}
+
+	// Buckets[i] and Buckets[i+1] are the bounds of Counts[i].
+	target := uint64(math.Ceil(float64(total) * 0.99))
+	var cumulative uint64
+	for i, n := range counts {
+		cumulative += n
+		if cumulative >= target {
+			upper := h.Buckets[i+1]
+			if math.IsInf(upper, +1) {
+				upper = h.Buckets[i]
+			}
+			return upper
+		}
+	}
+	return 0
+}
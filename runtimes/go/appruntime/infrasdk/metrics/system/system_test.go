@@ -0,0 +1,52 @@
+package system
+
+import (
+	"math"
+	"runtime/metrics"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestRead(t *testing.T) {
+	got := make(map[string]Kind)
+	for _, m := range Read(zerolog.Nop()) {
+		got[m.Name] = m.Kind
+	}
+
+	want := map[string]Kind{
+		MetricNameHeapObjectsBytes:       Gauge,
+		MetricNameHeapInUseBytes:         Gauge,
+		MetricNameGoroutines:             Gauge,
+		MetricNameSchedLatencyP99:        Gauge,
+		MetricNameGCCycles:               Counter,
+		MetricNameGCPauseCPUSecondsTotal: Counter,
+	}
+	for name, kind := range want {
+		if k, ok := got[name]; !ok {
+			t.Errorf("missing metric %s", name)
+		} else if k != kind {
+			t.Errorf("metric %s: got kind %v, want %v", name, k, kind)
+		}
+	}
+}
+
+func TestLatencyTracker(t *testing.T) {
+	var tr latencyTracker
+	h := &metrics.Float64Histogram{
+		Buckets: []float64{0, 0.001, 0.01, math.Inf(+1)},
+		Counts:  []uint64{99, 1, 0},
+	}
+	if got := tr.p99(h); got != 0.001 {
+		t.Fatalf("got p99 %v, want 0.001", got)
+	}
+
+	// Only the observations since the previous call are considered.
+	h.Counts = []uint64{99, 1, 10}
+	if got := tr.p99(h); got != 0.01 {
+		t.Fatalf("got p99 %v, want 0.01", got)
+	}
+	if got := tr.p99(h); got != 0 {
+		t.Fatalf("got p99 %v without observations, want 0", got)
+	}
+}
@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"path"
+	"strings"
+	"sync/atomic"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/metrics"
+)
+
+// view modifies how the metrics matching it are exported.
+type view struct {
+	match      string
+	drop       bool
+	rename     string
+	dropLabels map[string]bool
+}
+
+func newViews(cfgs []*config.MetricView) []*view {
+	views := make([]*view, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		v := &view{match: cfg.Match, drop: cfg.Drop, rename: cfg.Rename}
+		if len(cfg.DropLabels) > 0 {
+			v.dropLabels = make(map[string]bool, len(cfg.DropLabels))
+			for _, l := range cfg.DropLabels {
+				v.dropLabels[l] = true
+			}
+		}
+		views = append(views, v)
+	}
+	return views
+}
+
+// findView returns the first view matching the metric name, or nil.
+func findView(views []*view, name string) *view {
+	for _, v := range views {
+		if ok, _ := path.Match(v.match, name); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// renamedInfo overrides the name of a metric.
+type renamedInfo struct {
+	metrics.MetricInfo
+	name string
+}
+
+func (i renamedInfo) Name() string { return i.name }
+
+// mergeKey identifies a time series after labels have been dropped.
+type mergeKey struct {
+	info   metrics.MetricInfo // the original metric
+	labels string
+}
+
+// applyViews returns the collected metrics with the views applied.
+//
+// Dropping labels is only supported for counters and gauges,
+// whose time series that become identical are combined by summing their values.
+// The labels of other metric types are kept as-is.
+func applyViews(views []*view, collected []metrics.CollectedMetric) []metrics.CollectedMetric {
+	if len(views) == 0 {
+		return collected
+	}
+
+	result := make([]metrics.CollectedMetric, 0, len(collected))
+	merged := make(map[mergeKey]int) // index into result
+	for _, m := range collected {
+		v := findView(views, m.Info.Name())
+		if v == nil {
+			result = append(result, m)
+			continue
+		} else if v.drop {
+			continue
+		}
+
+		info := m.Info
+		if v.rename != "" {
+			m.Info = renamedInfo{MetricInfo: info, name: v.rename}
+		}
+
+		if typ := info.Type(); len(v.dropLabels) == 0 || (typ != metrics.CounterType && typ != metrics.GaugeType) {
+			result = append(result, m)
+			continue
+		}
+
+		var key strings.Builder
+		labels := make([]metrics.KeyValue, 0, len(m.Labels))
+		for _, l := range m.Labels {
+			if !v.dropLabels[l.Key] {
+				labels = append(labels, l)
+				key.WriteString(l.Key)
+				key.WriteByte('=')
+				key.WriteString(l.Value)
+				key.WriteByte(0)
+			}
+		}
+		m.Labels = labels
+
+		mk := mergeKey{info: info, labels: key.String()}
+		if idx, ok := merged[mk]; ok {
+			mergeSeries(&result[idx], m)
+		} else {
+			merged[mk] = len(result)
+			result = append(result, copySeries(m))
+		}
+	}
+	return result
+}
+
+// copySeries returns a copy of m whose values can be modified
+// without affecting the registry. Invalid values are zeroed.
+func copySeries(m metrics.CollectedMetric) metrics.CollectedMetric {
+	switch val := m.Val.(type) {
+	case []int64:
+		m.Val, m.Valid = copyValues(val, m.Valid)
+	case []uint64:
+		m.Val, m.Valid = copyValues(val, m.Valid)
+	case []float64:
+		m.Val, m.Valid = copyValues(val, m.Valid)
+	}
+	return m
+}
+
+// mergeSeries adds the values of src to dst, which must be a copy
+// made by copySeries of a time series of the same metric.
+func mergeSeries(dst *metrics.CollectedMetric, src metrics.CollectedMetric) {
+	// Use the lowest id so the merged time series keeps a stable id,
+	// as time series created later get higher ids.
+	dst.TimeSeriesID = min(dst.TimeSeriesID, src.TimeSeriesID)
+
+	switch val := dst.Val.(type) {
+	case []int64:
+		sumValues(val, src.Val.([]int64), dst.Valid, src.Valid)
+	case []uint64:
+		sumValues(val, src.Val.([]uint64), dst.Valid, src.Valid)
+	case []float64:
+		sumValues(val, src.Val.([]float64), dst.Valid, src.Valid)
+	}
+}
+
+func copyValues[T int64 | uint64 | float64](vals []T, valid []atomic.Bool) ([]T, []atomic.Bool) {
+	newVals := make([]T, len(vals))
+	newValid := make([]atomic.Bool, len(valid))
+	for i := range vals {
+		if valid[i].Load() {
+			newVals[i] = vals[i]
+			newValid[i].Store(true)
+		}
+	}
+	return newVals, newValid
+}
+
+func sumValues[T int64 | uint64 | float64](dst, src []T, dstValid, srcValid []atomic.Bool) {
+	for i := range src {
+		if srcValid[i].Load() {
+			dst[i] += src[i]
+			dstValid[i].Store(true)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"sync/atomic"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/metrics"
+)
+
+type testInfo struct {
+	name string
+	typ  metrics.MetricType
+}
+
+func (i *testInfo) Name() string             { return i.name }
+func (i *testInfo) Type() metrics.MetricType { return i.typ }
+func (i *testInfo) SvcNum() uint16           { return 0 }
+
+func series[T int64 | uint64 | float64](info metrics.MetricInfo, id uint64, labels []metrics.KeyValue, vals ...T) metrics.CollectedMetric {
+	valid := make([]atomic.Bool, len(vals))
+	for i := range valid {
+		valid[i].Store(true)
+	}
+	return metrics.CollectedMetric{
+		Info:         info,
+		TimeSeriesID: id,
+		Labels:       labels,
+		Val:          vals,
+		Valid:        valid,
+	}
+}
+
+func TestApplyViews(t *testing.T) {
+	c := qt.New(t)
+
+	requests := &testInfo{name: "e_requests_total", typ: metrics.CounterType}
+	latency := &testInfo{name: "e_requests_latency", typ: metrics.HistogramType}
+	orders := &testInfo{name: "orders_total", typ: metrics.CounterType}
+	internal := &testInfo{name: "internal_gauge", typ: metrics.GaugeType}
+
+	collected := []metrics.CollectedMetric{
+		series[uint64](requests, 3, []metrics.KeyValue{{Key: "endpoint", Value: "a"}, {Key: "code", Value: "ok"}}, 1, 2),
+		series[uint64](requests, 1, []metrics.KeyValue{{Key: "endpoint", Value: "a"}, {Key: "code", Value: "not_found"}}, 10, 20),
+		series[uint64](requests, 2, []metrics.KeyValue{{Key: "endpoint", Value: "b"}, {Key: "code", Value: "ok"}}, 5, 5),
+		series[float64](latency, 4, []metrics.KeyValue{{Key: "code", Value: "ok"}}, 1),
+		series[int64](orders, 5, nil, 7),
+		series[float64](internal, 6, nil, 1.5),
+	}
+	// The second service has no value for this time series.
+	collected[1].Valid[1].Store(false)
+
+	views := newViews([]*config.MetricView{
+		{Match: "internal_*", Drop: true},
+		{Match: "e_requests_*", DropLabels: []string{"code"}},
+		{Match: "orders_total", Rename: "app_orders_total"},
+		{Match: "orders_total", Drop: true}, // not applied; the first match wins
+	})
+	got := applyViews(views, collected)
+	c.Assert(got, qt.HasLen, 4)
+
+	// The counter series with the same endpoint are summed.
+	c.Assert(got[0].Info, qt.Equals, metrics.MetricInfo(requests))
+	c.Assert(got[0].TimeSeriesID, qt.Equals, uint64(1))
+	c.Assert(got[0].Labels, qt.DeepEquals, []metrics.KeyValue{{Key: "endpoint", Value: "a"}})
+	c.Assert(got[0].Val, qt.DeepEquals, []uint64{11, 2})
+	c.Assert(got[0].Valid[0].Load() && got[0].Valid[1].Load(), qt.IsTrue)
+
+	c.Assert(got[1].Labels, qt.DeepEquals, []metrics.KeyValue{{Key: "endpoint", Value: "b"}})
+	c.Assert(got[1].Val, qt.DeepEquals, []uint64{5, 5})
+
+	// Labels of histograms are kept.
+	c.Assert(got[2].Info, qt.Equals, metrics.MetricInfo(latency))
+	c.Assert(got[2].Labels, qt.DeepEquals, []metrics.KeyValue{{Key: "code", Value: "ok"}})
+
+	c.Assert(got[3].Info.Name(), qt.Equals, "app_orders_total")
+	c.Assert(got[3].Info.Type(), qt.Equals, metrics.CounterType)
+	c.Assert(got[3].Val, qt.DeepEquals, []int64{7})
+
+	// The collected values are left unmodified.
+	c.Assert(collected[0].Val, qt.DeepEquals, []uint64{1, 2})
+	c.Assert(collected[1].Val, qt.DeepEquals, []uint64{10, 20})
+}
+
+func TestApplyViews_None(t *testing.T) {
+	c := qt.New(t)
+	collected := []metrics.CollectedMetric{
+		series[int64](&testInfo{name: "foo", typ: metrics.CounterType}, 1, nil, 1),
+	}
+	got := applyViews(nil, collected)
+	c.Assert(got, qt.HasLen, 1)
+	c.Assert(&got[0], qt.Equals, &collected[0])
+}
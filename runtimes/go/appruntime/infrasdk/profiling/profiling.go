@@ -0,0 +1,125 @@
+// Package profiling continuously captures CPU profiles of the running app
+// and sends them to a profile collector.
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+const (
+	defaultInterval = time.Minute
+	defaultDuration = 10 * time.Second
+
+	// sendTimeout is the maximum time to spend sending a profile.
+	sendTimeout = 30 * time.Second
+)
+
+// sender sends profiles to a profile collector.
+type sender interface {
+	SendProfile(ctx context.Context, endpoint string, profile []byte) error
+}
+
+type Manager struct {
+	ctx    context.Context
+	cancel func()
+	done   chan struct{} // closed when BeginProfiling returns
+
+	cfg        config.ContinuousProfiling // Endpoint is empty if profiling is disabled
+	sender     sender
+	rootLogger zerolog.Logger
+}
+
+// NewManager returns a manager capturing profiles as configured by cfg.
+// If cfg is nil no profiles are captured.
+func NewManager(cfg *config.ContinuousProfiling, sender sender, rootLogger zerolog.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr := &Manager{
+		ctx:        ctx,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+		sender:     sender,
+		rootLogger: rootLogger,
+	}
+	if cfg == nil {
+		return mgr
+	}
+
+	mgr.cfg = *cfg
+	if mgr.cfg.Interval <= 0 {
+		mgr.cfg.Interval = defaultInterval
+	}
+	if mgr.cfg.Duration <= 0 {
+		mgr.cfg.Duration = defaultDuration
+	}
+	mgr.cfg.Duration = min(mgr.cfg.Duration, mgr.cfg.Interval)
+	return mgr
+}
+
+// Enabled reports whether profiles are captured.
+func (mgr *Manager) Enabled() bool {
+	return mgr != nil && mgr.cfg.Endpoint != ""
+}
+
+// BeginProfiling captures a profile every interval until the manager is shut down.
+func (mgr *Manager) BeginProfiling() {
+	defer close(mgr.done)
+	if !mgr.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(mgr.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		mgr.capture()
+		select {
+		case <-mgr.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (mgr *Manager) Shutdown(p *shutdown.Process) error {
+	if !mgr.Enabled() {
+		return nil
+	}
+
+	// Stop any profile being captured, and wait for it to be sent.
+	mgr.cancel()
+	select {
+	case <-mgr.done:
+	case <-p.ForceShutdown.Done():
+	}
+	return nil
+}
+
+// capture captures a CPU profile and sends it to the collector.
+// The profile is cut short if the manager is shut down.
+func (mgr *Manager) capture() {
+	var buf bytes.Buffer
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		// Only one CPU profile can be captured at a time, so skip this
+		// one if another is being captured, such as using "encore profile".
+		mgr.rootLogger.Debug().Err(err).Msg("profiling: unable to start cpu profile")
+		return
+	}
+	select {
+	case <-time.After(mgr.cfg.Duration):
+	case <-mgr.ctx.Done():
+	}
+	pprof.StopCPUProfile()
+
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	if err := mgr.sender.SendProfile(ctx, mgr.cfg.Endpoint, buf.Bytes()); err != nil {
+		mgr.rootLogger.Warn().Err(err).Msg("profiling: unable to send cpu profile")
+	}
+}
@@ -0,0 +1,83 @@
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"runtime/pprof"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+type fakeSender struct {
+	mu       sync.Mutex
+	endpoint string
+	profiles [][]byte
+}
+
+func (f *fakeSender) SendProfile(ctx context.Context, endpoint string, profile []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.endpoint = endpoint
+	f.profiles = append(f.profiles, profile)
+	return nil
+}
+
+func TestNewManager(t *testing.T) {
+	c := qt.New(t)
+
+	mgr := NewManager(nil, &fakeSender{}, zerolog.Nop())
+	c.Assert(mgr.Enabled(), qt.IsFalse)
+
+	mgr = NewManager(&config.ContinuousProfiling{Endpoint: "http://localhost/profile"}, &fakeSender{}, zerolog.Nop())
+	c.Assert(mgr.Enabled(), qt.IsTrue)
+	c.Assert(mgr.cfg.Interval, qt.Equals, defaultInterval)
+	c.Assert(mgr.cfg.Duration, qt.Equals, defaultDuration)
+
+	// Profiles can't be captured for longer than the interval.
+	mgr = NewManager(&config.ContinuousProfiling{Endpoint: "http://localhost/profile", Interval: time.Second}, &fakeSender{}, zerolog.Nop())
+	c.Assert(mgr.cfg.Duration, qt.Equals, time.Second)
+}
+
+func TestManager_Capture(t *testing.T) {
+	c := qt.New(t)
+	sender := &fakeSender{}
+	cfg := &config.ContinuousProfiling{Endpoint: "http://localhost/profile", Duration: 10 * time.Millisecond}
+	mgr := NewManager(cfg, sender, zerolog.Nop())
+
+	mgr.capture()
+	c.Assert(sender.endpoint, qt.Equals, "http://localhost/profile")
+	c.Assert(sender.profiles, qt.HasLen, 1)
+	// Profiles are gzip-compressed protobufs.
+	c.Assert(bytes.HasPrefix(sender.profiles[0], []byte{0x1f, 0x8b}), qt.IsTrue)
+
+	// No profile is captured while another CPU profile is being captured.
+	c.Assert(pprof.StartCPUProfile(io.Discard), qt.IsNil)
+	mgr.capture()
+	pprof.StopCPUProfile()
+	c.Assert(sender.profiles, qt.HasLen, 1)
+}
+
+func TestManager_BeginProfiling(t *testing.T) {
+	c := qt.New(t)
+	sender := &fakeSender{}
+	cfg := &config.ContinuousProfiling{Endpoint: "http://localhost/profile", Interval: time.Hour}
+	mgr := NewManager(cfg, sender, zerolog.Nop())
+
+	// Canceling cuts the profile being captured short, and sends it.
+	go mgr.BeginProfiling()
+	time.Sleep(10 * time.Millisecond)
+	mgr.cancel()
+	select {
+	case <-mgr.done:
+	case <-time.After(5 * time.Second):
+		c.Fatal("profiling did not stop")
+	}
+	c.Assert(sender.profiles, qt.HasLen, 1)
+}
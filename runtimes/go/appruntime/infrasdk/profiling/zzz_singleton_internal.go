@@ -0,0 +1,41 @@
+//go:build encore_app
+
+package profiling
+
+import (
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/encoreenv"
+	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/platform"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+var Singleton *Manager
+
+func init() {
+	Singleton = NewManager(profilingConfig(), platform.Singleton, logging.RootLogger)
+	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+	go Singleton.BeginProfiling()
+}
+
+// profilingConfig returns the continuous profiling configuration, if enabled.
+// The ENCORE_PROFILE_ENDPOINT environment variable takes precedence over
+// the configured endpoint, and enables profiling if it isn't configured.
+func profilingConfig() *config.ContinuousProfiling {
+	if appconf.Runtime.EnvType == "test" {
+		return nil
+	}
+
+	var cfg config.ContinuousProfiling
+	if c := appconf.Runtime.ContinuousProfiling; c != nil {
+		cfg = *c
+	}
+	if endpoint := encoreenv.Get("ENCORE_PROFILE_ENDPOINT"); endpoint != "" {
+		cfg.Endpoint = endpoint
+	}
+	if cfg.Endpoint == "" {
+		return nil
+	}
+	return &cfg
+}
@@ -2,22 +2,46 @@ package secrets
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"maps"
 	"os"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
 
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
 	"encore.dev/appruntime/shared/cfgutil"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/metrics"
+	"encore.dev/types/secret"
 )
 
 type Manager struct {
-	cfg     *config.Runtime
-	secrets map[string]string
+	cfg        *config.Runtime
+	rt         *reqtrack.RequestTracker
+	rootLogger zerolog.Logger
+	secrets    map[string]string
+	loads      *metrics.CounterGroup[secretLoadLabels, uint64]
+
+	mu    sync.Mutex
+	files []secret.File // file secrets to remove on shutdown
+}
+
+type secretLoadLabels struct {
+	service string // Service that loaded the secret.
+	secret  string // Name of the secret.
+	found   string // "true" if the secret had a value.
 }
 
-func NewManager(cfg *config.Runtime, infraCfgEnv, appSecretsEnv string) *Manager {
+func NewManager(cfg *config.Runtime, rt *reqtrack.RequestTracker, rootLogger zerolog.Logger, reg *metrics.Registry, infraCfgEnv, appSecretsEnv string) *Manager {
 	secrets := parse(appSecretsEnv)
 	if infraCfgEnv != "" {
 		cfg, err := config.LoadInfraConfig(infraCfgEnv)
@@ -26,12 +50,23 @@ func NewManager(cfg *config.Runtime, infraCfgEnv, appSecretsEnv string) *Manager
 		}
 		maps.Copy(secrets, cfg.Secrets.GetSecrets())
 	}
-	return &Manager{cfg: cfg, secrets: secrets}
+	loads := metrics.NewCounterGroupInternal[secretLoadLabels, uint64](reg, "e_secret_loads_total", metrics.CounterConfig{
+		EncoreInternal_LabelMapper: func(labels secretLoadLabels) []metrics.KeyValue {
+			return []metrics.KeyValue{
+				{Key: "service", Value: labels.service},
+				{Key: "secret", Value: labels.secret},
+				{Key: "found", Value: labels.found},
+			}
+		},
+	})
+	return &Manager{cfg: cfg, rt: rt, rootLogger: rootLogger, secrets: secrets, loads: loads}
 }
 
 // Load loads a secret.
 func (mgr *Manager) Load(key string, inService string) string {
-	if val, ok := mgr.secrets[key]; ok {
+	val, ok := mgr.secrets[key]
+	mgr.audit(key, inService, ok)
+	if ok {
 		return val
 	}
 
@@ -44,6 +79,112 @@ func (mgr *Manager) Load(key string, inService string) string {
 	return ""
 }
 
+// LoadBytes loads a secret as raw bytes.
+func (mgr *Manager) LoadBytes(key string, inService string) []byte {
+	val := mgr.Load(key, inService)
+	if val == "" {
+		return nil
+	}
+	return []byte(val)
+}
+
+// LoadFile loads a secret that is exposed as a file on disk.
+func (mgr *Manager) LoadFile(key string, inService string) secret.File {
+	f := secret.NewFileInternal(key, mgr.LoadBytes(key, inService))
+	mgr.mu.Lock()
+	mgr.files = append(mgr.files, f)
+	mgr.mu.Unlock()
+	return f
+}
+
+// Shutdown removes the files written for file secrets.
+func (mgr *Manager) Shutdown(p *shutdown.Process) error {
+	// Wait for all user code to finish, as it may still be using the files.
+	<-p.ServicesShutdownCompleted.Done()
+	<-p.OutstandingTasks.Done()
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	var errs []error
+	for _, f := range mgr.files {
+		if err := f.RemoveInternal(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// audit records that a secret was loaded by the given service,
+// as an audit log entry and in the e_secret_loads_total metric.
+// It records the name of the secret only, never its value.
+func (mgr *Manager) audit(key, inService string, found bool) {
+	mgr.rootLogger.Info().
+		Bool("audit", true).
+		Str("service", inService).
+		Str("secret", key).
+		Bool("found", found).
+		Msg("secret loaded")
+
+	mgr.loads.With(secretLoadLabels{
+		service: inService,
+		secret:  key,
+		found:   strconv.FormatBool(found),
+	}).Increment()
+
+}
+
+// RecordRead records that a secret was read by the current request, if any,
+// as an audit event in the request's trace. Secrets are loaded when the app starts,
+// so this is what ties a secret to the endpoints using it.
+// Each secret is recorded once per request, however many times it's read.
+// It records the name of the secret only, never its value.
+func (mgr *Manager) RecordRead(key, inService string) {
+	curr := mgr.rt.Current()
+	if curr.Req == nil || curr.Trace == nil || !firstRead(curr.Req, key) {
+		return
+	}
+
+	var endpoint string
+	if data := curr.Req.RPCData; data != nil && data.Desc != nil {
+		endpoint = data.Desc.Endpoint
+	}
+	curr.Trace.SecretRead(trace2.SecretReadParams{
+		EventParams: trace2.EventParams{
+			TraceID: curr.Req.TraceID,
+			SpanID:  curr.Req.SpanID,
+			Goid:    curr.Goctr,
+		},
+		Service:  inService,
+		Endpoint: endpoint,
+		Secret:   key,
+	})
+}
+
+// readSecrets is the set of secrets read by a request,
+// stored in the request's values.
+type readSecrets struct {
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+var readSecretsType = reflect.TypeOf((*readSecrets)(nil))
+
+// firstRead reports whether this is the first read of the secret key by req.
+func firstRead(req *model.Request, key string) bool {
+	if req.Values == nil {
+		return true
+	}
+	val, _ := req.Values.LoadOrStore(readSecretsType, &readSecrets{keys: make(map[string]bool)})
+	rs := val.(*readSecrets)
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.keys[key] {
+		return false
+	}
+	rs.keys[key] = true
+	return true
+}
+
 // parse parses secrets in "key1=base64(val1),key2=base64(val2)" format into a map.
 func parse(s string) map[string]string {
 	m := make(map[string]string)
@@ -0,0 +1,91 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/exported/trace2/decoder"
+	"encore.dev/appruntime/exported/trace2/tracetest"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/metrics"
+)
+
+func TestLoad_Audit(t *testing.T) {
+	var logs bytes.Buffer
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	reg := metrics.NewRegistry(rt, 1)
+	cfg := &config.Runtime{EnvCloud: "local"}
+	appSecrets := "Token=" + base64.RawURLEncoding.EncodeToString([]byte("super-secret"))
+
+	mgr := NewManager(cfg, rt, zerolog.New(&logs), reg, "", appSecrets)
+	if got := mgr.Load("Token", "svc"); got != "super-secret" {
+		t.Fatalf("got %q, want %q", got, "super-secret")
+	}
+	if got := mgr.Load("Missing", "svc"); got != "" {
+		t.Fatalf("got %q, want empty string", got)
+	}
+
+	out := logs.String()
+	if !strings.Contains(out, `"secret":"Token"`) || !strings.Contains(out, `"secret":"Missing"`) {
+		t.Errorf("audit log missing secret names: %s", out)
+	}
+	if strings.Contains(out, "super-secret") {
+		t.Errorf("audit log contains secret value: %s", out)
+	}
+
+	collected := reg.Collect()
+	if len(collected) != 2 {
+		t.Fatalf("got %d metrics, want 2", len(collected))
+	}
+	for _, m := range collected {
+		if m.Info.Name() != "e_secret_loads_total" {
+			t.Errorf("got metric %q, want e_secret_loads_total", m.Info.Name())
+		}
+	}
+}
+
+// recorderFactory makes requests trace to a recorder.
+type recorderFactory struct{ rec *tracetest.Recorder }
+
+func (f recorderFactory) NewLogger() trace2.Logger { return f.rec.Logger() }
+func (f recorderFactory) SampleTrace() bool        { return true }
+
+func TestRecordRead(t *testing.T) {
+	rec := tracetest.NewRecorder()
+	rt := reqtrack.New(zerolog.Logger{}, nil, recorderFactory{rec})
+	reg := metrics.NewRegistry(rt, 1)
+	cfg := &config.Runtime{EnvCloud: "local"}
+	appSecrets := "Token=" + base64.RawURLEncoding.EncodeToString([]byte("super-secret"))
+	mgr := NewManager(cfg, rt, zerolog.Nop(), reg, "", appSecrets)
+
+	// Reads outside of a request are not recorded.
+	mgr.RecordRead("Token", "svc")
+	tracetest.AssertEventCount(t, rec, trace2.SecretRead, 0)
+
+	rt.BeginRequest(&model.Request{
+		Type:    model.RPCCall,
+		TraceID: model.TraceID{1},
+		SpanID:  model.SpanID{2},
+		Traced:  true,
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Charge"}},
+	})
+	mgr.RecordRead("Token", "svc")
+	mgr.RecordRead("Token", "svc")
+	rt.FinishRequest(false)
+
+	// Repeated reads within a request are recorded once,
+	// as an audit event rather than a log message.
+	tracetest.AssertEventCount(t, rec, trace2.SecretRead, 1)
+	tracetest.AssertEventCount(t, rec, trace2.LogMessage, 0)
+
+	tracetest.AssertEvent(t, rec, func(ev *decoder.SecretRead) bool {
+		return ev.Service == "svc" && ev.Endpoint == "Charge" && ev.Secret == "Token"
+	})
+}
@@ -5,14 +5,41 @@ package secrets
 import (
 	"encore.dev/appruntime/shared/appconf"
 	"encore.dev/appruntime/shared/encoreenv"
+	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/metrics"
+	"encore.dev/types/secret"
 )
 
 var singleton = NewManager(
 	appconf.Runtime,
+	reqtrack.Singleton,
+	logging.RootLogger,
+	metrics.Singleton,
 	encoreenv.Get("ENCORE_INFRA_CONFIG_PATH"),
 	encoreenv.Get("ENCORE_APP_SECRETS"),
 )
 
+func init() {
+	shutdown.Singleton.RegisterShutdownHandler(singleton.Shutdown)
+}
+
 func Load(key string, inService string) string {
 	return singleton.Load(key, inService)
 }
+
+func LoadBytes(key string, inService string) []byte {
+	return singleton.LoadBytes(key, inService)
+}
+
+func LoadFile(key string, inService string) secret.File {
+	return singleton.LoadFile(key, inService)
+}
+
+// Read records that the secret key is read by the current request,
+// and returns its value. Reads of secrets are rewritten to call it.
+func Read[T any](val T, key string, inService string) T {
+	singleton.RecordRead(key, inService)
+	return val
+}
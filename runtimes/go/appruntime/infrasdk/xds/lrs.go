@@ -0,0 +1,200 @@
+package xds
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// loadStore records the load of the calls made to each endpoint,
+// for reporting to the management server.
+type loadStore struct {
+	mu       sync.Mutex
+	clusters map[string]*clusterLoad
+}
+
+// clusterLoad is the load of a cluster since it was last reported.
+type clusterLoad struct {
+	since      time.Time
+	localities map[localityKey]*localityLoad
+}
+
+type localityKey struct {
+	locality locality
+	priority uint32
+}
+
+type localityLoad struct {
+	requestCounts
+	endpoints map[string]*requestCounts // keyed by address
+}
+
+func newLoadStore() *loadStore {
+	return &loadStore{clusters: make(map[string]*clusterLoad)}
+}
+
+// load returns the load counters of the endpoint's locality and of the endpoint.
+// It must be called with s.mu held.
+func (s *loadStore) load(ep *Endpoint) (*requestCounts, *requestCounts) {
+	cl, ok := s.clusters[ep.cluster]
+	if !ok {
+		cl = &clusterLoad{since: time.Now(), localities: make(map[localityKey]*localityLoad)}
+		s.clusters[ep.cluster] = cl
+	}
+	key := localityKey{locality: ep.locality, priority: ep.priority}
+	loc, ok := cl.localities[key]
+	if !ok {
+		loc = &localityLoad{endpoints: make(map[string]*requestCounts)}
+		cl.localities[key] = loc
+	}
+	counts, ok := loc.endpoints[ep.Addr]
+	if !ok {
+		counts = &requestCounts{}
+		loc.endpoints[ep.Addr] = counts
+	}
+	return &loc.requestCounts, counts
+}
+
+// begin records the start of a call to ep.
+func (s *loadStore) begin(ep *Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	loc, endpoint := s.load(ep)
+	for _, c := range []*requestCounts{loc, endpoint} {
+		c.Issued++
+		c.InProgress++
+	}
+}
+
+// end records the completion of a call to ep.
+func (s *loadStore) end(ep *Endpoint, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	loc, endpoint := s.load(ep)
+	for _, c := range []*requestCounts{loc, endpoint} {
+		c.InProgress--
+		if err != nil {
+			c.Errors++
+		} else {
+			c.Successful++
+		}
+	}
+}
+
+// report returns the load of the given clusters since they were last reported,
+// or of all clusters if all is true, and resets the counters.
+func (s *loadStore) report(clusters []string, all, perEndpoint bool) []clusterStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var stats []clusterStats
+	for name, cl := range s.clusters {
+		if !all && !slices.Contains(clusters, name) {
+			continue
+		}
+
+		cs := clusterStats{ClusterName: name, Interval: now.Sub(cl.since)}
+		for key, loc := range cl.localities {
+			ls := localityStats{Locality: key.locality, Priority: key.priority, requestCounts: loc.requestCounts}
+			for addr, counts := range loc.endpoints {
+				if perEndpoint {
+					ls.EndpointStats = append(ls.EndpointStats, endpointStats{Address: addr, requestCounts: *counts})
+				}
+				resetCounts(counts)
+				if counts.InProgress == 0 {
+					delete(loc.endpoints, addr)
+				}
+			}
+			cs.LocalityStats = append(cs.LocalityStats, ls)
+			resetCounts(&loc.requestCounts)
+		}
+		stats = append(stats, cs)
+		cl.since = now
+	}
+	return stats
+}
+
+// resetCounts resets the counters of completed calls.
+// Calls in progress are kept, as they're reported until they complete.
+func resetCounts(c *requestCounts) {
+	*c = requestCounts{InProgress: c.InProgress}
+}
+
+// reportLoad keeps an LRS stream open to the management server, reconnecting
+// with backoff when it fails, until the client is closed.
+func (c *Client) reportLoad() {
+	defer c.done.Done()
+	retry(c.ctx, func() bool {
+		reported, err := c.runLRS()
+		if c.ctx.Err() == nil {
+			c.logger.Warn().Err(err).Msg("xds: load reporting stream failed, reconnecting")
+		}
+		return reported
+	})
+}
+
+// runLRS runs a single LRS stream until it fails.
+// It reports whether any load reports were sent.
+func (c *Client) runLRS() (reported bool, err error) {
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+	stream, err := c.conn.NewStream(ctx, &grpc.StreamDesc{
+		StreamName:    "StreamLoadStats",
+		ServerStreams: true,
+		ClientStreams: true,
+	}, lrsMethod, grpc.ForceCodec(codec{}))
+	if err != nil {
+		return false, err
+	}
+
+	// The management server responds to the initial request
+	// with the clusters to report and how often to report them.
+	if err := stream.SendMsg(&loadStatsRequest{Node: c.node}); err != nil {
+		return false, err
+	}
+	settings := &loadStatsResponse{}
+	if err := stream.RecvMsg(settings); err != nil {
+		return false, err
+	}
+
+	// Receive any changes to the settings in the background.
+	updates := make(chan *loadStatsResponse)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			resp := &loadStatsResponse{}
+			if err := stream.RecvMsg(resp); err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case updates <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(max(settings.Interval, time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return reported, ctx.Err()
+		case err := <-recvErr:
+			return reported, err
+		case settings = <-updates:
+			ticker.Reset(max(settings.Interval, time.Second))
+		case <-ticker.C:
+			stats := c.loads.report(settings.Clusters, settings.SendAllClusters, settings.ReportEndpointGranularity)
+			if err := stream.SendMsg(&loadStatsRequest{Node: c.node, ClusterStats: stats}); err != nil {
+				return reported, err
+			}
+			reported = true
+		}
+	}
+}
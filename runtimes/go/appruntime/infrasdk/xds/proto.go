@@ -0,0 +1,708 @@
+package xds
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// This file contains hand-written protobuf encodings of the subset of the
+// xDS messages used by the client, to avoid depending on the full set of
+// generated Envoy API types. Unknown fields are skipped when decoding.
+
+const (
+	edsTypeURL = "type.googleapis.com/envoy.config.endpoint.v3.ClusterLoadAssignment"
+
+	adsMethod = "/envoy.service.discovery.v3.AggregatedDiscoveryService/StreamAggregatedResources"
+	lrsMethod = "/envoy.service.load_stats.v3.LoadReportingService/StreamLoadStats"
+)
+
+// message is implemented by the xDS messages the client sends or receives.
+type message interface {
+	marshal(b []byte) []byte
+	unmarshal(b []byte) error
+}
+
+// codec is a gRPC codec for messages.
+type codec struct{}
+
+func (codec) Name() string { return "proto" }
+
+func (codec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(message)
+	if !ok {
+		return nil, fmt.Errorf("xds: cannot marshal %T", v)
+	}
+	return m.marshal(nil), nil
+}
+
+func (codec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(message)
+	if !ok {
+		return fmt.Errorf("xds: cannot unmarshal into %T", v)
+	}
+	return m.unmarshal(data)
+}
+
+// envoy.config.core.v3.Node
+type node struct {
+	ID             string
+	Cluster        string
+	UserAgentName  string
+	ClientFeatures []string
+}
+
+func (n *node) marshal(b []byte) []byte {
+	b = appendString(b, 1, n.ID)
+	b = appendString(b, 2, n.Cluster)
+	b = appendString(b, 6, n.UserAgentName)
+	for _, f := range n.ClientFeatures {
+		b = protowire.AppendTag(b, 10, protowire.BytesType)
+		b = protowire.AppendString(b, f)
+	}
+	return b
+}
+
+func (n *node) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		switch num {
+		case 1:
+			n.ID = string(val)
+		case 2:
+			n.Cluster = string(val)
+		case 6:
+			n.UserAgentName = string(val)
+		case 10:
+			n.ClientFeatures = append(n.ClientFeatures, string(val))
+		}
+		return nil
+	})
+}
+
+// envoy.config.core.v3.Locality
+type locality struct {
+	Region  string
+	Zone    string
+	SubZone string
+}
+
+func (l *locality) marshal(b []byte) []byte {
+	b = appendString(b, 1, l.Region)
+	b = appendString(b, 2, l.Zone)
+	b = appendString(b, 3, l.SubZone)
+	return b
+}
+
+func (l *locality) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		switch num {
+		case 1:
+			l.Region = string(val)
+		case 2:
+			l.Zone = string(val)
+		case 3:
+			l.SubZone = string(val)
+		}
+		return nil
+	})
+}
+
+// envoy.service.discovery.v3.DiscoveryRequest
+type discoveryRequest struct {
+	VersionInfo   string
+	Node          *node
+	ResourceNames []string
+	TypeURL       string
+	ResponseNonce string
+	ErrorDetail   string // the message of the google.rpc.Status, if any
+}
+
+func (r *discoveryRequest) marshal(b []byte) []byte {
+	b = appendString(b, 1, r.VersionInfo)
+	if r.Node != nil {
+		b = appendMessage(b, 2, r.Node)
+	}
+	for _, name := range r.ResourceNames {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendString(b, name)
+	}
+	b = appendString(b, 4, r.TypeURL)
+	b = appendString(b, 5, r.ResponseNonce)
+	if r.ErrorDetail != "" {
+		var status []byte
+		status = protowire.AppendTag(status, 1, protowire.VarintType)
+		status = protowire.AppendVarint(status, 3) // INVALID_ARGUMENT
+		status = appendString(status, 2, r.ErrorDetail)
+		b = protowire.AppendTag(b, 6, protowire.BytesType)
+		b = protowire.AppendBytes(b, status)
+	}
+	return b
+}
+
+func (r *discoveryRequest) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		switch num {
+		case 1:
+			r.VersionInfo = string(val)
+		case 2:
+			r.Node = &node{}
+			return r.Node.unmarshal(val)
+		case 3:
+			r.ResourceNames = append(r.ResourceNames, string(val))
+		case 4:
+			r.TypeURL = string(val)
+		case 5:
+			r.ResponseNonce = string(val)
+		case 6:
+			return parseFields(val, func(num protowire.Number, typ protowire.Type, val []byte) error {
+				if num == 2 {
+					r.ErrorDetail = string(val)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+}
+
+// envoy.service.discovery.v3.DiscoveryResponse
+type discoveryResponse struct {
+	VersionInfo string
+	Resources   []anyResource
+	TypeURL     string
+	Nonce       string
+}
+
+// google.protobuf.Any
+type anyResource struct {
+	TypeURL string
+	Value   []byte
+}
+
+func (r *discoveryResponse) marshal(b []byte) []byte {
+	b = appendString(b, 1, r.VersionInfo)
+	for _, res := range r.Resources {
+		var a []byte
+		a = appendString(a, 1, res.TypeURL)
+		a = protowire.AppendTag(a, 2, protowire.BytesType)
+		a = protowire.AppendBytes(a, res.Value)
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, a)
+	}
+	b = appendString(b, 4, r.TypeURL)
+	b = appendString(b, 5, r.Nonce)
+	return b
+}
+
+func (r *discoveryResponse) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		switch num {
+		case 1:
+			r.VersionInfo = string(val)
+		case 2:
+			var res anyResource
+			err := parseFields(val, func(num protowire.Number, typ protowire.Type, val []byte) error {
+				switch num {
+				case 1:
+					res.TypeURL = string(val)
+				case 2:
+					res.Value = val
+				}
+				return nil
+			})
+			r.Resources = append(r.Resources, res)
+			return err
+		case 4:
+			r.TypeURL = string(val)
+		case 5:
+			r.Nonce = string(val)
+		}
+		return nil
+	})
+}
+
+// healthStatus is an envoy.config.core.v3.HealthStatus.
+type healthStatus uint64
+
+const (
+	healthUnknown   healthStatus = 0
+	healthHealthy   healthStatus = 1
+	healthUnhealthy healthStatus = 2
+	healthDraining  healthStatus = 3
+	healthTimeout   healthStatus = 4
+	healthDegraded  healthStatus = 5
+)
+
+// envoy.config.endpoint.v3.ClusterLoadAssignment
+type clusterLoadAssignment struct {
+	ClusterName string
+	Endpoints   []localityEndpoints
+}
+
+// envoy.config.endpoint.v3.LocalityLbEndpoints
+type localityEndpoints struct {
+	Locality    locality
+	LbEndpoints []lbEndpoint
+	Priority    uint32
+}
+
+// envoy.config.endpoint.v3.LbEndpoint, with the address
+// of its envoy.config.endpoint.v3.Endpoint flattened.
+type lbEndpoint struct {
+	Address      string // host:port
+	HealthStatus healthStatus
+	Weight       uint32 // zero if unset
+}
+
+func (c *clusterLoadAssignment) marshal(b []byte) []byte {
+	b = appendString(b, 1, c.ClusterName)
+	for _, le := range c.Endpoints {
+		b = appendMessage(b, 2, &le)
+	}
+	return b
+}
+
+func (c *clusterLoadAssignment) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		switch num {
+		case 1:
+			c.ClusterName = string(val)
+		case 2:
+			var le localityEndpoints
+			if err := le.unmarshal(val); err != nil {
+				return err
+			}
+			c.Endpoints = append(c.Endpoints, le)
+		}
+		return nil
+	})
+}
+
+func (le *localityEndpoints) marshal(b []byte) []byte {
+	b = appendMessage(b, 1, &le.Locality)
+	for _, ep := range le.LbEndpoints {
+		b = appendMessage(b, 2, &ep)
+	}
+	b = appendVarint(b, 5, uint64(le.Priority))
+	return b
+}
+
+func (le *localityEndpoints) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		switch num {
+		case 1:
+			return le.Locality.unmarshal(val)
+		case 2:
+			var ep lbEndpoint
+			if err := ep.unmarshal(val); err != nil {
+				return err
+			}
+			le.LbEndpoints = append(le.LbEndpoints, ep)
+		case 5:
+			v, err := varint(typ, val)
+			le.Priority = uint32(v)
+			return err
+		}
+		return nil
+	})
+}
+
+func (ep *lbEndpoint) marshal(b []byte) []byte {
+	var endpoint []byte
+	endpoint = protowire.AppendTag(endpoint, 1, protowire.BytesType)
+	endpoint = protowire.AppendBytes(endpoint, marshalAddress(ep.Address))
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendBytes(b, endpoint)
+	b = appendVarint(b, 2, uint64(ep.HealthStatus))
+	if ep.Weight > 0 {
+		var w []byte
+		w = appendVarint(w, 1, uint64(ep.Weight))
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendBytes(b, w)
+	}
+	return b
+}
+
+func (ep *lbEndpoint) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		switch num {
+		case 1:
+			// Endpoint.address
+			return parseFields(val, func(num protowire.Number, typ protowire.Type, val []byte) error {
+				if num != 1 {
+					return nil
+				}
+				addr, err := parseAddress(val)
+				ep.Address = addr
+				return err
+			})
+		case 2:
+			v, err := varint(typ, val)
+			ep.HealthStatus = healthStatus(v)
+			return err
+		case 4:
+			// google.protobuf.UInt32Value
+			return parseFields(val, func(num protowire.Number, typ protowire.Type, val []byte) error {
+				if num == 1 {
+					v, err := varint(typ, val)
+					ep.Weight = uint32(v)
+					return err
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+}
+
+// marshalAddress encodes an envoy.config.core.v3.Address
+// with a socket address for the given host:port.
+func marshalAddress(addr string) []byte {
+	var sock []byte
+	host, portStr, _ := net.SplitHostPort(addr)
+	port, _ := strconv.ParseUint(portStr, 10, 32)
+	sock = appendString(sock, 2, host)
+	sock = appendVarint(sock, 3, port)
+
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	return protowire.AppendBytes(b, sock)
+}
+
+// parseAddress parses an envoy.config.core.v3.Address into host:port.
+// Addresses other than socket addresses are reported as empty.
+func parseAddress(b []byte) (string, error) {
+	var addr string
+	err := parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		if num != 1 {
+			return nil
+		}
+		var err error
+		addr, err = parseSocketAddress(val)
+		return err
+	})
+	return addr, err
+}
+
+// parseSocketAddress parses an envoy.config.core.v3.SocketAddress into host:port.
+func parseSocketAddress(b []byte) (string, error) {
+	var host string
+	var port uint64
+	err := parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		switch num {
+		case 2:
+			host = string(val)
+		case 3:
+			v, err := varint(typ, val)
+			port = v
+			return err
+		}
+		return nil
+	})
+	return net.JoinHostPort(host, strconv.FormatUint(port, 10)), err
+}
+
+// envoy.service.load_stats.v3.LoadStatsRequest
+type loadStatsRequest struct {
+	Node         *node
+	ClusterStats []clusterStats
+}
+
+// envoy.config.endpoint.v3.ClusterStats
+type clusterStats struct {
+	ClusterName   string
+	LocalityStats []localityStats
+	Interval      time.Duration
+}
+
+// envoy.config.endpoint.v3.UpstreamLocalityStats
+type localityStats struct {
+	Locality      locality
+	Priority      uint32
+	EndpointStats []endpointStats // only set if endpoint granularity is requested
+	requestCounts
+}
+
+// envoy.config.endpoint.v3.UpstreamEndpointStats
+type endpointStats struct {
+	Address string
+	requestCounts
+}
+
+// requestCounts are the request counts reported in both locality and endpoint stats.
+type requestCounts struct {
+	Successful uint64
+	InProgress uint64
+	Errors     uint64
+	Issued     uint64
+}
+
+func (r *loadStatsRequest) marshal(b []byte) []byte {
+	if r.Node != nil {
+		b = appendMessage(b, 1, r.Node)
+	}
+	for _, cs := range r.ClusterStats {
+		b = appendMessage(b, 2, &cs)
+	}
+	return b
+}
+
+func (r *loadStatsRequest) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		switch num {
+		case 1:
+			r.Node = &node{}
+			return r.Node.unmarshal(val)
+		case 2:
+			var cs clusterStats
+			if err := cs.unmarshal(val); err != nil {
+				return err
+			}
+			r.ClusterStats = append(r.ClusterStats, cs)
+		}
+		return nil
+	})
+}
+
+func (cs *clusterStats) marshal(b []byte) []byte {
+	b = appendString(b, 1, cs.ClusterName)
+	for _, ls := range cs.LocalityStats {
+		b = appendMessage(b, 2, &ls)
+	}
+	b = protowire.AppendTag(b, 4, protowire.BytesType)
+	b = protowire.AppendBytes(b, marshalDuration(cs.Interval))
+	return b
+}
+
+func (cs *clusterStats) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		switch num {
+		case 1:
+			cs.ClusterName = string(val)
+		case 2:
+			var ls localityStats
+			if err := ls.unmarshal(val); err != nil {
+				return err
+			}
+			cs.LocalityStats = append(cs.LocalityStats, ls)
+		case 4:
+			d, err := parseDuration(val)
+			cs.Interval = d
+			return err
+		}
+		return nil
+	})
+}
+
+func (ls *localityStats) marshal(b []byte) []byte {
+	b = appendMessage(b, 1, &ls.Locality)
+	b = appendVarint(b, 2, ls.Successful)
+	b = appendVarint(b, 3, ls.InProgress)
+	b = appendVarint(b, 4, ls.Errors)
+	b = appendVarint(b, 6, uint64(ls.Priority))
+	for _, es := range ls.EndpointStats {
+		b = appendMessage(b, 7, &es)
+	}
+	b = appendVarint(b, 8, ls.Issued)
+	return b
+}
+
+func (ls *localityStats) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		var err error
+		switch num {
+		case 1:
+			err = ls.Locality.unmarshal(val)
+		case 2:
+			ls.Successful, err = varint(typ, val)
+		case 3:
+			ls.InProgress, err = varint(typ, val)
+		case 4:
+			ls.Errors, err = varint(typ, val)
+		case 6:
+			var v uint64
+			v, err = varint(typ, val)
+			ls.Priority = uint32(v)
+		case 7:
+			var es endpointStats
+			err = es.unmarshal(val)
+			ls.EndpointStats = append(ls.EndpointStats, es)
+		case 8:
+			ls.Issued, err = varint(typ, val)
+		}
+		return err
+	})
+}
+
+func (es *endpointStats) marshal(b []byte) []byte {
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendBytes(b, marshalAddress(es.Address))
+	b = appendVarint(b, 2, es.Successful)
+	b = appendVarint(b, 3, es.InProgress)
+	b = appendVarint(b, 4, es.Errors)
+	b = appendVarint(b, 7, es.Issued)
+	return b
+}
+
+func (es *endpointStats) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		var err error
+		switch num {
+		case 1:
+			es.Address, err = parseAddress(val)
+		case 2:
+			es.Successful, err = varint(typ, val)
+		case 3:
+			es.InProgress, err = varint(typ, val)
+		case 4:
+			es.Errors, err = varint(typ, val)
+		case 7:
+			es.Issued, err = varint(typ, val)
+		}
+		return err
+	})
+}
+
+// envoy.service.load_stats.v3.LoadStatsResponse
+type loadStatsResponse struct {
+	Clusters                  []string
+	SendAllClusters           bool
+	Interval                  time.Duration
+	ReportEndpointGranularity bool
+}
+
+func (r *loadStatsResponse) marshal(b []byte) []byte {
+	for _, c := range r.Clusters {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, c)
+	}
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendBytes(b, marshalDuration(r.Interval))
+	if r.ReportEndpointGranularity {
+		b = appendVarint(b, 3, 1)
+	}
+	if r.SendAllClusters {
+		b = appendVarint(b, 4, 1)
+	}
+	return b
+}
+
+func (r *loadStatsResponse) unmarshal(b []byte) error {
+	return parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		switch num {
+		case 1:
+			r.Clusters = append(r.Clusters, string(val))
+		case 2:
+			d, err := parseDuration(val)
+			r.Interval = d
+			return err
+		case 3:
+			v, err := varint(typ, val)
+			r.ReportEndpointGranularity = v != 0
+			return err
+		case 4:
+			v, err := varint(typ, val)
+			r.SendAllClusters = v != 0
+			return err
+		}
+		return nil
+	})
+}
+
+// marshalDuration encodes a google.protobuf.Duration.
+func marshalDuration(d time.Duration) []byte {
+	var b []byte
+	b = appendVarint(b, 1, uint64(d/time.Second))
+	b = appendVarint(b, 2, uint64(d%time.Second))
+	return b
+}
+
+// parseDuration decodes a google.protobuf.Duration.
+func parseDuration(b []byte) (time.Duration, error) {
+	var d time.Duration
+	err := parseFields(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		v, err := varint(typ, val)
+		switch num {
+		case 1:
+			d += time.Duration(int64(v)) * time.Second
+		case 2:
+			d += time.Duration(int32(v))
+		}
+		return err
+	})
+	return d, err
+}
+
+// parseFields calls fn for each field in the encoded message b.
+// For varint fields val is the encoded varint, and for length-delimited
+// fields it's the contents of the field.
+func parseFields(b []byte, fn func(num protowire.Number, typ protowire.Type, val []byte) error) error {
+	for len(b) > 0 {
+		num, typ, val, n := consumeField(b)
+		if n < 0 {
+			return fmt.Errorf("xds: invalid message: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+		if err := fn(num, typ, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func consumeField(b []byte) (num protowire.Number, typ protowire.Type, val []byte, n int) {
+	num, typ, tagLen := protowire.ConsumeTag(b)
+	if tagLen < 0 {
+		return 0, 0, nil, tagLen
+	}
+	var valLen int
+	switch typ {
+	case protowire.BytesType:
+		val, valLen = protowire.ConsumeBytes(b[tagLen:])
+	default:
+		valLen = protowire.ConsumeFieldValue(num, typ, b[tagLen:])
+		if valLen >= 0 {
+			val = b[tagLen : tagLen+valLen]
+		}
+	}
+	if valLen < 0 {
+		return 0, 0, nil, valLen
+	}
+	return num, typ, val, tagLen + valLen
+}
+
+// varint decodes the value of a varint field.
+func varint(typ protowire.Type, val []byte) (uint64, error) {
+	if typ != protowire.VarintType {
+		return 0, fmt.Errorf("xds: invalid message: got wire type %d, want varint", typ)
+	}
+	v, n := protowire.ConsumeVarint(val)
+	if n < 0 {
+		return 0, fmt.Errorf("xds: invalid message: %w", protowire.ParseError(n))
+	}
+	return v, nil
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendMessage(b []byte, num protowire.Number, m message) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, m.marshal(nil))
+}
@@ -0,0 +1,421 @@
+// Package xds resolves the endpoints of services using an xDS management
+// server, such as Istio's or one built with Envoy's go-control-plane,
+// for deployments into an existing service mesh.
+//
+// Endpoints are fetched using EDS over an ADS stream, subscribing to each
+// service's cluster when it's first called. If configured, the load of the
+// calls made to each endpoint is reported back to the management server
+// using LRS, so the mesh can take it into account.
+package xds
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/beta/errs"
+)
+
+// resolveTimeout is how long Pick waits for the endpoints of a
+// service that's being called for the first time.
+const resolveTimeout = 10 * time.Second
+
+// Client resolves service endpoints using an xDS management server.
+type Client struct {
+	cfg    *config.XDS
+	logger zerolog.Logger
+	node   *node
+	conn   *grpc.ClientConn
+	loads  *loadStore // nil if load reporting is disabled
+
+	ctx    context.Context // canceled when the client is closed
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+
+	mu       sync.Mutex
+	clusters map[string]*cluster // keyed by cluster name
+	changed  chan struct{}       // signaled when a cluster is added
+}
+
+// cluster holds the endpoints of a single xDS cluster.
+type cluster struct {
+	name  string
+	ready chan struct{} // closed once the endpoints have been received
+
+	mu        sync.RWMutex
+	endpoints []*Endpoint // the endpoints to pick from
+}
+
+// Endpoint is an endpoint of a service.
+type Endpoint struct {
+	// Addr is the address of the endpoint, as host:port.
+	Addr string
+
+	cluster  string
+	locality locality
+	priority uint32
+	weight   uint32
+	loads    *loadStore // nil if load reporting is disabled
+}
+
+// NewClient returns a new client for the given configuration.
+// It connects to the management server in the background.
+func NewClient(cfg *config.XDS, logger zerolog.Logger) (*Client, error) {
+	creds := insecure.NewCredentials()
+	if cfg.TLS {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+	conn, err := grpc.NewClient(cfg.ServerAddr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("xds: connect to %s: %w", cfg.ServerAddr, err)
+	}
+
+	c := &Client{
+		cfg:    cfg,
+		logger: logger.With().Str("xds_server", cfg.ServerAddr).Logger(),
+		node: &node{
+			ID:             cfg.NodeID,
+			Cluster:        cfg.NodeCluster,
+			UserAgentName:  "encore",
+			ClientFeatures: []string{"envoy.lrs.supports_send_all_clusters"},
+		},
+		conn:     conn,
+		clusters: make(map[string]*cluster),
+		changed:  make(chan struct{}, 1),
+	}
+	if cfg.LoadReporting {
+		c.loads = newLoadStore()
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	c.done.Add(1)
+	go c.watch()
+	if c.loads != nil {
+		c.done.Add(1)
+		go c.reportLoad()
+	}
+	return c, nil
+}
+
+// Close stops the client.
+func (c *Client) Close() error {
+	c.cancel()
+	c.done.Wait()
+	return c.conn.Close()
+}
+
+// ClusterName returns the name of the xDS cluster serving the given service.
+func (c *Client) ClusterName(service string) string {
+	if name, ok := c.cfg.ClusterNames[service]; ok {
+		return name
+	} else if c.cfg.ClusterTemplate != "" {
+		return strings.ReplaceAll(c.cfg.ClusterTemplate, "{service}", service)
+	}
+	return service
+}
+
+// Pick picks an endpoint to call the given service on.
+// The first time a service is called, it waits for its endpoints
+// to be received from the management server.
+//
+// The caller must call Done on the endpoint once the call completes.
+func (c *Client) Pick(ctx context.Context, service string) (*Endpoint, error) {
+	cl := c.cluster(c.ClusterName(service))
+	select {
+	case <-cl.ready:
+	default:
+		ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+		defer cancel()
+		select {
+		case <-cl.ready:
+		case <-ctx.Done():
+			return nil, errs.B().Code(errs.Unavailable).Meta("service", service, "cluster", cl.name).
+				Msg("xds: timed out waiting for service endpoints").Err()
+		}
+	}
+
+	ep := cl.pick()
+	if ep == nil {
+		return nil, errs.B().Code(errs.Unavailable).Meta("service", service, "cluster", cl.name).
+			Msg("xds: no healthy endpoints for service").Err()
+	}
+	if ep.loads != nil {
+		ep.loads.begin(ep)
+	}
+	return ep, nil
+}
+
+// Done records the completion of a call made to the endpoint.
+func (ep *Endpoint) Done(err error) {
+	if ep.loads != nil {
+		ep.loads.end(ep, err)
+	}
+}
+
+// cluster returns the cluster with the given name, subscribing to it if necessary.
+func (c *Client) cluster(name string) *cluster {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cl, ok := c.clusters[name]
+	if !ok {
+		cl = &cluster{name: name, ready: make(chan struct{})}
+		c.clusters[name] = cl
+		select {
+		case c.changed <- struct{}{}:
+		default:
+		}
+	}
+	return cl
+}
+
+// clusterNames returns the names of the clusters subscribed to.
+func (c *Client) clusterNames() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	names := make([]string, 0, len(c.clusters))
+	for name := range c.clusters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// pick picks an endpoint of the cluster, or nil if it has none.
+// Endpoints are picked at random in proportion to their weight.
+func (cl *cluster) pick() *Endpoint {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+
+	var total uint64
+	for _, ep := range cl.endpoints {
+		total += uint64(ep.weight)
+	}
+	if total == 0 {
+		return nil
+	}
+	n := rand.Uint64N(total)
+	for _, ep := range cl.endpoints {
+		if n < uint64(ep.weight) {
+			return ep
+		}
+		n -= uint64(ep.weight)
+	}
+	return nil
+}
+
+// update replaces the cluster's endpoints with those of the given assignment,
+// and returns the number of endpoints to pick from.
+func (cl *cluster) update(cla *clusterLoadAssignment, loads *loadStore) int {
+	endpoints := usableEndpoints(cla, loads)
+
+	cl.mu.Lock()
+	cl.endpoints = endpoints
+	cl.mu.Unlock()
+
+	select {
+	case <-cl.ready:
+	default:
+		close(cl.ready)
+	}
+	return len(endpoints)
+}
+
+// usableEndpoints returns the endpoints to pick from for the given assignment.
+// It uses the healthy endpoints with the highest priority (the lowest number),
+// falling back to degraded endpoints if there are no healthy ones.
+func usableEndpoints(cla *clusterLoadAssignment, loads *loadStore) []*Endpoint {
+	var healthy, degraded []*Endpoint
+	var bestHealthy, bestDegraded uint32
+	for _, le := range cla.Endpoints {
+		for _, lbe := range le.LbEndpoints {
+			ep := &Endpoint{
+				Addr:     lbe.Address,
+				cluster:  cla.ClusterName,
+				locality: le.Locality,
+				priority: le.Priority,
+				weight:   max(lbe.Weight, 1),
+				loads:    loads,
+			}
+			switch lbe.HealthStatus {
+			case healthUnknown, healthHealthy:
+				healthy, bestHealthy = addByPriority(healthy, bestHealthy, ep)
+			case healthDegraded:
+				degraded, bestDegraded = addByPriority(degraded, bestDegraded, ep)
+			}
+		}
+	}
+	if len(healthy) > 0 {
+		return healthy
+	}
+	return degraded
+}
+
+// addByPriority adds ep to the endpoints if it has the same priority
+// as them, or replaces them if it has a higher priority.
+func addByPriority(endpoints []*Endpoint, priority uint32, ep *Endpoint) ([]*Endpoint, uint32) {
+	switch {
+	case len(endpoints) == 0 || ep.priority < priority:
+		return []*Endpoint{ep}, ep.priority
+	case ep.priority == priority:
+		return append(endpoints, ep), priority
+	default:
+		return endpoints, priority
+	}
+}
+
+// watch keeps an ADS stream open to the management server, reconnecting
+// with backoff when it fails, until the client is closed.
+func (c *Client) watch() {
+	defer c.done.Done()
+	retry(c.ctx, func() bool {
+		received, err := c.runADS()
+		if c.ctx.Err() == nil {
+			c.logger.Warn().Err(err).Msg("xds: endpoints stream failed, reconnecting")
+		}
+		return received
+	})
+}
+
+// runADS runs a single ADS stream until it fails.
+// It reports whether any responses were received.
+func (c *Client) runADS() (received bool, err error) {
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+	stream, err := c.conn.NewStream(ctx, &grpc.StreamDesc{
+		StreamName:    "StreamAggregatedResources",
+		ServerStreams: true,
+		ClientStreams: true,
+	}, adsMethod, grpc.ForceCodec(codec{}))
+	if err != nil {
+		return false, err
+	}
+
+	// Receive responses in the background, as requests are sent
+	// both in response to them and when subscribing to new clusters.
+	responses := make(chan *discoveryResponse)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			resp := &discoveryResponse{}
+			if err := stream.RecvMsg(resp); err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case responses <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var version, nonce string
+	var subscribed []string
+	subscribe := func(errDetail string) error {
+		subscribed = c.clusterNames()
+		return stream.SendMsg(&discoveryRequest{
+			VersionInfo:   version,
+			Node:          c.node,
+			ResourceNames: subscribed,
+			TypeURL:       edsTypeURL,
+			ResponseNonce: nonce,
+			ErrorDetail:   errDetail,
+		})
+	}
+
+	// Subscribe to the clusters known so far, if any.
+	if len(c.clusterNames()) > 0 {
+		if err := subscribe(""); err != nil {
+			return false, err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return received, ctx.Err()
+		case err := <-recvErr:
+			return received, err
+		case <-c.changed:
+			if !slices.Equal(subscribed, c.clusterNames()) {
+				if err := subscribe(""); err != nil {
+					return received, err
+				}
+			}
+		case resp := <-responses:
+			received = true
+			nonce = resp.Nonce
+			if err := c.handleResponse(resp); err != nil {
+				// NACK the response, keeping the previous version.
+				c.logger.Error().Err(err).Str("version", resp.VersionInfo).Msg("xds: rejected endpoints update")
+				if err := subscribe(err.Error()); err != nil {
+					return received, err
+				}
+				continue
+			}
+			version = resp.VersionInfo
+			if err := subscribe(""); err != nil {
+				return received, err
+			}
+		}
+	}
+}
+
+// handleResponse applies the endpoints in the given response.
+func (c *Client) handleResponse(resp *discoveryResponse) error {
+	if resp.TypeURL != edsTypeURL {
+		return fmt.Errorf("unexpected resource type %q", resp.TypeURL)
+	}
+
+	assignments := make([]*clusterLoadAssignment, 0, len(resp.Resources))
+	for _, res := range resp.Resources {
+		if res.TypeURL != edsTypeURL {
+			return fmt.Errorf("unexpected resource type %q", res.TypeURL)
+		}
+		cla := &clusterLoadAssignment{}
+		if err := cla.unmarshal(res.Value); err != nil {
+			return err
+		}
+		assignments = append(assignments, cla)
+	}
+
+	for _, cla := range assignments {
+		c.mu.Lock()
+		cl := c.clusters[cla.ClusterName]
+		c.mu.Unlock()
+		if cl == nil {
+			continue // not subscribed to
+		}
+		n := cl.update(cla, c.loads)
+		c.logger.Debug().Str("cluster", cla.ClusterName).Int("endpoints", n).Msg("xds: updated endpoints")
+	}
+	return nil
+}
+
+// retry calls fn until ctx is canceled, backing off exponentially between
+// attempts. The backoff is reset whenever fn reports it made progress.
+func retry(ctx context.Context, fn func() (progress bool)) {
+	const minBackoff, maxBackoff = time.Second, 30 * time.Second
+	backoff := minBackoff
+	for ctx.Err() == nil {
+		if fn() {
+			backoff = minBackoff
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
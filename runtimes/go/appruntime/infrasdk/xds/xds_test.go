@@ -0,0 +1,429 @@
+package xds
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/beta/errs"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	tests := []message{
+		&discoveryRequest{
+			VersionInfo:   "1",
+			Node:          &node{ID: "node", Cluster: "app", UserAgentName: "encore", ClientFeatures: []string{"a", "b"}},
+			ResourceNames: []string{"orders", "users"},
+			TypeURL:       edsTypeURL,
+			ResponseNonce: "nonce",
+			ErrorDetail:   "bad update",
+		},
+		&discoveryResponse{
+			VersionInfo: "2",
+			Resources:   []anyResource{{TypeURL: edsTypeURL, Value: []byte{1, 2, 3}}},
+			TypeURL:     edsTypeURL,
+			Nonce:       "nonce",
+		},
+		&clusterLoadAssignment{
+			ClusterName: "orders",
+			Endpoints: []localityEndpoints{{
+				Locality: locality{Region: "eu", Zone: "eu-1", SubZone: "a"},
+				Priority: 1,
+				LbEndpoints: []lbEndpoint{
+					{Address: "10.0.0.1:8080", HealthStatus: healthHealthy, Weight: 3},
+					{Address: "[::1]:9090", HealthStatus: healthDegraded},
+				},
+			}},
+		},
+		&loadStatsRequest{
+			Node: &node{ID: "node"},
+			ClusterStats: []clusterStats{{
+				ClusterName: "orders",
+				Interval:    1500 * time.Millisecond,
+				LocalityStats: []localityStats{{
+					Locality:      locality{Zone: "eu-1"},
+					Priority:      2,
+					requestCounts: requestCounts{Successful: 4, InProgress: 1, Errors: 2, Issued: 7},
+					EndpointStats: []endpointStats{{
+						Address:       "10.0.0.1:8080",
+						requestCounts: requestCounts{Successful: 4, Issued: 4},
+					}},
+				}},
+			}},
+		},
+		&loadStatsResponse{
+			Clusters:                  []string{"orders"},
+			Interval:                  10 * time.Second,
+			ReportEndpointGranularity: true,
+		},
+	}
+
+	for _, want := range tests {
+		got, err := roundTrip(want)
+		if err != nil {
+			t.Errorf("%T: %v", want, err)
+		} else if diff := cmp.Diff(want, got, cmp.AllowUnexported(localityStats{}, endpointStats{})); diff != "" {
+			t.Errorf("%T: round trip mismatch (-want +got):\n%s", want, diff)
+		}
+	}
+}
+
+func roundTrip(m message) (message, error) {
+	var got message
+	switch m.(type) {
+	case *discoveryRequest:
+		got = &discoveryRequest{}
+	case *discoveryResponse:
+		got = &discoveryResponse{}
+	case *clusterLoadAssignment:
+		got = &clusterLoadAssignment{}
+	case *loadStatsRequest:
+		got = &loadStatsRequest{}
+	case *loadStatsResponse:
+		got = &loadStatsResponse{}
+	}
+	return got, got.unmarshal(m.marshal(nil))
+}
+
+func TestUsableEndpoints(t *testing.T) {
+	endpoints := func(priority uint32, eps ...lbEndpoint) localityEndpoints {
+		return localityEndpoints{Priority: priority, LbEndpoints: eps}
+	}
+	ep := func(addr string, health healthStatus) lbEndpoint {
+		return lbEndpoint{Address: addr, HealthStatus: health}
+	}
+
+	tests := []struct {
+		name string
+		cla  *clusterLoadAssignment
+		want []string
+	}{
+		{
+			name: "healthy_and_unknown",
+			cla: &clusterLoadAssignment{Endpoints: []localityEndpoints{
+				endpoints(0, ep("a:1", healthHealthy), ep("b:1", healthUnknown), ep("c:1", healthUnhealthy), ep("d:1", healthDraining)),
+			}},
+			want: []string{"a:1", "b:1"},
+		},
+		{
+			name: "highest_priority",
+			cla: &clusterLoadAssignment{Endpoints: []localityEndpoints{
+				endpoints(1, ep("a:1", healthHealthy)),
+				endpoints(0, ep("b:1", healthHealthy)),
+				endpoints(0, ep("c:1", healthHealthy)),
+			}},
+			want: []string{"b:1", "c:1"},
+		},
+		{
+			name: "failover_to_lower_priority",
+			cla: &clusterLoadAssignment{Endpoints: []localityEndpoints{
+				endpoints(0, ep("a:1", healthUnhealthy)),
+				endpoints(1, ep("b:1", healthHealthy)),
+			}},
+			want: []string{"b:1"},
+		},
+		{
+			name: "degraded_fallback",
+			cla: &clusterLoadAssignment{Endpoints: []localityEndpoints{
+				endpoints(0, ep("a:1", healthUnhealthy), ep("b:1", healthDegraded)),
+			}},
+			want: []string{"b:1"},
+		},
+		{
+			name: "none",
+			cla: &clusterLoadAssignment{Endpoints: []localityEndpoints{
+				endpoints(0, ep("a:1", healthUnhealthy), ep("b:1", healthTimeout)),
+			}},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, ep := range usableEndpoints(tt.cla, nil) {
+				got = append(got, ep.Addr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("endpoints mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_ClusterName(t *testing.T) {
+	c := &Client{cfg: &config.XDS{
+		ClusterNames:    map[string]string{"orders": "orders-v2"},
+		ClusterTemplate: "outbound|8080||{service}.default.svc.cluster.local",
+	}}
+	if got, want := c.ClusterName("orders"), "orders-v2"; got != want {
+		t.Errorf("got cluster %q, want %q", got, want)
+	}
+	if got, want := c.ClusterName("users"), "outbound|8080||users.default.svc.cluster.local"; got != want {
+		t.Errorf("got cluster %q, want %q", got, want)
+	}
+
+	c.cfg.ClusterTemplate = ""
+	if got, want := c.ClusterName("users"), "users"; got != want {
+		t.Errorf("got cluster %q, want %q", got, want)
+	}
+}
+
+func TestClient_Pick(t *testing.T) {
+	srv := newFakeServer(t, nil)
+	c := newTestClient(t, srv, false)
+
+	picked := make(chan *Endpoint, 1)
+	go func() {
+		ep, err := c.Pick(context.Background(), "orders")
+		if err != nil {
+			t.Error(err)
+		}
+		picked <- ep
+	}()
+
+	// The first call subscribes to the service's cluster.
+	req := srv.recv(t)
+	if diff := cmp.Diff([]string{"orders"}, req.ResourceNames); diff != "" {
+		t.Fatalf("resource names mismatch (-want +got):\n%s", diff)
+	}
+	if req.Node.ID != "node-1" || req.TypeURL != edsTypeURL {
+		t.Fatalf("got node %q and type %q", req.Node.ID, req.TypeURL)
+	}
+
+	srv.send(t, "1", &clusterLoadAssignment{
+		ClusterName: "orders",
+		Endpoints: []localityEndpoints{{
+			LbEndpoints: []lbEndpoint{
+				{Address: "10.0.0.1:8080", HealthStatus: healthUnhealthy},
+				{Address: "10.0.0.2:8080", HealthStatus: healthHealthy},
+			},
+		}},
+	})
+	select {
+	case ep := <-picked:
+		if ep == nil || ep.Addr != "10.0.0.2:8080" {
+			t.Fatalf("got endpoint %+v, want 10.0.0.2:8080", ep)
+		}
+		ep.Done(nil)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for endpoint")
+	}
+
+	// The update is acknowledged.
+	if ack := srv.recv(t); ack.VersionInfo != "1" || ack.ErrorDetail != "" {
+		t.Fatalf("got version %q and error %q, want an ACK of version 1", ack.VersionInfo, ack.ErrorDetail)
+	}
+
+	// Invalid updates are rejected, keeping the previous endpoints.
+	srv.sendRaw(t, &discoveryResponse{VersionInfo: "2", TypeURL: "bad", Nonce: "2"})
+	if nack := srv.recv(t); nack.VersionInfo != "1" || nack.ErrorDetail == "" {
+		t.Fatalf("got version %q and error %q, want a NACK keeping version 1", nack.VersionInfo, nack.ErrorDetail)
+	}
+	if ep, err := c.Pick(context.Background(), "orders"); err != nil || ep.Addr != "10.0.0.2:8080" {
+		t.Fatalf("got endpoint %+v and err %v, want 10.0.0.2:8080", ep, err)
+	}
+
+	// Services without usable endpoints are unavailable.
+	srv.send(t, "3", &clusterLoadAssignment{ClusterName: "orders"})
+	srv.recv(t)
+	if _, err := c.Pick(context.Background(), "orders"); errs.Code(err) != errs.Unavailable {
+		t.Fatalf("got err %v, want unavailable", err)
+	}
+}
+
+func TestClient_LoadReporting(t *testing.T) {
+	srv := newFakeServer(t, &loadStatsResponse{SendAllClusters: true, Interval: time.Second})
+	c := newTestClient(t, srv, true)
+
+	picked := make(chan error, 1)
+	go func() {
+		ep, err := c.Pick(context.Background(), "orders")
+		if err == nil {
+			ep.Done(nil)
+		}
+		picked <- err
+	}()
+	srv.recv(t)
+	srv.send(t, "1", &clusterLoadAssignment{
+		ClusterName: "orders",
+		Endpoints: []localityEndpoints{{
+			Locality:    locality{Zone: "eu-1"},
+			LbEndpoints: []lbEndpoint{{Address: "10.0.0.1:8080"}},
+		}},
+	})
+	if err := <-picked; err != nil {
+		t.Fatal(err)
+	}
+
+	for _, err := range []error{nil, errors.New("fail"), nil} {
+		ep, pickErr := c.Pick(context.Background(), "orders")
+		if pickErr != nil {
+			t.Fatal(pickErr)
+		}
+		ep.Done(err)
+	}
+	// A call still in progress.
+	if _, err := c.Pick(context.Background(), "orders"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sum the reports until all calls have been reported.
+	var got requestCounts
+	timeout := time.After(5 * time.Second)
+	for got.Issued < 5 {
+		select {
+		case req := <-srv.loadReports:
+			for _, cs := range req.ClusterStats {
+				if cs.ClusterName != "orders" {
+					t.Fatalf("got stats for cluster %q", cs.ClusterName)
+				}
+				for _, ls := range cs.LocalityStats {
+					if ls.Locality.Zone != "eu-1" {
+						t.Fatalf("got stats for locality %+v", ls.Locality)
+					}
+					got.Successful += ls.Successful
+					got.Errors += ls.Errors
+					got.Issued += ls.Issued
+					got.InProgress = ls.InProgress
+				}
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for load reports, got %+v", got)
+		}
+	}
+
+	want := requestCounts{Successful: 3, Errors: 1, Issued: 5, InProgress: 1}
+	if got != want {
+		t.Errorf("got request counts %+v, want %+v", got, want)
+	}
+}
+
+func newTestClient(t *testing.T, srv *fakeServer, loadReporting bool) *Client {
+	c, err := NewClient(&config.XDS{
+		ServerAddr:    srv.addr,
+		NodeID:        "node-1",
+		LoadReporting: loadReporting,
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+// fakeServer is a management server serving endpoints over ADS
+// and receiving load reports over LRS.
+type fakeServer struct {
+	addr        string
+	requests    chan *discoveryRequest
+	responses   chan *discoveryResponse
+	loadReports chan *loadStatsRequest
+}
+
+func newFakeServer(t *testing.T, lrsSettings *loadStatsResponse) *fakeServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := &fakeServer{
+		addr:        ln.Addr().String(),
+		requests:    make(chan *discoveryRequest, 10),
+		responses:   make(chan *discoveryResponse),
+		loadReports: make(chan *loadStatsRequest, 10),
+	}
+
+	srv := grpc.NewServer(grpc.ForceServerCodec(codec{}), grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+		switch method, _ := grpc.MethodFromServerStream(stream); method {
+		case adsMethod:
+			return fs.serveADS(stream)
+		case lrsMethod:
+			return fs.serveLRS(stream, lrsSettings)
+		default:
+			return errors.New("unknown method")
+		}
+	}))
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(srv.Stop)
+	return fs
+}
+
+func (fs *fakeServer) serveADS(stream grpc.ServerStream) error {
+	go func() {
+		for {
+			req := &discoveryRequest{}
+			if err := stream.RecvMsg(req); err != nil {
+				return
+			}
+			fs.requests <- req
+		}
+	}()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case resp := <-fs.responses:
+			if err := stream.SendMsg(resp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (fs *fakeServer) serveLRS(stream grpc.ServerStream, settings *loadStatsResponse) error {
+	if settings == nil {
+		return errors.New("load reporting not supported")
+	}
+	if err := stream.RecvMsg(&loadStatsRequest{}); err != nil {
+		return err
+	}
+	if err := stream.SendMsg(settings); err != nil {
+		return err
+	}
+	for {
+		req := &loadStatsRequest{}
+		if err := stream.RecvMsg(req); err != nil {
+			return nil
+		}
+		fs.loadReports <- req
+	}
+}
+
+// recv returns the next discovery request received.
+func (fs *fakeServer) recv(t *testing.T) *discoveryRequest {
+	t.Helper()
+	select {
+	case req := <-fs.requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for discovery request")
+		return nil
+	}
+}
+
+// send sends a response with the given version and endpoints.
+func (fs *fakeServer) send(t *testing.T, version string, cla *clusterLoadAssignment) {
+	t.Helper()
+	fs.sendRaw(t, &discoveryResponse{
+		VersionInfo: version,
+		Resources:   []anyResource{{TypeURL: edsTypeURL, Value: cla.marshal(nil)}},
+		TypeURL:     edsTypeURL,
+		Nonce:       version,
+	})
+}
+
+func (fs *fakeServer) sendRaw(t *testing.T, resp *discoveryResponse) {
+	t.Helper()
+	select {
+	case fs.responses <- resp:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out sending discovery response")
+	}
+}
@@ -0,0 +1,54 @@
+// Package clock provides the current time to the runtime.
+//
+// When running tests the time can be changed by the tests
+// using et.SetTime and et.AdvanceTime, so that time-dependent
+// code such as cache expiry can be tested deterministically.
+package clock
+
+import (
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/testsupport"
+)
+
+// Clock tells the current time.
+type Clock struct {
+	ts *testsupport.Manager // nil if not testing
+}
+
+// New returns a clock that tells the actual time,
+// or the time as seen by the current test when testing.
+func New(static *config.Static, ts *testsupport.Manager) *Clock {
+	c := &Clock{}
+	if static != nil && static.Testing {
+		c.ts = ts
+	}
+	return c
+}
+
+// Now returns the current time.
+func (c *Clock) Now() time.Time {
+	if c != nil && c.ts != nil {
+		return c.ts.Now()
+	}
+	return time.Now()
+}
+
+// Since returns the time elapsed since t.
+func (c *Clock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the duration until t.
+func (c *Clock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+// OnAdvance registers a function to call when a test moves the time forward,
+// for code that keeps track of time on its own. It's never called when not testing.
+func (c *Clock) OnAdvance(fn func(d time.Duration)) {
+	if c != nil && c.ts != nil {
+		c.ts.OnTimeAdvanced(fn)
+	}
+}
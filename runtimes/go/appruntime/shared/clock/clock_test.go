@@ -0,0 +1,61 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
+)
+
+func TestClock(t *testing.T) {
+	static := &config.Static{Testing: true}
+	ts := testsupport.NewManager(static, reqtrack.New(zerolog.Nop(), nil, nil), zerolog.Nop())
+	c := New(static, ts)
+
+	var advanced []time.Duration
+	c.OnAdvance(func(d time.Duration) { advanced = append(advanced, d) })
+
+	if d := c.Since(time.Now()); d < -time.Second || d > time.Second {
+		t.Fatalf("got unchanged clock %s off the actual time", d)
+	}
+
+	t0 := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	ts.SetTime(t0)
+	if got := c.Now(); !got.Equal(t0) {
+		t.Fatalf("got %s, want %s", got, t0)
+	}
+
+	ts.AdvanceTime(time.Hour)
+	if got, want := c.Now(), t0.Add(time.Hour); !got.Equal(want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if c.Until(t0.Add(2*time.Hour)) != time.Hour {
+		t.Fatalf("got Until %s, want 1h", c.Until(t0.Add(2*time.Hour)))
+	}
+
+	// Moving the time back doesn't notify the listeners.
+	ts.SetTime(t0)
+	if len(advanced) != 2 || advanced[1] != time.Hour {
+		t.Fatalf("got advanced %v", advanced)
+	}
+}
+
+func TestClock_NotTesting(t *testing.T) {
+	static := &config.Static{}
+	ts := testsupport.NewManager(static, reqtrack.New(zerolog.Nop(), nil, nil), zerolog.Nop())
+	c := New(static, ts)
+
+	ts.SetTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	if d := c.Since(time.Now()); d < -time.Second || d > time.Second {
+		t.Fatalf("got clock %s off the actual time, want the actual time", d)
+	}
+
+	var nilClock *Clock
+	if d := nilClock.Since(time.Now()); d < -time.Second || d > time.Second {
+		t.Fatalf("got nil clock %s off the actual time", d)
+	}
+}
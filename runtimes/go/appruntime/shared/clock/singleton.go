@@ -0,0 +1,10 @@
+//go:build encore_app
+
+package clock
+
+import (
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/testsupport"
+)
+
+var Singleton = New(appconf.Static, testsupport.Singleton)
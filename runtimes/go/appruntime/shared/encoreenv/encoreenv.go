@@ -13,6 +13,18 @@ func Set(env, val string) {
 	envs[env] = val
 }
 
+// WithPrefix returns all captured environment variables
+// whose name starts with the given prefix.
+func WithPrefix(prefix string) map[string]string {
+	res := make(map[string]string)
+	for k, v := range envs {
+		if strings.HasPrefix(k, prefix) {
+			res[k] = v
+		}
+	}
+	return res
+}
+
 var envs map[string]string
 
 func init() {
@@ -5,6 +5,8 @@ import (
 	stdjson "encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -168,6 +170,38 @@ func (u *Unmarshaller) ReadBody(body io.Reader) (payload []byte) {
 	return payload
 }
 
+// maxMultipartMemory is the number of bytes of a multipart form's files
+// kept in memory, with the rest written to temporary files.
+const maxMultipartMemory = 32 << 20
+
+// ReadMultipartForm parses the request body as a multipart/form-data form.
+func (u *Unmarshaller) ReadMultipartForm(req *http.Request) *multipart.Form {
+	if err := req.ParseMultipartForm(maxMultipartMemory); err != nil {
+		u.setErr("could not parse multipart form", "request_body", err)
+		return &multipart.Form{}
+	}
+	return req.MultipartForm
+}
+
+// UnmarshalFile returns the first of the files uploaded for a form field,
+// or nil if there are none.
+func UnmarshalFile(u *Unmarshaller, files []*multipart.FileHeader) *multipart.FileHeader {
+	if len(files) == 0 {
+		return nil
+	}
+	u.NonEmptyValues++
+	return files[0]
+}
+
+// UnmarshalFiles returns the files uploaded for a form field.
+func UnmarshalFiles(u *Unmarshaller, files []*multipart.FileHeader) []*multipart.FileHeader {
+	if len(files) == 0 {
+		return nil
+	}
+	u.NonEmptyValues++
+	return files
+}
+
 func (u *Unmarshaller) ParseJSON(field string, iter *jsoniter.Iterator, dst any) {
 	iter.ReadVal(dst)
 	u.setErr("invalid json parameter", field, iter.Error)
@@ -0,0 +1,55 @@
+// Package exemplar stores exemplars, which are sample observations
+// of a metric linked to the trace they were recorded in.
+package exemplar
+
+import (
+	"sync/atomic"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+type Exemplar struct {
+	Value   float64
+	Time    time.Time
+	TraceID model.TraceID
+	SpanID  model.SpanID
+}
+
+// NewReservoir returns a reservoir with the given number of slots.
+func NewReservoir(size int) *Reservoir {
+	return &Reservoir{slots: make([]atomic.Pointer[Exemplar], size)}
+}
+
+// Reservoir holds the most recent exemplar offered to each of a fixed number of slots.
+// It is safe for concurrent use.
+type Reservoir struct {
+	slots []atomic.Pointer[Exemplar]
+}
+
+// Offer stores e in the given slot, replacing the previous exemplar.
+// Slots outside of the reservoir size wrap around.
+func (r *Reservoir) Offer(slot int, e Exemplar) {
+	r.slots[r.index(slot)].Store(&e)
+}
+
+// Load returns the exemplar in the given slot, or nil if there is none.
+func (r *Reservoir) Load(slot int) *Exemplar {
+	return r.slots[r.index(slot)].Load()
+}
+
+// All returns the stored exemplars, ordered by slot.
+func (r *Reservoir) All() []Exemplar {
+	var all []Exemplar
+	for i := range r.slots {
+		if e := r.slots[i].Load(); e != nil {
+			all = append(all, *e)
+		}
+	}
+	return all
+}
+
+func (r *Reservoir) index(slot int) int {
+	n := len(r.slots)
+	return (slot%n + n) % n
+}
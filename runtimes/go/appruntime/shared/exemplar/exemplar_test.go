@@ -0,0 +1,27 @@
+package exemplar
+
+import (
+	"testing"
+)
+
+func TestReservoir(t *testing.T) {
+	r := NewReservoir(3)
+	if r.Load(0) != nil || len(r.All()) != 0 {
+		t.Fatal("expected empty reservoir")
+	}
+
+	r.Offer(0, Exemplar{Value: 1})
+	r.Offer(1, Exemplar{Value: 2})
+	r.Offer(0, Exemplar{Value: 3})
+	if e := r.Load(0); e == nil || e.Value != 3 {
+		t.Fatalf("got exemplar %+v in slot 0, want value 3", e)
+	}
+
+	// Slots wrap around, including negative ones.
+	r.Offer(-1, Exemplar{Value: 4})
+	r.Offer(4, Exemplar{Value: 5})
+	all := r.All()
+	if len(all) != 3 || all[0].Value != 3 || all[1].Value != 5 || all[2].Value != 4 {
+		t.Fatalf("got exemplars %+v", all)
+	}
+}
@@ -0,0 +1,110 @@
+// Package explicithist implements histograms with explicit bucket boundaries.
+package explicithist
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync/atomic"
+
+	"encore.dev/appruntime/shared/exemplar"
+)
+
+// New returns a histogram with the given bucket upper bounds.
+// The bounds must be valid according to ValidateBounds.
+func New(bounds []float64) *Histogram {
+	return &Histogram{
+		Bounds:    bounds,
+		Exemplars: exemplar.NewReservoir(len(bounds) + 1),
+		counts:    make([]atomic.Uint64, len(bounds)+1),
+	}
+}
+
+// ValidateBounds reports whether bounds are valid bucket upper bounds,
+// meaning they are non-empty, finite and strictly increasing.
+func ValidateBounds(bounds []float64) error {
+	if len(bounds) == 0 {
+		return errors.New("no bucket bounds given")
+	}
+	for i, b := range bounds {
+		if math.IsNaN(b) || math.IsInf(b, 0) {
+			return fmt.Errorf("bucket bound %v is not finite", b)
+		} else if i > 0 && b <= bounds[i-1] {
+			return fmt.Errorf("bucket bounds must be strictly increasing, got %v after %v", b, bounds[i-1])
+		}
+	}
+	return nil
+}
+
+type Histogram struct {
+	// Bounds are the upper bounds of the buckets, in increasing order.
+	// Bucket i covers the range (Bounds[i-1], Bounds[i]], and a final
+	// bucket covers the observations greater than the last bound.
+	Bounds []float64
+
+	// Exemplars holds the most recent exemplar of each bucket,
+	// indexed by bucket.
+	Exemplars *exemplar.Reservoir
+
+	counts  []atomic.Uint64
+	count   atomic.Uint64
+	sumBits atomic.Uint64
+}
+
+// Observe records an observation in the histogram.
+func (h *Histogram) Observe(v float64) {
+	h.observe(v)
+}
+
+// ObserveWithExemplar records an observation in the histogram,
+// and stores e as the exemplar of the observation's bucket.
+func (h *Histogram) ObserveWithExemplar(v float64, e exemplar.Exemplar) {
+	idx := h.observe(v)
+	h.Exemplars.Offer(idx, e)
+}
+
+func (h *Histogram) observe(v float64) (bucket int) {
+	idx := sort.SearchFloat64s(h.Bounds, v)
+	h.counts[idx].Add(1)
+	h.count.Add(1)
+	for {
+		old := h.sumBits.Load()
+		sum := math.Float64bits(math.Float64frombits(old) + v)
+		if h.sumBits.CompareAndSwap(old, sum) {
+			return idx
+		}
+	}
+}
+
+// Snapshot is a point-in-time copy of a histogram.
+type Snapshot struct {
+	// Bounds are the upper bounds of the buckets, as in Histogram.
+	Bounds []float64
+	// BucketCounts are the number of observations in each bucket,
+	// with one more entry than Bounds.
+	BucketCounts []uint64
+	Count        uint64
+	Sum          float64
+	// Exemplars are the exemplars of each bucket,
+	// with nil entries for buckets without one.
+	Exemplars []*exemplar.Exemplar
+}
+
+// Snapshot returns a copy of the histogram's current state.
+// Concurrent observations may be partially reflected in the snapshot.
+func (h *Histogram) Snapshot() Snapshot {
+	s := Snapshot{
+		Bounds:       h.Bounds,
+		BucketCounts: make([]uint64, len(h.counts)),
+		Exemplars:    make([]*exemplar.Exemplar, len(h.counts)),
+	}
+	for i := range h.counts {
+		n := h.counts[i].Load()
+		s.BucketCounts[i] = n
+		s.Count += n
+		s.Exemplars[i] = h.Exemplars.Load(i)
+	}
+	s.Sum = math.Float64frombits(h.sumBits.Load())
+	return s
+}
@@ -0,0 +1,59 @@
+package explicithist
+
+import (
+	"reflect"
+	"testing"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/exemplar"
+)
+
+func TestHistogram(t *testing.T) {
+	h := New([]float64{0.001, 0.01, 0.1})
+	for _, v := range []float64{0.0005, 0.001, 0.002, 0.05, 5} {
+		h.Observe(v)
+	}
+
+	s := h.Snapshot()
+	if want := []uint64{2, 1, 1, 1}; !reflect.DeepEqual(s.BucketCounts, want) {
+		t.Fatalf("got bucket counts %v, want %v", s.BucketCounts, want)
+	} else if s.Count != 5 {
+		t.Fatalf("got count %d, want 5", s.Count)
+	} else if s.Sum < 5.0534 || s.Sum > 5.0536 {
+		t.Fatalf("got sum %v, want 5.0535", s.Sum)
+	}
+}
+
+func TestValidateBounds(t *testing.T) {
+	tests := []struct {
+		bounds []float64
+		ok     bool
+	}{
+		{[]float64{1, 2, 3}, true},
+		{[]float64{-1, 0}, true},
+		{nil, false},
+		{[]float64{1, 1}, false},
+		{[]float64{2, 1}, false},
+	}
+	for _, test := range tests {
+		if err := ValidateBounds(test.bounds); (err == nil) != test.ok {
+			t.Errorf("ValidateBounds(%v): got err %v, want ok=%v", test.bounds, err, test.ok)
+		}
+	}
+}
+
+func TestHistogram_Exemplars(t *testing.T) {
+	h := New([]float64{1, 2})
+	h.Observe(0.5)
+	h.ObserveWithExemplar(1.5, exemplar.Exemplar{Value: 1.5, TraceID: model.TraceID{1}})
+	h.ObserveWithExemplar(1.8, exemplar.Exemplar{Value: 1.8, TraceID: model.TraceID{2}})
+
+	s := h.Snapshot()
+	if s.Exemplars[0] != nil || s.Exemplars[2] != nil {
+		t.Fatalf("got exemplars %+v, want only the middle bucket", s.Exemplars)
+	} else if e := s.Exemplars[1]; e == nil || e.Value != 1.8 || e.TraceID != (model.TraceID{2}) {
+		t.Fatalf("got exemplar %+v, want the most recent one", e)
+	} else if s.Count != 3 {
+		t.Fatalf("got count %d, want 3", s.Count)
+	}
+}
@@ -0,0 +1,181 @@
+// Package faults injects faults into API calls and infrastructure operations,
+// as configured by the runtime config, for testing how applications
+// handle latency and failures.
+package faults
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/beta/errs"
+)
+
+// Op describes an operation faults may be injected into.
+type Op struct {
+	Target   config.FaultTarget
+	Service  string // the service, for the endpoint and call targets
+	Endpoint string // the endpoint, for the endpoint and call targets
+	Resource string // the infrastructure resource, for other targets
+}
+
+// Fault is the fault injected into an operation.
+type Fault struct {
+	// Err is the error to fail the operation with, or nil.
+	Err error
+
+	// Drop is whether to drop the response of the operation.
+	Drop bool
+}
+
+// Injector injects faults into operations.
+// A nil *Injector injects no faults.
+type Injector struct {
+	rt    *reqtrack.RequestTracker
+	rules []rule
+	rand  func() float64
+}
+
+type rule struct {
+	target      config.FaultTarget
+	service     string
+	endpoint    string
+	resource    string
+	probability float64
+	latency     time.Duration
+	code        errs.ErrCode // OK if no error is injected
+	drop        bool
+}
+
+// New returns an injector for the given configuration,
+// or nil if there's no fault injection configured.
+func New(cfg *config.FaultInjection, rt *reqtrack.RequestTracker) (*Injector, error) {
+	if cfg == nil || len(cfg.Rules) == 0 {
+		return nil, nil
+	}
+
+	inj := &Injector{rt: rt, rand: rand.Float64}
+	for i, r := range cfg.Rules {
+		switch r.Target {
+		case config.FaultEndpoint, config.FaultCall, config.FaultPubSubPublish,
+			config.FaultSQLDBQuery, config.FaultCache, config.FaultObjects:
+		default:
+			return nil, fmt.Errorf("rule %d: unknown target %q", i, r.Target)
+		}
+		if r.Drop && !r.Target.CanDrop() {
+			return nil, fmt.Errorf("rule %d: drop is not supported for target %q", i, r.Target)
+		}
+		probability := 1.0
+		if r.Probability != nil {
+			probability = *r.Probability
+		}
+		if probability < 0 || probability > 1 {
+			return nil, fmt.Errorf("rule %d: probability must be between 0 and 1", i)
+		}
+
+		code := errs.OK
+		if r.ErrorCode != "" {
+			var ok bool
+			if code, ok = parseCode(r.ErrorCode); !ok || code == errs.OK {
+				return nil, fmt.Errorf("rule %d: invalid error code %q", i, r.ErrorCode)
+			}
+		}
+
+		inj.rules = append(inj.rules, rule{
+			target:      r.Target,
+			service:     r.Service,
+			endpoint:    r.Endpoint,
+			resource:    r.Resource,
+			probability: probability,
+			latency:     r.Latency,
+			code:        code,
+			drop:        r.Drop,
+		})
+	}
+	return inj, nil
+}
+
+func parseCode(s string) (errs.ErrCode, bool) {
+	for c := errs.OK; c <= errs.Unauthenticated; c++ {
+		if c.String() == s {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+func (r *rule) matches(op Op) bool {
+	return r.target == op.Target &&
+		(r.service == "" || r.service == op.Service) &&
+		(r.endpoint == "" || r.endpoint == op.Endpoint) &&
+		(r.resource == "" || r.resource == op.Resource)
+}
+
+// Inject injects the faults of the rules matching op. It sleeps for the
+// injected latency, if any, and returns the fault to apply to the operation.
+//
+// Injected faults are recorded in the trace of the current request.
+func (inj *Injector) Inject(ctx context.Context, op Op) Fault {
+	if inj == nil {
+		return Fault{}
+	}
+
+	var fault Fault
+	for i := range inj.rules {
+		r := &inj.rules[i]
+		if !r.matches(op) || inj.rand() >= r.probability {
+			continue
+		}
+
+		inj.record(op, r)
+		if r.latency > 0 {
+			select {
+			case <-time.After(r.latency):
+			case <-ctx.Done():
+				return Fault{Err: ctx.Err()}
+			}
+		}
+		if r.code != errs.OK && fault.Err == nil {
+			fault.Err = errs.B().Code(r.code).Meta("fault_injected", true).Msg("injected fault").Err()
+		}
+		fault.Drop = fault.Drop || r.drop
+	}
+	return fault
+}
+
+// DroppedError returns the error an operation fails with
+// when its response is dropped.
+func DroppedError() error {
+	return errs.B().Code(errs.Unavailable).Meta("fault_injected", true).Msg("injected fault: response dropped").Err()
+}
+
+// record records the injection of the rule's fault into op in the trace.
+func (inj *Injector) record(op Op, r *rule) {
+	curr := inj.rt.Current()
+	if curr.Req == nil || curr.Trace == nil {
+		return
+	}
+
+	var code string
+	if r.code != errs.OK {
+		code = r.code.String()
+	}
+	curr.Trace.FaultInjected(trace2.FaultInjectedParams{
+		EventParams: trace2.EventParams{
+			TraceID: curr.Req.TraceID,
+			SpanID:  curr.Req.SpanID,
+			Goid:    curr.Goctr,
+		},
+		Target:    string(op.Target),
+		Service:   op.Service,
+		Endpoint:  op.Endpoint,
+		Resource:  op.Resource,
+		Latency:   r.latency,
+		ErrorCode: code,
+		Drop:      r.drop,
+	})
+}
@@ -0,0 +1,177 @@
+package faults
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/traceprovider/mock_trace"
+	"encore.dev/beta/errs"
+)
+
+func newInjector(t *testing.T, rules ...*config.FaultRule) *Injector {
+	t.Helper()
+	inj, err := New(&config.FaultInjection{Rules: rules}, reqtrack.New(zerolog.Logger{}, nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return inj
+}
+
+func TestNew_Invalid(t *testing.T) {
+	tests := []*config.FaultRule{
+		{Target: "database"},
+		{Target: config.FaultCall, Probability: ptr(1.5)},
+		{Target: config.FaultCall, ErrorCode: "broken"},
+		{Target: config.FaultCall, ErrorCode: "ok"},
+		// Only responses of endpoints and calls can be dropped.
+		{Target: config.FaultPubSubPublish, Drop: true},
+		{Target: config.FaultSQLDBQuery, Drop: true},
+	}
+	for _, rule := range tests {
+		if _, err := New(&config.FaultInjection{Rules: []*config.FaultRule{rule}}, nil); err == nil {
+			t.Errorf("%+v: expected an error", rule)
+		}
+	}
+
+	if inj, err := New(nil, nil); inj != nil || err != nil {
+		t.Errorf("got %v, %v for no config, want nil, nil", inj, err)
+	}
+}
+
+func TestInject(t *testing.T) {
+	inj := newInjector(t,
+		&config.FaultRule{Target: config.FaultEndpoint, Service: "orders", ErrorCode: "unavailable"},
+		&config.FaultRule{Target: config.FaultEndpoint, Service: "orders", Endpoint: "Place", ErrorCode: "internal", Drop: true},
+		&config.FaultRule{Target: config.FaultCall, Endpoint: "Get", Drop: true},
+		&config.FaultRule{Target: config.FaultPubSubPublish, Resource: "order-placed", ErrorCode: "aborted"},
+		&config.FaultRule{Target: config.FaultSQLDBQuery, Resource: "orders", ErrorCode: "unavailable"},
+		&config.FaultRule{Target: config.FaultCache, ErrorCode: "deadline_exceeded"},
+		&config.FaultRule{Target: config.FaultObjects, Resource: "invoices", ErrorCode: "internal"},
+	)
+	ctx := context.Background()
+
+	tests := []struct {
+		op   Op
+		code errs.ErrCode
+		drop bool
+	}{
+		{Op{Target: config.FaultEndpoint, Service: "orders", Endpoint: "List"}, errs.Unavailable, false},
+		// All matching rules apply, with the first error taking precedence.
+		{Op{Target: config.FaultEndpoint, Service: "orders", Endpoint: "Place"}, errs.Unavailable, true},
+		{Op{Target: config.FaultEndpoint, Service: "users", Endpoint: "Place"}, errs.OK, false},
+		{Op{Target: config.FaultCall, Service: "users", Endpoint: "Get"}, errs.OK, true},
+		{Op{Target: config.FaultCall, Service: "orders", Endpoint: "List"}, errs.OK, false},
+		{Op{Target: config.FaultPubSubPublish, Resource: "order-placed"}, errs.Aborted, false},
+		{Op{Target: config.FaultPubSubPublish, Resource: "user-signup"}, errs.OK, false},
+		{Op{Target: config.FaultSQLDBQuery, Resource: "orders"}, errs.Unavailable, false},
+		{Op{Target: config.FaultSQLDBQuery, Resource: "users"}, errs.OK, false},
+		{Op{Target: config.FaultCache, Resource: "sessions"}, errs.DeadlineExceeded, false},
+		{Op{Target: config.FaultObjects, Resource: "invoices"}, errs.Internal, false},
+	}
+	for _, test := range tests {
+		fault := inj.Inject(ctx, test.op)
+		if got := errs.Code(fault.Err); got != test.code {
+			t.Errorf("%+v: got code %v, want %v", test.op, got, test.code)
+		}
+		if fault.Drop != test.drop {
+			t.Errorf("%+v: got drop %v, want %v", test.op, fault.Drop, test.drop)
+		}
+		if fault.Err != nil && errs.Meta(fault.Err)["fault_injected"] != true {
+			t.Errorf("%+v: injected error is not marked as injected", test.op)
+		}
+	}
+}
+
+func TestInject_Probability(t *testing.T) {
+	inj := newInjector(t, &config.FaultRule{Target: config.FaultCall, Probability: ptr(0.25), ErrorCode: "unavailable"})
+	op := Op{Target: config.FaultCall, Service: "orders", Endpoint: "List"}
+
+	for _, test := range []struct {
+		roll   float64
+		inject bool
+	}{{0, true}, {0.2, true}, {0.25, false}, {0.9, false}} {
+		inj.rand = func() float64 { return test.roll }
+		if got := inj.Inject(context.Background(), op).Err != nil; got != test.inject {
+			t.Errorf("roll %v: got injected %v, want %v", test.roll, got, test.inject)
+		}
+	}
+}
+
+func TestInject_ZeroProbability(t *testing.T) {
+	inj := newInjector(t, &config.FaultRule{Target: config.FaultCall, Probability: ptr(0.0), ErrorCode: "unavailable"})
+	inj.rand = func() float64 { return 0 }
+	op := Op{Target: config.FaultCall, Service: "orders", Endpoint: "List"}
+	if fault := inj.Inject(context.Background(), op); fault.Err != nil {
+		t.Errorf("got err %v, want no fault", fault.Err)
+	}
+}
+
+func TestInject_Latency(t *testing.T) {
+	inj := newInjector(t, &config.FaultRule{Target: config.FaultCall, Latency: 50 * time.Millisecond})
+	op := Op{Target: config.FaultCall, Service: "orders", Endpoint: "List"}
+
+	start := time.Now()
+	if fault := inj.Inject(context.Background(), op); fault.Err != nil || fault.Drop {
+		t.Fatalf("got fault %+v, want only latency", fault)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("got latency %v, want at least 50ms", elapsed)
+	}
+
+	// The latency is cut short if the operation is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if fault := inj.Inject(ctx, op); fault.Err != context.Canceled {
+		t.Errorf("got err %v, want context.Canceled", fault.Err)
+	}
+}
+
+func TestInject_Trace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	traceMock := mock_trace.NewMockLogger(ctrl)
+	traceMock.EXPECT().WaitAndClear().Return(nil, true).AnyTimes()
+	rt := reqtrack.New(zerolog.Nop(), nil, mock_trace.NewMockFactory(traceMock))
+	inj, err := New(&config.FaultInjection{Rules: []*config.FaultRule{
+		{Target: config.FaultSQLDBQuery, Resource: "orders", Latency: time.Millisecond, ErrorCode: "unavailable"},
+	}}, rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &model.Request{TraceID: model.TraceID{1}, SpanID: model.SpanID{2}, Traced: true}
+	rt.BeginRequest(req)
+
+	var got trace2.FaultInjectedParams
+	traceMock.EXPECT().FaultInjected(gomock.Any()).Do(func(p trace2.FaultInjectedParams) { got = p })
+	inj.Inject(context.Background(), Op{Target: config.FaultSQLDBQuery, Resource: "orders"})
+
+	want := trace2.FaultInjectedParams{
+		EventParams: trace2.EventParams{TraceID: req.TraceID, SpanID: req.SpanID, Goid: got.Goid},
+		Target:      "sqldb_query",
+		Resource:    "orders",
+		Latency:     time.Millisecond,
+		ErrorCode:   "unavailable",
+	}
+	if got != want {
+		t.Errorf("got event %+v, want %+v", got, want)
+	}
+}
+
+func TestInject_Nil(t *testing.T) {
+	var inj *Injector
+	if fault := inj.Inject(context.Background(), Op{Target: config.FaultCall}); fault != (Fault{}) {
+		t.Errorf("got fault %+v, want none", fault)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
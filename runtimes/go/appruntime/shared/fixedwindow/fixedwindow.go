@@ -0,0 +1,88 @@
+// Package fixedwindow counts requests within fixed windows of time,
+// for rate limiting requests.
+package fixedwindow
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Store counts requests within fixed windows.
+type Store interface {
+	// Increment increments the number of requests counted for key
+	// in the window beginning at start, and returns the new count.
+	Increment(ctx context.Context, key string, start time.Time, window time.Duration) (int64, error)
+}
+
+// Memory is a Store that keeps counts in memory,
+// limiting requests to each instance of the application separately.
+type Memory struct {
+	mu        sync.Mutex
+	counters  map[string]*windowCounter
+	lastSweep time.Time
+}
+
+type windowCounter struct {
+	end   time.Time
+	count int64
+}
+
+// NewMemory returns a Store that keeps counts in memory.
+func NewMemory() *Memory {
+	return &Memory{counters: make(map[string]*windowCounter)}
+}
+
+func (s *Memory) Increment(_ context.Context, key string, start time.Time, window time.Duration) (int64, error) {
+	end := start.Add(window)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Periodically remove counters for windows that have ended.
+	if start.Sub(s.lastSweep) >= time.Minute {
+		for k, c := range s.counters {
+			if !c.end.After(start) {
+				delete(s.counters, k)
+			}
+		}
+		s.lastSweep = start
+	}
+
+	c := s.counters[key]
+	if c == nil || !c.end.Equal(end) {
+		c = &windowCounter{end: end}
+		s.counters[key] = c
+	}
+	c.count++
+	return c.count, nil
+}
+
+// Redis is a Store that keeps counts in Redis, sharing them between
+// all instances of the application using the same Redis server.
+// Counts expire once their window has ended.
+type Redis struct {
+	cl     *redis.Client
+	prefix string
+}
+
+// NewRedis returns a Store that keeps counts using the given Redis client,
+// in keys beginning with prefix.
+func NewRedis(cl *redis.Client, prefix string) *Redis {
+	return &Redis{cl: cl, prefix: prefix}
+}
+
+func (s *Redis) Increment(ctx context.Context, key string, start time.Time, window time.Duration) (int64, error) {
+	redisKey := s.prefix + key + "/" + strconv.FormatInt(start.UnixMilli(), 10)
+
+	pipe := s.cl.TxPipeline()
+	incr := pipe.Incr(ctx, redisKey)
+	pipe.PExpire(ctx, redisKey, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
@@ -0,0 +1,68 @@
+package fixedwindow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestMemory(t *testing.T) {
+	s := NewMemory()
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 1; i <= 3; i++ {
+		if got, err := s.Increment(ctx, "key", start, time.Minute); err != nil || got != int64(i) {
+			t.Fatalf("increment %d: got %d, %v, want %d", i, got, err, i)
+		}
+	}
+	if got, err := s.Increment(ctx, "other", start, time.Minute); err != nil || got != 1 {
+		t.Fatalf("got %d, %v, want 1 for another key", got, err)
+	}
+
+	// A new window starts from zero, and ended windows are removed.
+	next := start.Add(2 * time.Minute)
+	if got, err := s.Increment(ctx, "key", next, time.Minute); err != nil || got != 1 {
+		t.Fatalf("got %d, %v, want 1", got, err)
+	}
+	if n := len(s.counters); n != 1 {
+		t.Fatalf("got %d counters, want 1", n)
+	}
+}
+
+func TestRedis(t *testing.T) {
+	srv := miniredis.RunT(t)
+	cl := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	defer cl.Close()
+
+	// Two stores sharing the same Redis server share the counts.
+	a, b := NewRedis(cl, "prefix/"), NewRedis(cl, "prefix/")
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, s := range []Store{a, b, a} {
+		got, err := s.Increment(ctx, "key", start, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		} else if got != int64(i+1) {
+			t.Fatalf("increment %d: got %d, want %d", i, got, i+1)
+		}
+	}
+	if !srv.Exists("prefix/key/" + "1704067200000") {
+		t.Fatalf("got keys %v, want the count to be kept under the prefix", srv.Keys())
+	}
+
+	// A new window starts from zero.
+	if got, err := b.Increment(ctx, "key", start.Add(time.Minute), time.Minute); err != nil || got != 1 {
+		t.Fatalf("got %d, %v, want 1", got, err)
+	}
+
+	// Counters expire after the window.
+	srv.FastForward(2 * time.Minute)
+	if n := len(srv.Keys()); n != 0 {
+		t.Fatalf("got %d keys, want 0", n)
+	}
+}
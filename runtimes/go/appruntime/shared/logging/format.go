@@ -0,0 +1,160 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rs/zerolog"
+)
+
+// newWriter returns a writer that writes logs to out in the given format.
+// An empty format means JSON, or the console format if pretty is set.
+func newWriter(format string, pretty bool, out io.Writer) (io.Writer, error) {
+	if format == "" && pretty {
+		format = "console"
+	}
+
+	switch format {
+	case "", "json":
+		return out, nil
+	case "logfmt":
+		return &logfmtWriter{out: out}, nil
+	case "console":
+		return zerolog.NewConsoleWriter(func(w *zerolog.ConsoleWriter) {
+			w.Out = out
+		}), nil
+	default:
+		return out, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+// timeFieldFormat returns the zerolog time field format for the given
+// log time format, which is either a named format or a Go time layout.
+func timeFieldFormat(format string) string {
+	switch strings.ToLower(format) {
+	case "rfc3339":
+		return time.RFC3339
+	case "rfc3339nano":
+		return time.RFC3339Nano
+	case "unix":
+		return zerolog.TimeFormatUnix
+	case "unixms":
+		return zerolog.TimeFormatUnixMs
+	case "unixmicro":
+		return zerolog.TimeFormatUnixMicro
+	default:
+		return format
+	}
+}
+
+// logfmtWriter converts the JSON log events written
+// by zerolog to logfmt, such as:
+//
+//	timestamp=2024-01-02T15:04:05Z level=info message="user logged in" user_id=5
+type logfmtWriter struct {
+	out io.Writer
+}
+
+func (w *logfmtWriter) Write(p []byte) (int, error) {
+	line, err := jsonToLogfmt(p)
+	if err != nil {
+		// Write the event as-is rather than losing it.
+		return w.out.Write(p)
+	}
+	if _, err := w.out.Write(line); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// jsonToLogfmt converts a JSON object to a logfmt line. The timestamp,
+// level and message come first, followed by the other fields in order.
+func jsonToLogfmt(data []byte) ([]byte, error) {
+	type field struct {
+		key string
+		val json.RawMessage
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		return nil, fmt.Errorf("expected object, got %v", tok)
+	}
+
+	var fields []field
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var val json.RawMessage
+		if err := dec.Decode(&val); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field{key: tok.(string), val: val})
+	}
+
+	var buf bytes.Buffer
+	for _, first := range []string{zerolog.TimestampFieldName, zerolog.LevelFieldName, zerolog.MessageFieldName} {
+		for i, f := range fields {
+			if f.key == first {
+				appendLogfmtPair(&buf, f.key, f.val)
+				fields = append(fields[:i:i], fields[i+1:]...)
+				break
+			}
+		}
+	}
+	for _, f := range fields {
+		appendLogfmtPair(&buf, f.key, f.val)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func appendLogfmtPair(buf *bytes.Buffer, key string, val json.RawMessage) {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' {
+			return '_'
+		}
+		return r
+	}, key))
+	buf.WriteByte('=')
+
+	switch val[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(val, &s); err == nil {
+			appendLogfmtValue(buf, s)
+			return
+		}
+	case '{', '[':
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, val); err == nil {
+			appendLogfmtValue(buf, compact.String())
+			return
+		}
+	}
+	buf.Write(val)
+}
+
+// appendLogfmtValue appends s, quoted if necessary.
+func appendLogfmtValue(buf *bytes.Buffer, s string) {
+	needsQuote := s == "" || strings.IndexFunc(s, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r)
+	}) >= 0
+	if needsQuote {
+		buf.WriteString(strconv.Quote(s))
+	} else {
+		buf.WriteString(s)
+	}
+}
@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestLogfmtWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newWriter("logfmt", false, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := zerolog.New(w).With().Str("svc", "orders").Logger()
+	logger.Info().
+		Str("user", "alice smith").
+		Int("count", 3).
+		Bool("ok", true).
+		Err(errors.New(`bad "input"`)).
+		Interface("tags", []string{"a", "b"}).
+		Str("empty", "").
+		Msg("user logged in")
+
+	want := `level=info message="user logged in" svc=orders user="alice smith" count=3 ok=true error="bad \"input\"" tags="[\"a\",\"b\"]" empty=""` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestLogfmtWriter_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	w := &logfmtWriter{out: &buf}
+	if _, err := w.Write([]byte("not json\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "not json\n" {
+		t.Errorf("got %q, want the input as-is", got)
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	var out bytes.Buffer
+	tests := []struct {
+		format  string
+		pretty  bool
+		want    string
+		wantErr bool
+	}{
+		{format: "", want: "json"},
+		{format: "json", pretty: true, want: "json"},
+		{format: "", pretty: true, want: "console"},
+		{format: "console", want: "console"},
+		{format: "logfmt", pretty: true, want: "logfmt"},
+		{format: "xml", want: "json", wantErr: true},
+	}
+	for _, tt := range tests {
+		w, err := newWriter(tt.format, tt.pretty, &out)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("newWriter(%q): got err %v, want err %v", tt.format, err, tt.wantErr)
+		}
+		var got string
+		switch w.(type) {
+		case *bytes.Buffer:
+			got = "json"
+		case zerolog.ConsoleWriter:
+			got = "console"
+		case *logfmtWriter:
+			got = "logfmt"
+		}
+		if got != tt.want {
+			t.Errorf("newWriter(%q, %v): got %s writer, want %s", tt.format, tt.pretty, got, tt.want)
+		}
+	}
+}
+
+func TestTimeFieldFormat(t *testing.T) {
+	tests := map[string]string{
+		"rfc3339nano":      "2006-01-02T15:04:05.999999999Z07:00",
+		"UnixMs":           zerolog.TimeFormatUnixMs,
+		"2006-01-02 15:04": "2006-01-02 15:04",
+	}
+	for in, want := range tests {
+		if got := timeFieldFormat(in); got != want {
+			t.Errorf("timeFieldFormat(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
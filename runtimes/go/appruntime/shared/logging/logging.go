@@ -3,7 +3,6 @@
 package logging
 
 import (
-	"io"
 	"os"
 	"time"
 
@@ -17,12 +16,7 @@ import (
 var RootLogger = configure(appconf.Static, appconf.Runtime)
 
 func configure(static *config.Static, runtime *config.Runtime) zerolog.Logger {
-	var logOutput io.Writer = os.Stderr
-	if static.PrettyPrintLogs {
-		logOutput = zerolog.NewConsoleWriter(func(w *zerolog.ConsoleWriter) {
-			w.Out = logOutput
-		})
-	}
+	logOutput, formatErr := newWriter(runtime.LogFormat, static.PrettyPrintLogs, os.Stderr)
 
 	level := zerolog.TraceLevel
 	if runtime.LogConfig != "" {
@@ -31,8 +25,22 @@ func configure(static *config.Static, runtime *config.Runtime) zerolog.Logger {
 		}
 	}
 
+	// The default level of the per-service and per-package
+	// log levels takes precedence over the log config.
+	levels, levelsErr := config.ParseLogLevels(runtime.LogLevels)
+	if levels.Default != nil {
+		level = *levels.Default
+	}
+
 	reconfigureZerologFormat(runtime)
-	return zerolog.New(logOutput).Level(level).With().Timestamp().Logger()
+	logger := zerolog.New(logOutput).Level(level).With().Timestamp().Logger()
+	if levelsErr != nil {
+		logger.Error().Err(levelsErr).Msg("invalid log levels config, ignoring")
+	}
+	if formatErr != nil {
+		logger.Error().Err(formatErr).Msg("invalid log format config, using json")
+	}
+	return logger
 }
 
 func reconfigureZerologFormat(runtime *config.Runtime) {
@@ -46,4 +54,8 @@ func reconfigureZerologFormat(runtime *config.Runtime) {
 		zerolog.TimeFieldFormat = time.RFC3339Nano
 	default:
 	}
+
+	if runtime.LogTimeFormat != "" {
+		zerolog.TimeFieldFormat = timeFieldFormat(runtime.LogTimeFormat)
+	}
 }
@@ -9,14 +9,22 @@ import (
 	"sort"
 	"sync"
 	"sync/atomic"
+
+	"encore.dev/appruntime/shared/exemplar"
 )
 
 func New(bucketFactor float64) *Histogram {
 	return &Histogram{
-		Schema: pickSchema(bucketFactor),
+		Schema:    pickSchema(bucketFactor),
+		Exemplars: exemplar.NewReservoir(numExemplars),
 	}
 }
 
+// numExemplars is the number of exemplars kept per histogram.
+// Exemplars are stored by bucket key modulo this number, so the kept
+// exemplars tend to be spread across the range of observed values.
+const numExemplars = 8
+
 type Histogram struct {
 	// Order in this struct matters for the alignment required by atomic
 	// operations, see http://golang.org/pkg/sync/atomic/#pkg-note-BUG
@@ -30,12 +38,29 @@ type Histogram struct {
 
 	// PostiveVals and NegativeVals are the buckets for non-zero observations.
 	PositiveVals, NegativeVals sync.Map
+
+	// Exemplars holds recent exemplars of the histogram's observations.
+	Exemplars *exemplar.Reservoir
 }
 
 // Observe records an observation in the histogram.
 func (h *Histogram) Observe(v float64) {
+	h.observe(v)
+}
+
+// ObserveWithExemplar records an observation in the histogram
+// along with e as an exemplar of it.
+func (h *Histogram) ObserveWithExemplar(v float64, e exemplar.Exemplar) {
+	key := h.observe(v)
+	if v < 0 {
+		key = -key
+	}
+	h.Exemplars.Offer(key, e)
+}
+
+// observe records an observation and reports the key of its bucket.
+func (h *Histogram) observe(v float64) (key int) {
 	var (
-		key    int
 		schema = atomic.LoadInt32(&h.Schema)
 		isInf  bool
 	)
@@ -71,6 +96,7 @@ func (h *Histogram) Observe(v float64) {
 	default:
 		atomic.AddUint64(&h.NumZeroValues, 1)
 	}
+	return key
 }
 
 func (h *Histogram) reset() {
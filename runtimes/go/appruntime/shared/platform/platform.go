@@ -8,6 +8,8 @@ import (
 	"encoding/binary"
 	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"encore.dev/appruntime/exported/config"
@@ -16,13 +18,18 @@ import (
 
 func NewClient(static *config.Static, rt *config.Runtime) *Client {
 	exp := experiments.FromConfig(static, rt)
-	return &Client{static, rt, exp}
+	return &Client{static: static, runtime: rt, exp: exp}
 }
 
 type Client struct {
 	static  *config.Static
 	runtime *config.Runtime
 	exp     *experiments.Set
+
+	// negotiateTraceOnce guards negotiating traceVersion, the trace protocol
+	// version to send traces in, with the trace collector.
+	negotiateTraceOnce sync.Once
+	traceVersion       atomic.Int64
 }
 
 func (c *Client) addAuthKey(req *http.Request) {
@@ -0,0 +1,37 @@
+package platform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// SendProfile sends a profile in the pprof format to the profile collector at endpoint.
+func (c *Client) SendProfile(ctx context.Context, endpoint string, profile []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(profile))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Encore-App-ID", c.runtime.AppID)
+	req.Header.Set("X-Encore-Env-ID", c.runtime.EnvID)
+	req.Header.Set("X-Encore-Deploy-ID", c.runtime.DeployID)
+	req.Header.Set("X-Encore-App-Commit", c.static.AppCommit.AsRevisionString())
+	if len(c.runtime.AuthKeys) > 0 {
+		c.addAuthKey(req)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("http %s: %s", resp.Status, body)
+	}
+	return nil
+}
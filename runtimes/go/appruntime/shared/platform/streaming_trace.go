@@ -1,6 +1,7 @@
 package platform
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -12,32 +13,85 @@ import (
 	"encore.dev/appruntime/exported/trace2"
 )
 
+// maxTraceVersionHeader is the header trace collectors report
+// the newest trace protocol version they support in.
+const maxTraceVersionHeader = "X-Encore-Trace-Max-Version"
+
 func (c *Client) StreamTrace(log trace2.Logger) error {
-	if c.static.Testing {
-		// In testing we want to block the test until the trace is done.
-		return c.blockingTrace(log)
-	} else {
-		return c.streamingTrace(log)
+	// Downgrade the trace if the collector doesn't support the current version.
+	version := c.negotiatedTraceVersion()
+	return trace2.StreamDowngraded(log, version, func(log trace2.Logger) error {
+		if c.static.Testing {
+			// In testing we want to block the test until the trace is done.
+			return c.blockingTrace(log, version)
+		} else {
+			return c.streamingTrace(log, version)
+		}
+	})
+}
+
+// negotiatedTraceVersion returns the trace protocol version to send traces in.
+// The first time it's called it asks the trace collector for the versions it supports.
+// Collectors that don't report them are sent traces in trace2.CurrentVersion.
+func (c *Client) negotiatedTraceVersion() trace2.Version {
+	c.negotiateTraceOnce.Do(func() {
+		c.traceVersion.Store(int64(trace2.CurrentVersion))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodOptions, c.runtime.TraceEndpoint, nil)
+		if err != nil {
+			return
+		}
+		req.Header.Set("X-Encore-Trace-Version", strconv.Itoa(int(trace2.CurrentVersion)))
+		c.addAuthKey(req)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return
+		}
+		_ = resp.Body.Close()
+		c.updateTraceVersion(resp.Header)
+	})
+	return trace2.Version(c.traceVersion.Load())
+}
+
+// updateTraceVersion updates the version to send traces in from the
+// newest version the trace collector reports supporting, if any.
+func (c *Client) updateTraceVersion(h http.Header) {
+	max, err := strconv.Atoi(h.Get(maxTraceVersionHeader))
+	if err != nil {
+		return
+	}
+	// If the collector is too old, keep using the current version
+	// and let it reject the traces.
+	if v, err := trace2.NegotiateVersion(trace2.Version(max)); err == nil {
+		c.traceVersion.Store(int64(v))
 	}
 }
 
 // streamingTrace streams a trace to the platform.
-func (c *Client) streamingTrace(log trace2.Logger) error {
+func (c *Client) streamingTrace(log trace2.Logger, version trace2.Version) error {
 	// Wait a bit for the trace to start, so we can avoid the overhead
 	// of small chunk streaming if the trace is short.
 	done := log.WaitAtLeast(1 * time.Second)
 	var body io.Reader
 	if done {
-		data, _ := log.GetAndClear()
-		if len(data) == 0 {
+		data, allRead := log.GetAndClear()
+		if len(data) == 0 && allRead {
 			return nil
 		}
 
-		// Use a bytes.Reader so net/http knows the Content-Length.
-		body = bytes.NewReader(data)
+		if allRead {
+			// Use a bytes.Reader so net/http knows the Content-Length.
+			body = bytes.NewReader(data)
+		} else {
+			// The log has more data to read, such as events spilled to disk.
+			body = io.MultiReader(bytes.NewReader(data), log.Reader())
+		}
 	} else {
-		r := &traceLogReader{log: log}
-		if r.IsDoneAndEmpty() {
+		r := bufio.NewReader(log.Reader())
+		if _, err := r.Peek(1); err == io.EOF {
 			// We didn't get any trace data; don't bother streaming.
 			return nil
 		}
@@ -47,25 +101,28 @@ func (c *Client) streamingTrace(log trace2.Logger) error {
 	// Use a background context since the trace is streaming,
 	// and we don't know how long it will take to complete.
 	ctx := context.Background()
-	return c.sendTraceRequest(ctx, body)
+	return c.sendTraceRequest(ctx, version, log.Encoding(), body)
 }
 
 // blockingTrace waits for the trace to complete before sending it.
-func (c *Client) blockingTrace(log trace2.Logger) error {
+func (c *Client) blockingTrace(log trace2.Logger, version trace2.Version) error {
 	// Wait for the trace to complete
 	log.WaitUntilDone()
-	data, _ := log.GetAndClear()
-	if len(data) == 0 {
+	data, allRead := log.GetAndClear()
+	if len(data) == 0 && allRead {
 		return nil // optimization
 	}
-	body := bytes.NewReader(data)
+	var body io.Reader = bytes.NewReader(data)
+	if !allRead {
+		body = io.MultiReader(body, log.Reader())
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
-	return c.sendTraceRequest(ctx, body)
+	return c.sendTraceRequest(ctx, version, log.Encoding(), body)
 }
 
-func (c *Client) sendTraceRequest(ctx context.Context, body io.Reader) error {
+func (c *Client) sendTraceRequest(ctx context.Context, version trace2.Version, enc trace2.Encoding, body io.Reader) error {
 	req, err := http.NewRequestWithContext(ctx, "POST", c.runtime.TraceEndpoint, body)
 	if err != nil {
 		return err
@@ -80,52 +137,28 @@ func (c *Client) sendTraceRequest(ctx context.Context, body io.Reader) error {
 	req.Header.Set("X-Encore-Env-ID", c.runtime.EnvID)
 	req.Header.Set("X-Encore-Deploy-ID", c.runtime.DeployID)
 	req.Header.Set("X-Encore-App-Commit", c.static.AppCommit.AsRevisionString())
-	req.Header.Set("X-Encore-Trace-Version", strconv.Itoa(int(trace2.CurrentVersion)))
+	req.Header.Set("X-Encore-Trace-Version", strconv.Itoa(int(version)))
 	req.Header.Set("X-Encore-Trace-TimeAnchor", string(ta))
+	if enc != trace2.EncodingNone {
+		req.Header.Set("X-Encore-Trace-Encoding", string(enc))
+	}
 	c.addAuthKey(req)
 
+	// Streamed traces are sent for as long as the trace is being recorded,
+	// so only the sending of complete traces reflects the flush latency.
+	if _, complete := body.(*bytes.Reader); complete {
+		defer func(start time.Time) { trace2.RecordFlush(time.Since(start)) }(time.Now())
+	}
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
+	c.updateTraceVersion(resp.Header)
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("http %s: %s", resp.Status, body)
 	}
 	return nil
 }
-
-// traceLogReader implements io.Reader by reading from a trace log.
-type traceLogReader struct {
-	log  trace2.Logger
-	data []byte // current buffer
-	done bool
-}
-
-func (r *traceLogReader) Read(b []byte) (int, error) {
-	r.readMoreIfNeeded()
-	// Post-condition: we have data, or we're done (or both).
-
-	if len(r.data) > 0 {
-		n := copy(b, r.data)
-		r.data = r.data[n:]
-		return n, nil
-	} else {
-		return 0, io.EOF
-	}
-}
-
-// IsDoneAndEmpty blocks until we have some trace data, and then
-// reports whether the trace is done and empty.
-func (r *traceLogReader) IsDoneAndEmpty() bool {
-	r.readMoreIfNeeded()
-	return len(r.data) == 0 && r.done
-}
-
-func (r *traceLogReader) readMoreIfNeeded() {
-	for len(r.data) == 0 && !r.done {
-		r.data, r.done = r.log.WaitAndClear()
-	}
-	// Post-condition: we have data, or we're done (or both).
-}
@@ -0,0 +1,285 @@
+// Package quantile implements summaries that estimate quantiles
+// of the observations made over a sliding time window.
+//
+// The quantiles are estimated using the targeted quantiles algorithm described in
+// "Effective Computation of Biased Quantiles over Data Streams" by Cormode et al,
+// which bounds the memory usage while guaranteeing the error for each objective.
+package quantile
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// New returns a summary estimating the given objectives, which map each
+// quantile to its allowed absolute error (so 0.99: 0.001 estimates the
+// 99th percentile within the 98.9th and 99.1th percentile).
+//
+// The quantiles are computed over the observations made in the last
+// maxAge, which is divided into ageBuckets windows. When a window expires
+// its observations are discarded all at once, so the observations
+// included in the estimates vary between maxAge*(1-1/ageBuckets) and maxAge old.
+//
+// The arguments must be valid according to Validate.
+func New(objectives map[float64]float64, maxAge time.Duration, ageBuckets int) *Summary {
+	targets := make([]target, 0, len(objectives))
+	for q, e := range objectives {
+		targets = append(targets, target{quantile: q, epsilon: e})
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].quantile < targets[j].quantile })
+
+	s := &Summary{
+		targets:    targets,
+		streams:    make([]*stream, ageBuckets),
+		bucketSize: maxAge / time.Duration(ageBuckets),
+		now:        time.Now,
+	}
+	for i := range s.streams {
+		s.streams[i] = newStream(targets)
+	}
+	s.expires = s.now().Add(s.bucketSize)
+	return s
+}
+
+// Validate reports whether the arguments are valid for New.
+func Validate(objectives map[float64]float64, maxAge time.Duration, ageBuckets int) error {
+	if len(objectives) == 0 {
+		return errors.New("no objectives given")
+	}
+	for q, e := range objectives {
+		if !(q >= 0 && q <= 1) {
+			return fmt.Errorf("quantile %v must be between 0 and 1", q)
+		} else if !(e > 0 && e < 1) {
+			return fmt.Errorf("error %v of quantile %v must be between 0 and 1", e, q)
+		}
+	}
+	if ageBuckets <= 0 {
+		return fmt.Errorf("number of age buckets must be positive, got %d", ageBuckets)
+	} else if maxAge < time.Duration(ageBuckets) {
+		return fmt.Errorf("max age %v is too short for %d age buckets", maxAge, ageBuckets)
+	}
+	return nil
+}
+
+type target struct {
+	quantile float64
+	epsilon  float64
+}
+
+// Summary estimates quantiles over a sliding window of observations,
+// along with the total count and sum of all observations.
+type Summary struct {
+	targets    []target
+	bucketSize time.Duration
+	now        func() time.Time
+
+	mu sync.Mutex
+	// streams holds one stream per age bucket. Observations are added to all
+	// of them, and each is reset when it expires. The head stream is the
+	// oldest and is the one that's queried.
+	streams []*stream
+	head    int
+	expires time.Time // when the head stream expires
+	count   uint64
+	sum     float64
+}
+
+// Observe records an observation in the summary.
+func (s *Summary) Observe(v float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rotate()
+	for _, st := range s.streams {
+		st.insert(v)
+	}
+	s.count++
+	s.sum += v
+}
+
+// rotate resets the streams that have expired.
+// It must be called with s.mu held.
+func (s *Summary) rotate() {
+	now := s.now()
+	for i := 0; i < len(s.streams) && !now.Before(s.expires); i++ {
+		s.streams[s.head].reset()
+		s.head = (s.head + 1) % len(s.streams)
+		s.expires = s.expires.Add(s.bucketSize)
+	}
+	if !now.Before(s.expires) {
+		// All streams have expired; start over from now.
+		s.expires = now.Add(s.bucketSize)
+	}
+}
+
+// Quantile is the estimated value of a quantile.
+type Quantile struct {
+	Quantile float64
+	// Value is the estimated value, or NaN if there
+	// are no observations in the current window.
+	Value float64
+}
+
+// Snapshot is a point-in-time copy of a summary.
+type Snapshot struct {
+	// Quantiles are the estimated quantiles, ordered by quantile.
+	Quantiles []Quantile
+	// Count and Sum are the number and sum of all observations,
+	// including the ones outside the current window.
+	Count uint64
+	Sum   float64
+}
+
+// Snapshot returns the current quantile estimates of the summary.
+func (s *Summary) Snapshot() Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rotate()
+	st := s.streams[s.head]
+	snap := Snapshot{
+		Quantiles: make([]Quantile, len(s.targets)),
+		Count:     s.count,
+		Sum:       s.sum,
+	}
+	for i, t := range s.targets {
+		snap.Quantiles[i] = Quantile{Quantile: t.quantile, Value: st.query(t.quantile)}
+	}
+	return snap
+}
+
+// bufferSize is the number of observations to buffer
+// before merging them into a stream's samples.
+const bufferSize = 500
+
+// stream is a targeted quantile stream.
+type stream struct {
+	targets []target
+	n       float64  // number of observations
+	samples []sample // ordered by value
+	buf     []float64
+}
+
+type sample struct {
+	value float64
+	// width is the difference between the lowest possible rank
+	// of this sample and the previous one.
+	width float64
+	// delta is the difference between the highest and lowest
+	// possible rank of this sample.
+	delta float64
+}
+
+func newStream(targets []target) *stream {
+	return &stream{targets: targets, buf: make([]float64, 0, bufferSize)}
+}
+
+func (s *stream) reset() {
+	s.n = 0
+	s.samples = s.samples[:0]
+	s.buf = s.buf[:0]
+}
+
+func (s *stream) insert(v float64) {
+	s.buf = append(s.buf, v)
+	if len(s.buf) == cap(s.buf) {
+		s.flush()
+	}
+}
+
+// invariant returns the maximum allowed error at rank r.
+func (s *stream) invariant(r float64) float64 {
+	m := math.MaxFloat64
+	for _, t := range s.targets {
+		var f float64
+		if t.quantile*s.n <= r {
+			f = 2 * t.epsilon * r / t.quantile
+		} else {
+			f = 2 * t.epsilon * (s.n - r) / (1 - t.quantile)
+		}
+		m = min(m, f)
+	}
+	return m
+}
+
+// flush merges the buffered observations into the samples.
+func (s *stream) flush() {
+	if len(s.buf) == 0 {
+		return
+	}
+	sort.Float64s(s.buf)
+
+	merged := make([]sample, 0, len(s.samples)+len(s.buf))
+	var r float64
+	i := 0
+	for _, v := range s.buf {
+		for i < len(s.samples) && s.samples[i].value <= v {
+			r += s.samples[i].width
+			merged = append(merged, s.samples[i])
+			i++
+		}
+		var delta float64
+		if i > 0 && i < len(s.samples) {
+			delta = max(math.Floor(s.invariant(r))-1, 0)
+		}
+		merged = append(merged, sample{value: v, width: 1, delta: delta})
+		s.n++
+		r++
+	}
+	merged = append(merged, s.samples[i:]...)
+	s.samples = merged
+	s.buf = s.buf[:0]
+	s.compress()
+}
+
+// compress merges adjacent samples where the error bounds allow it.
+func (s *stream) compress() {
+	if len(s.samples) < 2 {
+		return
+	}
+	x := s.samples[len(s.samples)-1]
+	xi := len(s.samples) - 1
+	r := s.n - 1 - x.width
+
+	for i := len(s.samples) - 2; i >= 0; i-- {
+		c := s.samples[i]
+		if c.width+x.width+x.delta <= s.invariant(r) {
+			x.width += c.width
+			s.samples[xi] = x
+			s.samples = append(s.samples[:i], s.samples[i+1:]...)
+			xi--
+		} else {
+			x = c
+			xi = i
+		}
+		r -= c.width
+	}
+}
+
+// query returns the estimated value of quantile q,
+// or NaN if there are no observations.
+func (s *stream) query(q float64) float64 {
+	s.flush()
+	if len(s.samples) == 0 {
+		return math.NaN()
+	}
+
+	// Return the last sample whose maximum rank is within the allowed
+	// error of the target rank.
+	rank := math.Ceil(q * s.n)
+	t := rank + s.invariant(rank)/2
+	p := s.samples[0]
+	var r float64
+	for _, c := range s.samples[1:] {
+		r += p.width
+		if r+c.width+c.delta > t {
+			return p.value
+		}
+		p = c
+	}
+	return p.value
+}
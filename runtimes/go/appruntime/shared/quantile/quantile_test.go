@@ -0,0 +1,105 @@
+package quantile
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSummary(t *testing.T) {
+	objectives := map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
+	s := New(objectives, time.Minute, 5)
+
+	const n = 10000
+	rng := rand.New(rand.NewSource(1))
+	for _, i := range rng.Perm(n) {
+		s.Observe(float64(i + 1))
+	}
+
+	snap := s.Snapshot()
+	if snap.Count != n || snap.Sum != n*(n+1)/2 {
+		t.Fatalf("got count %d and sum %v", snap.Count, snap.Sum)
+	}
+	if len(snap.Quantiles) != 3 {
+		t.Fatalf("got quantiles %+v", snap.Quantiles)
+	}
+	for _, q := range snap.Quantiles {
+		// Since the observations are the ranks themselves,
+		// the value can be compared with the allowed rank error.
+		want, eps := q.Quantile*n, objectives[q.Quantile]*n
+		if math.Abs(q.Value-want) > eps {
+			t.Errorf("quantile %v: got %v, want %v±%v", q.Quantile, q.Value, want, eps)
+		}
+	}
+	if got := len(s.streams[s.head].samples); got >= n/10 {
+		t.Errorf("got %d samples, want the stream to be compressed", got)
+	}
+}
+
+func TestSummary_Window(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := New(map[float64]float64{0.5: 0.01}, 3*time.Second, 3)
+	s.now = func() time.Time { return now }
+	s.expires = now.Add(s.bucketSize)
+
+	median := func() float64 {
+		t.Helper()
+		return s.Snapshot().Quantiles[0].Value
+	}
+
+	if got := median(); !math.IsNaN(got) {
+		t.Fatalf("got median %v without observations, want NaN", got)
+	}
+
+	s.Observe(1)
+	now = now.Add(time.Second)
+	s.Observe(2)
+	s.Observe(2)
+	if got := median(); got != 2 {
+		t.Fatalf("got median %v, want 2", got)
+	}
+
+	// After three seconds the first observation has expired.
+	now = now.Add(2 * time.Second)
+	s.Observe(3)
+	s.Observe(3)
+	s.Observe(3)
+	if got := median(); got != 3 {
+		t.Fatalf("got median %v, want 3", got)
+	}
+
+	// After a long pause all observations have expired,
+	// but the count and sum are kept.
+	now = now.Add(time.Hour)
+	if snap := s.Snapshot(); !math.IsNaN(snap.Quantiles[0].Value) || snap.Count != 6 || snap.Sum != 14 {
+		t.Fatalf("got snapshot %+v", snap)
+	}
+	s.Observe(5)
+	if got := median(); got != 5 {
+		t.Fatalf("got median %v, want 5", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		objectives map[float64]float64
+		maxAge     time.Duration
+		ageBuckets int
+		wantErr    bool
+	}{
+		{map[float64]float64{0.5: 0.05}, time.Minute, 5, false},
+		{nil, time.Minute, 5, true},
+		{map[float64]float64{1.5: 0.05}, time.Minute, 5, true},
+		{map[float64]float64{0.5: 0}, time.Minute, 5, true},
+		{map[float64]float64{0.5: 0.05}, time.Minute, 0, true},
+		{map[float64]float64{0.5: 0.05}, 0, 5, true},
+	}
+	for _, test := range tests {
+		err := Validate(test.objectives, test.maxAge, test.ageBuckets)
+		if (err != nil) != test.wantErr {
+			t.Errorf("Validate(%v, %v, %d) = %v, want error: %v",
+				test.objectives, test.maxAge, test.ageBuckets, err, test.wantErr)
+		}
+	}
+}
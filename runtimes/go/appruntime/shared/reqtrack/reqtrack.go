@@ -1,6 +1,9 @@
 package reqtrack
 
 import (
+	"context"
+	"runtime/pprof"
+
 	"github.com/rs/zerolog"
 
 	"encore.dev/appruntime/exported/model"
@@ -45,7 +48,24 @@ func (t *RequestTracker) BeginRequest(req *model.Request) {
 		copyReqInfoFromParent(req, prev)
 		t.clearReq()
 	}
+	if req.Values == nil {
+		req.Values = &model.RequestValues{}
+	}
+	if req.Baggage == nil {
+		req.Baggage = &model.Baggage{}
+	}
 	t.beginReq(req, req.Traced)
+	if req.Traced {
+		setProfileLabels(req)
+	}
+}
+
+// setProfileLabels labels the current goroutine, and the goroutines it starts,
+// with the trace and span of req. The samples of CPU profiles are labeled
+// with them, so profiles can be narrowed down to a trace.
+func setProfileLabels(req *model.Request) {
+	labels := pprof.Labels("trace_id", req.TraceID.String(), "span_id", req.SpanID.String())
+	pprof.SetGoroutineLabels(pprof.WithLabels(context.Background(), labels))
 }
 
 // copyReqInfoFromParent copies over relevant request from the parent request.
@@ -79,6 +99,10 @@ func copyReqInfoFromParent(next, prev *model.Request) {
 	if next.ExtCorrelationID == "" {
 		next.ExtCorrelationID = prev.ExtCorrelationID
 	}
+	if next.Baggage == nil {
+		// Copy the baggage so changes made by the child are not seen by the parent.
+		next.Baggage = prev.Baggage.Clone()
+	}
 	if next.Test == nil {
 		next.Test = prev.Test
 	}
@@ -86,6 +110,9 @@ func copyReqInfoFromParent(next, prev *model.Request) {
 }
 
 func (t *RequestTracker) FinishRequest(blockOnTraceSend bool) {
+	if req, _, _, _ := t.currentReq(); req != nil && req.Traced {
+		pprof.SetGoroutineLabels(context.Background())
+	}
 	t.finishReq(blockOnTraceSend)
 }
 
@@ -3,22 +3,179 @@
 package reqtrack
 
 import (
+	"strconv"
+	"strings"
+
+	"encore.dev/appruntime/exported/trace2"
 	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/encoreenv"
 	"encore.dev/appruntime/shared/logging"
 	"encore.dev/appruntime/shared/platform"
+	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/traceprovider"
+	"encore.dev/appruntime/shared/traceprovider/tracecapture"
 )
 
 var Singleton *RequestTracker
 
 func init() {
 	var traceFactory traceprovider.Factory
-	tracingEnabled := appconf.Runtime.TraceEndpoint != "" && len(appconf.Runtime.AuthKeys) > 0
+
+	// Stream traces to each configured destination. The OpenTelemetry
+	// and file exporters parse the trace data so it's never compressed.
+	fanout := trace2.NewFanout()
+	tracingEnabled := false
+	if appconf.Runtime.TraceEndpoint != "" && len(appconf.Runtime.AuthKeys) > 0 {
+		enc, err := trace2.ParseEncoding(appconf.Runtime.TraceEncoding)
+		if err != nil {
+			logging.RootLogger.Warn().Err(err).Msg("sending traces uncompressed")
+		}
+		fanout.Add(platform.Singleton, enc)
+		tracingEnabled = true
+	}
+	if exp := newOTLPExporter(); exp != nil {
+		fanout.Add(exp, trace2.EncodingNone)
+		tracingEnabled = true
+	}
+	if sink := newFileSink(); sink != nil {
+		fanout.Add(sink, trace2.EncodingNone)
+		tracingEnabled = true
+	}
+
 	if tracingEnabled {
-		traceFactory = &traceprovider.DefaultFactory{
-			SampleRate: appconf.Runtime.TraceSamplingRate,
+		factory := &traceprovider.DefaultFactory{
+			SampleRate:       appconf.Runtime.TraceSamplingRate,
+			Encoding:         fanout.Encoding(),
+			BufferLimits:     traceBufferLimits(),
+			SuppressedEvents: suppressedTraceEvents(),
+		}
+		if s := appconf.Runtime.TraceTailSampling; s != nil && !appconf.Static.Testing {
+			factory.TailSampling = &trace2.TailSampling{
+				SampleRate:       s.SampleRate,
+				LatencyThreshold: s.LatencyThreshold,
+			}
+		}
+		traceFactory = factory
+
+		// Capture the trace events in tests, for et.Trace.
+		if appconf.Static.Testing {
+			traceFactory = tracecapture.NewFactory(traceFactory)
 		}
 	}
 
-	Singleton = New(logging.RootLogger, platform.Singleton, traceFactory)
+	Singleton = New(logging.RootLogger, fanout, traceFactory)
+}
+
+// traceBufferLimits returns the configured trace buffer limits,
+// with the ENCORE_TRACE_BUFFER_* environment variables taking precedence.
+func traceBufferLimits() trace2.BufferLimits {
+	var limits trace2.BufferLimits
+	if cfg := appconf.Runtime.TraceBuffer; cfg != nil {
+		limits = trace2.BufferLimits{
+			InitialSize:  cfg.InitialSize,
+			MaxSize:      cfg.MaxSize,
+			DropPolicy:   trace2.DropPolicy(cfg.DropPolicy),
+			SpillToDisk:  cfg.SpillToDisk,
+			SpillDir:     cfg.SpillDir,
+			MaxSpillSize: cfg.MaxSpillSize,
+		}
+	}
+
+	for env, dst := range map[string]*int{
+		"ENCORE_TRACE_BUFFER_INITIAL_SIZE":   &limits.InitialSize,
+		"ENCORE_TRACE_BUFFER_MAX_SIZE":       &limits.MaxSize,
+		"ENCORE_TRACE_BUFFER_MAX_SPILL_SIZE": &limits.MaxSpillSize,
+	} {
+		if val := encoreenv.Get(env); val != "" {
+			if n, err := strconv.Atoi(val); err != nil || n < 0 {
+				logging.RootLogger.Warn().Str("value", val).Msgf("ignoring invalid %s (expected a size in bytes)", env)
+			} else {
+				*dst = n
+			}
+		}
+	}
+	if val := encoreenv.Get("ENCORE_TRACE_BUFFER_DROP_POLICY"); val != "" {
+		limits.DropPolicy = trace2.DropPolicy(val)
+	}
+	if val := encoreenv.Get("ENCORE_TRACE_BUFFER_SPILL_DIR"); val != "" {
+		limits.SpillToDisk, limits.SpillDir = true, val
+	}
+	if limits.SpillToDisk && limits.MaxSize == 0 {
+		logging.RootLogger.Warn().Msg("trace buffer spilling to disk requires a max size, ignoring")
+	}
+
+	switch limits.DropPolicy {
+	case "", trace2.DropNew, trace2.DropOldest:
+	default:
+		logging.RootLogger.Warn().Str("drop_policy", string(limits.DropPolicy)).Msg("unknown trace buffer drop policy, dropping new events")
+		limits.DropPolicy = trace2.DropNew
+	}
+	return limits
+}
+
+// suppressedTraceEvents returns the event types to leave out of traces,
+// with the ENCORE_TRACE_SUPPRESS_EVENTS environment variable, a comma-separated
+// list of event categories, taking precedence over the runtime config.
+func suppressedTraceEvents() trace2.EventTypeSet {
+	var categories []string
+	if cfg := appconf.Runtime.TraceFilter; cfg != nil {
+		categories = cfg.SuppressEvents
+	}
+	if val := encoreenv.Get("ENCORE_TRACE_SUPPRESS_EVENTS"); val != "" {
+		categories = strings.Split(val, ",")
+		for i, c := range categories {
+			categories[i] = strings.TrimSpace(c)
+		}
+	}
+
+	set, err := trace2.ParseEventCategories(categories)
+	if err != nil {
+		logging.RootLogger.Warn().Err(err).Msg("not suppressing any trace events")
+	}
+	return set
+}
+
+// newOTLPExporter returns the exporter for exporting traces to
+// an OpenTelemetry collector, or nil if trace export isn't configured.
+func newOTLPExporter() *trace2.OTLPExporter {
+	cfg := appconf.Runtime.TraceExport
+	if cfg == nil || cfg.OTLP == nil || appconf.Static.Testing {
+		return nil
+	}
+
+	exp, err := trace2.NewOTLPExporter(cfg.OTLP, logging.RootLogger)
+	if err != nil {
+		logging.RootLogger.Err(err).Msg("unable to initialize trace exporter")
+		return nil
+	}
+	shutdown.Singleton.RegisterShutdownHandler(func(p *shutdown.Process) error {
+		// Wait for all services and all tasks to shut down before we stop
+		// exporting traces, so the requests they complete are exported.
+		<-p.ServicesShutdownCompleted.Done()
+		<-p.OutstandingTasks.Done()
+		return exp.Shutdown(p.ForceShutdown)
+	})
+	go exp.BeginFlushing()
+	return exp
+}
+
+// newFileSink returns the sink for writing traces to
+// a local file, or nil if it isn't configured.
+func newFileSink() *trace2.FileSink {
+	cfg := appconf.Runtime.TraceExport
+	if cfg == nil || cfg.File == nil || appconf.Static.Testing {
+		return nil
+	}
+
+	sink, err := trace2.NewFileSink(cfg.File.Path)
+	if err != nil {
+		logging.RootLogger.Err(err).Msg("unable to initialize trace file")
+		return nil
+	}
+	shutdown.Singleton.RegisterShutdownHandler(func(p *shutdown.Process) error {
+		<-p.ServicesShutdownCompleted.Done()
+		<-p.OutstandingTasks.Done()
+		return sink.Close()
+	})
+	return sink
 }
@@ -0,0 +1,211 @@
+package testsupport
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+// UseHTTPCassette makes the current test and its sub-tests replay the outbound HTTP calls
+// recorded in the cassette file at path, or record them to it if the file doesn't exist.
+// The recorded calls are written to the file when the test ends.
+func (mgr *Manager) UseHTTPCassette(path string) error {
+	c := &httpCassette{path: path}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		c.recording = true
+		c.interactions = []*httpInteraction{}
+		mgr.AddEndCallback(func(t *testing.T) {
+			if err := c.save(); err != nil {
+				t.Errorf("encore: unable to save http cassette: %v", err)
+			}
+		})
+	case err != nil:
+		return fmt.Errorf("read http cassette: %w", err)
+	default:
+		if err := json.Unmarshal(data, &c.interactions); err != nil {
+			return fmt.Errorf("parse http cassette %s: %w", path, err)
+		}
+		c.replayed = make([]bool, len(c.interactions))
+	}
+
+	cfg := mgr.currentConfig()
+	cfg.Mu.Lock()
+	defer cfg.Mu.Unlock()
+	cfg.HTTPCassette = c
+	return nil
+}
+
+// HTTPTransport returns a transport that records or replays the HTTP calls made
+// through it when the current test uses a cassette, and otherwise makes them
+// using base. If base is nil, http.DefaultTransport is used.
+func (mgr *Manager) HTTPTransport(base http.RoundTripper) http.RoundTripper {
+	return &cassetteTransport{mgr: mgr, base: base}
+}
+
+type cassetteTransport struct {
+	mgr  *Manager
+	base http.RoundTripper
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	c, _ := walkConfig(t.mgr.currentConfig(), func(cfg *TestConfig) (*httpCassette, bool) {
+		c, ok := cfg.HTTPCassette.(*httpCassette)
+		return c, ok
+	})
+	if c != nil {
+		return c.roundTrip(req, base)
+	}
+	return base.RoundTrip(req)
+}
+
+// httpCassette holds the HTTP calls recorded to or replayed from a cassette file.
+type httpCassette struct {
+	path      string
+	recording bool
+
+	mu           sync.Mutex
+	interactions []*httpInteraction
+	replayed     []bool // whether each interaction has been replayed
+}
+
+// httpInteraction is a recorded HTTP call.
+type httpInteraction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	recordedBody
+}
+
+type recordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	recordedBody
+}
+
+// recordedBody is a request or response body. Bodies that are
+// valid UTF-8 are stored as-is to keep the cassettes readable.
+type recordedBody struct {
+	Body       string `json:"body,omitempty"`
+	BodyBase64 string `json:"body_base64,omitempty"`
+}
+
+func newRecordedBody(data []byte) recordedBody {
+	if utf8.Valid(data) {
+		return recordedBody{Body: string(data)}
+	}
+	return recordedBody{BodyBase64: base64.StdEncoding.EncodeToString(data)}
+}
+
+func (b recordedBody) bytes() []byte {
+	if b.BodyBase64 != "" {
+		data, _ := base64.StdEncoding.DecodeString(b.BodyBase64)
+		return data
+	}
+	return []byte(b.Body)
+}
+
+func (c *httpCassette) roundTrip(req *http.Request, base http.RoundTripper) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	recReq := recordedRequest{Method: req.Method, URL: req.URL.String(), recordedBody: newRecordedBody(body)}
+
+	if !c.recording {
+		return c.replay(req, recReq)
+	}
+
+	// Make the call with a copy of the request, as the body has been consumed.
+	out := req.Clone(req.Context())
+	if req.Body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := base.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, &httpInteraction{
+		Request:  recReq,
+		Response: recordedResponse{Status: resp.StatusCode, Header: resp.Header.Clone(), recordedBody: newRecordedBody(respBody)},
+	})
+	c.mu.Unlock()
+	return resp, nil
+}
+
+// replay returns the response of the first recorded call to the same method and
+// URL with the same body that hasn't been replayed yet.
+func (c *httpCassette) replay(req *http.Request, recReq recordedRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, in := range c.interactions {
+		if c.replayed[i] || in.Request.Method != recReq.Method || in.Request.URL != recReq.URL ||
+			!bytes.Equal(in.Request.bytes(), recReq.bytes()) {
+			continue
+		}
+		c.replayed[i] = true
+
+		body := in.Response.bytes()
+		return &http.Response{
+			Status:        strconv.Itoa(in.Response.Status) + " " + http.StatusText(in.Response.Status),
+			StatusCode:    in.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded response for %s %s in http cassette %s (delete the file to record it again)",
+		recReq.Method, recReq.URL, c.path)
+}
+
+// save writes the recorded calls to the cassette file.
+func (c *httpCassette) save() error {
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.interactions, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,75 @@
+package testsupport
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestHTTPCassette(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Call", r.Method)
+		_, _ = w.Write([]byte(r.URL.Path + ":" + string(body)))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	mgr := NewManager(&config.Static{}, reqtrack.New(zerolog.Nop(), nil, nil), zerolog.Nop())
+	client := &http.Client{Transport: mgr.HTTPTransport(nil)}
+
+	do := func(method, url, body string) (string, error) {
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.Header.Get("X-Call") + " " + string(data), nil
+	}
+
+	// Record the calls.
+	if err := mgr.UseHTTPCassette(path); err != nil {
+		t.Fatal(err)
+	}
+	for _, body := range []string{"a", "b"} {
+		if got, err := do("POST", srv.URL+"/items", body); err != nil || got != "POST /items:"+body {
+			t.Fatalf("got %q, %v", got, err)
+		}
+	}
+	if err := mgr.rootTestConfig.HTTPCassette.(*httpCassette).save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replay them, matching on the body, without calling the server.
+	if err := mgr.UseHTTPCassette(path); err != nil {
+		t.Fatal(err)
+	}
+	for _, body := range []string{"b", "a"} {
+		if got, err := do("POST", srv.URL+"/items", body); err != nil || got != "POST /items:"+body {
+			t.Errorf("got %q, %v", got, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls to the server, want 2", calls)
+	}
+
+	// Each call is replayed once, and unknown calls fail.
+	if _, err := do("POST", srv.URL+"/items", "a"); err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("got err %v, want no recorded response", err)
+	}
+	if _, err := do("GET", srv.URL+"/other", ""); err == nil {
+		t.Error("got no error for unrecorded call")
+	}
+}
@@ -3,6 +3,7 @@
 package testsupport
 
 import (
+	"net/http"
 	"strings"
 	"testing"
 	_ "unsafe" // for go:linkname
@@ -13,10 +14,23 @@ import (
 	"encore.dev/appruntime/shared/appconf"
 	"encore.dev/appruntime/shared/logging"
 	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/types/uuid"
 )
 
 var Singleton = NewManager(appconf.Static, reqtrack.Singleton, logging.RootLogger)
 
+func init() {
+	// Route the calls made using the default HTTP client through
+	// the cassette transport, so tests can record and replay them.
+	if appconf.Static.Testing {
+		http.DefaultClient.Transport = Singleton.HTTPTransport(nil)
+
+		// Generate IDs from the seeded sequence of tests using et.SetIDSeed.
+		model.IDRand = Singleton.IDReader()
+		uuid.SetRandReader(Singleton.IDReader())
+	}
+}
+
 func isGeneratedWrapperTest(t *testing.T) bool {
 	// A test with an empty name is the generated wrapper test that Go adds around all the users tests.
 	// we don't want to treat this as a real test, so we ignore it.
@@ -2,6 +2,9 @@ package testsupport
 
 import (
 	"context"
+	"crypto/rand"
+	"hash/fnv"
+	"io"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -28,6 +31,9 @@ type Manager struct {
 	rootLogger     zerolog.Logger
 	rootTestConfig *TestConfig
 
+	timeMu        sync.Mutex
+	timeListeners []func(d time.Duration)
+
 	wd              string
 	testServiceOnce sync.Once
 	testService     string
@@ -106,6 +112,13 @@ func (mgr *Manager) StartTest(t *testing.T, fn func(*testing.T)) {
 		Logger: &logger,
 		SvcNum: svcNum,
 	}
+
+	// Sub-tests run with the auth information of their parent.
+	if parent != nil && parent.Test != nil {
+		req.Test.UserID = parent.Test.UserID
+		req.Test.AuthData = parent.Test.AuthData
+	}
+
 	mgr.rt.BeginRequest(req)
 	if curr := mgr.rt.Current(); curr.Trace != nil {
 		curr.Trace.TestSpanStart(req, curr.Goctr)
@@ -338,9 +351,143 @@ func (mgr *Manager) GetAPIMock(service string, api string) (model.ApiMock, bool)
 	})
 }
 
+// SetAuthHandlerMock sets a mock replacing the auth handler for the current test.
+// A nil mock removes the mock set for the current test.
+func (mgr *Manager) SetAuthHandlerMock(mock any) {
+	cfg := mgr.currentConfig()
+	cfg.Mu.Lock()
+	defer cfg.Mu.Unlock()
+	cfg.AuthHandlerMock = mock
+}
+
+// GetAuthHandlerMock returns the mock replacing the auth handler for the current test
+// or any parent tests - returning the lowest level mock available.
+func (mgr *Manager) GetAuthHandlerMock() (any, bool) {
+	return walkConfig(mgr.currentConfig(), func(cfg *TestConfig) (value any, found bool) {
+		return cfg.AuthHandlerMock, cfg.AuthHandlerMock != nil
+	})
+}
+
 func (mgr *Manager) AddEndCallback(fn func(t *testing.T)) {
 	cfg := mgr.currentConfig()
 	cfg.Mu.Lock()
 	defer cfg.Mu.Unlock()
 	cfg.EndCallbacks = append(cfg.EndCallbacks, fn)
 }
+
+// Now returns the current time as seen by the current test,
+// which may have been changed using SetTime or AdvanceTime.
+func (mgr *Manager) Now() time.Time {
+	if clock := mgr.clock(); clock != nil {
+		return clock.Now()
+	}
+	return time.Now()
+}
+
+// SetTime freezes the time as seen by the current test and its sub-tests at t.
+func (mgr *Manager) SetTime(t time.Time) {
+	mgr.setClock(&model.TestClock{Frozen: true, Time: t})
+}
+
+// AdvanceTime moves the time as seen by the current test and its sub-tests forward by d.
+// If the time is frozen it stays frozen at the new time.
+func (mgr *Manager) AdvanceTime(d time.Duration) {
+	switch clock := mgr.clock(); {
+	case clock == nil:
+		mgr.setClock(&model.TestClock{Offset: d})
+	case clock.Frozen:
+		mgr.setClock(&model.TestClock{Frozen: true, Time: clock.Time.Add(d)})
+	default:
+		mgr.setClock(&model.TestClock{Offset: clock.Offset + d})
+	}
+}
+
+// OnTimeAdvanced registers a function to call when the time is moved forward
+// by a test, for parts of the runtime that keep track of time on their own.
+func (mgr *Manager) OnTimeAdvanced(fn func(d time.Duration)) {
+	mgr.timeMu.Lock()
+	defer mgr.timeMu.Unlock()
+	mgr.timeListeners = append(mgr.timeListeners, fn)
+}
+
+// SetIDSeed makes the IDs generated in the current test and its sub-tests,
+// such as trace ids and UUIDs, come from a sequence seeded with seed.
+func (mgr *Manager) SetIDSeed(seed uint64) {
+	cfg := mgr.currentConfig()
+	cfg.Mu.Lock()
+	defer cfg.Mu.Unlock()
+	cfg.IDs = model.NewTestIDs(seed)
+}
+
+// IDReader returns a reader of random bytes for generating IDs.
+// It reads from the seeded sequence of the current test if an ID seed
+// has been set, and from crypto/rand otherwise.
+func (mgr *Manager) IDReader() io.Reader {
+	return idReader{mgr}
+}
+
+type idReader struct {
+	mgr *Manager
+}
+
+func (r idReader) Read(p []byte) (int, error) {
+	cfg, name := r.mgr.rootTestConfig, ""
+	if req := r.mgr.rt.Current().Req; req != nil && req.Test != nil {
+		cfg, name = req.Test.Config, req.Test.Current.Name()
+	}
+	if ids, ok := testIDs(cfg, name); ok {
+		return ids.Read(p)
+	}
+	return rand.Read(p)
+}
+
+// testIDs returns the seeded sequence for the test with the given config and name, if any.
+//
+// Tests inherit the ID seed of their parent, but get a sequence of their own
+// derived from it and their name, so the IDs they generate don't depend on
+// how tests running in parallel are scheduled.
+func testIDs(cfg *TestConfig, name string) (*model.TestIDs, bool) {
+	ids, found := walkConfig(cfg, func(cfg *TestConfig) (*model.TestIDs, bool) {
+		return cfg.IDs, cfg.IDs != nil
+	})
+	if !found || cfg.Parent == nil {
+		return ids, found
+	}
+
+	cfg.Mu.Lock()
+	defer cfg.Mu.Unlock()
+	if cfg.IDs == nil {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(name))
+		cfg.IDs = model.NewTestIDs(ids.Seed ^ h.Sum64())
+	}
+	return cfg.IDs, true
+}
+
+// clock returns the clock of the current test, or nil if the time hasn't been changed.
+func (mgr *Manager) clock() *model.TestClock {
+	clock, _ := walkConfig(mgr.currentConfig(), func(cfg *TestConfig) (*model.TestClock, bool) {
+		return cfg.Clock, cfg.Clock != nil
+	})
+	return clock
+}
+
+// setClock sets the clock of the current test, and notifies
+// the listeners if the time moved forward.
+func (mgr *Manager) setClock(clock *model.TestClock) {
+	prev := mgr.Now()
+
+	cfg := mgr.currentConfig()
+	cfg.Mu.Lock()
+	cfg.Clock = clock
+	cfg.Mu.Unlock()
+
+	if d := clock.Now().Sub(prev); d > 0 {
+		mgr.timeMu.Lock()
+		listeners := mgr.timeListeners
+		mgr.timeMu.Unlock()
+		for _, fn := range listeners {
+			fn(d)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package testsupport
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestIDReader(t *testing.T) {
+	read := func(seed uint64) []byte {
+		mgr := NewManager(&config.Static{Testing: true}, reqtrack.New(zerolog.Nop(), nil, nil), zerolog.Nop())
+		mgr.SetIDSeed(seed)
+		b := make([]byte, 16)
+		if _, err := io.ReadFull(mgr.IDReader(), b); err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	if a, b := read(1), read(1); !bytes.Equal(a, b) {
+		t.Errorf("got %x and %x, want the same ids for the same seed", a, b)
+	}
+	if a, b := read(1), read(2); bytes.Equal(a, b) {
+		t.Errorf("got %x for different seeds", a)
+	}
+}
+
+func TestTestIDs(t *testing.T) {
+	root := newTestConfig(nil)
+	if _, ok := testIDs(root, ""); ok {
+		t.Fatal("got seeded ids without a seed")
+	}
+
+	mgr := NewManager(&config.Static{Testing: true}, reqtrack.New(zerolog.Nop(), nil, nil), zerolog.Nop())
+	mgr.SetIDSeed(42)
+	root = mgr.rootTestConfig
+
+	// Sub-tests get a sequence of their own, derived from the seed and their name.
+	a, _ := testIDs(newTestConfig(root), "TestA")
+	a2, _ := testIDs(newTestConfig(root), "TestA")
+	b, _ := testIDs(newTestConfig(root), "TestB")
+	if a == root.IDs || a.Seed != a2.Seed || a.Seed == b.Seed {
+		t.Errorf("got seeds %d, %d and %d", a.Seed, a2.Seed, b.Seed)
+	}
+
+	// The derived sequence is kept for the test.
+	cfg := newTestConfig(root)
+	if first, _ := testIDs(cfg, "TestA"); first != cfg.IDs {
+		t.Error("got a new sequence on each call")
+	}
+}
@@ -6,6 +6,7 @@ package mock_trace
 
 import (
 	context "context"
+	io "io"
 	http "net/http"
 	reflect "reflect"
 	time "time"
@@ -245,6 +246,44 @@ func (mr *MockLoggerMockRecorder) CacheCallStart(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheCallStart", reflect.TypeOf((*MockLogger)(nil).CacheCallStart), arg0)
 }
 
+// CustomEvent mocks base method.
+func (m *MockLogger) CustomEvent(arg0 trace2.CustomEventParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CustomEvent", arg0)
+}
+
+// CustomEvent indicates an expected call of CustomEvent.
+func (mr *MockLoggerMockRecorder) CustomEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CustomEvent", reflect.TypeOf((*MockLogger)(nil).CustomEvent), arg0)
+}
+
+// CustomSpanEnd mocks base method.
+func (m *MockLogger) CustomSpanEnd(arg0 trace2.CustomSpanEndParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CustomSpanEnd", arg0)
+}
+
+// CustomSpanEnd indicates an expected call of CustomSpanEnd.
+func (mr *MockLoggerMockRecorder) CustomSpanEnd(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CustomSpanEnd", reflect.TypeOf((*MockLogger)(nil).CustomSpanEnd), arg0)
+}
+
+// CustomSpanStart mocks base method.
+func (m *MockLogger) CustomSpanStart(arg0 trace2.CustomSpanStartParams) trace2.EventID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CustomSpanStart", arg0)
+	ret0, _ := ret[0].(trace2.EventID)
+	return ret0
+}
+
+// CustomSpanStart indicates an expected call of CustomSpanStart.
+func (mr *MockLoggerMockRecorder) CustomSpanStart(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CustomSpanStart", reflect.TypeOf((*MockLogger)(nil).CustomSpanStart), arg0)
+}
+
 // DBQueryEnd mocks base method.
 func (m *MockLogger) DBQueryEnd(arg0 trace2.EventParams, arg1 trace2.EventID, arg2 error) {
 	m.ctrl.T.Helper()
@@ -257,6 +296,18 @@ func (mr *MockLoggerMockRecorder) DBQueryEnd(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBQueryEnd", reflect.TypeOf((*MockLogger)(nil).DBQueryEnd), arg0, arg1, arg2)
 }
 
+// DBQueryPlan mocks base method.
+func (m *MockLogger) DBQueryPlan(arg0 trace2.DBQueryPlanParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DBQueryPlan", arg0)
+}
+
+// DBQueryPlan indicates an expected call of DBQueryPlan.
+func (mr *MockLoggerMockRecorder) DBQueryPlan(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBQueryPlan", reflect.TypeOf((*MockLogger)(nil).DBQueryPlan), arg0)
+}
+
 // DBQueryStart mocks base method.
 func (m *MockLogger) DBQueryStart(p trace2.DBQueryStartParams) trace2.EventID {
 	m.ctrl.T.Helper()
@@ -297,6 +348,20 @@ func (mr *MockLoggerMockRecorder) DBTransactionStart(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBTransactionStart", reflect.TypeOf((*MockLogger)(nil).DBTransactionStart), arg0, arg1)
 }
 
+// Encoding mocks base method.
+func (m *MockLogger) Encoding() trace2.Encoding {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encoding")
+	ret0, _ := ret[0].(trace2.Encoding)
+	return ret0
+}
+
+// Encoding indicates an expected call of Encoding.
+func (mr *MockLoggerMockRecorder) Encoding() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encoding", reflect.TypeOf((*MockLogger)(nil).Encoding))
+}
+
 // GetAndClear mocks base method.
 func (m *MockLogger) GetAndClear() ([]byte, bool) {
 	m.ctrl.T.Helper()
@@ -312,6 +377,42 @@ func (mr *MockLoggerMockRecorder) GetAndClear() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAndClear", reflect.TypeOf((*MockLogger)(nil).GetAndClear))
 }
 
+// FaultInjected mocks base method.
+func (m *MockLogger) FaultInjected(arg0 trace2.FaultInjectedParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "FaultInjected", arg0)
+}
+
+// FaultInjected indicates an expected call of FaultInjected.
+func (mr *MockLoggerMockRecorder) FaultInjected(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FaultInjected", reflect.TypeOf((*MockLogger)(nil).FaultInjected), arg0)
+}
+
+// SecretRead mocks base method.
+func (m *MockLogger) SecretRead(arg0 trace2.SecretReadParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SecretRead", arg0)
+}
+
+// SecretRead indicates an expected call of SecretRead.
+func (mr *MockLoggerMockRecorder) SecretRead(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretRead", reflect.TypeOf((*MockLogger)(nil).SecretRead), arg0)
+}
+
+// GoroutineSnapshot mocks base method.
+func (m *MockLogger) GoroutineSnapshot(arg0 trace2.GoroutineSnapshotParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GoroutineSnapshot", arg0)
+}
+
+// GoroutineSnapshot indicates an expected call of GoroutineSnapshot.
+func (mr *MockLoggerMockRecorder) GoroutineSnapshot(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GoroutineSnapshot", reflect.TypeOf((*MockLogger)(nil).GoroutineSnapshot), arg0)
+}
+
 // HTTPBeginRoundTrip mocks base method.
 func (m *MockLogger) HTTPBeginRoundTrip(httpReq *http.Request, req *model.Request, goid uint32) (context.Context, error) {
 	m.ctrl.T.Helper()
@@ -339,6 +440,30 @@ func (mr *MockLoggerMockRecorder) HTTPCompleteRoundTrip(req, resp, goid, err int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HTTPCompleteRoundTrip", reflect.TypeOf((*MockLogger)(nil).HTTPCompleteRoundTrip), req, resp, goid, err)
 }
 
+// JobSpanEnd mocks base method.
+func (m *MockLogger) JobSpanEnd(params trace2.JobSpanEndParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "JobSpanEnd", params)
+}
+
+// JobSpanEnd indicates an expected call of JobSpanEnd.
+func (mr *MockLoggerMockRecorder) JobSpanEnd(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JobSpanEnd", reflect.TypeOf((*MockLogger)(nil).JobSpanEnd), params)
+}
+
+// JobSpanStart mocks base method.
+func (m *MockLogger) JobSpanStart(req *model.Request, goid uint32) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "JobSpanStart", req, goid)
+}
+
+// JobSpanStart indicates an expected call of JobSpanStart.
+func (mr *MockLoggerMockRecorder) JobSpanStart(req, goid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JobSpanStart", reflect.TypeOf((*MockLogger)(nil).JobSpanStart), req, goid)
+}
+
 // LogMessage mocks base method.
 func (m *MockLogger) LogMessage(arg0 trace2.LogMessageParams) {
 	m.ctrl.T.Helper()
@@ -439,6 +564,20 @@ func (mr *MockLoggerMockRecorder) RPCCallStart(call, goid interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPCCallStart", reflect.TypeOf((*MockLogger)(nil).RPCCallStart), call, goid)
 }
 
+// Reader mocks base method.
+func (m *MockLogger) Reader() io.ReadCloser {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reader")
+	ret0, _ := ret[0].(io.ReadCloser)
+	return ret0
+}
+
+// Reader indicates an expected call of Reader.
+func (mr *MockLoggerMockRecorder) Reader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reader", reflect.TypeOf((*MockLogger)(nil).Reader))
+}
+
 // RequestSpanEnd mocks base method.
 func (m *MockLogger) RequestSpanEnd(params trace2.RequestSpanEndParams) {
 	m.ctrl.T.Helper()
@@ -489,6 +628,18 @@ func (mr *MockLoggerMockRecorder) ServiceInitStart(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceInitStart", reflect.TypeOf((*MockLogger)(nil).ServiceInitStart), arg0)
 }
 
+// SpanLink mocks base method.
+func (m *MockLogger) SpanLink(arg0 trace2.SpanLinkParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SpanLink", arg0)
+}
+
+// SpanLink indicates an expected call of SpanLink.
+func (mr *MockLoggerMockRecorder) SpanLink(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpanLink", reflect.TypeOf((*MockLogger)(nil).SpanLink), arg0)
+}
+
 // TestSpanEnd mocks base method.
 func (m *MockLogger) TestSpanEnd(params trace2.TestSpanEndParams) {
 	m.ctrl.T.Helper()
@@ -553,3 +704,41 @@ func (mr *MockLoggerMockRecorder) WaitUntilDone() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilDone", reflect.TypeOf((*MockLogger)(nil).WaitUntilDone))
 }
+
+// WebSocketClose mocks base method.
+func (m *MockLogger) WebSocketClose(arg0 trace2.WebSocketCloseParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WebSocketClose", arg0)
+}
+
+// WebSocketClose indicates an expected call of WebSocketClose.
+func (mr *MockLoggerMockRecorder) WebSocketClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebSocketClose", reflect.TypeOf((*MockLogger)(nil).WebSocketClose), arg0)
+}
+
+// WebSocketConnect mocks base method.
+func (m *MockLogger) WebSocketConnect(arg0 trace2.WebSocketConnectParams) trace2.EventID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebSocketConnect", arg0)
+	ret0, _ := ret[0].(trace2.EventID)
+	return ret0
+}
+
+// WebSocketConnect indicates an expected call of WebSocketConnect.
+func (mr *MockLoggerMockRecorder) WebSocketConnect(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebSocketConnect", reflect.TypeOf((*MockLogger)(nil).WebSocketConnect), arg0)
+}
+
+// WebSocketMessage mocks base method.
+func (m *MockLogger) WebSocketMessage(arg0 trace2.WebSocketMessageParams) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WebSocketMessage", arg0)
+}
+
+// WebSocketMessage indicates an expected call of WebSocketMessage.
+func (mr *MockLoggerMockRecorder) WebSocketMessage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebSocketMessage", reflect.TypeOf((*MockLogger)(nil).WebSocketMessage), arg0)
+}
@@ -0,0 +1,436 @@
+// Package tracecapture captures trace events in structured form,
+// so tests can make assertions on the traces they produce.
+package tracecapture
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/traceprovider"
+)
+
+// NewFactory returns a trace factory creating loggers that capture the events
+// logged to them, in addition to logging them to the loggers created by f.
+func NewFactory(f traceprovider.Factory) traceprovider.Factory {
+	return &factory{f}
+}
+
+type factory struct {
+	traceprovider.Factory
+}
+
+func (f *factory) NewLogger() trace2.Logger {
+	return NewLogger(f.Factory.NewLogger())
+}
+
+// SpanKind is the kind of operation a span represents.
+type SpanKind string
+
+const (
+	RequestSpan       SpanKind = "request"
+	AuthSpan          SpanKind = "auth"
+	PubsubMessageSpan SpanKind = "pubsub_message"
+	JobSpan           SpanKind = "job"
+	TestSpan          SpanKind = "test"
+)
+
+// EventKind is the kind of a trace event.
+type EventKind string
+
+const (
+	RPCCallEvent       EventKind = "rpc_call"
+	DBQueryEvent       EventKind = "db_query"
+	PubsubPublishEvent EventKind = "pubsub_publish"
+	CacheCallEvent     EventKind = "cache_call"
+	LogMessageEvent    EventKind = "log_message"
+	CustomSpanEvent    EventKind = "custom_span"
+	CustomEvent        EventKind = "custom_event"
+)
+
+// Span is a captured trace span.
+type Span struct {
+	Kind SpanKind
+	ID   string
+
+	// Parent is the span's parent span, or nil for the root span.
+	Parent *Span
+	// Children are the spans started from within this span, in the order they were started.
+	Children []*Span
+	// Events are the events logged within this span, in the order they were logged.
+	Events []*Event
+
+	// Service and Endpoint are the endpoint handling the request,
+	// for request and auth spans, and the subscribing service for
+	// Pub/Sub message spans. For job spans, Service is the service
+	// that enqueued the job, if any.
+	Service  string
+	Endpoint string
+
+	// Topic, Subscription and MessageID describe the message
+	// being processed, for Pub/Sub message spans.
+	Topic        string
+	Subscription string
+	MessageID    string
+
+	// Queue and JobID describe the job being processed, for job spans.
+	Queue string
+	JobID string
+
+	// Test is the name of the test, for test spans.
+	Test string
+
+	// Request and Response are the JSON-encoded request and response payloads, if any.
+	// For Pub/Sub message spans, Request is the message,
+	// and for job spans it is the job payload.
+	Request  json.RawMessage
+	Response json.RawMessage
+
+	// Done reports whether the span has ended,
+	// in which case Err and Duration are set.
+	Done     bool
+	Err      error
+	Duration time.Duration
+
+	parentID model.SpanID
+	seq      uint64
+}
+
+// Event is a captured trace event.
+type Event struct {
+	Kind EventKind
+
+	// Span is the span the event was logged in.
+	Span *Span
+
+	// Service and Endpoint are the endpoint being called, for RPC call events.
+	Service  string
+	Endpoint string
+
+	// Query is the query being executed, for database query events.
+	Query string
+
+	// Topic, Message and MessageID describe the message
+	// being published, for Pub/Sub publish events.
+	Topic     string
+	Message   json.RawMessage
+	MessageID string
+
+	// Operation and Keys describe the cache operation, for cache call events.
+	Operation string
+	Keys      []string
+
+	// Level, Msg and Fields describe the message being logged, for log message events.
+	// Fields are also set for custom spans and events.
+	Level  string
+	Msg    string
+	Fields []trace2.LogField
+
+	// Name is the name of the custom span or event, for custom span and custom events.
+	Name string
+
+	// Done reports whether the operation has completed, in which case
+	// Err is set. Log message and custom events are always done.
+	Done bool
+	Err  error
+
+	spanID model.SpanID
+	seq    uint64
+}
+
+// Spans returns the span and its descendants, depth-first.
+func (s *Span) Spans() []*Span {
+	spans := []*Span{s}
+	for _, c := range s.Children {
+		spans = append(spans, c.Spans()...)
+	}
+	return spans
+}
+
+// AllEvents returns the events of the given kind logged within the span
+// and its descendants, in the order they were logged.
+// If kind is empty, events of all kinds are returned.
+func (s *Span) AllEvents(kind EventKind) []*Event {
+	var events []*Event
+	for _, sp := range s.Spans() {
+		for _, ev := range sp.Events {
+			if kind == "" || ev.Kind == kind {
+				events = append(events, ev)
+			}
+		}
+	}
+	slices.SortFunc(events, func(a, b *Event) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+	return events
+}
+
+// Logger is a trace logger capturing the events logged to it.
+type Logger struct {
+	trace2.Logger
+
+	mu     sync.Mutex
+	seq    uint64
+	spans  map[model.SpanID]*Span
+	events map[trace2.EventID]*Event
+	order  []*Event
+}
+
+// NewLogger returns a logger capturing the events logged to it,
+// in addition to logging them to log.
+func NewLogger(log trace2.Logger) *Logger {
+	return &Logger{
+		Logger: log,
+		spans:  make(map[model.SpanID]*Span),
+		events: make(map[trace2.EventID]*Event),
+	}
+}
+
+// Trace returns a snapshot of the span with the given id
+// and its descendants, or nil if no such span has been captured.
+func (l *Logger) Trace(root model.SpanID) *Span {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	copies := make(map[model.SpanID]*Span, len(l.spans))
+	for id, sp := range l.spans {
+		cp := *sp
+		cp.Parent, cp.Children, cp.Events = nil, nil, nil
+		copies[id] = &cp
+	}
+	for id, sp := range copies {
+		if id == root {
+			continue
+		}
+		if parent, ok := copies[sp.parentID]; ok {
+			sp.Parent = parent
+			parent.Children = append(parent.Children, sp)
+		}
+	}
+	for _, sp := range copies {
+		slices.SortFunc(sp.Children, func(a, b *Span) int {
+			return cmp.Compare(a.seq, b.seq)
+		})
+	}
+	for _, ev := range l.order {
+		if sp, ok := copies[ev.spanID]; ok {
+			cp := *ev
+			cp.Span = sp
+			sp.Events = append(sp.Events, &cp)
+		}
+	}
+	return copies[root]
+}
+
+func (l *Logger) startSpan(kind SpanKind, req *model.Request) {
+	sp := &Span{Kind: kind, ID: req.SpanID.String(), parentID: req.ParentSpanID}
+	if data := req.RPCData; data != nil && data.Desc != nil {
+		sp.Service, sp.Endpoint = data.Desc.Service, data.Desc.Endpoint
+		sp.Request = data.NonRawPayload
+	}
+	if msg := req.MsgData; msg != nil {
+		sp.Service, sp.Topic, sp.Subscription, sp.MessageID = msg.Service, msg.Topic, msg.Subscription, msg.MessageID
+		sp.Request = msg.Payload
+	}
+	if job := req.JobData; job != nil {
+		sp.Service, sp.Queue, sp.JobID = job.Service, job.Queue, job.JobID
+		sp.Request = job.Payload
+	}
+	if test := req.Test; test != nil && kind == TestSpan {
+		sp.Test = test.Current.Name()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.seq++
+	sp.seq = l.seq
+	l.spans[req.SpanID] = sp
+}
+
+func (l *Logger) endSpan(req *model.Request, resp *model.Response, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if sp, ok := l.spans[req.SpanID]; ok {
+		sp.Done, sp.Err = true, err
+		if resp != nil {
+			sp.Response, sp.Duration = resp.Payload, resp.Duration
+		} else {
+			sp.Duration = time.Since(req.Start)
+		}
+	}
+}
+
+func (l *Logger) addEvent(id trace2.EventID, spanID model.SpanID, ev *Event) {
+	ev.spanID = spanID
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.seq++
+	ev.seq = l.seq
+	l.order = append(l.order, ev)
+	if id != 0 {
+		l.events[id] = ev
+	}
+}
+
+func (l *Logger) endEvent(id trace2.EventID, update func(ev *Event)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if ev, ok := l.events[id]; ok {
+		ev.Done = true
+		update(ev)
+		delete(l.events, id)
+	}
+}
+
+func (l *Logger) RequestSpanStart(req *model.Request, goid uint32) {
+	l.Logger.RequestSpanStart(req, goid)
+	l.startSpan(RequestSpan, req)
+}
+
+func (l *Logger) RequestSpanEnd(p trace2.RequestSpanEndParams) {
+	l.Logger.RequestSpanEnd(p)
+	l.endSpan(p.Req, p.Resp, p.Resp.Err)
+}
+
+func (l *Logger) AuthSpanStart(req *model.Request, goid uint32) {
+	l.Logger.AuthSpanStart(req, goid)
+	l.startSpan(AuthSpan, req)
+}
+
+func (l *Logger) AuthSpanEnd(p trace2.AuthSpanEndParams) {
+	l.Logger.AuthSpanEnd(p)
+	l.endSpan(p.Req, p.Resp, p.Resp.Err)
+}
+
+func (l *Logger) PubsubMessageSpanStart(req *model.Request, goid uint32) {
+	l.Logger.PubsubMessageSpanStart(req, goid)
+	l.startSpan(PubsubMessageSpan, req)
+}
+
+func (l *Logger) PubsubMessageSpanEnd(p trace2.PubsubMessageSpanEndParams) {
+	l.Logger.PubsubMessageSpanEnd(p)
+	l.endSpan(p.Req, p.Resp, p.Resp.Err)
+}
+
+func (l *Logger) JobSpanStart(req *model.Request, goid uint32) {
+	l.Logger.JobSpanStart(req, goid)
+	l.startSpan(JobSpan, req)
+}
+
+func (l *Logger) JobSpanEnd(p trace2.JobSpanEndParams) {
+	l.Logger.JobSpanEnd(p)
+	l.endSpan(p.Req, p.Resp, p.Resp.Err)
+}
+
+func (l *Logger) TestSpanStart(req *model.Request, goid uint32) {
+	l.Logger.TestSpanStart(req, goid)
+	l.startSpan(TestSpan, req)
+}
+
+func (l *Logger) TestSpanEnd(p trace2.TestSpanEndParams) {
+	l.Logger.TestSpanEnd(p)
+	l.endSpan(p.Req, nil, nil)
+}
+
+func (l *Logger) RPCCallStart(call *model.APICall, goid uint32) trace2.EventID {
+	id := l.Logger.RPCCallStart(call, goid)
+	l.addEvent(id, call.Source.SpanID, &Event{
+		Kind:     RPCCallEvent,
+		Service:  call.TargetServiceName,
+		Endpoint: call.TargetEndpointName,
+	})
+	return id
+}
+
+func (l *Logger) RPCCallEnd(call *model.APICall, goid uint32, err error) {
+	l.Logger.RPCCallEnd(call, goid, err)
+	l.endEvent(call.StartEventID, func(ev *Event) { ev.Err = err })
+}
+
+func (l *Logger) DBQueryStart(p trace2.DBQueryStartParams) trace2.EventID {
+	id := l.Logger.DBQueryStart(p)
+	l.addEvent(id, p.SpanID, &Event{Kind: DBQueryEvent, Query: p.Query})
+	return id
+}
+
+func (l *Logger) DBQueryEnd(p trace2.EventParams, startID trace2.EventID, err error) {
+	l.Logger.DBQueryEnd(p, startID, err)
+	l.endEvent(startID, func(ev *Event) { ev.Err = err })
+}
+
+func (l *Logger) PubsubPublishStart(p trace2.PubsubPublishStartParams) trace2.EventID {
+	id := l.Logger.PubsubPublishStart(p)
+	l.addEvent(id, p.SpanID, &Event{Kind: PubsubPublishEvent, Topic: p.Topic, Message: p.Message})
+	return id
+}
+
+func (l *Logger) PubsubPublishEnd(p trace2.PubsubPublishEndParams) {
+	l.Logger.PubsubPublishEnd(p)
+	l.endEvent(p.StartID, func(ev *Event) { ev.MessageID, ev.Err = p.MessageID, p.Err })
+}
+
+func (l *Logger) CacheCallStart(p trace2.CacheCallStartParams) trace2.EventID {
+	id := l.Logger.CacheCallStart(p)
+	l.addEvent(id, p.SpanID, &Event{Kind: CacheCallEvent, Operation: p.Operation, Keys: slices.Clone(p.Keys)})
+	return id
+}
+
+func (l *Logger) CacheCallEnd(p trace2.CacheCallEndParams) {
+	l.Logger.CacheCallEnd(p)
+	l.endEvent(p.StartID, func(ev *Event) { ev.Err = p.Err })
+}
+
+func (l *Logger) LogMessage(p trace2.LogMessageParams) {
+	l.Logger.LogMessage(p)
+	l.addEvent(0, p.SpanID, &Event{
+		Kind:   LogMessageEvent,
+		Level:  logLevel(p.Level),
+		Msg:    p.Msg,
+		Fields: slices.Clone(p.Fields),
+		Done:   true,
+	})
+}
+
+func (l *Logger) CustomSpanStart(p trace2.CustomSpanStartParams) trace2.EventID {
+	id := l.Logger.CustomSpanStart(p)
+	l.addEvent(id, p.SpanID, &Event{Kind: CustomSpanEvent, Name: p.Name, Fields: slices.Clone(p.Fields)})
+	return id
+}
+
+func (l *Logger) CustomSpanEnd(p trace2.CustomSpanEndParams) {
+	l.Logger.CustomSpanEnd(p)
+	l.endEvent(p.StartID, func(ev *Event) {
+		ev.Err = p.Err
+		ev.Fields = append(ev.Fields, p.Fields...)
+	})
+}
+
+func (l *Logger) CustomEvent(p trace2.CustomEventParams) {
+	l.Logger.CustomEvent(p)
+	l.addEvent(0, p.SpanID, &Event{
+		Kind:   CustomEvent,
+		Name:   p.Name,
+		Fields: slices.Clone(p.Fields),
+		Done:   true,
+	})
+}
+
+func logLevel(level model.LogLevel) string {
+	switch level {
+	case model.LevelTrace:
+		return "trace"
+	case model.LevelDebug:
+		return "debug"
+	case model.LevelInfo:
+		return "info"
+	case model.LevelWarn:
+		return "warn"
+	default:
+		return "error"
+	}
+}
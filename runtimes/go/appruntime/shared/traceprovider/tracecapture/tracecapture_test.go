@@ -0,0 +1,102 @@
+package tracecapture
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+)
+
+func TestLogger(t *testing.T) {
+	log := NewLogger(trace2.NewLog())
+	traceID := model.TraceID{1}
+	testSpan, reqSpan, otherSpan := model.SpanID{1}, model.SpanID{2}, model.SpanID{3}
+
+	test := &model.Request{
+		Type:    model.Test,
+		TraceID: traceID,
+		SpanID:  testSpan,
+		Start:   time.Now(),
+		Test:    &model.TestData{Current: t},
+	}
+	log.TestSpanStart(test, 1)
+
+	req := &model.Request{
+		Type:         model.RPCCall,
+		TraceID:      traceID,
+		SpanID:       reqSpan,
+		ParentSpanID: testSpan,
+		RPCData: &model.RPCData{
+			Desc:          &model.RPCDesc{Service: "users", Endpoint: "Get"},
+			NonRawPayload: []byte(`{"ID":1}`),
+		},
+	}
+	call := &model.APICall{Source: test, TargetServiceName: "users", TargetEndpointName: "Get"}
+	call.StartEventID = log.RPCCallStart(call, 1)
+	log.RequestSpanStart(req, 1)
+	queryErr := errors.New("no rows")
+	queryID := log.DBQueryStart(trace2.DBQueryStartParams{
+		EventParams: trace2.EventParams{TraceID: traceID, SpanID: reqSpan},
+		Query:       "SELECT name FROM users WHERE id = $1",
+	})
+	log.DBQueryEnd(trace2.EventParams{TraceID: traceID, SpanID: reqSpan}, queryID, queryErr)
+	log.LogMessage(trace2.LogMessageParams{
+		EventParams: trace2.EventParams{TraceID: traceID, SpanID: reqSpan},
+		Level:       model.LevelWarn,
+		Msg:         "user not found",
+	})
+	log.RequestSpanEnd(trace2.RequestSpanEndParams{
+		Req:  req,
+		Resp: &model.Response{Payload: []byte(`{"Name":"alice"}`), Duration: time.Second},
+	})
+	log.RPCCallEnd(call, 1, nil)
+
+	// Spans outside of the test span are left out.
+	log.RequestSpanStart(&model.Request{
+		TraceID: traceID,
+		SpanID:  otherSpan,
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "other", Endpoint: "Other"}},
+	}, 2)
+
+	root := log.Trace(testSpan)
+	if root == nil || root.Kind != TestSpan || root.Test != t.Name() || root.Done {
+		t.Fatalf("got root span %+v", root)
+	}
+	if len(root.Children) != 1 || len(root.Spans()) != 2 {
+		t.Fatalf("got %d children and %d spans, want 1 and 2", len(root.Children), len(root.Spans()))
+	}
+	sp := root.Children[0]
+	if sp.Parent != root || sp.Service != "users" || sp.Endpoint != "Get" || !sp.Done || sp.Duration != time.Second ||
+		string(sp.Request) != `{"ID":1}` || string(sp.Response) != `{"Name":"alice"}` {
+		t.Errorf("got request span %+v", sp)
+	}
+
+	events := root.AllEvents("")
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+	if ev := events[0]; ev.Kind != RPCCallEvent || ev.Span != root || ev.Endpoint != "Get" || !ev.Done {
+		t.Errorf("got rpc call event %+v", ev)
+	}
+	if ev := events[1]; ev.Kind != DBQueryEvent || ev.Span != sp || !ev.Done || ev.Err != queryErr {
+		t.Errorf("got db query event %+v", ev)
+	}
+	if ev := events[2]; ev.Kind != LogMessageEvent || ev.Level != "warn" || ev.Msg != "user not found" {
+		t.Errorf("got log message event %+v", ev)
+	}
+	if got := root.AllEvents(DBQueryEvent); len(got) != 1 || got[0].Query != "SELECT name FROM users WHERE id = $1" {
+		t.Errorf("got db query events %+v", got)
+	}
+
+	// The trace is a snapshot.
+	log.TestSpanEnd(trace2.TestSpanEndParams{Req: test})
+	if root.Done || !log.Trace(testSpan).Done {
+		t.Errorf("got root done %v, want the snapshot unchanged", root.Done)
+	}
+
+	if got := log.Trace(model.SpanID{9}); got != nil {
+		t.Errorf("got span %+v for unknown span id, want nil", got)
+	}
+}
@@ -15,10 +15,29 @@ type DefaultFactory struct {
 	// SampleRate is the rate at which to sample traces, between [0, 1].
 	// If nil, 100% of traces are sampled.
 	SampleRate *float64
+
+	// Encoding is the encoding to compress trace data with.
+	Encoding trace2.Encoding
+
+	// TailSampling is the tail sampling policy of sampled traces.
+	// If nil, all sampled traces are kept.
+	TailSampling *trace2.TailSampling
+
+	// BufferLimits limits the memory used to buffer each trace's data.
+	BufferLimits trace2.BufferLimits
+
+	// SuppressedEvents are the event types to leave out of traces.
+	SuppressedEvents trace2.EventTypeSet
 }
 
 func (f *DefaultFactory) NewLogger() trace2.Logger {
-	return trace2.NewLog()
+	var log *trace2.Log
+	if f.TailSampling != nil {
+		log = trace2.NewTailSampledLog(f.Encoding, *f.TailSampling)
+	} else {
+		log = trace2.NewEncodedLog(f.Encoding)
+	}
+	return log.WithBufferLimits(f.BufferLimits).WithSuppressedEvents(f.SuppressedEvents)
 }
 
 func (f *DefaultFactory) SampleTrace() bool {
@@ -0,0 +1,43 @@
+// Package baggage provides request-scoped key-value pairs that propagate
+// across the whole call graph of a request.
+//
+// Unlike the values stored using the reqdata package, baggage is passed on to
+// the API calls made while handling the request, including calls to services
+// running in other processes, and to the subscribers of the Pub/Sub messages
+// published. It's also recorded in the request's trace, which makes it a good
+// fit for things like tenant IDs and experiment flags:
+//
+//	//encore:middleware target=all
+//	func Tenant(req middleware.Request, next middleware.Next) middleware.Response {
+//		baggage.Set(req.Context(), "tenant", req.Data().Headers.Get("X-Tenant-ID"))
+//		return next(req)
+//	}
+//
+//	//encore:api private
+//	func Charge(ctx context.Context, p *ChargeParams) error {
+//		tenant, _ := baggage.Get(ctx, "tenant") // set by the calling service
+//		// ...
+//	}
+//
+// Changes made to the baggage while handling a request only affect that request
+// and the calls and messages it makes afterwards, never the caller.
+//
+// For more information see https://encore.dev/docs/go/develop/request-data#baggage
+package baggage
+
+import "errors"
+
+// MaxSize is the maximum size of the baggage of a request, in bytes,
+// counting the length of the keys and values.
+const MaxSize = 8192
+
+var (
+	// ErrNoRequest is reported by Set and Delete when called outside of a request.
+	ErrNoRequest = errors.New("baggage: not called within a request")
+
+	// ErrEmptyKey is reported by Set when the key is empty.
+	ErrEmptyKey = errors.New("baggage: empty key")
+
+	// ErrTooLarge is reported by Set when the baggage would exceed MaxSize.
+	ErrTooLarge = errors.New("baggage: size exceeds MaxSize")
+)
@@ -0,0 +1,80 @@
+package baggage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestSetGet(t *testing.T) {
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	mgr := NewManager(rt)
+
+	if err := SetInternal(mgr, "tenant", "acme"); !errors.Is(err, ErrNoRequest) {
+		t.Fatalf("got err %v, want ErrNoRequest", err)
+	}
+	if _, ok := GetInternal(mgr, "tenant"); ok {
+		t.Fatal("got a value outside of a request")
+	}
+
+	rt.BeginRequest(&model.Request{Type: model.RPCCall})
+	defer rt.FinishRequest(false)
+	if err := SetInternal(mgr, "tenant", "acme"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetInternal(mgr, "experiment", "new-checkout"); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := GetInternal(mgr, "tenant"); !ok || got != "acme" {
+		t.Errorf("got (%q, %v), want tenant acme", got, ok)
+	}
+
+	if err := DeleteInternal(mgr, "experiment"); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]string{"tenant": "acme"}, AllInternal(mgr)); diff != "" {
+		t.Errorf("baggage mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := SetInternal(mgr, "", "x"); !errors.Is(err, ErrEmptyKey) {
+		t.Errorf("got err %v, want ErrEmptyKey", err)
+	}
+	if err := SetInternal(mgr, "big", strings.Repeat("x", MaxSize)); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("got err %v, want ErrTooLarge", err)
+	}
+	// Replacing a value only counts the new value towards the size.
+	if err := SetInternal(mgr, "tenant", strings.Repeat("x", MaxSize-len("tenant"))); err != nil {
+		t.Errorf("got err %v, want nil", err)
+	}
+}
+
+func TestGet_Inherited(t *testing.T) {
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	mgr := NewManager(rt)
+
+	parent := &model.Request{Type: model.RPCCall}
+	rt.BeginRequest(parent)
+	if err := SetInternal(mgr, "tenant", "acme"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests begun within a request inherit its baggage,
+	// but changes made to it are not seen by the parent.
+	rt.BeginRequest(&model.Request{Type: model.RPCCall})
+	defer rt.FinishRequest(false)
+	if got, ok := GetInternal(mgr, "tenant"); !ok || got != "acme" {
+		t.Errorf("got (%q, %v), want tenant acme", got, ok)
+	}
+	if err := SetInternal(mgr, "tenant", "globex"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := parent.Baggage.Get("tenant"); got != "acme" {
+		t.Errorf("got parent tenant %q, want acme", got)
+	}
+}
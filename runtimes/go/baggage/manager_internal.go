@@ -0,0 +1,76 @@
+package baggage
+
+import (
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+//publicapigen:drop
+type Manager struct {
+	rt *reqtrack.RequestTracker
+}
+
+//publicapigen:drop
+func NewManager(rt *reqtrack.RequestTracker) *Manager {
+	return &Manager{rt: rt}
+}
+
+// baggage returns the baggage of the current request,
+// or nil if there is no current request.
+func (mgr *Manager) baggage() *model.Baggage {
+	if curr := mgr.rt.Current(); curr.Req != nil {
+		return curr.Req.Baggage
+	}
+	return nil
+}
+
+// SetInternal sets key to val in the baggage of the current request.
+//
+//publicapigen:drop
+func SetInternal(mgr *Manager, key, val string) error {
+	b := mgr.baggage()
+	if b == nil {
+		return ErrNoRequest
+	} else if key == "" {
+		return ErrEmptyKey
+	}
+
+	size := len(key) + len(val)
+	for k, v := range b.All() {
+		if k != key {
+			size += len(k) + len(v)
+		}
+	}
+	if size > MaxSize {
+		return ErrTooLarge
+	}
+
+	b.Set(key, val)
+	return nil
+}
+
+// GetInternal returns the value of key in the baggage of the current request.
+//
+//publicapigen:drop
+func GetInternal(mgr *Manager, key string) (val string, ok bool) {
+	return mgr.baggage().Get(key)
+}
+
+// DeleteInternal removes key from the baggage of the current request.
+//
+//publicapigen:drop
+func DeleteInternal(mgr *Manager, key string) error {
+	b := mgr.baggage()
+	if b == nil {
+		return ErrNoRequest
+	}
+	b.Delete(key)
+	return nil
+}
+
+// AllInternal returns a copy of the baggage of the current request.
+//
+//publicapigen:drop
+func AllInternal(mgr *Manager) map[string]string {
+	return mgr.baggage().All()
+}
@@ -0,0 +1,38 @@
+//go:build encore_app
+
+package baggage
+
+import (
+	"context"
+
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+//publicapigen:drop
+var Singleton = NewManager(reqtrack.Singleton)
+
+// Set sets key to val in the baggage of the current request,
+// replacing any value already set for key.
+// It reports ErrNoRequest if called outside of a request.
+func Set(ctx context.Context, key, val string) error {
+	return SetInternal(Singleton, key, val)
+}
+
+// Get returns the value of key in the baggage of the current request,
+// and reports whether it was set.
+func Get(ctx context.Context, key string) (val string, ok bool) {
+	return GetInternal(Singleton, key)
+}
+
+// Delete removes key from the baggage of the current request,
+// so it's no longer propagated.
+// It reports ErrNoRequest if called outside of a request.
+func Delete(ctx context.Context, key string) error {
+	return DeleteInternal(Singleton, key)
+}
+
+// All returns a copy of the baggage of the current request,
+// or nil if it's empty.
+func All(ctx context.Context) map[string]string {
+	return AllInternal(Singleton)
+}
@@ -0,0 +1,370 @@
+// Package apikey provides managed API keys for authenticating
+// machine-to-machine requests.
+//
+// Keys are stored hashed in a SQL database, and carry a set of scopes
+// and an optional rate limit. The table must be created by a migration
+// in the database passed to New, using the statements in Schema.
+//
+//	var keys = apikey.New(apikey.Config{DB: db})
+//
+//	//encore:authhandler
+//	func AuthHandler(ctx context.Context, token string) (auth.UID, *apikey.Key, error) {
+//		return keys.Authenticate(ctx, token)
+//	}
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+// Schema is the SQL needed to create the table used to store API keys.
+// Add it to a migration for the database passed in Config.
+const Schema = `CREATE TABLE encore_api_keys (
+	id          TEXT PRIMARY KEY,
+	hash        BYTEA NOT NULL,
+	user_id     TEXT NOT NULL,
+	name        TEXT NOT NULL,
+	scopes      TEXT[] NOT NULL,
+	rate_limit  INTEGER NOT NULL,
+	created_at  TIMESTAMPTZ NOT NULL,
+	expires_at  TIMESTAMPTZ,
+	revoked_at  TIMESTAMPTZ
+);
+
+CREATE INDEX encore_api_keys_user_id ON encore_api_keys (user_id);
+`
+
+// Config configures a Manager.
+type Config struct {
+	// DB is the database the keys are stored in.
+	DB *sqldb.Database
+
+	// Prefix is prepended to generated keys to make them recognizable,
+	// for example by secret scanners. It defaults to "sk".
+	Prefix string
+
+	// CacheTTL is how long resolved keys are cached in memory.
+	// Revoking a key takes effect immediately on the instance it was revoked on,
+	// and within CacheTTL on other instances. It defaults to one minute.
+	// Keys that don't exist are cached for at most 10 seconds.
+	CacheTTL time.Duration
+}
+
+// Key describes an API key. It never contains the key itself,
+// which is only returned once when the key is created.
+type Key struct {
+	ID     string
+	UserID auth.UID
+	Name   string
+	Scopes []string
+
+	// RateLimit is the maximum number of requests per minute
+	// allowed using the key. Zero means no limit.
+	RateLimit int
+
+	CreatedAt time.Time
+	ExpiresAt *time.Time // nil if the key never expires
+	RevokedAt *time.Time // nil if the key has not been revoked
+}
+
+// HasScope reports whether the key has the given scope.
+func (k *Key) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateParams are the parameters for creating a key.
+type CreateParams struct {
+	// UserID is the user the key authenticates as.
+	UserID auth.UID
+
+	// Name is a human-readable description of the key.
+	Name string
+
+	Scopes    []string
+	RateLimit int
+
+	// ExpiresAt, if non-zero, is when the key stops being valid.
+	ExpiresAt time.Time
+}
+
+// Manager creates, revokes and authenticates API keys.
+// It is safe for concurrent use.
+type Manager struct {
+	cfg   Config
+	store store
+	now   func() time.Time
+
+	mu       sync.Mutex
+	cache    map[string]*cacheEntry // keyed by key id
+	buckets  map[string]*bucket     // keyed by key id
+	prunedAt time.Time              // when idle entries were last evicted
+}
+
+// negativeCacheTTL is how long the absence of a key is cached,
+// so that unknown keys don't cause a database query per request.
+const negativeCacheTTL = 10 * time.Second
+
+type cacheEntry struct {
+	key       *Key // nil if the key doesn't exist
+	hash      []byte
+	fetchedAt time.Time
+}
+
+// New returns a new Manager.
+func New(cfg Config) *Manager {
+	return newManager(cfg, &sqlStore{db: cfg.DB})
+}
+
+func newManager(cfg Config, store store) *Manager {
+	if cfg.Prefix == "" {
+		cfg.Prefix = "sk"
+	}
+	if cfg.CacheTTL == 0 {
+		cfg.CacheTTL = time.Minute
+	}
+	return &Manager{
+		cfg:     cfg,
+		store:   store,
+		now:     time.Now,
+		cache:   make(map[string]*cacheEntry),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Create creates a new key. It returns the key itself, which is not stored
+// and must be handed to the caller, along with its description.
+func (m *Manager) Create(ctx context.Context, p CreateParams) (secret string, key *Key, err error) {
+	if p.UserID == "" {
+		return "", nil, errs.B().Code(errs.InvalidArgument).Msg("missing user id").Err()
+	} else if p.RateLimit < 0 {
+		return "", nil, errs.B().Code(errs.InvalidArgument).Msg("rate limit must not be negative").Err()
+	}
+
+	id := hex.EncodeToString(randomBytes(8))
+	raw := base64.RawURLEncoding.EncodeToString(randomBytes(32))
+	key = &Key{
+		ID:        id,
+		UserID:    p.UserID,
+		Name:      p.Name,
+		Scopes:    p.Scopes,
+		RateLimit: p.RateLimit,
+		CreatedAt: m.now().UTC(),
+	}
+	if key.Scopes == nil {
+		key.Scopes = []string{}
+	}
+	if !p.ExpiresAt.IsZero() {
+		exp := p.ExpiresAt.UTC()
+		key.ExpiresAt = &exp
+	}
+
+	if err := m.store.insert(ctx, key, hash(raw)); err != nil {
+		return "", nil, errs.B().Code(errs.Internal).Msg("unable to create api key").Cause(err).Err()
+	}
+
+	m.mu.Lock()
+	delete(m.cache, id)
+	m.mu.Unlock()
+	return m.cfg.Prefix + "_" + id + "_" + raw, key, nil
+}
+
+// Revoke revokes the key with the given id.
+func (m *Manager) Revoke(ctx context.Context, id string) error {
+	if err := m.store.revoke(ctx, id, m.now().UTC()); err != nil {
+		if errors.Is(err, sqldb.ErrNoRows) {
+			return errs.B().Code(errs.NotFound).Msg("api key not found").Err()
+		}
+		return errs.B().Code(errs.Internal).Msg("unable to revoke api key").Cause(err).Err()
+	}
+
+	m.mu.Lock()
+	delete(m.cache, id)
+	delete(m.buckets, id)
+	m.mu.Unlock()
+	return nil
+}
+
+// List lists the keys belonging to the given user, including revoked ones.
+func (m *Manager) List(ctx context.Context, uid auth.UID) ([]*Key, error) {
+	keys, err := m.store.list(ctx, uid)
+	if err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("unable to list api keys").Cause(err).Err()
+	}
+	return keys, nil
+}
+
+// Authenticate resolves the given key, in the form expected from an auth handler.
+//
+// The key may optionally be prefixed with "Bearer ". If the key is invalid,
+// expired or revoked the returned error has the code errs.Unauthenticated, and
+// if the key's rate limit is exceeded the code is errs.ResourceExhausted.
+func (m *Manager) Authenticate(ctx context.Context, token string) (auth.UID, *Key, error) {
+	token = strings.TrimPrefix(token, "Bearer ")
+	id, raw, ok := m.parse(token)
+	if !ok {
+		return "", nil, invalidKey()
+	}
+
+	entry, err := m.lookup(ctx, id)
+	if err != nil {
+		return "", nil, err
+	} else if entry == nil || subtle.ConstantTimeCompare(entry.hash, hash(raw)) != 1 {
+		return "", nil, invalidKey()
+	}
+
+	key := entry.key
+	now := m.now()
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && !now.Before(*key.ExpiresAt)) {
+		return "", nil, invalidKey()
+	}
+	if key.RateLimit > 0 && !m.allow(key, now) {
+		return "", nil, errs.B().Code(errs.ResourceExhausted).Msg("api key rate limit exceeded").Err()
+	}
+	return key.UserID, key, nil
+}
+
+// parse splits a key into its id and secret parts.
+func (m *Manager) parse(token string) (id, raw string, ok bool) {
+	rest, ok := strings.CutPrefix(token, m.cfg.Prefix+"_")
+	if !ok {
+		return "", "", false
+	}
+	id, raw, ok = strings.Cut(rest, "_")
+	return id, raw, ok && validID(id) && raw != ""
+}
+
+// validID reports whether id has the format of the ids of created keys,
+// so that malformed keys are rejected without looking them up.
+func validID(id string) bool {
+	if len(id) != 16 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+// lookup returns the key with the given id, or nil if it does not exist.
+func (m *Manager) lookup(ctx context.Context, id string) (*cacheEntry, error) {
+	now := m.now()
+	m.mu.Lock()
+	entry, ok := m.cache[id]
+	m.mu.Unlock()
+	if ok && now.Sub(entry.fetchedAt) < m.ttl(entry) {
+		if entry.key == nil {
+			return nil, nil
+		}
+		return entry, nil
+	}
+
+	key, h, err := m.store.get(ctx, id)
+	if errors.Is(err, sqldb.ErrNoRows) {
+		entry = &cacheEntry{fetchedAt: now}
+	} else if err != nil {
+		return nil, errs.B().Code(errs.Unavailable).Msg("unable to look up api key").Cause(err).Err()
+	} else {
+		entry = &cacheEntry{key: key, hash: h, fetchedAt: now}
+	}
+
+	m.mu.Lock()
+	m.cache[id] = entry
+	m.pruneLocked(now)
+	m.mu.Unlock()
+
+	if entry.key == nil {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+// ttl returns how long the cache entry is valid for.
+func (m *Manager) ttl(entry *cacheEntry) time.Duration {
+	if entry.key == nil {
+		return min(negativeCacheTTL, m.cfg.CacheTTL)
+	}
+	return m.cfg.CacheTTL
+}
+
+// pruneLocked evicts expired cache entries and idle rate limit buckets,
+// at most once per CacheTTL. m.mu must be held.
+func (m *Manager) pruneLocked(now time.Time) {
+	if now.Sub(m.prunedAt) < m.cfg.CacheTTL {
+		return
+	}
+	m.prunedAt = now
+
+	for id, entry := range m.cache {
+		if now.Sub(entry.fetchedAt) >= m.ttl(entry) {
+			delete(m.cache, id)
+		}
+	}
+	for id, b := range m.buckets {
+		// Buckets are full again after a minute, like new ones.
+		if now.Sub(b.last) >= time.Minute {
+			delete(m.buckets, id)
+		}
+	}
+}
+
+// bucket is a token bucket holding up to RateLimit tokens,
+// refilled at RateLimit tokens per minute.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// allow reports whether a request using the key is allowed by its rate limit.
+// Limits are enforced per instance.
+func (m *Manager) allow(key *Key, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	limit := float64(key.RateLimit)
+	b, ok := m.buckets[key.ID]
+	if !ok {
+		b = &bucket{tokens: limit, last: now}
+		m.buckets[key.ID] = b
+	}
+
+	b.tokens = min(limit, b.tokens+now.Sub(b.last).Minutes()*limit)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func invalidKey() error {
+	return errs.B().Code(errs.Unauthenticated).Msg("invalid api key").Err()
+}
+
+func hash(raw string) []byte {
+	h := sha256.Sum256([]byte(raw))
+	return h[:]
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic("apikey: unable to generate random bytes: " + err.Error())
+	}
+	return b
+}
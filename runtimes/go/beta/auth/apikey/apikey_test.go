@@ -0,0 +1,230 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/sqldb"
+)
+
+type memStore struct {
+	mu     sync.Mutex
+	keys   map[string]*Key
+	hashes map[string][]byte
+	gets   int
+}
+
+func newMemStore() *memStore {
+	return &memStore{keys: make(map[string]*Key), hashes: make(map[string][]byte)}
+}
+
+func (s *memStore) insert(_ context.Context, k *Key, hash []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cpy := *k
+	s.keys[k.ID], s.hashes[k.ID] = &cpy, hash
+	return nil
+}
+
+func (s *memStore) get(_ context.Context, id string) (*Key, []byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gets++
+	k, ok := s.keys[id]
+	if !ok {
+		return nil, nil, sqldb.ErrNoRows
+	}
+	cpy := *k
+	return &cpy, s.hashes[id], nil
+}
+
+func (s *memStore) revoke(_ context.Context, id string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k, ok := s.keys[id]
+	if !ok {
+		return sqldb.ErrNoRows
+	}
+	k.RevokedAt = &at
+	return nil
+}
+
+func (s *memStore) list(_ context.Context, uid auth.UID) ([]*Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []*Key
+	for _, k := range s.keys {
+		if k.UserID == uid {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func TestManager_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	m := newManager(Config{}, store)
+
+	secret, key, err := m.Create(ctx, CreateParams{UserID: "user", Name: "ci", Scopes: []string{"read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(secret, "sk_"+key.ID+"_") {
+		t.Fatalf("unexpected key format %q", secret)
+	}
+
+	uid, got, err := m.Authenticate(ctx, "Bearer "+secret)
+	if err != nil {
+		t.Fatal(err)
+	} else if uid != "user" || !got.HasScope("read") || got.HasScope("write") {
+		t.Fatalf("got uid %q and key %+v", uid, got)
+	}
+
+	// Resolved keys are cached.
+	if _, _, err := m.Authenticate(ctx, secret); err != nil {
+		t.Fatal(err)
+	} else if store.gets != 1 {
+		t.Fatalf("got %d store lookups, want 1", store.gets)
+	}
+
+	for _, bad := range []string{"", "garbage", secret + "x", "sk_unknown_abc", strings.Replace(secret, "sk_", "pk_", 1)} {
+		if _, _, err := m.Authenticate(ctx, bad); errs.Code(err) != errs.Unauthenticated {
+			t.Errorf("Authenticate(%q): got err %v, want Unauthenticated", bad, err)
+		}
+	}
+
+	if err := m.Revoke(ctx, key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := m.Authenticate(ctx, secret); errs.Code(err) != errs.Unauthenticated {
+		t.Fatalf("got err %v for revoked key, want Unauthenticated", err)
+	}
+	if err := m.Revoke(ctx, "unknown"); errs.Code(err) != errs.NotFound {
+		t.Fatalf("got err %v for unknown key, want NotFound", err)
+	}
+}
+
+func TestManager_Expiry(t *testing.T) {
+	ctx := context.Background()
+	m := newManager(Config{}, newMemStore())
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	secret, _, err := m.Create(ctx, CreateParams{UserID: "user", ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := m.Authenticate(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, _, err := m.Authenticate(ctx, secret); errs.Code(err) != errs.Unauthenticated {
+		t.Fatalf("got err %v for expired key, want Unauthenticated", err)
+	}
+}
+
+func TestManager_RateLimit(t *testing.T) {
+	ctx := context.Background()
+	m := newManager(Config{}, newMemStore())
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	secret, _, err := m.Create(ctx, CreateParams{UserID: "user", RateLimit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, _, err := m.Authenticate(ctx, secret); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, err := m.Authenticate(ctx, secret); errs.Code(err) != errs.ResourceExhausted {
+		t.Fatalf("got err %v, want ResourceExhausted", err)
+	}
+
+	// Tokens are refilled over time.
+	now = now.Add(30 * time.Second)
+	if _, _, err := m.Authenticate(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManager_UnknownKeys(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	m := newManager(Config{}, store)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	// Malformed key ids are rejected without a lookup.
+	if _, _, err := m.Authenticate(ctx, "sk_unknown_secret"); errs.Code(err) != errs.Unauthenticated {
+		t.Fatalf("got err %v, want Unauthenticated", err)
+	} else if store.gets != 0 {
+		t.Fatalf("got %d store lookups, want 0", store.gets)
+	}
+
+	// The absence of a key is cached for a short while.
+	for i := 0; i < 3; i++ {
+		if _, _, err := m.Authenticate(ctx, "sk_0123456789abcdef_secret"); errs.Code(err) != errs.Unauthenticated {
+			t.Fatalf("got err %v, want Unauthenticated", err)
+		}
+	}
+	if store.gets != 1 {
+		t.Fatalf("got %d store lookups, want 1", store.gets)
+	}
+	now = now.Add(negativeCacheTTL)
+	if _, _, err := m.Authenticate(ctx, "sk_0123456789abcdef_secret"); errs.Code(err) != errs.Unauthenticated {
+		t.Fatalf("got err %v, want Unauthenticated", err)
+	} else if store.gets != 2 {
+		t.Fatalf("got %d store lookups, want 2", store.gets)
+	}
+}
+
+func TestManager_Prune(t *testing.T) {
+	ctx := context.Background()
+	m := newManager(Config{}, newMemStore())
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	secret, _, err := m.Create(ctx, CreateParams{UserID: "user", RateLimit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := m.Authenticate(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"0000000000000001", "0000000000000002"} {
+		_, _, _ = m.Authenticate(ctx, "sk_"+id+"_secret")
+	}
+	if len(m.cache) != 3 || len(m.buckets) != 1 {
+		t.Fatalf("got %d cache entries and %d buckets, want 3 and 1", len(m.cache), len(m.buckets))
+	}
+
+	// Idle entries are evicted once they've expired.
+	now = now.Add(time.Minute)
+	_, _, _ = m.Authenticate(ctx, "sk_0000000000000003_secret")
+	if len(m.cache) != 1 || len(m.buckets) != 0 {
+		t.Fatalf("got %d cache entries and %d buckets, want 1 and 0", len(m.cache), len(m.buckets))
+	}
+}
+
+func TestManager_StoreError(t *testing.T) {
+	m := newManager(Config{}, failingStore{newMemStore()})
+	if _, _, err := m.Authenticate(context.Background(), "sk_0123456789abcdef_secret"); errs.Code(err) != errs.Unavailable {
+		t.Fatalf("got err %v, want Unavailable", err)
+	}
+}
+
+type failingStore struct{ *memStore }
+
+func (failingStore) get(context.Context, string) (*Key, []byte, error) {
+	return nil, nil, errors.New("connection refused")
+}
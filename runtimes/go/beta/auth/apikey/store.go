@@ -0,0 +1,94 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"encore.dev/beta/auth"
+	"encore.dev/storage/sqldb"
+)
+
+// store persists API keys.
+type store interface {
+	insert(ctx context.Context, key *Key, hash []byte) error
+	// get returns the key and its hash, or sqldb.ErrNoRows if it does not exist.
+	get(ctx context.Context, id string) (*Key, []byte, error)
+	// revoke revokes the key, or returns sqldb.ErrNoRows if it does not exist.
+	revoke(ctx context.Context, id string, at time.Time) error
+	list(ctx context.Context, uid auth.UID) ([]*Key, error)
+}
+
+// sqlStore stores keys in the encore_api_keys table.
+type sqlStore struct {
+	db *sqldb.Database
+}
+
+const keyColumns = "id, user_id, name, scopes, rate_limit, created_at, expires_at, revoked_at"
+
+func (s *sqlStore) insert(ctx context.Context, k *Key, hash []byte) error {
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO encore_api_keys (id, hash, user_id, name, scopes, rate_limit, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, k.ID, hash, string(k.UserID), k.Name, k.Scopes, k.RateLimit, k.CreatedAt, k.ExpiresAt)
+	return err
+}
+
+func (s *sqlStore) get(ctx context.Context, id string) (*Key, []byte, error) {
+	var hash []byte
+	k, err := scanKey(s.db.QueryRow(ctx, `
+		SELECT `+keyColumns+`, hash FROM encore_api_keys WHERE id = $1
+	`, id), &hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return k, hash, nil
+}
+
+func (s *sqlStore) revoke(ctx context.Context, id string, at time.Time) error {
+	res, err := s.db.Exec(ctx, `
+		UPDATE encore_api_keys SET revoked_at = COALESCE(revoked_at, $2) WHERE id = $1
+	`, id, at)
+	if err != nil {
+		return err
+	} else if res.RowsAffected() == 0 {
+		return sqldb.ErrNoRows
+	}
+	return nil
+}
+
+func (s *sqlStore) list(ctx context.Context, uid auth.UID) ([]*Key, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT `+keyColumns+` FROM encore_api_keys WHERE user_id = $1 ORDER BY created_at
+	`, string(uid))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*Key
+	for rows.Next() {
+		k, err := scanKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanKey(row scanner, extra ...any) (*Key, error) {
+	var (
+		k   Key
+		uid string
+	)
+	dest := append([]any{&k.ID, &uid, &k.Name, &k.Scopes, &k.RateLimit, &k.CreatedAt, &k.ExpiresAt, &k.RevokedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	k.UserID = auth.UID(uid)
+	return &k, nil
+}
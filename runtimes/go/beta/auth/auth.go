@@ -14,6 +14,28 @@ import (
 // UID is a unique identifier representing a user (a user id).
 type UID = model.UID
 
+// ScopeChecker can be implemented by the auth data returned from the auth handler
+// to support endpoints that require scopes:
+//
+//	//encore:api auth scopes=orders:read,orders:write
+//
+// Requests are only allowed if the auth data has all the required scopes,
+// and otherwise fail with errs.PermissionDenied.
+type ScopeChecker interface {
+	HasScope(scope string) bool
+}
+
+// RoleChecker can be implemented by the auth data returned from the auth handler
+// to support endpoints that require roles:
+//
+//	//encore:api auth roles=admin,support
+//
+// Requests are only allowed if the auth data has at least one of the required roles,
+// and otherwise fail with errs.PermissionDenied.
+type RoleChecker interface {
+	HasRole(role string) bool
+}
+
 //publicapigen:drop
 type Manager struct {
 	rt *reqtrack.RequestTracker
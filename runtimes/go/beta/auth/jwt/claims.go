@@ -0,0 +1,77 @@
+package jwt
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// RegisteredClaims are the registered claims defined by RFC 7519.
+//
+// Embed it in a custom claims struct to access the registered claims
+// alongside application-specific ones:
+//
+//	type Claims struct {
+//		jwt.RegisteredClaims
+//		Email string `json:"email"`
+//	}
+type RegisteredClaims struct {
+	Issuer    string       `json:"iss,omitempty"`
+	Subject   string       `json:"sub,omitempty"`
+	Audience  Audience     `json:"aud,omitempty"`
+	ExpiresAt *NumericDate `json:"exp,omitempty"`
+	NotBefore *NumericDate `json:"nbf,omitempty"`
+	IssuedAt  *NumericDate `json:"iat,omitempty"`
+	ID        string       `json:"jti,omitempty"`
+}
+
+// Audience is the "aud" claim, which may be encoded
+// either as a single string or as a list of strings.
+type Audience []string
+
+// Contains reports whether the audience contains aud.
+func (a Audience) Contains(aud string) bool {
+	for _, s := range a {
+		if s == aud {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// NumericDate is a JSON numeric date value, representing
+// the number of seconds since the Unix epoch.
+type NumericDate struct {
+	time.Time
+}
+
+func (d NumericDate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(d.Unix(), 10)), nil
+}
+
+func (d *NumericDate) UnmarshalJSON(data []byte) error {
+	var f json.Number
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	secs, err := f.Float64()
+	if err != nil {
+		return err
+	}
+	d.Time = time.Unix(0, int64(secs*float64(time.Second))).UTC()
+	return nil
+}
@@ -0,0 +1,207 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// minRefreshInterval is the minimum time between JWKS refreshes
+// triggered by unknown key ids, to avoid hammering the JWKS endpoint
+// with tokens signed by unknown keys.
+const minRefreshInterval = 30 * time.Second
+
+// fetchTimeout is the timeout for fetching the key set.
+const fetchTimeout = 10 * time.Second
+
+// keySet is a cached set of JSON Web Keys fetched from a JWKS URL.
+type keySet struct {
+	url    string
+	ttl    time.Duration
+	client *http.Client
+	now    func() time.Time
+
+	group singleflight.Group // collapses concurrent refreshes
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey // keyed by kid
+	fetchedAt time.Time                   // time of the last fetch attempt
+	fetchErr  error                       // error of the last fetch attempt, if any
+}
+
+// key returns the key with the given key id.
+//
+// The key set is refreshed if the cache has expired or, to handle key
+// rotation, if the key id is not known.
+func (ks *keySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	ks.mu.Lock()
+	now := ks.now()
+	expired := now.Sub(ks.fetchedAt) >= ks.ttl
+	key, known := ks.keys[kid]
+	refresh := expired || (!known && now.Sub(ks.fetchedAt) >= minRefreshInterval)
+	fetchErr := ks.fetchErr
+	ks.mu.Unlock()
+
+	switch {
+	case known && !expired:
+		return key, nil
+	case refresh:
+		if err := ks.refresh(ctx); err != nil {
+			// Keep using the cached key if we have it.
+			if known {
+				return key, nil
+			}
+			return nil, fetchError{err}
+		}
+		ks.mu.Lock()
+		key, known = ks.keys[kid]
+		ks.mu.Unlock()
+	case fetchErr != nil:
+		return nil, fetchError{fetchErr}
+	}
+
+	if known {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
+
+// refresh fetches the key set and updates the cache, without holding ks.mu
+// during the fetch. Concurrent refreshes share a single fetch.
+//
+// The attempt is recorded even if the fetch fails, so that retries
+// are limited by minRefreshInterval as well.
+func (ks *keySet) refresh(ctx context.Context) error {
+	_, err, _ := ks.group.Do("", func() (any, error) {
+		// Don't let the caller that started the fetch cancel it for everyone.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+		keys, err := ks.fetch(ctx)
+		ks.mu.Lock()
+		defer ks.mu.Unlock()
+		ks.fetchedAt, ks.fetchErr = ks.now(), err
+		if err == nil {
+			ks.keys = keys
+		}
+		return nil, err
+	})
+	return err
+}
+
+// fetchError is returned when the key set could not be fetched.
+type fetchError struct {
+	err error
+}
+
+func (e fetchError) Error() string { return e.err.Error() }
+func (e fetchError) Unwrap() error { return e.err }
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+
+	// RSA keys
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC keys
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (ks *keySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", ks.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ks.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: unexpected status code %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("parse jwks: %v", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			// Skip keys we don't support rather than failing entirely.
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, fmt.Errorf("invalid rsa exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if !key.Curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("invalid ec key")
+		}
+		return key, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,229 @@
+// Package jwt provides a helper for validating JSON Web Tokens
+// signed by keys published at a JWKS URL, for use in auth handlers.
+//
+// Tokens signed using RS256 and ES256 are supported.
+//
+//	var validator = jwt.NewValidator[Claims](jwt.Config{
+//		JWKSURL:  "https://example.auth0.com/.well-known/jwks.json",
+//		Issuer:   "https://example.auth0.com/",
+//		Audience: "my-api",
+//	})
+//
+//	//encore:authhandler
+//	func AuthHandler(ctx context.Context, token string) (auth.UID, *Claims, error) {
+//		claims, err := validator.Validate(ctx, token)
+//		if err != nil {
+//			return "", nil, err
+//		}
+//		return auth.UID(claims.Subject), claims, nil
+//	}
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+// Config configures a Validator.
+type Config struct {
+	// JWKSURL is the URL of the JSON Web Key Set used to verify token signatures.
+	JWKSURL string
+
+	// Issuer, if non-empty, is the required value of the "iss" claim.
+	Issuer string
+
+	// Audience, if non-empty, is required to be present in the "aud" claim.
+	Audience string
+
+	// ClockSkew is the leeway allowed when validating the
+	// "exp", "nbf" and "iat" claims. It defaults to one minute.
+	ClockSkew time.Duration
+
+	// CacheTTL is how long the key set is cached before it is refetched.
+	// Unknown key ids always trigger a refetch, to handle key rotation.
+	// It defaults to one hour.
+	CacheTTL time.Duration
+
+	// HTTPClient is the client used to fetch the key set.
+	// It defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Validator validates tokens and extracts claims of type C.
+//
+// C must be a struct type that the token payload can be decoded into,
+// and typically embeds RegisteredClaims.
+type Validator[C any] struct {
+	cfg  Config
+	keys *keySet
+	now  func() time.Time
+}
+
+// NewValidator returns a new Validator with the given configuration.
+// It is safe for concurrent use and should be reused across requests
+// so the key set is cached.
+func NewValidator[C any](cfg Config) *Validator[C] {
+	if cfg.ClockSkew == 0 {
+		cfg.ClockSkew = time.Minute
+	}
+	if cfg.CacheTTL == 0 {
+		cfg.CacheTTL = time.Hour
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+
+	now := time.Now
+	return &Validator[C]{
+		cfg: cfg,
+		now: now,
+		keys: &keySet{
+			url:    cfg.JWKSURL,
+			ttl:    cfg.CacheTTL,
+			client: cfg.HTTPClient,
+			now:    now,
+		},
+	}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// Validate validates the token and returns its claims.
+//
+// The token may optionally be prefixed with "Bearer ".
+// If the token is invalid the returned error has the code errs.Unauthenticated,
+// and can be returned directly from an auth handler. If the key set cannot be
+// fetched the error has the code errs.Unavailable.
+func (v *Validator[C]) Validate(ctx context.Context, token string) (*C, error) {
+	token = strings.TrimPrefix(token, "Bearer ")
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalidToken(fmt.Errorf("malformed token"))
+	}
+
+	var hdr header
+	if err := decodeSegment(parts[0], &hdr); err != nil {
+		return nil, invalidToken(fmt.Errorf("invalid header: %v", err))
+	}
+
+	key, err := v.keys.key(ctx, hdr.Kid)
+	if err != nil {
+		var fetchErr fetchError
+		if errors.As(err, &fetchErr) {
+			return nil, errs.B().Code(errs.Unavailable).Msg("unable to fetch signing keys").Cause(err).Err()
+		}
+		return nil, invalidToken(err)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalidToken(fmt.Errorf("invalid signature encoding"))
+	}
+	if err := verify(hdr.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, invalidToken(err)
+	}
+
+	var registered RegisteredClaims
+	if err := decodeSegment(parts[1], &registered); err != nil {
+		return nil, invalidToken(fmt.Errorf("invalid claims: %v", err))
+	}
+	if err := v.validateClaims(&registered); err != nil {
+		return nil, invalidToken(err)
+	}
+
+	claims := new(C)
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, invalidToken(fmt.Errorf("invalid claims: %v", err))
+	}
+	return claims, nil
+}
+
+func (v *Validator[C]) validateClaims(c *RegisteredClaims) error {
+	now := v.now()
+	skew := v.cfg.ClockSkew
+
+	if c.ExpiresAt == nil {
+		return fmt.Errorf("missing exp claim")
+	} else if now.After(c.ExpiresAt.Add(skew)) {
+		return fmt.Errorf("token has expired")
+	}
+	if c.NotBefore != nil && now.Before(c.NotBefore.Add(-skew)) {
+		return fmt.Errorf("token is not valid yet")
+	}
+	if c.IssuedAt != nil && now.Before(c.IssuedAt.Add(-skew)) {
+		return fmt.Errorf("token was issued in the future")
+	}
+	if v.cfg.Issuer != "" && c.Issuer != v.cfg.Issuer {
+		return fmt.Errorf("invalid issuer")
+	}
+	if v.cfg.Audience != "" && !c.Audience.Contains(v.cfg.Audience) {
+		return fmt.Errorf("invalid audience")
+	}
+	return nil
+}
+
+// verify verifies the signature of the signing input using the given algorithm and key.
+// The algorithm must match the key type, which protects against algorithm confusion attacks.
+func verify(alg string, key crypto.PublicKey, signingInput string, sig []byte) error {
+	digest := sha256.Sum256([]byte(signingInput))
+
+	switch alg {
+	case "RS256":
+		k, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match algorithm %s", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig); err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+
+	case "ES256":
+		k, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match algorithm %s", alg)
+		}
+		if len(sig) != 64 {
+			return fmt.Errorf("invalid signature")
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(k, digest[:], r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+}
+
+func decodeSegment(seg string, dst any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+func invalidToken(cause error) error {
+	return errs.B().Code(errs.Unauthenticated).Msg("invalid token").Cause(cause).Err()
+}
@@ -0,0 +1,188 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+type testClaims struct {
+	RegisteredClaims
+	Email string `json:"email"`
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func sign(t *testing.T, alg, kid string, key crypto.Signer, claims any) string {
+	t.Helper()
+	hdr, _ := json.Marshal(header{Alg: alg, Kid: kid, Typ: "JWT"})
+	payload, _ := json.Marshal(claims)
+	input := b64(hdr) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(input))
+
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+	return input + "." + b64(sig)
+}
+
+func TestValidator(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fetches++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{
+				{
+					"kid": "rsa", "kty": "RSA", "use": "sig",
+					"n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+				},
+				{
+					"kid": "ec", "kty": "EC", "crv": "P-256",
+					"x": b64(ecKey.X.Bytes()), "y": b64(ecKey.Y.Bytes()),
+				},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	now := time.Unix(1700000000, 0)
+	v := NewValidator[testClaims](Config{JWKSURL: srv.URL, Issuer: "iss", Audience: "aud", HTTPClient: srv.Client()})
+	v.now = func() time.Time { return now }
+	v.keys.now = v.now
+
+	valid := testClaims{
+		RegisteredClaims: RegisteredClaims{
+			Issuer:    "iss",
+			Subject:   "user-1",
+			Audience:  Audience{"aud"},
+			ExpiresAt: &NumericDate{now.Add(time.Hour)},
+		},
+		Email: "user@example.com",
+	}
+	expired := valid
+	expired.ExpiresAt = &NumericDate{now.Add(-2 * time.Minute)}
+	skewed := valid
+	skewed.ExpiresAt = &NumericDate{now.Add(-30 * time.Second)}
+	wrongAud := valid
+	wrongAud.Audience = Audience{"other"}
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"rs256", sign(t, "RS256", "rsa", rsaKey, valid), true},
+		{"es256", "Bearer " + sign(t, "ES256", "ec", ecKey, valid), true},
+		{"within_skew", sign(t, "RS256", "rsa", rsaKey, skewed), true},
+		{"expired", sign(t, "RS256", "rsa", rsaKey, expired), false},
+		{"wrong_audience", sign(t, "RS256", "rsa", rsaKey, wrongAud), false},
+		{"alg_mismatch", sign(t, "ES256", "rsa", ecKey, valid), false},
+		{"unknown_kid", sign(t, "RS256", "missing", rsaKey, valid), false},
+		{"malformed", "not-a-token", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims, err := v.Validate(context.Background(), test.token)
+			if !test.ok {
+				if errs.Code(err) != errs.Unauthenticated {
+					t.Fatalf("got err %v, want Unauthenticated", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if claims.Subject != "user-1" || claims.Email != "user@example.com" {
+				t.Fatalf("got claims %+v", claims)
+			}
+		})
+	}
+
+	// Refetches triggered by unknown key ids are rate limited.
+	if fetches != 1 {
+		t.Errorf("got %d fetches, want 1", fetches)
+	}
+	now = now.Add(minRefreshInterval)
+	_, _ = v.Validate(context.Background(), sign(t, "RS256", "missing", rsaKey, valid))
+	if fetches != 2 {
+		t.Errorf("got %d fetches, want 2", fetches)
+	}
+}
+
+func TestKeySet_FailedFetch(t *testing.T) {
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fetches.Add(1)
+		<-release
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	now := time.Unix(1700000000, 0)
+	ks := &keySet{url: srv.URL, ttl: time.Hour, client: srv.Client(), now: func() time.Time { return now }}
+
+	// Concurrent lookups share a single fetch.
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ks.key(context.Background(), "kid"); err == nil {
+				t.Error("got nil error, want fetch error")
+			}
+		}()
+	}
+	for fetches.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if n := fetches.Load(); n != 1 {
+		t.Fatalf("got %d fetches, want 1", n)
+	}
+
+	// Failed fetches are rate limited too.
+	if _, err := ks.key(context.Background(), "kid"); err == nil {
+		t.Fatal("got nil error, want the last fetch error")
+	} else if n := fetches.Load(); n != 1 {
+		t.Fatalf("got %d fetches, want 1", n)
+	}
+	now = now.Add(minRefreshInterval)
+	_, _ = ks.key(context.Background(), "kid")
+	if n := fetches.Load(); n != 2 {
+		t.Fatalf("got %d fetches, want 2", n)
+	}
+}
@@ -0,0 +1,113 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	stateCookie = "encore_oidc_state"
+	nonceCookie = "encore_oidc_nonce"
+)
+
+// LoginHandler returns a handler that redirects the user to the provider to log in.
+// It is intended to be served from a raw endpoint:
+//
+//	//encore:api public raw path=/login
+//	func Login(w http.ResponseWriter, req *http.Request) {
+//		provider.LoginHandler().ServeHTTP(w, req)
+//	}
+func (p *Provider) LoginHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if p.cfg.RedirectURL == "" {
+			http.Error(w, "oidc: RedirectURL is not configured", http.StatusInternalServerError)
+			return
+		}
+
+		state, nonce := randomString(), randomString()
+		authURL, err := p.AuthCodeURL(req.Context(), p.cfg.RedirectURL, state, nonce)
+		if err != nil {
+			http.Error(w, "identity provider unavailable", http.StatusBadGateway)
+			return
+		}
+
+		p.setCookie(w, stateCookie, state)
+		p.setCookie(w, nonceCookie, nonce)
+		http.Redirect(w, req, authURL, http.StatusFound)
+	})
+}
+
+// CallbackHandler returns a handler for the provider's redirect back to the application.
+// It verifies the state, exchanges the authorization code and validates the ID token,
+// and then calls onLogin with the resulting token. onLogin is responsible for writing
+// the response, such as by setting a session cookie and redirecting the user.
+func (p *Provider) CallbackHandler(onLogin func(w http.ResponseWriter, req *http.Request, tok *Token)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if p.cfg.RedirectURL == "" {
+			http.Error(w, "oidc: RedirectURL is not configured", http.StatusInternalServerError)
+			return
+		}
+
+		q := req.URL.Query()
+		if errCode := q.Get("error"); errCode != "" {
+			http.Error(w, "login failed: "+errCode, http.StatusUnauthorized)
+			return
+		}
+
+		state, err := req.Cookie(stateCookie)
+		if err != nil || !equal(state.Value, q.Get("state")) {
+			http.Error(w, "invalid login state", http.StatusBadRequest)
+			return
+		}
+		clearCookie(w, stateCookie)
+
+		tok, err := p.Exchange(req.Context(), q.Get("code"), p.cfg.RedirectURL)
+		if err != nil {
+			http.Error(w, "login failed", http.StatusUnauthorized)
+			return
+		}
+
+		nonce, err := req.Cookie(nonceCookie)
+		if err != nil || !equal(nonce.Value, tok.Claims.Nonce) {
+			http.Error(w, "invalid login nonce", http.StatusBadRequest)
+			return
+		}
+		clearCookie(w, nonceCookie)
+
+		onLogin(w, req, tok)
+	})
+}
+
+// setCookie sets a login cookie. It is marked Secure when the configured
+// redirect URL uses https, rather than trusting the request's headers.
+func (p *Provider) setCookie(w http.ResponseWriter, name, value string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int((10 * time.Minute).Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(p.cfg.RedirectURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func clearCookie(w http.ResponseWriter, name string) {
+	http.SetCookie(w, &http.Cookie{Name: name, Value: "", Path: "/", MaxAge: -1})
+}
+
+func equal(a, b string) bool {
+	return a != "" && subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+func randomString() string {
+	var b [24]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("oidc: unable to generate random string: " + err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
@@ -0,0 +1,352 @@
+// Package oidc provides an OpenID Connect client for authenticating users
+// with identity providers such as Auth0, Keycloak, Okta and Google.
+//
+// It handles provider discovery, the authorization code flow,
+// ID token validation and fetching user info:
+//
+//	var provider = oidc.New(oidc.Config{
+//		IssuerURL:    "https://accounts.google.com",
+//		ClientID:     secrets.OIDCClientID,
+//		ClientSecret: secrets.OIDCClientSecret,
+//		RedirectURL:  "https://app.example.com/oidc/callback",
+//	})
+//
+//	//encore:authhandler
+//	func AuthHandler(ctx context.Context, token string) (auth.UID, *oidc.Claims, error) {
+//		return provider.Authenticate(ctx, token)
+//	}
+//
+// The login flow itself can be served from raw endpoints using
+// LoginHandler and CallbackHandler.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"encore.dev/beta/auth"
+	"encore.dev/beta/auth/jwt"
+	"encore.dev/beta/errs"
+)
+
+// Config configures a Provider.
+type Config struct {
+	// IssuerURL is the URL of the OpenID Connect issuer,
+	// used to discover the provider's endpoints.
+	IssuerURL string
+
+	// ClientID and ClientSecret are the OAuth2 client credentials.
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is the URL the provider redirects back to after login,
+	// which must be served by CallbackHandler. It is required to use
+	// LoginHandler and CallbackHandler.
+	//
+	// The login cookies are only marked Secure when it is an https URL.
+	RedirectURL string
+
+	// Scopes are the scopes to request. It defaults to "openid", "profile" and "email".
+	// The "openid" scope is always included.
+	Scopes []string
+
+	// HTTPClient is the client used to communicate with the provider.
+	// It defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Claims are the standard claims of an OpenID Connect ID token.
+type Claims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Picture       string `json:"picture,omitempty"`
+}
+
+// Token is the result of a successful authorization code exchange.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	IDToken      string
+	Expiry       time.Time
+
+	// Claims are the validated claims of the ID token.
+	Claims *Claims
+}
+
+// minDiscoveryRetryInterval is the minimum time between discovery attempts
+// after a failed one, to avoid hitting an unavailable issuer on every request.
+const minDiscoveryRetryInterval = 30 * time.Second
+
+// discoveryTimeout is the timeout for fetching the provider metadata.
+const discoveryTimeout = 10 * time.Second
+
+// Provider is an OpenID Connect client for a single identity provider.
+// It is safe for concurrent use.
+type Provider struct {
+	cfg Config
+
+	group singleflight.Group // collapses concurrent discoveries
+
+	mu          sync.Mutex
+	disc        *discovery
+	validator   *jwt.Validator[Claims]
+	attemptedAt time.Time // time of the last failed discovery attempt
+	discErr     error     // error of the last failed discovery attempt, if any
+}
+
+// discovery is the subset of the provider metadata we use.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// New returns a new Provider.
+//
+// Discovery is performed lazily on first use, so New can be called
+// when initializing package-level variables.
+func New(cfg Config) *Provider {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	} else if !contains(cfg.Scopes, "openid") {
+		cfg.Scopes = append([]string{"openid"}, cfg.Scopes...)
+	}
+	return &Provider{cfg: cfg}
+}
+
+// discover returns the provider metadata, fetching it on first use and caching it on success.
+//
+// Concurrent callers share a single fetch, which is not held under p.mu. After a failed
+// fetch the error is returned without contacting the issuer again until
+// minDiscoveryRetryInterval has passed.
+func (p *Provider) discover(ctx context.Context) (*discovery, *jwt.Validator[Claims], error) {
+	p.mu.Lock()
+	disc, validator := p.disc, p.validator
+	discErr := p.discErr
+	retry := time.Since(p.attemptedAt) >= minDiscoveryRetryInterval
+	p.mu.Unlock()
+
+	switch {
+	case disc != nil:
+		return disc, validator, nil
+	case discErr != nil && !retry:
+		return nil, nil, discErr
+	}
+
+	_, err, _ := p.group.Do("", func() (any, error) {
+		// Don't let the caller that started the fetch cancel it for everyone.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), discoveryTimeout)
+		defer cancel()
+		disc, err := p.fetchDiscovery(ctx)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if err != nil {
+			p.attemptedAt, p.discErr = time.Now(), err
+			return nil, err
+		}
+		p.disc, p.discErr = disc, nil
+		p.validator = jwt.NewValidator[Claims](jwt.Config{
+			JWKSURL:    disc.JWKSURI,
+			Issuer:     disc.Issuer,
+			Audience:   p.cfg.ClientID,
+			HTTPClient: p.cfg.HTTPClient,
+		})
+		return nil, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.disc, p.validator, nil
+}
+
+// fetchDiscovery fetches and validates the provider metadata.
+func (p *Provider) fetchDiscovery(ctx context.Context) (*discovery, error) {
+	wellKnown := strings.TrimSuffix(p.cfg.IssuerURL, "/") + "/.well-known/openid-configuration"
+	var disc discovery
+	if err := p.getJSON(ctx, wellKnown, "", &disc); err != nil {
+		return nil, fmt.Errorf("oidc: discovery failed: %v", err)
+	}
+	if disc.Issuer != strings.TrimSuffix(p.cfg.IssuerURL, "/") && disc.Issuer != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("oidc: issuer mismatch: expected %q, got %q", p.cfg.IssuerURL, disc.Issuer)
+	}
+	return &disc, nil
+}
+
+// AuthCodeURL returns the URL to redirect the user to in order to log in.
+// The state and nonce values must be verified when handling the callback.
+func (p *Provider) AuthCodeURL(ctx context.Context, redirectURL, state, nonce string) (string, error) {
+	disc, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.cfg.ClientID},
+		"redirect_uri":  {redirectURL},
+		"scope":         {strings.Join(p.cfg.Scopes, " ")},
+		"state":         {state},
+	}
+	if nonce != "" {
+		q.Set("nonce", nonce)
+	}
+
+	sep := "?"
+	if strings.Contains(disc.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return disc.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange exchanges an authorization code for tokens,
+// and validates the returned ID token.
+func (p *Provider) Exchange(ctx context.Context, code, redirectURL string) (*Token, error) {
+	disc, _, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {redirectURL},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", disc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	var resp struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		IDToken      string `json:"id_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+		Error        string `json:"error"`
+		ErrorDesc    string `json:"error_description"`
+	}
+	if err := p.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("oidc: token exchange failed: %v", err)
+	} else if resp.Error != "" {
+		return nil, fmt.Errorf("oidc: token exchange failed: %s: %s", resp.Error, resp.ErrorDesc)
+	} else if resp.IDToken == "" {
+		return nil, fmt.Errorf("oidc: token response is missing id_token")
+	}
+
+	claims, err := p.VerifyIDToken(ctx, resp.IDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	tok := &Token{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		IDToken:      resp.IDToken,
+		Claims:       claims,
+	}
+	if resp.ExpiresIn > 0 {
+		tok.Expiry = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+	return tok, nil
+}
+
+// VerifyIDToken validates the ID token and returns its claims.
+func (p *Provider) VerifyIDToken(ctx context.Context, idToken string) (*Claims, error) {
+	_, validator, err := p.discover(ctx)
+	if err != nil {
+		return nil, errs.B().Code(errs.Unavailable).Msg("identity provider unavailable").Cause(err).Err()
+	}
+	return validator.Validate(ctx, idToken)
+}
+
+// Authenticate validates the ID token and returns the user id (the "sub" claim)
+// and claims, in the form expected from an auth handler.
+func (p *Provider) Authenticate(ctx context.Context, idToken string) (auth.UID, *Claims, error) {
+	claims, err := p.VerifyIDToken(ctx, idToken)
+	if err != nil {
+		return "", nil, err
+	}
+	return auth.UID(claims.Subject), claims, nil
+}
+
+// UserInfo fetches the claims about the user from the provider's userinfo endpoint.
+func (p *Provider) UserInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	disc, _, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	} else if disc.UserinfoEndpoint == "" {
+		return nil, fmt.Errorf("oidc: provider does not support the userinfo endpoint")
+	}
+
+	var info map[string]any
+	if err := p.getJSON(ctx, disc.UserinfoEndpoint, accessToken, &info); err != nil {
+		return nil, fmt.Errorf("oidc: userinfo request failed: %v", err)
+	}
+	return info, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url, bearer string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	return p.doJSON(req, dst)
+}
+
+func (p *Provider) doJSON(req *http.Request, dst any) error {
+	resp, err := p.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	// Token endpoints report errors as JSON with a 400 status code,
+	// so decode those too and let the caller inspect them.
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, dst); err != nil {
+		return fmt.Errorf("invalid response (status %d): %v", resp.StatusCode, err)
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeProvider struct {
+	t     *testing.T
+	srv   *httptest.Server
+	key   *rsa.PrivateKey
+	nonce string // nonce to include in issued ID tokens
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fp := &fakeProvider{t: t, key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, req *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 fp.srv.URL,
+			"authorization_endpoint": fp.srv.URL + "/authorize",
+			"token_endpoint":         fp.srv.URL + "/token",
+			"userinfo_endpoint":      fp.srv.URL + "/userinfo",
+			"jwks_uri":               fp.srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, req *http.Request) {
+		enc := base64.RawURLEncoding
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kid": "k1", "kty": "RSA",
+			"n": enc.EncodeToString(key.N.Bytes()),
+			"e": enc.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		if id, secret, _ := req.BasicAuth(); id != "client" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if req.FormValue("code") != "good-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"id_token":     fp.idToken("user-1"),
+			"expires_in":   3600,
+		})
+	})
+	fp.srv = httptest.NewServer(mux)
+	t.Cleanup(fp.srv.Close)
+	return fp
+}
+
+func (fp *fakeProvider) idToken(sub string) string {
+	enc := base64.RawURLEncoding
+	hdr, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "k1"})
+	payload, _ := json.Marshal(map[string]any{
+		"iss":   fp.srv.URL,
+		"sub":   sub,
+		"aud":   "client",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": fp.nonce,
+		"email": "user@example.com",
+	})
+	input := enc.EncodeToString(hdr) + "." + enc.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, fp.key, crypto.SHA256, digest[:])
+	if err != nil {
+		fp.t.Fatal(err)
+	}
+	return input + "." + enc.EncodeToString(sig)
+}
+
+func TestProvider_LoginFlow(t *testing.T) {
+	fp := newFakeProvider(t)
+	p := New(Config{
+		IssuerURL:    fp.srv.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:4000/oidc/callback",
+		HTTPClient:   fp.srv.Client(),
+	})
+
+	// Start the login.
+	w := httptest.NewRecorder()
+	p.LoginHandler().ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:4000/login", nil))
+	if w.Code != http.StatusFound {
+		t.Fatalf("got status %d, want %d", w.Code, http.StatusFound)
+	}
+	loc, _ := url.Parse(w.Header().Get("Location"))
+	if got := loc.Query().Get("redirect_uri"); got != "http://localhost:4000/oidc/callback" {
+		t.Fatalf("got redirect_uri %q", got)
+	}
+	fp.nonce = loc.Query().Get("nonce")
+
+	// Handle the callback.
+	cbURL := "http://localhost:4000/oidc/callback?code=good-code&state=" + url.QueryEscape(loc.Query().Get("state"))
+	req := httptest.NewRequest("GET", cbURL, nil)
+	for _, c := range w.Result().Cookies() {
+		req.AddCookie(c)
+	}
+
+	var got *Token
+	w = httptest.NewRecorder()
+	p.CallbackHandler(func(w http.ResponseWriter, req *http.Request, tok *Token) {
+		got = tok
+	}).ServeHTTP(w, req)
+	if got == nil {
+		t.Fatalf("login failed: %d %s", w.Code, w.Body.String())
+	}
+	if got.AccessToken != "access" || got.Claims.Subject != "user-1" || got.Claims.Email != "user@example.com" {
+		t.Fatalf("got token %+v", got)
+	}
+
+	// A mismatched state is rejected.
+	req = httptest.NewRequest("GET", "http://localhost:4000/oidc/callback?code=good-code&state=bad", nil)
+	req.AddCookie(&http.Cookie{Name: stateCookie, Value: "other"})
+	w = httptest.NewRecorder()
+	p.CallbackHandler(func(http.ResponseWriter, *http.Request, *Token) {
+		t.Fatal("unexpected login")
+	}).ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("got status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestProvider_Authenticate(t *testing.T) {
+	fp := newFakeProvider(t)
+	p := New(Config{IssuerURL: fp.srv.URL, ClientID: "client", HTTPClient: fp.srv.Client()})
+
+	uid, claims, err := p.Authenticate(context.Background(), fp.idToken("user-2"))
+	if err != nil {
+		t.Fatal(err)
+	} else if uid != "user-2" || claims.Email != "user@example.com" {
+		t.Fatalf("got uid %q and claims %+v", uid, claims)
+	}
+
+	if _, _, err := p.Authenticate(context.Background(), "invalid"); err == nil {
+		t.Fatal("expected error for invalid token")
+	}
+}
+
+func TestProvider_DiscoveryBackoff(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+	p := New(Config{IssuerURL: srv.URL, ClientID: "client", HTTPClient: srv.Client()})
+
+	// A failed discovery is not retried on every request.
+	for i := 0; i < 3; i++ {
+		if _, _, err := p.Authenticate(context.Background(), "token"); err == nil {
+			t.Fatal("expected error for unavailable issuer")
+		}
+	}
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("got %d discovery requests, want 1", got)
+	}
+}
@@ -0,0 +1,288 @@
+// Package session provides opaque session tokens with sliding expiration
+// and rotating refresh tokens, for use in auth handlers.
+//
+// Each session is identified by a short-lived access token, which is sent
+// with every request, and a refresh token, which is exchanged for a new pair
+// of tokens when the access token expires. Refresh tokens can only be used
+// once; presenting a refresh token that has already been used revokes the
+// session, since it indicates the token has been stolen.
+//
+//	var sessions = session.New(session.Config{
+//		Store: session.NewSQLStore(db),
+//	})
+//
+// Sessions can also be stored in a cache cluster using NewCacheStore.
+//
+//	//encore:authhandler
+//	func AuthHandler(ctx context.Context, token string) (auth.UID, *session.Session, error) {
+//		return sessions.Authenticate(ctx, token)
+//	}
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+)
+
+// Config configures a Manager.
+type Config struct {
+	// Store is where sessions are stored. It must be set.
+	Store Store
+
+	// AccessTokenTTL is how long access tokens are valid for.
+	// It defaults to 15 minutes.
+	AccessTokenTTL time.Duration
+
+	// IdleTimeout is how long a session stays valid without being used.
+	// Each use of the session extends it. It defaults to 7 days.
+	IdleTimeout time.Duration
+
+	// MaxLifetime is the maximum lifetime of a session,
+	// regardless of use. It defaults to 30 days.
+	MaxLifetime time.Duration
+}
+
+// Session describes an active session.
+type Session struct {
+	ID     string
+	UserID auth.UID
+
+	// Data is application-defined data stored with the session.
+	Data []byte
+
+	CreatedAt time.Time
+	ExpiresAt time.Time // when the session expires unless used again
+}
+
+// Tokens are the tokens for a session, to be handed to the client.
+type Tokens struct {
+	AccessToken     string
+	AccessExpiresAt time.Time
+	RefreshToken    string
+}
+
+// Manager creates, refreshes and validates sessions.
+// It is safe for concurrent use.
+type Manager struct {
+	cfg Config
+	now func() time.Time
+}
+
+// New returns a new Manager.
+func New(cfg Config) *Manager {
+	if cfg.Store == nil {
+		panic("session: Config.Store must be set")
+	}
+	if cfg.AccessTokenTTL == 0 {
+		cfg.AccessTokenTTL = 15 * time.Minute
+	}
+	if cfg.IdleTimeout == 0 {
+		cfg.IdleTimeout = 7 * 24 * time.Hour
+	}
+	if cfg.MaxLifetime == 0 {
+		cfg.MaxLifetime = 30 * 24 * time.Hour
+	}
+	return &Manager{cfg: cfg, now: time.Now}
+}
+
+// Create creates a new session for the given user, such as after logging in.
+func (m *Manager) Create(ctx context.Context, uid auth.UID, data []byte) (*Tokens, error) {
+	if uid == "" {
+		return nil, errs.B().Code(errs.InvalidArgument).Msg("missing user id").Err()
+	}
+
+	now := m.now().UTC()
+	rec := &Record{
+		ID:          hex.EncodeToString(randomBytes(16)),
+		UserID:      uid,
+		Data:        data,
+		CreatedAt:   now,
+		LastUsedAt:  now,
+		MaxExpireAt: now.Add(m.cfg.MaxLifetime),
+	}
+	tokens := m.issue(rec, now)
+	if err := m.cfg.Store.Create(ctx, rec); err != nil {
+		return nil, errs.B().Code(errs.Internal).Msg("unable to create session").Cause(err).Err()
+	}
+	return tokens, nil
+}
+
+// Authenticate validates the access token and returns the user id and session,
+// in the form expected from an auth handler.
+//
+// The token may optionally be prefixed with "Bearer ". If the token is invalid
+// or expired, or the session has been revoked, the returned error has the code
+// errs.Unauthenticated.
+func (m *Manager) Authenticate(ctx context.Context, accessToken string) (auth.UID, *Session, error) {
+	id, raw, ok := parseToken(strings.TrimPrefix(accessToken, "Bearer "))
+	if !ok {
+		return "", nil, invalidSession("invalid access token")
+	}
+	rec, err := m.get(ctx, id)
+	if err != nil {
+		return "", nil, err
+	} else if subtle.ConstantTimeCompare(rec.AccessHash, hash(raw)) != 1 {
+		return "", nil, invalidSession("invalid access token")
+	}
+
+	now := m.now()
+	if !now.Before(rec.AccessExpiresAt) {
+		return "", nil, invalidSession("access token has expired")
+	}
+
+	// Extend the session, but avoid writing on every request.
+	if now.Sub(rec.LastUsedAt) >= m.cfg.IdleTimeout/100 {
+		rec.LastUsedAt = now.UTC()
+		if err := m.cfg.Store.Touch(ctx, rec.ID, rec.LastUsedAt); err != nil {
+			return "", nil, errs.B().Code(errs.Unavailable).Msg("unable to update session").Cause(err).Err()
+		}
+	}
+	return rec.UserID, m.session(rec), nil
+}
+
+// Refresh exchanges a refresh token for a new set of tokens.
+// The refresh token is rotated and cannot be used again.
+//
+// If any of the last MaxUsedRefreshHashes refresh tokens previously issued for
+// the session is reused, the session is revoked, and the returned error has the
+// code errs.Unauthenticated.
+func (m *Manager) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	id, raw, ok := parseToken(refreshToken)
+	if !ok {
+		return nil, invalidSession("invalid refresh token")
+	}
+	rec, err := m.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	h := hash(raw)
+	if subtle.ConstantTimeCompare(rec.RefreshHash, h) != 1 {
+		// Any previously issued refresh token for this session means it has been
+		// used twice, so one of the holders must not be the legitimate client.
+		// Revoke the whole session, along with all tokens issued from it.
+		for _, used := range rec.UsedRefreshHashes {
+			if subtle.ConstantTimeCompare(used, h) == 1 {
+				_ = m.Revoke(ctx, rec.ID)
+				return nil, invalidSession("refresh token reused; session revoked")
+			}
+		}
+		return nil, invalidSession("invalid refresh token")
+	}
+
+	now := m.now().UTC()
+	rec.LastUsedAt = now
+	tokens := m.issue(rec, now)
+	if err := m.cfg.Store.Rotate(ctx, rec); errors.Is(err, ErrConflict) {
+		// The token was concurrently refreshed.
+		return nil, invalidSession("invalid refresh token")
+	} else if err != nil {
+		return nil, errs.B().Code(errs.Unavailable).Msg("unable to update session").Cause(err).Err()
+	}
+	return tokens, nil
+}
+
+// Revoke revokes the session with the given id, such as when logging out.
+func (m *Manager) Revoke(ctx context.Context, id string) error {
+	if err := m.cfg.Store.Revoke(ctx, id, m.now().UTC()); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return errs.B().Code(errs.NotFound).Msg("session not found").Err()
+		}
+		return errs.B().Code(errs.Internal).Msg("unable to revoke session").Cause(err).Err()
+	}
+	return nil
+}
+
+// RevokeUser revokes all sessions belonging to the given user,
+// such as after changing their password.
+func (m *Manager) RevokeUser(ctx context.Context, uid auth.UID) error {
+	if err := m.cfg.Store.RevokeUser(ctx, uid, m.now().UTC()); err != nil {
+		return errs.B().Code(errs.Internal).Msg("unable to revoke sessions").Cause(err).Err()
+	}
+	return nil
+}
+
+// issue generates new tokens for the session, storing their hashes in rec.
+func (m *Manager) issue(rec *Record, now time.Time) *Tokens {
+	access := base64.RawURLEncoding.EncodeToString(randomBytes(32))
+	refresh := base64.RawURLEncoding.EncodeToString(randomBytes(32))
+
+	rec.AccessHash = hash(access)
+	rec.AccessExpiresAt = now.Add(m.cfg.AccessTokenTTL)
+	rec.PrevRefreshHash = rec.RefreshHash
+	rec.RefreshHash = hash(refresh)
+
+	return &Tokens{
+		AccessToken:     rec.ID + "." + access,
+		AccessExpiresAt: rec.AccessExpiresAt,
+		RefreshToken:    rec.ID + "." + refresh,
+	}
+}
+
+// get returns the session with the given id if it is still active.
+func (m *Manager) get(ctx context.Context, id string) (*Record, error) {
+	rec, err := m.cfg.Store.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return nil, invalidSession("unknown session")
+	} else if err != nil {
+		return nil, errs.B().Code(errs.Unavailable).Msg("unable to look up session").Cause(err).Err()
+	}
+
+	now := m.now()
+	if rec.RevokedAt != nil {
+		return nil, invalidSession("session has been revoked")
+	} else if !now.Before(m.expiresAt(rec)) {
+		return nil, invalidSession("session has expired")
+	}
+	return rec, nil
+}
+
+func (m *Manager) expiresAt(rec *Record) time.Time {
+	exp := rec.LastUsedAt.Add(m.cfg.IdleTimeout)
+	if rec.MaxExpireAt.Before(exp) {
+		exp = rec.MaxExpireAt
+	}
+	return exp
+}
+
+func (m *Manager) session(rec *Record) *Session {
+	return &Session{
+		ID:        rec.ID,
+		UserID:    rec.UserID,
+		Data:      rec.Data,
+		CreatedAt: rec.CreatedAt,
+		ExpiresAt: m.expiresAt(rec),
+	}
+}
+
+func parseToken(token string) (id, raw string, ok bool) {
+	id, raw, ok = strings.Cut(token, ".")
+	return id, raw, ok && id != "" && raw != ""
+}
+
+func invalidSession(msg string) error {
+	return errs.B().Code(errs.Unauthenticated).Msg(msg).Err()
+}
+
+func hash(raw string) []byte {
+	h := sha256.Sum256([]byte(raw))
+	return h[:]
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic("session: unable to generate random bytes: " + err.Error())
+	}
+	return b
+}
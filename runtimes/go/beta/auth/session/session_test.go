@@ -0,0 +1,340 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/clock"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/storage/cache"
+)
+
+type memStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+}
+
+func newMemStore() *memStore {
+	return &memStore{records: make(map[string]Record)}
+}
+
+func (s *memStore) Create(_ context.Context, rec *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[rec.ID] = *rec
+	return nil
+}
+
+func (s *memStore) Get(_ context.Context, id string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.records[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &rec, nil
+}
+
+func (s *memStore) Touch(_ context.Context, id string, lastUsedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec := s.records[id]
+	rec.LastUsedAt = lastUsedAt
+	s.records[id] = rec
+	return nil
+}
+
+func (s *memStore) Rotate(_ context.Context, rec *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := s.records[rec.ID]
+	if !bytes.Equal(stored.RefreshHash, rec.PrevRefreshHash) || stored.RevokedAt != nil {
+		return ErrConflict
+	}
+	updated := *rec
+	updated.UsedRefreshHashes = append(stored.UsedRefreshHashes[:len(stored.UsedRefreshHashes):len(stored.UsedRefreshHashes)], rec.PrevRefreshHash)
+	if n := len(updated.UsedRefreshHashes); n > MaxUsedRefreshHashes {
+		updated.UsedRefreshHashes = updated.UsedRefreshHashes[n-MaxUsedRefreshHashes:]
+	}
+	s.records[rec.ID] = updated
+	return nil
+}
+
+func (s *memStore) Revoke(_ context.Context, id string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.records[id]
+	if !ok {
+		return ErrNotFound
+	}
+	rec.RevokedAt = &at
+	s.records[id] = rec
+	return nil
+}
+
+func (s *memStore) RevokeUser(_ context.Context, uid auth.UID, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, rec := range s.records {
+		if rec.UserID == uid {
+			rec.RevokedAt = &at
+			s.records[id] = rec
+		}
+	}
+	return nil
+}
+
+// testStores returns constructors for the stores to run the tests against.
+func testStores(t *testing.T) map[string]func() Store {
+	return map[string]func() Store{
+		"mem": func() Store { return newMemStore() },
+		"redis": func() Store {
+			srv := miniredis.RunT(t)
+			cl := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+			t.Cleanup(func() { _ = cl.Close() })
+			return NewCacheStoreInternal(cl)
+		},
+		"cache_test_mode": func() Store {
+			// In tests cache clusters use an in-memory store instead of Redis,
+			// which must support the scripts used by the store.
+			static := &config.Static{Testing: true}
+			rt := reqtrack.New(zerolog.Nop(), nil, nil)
+			ts := testsupport.NewManager(static, rt, zerolog.Nop())
+			mgr := cache.NewManager(static, nil, rt, ts, clock.New(static, ts), nil, nil)
+			return NewCacheStore(cache.NewClusterInternal(mgr, "sessions", cache.ClusterConfig{}))
+		},
+	}
+}
+
+// runWithStores runs fn as a subtest for each store.
+func runWithStores(t *testing.T, fn func(t *testing.T, store Store)) {
+	for name, newStore := range testStores(t) {
+		t.Run(name, func(t *testing.T) { fn(t, newStore()) })
+	}
+}
+
+func newTestManager(cfg Config) (*Manager, *time.Time) {
+	if cfg.Store == nil {
+		cfg.Store = newMemStore()
+	}
+	m := New(cfg)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func wantUnauthenticated(t *testing.T, err error) {
+	t.Helper()
+	if errs.Code(err) != errs.Unauthenticated {
+		t.Fatalf("got err %v, want Unauthenticated", err)
+	}
+}
+
+func TestManager_Lifecycle(t *testing.T) {
+	runWithStores(t, testLifecycle)
+}
+
+func testLifecycle(t *testing.T, store Store) {
+	ctx := context.Background()
+	m, now := newTestManager(Config{Store: store, AccessTokenTTL: time.Minute})
+
+	tokens, err := m.Create(ctx, "user", []byte("data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uid, sess, err := m.Authenticate(ctx, "Bearer "+tokens.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	} else if uid != "user" || string(sess.Data) != "data" {
+		t.Fatalf("got uid %q and session %+v", uid, sess)
+	}
+
+	// The refresh token is not an access token.
+	_, _, err = m.Authenticate(ctx, tokens.RefreshToken)
+	wantUnauthenticated(t, err)
+
+	// Access tokens expire, and are replaced by refreshing.
+	*now = now.Add(time.Minute)
+	_, _, err = m.Authenticate(ctx, tokens.AccessToken)
+	wantUnauthenticated(t, err)
+
+	refreshed, err := m.Refresh(ctx, tokens.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := m.Authenticate(ctx, refreshed.AccessToken); err != nil {
+		t.Fatal(err)
+	}
+
+	// Logging out revokes the session.
+	if err := m.Revoke(ctx, sess.ID); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = m.Authenticate(ctx, refreshed.AccessToken)
+	wantUnauthenticated(t, err)
+	_, err = m.Refresh(ctx, refreshed.RefreshToken)
+	wantUnauthenticated(t, err)
+}
+
+func TestManager_RefreshReuse(t *testing.T) {
+	runWithStores(t, testRefreshReuse)
+}
+
+func testRefreshReuse(t *testing.T, store Store) {
+	ctx := context.Background()
+	m, _ := newTestManager(Config{Store: store})
+
+	tokens, err := m.Create(ctx, "user", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refreshed, err := m.Refresh(ctx, tokens.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reusing the old refresh token revokes the session entirely.
+	_, err = m.Refresh(ctx, tokens.RefreshToken)
+	wantUnauthenticated(t, err)
+	_, _, err = m.Authenticate(ctx, refreshed.AccessToken)
+	wantUnauthenticated(t, err)
+	_, err = m.Refresh(ctx, refreshed.RefreshToken)
+	wantUnauthenticated(t, err)
+
+	// So does reusing any earlier refresh token, not just the previous one.
+	tokens, err = m.Create(ctx, "user", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := tokens
+	for i := 0; i < 3; i++ {
+		if tokens, err = m.Refresh(ctx, tokens.RefreshToken); err != nil {
+			t.Fatalf("refresh %d: %v", i, err)
+		}
+	}
+	_, err = m.Refresh(ctx, first.RefreshToken)
+	wantUnauthenticated(t, err)
+	_, _, err = m.Authenticate(ctx, tokens.AccessToken)
+	wantUnauthenticated(t, err)
+
+	// Unknown refresh tokens don't revoke the session.
+	tokens, err = m.Create(ctx, "user", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _, _ := strings.Cut(tokens.RefreshToken, ".")
+	_, err = m.Refresh(ctx, id+".forged")
+	wantUnauthenticated(t, err)
+	if _, _, err := m.Authenticate(ctx, tokens.AccessToken); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManager_UsedRefreshHashesBounded(t *testing.T) {
+	runWithStores(t, testUsedRefreshHashesBounded)
+}
+
+func testUsedRefreshHashesBounded(t *testing.T, store Store) {
+	ctx := context.Background()
+	m, _ := newTestManager(Config{Store: store})
+
+	tokens, err := m.Create(ctx, "user", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issued := []*Tokens{tokens}
+	for i := 0; i < MaxUsedRefreshHashes+2; i++ {
+		if tokens, err = m.Refresh(ctx, tokens.RefreshToken); err != nil {
+			t.Fatalf("refresh %d: %v", i, err)
+		}
+		issued = append(issued, tokens)
+	}
+
+	id, _, _ := strings.Cut(tokens.RefreshToken, ".")
+	rec, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	} else if got := len(rec.UsedRefreshHashes); got != MaxUsedRefreshHashes {
+		t.Fatalf("got %d used refresh hashes, want %d", got, MaxUsedRefreshHashes)
+	}
+
+	// Tokens rotated out before the kept ones are rejected without revoking the session.
+	_, err = m.Refresh(ctx, issued[0].RefreshToken)
+	wantUnauthenticated(t, err)
+	if _, _, err := m.Authenticate(ctx, tokens.AccessToken); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reusing a recently rotated out token still revokes it.
+	_, err = m.Refresh(ctx, issued[len(issued)-2].RefreshToken)
+	wantUnauthenticated(t, err)
+	_, _, err = m.Authenticate(ctx, tokens.AccessToken)
+	wantUnauthenticated(t, err)
+}
+
+func TestManager_Expiration(t *testing.T) {
+	ctx := context.Background()
+	m, now := newTestManager(Config{AccessTokenTTL: time.Hour, IdleTimeout: 2 * time.Hour, MaxLifetime: 5 * time.Hour})
+
+	tokens, err := m.Create(ctx, "user", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Refreshing within the idle timeout keeps the session alive...
+	for i := 0; i < 3; i++ {
+		*now = now.Add(90 * time.Minute)
+		if tokens, err = m.Refresh(ctx, tokens.RefreshToken); err != nil {
+			t.Fatalf("refresh %d: %v", i, err)
+		}
+	}
+	// ...but not beyond its maximum lifetime.
+	*now = now.Add(90 * time.Minute)
+	_, err = m.Refresh(ctx, tokens.RefreshToken)
+	wantUnauthenticated(t, err)
+
+	// Sessions expire when idle.
+	tokens, err = m.Create(ctx, "user", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(2 * time.Hour)
+	_, err = m.Refresh(ctx, tokens.RefreshToken)
+	wantUnauthenticated(t, err)
+}
+
+func TestManager_RevokeUser(t *testing.T) {
+	runWithStores(t, testRevokeUser)
+}
+
+func testRevokeUser(t *testing.T, store Store) {
+	ctx := context.Background()
+	m, _ := newTestManager(Config{Store: store})
+
+	a, _ := m.Create(ctx, "user", nil)
+	b, _ := m.Create(ctx, "user", nil)
+	other, _ := m.Create(ctx, "other", nil)
+	if err := m.RevokeUser(ctx, "user"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tok := range []*Tokens{a, b} {
+		_, _, err := m.Authenticate(ctx, tok.AccessToken)
+		wantUnauthenticated(t, err)
+	}
+	if _, _, err := m.Authenticate(ctx, other.AccessToken); err != nil {
+		t.Fatal(err)
+	}
+}
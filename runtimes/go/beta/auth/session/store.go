@@ -0,0 +1,468 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"encore.dev/beta/auth"
+	"encore.dev/storage/cache"
+	"encore.dev/storage/sqldb"
+)
+
+var (
+	// ErrNotFound is returned by a Store when a session does not exist.
+	ErrNotFound = errors.New("session: not found")
+
+	// ErrConflict is returned by Store.Rotate when the session's
+	// refresh token has changed since it was read.
+	ErrConflict = errors.New("session: conflict")
+)
+
+// Record is the stored representation of a session.
+// Tokens are never stored, only their hashes.
+type Record struct {
+	ID     string
+	UserID auth.UID
+	Data   []byte
+
+	AccessHash      []byte
+	AccessExpiresAt time.Time
+	RefreshHash     []byte
+	PrevRefreshHash []byte // hash of the refresh token being rotated out
+
+	// UsedRefreshHashes are the hashes of the most recent refresh tokens
+	// rotated out of the session, oldest first, to detect the reuse of any
+	// of them. At most MaxUsedRefreshHashes are kept.
+	UsedRefreshHashes [][]byte
+
+	CreatedAt   time.Time
+	LastUsedAt  time.Time
+	MaxExpireAt time.Time
+	RevokedAt   *time.Time
+}
+
+// MaxUsedRefreshHashes is the number of rotated out refresh token hashes
+// kept for each session. Reuse of a refresh token rotated out longer ago
+// is rejected without revoking the session.
+const MaxUsedRefreshHashes = 32
+
+// Store stores sessions.
+//
+// NewSQLStore provides a Store backed by a SQL database, and NewCacheStore
+// one backed by a cache cluster. Other backends can be supported by
+// implementing this interface.
+type Store interface {
+	// Create stores a new session.
+	Create(ctx context.Context, rec *Record) error
+
+	// Get returns the session with the given id, or ErrNotFound.
+	Get(ctx context.Context, id string) (*Record, error)
+
+	// Touch updates the time the session was last used.
+	Touch(ctx context.Context, id string, lastUsedAt time.Time) error
+
+	// Rotate stores the session's new token hashes and last used time, provided the
+	// stored refresh token hash equals rec.PrevRefreshHash. Otherwise it returns ErrConflict.
+	// It adds rec.PrevRefreshHash to the session's used refresh token hashes,
+	// dropping the oldest ones to keep at most MaxUsedRefreshHashes.
+	Rotate(ctx context.Context, rec *Record) error
+
+	// Revoke revokes the session with the given id, or returns ErrNotFound.
+	Revoke(ctx context.Context, id string, at time.Time) error
+
+	// RevokeUser revokes all sessions belonging to the user.
+	RevokeUser(ctx context.Context, uid auth.UID, at time.Time) error
+}
+
+// Schema is the SQL needed to create the table used by NewSQLStore.
+// Add it to a migration for the database passed to NewSQLStore.
+const Schema = `CREATE TABLE encore_sessions (
+	id                  TEXT PRIMARY KEY,
+	user_id             TEXT NOT NULL,
+	data                BYTEA,
+	access_hash         BYTEA NOT NULL,
+	access_expires_at   TIMESTAMPTZ NOT NULL,
+	refresh_hash        BYTEA NOT NULL,
+	prev_refresh_hash   BYTEA,
+	used_refresh_hashes BYTEA,
+	created_at          TIMESTAMPTZ NOT NULL,
+	last_used_at        TIMESTAMPTZ NOT NULL,
+	max_expire_at       TIMESTAMPTZ NOT NULL,
+	revoked_at          TIMESTAMPTZ
+);
+
+CREATE INDEX encore_sessions_user_id ON encore_sessions (user_id);
+`
+
+// NewSQLStore returns a Store that stores sessions in the encore_sessions table
+// of the given database, which must be created using Schema.
+//
+// Expired sessions are not deleted automatically, and can be cleaned up
+// periodically using a cron job.
+func NewSQLStore(db *sqldb.Database) Store {
+	return &sqlStore{db: db}
+}
+
+type sqlStore struct {
+	db *sqldb.Database
+}
+
+func (s *sqlStore) Create(ctx context.Context, r *Record) error {
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO encore_sessions (
+			id, user_id, data, access_hash, access_expires_at, refresh_hash,
+			prev_refresh_hash, created_at, last_used_at, max_expire_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, r.ID, string(r.UserID), r.Data, r.AccessHash, r.AccessExpiresAt, r.RefreshHash,
+		r.PrevRefreshHash, r.CreatedAt, r.LastUsedAt, r.MaxExpireAt)
+	return err
+}
+
+func (s *sqlStore) Get(ctx context.Context, id string) (*Record, error) {
+	var (
+		r    Record
+		uid  string
+		used []byte
+	)
+	err := s.db.QueryRow(ctx, `
+		SELECT
+			id, user_id, data, access_hash, access_expires_at, refresh_hash,
+			prev_refresh_hash, used_refresh_hashes, created_at, last_used_at,
+			max_expire_at, revoked_at
+		FROM encore_sessions
+		WHERE id = $1
+	`, id).Scan(&r.ID, &uid, &r.Data, &r.AccessHash, &r.AccessExpiresAt, &r.RefreshHash,
+		&r.PrevRefreshHash, &used, &r.CreatedAt, &r.LastUsedAt, &r.MaxExpireAt, &r.RevokedAt)
+	if errors.Is(err, sqldb.ErrNoRows) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	r.UserID = auth.UID(uid)
+	r.UsedRefreshHashes = splitHashes(used)
+	return &r, nil
+}
+
+func (s *sqlStore) Touch(ctx context.Context, id string, lastUsedAt time.Time) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE encore_sessions SET last_used_at = GREATEST(last_used_at, $2) WHERE id = $1
+	`, id, lastUsedAt)
+	return err
+}
+
+func (s *sqlStore) Rotate(ctx context.Context, r *Record) error {
+	// Keep the last $7 bytes of the used hashes once the new one is appended.
+	res, err := s.db.Exec(ctx, `
+		UPDATE encore_sessions
+		SET access_hash = $2, access_expires_at = $3, refresh_hash = $4,
+			prev_refresh_hash = $5, last_used_at = $6,
+			used_refresh_hashes = substring(
+				COALESCE(used_refresh_hashes, ''::bytea) || $5
+				FROM greatest(octet_length(COALESCE(used_refresh_hashes, ''::bytea)) + octet_length($5) - $7 + 1, 1))
+		WHERE id = $1 AND refresh_hash = $5 AND revoked_at IS NULL
+	`, r.ID, r.AccessHash, r.AccessExpiresAt, r.RefreshHash, r.PrevRefreshHash, r.LastUsedAt,
+		MaxUsedRefreshHashes*sha256.Size)
+	if err != nil {
+		return err
+	} else if res.RowsAffected() == 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+func (s *sqlStore) Revoke(ctx context.Context, id string, at time.Time) error {
+	res, err := s.db.Exec(ctx, `
+		UPDATE encore_sessions SET revoked_at = COALESCE(revoked_at, $2) WHERE id = $1
+	`, id, at)
+	if err != nil {
+		return err
+	} else if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *sqlStore) RevokeUser(ctx context.Context, uid auth.UID, at time.Time) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE encore_sessions SET revoked_at = $2 WHERE user_id = $1 AND revoked_at IS NULL
+	`, string(uid), at)
+	return err
+}
+
+// splitHashes splits concatenated SHA-256 hashes.
+func splitHashes(b []byte) [][]byte {
+	var hashes [][]byte
+	for len(b) >= sha256.Size {
+		hashes = append(hashes, b[:sha256.Size])
+		b = b[sha256.Size:]
+	}
+	return hashes
+}
+
+// lastN returns the last n bytes of s.
+func lastN(s string, n int) string {
+	if len(s) > n {
+		return s[len(s)-n:]
+	}
+	return s
+}
+
+// NewCacheStore returns a Store that stores sessions in the given cache cluster.
+//
+// Sessions are evicted from the cache at the end of their maximum lifetime.
+// Use a cluster with a volatile eviction policy (or NoEviction) so that
+// sessions aren't evicted earlier to make room for other data.
+func NewCacheStore(cluster *cache.Cluster) Store {
+	return NewCacheStoreInternal(cluster.RedisClient())
+}
+
+// NewCacheStoreInternal returns a Store that stores sessions using the given Redis client.
+//
+//publicapigen:drop
+func NewCacheStoreInternal(cl *redis.Client) Store {
+	return &cacheStore{cl: cl}
+}
+
+type cacheStore struct {
+	cl *redis.Client
+}
+
+// cacheRecord is the representation of a session in the cache.
+// Hashes are hex encoded and times are in unix milliseconds,
+// so the scripts updating sessions can work with them directly.
+type cacheRecord struct {
+	UserID          string `json:"uid"`
+	Data            []byte `json:"data"`
+	AccessHash      string `json:"access"`
+	AccessExpiresAt int64  `json:"access_exp"`
+	RefreshHash     string `json:"refresh"`
+	PrevRefreshHash string `json:"prev_refresh"`
+	UsedHashes      string `json:"used"` // concatenated
+	CreatedAt       int64  `json:"created"`
+	LastUsedAt      int64  `json:"last_used"`
+	MaxExpireAt     int64  `json:"max_exp"`
+	RevokedAt       int64  `json:"revoked"` // 0 if not revoked
+}
+
+var (
+	touchScript = cache.NewScriptInternal(`
+		local data = redis.call("GET", KEYS[1])
+		if not data then
+			return 0
+		end
+		local rec = cjson.decode(data)
+		if rec.last_used < tonumber(ARGV[1]) then
+			rec.last_used = tonumber(ARGV[1])
+			redis.call("SET", KEYS[1], cjson.encode(rec), "KEEPTTL")
+		end
+		return 1`,
+		func(call func(args ...string) (any, error), keys, args []string) (any, error) {
+			return updateCacheRecord(call, keys[0], func(rec *cacheRecord) bool {
+				lastUsed, _ := strconv.ParseInt(args[0], 10, 64)
+				rec.LastUsedAt = max(rec.LastUsedAt, lastUsed)
+				return true
+			})
+		})
+
+	rotateScript = cache.NewScriptInternal(`
+		local data = redis.call("GET", KEYS[1])
+		if not data then
+			return 0
+		end
+		local rec = cjson.decode(data)
+		if rec.refresh ~= ARGV[4] or rec.revoked ~= 0 then
+			return 0
+		end
+		rec.access, rec.access_exp, rec.refresh = ARGV[1], tonumber(ARGV[2]), ARGV[3]
+		rec.prev_refresh, rec.last_used = ARGV[4], tonumber(ARGV[5])
+		rec.used = string.sub(rec.used .. ARGV[4], -tonumber(ARGV[6]))
+		redis.call("SET", KEYS[1], cjson.encode(rec), "KEEPTTL")
+		return 1`,
+		func(call func(args ...string) (any, error), keys, args []string) (any, error) {
+			return updateCacheRecord(call, keys[0], func(rec *cacheRecord) bool {
+				if rec.RefreshHash != args[3] || rec.RevokedAt != 0 {
+					return false
+				}
+				rec.AccessHash, rec.RefreshHash = args[0], args[2]
+				rec.AccessExpiresAt, _ = strconv.ParseInt(args[1], 10, 64)
+				rec.PrevRefreshHash = args[3]
+				rec.LastUsedAt, _ = strconv.ParseInt(args[4], 10, 64)
+				maxLen, _ := strconv.Atoi(args[5])
+				rec.UsedHashes = lastN(rec.UsedHashes+args[3], maxLen)
+				return true
+			})
+		})
+
+	revokeScript = cache.NewScriptInternal(`
+		local data = redis.call("GET", KEYS[1])
+		if not data then
+			return 0
+		end
+		local rec = cjson.decode(data)
+		if rec.revoked == 0 then
+			rec.revoked = tonumber(ARGV[1])
+			redis.call("SET", KEYS[1], cjson.encode(rec), "KEEPTTL")
+		end
+		return 1`,
+		func(call func(args ...string) (any, error), keys, args []string) (any, error) {
+			return updateCacheRecord(call, keys[0], func(rec *cacheRecord) bool {
+				if rec.RevokedAt == 0 {
+					rec.RevokedAt, _ = strconv.ParseInt(args[0], 10, 64)
+				}
+				return true
+			})
+		})
+)
+
+// updateCacheRecord implements the scripts updating a session for the in-memory store.
+// It returns 1 if update returns true, and 0 if it returns false or the session doesn't exist.
+func updateCacheRecord(call func(args ...string) (any, error), key string, update func(rec *cacheRecord) bool) (any, error) {
+	data, err := call("GET", key)
+	if err != nil || data == nil {
+		return int64(0), err
+	}
+	var rec cacheRecord
+	if err := json.Unmarshal([]byte(data.(string)), &rec); err != nil {
+		return nil, err
+	} else if !update(&rec) {
+		return int64(0), nil
+	}
+	updated, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := call("SET", key, string(updated), "KEEPTTL"); err != nil {
+		return nil, err
+	}
+	return int64(1), nil
+}
+
+func (s *cacheStore) key(id string) string {
+	return "__encore/session/{" + id + "}"
+}
+
+func (s *cacheStore) userKey(uid auth.UID) string {
+	return "__encore/session-user/{" + string(uid) + "}"
+}
+
+func (s *cacheStore) Create(ctx context.Context, r *Record) error {
+	data, err := json.Marshal(cacheRecord{
+		UserID:          string(r.UserID),
+		Data:            r.Data,
+		AccessHash:      hex.EncodeToString(r.AccessHash),
+		AccessExpiresAt: r.AccessExpiresAt.UnixMilli(),
+		RefreshHash:     hex.EncodeToString(r.RefreshHash),
+		PrevRefreshHash: hex.EncodeToString(r.PrevRefreshHash),
+		CreatedAt:       r.CreatedAt.UnixMilli(),
+		LastUsedAt:      r.LastUsedAt.UnixMilli(),
+		MaxExpireAt:     r.MaxExpireAt.UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Sessions are indexed by user for RevokeUser. As the maximum lifetime
+	// of sessions is the same, the index expires with the newest session.
+	_, err = s.cl.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.SetArgs(ctx, s.key(r.ID), data, redis.SetArgs{Mode: "NX", ExpireAt: r.MaxExpireAt})
+		p.SAdd(ctx, s.userKey(r.UserID), r.ID)
+		p.PExpireAt(ctx, s.userKey(r.UserID), r.MaxExpireAt)
+		return nil
+	})
+	return err
+}
+
+func (s *cacheStore) Get(ctx context.Context, id string) (*Record, error) {
+	data, err := s.cl.Get(ctx, s.key(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	var cr cacheRecord
+	if err := json.Unmarshal(data, &cr); err != nil {
+		return nil, err
+	}
+	r := &Record{
+		ID:              id,
+		UserID:          auth.UID(cr.UserID),
+		Data:            cr.Data,
+		AccessExpiresAt: time.UnixMilli(cr.AccessExpiresAt).UTC(),
+		CreatedAt:       time.UnixMilli(cr.CreatedAt).UTC(),
+		LastUsedAt:      time.UnixMilli(cr.LastUsedAt).UTC(),
+		MaxExpireAt:     time.UnixMilli(cr.MaxExpireAt).UTC(),
+	}
+	if cr.RevokedAt != 0 {
+		revokedAt := time.UnixMilli(cr.RevokedAt).UTC()
+		r.RevokedAt = &revokedAt
+	}
+	var used []byte
+	for _, h := range []struct {
+		dst *[]byte
+		src string
+	}{
+		{&r.AccessHash, cr.AccessHash},
+		{&r.RefreshHash, cr.RefreshHash},
+		{&r.PrevRefreshHash, cr.PrevRefreshHash},
+		{&used, cr.UsedHashes},
+	} {
+		if *h.dst, err = hex.DecodeString(h.src); err != nil {
+			return nil, err
+		}
+	}
+	r.UsedRefreshHashes = splitHashes(used)
+	return r, nil
+}
+
+func (s *cacheStore) Touch(ctx context.Context, id string, lastUsedAt time.Time) error {
+	return touchScript.Run(ctx, s.cl, []string{s.key(id)}, lastUsedAt.UnixMilli()).Err()
+}
+
+func (s *cacheStore) Rotate(ctx context.Context, r *Record) error {
+	ok, err := rotateScript.Run(ctx, s.cl, []string{s.key(r.ID)},
+		hex.EncodeToString(r.AccessHash), r.AccessExpiresAt.UnixMilli(),
+		hex.EncodeToString(r.RefreshHash), hex.EncodeToString(r.PrevRefreshHash),
+		r.LastUsedAt.UnixMilli(), MaxUsedRefreshHashes*2*sha256.Size).Int64()
+	if err != nil {
+		return err
+	} else if ok == 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+func (s *cacheStore) Revoke(ctx context.Context, id string, at time.Time) error {
+	ok, err := revokeScript.Run(ctx, s.cl, []string{s.key(id)}, at.UnixMilli()).Int64()
+	if err != nil {
+		return err
+	} else if ok == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *cacheStore) RevokeUser(ctx context.Context, uid auth.UID, at time.Time) error {
+	ids, err := s.cl.SMembers(ctx, s.userKey(uid)).Result()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := s.Revoke(ctx, id, at); errors.Is(err, ErrNotFound) {
+			// The session has expired; drop it from the index.
+			if err := s.cl.SRem(ctx, s.userKey(uid), id).Err(); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// envPathSeparator separates the path segments of a config value
+// within an override environment variable name.
+//
+// For example ENCORE_CFG_MYSVC__DATABASE__MAX_CONNS overrides
+// the value at the path ["Database", "MaxConns"] for the "mysvc" service.
+const envPathSeparator = "__"
+
+// envOverride is a single config value override read from the environment.
+type envOverride struct {
+	envName string
+	path    []string
+	value   string
+}
+
+// parseEnvOverrides returns the overrides for the given service,
+// ordered so that less specific paths are applied first.
+func parseEnvOverrides(serviceName string, envs map[string]string) []envOverride {
+	prefix := envName(serviceName) + envPathSeparator
+
+	var overrides []envOverride
+	for k, v := range envs {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		path := strings.Split(k[len(prefix):], envPathSeparator)
+		overrides = append(overrides, envOverride{envName: k, path: path, value: v})
+	}
+
+	sort.Slice(overrides, func(i, j int) bool {
+		a, b := overrides[i], overrides[j]
+		if len(a.path) != len(b.path) {
+			return len(a.path) < len(b.path)
+		}
+		return a.envName < b.envName
+	})
+	return overrides
+}
+
+// applyEnvOverrides applies the environment variable overrides for the given service
+// on top of the computed configuration, returning the updated configuration.
+//
+// The override values are coerced to the type of the value they replace.
+// Values that are null in the computed configuration are parsed as JSON
+// if possible, and otherwise treated as strings. Overriding a field that
+// doesn't exist in the computed configuration is an error.
+func applyEnvOverrides(serviceName string, cfgBytes []byte, envs map[string]string) ([]byte, error) {
+	overrides := parseEnvOverrides(serviceName, envs)
+	if len(overrides) == 0 {
+		return cfgBytes, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(cfgBytes))
+	dec.UseNumber()
+	var root any
+	if err := dec.Decode(&root); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration for service `%s`: %v", serviceName, err)
+	}
+
+	for _, o := range overrides {
+		if err := setPath(root, o.path, o.value); err != nil {
+			return nil, fmt.Errorf("invalid config override %s: %v", o.envName, err)
+		}
+	}
+
+	return json.Marshal(root)
+}
+
+// setPath sets the value at the given path within the decoded JSON value.
+func setPath(node any, path []string, raw string) error {
+	seg, rest := path[0], path[1:]
+
+	switch n := node.(type) {
+	case map[string]any:
+		key, found := findKey(n, seg)
+		if !found {
+			return fmt.Errorf("field %q not found", seg)
+		}
+		if len(rest) > 0 {
+			return setPath(n[key], rest, raw)
+		}
+		val, err := coerceExisting(n[key], raw)
+		if err != nil {
+			return fmt.Errorf("field %q: %v", key, err)
+		}
+		n[key] = val
+		return nil
+
+	case []any:
+		idx, err := strconv.Atoi(seg)
+		if err != nil || idx < 0 || idx >= len(n) {
+			return fmt.Errorf("invalid list index %q", seg)
+		}
+		if len(rest) > 0 {
+			return setPath(n[idx], rest, raw)
+		}
+		val, err := coerceExisting(n[idx], raw)
+		if err != nil {
+			return fmt.Errorf("index %d: %v", idx, err)
+		}
+		n[idx] = val
+		return nil
+
+	default:
+		return fmt.Errorf("cannot set %q on a non-object value", seg)
+	}
+}
+
+// findKey finds the key in the object matching the environment variable segment.
+// Keys are matched case-insensitively and ignoring underscores, so that the
+// segment "MAX_CONNS" matches the field "MaxConns".
+func findKey(obj map[string]any, seg string) (key string, found bool) {
+	want := normalizeKey(seg)
+	for k := range obj {
+		if normalizeKey(k) == want {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+func normalizeKey(s string) string {
+	return strings.ToUpper(strings.ReplaceAll(s, "_", ""))
+}
+
+// coerceExisting coerces raw to the type of the existing value.
+func coerceExisting(existing any, raw string) (any, error) {
+	switch existing.(type) {
+	case string:
+		return raw, nil
+	case bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected a boolean, got %q", raw)
+		}
+		return b, nil
+	case json.Number:
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return nil, fmt.Errorf("expected a number, got %q", raw)
+		}
+		return json.Number(raw), nil
+	case map[string]any, []any:
+		var val any
+		dec := json.NewDecoder(strings.NewReader(raw))
+		dec.UseNumber()
+		if err := dec.Decode(&val); err != nil {
+			return nil, fmt.Errorf("expected a JSON value: %v", err)
+		} else if dec.More() {
+			return nil, fmt.Errorf("expected a JSON value, got trailing data in %q", raw)
+		}
+		return val, nil
+	default:
+		return coerce(raw), nil
+	}
+}
+
+// coerce parses raw as a JSON value if possible, falling back to a string.
+func coerce(raw string) any {
+	var val any
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&val); err == nil && !dec.More() {
+		return val
+	}
+	return raw
+}
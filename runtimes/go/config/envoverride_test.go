@@ -0,0 +1,87 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyEnvOverrides(t *testing.T) {
+	const base = `{"Enabled":false,"MaxConns":10,"Name":"svc","Nested":{"Timeout":"1s","Tags":["a","b"]}}`
+
+	tests := []struct {
+		name    string
+		envs    map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "no_overrides",
+			envs: map[string]string{"ENCORE_CFG_OTHER__ENABLED": "true"},
+			want: base,
+		},
+		{
+			name: "coerce_types",
+			envs: map[string]string{
+				"ENCORE_CFG_MYSVC__ENABLED":   "true",
+				"ENCORE_CFG_MYSVC__MAX_CONNS": "25",
+				"ENCORE_CFG_MYSVC__NAME":      "123",
+			},
+			want: `{"Enabled":true,"MaxConns":25,"Name":"123","Nested":{"Timeout":"1s","Tags":["a","b"]}}`,
+		},
+		{
+			name: "nested_and_precedence",
+			envs: map[string]string{
+				"ENCORE_CFG_MYSVC__NESTED":          `{"Timeout":"5s","Tags":["c"]}`,
+				"ENCORE_CFG_MYSVC__NESTED__TAGS__0": "d",
+			},
+			want: `{"Enabled":false,"MaxConns":10,"Name":"svc","Nested":{"Timeout":"5s","Tags":["d"]}}`,
+		},
+		{
+			name:    "unknown_field",
+			envs:    map[string]string{"ENCORE_CFG_MYSVC__NESTED__RETRIES": "3"},
+			wantErr: `field "RETRIES" not found`,
+		},
+		{
+			name:    "trailing_data",
+			envs:    map[string]string{"ENCORE_CFG_MYSVC__NESTED__TAGS": `["c"] junk`},
+			wantErr: "trailing data",
+		},
+		{
+			name:    "invalid_bool",
+			envs:    map[string]string{"ENCORE_CFG_MYSVC__ENABLED": "yes please"},
+			wantErr: "expected a boolean",
+		},
+		{
+			name:    "missing_parent",
+			envs:    map[string]string{"ENCORE_CFG_MYSVC__MISSING__FIELD": "x"},
+			wantErr: `field "MISSING" not found`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := applyEnvOverrides("mysvc", []byte(base), test.envs)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("got err %v, want it to contain %q", err, test.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var gotVal, wantVal any
+			if err := json.Unmarshal(got, &gotVal); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(test.want), &wantVal); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(gotVal, wantVal) {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -13,18 +13,22 @@ type Unmarshaler[T any] func(itr *jsoniter.Iterator, path []string) T
 // CreateValue creates a new Value on the given path with the given value
 func CreateValue[T any](value T, pathToValue ValuePath) Value[T] {
 	valueID := Singleton.nextID()
+	remote := newRemoteValue[T](Singleton, pathToValue)
+	reload := newReloadValue[T](Singleton, pathToValue)
 	return func() T {
 		Singleton.valueMeta(valueID, pathToValue)
-		return testOverrideOrValue(valueID, value)
+		return testOverrideOrValue(valueID, remoteOrValue(remote, reloadedOrValue(reload, value)))
 	}
 }
 
 // CreateValueList creates a new Value Slice on the given path with the given values
 func CreateValueList[T any](value []T, pathToValue ValuePath) Values[T] {
 	valueID := Singleton.nextID()
+	remote := newRemoteValue[[]T](Singleton, pathToValue)
+	reload := newReloadValue[[]T](Singleton, pathToValue)
 	return func() []T {
 		Singleton.valueMeta(valueID, pathToValue)
-		return testOverrideOrValue(valueID, value)
+		return testOverrideOrValue(valueID, remoteOrValue(remote, reloadedOrValue(reload, value)))
 	}
 }
 
@@ -0,0 +1,63 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func init() {
+	registerProvider(providerDesc{
+		name: "consul",
+		matches: func(cfg *config.RemoteConfigValue) bool {
+			return cfg.Consul != nil
+		},
+		newSource: func(cfg *config.RemoteConfigValue) (Source, error) {
+			return newConsulSource(cfg.Consul, http.DefaultClient)
+		},
+	})
+}
+
+type consulSource struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func newConsulSource(cfg *config.ConsulKVSource, client *http.Client) (*consulSource, error) {
+	if cfg.Address == "" || cfg.Key == "" {
+		return nil, fmt.Errorf("consul: address and key must be set")
+	}
+	u, err := url.Parse(strings.TrimSuffix(cfg.Address, "/") + "/v1/kv/" + strings.TrimPrefix(cfg.Key, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("consul: invalid address: %v", err)
+	}
+	u.RawQuery = "raw=true"
+	return &consulSource{url: u.String(), token: cfg.Token, client: client}, nil
+}
+
+func (s *consulSource) Fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.token != "" {
+		req.Header.Set("X-Consul-Token", s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("consul: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("consul: unexpected status code %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 512*1024))
+}
@@ -0,0 +1,40 @@
+package remote
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func TestConsulSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v1/kv/app/greeting" || req.URL.Query().Get("raw") != "true" {
+			http.NotFound(w, req)
+			return
+		} else if req.Header.Get("X-Consul-Token") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+
+	src, err := newConsulSource(&config.ConsulKVSource{Address: srv.URL + "/", Key: "/app/greeting", Token: "secret"}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := src.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	} else if string(data) != "hello" {
+		t.Fatalf("got %q, want %q", data, "hello")
+	}
+
+	src.token = ""
+	if _, err := src.Fetch(context.Background()); err == nil {
+		t.Fatal("expected error without token")
+	}
+}
@@ -0,0 +1,38 @@
+// Package remote implements providers for sourcing config values
+// from remote systems at runtime.
+package remote
+
+import (
+	"context"
+	"fmt"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+// Source fetches the current value of a single remote config value.
+type Source interface {
+	// Fetch returns the raw value as stored in the remote system.
+	Fetch(ctx context.Context) ([]byte, error)
+}
+
+type providerDesc struct {
+	name      string
+	matches   func(cfg *config.RemoteConfigValue) bool
+	newSource func(cfg *config.RemoteConfigValue) (Source, error)
+}
+
+var providerRegistry []providerDesc
+
+func registerProvider(desc providerDesc) {
+	providerRegistry = append(providerRegistry, desc)
+}
+
+// NewSource returns a Source for the given remote config value.
+func NewSource(cfg *config.RemoteConfigValue) (Source, error) {
+	for _, desc := range providerRegistry {
+		if desc.matches(cfg) {
+			return desc.newSource(cfg)
+		}
+	}
+	return nil, fmt.Errorf("no remote config provider found for value %v of service %s", cfg.Path, cfg.Service)
+}
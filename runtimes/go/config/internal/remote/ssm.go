@@ -0,0 +1,58 @@
+//go:build !encore_no_aws
+
+package remote
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func init() {
+	registerProvider(providerDesc{
+		name: "aws_ssm",
+		matches: func(cfg *config.RemoteConfigValue) bool {
+			return cfg.SSM != nil
+		},
+		newSource: func(cfg *config.RemoteConfigValue) (Source, error) {
+			return newSSMSource(cfg.SSM)
+		},
+	})
+}
+
+type ssmSource struct {
+	name   string
+	client *ssm.Client
+}
+
+func newSSMSource(cfg *config.SSMParameterSource) (*ssmSource, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("ssm: parameter name must be set")
+	}
+
+	var opts []func(*awsConfig.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, awsConfig.WithRegion(cfg.Region))
+	}
+	awsCfg, err := awsConfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("ssm: unable to load AWS config: %v", err)
+	}
+	return &ssmSource{name: cfg.Name, client: ssm.NewFromConfig(awsCfg)}, nil
+}
+
+func (s *ssmSource) Fetch(ctx context.Context) ([]byte, error) {
+	out, err := s.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(s.name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ssm: get parameter %s: %v", s.name, err)
+	}
+	return []byte(aws.ToString(out.Parameter.Value)), nil
+}
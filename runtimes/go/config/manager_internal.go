@@ -9,7 +9,10 @@ import (
 	"testing"
 
 	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog"
 
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/shared/encoreenv"
 	"encore.dev/appruntime/shared/reqtrack"
@@ -20,8 +23,20 @@ type ValuePath []string
 
 type Manager struct {
 	// Runtime components we need for config
-	rt   *reqtrack.RequestTracker
-	json jsoniter.API
+	runtime    *config.Runtime
+	rt         *reqtrack.RequestTracker
+	json       jsoniter.API
+	rootLogger zerolog.Logger
+
+	// remote config tracking
+	remoteCfg      map[string]*config.RemoteConfigValue // keyed by remoteKey
+	loadMutex      sync.Mutex                           // held while a service's config is loaded
+	loadingService string                               // the service whose config is being loaded
+
+	// config reloading when running locally
+	reloadMu     sync.Mutex                // protects the fields below
+	loadedCfg    map[string][]byte         // the current config, keyed by service name
+	reloadValues map[string][]*reloadValue // the reloadable values, keyed by service name
 
 	// config tracking systems
 	nextValueID atomic.Uint64
@@ -41,12 +56,36 @@ type Manager struct {
 	testOverrides map[*testing.T]map[ValueID]any
 }
 
-func NewManager(rt *reqtrack.RequestTracker, json jsoniter.API) *Manager {
-	return &Manager{
+func NewManager(runtime *config.Runtime, rt *reqtrack.RequestTracker, json jsoniter.API, rootLogger zerolog.Logger, server *api.Server) *Manager {
+	m := &Manager{
+		runtime:       runtime,
 		rt:            rt,
 		json:          json,
+		rootLogger:    rootLogger,
+		remoteCfg:     parseRemoteConfig(runtime.RemoteConfig),
+		loadedCfg:     make(map[string][]byte),
+		reloadValues:  make(map[string][]*reloadValue),
 		testOverrides: make(map[*testing.T]map[ValueID]any),
 	}
+	m.registerRoutes(server)
+	return m
+}
+
+// beginLoad marks the start of loading the given config for the given service,
+// which is used to resolve remote and reloadable config values for the service.
+// It must be paired with a call to endLoad.
+func (m *Manager) beginLoad(serviceName string, cfgBytes []byte) {
+	m.loadMutex.Lock()
+	m.loadingService = serviceName
+
+	m.reloadMu.Lock()
+	m.loadedCfg[serviceName] = cfgBytes
+	m.reloadMu.Unlock()
+}
+
+func (m *Manager) endLoad() {
+	m.loadingService = ""
+	m.loadMutex.Unlock()
 }
 
 func (m *Manager) getComputedCUE(serviceName string) (jsonBytes []byte, found bool, err error) {
@@ -63,6 +102,12 @@ func (m *Manager) getComputedCUE(serviceName string) (jsonBytes []byte, found bo
 	if err != nil {
 		return nil, true, fmt.Errorf("failed to decode configuration for service `%s`: %v", serviceName, err)
 	}
+
+	// Apply any overrides set using environment variables
+	cfgBytes, err = applyEnvOverrides(serviceName, cfgBytes, encoreenv.WithPrefix(envName(serviceName)+envPathSeparator))
+	if err != nil {
+		return nil, true, err
+	}
 	return cfgBytes, true, nil
 }
 
@@ -11,12 +11,15 @@ package config
 import (
 	"fmt"
 
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/shared/appconf"
 	"encore.dev/appruntime/shared/jsonapi"
+	"encore.dev/appruntime/shared/logging"
 	"encore.dev/appruntime/shared/reqtrack"
 )
 
 //publicapigen:drop
-var Singleton = NewManager(reqtrack.Singleton, jsonapi.Default)
+var Singleton = NewManager(appconf.Runtime, reqtrack.Singleton, jsonapi.Default, logging.RootLogger, api.Singleton)
 
 // Load returns the fully loaded configuration for this service.
 //
@@ -27,6 +30,19 @@ var Singleton = NewManager(reqtrack.Singleton, jsonapi.Default)
 // Encore will generate a `encore.gen.cue` file in the service directory which
 // will contain generated CUE matching the configuration type T.
 //
+// Individual values can be overridden at process start using environment variables
+// named ENCORE_CFG_<SERVICE>__<FIELD>[__<FIELD>...], such as ENCORE_CFG_EMAIL__SEND_EMAILS=false.
+// Override values are coerced to the type of the value they replace, and more specific
+// paths take precedence over less specific ones.
+//
+// Values of type Value[T] can additionally be sourced from remote providers such as
+// AWS SSM Parameter Store or Consul KV, as configured in the runtime configuration.
+// Remote values are cached and periodically refreshed, and fall back to the value
+// from the CUE files if they cannot be fetched.
+//
+// When running locally with "encore run", changes to the CUE files are applied to
+// values of type Value[T] without restarting the app.
+//
 // Note: This function can only be called from within services and cannot be
 // referenced from other services.
 func Load[T any](__serviceName string, __unmarshaler Unmarshaler[T]) T {
@@ -42,6 +58,10 @@ func Load[T any](__serviceName string, __unmarshaler Unmarshaler[T]) T {
 		panic(err.Error())
 	}
 
+	// Track which service is being loaded so remote and reloadable values can be resolved
+	Singleton.beginLoad(__serviceName, cfgBytes)
+	defer Singleton.endLoad()
+
 	// Create an iterator for the JSON config
 	itr := Singleton.json.BorrowIterator(cfgBytes)
 	defer Singleton.json.ReturnIterator(itr)
@@ -0,0 +1,291 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/shared/encoreenv"
+)
+
+// reloadValue is a config value that can be updated in place
+// when the configuration is reloaded by "encore run".
+type reloadValue struct {
+	path   ValuePath
+	decode func(data []byte) (any, error)
+
+	mu    sync.RWMutex
+	val   any
+	valid bool
+}
+
+// newReloadValue returns the reloadable value for the given path within
+// the config currently being loaded, or nil if config reloading
+// is not supported in this environment.
+func newReloadValue[T any](m *Manager, path ValuePath) *reloadValue {
+	if m == nil || m.loadingService == "" || !m.reloadable() {
+		return nil
+	}
+
+	rv := &reloadValue{
+		// The path may share its backing array with the paths of other values.
+		path: slices.Clone(path),
+		decode: func(data []byte) (any, error) {
+			var val T
+			err := m.json.Unmarshal(data, &val)
+			return val, err
+		},
+	}
+
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+	m.reloadValues[m.loadingService] = append(m.reloadValues[m.loadingService], rv)
+	return rv
+}
+
+// reloadedOrValue returns the reloaded value if the value has been reloaded,
+// and otherwise the fallback value.
+func reloadedOrValue[T any](rv *reloadValue, fallback T) T {
+	if rv == nil {
+		return fallback
+	}
+	rv.mu.RLock()
+	defer rv.mu.RUnlock()
+	if rv.valid {
+		return rv.val.(T)
+	}
+	return fallback
+}
+
+func (rv *reloadValue) set(val any) {
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+	rv.val, rv.valid = val, true
+}
+
+// reloadable reports whether config can be reloaded without restarting,
+// which is only supported when running locally.
+func (m *Manager) reloadable() bool {
+	return m.runtime != nil && m.runtime.EnvCloud == "local"
+}
+
+// registerRoutes registers the route used by "encore run" to push config changes.
+// They are only registered when running locally, since the
+// Encore internal routes are not authenticated.
+func (m *Manager) registerRoutes(server *api.Server) {
+	if server == nil || !m.reloadable() {
+		return
+	}
+	server.RegisterEncoreRoute("PUT", "/config", http.HandlerFunc(m.handleReload))
+}
+
+// handleReload reloads the config for the services in the request body,
+// which is a JSON object of service names to their computed config.
+//
+// It responds with 409 Conflict if the changes can't be applied without
+// restarting the app, in which case no changes are applied.
+func (m *Manager) handleReload(w http.ResponseWriter, req *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, req.Body, 10<<20))
+	if err != nil {
+		http.Error(w, "could not read request body", http.StatusBadRequest)
+		return
+	}
+	var configs map[string]json.RawMessage
+	if err := json.Unmarshal(data, &configs); err != nil {
+		http.Error(w, "invalid config: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fixed, err := m.reload(configs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if len(fixed) > 0 {
+		http.Error(w, "config values require a restart to change: "+strings.Join(fixed, ", "), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// reload replaces the config of the given services with the new config.
+//
+// Only changes to config values wrapped in config.Value or config.Values can be
+// applied to the running app. If any other values changed, reload returns their
+// paths and leaves the config unchanged.
+func (m *Manager) reload(configs map[string]json.RawMessage) (fixed []string, err error) {
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
+	type update struct {
+		rv  *reloadValue
+		val any
+	}
+	var updates []update
+	loaded := make(map[string][]byte, len(configs))
+
+	for svc, data := range configs {
+		curr, ok := m.loadedCfg[svc]
+		if !ok {
+			// The service's config is not used by this process.
+			continue
+		}
+
+		data, err := applyEnvOverrides(svc, data, encoreenv.WithPrefix(envName(svc)+envPathSeparator))
+		if err != nil {
+			return nil, err
+		}
+		currTree, err := parseTree(curr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse current configuration for service `%s`: %v", svc, err)
+		}
+		newTree, err := parseTree(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse configuration for service `%s`: %v", svc, err)
+		}
+
+		changed := diffTree(currTree, newTree, nil, nil)
+		covered := make([]bool, len(changed))
+		for _, rv := range m.reloadValues[svc] {
+			if !overlapsAny(rv.path, changed) {
+				continue
+			}
+			node, ok := lookupTree(newTree, rv.path)
+			if !ok {
+				continue
+			}
+			raw, err := json.Marshal(node)
+			if err != nil {
+				continue
+			}
+			val, err := rv.decode(raw)
+			if err != nil {
+				// Values whose type contains other config wrappers can't be decoded,
+				// but the wrappers within them are reloaded individually.
+				continue
+			}
+			updates = append(updates, update{rv, val})
+			for i, p := range changed {
+				if hasPathPrefix(p, rv.path) {
+					covered[i] = true
+				}
+			}
+		}
+
+		for i, p := range changed {
+			if !covered[i] {
+				fixed = append(fixed, svc+":"+strings.Join(p, "."))
+			}
+		}
+		loaded[svc] = data
+	}
+
+	if len(fixed) > 0 {
+		sort.Strings(fixed)
+		return fixed, nil
+	}
+	for _, u := range updates {
+		u.rv.set(u.val)
+	}
+	for svc, data := range loaded {
+		m.loadedCfg[svc] = data
+	}
+	return nil, nil
+}
+
+// parseTree parses the JSON config into a tree of maps and slices,
+// keeping numbers as json.Number to avoid losing precision.
+func parseTree(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var root any
+	err := dec.Decode(&root)
+	return root, err
+}
+
+// diffTree returns the paths of the values that differ between a and b.
+// Objects and lists are compared element-wise, unless a list changed length
+// in which case the list itself is reported as changed.
+func diffTree(a, b any, path ValuePath, changed []ValuePath) []ValuePath {
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok {
+			return append(changed, slices.Clone(path))
+		}
+		for k, av := range a {
+			if bv, ok := b[k]; ok {
+				changed = diffTree(av, bv, append(path, k), changed)
+			} else {
+				changed = append(changed, slices.Clone(append(path, k)))
+			}
+		}
+		for k := range b {
+			if _, ok := a[k]; !ok {
+				changed = append(changed, slices.Clone(append(path, k)))
+			}
+		}
+		return changed
+
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return append(changed, slices.Clone(path))
+		}
+		for i := range a {
+			changed = diffTree(a[i], b[i], append(path, strconv.Itoa(i)), changed)
+		}
+		return changed
+
+	default:
+		// a is a scalar, so the comparison can't panic.
+		if a != b {
+			return append(changed, slices.Clone(path))
+		}
+		return changed
+	}
+}
+
+// lookupTree returns the value at the given path within the tree.
+func lookupTree(node any, path ValuePath) (any, bool) {
+	for _, seg := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			val, ok := n[seg]
+			if !ok {
+				return nil, false
+			}
+			node = val
+		case []any:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(n) {
+				return nil, false
+			}
+			node = n[idx]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// overlapsAny reports whether the value at path is affected by any of the changed paths.
+func overlapsAny(path ValuePath, changed []ValuePath) bool {
+	for _, p := range changed {
+		if hasPathPrefix(p, path) || hasPathPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPathPrefix(path, prefix ValuePath) bool {
+	return len(path) >= len(prefix) && slices.Equal(path[:len(prefix)], prefix)
+}
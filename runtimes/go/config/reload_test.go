@@ -0,0 +1,97 @@
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+type reloadServer struct {
+	Name  string
+	Ports []int
+}
+
+func TestReload(t *testing.T) {
+	mgr := NewManager(&config.Runtime{EnvCloud: "local"}, nil, jsoniter.ConfigCompatibleWithStandardLibrary, zerolog.Nop(), nil)
+
+	mgr.beginLoad("svc", []byte(`{"Name": "a", "Port": 1, "Servers": [{"Name": "x", "Ports": [1]}], "Limits": {"Max": 5}}`))
+	name := newReloadValue[string](mgr, ValuePath{"Name"})
+	servers := newReloadValue[[]reloadServer](mgr, ValuePath{"Servers"})
+	limit := newReloadValue[int](mgr, ValuePath{"Limits", "Max"})
+	mgr.endLoad()
+
+	fixed, err := mgr.reload(map[string]json.RawMessage{
+		"svc":   json.RawMessage(`{"Name": "b", "Port": 1, "Servers": [{"Name": "x", "Ports": [1]}, {"Name": "y", "Ports": [2, 3]}], "Limits": {"Max": 10}}`),
+		"other": json.RawMessage(`{"Name": "ignored"}`),
+	})
+	if err != nil || len(fixed) > 0 {
+		t.Fatalf("got (%v, %v), want reload to succeed", fixed, err)
+	}
+	if got := reloadedOrValue(name, "a"); got != "b" {
+		t.Errorf("got name %q, want b", got)
+	}
+	if got := reloadedOrValue[[]reloadServer](servers, nil); len(got) != 2 || got[1].Name != "y" || len(got[1].Ports) != 2 {
+		t.Errorf("got servers %+v, want 2 servers", got)
+	}
+	if got := reloadedOrValue(limit, 5); got != 10 {
+		t.Errorf("got max %d, want 10", got)
+	}
+
+	// Changing a value that isn't wrapped in a config.Value requires a restart,
+	// and leaves the config unchanged.
+	fixed, err = mgr.reload(map[string]json.RawMessage{
+		"svc": json.RawMessage(`{"Name": "c", "Port": 2, "Servers": [], "Limits": {"Max": 10, "Min": 1}}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"svc:Limits.Min", "svc:Port"}; strings.Join(fixed, ",") != strings.Join(want, ",") {
+		t.Errorf("got fixed %v, want %v", fixed, want)
+	}
+	if got := reloadedOrValue(name, "a"); got != "b" {
+		t.Errorf("got name %q after failed reload, want b", got)
+	}
+}
+
+func TestReload_HTTP(t *testing.T) {
+	mgr := NewManager(&config.Runtime{EnvCloud: "local"}, nil, jsoniter.ConfigCompatibleWithStandardLibrary, zerolog.Nop(), nil)
+	mgr.beginLoad("svc", []byte(`{"Name": "a", "Port": 1}`))
+	newReloadValue[string](mgr, ValuePath{"Name"})
+	mgr.endLoad()
+
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`{"svc": {"Name": "b", "Port": 1}}`, http.StatusNoContent},
+		{`{"svc": {"Name": "b", "Port": 2}}`, http.StatusConflict},
+		{`not json`, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		mgr.handleReload(w, httptest.NewRequest("PUT", "/config", strings.NewReader(test.body)))
+		if w.Code != test.want {
+			t.Errorf("body %s: got status %d, want %d", test.body, w.Code, test.want)
+		}
+	}
+}
+
+func TestNewReloadValue_NotLocal(t *testing.T) {
+	mgr := NewManager(&config.Runtime{EnvCloud: "aws"}, nil, jsoniter.ConfigCompatibleWithStandardLibrary, zerolog.Nop(), nil)
+	mgr.beginLoad("svc", []byte(`{"Name": "a"}`))
+	rv := newReloadValue[string](mgr, ValuePath{"Name"})
+	mgr.endLoad()
+	if rv != nil {
+		t.Fatal("expected no reloadable value outside of local development")
+	}
+	if got := reloadedOrValue(rv, "a"); got != "a" {
+		t.Fatalf("got %q, want a", got)
+	}
+}
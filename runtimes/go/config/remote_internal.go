@@ -0,0 +1,176 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/config/internal/remote"
+)
+
+const (
+	// defaultRemoteCacheTTL is how long remote values are cached
+	// if the runtime config doesn't specify otherwise.
+	defaultRemoteCacheTTL = time.Minute
+
+	// remoteFetchTimeout is the timeout for fetching a single remote value.
+	remoteFetchTimeout = 5 * time.Second
+)
+
+// remoteKey returns the key used to look up remote config for a value.
+func remoteKey(serviceName string, path ValuePath) string {
+	return strings.ToLower(serviceName) + ":" + strings.Join(path, ".")
+}
+
+// remoteValue is a config value sourced from a remote provider.
+type remoteValue struct {
+	mgr    *Manager
+	desc   string
+	src    remote.Source
+	ttl    time.Duration
+	decode func(data []byte) (any, error)
+
+	mu        sync.Mutex
+	val       any
+	valid     bool
+	fetchedAt time.Time
+	inflight  chan struct{} // closed when the in-flight fetch completes, if any
+}
+
+// newRemoteValue returns the remote value for the given path within
+// the config currently being loaded, or nil if the value is not
+// sourced remotely.
+func newRemoteValue[T any](m *Manager, path ValuePath) *remoteValue {
+	if m == nil || m.loadingService == "" {
+		return nil
+	}
+	cfg, ok := m.remoteCfg[remoteKey(m.loadingService, path)]
+	if !ok {
+		return nil
+	}
+
+	src, err := remote.NewSource(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("failed to configure remote config value %v for service %s: %v", path, m.loadingService, err))
+	}
+
+	ttl := defaultRemoteCacheTTL
+	if rc := m.runtime.RemoteConfig; rc != nil && rc.CacheTTL > 0 {
+		ttl = rc.CacheTTL
+	}
+
+	return &remoteValue{
+		mgr:  m,
+		desc: fmt.Sprintf("%s:%s", m.loadingService, strings.Join(path, ".")),
+		src:  src,
+		ttl:  ttl,
+		decode: func(data []byte) (any, error) {
+			var val T
+			if err := m.json.Unmarshal(data, &val); err != nil {
+				// Remote systems commonly store strings without quotes,
+				// so try again with the data as a JSON string.
+				quoted, _ := m.json.Marshal(string(data))
+				if err2 := m.json.Unmarshal(quoted, &val); err2 != nil {
+					return nil, err
+				}
+			}
+			return val, nil
+		},
+	}
+}
+
+// remoteOrValue returns the remote value if it's available, and otherwise the fallback value.
+func remoteOrValue[T any](rv *remoteValue, fallback T) T {
+	if rv == nil {
+		return fallback
+	}
+	if val, ok := rv.get(); ok {
+		return val.(T)
+	}
+	return fallback
+}
+
+// get returns the cached value.
+//
+// The first call waits for the value to be fetched. Once a value has been fetched,
+// expired values are refreshed in the background while the cached value
+// continues to be served. Fetching never holds rv.mu, and at most one fetch
+// is in flight at a time, so concurrent callers share the same fetch.
+func (rv *remoteValue) get() (any, bool) {
+	rv.mu.Lock()
+	var wait <-chan struct{}
+	switch {
+	case rv.fetchedAt.IsZero():
+		wait = rv.startFetch()
+	case time.Since(rv.fetchedAt) >= rv.ttl:
+		rv.startFetch()
+	}
+	rv.mu.Unlock()
+
+	if wait != nil {
+		<-wait
+	}
+
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+	return rv.val, rv.valid
+}
+
+// startFetch fetches the value in the background unless a fetch is already
+// in flight, and returns a channel that is closed once the fetch completes.
+// rv.mu must be held.
+func (rv *remoteValue) startFetch() <-chan struct{} {
+	if rv.inflight != nil {
+		return rv.inflight
+	}
+	done := make(chan struct{})
+	rv.inflight = done
+	go func() {
+		val, err := rv.fetch()
+		rv.mu.Lock()
+		rv.update(val, err)
+		rv.inflight = nil
+		rv.mu.Unlock()
+		close(done)
+	}()
+	return done
+}
+
+// update updates the cached value with the result of a fetch.
+// If fetching failed the previously fetched value is kept.
+// rv.mu must be held.
+func (rv *remoteValue) update(val any, err error) {
+	// Update fetchedAt even on failure to avoid hammering a failing provider.
+	rv.fetchedAt = time.Now()
+	if err != nil {
+		rv.mgr.rootLogger.Error().Err(err).Str("value", rv.desc).Msg("unable to fetch remote config value")
+		return
+	}
+	rv.val, rv.valid = val, true
+}
+
+func (rv *remoteValue) fetch() (any, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteFetchTimeout)
+	defer cancel()
+
+	data, err := rv.src.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rv.decode(data)
+}
+
+// parseRemoteConfig indexes the remote config values by service and path.
+func parseRemoteConfig(rc *config.RemoteConfig) map[string]*config.RemoteConfigValue {
+	m := make(map[string]*config.RemoteConfigValue)
+	if rc == nil {
+		return m
+	}
+	for _, v := range rc.Values {
+		m[remoteKey(v.Service, v.Path)] = v
+	}
+	return m
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+type fakeSource struct {
+	data  []byte
+	err   error
+	calls int
+}
+
+func (s *fakeSource) Fetch(ctx context.Context) ([]byte, error) {
+	s.calls++
+	return s.data, s.err
+}
+
+func TestRemoteValue(t *testing.T) {
+	mgr := NewManager(&config.Runtime{}, nil, jsoniter.ConfigCompatibleWithStandardLibrary, zerolog.Nop(), nil)
+	src := &fakeSource{data: []byte("42")}
+	rv := &remoteValue{mgr: mgr, src: src, ttl: time.Hour}
+	rv.decode = func(data []byte) (any, error) {
+		var v int
+		err := mgr.json.Unmarshal(data, &v)
+		return v, err
+	}
+
+	if got := remoteOrValue(rv, 1); got != 42 {
+		t.Fatalf("got %d, want 42", got)
+	}
+	src.data = []byte("43")
+	if got := remoteOrValue(rv, 1); got != 42 || src.calls != 1 {
+		t.Fatalf("got %d with %d calls, want cached value 42 with 1 call", got, src.calls)
+	}
+
+	// A failing initial fetch falls back to the static value.
+	src2 := &fakeSource{err: errors.New("unavailable")}
+	rv2 := &remoteValue{mgr: mgr, src: src2, ttl: time.Hour, decode: rv.decode}
+	if got := remoteOrValue(rv2, 1); got != 1 {
+		t.Fatalf("got %d, want fallback 1", got)
+	}
+
+	if got := remoteOrValue[int](nil, 7); got != 7 {
+		t.Fatalf("got %d, want 7", got)
+	}
+}
+
+// blockingSource blocks fetches until release is closed.
+type blockingSource struct {
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (s *blockingSource) Fetch(ctx context.Context) ([]byte, error) {
+	s.calls.Add(1)
+	<-s.release
+	return []byte("42"), nil
+}
+
+func TestRemoteValue_SingleFetch(t *testing.T) {
+	mgr := NewManager(&config.Runtime{}, nil, jsoniter.ConfigCompatibleWithStandardLibrary, zerolog.Nop(), nil)
+	src := &blockingSource{release: make(chan struct{})}
+	rv := &remoteValue{mgr: mgr, src: src, ttl: time.Hour}
+	rv.decode = func(data []byte) (any, error) {
+		var v int
+		err := mgr.json.Unmarshal(data, &v)
+		return v, err
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := remoteOrValue(rv, 1); got != 42 {
+				t.Errorf("got %d, want 42", got)
+			}
+		}()
+	}
+
+	// The lock must not be held while fetching.
+	for src.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	rv.mu.Lock()
+	rv.mu.Unlock()
+
+	close(src.release)
+	wg.Wait()
+	if n := src.calls.Load(); n != 1 {
+		t.Errorf("got %d fetches, want 1", n)
+	}
+}
+
+func TestNewRemoteValue_Decode(t *testing.T) {
+	mgr := NewManager(&config.Runtime{
+		RemoteConfig: &config.RemoteConfig{
+			Values: []*config.RemoteConfigValue{{
+				Service: "svc",
+				Path:    []string{"Greeting"},
+				Consul:  &config.ConsulKVSource{Address: "http://localhost:8500", Key: "greeting"},
+			}},
+		},
+	}, nil, jsoniter.ConfigCompatibleWithStandardLibrary, zerolog.Nop(), nil)
+
+	if rv := newRemoteValue[string](mgr, ValuePath{"Greeting"}); rv != nil {
+		t.Fatal("expected no remote value outside of loading a service")
+	}
+
+	mgr.beginLoad("svc", []byte("{}"))
+	rv := newRemoteValue[string](mgr, ValuePath{"Greeting"})
+	other := newRemoteValue[string](mgr, ValuePath{"Other"})
+	mgr.endLoad()
+	if rv == nil || other != nil {
+		t.Fatalf("got remote values %v and %v, want only the first to be set", rv, other)
+	}
+
+	// Unquoted strings are accepted.
+	val, err := rv.decode([]byte("hello"))
+	if err != nil || val != "hello" {
+		t.Fatalf("got (%v, %v), want hello", val, err)
+	}
+}
@@ -0,0 +1,27 @@
+package cron
+
+import "sync"
+
+var (
+	jobsMu sync.RWMutex
+	jobs   = make(map[string]*Job)
+)
+
+func registerJob(job *Job) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	jobs[job.ID] = job
+}
+
+// LookupJob is an internal API for Encore. This function should
+// never be directly called as it is considered an unstable API and Encore
+// can change it at any time
+//
+// It returns the cron job with the given id, if it has been defined
+// by a package linked into the running program.
+func LookupJob(id string) (*Job, bool) {
+	jobsMu.RLock()
+	defer jobsMu.RUnlock()
+	job, ok := jobs[id]
+	return job, ok
+}
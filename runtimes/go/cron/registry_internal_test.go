@@ -0,0 +1,13 @@
+package cron
+
+import "testing"
+
+func TestLookupJob(t *testing.T) {
+	job := NewJob("lookup-job", JobConfig{Title: "Lookup", Every: Hour})
+	if got, ok := LookupJob("lookup-job"); !ok || got != job {
+		t.Errorf("got job %v, want %v", got, job)
+	}
+	if _, ok := LookupJob("unknown-job"); ok {
+		t.Error("got a job for an unknown id")
+	}
+}
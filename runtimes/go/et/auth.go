@@ -1,7 +1,10 @@
 package et
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"testing"
 
 	"encore.dev/appruntime/apisdk/api"
 	"encore.dev/beta/auth"
@@ -21,3 +24,38 @@ func (mgr *Manager) OverrideAuthInfo(uid auth.UID, authData any) {
 		}
 	}
 }
+
+func (mgr *Manager) SetAuth(t testing.TB, uid auth.UID, authData any) {
+	t.Helper()
+
+	// Treat typed nil pointers as no auth data.
+	if v := reflect.ValueOf(authData); v.Kind() == reflect.Pointer && v.IsNil() {
+		authData = nil
+	}
+	if err := api.CheckAuthData(uid, authData); err != nil {
+		t.Fatalf("et: set auth: %v", err)
+	}
+
+	curr := mgr.rt.Current()
+	if curr.Req == nil || curr.Req.Test == nil {
+		t.Fatal("et: set auth: must be called from within a test")
+	}
+	curr.Req.Test.UserID = uid
+	curr.Req.Test.AuthData = authData
+}
+
+func (mgr *Manager) MockAuthHandler(mock any) {
+	if v := reflect.ValueOf(mock); !v.IsValid() || (v.Kind() == reflect.Func && v.IsNil()) {
+		mock = nil
+	}
+	mgr.testMgr.SetAuthHandlerMock(mock)
+}
+
+func (mgr *Manager) Authenticate(t testing.TB, params any) {
+	t.Helper()
+	info, err := mgr.server.AuthenticateInTest(context.Background(), params)
+	if err != nil {
+		t.Fatalf("et: authenticate: %v", err)
+	}
+	mgr.SetAuth(t, info.UID, info.UserData)
+}
@@ -0,0 +1,32 @@
+package et
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"encore.dev/cron"
+)
+
+var errorType = reflect.TypeFor[error]()
+
+func (mgr *Manager) RunCron(t testing.TB, jobID string) error {
+	t.Helper()
+	job, ok := cron.LookupJob(jobID)
+	if !ok {
+		t.Fatalf("et: run cron: unknown cron job %q (is the package defining it imported by the test?)", jobID)
+	}
+	if v := reflect.ValueOf(job.Endpoint); v.Kind() != reflect.Func || v.IsNil() || mgr.server.HandlerForFunc(job.Endpoint) == nil {
+		t.Fatalf("et: run cron: the endpoint of cron job %q is not an Encore API", jobID)
+	}
+
+	fn := reflect.ValueOf(job.Endpoint)
+	typ := fn.Type()
+	if typ.NumIn() != 1 || typ.NumOut() == 0 || typ.NumOut() > 2 || typ.Out(typ.NumOut()-1) != errorType {
+		t.Fatalf("et: run cron: the endpoint of cron job %q has signature %s, want func(context.Context) error or func(context.Context) (T, error)", jobID, typ)
+	}
+
+	out := fn.Call([]reflect.Value{reflect.ValueOf(context.Background())})
+	err, _ := out[len(out)-1].Interface().(error)
+	return err
+}
@@ -0,0 +1,248 @@
+package et
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+
+	"encore.dev/storage/sqldb"
+)
+
+// Fixture is a factory for rows of a database table, each row represented by a T.
+// Declare fixtures once, typically as package-level variables, and use them to insert
+// rows in tests instead of seeding the database with hand-written SQL.
+//
+// The table's columns are the exported fields of T, named by their `db` struct tag,
+// or else by the field name in snake_case. Fields tagged `db:"-"` are skipped.
+// The tag supports the following options, separated by commas:
+//
+//   - pk: the column is part of the primary key, which is used to delete the
+//     row when the test ends. If no field is tagged pk, the "id" column is used
+//     if there is one, and otherwise all columns.
+//   - generated: the column is left out of the insert when the field is zero,
+//     so the database generates its value, such as for serial ids.
+//
+// For example:
+//
+//	type User struct {
+//		ID    int64  `db:"id,pk,generated"`
+//		Email string `db:"email"`
+//	}
+//
+//	var Users = et.NewFixture("users", func(n int) User {
+//		return User{Email: fmt.Sprintf("user%d@example.com", n)}
+//	})
+type Fixture[T any] struct {
+	table    string
+	defaults func(n int) T
+	hooks    []func(fc *FixtureContext, row *T)
+	seq      atomic.Int64
+}
+
+// FixtureContext is passed to BeforeInsert hooks,
+// to insert related rows in the same test and database.
+type FixtureContext struct {
+	T  testing.TB
+	DB *sqldb.Database
+}
+
+// NewFixture returns a fixture for the given table, whose rows default to the value
+// returned by defaults. defaults is called with a sequence number starting at 1 that
+// increases with each row the fixture inserts, for generating unique values.
+func NewFixture[T any](table string, defaults func(n int) T) *Fixture[T] {
+	return &Fixture[T]{table: table, defaults: defaults}
+}
+
+// BeforeInsert registers fn to be called before each row is inserted, after the
+// overrides have been applied. Use it to insert related rows the row refers to,
+// when the overrides haven't set the reference already:
+//
+//	var Posts = et.NewFixture("posts", func(n int) Post {
+//		return Post{Title: fmt.Sprintf("Post %d", n)}
+//	}).BeforeInsert(func(fc *et.FixtureContext, p *Post) {
+//		if p.AuthorID == 0 {
+//			p.AuthorID = Users.Insert(fc.T, fc.DB).ID
+//		}
+//	})
+//
+// It returns the fixture for chaining.
+func (f *Fixture[T]) BeforeInsert(fn func(fc *FixtureContext, row *T)) *Fixture[T] {
+	f.hooks = append(f.hooks, fn)
+	return f
+}
+
+// Insert inserts a row into db, starting from the fixture's defaults and applying
+// the overrides in order, and returns the inserted row including generated values.
+//
+// Unless db is a test database created by NewTestDatabase, which is dropped anyway,
+// the row is deleted when the test ends. Rows are deleted in the reverse order
+// of insertion, so rows are deleted before the related rows they refer to.
+func (f *Fixture[T]) Insert(t testing.TB, db *sqldb.Database, overrides ...func(row *T)) T {
+	t.Helper()
+	row := f.defaults(int(f.seq.Add(1)))
+	for _, o := range overrides {
+		o(&row)
+	}
+	fc := &FixtureContext{T: t, DB: db}
+	for _, h := range f.hooks {
+		h(fc, &row)
+	}
+
+	cols, err := fixtureColumns(reflect.TypeOf(row))
+	if err != nil {
+		t.Fatalf("et: invalid fixture for table %s: %v", f.table, err)
+	}
+	query, args, dest := insertQuery(f.table, cols, reflect.ValueOf(&row).Elem())
+	if err := db.QueryRow(context.Background(), query, args...).Scan(dest...); err != nil {
+		t.Fatalf("et: insert into %s: %v", f.table, err)
+	}
+
+	if !sqldb.IsTestDatabase(db) {
+		query, args := deleteQuery(f.table, cols, reflect.ValueOf(row))
+		t.Cleanup(func() {
+			if _, err := db.Exec(context.Background(), query, args...); err != nil {
+				t.Errorf("et: delete fixture row from %s: %v", f.table, err)
+			}
+		})
+	}
+	return row
+}
+
+// InsertN inserts n rows into db like Insert, applying the overrides to each row.
+func (f *Fixture[T]) InsertN(t testing.TB, db *sqldb.Database, n int, overrides ...func(row *T)) []T {
+	t.Helper()
+	rows := make([]T, n)
+	for i := range rows {
+		rows[i] = f.Insert(t, db, overrides...)
+	}
+	return rows
+}
+
+type fixtureColumn struct {
+	name      string
+	index     int
+	pk        bool
+	generated bool
+}
+
+// fixtureColumns returns the columns of the row type typ.
+func fixtureColumns(typ reflect.Type) ([]fixtureColumn, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("row type %s is not a struct", typ)
+	}
+	var cols []fixtureColumn
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = snakeCase(field.Name)
+		}
+		col := fixtureColumn{name: name, index: i}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "pk":
+				col.pk = true
+			case "generated":
+				col.generated = true
+			case "":
+			default:
+				return nil, fmt.Errorf("field %s: unknown db tag option %q", field.Name, opt)
+			}
+		}
+		cols = append(cols, col)
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("row type %s has no columns", typ)
+	}
+	return cols, nil
+}
+
+// insertQuery returns a query inserting row, the query arguments, and the
+// scan destinations for reading back the inserted row into row.
+func insertQuery(table string, cols []fixtureColumn, row reflect.Value) (query string, args, dest []any) {
+	var names, params, returning []string
+	for _, col := range cols {
+		field := row.Field(col.index)
+		returning = append(returning, pgx.Identifier{col.name}.Sanitize())
+		dest = append(dest, field.Addr().Interface())
+		if col.generated && field.IsZero() {
+			continue
+		}
+		names = append(names, pgx.Identifier{col.name}.Sanitize())
+		args = append(args, field.Interface())
+		params = append(params, fmt.Sprintf("$%d", len(args)))
+	}
+
+	if len(names) == 0 {
+		query = fmt.Sprintf("INSERT INTO %s DEFAULT VALUES", tableIdent(table))
+	} else {
+		query = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			tableIdent(table), strings.Join(names, ", "), strings.Join(params, ", "))
+	}
+	query += " RETURNING " + strings.Join(returning, ", ")
+	return query, args, dest
+}
+
+// deleteQuery returns a query deleting row, identified by its primary key columns.
+func deleteQuery(table string, cols []fixtureColumn, row reflect.Value) (query string, args []any) {
+	var keys []fixtureColumn
+	for _, col := range cols {
+		if col.pk {
+			keys = append(keys, col)
+		}
+	}
+	if len(keys) == 0 {
+		for _, col := range cols {
+			if col.name == "id" {
+				keys = append(keys, col)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		keys = cols
+	}
+
+	var conds []string
+	for _, col := range keys {
+		args = append(args, row.Field(col.index).Interface())
+		conds = append(conds, fmt.Sprintf("%s IS NOT DISTINCT FROM $%d", pgx.Identifier{col.name}.Sanitize(), len(args)))
+	}
+	query = fmt.Sprintf("DELETE FROM %s WHERE %s", tableIdent(table), strings.Join(conds, " AND "))
+	return query, args
+}
+
+// tableIdent returns the quoted identifier of the possibly schema-qualified table.
+func tableIdent(table string) string {
+	return pgx.Identifier(strings.Split(table, ".")).Sanitize()
+}
+
+// snakeCase converts a Go field name like "UserID" to snake_case, like "user_id".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word at the beginning of a word following a lowercase letter
+			// or digit, or at the last letter of an acronym followed by a lowercase letter.
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package et
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fixtureUser struct {
+	ID        int64 `db:"id,pk,generated"`
+	Email     string
+	UserID    string
+	CreatedAt time.Time `db:"created,generated"`
+	Ignored   string    `db:"-"`
+}
+
+func TestFixtureQueries(t *testing.T) {
+	cols, err := fixtureColumns(reflect.TypeOf(fixtureUser{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row := fixtureUser{Email: "a@example.com", UserID: "u1", CreatedAt: time.Unix(1, 0)}
+	query, args, dest := insertQuery("app.users", cols, reflect.ValueOf(&row).Elem())
+	wantQuery := `INSERT INTO "app"."users" ("email", "user_id", "created") VALUES ($1, $2, $3) RETURNING "id", "email", "user_id", "created"`
+	if query != wantQuery {
+		t.Errorf("got query %s\nwant %s", query, wantQuery)
+	}
+	if len(args) != 3 || args[0] != "a@example.com" || len(dest) != 4 || dest[0] != &row.ID {
+		t.Errorf("got args %v and dest %v", args, dest)
+	}
+
+	row.ID = 5
+	query, args = deleteQuery("app.users", cols, reflect.ValueOf(row))
+	if want := `DELETE FROM "app"."users" WHERE "id" IS NOT DISTINCT FROM $1`; query != want || len(args) != 1 || args[0] != int64(5) {
+		t.Errorf("got %s with args %v, want %s", query, args, want)
+	}
+}
+
+func TestFixtureColumns(t *testing.T) {
+	type noKey struct {
+		A string
+		B *int
+	}
+	cols, err := fixtureColumns(reflect.TypeOf(noKey{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, _ := deleteQuery("t", cols, reflect.ValueOf(noKey{}))
+	if want := `DELETE FROM "t" WHERE "a" IS NOT DISTINCT FROM $1 AND "b" IS NOT DISTINCT FROM $2`; query != want {
+		t.Errorf("got %s, want %s", query, want)
+	}
+
+	type badOption struct {
+		A string `db:"a,unique"`
+	}
+	if _, err := fixtureColumns(reflect.TypeOf(badOption{})); err == nil {
+		t.Error("got no error for unknown tag option")
+	}
+	if _, err := fixtureColumns(reflect.TypeOf(0)); err == nil {
+		t.Error("got no error for non-struct row type")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":         "id",
+		"UserID":     "user_id",
+		"HTTPServer": "http_server",
+		"CreatedAt":  "created_at",
+		"Address2":   "address2",
+		"name":       "name",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package et
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// MockCall is a call recorded by a mock.
+type MockCall struct {
+	// Args are the arguments the mock was called with.
+	Args []any
+
+	// Results are the values returned by the mock.
+	Results []any
+}
+
+// MockCalls records the calls made to a mock, for verifying
+// how the code under test used it. It is safe for concurrent use.
+type MockCalls struct {
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+// Len reports the number of calls made to the mock.
+func (c *MockCalls) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.calls)
+}
+
+// Calls returns the calls made to the mock so far, in the order they were made.
+func (c *MockCalls) Calls() []MockCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	calls := make([]MockCall, len(c.calls))
+	copy(calls, c.calls)
+	return calls
+}
+
+// Last returns the most recent call made to the mock.
+// It reports false if the mock hasn't been called.
+func (c *MockCalls) Last() (MockCall, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.calls) == 0 {
+		return MockCall{}, false
+	}
+	return c.calls[len(c.calls)-1], true
+}
+
+func (c *MockCalls) record(call MockCall) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+}
+
+// RecordCalls wraps the function fn so the calls made to it are recorded
+// in the returned MockCalls. It is useful for verifying the use of fakes
+// passed to MockDependency, or of functions used as dependencies directly.
+//
+// It panics if fn is not a function.
+func RecordCalls[F any](fn F) (F, *MockCalls) {
+	wrapped, calls := recordCalls(fn)
+	return wrapped.(F), calls
+}
+
+func recordCalls(fn any) (any, *MockCalls) {
+	val := reflect.ValueOf(fn)
+	if val.Kind() != reflect.Func || val.IsNil() {
+		panic(fmt.Sprintf("et: cannot record calls of %T: not a function", fn))
+	}
+
+	calls := &MockCalls{}
+	wrapped := reflect.MakeFunc(val.Type(), func(in []reflect.Value) []reflect.Value {
+		var out []reflect.Value
+		if val.Type().IsVariadic() {
+			out = val.CallSlice(in)
+		} else {
+			out = val.Call(in)
+		}
+		calls.record(MockCall{Args: interfaces(in), Results: interfaces(out)})
+		return out
+	})
+	return wrapped.Interface(), calls
+}
+
+func interfaces(vals []reflect.Value) []any {
+	res := make([]any, len(vals))
+	for i, v := range vals {
+		res[i] = v.Interface()
+	}
+	return res
+}
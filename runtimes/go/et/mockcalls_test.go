@@ -0,0 +1,72 @@
+package et
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRecordCalls(t *testing.T) {
+	errEmpty := errors.New("empty")
+	fn, calls := RecordCalls(func(s string) (int, error) {
+		if s == "" {
+			return 0, errEmpty
+		}
+		return len(s), nil
+	})
+
+	if _, ok := calls.Last(); ok {
+		t.Fatal("got a call before calling the function")
+	}
+	if n, err := fn("abc"); n != 3 || err != nil {
+		t.Fatalf("got (%d, %v), want (3, nil)", n, err)
+	}
+	_, _ = fn("")
+
+	want := []MockCall{
+		{Args: []any{"abc"}, Results: []any{3, nil}},
+		{Args: []any{""}, Results: []any{0, errEmpty}},
+	}
+	if got := calls.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got calls %+v, want %+v", got, want)
+	}
+	if last, _ := calls.Last(); !reflect.DeepEqual(last, want[1]) {
+		t.Errorf("got last call %+v, want %+v", last, want[1])
+	}
+}
+
+func TestRecordCalls_Variadic(t *testing.T) {
+	fn, calls := RecordCalls(func(sep string, parts ...string) string {
+		return strings.Join(parts, sep)
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := fn("-", "a", "b"); got != "a-b" {
+				t.Errorf("got %q, want %q", got, "a-b")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Len(); got != 10 {
+		t.Fatalf("got %d calls, want 10", got)
+	}
+	if args := calls.Calls()[0].Args; !reflect.DeepEqual(args, []any{"-", []string{"a", "b"}}) {
+		t.Errorf("got args %v", args)
+	}
+}
+
+func TestRecordCalls_NotFunc(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	RecordCalls(42)
+}
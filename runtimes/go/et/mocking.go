@@ -5,6 +5,9 @@ package et
 import (
 	"fmt"
 	"reflect"
+	"testing"
+
+	"encore.dev/appruntime/apisdk/service"
 )
 
 // MockOption is a function that can be passed to MockEndpoint or MockService to configure the mocking behavior.
@@ -107,3 +110,78 @@ func MockService[T any](serviceName string, mock T, opts ...MockOption) {
 
 	Singleton.testMgr.SetServiceMock(serviceName, mock, options.runMiddleware)
 }
+
+// MockMethod mocks out a single API method of a service for the current test
+// and its sub-tests, like [MockEndpoint], and records the calls made to the mock
+// in the returned MockCalls for verifying them later in the test.
+//
+// The other API methods of the service are unaffected, so MockMethod can be used
+// to replace individual methods of a service while leaving the rest of it in place,
+// as opposed to [MockService] which replaces the whole service.
+//
+// For example:
+//
+//	calls := et.MockMethod(t, products.GetPrice, func(ctx context.Context, p *products.PriceParams) (*products.PriceResponse, error) {
+//		return &products.PriceResponse{Price: 100}, nil
+//	})
+//	...
+//	if calls.Len() != 1 {
+//		t.Errorf("got %d calls to GetPrice, want 1", calls.Len())
+//	}
+//
+// The mock is removed automatically when the test ends.
+func MockMethod[F any](t testing.TB, method F, mock F, opts ...MockOption) *MockCalls {
+	t.Helper()
+	options := &mockOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	handler := Singleton.server.HandlerForFunc(method)
+	if handler == nil {
+		t.Fatalf("et.MockMethod: the function %T does not appear to be labelled as an Encore API", method)
+	}
+
+	wrapped, calls := RecordCalls(mock)
+	Singleton.testMgr.SetAPIMock(handler.ServiceName(), handler.EndpointName(), wrapped, options.runMiddleware)
+	return calls
+}
+
+// MockDependency replaces a dependency of the service struct of the given service
+// with mock for the duration of the current test, and restores the original value
+// when the test ends. The field function selects the dependency to replace,
+// which is typically a field of an interface type set by the service's initService function.
+//
+// For example, for a service struct declared as:
+//
+//	//encore:service
+//	type Service struct {
+//		mailer Mailer
+//	}
+//
+// The mailer can be replaced in a test in the service's package with:
+//
+//	et.MockDependency(t, "email", func(s *Service) *Mailer { return &s.mailer }, &fakeMailer{})
+//
+// The service is initialized if it hasn't been already. As service instances are shared
+// between tests by default, use [EnableServiceInstanceIsolation] when replacing
+// dependencies in tests that run in parallel.
+func MockDependency[S any, D any](t testing.TB, serviceName string, field func(*S) *D, mock D) {
+	t.Helper()
+	decl, err := service.Get[*service.Decl[S]](serviceName)
+	if err != nil {
+		t.Fatalf("et.MockDependency: %v", err)
+	}
+	svc, err := decl.Get()
+	if err != nil {
+		t.Fatalf("et.MockDependency: cannot initialize service %s: %v", serviceName, err)
+	}
+
+	dep := field(svc)
+	if dep == nil {
+		t.Fatalf("et.MockDependency: the field function returned nil for service %s", serviceName)
+	}
+	orig := *dep
+	*dep = mock
+	t.Cleanup(func() { *dep = orig })
+}
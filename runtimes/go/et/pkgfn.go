@@ -4,7 +4,11 @@ package et
 
 import (
 	"context"
+	"path/filepath"
+	"testing"
+	"time"
 
+	"encore.dev/appruntime/exported/trace2/tracetest"
 	"encore.dev/beta/auth"
 	"encore.dev/storage/sqldb"
 )
@@ -30,6 +34,59 @@ func OverrideAuthInfo(uid auth.UID, data any) {
 	Singleton.OverrideAuthInfo(uid, data)
 }
 
+// SetAuth sets the authenticated user for the current test and the sub-tests
+// it starts afterwards. API calls made from the test are made as the given user,
+// and auth.UserID and auth.Data return the given uid and data within the test.
+//
+// If the application's auth handler returns custom auth data, data must be of
+// the same type as the auth handler returns. Use SetUserID for applications
+// whose auth handler doesn't return auth data.
+func SetAuth[T any](t testing.TB, uid auth.UID, data T) {
+	t.Helper()
+	Singleton.SetAuth(t, uid, data)
+}
+
+// SetUserID is like SetAuth, for applications whose auth handler doesn't return
+// custom auth data. Passing in an empty uid makes the test unauthenticated again.
+func SetUserID(t testing.TB, uid auth.UID) {
+	t.Helper()
+	Singleton.SetAuth(t, uid, nil)
+}
+
+// Authenticate runs the application's auth handler, or its mock set using MockAuthHandler,
+// with the given auth params and sets the authenticated user for the current test
+// like SetAuth. The params must be of the same type as the auth handler's params,
+// such as the token string for auth handlers taking a token.
+//
+// The test fails if the auth handler returns an error.
+func Authenticate[P any](t testing.TB, params P) {
+	t.Helper()
+	Singleton.Authenticate(t, params)
+}
+
+// MockAuthHandler replaces the application's auth handler with mock for the current test
+// and its sub-tests. The mock must have the same signature as the auth handler,
+// and is called in its place by Authenticate and when authenticating incoming requests,
+// so tests don't need to mint real credentials.
+//
+// For example, if the auth handler is defined as:
+//
+//	//encore:authhandler
+//	func AuthHandler(ctx context.Context, token string) (auth.UID, *UserData, error) {
+//		...
+//	}
+//
+// You can mock it in your test as:
+//
+//	et.MockAuthHandler(func(ctx context.Context, token string) (auth.UID, *UserData, error) {
+//		return "test-user", &UserData{Admin: token == "admin"}, nil
+//	})
+//
+// Setting the mock to nil removes the mock set by the current test.
+func MockAuthHandler[T any](mock T) {
+	Singleton.MockAuthHandler(mock)
+}
+
 // EnableServiceInstanceIsolation will causes all Service singletons to be isolated to each test
 // from this test and on any of its sub-tests. (Calling this in a TestMain has the impact
 // of isolating all tests in the package.)
@@ -43,6 +100,78 @@ func EnableServiceInstanceIsolation() {
 	Singleton.testMgr.SetIsolatedServices(true)
 }
 
+// SetTime sets the time as seen by the current test and its sub-tests to t,
+// and freezes it there until changed again using SetTime or AdvanceTime.
+//
+// The time is used by encore.Now, cache expiry and the publish time of
+// Pub/Sub messages, making time-dependent code testable deterministically.
+// Note that traces always use the actual time.
+func SetTime(t time.Time) {
+	Singleton.testMgr.SetTime(t)
+}
+
+// AdvanceTime moves the time as seen by the current test and its sub-tests
+// forward by d. If the time has been frozen using SetTime it stays frozen,
+// and otherwise it keeps moving on from the new time.
+//
+// Cache keys expire as the time advances. Note that the test cache is
+// shared across tests, so keys written by other tests expire as well.
+func AdvanceTime(d time.Duration) {
+	Singleton.testMgr.AdvanceTime(d)
+}
+
+// SetIDSeed makes the IDs generated in the current test and its sub-tests
+// come from a sequence seeded with seed, instead of being random.
+// This covers trace and span ids, and UUIDs generated using uuid.NewV4
+// from encore.dev/types/uuid, so responses and events containing them
+// can be compared against golden files.
+//
+// Each sub-test gets a sequence of its own, derived from the seed and the
+// name of the sub-test, so the ids don't depend on the order parallel tests run in.
+func SetIDSeed(seed uint64) {
+	Singleton.testMgr.SetIDSeed(seed)
+}
+
+// Trace returns the trace captured so far in the current test: the span of
+// the test itself, with the spans of the requests made from within the test
+// as its descendants, and the events captured within them such as database
+// queries, API calls, published messages and log messages.
+//
+// The returned trace is a snapshot and is not updated as the test continues.
+// Traces are captured when tracing is enabled, as it is when using 'encore test'.
+func Trace(t testing.TB) *TraceSpan {
+	t.Helper()
+	return Singleton.Trace(t)
+}
+
+// RecordTrace returns a recorder recording the trace events logged in the
+// current test from then on, exactly as they're written to the trace,
+// for use with the assertions in encore.dev/appruntime/exported/trace2/tracetest.
+// It complements Trace for regression tests verifying that instrumentation,
+// such as database queries or published messages, isn't silently lost.
+//
+// Events are recorded when tracing is enabled, as it is when using 'encore test'.
+func RecordTrace(t testing.TB) *tracetest.Recorder {
+	t.Helper()
+	return Singleton.RecordTrace(t)
+}
+
+// UseHTTPCassette makes the outbound HTTP calls made using http.DefaultClient
+// (including http.Get and http.Post) in the current test and its sub-tests
+// replay the calls recorded in the cassette file testdata/cassettes/<name>.json.
+// If the file doesn't exist the calls are made and recorded to it when the test ends,
+// so the first run records the calls and later runs replay them.
+//
+// Calls are replayed by matching the method, URL and request body, and each recorded call
+// is replayed once. Calls not found in the cassette fail. Delete the file to record it again.
+// Only the request method, URL and body are recorded, not the request headers.
+func UseHTTPCassette(t *testing.T, name string) {
+	t.Helper()
+	if err := Singleton.testMgr.UseHTTPCassette(filepath.Join("testdata", "cassettes", name+".json")); err != nil {
+		t.Fatalf("et.UseHTTPCassette: %v", err)
+	}
+}
+
 //publicapigen:keep
 type stringLiteral string
 
@@ -61,3 +190,14 @@ type stringLiteral string
 func NewTestDatabase(ctx context.Context, name stringLiteral) (*sqldb.Database, error) {
 	return Singleton.db.NewTestDatabase(ctx, string(name))
 }
+
+// RunCron runs the cron job with the given id from the current test, by calling its endpoint
+// like the Encore Platform does when the job is scheduled, and returns the error returned
+// by the endpoint. The endpoint observes the time set using SetTime and AdvanceTime,
+// so scheduled logic that depends on the current time can be tested deterministically.
+//
+// The test fails if the cron job isn't defined by a package imported by the test.
+func RunCron(t testing.TB, jobID string) error {
+	t.Helper()
+	return Singleton.RunCron(t, jobID)
+}
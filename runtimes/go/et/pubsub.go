@@ -1,6 +1,12 @@
 package et
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
 	"encore.dev/pubsub"
 )
 
@@ -17,4 +23,144 @@ func Topic[T any](topic *pubsub.Topic[T]) TopicHelpers[T] {
 type TopicHelpers[T any] interface {
 	// PublishedMessages returns a slice of all messages published during this test on this topic.
 	PublishedMessages() []T
+
+	// DeliverPublished delivers the messages published during this test on this topic
+	// to the topic's subscriptions, and waits for the subscription handlers to complete.
+	// Each message is only delivered once, so messages published by the handlers
+	// themselves are delivered on the next call.
+	//
+	// It returns the errors returned by the subscription handlers, if any.
+	DeliverPublished(ctx context.Context) error
+}
+
+// MessageMatcher reports whether a published message is the one expected.
+type MessageMatcher[T any] func(msg T) bool
+
+// MessageEqual returns a MessageMatcher matching messages deeply equal to want.
+func MessageEqual[T any](want T) MessageMatcher[T] {
+	return func(msg T) bool {
+		return reflect.DeepEqual(msg, want)
+	}
+}
+
+// AssertPublished reports a test failure unless a message
+// matching matcher was published on the topic during this test.
+func AssertPublished[T any](t testing.TB, topic *pubsub.Topic[T], matcher MessageMatcher[T]) bool {
+	t.Helper()
+	msgs := Topic(topic).PublishedMessages()
+	for _, msg := range msgs {
+		if matcher(msg) {
+			return true
+		}
+	}
+	t.Errorf("no matching message was published on the topic\n%s", formatMessages(msgs))
+	return false
+}
+
+// AssertNotPublished reports a test failure if a message
+// matching matcher was published on the topic during this test.
+func AssertNotPublished[T any](t testing.TB, topic *pubsub.Topic[T], matcher MessageMatcher[T]) bool {
+	t.Helper()
+	msgs := Topic(topic).PublishedMessages()
+	for i, msg := range msgs {
+		if matcher(msg) {
+			t.Errorf("unexpected message %d was published on the topic: %+v", i, msg)
+			return false
+		}
+	}
+	return true
+}
+
+// AssertPublishedInOrder reports a test failure unless messages matching the matchers
+// were published on the topic during this test, in the order of the matchers.
+// Other messages may have been published before, after and in between them.
+func AssertPublishedInOrder[T any](t testing.TB, topic *pubsub.Topic[T], matchers ...MessageMatcher[T]) bool {
+	t.Helper()
+	msgs := Topic(topic).PublishedMessages()
+	if i := matchInOrder(msgs, matchers); i >= 0 {
+		t.Errorf("no message matching expectation %d was published on the topic after the messages matching the preceding expectations\n%s",
+			i, formatMessages(msgs))
+		return false
+	}
+	return true
+}
+
+// AssertPublishedUnordered reports a test failure unless a separate message matching
+// each of the matchers was published on the topic during this test, in any order.
+// Other messages may have been published as well.
+func AssertPublishedUnordered[T any](t testing.TB, topic *pubsub.Topic[T], matchers ...MessageMatcher[T]) bool {
+	t.Helper()
+	msgs := Topic(topic).PublishedMessages()
+	if n := matchUnordered(msgs, matchers); n < len(matchers) {
+		t.Errorf("only %d of %d expectations could be matched by separate messages published on the topic\n%s",
+			n, len(matchers), formatMessages(msgs))
+		return false
+	}
+	return true
+}
+
+// matchInOrder matches the messages against the matchers in order,
+// returning the index of the first matcher not matched, or -1 if all match.
+func matchInOrder[T any](msgs []T, matchers []MessageMatcher[T]) int {
+	i := 0
+	for _, msg := range msgs {
+		if i < len(matchers) && matchers[i](msg) {
+			i++
+		}
+	}
+	if i < len(matchers) {
+		return i
+	}
+	return -1
+}
+
+// matchUnordered returns the maximum number of matchers that
+// can be matched by separate messages, in any order.
+func matchUnordered[T any](msgs []T, matchers []MessageMatcher[T]) int {
+	// Find a maximum bipartite matching between
+	// matchers and messages using augmenting paths.
+	matches := make([][]int, len(matchers))
+	for i, m := range matchers {
+		for j, msg := range msgs {
+			if m(msg) {
+				matches[i] = append(matches[i], j)
+			}
+		}
+	}
+
+	msgMatcher := make([]int, len(msgs)) // matcher index+1 for each message, or 0
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for _, j := range matches[i] {
+			if seen[j] {
+				continue
+			}
+			seen[j] = true
+			if msgMatcher[j] == 0 || augment(msgMatcher[j]-1, seen) {
+				msgMatcher[j] = i + 1
+				return true
+			}
+		}
+		return false
+	}
+
+	n := 0
+	for i := range matchers {
+		if augment(i, make([]bool, len(msgs))) {
+			n++
+		}
+	}
+	return n
+}
+
+func formatMessages[T any](msgs []T) string {
+	if len(msgs) == 0 {
+		return "no messages were published"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "published messages:")
+	for i, msg := range msgs {
+		fmt.Fprintf(&b, "\n\t%d: %+v", i, msg)
+	}
+	return b.String()
 }
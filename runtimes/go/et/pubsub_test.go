@@ -0,0 +1,46 @@
+package et
+
+import "testing"
+
+func TestMatchInOrder(t *testing.T) {
+	msgs := []int{1, 2, 3, 2}
+	tests := []struct {
+		expect []int
+		want   int
+	}{
+		{expect: nil, want: -1},
+		{expect: []int{1, 3}, want: -1},
+		{expect: []int{2, 2}, want: -1},
+		{expect: []int{3, 1}, want: 1},
+		{expect: []int{4}, want: 0},
+	}
+	for _, tt := range tests {
+		if got := matchInOrder(msgs, equalMatchers(tt.expect)); got != tt.want {
+			t.Errorf("matchInOrder(%v) = %d, want %d", tt.expect, got, tt.want)
+		}
+	}
+}
+
+func TestMatchUnordered(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	two := func(n int) bool { return n == 2 }
+
+	// The first matcher must not use up the only message the second matcher matches.
+	if got := matchUnordered([]int{2, 4}, []MessageMatcher[int]{even, two}); got != 2 {
+		t.Errorf("got %d matches, want 2", got)
+	}
+	if got := matchUnordered([]int{2, 3}, []MessageMatcher[int]{even, two}); got != 1 {
+		t.Errorf("got %d matches, want 1", got)
+	}
+	if got := matchUnordered([]int{3, 1, 2}, equalMatchers([]int{2, 3, 1})); got != 3 {
+		t.Errorf("got %d matches, want 3", got)
+	}
+}
+
+func equalMatchers(vals []int) []MessageMatcher[int] {
+	var matchers []MessageMatcher[int]
+	for _, v := range vals {
+		matchers = append(matchers, MessageEqual(v))
+	}
+	return matchers
+}
@@ -0,0 +1,213 @@
+package et
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/exported/trace2/tracetest"
+	"encore.dev/appruntime/shared/traceprovider/tracecapture"
+)
+
+// TraceSpan is a span captured during a test, such as an API call
+// or the test itself, along with the spans started from within it.
+type TraceSpan = tracecapture.Span
+
+// TraceEvent is an event captured within a TraceSpan, such as
+// a database query or a call to another API.
+type TraceEvent = tracecapture.Event
+
+type SpanKind = tracecapture.SpanKind
+
+const (
+	RequestSpan       = tracecapture.RequestSpan
+	AuthSpan          = tracecapture.AuthSpan
+	PubsubMessageSpan = tracecapture.PubsubMessageSpan
+	JobSpan           = tracecapture.JobSpan
+	TestSpan          = tracecapture.TestSpan
+)
+
+type EventKind = tracecapture.EventKind
+
+const (
+	RPCCallEvent       = tracecapture.RPCCallEvent
+	DBQueryEvent       = tracecapture.DBQueryEvent
+	PubsubPublishEvent = tracecapture.PubsubPublishEvent
+	CacheCallEvent     = tracecapture.CacheCallEvent
+	LogMessageEvent    = tracecapture.LogMessageEvent
+	CustomSpanEvent    = tracecapture.CustomSpanEvent
+	CustomEvent        = tracecapture.CustomEvent
+)
+
+//publicapigen:drop
+func (mgr *Manager) Trace(t testing.TB) *TraceSpan {
+	t.Helper()
+	log, spanID := mgr.testLogger(t, "et.Trace")
+	sp := log.Trace(spanID)
+	if sp == nil {
+		t.Fatal("et.Trace: the trace of the current test was not captured")
+	}
+	return sp
+}
+
+//publicapigen:drop
+func (mgr *Manager) RecordTrace(t testing.TB) *tracetest.Recorder {
+	t.Helper()
+	log, _ := mgr.testLogger(t, "et.RecordTrace")
+	raw, ok := log.Logger.(*trace2.Log)
+	if !ok {
+		t.Fatal("et.RecordTrace: the trace of the current test is not recorded")
+	}
+	rec := tracetest.NewRecorder()
+	rec.Record(raw)
+	return rec
+}
+
+// testLogger returns the logger capturing the trace of the current test,
+// and the id of the test's span. It fails the test if there's none.
+func (mgr *Manager) testLogger(t testing.TB, fn string) (*tracecapture.Logger, model.SpanID) {
+	t.Helper()
+	curr := mgr.rt.Current()
+	if curr.Req == nil || curr.Req.Test == nil {
+		t.Fatalf("%s: not called from within a test", fn)
+	}
+	log, ok := curr.Trace.(*tracecapture.Logger)
+	if !ok {
+		t.Fatalf("%s: tracing is not enabled; run the tests using 'encore test'", fn)
+	}
+	return log, curr.Req.SpanID
+}
+
+// AssertSpan reports a test failure unless a request to the given endpoint
+// was handled within root, and returns the span of the first such request.
+func AssertSpan(t testing.TB, root *TraceSpan, service, endpoint string) *TraceSpan {
+	t.Helper()
+	for _, sp := range root.Spans() {
+		if sp.Kind == RequestSpan && sp.Service == service && sp.Endpoint == endpoint {
+			return sp
+		}
+	}
+	t.Errorf("no request to %s.%s was traced\n%s", service, endpoint, formatSpans(root))
+	return nil
+}
+
+// AssertEvent reports a test failure unless an event of the given kind matching
+// matcher was captured within root, and returns the first such event.
+func AssertEvent(t testing.TB, root *TraceSpan, kind EventKind, matcher func(ev *TraceEvent) bool) *TraceEvent {
+	t.Helper()
+	events := root.AllEvents(kind)
+	for _, ev := range events {
+		if matcher(ev) {
+			return ev
+		}
+	}
+	t.Errorf("no matching %s event was traced\n%s", kind, formatEvents(kind, events))
+	return nil
+}
+
+// AssertRPCCall reports a test failure unless the given endpoint was called
+// from within root, and returns the event of the first such call.
+func AssertRPCCall(t testing.TB, root *TraceSpan, service, endpoint string) *TraceEvent {
+	t.Helper()
+	return AssertEvent(t, root, RPCCallEvent, func(ev *TraceEvent) bool {
+		return ev.Service == service && ev.Endpoint == endpoint
+	})
+}
+
+// AssertDBQuery reports a test failure unless a database query containing
+// query was made from within root, and returns the event of the first such query.
+func AssertDBQuery(t testing.TB, root *TraceSpan, query string) *TraceEvent {
+	t.Helper()
+	return AssertEvent(t, root, DBQueryEvent, func(ev *TraceEvent) bool {
+		return strings.Contains(ev.Query, query)
+	})
+}
+
+// AssertNoDBQuery reports a test failure if a database query
+// containing query was made from within root.
+func AssertNoDBQuery(t testing.TB, root *TraceSpan, query string) bool {
+	t.Helper()
+	for _, ev := range root.AllEvents(DBQueryEvent) {
+		if strings.Contains(ev.Query, query) {
+			t.Errorf("unexpected database query was traced: %s", ev.Query)
+			return false
+		}
+	}
+	return true
+}
+
+// AssertPayload reports a test failure unless the captured JSON payload
+// is equal to want when encoded as JSON, ignoring formatting and field order.
+func AssertPayload(t testing.TB, payload json.RawMessage, want any) bool {
+	t.Helper()
+	wantJSON, err := json.Marshal(want)
+	if err != nil {
+		t.Errorf("unable to marshal the expected payload: %v", err)
+		return false
+	}
+	var got, exp any
+	if err := json.Unmarshal(payload, &got); err != nil {
+		t.Errorf("invalid payload %s: %v", payload, err)
+		return false
+	}
+	_ = json.Unmarshal(wantJSON, &exp)
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("got payload %s, want %s", payload, wantJSON)
+		return false
+	}
+	return true
+}
+
+func formatSpans(root *TraceSpan) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "traced spans:")
+	var write func(sp *TraceSpan, depth int)
+	write = func(sp *TraceSpan, depth int) {
+		fmt.Fprintf(&b, "\n\t%s%s", strings.Repeat("  ", depth), sp.Kind)
+		switch sp.Kind {
+		case RequestSpan, AuthSpan:
+			fmt.Fprintf(&b, " %s.%s", sp.Service, sp.Endpoint)
+		case PubsubMessageSpan:
+			fmt.Fprintf(&b, " %s/%s", sp.Topic, sp.Subscription)
+		case JobSpan:
+			fmt.Fprintf(&b, " %s/%s", sp.Queue, sp.JobID)
+		case TestSpan:
+			fmt.Fprintf(&b, " %s", sp.Test)
+		}
+		for _, c := range sp.Children {
+			write(c, depth+1)
+		}
+	}
+	write(root, 0)
+	return b.String()
+}
+
+func formatEvents(kind EventKind, events []*TraceEvent) string {
+	if len(events) == 0 {
+		return fmt.Sprintf("no %s events were traced", kind)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "traced %s events:", kind)
+	for i, ev := range events {
+		fmt.Fprintf(&b, "\n\t%d: ", i)
+		switch ev.Kind {
+		case RPCCallEvent:
+			fmt.Fprintf(&b, "%s.%s", ev.Service, ev.Endpoint)
+		case DBQueryEvent:
+			fmt.Fprintf(&b, "%s", ev.Query)
+		case PubsubPublishEvent:
+			fmt.Fprintf(&b, "%s %s", ev.Topic, ev.Message)
+		case CacheCallEvent:
+			fmt.Fprintf(&b, "%s %v", ev.Operation, ev.Keys)
+		case LogMessageEvent:
+			fmt.Fprintf(&b, "%s %s", ev.Level, ev.Msg)
+		case CustomSpanEvent, CustomEvent:
+			fmt.Fprintf(&b, "%s", ev.Name)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package et
+
+import (
+	"fmt"
+	"testing"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/traceprovider/tracecapture"
+)
+
+// recordingTB records the failures reported by assertions.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (tb *recordingTB) Helper() {}
+
+func (tb *recordingTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestTraceAssertions(t *testing.T) {
+	log := tracecapture.NewLogger(trace2.NewLog())
+	test := &model.Request{SpanID: model.SpanID{1}, Test: &model.TestData{Current: t}}
+	log.TestSpanStart(test, 1)
+	req := &model.Request{
+		SpanID:       model.SpanID{2},
+		ParentSpanID: test.SpanID,
+		RPCData: &model.RPCData{
+			Desc:          &model.RPCDesc{Service: "users", Endpoint: "Get"},
+			NonRawPayload: []byte(`{"ID": 1, "Fields": ["name"]}`),
+		},
+	}
+	log.RequestSpanStart(req, 1)
+	log.DBQueryStart(trace2.DBQueryStartParams{
+		EventParams: trace2.EventParams{SpanID: req.SpanID},
+		Query:       "SELECT name FROM users WHERE id = $1",
+	})
+	root := log.Trace(test.SpanID)
+
+	tb := &recordingTB{TB: t}
+	sp := AssertSpan(tb, root, "users", "Get")
+	AssertDBQuery(tb, sp, "FROM users")
+	AssertNoDBQuery(tb, root, "DELETE")
+	AssertPayload(tb, sp.Request, map[string]any{"Fields": []string{"name"}, "ID": 1})
+	if len(tb.errors) > 0 {
+		t.Fatalf("got failures %q, want none", tb.errors)
+	}
+
+	if AssertSpan(tb, root, "users", "List") != nil {
+		t.Error("got span for untraced request")
+	}
+	if AssertRPCCall(tb, root, "users", "Get") != nil {
+		t.Error("got event for untraced call")
+	}
+	AssertNoDBQuery(tb, root, "SELECT")
+	AssertPayload(tb, sp.Request, map[string]any{"ID": 2})
+	if len(tb.errors) != 4 {
+		t.Errorf("got %d failures, want 4: %q", len(tb.errors), tb.errors)
+	}
+}
@@ -0,0 +1,141 @@
+// Package featureflags provides typed feature flags with built-in
+// support for percentage rollouts and attribute-based targeting.
+//
+// Flags are declared as package-level variables and evaluated at request time:
+//
+//	var NewCheckout = featureflags.New("new-checkout", featureflags.Config[bool]{
+//		Default: false,
+//		Rules: []featureflags.Rule[bool]{
+//			{Value: true, Attributes: map[string]string{"tenant": "acme"}},
+//			{Value: true, Percentage: 10},
+//		},
+//	})
+//
+//	uid, _ := auth.UserID()
+//	target := featureflags.Target{UserID: uid, Attributes: map[string]string{"tenant": tenant}}
+//	if NewCheckout.GetFor(target) { /* ... */ }
+//
+// Get evaluates a flag for the authenticated user without any attributes,
+// so rules matching on attributes require GetFor.
+//
+// Every evaluation made during a traced request is recorded in the trace.
+// When running locally, flag values can be toggled without restarting the app
+// through the "/__encore/featureflags" endpoint.
+package featureflags
+
+import (
+	"sync"
+
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/beta/auth"
+)
+
+// Value is the set of types a feature flag can hold.
+type Value interface {
+	~bool | ~string | ~int | ~int64 | ~float64
+}
+
+// Config configures a feature flag.
+type Config[T Value] struct {
+	// Default is the value of the flag when no rule matches.
+	Default T
+
+	// Rules are evaluated in order, and the value of the first
+	// matching rule is used.
+	Rules []Rule[T]
+}
+
+// Rule describes a targeting rule for a feature flag.
+//
+// A rule matches a Target when all of its conditions match.
+// A rule without any conditions matches every target.
+type Rule[T Value] struct {
+	// Value is the flag value to use when the rule matches.
+	Value T
+
+	// UserIDs, if non-empty, restricts the rule to the given users.
+	UserIDs []auth.UID
+
+	// Attributes, if non-empty, restricts the rule to targets
+	// whose attributes contain all the given key-value pairs.
+	Attributes map[string]string
+
+	// Percentage, if non-zero, restricts the rule to the given percentage
+	// (between 0 and 100) of targets. Targets are bucketed deterministically
+	// based on the flag name and the target's user id, so a given user
+	// consistently sees the same value.
+	//
+	// Targets without a user id never match a percentage rule below 100.
+	Percentage float64
+}
+
+// Target describes the subject a feature flag is evaluated for.
+type Target struct {
+	// UserID is the user to evaluate the flag for.
+	UserID auth.UID
+
+	// Attributes are additional attributes to match rules against,
+	// such as {"tenant": "acme"}.
+	Attributes map[string]string
+}
+
+// Flag is a feature flag of type T.
+// Use New to declare a flag.
+type Flag[T Value] struct {
+	mgr  *Manager
+	name string
+	cfg  Config[T]
+}
+
+// Name returns the name of the flag.
+func (f *Flag[T]) Name() string {
+	return f.name
+}
+
+// Get evaluates the flag for the authenticated user making the current request, if any.
+func (f *Flag[T]) Get() T {
+	uid, _ := f.mgr.currentUID()
+	return f.GetFor(Target{UserID: uid})
+}
+
+// GetFor evaluates the flag for the given target.
+func (f *Flag[T]) GetFor(t Target) T {
+	val, reason := f.eval(t)
+	f.mgr.recordEval(f.name, val, reason)
+	return val
+}
+
+//publicapigen:drop
+type Manager struct {
+	runtime *config.Runtime
+	rt      *reqtrack.RequestTracker
+
+	mu         sync.RWMutex
+	flags      map[string]flagInfo
+	configured map[string]any // overrides from the runtime config
+	overrides  map[string]any // local overrides set via the dashboard
+}
+
+//publicapigen:drop
+func NewManager(runtime *config.Runtime, rt *reqtrack.RequestTracker, server *api.Server) *Manager {
+	mgr := &Manager{
+		runtime:    runtime,
+		rt:         rt,
+		flags:      make(map[string]flagInfo),
+		configured: make(map[string]any),
+		overrides:  make(map[string]any),
+	}
+	mgr.registerRoutes(server)
+	return mgr
+}
+
+// NewInternal declares a new feature flag using the given manager.
+//
+//publicapigen:drop
+func NewInternal[T Value](mgr *Manager, name string, cfg Config[T]) *Flag[T] {
+	f := &Flag[T]{mgr: mgr, name: name, cfg: cfg}
+	mgr.register(f)
+	return f
+}
@@ -0,0 +1,100 @@
+package featureflags
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/beta/auth"
+)
+
+func newTestManager(runtime *config.Runtime) *Manager {
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	return NewManager(runtime, rt, nil)
+}
+
+func TestFlag_Rules(t *testing.T) {
+	mgr := newTestManager(nil)
+	f := NewInternal(mgr, "checkout", Config[string]{
+		Default: "v1",
+		Rules: []Rule[string]{
+			{Value: "beta", UserIDs: []auth.UID{"alice"}},
+			{Value: "v2", Attributes: map[string]string{"tenant": "acme"}},
+		},
+	})
+
+	tests := []struct {
+		target Target
+		want   string
+	}{
+		{Target{}, "v1"},
+		{Target{UserID: "alice"}, "beta"},
+		{Target{UserID: "bob"}, "v1"},
+		{Target{UserID: "bob", Attributes: map[string]string{"tenant": "acme"}}, "v2"},
+		{Target{UserID: "bob", Attributes: map[string]string{"tenant": "other"}}, "v1"},
+	}
+	for i, test := range tests {
+		if got := f.GetFor(test.target); got != test.want {
+			t.Errorf("test %d: got %q, want %q", i, got, test.want)
+		}
+	}
+
+	// Without a current request there is no user.
+	if got := f.Get(); got != "v1" {
+		t.Errorf("Get: got %q, want %q", got, "v1")
+	}
+}
+
+func TestFlag_Percentage(t *testing.T) {
+	mgr := newTestManager(nil)
+	f := NewInternal(mgr, "rollout", Config[bool]{
+		Rules: []Rule[bool]{{Value: true, Percentage: 25}},
+	})
+
+	enabled := 0
+	const n = 10000
+	for i := 0; i < n; i++ {
+		uid := auth.UID(fmt.Sprintf("user-%d", i))
+		got := f.GetFor(Target{UserID: uid})
+		if got != f.GetFor(Target{UserID: uid}) {
+			t.Fatalf("evaluation for %s is not deterministic", uid)
+		}
+		if got {
+			enabled++
+		}
+	}
+	if pct := float64(enabled) / n * 100; pct < 22 || pct > 28 {
+		t.Errorf("got %.1f%% enabled, want approximately 25%%", pct)
+	}
+
+	if f.GetFor(Target{}) {
+		t.Errorf("percentage rule matched target without user id")
+	}
+
+	all := NewInternal(mgr, "full-rollout", Config[bool]{
+		Rules: []Rule[bool]{{Value: true, Percentage: 100}},
+	})
+	if !all.GetFor(Target{}) {
+		t.Errorf("100%% rule did not match target without user id")
+	}
+}
+
+func TestFlag_Overrides(t *testing.T) {
+	mgr := newTestManager(&config.Runtime{
+		FeatureFlags: map[string]json.RawMessage{"limit": json.RawMessage("5")},
+	})
+	f := NewInternal(mgr, "limit", Config[int]{Default: 1})
+
+	if val, reason := f.eval(Target{}); val != 5 || reason != reasonConfig {
+		t.Errorf("got (%d, %s), want (5, %s)", val, reason, reasonConfig)
+	}
+
+	mgr.overrides["limit"] = 10
+	if val, reason := f.eval(Target{}); val != 10 || reason != reasonOverride {
+		t.Errorf("got (%d, %s), want (10, %s)", val, reason, reasonOverride)
+	}
+}
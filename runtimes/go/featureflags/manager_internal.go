@@ -0,0 +1,254 @@
+package featureflags
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/jsonapi"
+	"encore.dev/beta/auth"
+)
+
+// Evaluation reasons recorded in traces.
+const (
+	reasonOverride = "override" // set via the local dashboard
+	reasonConfig   = "config"   // set via the runtime configuration
+	reasonDefault  = "default"  // no rule matched
+)
+
+// flagInfo is the type-erased view of a flag used by the manager.
+type flagInfo interface {
+	Name() string
+	defaultValue() any
+	parseValue(data []byte) (any, error)
+}
+
+func (f *Flag[T]) defaultValue() any { return f.cfg.Default }
+
+func (f *Flag[T]) parseValue(data []byte) (any, error) {
+	var val T
+	if err := json.Unmarshal(data, &val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// eval evaluates the flag for the given target,
+// returning the value and the reason it was chosen.
+func (f *Flag[T]) eval(t Target) (T, string) {
+	if val, reason, ok := f.mgr.override(f.name); ok {
+		if v, ok := val.(T); ok {
+			return v, reason
+		}
+	}
+
+	for i, r := range f.cfg.Rules {
+		if r.matches(f.name, t) {
+			return r.Value, fmt.Sprintf("rule:%d", i)
+		}
+	}
+	return f.cfg.Default, reasonDefault
+}
+
+func (r *Rule[T]) matches(flagName string, t Target) bool {
+	if len(r.UserIDs) > 0 {
+		found := false
+		for _, uid := range r.UserIDs {
+			if uid == t.UserID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for k, v := range r.Attributes {
+		if got, ok := t.Attributes[k]; !ok || got != v {
+			return false
+		}
+	}
+
+	if r.Percentage > 0 && r.Percentage < 100 {
+		if t.UserID == "" {
+			return false
+		}
+		return bucket(flagName, t.UserID) < uint32(r.Percentage*100)
+	}
+	return true
+}
+
+// bucket deterministically maps a flag and user to a bucket in [0, 10000).
+func bucket(flagName string, uid auth.UID) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(flagName))
+	_, _ = h.Write([]byte{':'})
+	_, _ = h.Write([]byte(uid))
+	return h.Sum32() % 10000
+}
+
+func (mgr *Manager) register(f flagInfo) {
+	name := f.Name()
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if _, exists := mgr.flags[name]; exists {
+		panic(fmt.Sprintf("featureflags: flag %q declared more than once", name))
+	}
+	mgr.flags[name] = f
+
+	// Apply any override from the runtime configuration.
+	if mgr.runtime != nil {
+		if data, ok := mgr.runtime.FeatureFlags[name]; ok {
+			val, err := f.parseValue(data)
+			if err != nil {
+				panic(fmt.Sprintf("featureflags: invalid configured value for flag %q: %v", name, err))
+			}
+			mgr.configured[name] = val
+		}
+	}
+}
+
+// override reports the overridden value for the given flag, if any.
+// Overrides set via the dashboard take precedence over configured ones.
+func (mgr *Manager) override(name string) (val any, reason string, ok bool) {
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+	if val, ok := mgr.overrides[name]; ok {
+		return val, reasonOverride, true
+	} else if val, ok := mgr.configured[name]; ok {
+		return val, reasonConfig, true
+	}
+	return nil, "", false
+}
+
+func (mgr *Manager) currentUID() (auth.UID, bool) {
+	if curr := mgr.rt.Current(); curr.Req != nil {
+		if curr.Req.RPCData != nil {
+			uid := curr.Req.RPCData.UserID
+			return uid, uid != ""
+		} else if curr.Req.Test != nil {
+			uid := curr.Req.Test.UserID
+			return uid, uid != ""
+		}
+	}
+	return "", false
+}
+
+// recordEval records a flag evaluation in the current trace, if any.
+func (mgr *Manager) recordEval(name string, val any, reason string) {
+	curr := mgr.rt.Current()
+	if curr.Req == nil || curr.Trace == nil {
+		return
+	}
+
+	curr.Trace.LogMessage(trace2.LogMessageParams{
+		EventParams: trace2.EventParams{
+			TraceID: curr.Req.TraceID,
+			SpanID:  curr.Req.SpanID,
+			Goid:    curr.Goctr,
+		},
+		Level: model.LevelDebug,
+		Msg:   "feature flag evaluated",
+		Fields: []trace2.LogField{
+			{Key: "flag", Value: name},
+			{Key: "value", Value: val},
+			{Key: "reason", Value: reason},
+		},
+	})
+}
+
+// registerRoutes registers the dashboard routes for toggling flags.
+// They are only registered when running locally, since the
+// Encore internal routes are not authenticated.
+func (mgr *Manager) registerRoutes(server *api.Server) {
+	if server == nil || mgr.runtime == nil || mgr.runtime.EnvCloud != "local" {
+		return
+	}
+	server.RegisterEncoreRoute("GET", "/featureflags", http.HandlerFunc(mgr.handleList))
+	server.RegisterEncoreRoute("PUT", "/featureflags/:name", http.HandlerFunc(mgr.handleSet))
+	server.RegisterEncoreRoute("DELETE", "/featureflags/:name", http.HandlerFunc(mgr.handleSet))
+}
+
+type flagState struct {
+	Name       string `json:"name"`
+	Default    any    `json:"default"`
+	Override   any    `json:"override,omitempty"`
+	Overridden bool   `json:"overridden"`
+}
+
+func (mgr *Manager) handleList(w http.ResponseWriter, req *http.Request) {
+	mgr.mu.RLock()
+	states := make([]flagState, 0, len(mgr.flags))
+	for name, f := range mgr.flags {
+		override, ok := mgr.overrides[name]
+		states = append(states, flagState{
+			Name:       name,
+			Default:    f.defaultValue(),
+			Override:   override,
+			Overridden: ok,
+		})
+	}
+	mgr.mu.RUnlock()
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	writeJSON(w, http.StatusOK, states)
+}
+
+// handleSet sets (PUT) or clears (DELETE) the override for a flag.
+func (mgr *Manager) handleSet(w http.ResponseWriter, req *http.Request) {
+	name := httprouter.ParamsFromContext(req.Context()).ByName("name")
+
+	var data []byte
+	if req.Method != http.MethodDelete {
+		var err error
+		data, err = io.ReadAll(http.MaxBytesReader(w, req.Body, 1<<16))
+		if err != nil {
+			http.Error(w, "could not read request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	f, ok := mgr.flags[name]
+	if !ok {
+		http.Error(w, "unknown feature flag", http.StatusNotFound)
+		return
+	}
+
+	if req.Method == http.MethodDelete {
+		delete(mgr.overrides, name)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	val, err := f.parseValue(data)
+	if err != nil {
+		http.Error(w, "invalid flag value: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	mgr.overrides[name] = val
+	writeJSON(w, http.StatusOK, flagState{Name: name, Default: f.defaultValue(), Override: val, Overridden: true})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	data, err := jsonapi.Default.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,20 @@
+//go:build encore_app
+
+package featureflags
+
+import (
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+//publicapigen:drop
+var Singleton = NewManager(appconf.Runtime, reqtrack.Singleton, api.Singleton)
+
+// New declares a new feature flag with the given name and configuration.
+// Flag names must be unique within the application.
+//
+// It must be called from a package-level variable declaration.
+func New[T Value](name string, cfg Config[T]) *Flag[T] {
+	return NewInternal(Singleton, name, cfg)
+}
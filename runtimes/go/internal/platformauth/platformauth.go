@@ -5,7 +5,14 @@
 // mark requests as such.
 package platformauth
 
-import "context"
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"encore.dev/appruntime/exported/config"
+)
 
 type ctxKey string
 
@@ -26,3 +33,30 @@ func IsEncorePlatformRequest(ctx context.Context) bool {
 	v, ok := value.(bool)
 	return ok && v
 }
+
+// AuthorizeAdmin reports whether the request may use the runtime's admin routes,
+// which is when running locally, when authenticated as coming from the Encore Platform,
+// or when carrying the admin bearer token configured in cfg.
+// Otherwise it responds with 401 Unauthorized.
+//
+// cfg may be nil, in which case only requests from the Encore Platform are allowed.
+func AuthorizeAdmin(w http.ResponseWriter, req *http.Request, cfg *config.Runtime) bool {
+	if IsEncorePlatformRequest(req.Context()) {
+		return true
+	} else if cfg != nil && (cfg.EnvCloud == "local" || hasAdminToken(req, cfg.AdminBearerToken)) {
+		return true
+	}
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	http.Error(w, "unauthorized", http.StatusUnauthorized)
+	return false
+}
+
+// hasAdminToken reports whether the request carries the given admin
+// bearer token in its Authorization header. It reports false if token is empty.
+func hasAdminToken(req *http.Request, token string) bool {
+	if token == "" {
+		return false
+	}
+	got, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"math"
+	"sync"
+)
+
+// seriesOverflowMetric is the name of the counter tracking
+// how often label values were replaced due to the series limit.
+const seriesOverflowMetric = "e_metrics_series_overflow_total"
+
+// overflowLabels is the registry labels key of the series that
+// a metric group's label values are reported under once
+// the group has reached its series limit.
+type overflowLabels struct{}
+
+// seriesOverflowLabels are the labels of the series overflow counter.
+type seriesOverflowLabels struct {
+	Metric string
+}
+
+// seriesOverflows tracks metric groups that have exceeded their series limit.
+type seriesOverflows struct {
+	once    sync.Once
+	counter *CounterGroup[seriesOverflowLabels, uint64]
+	warned  sync.Map // map[string]bool, by metric name
+}
+
+// maxSeriesLimit returns the series limit to use for a configured limit of n.
+// Metric groups are only limited if a limit is configured.
+func maxSeriesLimit(n int) int64 {
+	if n <= 0 {
+		return math.MaxInt64
+	}
+	return int64(n)
+}
+
+// limitSeries returns the registry labels key and the label values to use
+// for the time series with the given labels, enforcing the series limit.
+//
+// While the metric is below its limit they are returned unchanged.
+// After that, new label combinations are reported under a single series
+// with all label values set to "other". The limit is approximate,
+// as concurrent calls with new labels may exceed it slightly.
+func (m *metricInfo[V]) limitSeries(labels any, mapLabels func() []KeyValue) (key any, mapped func() []KeyValue) {
+	if m.maxSeries == math.MaxInt64 {
+		return labels, mapLabels
+	}
+	if _, ok := m.reg.registry.Load(registryKey{metricName: m.name, labels: labels}); ok {
+		return labels, mapLabels
+	}
+	if m.numSeries.Add(1) <= m.maxSeries {
+		return labels, mapLabels
+	}
+	m.numSeries.Add(-1)
+	m.reg.reportSeriesOverflow(m.name, m.maxSeries)
+
+	return overflowLabels{}, func() []KeyValue {
+		kvs := mapLabels()
+		for i := range kvs {
+			kvs[i].Value = otherLabelValue
+		}
+		return kvs
+	}
+}
+
+// reportSeriesOverflow records that a new time series of the given metric
+// was reported under the overflow series, and logs a warning the first time.
+func (r *Registry) reportSeriesOverflow(name string, limit int64) {
+	o := &r.overflows
+	if _, warned := o.warned.LoadOrStore(name, true); !warned {
+		r.rt.Logger().Warn().Str("metric", name).Int64("limit", limit).
+			Msg("metrics: metric exceeded its maximum number of time series; " +
+				"reporting additional label values as \"other\"")
+	}
+
+	o.once.Do(func() {
+		o.counter = newCounterGroup[seriesOverflowLabels, uint64](r, seriesOverflowMetric, CounterConfig{
+			EncoreInternal_LabelMapper: func(l seriesOverflowLabels) []KeyValue {
+				return []KeyValue{{Key: "metric", Value: l.Metric}}
+			},
+		})
+		// The number of series is bounded by the number of metrics.
+		o.counter.maxSeries = math.MaxInt64
+	})
+	o.counter.With(seriesOverflowLabels{Metric: name}).Increment()
+}
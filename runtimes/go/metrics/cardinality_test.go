@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestSeriesLimit(t *testing.T) {
+	type myLabels struct {
+		user string
+	}
+
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	mgr := NewRegistry(rt, 1)
+	c := newCounterGroup[myLabels, int64](mgr, "foo", CounterConfig{
+		MaxSeries:             2,
+		EncoreInternal_SvcNum: 1,
+		EncoreInternal_LabelMapper: func(labels myLabels) []KeyValue {
+			return []KeyValue{{Key: "user", Value: labels.user}}
+		},
+	})
+
+	for _, user := range []string{"a", "b", "c", "d", "a", "c"} {
+		c.With(myLabels{user: user}).Increment()
+	}
+
+	// Previously seen labels keep their own series.
+	a, _ := getTS[int64](mgr, "foo", myLabels{user: "a"}, nil)
+	eq(t, a.value[0], 2)
+	if _, loaded := mgr.registry.Load(registryKey{metricName: "foo", labels: myLabels{user: "c"}}); loaded {
+		t.Fatal("got a series for labels beyond the limit")
+	}
+
+	other, _ := getTS[int64](mgr, "foo", overflowLabels{}, nil)
+	eq(t, other.value[0], 3)
+	if want := []KeyValue{{Key: "user", Value: "other"}}; !reflect.DeepEqual(other.labels, want) {
+		t.Fatalf("got overflow labels %+v, want %+v", other.labels, want)
+	}
+
+	overflow, loaded := getTS[uint64](mgr, seriesOverflowMetric, seriesOverflowLabels{Metric: "foo"}, nil)
+	eq(t, loaded, true)
+	if want := []KeyValue{{Key: "metric", Value: "foo"}}; !reflect.DeepEqual(overflow.labels, want) {
+		t.Fatalf("got overflow counter labels %+v, want %+v", overflow.labels, want)
+	}
+	eq(t, countryRegistry(&mgr.registry), 4)
+}
+
+func TestMaxSeriesLimit(t *testing.T) {
+	eq(t, maxSeriesLimit(0), math.MaxInt64)
+	eq(t, maxSeriesLimit(-1), math.MaxInt64)
+	eq(t, maxSeriesLimit(10), 10)
+}
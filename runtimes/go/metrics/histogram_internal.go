@@ -1,16 +1,32 @@
-//go:build encore_app
-
 package metrics
 
 import (
+	"fmt"
 	"math"
+	"time"
 
+	"encore.dev/appruntime/shared/exemplar"
+	"encore.dev/appruntime/shared/explicithist"
 	"encore.dev/appruntime/shared/nativehist"
 )
 
 // HistogramConfig configures a histogram.
-// It's currently a placeholder as there is not yet any additional configuration.
 type HistogramConfig struct {
+	// Buckets are the upper bounds of the histogram buckets, in strictly
+	// increasing order. Observations greater than the last bound are
+	// counted in an additional overflow bucket.
+	//
+	// If empty, the histogram uses exponential buckets that adapt to the
+	// observed values, for backends that support native histograms.
+	Buckets []float64
+
+	// MaxSeries is the maximum number of distinct label combinations
+	// of a histogram group, after which new combinations are reported
+	// with all label values set to "other". If zero the number of
+	// label combinations is not limited.
+	// It has no effect on histograms without labels.
+	MaxSeries int
+
 	//publicapigen:drop
 	EncoreInternal_LabelMapper any // func(L) []KeyValue
 
@@ -18,65 +34,56 @@ type HistogramConfig struct {
 	EncoreInternal_SvcNum uint16
 }
 
-// NewHistogram creates a new histogram metric, without any labels.
-// Use NewHistogramGroup for histograms with labels.
-func NewHistogram[V Value](name string, cfg HistogramConfig) *Histogram[V] {
-	return newHistogramInternal[V](newMetricInfo[V](Singleton, name, HistogramType, cfg.EncoreInternal_SvcNum))
-}
-
-func newHistogramInternal[V Value](m *metricInfo[V]) *Histogram[V] {
-	ts, setup := getTS[*nativehist.Histogram](m.reg, m.name, nil, m)
-
-	// Initialize the values if they haven't yet been set up.
-	if !setup {
-		n := m.reg.numSvcs
-		if m.svcNum > 0 {
-			n = 1
-		}
-		ts.value = make([]*nativehist.Histogram, n)
-		for i := range ts.value {
-			ts.value[i] = nativehist.New(bucketFactor)
-		}
-	}
-
+func newHistogramInternal[V Value](m *metricInfo[V], buckets []float64) *Histogram[V] {
 	return &Histogram[V]{
 		metricInfo: m,
-		ts:         ts,
+		vals:       histogramValues(m, nil, nil, checkBuckets(m.name, buckets)),
 		toFloat:    makeToFloat[V](),
 	}
 }
 
 type Histogram[V Value] struct {
 	*metricInfo[V]
-	ts      *timeseries[*nativehist.Histogram]
+	vals    []histogramValue
 	toFloat func(V) float64
 }
 
+// Observe records an observation in the histogram.
+//
+// If called during a traced request, the observation is also
+// recorded as an exemplar linking the histogram to the trace.
 func (h *Histogram[V]) Observe(val V) {
 	f := h.toFloat(val)
 	if math.IsNaN(f) {
 		return
 	}
 	if idx, ok := h.svcIdx(); ok {
-		h.ts.value[idx].Observe(f)
+		if curr := h.reg.rt.Current(); curr.Req != nil && curr.Req.Traced {
+			h.vals[idx].ObserveWithExemplar(f, exemplar.Exemplar{
+				Value:   f,
+				Time:    time.Now(),
+				TraceID: curr.Req.TraceID,
+				SpanID:  curr.Req.SpanID,
+			})
+		} else {
+			h.vals[idx].Observe(f)
+		}
 	}
 }
 
-// NewHistogramGroup creates a new histogram group with a set of labels,
-// where each unique combination of labels becomes its own histogram.
-//
-// The Labels type must be a named struct, where each field corresponds to
-// a single label. Each field must be of type string.
-func NewHistogramGroup[L Labels, V Value](name string, cfg HistogramConfig) *HistogramGroup[L, V] {
-	return newHistogramGroup[L, V](Singleton, name, cfg)
+//publicapigen:drop
+func NewHistogramGroupInternal[L Labels, V Value](reg *Registry, name string, cfg HistogramConfig) *HistogramGroup[L, V] {
+	return newHistogramGroup[L, V](reg, name, cfg)
 }
 
 func newHistogramGroup[L Labels, V Value](mgr *Registry, name string, cfg HistogramConfig) *HistogramGroup[L, V] {
 	labelMapper := cfg.EncoreInternal_LabelMapper.(func(L) []KeyValue)
 	m := newMetricInfo[V](mgr, name, HistogramType, cfg.EncoreInternal_SvcNum)
+	m.maxSeries = maxSeriesLimit(cfg.MaxSeries)
 	return &HistogramGroup[L, V]{
 		metricInfo:  m,
 		labelMapper: labelMapper,
+		buckets:     checkBuckets(name, cfg.Buckets),
 		toFloat:     makeToFloat[V](),
 	}
 }
@@ -84,40 +91,78 @@ func newHistogramGroup[L Labels, V Value](mgr *Registry, name string, cfg Histog
 type HistogramGroup[L Labels, V Value] struct {
 	*metricInfo[V]
 	labelMapper func(L) []KeyValue
+	buckets     []float64
 	toFloat     func(V) float64
 }
 
 func (c *HistogramGroup[L, V]) With(labels L) *Histogram[V] {
-	ts := c.get(labels)
+	key, mapLabels := c.limitSeries(labels, func() []KeyValue { return c.labelMapper(labels) })
 	return &Histogram[V]{
 		metricInfo: c.metricInfo,
-		ts:         ts,
+		vals:       histogramValues(c.metricInfo, key, mapLabels, c.buckets),
 		toFloat:    c.toFloat,
 	}
 }
 
-func (c *HistogramGroup[L, V]) get(labels L) *timeseries[*nativehist.Histogram] {
-	ts, setup := getTS[*nativehist.Histogram](c.reg, c.name, labels, c.metricInfo)
+// histogramValue is implemented by the histogram types backing a Histogram.
+type histogramValue interface {
+	Observe(float64)
+	ObserveWithExemplar(float64, exemplar.Exemplar)
+}
+
+// histogramValues returns the per-service histograms of the time series
+// for the given labels, creating them on first use.
+// If buckets is empty the histograms use exponential buckets.
+func histogramValues[V Value](m *metricInfo[V], labels any, mapLabels func() []KeyValue, buckets []float64) []histogramValue {
+	if len(buckets) > 0 {
+		return initHistogramTS(m, labels, mapLabels, func() *explicithist.Histogram {
+			return explicithist.New(buckets)
+		})
+	}
+	return initHistogramTS(m, labels, mapLabels, func() *nativehist.Histogram {
+		return nativehist.New(bucketFactor)
+	})
+}
+
+func initHistogramTS[V Value, T histogramValue](m *metricInfo[V], labels any, mapLabels func() []KeyValue, newHist func() T) []histogramValue {
+	ts, setup := getTS[T](m.reg, m.name, labels, m)
 
 	if !setup {
 		// Initialize this histogram timeseries on first use.
 		ts.init.Start()
 		defer ts.init.Done()
 
-		n := c.reg.numSvcs
-		if c.svcNum > 0 {
+		if mapLabels != nil {
+			ts.labels = mapLabels()
+		}
+		n := m.reg.numSvcs
+		if m.svcNum > 0 {
 			n = 1
 		}
-		ts.value = make([]*nativehist.Histogram, n)
+		ts.value = make([]T, n)
 		for i := range ts.value {
-			ts.value[i] = nativehist.New(bucketFactor)
+			ts.value[i] = newHist()
 		}
 	} else {
 		// Wait for the timeseries to be initialized before we continue.
 		ts.init.Wait()
 	}
 
-	return ts
+	vals := make([]histogramValue, len(ts.value))
+	for i, v := range ts.value {
+		vals[i] = v
+	}
+	return vals
+}
+
+// checkBuckets panics if buckets are given but are not valid bucket bounds.
+func checkBuckets(name string, buckets []float64) []float64 {
+	if len(buckets) > 0 {
+		if err := explicithist.ValidateBounds(buckets); err != nil {
+			panic(fmt.Sprintf("metrics: invalid buckets for histogram %s: %v", name, err))
+		}
+	}
+	return buckets
 }
 
 func makeToFloat[V Value]() func(V) float64 {
@@ -0,0 +1,18 @@
+//go:build encore_app
+
+package metrics
+
+// NewHistogram creates a new histogram metric, without any labels.
+// Use NewHistogramGroup for histograms with labels.
+func NewHistogram[V Value](name string, cfg HistogramConfig) *Histogram[V] {
+	return newHistogramInternal[V](newMetricInfo[V](Singleton, name, HistogramType, cfg.EncoreInternal_SvcNum), cfg.Buckets)
+}
+
+// NewHistogramGroup creates a new histogram group with a set of labels,
+// where each unique combination of labels becomes its own histogram.
+//
+// The Labels type must be a named struct, where each field corresponds to
+// a single label. Each field must be of type string.
+func NewHistogramGroup[L Labels, V Value](name string, cfg HistogramConfig) *HistogramGroup[L, V] {
+	return newHistogramGroup[L, V](Singleton, name, cfg)
+}
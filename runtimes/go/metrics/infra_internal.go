@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// infraOpBuckets are the bucket bounds, in seconds,
+// of the infrastructure operation duration histogram.
+var infraOpBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type infraOpLabels struct {
+	kind      string // "sqldb", "cache", "pubsub" or "objects"
+	resource  string // The database, keyspace, topic or bucket.
+	operation string
+}
+
+type infraOpTotalLabels struct {
+	infraOpLabels
+	status string // "ok" or "error"
+}
+
+// infraOps holds the lazily created metrics of infrastructure operations.
+type infraOps struct {
+	once    sync.Once
+	metrics *infraOpMetrics
+}
+
+// infraOpMetrics are the built-in metrics of infrastructure operations.
+type infraOpMetrics struct {
+	total    *CounterGroup[infraOpTotalLabels, uint64]
+	duration *HistogramGroup[infraOpLabels, float64]
+}
+
+func newInfraOpMetrics(r *Registry) *infraOpMetrics {
+	mapLabels := func(l infraOpLabels) []KeyValue {
+		return []KeyValue{
+			{Key: "type", Value: l.kind},
+			{Key: "resource", Value: l.resource},
+			{Key: "operation", Value: l.operation},
+		}
+	}
+	return &infraOpMetrics{
+		total: NewCounterGroupInternal[infraOpTotalLabels, uint64](r, "e_infra_operations_total", CounterConfig{
+			EncoreInternal_LabelMapper: func(l infraOpTotalLabels) []KeyValue {
+				return append(mapLabels(l.infraOpLabels), KeyValue{Key: "status", Value: l.status})
+			},
+		}),
+		duration: NewHistogramGroupInternal[infraOpLabels, float64](r, "e_infra_operation_duration_seconds", HistogramConfig{
+			Buckets:                    infraOpBuckets,
+			EncoreInternal_LabelMapper: mapLabels,
+		}),
+	}
+}
+
+// InfraOp is an operation performed through an infrastructure SDK,
+// such as a database query, whose outcome is recorded by End.
+type InfraOp struct {
+	m      *infraOpMetrics // nil if not recorded
+	labels infraOpLabels
+	start  time.Time
+}
+
+// StartInfraOp starts recording an operation of the given kind of
+// infrastructure on the named resource, such as a "query" of a database.
+// It's safe to call on a nil registry, in which case nothing is recorded.
+func (r *Registry) StartInfraOp(kind, resource, operation string) InfraOp {
+	if r == nil {
+		return InfraOp{}
+	}
+	o := &r.infraOps
+	o.once.Do(func() {
+		o.metrics = newInfraOpMetrics(r)
+	})
+	return InfraOp{
+		m:      o.metrics,
+		labels: infraOpLabels{kind: kind, resource: resource, operation: operation},
+		start:  time.Now(),
+	}
+}
+
+// End records the completion of the operation,
+// which failed if err is non-nil.
+func (op InfraOp) End(err error) {
+	if op.m == nil {
+		return
+	}
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	op.m.total.With(infraOpTotalLabels{infraOpLabels: op.labels, status: status}).Increment()
+	op.m.duration.With(op.labels).Observe(time.Since(op.start).Seconds())
+}
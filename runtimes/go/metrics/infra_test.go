@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/explicithist"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestInfraOp(t *testing.T) {
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	mgr := NewRegistry(rt, 2)
+
+	rt.BeginRequest(&model.Request{SvcNum: 2})
+	mgr.StartInfraOp("sqldb", "orders", "query").End(nil)
+	mgr.StartInfraOp("sqldb", "orders", "query").End(errors.New("boom"))
+	mgr.StartInfraOp("sqldb", "orders", "query").End(nil)
+	rt.FinishRequest(false)
+
+	labels := infraOpLabels{kind: "sqldb", resource: "orders", operation: "query"}
+	ok, _ := getTS[uint64](mgr, "e_infra_operations_total", infraOpTotalLabels{infraOpLabels: labels, status: "ok"}, nil)
+	eq(t, ok.value[0], 0)
+	eq(t, ok.value[1], 2)
+	if want := []KeyValue{
+		{Key: "type", Value: "sqldb"},
+		{Key: "resource", Value: "orders"},
+		{Key: "operation", Value: "query"},
+		{Key: "status", Value: "ok"},
+	}; !reflect.DeepEqual(ok.labels, want) {
+		t.Fatalf("got labels %+v, want %+v", ok.labels, want)
+	}
+
+	failed, _ := getTS[uint64](mgr, "e_infra_operations_total", infraOpTotalLabels{infraOpLabels: labels, status: "error"}, nil)
+	eq(t, failed.value[1], 1)
+
+	duration, loaded := getTS[*explicithist.Histogram](mgr, "e_infra_operation_duration_seconds", labels, nil)
+	eq(t, loaded, true)
+	eq(t, duration.value[1].Snapshot().Count, 3)
+}
+
+func TestInfraOp_NilRegistry(t *testing.T) {
+	var mgr *Registry
+	// Must not panic.
+	mgr.StartInfraOp("cache", "users/:id", "get").End(nil)
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"math"
 	"sync/atomic"
 )
 
@@ -10,8 +11,14 @@ type Labels interface {
 }
 
 // CounterConfig configures a counter.
-// It's currently a placeholder as there is not yet any additional configuration.
 type CounterConfig struct {
+	// MaxSeries is the maximum number of distinct label combinations
+	// of a counter group, after which new combinations are reported
+	// with all label values set to "other". If zero the number of
+	// label combinations is not limited.
+	// It has no effect on counters without labels.
+	MaxSeries int
+
 	//publicapigen:drop
 	EncoreInternal_LabelMapper any // func(L) []KeyValue
 
@@ -60,6 +67,7 @@ func NewCounterGroupInternal[L Labels, V Value](reg *Registry, name string, cfg
 func newCounterGroup[L Labels, V Value](mgr *Registry, name string, cfg CounterConfig) *CounterGroup[L, V] {
 	labelMapper := cfg.EncoreInternal_LabelMapper.(func(L) []KeyValue)
 	m := newMetricInfo[V](mgr, name, CounterType, cfg.EncoreInternal_SvcNum)
+	m.maxSeries = maxSeriesLimit(cfg.MaxSeries)
 	return &CounterGroup[L, V]{metricInfo: m, labelMapper: labelMapper}
 }
 
@@ -74,9 +82,10 @@ func (c *CounterGroup[L, V]) With(labels L) *Counter[V] {
 }
 
 func (c *CounterGroup[L, V]) get(labels L) *timeseries[V] {
-	ts, setup := c.metricInfo.getTS(labels)
+	key, mapLabels := c.limitSeries(labels, func() []KeyValue { return c.labelMapper(labels) })
+	ts, setup := c.metricInfo.getTS(key)
 	if !setup {
-		ts.setup(c.labelMapper(labels))
+		ts.setup(mapLabels())
 	} else {
 		// Wait for the timeseries to be initialized before we continue.
 		ts.init.Wait()
@@ -94,8 +103,14 @@ func newGauge[V Value](m *metricInfo[V]) *Gauge[V] {
 }
 
 // GaugeConfig configures a gauge.
-// It's currently a placeholder as there is not yet any additional configuration.
 type GaugeConfig struct {
+	// MaxSeries is the maximum number of distinct label combinations
+	// of a gauge group, after which new combinations are reported
+	// with all label values set to "other". If zero the number of
+	// label combinations is not limited.
+	// It has no effect on gauges without labels.
+	MaxSeries int
+
 	//publicapigen:drop
 	EncoreInternal_LabelMapper any // func(L) any) []KeyValue
 
@@ -122,9 +137,39 @@ func (g *Gauge[V]) Add(val V) {
 	}
 }
 
+func newGaugeFunc[V Value](m *metricInfo[V], fn func() V) *GaugeFunc[V] {
+	ts, setup := m.getTS(nil)
+	if !setup {
+		ts.setup(nil)
+	}
+
+	g := &GaugeFunc[V]{metricInfo: m, ts: ts, fn: fn}
+	m.reg.addCollectHook(g.observe)
+	return g
+}
+
+// GaugeFunc is a gauge whose value is computed by a callback
+// each time metrics are collected.
+type GaugeFunc[V Value] struct {
+	*metricInfo[V]
+	ts *timeseries[V]
+	fn func() V
+}
+
+func (g *GaugeFunc[V]) observe() {
+	// The callback runs outside of any request, so the value can only
+	// be attributed to the service the gauge is declared in.
+	if g.svcNum == 0 {
+		return
+	}
+	g.set(&g.ts.value[0], g.fn())
+	g.ts.valid[0].Store(true)
+}
+
 func newGaugeGroup[L Labels, V Value](mgr *Registry, name string, cfg GaugeConfig) *GaugeGroup[L, V] {
 	labelMapper := cfg.EncoreInternal_LabelMapper.(func(L) []KeyValue)
 	m := newMetricInfo[V](mgr, name, GaugeType, cfg.EncoreInternal_SvcNum)
+	m.maxSeries = maxSeriesLimit(cfg.MaxSeries)
 	return &GaugeGroup[L, V]{metricInfo: m, labelMapper: labelMapper}
 }
 
@@ -139,9 +184,10 @@ func (g *GaugeGroup[L, V]) With(labels L) *Gauge[V] {
 }
 
 func (g *GaugeGroup[L, V]) get(labels L) *timeseries[V] {
-	ts, setup := g.metricInfo.getTS(labels)
+	key, mapLabels := g.limitSeries(labels, func() []KeyValue { return g.labelMapper(labels) })
+	ts, setup := g.metricInfo.getTS(key)
 	if !setup {
-		ts.setup(g.labelMapper(labels))
+		ts.setup(mapLabels())
 	} else {
 		// Wait for the timeseries to be initialized before we continue.
 		ts.init.Wait()
@@ -160,6 +206,8 @@ func newMetricInfo[V Value](mgr *Registry, name string, typ MetricType, svcNum u
 		typ:    typ,
 		svcNum: svcNum,
 
+		maxSeries: math.MaxInt64,
+
 		add: add,
 		set: set,
 		inc: inc,
@@ -172,6 +220,9 @@ type metricInfo[V Value] struct {
 	typ    MetricType
 	svcNum uint16
 
+	maxSeries int64        // the maximum number of time series, for groups
+	numSeries atomic.Int64 // the number of time series created, for groups
+
 	add func(addr *V, val V)
 	set func(addr *V, val V)
 	inc func(addr *V)
@@ -126,6 +126,34 @@ func TestGauge(t *testing.T) {
 	eq(t, countryRegistry(&mgr.registry), 1)
 }
 
+func TestGaugeFunc(t *testing.T) {
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	mgr := NewRegistry(rt, 1)
+	depth := 3
+	newGaugeFunc(newMetricInfo[int64](mgr, "foo", GaugeType, 1), func() int64 {
+		return int64(depth)
+	})
+	newGaugeFunc(newMetricInfo[int64](mgr, "bar", GaugeType, 1), func() int64 {
+		panic("boom")
+	})
+
+	ts, _ := getTS[int64](mgr, "foo", nil, nil)
+	eq(t, ts.valid[0].Load(), false)
+
+	collected := mgr.Collect()
+	eq(t, len(collected), 2)
+	eq(t, ts.value[0], 3)
+	eq(t, ts.valid[0].Load(), true)
+
+	depth = 5
+	mgr.Collect()
+	eq(t, ts.value[0], 5)
+
+	// A panicking callback leaves its gauge unset.
+	bar, _ := getTS[int64](mgr, "bar", nil, nil)
+	eq(t, bar.valid[0].Load(), false)
+}
+
 func TestCounterGroup(t *testing.T) {
 	type myLabels struct {
 		key string
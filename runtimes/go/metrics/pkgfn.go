@@ -23,6 +23,16 @@ func NewGauge[V Value](name string, cfg GaugeConfig) *Gauge[V] {
 	return newGauge[V](newMetricInfo[V](Singleton, name, GaugeType, cfg.EncoreInternal_SvcNum))
 }
 
+// NewGaugeFunc creates a new gauge metric whose value is computed
+// by calling fn each time metrics are collected, without any labels.
+//
+// It's useful for values that are cheap to read on demand but wasteful
+// to keep updated continuously, like the length of a queue.
+// The function must be safe to call concurrently and should return quickly.
+func NewGaugeFunc[V Value](name string, cfg GaugeConfig, fn func() V) *GaugeFunc[V] {
+	return newGaugeFunc[V](newMetricInfo[V](Singleton, name, GaugeType, cfg.EncoreInternal_SvcNum), fn)
+}
+
 // NewGaugeGroup creates a new gauge group with a set of labels,
 // where each unique combination of labels becomes its own gauge.
 //
@@ -5,7 +5,9 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"encore.dev/appruntime/shared/explicithist"
 	"encore.dev/appruntime/shared/nativehist"
+	"encore.dev/appruntime/shared/quantile"
 	"encore.dev/appruntime/shared/reqtrack"
 )
 
@@ -14,6 +16,13 @@ type Registry struct {
 	numSvcs  uint16
 	tsid     uint64
 	registry sync.Map // map[registryKey]*timeseries
+
+	requestLabeler atomic.Pointer[requestLabeler] // nil if not configured
+	overflows      seriesOverflows
+	infraOps       infraOps
+
+	hooksMu sync.Mutex
+	hooks   []func() // called before collecting metrics
 }
 
 func NewRegistry(rt *reqtrack.RequestTracker, numServicesInBinary int) *Registry {
@@ -21,6 +30,8 @@ func NewRegistry(rt *reqtrack.RequestTracker, numServicesInBinary int) *Registry
 }
 
 func (r *Registry) Collect() []CollectedMetric {
+	r.runCollectHooks()
+
 	metrics := make([]CollectedMetric, 0, 128)
 	r.registry.Range(func(key, value any) bool {
 		switch val := value.(type) {
@@ -56,6 +67,22 @@ func (r *Registry) Collect() []CollectedMetric {
 				Val:          val.value,
 				Valid:        val.valid,
 			})
+		case *timeseries[*explicithist.Histogram]:
+			metrics = append(metrics, CollectedMetric{
+				Info:         val.info,
+				TimeSeriesID: val.id,
+				Labels:       val.labels,
+				Val:          val.value,
+				Valid:        val.valid,
+			})
+		case *timeseries[*quantile.Summary]:
+			metrics = append(metrics, CollectedMetric{
+				Info:         val.info,
+				TimeSeriesID: val.id,
+				Labels:       val.labels,
+				Val:          val.value,
+				Valid:        val.valid,
+			})
 		default:
 			panic(fmt.Sprintf("unhandled timeseries type %T", val))
 		}
@@ -64,12 +91,34 @@ func (r *Registry) Collect() []CollectedMetric {
 	return metrics
 }
 
+// addCollectHook adds a function to call each time metrics are collected.
+func (r *Registry) addCollectHook(fn func()) {
+	r.hooksMu.Lock()
+	defer r.hooksMu.Unlock()
+	r.hooks = append(r.hooks, fn)
+}
+
+func (r *Registry) runCollectHooks() {
+	r.hooksMu.Lock()
+	hooks := r.hooks
+	r.hooksMu.Unlock()
+
+	for _, fn := range hooks {
+		// A panicking callback must not prevent the other metrics from being collected.
+		func() {
+			defer func() { _ = recover() }()
+			fn()
+		}()
+	}
+}
+
 type MetricType int
 
 const (
 	CounterType MetricType = iota
 	GaugeType
 	HistogramType
+	SummaryType
 )
 
 type MetricInfo interface {
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// requestLabeler computes the application-defined labels
+// for Encore's built-in request metrics.
+type requestLabeler struct {
+	keys      []string
+	maxValues int
+	fn        func() map[string]string
+
+	mu   sync.Mutex
+	seen []map[string]bool // distinct values seen, per key
+}
+
+// otherLabelValue is reported instead of label values
+// beyond the cardinality limit.
+const otherLabelValue = "other"
+
+func (r *Registry) setRequestLabeler(keys []string, maxValues int, fn func() map[string]string) {
+	l := &requestLabeler{
+		keys:      keys,
+		maxValues: maxValues,
+		fn:        fn,
+		seen:      make([]map[string]bool, len(keys)),
+	}
+	for i := range l.seen {
+		l.seen[i] = make(map[string]bool)
+	}
+	if !r.requestLabeler.CompareAndSwap(nil, l) {
+		panic("metrics: request labels have already been configured")
+	}
+}
+
+// RequestLabels returns the application-defined labels for the built-in
+// metrics of the current request, encoded as a comparable string.
+// Use DecodeRequestLabels to get the labels back.
+//
+//publicapigen:drop
+func (r *Registry) RequestLabels() string {
+	l := r.requestLabeler.Load()
+	if l == nil {
+		return ""
+	}
+
+	var values map[string]string
+	func() {
+		defer func() {
+			if err := recover(); err != nil {
+				r.rt.Logger().Error().Msgf("metrics: request labels function panicked: %v", err)
+			}
+		}()
+		values = l.fn()
+	}()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	encoded := make([]string, len(l.keys))
+	for i, key := range l.keys {
+		val := strings.ReplaceAll(values[key], "\x00", "")
+		if !l.seen[i][val] {
+			if len(l.seen[i]) >= l.maxValues {
+				val = otherLabelValue
+			} else {
+				l.seen[i][val] = true
+			}
+		}
+		encoded[i] = val
+	}
+	return strings.Join(encoded, "\x00")
+}
+
+// DecodeRequestLabels decodes labels returned by RequestLabels.
+//
+//publicapigen:drop
+func (r *Registry) DecodeRequestLabels(encoded string) []KeyValue {
+	l := r.requestLabeler.Load()
+	if l == nil {
+		return nil
+	}
+
+	values := strings.Split(encoded, "\x00")
+	if len(values) != len(l.keys) {
+		panic(fmt.Sprintf("metrics: got %d request label values, want %d", len(values), len(l.keys)))
+	}
+	labels := make([]KeyValue, len(l.keys))
+	for i, key := range l.keys {
+		labels[i] = KeyValue{Key: key, Value: values[i]}
+	}
+	return labels
+}
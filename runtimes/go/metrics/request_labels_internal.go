@@ -0,0 +1,57 @@
+//go:build encore_app
+
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+
+	"encore.dev"
+)
+
+// RequestLabelsConfig configures additional labels on Encore's built-in
+// request metrics, such as e_requests_total.
+type RequestLabelsConfig struct {
+	// Labels are the names of the additional labels, in snake_case.
+	// Values returned by Func for other labels are ignored.
+	Labels []string
+
+	// MaxValues is the maximum number of distinct values tracked per label,
+	// to guard against unbounded cardinality. Further values are reported as "other".
+	// If zero it defaults to 100.
+	MaxValues int
+
+	// Func returns the label values for a request, keyed by label name.
+	// Labels without a value are reported as the empty string.
+	//
+	// It's called when the request completes, and can use
+	// functions like auth.Data to access the request's data.
+	Func func(req *encore.Request) map[string]string
+}
+
+// SetRequestLabels adds labels to Encore's built-in request metrics,
+// allowing them to be sliced by things like tenant or API version.
+// It can only be called once, and should be called during initialization.
+func SetRequestLabels(cfg RequestLabelsConfig) {
+	for _, label := range cfg.Labels {
+		if !labelNameRe.MatchString(label) {
+			panic(fmt.Sprintf("metrics: invalid request label name %q, must be snake_case", label))
+		}
+		switch label {
+		case "service", "endpoint", "code":
+			panic(fmt.Sprintf("metrics: request label name %q is reserved", label))
+		}
+	}
+
+	maxValues := cfg.MaxValues
+	if maxValues <= 0 {
+		maxValues = 100
+	}
+
+	fn := cfg.Func
+	Singleton.setRequestLabeler(cfg.Labels, maxValues, func() map[string]string {
+		return fn(encore.CurrentRequest())
+	})
+}
+
+var labelNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestRequestLabels(t *testing.T) {
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	reg := NewRegistry(rt, 1)
+	if got := reg.RequestLabels(); got != "" {
+		t.Fatalf("got labels %q without a labeler, want none", got)
+	} else if got := reg.DecodeRequestLabels(got); got != nil {
+		t.Fatalf("got decoded labels %+v, want nil", got)
+	}
+
+	var values map[string]string
+	reg.setRequestLabeler([]string{"tenant", "version"}, 2, func() map[string]string {
+		return values
+	})
+
+	check := func(want ...KeyValue) {
+		t.Helper()
+		if got := reg.DecodeRequestLabels(reg.RequestLabels()); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got labels %+v, want %+v", got, want)
+		}
+	}
+
+	values = map[string]string{"tenant": "a", "version": "v1", "ignored": "x"}
+	check(KeyValue{Key: "tenant", Value: "a"}, KeyValue{Key: "version", Value: "v1"})
+
+	// Missing labels are reported as empty.
+	values = map[string]string{"tenant": "b"}
+	check(KeyValue{Key: "tenant", Value: "b"}, KeyValue{Key: "version", Value: ""})
+
+	// Values beyond the limit are reported as "other",
+	// while previously seen values are still reported.
+	values = map[string]string{"tenant": "c", "version": "v1"}
+	check(KeyValue{Key: "tenant", Value: "other"}, KeyValue{Key: "version", Value: "v1"})
+	values = map[string]string{"tenant": "a", "version": "v2"}
+	check(KeyValue{Key: "tenant", Value: "a"}, KeyValue{Key: "version", Value: "other"})
+
+	// The same labels encode to the same comparable value.
+	if reg.RequestLabels() != reg.RequestLabels() {
+		t.Fatal("got different encodings for the same labels")
+	}
+}
+
+func TestRequestLabels_Panic(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, nil)
+	reg := NewRegistry(rt, 1)
+	reg.setRequestLabeler([]string{"tenant"}, 10, func() map[string]string {
+		panic("boom")
+	})
+
+	got := reg.DecodeRequestLabels(reg.RequestLabels())
+	if want := []KeyValue{{Key: "tenant", Value: ""}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got labels %+v, want %+v", got, want)
+	}
+}
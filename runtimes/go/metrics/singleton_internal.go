@@ -8,3 +8,7 @@ import (
 )
 
 var Singleton = NewRegistry(reqtrack.Singleton, len(appconf.Static.BundledServices))
+
+func init() {
+	Singleton.registerTraceMetrics()
+}
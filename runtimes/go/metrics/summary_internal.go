@@ -0,0 +1,162 @@
+//go:build encore_app
+
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"encore.dev/appruntime/shared/quantile"
+)
+
+// SummaryConfig configures a summary.
+type SummaryConfig struct {
+	// Objectives maps the quantiles to report to their allowed absolute error.
+	// For example, 0.99: 0.001 reports the 99th percentile with an
+	// estimate that lies between the 98.9th and 99.1th percentile.
+	//
+	// If nil, it defaults to the 50th, 90th and 99th percentiles
+	// with errors of 0.05, 0.01 and 0.001 respectively.
+	Objectives map[float64]float64
+
+	// MaxAge is the duration of the sliding window of observations
+	// the quantiles are computed over. It defaults to 10 minutes.
+	MaxAge time.Duration
+
+	// AgeBuckets is the number of buckets the sliding window is divided into.
+	// Observations are discarded one bucket at a time as they become older
+	// than MaxAge. It defaults to 5.
+	AgeBuckets int
+
+	// MaxSeries is the maximum number of distinct label combinations
+	// of a summary group, after which new combinations are reported
+	// with all label values set to "other". If zero the number of
+	// label combinations is not limited.
+	// It has no effect on summaries without labels.
+	MaxSeries int
+
+	//publicapigen:drop
+	EncoreInternal_LabelMapper any // func(L) []KeyValue
+
+	//publicapigen:drop
+	EncoreInternal_SvcNum uint16
+}
+
+// NewSummary creates a new summary metric, without any labels.
+// Use NewSummaryGroup for summaries with labels.
+//
+// Summaries compute quantiles of the observed values within the application,
+// which is useful when the metrics backend can't aggregate histograms.
+// Unlike histograms, the quantiles of different summaries can't be aggregated
+// in a meaningful way, such as across multiple instances of a service.
+func NewSummary[V Value](name string, cfg SummaryConfig) *Summary[V] {
+	m := newMetricInfo[V](Singleton, name, SummaryType, cfg.EncoreInternal_SvcNum)
+	newSummary := summaryFactory(name, cfg)
+	return &Summary[V]{
+		metricInfo: m,
+		vals:       initSummaryTS(m, nil, nil, newSummary),
+		toFloat:    makeToFloat[V](),
+	}
+}
+
+type Summary[V Value] struct {
+	*metricInfo[V]
+	vals    []*quantile.Summary
+	toFloat func(V) float64
+}
+
+// Observe records an observation in the summary.
+func (s *Summary[V]) Observe(val V) {
+	f := s.toFloat(val)
+	if math.IsNaN(f) {
+		return
+	}
+	if idx, ok := s.svcIdx(); ok {
+		s.vals[idx].Observe(f)
+	}
+}
+
+// NewSummaryGroup creates a new summary group with a set of labels,
+// where each unique combination of labels becomes its own summary.
+//
+// The Labels type must be a named struct, where each field corresponds to
+// a single label. Each field must be of type string.
+func NewSummaryGroup[L Labels, V Value](name string, cfg SummaryConfig) *SummaryGroup[L, V] {
+	labelMapper := cfg.EncoreInternal_LabelMapper.(func(L) []KeyValue)
+	m := newMetricInfo[V](Singleton, name, SummaryType, cfg.EncoreInternal_SvcNum)
+	m.maxSeries = maxSeriesLimit(cfg.MaxSeries)
+	return &SummaryGroup[L, V]{
+		metricInfo:  m,
+		labelMapper: labelMapper,
+		newSummary:  summaryFactory(name, cfg),
+		toFloat:     makeToFloat[V](),
+	}
+}
+
+type SummaryGroup[L Labels, V Value] struct {
+	*metricInfo[V]
+	labelMapper func(L) []KeyValue
+	newSummary  func() *quantile.Summary
+	toFloat     func(V) float64
+}
+
+func (g *SummaryGroup[L, V]) With(labels L) *Summary[V] {
+	key, mapLabels := g.limitSeries(labels, func() []KeyValue { return g.labelMapper(labels) })
+	return &Summary[V]{
+		metricInfo: g.metricInfo,
+		vals:       initSummaryTS(g.metricInfo, key, mapLabels, g.newSummary),
+		toFloat:    g.toFloat,
+	}
+}
+
+func initSummaryTS[V Value](m *metricInfo[V], labels any, mapLabels func() []KeyValue, newSummary func() *quantile.Summary) []*quantile.Summary {
+	ts, setup := getTS[*quantile.Summary](m.reg, m.name, labels, m)
+
+	if !setup {
+		// Initialize this summary timeseries on first use.
+		ts.init.Start()
+		defer ts.init.Done()
+
+		if mapLabels != nil {
+			ts.labels = mapLabels()
+		}
+		n := m.reg.numSvcs
+		if m.svcNum > 0 {
+			n = 1
+		}
+		ts.value = make([]*quantile.Summary, n)
+		for i := range ts.value {
+			ts.value[i] = newSummary()
+		}
+	} else {
+		// Wait for the timeseries to be initialized before we continue.
+		ts.init.Wait()
+	}
+
+	return ts.value
+}
+
+// summaryFactory returns a function creating summaries configured by cfg,
+// with defaults applied. It panics if the configuration is invalid.
+func summaryFactory(name string, cfg SummaryConfig) func() *quantile.Summary {
+	objectives := cfg.Objectives
+	if objectives == nil {
+		objectives = map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
+	}
+	maxAge := cfg.MaxAge
+	if maxAge == 0 {
+		maxAge = 10 * time.Minute
+	}
+	ageBuckets := cfg.AgeBuckets
+	if ageBuckets == 0 {
+		ageBuckets = 5
+	}
+
+	if err := quantile.Validate(objectives, maxAge, ageBuckets); err != nil {
+		panic(fmt.Sprintf("metrics: invalid configuration for summary %s: %v", name, err))
+	}
+	return func() *quantile.Summary {
+		return quantile.New(objectives, maxAge, ageBuckets)
+	}
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/explicithist"
+)
+
+// registerTraceMetrics registers the built-in metrics of the trace pipeline,
+// which are updated from trace2.ReadStats each time metrics are collected.
+//
+// The trace pipeline is shared by all services in the process,
+// so the metrics are reported for the first service.
+func (r *Registry) registerTraceMetrics() {
+	if r.numSvcs == 0 {
+		return
+	}
+
+	written := newTraceStat[uint64](r, "e_trace_events_total", CounterType)
+	dropped := newTraceStat[uint64](r, "e_trace_events_dropped_total", CounterType)
+	buffered := newTraceStat[int64](r, "e_trace_buffered_bytes", GaugeType)
+	reallocs := newTraceStat[uint64](r, "e_trace_buffer_reallocations_total", CounterType)
+
+	flush, _ := getTS[*explicithist.Histogram](r, "e_trace_flush_duration_seconds", nil,
+		newMetricInfo[float64](r, "e_trace_flush_duration_seconds", HistogramType, 1))
+	flush.value = []*explicithist.Histogram{trace2.ReadStats().FlushDuration}
+
+	r.addCollectHook(func() {
+		stats := trace2.ReadStats()
+		written.set(stats.EventsWritten)
+		dropped.set(stats.EventsDropped)
+		buffered.set(stats.BytesBuffered)
+		reallocs.set(stats.BufferReallocs)
+	})
+}
+
+// traceStat is a metric whose value is set from the trace pipeline's statistics.
+type traceStat[V Value] struct {
+	*metricInfo[V]
+	ts *timeseries[V]
+}
+
+func newTraceStat[V Value](r *Registry, name string, typ MetricType) *traceStat[V] {
+	m := newMetricInfo[V](r, name, typ, 1)
+	ts, setup := m.getTS(nil)
+	if !setup {
+		ts.setup(nil)
+	}
+	return &traceStat[V]{metricInfo: m, ts: ts}
+}
+
+func (s *traceStat[V]) set(val V) {
+	s.metricInfo.set(&s.ts.value[0], val)
+	s.ts.valid[0].Store(true)
+}
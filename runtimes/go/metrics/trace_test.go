@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/explicithist"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestTraceMetrics(t *testing.T) {
+	mgr := NewRegistry(reqtrack.New(zerolog.Logger{}, nil, nil), 2)
+	mgr.registerTraceMetrics()
+
+	before := trace2.ReadStats()
+	log := trace2.NewLog()
+	log.LogMessage(trace2.LogMessageParams{EventParams: trace2.EventParams{TraceID: model.TraceID{1}}, Msg: "hello"})
+	mgr.Collect()
+
+	written, _ := getTS[uint64](mgr, "e_trace_events_total", nil, nil)
+	if got := written.value[0]; got < before.EventsWritten+1 {
+		t.Errorf("got %d events written, want at least %d", got, before.EventsWritten+1)
+	}
+	buffered, _ := getTS[int64](mgr, "e_trace_buffered_bytes", nil, nil)
+	if got := buffered.value[0]; got <= 0 {
+		t.Errorf("got %d bytes buffered, want the log's data", got)
+	}
+
+	flush, _ := getTS[*explicithist.Histogram](mgr, "e_trace_flush_duration_seconds", nil, nil)
+	if flush.value[0] != trace2.ReadStats().FlushDuration {
+		t.Error("flush duration histogram not reported")
+	}
+	_, _ = log.GetAndClear()
+}
@@ -7,6 +7,7 @@ package middleware
 
 import (
 	"context"
+	"net/http"
 
 	encore "encore.dev"
 )
@@ -92,6 +93,14 @@ type Response struct {
 	// For raw handlers middleware cannot modify this as it has already
 	// been written to the network.
 	HTTPStatus int
+
+	// Header contains additional HTTP headers to write with the response,
+	// such as Retry-After or Cache-Control. They are added to any headers
+	// set by the API itself.
+	//
+	// Like HTTPStatus, it's not used for raw handlers, nor for
+	// service-to-service calls.
+	Header http.Header
 }
 
 // NewRequest constructs a new Request that returns the given context and request data.
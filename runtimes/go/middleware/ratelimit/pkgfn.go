@@ -0,0 +1,33 @@
+//go:build encore_app
+
+package ratelimit
+
+import (
+	"net/netip"
+
+	"encore.dev/appruntime/shared/clock"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/beta/auth"
+	"encore.dev/rlog"
+)
+
+// New returns a new Limiter. It panics if the configuration is invalid.
+func New(cfg Config) *Limiter {
+	l := newLimiter(cfg)
+	l.now = clock.Singleton.Now
+	l.userID = auth.UserID
+	l.clientIP = clientIP
+	l.logError = func(err error) {
+		rlog.Error("unable to check rate limit, allowing request", "limiter", cfg.Name, "err", err)
+	}
+	return l
+}
+
+// clientIP reports the IP address of the client making the current request.
+func clientIP() (netip.Addr, bool) {
+	if curr := reqtrack.Singleton.Current(); curr.Req != nil && curr.Req.RPCData != nil {
+		ip := curr.Req.RPCData.ClientIP
+		return ip, ip.IsValid()
+	}
+	return netip.Addr{}, false
+}
@@ -0,0 +1,175 @@
+// Package ratelimit provides middleware limiting the rate of requests
+// to API endpoints, per endpoint and per authenticated user or client IP.
+//
+// A Limiter is defined once, and invoked from a middleware whose target
+// selects the endpoints to limit:
+//
+//	var limiter = ratelimit.New(ratelimit.Config{
+//		Limit:  100,
+//		Window: time.Minute,
+//		Key:    ratelimit.PerEndpointAndAuthUID,
+//	})
+//
+//	//encore:middleware target=tag:limited
+//	func RateLimit(req middleware.Request, next middleware.Next) middleware.Response {
+//		return limiter.Handle(req, next)
+//	}
+//
+// Requests over the limit fail with errs.ResourceExhausted, which is
+// returned as a 429 Too Many Requests response with a Retry-After header
+// saying how many seconds remain until the limit resets.
+//
+// These limits are defined in code and apply in addition to any limits
+// set by the rate_limit section of the runtime configuration, which are
+// checked first, before any middleware runs. A request must be within both
+// to be handled. The two count requests separately, even when using the
+// same cache cluster.
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/middleware"
+)
+
+// Key determines which requests share a rate limit.
+type Key string
+
+const (
+	// PerEndpoint limits each endpoint separately,
+	// with all callers of an endpoint sharing its limit.
+	PerEndpoint Key = "endpoint"
+
+	// PerAuthUID limits each authenticated user separately,
+	// with the limit shared across all the endpoints the Limiter is used for.
+	// Unauthenticated requests are limited per client IP address instead.
+	PerAuthUID Key = "uid"
+
+	// PerEndpointAndAuthUID limits each authenticated user separately
+	// on each endpoint. Unauthenticated requests are limited per client IP
+	// address instead.
+	PerEndpointAndAuthUID Key = "endpoint_uid"
+)
+
+// Config configures a Limiter.
+type Config struct {
+	// Limit is the maximum number of requests allowed within each window.
+	Limit int
+
+	// Window is the length of the fixed windows requests are counted in,
+	// such as time.Minute.
+	Window time.Duration
+
+	// Key determines which requests share a limit.
+	// It defaults to PerEndpointAndAuthUID.
+	Key Key
+
+	// Store is where the number of requests is counted.
+	// It defaults to an in-memory store, which limits requests
+	// to each instance of the application separately.
+	// Use Cache to share the limit between instances.
+	Store Store
+
+	// Name distinguishes the limiter's counts from those of other limiters
+	// using the same store. It must be set when several limiters share
+	// a cache cluster.
+	Name string
+}
+
+// Limiter limits the rate of requests using fixed windows.
+// It is safe for concurrent use.
+type Limiter struct {
+	cfg Config
+	now func() time.Time
+
+	// userID reports the authenticated user making the current request.
+	userID func() (auth.UID, bool)
+
+	// clientIP reports the IP address of the client making the current request.
+	clientIP func() (netip.Addr, bool)
+
+	// logError logs failures to count requests.
+	logError func(err error)
+}
+
+func newLimiter(cfg Config) *Limiter {
+	if cfg.Limit <= 0 {
+		panic("ratelimit: Limit must be positive")
+	} else if cfg.Window <= 0 {
+		panic("ratelimit: Window must be positive")
+	}
+
+	switch cfg.Key {
+	case "":
+		cfg.Key = PerEndpointAndAuthUID
+	case PerEndpoint, PerAuthUID, PerEndpointAndAuthUID:
+	default:
+		panic("ratelimit: invalid Key " + strconv.Quote(string(cfg.Key)))
+	}
+
+	if cfg.Store == nil {
+		cfg.Store = Memory()
+	}
+
+	return &Limiter{
+		cfg:      cfg,
+		now:      time.Now,
+		userID:   func() (auth.UID, bool) { return "", false },
+		clientIP: func() (netip.Addr, bool) { return netip.Addr{}, false },
+		logError: func(error) {},
+	}
+}
+
+// Handle counts the request, calling next if it's within the limit
+// and failing it with errs.ResourceExhausted otherwise.
+//
+// If the store is unavailable the request is let through,
+// so the API stays up without rate limiting.
+func (l *Limiter) Handle(req middleware.Request, next middleware.Next) middleware.Response {
+	now := l.now()
+	start := now.Truncate(l.cfg.Window)
+	count, err := l.cfg.Store.Increment(req.Context(), l.key(req), start, l.cfg.Window)
+	if err != nil {
+		l.logError(err)
+		return next(req)
+	} else if count <= int64(l.cfg.Limit) {
+		return next(req)
+	}
+
+	retryAfter := math.Ceil(start.Add(l.cfg.Window).Sub(now).Seconds())
+	return middleware.Response{
+		Err:        errs.B().Code(errs.ResourceExhausted).Msg("rate limit exceeded").Err(),
+		HTTPStatus: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {strconv.Itoa(int(retryAfter))}},
+	}
+}
+
+// key returns the key of the counter the request is counted in.
+//
+// Unauthenticated requests are counted per client IP, like the limits
+// from the runtime configuration, so one client can't exhaust the limit
+// for every anonymous caller. Only requests whose client IP is unknown
+// share a single count.
+func (l *Limiter) key(req middleware.Request) string {
+	key := l.cfg.Name
+	if l.cfg.Key != PerAuthUID {
+		data := req.Data()
+		key += "/endpoint/" + data.Service + "." + data.Endpoint
+	}
+	if l.cfg.Key != PerEndpoint {
+		if uid, ok := l.userID(); ok {
+			key += "/uid/" + string(uid)
+		} else if ip, ok := l.clientIP(); ok {
+			key += "/ip/" + ip.String()
+		} else {
+			key += "/anonymous"
+		}
+	}
+	return key
+}
@@ -0,0 +1,156 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	qt "github.com/frankban/quicktest"
+	"github.com/go-redis/redis/v8"
+
+	encore "encore.dev"
+	"encore.dev/beta/auth"
+	"encore.dev/beta/errs"
+	"encore.dev/middleware"
+)
+
+// newTestLimiter returns a limiter whose clock and current user are
+// controlled by the returned pointers.
+func newTestLimiter(cfg Config) (l *Limiter, now *time.Time, uid *auth.UID) {
+	l = newLimiter(cfg)
+	now, uid = new(time.Time), new(auth.UID)
+	*now = time.Date(2024, 1, 1, 0, 0, 15, 0, time.UTC)
+	l.now = func() time.Time { return *now }
+	l.userID = func() (auth.UID, bool) { return *uid, *uid != "" }
+	return l, now, uid
+}
+
+func call(l *Limiter, endpoint string) middleware.Response {
+	req := middleware.NewRequest(context.Background(), &encore.Request{Service: "svc", Endpoint: endpoint})
+	return l.Handle(req, func(middleware.Request) middleware.Response {
+		return middleware.Response{Payload: "ok"}
+	})
+}
+
+func TestLimiter(t *testing.T) {
+	c := qt.New(t)
+	l, now, _ := newTestLimiter(Config{Limit: 2, Window: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		resp := call(l, "Get")
+		c.Assert(resp.Err, qt.IsNil)
+		c.Assert(resp.Payload, qt.Equals, "ok")
+	}
+
+	resp := call(l, "Get")
+	c.Assert(errs.Code(resp.Err), qt.Equals, errs.ResourceExhausted)
+	c.Assert(resp.HTTPStatus, qt.Equals, 429)
+	c.Assert(resp.Header.Get("Retry-After"), qt.Equals, "45")
+	c.Assert(resp.Payload, qt.IsNil)
+
+	// The limit resets once the window has ended.
+	*now = now.Add(45 * time.Second)
+	c.Assert(call(l, "Get").Err, qt.IsNil)
+}
+
+func TestLimiter_Keys(t *testing.T) {
+	tests := []struct {
+		key Key
+
+		// Whether a second request is limited when it's made by another user
+		// or to another endpoint, given a limit of one request.
+		sameEndpointOtherUser bool
+		otherEndpointSameUser bool
+	}{
+		{key: PerEndpoint, sameEndpointOtherUser: true, otherEndpointSameUser: false},
+		{key: PerAuthUID, sameEndpointOtherUser: false, otherEndpointSameUser: true},
+		{key: PerEndpointAndAuthUID, sameEndpointOtherUser: false, otherEndpointSameUser: false},
+	}
+	for _, test := range tests {
+		t.Run(string(test.key), func(t *testing.T) {
+			c := qt.New(t)
+
+			l, _, uid := newTestLimiter(Config{Limit: 1, Window: time.Minute, Key: test.key})
+			*uid = "alice"
+			c.Assert(call(l, "Get").Err, qt.IsNil)
+			*uid = "bob"
+			c.Assert(call(l, "Get").Err != nil, qt.Equals, test.sameEndpointOtherUser)
+
+			l, _, uid = newTestLimiter(Config{Limit: 1, Window: time.Minute, Key: test.key})
+			*uid = "alice"
+			c.Assert(call(l, "Get").Err, qt.IsNil)
+			c.Assert(call(l, "List").Err != nil, qt.Equals, test.otherEndpointSameUser)
+		})
+	}
+}
+
+func TestLimiter_Anonymous(t *testing.T) {
+	c := qt.New(t)
+	l, _, uid := newTestLimiter(Config{Limit: 1, Window: time.Minute, Key: PerAuthUID})
+
+	// Unauthenticated requests with an unknown client IP share a limit, separate from users'.
+	c.Assert(call(l, "Get").Err, qt.IsNil)
+	c.Assert(call(l, "Get").Err, qt.IsNotNil)
+	*uid = "alice"
+	c.Assert(call(l, "Get").Err, qt.IsNil)
+}
+
+func TestLimiter_AnonymousPerIP(t *testing.T) {
+	c := qt.New(t)
+	l, _, _ := newTestLimiter(Config{Limit: 1, Window: time.Minute})
+	ip := netip.MustParseAddr("10.0.0.1")
+	l.clientIP = func() (netip.Addr, bool) { return ip, true }
+
+	// Unauthenticated requests are limited per client IP.
+	c.Assert(call(l, "Get").Err, qt.IsNil)
+	c.Assert(call(l, "Get").Err, qt.IsNotNil)
+	ip = netip.MustParseAddr("10.0.0.2")
+	c.Assert(call(l, "Get").Err, qt.IsNil)
+}
+
+type failingStore struct{}
+
+func (failingStore) Increment(context.Context, string, time.Time, time.Duration) (int64, error) {
+	return 0, errors.New("unavailable")
+}
+
+func TestLimiter_StoreError(t *testing.T) {
+	c := qt.New(t)
+	l, _, _ := newTestLimiter(Config{Limit: 1, Window: time.Minute, Store: failingStore{}})
+	var logged error
+	l.logError = func(err error) { logged = err }
+
+	// Requests are let through when the store is unavailable.
+	for i := 0; i < 2; i++ {
+		c.Assert(call(l, "Get").Err, qt.IsNil)
+	}
+	c.Assert(logged, qt.ErrorMatches, "unavailable")
+}
+
+func TestLimiter_InvalidConfig(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(func() { newLimiter(Config{Window: time.Minute}) }, qt.PanicMatches, "ratelimit: Limit must be positive")
+	c.Assert(func() { newLimiter(Config{Limit: 1}) }, qt.PanicMatches, "ratelimit: Window must be positive")
+	c.Assert(func() { newLimiter(Config{Limit: 1, Window: time.Minute, Key: "ip"}) }, qt.PanicMatches, `ratelimit: invalid Key "ip"`)
+}
+
+func TestCacheStore(t *testing.T) {
+	c := qt.New(t)
+	srv := miniredis.RunT(t)
+	cl := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = cl.Close() })
+
+	// Limiters on different instances using the same cache cluster share the counts.
+	a, _, _ := newTestLimiter(Config{Limit: 2, Window: time.Minute, Store: CacheInternal(cl)})
+	b, _, _ := newTestLimiter(Config{Limit: 2, Window: time.Minute, Store: CacheInternal(cl)})
+	c.Assert(call(a, "Get").Err, qt.IsNil)
+	c.Assert(call(b, "Get").Err, qt.IsNil)
+	c.Assert(call(a, "Get").Err, qt.IsNotNil)
+
+	// Counts expire after the window.
+	srv.FastForward(time.Minute)
+	c.Assert(srv.Keys(), qt.HasLen, 0)
+}
@@ -0,0 +1,40 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"encore.dev/appruntime/shared/fixedwindow"
+	"encore.dev/storage/cache"
+)
+
+// Store counts requests within fixed windows.
+// Use Memory or Cache to create a Store, or implement it
+// to count requests elsewhere.
+type Store interface {
+	// Increment increments the number of requests counted for key
+	// in the window beginning at start, and returns the new count.
+	Increment(ctx context.Context, key string, start time.Time, window time.Duration) (int64, error)
+}
+
+// Memory returns a Store that keeps counts in memory,
+// limiting requests to each instance of the application separately.
+func Memory() Store {
+	return fixedwindow.NewMemory()
+}
+
+// Cache returns a Store that keeps counts in the given cache cluster,
+// sharing the limits between all instances of the application.
+// Counts expire once their window has ended.
+func Cache(cluster *cache.Cluster) Store {
+	return CacheInternal(cluster.RedisClient())
+}
+
+// CacheInternal returns a Store that keeps counts using the given Redis client.
+//
+//publicapigen:drop
+func CacheInternal(cl *redis.Client) Store {
+	return fixedwindow.NewRedis(cl, "__encore/middleware/ratelimit/")
+}
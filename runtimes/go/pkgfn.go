@@ -3,7 +3,10 @@
 package encore
 
 import (
+	"time"
+
 	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/clock"
 	"encore.dev/appruntime/shared/reqtrack"
 )
 
@@ -26,3 +29,12 @@ func Meta() *AppMetadata {
 func CurrentRequest() *Request {
 	return Singleton.CurrentRequest()
 }
+
+// Now returns the current time.
+//
+// Unlike time.Now, the time can be changed in tests using et.SetTime
+// and et.AdvanceTime, which makes time-dependent code such as expiry
+// and scheduling logic possible to test deterministically.
+func Now() time.Time {
+	return clock.Singleton.Now()
+}
@@ -2,7 +2,9 @@ package test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -55,7 +57,7 @@ func (t *TestTopic[T]) PublishMessage(ctx context.Context, orderingKey string, a
 
 	instance := t.TestInstance(test)
 
-	msgID, err := instance.publishMessage(unmarshalled)
+	msgID, err := instance.publishMessage(unmarshalled, attrs, data)
 	if err != nil {
 		return "", err
 	}
@@ -63,7 +65,7 @@ func (t *TestTopic[T]) PublishMessage(ctx context.Context, orderingKey string, a
 	// If subscriptions are enabled for this test, then trigger those subscribers asynchronously
 	// allowing the publishing code to continue as it would in a real system
 	if instance.subscriptionsEnabled {
-		published := time.Now()
+		published := t.ts.Now()
 
 		for name, sub := range t.subscribers {
 			name := name
@@ -100,6 +102,7 @@ func (t *TestTopic[T]) TestInstance(test *testing.T) *testInstance[T] {
 	defer t.m.Unlock()
 	if _, found := t.instances[test]; !found {
 		t.instances[test] = &testInstance[T]{
+			topic:     t,
 			topicName: t.name,
 			t:         test,
 		}
@@ -111,25 +114,41 @@ func (t *TestTopic[T]) TestInstance(test *testing.T) *testInstance[T] {
 // testInstance represents a topic, as it is seen from a test
 // This struct implements test.TestTopic[T] to allow the testing package to interface with it
 type testInstance[T any] struct {
-	topicName            string     // The topic name
-	t                    *testing.T // The test we're running against
-	msgID                int32      // The last message ID we sent (updated atomically)
-	m                    sync.Mutex // Mutex for the published messages
-	messages             []T        // What messages have been published
-	subscriptionsEnabled bool       // If subscriptions are enabled for this test
+	topic                *TestTopic[T]  // The topic this is an instance of
+	topicName            string         // The topic name
+	t                    *testing.T     // The test we're running against
+	msgID                int32          // The last message ID we sent (updated atomically)
+	m                    sync.Mutex     // Mutex for the published messages
+	messages             []T            // What messages have been published
+	undelivered          []publishedMsg // Published messages not yet delivered to subscribers
+	subscriptionsEnabled bool           // If subscriptions are enabled for this test
+}
+
+// publishedMsg is a published message in its raw form,
+// as it's passed to subscribers.
+type publishedMsg struct {
+	id        string
+	published time.Time
+	attrs     map[string]string
+	data      []byte
 }
 
 // publishMessage records the message which was sent, and generates a deterministic message ID
 // which is guaranteed to be unique across all tests
-func (t *testInstance[T]) publishMessage(unmarshalled T) (id string, err error) {
+func (t *testInstance[T]) publishMessage(unmarshalled T, attrs map[string]string, data []byte) (id string, err error) {
 	msgID := atomic.AddInt32(&t.msgID, 1)
 
+	// we use "/" as the separator to mirror the behaviour of tests and sub tests
+	id = fmt.Sprintf("%s/%s/%d", t.t.Name(), t.topicName, msgID)
+
 	t.m.Lock()
 	defer t.m.Unlock()
 	t.messages = append(t.messages, unmarshalled)
+	if !t.subscriptionsEnabled {
+		t.undelivered = append(t.undelivered, publishedMsg{id: id, published: t.topic.ts.Now(), attrs: attrs, data: data})
+	}
 
-	// we use "/" as the separator to mirror the behaviour of tests and sub tests
-	return fmt.Sprintf("%s/%s/%d", t.t.Name(), t.topicName, msgID), nil
+	return id, nil
 }
 
 func (t *testInstance[T]) PublishedMessages() []T {
@@ -137,3 +156,41 @@ func (t *testInstance[T]) PublishedMessages() []T {
 	defer t.m.Unlock()
 	return t.messages
 }
+
+// DeliverPublished delivers the messages published so far which have not yet been delivered
+// to the subscriptions of the topic, in the order they were published, and waits for the
+// subscription handlers to complete. It returns the errors returned by the handlers.
+func (t *testInstance[T]) DeliverPublished(ctx context.Context) error {
+	t.m.Lock()
+	msgs := t.undelivered
+	t.undelivered = nil
+	t.m.Unlock()
+
+	type subscriber struct {
+		name string
+		fn   types.RawSubscriptionCallback
+	}
+	t.topic.m.RLock()
+	subs := make([]subscriber, 0, len(t.topic.subscribers))
+	for name, fn := range t.topic.subscribers {
+		subs = append(subs, subscriber{name, fn})
+	}
+	t.topic.m.RUnlock()
+	sort.Slice(subs, func(i, j int) bool { return subs[i].name < subs[j].name })
+
+	var errs []error
+	for _, msg := range msgs {
+		for _, sub := range subs {
+			// Run the handler in its own goroutine, as the subscription
+			// begins a new request which would otherwise replace the test's.
+			done := make(chan error, 1)
+			go func() {
+				done <- sub.fn(ctx, msg.id, msg.published, 1, msg.attrs, msg.data)
+			}()
+			if err := <-done; err != nil {
+				errs = append(errs, fmt.Errorf("subscription %s failed to process message %s: %w", sub.name, msg.id, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -2,6 +2,7 @@ package pubsub
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"sync"
 
@@ -9,10 +10,12 @@ import (
 	"github.com/rs/zerolog"
 
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/faults"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
 	"encore.dev/beta/errs"
+	"encore.dev/metrics"
 	"encore.dev/pubsub/internal/types"
 	"encore.dev/pubsub/internal/utils"
 )
@@ -25,6 +28,8 @@ type Manager struct {
 	ts         *testsupport.Manager
 	rootLogger zerolog.Logger
 	json       jsoniter.API
+	metrics    *metrics.Registry
+	faults     *faults.Injector
 	providers  []provider
 
 	publishCounter  uint64
@@ -34,7 +39,12 @@ type Manager struct {
 }
 
 func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker,
-	ts *testsupport.Manager, rootLogger zerolog.Logger, json jsoniter.API) *Manager {
+	ts *testsupport.Manager, rootLogger zerolog.Logger, json jsoniter.API, reg *metrics.Registry) *Manager {
+	faultInjector, err := faults.New(runtime.FaultInjection, rt)
+	if err != nil {
+		panic(fmt.Errorf("error loading fault injection rules: %w", err))
+	}
+
 	mgr := &Manager{
 		ctxs:         utils.NewContexts(context.Background()),
 		static:       static,
@@ -43,6 +53,8 @@ func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.Req
 		ts:           ts,
 		rootLogger:   rootLogger,
 		json:         json,
+		metrics:      reg,
+		faults:       faultInjector,
 		pushHandlers: make(map[types.SubscriptionID]http.HandlerFunc),
 	}
 
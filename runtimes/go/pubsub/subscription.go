@@ -164,6 +164,14 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 			}
 		}
 
+		var parentSpanID model.SpanID
+		if parentSpanIDStr := attrs[parentSpanIDAttribute]; parentSpanIDStr != "" {
+			parentSpanID, err = model.ParseSpanID(parentSpanIDStr)
+			if err != nil {
+				log.Err(err).Str("msg_id", msgID).Int("delivery_attempt", deliveryAttempt).Msg("failed to parse parent span id")
+			}
+		}
+
 		// Default to logging with the external correlation id if present
 		extCorrelationID := attrs[extCorrelationIDAttribute]
 		if extCorrelationID != "" {
@@ -172,6 +180,11 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 			logCtx = logCtx.Str("x_correlation_id", parentTraceID.String())
 		}
 
+		baggage, ok := model.ParseBaggage(attrs[baggageAttribute])
+		if !ok {
+			log.Error().Str("msg_id", msgID).Int("delivery_attempt", deliveryAttempt).Msg("failed to parse baggage")
+		}
+
 		traced := false
 		if val, ok := attrs[parentSampledAttribute]; ok {
 			traced, _ = strconv.ParseBool(val)
@@ -197,9 +210,10 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 				DecodedPayload: msg,
 				Payload:        marshalParams(mgr.json, msg),
 			},
-			DefLoc: staticCfg.TraceIdx,
-			SvcNum: staticCfg.SvcNum,
-			Traced: traced,
+			DefLoc:  staticCfg.TraceIdx,
+			SvcNum:  staticCfg.SvcNum,
+			Traced:  traced,
+			Baggage: model.NewBaggage(baggage),
 		}
 		reqLogger := logCtx.Logger()
 		req.Logger = &reqLogger
@@ -220,6 +234,20 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 		curr := mgr.rt.Current()
 		if curr.Trace != nil {
 			curr.Trace.PubsubMessageSpanStart(req, curr.Goctr)
+
+			// Link the message's span to the span that published it, so the
+			// otherwise disconnected traces can be followed across the topic.
+			if !parentTraceID.IsZero() && !parentSpanID.IsZero() {
+				curr.Trace.SpanLink(trace2.SpanLinkParams{
+					EventParams: trace2.EventParams{
+						TraceID: req.TraceID,
+						SpanID:  req.SpanID,
+						Goid:    curr.Goctr,
+					},
+					LinkedTraceID: parentTraceID,
+					LinkedSpanID:  parentSpanID,
+				})
+			}
 		}
 
 		err = panicCatchWrapper(ctx, msg)
@@ -9,6 +9,7 @@ import (
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/stack"
 	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/faults"
 	"encore.dev/beta/errs"
 	"encore.dev/internal/limiter"
 	"encore.dev/pubsub/internal/noop"
@@ -146,6 +147,9 @@ func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
 		if req.TraceID != (model.TraceID{}) {
 			attrs[parentTraceIDAttribute] = req.TraceID.String()
 		}
+		if !req.SpanID.IsZero() {
+			attrs[parentSpanIDAttribute] = req.SpanID.String()
+		}
 
 		if req.ExtCorrelationID != "" {
 			// If we have a correlation ID from the request, use that
@@ -156,6 +160,10 @@ func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
 		}
 
 		attrs[parentSampledAttribute] = strconv.FormatBool(req.Traced)
+
+		if baggage := req.Baggage.All(); len(baggage) > 0 {
+			attrs[baggageAttribute] = model.EncodeBaggage(baggage)
+		}
 	} else {
 		attrs[parentSampledAttribute] = strconv.FormatBool(false)
 	}
@@ -176,11 +184,18 @@ func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
 		})
 	}
 
-	// Publish once the rate limiter allows it
-	if err = t.publishLimiter.Wait(ctx); err == nil {
+	// Inject any configured faults, then publish once the rate limiter allows it
+	op := t.mgr.metrics.StartInfraOp("pubsub", t.runtimeCfg.EncoreName, "publish")
+	fault := t.mgr.faults.Inject(ctx, faults.Op{Target: config.FaultPubSubPublish, Resource: t.runtimeCfg.EncoreName})
+	err = fault.Err
+	if err == nil {
+		err = t.publishLimiter.Wait(ctx)
+	}
+	if err == nil {
 		// Publish to the clouds topic
 		id, err = t.topic.PublishMessage(ctx, orderingKey, attrs, data)
 	}
+	op.End(err)
 
 	// End the trace span
 	if curr.Req != nil && curr.Trace != nil {
@@ -196,7 +211,9 @@ func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
 		})
 	}
 
-	if err != nil {
+	if fault.Err != nil {
+		return "", fault.Err
+	} else if err != nil {
 		return "", errs.B().Cause(err).Code(errs.Unavailable).Msgf("failed to publish message to %s", t.runtimeCfg.EncoreName).Err()
 	}
 
@@ -10,12 +10,19 @@ import (
 // parentTraceIDAttribute is the attribute name we use to track request correlation IDs
 const parentTraceIDAttribute = "encore_parent_trace_id"
 
+// parentSpanIDAttribute is the attribute name we use to track the span that published the message,
+// so subscribers can link their traces to it.
+const parentSpanIDAttribute = "encore_parent_span_id"
+
 // extCorrelationIDAttribute is the attribute name we use to track externally provided correlation IDs
 const extCorrelationIDAttribute = "encore_ext_correlation_id"
 
 // parentSampledAttribute is the attribute name for determining if the parent was sampled.
 const parentSampledAttribute = "encore_parent_sampled"
 
+// baggageAttribute is the attribute name we use to propagate the publishing request's baggage.
+const baggageAttribute = "encore_baggage"
+
 // SubscriptionConfig is used when creating a subscription
 //
 // The values given here may be clamped to the supported values by
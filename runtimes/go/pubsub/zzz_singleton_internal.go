@@ -9,6 +9,7 @@ import (
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/metrics"
 )
 
 // Initialize the singleton instance.
@@ -22,7 +23,7 @@ var Singleton *Manager
 func init() {
 	Singleton = NewManager(
 		appconf.Static, appconf.Runtime, reqtrack.Singleton, testsupport.Singleton,
-		logging.RootLogger, jsonapi.Default,
+		logging.RootLogger, jsonapi.Default, metrics.Singleton,
 	)
 	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
 }
@@ -0,0 +1,54 @@
+package reqdata
+
+import (
+	"reflect"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+//publicapigen:drop
+type Manager struct {
+	rt *reqtrack.RequestTracker
+}
+
+//publicapigen:drop
+func NewManager(rt *reqtrack.RequestTracker) *Manager {
+	return &Manager{rt: rt}
+}
+
+// values returns the values of the current request,
+// or nil if there is no current request.
+func (mgr *Manager) values() *model.RequestValues {
+	if curr := mgr.rt.Current(); curr.Req != nil {
+		return curr.Req.Values
+	}
+	return nil
+}
+
+// SetInternal sets the value of type T on the current request.
+//
+//publicapigen:drop
+func SetInternal[T any](mgr *Manager, v T) error {
+	vals := mgr.values()
+	if vals == nil {
+		return ErrNoRequest
+	}
+	vals.Set(reflect.TypeFor[T](), v)
+	return nil
+}
+
+// GetInternal returns the value of type T from the current request.
+//
+//publicapigen:drop
+func GetInternal[T any](mgr *Manager) (v T, ok bool) {
+	vals := mgr.values()
+	if vals == nil {
+		return v, false
+	}
+	val, ok := vals.Get(reflect.TypeFor[T]())
+	if !ok {
+		return v, false
+	}
+	return val.(T), true
+}
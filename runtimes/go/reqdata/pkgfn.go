@@ -0,0 +1,21 @@
+//go:build encore_app
+
+package reqdata
+
+import "encore.dev/appruntime/shared/reqtrack"
+
+//publicapigen:drop
+var Singleton = NewManager(reqtrack.Singleton)
+
+// Set stores v on the current request, replacing any value
+// of the same type that's already stored.
+// It reports ErrNoRequest if called outside of a request.
+func Set[T any](v T) error {
+	return SetInternal(Singleton, v)
+}
+
+// Get returns the value of type T stored on the current request,
+// and reports whether one was found.
+func Get[T any]() (v T, ok bool) {
+	return GetInternal[T](Singleton)
+}
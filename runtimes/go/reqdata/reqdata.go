@@ -0,0 +1,37 @@
+// Package reqdata provides typed storage for request-scoped values.
+//
+// Values are keyed by their type and stored on the current request, so a value
+// set by an auth handler or middleware is available to the API handler
+// without threading it through context.WithValue:
+//
+//	type Tenant struct{ ID string }
+//
+//	//encore:middleware target=all
+//	func ResolveTenant(req middleware.Request, next middleware.Next) middleware.Response {
+//		reqdata.Set(&Tenant{ID: req.Data().Headers.Get("X-Tenant-ID")})
+//		return next(req)
+//	}
+//
+//	//encore:api public
+//	func Get(ctx context.Context) (*Response, error) {
+//		tenant, ok := reqdata.Get[*Tenant]()
+//		// ...
+//	}
+//
+// As values are keyed by their type, define your own types for the values
+// rather than using built-in types like string, in the same way as context keys.
+//
+// Like auth.Data, Set and Get operate on the request being handled
+// by the calling goroutine, and goroutines it starts, rather than taking a context.
+//
+// Values are not carried over to API calls made while handling the request,
+// which begin with their own values. Use the baggage package for string values
+// that should be.
+//
+// For more information see https://encore.dev/docs/go/develop/request-data
+package reqdata
+
+import "errors"
+
+// ErrNoRequest is reported by Set when it's called outside of a request.
+var ErrNoRequest = errors.New("reqdata: not called within a request")
@@ -0,0 +1,82 @@
+package reqdata
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+type tenant struct {
+	ID string
+}
+
+type locale string
+
+func TestSetGet(t *testing.T) {
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	mgr := NewManager(rt)
+
+	if err := SetInternal(mgr, &tenant{ID: "acme"}); !errors.Is(err, ErrNoRequest) {
+		t.Fatalf("got err %v, want ErrNoRequest", err)
+	}
+	if _, ok := GetInternal[*tenant](mgr); ok {
+		t.Fatal("got a value outside of a request")
+	}
+
+	rt.BeginRequest(&model.Request{Type: model.RPCCall})
+	if _, ok := GetInternal[*tenant](mgr); ok {
+		t.Fatal("got a value before it was set")
+	}
+	if err := SetInternal(mgr, &tenant{ID: "acme"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetInternal(mgr, locale("sv-SE")); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := GetInternal[*tenant](mgr); !ok || got.ID != "acme" {
+		t.Errorf("got (%+v, %v), want tenant acme", got, ok)
+	}
+	if got, ok := GetInternal[locale](mgr); !ok || got != "sv-SE" {
+		t.Errorf("got (%q, %v), want locale sv-SE", got, ok)
+	}
+
+	// Values are keyed by their exact type.
+	if _, ok := GetInternal[string](mgr); ok {
+		t.Error("got a string value, want none")
+	}
+
+	// Setting a value replaces the previous one.
+	if err := SetInternal(mgr, &tenant{ID: "globex"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := GetInternal[*tenant](mgr); got.ID != "globex" {
+		t.Errorf("got tenant %q, want globex", got.ID)
+	}
+	rt.FinishRequest(false)
+
+	// Each request begins without any values.
+	rt.BeginRequest(&model.Request{Type: model.RPCCall})
+	defer rt.FinishRequest(false)
+	if _, ok := GetInternal[*tenant](mgr); ok {
+		t.Error("got a value from the previous request")
+	}
+}
+
+func TestGet_Inherited(t *testing.T) {
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	mgr := NewManager(rt)
+
+	// Values set by an auth handler are passed on to the request it authenticated.
+	vals := &model.RequestValues{}
+	vals.Set(reflect.TypeFor[*tenant](), &tenant{ID: "acme"})
+	rt.BeginRequest(&model.Request{Type: model.RPCCall, Values: vals})
+	defer rt.FinishRequest(false)
+	if got, ok := GetInternal[*tenant](mgr); !ok || got.ID != "acme" {
+		t.Errorf("got (%+v, %v), want tenant acme", got, ok)
+	}
+}
@@ -57,7 +57,11 @@ type Request struct {
 	// Message contains information about the PubSub message,
 	Message *MessageData
 
-	// Payload is the decoded request payload or Pub/Sub message payload,
+	// WorkerJob specific parameters.
+	// Job contains information about the background job being processed.
+	Job *JobData
+
+	// Payload is the decoded request payload, Pub/Sub message payload or job payload,
 	// or nil if the API endpoint has no request payload or the endpoint is raw.
 	Payload any
 
@@ -106,6 +110,22 @@ type MessageData struct {
 	DeliveryAttempt int
 }
 
+// JobData describes the request data for a background job
+// processed by a worker queue.
+type JobData struct {
+	// Queue is the name of the queue the job was enqueued on.
+	Queue string
+
+	// ID is the unique ID of the job, as returned by Enqueue.
+	ID string
+
+	// EnqueuedAt is the time the job was enqueued.
+	EnqueuedAt time.Time
+
+	// Attempt is the attempt number, starting from 1.
+	Attempt int
+}
+
 // RequestType describes how the currently running code was triggered
 type RequestType string
 
@@ -113,6 +133,7 @@ const (
 	None          RequestType = "none"           // There was no external trigger which caused this code to run. Most likely it was triggered by a package level init function.
 	APICall       RequestType = "api-call"       // The code was triggered via an API call to a service
 	PubSubMessage RequestType = "pubsub-message" // The code was triggered by a PubSub subscriber
+	WorkerJob     RequestType = "worker-job"     // The code was triggered by a background job from a worker queue
 )
 
 // PathParams contains the path parameters parsed from the request path.
@@ -199,6 +220,17 @@ func (mgr *Manager) CurrentRequest() *Request {
 			Published:       req.MsgData.Published,
 			DeliveryAttempt: req.MsgData.Attempt,
 		}
+
+	case model.WorkerJob:
+		result.Type = WorkerJob
+		result.Service = req.JobData.Service
+		result.Payload = req.JobData.DecodedPayload
+		result.Job = &JobData{
+			Queue:      req.JobData.Queue,
+			ID:         req.JobData.JobID,
+			EnqueuedAt: req.JobData.EnqueuedAt,
+			Attempt:    req.JobData.Attempt,
+		}
 	}
 
 	return result
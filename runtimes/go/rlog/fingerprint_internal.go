@@ -0,0 +1,212 @@
+package rlog
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/stack"
+	"encore.dev/beta/errs"
+)
+
+// fingerprintKey is the key of the field holding the fingerprint
+// of the error logged by an error-level log message.
+const fingerprintKey = "error_fingerprint"
+
+// fingerprintFrames is the number of stack frames to include in fingerprints.
+const fingerprintFrames = 3
+
+// errorFingerprint returns a fingerprint that groups errors of the same
+// kind: errors of the same type created by the same functions.
+// The functions are taken from the stack of errs.Error errors, or else
+// from callSite, the stack of the code logging the error.
+//
+// Line numbers are left out so fingerprints stay the same
+// when unrelated code in the same file changes.
+func errorFingerprint(err error, callSite func() stack.Stack) string {
+	h := fnv.New64a()
+
+	var s stack.Stack
+	var e *errs.Error
+	if errors.As(err, &e) {
+		_, _ = fmt.Fprintf(h, "errs.Error:%s", e.Code)
+		s = errs.Stack(e)
+	} else {
+		_, _ = fmt.Fprintf(h, "%T", rootCause(err))
+	}
+	if len(s.Frames) == 0 {
+		s = callSite()
+	}
+
+	frames := runtime.CallersFrames(s.Frames)
+	for n := 0; n < fingerprintFrames; {
+		frame, more := frames.Next()
+		// Skip the frames of this package, except for its tests.
+		if funcPackage(frame.Function) != "encore.dev/rlog" || strings.HasSuffix(frame.File, "_test.go") {
+			_, _ = fmt.Fprintf(h, "\n%s", frame.Function)
+			n++
+		}
+		if !more {
+			break
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// rootCause returns the innermost error wrapped by err.
+func rootCause(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+// loggedError returns the first error among the given key-value pairs
+// and its key, and whether the pairs include a fingerprint already.
+func loggedError(fieldLists ...[]any) (key string, err error, hasFingerprint bool) {
+	for _, fields := range fieldLists {
+		for i := 0; i < len(fields); i += 2 {
+			if fields[i] == fingerprintKey {
+				hasFingerprint = true
+			}
+			if e, ok := fields[i+1].(error); ok && err == nil {
+				key, err = fields[i].(string), e
+			}
+		}
+	}
+	return key, err, hasFingerprint
+}
+
+// maxReportedFingerprints bounds the number of distinct
+// fingerprints tracked between reports.
+const maxReportedFingerprints = 1000
+
+// errorReport counts the errors logged per fingerprint,
+// to periodically log the most frequent ones.
+type errorReport struct {
+	interval time.Duration
+	top      int
+	done     chan struct{}
+	stopOnce sync.Once
+
+	mu     sync.Mutex
+	counts map[string]*errorCount
+	total  int
+	since  time.Time
+}
+
+type errorCount struct {
+	Fingerprint string `json:"fingerprint"`
+	Count       int    `json:"count"`
+	Error       string `json:"error"` // the first error logged
+}
+
+// newErrorReport returns an error report for the given config,
+// or nil if errors are not to be reported.
+func newErrorReport(cfg *config.LogErrorReport) *errorReport {
+	if cfg == nil {
+		return nil
+	}
+	r := &errorReport{
+		interval: cfg.Interval,
+		top:      cfg.Top,
+		done:     make(chan struct{}),
+		counts:   make(map[string]*errorCount),
+		since:    time.Now(),
+	}
+	if r.interval <= 0 {
+		r.interval = time.Minute
+	}
+	if r.top <= 0 {
+		r.top = 10
+	}
+	return r
+}
+
+// add counts a logged error.
+func (r *errorReport) add(fingerprint, msg string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.total++
+	if c, ok := r.counts[fingerprint]; ok {
+		c.Count++
+	} else if len(r.counts) < maxReportedFingerprints {
+		r.counts[fingerprint] = &errorCount{Fingerprint: fingerprint, Count: 1, Error: msg}
+	}
+}
+
+// take returns the errors counted since the last call, most frequent
+// first, along with the total number of errors and the start of the period.
+func (r *errorReport) take() (counts []*errorCount, total int, since time.Time) {
+	r.mu.Lock()
+	byFingerprint, total, since := r.counts, r.total, r.since
+	r.counts, r.total, r.since = make(map[string]*errorCount), 0, time.Now()
+	r.mu.Unlock()
+
+	for _, c := range byFingerprint {
+		counts = append(counts, c)
+	}
+	slices.SortFunc(counts, func(a, b *errorCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Fingerprint, b.Fingerprint)
+	})
+	return counts, total, since
+}
+
+func (r *errorReport) stop() {
+	r.stopOnce.Do(func() { close(r.done) })
+}
+
+// BeginReportingErrors periodically logs the most frequent errors,
+// if configured to, until the manager is shut down.
+//
+//publicapigen:drop
+func (l *Manager) BeginReportingErrors() {
+	r := l.errors
+	if r == nil {
+		return
+	}
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			l.reportErrors()
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// reportErrors logs the most frequent errors since the last report.
+func (l *Manager) reportErrors() {
+	counts, total, since := l.errors.take()
+	if total == 0 {
+		return
+	}
+	top := counts
+	if len(top) > l.errors.top {
+		top = top[:l.errors.top]
+	}
+	l.rt.Logger().Info().
+		Int("total", total).
+		Int("distinct", len(counts)).
+		Dur("period", time.Since(since)).
+		Interface("top_errors", top).
+		Msg("top errors")
+}
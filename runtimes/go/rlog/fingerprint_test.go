@@ -0,0 +1,127 @@
+package rlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/beta/errs"
+)
+
+func newNotFound(id int) error {
+	return errs.B().Code(errs.NotFound).Msgf("user %d not found", id).Err()
+}
+
+func newInternal() error {
+	return errs.B().Code(errs.Internal).Msg("boom").Err()
+}
+
+func TestErrorFingerprint(t *testing.T) {
+	var buf bytes.Buffer
+	rt := reqtrack.New(zerolog.New(&buf), nil, nil)
+	mgr := NewManager(nil, &config.Runtime{}, rt, nil, nil)
+
+	fingerprints := func() []string {
+		var fps []string
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var entry map[string]any
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				t.Fatal(err)
+			}
+			fp, _ := entry[fingerprintKey].(string)
+			fps = append(fps, fp)
+		}
+		buf.Reset()
+		return fps
+	}
+
+	// errs.Error errors are fingerprinted by where they're created,
+	// regardless of their message or where they're logged.
+	mgr.Error("lookup failed", "err", newNotFound(1))
+	mgr.With("user", 2).Error("lookup failed again", "err", newNotFound(2))
+	mgr.Error("other failure", "err", newInternal())
+	fps := fingerprints()
+	if fps[0] == "" || fps[0] != fps[1] || fps[0] == fps[2] {
+		t.Errorf("got fingerprints %v, want the first two to be equal only", fps)
+	}
+
+	// Other errors are fingerprinted by their root cause
+	// and the function logging them.
+	for i := 0; i < 2; i++ {
+		mgr.Error("wrapped", "err", fmt.Errorf("wrap %d: %w", i, io.EOF))
+	}
+	func() {
+		mgr.Error("wrapped", "err", fmt.Errorf("wrap: %w", io.EOF))
+	}()
+	fps = fingerprints()
+	if fps[0] == "" || fps[0] != fps[1] || fps[0] == fps[2] {
+		t.Errorf("got fingerprints %v, want the first two to be equal only", fps)
+	}
+
+	// Only error-level messages with errors are fingerprinted,
+	// and existing fingerprints are kept.
+	mgr.Warn("warning", "err", io.EOF)
+	mgr.Error("no error", "count", 1)
+	mgr.Error("custom", "err", io.EOF, fingerprintKey, "custom")
+	if fps := fingerprints(); fps[0] != "" || fps[1] != "" || fps[2] != "custom" {
+		t.Errorf("got fingerprints %v", fps)
+	}
+}
+
+func TestErrorReport(t *testing.T) {
+	var buf bytes.Buffer
+	rt := reqtrack.New(zerolog.New(&buf), nil, nil)
+	mgr := NewManager(nil, &config.Runtime{
+		LogErrorReport: &config.LogErrorReport{Interval: time.Hour, Top: 1},
+	}, rt, nil, nil)
+
+	for i := 0; i < 3; i++ {
+		mgr.Error("lookup failed", "err", newNotFound(i))
+	}
+	mgr.Error("other failure", "err", newInternal())
+	buf.Reset()
+
+	mgr.reportErrors()
+	var report struct {
+		Message   string `json:"message"`
+		Total     int    `json:"total"`
+		Distinct  int    `json:"distinct"`
+		TopErrors []struct {
+			Fingerprint string `json:"fingerprint"`
+			Count       int    `json:"count"`
+			Error       string `json:"error"`
+		} `json:"top_errors"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid report %q: %v", buf.String(), err)
+	}
+	if report.Message != "top errors" || report.Total != 4 || report.Distinct != 2 || len(report.TopErrors) != 1 {
+		t.Fatalf("got report %+v", report)
+	}
+	if top := report.TopErrors[0]; top.Count != 3 || !strings.Contains(top.Error, "user 0 not found") || top.Fingerprint == "" {
+		t.Errorf("got top error %+v", top)
+	}
+
+	// Nothing is reported when no errors were logged since the last report.
+	buf.Reset()
+	mgr.reportErrors()
+	if buf.Len() != 0 {
+		t.Errorf("got report %q, want none", buf.String())
+	}
+}
+
+func TestRootCause(t *testing.T) {
+	err := fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", io.EOF))
+	if got := rootCause(err); !errors.Is(got, io.EOF) || got != io.EOF {
+		t.Errorf("got %v, want io.EOF", got)
+	}
+}
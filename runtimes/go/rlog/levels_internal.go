@@ -0,0 +1,275 @@
+package rlog
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/jsonapi"
+	"encore.dev/internal/platformauth"
+)
+
+// levelOverrides are log levels set at runtime, which take precedence
+// over the level the application was configured to log at.
+type levelOverrides struct {
+	svcs []string // bundled services, indexed by service number - 1
+
+	// active is whether any override is set, to avoid
+	// taking the lock when logging in the common case.
+	active atomic.Bool
+
+	mu     sync.RWMutex
+	global *zerolog.Level           // nil if not overridden
+	perSvc map[string]zerolog.Level // keyed by service name
+}
+
+// level reports the overridden level for the given service, if any.
+// Service overrides take precedence over the global override.
+func (o *levelOverrides) level(svcNum uint16) (zerolog.Level, bool) {
+	if !o.active.Load() {
+		return 0, false
+	}
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if svcNum > 0 && int(svcNum) <= len(o.svcs) {
+		if lvl, ok := o.perSvc[o.svcs[svcNum-1]]; ok {
+			return lvl, true
+		}
+	}
+	if o.global != nil {
+		return *o.global, true
+	}
+	return 0, false
+}
+
+// set sets the override for the given service, or the global
+// override if svc is empty. A nil level clears the override.
+func (o *levelOverrides) set(svc string, lvl *zerolog.Level) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if svc == "" {
+		o.global = lvl
+	} else if lvl == nil {
+		delete(o.perSvc, svc)
+	} else {
+		if o.perSvc == nil {
+			o.perSvc = make(map[string]zerolog.Level)
+		}
+		o.perSvc[svc] = *lvl
+	}
+	o.active.Store(o.global != nil || len(o.perSvc) > 0)
+}
+
+// configuredLevels are the levels configured for specific
+// services and packages in the runtime config.
+type configuredLevels struct {
+	svcs  []string // bundled services, indexed by service number - 1
+	rules []config.LogLevelRule
+	isSvc []bool // whether each rule is for a service, rather than a package
+}
+
+func newConfiguredLevels(runtime *config.Runtime, svcs []string) configuredLevels {
+	if runtime == nil {
+		return configuredLevels{}
+	}
+
+	// Invalid configs are reported when configuring the root logger.
+	levels, _ := config.ParseLogLevels(runtime.LogLevels)
+	c := configuredLevels{svcs: svcs, rules: levels.Rules}
+	for _, r := range levels.Rules {
+		c.isSvc = append(c.isSvc, slices.Contains(svcs, r.Name))
+	}
+	return c
+}
+
+// level reports the level of the first rule matching the given
+// service or the package of the code writing the log message, if any.
+func (c *configuredLevels) level(svcNum uint16) (zerolog.Level, bool) {
+	if len(c.rules) == 0 {
+		return 0, false
+	}
+
+	var svc string
+	if svcNum > 0 && int(svcNum) <= len(c.svcs) {
+		svc = c.svcs[svcNum-1]
+	}
+
+	pkg, pkgKnown := "", false
+	for i, r := range c.rules {
+		if c.isSvc[i] {
+			if r.Name == svc {
+				return r.Level, true
+			}
+			continue
+		}
+
+		if !pkgKnown {
+			pkg, pkgKnown = callerPackage(), true
+		}
+		if r.MatchesPackage(pkg) {
+			return r.Level, true
+		}
+	}
+	return 0, false
+}
+
+// callerPackage returns the package path of the code calling rlog.
+func callerPackage() string {
+	var pcs [16]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		pkg := funcPackage(frame.Function)
+		// Skip the frames of this package, except for its tests.
+		if pkg != "encore.dev/rlog" || strings.HasSuffix(frame.File, "_test.go") || !more {
+			return pkg
+		}
+	}
+}
+
+// funcPackage returns the package path of a fully qualified
+// function name, such as "encore.app/orders.(*Service).Create".
+func funcPackage(name string) string {
+	lastSlash := strings.LastIndexByte(name, '/')
+	if dot := strings.IndexByte(name[lastSlash+1:], '.'); dot >= 0 {
+		return name[:lastSlash+1+dot]
+	}
+	return name
+}
+
+// logger returns the logger to use for logging, with the level
+// overridden at runtime or configured for the current service
+// or package, if any.
+func (l *Manager) logger(base *zerolog.Logger) *zerolog.Logger {
+	svcNum := l.rt.Current().SvcNum
+	lvl, ok := l.levels.level(svcNum)
+	if !ok {
+		lvl, ok = l.configured.level(svcNum)
+	}
+	if ok {
+		lg := base.Level(lvl)
+		return &lg
+	}
+	return base
+}
+
+// registerRoutes registers the routes for changing log levels at runtime.
+// Requests are only accepted when authorized to use the runtime's admin routes,
+// since the Encore internal routes are not otherwise authenticated.
+func (l *Manager) registerRoutes(server *api.Server) {
+	if server == nil {
+		return
+	}
+	server.RegisterEncoreRoute("GET", "/loglevel", http.HandlerFunc(l.handleList))
+	server.RegisterEncoreRoute("PUT", "/loglevel", http.HandlerFunc(l.handleSet))
+	server.RegisterEncoreRoute("DELETE", "/loglevel", http.HandlerFunc(l.handleSet))
+	server.RegisterEncoreRoute("PUT", "/loglevel/:service", http.HandlerFunc(l.handleSet))
+	server.RegisterEncoreRoute("DELETE", "/loglevel/:service", http.HandlerFunc(l.handleSet))
+}
+
+func (l *Manager) authorized(w http.ResponseWriter, req *http.Request) bool {
+	return platformauth.AuthorizeAdmin(w, req, l.runtime)
+}
+
+type levelState struct {
+	Level    string            `json:"level,omitempty"`
+	Services map[string]string `json:"services"`
+}
+
+func (l *Manager) handleList(w http.ResponseWriter, req *http.Request) {
+	if !l.authorized(w, req) {
+		return
+	}
+
+	o := &l.levels
+	o.mu.RLock()
+	state := levelState{Services: make(map[string]string, len(o.perSvc))}
+	if o.global != nil {
+		state.Level = o.global.String()
+	}
+	for svc, lvl := range o.perSvc {
+		state.Services[svc] = lvl.String()
+	}
+	o.mu.RUnlock()
+
+	writeJSON(w, http.StatusOK, state)
+}
+
+// handleSet sets (PUT) or clears (DELETE) the level override
+// for a service, or globally if no service is given.
+func (l *Manager) handleSet(w http.ResponseWriter, req *http.Request) {
+	if !l.authorized(w, req) {
+		return
+	}
+
+	svc := httprouter.ParamsFromContext(req.Context()).ByName("service")
+	if svc != "" && !l.hasService(svc) {
+		http.Error(w, "unknown service", http.StatusNotFound)
+		return
+	}
+
+	if req.Method == http.MethodDelete {
+		l.levels.set(svc, nil)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var params struct {
+		Level string `json:"level"`
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, req.Body, 1<<16))
+	if err != nil {
+		http.Error(w, "could not read request body", http.StatusBadRequest)
+		return
+	} else if err := json.Unmarshal(data, &params); err != nil {
+		http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	lvl, err := zerolog.ParseLevel(params.Level)
+	if err != nil || params.Level == "" {
+		http.Error(w, "invalid log level", http.StatusBadRequest)
+		return
+	}
+
+	l.levels.set(svc, &lvl)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (l *Manager) hasService(name string) bool {
+	for _, svc := range l.levels.svcs {
+		if svc == name {
+			return true
+		}
+	}
+	return false
+}
+
+func bundledServices(static *config.Static) []string {
+	if static == nil {
+		return nil
+	}
+	return static.BundledServices
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	data, err := jsonapi.Default.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
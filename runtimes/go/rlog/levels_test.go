@@ -0,0 +1,167 @@
+package rlog
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestLevelOverrides(t *testing.T) {
+	var buf bytes.Buffer
+	rt := reqtrack.New(zerolog.New(&buf).Level(zerolog.InfoLevel), nil, nil)
+	mgr := NewManager(&config.Static{BundledServices: []string{"foo", "bar"}}, &config.Runtime{EnvCloud: "local"}, rt, nil, nil)
+
+	logs := func(svcNum uint16) string {
+		t.Helper()
+		buf.Reset()
+		rt.BeginRequest(&model.Request{SvcNum: svcNum})
+		defer rt.FinishRequest(false)
+		mgr.Debug("debug")
+		mgr.With("key", "value").Info("info")
+		return buf.String()
+	}
+	set := func(method, svc, body string) int {
+		t.Helper()
+		req := httptest.NewRequest(method, "/loglevel", strings.NewReader(body))
+		if svc != "" {
+			req = req.WithContext(context.WithValue(req.Context(), httprouter.ParamsKey, httprouter.Params{{Key: "service", Value: svc}}))
+		}
+		w := httptest.NewRecorder()
+		mgr.handleSet(w, req)
+		return w.Code
+	}
+
+	if got := logs(1); strings.Contains(got, "debug") || !strings.Contains(got, "info") {
+		t.Fatalf("got logs %q before override", got)
+	}
+
+	if code := set("PUT", "foo", `{"level": "debug"}`); code != http.StatusNoContent {
+		t.Fatalf("got code %d, want %d", code, http.StatusNoContent)
+	}
+	if got := logs(1); !strings.Contains(got, "debug") {
+		t.Fatalf("got logs %q, want debug logs for overridden service", got)
+	}
+	if got := logs(2); strings.Contains(got, "debug") {
+		t.Fatalf("got logs %q, want no debug logs for other service", got)
+	}
+
+	// The service override takes precedence over the global one.
+	set("PUT", "", `{"level": "error"}`)
+	if got := logs(2); got != "" {
+		t.Fatalf("got logs %q, want none", got)
+	}
+	if got := logs(1); !strings.Contains(got, "debug") {
+		t.Fatalf("got logs %q, want debug logs for overridden service", got)
+	}
+
+	set("DELETE", "foo", "")
+	set("DELETE", "", "")
+	if got := logs(1); strings.Contains(got, "debug") || !strings.Contains(got, "info") {
+		t.Fatalf("got logs %q after clearing overrides", got)
+	}
+
+	if code := set("PUT", "unknown", `{"level": "debug"}`); code != http.StatusNotFound {
+		t.Fatalf("got code %d for unknown service, want %d", code, http.StatusNotFound)
+	}
+	if code := set("PUT", "", `{"level": "loud"}`); code != http.StatusBadRequest {
+		t.Fatalf("got code %d for invalid level, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestLevelOverrides_Unauthorized(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, nil)
+	mgr := NewManager(&config.Static{}, &config.Runtime{EnvCloud: "aws"}, rt, nil, nil)
+
+	w := httptest.NewRecorder()
+	mgr.handleSet(w, httptest.NewRequest("PUT", "/loglevel", strings.NewReader(`{"level": "debug"}`)))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("got code %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestLevelOverrides_AdminToken(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, nil)
+	mgr := NewManager(&config.Static{}, &config.Runtime{EnvCloud: "aws", AdminBearerToken: "s3cret"}, rt, nil, nil)
+
+	for _, test := range []struct {
+		auth string
+		code int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer wrong", http.StatusUnauthorized},
+		{"s3cret", http.StatusUnauthorized},
+		{"Bearer s3cret", http.StatusNoContent},
+	} {
+		req := httptest.NewRequest("PUT", "/loglevel", strings.NewReader(`{"level": "debug"}`))
+		if test.auth != "" {
+			req.Header.Set("Authorization", test.auth)
+		}
+		w := httptest.NewRecorder()
+		mgr.handleSet(w, req)
+		if w.Code != test.code {
+			t.Errorf("auth %q: got code %d, want %d", test.auth, w.Code, test.code)
+		}
+	}
+}
+
+func TestConfiguredLevels(t *testing.T) {
+	var buf bytes.Buffer
+	rt := reqtrack.New(zerolog.New(&buf).Level(zerolog.InfoLevel), nil, nil)
+	mgr := NewManager(
+		&config.Static{BundledServices: []string{"foo", "bar"}},
+		&config.Runtime{EnvCloud: "local", LogLevels: "foo=debug,encore.dev/rlog/...=warn,*=info"},
+		rt, nil, nil,
+	)
+
+	logs := func(svcNum uint16) string {
+		t.Helper()
+		buf.Reset()
+		rt.BeginRequest(&model.Request{SvcNum: svcNum})
+		defer rt.FinishRequest(false)
+		mgr.Debug("debug")
+		mgr.Info("info")
+		mgr.Warn("warn")
+		return buf.String()
+	}
+
+	// The service rule matches first.
+	if got := logs(1); !strings.Contains(got, "debug") {
+		t.Fatalf("got logs %q, want debug logs for service", got)
+	}
+
+	// The package rule matches the calling package.
+	if got := logs(2); strings.Contains(got, `"info"`) || !strings.Contains(got, "warn") {
+		t.Fatalf("got logs %q, want only warnings for package", got)
+	}
+
+	// Levels set at runtime take precedence.
+	mgr.levels.set("bar", ptr(zerolog.DebugLevel))
+	if got := logs(2); !strings.Contains(got, "debug") {
+		t.Fatalf("got logs %q, want debug logs after override", got)
+	}
+}
+
+func TestFuncPackage(t *testing.T) {
+	tests := map[string]string{
+		"encore.app/orders.(*Service).Create.func1": "encore.app/orders",
+		"encore.app/orders.Create":                  "encore.app/orders",
+		"main.main":                                 "main",
+	}
+	for name, want := range tests {
+		if got := funcPackage(name); got != want {
+			t.Errorf("funcPackage(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -2,10 +2,21 @@
 
 package rlog
 
-import "encore.dev/appruntime/shared/reqtrack"
+import (
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/infrasdk/logexport"
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/shutdown"
+)
 
 //publicapigen:drop
-var Singleton = NewManager(reqtrack.Singleton)
+var Singleton = NewManager(appconf.Static, appconf.Runtime, reqtrack.Singleton, api.Singleton, logexport.Singleton)
+
+func init() {
+	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+	go Singleton.BeginReportingErrors()
+}
 
 // Debug logs a debug-level message.
 // The variadic key-value pairs are treated as they are in With.
@@ -36,3 +47,31 @@ func Error(msg string, keysAndValues ...any) {
 func With(keysAndValues ...any) Ctx {
 	return Singleton.With(keysAndValues...)
 }
+
+// Sample returns a logging context that only logs a sample of the messages,
+// as configured by s. See Ctx.Sample for more information.
+//
+// The context should be stored and reused, for example in a package-level variable:
+//
+//	var hotLog = rlog.Sample(rlog.Sampling{Every: 100, PerSecond: 10})
+func Sample(s Sampling) Ctx {
+	return Singleton.Sample(s)
+}
+
+// AddSink registers a sink that receives the log records written using rlog,
+// in addition to the standard log output. It returns a function that removes
+// the sink again, after it has written its buffered records.
+//
+// Each sink has its own buffer and goroutine, so a slow sink never blocks
+// the application: records are dropped for a sink whose buffer is full.
+// Before the application shuts down, sinks are given the opportunity
+// to write their buffered records.
+//
+// For example, to forward errors to an error tracking service:
+//
+//	rlog.AddSink(rlog.SinkFunc(func(r rlog.Record) {
+//		tracker.Report(r.Message, r.Fields)
+//	}), rlog.SinkConfig{MinLevel: rlog.LevelError})
+func AddSink(sink Sink, cfg SinkConfig) (remove func()) {
+	return Singleton.AddSink(sink, cfg)
+}
@@ -0,0 +1,154 @@
+package rlog
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+// redactedValue replaces redacted data in logs.
+const redactedValue = "[REDACTED]"
+
+// valueMatcher matches sensitive values to redact.
+type valueMatcher struct {
+	re *regexp.Regexp
+
+	// valid, if non-nil, reports whether a match is
+	// sensitive, to reduce false positives.
+	valid func(match string) bool
+}
+
+// builtinMatchers are the built-in matchers, keyed by name.
+var builtinMatchers = map[string]valueMatcher{
+	"email":        {re: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)},
+	"card_number":  {re: regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`), valid: luhnValid},
+	"bearer_token": {re: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`)},
+}
+
+// redactor redacts sensitive data from logs
+// before they're written, traced or exported.
+type redactor struct {
+	fields   []string // lowercase field name patterns
+	matchers []valueMatcher
+}
+
+// newRedactor returns a redactor for the given config,
+// or nil if nothing is to be redacted.
+func newRedactor(cfg *config.LogRedaction) (*redactor, error) {
+	if cfg == nil || (len(cfg.Fields) == 0 && len(cfg.Matchers) == 0 && len(cfg.Patterns) == 0) {
+		return nil, nil
+	}
+
+	r := &redactor{}
+	for _, f := range cfg.Fields {
+		if _, err := path.Match(f, ""); err != nil {
+			return nil, fmt.Errorf("invalid field pattern %q: %v", f, err)
+		}
+		r.fields = append(r.fields, strings.ToLower(f))
+	}
+	for _, name := range cfg.Matchers {
+		m, ok := builtinMatchers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown matcher %q", name)
+		}
+		r.matchers = append(r.matchers, m)
+	}
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", p, err)
+		}
+		r.matchers = append(r.matchers, valueMatcher{re: re})
+	}
+	return r, nil
+}
+
+// message redacts sensitive values from a log message.
+func (r *redactor) message(msg string) string {
+	if r == nil {
+		return msg
+	}
+	return r.redactString(msg)
+}
+
+// field redacts the value of a log field, if sensitive.
+func (r *redactor) field(key string, val any) any {
+	if r == nil {
+		return val
+	}
+
+	lowerKey := strings.ToLower(key)
+	for _, f := range r.fields {
+		if ok, _ := path.Match(f, lowerKey); ok {
+			return redactedValue
+		}
+	}
+
+	if len(r.matchers) > 0 {
+		switch v := val.(type) {
+		case string:
+			return r.redactString(v)
+		case error:
+			msg := v.Error()
+			if redacted := r.redactString(msg); redacted != msg {
+				return redacted
+			}
+		}
+	}
+	return val
+}
+
+// fieldPairs returns the key-value pairs with sensitive values redacted.
+// The given slice is not modified.
+func (r *redactor) fieldPairs(fields []any) []any {
+	if r == nil || len(fields) == 0 {
+		return fields
+	}
+	result := make([]any, len(fields))
+	for i := 0; i < len(fields); i += 2 {
+		result[i] = fields[i]
+		result[i+1] = r.field(fields[i].(string), fields[i+1])
+	}
+	return result
+}
+
+func (r *redactor) redactString(s string) string {
+	for _, m := range r.matchers {
+		if m.valid == nil {
+			s = m.re.ReplaceAllLiteralString(s, redactedValue)
+			continue
+		}
+		s = m.re.ReplaceAllStringFunc(s, func(match string) string {
+			if m.valid(match) {
+				return redactedValue
+			}
+			return match
+		})
+	}
+	return s
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum
+// used by payment card numbers.
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n > 0 && sum%10 == 0
+}
@@ -0,0 +1,92 @@
+package rlog
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestRedactor(t *testing.T) {
+	r, err := newRedactor(&config.LogRedaction{
+		Fields:   []string{"*password*", "authorization"},
+		Matchers: []string{"email", "card_number", "bearer_token"},
+		Patterns: []string{`sk_live_[a-zA-Z0-9]+`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key  string
+		val  any
+		want any
+	}{
+		{"user_password", "hunter2", redactedValue},
+		{"Authorization", "anything", redactedValue},
+		{"db_password", 1234, redactedValue},
+		{"user", "alice@example.com", redactedValue},
+		{"note", "contact alice@example.com today", "contact [REDACTED] today"},
+		{"card", "4242 4242 4242 4242", redactedValue},
+		{"order", "order 1234567890123", "order 1234567890123"}, // fails the Luhn check
+		{"header", "Bearer abc.def-ghi", redactedValue},
+		{"key", "using sk_live_abc123", "using [REDACTED]"},
+		{"count", 5, 5},
+		{"err", errors.New("no user alice@example.com"), "no user [REDACTED]"},
+	}
+	for _, tt := range tests {
+		if got := r.field(tt.key, tt.val); got != tt.want {
+			t.Errorf("field(%q, %v) = %v, want %v", tt.key, tt.val, got, tt.want)
+		}
+	}
+
+	// Errors without sensitive data are kept as-is.
+	err = errors.New("boom")
+	if got := r.field("err", err); got != err {
+		t.Errorf("got %v, want original error", got)
+	}
+
+	if got := r.message("signup from bob@example.com"); got != "signup from [REDACTED]" {
+		t.Errorf("got message %q", got)
+	}
+}
+
+func TestRedactor_Invalid(t *testing.T) {
+	if _, err := newRedactor(&config.LogRedaction{Matchers: []string{"ssn"}}); err == nil {
+		t.Error("expected error for unknown matcher")
+	}
+	if _, err := newRedactor(&config.LogRedaction{Patterns: []string{"("}}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+	if r, err := newRedactor(&config.LogRedaction{}); r != nil || err != nil {
+		t.Errorf("got %v, %v, want nil redactor", r, err)
+	}
+}
+
+func TestManager_Redact(t *testing.T) {
+	var buf bytes.Buffer
+	rt := reqtrack.New(zerolog.New(&buf), nil, nil)
+	mgr := NewManager(nil, &config.Runtime{LogRedaction: &config.LogRedaction{
+		Fields:   []string{"token"},
+		Matchers: []string{"email"},
+	}}, rt, nil, nil)
+
+	fields := []any{"token", "secret"}
+	mgr.With(fields...).Info("login by alice@example.com", "email", "alice@example.com", "method", "oauth")
+
+	got := buf.String()
+	if strings.Contains(got, "secret") || strings.Contains(got, "alice@example.com") {
+		t.Fatalf("got unredacted logs %q", got)
+	}
+	if !strings.Contains(got, `"method":"oauth"`) || !strings.Contains(got, `"message":"login by [REDACTED]"`) {
+		t.Fatalf("got logs %q", got)
+	}
+	if fields[1] != "secret" {
+		t.Fatalf("fields were modified: %v", fields)
+	}
+}
@@ -5,14 +5,18 @@
 package rlog
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
 
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/stack"
 	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/infrasdk/logexport"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/types/uuid"
 )
@@ -26,45 +30,67 @@ const InternalKeyPrefix = "encore_"
 
 //publicapigen:drop
 type Manager struct {
-	rt *reqtrack.RequestTracker
+	runtime    *config.Runtime
+	rt         *reqtrack.RequestTracker
+	logs       *logexport.Manager // nil if logs are not exported
+	redact     *redactor          // nil if nothing is redacted
+	levels     levelOverrides
+	configured configuredLevels
+	sinks      sinks
+	errors     *errorReport // nil if errors are not reported
 }
 
 //publicapigen:drop
-func NewManager(rt *reqtrack.RequestTracker) *Manager {
-	return &Manager{rt}
+func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker, server *api.Server, logs *logexport.Manager) *Manager {
+	mgr := &Manager{runtime: runtime, rt: rt, logs: logs}
+	if runtime != nil {
+		r, err := newRedactor(runtime.LogRedaction)
+		if err != nil {
+			panic(fmt.Sprintf("rlog: invalid log redaction config: %v", err))
+		}
+		mgr.redact = r
+	}
+	if runtime != nil {
+		mgr.errors = newErrorReport(runtime.LogErrorReport)
+	}
+	mgr.levels.svcs = bundledServices(static)
+	mgr.configured = newConfiguredLevels(runtime, mgr.levels.svcs)
+	mgr.registerRoutes(server)
+	return mgr
 }
 
 // Ctx holds additional logging context for use with the Infoc and family
 // of logging functions.
 type Ctx struct {
-	logger zerolog.Logger
-	mgr    *Manager
-	fields []any
+	logger  zerolog.Logger
+	mgr     *Manager
+	fields  []any
+	sampler *sampler // nil if not sampled
 }
 
 func (l *Manager) Debug(msg string, keysAndValues ...any) {
 	fields := pairs(keysAndValues)
-	l.doLog(model.LevelDebug, l.rt.Logger().Debug(), msg, nil, fields)
+	l.doLog(model.LevelDebug, l.logger(l.rt.Logger()).Debug(), msg, nil, fields)
 }
 
 func (l *Manager) Info(msg string, keysAndValues ...any) {
 	fields := pairs(keysAndValues)
-	l.doLog(model.LevelInfo, l.rt.Logger().Info(), msg, nil, fields)
+	l.doLog(model.LevelInfo, l.logger(l.rt.Logger()).Info(), msg, nil, fields)
 }
 
 func (l *Manager) Warn(msg string, keysAndValues ...any) {
 	fields := pairs(keysAndValues)
-	l.doLog(model.LevelWarn, l.rt.Logger().Warn(), msg, nil, fields)
+	l.doLog(model.LevelWarn, l.logger(l.rt.Logger()).Warn(), msg, nil, fields)
 }
 
 func (l *Manager) Error(msg string, keysAndValues ...any) {
 	fields := pairs(keysAndValues)
-	l.doLog(model.LevelError, l.rt.Logger().Error(), msg, nil, fields)
+	l.doLog(model.LevelError, l.logger(l.rt.Logger()).Error(), msg, nil, fields)
 }
 
 func (l *Manager) With(keysAndValues ...any) Ctx {
 	ctx := l.rt.Logger().With()
-	fields := pairs(keysAndValues)
+	fields := l.redact.fieldPairs(pairs(keysAndValues))
 	for i := 0; i < len(fields); i += 2 {
 		key := fields[i].(string)
 		val := fields[i+1]
@@ -73,11 +99,18 @@ func (l *Manager) With(keysAndValues ...any) Ctx {
 	return Ctx{logger: ctx.Logger(), mgr: l, fields: fields}
 }
 
+func (l *Manager) Sample(s Sampling) Ctx {
+	return Ctx{logger: *l.rt.Logger(), mgr: l, sampler: newSampler(s)}
+}
+
 // Debug logs a debug-level message, merging the context from ctx
 // with the additional context provided as key-value pairs.
 // The variadic key-value pairs are treated as they are in With.
 func (ctx Ctx) Debug(msg string, keysAndValues ...any) {
-	l := ctx.logger
+	if !ctx.sampler.sample() {
+		return
+	}
+	l := ctx.mgr.logger(&ctx.logger)
 	fields := pairs(keysAndValues)
 	ctx.mgr.doLog(model.LevelDebug, l.Debug(), msg, ctx.fields, fields)
 }
@@ -86,7 +119,10 @@ func (ctx Ctx) Debug(msg string, keysAndValues ...any) {
 // with the additional context provided as key-value pairs.
 // The variadic key-value pairs are treated as they are in With.
 func (ctx Ctx) Info(msg string, keysAndValues ...any) {
-	l := ctx.logger
+	if !ctx.sampler.sample() {
+		return
+	}
+	l := ctx.mgr.logger(&ctx.logger)
 	fields := pairs(keysAndValues)
 	ctx.mgr.doLog(model.LevelInfo, l.Info(), msg, ctx.fields, fields)
 }
@@ -95,7 +131,10 @@ func (ctx Ctx) Info(msg string, keysAndValues ...any) {
 // with the additional context provided as key-value pairs.
 // The variadic key-value pairs are treated as they are in With.
 func (ctx Ctx) Warn(msg string, keysAndValues ...any) {
-	l := ctx.logger
+	if !ctx.sampler.sample() {
+		return
+	}
+	l := ctx.mgr.logger(&ctx.logger)
 	fields := pairs(keysAndValues)
 	ctx.mgr.doLog(model.LevelWarn, l.Warn(), msg, ctx.fields, fields)
 }
@@ -104,7 +143,10 @@ func (ctx Ctx) Warn(msg string, keysAndValues ...any) {
 // with the additional context provided as key-value pairs.
 // The variadic key-value pairs are treated as they are in With.
 func (ctx Ctx) Error(msg string, keysAndValues ...any) {
-	l := ctx.logger
+	if !ctx.sampler.sample() {
+		return
+	}
+	l := ctx.mgr.logger(&ctx.logger)
 	fields := pairs(keysAndValues)
 	ctx.mgr.doLog(model.LevelError, l.Error(), msg, ctx.fields, fields)
 }
@@ -114,7 +156,7 @@ func (ctx Ctx) Error(msg string, keysAndValues ...any) {
 // The original ctx is not affected.
 func (ctx Ctx) With(keysAndValues ...any) Ctx {
 	c := ctx.logger.With()
-	fields := pairs(keysAndValues)
+	fields := ctx.mgr.redact.fieldPairs(pairs(keysAndValues))
 	for i := 0; i < len(fields); i += 2 {
 		key := fields[i].(string)
 		val := fields[i+1]
@@ -125,51 +167,106 @@ func (ctx Ctx) With(keysAndValues ...any) Ctx {
 	copy(newFields, ctx.fields)
 	copy(newFields[len(ctx.fields):], fields)
 
-	return Ctx{logger: c.Logger(), mgr: ctx.mgr, fields: newFields}
+	return Ctx{logger: c.Logger(), mgr: ctx.mgr, fields: newFields, sampler: ctx.sampler}
+}
+
+// Sample creates a new logging context that inherits the context
+// from the original ctx and only logs a sample of the messages,
+// as configured by s. The original ctx is not affected.
+//
+// The returned context should be reused rather than created for each
+// message, as it keeps track of how many messages have been logged.
+func (ctx Ctx) Sample(s Sampling) Ctx {
+	ctx.sampler = newSampler(s)
+	return ctx
 }
 
 func (l *Manager) doLog(level model.LogLevel, ev *zerolog.Event, msg string, ctxFields, logFields []any) {
-	var (
-		tp     trace2.LogMessageParams
-		traced bool
-	)
+	msg = l.redact.message(msg)
 	curr := l.rt.Current()
-	numFields := len(ctxFields)/2 + len(logFields)/2
-
-	if curr.Req != nil && curr.Trace != nil {
-		traced = true
-		tp = trace2.LogMessageParams{
-			EventParams: trace2.EventParams{
-				TraceID: curr.Req.TraceID,
-				SpanID:  curr.Req.SpanID,
-				Goid:    curr.Goctr,
-			},
-			Level:  level,
-			Msg:    msg,
-			Stack:  stack.Build(3),
-			Fields: make([]trace2.LogField, 0, numFields),
-		}
-
+	traced := curr.Req != nil && curr.Trace != nil
+	exported := ev != nil && l.logs.Enabled()
+	sunk := ev != nil && l.sinks.enabled(Level(level))
+
+	// The fields are only needed for traces, exported logs and sinks.
+	var fields []trace2.LogField
+	if traced || exported || sunk {
+		fields = make([]trace2.LogField, 0, len(ctxFields)/2+len(logFields)/2)
 		for i := 0; i < len(ctxFields); i += 2 {
 			key := ctxFields[i].(string)
 			val := ctxFields[i+1]
-			tp.Fields = append(tp.Fields, trace2.LogField{Key: key, Value: val})
+			fields = append(fields, trace2.LogField{Key: key, Value: val})
 		}
 	}
 
 	for i := 0; i < len(logFields); i += 2 {
 		key := logFields[i].(string)
-		val := logFields[i+1]
+		val := l.redact.field(key, logFields[i+1])
 		addEventEntry(ev, key, val)
-		if traced {
-			tp.Fields = append(tp.Fields, trace2.LogField{Key: key, Value: val})
+		if fields != nil {
+			fields = append(fields, trace2.LogField{Key: key, Value: val})
+		}
+	}
+
+	// Fingerprint logged errors so they can be grouped by log tools.
+	if ev != nil && level == model.LevelError {
+		if key, err, hasFingerprint := loggedError(logFields, ctxFields); err != nil && !hasFingerprint {
+			fp := errorFingerprint(err, func() stack.Stack { return stack.Build(0) })
+			addEventEntry(ev, fingerprintKey, fp)
+			if fields != nil {
+				fields = append(fields, trace2.LogField{Key: fingerprintKey, Value: fp})
+			}
+			l.errors.add(fp, fmt.Sprint(l.redact.field(key, err)))
 		}
 	}
 
 	ev.Msg(msg)
 
 	if traced {
-		curr.Trace.LogMessage(tp)
+		curr.Trace.LogMessage(trace2.LogMessageParams{
+			EventParams: trace2.EventParams{
+				TraceID: curr.Req.TraceID,
+				SpanID:  curr.Req.SpanID,
+				Goid:    curr.Goctr,
+			},
+			Level:  level,
+			Msg:    msg,
+			Stack:  stack.Build(3),
+			Fields: fields,
+		})
+	}
+
+	if exported {
+		rec := logexport.Record{
+			Time:   time.Now(),
+			Level:  level,
+			Msg:    msg,
+			Fields: fields,
+			SvcNum: curr.SvcNum,
+		}
+		if curr.Req != nil {
+			rec.TraceID, rec.SpanID = curr.Req.TraceID, curr.Req.SpanID
+		}
+		l.logs.Export(rec)
+	}
+
+	if sunk {
+		rec := Record{
+			Time:    time.Now(),
+			Level:   Level(level),
+			Message: msg,
+			Fields:  make([]Field, len(fields)),
+		}
+		for i, f := range fields {
+			rec.Fields[i] = Field{Key: f.Key, Value: f.Value}
+		}
+		if curr.Req != nil {
+			rec.TraceID, rec.SpanID = curr.Req.TraceID.String(), curr.Req.SpanID.String()
+		}
+		if svcNum := curr.SvcNum; svcNum > 0 && int(svcNum) <= len(l.levels.svcs) {
+			rec.Service = l.levels.svcs[svcNum-1]
+		}
+		l.sinks.write(rec)
 	}
 }
 
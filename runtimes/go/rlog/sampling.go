@@ -0,0 +1,74 @@
+package rlog
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sampling configures the sampling of log messages, which limits the volume
+// of logs produced by log statements on frequently executed code paths.
+//
+// Messages are sampled separately for each call site, so a frequently
+// executed log statement doesn't prevent other statements from logging.
+// Messages that are not sampled are neither logged nor included in traces.
+type Sampling struct {
+	// Every, if greater than 1, only logs every Nth message,
+	// starting with the first one.
+	Every uint32
+
+	// PerSecond, if non-zero, is the maximum number of messages
+	// logged per second.
+	PerSecond uint32
+}
+
+// sampler keeps track of the messages logged per call site.
+type sampler struct {
+	cfg   Sampling
+	sites sync.Map // uintptr -> *siteCounter
+}
+
+type siteCounter struct {
+	total    atomic.Uint64
+	second   atomic.Int64 // unix time of the current second
+	inSecond atomic.Uint32
+}
+
+func newSampler(cfg Sampling) *sampler {
+	if cfg.Every <= 1 && cfg.PerSecond == 0 {
+		return nil
+	}
+	return &sampler{cfg: cfg}
+}
+
+// sample reports whether to log the message logged by the caller
+// of the function calling sample.
+func (s *sampler) sample() bool {
+	if s == nil {
+		return true
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	c, ok := s.sites.Load(pcs[0])
+	if !ok {
+		c, _ = s.sites.LoadOrStore(pcs[0], &siteCounter{})
+	}
+	return s.allow(c.(*siteCounter), time.Now())
+}
+
+func (s *sampler) allow(c *siteCounter, now time.Time) bool {
+	if every := uint64(s.cfg.Every); every > 1 && (c.total.Add(1)-1)%every != 0 {
+		return false
+	}
+
+	if limit := s.cfg.PerSecond; limit > 0 {
+		sec := now.Unix()
+		if prev := c.second.Load(); prev != sec && c.second.CompareAndSwap(prev, sec) {
+			c.inSecond.Store(0)
+		}
+		return c.inSecond.Add(1) <= limit
+	}
+	return true
+}
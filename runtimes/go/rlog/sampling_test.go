@@ -0,0 +1,78 @@
+package rlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+func TestSampler_Every(t *testing.T) {
+	s := newSampler(Sampling{Every: 3})
+	c := &siteCounter{}
+	now := time.Now()
+
+	var got []bool
+	for i := 0; i < 7; i++ {
+		got = append(got, s.allow(c, now))
+	}
+	want := []bool{true, false, false, true, false, false, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSampler_PerSecond(t *testing.T) {
+	s := newSampler(Sampling{PerSecond: 2})
+	c := &siteCounter{}
+	now := time.Unix(1000, 0)
+
+	for i, want := range []bool{true, true, false, false} {
+		if got := s.allow(c, now); got != want {
+			t.Fatalf("message %d: got %v, want %v", i, got, want)
+		}
+	}
+
+	// The limit resets every second.
+	now = now.Add(time.Second)
+	if !s.allow(c, now) {
+		t.Fatalf("got message dropped in the next second")
+	}
+}
+
+func TestSampler_Disabled(t *testing.T) {
+	if s := newSampler(Sampling{Every: 1}); s != nil {
+		t.Fatalf("got sampler %+v, want nil", s)
+	}
+}
+
+func TestCtx_Sample(t *testing.T) {
+	var buf bytes.Buffer
+	rt := reqtrack.New(zerolog.New(&buf), nil, nil)
+	mgr := NewManager(nil, nil, rt, nil, nil)
+
+	ctx := mgr.With("key", "value").Sample(Sampling{Every: 10})
+	for i := 0; i < 25; i++ {
+		ctx.Info("first")
+	}
+	for i := 0; i < 5; i++ {
+		ctx.With("other", true).Info("second")
+	}
+
+	// Each call site is sampled separately.
+	if got := strings.Count(buf.String(), `"message":"first"`); got != 3 {
+		t.Fatalf("got %d messages logged from the first call site, want 3", got)
+	}
+	if got := strings.Count(buf.String(), `"message":"second"`); got != 1 {
+		t.Fatalf("got %d messages logged from the second call site, want 1", got)
+	}
+	if !strings.Contains(buf.String(), `"key":"value","other":true`) {
+		t.Fatalf("got logs %q, want context fields", buf.String())
+	}
+}
@@ -0,0 +1,238 @@
+package rlog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+// Level is the level of a log record.
+type Level uint8
+
+const (
+	LevelDebug = Level(model.LevelDebug)
+	LevelInfo  = Level(model.LevelInfo)
+	LevelWarn  = Level(model.LevelWarn)
+	LevelError = Level(model.LevelError)
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Record is a log message passed to sinks.
+type Record struct {
+	Time    time.Time
+	Level   Level
+	Message string
+
+	// Fields are the key-value pairs of the message,
+	// including those of the logging context.
+	Fields []Field
+
+	// TraceID and SpanID identify the request the message was
+	// logged during. They're empty if not logged during a request.
+	TraceID string
+	SpanID  string
+
+	// Service is the service the message was logged from,
+	// or empty if not logged from within a service.
+	Service string
+}
+
+// Field is a key-value pair of a log record.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Sink receives log records in addition to the standard log output,
+// for example to forward errors to an error tracking service.
+type Sink interface {
+	// Write handles a log record. It's called from a goroutine
+	// dedicated to the sink, one record at a time.
+	Write(r Record)
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(r Record)
+
+func (f SinkFunc) Write(r Record) { f(r) }
+
+// SinkConfig configures how records are passed to a sink.
+type SinkConfig struct {
+	// MinLevel is the minimum level of the records to pass to the sink.
+	// If zero, records of all levels are passed.
+	MinLevel Level
+
+	// BufferSize is the number of records to buffer for the sink.
+	// Records logged while the buffer is full are dropped, so that
+	// a slow sink never blocks the application. It defaults to 1000.
+	BufferSize int
+}
+
+// sinks holds the registered sinks.
+type sinks struct {
+	// active is whether any sink is registered, to avoid
+	// taking the lock when logging in the common case.
+	active atomic.Bool
+
+	mu      sync.RWMutex
+	runners []*sinkRunner
+	closed  bool
+}
+
+// sinkRunner passes records to a sink from a dedicated goroutine.
+type sinkRunner struct {
+	sink     Sink
+	minLevel Level
+	ch       chan Record
+	done     chan struct{}
+}
+
+func (s *sinks) add(sink Sink, cfg SinkConfig) *sinkRunner {
+	size := cfg.BufferSize
+	if size <= 0 {
+		size = 1000
+	}
+	r := &sinkRunner{
+		sink:     sink,
+		minLevel: cfg.MinLevel,
+		ch:       make(chan Record, size),
+		done:     make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		close(r.ch)
+	} else {
+		s.runners = append(s.runners, r)
+		s.active.Store(true)
+	}
+	go r.run()
+	return r
+}
+
+// remove removes the sink and waits for its buffered records to be written.
+func (s *sinks) remove(r *sinkRunner) {
+	s.mu.Lock()
+	for i, other := range s.runners {
+		if other == r {
+			s.runners = append(s.runners[:i:i], s.runners[i+1:]...)
+			close(r.ch)
+			break
+		}
+	}
+	s.active.Store(len(s.runners) > 0)
+	s.mu.Unlock()
+	<-r.done
+}
+
+// enabled reports whether any sink wants records of the given level.
+func (s *sinks) enabled(level Level) bool {
+	if !s.active.Load() {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, r := range s.runners {
+		if level >= r.minLevel {
+			return true
+		}
+	}
+	return false
+}
+
+// write passes the record to the sinks, without blocking.
+func (s *sinks) write(rec Record) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, r := range s.runners {
+		if rec.Level < r.minLevel {
+			continue
+		}
+		select {
+		case r.ch <- rec:
+		default:
+			// The sink is falling behind; drop the record.
+		}
+	}
+}
+
+// close stops accepting records and waits for the buffered records
+// to be written, or until the force shutdown deadline.
+func (s *sinks) close(p *shutdown.Process) {
+	s.mu.Lock()
+	runners := s.runners
+	s.runners, s.closed = nil, true
+	s.active.Store(false)
+	for _, r := range runners {
+		close(r.ch)
+	}
+	s.mu.Unlock()
+
+	for _, r := range runners {
+		select {
+		case <-r.done:
+		case <-p.ForceShutdown.Done():
+			return
+		}
+	}
+}
+
+func (r *sinkRunner) run() {
+	defer close(r.done)
+	for rec := range r.ch {
+		r.write(rec)
+	}
+}
+
+func (r *sinkRunner) write(rec Record) {
+	// Don't let a misbehaving sink crash the application.
+	defer func() { _ = recover() }()
+	r.sink.Write(rec)
+}
+
+// AddSink registers a sink to pass log records to,
+// returning a function that removes it again.
+//
+//publicapigen:drop
+func (l *Manager) AddSink(sink Sink, cfg SinkConfig) (remove func()) {
+	r := l.sinks.add(sink, cfg)
+	var once sync.Once
+	return func() {
+		once.Do(func() { l.sinks.remove(r) })
+	}
+}
+
+// Shutdown reports the errors logged since the last error report,
+// and waits for the sinks to write their buffered records.
+//
+//publicapigen:drop
+func (l *Manager) Shutdown(p *shutdown.Process) error {
+	// Wait for all services and all tasks to shut down first,
+	// so the logs they write while shutting down are written too.
+	<-p.ServicesShutdownCompleted.Done()
+	<-p.OutstandingTasks.Done()
+	if l.errors != nil {
+		l.errors.stop()
+		l.reportErrors()
+	}
+	l.sinks.close(p)
+	return nil
+}
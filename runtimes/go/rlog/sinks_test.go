@@ -0,0 +1,110 @@
+package rlog
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+func TestManager_Sinks(t *testing.T) {
+	rt := reqtrack.New(zerolog.New(io.Discard), nil, nil)
+	mgr := NewManager(&config.Static{BundledServices: []string{"orders"}}, &config.Runtime{}, rt, nil, nil)
+
+	var all, errs []Record
+	removeAll := mgr.AddSink(SinkFunc(func(r Record) { all = append(all, r) }), SinkConfig{})
+	removeErrs := mgr.AddSink(SinkFunc(func(r Record) { errs = append(errs, r) }), SinkConfig{MinLevel: LevelError})
+
+	rt.BeginRequest(&model.Request{SvcNum: 1, TraceID: model.TraceID{1}})
+	mgr.With("user", "alice").Info("hello", "count", 3)
+	mgr.Error("boom")
+	rt.FinishRequest(false)
+
+	removeAll()
+	removeErrs()
+	mgr.Error("after removal")
+
+	if len(all) != 2 || len(errs) != 1 {
+		t.Fatalf("got %d records and %d errors, want 2 and 1", len(all), len(errs))
+	}
+	want := []Field{{Key: "user", Value: "alice"}, {Key: "count", Value: 3}}
+	if r := all[0]; r.Level != LevelInfo || r.Message != "hello" || r.Service != "orders" || r.TraceID == "" {
+		t.Errorf("got record %+v", r)
+	} else if len(r.Fields) != 2 || r.Fields[0] != want[0] || r.Fields[1] != want[1] {
+		t.Errorf("got fields %v, want %v", r.Fields, want)
+	}
+	if errs[0].Message != "boom" || errs[0].Level != LevelError {
+		t.Errorf("got error record %+v", errs[0])
+	}
+}
+
+func TestManager_SlowSink(t *testing.T) {
+	rt := reqtrack.New(zerolog.New(io.Discard), nil, nil)
+	mgr := NewManager(nil, nil, rt, nil, nil)
+
+	block := make(chan struct{})
+	var got int
+	mgr.AddSink(SinkFunc(func(r Record) {
+		<-block
+		got++
+	}), SinkConfig{BufferSize: 2})
+
+	// Logging must not block even though the sink does.
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			mgr.Info("msg")
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("logging blocked on slow sink")
+	}
+	close(block)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := &shutdown.Process{
+		ServicesShutdownCompleted: ctx,
+		OutstandingTasks:          ctx,
+		ForceShutdown:             context.Background(),
+	}
+	cancel()
+	if err := mgr.Shutdown(p); err != nil {
+		t.Fatal(err)
+	}
+
+	// At most the message being written and the buffered ones are written.
+	if got == 0 || got > 3 {
+		t.Errorf("got %d records written, want between 1 and 3", got)
+	}
+}
+
+func TestSinks_Panic(t *testing.T) {
+	rt := reqtrack.New(zerolog.New(io.Discard), nil, nil)
+	mgr := NewManager(nil, nil, rt, nil, nil)
+
+	var got []string
+	remove := mgr.AddSink(SinkFunc(func(r Record) {
+		if r.Message == "panic" {
+			panic("sink failure")
+		}
+		got = append(got, r.Message)
+	}), SinkConfig{})
+	mgr.Info("panic")
+	mgr.Info("ok")
+	remove()
+
+	if len(got) != 1 || got[0] != "ok" {
+		t.Errorf("got %v, want [ok]", got)
+	}
+}
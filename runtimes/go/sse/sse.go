@@ -0,0 +1,165 @@
+// Package sse lets APIs stream server-sent events to their clients.
+//
+// An API streams events by taking an *sse.Writer as its last parameter,
+// instead of returning a response:
+//
+//	//encore:api public path=/orders/:id/updates
+//	func Updates(ctx context.Context, id string, w *sse.Writer[*OrderUpdate]) error {
+//		for update := range subscribe(ctx, id) {
+//			if err := w.Send(update); err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	}
+//
+// The events are encoded as JSON, and the generated clients expose
+// the stream as an async iterator of events.
+// See https://html.spec.whatwg.org/multipage/server-sent-events.html.
+package sse
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"encore.dev/beta/errs"
+)
+
+// Event is an event with optional fields besides its data.
+type Event[T any] struct {
+	// ID is the id of the event. If the client reconnects, it's
+	// passed back to the API and reported by Writer.LastEventID.
+	ID string
+
+	// Name is the name of the event. If empty, it's a "message" event.
+	Name string
+
+	// Retry is how long the client should wait before reconnecting
+	// if the connection is lost. If zero, the client decides.
+	Retry time.Duration
+
+	// Data is the event data.
+	Data T
+}
+
+// Writer streams events of type T to the client.
+// It's safe for concurrent use.
+type Writer[T any] struct {
+	w           http.ResponseWriter
+	marshal     func(any) ([]byte, error)
+	lastEventID string
+
+	mu      sync.Mutex
+	started bool
+}
+
+// NewWriter returns a writer streaming events to w.
+//
+//publicapigen:drop
+func NewWriter[T any](w http.ResponseWriter, json jsoniter.API, lastEventID string) *Writer[T] {
+	return &Writer[T]{w: w, marshal: json.Marshal, lastEventID: lastEventID}
+}
+
+// LastEventID returns the id of the last event the client received before
+// reconnecting, so the stream can be resumed. It's "" if the client is
+// connecting for the first time.
+func (w *Writer[T]) LastEventID() string {
+	return w.lastEventID
+}
+
+// Send sends an event with the given data.
+func (w *Writer[T]) Send(data T) error {
+	return w.SendEvent(Event[T]{Data: data})
+}
+
+// SendEvent sends the event.
+func (w *Writer[T]) SendEvent(ev Event[T]) error {
+	data, err := w.marshal(ev.Data)
+	if err != nil {
+		return err
+	}
+	return w.send(ev.ID, ev.Name, ev.Retry, data)
+}
+
+// Start sends the response headers to the client, establishing the stream
+// before the first event is sent. It's done by the first event otherwise.
+func (w *Writer[T]) Start() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.start()
+}
+
+// Started reports whether the stream has been established, after which
+// errors must be reported to the client as events.
+//
+//publicapigen:drop
+func (w *Writer[T]) Started() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.started
+}
+
+// SendError sends err to the client as an "error" event, encoded
+// the same way as the errors returned by other APIs.
+//
+//publicapigen:drop
+func (w *Writer[T]) SendError(err error) error {
+	data, jsonErr := w.marshal(errs.Convert(err))
+	if jsonErr != nil {
+		e := errs.Convert(err).(*errs.Error)
+		data, _ = w.marshal(&errs.Error{Code: e.Code, Message: e.Message})
+	}
+	return w.send("", "error", 0, data)
+}
+
+func (w *Writer[T]) send(id, name string, retry time.Duration, data []byte) error {
+	if strings.ContainsAny(id, "\r\n\x00") || strings.ContainsAny(name, "\r\n") {
+		return errors.New("sse: event id and name must not contain newlines")
+	}
+
+	var b strings.Builder
+	if id != "" {
+		b.WriteString("id: " + id + "\n")
+	}
+	if name != "" {
+		b.WriteString("event: " + name + "\n")
+	}
+	if retry > 0 {
+		b.WriteString("retry: " + strconv.FormatInt(retry.Milliseconds(), 10) + "\n")
+	}
+	// The data is compact JSON, so it's always a single line.
+	b.WriteString("data: ")
+	b.Write(data)
+	b.WriteString("\n\n")
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.start(); err != nil {
+		return err
+	}
+	if _, err := w.w.Write([]byte(b.String())); err != nil {
+		return err
+	}
+	return http.NewResponseController(w.w).Flush()
+}
+
+func (w *Writer[T]) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	h := w.w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	// Stop proxies such as nginx from buffering the events.
+	h.Set("X-Accel-Buffering", "no")
+	w.w.WriteHeader(http.StatusOK)
+	return http.NewResponseController(w.w).Flush()
+}
@@ -0,0 +1,66 @@
+package sse
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	jsoniter "github.com/json-iterator/go"
+
+	"encore.dev/beta/errs"
+)
+
+type tick struct {
+	N int `json:"n"`
+}
+
+func TestWriter(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	w := NewWriter[*tick](rec, jsoniter.ConfigCompatibleWithStandardLibrary, "41")
+	c.Assert(w.LastEventID(), qt.Equals, "41")
+	c.Assert(w.Started(), qt.IsFalse)
+
+	c.Assert(w.Send(&tick{N: 1}), qt.IsNil)
+	c.Assert(w.Started(), qt.IsTrue)
+	c.Assert(rec.Header().Get("Content-Type"), qt.Equals, "text/event-stream")
+	c.Assert(rec.Flushed, qt.IsTrue)
+
+	c.Assert(w.SendEvent(Event[*tick]{ID: "42", Name: "tick", Retry: 3 * time.Second, Data: &tick{N: 2}}), qt.IsNil)
+	c.Assert(w.SendError(errs.B().Code(errs.Unavailable).Msg("shutting down").Err()), qt.IsNil)
+
+	c.Assert(rec.Body.String(), qt.Equals, `data: {"n":1}
+
+id: 42
+event: tick
+retry: 3000
+data: {"n":2}
+
+event: error
+data: {"code":"unavailable","message":"shutting down","details":null}
+
+`)
+}
+
+func TestWriter_InvalidEvent(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	w := NewWriter[*tick](rec, jsoniter.ConfigCompatibleWithStandardLibrary, "")
+
+	err := w.SendEvent(Event[*tick]{Name: "tick\ndata: injected", Data: &tick{}})
+	c.Assert(err, qt.ErrorMatches, ".*must not contain newlines")
+	c.Assert(w.Started(), qt.IsFalse)
+}
+
+func TestWriter_Start(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	w := NewWriter[*tick](rec, jsoniter.ConfigCompatibleWithStandardLibrary, "")
+
+	c.Assert(w.Start(), qt.IsNil)
+	c.Assert(w.Start(), qt.IsNil)
+	c.Assert(rec.Code, qt.Equals, 200)
+	c.Assert(rec.Header().Get("Cache-Control"), qt.Equals, "no-cache")
+	c.Assert(rec.Body.Len(), qt.Equals, 0)
+}
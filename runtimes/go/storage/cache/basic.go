@@ -610,7 +610,7 @@ func (s *basicKeyspace[K, V]) set(ctx context.Context, key K, val V, flag setFla
 		args = append(args, "get")
 	}
 
-	now := time.Now()
+	now := s.clock.Now()
 	exp := s.expiry(now)
 	switch exp {
 	case neverExpire:
@@ -6,11 +6,17 @@ import (
 	"testing"
 	"time"
 
-	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+
+	"encore.dev/appruntime/shared/testsupport"
 )
 
 func TestBasicKeyspace(t *testing.T) {
-	kt := newStringTest(t)
+	testBasicKeyspace(t, miniredisStore)
+}
+
+func testBasicKeyspace(t *testing.T, store testStore) {
+	kt := newStringTest(t, store)
 	ks, ctx := kt.ks, kt.ctx
 
 	kt.Set("one", "alpha")
@@ -55,8 +61,36 @@ func TestBasicKeyspace(t *testing.T) {
 	kt.Missing("one")
 }
 
+func TestBasicKeyspace_Expiry(t *testing.T) {
+	// Expiry follows the runtime clock, which only the in-memory store uses.
+	kt := newStringTest(t, memTestStore)
+	ks, ctx := kt.ks, kt.ctx
+
+	check(ks.With(ExpireIn(time.Minute)).Set(ctx, "one", "alpha"))
+	check(ks.With(ExpireIn(time.Hour)).Set(ctx, "two", "bravo"))
+
+	kt.ts.AdvanceTime(time.Minute - time.Millisecond)
+	kt.Val("one", "alpha")
+	kt.TTL("one", time.Millisecond)
+
+	kt.ts.AdvanceTime(time.Millisecond)
+	kt.Missing("one")
+	kt.Val("two", "bravo")
+
+	// Updating the value with KeepTTL keeps the expiry.
+	check(ks.With(KeepTTL).Set(ctx, "two", "charlie"))
+	kt.TTL("two", time.Hour-time.Minute)
+
+	kt.ts.AdvanceTime(time.Hour)
+	kt.Missing("two")
+}
+
 func TestStringKeyspace(t *testing.T) {
-	kt := newStringTest(t)
+	testStringKeyspace(t, miniredisStore)
+}
+
+func testStringKeyspace(t *testing.T, store testStore) {
+	kt := newStringTest(t, store)
 	ks, ctx := kt.ks, kt.ctx
 
 	kt.Set("one", "alpha")
@@ -94,7 +128,11 @@ func TestStringKeyspace(t *testing.T) {
 }
 
 func TestIntKeyspace(t *testing.T) {
-	ks := newIntTest(t)
+	testIntKeyspace(t, miniredisStore)
+}
+
+func testIntKeyspace(t *testing.T, store testStore) {
+	ks := newIntTest(t, store)
 	ctx := context.Background()
 
 	check(ks.Set(ctx, "one", 1))
@@ -112,7 +150,11 @@ func TestIntKeyspace(t *testing.T) {
 }
 
 func TestFloatKeyspace(t *testing.T) {
-	ks := newFloatTest(t)
+	testFloatKeyspace(t, miniredisStore)
+}
+
+func testFloatKeyspace(t *testing.T, store testStore) {
+	ks := newFloatTest(t, store)
 	ctx := context.Background()
 
 	// We may need to change these to approximate comparisons, but it's
@@ -131,26 +173,26 @@ func TestFloatKeyspace(t *testing.T) {
 	}
 }
 
-func newStringTest(t *testing.T) *stringTester {
-	cluster, srv := newTestCluster(t)
+func newStringTest(t *testing.T, store testStore) *stringTester {
+	cluster, ts := newTestCluster(t, store)
 	ks := NewStringKeyspace[string](cluster, KeyspaceConfig{
 		EncoreInternal_KeyMapper: func(s string) string { return s },
 	})
 
 	ctx := context.Background()
-	return &stringTester{t: t, ctx: ctx, ks: ks, srv: srv}
+	return &stringTester{t: t, ctx: ctx, ks: ks, cl: cluster.cl, ts: ts}
 }
 
-func newIntTest(t *testing.T) *IntKeyspace[string] {
-	cluster, _ := newTestCluster(t)
+func newIntTest(t *testing.T, store testStore) *IntKeyspace[string] {
+	cluster, _ := newTestCluster(t, store)
 	ks := NewIntKeyspace[string](cluster, KeyspaceConfig{
 		EncoreInternal_KeyMapper: func(s string) string { return s },
 	})
 	return ks
 }
 
-func newFloatTest(t *testing.T) *FloatKeyspace[string] {
-	cluster, _ := newTestCluster(t)
+func newFloatTest(t *testing.T, store testStore) *FloatKeyspace[string] {
+	cluster, _ := newTestCluster(t, store)
 	ks := NewFloatKeyspace[string](cluster, KeyspaceConfig{
 		EncoreInternal_KeyMapper: func(s string) string { return s },
 	})
@@ -161,7 +203,8 @@ type stringTester struct {
 	t   *testing.T
 	ctx context.Context
 	ks  *StringKeyspace[string]
-	srv *miniredis.Miniredis
+	cl  *redis.Client
+	ts  *testsupport.Manager
 }
 
 func (t *stringTester) Set(key, val string) {
@@ -186,10 +229,10 @@ func (t *stringTester) Val(key, want string) {
 
 func (t *stringTester) TTL(key string, want time.Duration) {
 	t.t.Helper()
-	if !t.srv.Exists(key) {
+	if n := must(t.cl.Exists(t.ctx, key).Result()); n != 1 {
 		t.t.Errorf("key %s: key not in cache", key)
 	}
-	got := t.srv.TTL(key)
+	got := must(t.cl.PTTL(t.ctx, key).Result())
 	if got != want {
 		t.t.Errorf("key %s: got ttl %v, want %v", key, got, want)
 	}
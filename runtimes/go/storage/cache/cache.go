@@ -75,6 +75,25 @@ type Cluster struct {
 	cl  *redis.Client
 }
 
+// NewClusterInternal declares a new cache cluster using the given manager.
+//
+//publicapigen:drop
+func NewClusterInternal(mgr *Manager, name string, cfg ClusterConfig) *Cluster {
+	return &Cluster{
+		cfg: cfg,
+		mgr: mgr,
+		cl:  mgr.getClient(name),
+	}
+}
+
+// RedisClient returns the Redis client for the cluster,
+// for use by other Encore packages building on the cluster.
+//
+//publicapigen:drop
+func (c *Cluster) RedisClient() *redis.Client {
+	return c.cl
+}
+
 // KeyspaceConfig specifies the configuration options for a cache keyspace.
 type KeyspaceConfig struct {
 	// KeyPattern is a string literal representing the
@@ -3,32 +3,58 @@ package cache
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/go-redis/redis/v8"
 	"github.com/rs/zerolog"
 
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/clock"
 	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
 )
 
-func newTestCluster(t *testing.T) (*Cluster, *miniredis.Miniredis) {
-	srv := miniredis.RunT(t)
-	redisClient := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+// testStore selects the backend a test cluster is created with.
+type testStore int
 
+const (
+	// miniredisStore backs the cluster with a miniredis server.
+	miniredisStore testStore = iota
+	// memTestStore backs the cluster with the in-memory store,
+	// whose expiry follows the runtime clock.
+	memTestStore
+)
+
+// newTestCluster returns a cluster backed by the given store,
+// and the test support manager controlling the time.
+func newTestCluster(t *testing.T, store testStore) (*Cluster, *testsupport.Manager) {
 	rt := reqtrack.New(zerolog.New(os.Stdout), nil, nil)
+	ts := testsupport.NewManager(&config.Static{Testing: true}, rt, zerolog.Nop())
+	ts.SetTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk := clock.New(&config.Static{Testing: true}, ts)
+
+	var redisClient *redis.Client
+	if store == memTestStore {
+		redisClient = newMemStore(clk.Now).newClient()
+	} else {
+		srv := miniredis.RunT(t)
+		redisClient = redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	}
+
 	mgr := &Manager{
 		static: &config.Static{
 			// We're testing the "production mode" of the cache, not the test mode.
 			Testing: false,
 		},
-		rt: rt,
+		rt:    rt,
+		clock: clk,
 	}
 	cluster := &Cluster{
 		mgr: mgr,
 		cl:  redisClient,
 	}
-	return cluster, srv
+	return cluster, ts
 }
 
 func must[T any](val T, err error) T {
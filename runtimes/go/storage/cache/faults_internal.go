@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/faults"
+)
+
+// faultHook injects the configured faults into the commands sent to a cache cluster.
+type faultHook struct {
+	faults  *faults.Injector
+	cluster string
+}
+
+func (h *faultHook) inject(ctx context.Context) error {
+	return h.faults.Inject(ctx, faults.Op{Target: config.FaultCache, Resource: h.cluster}).Err
+}
+
+func (h *faultHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return ctx, h.inject(ctx)
+}
+
+func (h *faultHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	return nil
+}
+
+func (h *faultHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return ctx, h.inject(ctx)
+}
+
+func (h *faultHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	return nil
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"reflect"
 	"testing"
-
-	"github.com/alicebob/miniredis/v2"
 )
 
 func TestListKeyspace(t *testing.T) {
-	kt := newListTest(t)
+	testListKeyspace(t, miniredisStore)
+}
+
+func testListKeyspace(t *testing.T, store testStore) {
+	kt := newListTest(t, store)
 	ks, ctx := kt.ks, kt.ctx
 
 	if got, want := kt.PushLeft("one", "a"), int64(1); got != want {
@@ -71,20 +73,19 @@ func TestListKeyspace(t *testing.T) {
 	kt.Val("one", "b", "a", "e", "f", "c", "d")
 }
 
-func newListTest(t *testing.T) *listTester {
-	cluster, srv := newTestCluster(t)
+func newListTest(t *testing.T, store testStore) *listTester {
+	cluster, _ := newTestCluster(t, store)
 	ks := NewListKeyspace[string, string](cluster, KeyspaceConfig{
 		EncoreInternal_KeyMapper: func(s string) string { return s },
 	})
 	ctx := context.Background()
-	return &listTester{t: t, ctx: ctx, ks: ks, srv: srv}
+	return &listTester{t: t, ctx: ctx, ks: ks}
 }
 
 type listTester struct {
 	t   *testing.T
 	ctx context.Context
 	ks  *ListKeyspace[string, string]
-	srv *miniredis.Miniredis
 }
 
 func (t *listTester) PushLeft(key string, val ...string) int64 {
@@ -20,10 +20,13 @@ import (
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/stack"
 	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/clock"
+	"encore.dev/appruntime/shared/faults"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/syncutil"
 	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/metrics"
 )
 
 // Manager manages cache clients.
@@ -32,22 +35,40 @@ type Manager struct {
 	runtime *config.Runtime
 	rt      *reqtrack.RequestTracker
 	ts      *testsupport.Manager
+	clock   *clock.Clock
 	json    jsoniter.API
+	metrics *metrics.Registry
+	faults  *faults.Injector // nil if no fault injection is configured
 
 	initTestSrv syncutil.Once
 	testSrv     *miniredis.Miniredis
 
+	initMemStore sync.Once
+	memStore     *memStore
+
 	clientMu sync.RWMutex
 	clients  map[string]*redis.Client
 }
 
-func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsupport.Manager, json jsoniter.API) *Manager {
+func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsupport.Manager, clock *clock.Clock, json jsoniter.API, reg *metrics.Registry) *Manager {
+	var faultInjector *faults.Injector
+	if runtime != nil {
+		var err error
+		faultInjector, err = faults.New(runtime.FaultInjection, rt)
+		if err != nil {
+			panic(fmt.Errorf("error loading fault injection rules: %w", err))
+		}
+	}
+
 	return &Manager{
 		static:  static,
 		runtime: runtime,
 		rt:      rt,
 		ts:      ts,
+		clock:   clock,
 		json:    json,
+		metrics: reg,
+		faults:  faultInjector,
 		clients: make(map[string]*redis.Client),
 	}
 }
@@ -69,12 +90,21 @@ func (mgr *Manager) getClient(clusterName string) *redis.Client {
 		return cl
 	}
 
-	// Are we in a test or running in Encore Cloud? If so, use the redismock library.
-	if mgr.static.Testing || mgr.runningInEncoreCloud() {
+	// Are we in a test? If so, use the in-memory store.
+	if mgr.static.Testing {
+		cl := mgr.newMemStoreClient()
+		mgr.addFaultHook(cl, clusterName)
+		mgr.clients[clusterName] = cl
+		return cl
+	}
+
+	// Are we running in Encore Cloud? If so, use the redismock library.
+	if mgr.runningInEncoreCloud() {
 		cl, err := mgr.newMiniredisClient()
 		if err != nil {
 			panic(fmt.Sprintf("cache: unable to start redis mock: %v", err))
 		}
+		mgr.addFaultHook(cl, clusterName)
 		mgr.clients[clusterName] = cl
 		return cl
 	}
@@ -85,6 +115,7 @@ func (mgr *Manager) getClient(clusterName string) *redis.Client {
 			if err != nil {
 				panic(fmt.Sprintf("cache: unable to create redis client: %v", err))
 			}
+			mgr.addFaultHook(cl, clusterName)
 			mgr.clients[clusterName] = cl
 			return cl
 		}
@@ -93,6 +124,24 @@ func (mgr *Manager) getClient(clusterName string) *redis.Client {
 	return newNoopClient()
 }
 
+// addFaultHook injects the configured faults into the commands cl sends to the cluster.
+func (mgr *Manager) addFaultHook(cl *redis.Client, clusterName string) {
+	if mgr.faults != nil {
+		cl.AddHook(&faultHook{faults: mgr.faults, cluster: clusterName})
+	}
+}
+
+// RedisClient returns the client for the given cache cluster,
+// for use by other parts of the runtime.
+//
+// Keys written by the caller should use the reserved "__encore" prefix
+// to avoid conflicting with keyspaces defined by the application.
+//
+//publicapigen:drop
+func (mgr *Manager) RedisClient(clusterName string) *redis.Client {
+	return mgr.getClient(clusterName)
+}
+
 func (mgr *Manager) runningInEncoreCloud() bool {
 	if mgr.runtime != nil && mgr.runtime.EnvCloud == "encore" {
 		return true
@@ -141,11 +190,10 @@ func (mgr *Manager) newMiniredisClient() (*redis.Client, error) {
 		var err error
 		mgr.testSrv, err = miniredis.Run()
 
-		// Periodically clean up cache keys if running in Encore Cloud.
-		if err == nil && mgr.runningInEncoreCloud() {
+		// Periodically clean up cache keys.
+		if err == nil {
 			go miniredisCleanup(mgr.testSrv, 15*time.Second, 100)
 		}
-
 		return err
 	})
 	if err != nil {
@@ -167,6 +215,15 @@ func (mgr *Manager) newMiniredisClient() (*redis.Client, error) {
 	return cl, err
 }
 
+// newMemStoreClient returns a client for the in-memory store used in tests.
+// All clusters share the same store, as keys are already isolated per test.
+func (mgr *Manager) newMemStoreClient() *redis.Client {
+	mgr.initMemStore.Do(func() {
+		mgr.memStore = newMemStore(mgr.clock.Now)
+	})
+	return mgr.memStore.newClient()
+}
+
 func (mgr *Manager) Shutdown(p *shutdown.Process) error {
 	// The redis client does not have the concept of graceful shutdown,
 	// so wait for user code to shut down first.
@@ -208,6 +265,8 @@ func newClient[K, V any](cluster *Cluster, cfg KeyspaceConfig,
 
 	return &client[K, V]{
 		rt:        cluster.mgr.rt,
+		clock:     cluster.mgr.clock,
+		metrics:   cluster.mgr.metrics,
 		redis:     cluster.cl,
 		cfg:       cfg,
 		expiry:    defaultExpiry,
@@ -219,6 +278,8 @@ func newClient[K, V any](cluster *Cluster, cfg KeyspaceConfig,
 
 type client[K, V any] struct {
 	rt        *reqtrack.RequestTracker
+	clock     *clock.Clock
+	metrics   *metrics.Registry
 	redis     *redis.Client
 	cfg       KeyspaceConfig
 	expiry    ExpiryFunc
@@ -294,7 +355,7 @@ func (s *client[K, V]) valOrNil(res string, err error) (*V, error) {
 }
 
 func (s *client[K, V]) expiryCmd(ctx context.Context, key string) *redis.BoolCmd {
-	now := time.Now()
+	now := s.clock.Now()
 	expTime := s.expiry(now)
 	if expTime == keepTTL {
 		return nil
@@ -307,7 +368,7 @@ func (s *client[K, V]) expiryCmd(ctx context.Context, key string) *redis.BoolCmd
 }
 
 func (s *client[K, V]) expiryDur() time.Duration {
-	now := time.Now()
+	now := s.clock.Now()
 	expTime := s.expiry(now)
 
 	var exp time.Duration
@@ -324,8 +385,15 @@ func (s *client[K, V]) expiryDur() time.Duration {
 
 func (c *client[K, V]) doTrace(op string, write bool, keys ...string) func(error) {
 	eventID := c.traceStart(op, write, keys...)
+	infraOp := c.metrics.StartInfraOp("cache", string(c.cfg.KeyPattern), op)
 	return func(err error) {
 		c.traceEnd(eventID, err)
+
+		// Misses and conflicts are expected outcomes rather than failures.
+		if errors.Is(err, Miss) || errors.Is(err, KeyExists) {
+			err = nil
+		}
+		infraOp.End(err)
 	}
 }
 
@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ScriptFunc is the Go equivalent of a Lua script, run by the in-memory store
+// used in tests. It calls commands using call, like redis.call in Lua:
+// replies are returned as int64, string, []any, or nil for null replies,
+// and error replies are returned as errors.
+//
+// It returns the reply of the script, which must be an int64, a string or nil.
+//
+//publicapigen:drop
+type ScriptFunc func(call func(args ...string) (any, error), keys, args []string) (any, error)
+
+var (
+	memScriptsMu sync.RWMutex
+	memScripts   = make(map[string]ScriptFunc) // by SHA1 of the script's source
+)
+
+// NewScriptInternal returns a Lua script for use by other Encore packages building on
+// cache clusters. As the in-memory store used in tests can't run Lua, fn must implement
+// the same operations as the script.
+//
+//publicapigen:drop
+func NewScriptInternal(src string, fn ScriptFunc) *redis.Script {
+	script := redis.NewScript(src)
+	memScriptsMu.Lock()
+	defer memScriptsMu.Unlock()
+	memScripts[script.Hash()] = fn
+	return script
+}
+
+var errNoScript = errors.New("NOSCRIPT No matching script. Please use EVAL.")
+
+// cmdEval runs a script registered with NewScriptInternal. If bySHA is true the
+// script is identified by its SHA1 as with EVALSHA, and by its source otherwise.
+func cmdEval(bySHA bool) func(s *memStore, w respWriter, args []string) error {
+	return func(s *memStore, w respWriter, args []string) error {
+		sha := strings.ToLower(args[0])
+		if !bySHA {
+			h := sha1.Sum([]byte(args[0]))
+			sha = hex.EncodeToString(h[:])
+		}
+		memScriptsMu.RLock()
+		fn, ok := memScripts[sha]
+		memScriptsMu.RUnlock()
+		if !ok && bySHA {
+			return errNoScript
+		} else if !ok {
+			return errNotSupported
+		}
+
+		numKeys, err := strconv.Atoi(args[1])
+		if err != nil || numKeys < 0 || numKeys > len(args)-2 {
+			return errors.New("ERR Number of keys can't be greater than number of args")
+		}
+		keys, scriptArgs := args[2:2+numKeys], args[2+numKeys:]
+
+		call := func(args ...string) (any, error) {
+			var buf bytes.Buffer
+			s.exec(respWriter{&buf}, args)
+			return readReply(bufio.NewReader(&buf))
+		}
+		res, err := fn(call, keys, scriptArgs)
+		if err != nil {
+			return err
+		}
+		switch res := res.(type) {
+		case nil:
+			w.null()
+		case int64:
+			w.int(res)
+		case string:
+			w.bulk(res)
+		default:
+			return fmt.Errorf("ERR unsupported script reply type %T", res)
+		}
+		return nil
+	}
+}
+
+// readReply reads a reply in the Redis protocol written by respWriter.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("cache: invalid reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, errors.New(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		} else if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return string(data[:size]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		vals := make([]any, n)
+		for i := range vals {
+			if vals[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return vals, nil
+	default:
+		return nil, fmt.Errorf("cache: invalid reply %q", line)
+	}
+}
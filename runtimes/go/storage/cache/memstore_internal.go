@@ -0,0 +1,1182 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	mathrand "math/rand" // nosemgrep
+	"net"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// memStore is an in-memory implementation of the subset of Redis used by
+// the cache keyspaces, used when running tests.
+//
+// Clients talk to it using the Redis protocol over connections that process
+// commands as they are written, in the goroutine writing them. This lets keys
+// expire according to now as seen by that goroutine, which is the time of
+// the test making the call.
+type memStore struct {
+	now func() time.Time
+
+	mu   sync.Mutex
+	keys map[string]*memValue
+}
+
+type memKind uint8
+
+const (
+	memString memKind = iota + 1
+	memList
+	memSet
+)
+
+type memValue struct {
+	kind    memKind
+	str     string
+	list    []string
+	set     map[string]struct{}
+	expires time.Time // zero if the key doesn't expire
+}
+
+func newMemStore(now func() time.Time) *memStore {
+	return &memStore{now: now, keys: make(map[string]*memValue)}
+}
+
+// newClient returns a Redis client using the store.
+func (s *memStore) newClient() *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Network: "memory",
+		Addr:    "memory",
+		Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return &memConn{store: s}, nil
+		},
+		PoolSize: runtime.GOMAXPROCS(0) * 10,
+	})
+}
+
+var (
+	errWrongType    = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	errNotInteger   = errors.New("ERR value is not an integer or out of range")
+	errNotFloat     = errors.New("ERR value is not a valid float")
+	errSyntax       = errors.New("ERR syntax error")
+	errNoSuchKey    = errors.New("ERR no such key")
+	errOutOfRange   = errors.New("ERR index out of range")
+	errExecAbort    = errors.New("EXECABORT Transaction discarded because of previous errors.")
+	errNestedMulti  = errors.New("ERR MULTI calls can not be nested")
+	errExecNoMulti  = errors.New("ERR EXEC without MULTI")
+	errNotSupported = errors.New("ERR command not supported by the in-memory test cache")
+)
+
+// memConn is a connection to a memStore.
+// It is not safe for concurrent use, like the connections of the Redis client.
+type memConn struct {
+	store *memStore
+	in    bytes.Buffer // data written and not yet processed
+	out   bytes.Buffer // replies not yet read
+
+	multi   bool       // whether a transaction has been started with MULTI
+	queued  [][]string // the commands queued in the transaction
+	aborted bool       // whether the transaction is to be aborted
+}
+
+func (c *memConn) Write(b []byte) (int, error) {
+	c.in.Write(b)
+	for {
+		args, ok, err := readCommand(&c.in)
+		if err != nil {
+			return 0, err
+		} else if !ok {
+			return len(b), nil
+		}
+		c.process(args)
+	}
+}
+
+func (c *memConn) Read(b []byte) (int, error) {
+	if c.out.Len() == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return c.out.Read(b)
+}
+
+func (c *memConn) Close() error                       { return nil }
+func (c *memConn) LocalAddr() net.Addr                { return memAddr{} }
+func (c *memConn) RemoteAddr() net.Addr               { return memAddr{} }
+func (c *memConn) SetDeadline(t time.Time) error      { return nil }
+func (c *memConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *memConn) SetWriteDeadline(t time.Time) error { return nil }
+
+type memAddr struct{}
+
+func (memAddr) Network() string { return "memory" }
+func (memAddr) String() string  { return "memory" }
+
+// readCommand reads a command in the Redis protocol from buf. If buf doesn't hold
+// a complete command yet it returns false and leaves buf as is.
+func readCommand(buf *bytes.Buffer) (args []string, ok bool, err error) {
+	r := bufio.NewReader(bytes.NewReader(buf.Bytes()))
+	read := 0
+	readLine := func() (string, bool) {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", false
+		}
+		read += len(line)
+		return strings.TrimSuffix(line, "\r\n"), true
+	}
+
+	line, ok := readLine()
+	if !ok {
+		return nil, false, nil
+	} else if !strings.HasPrefix(line, "*") {
+		return nil, false, fmt.Errorf("cache: invalid command %q", line)
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, false, fmt.Errorf("cache: invalid command %q", line)
+	}
+	for i := 0; i < n; i++ {
+		line, ok := readLine()
+		if !ok {
+			return nil, false, nil
+		} else if !strings.HasPrefix(line, "$") {
+			return nil, false, fmt.Errorf("cache: invalid argument %q", line)
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, false, fmt.Errorf("cache: invalid argument %q", line)
+		}
+		arg := make([]byte, size+2)
+		if _, err := io.ReadFull(r, arg); err != nil {
+			return nil, false, nil
+		}
+		read += len(arg)
+		args = append(args, string(arg[:size]))
+	}
+	buf.Next(read)
+	return args, true, nil
+}
+
+func (c *memConn) process(args []string) {
+	if len(args) == 0 {
+		return
+	}
+	w := respWriter{&c.out}
+	switch name := strings.ToLower(args[0]); {
+	case name == "multi":
+		if c.multi {
+			w.err(errNestedMulti)
+			return
+		}
+		c.multi, c.queued, c.aborted = true, nil, false
+		w.status("OK")
+
+	case name == "discard":
+		c.multi, c.queued = false, nil
+		w.status("OK")
+
+	case name == "exec":
+		if !c.multi {
+			w.err(errExecNoMulti)
+			return
+		}
+		queued, aborted := c.queued, c.aborted
+		c.multi, c.queued = false, nil
+		if aborted {
+			w.err(errExecAbort)
+			return
+		}
+
+		c.store.mu.Lock()
+		defer c.store.mu.Unlock()
+		w.arrayLen(len(queued))
+		for _, args := range queued {
+			c.store.exec(w, args)
+		}
+
+	case c.multi:
+		if _, ok := memCommands[name]; !ok {
+			c.aborted = true
+			w.err(fmt.Errorf("ERR unknown command '%s'", args[0]))
+			return
+		}
+		c.queued = append(c.queued, args)
+		w.status("QUEUED")
+
+	default:
+		c.store.mu.Lock()
+		defer c.store.mu.Unlock()
+		c.store.exec(w, args)
+	}
+}
+
+// exec executes the command and writes the reply to w.
+// s.mu must be held.
+func (s *memStore) exec(w respWriter, args []string) {
+	cmd, ok := memCommands[strings.ToLower(args[0])]
+	if !ok {
+		w.err(fmt.Errorf("ERR unknown command '%s'", args[0]))
+		return
+	}
+	if len(args)-1 < cmd.minArgs || (cmd.maxArgs >= 0 && len(args)-1 > cmd.maxArgs) {
+		w.err(fmt.Errorf("ERR wrong number of arguments for '%s' command", strings.ToLower(args[0])))
+		return
+	}
+	if err := cmd.fn(s, w, args[1:]); err != nil {
+		w.err(err)
+	}
+}
+
+type memCommand struct {
+	minArgs, maxArgs int // maxArgs is -1 if unbounded
+	fn               func(s *memStore, w respWriter, args []string) error
+}
+
+var memCommands map[string]memCommand
+
+func init() {
+	memCommands = map[string]memCommand{
+		"ping":   {0, 1, cmdPing},
+		"select": {1, 1, func(s *memStore, w respWriter, args []string) error { w.status("OK"); return nil }},
+
+		// Scripting, for scripts registered with NewScriptInternal
+		"eval":    {2, -1, cmdEval(false)},
+		"evalsha": {2, -1, cmdEval(true)},
+
+		// Keys
+		"del":       {1, -1, cmdDel},
+		"exists":    {1, -1, cmdExists},
+		"expire":    {2, 2, cmdExpire(time.Second, false)},
+		"pexpire":   {2, 2, cmdExpire(time.Millisecond, false)},
+		"expireat":  {2, 2, cmdExpire(time.Second, true)},
+		"pexpireat": {2, 2, cmdExpire(time.Millisecond, true)},
+		"persist":   {1, 1, cmdPersist},
+		"ttl":       {1, 1, cmdTTL(time.Second)},
+		"pttl":      {1, 1, cmdTTL(time.Millisecond)},
+
+		// Strings
+		"get":         {1, 1, cmdGet},
+		"set":         {2, -1, cmdSet},
+		"getdel":      {1, 1, cmdGetDel},
+		"append":      {2, 2, cmdAppend},
+		"getrange":    {3, 3, cmdGetRange},
+		"setrange":    {3, 3, cmdSetRange},
+		"strlen":      {1, 1, cmdStrLen},
+		"incr":        {1, 1, cmdIncrBy(1, false)},
+		"decr":        {1, 1, cmdIncrBy(-1, false)},
+		"incrby":      {2, 2, cmdIncrBy(1, true)},
+		"decrby":      {2, 2, cmdIncrBy(-1, true)},
+		"incrbyfloat": {2, 2, cmdIncrByFloat},
+
+		// Lists
+		"lpush":   {2, -1, cmdPush(true)},
+		"rpush":   {2, -1, cmdPush(false)},
+		"lpop":    {1, 1, cmdPop(true)},
+		"rpop":    {1, 1, cmdPop(false)},
+		"lindex":  {2, 2, cmdLIndex},
+		"linsert": {4, 4, cmdLInsert},
+		"llen":    {1, 1, cmdLLen},
+		"lmove":   {4, 4, cmdLMove},
+		"lrange":  {3, 3, cmdLRange},
+		"lrem":    {3, 3, cmdLRem},
+		"lset":    {3, 3, cmdLSet},
+		"ltrim":   {3, 3, cmdLTrim},
+
+		// Sets
+		"sadd":        {2, -1, cmdSAdd},
+		"srem":        {2, -1, cmdSRem},
+		"scard":       {1, 1, cmdSCard},
+		"sismember":   {2, 2, cmdSIsMember},
+		"smembers":    {1, 1, cmdSMembers},
+		"spop":        {1, 2, cmdSPop},
+		"srandmember": {1, 2, cmdSRandMember},
+		"smove":       {3, 3, cmdSMove},
+		"sdiff":       {1, -1, cmdSetOp(setDiff, false)},
+		"sinter":      {1, -1, cmdSetOp(setInter, false)},
+		"sunion":      {1, -1, cmdSetOp(setUnion, false)},
+		"sdiffstore":  {2, -1, cmdSetOp(setDiff, true)},
+		"sinterstore": {2, -1, cmdSetOp(setInter, true)},
+		"sunionstore": {2, -1, cmdSetOp(setUnion, true)},
+	}
+}
+
+// lookup returns the value of the key, or nil if it doesn't exist.
+// It returns errWrongType if the key holds a value of a different kind.
+func (s *memStore) lookup(key string, kind memKind) (*memValue, error) {
+	v, ok := s.keys[key]
+	if !ok {
+		return nil, nil
+	}
+	if !v.expires.IsZero() && !s.now().Before(v.expires) {
+		delete(s.keys, key)
+		return nil, nil
+	}
+	if v.kind != kind {
+		return nil, errWrongType
+	}
+	return v, nil
+}
+
+// exists reports whether the key exists, regardless of its kind.
+func (s *memStore) exists(key string) bool {
+	v, ok := s.keys[key]
+	if ok && !v.expires.IsZero() && !s.now().Before(v.expires) {
+		delete(s.keys, key)
+		return false
+	}
+	return ok
+}
+
+// lookupOrCreate is like lookup but creates the key if it doesn't exist.
+func (s *memStore) lookupOrCreate(key string, kind memKind) (*memValue, error) {
+	v, err := s.lookup(key, kind)
+	if err != nil || v != nil {
+		return v, err
+	}
+	v = &memValue{kind: kind}
+	if kind == memSet {
+		v.set = make(map[string]struct{})
+	}
+	s.keys[key] = v
+	return v, nil
+}
+
+// deleteIfEmpty deletes the key if it's an empty list or set,
+// as Redis doesn't keep empty lists and sets.
+func (s *memStore) deleteIfEmpty(key string, v *memValue) {
+	if (v.kind == memList && len(v.list) == 0) || (v.kind == memSet && len(v.set) == 0) {
+		delete(s.keys, key)
+	}
+}
+
+func cmdPing(s *memStore, w respWriter, args []string) error {
+	if len(args) == 1 {
+		w.bulk(args[0])
+	} else {
+		w.status("PONG")
+	}
+	return nil
+}
+
+func cmdDel(s *memStore, w respWriter, args []string) error {
+	n := 0
+	for _, key := range args {
+		if s.exists(key) {
+			delete(s.keys, key)
+			n++
+		}
+	}
+	w.int(int64(n))
+	return nil
+}
+
+func cmdExists(s *memStore, w respWriter, args []string) error {
+	n := 0
+	for _, key := range args {
+		if s.exists(key) {
+			n++
+		}
+	}
+	w.int(int64(n))
+	return nil
+}
+
+func cmdExpire(unit time.Duration, absolute bool) func(s *memStore, w respWriter, args []string) error {
+	return func(s *memStore, w respWriter, args []string) error {
+		n, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return errNotInteger
+		}
+		if !s.exists(args[0]) {
+			w.int(0)
+			return nil
+		}
+		var expires time.Time
+		if absolute {
+			expires = time.UnixMilli(0).Add(time.Duration(n) * unit)
+		} else {
+			expires = s.now().Add(time.Duration(n) * unit)
+		}
+		if !s.now().Before(expires) {
+			delete(s.keys, args[0])
+		} else {
+			s.keys[args[0]].expires = expires
+		}
+		w.int(1)
+		return nil
+	}
+}
+
+func cmdPersist(s *memStore, w respWriter, args []string) error {
+	if !s.exists(args[0]) || s.keys[args[0]].expires.IsZero() {
+		w.int(0)
+		return nil
+	}
+	s.keys[args[0]].expires = time.Time{}
+	w.int(1)
+	return nil
+}
+
+func cmdTTL(unit time.Duration) func(s *memStore, w respWriter, args []string) error {
+	return func(s *memStore, w respWriter, args []string) error {
+		switch {
+		case !s.exists(args[0]):
+			w.int(-2)
+		case s.keys[args[0]].expires.IsZero():
+			w.int(-1)
+		default:
+			w.int(int64(s.keys[args[0]].expires.Sub(s.now()) / unit))
+		}
+		return nil
+	}
+}
+
+func cmdGet(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookup(args[0], memString)
+	if err != nil {
+		return err
+	} else if v == nil {
+		w.null()
+	} else {
+		w.bulk(v.str)
+	}
+	return nil
+}
+
+func cmdSet(s *memStore, w respWriter, args []string) error {
+	key, val := args[0], args[1]
+	var nx, xx, get, keepTTL bool
+	var expires time.Time
+	for i := 2; i < len(args); i++ {
+		switch opt := strings.ToLower(args[i]); opt {
+		case "nx":
+			nx = true
+		case "xx":
+			xx = true
+		case "get":
+			get = true
+		case "keepttl":
+			keepTTL = true
+		case "ex", "px", "exat", "pxat":
+			if i+1 >= len(args) {
+				return errSyntax
+			}
+			i++
+			n, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return errNotInteger
+			}
+			switch opt {
+			case "ex":
+				expires = s.now().Add(time.Duration(n) * time.Second)
+			case "px":
+				expires = s.now().Add(time.Duration(n) * time.Millisecond)
+			case "exat":
+				expires = time.Unix(n, 0)
+			case "pxat":
+				expires = time.UnixMilli(n)
+			}
+		default:
+			return errSyntax
+		}
+	}
+	if nx && xx {
+		return errSyntax
+	}
+
+	prev, err := s.lookup(key, memString)
+	if err != nil && (get || !s.exists(key)) {
+		return err
+	}
+	exists := s.exists(key)
+	if (nx && exists) || (xx && !exists) {
+		if get && prev != nil {
+			w.bulk(prev.str)
+		} else {
+			w.null()
+		}
+		return nil
+	}
+
+	next := &memValue{kind: memString, str: val, expires: expires}
+	if keepTTL && exists {
+		next.expires = s.keys[key].expires
+	}
+	if !next.expires.IsZero() && !s.now().Before(next.expires) {
+		delete(s.keys, key)
+	} else {
+		s.keys[key] = next
+	}
+
+	switch {
+	case !get:
+		w.status("OK")
+	case prev != nil:
+		w.bulk(prev.str)
+	default:
+		w.null()
+	}
+	return nil
+}
+
+func cmdGetDel(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookup(args[0], memString)
+	if err != nil {
+		return err
+	} else if v == nil {
+		w.null()
+		return nil
+	}
+	delete(s.keys, args[0])
+	w.bulk(v.str)
+	return nil
+}
+
+func cmdAppend(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookupOrCreate(args[0], memString)
+	if err != nil {
+		return err
+	}
+	v.str += args[1]
+	w.int(int64(len(v.str)))
+	return nil
+}
+
+// rangeIndices converts the inclusive, possibly negative, start and stop
+// indices to a half-open range within a sequence of length n.
+func rangeIndices(start, stop int64, n int) (from, to int) {
+	if start < 0 {
+		start += int64(n)
+	}
+	if stop < 0 {
+		stop += int64(n)
+	}
+	start = max(start, 0)
+	stop = min(stop, int64(n)-1)
+	if start > stop {
+		return 0, 0
+	}
+	return int(start), int(stop) + 1
+}
+
+func parseInts(args ...string) ([]int64, error) {
+	ints := make([]int64, len(args))
+	for i, arg := range args {
+		n, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return nil, errNotInteger
+		}
+		ints[i] = n
+	}
+	return ints, nil
+}
+
+func cmdGetRange(s *memStore, w respWriter, args []string) error {
+	idx, err := parseInts(args[1], args[2])
+	if err != nil {
+		return err
+	}
+	v, err := s.lookup(args[0], memString)
+	if err != nil {
+		return err
+	} else if v == nil {
+		w.bulk("")
+		return nil
+	}
+	from, to := rangeIndices(idx[0], idx[1], len(v.str))
+	w.bulk(v.str[from:to])
+	return nil
+}
+
+func cmdSetRange(s *memStore, w respWriter, args []string) error {
+	idx, err := parseInts(args[1])
+	if err != nil {
+		return err
+	} else if idx[0] < 0 {
+		return errors.New("ERR offset is out of range")
+	}
+	offset, val := int(idx[0]), args[2]
+
+	v, err := s.lookup(args[0], memString)
+	if err != nil {
+		return err
+	} else if v == nil && val == "" {
+		w.int(0)
+		return nil
+	} else if v == nil {
+		v, _ = s.lookupOrCreate(args[0], memString)
+	}
+
+	b := []byte(v.str)
+	if len(b) < offset+len(val) {
+		b = append(b, make([]byte, offset+len(val)-len(b))...)
+	}
+	copy(b[offset:], val)
+	v.str = string(b)
+	w.int(int64(len(v.str)))
+	return nil
+}
+
+func cmdStrLen(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookup(args[0], memString)
+	if err != nil {
+		return err
+	} else if v == nil {
+		w.int(0)
+	} else {
+		w.int(int64(len(v.str)))
+	}
+	return nil
+}
+
+func cmdIncrBy(sign int64, hasArg bool) func(s *memStore, w respWriter, args []string) error {
+	return func(s *memStore, w respWriter, args []string) error {
+		delta := int64(1)
+		if hasArg {
+			n, err := parseInts(args[1])
+			if err != nil {
+				return err
+			}
+			delta = n[0]
+		}
+		v, err := s.lookupOrCreate(args[0], memString)
+		if err != nil {
+			return err
+		}
+		var cur int64
+		if v.str != "" {
+			if cur, err = strconv.ParseInt(v.str, 10, 64); err != nil {
+				return errNotInteger
+			}
+		}
+		cur += sign * delta
+		v.str = strconv.FormatInt(cur, 10)
+		w.int(cur)
+		return nil
+	}
+}
+
+func cmdIncrByFloat(s *memStore, w respWriter, args []string) error {
+	delta, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return errNotFloat
+	}
+	v, err := s.lookupOrCreate(args[0], memString)
+	if err != nil {
+		return err
+	}
+	var cur float64
+	if v.str != "" {
+		if cur, err = strconv.ParseFloat(v.str, 64); err != nil {
+			return errNotFloat
+		}
+	}
+	cur += delta
+	v.str = strconv.FormatFloat(cur, 'f', -1, 64)
+	w.bulk(v.str)
+	return nil
+}
+
+func cmdPush(left bool) func(s *memStore, w respWriter, args []string) error {
+	return func(s *memStore, w respWriter, args []string) error {
+		v, err := s.lookupOrCreate(args[0], memList)
+		if err != nil {
+			return err
+		}
+		for _, val := range args[1:] {
+			if left {
+				v.list = append([]string{val}, v.list...)
+			} else {
+				v.list = append(v.list, val)
+			}
+		}
+		w.int(int64(len(v.list)))
+		return nil
+	}
+}
+
+func cmdPop(left bool) func(s *memStore, w respWriter, args []string) error {
+	return func(s *memStore, w respWriter, args []string) error {
+		v, err := s.lookup(args[0], memList)
+		if err != nil {
+			return err
+		} else if v == nil {
+			w.null()
+			return nil
+		}
+		var val string
+		if left {
+			val, v.list = v.list[0], v.list[1:]
+		} else {
+			val, v.list = v.list[len(v.list)-1], v.list[:len(v.list)-1]
+		}
+		s.deleteIfEmpty(args[0], v)
+		w.bulk(val)
+		return nil
+	}
+}
+
+func cmdLIndex(s *memStore, w respWriter, args []string) error {
+	idx, err := parseInts(args[1])
+	if err != nil {
+		return err
+	}
+	v, err := s.lookup(args[0], memList)
+	if err != nil {
+		return err
+	}
+	i := idx[0]
+	if v != nil && i < 0 {
+		i += int64(len(v.list))
+	}
+	if v == nil || i < 0 || i >= int64(len(v.list)) {
+		w.null()
+	} else {
+		w.bulk(v.list[i])
+	}
+	return nil
+}
+
+func cmdLInsert(s *memStore, w respWriter, args []string) error {
+	where := strings.ToLower(args[1])
+	if where != "before" && where != "after" {
+		return errSyntax
+	}
+	v, err := s.lookup(args[0], memList)
+	if err != nil {
+		return err
+	} else if v == nil {
+		w.int(0)
+		return nil
+	}
+	for i, val := range v.list {
+		if val == args[2] {
+			if where == "after" {
+				i++
+			}
+			v.list = append(v.list[:i], append([]string{args[3]}, v.list[i:]...)...)
+			w.int(int64(len(v.list)))
+			return nil
+		}
+	}
+	w.int(-1)
+	return nil
+}
+
+func cmdLLen(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookup(args[0], memList)
+	if err != nil {
+		return err
+	} else if v == nil {
+		w.int(0)
+	} else {
+		w.int(int64(len(v.list)))
+	}
+	return nil
+}
+
+func cmdLMove(s *memStore, w respWriter, args []string) error {
+	from, to := strings.ToLower(args[2]), strings.ToLower(args[3])
+	for _, dir := range []string{from, to} {
+		if dir != "left" && dir != "right" {
+			return errSyntax
+		}
+	}
+	src, err := s.lookup(args[0], memList)
+	if err != nil {
+		return err
+	} else if src == nil {
+		w.null()
+		return nil
+	}
+	if dst, err := s.lookup(args[1], memList); err != nil {
+		return err
+	} else if dst == nil {
+		_, _ = s.lookupOrCreate(args[1], memList)
+	}
+
+	var val string
+	if from == "left" {
+		val, src.list = src.list[0], src.list[1:]
+	} else {
+		val, src.list = src.list[len(src.list)-1], src.list[:len(src.list)-1]
+	}
+	dst := s.keys[args[1]]
+	if to == "left" {
+		dst.list = append([]string{val}, dst.list...)
+	} else {
+		dst.list = append(dst.list, val)
+	}
+	s.deleteIfEmpty(args[0], src)
+	w.bulk(val)
+	return nil
+}
+
+func cmdLRange(s *memStore, w respWriter, args []string) error {
+	idx, err := parseInts(args[1], args[2])
+	if err != nil {
+		return err
+	}
+	v, err := s.lookup(args[0], memList)
+	if err != nil {
+		return err
+	} else if v == nil {
+		w.strings(nil)
+		return nil
+	}
+	from, to := rangeIndices(idx[0], idx[1], len(v.list))
+	w.strings(v.list[from:to])
+	return nil
+}
+
+func cmdLRem(s *memStore, w respWriter, args []string) error {
+	idx, err := parseInts(args[1])
+	if err != nil {
+		return err
+	}
+	count := idx[0]
+	v, err := s.lookup(args[0], memList)
+	if err != nil {
+		return err
+	} else if v == nil {
+		w.int(0)
+		return nil
+	}
+
+	// Remove from the tail by reversing the list before and after.
+	fromTail := count < 0
+	if fromTail {
+		reverse(v.list)
+		count = -count
+	}
+	var kept []string
+	removed := int64(0)
+	for _, val := range v.list {
+		if val == args[2] && (count == 0 || removed < count) {
+			removed++
+			continue
+		}
+		kept = append(kept, val)
+	}
+	if fromTail {
+		reverse(kept)
+	}
+	v.list = kept
+	s.deleteIfEmpty(args[0], v)
+	w.int(removed)
+	return nil
+}
+
+func reverse(list []string) {
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+}
+
+func cmdLSet(s *memStore, w respWriter, args []string) error {
+	idx, err := parseInts(args[1])
+	if err != nil {
+		return err
+	}
+	v, err := s.lookup(args[0], memList)
+	if err != nil {
+		return err
+	} else if v == nil {
+		return errNoSuchKey
+	}
+	i := idx[0]
+	if i < 0 {
+		i += int64(len(v.list))
+	}
+	if i < 0 || i >= int64(len(v.list)) {
+		return errOutOfRange
+	}
+	v.list[i] = args[2]
+	w.status("OK")
+	return nil
+}
+
+func cmdLTrim(s *memStore, w respWriter, args []string) error {
+	idx, err := parseInts(args[1], args[2])
+	if err != nil {
+		return err
+	}
+	v, err := s.lookup(args[0], memList)
+	if err != nil {
+		return err
+	} else if v != nil {
+		from, to := rangeIndices(idx[0], idx[1], len(v.list))
+		v.list = v.list[from:to]
+		s.deleteIfEmpty(args[0], v)
+	}
+	w.status("OK")
+	return nil
+}
+
+func cmdSAdd(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookupOrCreate(args[0], memSet)
+	if err != nil {
+		return err
+	}
+	n := 0
+	for _, m := range args[1:] {
+		if _, ok := v.set[m]; !ok {
+			v.set[m] = struct{}{}
+			n++
+		}
+	}
+	w.int(int64(n))
+	return nil
+}
+
+func cmdSRem(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookup(args[0], memSet)
+	if err != nil {
+		return err
+	} else if v == nil {
+		w.int(0)
+		return nil
+	}
+	n := 0
+	for _, m := range args[1:] {
+		if _, ok := v.set[m]; ok {
+			delete(v.set, m)
+			n++
+		}
+	}
+	s.deleteIfEmpty(args[0], v)
+	w.int(int64(n))
+	return nil
+}
+
+func cmdSCard(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookup(args[0], memSet)
+	if err != nil {
+		return err
+	} else if v == nil {
+		w.int(0)
+	} else {
+		w.int(int64(len(v.set)))
+	}
+	return nil
+}
+
+func cmdSIsMember(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookup(args[0], memSet)
+	if err != nil {
+		return err
+	}
+	if _, ok := v.members()[args[1]]; ok {
+		w.int(1)
+	} else {
+		w.int(0)
+	}
+	return nil
+}
+
+func cmdSMembers(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookup(args[0], memSet)
+	if err != nil {
+		return err
+	}
+	w.strings(sortedMembers(v.members()))
+	return nil
+}
+
+// members returns the members of a set value, which may be nil.
+func (v *memValue) members() map[string]struct{} {
+	if v == nil {
+		return nil
+	}
+	return v.set
+}
+
+func sortedMembers(set map[string]struct{}) []string {
+	members := make([]string, 0, len(set))
+	for m := range set {
+		members = append(members, m)
+	}
+	sort.Strings(members)
+	return members
+}
+
+func cmdSPop(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookup(args[0], memSet)
+	if err != nil {
+		return err
+	}
+	members := sortedMembers(v.members())
+	mathrand.Shuffle(len(members), func(i, j int) { members[i], members[j] = members[j], members[i] })
+
+	if len(args) == 1 {
+		if len(members) == 0 {
+			w.null()
+			return nil
+		}
+		delete(v.set, members[0])
+		s.deleteIfEmpty(args[0], v)
+		w.bulk(members[0])
+		return nil
+	}
+
+	count, err := parseInts(args[1])
+	if err != nil {
+		return err
+	} else if count[0] < 0 {
+		return errors.New("ERR value is out of range, must be positive")
+	}
+	popped := members[:min(int(count[0]), len(members))]
+	for _, m := range popped {
+		delete(v.set, m)
+	}
+	if v != nil {
+		s.deleteIfEmpty(args[0], v)
+	}
+	w.strings(popped)
+	return nil
+}
+
+func cmdSRandMember(s *memStore, w respWriter, args []string) error {
+	v, err := s.lookup(args[0], memSet)
+	if err != nil {
+		return err
+	}
+	members := sortedMembers(v.members())
+
+	if len(args) == 1 {
+		if len(members) == 0 {
+			w.null()
+		} else {
+			w.bulk(members[mathrand.Intn(len(members))])
+		}
+		return nil
+	}
+
+	count, err := parseInts(args[1])
+	if err != nil {
+		return err
+	}
+	var res []string
+	if n := count[0]; n >= 0 {
+		// Distinct members.
+		mathrand.Shuffle(len(members), func(i, j int) { members[i], members[j] = members[j], members[i] })
+		res = members[:min(int(n), len(members))]
+	} else if len(members) > 0 {
+		// Possibly repeated members.
+		for i := int64(0); i < -n; i++ {
+			res = append(res, members[mathrand.Intn(len(members))])
+		}
+	}
+	w.strings(res)
+	return nil
+}
+
+func cmdSMove(s *memStore, w respWriter, args []string) error {
+	src, err := s.lookup(args[0], memSet)
+	if err != nil {
+		return err
+	}
+	if _, err := s.lookup(args[1], memSet); err != nil {
+		return err
+	}
+	if _, ok := src.members()[args[2]]; !ok {
+		w.int(0)
+		return nil
+	}
+	delete(src.set, args[2])
+	s.deleteIfEmpty(args[0], src)
+	dst, _ := s.lookupOrCreate(args[1], memSet)
+	dst.set[args[2]] = struct{}{}
+	w.int(1)
+	return nil
+}
+
+type setOp int
+
+const (
+	setDiff setOp = iota
+	setInter
+	setUnion
+)
+
+func cmdSetOp(op setOp, store bool) func(s *memStore, w respWriter, args []string) error {
+	return func(s *memStore, w respWriter, args []string) error {
+		keys := args
+		if store {
+			keys = args[1:]
+		}
+
+		var sets []map[string]struct{}
+		for _, key := range keys {
+			v, err := s.lookup(key, memSet)
+			if err != nil {
+				return err
+			}
+			sets = append(sets, v.members())
+		}
+
+		res := make(map[string]struct{})
+		for m := range sets[0] {
+			res[m] = struct{}{}
+		}
+		for _, set := range sets[1:] {
+			switch op {
+			case setDiff:
+				for m := range set {
+					delete(res, m)
+				}
+			case setInter:
+				for m := range res {
+					if _, ok := set[m]; !ok {
+						delete(res, m)
+					}
+				}
+			case setUnion:
+				for m := range set {
+					res[m] = struct{}{}
+				}
+			}
+		}
+
+		if !store {
+			w.strings(sortedMembers(res))
+			return nil
+		}
+		delete(s.keys, args[0])
+		if len(res) > 0 {
+			s.keys[args[0]] = &memValue{kind: memSet, set: res}
+		}
+		w.int(int64(len(res)))
+		return nil
+	}
+}
+
+// respWriter writes replies in the Redis protocol.
+type respWriter struct {
+	buf *bytes.Buffer
+}
+
+func (w respWriter) status(s string) { fmt.Fprintf(w.buf, "+%s\r\n", s) }
+func (w respWriter) err(err error)   { fmt.Fprintf(w.buf, "-%s\r\n", err.Error()) }
+func (w respWriter) int(n int64)     { fmt.Fprintf(w.buf, ":%d\r\n", n) }
+func (w respWriter) bulk(s string)   { fmt.Fprintf(w.buf, "$%d\r\n%s\r\n", len(s), s) }
+func (w respWriter) null()           { w.buf.WriteString("$-1\r\n") }
+func (w respWriter) arrayLen(n int)  { fmt.Fprintf(w.buf, "*%d\r\n", n) }
+
+func (w respWriter) strings(strs []string) {
+	w.arrayLen(len(strs))
+	for _, s := range strs {
+		w.bulk(s)
+	}
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemStore(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cl := newMemStore(func() time.Time { return now }).newClient()
+	ctx := context.Background()
+
+	if err := cl.Ping(ctx).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Operations on keys of the wrong type fail.
+	check(cl.Set(ctx, "str", "val", 0).Err())
+	if err := cl.LPush(ctx, "str", "a").Err(); err == nil || !strings.HasPrefix(err.Error(), "WRONGTYPE") {
+		t.Errorf("lpush on string: got err %v, want WRONGTYPE", err)
+	}
+	if err := cl.Do(ctx, "nosuchcommand").Err(); err == nil || !strings.Contains(err.Error(), "unknown command") {
+		t.Errorf("unknown command: got err %v", err)
+	}
+
+	// Transactions execute all commands and return their results.
+	pipe := cl.TxPipeline()
+	incr := pipe.Incr(ctx, "counter")
+	pipe.PExpire(ctx, "counter", time.Second)
+	if _, err := pipe.Exec(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if incr.Val() != 1 {
+		t.Errorf("incr: got %d, want 1", incr.Val())
+	}
+	if got := must(cl.PTTL(ctx, "counter").Result()); got != time.Second {
+		t.Errorf("pttl: got %v, want 1s", got)
+	}
+
+	// Keys expire according to the store's time.
+	check(cl.PExpireAt(ctx, "str", now.Add(time.Minute)).Err())
+	now = now.Add(time.Minute)
+	if n := must(cl.Exists(ctx, "str", "counter").Result()); n != 0 {
+		t.Errorf("exists: got %d keys, want 0", n)
+	}
+
+	// Emptied lists and sets are deleted.
+	check(cl.RPush(ctx, "list", "a", "b").Err())
+	check(cl.LTrim(ctx, "list", 2, -1).Err())
+	check(cl.SAdd(ctx, "set", "a").Err())
+	check(cl.SRem(ctx, "set", "a").Err())
+	if n := must(cl.Exists(ctx, "list", "set").Result()); n != 0 {
+		t.Errorf("exists: got %d keys, want 0", n)
+	}
+
+	check(cl.SAdd(ctx, "a", "1", "2", "3").Err())
+	check(cl.SAdd(ctx, "b", "2", "3", "4").Err())
+	if got, want := must(cl.SInter(ctx, "a", "b").Result()), []string{"2", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sinter: got %v, want %v", got, want)
+	}
+	if got, want := must(cl.SRandMemberN(ctx, "a", -5).Result()), 5; len(got) != want {
+		t.Errorf("srandmember: got %d members, want %d", len(got), want)
+	}
+}
+
+// TestMemStore_Keyspaces runs the keyspace tests against the in-memory store.
+func TestMemStore_Keyspaces(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, store testStore)
+	}{
+		{"Basic", testBasicKeyspace},
+		{"String", testStringKeyspace},
+		{"Int", testIntKeyspace},
+		{"Float", testFloatKeyspace},
+		{"List", testListKeyspace},
+		{"Set", testSets},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, memTestStore)
+		})
+	}
+}
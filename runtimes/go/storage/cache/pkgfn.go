@@ -6,9 +6,5 @@ package cache
 //
 // See https://encore.dev/docs/develop/caching for more information.
 func NewCluster(name string, cfg ClusterConfig) *Cluster {
-	return &Cluster{
-		cfg: cfg,
-		mgr: Singleton,
-		cl:  Singleton.getClient(name),
-	}
+	return NewClusterInternal(Singleton, name, cfg)
 }
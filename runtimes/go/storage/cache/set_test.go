@@ -6,12 +6,14 @@ import (
 	"reflect"
 	"sort"
 	"testing"
-
-	"github.com/alicebob/miniredis/v2"
 )
 
 func TestSets(t *testing.T) {
-	kt := newSetTest(t)
+	testSets(t, miniredisStore)
+}
+
+func testSets(t *testing.T, store testStore) {
+	kt := newSetTest(t, store)
 	ks, ctx := kt.ks, kt.ctx
 
 	if got, want := kt.Add("one", "a", "b"), 2; got != want {
@@ -138,20 +140,19 @@ func checkSetMap(t *testing.T, got map[string]struct{}, want ...string) {
 	}
 }
 
-func newSetTest(t *testing.T) *setTester {
-	cluster, srv := newTestCluster(t)
+func newSetTest(t *testing.T, store testStore) *setTester {
+	cluster, _ := newTestCluster(t, store)
 	ks := NewSetKeyspace[string, string](cluster, KeyspaceConfig{
 		EncoreInternal_KeyMapper: func(s string) string { return s },
 	})
 	ctx := context.Background()
-	return &setTester{t: t, ctx: ctx, ks: ks, srv: srv}
+	return &setTester{t: t, ctx: ctx, ks: ks}
 }
 
 type setTester struct {
 	t   *testing.T
 	ctx context.Context
 	ks  *SetKeyspace[string, string]
-	srv *miniredis.Miniredis
 }
 
 func (t *setTester) Add(key string, val ...string) int {
@@ -4,10 +4,12 @@ package cache
 
 import (
 	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/clock"
 	"encore.dev/appruntime/shared/jsonapi"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/metrics"
 )
 
 // Initialize the singleton instance.
@@ -19,6 +21,6 @@ import (
 var Singleton *Manager
 
 func init() {
-	Singleton = NewManager(appconf.Static, appconf.Runtime, reqtrack.Singleton, testsupport.Singleton, jsonapi.Default)
+	Singleton = NewManager(appconf.Static, appconf.Runtime, reqtrack.Singleton, testsupport.Singleton, clock.Singleton, jsonapi.Default, metrics.Singleton)
 	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
 }
@@ -3,6 +3,7 @@ package objects
 import (
 	"context"
 	"errors"
+	"io"
 	"iter"
 	"net/url"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"encore.dev/appruntime/exported/stack"
 	"encore.dev/appruntime/exported/trace2"
 	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/metrics"
 	"encore.dev/storage/objects/internal/providers/noop"
 	"encore.dev/storage/objects/internal/types"
 )
@@ -47,6 +49,25 @@ type BucketConfig struct {
 func newBucket(mgr *Manager, name string) *Bucket {
 	// Look up the bkt configuration
 	bkt, ok := mgr.runtime.Buckets[name]
+
+	// Are we in a test? If so, use an in-memory bucket.
+	if mgr.static.Testing {
+		b := &Bucket{
+			mgr:        mgr,
+			runtimeCfg: &config.Bucket{EncoreName: name},
+			impl:       mgr.withFaults(mgr.memoryBucket(name), name),
+			name:       name,
+		}
+		if ok {
+			b.runtimeCfg = bkt
+			b.baseCloudPrefix = bkt.KeyPrefix
+			if bkt.PublicBaseURL != "" {
+				b.publicBaseURL, _ = url.Parse(bkt.PublicBaseURL)
+			}
+		}
+		return b
+	}
+
 	if !ok {
 		// No runtime config; return the noop implementation.
 		return &Bucket{
@@ -63,7 +84,7 @@ func newBucket(mgr *Manager, name string) *Bucket {
 	tried := make([]string, 0, len(mgr.providers))
 	for _, p := range mgr.providers {
 		if p.Matches(provider) {
-			impl := p.NewBucket(provider, bkt)
+			impl := mgr.withFaults(p.NewBucket(provider, bkt), name)
 
 			var publicBaseURL *url.URL
 			if bkt.PublicBaseURL != "" {
@@ -107,6 +128,7 @@ func (b *Bucket) Upload(ctx context.Context, object string, options ...UploadOpt
 		ctx: ctx,
 		obj: object,
 		opt: opt,
+		op:  b.mgr.metrics.StartInfraOp("objects", b.name, "upload"),
 	}
 
 	curr := b.mgr.rt.Current()
@@ -161,6 +183,8 @@ type Writer struct {
 	// Initialized on first write
 	u types.Uploader
 
+	op metrics.InfraOp
+
 	// Set if tracing
 	curr         reqtrack.Current
 	startEventID trace2.EventID
@@ -185,6 +209,7 @@ func (w *Writer) Abort(err error) {
 func (w *Writer) Close() error {
 	u := w.initUpload()
 	attrs, err := u.Complete()
+	w.op.End(err)
 
 	if w.curr.Trace != nil {
 		params := trace2.BucketObjectUploadEndParams{
@@ -268,12 +293,13 @@ func (b *Bucket) Download(ctx context.Context, object string, options ...Downloa
 		})
 	}
 
+	op := b.mgr.metrics.StartInfraOp("objects", b.name, "download")
 	r, err := b.impl.Download(types.DownloadData{
 		Ctx:     ctx,
 		Object:  b.toCloudObject(object),
 		Version: opt.version,
 	})
-	return &Reader{r: r, err: err, curr: curr, startEventID: startEventID}
+	return &Reader{r: r, err: err, op: op, curr: curr, startEventID: startEventID}
 }
 
 // Reader is the reader for an object being downloaded from a bucket.
@@ -281,6 +307,7 @@ type Reader struct {
 	err       error // any error encountered
 	r         types.Downloader
 	totalRead uint64
+	op        metrics.InfraOp
 
 	// Set if traced
 	traceCompleted bool
@@ -323,6 +350,12 @@ func (r *Reader) completeTrace() {
 	}
 
 	r.traceCompleted = true
+	if r.err == nil || errors.Is(r.err, io.EOF) {
+		r.op.End(nil)
+	} else {
+		r.op.End(r.err)
+	}
+
 	if r.curr.Trace != nil && r.startEventID != 0 {
 		r.curr.Trace.BucketObjectDownloadEnd(trace2.BucketObjectDownloadEndParams{
 			StartID: r.startEventID,
@@ -350,7 +383,7 @@ type Query struct {
 func (b *Bucket) mapQuery(ctx context.Context, q *Query) types.ListData {
 	return types.ListData{
 		Ctx:    ctx,
-		Prefix: b.baseCloudPrefix + q.Prefix,
+		Prefix: b.cloudPrefix() + q.Prefix,
 		Limit:  ptrOrNil(q.Limit),
 	}
 }
@@ -447,6 +480,9 @@ func (b *Bucket) List(ctx context.Context, query *Query, options ...ListOption)
 			})
 		}
 
+		op := b.mgr.metrics.StartInfraOp("objects", b.name, "list")
+		defer func() { op.End(listErr) }()
+
 		iter := b.impl.List(b.mapQuery(ctx, query))
 		for entry, err := range iter {
 			if err != nil {
@@ -503,11 +539,13 @@ func (b *Bucket) Remove(ctx context.Context, object string, options ...RemoveOpt
 		})
 	}
 
+	op := b.mgr.metrics.StartInfraOp("objects", b.name, "remove")
 	removeErr = b.impl.Remove(types.RemoveData{
 		Ctx:     ctx,
 		Object:  b.toCloudObject(object),
 		Version: opts.version,
 	})
+	op.End(removeErr)
 
 	return removeErr
 }
@@ -575,11 +613,13 @@ func (b *Bucket) Attrs(ctx context.Context, object string, options ...AttrsOptio
 		}()
 	}
 
+	op := b.mgr.metrics.StartInfraOp("objects", b.name, "attrs")
 	attrs, attrsErr = b.impl.Attrs(types.AttrsData{
 		Ctx:     ctx,
 		Object:  b.toCloudObject(object),
 		Version: opt.version,
 	})
+	op.End(attrsErr)
 	if attrsErr != nil {
 		return nil, attrsErr
 	}
@@ -688,14 +728,18 @@ func (b *Bucket) Exists(ctx context.Context, object string, options ...ExistsOpt
 		}()
 	}
 
+	op := b.mgr.metrics.StartInfraOp("objects", b.name, "exists")
 	attrs, attrsErr = b.impl.Attrs(types.AttrsData{
 		Ctx:     ctx,
 		Object:  b.toCloudObject(object),
 		Version: opt.version,
 	})
 	if errors.Is(attrsErr, ErrObjectNotFound) {
+		op.End(nil)
 		return false, nil
-	} else if attrsErr != nil {
+	}
+	op.End(attrsErr)
+	if attrsErr != nil {
 		return false, attrsErr
 	}
 	return true, nil
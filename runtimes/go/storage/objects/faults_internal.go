@@ -0,0 +1,77 @@
+package objects
+
+import (
+	"context"
+	"iter"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/faults"
+	"encore.dev/storage/objects/internal/types"
+)
+
+// faultBucket injects the configured faults into the operations on a bucket.
+type faultBucket struct {
+	impl   types.BucketImpl
+	faults *faults.Injector
+	bucket string
+}
+
+func (b *faultBucket) inject(ctx context.Context) error {
+	return b.faults.Inject(ctx, faults.Op{Target: config.FaultObjects, Resource: b.bucket}).Err
+}
+
+func (b *faultBucket) Upload(data types.UploadData) (types.Uploader, error) {
+	if err := b.inject(data.Ctx); err != nil {
+		return nil, err
+	}
+	return b.impl.Upload(data)
+}
+
+func (b *faultBucket) Download(data types.DownloadData) (types.Downloader, error) {
+	if err := b.inject(data.Ctx); err != nil {
+		return nil, err
+	}
+	return b.impl.Download(data)
+}
+
+func (b *faultBucket) List(data types.ListData) iter.Seq2[*types.ListEntry, error] {
+	return func(yield func(*types.ListEntry, error) bool) {
+		if err := b.inject(data.Ctx); err != nil {
+			yield(nil, err)
+			return
+		}
+		for entry, err := range b.impl.List(data) {
+			if !yield(entry, err) {
+				return
+			}
+		}
+	}
+}
+
+func (b *faultBucket) Remove(data types.RemoveData) error {
+	if err := b.inject(data.Ctx); err != nil {
+		return err
+	}
+	return b.impl.Remove(data)
+}
+
+func (b *faultBucket) Attrs(data types.AttrsData) (*types.ObjectAttrs, error) {
+	if err := b.inject(data.Ctx); err != nil {
+		return nil, err
+	}
+	return b.impl.Attrs(data)
+}
+
+func (b *faultBucket) SignedUploadURL(data types.UploadURLData) (string, error) {
+	if err := b.inject(data.Ctx); err != nil {
+		return "", err
+	}
+	return b.impl.SignedUploadURL(data)
+}
+
+func (b *faultBucket) SignedDownloadURL(data types.DownloadURLData) (string, error) {
+	if err := b.inject(data.Ctx); err != nil {
+		return "", err
+	}
+	return b.impl.SignedDownloadURL(data)
+}
@@ -0,0 +1,244 @@
+// Package memory implements an in-memory bucket, used when running tests.
+package memory
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"iter"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"encore.dev/storage/objects/internal/types"
+)
+
+// Bucket is an in-memory bucket.
+//
+// Every upload creates a new version of the object, identified by an increasing
+// generation number like with GCS, and older versions remain available by version.
+type Bucket struct {
+	EncoreName string
+
+	mu      sync.Mutex
+	gen     int64
+	objects map[types.CloudObject]*object
+}
+
+// NewBucket returns a new, empty bucket.
+func NewBucket(encoreName string) *Bucket {
+	return &Bucket{EncoreName: encoreName, objects: make(map[types.CloudObject]*object)}
+}
+
+type object struct {
+	versions []*version // in upload order
+	live     bool       // whether the latest version hasn't been removed
+}
+
+type version struct {
+	attrs types.ObjectAttrs
+	data  []byte
+}
+
+// get returns the requested version of the object,
+// or the live version if ver is empty.
+// b.mu must be held.
+func (b *Bucket) get(name types.CloudObject, ver string) (*version, error) {
+	obj := b.objects[name]
+	if obj == nil {
+		return nil, types.ErrObjectNotExist
+	}
+	if ver == "" {
+		if !obj.live {
+			return nil, types.ErrObjectNotExist
+		}
+		return obj.versions[len(obj.versions)-1], nil
+	}
+	for _, v := range obj.versions {
+		if v.attrs.Version == ver {
+			return v, nil
+		}
+	}
+	return nil, types.ErrObjectNotExist
+}
+
+func (b *Bucket) Upload(data types.UploadData) (types.Uploader, error) {
+	return &uploader{bkt: b, data: data}, nil
+}
+
+type uploader struct {
+	bkt     *Bucket
+	data    types.UploadData
+	buf     bytes.Buffer
+	aborted error
+}
+
+func (u *uploader) Write(p []byte) (int, error) {
+	if u.aborted != nil {
+		return 0, u.aborted
+	} else if err := u.data.Ctx.Err(); err != nil {
+		return 0, err
+	}
+	return u.buf.Write(p)
+}
+
+func (u *uploader) Abort(err error) {
+	u.aborted = err
+}
+
+func (u *uploader) Complete() (*types.ObjectAttrs, error) {
+	if u.aborted != nil {
+		return nil, u.aborted
+	} else if err := u.data.Ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b := u.bkt
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	obj := b.objects[u.data.Object]
+	if u.data.Pre.NotExists && obj != nil && obj.live {
+		return nil, types.ErrPreconditionFailed
+	}
+	if obj == nil {
+		obj = &object{}
+		b.objects[u.data.Object] = obj
+	}
+
+	b.gen++
+	sum := md5.Sum(u.buf.Bytes())
+	v := &version{
+		data: bytes.Clone(u.buf.Bytes()),
+		attrs: types.ObjectAttrs{
+			Object:      u.data.Object,
+			Version:     strconv.FormatInt(b.gen, 10),
+			ContentType: u.data.Attrs.ContentType,
+			Size:        int64(u.buf.Len()),
+			ETag:        `"` + hex.EncodeToString(sum[:]) + `"`,
+		},
+	}
+	obj.versions = append(obj.versions, v)
+	obj.live = true
+
+	attrs := v.attrs
+	return &attrs, nil
+}
+
+func (b *Bucket) Download(data types.DownloadData) (types.Downloader, error) {
+	if err := data.Ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, err := b.get(data.Object, data.Version)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(v.data)), nil
+}
+
+// List lists the live objects in lexicographical order,
+// as of when the iteration starts.
+func (b *Bucket) List(data types.ListData) iter.Seq2[*types.ListEntry, error] {
+	return func(yield func(*types.ListEntry, error) bool) {
+		if err := data.Ctx.Err(); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		b.mu.Lock()
+		var entries []*types.ListEntry
+		for name, obj := range b.objects {
+			if obj.live && strings.HasPrefix(string(name), data.Prefix) {
+				attrs := obj.versions[len(obj.versions)-1].attrs
+				entries = append(entries, &types.ListEntry{Object: name, Size: attrs.Size, ETag: attrs.ETag})
+			}
+		}
+		b.mu.Unlock()
+
+		slices.SortFunc(entries, func(a, b *types.ListEntry) int {
+			return strings.Compare(string(a.Object), string(b.Object))
+		})
+		if data.Limit != nil && int64(len(entries)) > *data.Limit {
+			entries = entries[:*data.Limit]
+		}
+		for _, e := range entries {
+			if !yield(e, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Remove removes the live version of the object, or the given version.
+func (b *Bucket) Remove(data types.RemoveData) error {
+	if err := data.Ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, err := b.get(data.Object, data.Version)
+	if err != nil {
+		return err
+	}
+
+	obj := b.objects[data.Object]
+	if data.Version == "" {
+		obj.live = false
+	} else {
+		latest := obj.versions[len(obj.versions)-1] == v
+		obj.versions = slices.DeleteFunc(obj.versions, func(o *version) bool { return o == v })
+		if latest {
+			obj.live = false
+		}
+	}
+	if len(obj.versions) == 0 {
+		delete(b.objects, data.Object)
+	}
+	return nil
+}
+
+func (b *Bucket) Attrs(data types.AttrsData) (*types.ObjectAttrs, error) {
+	if err := data.Ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, err := b.get(data.Object, data.Version)
+	if err != nil {
+		return nil, err
+	}
+	attrs := v.attrs
+	return &attrs, nil
+}
+
+// SignedUploadURL returns a placeholder URL identifying the bucket,
+// object, method and expiry. The URL can't be used to upload the object.
+func (b *Bucket) SignedUploadURL(data types.UploadURLData) (string, error) {
+	return b.signedURL("PUT", data.Object, data.TTL), nil
+}
+
+// SignedDownloadURL returns a placeholder URL identifying the bucket,
+// object, method and expiry. The URL can't be used to download the object.
+func (b *Bucket) SignedDownloadURL(data types.DownloadURLData) (string, error) {
+	return b.signedURL("GET", data.Object, data.TTL), nil
+}
+
+func (b *Bucket) signedURL(method string, object types.CloudObject, ttl time.Duration) string {
+	q := url.Values{
+		"method":  {method},
+		"expires": {time.Now().Add(ttl).UTC().Format(time.RFC3339)},
+	}
+	u := url.URL{
+		Scheme:   "memory",
+		Host:     b.EncoreName,
+		Path:     "/" + string(object),
+		RawQuery: q.Encode(),
+	}
+	return u.String()
+}
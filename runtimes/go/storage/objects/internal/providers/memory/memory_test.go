@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"encore.dev/storage/objects/internal/types"
+)
+
+func upload(t *testing.T, b *Bucket, name, content string, pre types.Preconditions) (*types.ObjectAttrs, error) {
+	t.Helper()
+	u, err := b.Upload(types.UploadData{
+		Ctx:    context.Background(),
+		Object: types.CloudObject(name),
+		Attrs:  types.UploadAttrs{ContentType: "text/plain"},
+		Pre:    pre,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	return u.Complete()
+}
+
+func download(t *testing.T, b *Bucket, name, version string) (string, error) {
+	t.Helper()
+	r, err := b.Download(types.DownloadData{Ctx: context.Background(), Object: types.CloudObject(name), Version: version})
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = r.Close() }()
+	data, err := io.ReadAll(r)
+	return string(data), err
+}
+
+func TestBucket(t *testing.T) {
+	ctx := context.Background()
+	b := NewBucket("files")
+
+	v1, err := upload(t, b, "a.txt", "one", types.Preconditions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v1.Size != 3 || v1.ContentType != "text/plain" || v1.ETag == "" {
+		t.Errorf("got attrs %+v", v1)
+	}
+
+	// The NotExists precondition fails for existing objects.
+	if _, err := upload(t, b, "a.txt", "two", types.Preconditions{NotExists: true}); !errors.Is(err, types.ErrPreconditionFailed) {
+		t.Fatalf("got err %v, want ErrPreconditionFailed", err)
+	}
+	v2, err := upload(t, b, "a.txt", "two", types.Preconditions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Older versions remain available.
+	if got, _ := download(t, b, "a.txt", ""); got != "two" {
+		t.Errorf("got %q, want latest version", got)
+	}
+	if got, _ := download(t, b, "a.txt", v1.Version); got != "one" {
+		t.Errorf("got %q, want first version", got)
+	}
+
+	// Removing the object keeps its versions.
+	if err := b.Remove(types.RemoveData{Ctx: ctx, Object: "a.txt"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Attrs(types.AttrsData{Ctx: ctx, Object: "a.txt"}); !errors.Is(err, types.ErrObjectNotExist) {
+		t.Errorf("got err %v, want ErrObjectNotExist", err)
+	}
+	if attrs, err := b.Attrs(types.AttrsData{Ctx: ctx, Object: "a.txt", Version: v2.Version}); err != nil || attrs.Size != 3 {
+		t.Errorf("got attrs %+v, err %v", attrs, err)
+	}
+	if _, err := download(t, b, "missing.txt", ""); !errors.Is(err, types.ErrObjectNotExist) {
+		t.Errorf("got err %v, want ErrObjectNotExist", err)
+	}
+}
+
+func TestBucket_List(t *testing.T) {
+	b := NewBucket("files")
+	for _, name := range []string{"dir/c", "dir/a", "other", "dir/b"} {
+		if _, err := upload(t, b, name, name, types.Preconditions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list := func(prefix string, limit *int64) []string {
+		var names []string
+		for e, err := range b.List(types.ListData{Ctx: context.Background(), Prefix: prefix, Limit: limit}) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, string(e.Object))
+		}
+		return names
+	}
+
+	if got := list("dir/", nil); len(got) != 3 || got[0] != "dir/a" || got[2] != "dir/c" {
+		t.Errorf("got %v, want sorted objects with prefix", got)
+	}
+	limit := int64(2)
+	if got := list("", &limit); len(got) != 2 || got[1] != "dir/b" {
+		t.Errorf("got %v, want first two objects", got)
+	}
+}
+
+func TestBucket_SignedURL(t *testing.T) {
+	b := NewBucket("files")
+	raw, err := b.SignedUploadURL(types.UploadURLData{Ctx: context.Background(), Object: "dir/a b.txt", TTL: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Host != "files" || u.Path != "/dir/a b.txt" || u.Query().Get("method") != "PUT" {
+		t.Errorf("got url %s", raw)
+	}
+	if exp, err := time.Parse(time.RFC3339, u.Query().Get("expires")); err != nil || time.Until(exp) <= 0 {
+		t.Errorf("got expiry %v, err %v", exp, err)
+	}
+}
@@ -2,13 +2,19 @@ package objects
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/rs/zerolog"
 
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/faults"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/metrics"
+	"encore.dev/storage/objects/internal/providers/memory"
+	"encore.dev/storage/objects/internal/types"
 )
 
 type Manager struct {
@@ -19,11 +25,21 @@ type Manager struct {
 	rt         *reqtrack.RequestTracker
 	ts         *testsupport.Manager
 	rootLogger zerolog.Logger
+	metrics    *metrics.Registry
+	faults     *faults.Injector // nil if no fault injection is configured
 	providers  []provider
+
+	memMu      sync.Mutex
+	memBuckets map[string]*memory.Bucket // in-memory buckets used in tests, by name
 }
 
 func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker,
-	ts *testsupport.Manager, rootLogger zerolog.Logger) *Manager {
+	ts *testsupport.Manager, rootLogger zerolog.Logger, reg *metrics.Registry) *Manager {
+	faultInjector, err := faults.New(runtime.FaultInjection, rt)
+	if err != nil {
+		panic(fmt.Errorf("error loading fault injection rules: %w", err))
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	mgr := &Manager{
 		ctx:        ctx,
@@ -33,6 +49,8 @@ func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.Req
 		rt:         rt,
 		ts:         ts,
 		rootLogger: rootLogger,
+		metrics:    reg,
+		faults:     faultInjector,
 	}
 
 	for _, p := range providerRegistry {
@@ -42,6 +60,29 @@ func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.Req
 	return mgr
 }
 
+// withFaults injects the configured faults into the operations on the given bucket.
+func (mgr *Manager) withFaults(impl types.BucketImpl, name string) types.BucketImpl {
+	if mgr.faults == nil {
+		return impl
+	}
+	return &faultBucket{impl: impl, faults: mgr.faults, bucket: name}
+}
+
+// memoryBucket returns the in-memory bucket with the given name, used when running tests.
+func (mgr *Manager) memoryBucket(name string) *memory.Bucket {
+	mgr.memMu.Lock()
+	defer mgr.memMu.Unlock()
+	if mgr.memBuckets == nil {
+		mgr.memBuckets = make(map[string]*memory.Bucket)
+	}
+	bkt, ok := mgr.memBuckets[name]
+	if !ok {
+		bkt = memory.NewBucket(name)
+		mgr.memBuckets[name] = bkt
+	}
+	return bkt
+}
+
 // Shutdown stops the manager from fetching new messages and processing them.
 func (mgr *Manager) Shutdown(p *shutdown.Process) error {
 	// Once it's time to force-close tasks, cancel the base context.
@@ -11,6 +11,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -28,6 +29,11 @@ type Database struct {
 	mgr      *Manager
 
 	noopDB bool // true if this is a dummy database that does nothing and returns errors for all operations
+	testDB bool // true if this database was created by et.NewTestDatabase
+
+	// template is the template database to create this database from
+	// on first use, if any. It's set when running tests.
+	template string
 
 	initOnce sync.Once
 	pool     *pgxpool.Pool
@@ -45,8 +51,20 @@ func (db *Database) init() {
 	}
 
 	db.initOnce.Do(func() {
+		if db.template != "" {
+			if err := db.mgr.createFromTemplate(context.Background(), db.origName, db.name, db.template); err != nil {
+				// Fall back to using the shared database.
+				db.mgr.rt.Logger().Warn().Err(err).Str("db", db.origName).Msg("unable to create database from template, using the shared database")
+				db.name = db.origName
+			}
+		}
+
 		if db.pool == nil {
-			pool, found := db.mgr.getPool(db.origName, db.name)
+			override := db.name
+			if db.name == db.origName {
+				override = ""
+			}
+			pool, found := db.mgr.getPool(db.origName, override)
 			db.pool, db.noopDB = pool, !found
 		}
 
@@ -192,11 +210,22 @@ func (db *Database) Exec(ctx context.Context, query string, args ...interface{})
 		})
 	}
 
-	res, err := db.pool.Exec(markTraced(ctx), query, args...)
-	err = convertErr(err)
+	op := db.mgr.metrics.StartInfraOp("sqldb", db.origName, "exec")
+	start := time.Now()
+	var res ExecResult
+	err := db.mgr.injectFault(ctx, db.origName)
+	if err == nil {
+		res, err = db.pool.Exec(markTraced(ctx), query, args...)
+		err = convertErr(err)
+	}
+	op.End(err)
 
 	if curr.Trace != nil {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+		if err == nil {
+			q := tracedQuery{trace: curr.Trace, params: eventParams, startID: startEventID, start: start}
+			db.mgr.explainSlowQuery(ctx, db.pool, q, query, args)
+		}
 	}
 
 	return res, err
@@ -233,11 +262,22 @@ func (db *Database) Query(ctx context.Context, query string, args ...interface{}
 		})
 	}
 
-	rows, err := db.pool.Query(markTraced(ctx), query, args...)
-	err = convertErr(err)
+	op := db.mgr.metrics.StartInfraOp("sqldb", db.origName, "query")
+	start := time.Now()
+	var rows pgx.Rows
+	err := db.mgr.injectFault(ctx, db.origName)
+	if err == nil {
+		rows, err = db.pool.Query(markTraced(ctx), query, args...)
+		err = convertErr(err)
+	}
+	op.End(err)
 
 	if curr.Trace != nil {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+		if err == nil {
+			q := tracedQuery{trace: curr.Trace, params: eventParams, startID: startEventID, start: start}
+			db.mgr.explainSlowQuery(ctx, db.pool, q, query, args)
+		}
 	}
 
 	if err != nil {
@@ -276,12 +316,23 @@ func (db *Database) QueryRow(ctx context.Context, query string, args ...interfac
 		})
 	}
 
-	rows, err := db.pool.Query(markTraced(ctx), query, args...)
-	err = convertErr(err)
+	op := db.mgr.metrics.StartInfraOp("sqldb", db.origName, "query_row")
+	start := time.Now()
+	var rows pgx.Rows
+	err := db.mgr.injectFault(ctx, db.origName)
+	if err == nil {
+		rows, err = db.pool.Query(markTraced(ctx), query, args...)
+		err = convertErr(err)
+	}
+	op.End(err)
 	r := &Row{rows: rows, err: err}
 
 	if curr.Trace != nil {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+		if err == nil {
+			q := tracedQuery{trace: curr.Trace, params: eventParams, startID: startEventID, start: start}
+			db.mgr.explainSlowQuery(ctx, db.pool, q, query, args)
+		}
 	}
 
 	return r
@@ -296,8 +347,10 @@ func (db *Database) Begin(ctx context.Context) (*Tx, error) {
 	}
 
 	db.init()
+	op := db.mgr.metrics.StartInfraOp("sqldb", db.origName, "begin")
 	tx, err := db.pool.Begin(markTraced(ctx))
 	err = convertErr(err)
+	op.End(err)
 	if err != nil {
 		return nil, err
 	}
@@ -312,7 +365,7 @@ func (db *Database) Begin(ctx context.Context) (*Tx, error) {
 		}, stack.Build(4))
 	}
 
-	return &Tx{mgr: db.mgr, std: tx, startID: startID}, nil
+	return &Tx{mgr: db.mgr, db: db.origName, pool: db.pool, std: tx, startID: startID}, nil
 }
 
 // Driver returns the underlying database driver for this database connection pool.
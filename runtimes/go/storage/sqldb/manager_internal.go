@@ -2,14 +2,20 @@ package sqldb
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/xid"
 
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/faults"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/metrics"
 )
 
 // Manager manages database connections.
@@ -17,20 +23,35 @@ type Manager struct {
 	runtime *config.Runtime
 	rt      *reqtrack.RequestTracker
 	ts      *testsupport.Manager
+	metrics *metrics.Registry
+	faults  *faults.Injector // nil if no fault injection is configured
 
 	mu  sync.RWMutex
 	dbs map[string]*Database
 }
 
-func NewManager(runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsupport.Manager) *Manager {
+func NewManager(runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsupport.Manager, reg *metrics.Registry) *Manager {
+	faultInjector, err := faults.New(runtime.FaultInjection, rt)
+	if err != nil {
+		panic(fmt.Errorf("error loading fault injection rules: %w", err))
+	}
+
 	return &Manager{
 		runtime: runtime,
 		rt:      rt,
 		ts:      ts,
+		metrics: reg,
+		faults:  faultInjector,
 		dbs:     make(map[string]*Database),
 	}
 }
 
+// injectFault injects the configured faults into a query to the given database,
+// returning the error to fail the query with, if any.
+func (mgr *Manager) injectFault(ctx context.Context, dbName string) error {
+	return mgr.faults.Inject(ctx, faults.Op{Target: config.FaultSQLDBQuery, Resource: dbName}).Err
+}
+
 // GetCurrentDB gets the database for the current request.
 func (mgr *Manager) GetCurrentDB() *Database {
 	var dbName string
@@ -58,13 +79,19 @@ func (mgr *Manager) GetDB(dbName string) *Database {
 	if db, ok := mgr.dbs[dbName]; ok {
 		return db
 	}
-	pool, found := mgr.getPool(dbName, "")
+	cfg := mgr.dbConfig(dbName)
 	db = &Database{
 		name:     dbName,
 		origName: dbName,
 		mgr:      mgr,
-		noopDB:   !found,
-		pool:     pool,
+		noopDB:   cfg == nil,
+	}
+	if cfg != nil && cfg.TemplateDatabaseName != "" {
+		// Create the database for this process from the template on first use.
+		db.name = strings.TrimSuffix(cfg.TemplateDatabaseName, "_template") + "_" + xid.New().String()
+		db.template = cfg.TemplateDatabaseName
+	} else {
+		db.pool, _ = mgr.getPool(dbName, "")
 	}
 	mgr.dbs[dbName] = db
 	return db
@@ -73,13 +100,7 @@ func (mgr *Manager) GetDB(dbName string) *Database {
 // getPool returns a database connection pool for the given database name.
 // Each time it's called it returns a new pool.
 func (mgr *Manager) getPool(encoreName, dbNameOverride string) (pool *pgxpool.Pool, found bool) {
-	var db *config.SQLDatabase
-	for _, d := range mgr.runtime.SQLDatabases {
-		if d.EncoreName == encoreName {
-			db = d
-			break
-		}
-	}
+	db := mgr.dbConfig(encoreName)
 	if db == nil {
 		return nil, false
 	}
@@ -99,6 +120,40 @@ func (mgr *Manager) getPool(encoreName, dbNameOverride string) (pool *pgxpool.Po
 	return pool, true
 }
 
+// dbConfig returns the config for the database with the given name, or nil if it's not found.
+func (mgr *Manager) dbConfig(encoreName string) *config.SQLDatabase {
+	for _, d := range mgr.runtime.SQLDatabases {
+		if d.EncoreName == encoreName {
+			return d
+		}
+	}
+	return nil
+}
+
+// createFromTemplate creates the database dbName from the template database,
+// connecting to the database with the given name to do so.
+func (mgr *Manager) createFromTemplate(ctx context.Context, encoreName, dbName, template string) error {
+	db := mgr.dbConfig(encoreName)
+	if db == nil {
+		return fmt.Errorf("unknown database %q", encoreName)
+	}
+	cfg, err := dbConf(mgr.runtime.SQLServers[db.ServerID], db, "")
+	if err != nil {
+		return err
+	}
+	conn, err := pgx.ConnectConfig(ctx, cfg.ConnConfig)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	_, err = conn.Exec(ctx, fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s",
+		pgx.Identifier{dbName}.Sanitize(),
+		pgx.Identifier{template}.Sanitize(),
+	))
+	return err
+}
+
 func (mgr *Manager) Shutdown(p *shutdown.Process) error {
 	// Wait for all user code to finish before shutting down databases.
 	<-p.ServicesShutdownCompleted.Done()
@@ -0,0 +1,64 @@
+package sqldb
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+)
+
+// explainTimeout is the maximum time to spend capturing a query plan.
+const explainTimeout = 5 * time.Second
+
+// tracedQuery is a query recorded in the trace of the current request.
+type tracedQuery struct {
+	trace   trace2.Logger
+	params  trace2.EventParams
+	startID model.TraceEventID
+	start   time.Time
+}
+
+// explainSlowQuery records the plan of a traced query that completed
+// successfully if it took at least the configured latency threshold.
+//
+// The plan is captured using the pool rather than the connection that ran
+// the query, which may be busy returning rows or part of a transaction.
+func (mgr *Manager) explainSlowQuery(ctx context.Context, pool *pgxpool.Pool, q tracedQuery, query string, args []any) {
+	cfg := mgr.runtime.TraceQueryPlans
+	if cfg == nil || q.trace == nil || time.Since(q.start) < cfg.LatencyThreshold || !explainable(query) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, explainTimeout)
+	defer cancel()
+
+	var plan []byte
+	if err := pool.QueryRow(markTraced(ctx), "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&plan); err != nil {
+		mgr.rt.Logger().Debug().Err(err).Msg("sqldb: unable to capture query plan")
+		return
+	}
+	q.trace.DBQueryPlan(trace2.DBQueryPlanParams{
+		EventParams: q.params,
+		QueryID:     q.startID,
+		Plan:        plan,
+	})
+}
+
+// explainable reports whether the query is a statement
+// PostgreSQL can produce a plan for.
+func explainable(query string) bool {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return false
+	}
+	switch strings.ToUpper(strings.TrimLeft(fields[0], "(")) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "MERGE", "WITH", "VALUES", "TABLE":
+		return true
+	default:
+		return false
+	}
+}
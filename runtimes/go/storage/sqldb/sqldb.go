@@ -6,8 +6,10 @@ package sqldb
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/stack"
@@ -30,8 +32,10 @@ type ExecResult interface {
 //
 // See *database/sql.Tx for additional documentation.
 type Tx struct {
-	mgr *Manager
-	std pgx.Tx
+	mgr  *Manager
+	db   string        // the name of the database
+	pool *pgxpool.Pool // the pool the transaction belongs to
+	std  pgx.Tx
 
 	startID model.TraceEventID
 }
@@ -47,8 +51,10 @@ func (tx *Tx) Commit() error { return tx.commit() }
 func (tx *Tx) Rollback() error { return tx.rollback() }
 
 func (tx *Tx) commit() error {
+	op := tx.mgr.metrics.StartInfraOp("sqldb", tx.db, "commit")
 	err := tx.std.Commit(markTraced(context.Background()))
 	err = convertErr(err)
+	op.End(err)
 
 	if curr := tx.mgr.rt.Current(); curr.Req != nil && curr.Trace != nil {
 		curr.Trace.DBTransactionEnd(trace2.DBTransactionEndParams{
@@ -69,8 +75,10 @@ func (tx *Tx) commit() error {
 }
 
 func (tx *Tx) rollback() error {
+	op := tx.mgr.metrics.StartInfraOp("sqldb", tx.db, "rollback")
 	err := tx.std.Rollback(markTraced(context.Background()))
 	err = convertErr(err)
+	op.End(err)
 
 	if curr := tx.mgr.rt.Current(); curr.Req != nil && curr.Trace != nil {
 		curr.Trace.DBTransactionEnd(trace2.DBTransactionEndParams{
@@ -117,11 +125,22 @@ func (tx *Tx) exec(ctx context.Context, query string, args ...interface{}) (Exec
 		})
 	}
 
-	res, err := tx.std.Exec(markTraced(ctx), query, args...)
-	err = convertErr(err)
+	op := tx.mgr.metrics.StartInfraOp("sqldb", tx.db, "exec")
+	start := time.Now()
+	var res ExecResult
+	err := tx.mgr.injectFault(ctx, tx.db)
+	if err == nil {
+		res, err = tx.std.Exec(markTraced(ctx), query, args...)
+		err = convertErr(err)
+	}
+	op.End(err)
 
 	if startEventID > 0 {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+		if err == nil {
+			q := tracedQuery{trace: curr.Trace, params: eventParams, startID: startEventID, start: start}
+			tx.mgr.explainSlowQuery(ctx, tx.pool, q, query, args)
+		}
 	}
 
 	return res, err
@@ -150,11 +169,22 @@ func (tx *Tx) Query(ctx context.Context, query string, args ...interface{}) (*Ro
 		})
 	}
 
-	rows, err := tx.std.Query(markTraced(ctx), query, args...)
-	err = convertErr(err)
+	op := tx.mgr.metrics.StartInfraOp("sqldb", tx.db, "query")
+	start := time.Now()
+	var rows pgx.Rows
+	err := tx.mgr.injectFault(ctx, tx.db)
+	if err == nil {
+		rows, err = tx.std.Query(markTraced(ctx), query, args...)
+		err = convertErr(err)
+	}
+	op.End(err)
 
 	if startEventID > 0 {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+		if err == nil {
+			q := tracedQuery{trace: curr.Trace, params: eventParams, startID: startEventID, start: start}
+			tx.mgr.explainSlowQuery(ctx, tx.pool, q, query, args)
+		}
 	}
 
 	if err != nil {
@@ -178,7 +208,7 @@ func (tx *Tx) QueryRow(ctx context.Context, query string, args ...interface{}) *
 			Goid:    curr.Goctr,
 			DefLoc:  0,
 		}
-		curr.Trace.DBQueryStart(trace2.DBQueryStartParams{
+		startEventID = curr.Trace.DBQueryStart(trace2.DBQueryStartParams{
 			EventParams: eventParams,
 			Query:       query,
 			TxStartID:   tx.startID,
@@ -186,14 +216,25 @@ func (tx *Tx) QueryRow(ctx context.Context, query string, args ...interface{}) *
 		})
 	}
 
+	op := tx.mgr.metrics.StartInfraOp("sqldb", tx.db, "query_row")
+	start := time.Now()
 	// pgx currently does not support .Err() on Row.
 	// Work around this by using Query.
-	rows, err := tx.std.Query(markTraced(ctx), query, args...)
-	err = convertErr(err)
+	var rows pgx.Rows
+	err := tx.mgr.injectFault(ctx, tx.db)
+	if err == nil {
+		rows, err = tx.std.Query(markTraced(ctx), query, args...)
+		err = convertErr(err)
+	}
+	op.End(err)
 	r := &Row{rows: rows, err: err}
 
 	if startEventID > 0 {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+		if err == nil {
+			q := tracedQuery{trace: curr.Trace, params: eventParams, startID: startEventID, start: start}
+			tx.mgr.explainSlowQuery(ctx, tx.pool, q, query, args)
+		}
 	}
 
 	return r
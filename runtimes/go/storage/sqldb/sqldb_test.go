@@ -91,3 +91,53 @@ func TestDBConf(t *testing.T) {
 		}
 	}
 }
+
+func TestGetDB_Template(t *testing.T) {
+	mgr := NewManager(&config.Runtime{
+		SQLServers: []*config.SQLServer{{Host: "localhost:5432"}},
+		SQLDatabases: []*config.SQLDatabase{
+			{EncoreName: "shared", DatabaseName: "shared"},
+			{EncoreName: "templated", DatabaseName: "templated", TemplateDatabaseName: "templated-app_template"},
+		},
+	}, nil, nil, nil)
+
+	if db := mgr.GetDB("shared"); db.name != "shared" || db.template != "" || db.pool == nil {
+		t.Errorf("shared: got name %q, template %q", db.name, db.template)
+	}
+
+	// Databases with a template get a database of their own, created on first use.
+	db := mgr.GetDB("templated")
+	if !strings.HasPrefix(db.name, "templated-app_") || db.template != "templated-app_template" || db.pool != nil {
+		t.Errorf("templated: got name %q, template %q", db.name, db.template)
+	}
+	if IsTestDatabase(db) {
+		t.Error("templated: got test database, want package database")
+	}
+	if db2 := mgr.GetDB("templated"); db2 != db {
+		t.Error("templated: got a new database on second use")
+	}
+}
+
+func TestExplainable(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "SELECT 1", want: true},
+		{query: "  select id FROM foo", want: true},
+		{query: "(SELECT 1) UNION (SELECT 2)", want: true},
+		{query: "WITH x AS (SELECT 1) SELECT * FROM x", want: true},
+		{query: "INSERT INTO foo VALUES ($1)", want: true},
+		{query: "update foo SET x = 1", want: true},
+		{query: "DELETE FROM foo", want: true},
+		{query: "", want: false},
+		{query: "CREATE TABLE foo (id INT)", want: false},
+		{query: "BEGIN", want: false},
+		{query: "EXPLAIN SELECT 1", want: false},
+	}
+	for _, tt := range tests {
+		if got := explainable(tt.query); got != tt.want {
+			t.Errorf("explainable(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
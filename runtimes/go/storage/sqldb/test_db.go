@@ -3,6 +3,7 @@ package sqldb
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/jackc/pgx/v5"
@@ -16,8 +17,11 @@ func (mgr *Manager) NewTestDatabase(ctx context.Context, name string) (*Database
 		return nil, fmt.Errorf("et: unknown database name: %q", name)
 	}
 
-	dbName := db.origName + "_" + xid.New().String()
 	templateName := db.origName + "_template"
+	if cfg := mgr.dbConfig(name); cfg != nil && cfg.TemplateDatabaseName != "" {
+		templateName = cfg.TemplateDatabaseName
+	}
+	dbName := strings.TrimSuffix(templateName, "_template") + "_" + xid.New().String()
 	if _, err := db.Exec(ctx, fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s",
 		pgx.Identifier{dbName}.Sanitize(),
 		pgx.Identifier{templateName}.Sanitize(),
@@ -29,6 +33,7 @@ func (mgr *Manager) NewTestDatabase(ctx context.Context, name string) (*Database
 		name:     dbName,
 		origName: db.origName,
 		mgr:      mgr,
+		testDB:   true,
 	}
 
 	mgr.ts.AddEndCallback(func(t *testing.T) {
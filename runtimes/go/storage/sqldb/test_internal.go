@@ -0,0 +1,11 @@
+package sqldb
+
+// IsTestDatabase is an internal API for Encore. This function should
+// never be directly called as it is considered an unstable API and Encore
+// can change it at any time
+//
+// It reports whether db was created by et.NewTestDatabase,
+// and is dropped when the test ends.
+func IsTestDatabase(db *Database) bool {
+	return db.testDB
+}
@@ -7,6 +7,7 @@ import (
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/metrics"
 )
 
 // Initialize the singleton instance.
@@ -18,6 +19,6 @@ import (
 var Singleton *Manager
 
 func init() {
-	Singleton = NewManager(appconf.Runtime, reqtrack.Singleton, testsupport.Singleton)
+	Singleton = NewManager(appconf.Runtime, reqtrack.Singleton, testsupport.Singleton, metrics.Singleton)
 	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
 }
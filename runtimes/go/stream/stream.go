@@ -0,0 +1,111 @@
+// Package stream lets APIs stream request and response bodies,
+// so large uploads and downloads don't need to be buffered in memory.
+//
+// An API streams its request body by taking a *stream.Body as its last
+// parameter, and streams its response body by returning one:
+//
+//	type UploadParams struct {
+//		Name string `query:"name"`
+//	}
+//
+//	//encore:api auth method=PUT path=/files/:id
+//	func Upload(ctx context.Context, id string, p *UploadParams, body *stream.Body) (*File, error) {
+//		// Read the uploaded file from body.
+//	}
+//
+//	type DownloadResponse struct {
+//		Disposition string `header:"Content-Disposition"`
+//	}
+//
+//	//encore:api auth method=GET path=/files/:id
+//	func Download(ctx context.Context, id string) (*DownloadResponse, *stream.Body, error) {
+//		f, size, err := open(ctx, id)
+//		if err != nil {
+//			return nil, nil, err
+//		}
+//		return &DownloadResponse{Disposition: "attachment"}, stream.NewBody(f, "application/pdf", size), nil
+//	}
+//
+// The rest of the request and response, such as path parameters, query strings
+// and headers, is typed like in other APIs. Since the body is streamed,
+// they can't have fields encoded in the body. APIs can omit the response
+// metadata by returning (*stream.Body, error) instead.
+package stream
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// DefaultContentType is the content type of bodies that don't specify one.
+const DefaultContentType = "application/octet-stream"
+
+// Body is a request or response body streamed between an API and its client.
+type Body struct {
+	// ContentType is the media type of the body, such as "image/png".
+	ContentType string
+
+	// Size is the size of the body in bytes, or -1 if it's unknown.
+	Size int64
+
+	r io.Reader
+}
+
+// NewBody returns a body streaming the contents of r, of the given
+// content type and size. If the size is unknown it should be -1.
+//
+// When returned by an API, the body is closed once it's been sent
+// if r implements io.Closer.
+func NewBody(r io.Reader, contentType string, size int64) *Body {
+	if contentType == "" {
+		contentType = DefaultContentType
+	}
+	return &Body{ContentType: contentType, Size: size, r: r}
+}
+
+// Read reads from the body.
+func (b *Body) Read(p []byte) (int, error) {
+	if b.r == nil {
+		return 0, io.EOF
+	}
+	return b.r.Read(p)
+}
+
+// Close closes the body's reader if it implements io.Closer.
+func (b *Body) Close() error {
+	if c, ok := b.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Write writes b as the body of a response, closing it once it's been written.
+// If b is nil the response has an empty body.
+//
+//publicapigen:drop
+func Write(w http.ResponseWriter, b *Body) error {
+	if b == nil {
+		w.Header().Set("Content-Length", "0")
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	defer func() { _ = b.Close() }()
+
+	contentType := b.ContentType
+	if contentType == "" {
+		contentType = DefaultContentType
+	}
+	w.Header().Set("Content-Type", contentType)
+	if b.Size >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(b.Size, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	n, err := io.Copy(w, b)
+	if err == nil && b.Size >= 0 && n != b.Size {
+		err = errors.New("stream: body size doesn't match its contents")
+	}
+	return err
+}
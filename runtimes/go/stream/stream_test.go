@@ -0,0 +1,68 @@
+package stream
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestWrite(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	r := &closeTracker{Reader: strings.NewReader("hello")}
+	c.Assert(Write(rec, NewBody(r, "text/plain", 5)), qt.IsNil)
+	c.Assert(rec.Code, qt.Equals, 200)
+	c.Assert(rec.Header().Get("Content-Type"), qt.Equals, "text/plain")
+	c.Assert(rec.Header().Get("Content-Length"), qt.Equals, "5")
+	c.Assert(rec.Body.String(), qt.Equals, "hello")
+	c.Assert(r.closed, qt.IsTrue)
+}
+
+func TestWrite_UnknownSize(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	c.Assert(Write(rec, NewBody(strings.NewReader("hello"), "", -1)), qt.IsNil)
+	c.Assert(rec.Header().Get("Content-Type"), qt.Equals, DefaultContentType)
+	c.Assert(rec.Header().Get("Content-Length"), qt.Equals, "")
+	c.Assert(rec.Body.String(), qt.Equals, "hello")
+}
+
+func TestWrite_SizeMismatch(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	err := Write(rec, NewBody(strings.NewReader("hello"), "", 10))
+	c.Assert(err, qt.ErrorMatches, "stream: body size doesn't match its contents")
+}
+
+func TestWrite_Nil(t *testing.T) {
+	c := qt.New(t)
+	rec := httptest.NewRecorder()
+	c.Assert(Write(rec, nil), qt.IsNil)
+	c.Assert(rec.Code, qt.Equals, 200)
+	c.Assert(rec.Body.Len(), qt.Equals, 0)
+}
+
+func TestBody_Read(t *testing.T) {
+	c := qt.New(t)
+	data, err := io.ReadAll(NewBody(strings.NewReader("hello"), "", -1))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(data), qt.Equals, "hello")
+
+	// The zero value is an empty body.
+	data, err = io.ReadAll(&Body{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(data, qt.HasLen, 0)
+}
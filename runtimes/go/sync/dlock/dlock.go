@@ -0,0 +1,223 @@
+// Package dlock provides distributed locks, for coordinating work
+// between all running instances of an application.
+//
+// A lock is held through a lease that expires unless it's renewed,
+// so a lock held by an instance that crashes is eventually released.
+// Leases are renewed automatically for as long as they are held.
+//
+//	var db = sqldb.NewDatabase("reports", sqldb.DatabaseConfig{Migrations: "./migrations"})
+//
+//	var reportLock = dlock.NewMutex("daily-report", dlock.Config{
+//		Store: dlock.SQLDB(db),
+//		TTL:   30 * time.Second,
+//	})
+//
+//	//encore:api private
+//	func GenerateReport(ctx context.Context) error {
+//		// Skip this run if the previous one is still running.
+//		lease, err := reportLock.TryLock(ctx)
+//		if errors.Is(err, dlock.ErrLocked) {
+//			return nil
+//		} else if err != nil {
+//			return err
+//		}
+//		defer lease.Release(context.Background())
+//
+//		// Use lease.Context() to stop working if the lease is lost.
+//		return generate(lease.Context(), lease.Token)
+//	}
+//
+// For more information see https://encore.dev/docs/go/primitives/distributed-locks
+package dlock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// ErrLocked is reported by TryLock when the lock is held by someone else.
+var ErrLocked = errors.New("dlock: lock is held by someone else")
+
+// Config configures a Mutex.
+type Config struct {
+	// Store is where the lock is kept.
+	// It must be either SQLDB or Cache.
+	Store Store
+
+	// TTL is how long a lease is valid for unless it's renewed.
+	// Leases are renewed automatically when a third of the TTL has passed.
+	// If zero it defaults to 30 seconds.
+	TTL time.Duration
+
+	// RetryInterval is how often Lock tries to acquire a lock
+	// that's held by someone else. If zero it defaults to 250ms.
+	RetryInterval time.Duration
+}
+
+// Mutex is a distributed mutual exclusion lock.
+// Use NewMutex to create one.
+type Mutex struct {
+	name string
+	cfg  Config
+}
+
+// NewMutex returns a mutex with the given name.
+// Mutexes with the same name and store refer to the same lock,
+// across all instances of the application.
+func NewMutex(name string, cfg Config) *Mutex {
+	if cfg.Store == nil {
+		panic("dlock: mutex " + name + " has no store")
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 30 * time.Second
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = 250 * time.Millisecond
+	}
+	return &Mutex{name: name, cfg: cfg}
+}
+
+// Name returns the name of the mutex.
+func (m *Mutex) Name() string {
+	return m.name
+}
+
+// TryLock acquires the lock if it's free.
+// If it's held by someone else it reports ErrLocked.
+func (m *Mutex) TryLock(ctx context.Context) (*Lease, error) {
+	owner := xid.New().String()
+	token, ok, err := m.cfg.Store.acquire(ctx, m.name, owner, m.cfg.TTL)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrLocked
+	}
+	return newLease(m, owner, token), nil
+}
+
+// Lock acquires the lock, waiting for it to become free if necessary.
+// It reports an error if ctx is canceled before the lock is acquired.
+func (m *Mutex) Lock(ctx context.Context) (*Lease, error) {
+	for {
+		lease, err := m.TryLock(ctx)
+		if !errors.Is(err, ErrLocked) {
+			return lease, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(m.cfg.RetryInterval):
+		}
+	}
+}
+
+// Do acquires the lock, waiting for it to become free if necessary,
+// and calls fn while holding it. The context passed to fn is canceled
+// if the lease is lost or ctx is canceled.
+func (m *Mutex) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	lease, err := m.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lease.Release(context.WithoutCancel(ctx)) }()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(lease.Context(), cancel)
+	defer stop()
+	return fn(ctx)
+}
+
+// Lease is a held lock.
+type Lease struct {
+	// Token is the fencing token of the lease. Tokens increase each time
+	// the lock is acquired, so storage systems that record the highest token
+	// seen can reject writes made by previous holders of the lock
+	// whose lease has since expired.
+	Token uint64
+
+	mu      *Mutex
+	owner   string
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stopped chan struct{}
+
+	releaseOnce sync.Once
+	releaseErr  error
+}
+
+func newLease(m *Mutex, owner string, token uint64) *Lease {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &Lease{
+		Token:   token,
+		mu:      m,
+		owner:   owner,
+		ctx:     ctx,
+		cancel:  cancel,
+		stopped: make(chan struct{}),
+	}
+	go l.renew()
+	return l
+}
+
+// Context returns a context that is canceled when the lease
+// is released, or lost because it could not be renewed in time.
+func (l *Lease) Context() context.Context {
+	return l.ctx
+}
+
+// Release releases the lock. It's safe to call more than once.
+func (l *Lease) Release(ctx context.Context) error {
+	l.releaseOnce.Do(func() {
+		l.cancel()
+		<-l.stopped
+		l.releaseErr = l.mu.cfg.Store.release(ctx, l.mu.name, l.owner)
+	})
+	return l.releaseErr
+}
+
+// renew renews the lease until it's released or lost.
+func (l *Lease) renew() {
+	defer close(l.stopped)
+	ttl := l.mu.cfg.TTL
+	expires := time.Now().Add(ttl)
+	wait := ttl / 3
+
+	for {
+		wait = min(wait, time.Until(expires))
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		// Give up once the lease has expired,
+		// as someone else may have acquired the lock by then.
+		if !time.Now().Before(expires) {
+			l.cancel()
+			return
+		}
+
+		ctx, cancel := context.WithDeadline(l.ctx, expires)
+		start := time.Now()
+		ok, err := l.mu.cfg.Store.renew(ctx, l.mu.name, l.owner, ttl)
+		cancel()
+		switch {
+		case err == nil && !ok:
+			// The lease has been taken over.
+			l.cancel()
+			return
+		case err == nil:
+			expires = start.Add(ttl)
+			wait = ttl / 3
+		default:
+			// Retry sooner, while the lease is still valid.
+			wait = ttl / 10
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package dlock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/clock"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/storage/cache"
+)
+
+func newTestMutex(t *testing.T, ttl time.Duration) (*Mutex, *miniredis.Miniredis) {
+	srv := miniredis.RunT(t)
+	cl := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = cl.Close() })
+	return NewMutex("report", Config{Store: CacheInternal(cl), TTL: ttl, RetryInterval: 10 * time.Millisecond}), srv
+}
+
+func TestMutex_TryLock(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestMutex(t, time.Minute)
+
+	lease, err := m.TryLock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.TryLock(ctx); !errors.Is(err, ErrLocked) {
+		t.Fatalf("got err %v, want ErrLocked", err)
+	}
+	if err := lease.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if lease.Context().Err() == nil {
+		t.Error("expected the lease context to be canceled after release")
+	}
+
+	next, err := m.TryLock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer next.Release(ctx)
+	if next.Token <= lease.Token {
+		t.Errorf("got token %d, want it to be greater than %d", next.Token, lease.Token)
+	}
+}
+
+func TestMutex_Lock(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestMutex(t, time.Minute)
+
+	lease, err := m.Lock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acquired := make(chan *Lease)
+	go func() {
+		next, err := m.Lock(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- next
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("lock acquired while held")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if err := lease.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case next := <-acquired:
+		_ = next.Release(ctx)
+	case <-time.After(time.Second):
+		t.Fatal("lock not acquired after release")
+	}
+
+	// Waiting for a held lock stops when the context is canceled.
+	lease, err = m.Lock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lease.Release(ctx)
+	ctx2, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := m.Lock(ctx2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got err %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestLease_Lost(t *testing.T) {
+	ctx := context.Background()
+	m, srv := newTestMutex(t, 150*time.Millisecond)
+
+	lease, err := m.TryLock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lease.Release(ctx)
+
+	// The lease is renewed while it's held.
+	time.Sleep(200 * time.Millisecond)
+	if lease.Context().Err() != nil {
+		t.Fatal("lease lost while held")
+	}
+
+	// Someone else taking over the lock cancels the lease.
+	key := (&cacheStore{}).keys(m.name)[0]
+	if err := srv.Set(key, "someone-else"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-lease.Context().Done():
+	case <-time.After(time.Second):
+		t.Fatal("lease not lost after being taken over")
+	}
+}
+
+func TestMutex_Do(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestMutex(t, time.Minute)
+
+	called := false
+	err := m.Do(ctx, func(ctx context.Context) error {
+		called = true
+		if _, err := m.TryLock(ctx); !errors.Is(err, ErrLocked) {
+			t.Errorf("got err %v, want ErrLocked within Do", err)
+		}
+		return nil
+	})
+	if err != nil || !called {
+		t.Fatalf("got (%v, called=%v), want fn to be called", err, called)
+	}
+
+	lease, err := m.TryLock(ctx)
+	if err != nil {
+		t.Fatalf("lock not released after Do: %v", err)
+	}
+	_ = lease.Release(ctx)
+}
+
+func TestCache_TestMode(t *testing.T) {
+	// In tests cache clusters use an in-memory store instead of Redis,
+	// which must support the scripts used by the store.
+	static := &config.Static{Testing: true}
+	rt := reqtrack.New(zerolog.Nop(), nil, nil)
+	ts := testsupport.NewManager(static, rt, zerolog.Nop())
+	ts.SetTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	mgr := cache.NewManager(static, nil, rt, ts, clock.New(static, ts), nil, nil)
+	store := Cache(cache.NewClusterInternal(mgr, "locks", cache.ClusterConfig{}))
+
+	ctx := context.Background()
+	cfg := Config{Store: store, TTL: 150 * time.Millisecond, RetryInterval: 10 * time.Millisecond}
+	m, other := NewMutex("report", cfg), NewMutex("report", cfg)
+
+	lease, err := m.TryLock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.TryLock(ctx); !errors.Is(err, ErrLocked) {
+		t.Fatalf("got err %v, want ErrLocked", err)
+	}
+
+	// The lease is renewed while it's held.
+	time.Sleep(100 * time.Millisecond)
+	if lease.Context().Err() != nil {
+		t.Fatal("lease lost while held")
+	}
+	if err := lease.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := other.TryLock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.Token <= lease.Token {
+		t.Errorf("got token %d, want it to be greater than %d", next.Token, lease.Token)
+	}
+
+	// Leases expire according to the test's time.
+	ts.AdvanceTime(time.Minute)
+	taken, err := m.TryLock(ctx)
+	if err != nil {
+		t.Fatalf("lock not acquired after the lease expired: %v", err)
+	}
+	defer taken.Release(ctx)
+	select {
+	case <-next.Context().Done():
+	case <-time.After(time.Second):
+		t.Fatal("expired lease not lost")
+	}
+}
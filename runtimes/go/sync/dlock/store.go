@@ -0,0 +1,167 @@
+package dlock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"encore.dev/storage/cache"
+	"encore.dev/storage/sqldb"
+)
+
+// Store keeps the state of locks.
+// Use SQLDB or Cache to create a Store.
+type Store interface {
+	// acquire acquires the named lock for owner if it's free or its lease
+	// has expired, and returns the new fencing token.
+	acquire(ctx context.Context, name, owner string, ttl time.Duration) (token uint64, ok bool, err error)
+
+	// renew extends the lease of the named lock if it's still held by owner.
+	renew(ctx context.Context, name, owner string, ttl time.Duration) (ok bool, err error)
+
+	// release releases the named lock if it's held by owner.
+	release(ctx context.Context, name, owner string) error
+}
+
+// Schema is the SQL needed to create the table used by SQLDB.
+// Add it to a migration for the database passed to SQLDB.
+const Schema = `CREATE TABLE encore_dlocks (
+	name       TEXT PRIMARY KEY,
+	owner      TEXT NOT NULL,
+	token      BIGINT NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL
+);
+`
+
+// SQLDB returns a Store that keeps locks in the encore_dlocks table
+// of the given database, which must be created using Schema.
+//
+// Locks are kept as lease records. Unlike session-level advisory locks,
+// lease records don't tie up a database connection while the lock is held,
+// and let locks survive connection failures for the duration of the lease.
+func SQLDB(db *sqldb.Database) Store {
+	return &sqldbStore{db: db}
+}
+
+type sqldbStore struct {
+	db *sqldb.Database
+}
+
+func (s *sqldbStore) acquire(ctx context.Context, name, owner string, ttl time.Duration) (uint64, bool, error) {
+	// Released locks keep their row, so that tokens keep increasing.
+	var token uint64
+	err := s.db.QueryRow(ctx, `
+		INSERT INTO encore_dlocks (name, owner, token, expires_at)
+		VALUES ($1, $2, 1, now() + $3::bigint * interval '1 millisecond')
+		ON CONFLICT (name) DO UPDATE
+		SET owner = excluded.owner, token = encore_dlocks.token + 1, expires_at = excluded.expires_at
+		WHERE encore_dlocks.expires_at < now()
+		RETURNING token`, name, owner, ttl.Milliseconds()).Scan(&token)
+	if errors.Is(err, sqldb.ErrNoRows) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	return token, true, nil
+}
+
+func (s *sqldbStore) renew(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	res, err := s.db.Exec(ctx, `
+		UPDATE encore_dlocks SET expires_at = now() + $3::bigint * interval '1 millisecond'
+		WHERE name = $1 AND owner = $2 AND expires_at >= now()`, name, owner, ttl.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (s *sqldbStore) release(ctx context.Context, name, owner string) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE encore_dlocks SET owner = '', expires_at = '-infinity'
+		WHERE name = $1 AND owner = $2`, name, owner)
+	return err
+}
+
+// Cache returns a Store that keeps locks in the given cache cluster.
+//
+// Fencing tokens are kept in a counter in the cache, which is reset if
+// the cache evicts it. Use a cluster with a volatile eviction policy
+// (or NoEviction) if the tokens must always increase.
+func Cache(cluster *cache.Cluster) Store {
+	return CacheInternal(cluster.RedisClient())
+}
+
+// CacheInternal returns a Store that keeps locks using the given Redis client.
+//
+//publicapigen:drop
+func CacheInternal(cl *redis.Client) Store {
+	return &cacheStore{cl: cl}
+}
+
+type cacheStore struct {
+	cl *redis.Client
+}
+
+var (
+	acquireScript = cache.NewScriptInternal(`
+		if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+			return redis.call("INCR", KEYS[2])
+		end
+		return 0`,
+		func(call func(args ...string) (any, error), keys, args []string) (any, error) {
+			if ok, err := call("SET", keys[0], args[0], "NX", "PX", args[1]); err != nil || ok == nil {
+				return int64(0), err
+			}
+			return call("INCR", keys[1])
+		})
+
+	renewScript = cache.NewScriptInternal(`
+		if redis.call("GET", KEYS[1]) == ARGV[1] then
+			return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+		end
+		return 0`,
+		func(call func(args ...string) (any, error), keys, args []string) (any, error) {
+			if owner, err := call("GET", keys[0]); err != nil || owner != args[0] {
+				return int64(0), err
+			}
+			return call("PEXPIRE", keys[0], args[1])
+		})
+
+	releaseScript = cache.NewScriptInternal(`
+		if redis.call("GET", KEYS[1]) == ARGV[1] then
+			return redis.call("DEL", KEYS[1])
+		end
+		return 0`,
+		func(call func(args ...string) (any, error), keys, args []string) (any, error) {
+			if owner, err := call("GET", keys[0]); err != nil || owner != args[0] {
+				return int64(0), err
+			}
+			return call("DEL", keys[0])
+		})
+)
+
+// keys returns the keys of the lock and its token counter.
+// They share a hash tag so they are kept on the same node.
+func (s *cacheStore) keys(name string) []string {
+	key := "__encore/dlock/{" + name + "}"
+	return []string{key, key + "/token"}
+}
+
+func (s *cacheStore) acquire(ctx context.Context, name, owner string, ttl time.Duration) (uint64, bool, error) {
+	token, err := acquireScript.Run(ctx, s.cl, s.keys(name), owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return 0, false, err
+	}
+	return uint64(token), token > 0, nil
+}
+
+func (s *cacheStore) renew(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	n, err := renewScript.Run(ctx, s.cl, s.keys(name)[:1], owner, ttl.Milliseconds()).Int64()
+	return n > 0, err
+}
+
+func (s *cacheStore) release(ctx context.Context, name, owner string) error {
+	return releaseScript.Run(ctx, s.cl, s.keys(name)[:1], owner).Err()
+}
@@ -0,0 +1,56 @@
+// Package leader provides leader election, for background work that
+// must run on exactly one instance of a horizontally scaled service,
+// such as compaction jobs or polling an external system.
+//
+// Run campaigns for leadership and calls the given function once elected.
+// It's typically started in a goroutine when the service starts:
+//
+//	var db = sqldb.NewDatabase("ingest", sqldb.DatabaseConfig{Migrations: "./migrations"})
+//
+//	func initService() (*Service, error) {
+//		ctx, cancel := context.WithCancel(context.Background())
+//		go leader.Run(ctx, "poll-feeds", pollFeeds, leader.WithStore(dlock.SQLDB(db)))
+//		return &Service{cancel: cancel}, nil
+//	}
+//
+//	func pollFeeds(ctx context.Context) error {
+//		for {
+//			// ... poll feeds until ctx is canceled
+//		}
+//	}
+//
+// Leadership is held through a lease, as with the locks in the dlock package.
+// If the leader crashes or can't renew its lease another instance takes over,
+// and the context passed to the function is canceled if leadership is lost.
+//
+// For more information see https://encore.dev/docs/go/primitives/leader-election
+package leader
+
+import (
+	"time"
+
+	"encore.dev/sync/dlock"
+)
+
+// Option customizes an election.
+type Option func(*options)
+
+type options struct {
+	store dlock.Store
+	ttl   time.Duration
+}
+
+// WithStore sets the store that keeps track of the current leader.
+// It's required, as there is no default store, and must be either
+// dlock.SQLDB or dlock.Cache. The table used by dlock.SQLDB must be
+// created using dlock.Schema.
+func WithStore(store dlock.Store) Option {
+	return func(o *options) { o.store = store }
+}
+
+// WithTTL sets how long leadership lasts unless it's renewed, which
+// also bounds how long it takes for another instance to take over
+// if the leader goes away. If not set it defaults to 15 seconds.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) { o.ttl = ttl }
+}
@@ -0,0 +1,222 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/traceprovider"
+	"encore.dev/appruntime/shared/traceprovider/tracecapture"
+	"encore.dev/rlog"
+	"encore.dev/sync/dlock"
+	"encore.dev/trace"
+)
+
+func newTestManager() *Manager {
+	return newTestManagerFor(reqtrack.New(zerolog.Logger{}, nil, nil))
+}
+
+func newTestManagerFor(rt *reqtrack.RequestTracker) *Manager {
+	return NewManager(rt, rlog.NewManager(nil, &config.Runtime{}, rt, nil, nil), trace.NewManager(rt))
+}
+
+func newTestStore(t *testing.T) (dlock.Store, *miniredis.Miniredis) {
+	srv := miniredis.RunT(t)
+	cl := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = cl.Close() })
+	return dlock.CacheInternal(cl), srv
+}
+
+func TestRun_SingleLeader(t *testing.T) {
+	store, _ := newTestStore(t)
+	mgr := newTestManager()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var leaders, elected atomic.Int32
+	done := make(chan error, 2)
+	for range 2 {
+		go func() {
+			done <- mgr.Run(ctx, "compaction", func(ctx context.Context) error {
+				if leaders.Add(1) > 1 {
+					t.Error("more than one leader at a time")
+				}
+				elected.Add(1)
+				<-ctx.Done()
+				leaders.Add(-1)
+				return ctx.Err()
+			}, WithStore(store), WithTTL(150*time.Millisecond))
+		}()
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	if got := elected.Load(); got != 1 {
+		t.Fatalf("got %d elections, want 1", got)
+	}
+	cancel()
+	for range 2 {
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("got err %v, want context.Canceled", err)
+		}
+	}
+}
+
+func TestRun_StepDown(t *testing.T) {
+	store, _ := newTestStore(t)
+	mgr := newTestManager()
+	ctx := context.Background()
+
+	errDone := errors.New("done")
+	for i := range 2 {
+		// Each run is elected once the previous one has stepped down.
+		err := mgr.Run(ctx, "compaction", func(ctx context.Context) error {
+			return errDone
+		}, WithStore(store), WithTTL(time.Minute))
+		if err != errDone {
+			t.Fatalf("run %d: got err %v, want %v", i, err, errDone)
+		}
+	}
+}
+
+func TestRun_Traced(t *testing.T) {
+	store, _ := newTestStore(t)
+	rt := reqtrack.New(zerolog.Nop(), nil, tracecapture.NewFactory(&traceprovider.DefaultFactory{}))
+	mgr := newTestManagerFor(rt)
+
+	req := &model.Request{
+		Type:    model.RPCCall,
+		TraceID: model.TraceID{1},
+		SpanID:  model.SpanID{1},
+		Traced:  true,
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "ingest", Endpoint: "Start"}},
+	}
+	rt.BeginRequest(req)
+	defer rt.FinishRequest(false)
+	log := rt.Current().Trace.(*tracecapture.Logger)
+	log.RequestSpanStart(req, 1)
+
+	errDone := errors.New("done")
+	err := mgr.Run(context.Background(), "compaction", func(ctx context.Context) error {
+		return errDone
+	}, WithStore(store), WithTTL(time.Minute))
+	if err != errDone {
+		t.Fatalf("got err %v, want %v", err, errDone)
+	}
+
+	spans := log.Trace(req.SpanID).AllEvents(tracecapture.CustomSpanEvent)
+	if len(spans) != 1 {
+		t.Fatalf("got %d custom spans, want 1", len(spans))
+	}
+	if sp := spans[0]; sp.Name != "leader: compaction" || !sp.Done || sp.Err != errDone {
+		t.Errorf("got custom span %+v", sp)
+	}
+}
+
+// captureFactory is a trace factory returning the same capturing logger for every trace.
+type captureFactory struct {
+	log *tracecapture.Logger
+}
+
+func (f captureFactory) NewLogger() trace2.Logger { return f.log }
+func (f captureFactory) SampleTrace() bool        { return true }
+
+func TestRun_TracedWithoutRequest(t *testing.T) {
+	store, _ := newTestStore(t)
+	log := tracecapture.NewLogger((&traceprovider.DefaultFactory{}).NewLogger())
+	mgr := newTestManagerFor(reqtrack.New(zerolog.Nop(), nil, captureFactory{log}))
+
+	// Terms of elections run outside of a request are traced as job spans.
+	errDone := errors.New("done")
+	var spanID model.SpanID
+	err := mgr.Run(context.Background(), "compaction", func(ctx context.Context) error {
+		spanID = mgr.rt.Current().Req.SpanID
+		return errDone
+	}, WithStore(store), WithTTL(time.Minute))
+	if err != errDone {
+		t.Fatalf("got err %v, want %v", err, errDone)
+	}
+
+	sp := log.Trace(spanID)
+	if sp == nil {
+		t.Fatal("term was not traced")
+	}
+	if sp.Kind != tracecapture.JobSpan || sp.Queue != "leader/compaction" || !sp.Done || sp.Err != errDone {
+		t.Errorf("got span %+v", sp)
+	}
+}
+
+func TestRun_LostLeadership(t *testing.T) {
+	store, srv := newTestStore(t)
+	mgr := newTestManager()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	elected := make(chan struct{}, 2)
+	done := make(chan error, 1)
+	go func() {
+		done <- mgr.Run(ctx, "compaction", func(ctx context.Context) error {
+			elected <- struct{}{}
+			<-ctx.Done()
+			return ctx.Err()
+		}, WithStore(store), WithTTL(150*time.Millisecond))
+	}()
+
+	select {
+	case <-elected:
+	case <-time.After(time.Second):
+		t.Fatal("not elected")
+	}
+
+	// Losing the lease cancels the leader, which then campaigns again.
+	srv.FlushAll()
+	select {
+	case <-elected:
+	case <-time.After(time.Second):
+		t.Fatal("not re-elected after losing leadership")
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("got err %v, want context.Canceled", err)
+	}
+}
+
+func TestRun_Shutdown(t *testing.T) {
+	store, _ := newTestStore(t)
+	mgr := newTestManager()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- mgr.Run(context.Background(), "compaction", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, WithStore(store))
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if err := mgr.Shutdown(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("got err %v, want context.Canceled", err)
+	}
+}
+
+func TestRun_NoStore(t *testing.T) {
+	err := newTestManager().Run(context.Background(), "compaction", func(ctx context.Context) error {
+		return nil
+	})
+	if err == nil {
+		t.Error("expected an error for an election without a store")
+	}
+}
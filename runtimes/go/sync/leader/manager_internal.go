@@ -0,0 +1,194 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/beta/errs"
+	"encore.dev/rlog"
+	"encore.dev/sync/dlock"
+	"encore.dev/trace"
+)
+
+// errLeadershipLost is recorded as the error of a term that ended
+// because leadership was lost.
+var errLeadershipLost = errors.New("leader: lost leadership")
+
+//publicapigen:drop
+type Manager struct {
+	rt      *reqtrack.RequestTracker
+	logs    *rlog.Manager
+	tracer  *trace.Manager
+	ctx     context.Context // canceled when the application shuts down
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+//publicapigen:drop
+func NewManager(rt *reqtrack.RequestTracker, logs *rlog.Manager, tracer *trace.Manager) *Manager {
+	mgr := &Manager{rt: rt, logs: logs, tracer: tracer}
+	mgr.ctx, mgr.cancel = context.WithCancel(context.Background())
+	return mgr
+}
+
+// Run campaigns for leadership of the named election and calls fn
+// while leading. See the package-level Run for details.
+func (mgr *Manager) Run(ctx context.Context, name string, fn func(ctx context.Context) error, opts ...Option) error {
+	o := options{ttl: 15 * time.Second}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.store == nil {
+		return fmt.Errorf("leader: election %q has no store, use leader.WithStore to set one", name)
+	}
+
+	mgr.running.Add(1)
+	defer mgr.running.Done()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(mgr.ctx, cancel)
+	defer stop()
+
+	// Elections are kept separately from locks with the same name.
+	mu := dlock.NewMutex("leader/"+name, dlock.Config{
+		Store:         o.store,
+		TTL:           o.ttl,
+		RetryInterval: o.ttl / 3,
+	})
+	log := mgr.logs.With("election", name)
+
+	for term := 1; ; {
+		lease, err := mu.Lock(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		} else if err != nil {
+			log.Warn("leader: failed to campaign for leadership, retrying", "err", err)
+			mgr.tracer.Event("leader: failed to campaign", "election", name, "err", err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(o.ttl / 3):
+			}
+			continue
+		}
+
+		log.Info("leader: elected leader", "token", lease.Token)
+		lost, err := mgr.lead(ctx, name, term, lease, fn)
+		term++
+		if !lost {
+			log.Info("leader: stepped down", "token", lease.Token)
+			return err
+		}
+		log.Warn("leader: lost leadership, campaigning again", "token", lease.Token, "err", err)
+	}
+}
+
+// lead calls fn for a term as leader, recording the term in the trace.
+//
+// When called while handling a request the term is recorded as a custom span
+// in the request's trace. Otherwise, such as when Run is started from initService,
+// the term is traced as its own job span, like a worker job, on the queue
+// "leader/<name>" with the term number as the attempt.
+func (mgr *Manager) lead(ctx context.Context, name string, term int, lease *dlock.Lease, fn func(ctx context.Context) error) (lost bool, err error) {
+	if mgr.rt.Current().Req != nil {
+		span := mgr.tracer.StartSpan("leader: "+name, "election", name, "token", lease.Token)
+		lost, err = holdLease(ctx, lease, fn)
+		span.End(termErr(lost, err), "lost", lost)
+		return lost, err
+	}
+
+	traceID, traceErr := model.GenTraceID()
+	spanID, spanErr := model.GenSpanID()
+	if traceErr != nil || spanErr != nil {
+		return holdLease(ctx, lease, fn)
+	}
+
+	token := strconv.FormatUint(lease.Token, 10)
+	req := &model.Request{
+		Type:    model.WorkerJob,
+		TraceID: traceID,
+		SpanID:  spanID,
+		Start:   time.Now(),
+		JobData: &model.JobData{
+			Queue:      "leader/" + name,
+			JobID:      token,
+			EnqueuedAt: time.Now(),
+			Attempt:    term,
+		},
+		Traced: mgr.rt.SampleTrace(),
+	}
+	reqLogger := mgr.rt.Logger().With().
+		Str("election", name).
+		Str("token", token).
+		Str("trace_id", traceID.String()).
+		Logger()
+	req.Logger = &reqLogger
+
+	mgr.rt.BeginOperation()
+	defer mgr.rt.FinishOperation()
+	mgr.rt.BeginRequest(req)
+	defer mgr.rt.FinishRequest(false)
+
+	curr := mgr.rt.Current()
+	if curr.Trace != nil {
+		curr.Trace.JobSpanStart(req, curr.Goctr)
+		defer func() {
+			spanErr := termErr(lost, err)
+			curr.Trace.JobSpanEnd(trace2.JobSpanEndParams{
+				EventParams: trace2.EventParams{
+					TraceID: req.TraceID,
+					SpanID:  req.SpanID,
+				},
+				Req: req,
+				Resp: &model.Response{
+					Duration:   time.Since(req.Start),
+					Err:        spanErr,
+					HTTPStatus: errs.HTTPStatus(spanErr),
+				},
+			})
+		}()
+	}
+
+	return holdLease(ctx, lease, fn)
+}
+
+// termErr returns the error to record for a term that ended with err,
+// marking terms that ended because leadership was lost.
+func termErr(lost bool, err error) error {
+	if lost && err == nil {
+		return errLeadershipLost
+	} else if lost {
+		return fmt.Errorf("%w: %w", errLeadershipLost, err)
+	}
+	return err
+}
+
+// holdLease calls fn while holding the lease, and releases it once fn returns.
+// It reports whether fn returned because the lease was lost.
+func holdLease(ctx context.Context, lease *dlock.Lease, fn func(ctx context.Context) error) (lost bool, err error) {
+	defer func() { _ = lease.Release(context.WithoutCancel(ctx)) }()
+
+	leadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(lease.Context(), cancel)
+	defer stop()
+
+	err = fn(leadCtx)
+	return lease.Context().Err() != nil && ctx.Err() == nil, err
+}
+
+// Shutdown stops all elections, stepping down from those being led
+// so other instances can take over, and waits for them to complete.
+func (mgr *Manager) Shutdown(p *shutdown.Process) error {
+	mgr.cancel()
+	mgr.running.Wait()
+	return nil
+}
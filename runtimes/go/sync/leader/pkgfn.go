@@ -0,0 +1,40 @@
+//go:build encore_app
+
+package leader
+
+import (
+	"context"
+
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/rlog"
+	"encore.dev/trace"
+)
+
+//publicapigen:drop
+var Singleton = NewManager(reqtrack.Singleton, rlog.Singleton, trace.Singleton)
+
+func init() {
+	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+}
+
+// Run campaigns for leadership of the named election, and calls fn once
+// this instance is elected leader. Elections with the same name and store
+// have at most one leader at a time, across all instances of the application.
+//
+// The context passed to fn is canceled if leadership is lost, in which case
+// Run campaigns for leadership again. Otherwise Run steps down and returns
+// fn's error once fn returns.
+//
+// Run returns when ctx is canceled or the application shuts down.
+// It returns an error right away if no store is set using WithStore,
+// as there is no default store.
+//
+// Leadership changes are logged and traced. If Run is called while handling
+// a request, each term as leader is recorded as a span in the request's trace,
+// and failed campaigns as events. Otherwise, such as when started from
+// initService, each term is traced as its own job span, and fn's work is
+// recorded in it.
+func Run(ctx context.Context, name string, fn func(ctx context.Context) error, opts ...Option) error {
+	return Singleton.Run(ctx, name, fn, opts...)
+}
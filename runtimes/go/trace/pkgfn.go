@@ -0,0 +1,32 @@
+//go:build encore_app
+
+package trace
+
+import (
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+//publicapigen:drop
+var Singleton = NewManager(reqtrack.Singleton)
+
+// StartSpan starts a custom span with the given name in the current trace.
+// The variadic key-value pairs are recorded as fields of the span,
+// and must be pairs of string keys and arbitrary data.
+//
+// The span must be ended by calling End.
+func StartSpan(name string, keysAndValues ...any) *Span {
+	return Singleton.StartSpan(name, keysAndValues...)
+}
+
+// Event records an event with the given name in the current trace.
+// The variadic key-value pairs are treated as they are in StartSpan.
+func Event(name string, keysAndValues ...any) {
+	Singleton.Event(name, keysAndValues...)
+}
+
+// CurrentTraceID returns the trace id of the request being processed,
+// or "" if there is none. It's the id sent back to callers in the
+// X-Encore-Trace-ID response header.
+func CurrentTraceID() string {
+	return Singleton.CurrentTraceID()
+}
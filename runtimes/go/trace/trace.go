@@ -0,0 +1,192 @@
+// Package trace lets applications add their own spans and events
+// to the traces Encore records.
+//
+// Custom spans describe business-level steps of a request, such as
+// charging a card or rendering a report, and are shown alongside the
+// database queries, API calls and other operations Encore traces automatically:
+//
+//	span := trace.StartSpan("charge card", "amount", amount)
+//	err := chargeCard(ctx, amount)
+//	span.End(err)
+//
+// Events mark notable moments during a request. Events recorded using
+// a span's Event method belong to that span.
+//
+// Spans and events are only recorded when the current request is traced;
+// otherwise they have no effect.
+//
+// CurrentTraceID returns the id of the current request's trace, such as
+// to include it in error reports so they can be correlated with the trace.
+package trace
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/stack"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+// Manager records custom spans and events in the current trace.
+type Manager struct {
+	rt *reqtrack.RequestTracker
+}
+
+//publicapigen:drop
+func NewManager(rt *reqtrack.RequestTracker) *Manager {
+	return &Manager{rt: rt}
+}
+
+// Span is a custom span started using StartSpan.
+// It must be ended by calling End.
+type Span struct {
+	mgr     *Manager
+	startID model.TraceEventID // 0 if the span isn't traced
+	ended   atomic.Bool
+}
+
+// StartSpan starts a custom span with the given name in the current trace.
+// The variadic key-value pairs are recorded as fields of the span,
+// and must be pairs of string keys and arbitrary data.
+func (mgr *Manager) StartSpan(name string, keysAndValues ...any) *Span {
+	s := &Span{mgr: mgr}
+	if curr := mgr.rt.Current(); curr.Req != nil && curr.Trace != nil {
+		s.startID = curr.Trace.CustomSpanStart(trace2.CustomSpanStartParams{
+			EventParams: trace2.EventParams{
+				TraceID: curr.Req.TraceID,
+				SpanID:  curr.Req.SpanID,
+				Goid:    curr.Goctr,
+			},
+			Name:   name,
+			Fields: fields(keysAndValues),
+			Stack:  stack.Build(2),
+		})
+	}
+	return s
+}
+
+// Event records an event with the given name in the current trace.
+// The variadic key-value pairs are treated as they are in StartSpan.
+func (mgr *Manager) Event(name string, keysAndValues ...any) {
+	mgr.event(0, name, keysAndValues, 2)
+}
+
+// Event records an event with the given name as part of the span.
+// The variadic key-value pairs are treated as they are in StartSpan.
+func (s *Span) Event(name string, keysAndValues ...any) {
+	if s.startID != 0 {
+		s.mgr.event(s.startID, name, keysAndValues, 1)
+	}
+}
+
+// End ends the span, marking it as failed if err is non-nil.
+// The variadic key-value pairs are treated as they are in StartSpan.
+// Subsequent calls to End have no effect.
+func (s *Span) End(err error, keysAndValues ...any) {
+	if s.startID == 0 || s.ended.Swap(true) {
+		return
+	}
+	if curr := s.mgr.rt.Current(); curr.Req != nil && curr.Trace != nil {
+		curr.Trace.CustomSpanEnd(trace2.CustomSpanEndParams{
+			EventParams: trace2.EventParams{
+				TraceID: curr.Req.TraceID,
+				SpanID:  curr.Req.SpanID,
+				Goid:    curr.Goctr,
+			},
+			StartID: s.startID,
+			Err:     err,
+			Fields:  fields(keysAndValues),
+		})
+	}
+}
+
+// CurrentTraceID returns the trace id of the request being processed,
+// or "" if there is none. The id is the one sent back to callers in the
+// X-Encore-Trace-ID response header, and is set even if the request is
+// not traced.
+func (mgr *Manager) CurrentTraceID() string {
+	if curr := mgr.rt.Current(); curr.Req != nil && !curr.Req.TraceID.IsZero() {
+		return curr.Req.TraceID.String()
+	}
+	return ""
+}
+
+// event records a custom event, skipping the given number
+// of callers when capturing the stack trace.
+func (mgr *Manager) event(spanStartID model.TraceEventID, name string, keysAndValues []any, skip int) {
+	curr := mgr.rt.Current()
+	if curr.Req == nil || curr.Trace == nil {
+		return
+	}
+	curr.Trace.CustomEvent(trace2.CustomEventParams{
+		EventParams: trace2.EventParams{
+			TraceID: curr.Req.TraceID,
+			SpanID:  curr.Req.SpanID,
+			Goid:    curr.Goctr,
+		},
+		SpanStartID: spanStartID,
+		Name:        name,
+		Fields:      fields(keysAndValues),
+		Stack:       stack.Build(skip + 1),
+	})
+}
+
+// fields converts the key-value pairs into trace fields.
+// A trailing key without a value is dropped.
+func fields(keysAndValues []any) []trace2.LogField {
+	fields := make([]trace2.LogField, 0, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		fields = append(fields, trace2.LogField{Key: key, Value: keysAndValues[i+1]})
+	}
+	return fields
+}
+
+// Redactor scrubs sensitive data, such as personally identifiable information,
+// from request and response bodies, header values, Pub/Sub messages and
+// job payloads before they're recorded in a trace. See RegisterRedactor.
+type Redactor = trace2.Redactor
+
+// Payload describes the data passed to a Redactor.
+type Payload = trace2.Payload
+
+// PayloadKind is the kind of data passed to a Redactor.
+type PayloadKind = trace2.PayloadKind
+
+const (
+	RequestBody    = trace2.RequestBody
+	ResponseBody   = trace2.ResponseBody
+	RequestHeader  = trace2.RequestHeader
+	ResponseHeader = trace2.ResponseHeader
+	MessageBody    = trace2.MessageBody
+	JobPayload     = trace2.JobPayload
+)
+
+// RegisterRedactor registers a redactor that is called for all data
+// recorded in traces that may contain sensitive information.
+// Redactors are called in the order they were registered.
+//
+// It should be called from an init function,
+// so that no data is recorded before the redactor is registered.
+func RegisterRedactor(r Redactor) {
+	trace2.RegisterRedactor(r)
+}
+
+// Sink consumes the traces recorded by the application, such as to export
+// them to a tracing backend Encore doesn't support. See RegisterSink.
+type Sink = trace2.Sink
+
+// RegisterSink registers a sink that receives every trace recorded from
+// then on, in addition to the destinations Encore is configured with.
+// The sink's StreamTrace method is called once per trace and must read
+// the trace's log until it's done, typically using its Reader method.
+//
+// Sinks only receive traces when tracing is enabled for the environment.
+func RegisterSink(s Sink) {
+	trace2.RegisterSink(s)
+}
@@ -0,0 +1,77 @@
+package trace
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/traceprovider"
+	"encore.dev/appruntime/shared/traceprovider/tracecapture"
+)
+
+func TestManager(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, tracecapture.NewFactory(&traceprovider.DefaultFactory{}))
+	mgr := NewManager(rt)
+
+	req := &model.Request{
+		Type:    model.RPCCall,
+		TraceID: model.TraceID{1},
+		SpanID:  model.SpanID{1},
+		Traced:  true,
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "billing", Endpoint: "Charge"}},
+	}
+	rt.BeginRequest(req)
+	defer rt.FinishRequest(false)
+	log := rt.Current().Trace.(*tracecapture.Logger)
+	log.RequestSpanStart(req, 1)
+
+	if got, want := mgr.CurrentTraceID(), req.TraceID.String(); got != want {
+		t.Errorf("got trace id %q, want %q", got, want)
+	}
+
+	errDeclined := errors.New("declined")
+	span := mgr.StartSpan("charge card", "amount", 100)
+	span.Event("card declined", "reason", "insufficient funds")
+	span.End(errDeclined, "retry", false)
+	span.End(nil) // no effect
+	mgr.Event("charge failed", "odd")
+
+	root := log.Trace(req.SpanID)
+	spans := root.AllEvents(tracecapture.CustomSpanEvent)
+	if len(spans) != 1 {
+		t.Fatalf("got %d custom spans, want 1", len(spans))
+	}
+	if sp := spans[0]; sp.Name != "charge card" || !sp.Done || sp.Err != errDeclined || len(sp.Fields) != 2 {
+		t.Errorf("got custom span %+v", sp)
+	}
+
+	events := root.AllEvents(tracecapture.CustomEvent)
+	if len(events) != 2 {
+		t.Fatalf("got %d custom events, want 2", len(events))
+	}
+	if ev := events[0]; ev.Name != "card declined" || len(ev.Fields) != 1 || ev.Fields[0].Value != "insufficient funds" {
+		t.Errorf("got custom event %+v", ev)
+	}
+	// The trailing key without a value is dropped.
+	if ev := events[1]; ev.Name != "charge failed" || len(ev.Fields) != 0 {
+		t.Errorf("got custom event %+v", ev)
+	}
+}
+
+func TestManager_Untraced(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, nil)
+	mgr := NewManager(rt)
+
+	// Spans and events outside of traced requests have no effect.
+	span := mgr.StartSpan("charge card")
+	span.Event("card declined")
+	span.End(nil)
+	mgr.Event("charge failed")
+
+	if id := mgr.CurrentTraceID(); id != "" {
+		t.Errorf("got trace id %q outside of a request, want none", id)
+	}
+}
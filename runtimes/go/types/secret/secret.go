@@ -0,0 +1,112 @@
+// Package secret provides types for declaring secrets whose values
+// are not plain strings, such as TLS certificates or service account keys.
+//
+// Declare them as fields of a service's secrets struct:
+//
+//	var secrets struct {
+//		ClientCert secret.File   // exposed as a file on disk
+//		SigningKey []byte        // loaded as raw bytes
+//		APIToken   string        // loaded as a string
+//	}
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// File is a secret that is made available as a file on disk,
+// for use with libraries that require a file path rather than the
+// secret value itself.
+//
+// The file is written lazily the first time Path is called, is
+// only readable by the current user, and is removed when the app shuts down.
+type File struct {
+	f *file
+}
+
+type file struct {
+	name string
+	data []byte
+
+	once sync.Once
+	dir  string
+	path string
+	err  error
+}
+
+var errRemoved = errors.New("secret: file secret has been removed")
+
+// NewFileInternal returns a new File for the secret with the given name and value.
+// It is used by the Encore runtime and should not be called directly.
+//
+//publicapigen:drop
+func NewFileInternal(name string, data []byte) File {
+	return File{f: &file{name: name, data: data}}
+}
+
+// Bytes returns the contents of the secret.
+func (f File) Bytes() []byte {
+	if f.f == nil {
+		return nil
+	}
+	return f.f.data
+}
+
+// Path writes the secret to a temporary file, if it hasn't been already,
+// and returns the path to it.
+func (f File) Path() (string, error) {
+	if f.f == nil {
+		return "", fmt.Errorf("secret: file secret has not been loaded")
+	}
+	f.f.once.Do(f.f.write)
+	return f.f.path, f.f.err
+}
+
+// RemoveInternal removes the file written by Path, if any.
+// Once removed, Path returns an error instead of writing the file again.
+// It is used by the Encore runtime and should not be called directly.
+//
+//publicapigen:drop
+func (f File) RemoveInternal() error {
+	if f.f == nil {
+		return nil
+	}
+	f.f.once.Do(func() { f.f.err = errRemoved })
+	if f.f.dir == "" {
+		return nil
+	}
+	return os.RemoveAll(f.f.dir)
+}
+
+func (f *file) write() {
+	dir, err := os.MkdirTemp("", "encore-secret-")
+	if err != nil {
+		f.err = fmt.Errorf("secret: create temp dir for %s: %v", f.name, err)
+		return
+	}
+	f.dir = dir
+
+	path := filepath.Join(dir, sanitizeName(f.name))
+	if err := os.WriteFile(path, f.data, 0600); err != nil {
+		f.err = fmt.Errorf("secret: write %s: %v", f.name, err)
+		return
+	}
+	f.path = path
+}
+
+// sanitizeName makes the secret name safe to use as a file name.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
@@ -0,0 +1,74 @@
+package secret
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	f := NewFileInternal("tls/client cert", []byte("certificate"))
+	if got := f.Bytes(); !bytes.Equal(got, []byte("certificate")) {
+		t.Fatalf("Bytes() = %q, want %q", got, "certificate")
+	}
+
+	path, err := f.Path()
+	if err != nil {
+		t.Fatalf("Path() failed: %v", err)
+	}
+	if got, want := filepath.Base(path), "tls_client_cert"; got != want {
+		t.Errorf("file name = %q, want %q", got, want)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read secret file: %v", err)
+	}
+	if !bytes.Equal(data, []byte("certificate")) {
+		t.Errorf("file contents = %q, want %q", data, "certificate")
+	}
+
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("stat secret file: %v", err)
+		}
+		if mode := fi.Mode().Perm(); mode != 0600 {
+			t.Errorf("file mode = %v, want %v", mode, os.FileMode(0600))
+		}
+	}
+
+	// Path only writes the file once.
+	if path2, err := f.Path(); err != nil || path2 != path {
+		t.Errorf("second Path() = %q, %v, want %q, nil", path2, err, path)
+	}
+
+	if err := f.RemoveInternal(); err != nil {
+		t.Fatalf("RemoveInternal() failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(path)); !os.IsNotExist(err) {
+		t.Errorf("secret dir still exists after RemoveInternal: %v", err)
+	}
+}
+
+func TestFile_RemoveBeforePath(t *testing.T) {
+	f := NewFileInternal("key", []byte("value"))
+	if err := f.RemoveInternal(); err != nil {
+		t.Fatalf("RemoveInternal() failed: %v", err)
+	}
+	if _, err := f.Path(); err == nil {
+		t.Error("Path() after RemoveInternal succeeded, want error")
+	}
+}
+
+func TestFile_NotLoaded(t *testing.T) {
+	var f File
+	if _, err := f.Path(); err == nil {
+		t.Error("Path() on zero File succeeded, want error")
+	}
+	if got := f.Bytes(); got != nil {
+		t.Errorf("Bytes() on zero File = %q, want nil", got)
+	}
+}
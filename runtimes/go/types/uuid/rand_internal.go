@@ -0,0 +1,12 @@
+package uuid
+
+import "io"
+
+// SetRandReader is an internal API for Encore. This function should
+// never be directly called as it is considered an unstable API and Encore
+// can change it at any time
+//
+// It sets the source of random bytes used by NewV4.
+func SetRandReader(r io.Reader) {
+	g.rand = r
+}
@@ -0,0 +1,475 @@
+// Package validate enforces validation rules declared with validate struct tags.
+//
+// Encore validates API requests against their validate tags before the
+// API handler (and any middleware) runs:
+//
+//	type CreateUserParams struct {
+//		Name  string   `validate:"required,max=100"`
+//		Email string   `validate:"email"`
+//		Age   int      `validate:"min=18"`
+//		Tags  []string `validate:"max=10"`
+//	}
+//
+// The supported rules are:
+//
+//	required       the value must not be the zero value, or empty
+//	min=N, max=N   numbers must be at least (at most) N; strings, slices
+//	               and maps must have at least (at most) N characters or elements
+//	len=N          shorthand for min=N,max=N
+//	email          the string must be an email address
+//	url            the string must be an absolute URL
+//	startswith=S   the string must start with S
+//	endswith=S     the string must end with S
+//	maxsize=N      files must be at most N bytes, or N KB, MB or GB with a suffix
+//	accept=T       files must have one of the media types T, separated by spaces,
+//	               where "image/*" matches all images
+//
+// Rules are separated by commas, and all of them must hold. Fields of
+// nested structs, and of structs in slices and maps, are validated too.
+//
+// As with github.com/go-playground/validator, omitempty skips the rules
+// for empty values, and dive applies the rules following it to each element
+// of a slice, array or map instead of to the field itself:
+//
+//	Nickname string   `validate:"omitempty,min=3"`
+//	Emails   []string `validate:"max=5,dive,email"`
+//
+// Rules other than the ones above are ignored, including alternatives
+// separated by "|" and rules for map keys, so tags written for other
+// validation libraries can be used alongside Encore's validation.
+//
+// Requests failing validation are rejected with errs.InvalidArgument,
+// with a *Details describing each invalid field.
+package validate
+
+import (
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"encore.dev/beta/errs"
+)
+
+// Details are the details of errors reported by Struct.
+type Details struct {
+	// Fields are the fields that failed validation.
+	Fields []FieldError `json:"fields"`
+}
+
+func (*Details) ErrDetails() {}
+
+// FieldError describes a field that failed validation.
+type FieldError struct {
+	// Field is the path of the field, using the names the fields are
+	// encoded with, such as "items[2].name".
+	Field string `json:"field"`
+
+	// Rule is the rule that failed, such as "min=1".
+	Rule string `json:"rule"`
+
+	// Message describes why the field failed validation.
+	Message string `json:"message"`
+}
+
+// Struct validates v, which must be a struct or a pointer to one,
+// against the rules in its validate tags.
+//
+// It returns nil if v is valid, and otherwise an *errs.Error with code
+// errs.InvalidArgument and a *Details describing the invalid fields.
+func Struct(v any) error {
+	var fields []FieldError
+	validateValue(reflect.ValueOf(v), "", &fields)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, len(fields))
+	for i, f := range fields {
+		msgs[i] = f.Field + " " + f.Message
+	}
+	return errs.B().Code(errs.InvalidArgument).Details(&Details{Fields: fields}).
+		Msg("validation failed: " + strings.Join(msgs, "; ")).Err()
+}
+
+// validateValue validates the fields of the structs in v, recursively.
+func validateValue(v reflect.Value, path string, out *[]FieldError) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range rulesFor(v.Type()) {
+			fv := v.Field(f.index)
+			fieldPath := f.name
+			if path != "" {
+				fieldPath = path + "." + f.name
+			}
+			if f.rules != nil {
+				checkField(fv, fieldPath, f.rules, out)
+			}
+			if f.nested {
+				validateValue(fv, fieldPath, out)
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		if !mayContainStructs(v.Type().Elem()) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			validateValue(v.Index(i), path+"["+strconv.Itoa(i)+"]", out)
+		}
+
+	case reflect.Map:
+		if !mayContainStructs(v.Type().Elem()) {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			validateValue(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), out)
+		}
+	}
+}
+
+// checkField checks the value of a field, or of an element of one,
+// against its rules.
+func checkField(v reflect.Value, path string, rules *ruleSet, out *[]FieldError) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			break
+		}
+		v = v.Elem()
+	}
+	if rules.omitEmpty && isEmpty(v) {
+		return
+	}
+
+	for _, r := range rules.rules {
+		var msg string
+		if r.name == "required" {
+			if isEmpty(v) {
+				msg = "is required"
+			}
+		} else if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			// Only required applies to fields without a value.
+			continue
+		} else {
+			msg = r.check(v)
+		}
+
+		if msg != "" {
+			*out = append(*out, FieldError{Field: path, Rule: r.String(), Message: msg})
+			// Report at most one error per field.
+			return
+		}
+	}
+
+	if rules.dive != nil {
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				checkField(v.Index(i), path+"["+strconv.Itoa(i)+"]", rules.dive, out)
+			}
+		case reflect.Map:
+			iter := v.MapRange()
+			for iter.Next() {
+				checkField(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), rules.dive, out)
+			}
+		}
+	}
+}
+
+// isEmpty reports whether v is the zero value, or an empty slice or map.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// ruleSet are the rules applying to a value.
+type ruleSet struct {
+	rules     []rule
+	omitEmpty bool     // whether to skip the rules for empty values
+	dive      *ruleSet // the rules for the elements, or nil
+}
+
+// parseRules parses the rules of a validate tag.
+// It returns nil if there are none to enforce.
+func parseRules(tag string) *ruleSet {
+	root := &ruleSet{}
+	rs := root
+	inKeys := false
+	for _, s := range strings.Split(tag, ",") {
+		s = strings.TrimSpace(s)
+		name, arg, _ := strings.Cut(s, "=")
+		switch {
+		case inKeys:
+			// Rules for map keys aren't enforced.
+			inKeys = name != "endkeys"
+		case name == "keys":
+			inKeys = true
+		case name == "omitempty":
+			rs.omitEmpty = true
+		case name == "dive":
+			rs.dive = &ruleSet{}
+			rs = rs.dive
+		case knownRules[name] && !strings.Contains(s, "|"):
+			rs.rules = append(rs.rules, rule{name: name, arg: arg})
+		}
+	}
+	return root.trim()
+}
+
+// trim returns rs without rules that have no effect, or nil if none do.
+func (rs *ruleSet) trim() *ruleSet {
+	if rs == nil {
+		return nil
+	}
+	rs.dive = rs.dive.trim()
+	if len(rs.rules) == 0 && rs.dive == nil {
+		return nil
+	}
+	return rs
+}
+
+// rule is a single rule of a validate tag.
+type rule struct {
+	name string
+	arg  string
+}
+
+func (r rule) String() string {
+	if r.arg == "" {
+		return r.name
+	}
+	return r.name + "=" + r.arg
+}
+
+// check checks v against the rule, returning a message describing
+// why it's invalid, or "" if it's valid.
+func (r rule) check(v reflect.Value) string {
+	switch r.name {
+	case "min", "max", "len":
+		limit, err := strconv.ParseFloat(r.arg, 64)
+		if err != nil {
+			return ""
+		}
+
+		var n float64
+		var unit string
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = float64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			n = float64(v.Uint())
+		case reflect.Float32, reflect.Float64:
+			n = v.Float()
+		case reflect.String:
+			n, unit = float64(utf8.RuneCountInString(v.String())), "character"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			n, unit = float64(v.Len()), "element"
+		default:
+			return ""
+		}
+
+		switch {
+		case r.name == "min" && n < limit:
+			return describeLimit("at least", r.arg, unit, limit)
+		case r.name == "max" && n > limit:
+			return describeLimit("at most", r.arg, unit, limit)
+		case r.name == "len" && n != limit:
+			return describeLimit("exactly", r.arg, unit, limit)
+		}
+
+	case "email":
+		if v.Kind() == reflect.String {
+			if addr, err := mail.ParseAddress(v.String()); err != nil || addr.Address != v.String() {
+				return "must be a valid email address"
+			}
+		}
+
+	case "url":
+		if v.Kind() == reflect.String {
+			if u, err := url.Parse(v.String()); err != nil || u.Scheme == "" || u.Host == "" {
+				return "must be a valid URL"
+			}
+		}
+
+	case "maxsize", "accept":
+		switch {
+		case v.Type() == fileHeaderType:
+			fh := v.Interface().(multipart.FileHeader)
+			return r.checkFile(&fh)
+		case v.Kind() == reflect.Slice && v.Type().Elem() == reflect.PointerTo(fileHeaderType):
+			for i := 0; i < v.Len(); i++ {
+				if fh := v.Index(i).Interface().(*multipart.FileHeader); fh != nil {
+					if msg := r.checkFile(fh); msg != "" {
+						return strings.Replace(msg, "must be", "must only contain files", 1)
+					}
+				}
+			}
+		}
+
+	case "startswith":
+		if v.Kind() == reflect.String && !strings.HasPrefix(v.String(), r.arg) {
+			return "must start with " + strconv.Quote(r.arg)
+		}
+
+	case "endswith":
+		if v.Kind() == reflect.String && !strings.HasSuffix(v.String(), r.arg) {
+			return "must end with " + strconv.Quote(r.arg)
+		}
+	}
+	return ""
+}
+
+var fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+
+// checkFile checks the file fh against a maxsize or accept rule.
+func (r rule) checkFile(fh *multipart.FileHeader) string {
+	if r.name == "maxsize" {
+		if limit, ok := parseSize(r.arg); ok && fh.Size > limit {
+			return "must be at most " + r.arg
+		}
+		return ""
+	}
+
+	mediaType, _, err := mime.ParseMediaType(fh.Header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "application/octet-stream"
+	}
+	accepted := strings.Fields(r.arg)
+	for _, pattern := range accepted {
+		typ, subtype, _ := strings.Cut(strings.ToLower(pattern), "/")
+		if typ == "*" || (subtype == "*" && strings.HasPrefix(mediaType, typ+"/")) || mediaType == typ+"/"+subtype {
+			return ""
+		}
+	}
+
+	desc := accepted[0]
+	if n := len(accepted); n > 1 {
+		desc = strings.Join(accepted[:n-1], ", ") + " or " + accepted[n-1]
+	}
+	return "must be of type " + desc
+}
+
+// parseSize parses a size in bytes, optionally suffixed
+// by a unit of B, KB, MB or GB, each 1024 times the previous one.
+func parseSize(s string) (int64, bool) {
+	mult := int64(1)
+	for i, unit := range []string{"KB", "MB", "GB"} {
+		if num, ok := strings.CutSuffix(s, unit); ok {
+			s, mult = num, 1<<(10*(i+1))
+			break
+		}
+	}
+	if mult == 1 {
+		s = strings.TrimSuffix(s, "B")
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	return n * mult, err == nil && n > 0
+}
+
+// describeLimit describes a numeric or length limit, like "must be at least 3"
+// or "must contain at most 1 element".
+func describeLimit(bound, arg, unit string, limit float64) string {
+	switch unit {
+	case "":
+		return "must be " + bound + " " + arg
+	case "character":
+		if limit != 1 {
+			unit += "s"
+		}
+		return "must be " + bound + " " + arg + " " + unit + " long"
+	default:
+		if limit != 1 {
+			unit += "s"
+		}
+		return "must contain " + bound + " " + arg + " " + unit
+	}
+}
+
+// fieldRules are the rules of a struct field.
+type fieldRules struct {
+	index  int
+	name   string   // the name the field is encoded with
+	rules  *ruleSet // nil if there are none
+	nested bool     // whether the field may contain structs to validate
+}
+
+var cache sync.Map // reflect.Type -> []fieldRules
+
+// knownRules are the rules Encore enforces.
+var knownRules = map[string]bool{
+	"required": true, "min": true, "max": true, "len": true,
+	"email": true, "url": true, "startswith": true, "endswith": true,
+	"maxsize": true, "accept": true,
+}
+
+// rulesFor returns the rules of the fields of the struct type t.
+func rulesFor(t reflect.Type) []fieldRules {
+	if cached, ok := cache.Load(t); ok {
+		return cached.([]fieldRules)
+	}
+
+	var fields []fieldRules
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		fr := fieldRules{index: i, name: fieldName(f), nested: mayContainStructs(f.Type)}
+		if tag, ok := f.Tag.Lookup("validate"); ok {
+			fr.rules = parseRules(tag)
+		}
+		if fr.rules != nil || fr.nested {
+			fields = append(fields, fr)
+		}
+	}
+
+	cache.Store(t, fields)
+	return fields
+}
+
+// fieldName returns the name f is encoded with: its JSON name,
+// or the name of the query string or header it's encoded in.
+func fieldName(f reflect.StructField) string {
+	for _, key := range []string{"json", "query", "qs", "header"} {
+		if name, _, _ := strings.Cut(f.Tag.Get(key), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// mayContainStructs reports whether values of type t may contain structs
+// with fields to validate.
+func mayContainStructs(t reflect.Type) bool {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			return t != fileHeaderType
+		case reflect.Interface:
+			return true
+		default:
+			return false
+		}
+	}
+}
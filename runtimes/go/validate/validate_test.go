@@ -0,0 +1,295 @@
+package validate
+
+import (
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/beta/errs"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"len=5"`
+}
+
+type params struct {
+	Name      string             `validate:"required,max=10"`
+	Email     string             `json:"email" validate:"email"`
+	Website   *string            `json:"website,omitempty" validate:"url"`
+	Age       int                `query:"age" validate:"min=18,max=130"`
+	Score     float64            `validate:"max=1.5"`
+	Tags      []string           `validate:"min=1"`
+	ID        string             `header:"X-Request-ID" validate:"startswith=req_"`
+	File      string             `validate:"endswith=.pdf"`
+	Address   *address           `json:"address"`
+	Addresses []address          `json:"addresses"`
+	ByName    map[string]address `json:"by_name"`
+	Other     string             `validate:"gte=3,oneof=a b"`
+}
+
+func valid() *params {
+	website := "https://encore.dev"
+	return &params{
+		Name:    "Ada",
+		Email:   "ada@example.com",
+		Website: &website,
+		Age:     36,
+		Score:   1,
+		Tags:    []string{"a"},
+		ID:      "req_123",
+		File:    "doc.pdf",
+		Address: &address{City: "London", Zip: "12345"},
+	}
+}
+
+func TestStruct_Valid(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(Struct(valid()), qt.IsNil)
+
+	// Optional fields without a value are only checked by required.
+	p := valid()
+	p.Website, p.Address = nil, nil
+	c.Assert(Struct(p), qt.IsNil)
+
+	// Values that aren't structs have nothing to validate.
+	c.Assert(Struct(nil), qt.IsNil)
+	c.Assert(Struct("foo"), qt.IsNil)
+}
+
+func TestStruct_Invalid(t *testing.T) {
+	badURL := "encore.dev"
+	tests := []struct {
+		name   string
+		modify func(p *params)
+		want   FieldError
+	}{
+		{
+			name:   "required",
+			modify: func(p *params) { p.Name = "" },
+			want:   FieldError{Field: "Name", Rule: "required", Message: "is required"},
+		},
+		{
+			name:   "max_length",
+			modify: func(p *params) { p.Name = "Ada Lovelace" },
+			want:   FieldError{Field: "Name", Rule: "max=10", Message: "must be at most 10 characters long"},
+		},
+		{
+			name:   "email",
+			modify: func(p *params) { p.Email = "Ada <ada@example.com>" },
+			want:   FieldError{Field: "email", Rule: "email", Message: "must be a valid email address"},
+		},
+		{
+			name:   "url",
+			modify: func(p *params) { p.Website = &badURL },
+			want:   FieldError{Field: "website", Rule: "url", Message: "must be a valid URL"},
+		},
+		{
+			name:   "min_value",
+			modify: func(p *params) { p.Age = 17 },
+			want:   FieldError{Field: "age", Rule: "min=18", Message: "must be at least 18"},
+		},
+		{
+			name:   "max_float",
+			modify: func(p *params) { p.Score = 1.6 },
+			want:   FieldError{Field: "Score", Rule: "max=1.5", Message: "must be at most 1.5"},
+		},
+		{
+			name:   "min_elements",
+			modify: func(p *params) { p.Tags = nil },
+			want:   FieldError{Field: "Tags", Rule: "min=1", Message: "must contain at least 1 element"},
+		},
+		{
+			name:   "startswith",
+			modify: func(p *params) { p.ID = "123" },
+			want:   FieldError{Field: "X-Request-ID", Rule: "startswith=req_", Message: `must start with "req_"`},
+		},
+		{
+			name:   "endswith",
+			modify: func(p *params) { p.File = "doc.txt" },
+			want:   FieldError{Field: "File", Rule: "endswith=.pdf", Message: `must end with ".pdf"`},
+		},
+		{
+			name:   "nested",
+			modify: func(p *params) { p.Address.Zip = "123" },
+			want:   FieldError{Field: "address.zip", Rule: "len=5", Message: "must be exactly 5 characters long"},
+		},
+		{
+			name:   "slice",
+			modify: func(p *params) { p.Addresses = []address{{City: "Paris", Zip: "75001"}, {Zip: "75002"}} },
+			want:   FieldError{Field: "addresses[1].city", Rule: "required", Message: "is required"},
+		},
+		{
+			name:   "map",
+			modify: func(p *params) { p.ByName = map[string]address{"home": {City: "Paris"}} },
+			want:   FieldError{Field: "by_name[home].zip", Rule: "len=5", Message: "must be exactly 5 characters long"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := qt.New(t)
+			p := valid()
+			test.modify(p)
+
+			err := Struct(p)
+			c.Assert(errs.Code(err), qt.Equals, errs.InvalidArgument)
+			c.Assert(err.Error(), qt.Equals, "invalid_argument: validation failed: "+test.want.Field+" "+test.want.Message)
+			c.Assert(errs.Details(err), qt.DeepEquals, &Details{Fields: []FieldError{test.want}})
+		})
+	}
+}
+
+func TestStruct_Files(t *testing.T) {
+	file := func(size int64, contentType string) *multipart.FileHeader {
+		return &multipart.FileHeader{Filename: "f", Size: size, Header: textproto.MIMEHeader{"Content-Type": {contentType}}}
+	}
+
+	type upload struct {
+		Avatar *multipart.FileHeader   `json:"avatar" validate:"required,maxsize=1KB,accept=image/png image/jpeg"`
+		Docs   []*multipart.FileHeader `json:"docs" validate:"max=2,accept=application/pdf text/*"`
+	}
+
+	tests := []struct {
+		name string
+		req  upload
+		want string // the message, or "" if valid
+	}{
+		{
+			name: "valid",
+			req:  upload{Avatar: file(1024, "image/png"), Docs: []*multipart.FileHeader{file(1, "text/plain; charset=utf-8")}},
+		},
+		{
+			name: "required",
+			req:  upload{},
+			want: "avatar is required",
+		},
+		{
+			name: "maxsize",
+			req:  upload{Avatar: file(1025, "image/png")},
+			want: "avatar must be at most 1KB",
+		},
+		{
+			name: "accept",
+			req:  upload{Avatar: file(1, "image/gif")},
+			want: "avatar must be of type image/png or image/jpeg",
+		},
+		{
+			name: "accept_list",
+			req:  upload{Avatar: file(1, "image/jpeg"), Docs: []*multipart.FileHeader{file(1, "application/pdf"), file(1, "")}},
+			want: "docs must only contain files of type application/pdf or text/*",
+		},
+		{
+			name: "max_files",
+			req:  upload{Avatar: file(1, "image/jpeg"), Docs: []*multipart.FileHeader{file(1, "text/csv"), file(1, "text/csv"), file(1, "text/csv")}},
+			want: "docs must contain at most 2 elements",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := qt.New(t)
+			err := Struct(&test.req)
+			if test.want == "" {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err.Error(), qt.Equals, "invalid_argument: validation failed: "+test.want)
+			}
+		})
+	}
+}
+
+func TestStruct_MultipleFields(t *testing.T) {
+	c := qt.New(t)
+	p := valid()
+	p.Name = ""
+	p.Age = 200
+
+	err := Struct(p)
+	c.Assert(err.Error(), qt.Equals, "invalid_argument: validation failed: Name is required; age must be at most 130")
+	c.Assert(errs.Details(err).(*Details).Fields, qt.HasLen, 2)
+}
+
+func TestStruct_OmitEmptyAndDive(t *testing.T) {
+	type item struct {
+		Nick    string            `json:"nick" validate:"omitempty,min=3"`
+		Site    *string           `json:"site" validate:"omitempty,url"`
+		Emails  []string          `json:"emails" validate:"max=2,dive,email"`
+		Scores  map[string][]int  `json:"scores" validate:"dive,keys,min=5,endkeys,omitempty,dive,max=10"`
+		Codes   []*string         `json:"codes" validate:"dive,required,len=2"`
+		Contact string            `json:"contact" validate:"email|url"`
+		Labels  map[string]string `json:"labels" validate:"dive,omitempty,startswith=x"`
+	}
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name string
+		req  item
+		want string // the message, or "" if valid
+	}{
+		{
+			name: "empty",
+			req:  item{Site: str("")},
+		},
+		{
+			name: "valid",
+			req: item{
+				Nick:    "ada",
+				Site:    str("https://encore.dev"),
+				Emails:  []string{"ada@example.com"},
+				Scores:  map[string][]int{"a": {1, 10}, "b": nil},
+				Codes:   []*string{str("ab")},
+				Contact: "https://encore.dev",
+				Labels:  map[string]string{"a": "", "b": "xy"},
+			},
+		},
+		{
+			name: "omitempty",
+			req:  item{Nick: "ad"},
+			want: "nick must be at least 3 characters long",
+		},
+		{
+			name: "omitempty_pointer",
+			req:  item{Site: str("encore.dev")},
+			want: "site must be a valid URL",
+		},
+		{
+			name: "before_dive",
+			req:  item{Emails: []string{"a@b.c", "b@b.c", "c@b.c"}},
+			want: "emails must contain at most 2 elements",
+		},
+		{
+			name: "dive",
+			req:  item{Emails: []string{"ada@example.com", "ada"}},
+			want: "emails[1] must be a valid email address",
+		},
+		{
+			name: "nested_dive",
+			req:  item{Scores: map[string][]int{"a": {1, 11}}},
+			want: "scores[a][1] must be at most 10",
+		},
+		{
+			name: "dive_required",
+			req:  item{Codes: []*string{str("ab"), nil}},
+			want: "codes[1] is required",
+		},
+		{
+			name: "dive_map",
+			req:  item{Labels: map[string]string{"a": "y"}},
+			want: `labels[a] must start with "x"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := qt.New(t)
+			err := Struct(&test.req)
+			if test.want == "" {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.IsNotNil)
+				c.Assert(err.Error(), qt.Equals, "invalid_argument: validation failed: "+test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Package websocket lets APIs accept WebSocket connections, exchanging
+// typed messages with their clients.
+//
+// An API accepts connections by taking a *websocket.Conn as its last parameter,
+// instead of returning a response:
+//
+//	//encore:api auth path=/chat/:room
+//	func Chat(ctx context.Context, room string, conn *websocket.Conn[*ChatMessage, *ChatEvent]) error {
+//		for {
+//			msg, err := conn.Recv()
+//			if err == io.EOF {
+//				return nil
+//			} else if err != nil {
+//				return err
+//			}
+//			if err := conn.Send(&ChatEvent{Room: room, Text: msg.Text}); err != nil {
+//				return err
+//			}
+//		}
+//	}
+//
+// The messages are encoded as JSON text messages. The connection is closed
+// once the API returns, with an error status if it returned an error.
+//
+// Like other APIs the request is authenticated by the auth handler,
+// and path parameters and a request payload, such as query strings
+// and headers, are decoded from the WebSocket handshake.
+// The generated clients pass headers in the handshake using the
+// "encore.dev.headers.<base64 JSON>" subprotocol, since browsers
+// can't set headers on WebSocket connections.
+package websocket
+
+// Socket is the underlying connection of a Conn, implemented by the runtime.
+//
+//publicapigen:drop
+type Socket interface {
+	// Read reads the next message, returning io.EOF
+	// once the client has closed the connection.
+	Read() ([]byte, error)
+
+	// Write writes a message.
+	Write(data []byte) error
+
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// Conn is a WebSocket connection receiving messages of type In from the client
+// and sending messages of type Out to it.
+//
+// Recv must not be called concurrently, but Send can be called
+// concurrently with Recv and with itself.
+type Conn[In, Out any] struct {
+	s Socket
+}
+
+// NewConn returns a connection exchanging messages over s.
+//
+//publicapigen:drop
+func NewConn[In, Out any](s Socket) *Conn[In, Out] {
+	return &Conn[In, Out]{s: s}
+}
+
+// Recv receives the next message from the client.
+// It returns io.EOF once the client has closed the connection.
+func (c *Conn[In, Out]) Recv() (msg In, err error) {
+	data, err := c.s.Read()
+	if err != nil {
+		return msg, err
+	}
+	err = c.s.Unmarshal(data, &msg)
+	return msg, err
+}
+
+// Send sends a message to the client.
+func (c *Conn[In, Out]) Send(msg Out) error {
+	data, err := c.s.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return c.s.Write(data)
+}
@@ -0,0 +1,50 @@
+package websocket
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type message struct {
+	Text string `json:"text"`
+}
+
+type fakeSocket struct {
+	in  [][]byte
+	out []string
+}
+
+func (s *fakeSocket) Read() ([]byte, error) {
+	if len(s.in) == 0 {
+		return nil, io.EOF
+	}
+	data := s.in[0]
+	s.in = s.in[1:]
+	return data, nil
+}
+
+func (s *fakeSocket) Write(data []byte) error {
+	s.out = append(s.out, string(data))
+	return nil
+}
+
+func (s *fakeSocket) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (s *fakeSocket) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func TestConn(t *testing.T) {
+	c := qt.New(t)
+	s := &fakeSocket{in: [][]byte{[]byte(`{"text":"hello"}`)}}
+	conn := NewConn[*message, *message](s)
+
+	msg, err := conn.Recv()
+	c.Assert(err, qt.IsNil)
+	c.Assert(msg, qt.DeepEquals, &message{Text: "hello"})
+	c.Assert(conn.Send(&message{Text: "world"}), qt.IsNil)
+	c.Assert(s.out, qt.DeepEquals, []string{`{"text":"world"}`})
+
+	_, err = conn.Recv()
+	c.Assert(err, qt.Equals, io.EOF)
+}
@@ -0,0 +1,230 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/clock"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/beta/errs"
+)
+
+// pollInterval is how often polling stores are checked for due jobs
+// when there were none the last time.
+const pollInterval = time.Second
+
+// processFunc processes a single attempt of a job.
+type processFunc func(ctx context.Context, msg *Message, trace bool) error
+
+//publicapigen:drop
+type Manager struct {
+	static     *config.Static
+	rt         *reqtrack.RequestTracker
+	clock      *clock.Clock
+	rootLogger zerolog.Logger
+	json       jsoniter.API
+
+	pollCtx    context.Context // canceled when it's time to stop polling for jobs
+	stopPoll   context.CancelFunc
+	jobCtx     context.Context // canceled when running jobs must be aborted
+	cancelJobs context.CancelFunc
+	polling    sync.WaitGroup
+	running    sync.WaitGroup
+
+	mu     sync.Mutex
+	queues map[string]bool
+}
+
+//publicapigen:drop
+func NewManager(static *config.Static, rt *reqtrack.RequestTracker, clock *clock.Clock, rootLogger zerolog.Logger, json jsoniter.API) *Manager {
+	mgr := &Manager{
+		static:     static,
+		rt:         rt,
+		clock:      clock,
+		rootLogger: rootLogger,
+		json:       json,
+		queues:     make(map[string]bool),
+	}
+	mgr.pollCtx, mgr.stopPoll = context.WithCancel(context.Background())
+	mgr.jobCtx, mgr.cancelJobs = context.WithCancel(context.Background())
+	return mgr
+}
+
+func (mgr *Manager) register(name string, store Store, timeout time.Duration, concurrency int, process processFunc) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.queues[name] {
+		panic(fmt.Sprintf("worker: queue %q declared more than once", name))
+	}
+	mgr.queues[name] = true
+
+	// Jobs aren't processed in the background during tests.
+	if store.polls() && (mgr.static == nil || !mgr.static.Testing) {
+		// Claim jobs for a bit longer than they may run, so a job
+		// is only picked up again if this instance went away.
+		lease := timeout + time.Minute
+		mgr.polling.Add(1)
+		go mgr.poll(name, store, lease, concurrency, process)
+	}
+}
+
+// poll claims due jobs for the queue from the store, and processes them
+// with at most concurrency jobs running at a time.
+func (mgr *Manager) poll(name string, store Store, lease time.Duration, concurrency int, process processFunc) {
+	defer mgr.polling.Done()
+	log := mgr.rootLogger.With().Str("queue", name).Logger()
+	slots := make(chan struct{}, concurrency)
+	failing := false
+
+	for {
+		free := cap(slots) - len(slots)
+		var msgs []*Message
+		if free > 0 {
+			var err error
+			msgs, err = store.poll(mgr.pollCtx, name, free, mgr.clock.Now(), lease)
+			if err != nil && mgr.pollCtx.Err() == nil {
+				// Only log the first of consecutive failures to avoid flooding the logs.
+				if !failing {
+					log.Error().Err(err).Msg("worker: failed to poll for jobs")
+				}
+				failing = true
+			} else {
+				failing = false
+			}
+		}
+
+		for _, msg := range msgs {
+			slots <- struct{}{}
+			mgr.running.Add(1)
+			go func() {
+				defer func() { <-slots }()
+				defer mgr.running.Done()
+				if err := process(mgr.jobCtx, msg, true); err != nil {
+					log.Error().Err(err).Str("job_id", msg.JobID).Msg("worker: failed to update job")
+				}
+			}()
+		}
+
+		// If we claimed as many jobs as we could there are likely more,
+		// so poll again as soon as there's room.
+		wait := pollInterval
+		if len(msgs) > 0 && len(msgs) == free {
+			wait = 10 * time.Millisecond
+		}
+		select {
+		case <-mgr.pollCtx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// runHandler runs fn with the given timeout. If trace is true it's run as
+// a new request, so that each job gets its own trace span.
+func (mgr *Manager) runHandler(ctx context.Context, queue string, timeout time.Duration, msg *Message, payload any, trace bool, fn func(ctx context.Context) error) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if !trace {
+		return callHandler(ctx, fn)
+	}
+
+	traceID, err := model.GenTraceID()
+	if err != nil {
+		return errs.B().Code(errs.Internal).Cause(err).Msg("failed to generate trace id").Err()
+	}
+	spanID, err := model.GenSpanID()
+	if err != nil {
+		return errs.B().Code(errs.Internal).Cause(err).Msg("failed to generate span id").Err()
+	}
+
+	req := &model.Request{
+		Type:    model.WorkerJob,
+		TraceID: traceID,
+		SpanID:  spanID,
+		Start:   time.Now(),
+		JobData: &model.JobData{
+			Service:        msg.Service,
+			Queue:          queue,
+			JobID:          msg.JobID,
+			EnqueuedAt:     msg.EnqueuedAt,
+			Attempt:        msg.Attempt,
+			DecodedPayload: payload,
+			Payload:        msg.Payload,
+		},
+		Traced: mgr.rt.SampleTrace(),
+	}
+	reqLogger := mgr.rootLogger.With().
+		Str("queue", queue).
+		Str("job_id", msg.JobID).
+		Str("trace_id", traceID.String()).
+		Logger()
+	req.Logger = &reqLogger
+
+	mgr.rt.BeginOperation()
+	defer mgr.rt.FinishOperation()
+	mgr.rt.BeginRequest(req)
+	defer mgr.rt.FinishRequest(false)
+
+	curr := mgr.rt.Current()
+	if curr.Trace != nil {
+		curr.Trace.JobSpanStart(req, curr.Goctr)
+		defer func() {
+			curr.Trace.JobSpanEnd(trace2.JobSpanEndParams{
+				EventParams: trace2.EventParams{
+					TraceID: req.TraceID,
+					SpanID:  req.SpanID,
+				},
+				Req: req,
+				Resp: &model.Response{
+					Duration:   time.Since(req.Start),
+					Err:        err,
+					HTTPStatus: errs.HTTPStatus(err),
+				},
+			})
+		}()
+	}
+
+	return callHandler(ctx, fn)
+}
+
+// callHandler calls fn, turning panics into errors.
+func callHandler(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = errs.B().Code(errs.Internal).Msgf("job handler panicked: %v", e).Err()
+		}
+	}()
+	return fn(ctx)
+}
+
+// currentService returns the name of the service making the current request, if any.
+func (mgr *Manager) currentService() string {
+	if req := mgr.rt.Current().Req; req != nil {
+		return req.Service()
+	}
+	return ""
+}
+
+// Shutdown stops polling for new jobs and waits for the running jobs to complete.
+func (mgr *Manager) Shutdown(p *shutdown.Process) error {
+	// Once it's time to force-close tasks, cancel the running jobs.
+	go func() {
+		<-p.ForceCloseTasks.Done()
+		mgr.cancelJobs()
+	}()
+
+	mgr.stopPoll()
+	mgr.polling.Wait()
+	mgr.running.Wait()
+	return nil
+}
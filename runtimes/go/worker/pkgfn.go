@@ -0,0 +1,28 @@
+//go:build encore_app
+
+package worker
+
+import (
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/clock"
+	"encore.dev/appruntime/shared/jsonapi"
+	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+//publicapigen:drop
+var Singleton *Manager
+
+func init() {
+	Singleton = NewManager(appconf.Static, reqtrack.Singleton, clock.Singleton, logging.RootLogger, jsonapi.Default)
+	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+}
+
+// NewQueue declares a new queue of background jobs with payloads of type T.
+// Queue names must be unique within the application.
+//
+// It must be called from a package-level variable declaration.
+func NewQueue[T any](name string, cfg QueueConfig[T]) *Queue[T] {
+	return NewInternal(Singleton, name, cfg)
+}
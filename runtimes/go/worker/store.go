@@ -0,0 +1,184 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"encore.dev/pubsub"
+	"encore.dev/storage/sqldb"
+)
+
+// Message is a job as it's stored by a queue's Store.
+// It's exported so that it can be used as the message type
+// of the topic backing a queue using the PubSub store.
+type Message struct {
+	Queue      string
+	JobID      string
+	Service    string // the service that enqueued the job, if any
+	Payload    json.RawMessage
+	Attempt    int
+	EnqueuedAt time.Time
+	RunAt      time.Time
+}
+
+// Store stores the jobs of a queue until they have been processed.
+// Use SQLDB or PubSub to create a Store.
+type Store interface {
+	// push adds a job to the store.
+	push(ctx context.Context, msg *Message) error
+
+	// complete removes a job that has been processed from the store.
+	complete(ctx context.Context, msg *Message) error
+
+	// retry schedules another attempt at a job that failed.
+	retry(ctx context.Context, msg *Message, runAt time.Time) error
+
+	// maxBackoff is the longest delay before retrying a job the store
+	// supports, or zero if there's no limit.
+	maxBackoff() time.Duration
+
+	// polls reports whether jobs are delivered by polling the store,
+	// as opposed to being delivered by the store itself.
+	polls() bool
+
+	// poll claims up to limit jobs on the given queue that are due at now
+	// for the duration of the lease, during which they are not returned again.
+	poll(ctx context.Context, queue string, limit int, now time.Time, lease time.Duration) ([]*Message, error)
+}
+
+// Schema is the SQL needed to create the table used by SQLDB.
+// Add it to a migration for the database passed to SQLDB.
+const Schema = `CREATE TABLE encore_worker_jobs (
+	id           TEXT PRIMARY KEY,
+	queue        TEXT NOT NULL,
+	service      TEXT NOT NULL,
+	payload      JSONB NOT NULL,
+	attempt      INT NOT NULL,
+	enqueued_at  TIMESTAMPTZ NOT NULL,
+	run_at       TIMESTAMPTZ NOT NULL,
+	locked_until TIMESTAMPTZ
+);
+
+CREATE INDEX encore_worker_jobs_due ON encore_worker_jobs (queue, run_at);
+`
+
+// SQLDB returns a Store that keeps jobs in the encore_worker_jobs table
+// of the given database, which must be created using Schema.
+//
+// Due jobs are polled for every second by each running instance of the
+// application, and claimed for the duration of the queue's timeout so that
+// each job is processed by one instance at a time.
+func SQLDB(db *sqldb.Database) Store {
+	return &sqldbStore{db: db}
+}
+
+type sqldbStore struct {
+	db *sqldb.Database
+}
+
+func (s *sqldbStore) push(ctx context.Context, msg *Message) error {
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO encore_worker_jobs (id, queue, service, payload, attempt, enqueued_at, run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		msg.JobID, msg.Queue, msg.Service, []byte(msg.Payload), msg.Attempt, msg.EnqueuedAt, msg.RunAt)
+	return err
+}
+
+func (s *sqldbStore) complete(ctx context.Context, msg *Message) error {
+	_, err := s.db.Exec(ctx, `DELETE FROM encore_worker_jobs WHERE id = $1`, msg.JobID)
+	return err
+}
+
+func (s *sqldbStore) retry(ctx context.Context, msg *Message, runAt time.Time) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE encore_worker_jobs
+		SET attempt = attempt + 1, run_at = $2, locked_until = NULL
+		WHERE id = $1`, msg.JobID, runAt)
+	return err
+}
+
+func (s *sqldbStore) maxBackoff() time.Duration { return 0 }
+
+func (s *sqldbStore) polls() bool { return true }
+
+func (s *sqldbStore) poll(ctx context.Context, queue string, limit int, now time.Time, lease time.Duration) ([]*Message, error) {
+	rows, err := s.db.Query(ctx, `
+		UPDATE encore_worker_jobs SET locked_until = $4
+		WHERE id IN (
+			SELECT id FROM encore_worker_jobs
+			WHERE queue = $1 AND run_at <= $3 AND (locked_until IS NULL OR locked_until < $3)
+			ORDER BY run_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, service, payload, attempt, enqueued_at, run_at`,
+		queue, limit, now, now.Add(lease))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var msgs []*Message
+	for rows.Next() {
+		msg := &Message{Queue: queue}
+		var payload []byte
+		if err := rows.Scan(&msg.JobID, &msg.Service, &payload, &msg.Attempt, &msg.EnqueuedAt, &msg.RunAt); err != nil {
+			return nil, err
+		}
+		msg.Payload = payload
+		msgs = append(msgs, msg)
+	}
+	return msgs, rows.Err()
+}
+
+// PubSub returns a Store that publishes jobs to the given topic.
+//
+// Jobs are delivered by a subscription to the topic, which must
+// use the queue's HandleMessage method as its handler.
+// Failed jobs are retried by publishing them again, so the subscription's
+// own retry policy only applies to jobs that are scheduled to run later:
+// they are redelivered according to it until they are due.
+//
+// Each of those redeliveries counts towards the subscription's MaxRetries,
+// so a job scheduled too far ahead may be dead-lettered before it's due.
+// For this reason the delay between retries of a job is limited to
+// 10 minutes regardless of the queue's RetryPolicy, which is well within
+// the default subscription retry policy.
+// Jobs enqueued with RunAt or RunAfter must be due within the time
+// the subscription's retry policy keeps redelivering them.
+func PubSub(topic pubsub.Publisher[*Message]) Store {
+	return &pubsubStore{topic: topic}
+}
+
+type pubsubStore struct {
+	topic pubsub.Publisher[*Message]
+}
+
+func (s *pubsubStore) push(ctx context.Context, msg *Message) error {
+	_, err := s.topic.Publish(ctx, msg)
+	return err
+}
+
+func (s *pubsubStore) complete(ctx context.Context, msg *Message) error {
+	// The message is acknowledged when the handler returns.
+	return nil
+}
+
+func (s *pubsubStore) retry(ctx context.Context, msg *Message, runAt time.Time) error {
+	next := *msg
+	next.Attempt++
+	next.RunAt = runAt
+	return s.push(ctx, &next)
+}
+
+// pubsubMaxBackoff is the longest delay before retrying a job with the PubSub store.
+const pubsubMaxBackoff = 10 * time.Minute
+
+func (s *pubsubStore) maxBackoff() time.Duration { return pubsubMaxBackoff }
+
+func (s *pubsubStore) polls() bool { return false }
+
+func (s *pubsubStore) poll(ctx context.Context, queue string, limit int, now time.Time, lease time.Duration) ([]*Message, error) {
+	return nil, nil
+}
@@ -0,0 +1,297 @@
+// Package worker provides queues of background jobs, for work that should
+// happen later and outside of the request that triggered it.
+//
+// Queues are declared as package-level variables with a handler for the jobs,
+// and a Store that holds the jobs until they are processed:
+//
+//	var db = sqldb.NewDatabase("jobs", sqldb.DatabaseConfig{Migrations: "./migrations"})
+//
+//	var WelcomeEmails = worker.NewQueue("welcome-emails", worker.QueueConfig[*SignupEvent]{
+//		Handler:        SendWelcomeEmail,
+//		Store:          worker.SQLDB(db),
+//		MaxConcurrency: 5,
+//		RetryPolicy:    &worker.RetryPolicy{MaxRetries: 10},
+//	})
+//
+//	func SendWelcomeEmail(ctx context.Context, job *worker.Job[*SignupEvent]) error {
+//		// ...
+//	}
+//
+// Jobs are then enqueued from anywhere in the application, either to be run
+// as soon as possible or at a later point in time:
+//
+//	_, err := WelcomeEmails.Enqueue(ctx, &SignupEvent{UserID: uid}, worker.RunAfter(time.Hour))
+//
+// Each time a job runs it is traced as its own request.
+//
+// For more information see https://encore.dev/docs/go/primitives/worker-queues
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/xid"
+
+	"encore.dev/beta/errs"
+)
+
+// Job is a job that is being processed by a queue.
+type Job[T any] struct {
+	// ID is the unique id of the job.
+	ID string
+
+	// Payload is the payload the job was enqueued with.
+	Payload T
+
+	// Attempt is the attempt number, starting from 1.
+	Attempt int
+
+	// EnqueuedAt is when the job was enqueued.
+	EnqueuedAt time.Time
+}
+
+// QueueConfig configures a queue.
+type QueueConfig[T any] struct {
+	// Handler is called to process each job.
+	// If it returns an error the job is retried according to RetryPolicy.
+	Handler func(ctx context.Context, job *Job[T]) error
+
+	// Store holds the queue's jobs until they have been processed.
+	// It must be either SQLDB or PubSub.
+	Store Store
+
+	// MaxConcurrency is the maximum number of jobs each instance of the
+	// application processes concurrently for this queue.
+	// If zero it defaults to 10.
+	MaxConcurrency int
+
+	// Timeout is the maximum duration of a single attempt at processing a job,
+	// after which the context passed to the handler is canceled.
+	// If zero it defaults to 5 minutes.
+	Timeout time.Duration
+
+	// RetryPolicy determines how failed jobs are retried.
+	// If nil, failed jobs are retried up to 100 times.
+	RetryPolicy *RetryPolicy
+}
+
+// RetryPolicy defines how a job should be retried when
+// the handler returns an error.
+type RetryPolicy struct {
+	// MinBackoff is the minimum delay before the first retry.
+	// The delay doubles with each retry. If zero it defaults to 10 seconds.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries.
+	// If zero it defaults to 10 minutes, which is also the
+	// limit for queues using the PubSub store.
+	MaxBackoff time.Duration
+
+	// MaxRetries is the maximum number of times a job is retried
+	// before it's discarded. Use NoRetries to disable retries.
+	// If zero it defaults to 100.
+	MaxRetries int
+}
+
+// NoRetries is used to disable retries on a RetryPolicy.
+const NoRetries = -1
+
+// EnqueueOption customizes how a job is enqueued.
+type EnqueueOption func(*enqueueOptions)
+
+type enqueueOptions struct {
+	runAt time.Time
+	delay time.Duration
+}
+
+// RunAt schedules the job to run at the given time, instead of as soon as possible.
+func RunAt(t time.Time) EnqueueOption {
+	return func(o *enqueueOptions) { o.runAt, o.delay = t, 0 }
+}
+
+// RunAfter schedules the job to run after the given delay,
+// instead of as soon as possible.
+func RunAfter(d time.Duration) EnqueueOption {
+	return func(o *enqueueOptions) { o.runAt, o.delay = time.Time{}, d }
+}
+
+// Queue is a queue of jobs with payloads of type T.
+// Use NewQueue to declare a queue.
+type Queue[T any] struct {
+	mgr  *Manager
+	name string
+	cfg  QueueConfig[T]
+	sem  chan struct{}
+}
+
+// Name returns the name of the queue.
+func (q *Queue[T]) Name() string {
+	return q.name
+}
+
+// Enqueue adds a job with the given payload to the queue,
+// and returns the id of the job.
+func (q *Queue[T]) Enqueue(ctx context.Context, payload T, opts ...EnqueueOption) (id string, err error) {
+	var o enqueueOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	data, err := q.mgr.json.Marshal(payload)
+	if err != nil {
+		return "", errs.B().Code(errs.InvalidArgument).Cause(err).Msg("worker: failed to marshal job payload").Err()
+	}
+
+	now := q.mgr.clock.Now()
+	if o.delay > 0 {
+		o.runAt = now.Add(o.delay)
+	}
+	msg := &Message{
+		Queue:      q.name,
+		JobID:      xid.New().String(),
+		Service:    q.mgr.currentService(),
+		Payload:    data,
+		Attempt:    1,
+		EnqueuedAt: now,
+		RunAt:      now,
+	}
+	if o.runAt.After(now) {
+		msg.RunAt = o.runAt
+	}
+
+	if err := q.cfg.Store.push(ctx, msg); err != nil {
+		return "", errs.B().Code(errs.Unavailable).Cause(err).Msgf("worker: failed to enqueue job on queue %s", q.name).Err()
+	}
+	return msg.JobID, nil
+}
+
+// HandleMessage processes a job delivered by a Pub/Sub subscription,
+// for queues using the PubSub store. It's meant to be used as the
+// subscription's handler:
+//
+//	var Jobs = pubsub.NewTopic[*worker.Message]("jobs", pubsub.TopicConfig{
+//		DeliveryGuarantee: pubsub.AtLeastOnce,
+//	})
+//
+//	var Queue = worker.NewQueue("my-queue", worker.QueueConfig[*Payload]{
+//		Handler: Process,
+//		Store:   worker.PubSub(pubsub.TopicRef[pubsub.Publisher[*worker.Message]](Jobs)),
+//	})
+//
+//	var _ = pubsub.NewSubscription(Jobs, "my-queue", pubsub.SubscriptionConfig[*worker.Message]{
+//		Handler: Queue.HandleMessage,
+//	})
+//
+// Messages for other queues sharing the same topic are ignored.
+func (q *Queue[T]) HandleMessage(ctx context.Context, msg *Message) error {
+	if msg.Queue != q.name {
+		return nil
+	}
+	if wait := q.mgr.clock.Until(msg.RunAt); wait > 0 {
+		// Have the subscription redeliver the message later.
+		return errs.B().Code(errs.Unavailable).Msgf("worker: job %s is scheduled to run in %v", msg.JobID, wait.Round(time.Second)).Err()
+	}
+	return q.process(ctx, msg, false)
+}
+
+// process runs a single attempt of the given job, and retries or
+// discards it if it fails. If trace is true the attempt is traced as a
+// new request, which is otherwise the responsibility of the caller.
+func (q *Queue[T]) process(ctx context.Context, msg *Message, trace bool) error {
+	var payload T
+	if err := q.mgr.json.Unmarshal(msg.Payload, &payload); err != nil {
+		q.mgr.rootLogger.Error().Err(err).Str("queue", q.name).Str("job_id", msg.JobID).Msg("worker: failed to unmarshal job payload, discarding job")
+		return q.cfg.Store.complete(ctx, msg)
+	}
+
+	select {
+	case q.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-q.sem }()
+
+	job := &Job[T]{
+		ID:         msg.JobID,
+		Payload:    payload,
+		Attempt:    msg.Attempt,
+		EnqueuedAt: msg.EnqueuedAt,
+	}
+	err := q.mgr.runHandler(ctx, q.name, q.cfg.Timeout, msg, payload, trace, func(ctx context.Context) error {
+		return q.cfg.Handler(ctx, job)
+	})
+	if err == nil {
+		return q.cfg.Store.complete(ctx, msg)
+	}
+
+	policy := q.cfg.RetryPolicy
+	log := q.mgr.rootLogger.With().Str("queue", q.name).Str("job_id", msg.JobID).Int("attempt", msg.Attempt).Logger()
+	if policy.MaxRetries == NoRetries || msg.Attempt > policy.MaxRetries {
+		log.Error().Err(err).Msg("worker: job failed, discarding it as it has no retries left")
+		return q.cfg.Store.complete(ctx, msg)
+	}
+	delay := backoff(policy, msg.Attempt)
+	if max := q.cfg.Store.maxBackoff(); max > 0 && delay > max {
+		delay = max
+	}
+	log.Warn().Err(err).Msg("worker: job failed, retrying")
+	return q.cfg.Store.retry(ctx, msg, q.mgr.clock.Now().Add(delay))
+}
+
+// backoff returns the delay before retrying a job that failed on the given attempt.
+func backoff(policy *RetryPolicy, attempt int) time.Duration {
+	d := policy.MinBackoff
+	for i := 1; i < attempt && d < policy.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, policy.MaxBackoff)
+}
+
+// withDefaults returns the config with default values for unset fields.
+func (cfg QueueConfig[T]) withDefaults() QueueConfig[T] {
+	if cfg.MaxConcurrency <= 0 {
+		cfg.MaxConcurrency = 10
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Minute
+	}
+
+	policy := RetryPolicy{}
+	if cfg.RetryPolicy != nil {
+		policy = *cfg.RetryPolicy
+	}
+	if policy.MinBackoff <= 0 {
+		policy.MinBackoff = 10 * time.Second
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = 10 * time.Minute
+	}
+	if policy.MaxRetries == 0 {
+		policy.MaxRetries = 100
+	}
+	cfg.RetryPolicy = &policy
+	return cfg
+}
+
+// NewInternal declares a new queue using the given manager.
+//
+//publicapigen:drop
+func NewInternal[T any](mgr *Manager, name string, cfg QueueConfig[T]) *Queue[T] {
+	if cfg.Handler == nil {
+		panic(fmt.Sprintf("worker: queue %q has no handler", name))
+	} else if cfg.Store == nil {
+		panic(fmt.Sprintf("worker: queue %q has no store", name))
+	}
+
+	cfg = cfg.withDefaults()
+	q := &Queue[T]{
+		mgr:  mgr,
+		name: name,
+		cfg:  cfg,
+		sem:  make(chan struct{}, cfg.MaxConcurrency),
+	}
+	mgr.register(name, cfg.Store, cfg.Timeout, cfg.MaxConcurrency, q.process)
+	return q
+}
@@ -0,0 +1,232 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/clock"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
+)
+
+// memStore is an in-memory Store that records the jobs it's given.
+type memStore struct {
+	pushed    []*Message
+	completed []string
+	retried   map[string]time.Time
+	max       time.Duration // max backoff, if any
+}
+
+func (s *memStore) push(ctx context.Context, msg *Message) error {
+	s.pushed = append(s.pushed, msg)
+	return nil
+}
+
+func (s *memStore) complete(ctx context.Context, msg *Message) error {
+	s.completed = append(s.completed, msg.JobID)
+	return nil
+}
+
+func (s *memStore) retry(ctx context.Context, msg *Message, runAt time.Time) error {
+	if s.retried == nil {
+		s.retried = make(map[string]time.Time)
+	}
+	s.retried[msg.JobID] = runAt
+	return nil
+}
+
+func (s *memStore) maxBackoff() time.Duration { return s.max }
+
+func (s *memStore) polls() bool { return false }
+
+func (s *memStore) poll(ctx context.Context, queue string, limit int, now time.Time, lease time.Duration) ([]*Message, error) {
+	return nil, nil
+}
+
+func newTestManager() *Manager {
+	mgr, _ := newTestManagerWithTime()
+	return mgr
+}
+
+// newTestManagerWithTime returns a manager whose clock is controlled
+// by the returned test support manager.
+func newTestManagerWithTime() (*Manager, *testsupport.Manager) {
+	static := &config.Static{Testing: true}
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	ts := testsupport.NewManager(static, rt, zerolog.Nop())
+	ts.SetTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk := clock.New(static, ts)
+	return NewManager(static, rt, clk, zerolog.Nop(), jsoniter.ConfigCompatibleWithStandardLibrary), ts
+}
+
+type payload struct {
+	Email string
+}
+
+func TestQueue_Process(t *testing.T) {
+	store := &memStore{}
+	var got []*Job[*payload]
+	q := NewInternal(newTestManager(), "emails", QueueConfig[*payload]{
+		Store:       store,
+		RetryPolicy: &RetryPolicy{MaxRetries: 1},
+		Handler: func(ctx context.Context, job *Job[*payload]) error {
+			got = append(got, job)
+			if job.Payload.Email == "fail" {
+				return errors.New("failed")
+			}
+			return nil
+		},
+	})
+
+	ctx := context.Background()
+	for _, email := range []string{"ok", "fail"} {
+		if _, err := q.Enqueue(ctx, &payload{Email: email}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, msg := range store.pushed {
+		if err := q.process(ctx, msg, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(got) != 2 || got[0].Payload.Email != "ok" || got[0].Attempt != 1 {
+		t.Fatalf("got jobs %+v, want 2 jobs", got)
+	}
+	okID, failID := store.pushed[0].JobID, store.pushed[1].JobID
+	if len(store.completed) != 1 || store.completed[0] != okID {
+		t.Errorf("got completed %v, want [%s]", store.completed, okID)
+	}
+	if _, ok := store.retried[failID]; !ok {
+		t.Errorf("got retried %v, want %s to be retried", store.retried, failID)
+	}
+
+	// The job is discarded once it has no retries left.
+	msg := *store.pushed[1]
+	msg.Attempt = 2
+	if err := q.process(ctx, &msg, false); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.completed) != 2 || store.completed[1] != failID {
+		t.Errorf("got completed %v, want %s to be discarded", store.completed, failID)
+	}
+}
+
+func TestQueue_HandleMessage(t *testing.T) {
+	store := &memStore{}
+	calls := 0
+	mgr, ts := newTestManagerWithTime()
+	q := NewInternal(mgr, "emails", QueueConfig[*payload]{
+		Store: store,
+		Handler: func(ctx context.Context, job *Job[*payload]) error {
+			calls++
+			return nil
+		},
+	})
+
+	ctx := context.Background()
+	if _, err := q.Enqueue(ctx, &payload{}, RunAfter(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	msg := store.pushed[0]
+	if want := ts.Now().Add(time.Hour); !msg.RunAt.Equal(want) {
+		t.Errorf("got run at %v, want %v", msg.RunAt, want)
+	}
+	if err := q.HandleMessage(ctx, msg); err == nil {
+		t.Error("expected an error for a job that isn't due yet")
+	}
+
+	other := *msg
+	other.Queue = "other"
+	ts.AdvanceTime(time.Hour)
+	if err := q.HandleMessage(ctx, &other); err != nil || calls != 0 {
+		t.Errorf("got (%v, %d calls), want a job on another queue to be ignored", err, calls)
+	}
+
+	// The job is due once the test's time has moved past it.
+	if err := q.HandleMessage(ctx, msg); err != nil || calls != 1 {
+		t.Errorf("got (%v, %d calls), want the job to be processed", err, calls)
+	}
+}
+
+func TestQueue_MaxBackoff(t *testing.T) {
+	store := &memStore{max: time.Minute}
+	mgr, ts := newTestManagerWithTime()
+	q := NewInternal(mgr, "emails", QueueConfig[*payload]{
+		Store:       store,
+		RetryPolicy: &RetryPolicy{MinBackoff: time.Hour, MaxBackoff: 2 * time.Hour},
+		Handler: func(ctx context.Context, job *Job[*payload]) error {
+			return errors.New("failed")
+		},
+	})
+	if _, err := q.Enqueue(context.Background(), &payload{}); err != nil {
+		t.Fatal(err)
+	}
+	msg := store.pushed[0]
+	if err := q.process(context.Background(), msg, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := store.retried[msg.JobID], ts.Now().Add(time.Minute); !got.Equal(want) {
+		t.Errorf("got retry at %v, want %v", got, want)
+	}
+}
+
+func TestQueue_HandlerPanic(t *testing.T) {
+	store := &memStore{}
+	q := NewInternal(newTestManager(), "emails", QueueConfig[*payload]{
+		Store: store,
+		Handler: func(ctx context.Context, job *Job[*payload]) error {
+			panic("boom")
+		},
+	})
+	if _, err := q.Enqueue(context.Background(), &payload{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.process(context.Background(), store.pushed[0], false); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.retried) != 1 {
+		t.Errorf("got retried %v, want the panicking job to be retried", store.retried)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{50, 10 * time.Second},
+	}
+	for _, test := range tests {
+		if got := backoff(policy, test.attempt); got != test.want {
+			t.Errorf("backoff(%d) = %v, want %v", test.attempt, got, test.want)
+		}
+	}
+}
+
+func TestNewInternal_Duplicate(t *testing.T) {
+	mgr := newTestManager()
+	cfg := QueueConfig[*payload]{
+		Store:   &memStore{},
+		Handler: func(ctx context.Context, job *Job[*payload]) error { return nil },
+	}
+	NewInternal(mgr, "emails", cfg)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic when declaring a queue twice")
+		}
+	}()
+	NewInternal(mgr, "emails", cfg)
+}
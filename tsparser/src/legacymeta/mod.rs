@@ -206,6 +206,10 @@ impl MetaBuilder<'_> {
                         streaming_request: ep.streaming_request,
                         streaming_response: ep.streaming_response,
                         static_assets,
+                        server_sent_events: false,
+                        streamed_request_body: false,
+                        streamed_response_body: false,
+                        multipart_request: false,
                     };
 
                     let Some(service_idx) =
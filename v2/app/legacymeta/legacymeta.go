@@ -97,10 +97,23 @@ func (b *builder) Build() *meta.Data {
 					Tags:           ep.Tags.ToProto(),
 					Sensitive:      ep.Sensitive,
 					Expose:         make(map[string]*meta.RPC_ExposeOptions),
+
+					ServerSentEvents:     ep.SSE,
+					StreamedRequestBody:  ep.StreamRequest,
+					StreamedResponseBody: ep.StreamResponse,
+					MultipartRequest:     ep.Multipart,
 				}
 				if ep.Raw {
 					rpc.Proto = meta.RPC_RAW
 				}
+				if ep.WebSocket {
+					// WebSocket endpoints are described like bidirectional streams,
+					// with the request payload sent in the handshake.
+					rpc.StreamingRequest = true
+					rpc.StreamingResponse = true
+					rpc.HandshakeSchema = rpc.RequestSchema
+					rpc.RequestSchema = b.schemaTypeUnwrapPointer(ep.InboundMessage)
+				}
 
 				switch ep.Access {
 				case api.Public:
@@ -110,6 +123,9 @@ func (b *builder) Build() *meta.Data {
 				case api.Auth:
 					rpc.AccessType = meta.RPC_AUTH
 					rpc.Expose["api-gateway"] = &meta.RPC_ExposeOptions{}
+					// Endpoints with optional auth are called with auth data if
+					// it's provided, and without it otherwise.
+					rpc.AllowUnauthenticated = ep.OptionalAuth
 				case api.Private:
 					rpc.AccessType = meta.RPC_PRIVATE
 					rpc.AllowUnauthenticated = true
@@ -413,6 +429,10 @@ func (b *builder) Build() *meta.Data {
 				m.Kind = meta.Metric_COUNTER
 			case metrics.Gauge:
 				m.Kind = meta.Metric_GAUGE
+			case metrics.Histogram:
+				m.Kind = meta.Metric_HISTOGRAM
+			case metrics.Summary:
+				m.Kind = meta.Metric_SUMMARY
 			default:
 				panic(fmt.Sprintf("unknown metric type %v", r.Type))
 			}
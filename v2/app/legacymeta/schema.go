@@ -11,6 +11,7 @@ import (
 	"encr.dev/v2/internals/pkginfo"
 	schemav2 "encr.dev/v2/internals/schema"
 	"encr.dev/v2/internals/schema/schemautil"
+	"encr.dev/v2/parser/apis/api/apienc"
 )
 
 func (b *builder) builtinType(typ schemav2.BuiltinType) schema.Builtin {
@@ -55,6 +56,8 @@ func (b *builder) builtinType(typ schemav2.BuiltinType) schema.Builtin {
 		return schema.Builtin_JSON
 	case schemav2.UserID:
 		return schema.Builtin_USER_ID
+	case schemav2.File:
+		return schema.Builtin_FILE
 
 	default:
 		panic(fmt.Sprintf("unknown builtin type %v", typ.Kind))
@@ -202,9 +205,86 @@ func (b *builder) structField(f schemav2.StructField) *schema.Field {
 		field.QueryStringName = idents.Convert(field.Name, idents.SnakeCase)
 	}
 
+	if v, ok := apienc.FieldValidation(b.errs, f); ok {
+		setValidation(field.Typ, v)
+	}
+
 	return field
 }
 
+// setValidation sets the validation expressions of typ, and of its elements
+// if v has rules for them.
+func setValidation(typ *schema.Type, v *apienc.Validation) {
+	if typ == nil || v == nil {
+		return
+	}
+
+	typ.Validation = validationExpr(v.Rules)
+	if v.OmitEmpty && typ.Validation != nil {
+		// Empty values are valid too, so describe the rules as an alternative
+		// to being empty if possible, and otherwise not at all.
+		if empty := validationExpr(v.Empty); empty != nil {
+			typ.Validation = &schema.ValidationExpr{Expr: &schema.ValidationExpr_Or_{Or: &schema.ValidationExpr_Or{
+				Exprs: []*schema.ValidationExpr{empty, typ.Validation},
+			}}}
+		} else {
+			typ.Validation = nil
+		}
+	}
+
+	if v.Elem != nil {
+		for typ.GetPointer() != nil {
+			typ = typ.GetPointer().Base
+		}
+		if list := typ.GetList(); list != nil {
+			setValidation(list.Elem, v.Elem)
+		} else if m := typ.GetMap(); m != nil {
+			setValidation(m.Value, v.Elem)
+		}
+	}
+}
+
+// validationExpr returns the validation expression for the given rules,
+// or nil if none of them can be described by one.
+func validationExpr(rules []apienc.ValidationRule) *schema.ValidationExpr {
+	var exprs []*schema.ValidationExpr
+	for _, r := range rules {
+		rule := &schema.ValidationRule{}
+		switch r.Kind {
+		case apienc.MinLen:
+			rule.Rule = &schema.ValidationRule_MinLen{MinLen: uint64(r.Num)}
+		case apienc.MaxLen:
+			rule.Rule = &schema.ValidationRule_MaxLen{MaxLen: uint64(r.Num)}
+		case apienc.MinVal:
+			rule.Rule = &schema.ValidationRule_MinVal{MinVal: r.Num}
+		case apienc.MaxVal:
+			rule.Rule = &schema.ValidationRule_MaxVal{MaxVal: r.Num}
+		case apienc.IsEmail:
+			rule.Rule = &schema.ValidationRule_Is_{Is: schema.ValidationRule_EMAIL}
+		case apienc.IsURL:
+			rule.Rule = &schema.ValidationRule_Is_{Is: schema.ValidationRule_URL}
+		case apienc.StartsWith:
+			rule.Rule = &schema.ValidationRule_StartsWith{StartsWith: r.Str}
+		case apienc.EndsWith:
+			rule.Rule = &schema.ValidationRule_EndsWith{EndsWith: r.Str}
+		default:
+			// Required fields are described by the field not being optional,
+			// and the metadata has no rules for the size or type of files.
+			continue
+		}
+		exprs = append(exprs, &schema.ValidationExpr{Expr: &schema.ValidationExpr_Rule{Rule: rule}})
+	}
+
+	switch len(exprs) {
+	case 0:
+		return nil
+	case 1:
+		return exprs[0]
+	default:
+		return &schema.ValidationExpr{Expr: &schema.ValidationExpr_And_{And: &schema.ValidationExpr_And{Exprs: exprs}}}
+	}
+}
+
 func (b *builder) configValue(typ schemav2.NamedType) *schema.Type {
 	switch typ.DeclInfo.Name {
 	case "Value", "Values":
@@ -24,6 +24,7 @@ func (d *Desc) validate(pc *parsectx.Context, result *parser.Result) {
 		d.validateAPIs(pc, fw, result)
 		d.validateMiddleware(pc, fw)
 		d.validateServiceStructs(pc, result)
+		d.validateServiceDependencies(pc)
 	}
 
 	// Validate infrastructure
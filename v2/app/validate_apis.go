@@ -75,13 +75,68 @@ func (d *Desc) validateAPIs(pc *parsectx.Context, fw *apiframework.AppDesc, resu
 				if ep.Request != nil {
 					// The request is always the first parameter after any path params (and after the ctx)
 					field, _ := schemautil.GetArgument(ep.Decl.AST.Type.Params, len(ep.Path.Params())+1)
-					d.validateType(pc, field.Type, ep.Request)
+					d.validateRequestType(pc, field.Type, ep.Request)
 				}
 
-				if ep.Response != nil {
+				if ep.SSE {
+					// The events are the type argument of the last parameter, the event writer.
+					field, _ := schemautil.GetArgument(ep.Decl.AST.Type.Params, len(ep.Decl.Type.Params)-1)
+					d.validateType(pc, field.Type, ep.Response)
+
+					for _, usage := range result.Usages(ep) {
+						if _, ok := usage.(*api.CallUsage); ok {
+							pc.Errs.Add(
+								api.ErrSSEEndpointsCannotBeCalled.
+									AtGoNode(usage, errors.AsError("called here")).
+									AtGoNode(ep.Decl.AST.Name, errors.AsHelp("defined here")),
+							)
+						}
+					}
+				} else if ep.WebSocket {
+					// The messages are the type arguments of the last parameter, the connection.
+					field, _ := schemautil.GetArgument(ep.Decl.AST.Type.Params, len(ep.Decl.Type.Params)-1)
+					d.validateType(pc, field.Type, ep.InboundMessage)
+					d.validateType(pc, field.Type, ep.Response)
+
+					for _, usage := range result.Usages(ep) {
+						if _, ok := usage.(*api.CallUsage); ok {
+							pc.Errs.Add(
+								api.ErrWebSocketEndpointsCannotBeCalled.
+									AtGoNode(usage, errors.AsError("called here")).
+									AtGoNode(ep.Decl.AST.Name, errors.AsHelp("defined here")),
+							)
+						}
+					}
+				} else if ep.Response != nil {
 					// The response is always the first return value
 					d.validateType(pc, ep.Decl.AST.Type.Results.List[0].Type, ep.Response)
 				}
+
+				if ep.StreamRequest || ep.StreamResponse {
+					for _, usage := range result.Usages(ep) {
+						if _, ok := usage.(*api.CallUsage); ok {
+							pc.Errs.Add(
+								api.ErrStreamEndpointsCannotBeCalled.
+									AtGoNode(usage, errors.AsError("called here")).
+									AtGoNode(ep.Decl.AST.Name, errors.AsHelp("defined here")),
+							)
+						}
+					}
+				} else if ep.Multipart {
+					for _, usage := range result.Usages(ep) {
+						if _, ok := usage.(*api.CallUsage); ok {
+							pc.Errs.Add(
+								api.ErrMultipartEndpointsCannotBeCalled.
+									AtGoNode(usage, errors.AsError("called here")).
+									AtGoNode(ep.Decl.AST.Name, errors.AsHelp("defined here")),
+							)
+						}
+					}
+				}
+
+				// ProtoEncoding will validate the field numbers of the
+				// protobuf messages used by calls made over gRPC.
+				ep.ProtoEncoding()
 			}
 
 			// Check for usages outside of services
@@ -2,6 +2,8 @@ package app
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"encr.dev/pkg/errors"
 	"encr.dev/v2/internals/parsectx"
@@ -32,3 +34,58 @@ func (d *Desc) validateServiceStructs(pc *parsectx.Context, result *parser.Resul
 		}
 	}
 }
+
+// validateServiceDependencies checks that the services declared as dependencies
+// by service structs exist, and that the dependencies don't form a cycle.
+func (d *Desc) validateServiceDependencies(pc *parsectx.Context) {
+	svcNames := make(map[string]bool, len(d.Services))
+	for _, svc := range d.Services {
+		svcNames[svc.Name] = true
+	}
+
+	deps := make(map[string][]servicestruct.Dependency)
+	for _, svc := range d.Services {
+		if fwSvc, ok := svc.Framework.Get(); ok {
+			if ss, ok := fwSvc.ServiceStruct.Get(); ok {
+				for _, dep := range ss.DependsOn {
+					if !svcNames[dep.Service] {
+						pc.Errs.Add(servicestruct.ErrUnknownServiceDependency(dep.Service).AtGoNode(dep.AST))
+						continue
+					}
+					deps[svc.Name] = append(deps[svc.Name], dep)
+				}
+			}
+		}
+	}
+
+	// Find cycles with a depth-first search, reporting each cycle once.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var stack []string
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, dep := range deps[name] {
+			switch state[dep.Service] {
+			case unvisited:
+				visit(dep.Service)
+			case visiting:
+				idx := slices.Index(stack, dep.Service)
+				cycle := append(slices.Clone(stack[idx:]), dep.Service)
+				pc.Errs.Add(servicestruct.ErrServiceDependencyCycle(strings.Join(cycle, " -> ")).AtGoNode(dep.AST))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+	}
+	for _, svc := range d.Services {
+		if state[svc.Name] == unvisited {
+			visit(svc.Name)
+		}
+	}
+}
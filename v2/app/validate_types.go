@@ -15,12 +15,46 @@ import (
 //
 // This walks the type recursively and validates the whole thing
 func (d *Desc) validateType(pc *parsectx.Context, usedAt ast.Node, typ schema.Type) {
+	d.validateTypeWithFiles(pc, usedAt, typ, nil)
+}
+
+// validateRequestType is like validateType, but allows files
+// to be used as the fields of the request type.
+func (d *Desc) validateRequestType(pc *parsectx.Context, usedAt ast.Node, typ schema.Type) {
+	files := make(map[ast.Expr]bool)
+	if ref, ok := schemautil.ResolveNamedStruct(typ, false); ok {
+		for _, field := range ref.Decl.Type.(schema.StructType).Fields {
+			if apienc.IsFile(field.Type) {
+				schemautil.Walk(field.Type, func(t schema.Type) bool {
+					if b, ok := t.(schema.BuiltinType); ok {
+						files[b.AST] = true
+					}
+					return true
+				})
+			}
+		}
+	}
+	d.validateTypeWithFiles(pc, usedAt, typ, files)
+}
+
+// validateTypeWithFiles validates typ, where files are only allowed
+// to be the file types whose AST nodes are in allowedFiles.
+func (d *Desc) validateTypeWithFiles(pc *parsectx.Context, usedAt ast.Node, typ schema.Type, allowedFiles map[ast.Expr]bool) {
 	// Convert generic types to their concrete types
 	typ = schemautil.ConcretizeGenericType(pc.Errs, typ)
 
 	// Walk the type recursively
 	schemautil.Walk(typ, func(t schema.Type) bool {
 		switch t := t.(type) {
+		case schema.BuiltinType:
+			if t.Kind == schema.File && !allowedFiles[t.AST] {
+				pc.Errs.Add(
+					apienc.ErrFileNotSupported.
+						AtGoNode(t.ASTExpr(), errors.AsError("defined here")).
+						AtGoNode(usedAt, errors.AsHelp("used here")),
+				)
+			}
+
 		case schema.StructType:
 			for _, field := range t.Fields {
 				if field.IsAnonymous() {
@@ -32,6 +66,7 @@ func (d *Desc) validateType(pc *parsectx.Context, usedAt ast.Node, typ schema.Ty
 							AtGoNode(usedAt, errors.AsHelp("used here")),
 					)
 				}
+				apienc.FieldValidation(pc.Errs, field)
 			}
 
 		case schema.FuncType:
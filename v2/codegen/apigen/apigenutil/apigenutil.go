@@ -104,6 +104,29 @@ func DecodeBody(g *Group, ioReaderExpr *Statement, paramsExpr *Statement, dec *g
 	g.Line()
 }
 
+// DecodeMultipartForm decodes a multipart/form-data request body into the given parameters,
+// reading files from the form's files and the other parameters from its values.
+func DecodeMultipartForm(g *Group, httpReqExpr, paramExpr *Statement, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding) {
+	if len(params) == 0 {
+		return
+	}
+	g.Comment("Decode multipart form")
+	g.Id("form").Op(":=").Add(dec.ReadMultipartForm(httpReqExpr))
+
+	for _, f := range params {
+		var decodeExpr *Statement
+		if apienc.IsFile(f.Type) {
+			decodeExpr = dec.UnmarshalFiles(f.Type, Id("form").Dot("File").Index(Lit(f.WireName)))
+		} else {
+			singleValExpr := Qual("net/url", "Values").Call(Id("form").Dot("Value")).Dot("Get").Call(Lit(f.WireName))
+			listValExpr := Id("form").Dot("Value").Index(Lit(f.WireName))
+			decodeExpr = dec.UnmarshalSingleOrList(f.Type, f.WireName, singleValExpr, listValExpr, false)
+		}
+		g.Add(paramExpr.Clone()).Dot(f.SrcName).Op("=").Add(decodeExpr)
+	}
+	g.Line()
+}
+
 // EncodeHeaders generates code for encoding HTTP headers into a http.Header map.
 func EncodeHeaders(errs *perr.List, g *Group, httpHeaderExpr, paramExpr *Statement, params []*apienc.ParameterEncoding) {
 	if len(params) == 0 {
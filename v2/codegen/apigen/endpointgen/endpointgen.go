@@ -65,7 +65,11 @@ func genAPIDesc(
 	case api.Public:
 		access = apiQ("Public")
 	case api.Auth:
-		access = apiQ("RequiresAuth")
+		if ep.OptionalAuth {
+			access = apiQ("OptionalAuth")
+		} else {
+			access = apiQ("RequiresAuth")
+		}
 	case api.Private:
 		access = apiQ("Private")
 	default:
@@ -73,11 +77,7 @@ func genAPIDesc(
 	}
 
 	pos := ep.Decl.AST.Pos()
-	desc := f.VarDecl("APIDesc", ep.Name)
-	desc.Value(Op("&").Add(apiQ("Desc")).Types(
-		reqDesc.Type(),
-		respDesc.Type(),
-	).Values(Dict{
+	fields := Dict{
 		Id("Service"):        Lit(svc.Name),
 		Id("SvcNum"):         Lit(svc.Num),
 		Id("Endpoint"):       Lit(ep.Name),
@@ -106,12 +106,70 @@ func genAPIDesc(
 
 		Id("ServiceMiddleware"):   serviceMiddleware(ep, fw, svcMiddleware),
 		Id("GlobalMiddlewareIDs"): globalMiddleware(appDesc, ep),
-	}))
+	}
+	if ep.SSE {
+		fields[Id("SSE")] = True()
+		fields[Id("SSEHandler")] = handler.SSE()
+	} else if ep.WebSocket {
+		fields[Id("WebSocket")] = True()
+		fields[Id("WebSocketHandler")] = handler.WebSocket()
+	}
+	if ep.StreamRequest {
+		fields[Id("StreamRequest")] = True()
+	}
+	if ep.StreamResponse {
+		fields[Id("StreamResponse")] = True()
+	}
+	if multipart, maxSize := multipartLimit(ep); multipart {
+		fields[Id("Multipart")] = True()
+		if maxSize > 0 {
+			fields[Id("MaxMultipartSize")] = Lit(maxSize)
+		}
+	}
+	if enc := ep.ProtoEncoding(); enc != nil {
+		if schema, ok := reqDesc.ProtoSchema(); ok {
+			fields[Id("ProtoReq")] = schema
+		}
+		if enc.Response != nil {
+			fields[Id("ProtoResp")] = protoSchema(enc.Response)
+		}
+	}
+	if len(ep.Scopes) > 0 {
+		fields[Id("RequiredScopes")] = gu.GoToJen(pos, ep.Scopes)
+	}
+	if len(ep.Roles) > 0 {
+		fields[Id("RequiredRoles")] = gu.GoToJen(pos, ep.Roles)
+	}
+	if rate, ok := ep.TraceSampleRate.Get(); ok {
+		fields[Id("TraceSampleRate")] = Op("&").Index().Float64().Values(Lit(rate)).Index(Lit(0))
+	}
+
+	desc := f.VarDecl("APIDesc", ep.Name)
+	desc.Value(Op("&").Add(apiQ("Desc")).Types(
+		reqDesc.Type(),
+		respDesc.Type(),
+	).Values(fields))
 
 	handler.desc = desc
 	return handler
 }
 
+// multipartLimit reports whether the endpoint accepts multipart requests,
+// and the largest multipart body it accepts, or 0 to use the runtime's default.
+func multipartLimit(ep *api.Endpoint) (multipart bool, maxSize int64) {
+	for _, enc := range ep.RequestEncoding() {
+		if !enc.Multipart {
+			continue
+		} else if enc.MaxMultipartSize == 0 || (multipart && maxSize == 0) {
+			maxSize = 0
+		} else {
+			maxSize = max(maxSize, enc.MaxMultipartSize)
+		}
+		multipart = true
+	}
+	return multipart, maxSize
+}
+
 func serviceMiddleware(ep *api.Endpoint, fw *apiframework.ServiceDesc, svcMiddleware map[*middleware.Middleware]*codegen.VarDecl) *Statement {
 	return Index().Op("*").Add(apiQ("Middleware")).ValuesFunc(func(g *Group) {
 		for _, mw := range fw.Middleware {
@@ -21,7 +21,7 @@ type handlerDesc struct {
 
 func (h *handlerDesc) Typed() *Statement {
 	ep := h.ep
-	if ep.Raw {
+	if ep.Raw || ep.SSE || ep.WebSocket {
 		return Nil()
 	}
 
@@ -44,12 +44,14 @@ func (h *handlerDesc) Typed() *Statement {
 			fnExpr = Id(ep.Name)
 		}
 
-		g.Do(func(s *Statement) {
+		g.ListFunc(func(g *Group) {
 			if ep.Response != nil {
-				s.List(Id("resp"), Err())
-			} else {
-				s.Err()
+				g.Id("resp")
 			}
+			if ep.StreamResponse {
+				g.Id("body")
+			}
+			g.Err()
 		}).Op(":=").Add(fnExpr).CallFunc(func(g *Group) {
 			g.Id("ctx")
 			for _, arg := range h.req.HandlerArgs() {
@@ -58,7 +60,14 @@ func (h *handlerDesc) Typed() *Statement {
 		})
 		g.If(Err().Op("!=").Nil()).Block(Return(h.resp.zero(), Err()))
 
-		if ep.Response != nil {
+		if ep.StreamResponse {
+			g.Return(h.resp.Type().Values(DictFunc(func(d Dict) {
+				if ep.Response != nil {
+					d[Id(h.resp.respMetaName())] = Id("resp")
+				}
+				d[Id(h.resp.respBodyName())] = Id("body")
+			})), Nil())
+		} else if ep.Response != nil {
 			g.Return(Id("resp"), Nil())
 		} else {
 			g.Return(h.resp.zero(), Nil())
@@ -66,6 +75,83 @@ func (h *handlerDesc) Typed() *Statement {
 	})
 }
 
+// SSE returns the handler for an endpoint streaming server-sent events,
+// which passes the event writer on to the endpoint.
+func (h *handlerDesc) SSE() *Statement {
+	ep := h.ep
+	if !ep.SSE {
+		return Nil()
+	}
+
+	return Func().Params(
+		Id("ctx").Qual("context", "Context"),
+		h.req.reqDataExpr().Add(h.req.Type()),
+		Id("events").Op("*").Qual("encore.dev/sse", "Writer").Types(h.resp.Type()),
+	).Error().BlockFunc(func(g *Group) {
+		// fnExpr is the expression for the function we want to call,
+		// either just MyRPCName or svc.MyRPCName if we have a service struct.
+		var fnExpr *Statement
+
+		// If we have a service struct, initialize it first.
+		if ss, ok := h.svcStruct.Get(); ok && ep.Recv.Present() {
+			g.List(Id("svc"), Id("initErr")).Op(":=").Add(ss.Qual()).Dot("Get").Call()
+			g.If(Id("initErr").Op("!=").Nil()).Block(
+				Return(Id("initErr")),
+			)
+			fnExpr = Id("svc").Dot(ep.Name)
+		} else {
+			fnExpr = Id(ep.Name)
+		}
+
+		g.Return(fnExpr.CallFunc(func(g *Group) {
+			g.Id("ctx")
+			for _, arg := range h.req.HandlerArgs() {
+				g.Add(arg)
+			}
+			g.Id("events")
+		}))
+	})
+}
+
+// WebSocket returns the handler for an endpoint accepting WebSocket connections,
+// which wraps the accepted socket in a connection of the endpoint's message types.
+func (h *handlerDesc) WebSocket() *Statement {
+	ep := h.ep
+	if !ep.WebSocket {
+		return Nil()
+	}
+
+	return Func().Params(
+		Id("ctx").Qual("context", "Context"),
+		h.req.reqDataExpr().Add(h.req.Type()),
+		Id("socket").Qual("encore.dev/websocket", "Socket"),
+	).Error().BlockFunc(func(g *Group) {
+		// fnExpr is the expression for the function we want to call,
+		// either just MyRPCName or svc.MyRPCName if we have a service struct.
+		var fnExpr *Statement
+
+		// If we have a service struct, initialize it first.
+		if ss, ok := h.svcStruct.Get(); ok && ep.Recv.Present() {
+			g.List(Id("svc"), Id("initErr")).Op(":=").Add(ss.Qual()).Dot("Get").Call()
+			g.If(Id("initErr").Op("!=").Nil()).Block(
+				Return(Id("initErr")),
+			)
+			fnExpr = Id("svc").Dot(ep.Name)
+		} else {
+			fnExpr = Id(ep.Name)
+		}
+
+		conn := Qual("encore.dev/websocket", "NewConn").Types(h.gu.Type(ep.InboundMessage), h.resp.Type()).Call(Id("socket"))
+		g.Return(fnExpr.CallFunc(func(g *Group) {
+			g.Id("ctx")
+			for _, arg := range h.req.HandlerArgs() {
+				g.Add(arg)
+			}
+			g.Add(conn)
+		}))
+	})
+}
+
 func (h *handlerDesc) Raw() *Statement {
 	ep := h.ep
 	if !ep.Raw {
@@ -0,0 +1,59 @@
+package endpointgen
+
+import (
+	"maps"
+	"slices"
+
+	. "github.com/dave/jennifer/jen"
+
+	"encr.dev/v2/parser/apis/api/apienc"
+)
+
+const protoencPkg = "encore.dev/appruntime/apisdk/api/protoenc"
+
+// ProtoSchema renders the schema of the protobuf message the request is encoded as
+// in calls made over gRPC, which like the request type holds the payload and path parameters.
+// It reports false if the request is empty, and needs no schema.
+func (d *requestDesc) ProtoSchema() (*Statement, bool) {
+	msgs := make(map[string]*apienc.ProtoMessage)
+	root := &apienc.ProtoMessage{}
+	if payload := d.ep.ProtoEncoding().Request; payload != nil {
+		maps.Copy(msgs, payload.Messages)
+		root.Fields = append(root.Fields, apienc.ProtoField{Name: d.reqDataPayloadName(), Number: 1, Message: payload.Root})
+	}
+	for i := range d.ep.Path.Params() {
+		root.Fields = append(root.Fields, apienc.ProtoField{Name: d.pathParamFieldName(i), Number: int32(i + 2)})
+	}
+	if len(root.Fields) == 0 {
+		return nil, false
+	}
+	msgs[d.TypeName()] = root
+	return protoSchema(&apienc.ProtoSchema{Root: d.TypeName(), Messages: msgs}), true
+}
+
+// protoSchema renders s as a *protoenc.Schema, with the messages sorted by name.
+func protoSchema(s *apienc.ProtoSchema) *Statement {
+	return Op("&").Qual(protoencPkg, "Schema").Values(Dict{
+		Id("Root"): Lit(s.Root),
+		Id("Messages"): Map(String()).Qual(protoencPkg, "Message").Values(DictFunc(func(d Dict) {
+			for _, name := range slices.Sorted(maps.Keys(s.Messages)) {
+				d[Lit(name)] = Values(Id("Fields").Op(":").Index().Qual(protoencPkg, "Field").CustomFunc(Options{
+					Open:      "{",
+					Close:     "}",
+					Separator: ",",
+					Multi:     true,
+				}, func(g *Group) {
+					for _, f := range s.Messages[name].Fields {
+						g.ValuesFunc(func(g *Group) {
+							g.Id("Name").Op(":").Lit(f.Name)
+							g.Id("Number").Op(":").Lit(int(f.Number))
+							if f.Message != "" {
+								g.Id("Message").Op(":").Lit(f.Message)
+							}
+						})
+					}
+				}))
+			}
+		})),
+	})
+}
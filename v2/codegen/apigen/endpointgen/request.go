@@ -14,7 +14,10 @@ import (
 	"encr.dev/v2/parser/apis/api/apienc"
 )
 
-const jsonIterPkg = "github.com/json-iterator/go"
+const (
+	jsonIterPkg = "github.com/json-iterator/go"
+	streamPkg   = "encore.dev/stream"
+)
 
 // requestDesc describes the generated request type that contains the combined
 // request data + path parameters for the request.
@@ -42,6 +45,9 @@ func (d *requestDesc) TypeDecl() *Statement {
 		for i, seg := range d.ep.Path.Params() {
 			g.Id(d.pathParamFieldName(i)).Add(d.gu.Builtin(d.ep.Decl.AST.Pos(), seg.ValueType))
 		}
+		if d.ep.StreamRequest {
+			g.Id(d.reqDataBodyName()).Op("*").Qual(streamPkg, "Body").Tag(map[string]string{"json": "-"})
+		}
 	})
 }
 
@@ -56,6 +62,14 @@ func (d *requestDesc) DecodeRequest() *Statement {
 		Err().Error(),
 	).BlockFunc(func(g *Group) {
 		g.Add(d.reqDataExpr()).Op("=").New(Id(d.TypeName()))
+		if d.ep.StreamRequest {
+			// The body is streamed by the handler, so leave it unread.
+			g.Add(d.reqDataBodyExpr()).Op("=").Qual(streamPkg, "NewBody").Call(
+				d.httpReqExpr().Dot("Body"),
+				d.httpReqExpr().Dot("Header").Dot("Get").Call(Lit("Content-Type")),
+				d.httpReqExpr().Dot("ContentLength"),
+			)
+		}
 
 		if d.ep.Path.NumParams() == 0 && d.ep.Request == nil {
 			// Nothing to do; return an empty struct
@@ -86,6 +100,9 @@ func (d *requestDesc) HandlerArgs() []Code {
 	if d.ep.Request != nil {
 		args = append(args, d.reqDataPayloadExpr())
 	}
+	if d.ep.StreamRequest {
+		args = append(args, d.reqDataBodyExpr())
+	}
 	return args
 }
 
@@ -164,6 +181,17 @@ func (d *requestDesc) reqDataPayloadName() string {
 	return "Payload"
 }
 
+// reqDataBodyName returns the name of the streamed body field in the reqData struct.
+func (d *requestDesc) reqDataBodyName() string {
+	return "Body"
+}
+
+// reqDataBodyExpr returns an expression for accessing the streamed body
+// in the reqData variable.
+func (d *requestDesc) reqDataBodyExpr() *Statement {
+	return d.reqDataExpr().Dot(d.reqDataBodyName())
+}
+
 // httpHeaderExpr returns an expression to access the HTTP header variable,
 // for request encoding.
 func (d *requestDesc) httpHeaderExpr() *Statement {
@@ -248,7 +276,11 @@ func (d *requestDesc) renderRequestDecoding(g *Group, dec *genutil.TypeUnmarshal
 func (d *requestDesc) decodeRequestParameters(g *Group, dec *genutil.TypeUnmarshaller, req *apienc.RequestEncoding) {
 	apigenutil.DecodeHeaders(g, d.httpReqExpr().Dot("Header"), Id("params"), dec, req.HeaderParameters)
 	apigenutil.DecodeQuery(g, d.httpReqExpr().Dot("URL").Dot("Query").Call(), Id("params"), dec, req.QueryParameters)
-	apigenutil.DecodeBody(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, req.BodyParameters)
+	if req.Multipart {
+		apigenutil.DecodeMultipartForm(g, d.httpReqExpr(), Id("params"), dec, req.BodyParameters)
+	} else {
+		apigenutil.DecodeBody(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, req.BodyParameters)
+	}
 }
 
 // Clone returns the function literal to clone the request.
@@ -262,6 +294,12 @@ func (d *requestDesc) Clone() *Statement {
 		g.If(Err().Op("==").Nil()).Block(
 			Err().Op("=").Qual(jsonIterPkg, "ConfigDefault").Dot("Unmarshal").Call(Id("bytes"), Op("&").Id("clone")),
 		)
+		if d.ep.StreamRequest {
+			// The body can't be serialized; share it with the clone.
+			g.If(Err().Op("==").Nil().Op("&&").Id(recv).Op("!=").Nil()).Block(
+				Id("clone").Dot(d.reqDataBodyName()).Op("=").Id(recv).Dot(d.reqDataBodyName()),
+			)
+		}
 		g.Return(Id("clone"), Err())
 	})
 }
@@ -325,6 +363,11 @@ func (d *requestDesc) UserPayload() *Statement {
 }
 
 func (d *requestDesc) EncodeExternalReq() *Statement {
+	// Endpoints streaming their request body or accepting files can't be called by other services.
+	if d.ep.StreamRequest || d.ep.Multipart {
+		return Nil()
+	}
+
 	return Func().Params(
 		d.reqDataExpr().Add(d.Type()),
 		d.jsonStream().Add(Op("*").Qual(jsonIterPkg, "Stream")),
@@ -26,6 +26,17 @@ func (d *responseDesc) Type() *Statement {
 }
 
 func (d *responseDesc) TypeDecl() *Statement {
+	// Endpoints streaming their response body return it alongside
+	// the rest of the response, which is combined in a struct.
+	if d.ep.StreamResponse {
+		return Type().Id(d.TypeName()).StructFunc(func(g *Group) {
+			if d.ep.Response != nil {
+				g.Id(d.respMetaName()).Add(d.gu.Type(d.ep.Response))
+			}
+			g.Id(d.respBodyName()).Op("*").Qual(streamPkg, "Body").Tag(map[string]string{"json": "-"})
+		})
+	}
+
 	return Type().Id(d.TypeName()).Op("=").Do(func(s *Statement) {
 		if d.ep.Response != nil {
 			s.Add(d.gu.Type(d.ep.Response))
@@ -36,7 +47,9 @@ func (d *responseDesc) TypeDecl() *Statement {
 }
 
 func (d *responseDesc) ZeroType() *Statement {
-	if d.ep.Response != nil {
+	if d.ep.StreamResponse {
+		return d.Type().Values()
+	} else if d.ep.Response != nil {
 		return d.gu.Zero(d.ep.Response)
 	} else {
 		return apiQ("Void").Values()
@@ -44,8 +57,11 @@ func (d *responseDesc) ZeroType() *Statement {
 }
 
 func (d *responseDesc) EncodeResponse() *Statement {
-	if d.ep.Raw {
+	// SSE and WebSocket endpoints encode each event or message as it's sent instead.
+	if d.ep.Raw || d.ep.SSE || d.ep.WebSocket {
 		return Nil()
+	} else if d.ep.StreamResponse {
+		return d.encodeStreamResponse()
 	}
 
 	return Func().Params(
@@ -89,16 +105,7 @@ func (d *responseDesc) EncodeResponse() *Statement {
 
 			if len(resp.HeaderParameters) > 0 {
 				g.Line().Comment("Encode headers")
-				g.Id("headers").Op("=").Map(String()).Index().String().Values(DictFunc(func(dict Dict) {
-					for _, f := range resp.HeaderParameters {
-						if builtin, ok := f.Type.(schema.BuiltinType); ok {
-							encExpr := genutil.MarshalBuiltin(builtin.Kind, Id("resp").Dot(f.SrcName))
-							dict[Lit(f.WireName)] = Index().String().Values(encExpr)
-						} else {
-							d.gu.Errs.Addf(f.Type.ASTExpr().Pos(), "unsupported type in header: %s", d.gu.TypeToString(f.Type))
-						}
-					}
-				}))
+				g.Id("headers").Op("=").Add(d.headers(Id("resp"), resp.HeaderParameters))
 			}
 		})
 
@@ -111,21 +118,72 @@ func (d *responseDesc) EncodeResponse() *Statement {
 
 		g.Line().Comment("Write response")
 		if len(resp.HeaderParameters) > 0 {
-			g.For(List(Id("k"), Id("vs")).Op(":=").Range().Id("headers")).Block(
-				For(List(Id("_"), Id("v")).Op(":=").Range().Id("vs")).Block(
-					Id("w").Dot("Header").Call().Dot("Add").Call(Id("k"), Id("v")),
-				),
-			)
+			g.Add(writeHeaders())
 		}
 		g.Id("w").Dot("Write").Call(Id("respData"))
 		g.Return(Nil())
 	})
 }
 
+// encodeStreamResponse returns the function literal to encode the response
+// of an endpoint streaming its response body, which writes the headers
+// of the rest of the response before streaming the body.
+func (d *responseDesc) encodeStreamResponse() *Statement {
+	return Func().Params(
+		Id("w").Qual("net/http", "ResponseWriter"),
+		Id("json").Qual("github.com/json-iterator/go", "API"),
+		Id("resp").Add(d.Type()),
+	).Params(Err().Error()).BlockFunc(func(g *Group) {
+		if d.ep.Response != nil {
+			if resp := apienc.DescribeResponse(d.gu.Errs, d.ep.Response); len(resp.HeaderParameters) > 0 {
+				meta := Id("resp").Dot(d.respMetaName())
+				g.Comment("Encode headers")
+				encodeHeaders := func(g *Group) {
+					g.Id("headers").Op(":=").Add(d.headers(meta.Clone(), resp.HeaderParameters))
+					g.Add(writeHeaders())
+				}
+				if schemautil.IsPointer(d.ep.Response) {
+					g.If(meta.Clone().Op("!=").Nil()).BlockFunc(encodeHeaders)
+				} else {
+					encodeHeaders(g)
+				}
+				g.Line()
+			}
+		}
+		g.Return(Qual(streamPkg, "Write").Call(Id("w"), Id("resp").Dot(d.respBodyName())))
+	})
+}
+
+// headers returns an expression for the headers encoding the given
+// header parameters of the response in respExpr.
+func (d *responseDesc) headers(respExpr *Statement, params []*apienc.ParameterEncoding) *Statement {
+	return Map(String()).Index().String().Values(DictFunc(func(dict Dict) {
+		for _, f := range params {
+			if builtin, ok := f.Type.(schema.BuiltinType); ok {
+				encExpr := genutil.MarshalBuiltin(builtin.Kind, respExpr.Clone().Dot(f.SrcName))
+				dict[Lit(f.WireName)] = Index().String().Values(encExpr)
+			} else {
+				d.gu.Errs.Addf(f.Type.ASTExpr().Pos(), "unsupported type in header: %s", d.gu.TypeToString(f.Type))
+			}
+		}
+	}))
+}
+
+// writeHeaders returns the statement adding the headers variable to the response.
+func writeHeaders() *Statement {
+	return For(List(Id("k"), Id("vs")).Op(":=").Range().Id("headers")).Block(
+		For(List(Id("_"), Id("v")).Op(":=").Range().Id("vs")).Block(
+			Id("w").Dot("Header").Call().Dot("Add").Call(Id("k"), Id("v")),
+		),
+	)
+}
+
 func (d *responseDesc) DecodeExternalResp() *Statement {
 	if d.ep.Raw {
 		// TODO(andre) support
 		return Nil()
+	} else if d.ep.SSE || d.ep.WebSocket || d.ep.StreamResponse {
+		return Nil()
 	}
 
 	return Func().Params(
@@ -160,6 +218,18 @@ func (d *requestDesc) httpRespExpr() *Statement {
 	return Id("httpResp")
 }
 
+// respMetaName returns the name of the field holding the rest of the response
+// in the response struct of endpoints streaming their response body.
+func (d *responseDesc) respMetaName() string {
+	return "Meta"
+}
+
+// respBodyName returns the name of the streamed body field
+// in the response struct of endpoints streaming their response body.
+func (d *responseDesc) respBodyName() string {
+	return "Body"
+}
+
 // respDataExpr returns the expression to access the respData variable.
 func (d *responseDesc) respDataExpr() *Statement {
 	return Id("respData")
@@ -174,7 +244,9 @@ func (d *responseDesc) respDataPayloadExpr() *Statement {
 // zero returns an expression representing the zero value
 // of the response type.
 func (d *responseDesc) zero() *Statement {
-	if d.ep.Response != nil {
+	if d.ep.StreamResponse {
+		return d.Type().Values()
+	} else if d.ep.Response != nil {
 		return d.gu.Zero(d.ep.Response)
 	} else {
 		return apiQ("Void").Values()
@@ -192,6 +264,10 @@ func (d *responseDesc) Clone() *Statement {
 		g.If(Err().Op("==").Nil()).Block(
 			Err().Op("=").Qual(jsonIterPkg, "ConfigDefault").Dot("Unmarshal").Call(Id("bytes"), Op("&").Id("clone")),
 		)
+		if d.ep.StreamResponse {
+			// The body can't be serialized; share it with the clone.
+			g.Id("clone").Dot(d.respBodyName()).Op("=").Id(recv).Dot(d.respBodyName())
+		}
 		g.Return(Id("clone"), Err())
 	})
 }
@@ -15,15 +15,24 @@ func Gen(gen *codegen.Generator, svc *app.Service, s *servicestruct.ServiceStruc
 		return Id(init.Name)
 	}).GetOrElse(Nil())
 
-	f := gen.File(s.Decl.File.Pkg, "svcstruct")
-	decl := f.VarDecl(s.Decl.Name).Value(Op("&").Qual("encore.dev/appruntime/apisdk/service", "Decl").Types(
-		Id(s.Decl.Name),
-	).Values(Dict{
+	fields := Dict{
 		Id("Service"):     Lit(svc.Name),
 		Id("Name"):        Lit(s.Decl.Name),
 		Id("Setup"):       initFuncName,
 		Id("SetupDefLoc"): Lit(gen.TraceNodes.SvcStruct(s)),
-	}))
+	}
+	if len(s.DependsOn) > 0 {
+		fields[Id("DependsOn")] = Index().String().ValuesFunc(func(g *Group) {
+			for _, dep := range s.DependsOn {
+				g.Lit(dep.Service)
+			}
+		})
+	}
+
+	f := gen.File(s.Decl.File.Pkg, "svcstruct")
+	decl := f.VarDecl(s.Decl.Name).Value(Op("&").Qual("encore.dev/appruntime/apisdk/service", "Decl").Types(
+		Id(s.Decl.Name),
+	).Values(fields))
 
 	f.Jen.Func().Id("init").Params().Block(
 		Qual("encore.dev/appruntime/apisdk/service", "Register").Call(decl.Qual()),
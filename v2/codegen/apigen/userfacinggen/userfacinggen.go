@@ -56,7 +56,8 @@ func genUserFacing(gen *codegen.Generator, svc *apiframework.ServiceDesc, withIm
 		f.Jen.Comment("support service-to-service API calls to raw endpoints.")
 		f.Jen.Type().Id("Interface").InterfaceFunc(func(g *Group) {
 			for _, ep := range svc.Endpoints {
-				if !ep.Raw {
+				// SSE, WebSocket and streaming endpoints can't be called either.
+				if !ep.Raw && !ep.SSE && !ep.WebSocket && !ep.StreamRequest && !ep.StreamResponse {
 					getEndpointPrototype(gen.Util, g, ep, false, option.None[*codegen.VarDecl]())
 					count++
 					g.Line()
@@ -75,7 +76,7 @@ func genUserFacing(gen *codegen.Generator, svc *apiframework.ServiceDesc, withIm
 }
 
 func genEndpoint(gu *genutil.Helper, f *codegen.File, ep *api.Endpoint, withImpl option.Option[*codegen.VarDecl]) {
-	stmt, pathParamNames, alloc, ctxName, paramName := getEndpointPrototype(gu, f.Jen.Group, ep, true, withImpl)
+	stmt, pathParamNames, alloc, ctxName, paramName, streamName := getEndpointPrototype(gu, f.Jen.Group, ep, true, withImpl)
 	stmt.BlockFunc(func(g *Group) {
 		if svcStruct, ok := withImpl.Get(); ok {
 			if ep.Raw {
@@ -86,9 +87,12 @@ func genEndpoint(gu *genutil.Helper, f *codegen.File, ep *api.Endpoint, withImpl
 				g.If(Err().Op("!=").Nil()).Block(ReturnFunc(func(g *Group) {
 					if ep.Raw {
 						g.Nil()
-					} else if ep.Response != nil {
+					} else if ep.Response != nil && !ep.SSE && !ep.WebSocket {
 						g.Add(gu.Zero(ep.Response))
 					}
+					if ep.StreamResponse {
+						g.Nil()
+					}
 					g.Err()
 				}))
 
@@ -100,13 +104,24 @@ func genEndpoint(gu *genutil.Helper, f *codegen.File, ep *api.Endpoint, withImpl
 					if paramName != "" {
 						g.Id(paramName)
 					}
+					if streamName != "" {
+						g.Id(streamName)
+					}
 				}))
 			}
 		} else {
 			g.Comment("The implementation is elided here, and generated at compile-time by Encore.")
 			if ep.Raw {
 				g.Return(Nil(), Nil())
-			} else if ep.Response != nil {
+			} else if ep.StreamResponse {
+				g.ReturnFunc(func(g *Group) {
+					if ep.Response != nil {
+						g.Add(gu.Zero(ep.Response))
+					}
+					g.Nil()
+					g.Nil()
+				})
+			} else if ep.Response != nil && !ep.SSE && !ep.WebSocket {
 				g.Return(gu.Zero(ep.Response), Nil())
 			} else {
 				// Just an error return
@@ -116,7 +131,7 @@ func genEndpoint(gu *genutil.Helper, f *codegen.File, ep *api.Endpoint, withImpl
 	})
 }
 
-func getEndpointPrototype(gu *genutil.Helper, grp *Group, ep *api.Endpoint, withFuncKeyWord bool, withImpl option.Option[*codegen.VarDecl]) (*Statement, []string, func(input string, pathParam bool) string, string, string) {
+func getEndpointPrototype(gu *genutil.Helper, grp *Group, ep *api.Endpoint, withFuncKeyWord bool, withImpl option.Option[*codegen.VarDecl]) (*Statement, []string, func(input string, pathParam bool) string, string, string, string) {
 	// Add the doc comment
 	if ep.Doc != "" {
 		for _, line := range strings.Split(strings.TrimSpace(ep.Doc), "\n") {
@@ -139,6 +154,7 @@ func getEndpointPrototype(gu *genutil.Helper, grp *Group, ep *api.Endpoint, with
 		ctxName    = alloc("ctx", false)
 		rawReqName string
 		paramName  string
+		streamName string
 	)
 
 	var stmt *Statement
@@ -161,11 +177,23 @@ func getEndpointPrototype(gu *genutil.Helper, grp *Group, ep *api.Endpoint, with
 			paramName = alloc("p", false)
 			g.Id(paramName).Add(gu.Type(req))
 		}
+		if ep.SSE {
+			streamName = alloc("events", false)
+			g.Id(streamName).Op("*").Qual("encore.dev/sse", "Writer").Types(gu.Type(ep.Response))
+		} else if ep.WebSocket {
+			streamName = alloc("conn", false)
+			g.Id(streamName).Op("*").Qual("encore.dev/websocket", "Conn").Types(gu.Type(ep.InboundMessage), gu.Type(ep.Response))
+		} else if ep.StreamRequest {
+			streamName = alloc("body", false)
+			g.Id(streamName).Op("*").Qual("encore.dev/stream", "Body")
+		}
 	}).Do(func(s *Statement) {
 		if withImpl.Present() {
 			if ep.Raw {
 				s.Params(Op("*").Qual("net/http", "Response"), Error())
-			} else if resp := ep.Response; resp != nil {
+			} else if ep.StreamResponse {
+				s.Add(streamResults(gu, ep))
+			} else if resp := ep.Response; resp != nil && !ep.SSE && !ep.WebSocket {
 				s.Params(gu.Type(resp), Error())
 			} else {
 				s.Params(Error())
@@ -173,7 +201,9 @@ func getEndpointPrototype(gu *genutil.Helper, grp *Group, ep *api.Endpoint, with
 		} else {
 			if ep.Raw {
 				s.Params(Op("*").Qual("net/http", "Response"), Error())
-			} else if resp := ep.Response; resp != nil {
+			} else if ep.StreamResponse {
+				s.Add(streamResults(gu, ep))
+			} else if resp := ep.Response; resp != nil && !ep.SSE && !ep.WebSocket {
 				s.Params(gu.Type(resp), Error())
 			} else {
 				s.Error()
@@ -181,5 +211,16 @@ func getEndpointPrototype(gu *genutil.Helper, grp *Group, ep *api.Endpoint, with
 		}
 	})
 
-	return stmt, pathParamNames, alloc, ctxName, paramName
+	return stmt, pathParamNames, alloc, ctxName, paramName, streamName
+}
+
+// streamResults returns the results of an endpoint streaming its response body.
+func streamResults(gu *genutil.Helper, ep *api.Endpoint) *Statement {
+	return ParamsFunc(func(g *Group) {
+		if ep.Response != nil {
+			g.Add(gu.Type(ep.Response))
+		}
+		g.Op("*").Qual("encore.dev/stream", "Body")
+		g.Error()
+	})
 }
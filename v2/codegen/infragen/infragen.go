@@ -59,7 +59,7 @@ func Process(gg *codegen.Generator, appDesc *app.Desc) {
 				return r.(*caches.Keyspace)
 			}))
 		case resource.Metric:
-			metricsgen.Gen(gg, pkg, fns.Map(resources, func(r resource.Resource) *metrics.Metric {
+			metricsgen.Gen(gg, appDesc, pkg, fns.Map(resources, func(r resource.Resource) *metrics.Metric {
 				return r.(*metrics.Metric)
 			}))
 		case resource.PubSubSubscription:
@@ -68,7 +68,7 @@ func Process(gg *codegen.Generator, appDesc *app.Desc) {
 			}))
 		case resource.Secrets:
 			svc, _ := appDesc.ServiceForPath(pkg.FSPath)
-			secretsgen.Gen(gg, option.AsOptional(svc), pkg, fns.Map(resources, func(r resource.Resource) *secrets.Secrets {
+			secretsgen.Gen(gg, appDesc, option.AsOptional(svc), pkg, fns.Map(resources, func(r resource.Resource) *secrets.Secrets {
 				return r.(*secrets.Secrets)
 			}))
 		case resource.ConfigLoad:
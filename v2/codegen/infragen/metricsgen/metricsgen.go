@@ -6,6 +6,7 @@ import (
 	. "github.com/dave/jennifer/jen"
 
 	"encr.dev/pkg/idents"
+	"encr.dev/v2/app"
 	"encr.dev/v2/codegen"
 	"encr.dev/v2/internals/perr"
 	"encr.dev/v2/internals/pkginfo"
@@ -14,11 +15,27 @@ import (
 	"encr.dev/v2/parser/infra/metrics"
 )
 
-func Gen(gen *codegen.Generator, pkg *pkginfo.Package, metrics []*metrics.Metric) {
+func Gen(gen *codegen.Generator, appDesc *app.Desc, pkg *pkginfo.Package, metrics []*metrics.Metric) {
 	f := gen.File(pkg, "metrics")
 	for _, m := range metrics {
 		genLabelMapper(gen, f, m)
+		if m.Callback {
+			genSvcNum(gen, appDesc, pkg, m)
+		}
+	}
+}
+
+// genSvcNum injects the number of the service the metric is declared in,
+// for metrics whose value isn't recorded as part of a request.
+func genSvcNum(gen *codegen.Generator, appDesc *app.Desc, pkg *pkginfo.Package, m *metrics.Metric) {
+	svc, ok := appDesc.ServiceForPath(pkg.FSPath)
+	if !ok {
+		gen.Errs.AddPos(m.AST.Pos(), "metrics.NewGaugeFunc must be called within a service package")
+		return
 	}
+
+	snippet := fmt.Sprintf("EncoreInternal_SvcNum: %d,", svc.Num)
+	gen.Rewrite(m.File).Insert(m.ConfigLiteral.Lbrace+1, []byte(snippet))
 }
 
 func genLabelMapper(gen *codegen.Generator, f *codegen.File, m *metrics.Metric) {
@@ -3,6 +3,8 @@ package secretsgen
 import (
 	"bytes"
 	"fmt"
+	"go/ast"
+	"go/token"
 	"strconv"
 
 	"encr.dev/pkg/option"
@@ -12,37 +14,99 @@ import (
 	"encr.dev/v2/parser/infra/secrets"
 )
 
-func Gen(gen *codegen.Generator, svc option.Option[*app.Service], pkg *pkginfo.Package, secrets []*secrets.Secrets) {
+func Gen(gen *codegen.Generator, appDesc *app.Desc, svc option.Option[*app.Service], pkg *pkginfo.Package, secretList []*secrets.Secrets) {
 	addedImport := make(map[*pkginfo.File]bool)
-	for _, secret := range secrets {
-		file := secret.File
-		rw := gen.Rewrite(file)
-
+	addImport := func(file *pkginfo.File) {
 		if !addedImport[file] {
 			// Add an import of the runtime package to be able to load secrets.
 			insertPos := file.AST().Name.End()
 			ln := gen.FS.Position(insertPos)
 
-			rw.Insert(insertPos, []byte(fmt.Sprintf("\nimport __encore_secrets %s;/*line :%d:%d*/",
+			gen.Rewrite(file).Insert(insertPos, []byte(fmt.Sprintf("\nimport __encore_secrets %s;/*line :%d:%d*/",
 				strconv.Quote("encore.dev/appruntime/infrasdk/secrets"),
 				ln.Line, ln.Column)))
-			addedImport[secret.File] = true
+			addedImport[file] = true
 		}
+	}
 
-		getName := func(svc *app.Service) string { return svc.Name }
-		svcName := strconv.Quote(option.Map(svc, getName).GetOrElse(""))
+	getName := func(svc *app.Service) string { return svc.Name }
+	svcName := strconv.Quote(option.Map(svc, getName).GetOrElse(""))
+
+	for _, secret := range secretList {
+		file := secret.File
+		rw := gen.Rewrite(file)
+		addImport(file)
 
 		// Rewrite the value spec to load the secrets.
 		spec := secret.Spec
 		var buf bytes.Buffer
 		buf.WriteString("{\n")
 		for _, key := range secret.Keys {
-			fmt.Fprintf(&buf, "\t%s: __encore_secrets.Load(%s, %s),\n", key, strconv.Quote(key), svcName)
+			loadFn := "Load"
+			switch secret.Kinds[key] {
+			case secrets.BytesKey:
+				loadFn = "LoadBytes"
+			case secrets.FileKey:
+				loadFn = "LoadFile"
+			}
+			fmt.Fprintf(&buf, "\t%s: __encore_secrets.%s(%s, %s),\n", key, loadFn, strconv.Quote(key), svcName)
 		}
 		ep := gen.FS.Position(spec.End())
 		fmt.Fprintf(&buf, "}/*line :%d:%d*/", ep.Line, ep.Column)
 		rw.Insert(spec.Type.Pos(), []byte("= "))
 		rw.Insert(spec.End(), buf.Bytes())
 
+		// Wrap the reads of each secret so they're recorded in the trace
+		// of the request reading them.
+		reads := make(map[*pkginfo.File][]*secrets.ReadUsage)
+		for _, u := range appDesc.Parse.Usages(secret) {
+			if read, ok := u.(*secrets.ReadUsage); ok {
+				reads[read.File] = append(reads[read.File], read)
+			}
+		}
+		for file, fileReads := range reads {
+			assigned := assignedSelectors(file)
+			for _, read := range fileReads {
+				if assigned[read.Sel] {
+					continue
+				}
+				addImport(file)
+				rw := gen.Rewrite(file)
+				rw.Insert(read.Sel.Pos(), []byte("__encore_secrets.Read("))
+				rw.Insert(read.Sel.End(), []byte(fmt.Sprintf(", %s, %s)", strconv.Quote(read.Key), svcName)))
+			}
+		}
 	}
 }
+
+// assignedSelectors returns the selector expressions in file that are assigned to
+// or have their address taken, and therefore can't be wrapped in a function call.
+func assignedSelectors(file *pkginfo.File) map[*ast.SelectorExpr]bool {
+	assigned := make(map[*ast.SelectorExpr]bool)
+	file.ASTInspector().WithStack([]ast.Node{(*ast.SelectorExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push || len(stack) < 2 {
+			return true
+		}
+		sel := n.(*ast.SelectorExpr)
+		switch parent := stack[len(stack)-2].(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range parent.Lhs {
+				if lhs == sel {
+					assigned[sel] = true
+				}
+			}
+		case *ast.IncDecStmt:
+			assigned[sel] = true
+		case *ast.UnaryExpr:
+			if parent.Op == token.AND {
+				assigned[sel] = true
+			}
+		case *ast.RangeStmt:
+			if parent.Key == sel || parent.Value == sel {
+				assigned[sel] = true
+			}
+		}
+		return true
+	})
+	return assigned
+}
@@ -0,0 +1,26 @@
+package secretsgen
+
+import (
+	"testing"
+
+	"encr.dev/pkg/option"
+	"encr.dev/v2/app"
+	"encr.dev/v2/codegen"
+	"encr.dev/v2/codegen/internal/codegentest"
+	"encr.dev/v2/parser/infra/secrets"
+)
+
+func TestCodegen(t *testing.T) {
+	fn := func(gen *codegen.Generator, desc *app.Desc) {
+		svc := desc.Services[0]
+		var list []*secrets.Secrets
+		for _, r := range desc.Parse.Resources() {
+			if s, ok := r.(*secrets.Secrets); ok {
+				list = append(list, s)
+			}
+		}
+		Gen(gen, desc, option.Some(svc), list[0].File.Pkg, list)
+	}
+
+	codegentest.Run(t, fn)
+}
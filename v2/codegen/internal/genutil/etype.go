@@ -108,6 +108,22 @@ func (u *TypeUnmarshaller) ReadBody(bodyExpr *Statement) *Statement {
 	return u.unmarshallerExpr.Clone().Dot("ReadBody").Call(bodyExpr.Clone())
 }
 
+// ReadMultipartForm returns an expression to parse the request body
+// as a multipart form, returning the *multipart.Form.
+func (u *TypeUnmarshaller) ReadMultipartForm(httpReqExpr *Statement) *Statement {
+	return u.unmarshallerExpr.Clone().Dot("ReadMultipartForm").Call(httpReqExpr.Clone())
+}
+
+// UnmarshalFiles returns the code to unmarshal the files uploaded for a form field,
+// given as a []*multipart.FileHeader, into a field of the given file type.
+func (u *TypeUnmarshaller) UnmarshalFiles(typ schema.Type, files *Statement) *Statement {
+	fn := "UnmarshalFile"
+	if _, ok := typ.(schema.ListType); ok {
+		fn = "UnmarshalFiles"
+	}
+	return Qual("encore.dev/appruntime/shared/etype", fn).Call(u.unmarshallerExpr.Clone(), files.Clone())
+}
+
 // ParseJSON returns an expression to parse json.
 // It uses the iterator accessed through the given iteratorExpr to parse JSON into the given dstExpr.
 // The dstExpr must be a pointer value.
@@ -410,6 +410,8 @@ func (r *typeResolver) parseEncoreBuiltin(pkgPath paths.Pkg, name string) (Built
 		return Time, true
 	case pkgPath == "encoding/json" && name == "RawMessage":
 		return JSON, true
+	case pkgPath == "mime/multipart" && name == "FileHeader":
+		return File, true
 	}
 	return unsupported, false
 }
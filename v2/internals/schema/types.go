@@ -181,6 +181,7 @@ const (
 	UUID
 	JSON
 	UserID
+	File  // *multipart.FileHeader, for files uploaded in multipart requests
 	Error // builtin "error" type, for convenience
 
 	// unsupported is a special value used
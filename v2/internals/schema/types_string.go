@@ -29,13 +29,14 @@ func _() {
 	_ = x[UUID-18]
 	_ = x[JSON-19]
 	_ = x[UserID-20]
-	_ = x[Error-21]
+	_ = x[File-21]
+	_ = x[Error-22]
 	_ = x[unsupported - -1]
 }
 
-const _BuiltinKind_name = "unsupportedInvalidAnyBoolIntInt8Int16Int32Int64UintUint8Uint16Uint32Uint64Float32Float64StringBytesTimeUUIDJSONUserIDError"
+const _BuiltinKind_name = "unsupportedInvalidAnyBoolIntInt8Int16Int32Int64UintUint8Uint16Uint32Uint64Float32Float64StringBytesTimeUUIDJSONUserIDFileError"
 
-var _BuiltinKind_index = [...]uint8{0, 11, 18, 21, 25, 28, 32, 37, 42, 47, 51, 56, 62, 68, 74, 81, 88, 94, 99, 103, 107, 111, 117, 122}
+var _BuiltinKind_index = [...]uint8{0, 11, 18, 21, 25, 28, 32, 37, 42, 47, 51, 56, 62, 68, 74, 81, 88, 94, 99, 103, 107, 111, 117, 121, 126}
 
 func (i BuiltinKind) String() string {
 	i -= -1
@@ -6,6 +6,7 @@ import (
 	"go/ast"
 	"go/build"
 	"go/token"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
@@ -65,6 +66,11 @@ import "context"
 func TestFunc(ctx context.Context) error { return nil }`)
 	ts.Check(txtar.Write(additional, workdir))
 
+	// Resolve encore.dev to the runtime being tested, so the tests can use
+	// packages that are newer than the required version.
+	ts.Check(os.WriteFile(filepath.Join(workdir, "go.mod"), []byte(string(additional.Files[0].Data)+
+		"\nreplace encore.dev => "+filepath.Join(RuntimeDir, "go")+"\n"), 0644))
+
 	return newContextForFSPath(c, workdir, parseTests)
 }
 
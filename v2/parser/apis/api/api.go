@@ -5,6 +5,7 @@ import (
 	"go/ast"
 	"go/token"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -52,11 +53,52 @@ type Endpoint struct {
 	// meaning all request/response information will be redacted in traces.
 	Sensitive bool
 
+	// OptionalAuth indicates the endpoint was declared with "auth optional",
+	// meaning requests without credentials are allowed as unauthenticated requests,
+	// while requests with credentials must be accepted by the auth handler.
+	// Access is Auth when this is set.
+	OptionalAuth bool
+
+	// Scopes are the scopes the authenticated user must have, all of which are required.
+	// Roles are the roles the authenticated user must have, any one of which is sufficient.
+	Scopes []string
+	Roles  []string
+
+	// TraceSampleRate is the rate at which to sample traces of requests
+	// to the endpoint, between [0, 1], as declared using the sampling field.
+	TraceSampleRate option.Option[float64]
+
+	// SSE indicates the endpoint streams server-sent events to the client
+	// using an *sse.Writer[T] parameter, instead of returning a response.
+	// Response is then the type of the events.
+	SSE bool
+
+	// WebSocket indicates the endpoint accepts WebSocket connections
+	// using a *websocket.Conn[In, Out] parameter, instead of returning a response.
+	// Response is then the type of the outbound messages, and
+	// InboundMessage the type of the messages received from the client.
+	WebSocket      bool
+	InboundMessage schema.Type
+
+	// StreamRequest indicates the endpoint streams its request body
+	// using a *stream.Body parameter, and StreamResponse that it streams
+	// its response body by returning a *stream.Body. Request and Response
+	// are then the types of the rest of the request and response, if any.
+	StreamRequest  bool
+	StreamResponse bool
+
+	// Multipart indicates the request contains files,
+	// making it a multipart/form-data request.
+	Multipart bool
+
 	reqEncOnce  sync.Once
 	reqEncoding []*apienc.RequestEncoding
 
 	respEncOnce  sync.Once
 	respEncoding *apienc.ResponseEncoding
+
+	protoEncOnce  sync.Once
+	protoEncoding *apienc.ProtoEncoding
 }
 
 func (ep *Endpoint) GoString() string {
@@ -78,12 +120,40 @@ func (ep *Endpoint) RequestEncoding() []*apienc.RequestEncoding {
 	}
 
 	ep.reqEncOnce.Do(func() {
-		requestParam := ep.Decl.Type.Params[len(ep.Decl.Type.Params)-1]
+		requestParam := ep.Decl.Type.Params[ep.numPayloadParams()-1]
 		ep.reqEncoding = apienc.DescribeRequest(ep.errs, requestParam, ep.Request, ep.HTTPMethodsField, ep.HTTPMethods...)
 	})
 	return ep.reqEncoding
 }
 
+// SupportsGRPC reports whether the endpoint can be called over gRPC,
+// which isn't the case for streaming, multipart and raw endpoints.
+func (ep *Endpoint) SupportsGRPC() bool {
+	return !ep.Raw && !ep.SSE && !ep.WebSocket && !ep.Path.HasFallback() &&
+		!ep.StreamRequest && !ep.StreamResponse && !ep.Multipart
+}
+
+// ProtoEncoding describes the protobuf messages the request and response are
+// encoded as, or nil if the endpoint can't be called over gRPC.
+func (ep *Endpoint) ProtoEncoding() *apienc.ProtoEncoding {
+	ep.protoEncOnce.Do(func() {
+		if ep.SupportsGRPC() {
+			ep.protoEncoding = apienc.DescribeProto(ep.errs, ep.Request, ep.Response)
+		}
+	})
+	return ep.protoEncoding
+}
+
+// numPayloadParams is the number of parameters up to and including
+// the request payload, which excludes the event writer of SSE endpoints,
+// the connection of WebSocket endpoints and streamed request bodies.
+func (ep *Endpoint) numPayloadParams() int {
+	if ep.SSE || ep.WebSocket || ep.StreamRequest {
+		return len(ep.Decl.Type.Params) - 1
+	}
+	return len(ep.Decl.Type.Params)
+}
+
 func (ep *Endpoint) ResponseEncoding() *apienc.ResponseEncoding {
 	ep.respEncOnce.Do(func() {
 		ep.respEncoding = apienc.DescribeResponse(ep.errs, ep.Response)
@@ -148,8 +218,12 @@ func Parse(d ParseData) *Endpoint {
 			rpc.HTTPMethods = []string{"*"}
 		} else {
 			// For non-raw endpoints, if there's a request payload
-			// default to POST-only.
-			if rpc.Request != nil {
+			// or a streamed request body default to POST-only. SSE endpoints default to GET,
+			// as that's all browsers' EventSource supports, and
+			// WebSocket handshakes are always GET requests.
+			if rpc.SSE || rpc.WebSocket {
+				rpc.HTTPMethods = []string{"GET"}
+			} else if rpc.Request != nil || rpc.StreamRequest {
 				rpc.HTTPMethods = []string{"POST"}
 			} else {
 				rpc.HTTPMethods = []string{"GET", "POST"}
@@ -158,10 +232,30 @@ func Parse(d ParseData) *Endpoint {
 	}
 
 	// RequestEncoding will validate the request payload.
-	rpc.RequestEncoding()
+	encs := rpc.RequestEncoding()
+	if rpc.StreamRequest {
+		validateStreamRequest(d.Errs, rpc, encs)
+	}
+	rpc.Multipart = slices.ContainsFunc(encs, func(enc *apienc.RequestEncoding) bool { return enc.Multipart })
 
 	// ResponseEncoding will validate the response payload.
-	rpc.ResponseEncoding()
+	if enc := rpc.ResponseEncoding(); rpc.SSE && enc != nil && len(enc.HeaderParameters) > 0 {
+		d.Errs.Add(errSSEEventHeaders.AtGoNode(enc.HeaderParameters[0].Type.ASTExpr()))
+	} else if rpc.WebSocket {
+		// Messages are described like responses, so validate the inbound ones too.
+		inEnc := apienc.DescribeResponse(d.Errs, rpc.InboundMessage)
+		for _, e := range []*apienc.ResponseEncoding{enc, inEnc} {
+			if e != nil && len(e.HeaderParameters) > 0 {
+				d.Errs.Add(errWebSocketMessageHeaders.AtGoNode(e.HeaderParameters[0].Type.ASTExpr()))
+				break
+			}
+		}
+		if slices.ContainsFunc(rpc.HTTPMethods, func(m string) bool { return m != "GET" }) {
+			d.Errs.Add(errWebSocketMethod.AtGoNode(rpc.HTTPMethodsField.MustGet()))
+		}
+	} else if rpc.StreamResponse && enc != nil && len(enc.BodyParameters) > 0 {
+		d.Errs.Add(errStreamResponseBodyFields.AtGoNode(enc.BodyParameters[0].Type.ASTExpr()))
+	}
 
 	return rpc
 }
@@ -176,11 +270,42 @@ func initTypedRPC(errs *perr.List, endpoint *Endpoint) {
 	}
 
 	numResults := len(sig.Results)
-	if numResults == 0 || numResults > 2 {
+	if numResults == 0 || numResults > 3 || (numResults == 3 && !isStreamBody(sig.Results[1].Type)) {
 		errs.Add(errWrongNumberResults(numResults).AtGoNode(sig.AST.Results))
 		return
 	}
 
+	// SSE endpoints take an *sse.Writer[T] as their last parameter
+	// to send events of type T with, and only return an error.
+	if numParams > 1 {
+		last := sig.Params[numParams-1]
+		if deref, n := schemautil.Deref(last.Type); n == 1 && schemautil.IsNamed(deref, "encore.dev/sse", "Writer") {
+			if numResults != 1 {
+				errs.Add(errSSEResults.AtGoNode(sig.AST.Results))
+				return
+			}
+			endpoint.SSE = true
+			endpoint.Response = deref.(schema.NamedType).TypeArgs[0]
+			numParams--
+		} else if n == 1 && schemautil.IsNamed(deref, "encore.dev/websocket", "Conn") {
+			// WebSocket endpoints take a *websocket.Conn[In, Out] instead,
+			// to exchange messages with the client.
+			if numResults != 1 {
+				errs.Add(errWebSocketResults.AtGoNode(sig.AST.Results))
+				return
+			}
+			typeArgs := deref.(schema.NamedType).TypeArgs
+			endpoint.WebSocket = true
+			endpoint.InboundMessage = typeArgs[0]
+			endpoint.Response = typeArgs[1]
+			numParams--
+		} else if isStreamBody(last.Type) {
+			// Endpoints streaming their request body take a *stream.Body.
+			endpoint.StreamRequest = true
+			numParams--
+		}
+	}
+
 	// First type should always be context.Context
 	ctxParam := sig.Params[0]
 	if !schemautil.IsNamed(ctxParam.Type, "context", "Context") {
@@ -199,6 +324,10 @@ func initTypedRPC(errs *perr.List, endpoint *Endpoint) {
 	seenParams := 0
 	for i := 0; i < numParams-1; i++ {
 		param := sig.Params[i+1] // +1 to skip context.Context
+		if isStreamBody(param.Type) {
+			errs.Add(errStreamBodyParam.AtGoNode(param.AST))
+			continue
+		}
 
 		// Is it a path parameter?
 		if i < len(pathParams) {
@@ -233,8 +362,15 @@ func initTypedRPC(errs *perr.List, endpoint *Endpoint) {
 		errs.Add(err)
 	}
 
-	// First return value must be *T or *pkg.T
-	if numResults >= 2 {
+	// Endpoints streaming their response body return a *stream.Body,
+	// optionally preceded by the rest of the response.
+	if numResults >= 2 && isStreamBody(sig.Results[numResults-2].Type) {
+		endpoint.StreamResponse = true
+		if numResults == 3 {
+			endpoint.Response = sig.Results[0].Type
+		}
+	} else if numResults >= 2 && !endpoint.SSE && !endpoint.WebSocket {
+		// First return value must be *T or *pkg.T
 		result := sig.Results[0]
 		endpoint.Response = result.Type
 	}
@@ -246,6 +382,26 @@ func initTypedRPC(errs *perr.List, endpoint *Endpoint) {
 	}
 }
 
+// isStreamBody reports whether typ is *stream.Body.
+func isStreamBody(typ schema.Type) bool {
+	deref, n := schemautil.Deref(typ)
+	return n == 1 && schemautil.IsNamed(deref, "encore.dev/stream", "Body")
+}
+
+// validateStreamRequest validates the request of an endpoint streaming its request body,
+// whose fields can't be encoded in the body and which must be sent with a method that has one.
+func validateStreamRequest(errs *perr.List, ep *Endpoint, encs []*apienc.RequestEncoding) {
+	for _, enc := range encs {
+		if len(enc.BodyParameters) > 0 {
+			errs.Add(errStreamRequestBodyFields.AtGoNode(enc.BodyParameters[0].Type.ASTExpr()))
+			break
+		}
+	}
+	if slices.ContainsFunc(ep.HTTPMethods, func(m string) bool { return m == "GET" || m == "HEAD" || m == "*" }) {
+		errs.Add(errStreamRequestMethod.AtGoNode(ep.HTTPMethodsField.MustGet()))
+	}
+}
+
 func initRawRPC(errs *perr.List, endpoint *Endpoint) {
 	decl := endpoint.Decl
 	sig := decl.Type
@@ -306,11 +462,13 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 
 	var accessField directive.Field
 	var rawTag directive.Field
+	var authzFields []directive.Field
+	var optionalTag directive.Field
 
 	accessOptions := []string{"public", "private", "auth"}
 	ok := directive.Validate(errs, dir, directive.ValidateSpec{
-		AllowedOptions: append([]string{"raw", "sensitive"}, accessOptions...),
-		AllowedFields:  []string{"path", "method"},
+		AllowedOptions: append([]string{"raw", "sensitive", "optional"}, accessOptions...),
+		AllowedFields:  []string{"path", "method", "scopes", "roles", "sampling"},
 
 		ValidateOption: func(errs *perr.List, opt directive.Field) (ok bool) {
 			// If this is an access option, check for duplicates.
@@ -329,6 +487,9 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 				rawTag = opt
 			case "sensitive":
 				endpoint.Sensitive = true
+			case "optional":
+				optionalTag = opt
+				endpoint.OptionalAuth = true
 			}
 
 			return true
@@ -364,6 +525,22 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 						}
 					}
 				}
+
+			case "scopes":
+				endpoint.Scopes = f.List()
+				authzFields = append(authzFields, f)
+			case "roles":
+				endpoint.Roles = f.List()
+				authzFields = append(authzFields, f)
+
+			case "sampling":
+				// The sampling rate is given as a percentage, such as "sampling=1%".
+				pct, err := strconv.ParseFloat(strings.TrimSuffix(f.Value, "%"), 64)
+				if err != nil || pct < 0 || pct > 100 {
+					errs.Add(errInvalidSamplingRate(f.Value).AtGoNode(f))
+					return false
+				}
+				endpoint.TraceSampleRate = option.Some(pct / 100)
 			}
 			return true
 		},
@@ -389,6 +566,15 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 		errs.Add(errRawEndpointCantBePrivate.AtGoNode(rawTag, errors.AsError("declared as raw here")).AtGoNode(accessField, errors.AsError("set as private here")))
 		return nil, false
 	}
+	if endpoint.OptionalAuth && endpoint.Access != Auth {
+		errs.Add(errOptionalRequiresAuth.AtGoNode(optionalTag))
+		return nil, false
+	}
+	if (endpoint.Access != Auth || endpoint.OptionalAuth) && len(authzFields) > 0 {
+		f := authzFields[0]
+		errs.Add(errAuthorizationRequiresAuth(f.Key).AtGoNode(f))
+		return nil, false
+	}
 
 	return endpoint, true
 }
@@ -119,6 +119,312 @@ func Raw(w http.ResponseWriter, req *http.Request) {}
 				HTTPMethods: []string{"*"},
 			},
 		},
+		{
+			name:    "sse",
+			imports: []string{"encore.dev/sse"},
+			def: `
+type Params struct { Topic string }
+type Event struct { Message string }
+
+//encore:api public path=/events/:room
+func Events(ctx context.Context, room string, p *Params, w *sse.Writer[*Event]) error {}
+`,
+			want: &Endpoint{
+				Name:        "Events",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				SSE:         true,
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "events", ValueType: schema.String},
+					{Type: resourcepaths.Param, Value: "room", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"GET"},
+				Request:     schema.PointerType{Elem: schema.NamedType{}},
+				Response:    schema.PointerType{Elem: schema.NamedType{}},
+			},
+		},
+		{
+			name:    "sse_with_response",
+			imports: []string{"encore.dev/sse"},
+			def: `
+type Event struct { Message string }
+
+//encore:api public
+func Events(ctx context.Context, w *sse.Writer[*Event]) (*Event, error) {}
+`,
+			wantErrs: []string{`.*APIs sending server-sent events must only return an error.*`},
+		},
+		{
+			name:    "sse_event_headers",
+			imports: []string{"encore.dev/sse"},
+			def: `
+type Event struct { ID string ` + "`header:\"X-ID\"`" + ` }
+
+//encore:api public
+func Events(ctx context.Context, w *sse.Writer[*Event]) error {}
+`,
+			wantErrs: []string{`.*Server-sent events can't contain header fields.*`},
+		},
+		{
+			name:    "websocket",
+			imports: []string{"encore.dev/websocket"},
+			def: `
+type Params struct { Token string }
+type ChatMessage struct { Text string }
+type ChatEvent struct { Text string }
+
+//encore:api auth path=/chat/:room
+func Chat(ctx context.Context, room string, p *Params, conn *websocket.Conn[*ChatMessage, *ChatEvent]) error {}
+`,
+			want: &Endpoint{
+				Name:        "Chat",
+				Doc:         "",
+				Access:      Auth,
+				AccessField: option.Some(directive.Field{Value: "auth"}),
+				WebSocket:   true,
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "chat", ValueType: schema.String},
+					{Type: resourcepaths.Param, Value: "room", ValueType: schema.String},
+				}},
+				HTTPMethods:    []string{"GET"},
+				Request:        schema.PointerType{Elem: schema.NamedType{}},
+				InboundMessage: schema.PointerType{Elem: schema.NamedType{}},
+				Response:       schema.PointerType{Elem: schema.NamedType{}},
+			},
+		},
+		{
+			name:    "websocket_with_response",
+			imports: []string{"encore.dev/websocket"},
+			def: `
+type Message struct { Text string }
+
+//encore:api public
+func Chat(ctx context.Context, conn *websocket.Conn[*Message, *Message]) (*Message, error) {}
+`,
+			wantErrs: []string{`.*APIs accepting WebSocket connections must only return an error.*`},
+		},
+		{
+			name:    "websocket_message_headers",
+			imports: []string{"encore.dev/websocket"},
+			def: `
+type Message struct { ID string ` + "`header:\"X-ID\"`" + ` }
+
+//encore:api public
+func Chat(ctx context.Context, conn *websocket.Conn[*Message, *Message]) error {}
+`,
+			wantErrs: []string{`.*WebSocket messages can't contain header fields.*`},
+		},
+		{
+			name:    "websocket_post",
+			imports: []string{"encore.dev/websocket"},
+			def: `
+type Message struct { Text string }
+
+//encore:api public method=POST
+func Chat(ctx context.Context, conn *websocket.Conn[*Message, *Message]) error {}
+`,
+			wantErrs: []string{`.*APIs accepting WebSocket connections can only use the GET method.*`},
+		},
+		{
+			name:    "stream_request",
+			imports: []string{"encore.dev/stream"},
+			def: `
+type Params struct { Name string ` + "`query:\"name\"`" + ` }
+type File struct { ID string }
+
+//encore:api auth path=/files/:id
+func Upload(ctx context.Context, id string, p *Params, body *stream.Body) (*File, error) {}
+`,
+			want: &Endpoint{
+				Name:          "Upload",
+				Doc:           "",
+				Access:        Auth,
+				AccessField:   option.Some(directive.Field{Value: "auth"}),
+				StreamRequest: true,
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "files", ValueType: schema.String},
+					{Type: resourcepaths.Param, Value: "id", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"POST"},
+				Request:     schema.PointerType{Elem: schema.NamedType{}},
+				Response:    schema.PointerType{Elem: schema.NamedType{}},
+			},
+		},
+		{
+			name:    "stream_response",
+			imports: []string{"encore.dev/stream"},
+			def: `
+type Meta struct { Disposition string ` + "`header:\"Content-Disposition\"`" + ` }
+
+//encore:api public method=GET path=/files/:id
+func Download(ctx context.Context, id string) (*Meta, *stream.Body, error) {}
+`,
+			want: &Endpoint{
+				Name:           "Download",
+				Doc:            "",
+				Access:         Public,
+				AccessField:    option.Some(directive.Field{Value: "public"}),
+				StreamResponse: true,
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "files", ValueType: schema.String},
+					{Type: resourcepaths.Param, Value: "id", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"GET"},
+				Response:    schema.PointerType{Elem: schema.NamedType{}},
+			},
+		},
+		{
+			name:    "stream_request_and_response",
+			imports: []string{"encore.dev/stream"},
+			def: `
+//encore:api public
+func Convert(ctx context.Context, body *stream.Body) (*stream.Body, error) {}
+`,
+			want: &Endpoint{
+				Name:           "Convert",
+				Doc:            "",
+				Access:         Public,
+				AccessField:    option.Some(directive.Field{Value: "public"}),
+				StreamRequest:  true,
+				StreamResponse: true,
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Convert", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"POST"},
+			},
+		},
+		{
+			name:    "stream_request_body_fields",
+			imports: []string{"encore.dev/stream"},
+			def: `
+type Params struct { Name string }
+
+//encore:api public
+func Upload(ctx context.Context, p *Params, body *stream.Body) error {}
+`,
+			wantErrs: []string{`.*APIs streaming their request body can't have request fields encoded in the body.*`},
+		},
+		{
+			name:    "stream_response_body_fields",
+			imports: []string{"encore.dev/stream"},
+			def: `
+type Meta struct { Name string }
+
+//encore:api public
+func Download(ctx context.Context) (*Meta, *stream.Body, error) {}
+`,
+			wantErrs: []string{`.*APIs streaming their response body can't have response fields encoded in the body.*`},
+		},
+		{
+			name:    "stream_request_get",
+			imports: []string{"encore.dev/stream"},
+			def: `
+//encore:api public method=GET
+func Upload(ctx context.Context, body *stream.Body) error {}
+`,
+			wantErrs: []string{`.*APIs streaming their request body can't use the GET or HEAD methods.*`},
+		},
+		{
+			name:    "stream_body_not_last",
+			imports: []string{"encore.dev/stream", "encore.dev/sse"},
+			def: `
+type Event struct { Message string }
+
+//encore:api public
+func Upload(ctx context.Context, body *stream.Body, w *sse.Writer[*Event]) error {}
+`,
+			wantErrs: []string{`.*The \*stream.Body parameter must be the last parameter of the API.*`},
+		},
+		{
+			name: "three_results",
+			def: `
+type Meta struct { Name string }
+
+//encore:api public
+func Foo(ctx context.Context) (*Meta, *Meta, error) {}
+`,
+			wantErrs: []string{`.*API functions must have at most 1 or 2 results, found 3 results.*`},
+		},
+		{
+			name: "with_scopes_and_roles",
+			def: `
+//encore:api auth scopes=orders:read,orders:write roles=admin
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Auth,
+				AccessField: option.Some(directive.Field{Value: "auth"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"GET", "POST"},
+				Scopes:      []string{"orders:read", "orders:write"},
+				Roles:       []string{"admin"},
+			},
+		},
+		{
+			name: "scopes_without_auth",
+			def: `
+//encore:api public scopes=orders:read
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`.*The scopes field can only be used on APIs that require authentication.*`},
+		},
+		{
+			name: "with_sampling",
+			def: `
+//encore:api public sampling=1%
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods:     []string{"GET", "POST"},
+				TraceSampleRate: option.Some(0.01),
+			},
+		},
+		{
+			name: "invalid_sampling",
+			def: `
+//encore:api public sampling=150%
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`.*Invalid sampling rate "150%".*`},
+		},
+		{
+			name: "optional_auth",
+			def: `
+//encore:api auth optional
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:         "Foo",
+				Doc:          "",
+				Access:       Auth,
+				AccessField:  option.Some(directive.Field{Value: "auth"}),
+				OptionalAuth: true,
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"GET", "POST"},
+			},
+		},
+		{
+			name: "optional_without_auth",
+			def: `
+//encore:api public optional
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`.*The optional option can only be used together with the auth access option.*`},
+		},
 	}
 
 	// testArchive renders the txtar archive to use for a given test.
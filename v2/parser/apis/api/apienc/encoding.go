@@ -2,6 +2,7 @@ package apienc
 
 import (
 	"fmt"
+	"math"
 	"slices"
 	"sort"
 	"strings"
@@ -139,6 +140,12 @@ type RequestEncoding struct {
 	HeaderParameters []*ParameterEncoding `json:"header_parameters"`
 	QueryParameters  []*ParameterEncoding `json:"query_parameters"`
 	BodyParameters   []*ParameterEncoding `json:"body_parameters"`
+	// Multipart is true if the body parameters include files,
+	// in which case the body is encoded as multipart/form-data.
+	Multipart bool `json:"multipart"`
+	// MaxMultipartSize is the largest multipart body accepted, from the
+	// maxsize rules of the files. It's 0 if a file's size is unlimited.
+	MaxMultipartSize int64 `json:"max_multipart_size"`
 }
 
 func (r *RequestEncoding) AllParameters() []*ParameterEncoding {
@@ -205,6 +212,16 @@ func DescribeResponse(errs *perr.List, responseSchema schema.Type) *ResponseEnco
 	}
 }
 
+// IsFile reports whether t is the type of a file uploaded in a request,
+// *multipart.FileHeader, or of several files, []*multipart.FileHeader.
+func IsFile(t schema.Type) bool {
+	if list, ok := t.(schema.ListType); ok && list.Len == -1 {
+		t = list.Elem
+	}
+	deref, n := schemautil.Deref(t)
+	return n == 1 && schemautil.IsBuiltinKind(deref, schema.File)
+}
+
 func getConcreteNamedStruct(errs *perr.List, typ schema.Type) (st schema.StructType, ok bool) {
 	if res, ok := schemautil.ResolveNamedStruct(typ, false); ok {
 		concrete := schemautil.ConcretizeWithTypeArgs(errs, res.Decl.Type, res.TypeArgs)
@@ -301,6 +318,21 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 			}
 		}
 
+		// Requests containing files are sent as multipart forms,
+		// with the other body parameters encoded as form values.
+		multipart := slices.ContainsFunc(fields[Body], func(p *ParameterEncoding) bool { return IsFile(p.Type) })
+		if multipart {
+			for _, field := range fields[Body] {
+				if _, _, ok := schemautil.IsBuiltinOrList(field.Type); !ok && !IsFile(field.Type) {
+					errs.Add(
+						errInvalidFormFieldType(field.Type.String()).
+							AtGoNode(field.Type.ASTExpr(), errors.AsError("unsupported type")).
+							AtGoNode(requestAST.AST, errors.AsHelp("used here")),
+					)
+				}
+			}
+		}
+
 		if errs.Len() > 0 {
 			return nil
 		}
@@ -316,12 +348,17 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 			errs.Add(err)
 			return nil
 		}
-		reqs = append(reqs, &RequestEncoding{
+		enc := &RequestEncoding{
 			HTTPMethods:      methods,
 			QueryParameters:  fields[Query],
 			HeaderParameters: fields[Header],
 			BodyParameters:   fields[Body],
-		})
+			Multipart:        multipart,
+		}
+		if multipart {
+			enc.MaxMultipartSize = maxMultipartSize(st, fields[Body])
+		}
+		reqs = append(reqs, enc)
 	}
 
 	// Sort by first method to get a deterministic order (list is randomized by map above)
@@ -331,6 +368,31 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 	return reqs
 }
 
+// multipartOverhead is the size allowed for the form values and part headers
+// of multipart requests, in addition to the size of their files.
+const multipartOverhead = 1 << 20
+
+// maxMultipartSize returns the largest multipart body accepted for a request
+// with the given body parameters, or 0 if the size of a file is unlimited.
+func maxMultipartSize(st schema.StructType, body []*ParameterEncoding) int64 {
+	total := int64(multipartOverhead)
+	for _, f := range st.Fields {
+		if !IsFile(f.Type) || !slices.ContainsFunc(body, func(p *ParameterEncoding) bool {
+			return p.SrcName == f.Name.GetOrElse("")
+		}) {
+			continue
+		}
+		size, ok := maxFilesSize(f)
+		if !ok {
+			return 0
+		} else if size > math.MaxInt64-total {
+			return math.MaxInt64
+		}
+		total += size
+	}
+	return total
+}
+
 // AuthEncoding expresses how a response should be encoded on the wire.
 type AuthEncoding struct {
 	// LegacyTokenFormat specifies whether the auth encoding uses the legacy format of
@@ -78,4 +78,51 @@ var (
 			"thus all parameters must be sent as query strings or headers. "+
 			"See https://encore.dev/docs/develop/api-schemas#supported-types for more information."),
 	)
+
+	errValidationRuleArg = errRange.Newf(
+		"Invalid validate tag",
+		"The validation rule %q requires %s.",
+		errors.WithDetails("See https://encore.dev/docs/go/develop/validation for more information."),
+	)
+
+	errValidationRuleType = errRange.Newf(
+		"Invalid validate tag",
+		"The validation rule %q can only be used with %s.",
+		errors.WithDetails("See https://encore.dev/docs/go/develop/validation for more information."),
+	)
+
+	errInvalidFormFieldType = errRange.Newf(
+		"Invalid request type",
+		"API request parameters of type %s are not supported in requests containing files. You can only "+
+			"use built-in types, or slices of built-in types such as strings, booleans, int, time.Time.",
+
+		errors.WithDetails("Requests containing files are sent as multipart forms, "+
+			"which encode the other fields in the body as form values. "+
+			"See https://encore.dev/docs/go/primitives/file-uploads for more information."),
+	)
+
+	ErrFileNotSupported = errRange.New(
+		"Invalid API schema",
+		"Files can only be used as fields of API request types, of type *multipart.FileHeader or []*multipart.FileHeader.",
+
+		errors.WithDetails("See https://encore.dev/docs/go/primitives/file-uploads for more information."),
+	)
+
+	errInvalidProtoFieldNumber = errRange.Newf(
+		"Invalid proto tag",
+		"The protobuf field number %q is invalid. Field numbers must be between 1 and 536870911, "+
+			"excluding 19000 to 19999 which are reserved by protobuf.",
+
+		errors.WithDetails("See https://encore.dev/docs/go/self-host/configure-infra#calling-services-over-grpc for more information."),
+	)
+
+	errProtoFieldNumberConflict = errRange.Newf(
+		"Conflicting protobuf field numbers",
+		"The field %s has the same protobuf field number %d as the field %s. "+
+			"Use a `proto` struct tag to number one of them.",
+
+		errors.WithDetails("Fields of API request and response types are encoded as protobuf message fields "+
+			"for calls made over gRPC, numbered by their `proto` tag or otherwise by a hash of their JSON name. "+
+			"See https://encore.dev/docs/go/self-host/configure-infra#calling-services-over-grpc for more information."),
+	)
 )
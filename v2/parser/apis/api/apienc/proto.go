@@ -0,0 +1,194 @@
+package apienc
+
+import (
+	"fmt"
+	"go/ast"
+	"hash/fnv"
+	"strconv"
+
+	"encr.dev/pkg/errors"
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/internals/schema"
+	"encr.dev/v2/internals/schema/schemautil"
+)
+
+// ProtoEncoding describes the protobuf messages the request and response
+// of an endpoint are encoded as, for service-to-service calls made over gRPC.
+type ProtoEncoding struct {
+	Request  *ProtoSchema // nil if the endpoint has no request
+	Response *ProtoSchema // nil if the endpoint has no response
+}
+
+// ProtoSchema describes the messages a type is encoded as.
+type ProtoSchema struct {
+	// Root is the name of the message of the type itself.
+	Root string
+
+	// Messages are the messages of the struct types making up the type, keyed by name.
+	Messages map[string]*ProtoMessage
+
+	// hashes are the types of the messages, to tell apart
+	// different types with the same name.
+	hashes map[string]schemautil.TypeHash
+}
+
+// ProtoMessage is the message a struct type is encoded as.
+type ProtoMessage struct {
+	Fields []ProtoField
+}
+
+// ProtoField is a field of a message.
+type ProtoField struct {
+	Name   string // name of the struct field
+	Number int32
+
+	// Message is the name of the message of the field's struct type,
+	// or of its elements' for pointers, slices, arrays and maps.
+	// It's empty if there is none.
+	Message string
+}
+
+// Field numbers derived from field names are in the range [minHashedNumber, maxHashedNumber),
+// which is above the numbers reserved by protobuf and encoded in 3 bytes along with the wire type.
+const (
+	minHashedNumber = 20000
+	maxHashedNumber = 1 << 18
+
+	firstReservedNumber = 19000
+	lastReservedNumber  = 19999
+	maxNumber           = 1<<29 - 1
+)
+
+// DescribeProto describes the protobuf messages of the request and response types,
+// reporting invalid and conflicting field numbers to errs.
+//
+// Each exported field of a struct is a message field. Its number is set with
+// a `proto:"N"` struct tag, or otherwise derived from a hash of the field's
+// JSON name, so that like with JSON, fields can be reordered and added
+// without breaking callers running an older version. Fields ignored by JSON
+// with `json:"-"` are left out, unless they're sent as headers, query strings or cookies.
+func DescribeProto(errs *perr.List, req, resp schema.Type) *ProtoEncoding {
+	enc := &ProtoEncoding{}
+	if req != nil {
+		enc.Request = describeProtoSchema(errs, req)
+	}
+	if resp != nil {
+		enc.Response = describeProtoSchema(errs, resp)
+	}
+	return enc
+}
+
+func describeProtoSchema(errs *perr.List, typ schema.Type) *ProtoSchema {
+	s := &ProtoSchema{
+		Messages: make(map[string]*ProtoMessage),
+		hashes:   make(map[string]schemautil.TypeHash),
+	}
+	s.Root = s.add(errs, schemautil.ConcretizeGenericType(errs, typ), "")
+	return s
+}
+
+// add adds the message of the struct type of typ, or of its elements, and the messages
+// of the struct types making it up. It returns the name of the message, or "" if there is none.
+// Anonymous structs are named anonName.
+func (s *ProtoSchema) add(errs *perr.List, typ schema.Type, anonName string) string {
+	for {
+		switch t := typ.(type) {
+		case schema.PointerType:
+			typ = t.Elem
+		case schema.ListType:
+			typ = t.Elem
+		case schema.MapType:
+			typ = t.Value
+		case schema.NamedType:
+			st, ok := t.Decl().Type.(schema.StructType)
+			if !ok {
+				typ = t.Decl().Type
+				continue
+			}
+			return s.addMessage(errs, t.DeclInfo.File.Pkg.ImportPath.String()+"."+t.String(), t, st)
+		case schema.StructType:
+			return s.addMessage(errs, anonName, t, t)
+		default:
+			return ""
+		}
+	}
+}
+
+// addMessage adds the message of the struct type typ, whose underlying type is st,
+// and returns its name.
+func (s *ProtoSchema) addMessage(errs *perr.List, name string, typ schema.Type, st schema.StructType) string {
+	hash := schemautil.Hash(typ)
+	base := name
+	for i := 2; ; i++ {
+		if h, ok := s.hashes[name]; !ok {
+			break
+		} else if h == hash {
+			// The message has already been added, or is being added for recursive types.
+			return name
+		}
+		name = fmt.Sprintf("%s#%d", base, i)
+	}
+
+	msg := &ProtoMessage{}
+	s.Messages[name] = msg
+	s.hashes[name] = hash
+
+	byNum := make(map[int32]schema.StructField)
+	for _, f := range st.Fields {
+		fieldName, ok := f.Name.Get()
+		if !ok || !ast.IsExported(fieldName) || protoIgnored(f) {
+			continue
+		}
+		num, ok := protoFieldNumber(errs, f, fieldName)
+		if !ok {
+			continue
+		}
+		if other, ok := byNum[num]; ok {
+			errs.Add(
+				errProtoFieldNumberConflict(fieldName, num, other.Name.MustGet()).
+					AtGoNode(f.AST, errors.AsError("this field")).
+					AtGoNode(other.AST, errors.AsHelp("has the same number as this field")),
+			)
+			continue
+		}
+		byNum[num] = f
+		msg.Fields = append(msg.Fields, ProtoField{
+			Name:    fieldName,
+			Number:  num,
+			Message: s.add(errs, f.Type, name+"."+fieldName),
+		})
+	}
+	return name
+}
+
+// protoFieldNumber returns the number of a struct field in its message.
+func protoFieldNumber(errs *perr.List, f schema.StructField, name string) (num int32, ok bool) {
+	if tag, err := f.Tag.Get("proto"); err == nil {
+		n, err := strconv.ParseInt(tag.Value(), 10, 32)
+		if err != nil || n < 1 || n > maxNumber || (n >= firstReservedNumber && n <= lastReservedNumber) {
+			errs.Add(errInvalidProtoFieldNumber(tag.Value()).AtGoNode(f.AST.Tag))
+			return 0, false
+		}
+		return int32(n), true
+	}
+
+	if tag, err := f.Tag.Get("json"); err == nil && tag.Name != "" && tag.Name != "-" {
+		name = tag.Name
+	}
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return int32(minHashedNumber + h.Sum32()%(maxHashedNumber-minHashedNumber)), true
+}
+
+// protoIgnored reports whether a struct field is left out of its message.
+func protoIgnored(f schema.StructField) bool {
+	if tag, err := f.Tag.Get("json"); err != nil || tag.Value() != "-" {
+		return false
+	}
+	for _, key := range []string{"header", "query", "qs", "cookie"} {
+		if _, err := f.Tag.Get(key); err == nil {
+			return false
+		}
+	}
+	return true
+}
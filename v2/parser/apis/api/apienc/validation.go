@@ -0,0 +1,325 @@
+package apienc
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"encr.dev/pkg/errors"
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/internals/schema"
+	"encr.dev/v2/internals/schema/schemautil"
+)
+
+// ValidationRuleKind is the kind of a validation rule.
+type ValidationRuleKind int
+
+const (
+	Required   ValidationRuleKind = iota // the value must be non-zero
+	MinLen                               // the length must be at least Num
+	MaxLen                               // the length must be at most Num
+	MinVal                               // the value must be at least Num
+	MaxVal                               // the value must be at most Num
+	IsEmail                              // the value must be an email address
+	IsURL                                // the value must be a URL
+	StartsWith                           // the value must start with Str
+	EndsWith                             // the value must end with Str
+	MaxSize                              // files must be at most Num bytes
+	Accept                               // files must have one of the media types in Str
+)
+
+// ValidationRule is a rule from the validate tag of a struct field,
+// such as `validate:"min=1,max=100,email"`, which the runtime enforces
+// on incoming requests.
+type ValidationRule struct {
+	Kind ValidationRuleKind
+	Num  float64 // for MinLen, MaxLen, MinVal, MaxVal and MaxSize
+	Str  string  // for StartsWith, EndsWith and Accept
+}
+
+// Validation is the validation rules in the validate tag of a struct field.
+type Validation struct {
+	// Rules are the rules the value must satisfy.
+	Rules []ValidationRule
+
+	// OmitEmpty is whether the rules are skipped for empty values,
+	// as with the omitempty rule.
+	OmitEmpty bool
+
+	// Empty are rules only satisfied by empty values, describing
+	// the values OmitEmpty skips. It's nil if they can't be described.
+	Empty []ValidationRule
+
+	// Elem is the validation of the elements of slices, arrays and maps,
+	// from the rules following dive. It's nil if there is none.
+	Elem *Validation
+}
+
+// validationKind describes the kinds of values validation rules apply to.
+type validationKind int
+
+const (
+	validateOther validationKind = iota
+	validateString
+	validateNumber
+	validateList // slices, arrays and maps, whose length is validated
+)
+
+// FieldValidation returns the validation rules in the field's validate tag,
+// or nil if it has none. It reports an error and returns false if a rule
+// is invalid for the field's type.
+//
+// The tags are read the way go-playground/validator reads them, so
+// omitempty skips the rules for empty values and dive applies the rules
+// following it to each element. Other rules Encore doesn't know, including
+// alternatives separated by "|" and rules for map keys, are ignored.
+func FieldValidation(errs *perr.List, field schema.StructField) (v *Validation, ok bool) {
+	return parseValidation(field, errs.Add)
+}
+
+// parseValidation parses the validate tag of field, reporting errors to report.
+func parseValidation(field schema.StructField, report func(errors.Template)) (v *Validation, ok bool) {
+	tag, err := field.Tag.Get("validate")
+	if err != nil {
+		return nil, true
+	}
+
+	root := &Validation{}
+	v, typ := root, field.Type
+	inKeys := false
+	for _, rule := range strings.Split(tag.Value(), ",") {
+		rule = strings.TrimSpace(rule)
+		name, arg, hasArg := strings.Cut(rule, "=")
+		kind := validationKindOf(typ)
+
+		switch {
+		case inKeys:
+			// Rules for map keys aren't enforced.
+			inKeys = name != "endkeys"
+			continue
+		case name == "" || strings.Contains(rule, "|"):
+			continue
+		}
+
+		switch name {
+		case "omitempty":
+			v.OmitEmpty = true
+			switch kind {
+			case validateString, validateList:
+				v.Empty = []ValidationRule{{Kind: MaxLen}}
+			case validateNumber:
+				v.Empty = []ValidationRule{{Kind: MinVal}, {Kind: MaxVal}}
+			}
+
+		case "dive":
+			elem, ok := elemType(typ)
+			if !ok {
+				report(errValidationRuleType(name, "slices, arrays and maps").AtGoNode(field.AST.Tag))
+				return nil, false
+			}
+			v.Elem = &Validation{}
+			v, typ = v.Elem, elem
+
+		case "keys":
+			inKeys = true
+
+		case "required", "email", "url":
+			if hasArg {
+				report(errValidationRuleArg(name, "no argument").AtGoNode(field.AST.Tag))
+				return nil, false
+			}
+			switch {
+			case name == "required":
+				v.Rules = append(v.Rules, ValidationRule{Kind: Required})
+			case kind != validateString:
+				report(errValidationRuleType(name, "strings").AtGoNode(field.AST.Tag))
+				return nil, false
+			case name == "email":
+				v.Rules = append(v.Rules, ValidationRule{Kind: IsEmail})
+			default:
+				v.Rules = append(v.Rules, ValidationRule{Kind: IsURL})
+			}
+
+		case "startswith", "endswith":
+			if arg == "" {
+				report(errValidationRuleArg(name, "an argument").AtGoNode(field.AST.Tag))
+				return nil, false
+			} else if kind != validateString {
+				report(errValidationRuleType(name, "strings").AtGoNode(field.AST.Tag))
+				return nil, false
+			}
+			if name == "startswith" {
+				v.Rules = append(v.Rules, ValidationRule{Kind: StartsWith, Str: arg})
+			} else {
+				v.Rules = append(v.Rules, ValidationRule{Kind: EndsWith, Str: arg})
+			}
+
+		case "maxsize":
+			size, ok := parseSize(arg)
+			if !ok {
+				report(errValidationRuleArg(name, "a size argument, such as 10MB").AtGoNode(field.AST.Tag))
+				return nil, false
+			} else if !IsFile(typ) {
+				report(errValidationRuleType(name, "files").AtGoNode(field.AST.Tag))
+				return nil, false
+			}
+			v.Rules = append(v.Rules, ValidationRule{Kind: MaxSize, Num: float64(size)})
+
+		case "accept":
+			types := strings.Fields(arg)
+			if len(types) == 0 || slices.ContainsFunc(types, func(t string) bool {
+				typ, subtype, ok := strings.Cut(t, "/")
+				return !ok || typ == "" || subtype == ""
+			}) {
+				report(errValidationRuleArg(name, "media types separated by spaces, such as \"image/png image/jpeg\"").AtGoNode(field.AST.Tag))
+				return nil, false
+			} else if !IsFile(typ) {
+				report(errValidationRuleType(name, "files").AtGoNode(field.AST.Tag))
+				return nil, false
+			}
+			v.Rules = append(v.Rules, ValidationRule{Kind: Accept, Str: strings.Join(types, " ")})
+
+		case "min", "max", "len":
+			num, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				report(errValidationRuleArg(name, "a number argument").AtGoNode(field.AST.Tag))
+				return nil, false
+			}
+
+			// len=N is shorthand for min=N,max=N.
+			minKind, maxKind := MinVal, MaxVal
+			switch kind {
+			case validateNumber:
+			case validateString, validateList:
+				if num < 0 || num != math.Trunc(num) {
+					report(errValidationRuleArg(name, "a non-negative integer argument when validating lengths").AtGoNode(field.AST.Tag))
+					return nil, false
+				}
+				minKind, maxKind = MinLen, MaxLen
+			default:
+				report(errValidationRuleType(name, "numbers, strings, slices and maps").AtGoNode(field.AST.Tag))
+				return nil, false
+			}
+			if name != "max" {
+				v.Rules = append(v.Rules, ValidationRule{Kind: minKind, Num: num})
+			}
+			if name != "min" {
+				v.Rules = append(v.Rules, ValidationRule{Kind: maxKind, Num: num})
+			}
+
+		default:
+			// Leave rules we don't know to the request's Validate method,
+			// so tags written for other validation libraries keep working.
+		}
+	}
+	return root.trim(), true
+}
+
+// trim returns v without rules that have no effect, or nil if none do.
+func (v *Validation) trim() *Validation {
+	if v == nil {
+		return nil
+	}
+	v.Elem = v.Elem.trim()
+	if len(v.Rules) == 0 && v.Elem == nil {
+		return nil
+	}
+	return v
+}
+
+// elemType returns the type of the elements of slices, arrays and maps.
+func elemType(t schema.Type) (schema.Type, bool) {
+	t, _ = schemautil.Deref(t)
+	switch t := t.(type) {
+	case schema.ListType:
+		return t.Elem, true
+	case schema.MapType:
+		return t.Value, true
+	case schema.NamedType:
+		if decl := t.Decl(); decl != nil && decl.Type != nil {
+			return elemType(decl.Type)
+		}
+	}
+	return nil, false
+}
+
+// maxFilesSize returns the largest total size of the files in field, from its
+// maxsize rule and, for lists of files, its max rule limiting their number.
+// It reports false if either is missing, leaving the size unlimited.
+func maxFilesSize(field schema.StructField) (int64, bool) {
+	v, ok := parseValidation(field, func(errors.Template) {})
+	if !ok || v == nil {
+		return 0, false
+	}
+
+	ruleNum := func(v *Validation, kind ValidationRuleKind) (int64, bool) {
+		if v != nil {
+			for _, r := range v.Rules {
+				if r.Kind == kind {
+					return int64(r.Num), true
+				}
+			}
+		}
+		return 0, false
+	}
+
+	size, ok := ruleNum(v, MaxSize)
+	if _, isList := field.Type.(schema.ListType); !isList {
+		return size, ok
+	} else if !ok {
+		size, ok = ruleNum(v.Elem, MaxSize)
+	}
+	count, hasCount := ruleNum(v, MaxLen)
+	if !ok || !hasCount {
+		return 0, false
+	} else if count > 0 && size > math.MaxInt64/count {
+		return math.MaxInt64, true
+	}
+	return size * count, true
+}
+
+// parseSize parses a size in bytes, optionally suffixed
+// by a unit of B, KB, MB or GB, each 1024 times the previous one.
+func parseSize(s string) (int64, bool) {
+	mult := int64(1)
+	for i, unit := range []string{"KB", "MB", "GB"} {
+		if num, ok := strings.CutSuffix(s, unit); ok {
+			s, mult = num, 1<<(10*(i+1))
+			break
+		}
+	}
+	if mult == 1 {
+		s = strings.TrimSuffix(s, "B")
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 || n > math.MaxInt64/mult {
+		return 0, false
+	}
+	return n * mult, true
+}
+
+// validationKindOf reports which kind of value t is, for the purpose of validation.
+func validationKindOf(t schema.Type) validationKind {
+	t, _ = schemautil.Deref(t)
+	switch t := t.(type) {
+	case schema.BuiltinType:
+		switch {
+		case schemautil.IsBuiltinKind(t, schema.String, schema.UserID):
+			return validateString
+		case schemautil.IsBuiltinKind(t, schemautil.Integers...), schemautil.IsBuiltinKind(t, schema.Float32, schema.Float64):
+			return validateNumber
+		case schemautil.IsBuiltinKind(t, schema.Bytes):
+			return validateList
+		}
+	case schema.ListType, schema.MapType:
+		return validateList
+	case schema.NamedType:
+		// Validate named types by their underlying type.
+		if decl := t.Decl(); decl != nil && decl.Type != nil {
+			return validationKindOf(decl.Type)
+		}
+	}
+	return validateOther
+}
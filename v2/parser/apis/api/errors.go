@@ -8,6 +8,18 @@ const rawHint = `hint: signature must be func(http.ResponseWriter, *http.Request
 
 For more information on how to use raw APIs see https://encore.dev/docs/primitives/raw-endpoints`
 
+const sseHint = `hint: signature must be func(context.Context, [path params...], [*RequestData], *sse.Writer[*Event]) error
+
+For more information on how to send server-sent events see https://encore.dev/docs/go/primitives/server-sent-events`
+
+const webSocketHint = `hint: signature must be func(context.Context, [path params...], [*RequestData], *websocket.Conn[*InMessage, *OutMessage]) error
+
+For more information on how to accept WebSocket connections see https://encore.dev/docs/go/primitives/websockets`
+
+const streamHint = `hint: signature must be func(context.Context, [path params...], [*RequestData], [*stream.Body]) ([*ResponseData], [*stream.Body], error)
+
+For more information on how to stream request and response bodies see https://encore.dev/docs/go/primitives/streaming-bodies`
+
 const baseHint = "For more information on how to use APIs see https://encore.dev/docs/primitives/apis"
 
 var (
@@ -34,6 +46,11 @@ For more information on how to use APIs, see https://encore.dev/docs/primitives/
 		"Invalid endpoint method %q.",
 	)
 
+	errInvalidSamplingRate = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid sampling rate %q. The sampling rate must be a percentage between 0%% and 100%%, such as \"sampling=1%%\".",
+	)
+
 	errEndpointMethodMustBeAllCaps = errRange.New(
 		"Invalid API Directive",
 		"Endpoint method must be ALLCAPS.",
@@ -134,4 +151,97 @@ For more information on how to use APIs, see https://encore.dev/docs/primitives/
 		"Invalid API call",
 		"Raw APIs cannot be called from within an Encore application.",
 	)
+
+	errAuthorizationRequiresAuth = errRange.Newf(
+		"Invalid API Directive",
+		"The %s field can only be used on APIs that require authentication.",
+	)
+
+	errOptionalRequiresAuth = errRange.New(
+		"Invalid API Directive",
+		"The optional option can only be used together with the auth access option.",
+	)
+
+	errSSEResults = errRange.New(
+		"Invalid API Function",
+		"APIs sending server-sent events must only return an error.",
+
+		errors.WithDetails(sseHint),
+	)
+
+	errSSEEventHeaders = errRange.New(
+		"Invalid API Function",
+		"Server-sent events can't contain header fields.",
+
+		errors.WithDetails(sseHint),
+	)
+
+	ErrSSEEndpointsCannotBeCalled = errRange.New(
+		"Invalid API call",
+		"APIs sending server-sent events cannot be called from within an Encore application.",
+	)
+
+	errWebSocketResults = errRange.New(
+		"Invalid API Function",
+		"APIs accepting WebSocket connections must only return an error.",
+
+		errors.WithDetails(webSocketHint),
+	)
+
+	errWebSocketMessageHeaders = errRange.New(
+		"Invalid API Function",
+		"WebSocket messages can't contain header fields.",
+
+		errors.WithDetails(webSocketHint),
+	)
+
+	errWebSocketMethod = errRange.New(
+		"Invalid API Directive",
+		"APIs accepting WebSocket connections can only use the GET method.",
+
+		errors.WithDetails(webSocketHint),
+	)
+
+	ErrWebSocketEndpointsCannotBeCalled = errRange.New(
+		"Invalid API call",
+		"APIs accepting WebSocket connections cannot be called from within an Encore application.",
+	)
+
+	errStreamBodyParam = errRange.New(
+		"Invalid API Function",
+		"The *stream.Body parameter must be the last parameter of the API.",
+
+		errors.WithDetails(streamHint),
+	)
+
+	errStreamRequestBodyFields = errRange.New(
+		"Invalid API Function",
+		"APIs streaming their request body can't have request fields encoded in the body. Use query strings or headers instead.",
+
+		errors.WithDetails(streamHint),
+	)
+
+	errStreamResponseBodyFields = errRange.New(
+		"Invalid API Function",
+		"APIs streaming their response body can't have response fields encoded in the body. Use headers instead.",
+
+		errors.WithDetails(streamHint),
+	)
+
+	errStreamRequestMethod = errRange.New(
+		"Invalid API Directive",
+		"APIs streaming their request body can't use the GET or HEAD methods.",
+
+		errors.WithDetails(streamHint),
+	)
+
+	ErrStreamEndpointsCannotBeCalled = errRange.New(
+		"Invalid API call",
+		"APIs streaming their request or response body cannot be called from within an Encore application.",
+	)
+
+	ErrMultipartEndpointsCannotBeCalled = errRange.New(
+		"Invalid API call",
+		"APIs accepting file uploads cannot be called from within an Encore application.",
+	)
 )
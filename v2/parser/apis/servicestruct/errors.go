@@ -37,6 +37,11 @@ var (
 		"Service init functions must return (*%s, error).",
 	)
 
+	errInvalidDependency = errRange.New(
+		"Invalid service dependencies",
+		"The depends field must be a comma-separated list of service names, like \"depends=foo,bar\".",
+	)
+
 	ErrDuplicateServiceStructs = errRange.New(
 		"Multiple service structs found",
 		"Multiple service structs were found in the same service. Encore only allows one service struct to be defined per service.",
@@ -51,4 +56,14 @@ var (
 		"Service struct referenced in another service",
 		"Service structs cannot be referenced in other services. They can only be referenced in the service that defines them.",
 	)
+
+	ErrUnknownServiceDependency = errRange.Newf(
+		"Unknown service dependency",
+		"The service struct depends on the service %q, which does not exist.",
+	)
+
+	ErrServiceDependencyCycle = errRange.Newf(
+		"Service dependency cycle",
+		"The service dependencies form a cycle (%s), so the services can't be initialized in order.",
+	)
 )
@@ -24,6 +24,16 @@ type ServiceStruct struct {
 	// Init is the function for initializing this group.
 	// It is nil if there is no initialization function.
 	Init option.Option[*schema.FuncDecl]
+
+	// DependsOn are the services that must be initialized before this one,
+	// as declared with "encore:service depends=foo,bar".
+	DependsOn []Dependency
+}
+
+// Dependency describes a service that a service struct depends on.
+type Dependency struct {
+	AST     directive.Field // the directive field declaring the dependency
+	Service string          // the name of the service
 }
 
 func (ss *ServiceStruct) Kind() resource.Kind       { return resource.ServiceStruct }
@@ -46,8 +56,19 @@ type ParseData struct {
 
 // Parse parses the service struct in the provided type declaration.
 func Parse(d ParseData) *ServiceStruct {
-	// We don't allow anything on the directive besides "encore:service".
-	directive.Validate(d.Errs, d.Dir, directive.ValidateSpec{})
+	// The only thing allowed on the directive is the list of dependencies.
+	directive.Validate(d.Errs, d.Dir, directive.ValidateSpec{
+		AllowedFields: []string{"depends"},
+		ValidateField: func(errs *perr.List, f directive.Field) bool {
+			for _, name := range f.List() {
+				if name == "" {
+					errs.Add(errInvalidDependency.AtGoNode(f))
+					return false
+				}
+			}
+			return true
+		},
+	})
 
 	// We only support encore:service directives directly on the type declaration,
 	// not on a group of type declarations.
@@ -68,6 +89,14 @@ func Parse(d ParseData) *ServiceStruct {
 		Doc:  d.Doc,
 	}
 
+	for _, f := range d.Dir.Fields {
+		if f.Key == "depends" {
+			for _, name := range f.List() {
+				ss.DependsOn = append(ss.DependsOn, Dependency{AST: f, Service: name})
+			}
+		}
+	}
+
 	// Find the init function for this service struct, if any.
 	initFunc := d.File.Pkg.Names().PkgDecls["init"+ss.Decl.Name]
 	if initFunc != nil && initFunc.Type == token.FUNC {
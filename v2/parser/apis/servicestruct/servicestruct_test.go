@@ -111,6 +111,41 @@ func initFoo(int) (*Foo, error) {}
 `,
 			wantErrs: []string{`.*Service init functions cannot have parameters`},
 		},
+		{
+			name: "with_dependencies",
+			def: `
+//encore:service depends=bar,baz
+type Foo struct {}
+`,
+			want: &ServiceStruct{
+				Decl: &schema.TypeDecl{
+					File:       file,
+					Name:       "Foo",
+					Type:       schema.StructType{},
+					TypeParams: nil,
+				},
+				DependsOn: []Dependency{
+					{AST: directive.Field{Key: "depends", Value: "bar,baz"}, Service: "bar"},
+					{AST: directive.Field{Key: "depends", Value: "bar,baz"}, Service: "baz"},
+				},
+			},
+		},
+		{
+			name: "error_dependencies_empty",
+			def: `
+//encore:service depends=bar,
+type Foo struct {}
+`,
+			wantErrs: []string{`.*The depends field must be a comma-separated list of service names`},
+		},
+		{
+			name: "error_unknown_field",
+			def: `
+//encore:service after=bar
+type Foo struct {}
+`,
+			wantErrs: []string{`.*Unknown field.*`},
+		},
 	}
 
 	// testArchive renders the txtar archive to use for a given test.
@@ -96,7 +96,13 @@ func parseLoad(d parseutil.ReferenceInfo) {
 func walkCfgToVerify(errs *perr.List, load *Load, decl schema.Type, insideConfigValue bool) {
 	switch decl := decl.(type) {
 	case schema.BuiltinType:
-		// no-op ok
+		// Files only exist in API requests.
+		if decl.Kind == schema.File {
+			errs.Add(errInvalidConfigTypeUsed.
+				AtGoNode(decl.ASTExpr(), errors.AsError("files cannot be used in config")).
+				AtGoNode(load, errors.AsHelp("config loaded here")),
+			)
+		}
 	case schema.NamedType:
 		if decl.DeclInfo.File.Pkg.ImportPath == "encore.dev/config" {
 			if insideConfigValue {
@@ -74,7 +74,12 @@ elemLoop:
 				}
 
 			case *ast.CompositeLit:
-				subStruct = value
+				// Slice and map literals are values rather than sub structs.
+				switch value.Type.(type) {
+				case *ast.ArrayType, *ast.MapType:
+				default:
+					subStruct = value
+				}
 			}
 
 			if subStruct != nil {
@@ -112,6 +117,10 @@ func ParseConstant(errs *perr.List, file *pkginfo.File, value ast.Expr) (rtn con
 		// Functions are not literal constant values
 		return constant.MakeUnknown()
 
+	case *ast.CompositeLit:
+		// Neither are slice and map literals
+		return constant.MakeUnknown()
+
 	case *ast.Ident:
 		switch value.Name {
 		case "true":
@@ -16,6 +16,11 @@ var (
 		"%s requires 2 arguments; the metric name and the config object, got %d arguments.",
 	)
 
+	errInvalidCallbackArgCount = errRange.Newf(
+		"Invalid metric construction",
+		"%s requires 3 arguments; the metric name, the config object and the callback function, got %d arguments.",
+	)
+
 	errInvalidMetricType = errRange.New(
 		"Invalid metric construction",
 		"The metric value type must be a builtin type.",
@@ -41,6 +46,11 @@ var (
 		"Invalid metric label field: must be string, bool, or integer type.",
 	)
 
+	errNegativeMaxSeries = errRange.New(
+		"Invalid metric configuration",
+		"MaxSeries must not be negative.",
+	)
+
 	errLabelReservedName = errRange.New(
 		"Invalid metric label name",
 		"Metric labels cannot be named 'service' as this is reserved by Encore.",
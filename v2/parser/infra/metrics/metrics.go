@@ -5,6 +5,7 @@ import (
 	"go/ast"
 	"go/token"
 	"strings"
+	"time"
 
 	"encr.dev/pkg/errors"
 	"encr.dev/pkg/idents"
@@ -26,6 +27,8 @@ type MetricType int
 const (
 	Counter MetricType = iota
 	Gauge
+	Histogram
+	Summary
 )
 
 type Metric struct {
@@ -49,6 +52,10 @@ type Metric struct {
 	// The struct literal for the config. Used to inject additional configuration
 	// at compile-time.
 	ConfigLiteral *ast.CompositeLit
+
+	// Callback is whether the metric value is computed by a callback
+	// when metrics are collected, as opposed to being recorded directly.
+	Callback bool
 }
 
 func (m *Metric) Kind() resource.Kind       { return resource.Metric }
@@ -75,14 +82,20 @@ type metricConstructor struct {
 	ConfigName  string
 	ConfigParse configParseFunc
 	HasLabels   bool
+	HasCallback bool
 	Type        MetricType
 }
 
 var metricConstructors = []metricConstructor{
-	{"NewCounter", "CounterConfig", parseCounterConfig, false, Counter},
-	{"NewCounterGroup", "CounterConfig", parseCounterConfig, true, Counter},
-	{"NewGauge", "GaugeConfig", parseGaugeConfig, false, Gauge},
-	{"NewGaugeGroup", "GaugeConfig", parseGaugeConfig, true, Gauge},
+	{"NewCounter", "CounterConfig", parseCounterConfig, false, false, Counter},
+	{"NewCounterGroup", "CounterConfig", parseCounterConfig, true, false, Counter},
+	{"NewGauge", "GaugeConfig", parseGaugeConfig, false, false, Gauge},
+	{"NewGaugeGroup", "GaugeConfig", parseGaugeConfig, true, false, Gauge},
+	{"NewGaugeFunc", "GaugeConfig", parseGaugeConfig, false, true, Gauge},
+	{"NewHistogram", "HistogramConfig", parseHistogramConfig, false, false, Histogram},
+	{"NewHistogramGroup", "HistogramConfig", parseHistogramConfig, true, false, Histogram},
+	{"NewSummary", "SummaryConfig", parseSummaryConfig, false, false, Summary},
+	{"NewSummaryGroup", "SummaryConfig", parseSummaryConfig, true, false, Summary},
 }
 
 var MetricParser = &resourceparser.Parser{
@@ -130,7 +143,12 @@ var MetricParser = &resourceparser.Parser{
 func parseMetric(c metricConstructor, d parseutil.ReferenceInfo) {
 	displayName := d.ResourceFunc.NaiveDisplayName()
 	errs := d.Pass.Errs
-	if len(d.Call.Args) != 2 {
+	if c.HasCallback {
+		if len(d.Call.Args) != 3 {
+			errs.Add(errInvalidCallbackArgCount(displayName, len(d.Call.Args)).AtGoNode(d.Call))
+			return
+		}
+	} else if len(d.Call.Args) != 2 {
 		errs.Add(errInvalidArgCount(displayName, len(d.Call.Args)).AtGoNode(d.Call))
 		return
 	}
@@ -201,6 +219,7 @@ func parseMetric(c metricConstructor, d parseutil.ReferenceInfo) {
 		ValueType: valueType.(schema.BuiltinType),
 		LabelType: labelType,
 		Labels:    labelFields,
+		Callback:  c.HasCallback,
 	}
 
 	// Parse and validate the metric configuration.
@@ -218,15 +237,47 @@ func parseMetric(c metricConstructor, d parseutil.ReferenceInfo) {
 type configParseFunc func(c metricConstructor, d parseutil.ReferenceInfo, cfgLit *literals.Struct, dst *Metric)
 
 func parseCounterConfig(c metricConstructor, d parseutil.ReferenceInfo, cfgLit *literals.Struct, dst *Metric) {
-	// We don't have any actual configuration yet.
-	// Parse anyway to make sure we don't have any fields we don't expect.
-	type decodedConfig struct{}
-	_ = literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
+	type decodedConfig struct {
+		MaxSeries int `literal:",optional"`
+	}
+	cfg := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
+	checkMaxSeries(d, cfgLit, cfg.MaxSeries)
 }
 
 func parseGaugeConfig(c metricConstructor, d parseutil.ReferenceInfo, cfgLit *literals.Struct, dst *Metric) {
-	// We don't have any actual configuration yet.
-	// Parse anyway to make sure we don't have any fields we don't expect.
-	type decodedConfig struct{}
-	_ = literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
+	type decodedConfig struct {
+		MaxSeries int `literal:",optional"`
+	}
+	cfg := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
+	checkMaxSeries(d, cfgLit, cfg.MaxSeries)
+}
+
+func parseHistogramConfig(c metricConstructor, d parseutil.ReferenceInfo, cfgLit *literals.Struct, dst *Metric) {
+	// The buckets are validated when the histogram is created at runtime,
+	// so they don't need to be a compile-time constant.
+	type decodedConfig struct {
+		Buckets   ast.Expr `literal:",optional,dynamic"`
+		MaxSeries int      `literal:",optional"`
+	}
+	cfg := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
+	checkMaxSeries(d, cfgLit, cfg.MaxSeries)
+}
+
+func parseSummaryConfig(c metricConstructor, d parseutil.ReferenceInfo, cfgLit *literals.Struct, dst *Metric) {
+	// Like histogram buckets, the configuration is validated at runtime.
+	type decodedConfig struct {
+		Objectives ast.Expr      `literal:",optional,dynamic"`
+		MaxAge     time.Duration `literal:",optional"`
+		AgeBuckets int           `literal:",optional"`
+		MaxSeries  int           `literal:",optional"`
+	}
+	cfg := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
+	checkMaxSeries(d, cfgLit, cfg.MaxSeries)
+}
+
+// checkMaxSeries reports an error if the configured series limit is negative.
+func checkMaxSeries(d parseutil.ReferenceInfo, cfgLit *literals.Struct, maxSeries int) {
+	if maxSeries < 0 {
+		d.Pass.Errs.Add(errNegativeMaxSeries.AtGoNode(cfgLit.Expr("MaxSeries")))
+	}
 }
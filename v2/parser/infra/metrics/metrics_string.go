@@ -10,11 +10,13 @@ func _() {
 	var x [1]struct{}
 	_ = x[Counter-0]
 	_ = x[Gauge-1]
+	_ = x[Histogram-2]
+	_ = x[Summary-3]
 }
 
-const _MetricType_name = "CounterGauge"
+const _MetricType_name = "CounterGaugeHistogramSummary"
 
-var _MetricType_index = [...]uint8{0, 7, 12}
+var _MetricType_index = [...]uint8{0, 7, 12, 21, 28}
 
 func (i MetricType) String() string {
 	if i < 0 || i >= MetricType(len(_MetricType_index)-1) {
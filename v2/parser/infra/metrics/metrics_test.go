@@ -73,6 +73,106 @@ type Labels struct {
 				Type:      Gauge,
 			},
 		},
+		{
+			Name: "gauge_func",
+			Code: `
+// Metric docs
+var x = metrics.NewGaugeFunc[int]("name", metrics.GaugeConfig{}, func() int {
+	return 1
+})
+`,
+			Want: &Metric{
+				Name:      "name",
+				Doc:       "Metric docs\n",
+				Type:      Gauge,
+				ValueType: schematest.Int(),
+				Callback:  true,
+			},
+		},
+		{
+			Name: "gauge_func_no_callback",
+			Code: `
+var x = metrics.NewGaugeFunc[int]("name", metrics.GaugeConfig{})
+`,
+			WantErrs: []string{`.*requires 3 arguments.*`},
+		},
+		{
+			Name: "histogram",
+			Code: `
+// Metric docs
+var x = metrics.NewHistogram[int]("name", metrics.HistogramConfig{
+	Buckets: []float64{0.0001, 0.001, 0.01},
+})
+`,
+			Want: &Metric{
+				Name:      "name",
+				Doc:       "Metric docs\n",
+				Type:      Histogram,
+				ValueType: schematest.Int(),
+			},
+		},
+		{
+			Name: "histogram_group",
+			Code: `
+// Metric docs
+var x = metrics.NewHistogramGroup[Labels, int]("name", metrics.HistogramConfig{
+	Buckets: buckets,
+})
+
+var buckets = []float64{1, 2, 4}
+
+type Labels struct {
+	ID string
+}
+`,
+			Want: &Metric{
+				Name:      "name",
+				Doc:       "Metric docs\n",
+				Labels:    []Label{{Key: "id", Type: schematest.String()}},
+				ValueType: schematest.Int(),
+				Type:      Histogram,
+			},
+		},
+		{
+			Name: "summary",
+			Code: `
+// Metric docs
+var x = metrics.NewSummary[int]("name", metrics.SummaryConfig{
+	Objectives: map[float64]float64{0.5: 0.05, 0.99: 0.001},
+	MaxAge:     5 * time.Minute,
+})
+`,
+			Imports: []string{"time"},
+			Want: &Metric{
+				Name:      "name",
+				Doc:       "Metric docs\n",
+				Type:      Summary,
+				ValueType: schematest.Int(),
+			},
+		},
+		{
+			Name: "max_series",
+			Code: `
+var x = metrics.NewCounterGroup[Labels, int]("name", metrics.CounterConfig{MaxSeries: 100})
+
+type Labels struct {
+	ID string
+}
+`,
+			Want: &Metric{
+				Name:      "name",
+				Type:      Counter,
+				Labels:    []Label{{Key: "id", Type: schematest.String()}},
+				ValueType: schematest.Int(),
+			},
+		},
+		{
+			Name: "negative_max_series",
+			Code: `
+var x = metrics.NewGauge[int]("name", metrics.GaugeConfig{MaxSeries: -1})
+`,
+			WantErrs: []string{`.*MaxSeries must not be negative.*`},
+		},
 	}
 
 	resourcetest.Run(t, MetricParser, tests, cmpopts.IgnoreFields(Metric{}, "LabelType"))
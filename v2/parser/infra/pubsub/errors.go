@@ -14,7 +14,7 @@ const (
 		"\t\tHandler: func(ctx context.Context, event MyMessage) error { return nil },\n" +
 		"\t})"
 
-	pubsubTopicUsageHelp = "The topic can only be referenced by calling methods on it, or to pass it to pubsub.NewSubscription or et.Topic."
+	pubsubTopicUsageHelp = "The topic can only be referenced by calling methods on it, or to pass it to pubsub.NewSubscription, et.Topic or the et.AssertPublished family of functions."
 
 	pubsubMethodHandlerHelp = "For example `pubsub.MethodHandler(Service.MethodName)` or `pubsub.MethodHandler((*Service).MethodName)`.`"
 )
@@ -1,6 +1,8 @@
 package pubsub
 
 import (
+	"slices"
+
 	"encr.dev/pkg/option"
 	"encr.dev/v2/internals/perr"
 	"encr.dev/v2/internals/pkginfo"
@@ -53,7 +55,7 @@ func ResolveTopicUsage(data usage.ResolveData, topic *Topic) usage.Usage {
 		case option.Contains(expr.PkgFunc, pkginfo.Q("encore.dev/pubsub", "NewSubscription")):
 			// Allowed usage
 			return nil
-		case option.Contains(expr.PkgFunc, pkginfo.Q("encore.dev/et", "Topic")):
+		case expr.PkgFunc.Contains(isETTopicFunc):
 			// Allowed usage
 			return nil
 		case option.Contains(expr.PkgFunc, pkginfo.Q("encore.dev/pubsub", "TopicRef")):
@@ -65,6 +67,19 @@ func ResolveTopicUsage(data usage.ResolveData, topic *Topic) usage.Usage {
 	return nil
 }
 
+// etTopicFuncs are the functions in the et package that accept a topic.
+var etTopicFuncs = []string{
+	"Topic",
+	"AssertPublished",
+	"AssertNotPublished",
+	"AssertPublishedInOrder",
+	"AssertPublishedUnordered",
+}
+
+func isETTopicFunc(fn pkginfo.QualifiedName) bool {
+	return fn.PkgPath == "encore.dev/et" && slices.Contains(etTopicFuncs, fn.Name)
+}
+
 func parseTopicRef(errs *perr.List, expr *usage.FuncArg) usage.Usage {
 	if len(expr.TypeArgs) < 1 {
 		errs.Add(errTopicRefNoTypeArgs.AtGoNode(expr.Call))
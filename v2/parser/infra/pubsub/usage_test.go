@@ -32,6 +32,22 @@ func Foo() { topic.Publish(context.Background(), Msg{}) }
 `,
 			Want: []usage.Usage{&pubsub.PublishUsage{}},
 		},
+		{
+			Name:    "et_helpers",
+			Imports: []string{"encore.dev/et"},
+			Code: `
+type Msg struct{}
+
+var topic = pubsub.NewTopic[Msg]("topic", pubsub.TopicConfig{DeliveryGuarantee: pubsub.AtLeastOnce})
+
+func Foo() {
+	et.Topic(topic)
+	et.AssertPublished(nil, topic, nil)
+	et.AssertPublishedInOrder(nil, topic)
+}
+`,
+			Want: []usage.Usage{},
+		},
 		{
 			Name: "ref",
 			Code: `
@@ -32,8 +32,8 @@ var (
 		"Anonymous fields are not allowed in the secrets struct.",
 	)
 
-	errSecretsMustBeString = errRange.New(
+	errInvalidSecretType = errRange.New(
 		"Invalid secrets struct",
-		"Secrets must be of type string.",
+		"Secrets must be of type string, []byte, or secret.File (from encore.dev/types/secret).",
 	)
 )
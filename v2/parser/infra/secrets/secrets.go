@@ -6,6 +6,7 @@ import (
 	"go/token"
 
 	"encr.dev/pkg/errors"
+	"encr.dev/pkg/paths"
 	"encr.dev/v2/internals/pkginfo"
 	"encr.dev/v2/internals/schema"
 	"encr.dev/v2/internals/schema/schemautil"
@@ -22,6 +23,9 @@ type Secrets struct {
 	Ident *ast.Ident    // The identifier of the secrets struct
 	Keys  []string      // Secret keys to load
 
+	// Kinds describes how each secret key is loaded, keyed by name.
+	Kinds map[string]KeyKind
+
 	// Spec is the value spec that defines the 'secrets' variable.
 	Spec *ast.ValueSpec
 }
@@ -30,6 +34,18 @@ type SecretKey struct {
 	Name string
 }
 
+// KeyKind describes the Go type a secret is loaded into.
+type KeyKind int
+
+const (
+	StringKey KeyKind = iota // string
+	BytesKey                 // []byte
+	FileKey                  // secret.File
+)
+
+// secretTypePkg is the package containing the non-builtin secret types.
+const secretTypePkg paths.Pkg = "encore.dev/types/secret"
+
 func (*Secrets) Kind() resource.Kind         { return resource.Secrets }
 func (s *Secrets) Package() *pkginfo.Package { return s.File.Pkg }
 func (s *Secrets) ASTExpr() ast.Expr         { return s.AST }
@@ -74,6 +90,7 @@ var SecretsParser = &resourceparser.Parser{
 			File:  secrets.File,
 			Spec:  spec,
 			Ident: spec.Names[0],
+			Kinds: make(map[string]KeyKind),
 		}
 
 		for _, f := range st.Fields {
@@ -81,11 +98,21 @@ var SecretsParser = &resourceparser.Parser{
 				p.Errs.Add(errAnonymousFields.AtGoNode(f.AST))
 				continue
 			}
-			if !schemautil.IsBuiltinKind(f.Type, schema.String) {
-				p.Errs.Add(errSecretsMustBeString.AtGoNode(f.AST.Type, errors.AsError(fmt.Sprintf("got %s", literals.PrettyPrint(f.Type.ASTExpr())))))
+			var kind KeyKind
+			switch {
+			case schemautil.IsBuiltinKind(f.Type, schema.String):
+				kind = StringKey
+			case schemautil.IsBuiltinKind(f.Type, schema.Bytes):
+				kind = BytesKey
+			case schemautil.IsNamed(f.Type, secretTypePkg, "File"):
+				kind = FileKey
+			default:
+				p.Errs.Add(errInvalidSecretType.AtGoNode(f.AST.Type, errors.AsError(fmt.Sprintf("got %s", literals.PrettyPrint(f.Type.ASTExpr())))))
 				continue
 			}
-			res.Keys = append(res.Keys, f.Name.MustGet())
+			name := f.Name.MustGet()
+			res.Keys = append(res.Keys, name)
+			res.Kinds[name] = kind
 		}
 
 		p.RegisterResource(res)
@@ -0,0 +1,33 @@
+package secrets
+
+import (
+	"go/ast"
+	"slices"
+
+	"encr.dev/v2/parser/resource/usage"
+)
+
+// ReadUsage describes a secret being read from the secrets struct.
+type ReadUsage struct {
+	usage.Base
+	Key string
+	Sel *ast.SelectorExpr // the secrets.Key expression
+}
+
+func ResolveSecretsUsage(data usage.ResolveData, s *Secrets) usage.Usage {
+	// Only field accesses read individual secrets. Other uses, like passing
+	// the whole struct around, are not tracked.
+	fa, ok := data.Expr.(*usage.FieldAccess)
+	if !ok || !slices.Contains(s.Keys, fa.Field) {
+		return nil
+	}
+	return &ReadUsage{
+		Base: usage.Base{
+			File: data.Expr.DeclaredIn(),
+			Bind: data.Expr.ResourceBind(),
+			Expr: data.Expr,
+		},
+		Key: fa.Field,
+		Sel: fa.Expr,
+	}
+}
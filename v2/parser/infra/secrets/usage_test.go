@@ -0,0 +1,65 @@
+package secrets_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"encr.dev/v2/parser/infra/secrets"
+	"encr.dev/v2/parser/resource/usage"
+	"encr.dev/v2/parser/resource/usage/usagetest"
+)
+
+func TestResolveSecretsUsage(t *testing.T) {
+	tests := []usagetest.Case{
+		{
+			Name: "none",
+			Code: `
+var secrets struct {
+	Token string
+}
+`,
+			Want: []usage.Usage{},
+		},
+		{
+			Name: "read",
+			Code: `
+var secrets struct {
+	Token string
+	Key   []byte
+}
+
+func Foo() string { return secrets.Token + string(secrets.Key) }
+`,
+			Want: []usage.Usage{
+				&secrets.ReadUsage{Key: "Token"},
+				&secrets.ReadUsage{Key: "Key"},
+			},
+		},
+		{
+			Name:    "method_on_file",
+			Imports: []string{"encore.dev/types/secret"},
+			Code: `
+var secrets struct {
+	Cert secret.File
+}
+
+func Foo() (string, error) { return secrets.Cert.Path() }
+`,
+			Want: []usage.Usage{&secrets.ReadUsage{Key: "Cert"}},
+		},
+		{
+			Name: "whole_struct",
+			Code: `
+var secrets struct {
+	Token string
+}
+
+func Foo() any { return secrets }
+`,
+			Want: []usage.Usage{},
+		},
+	}
+
+	usagetest.Run(t, nil, tests, cmpopts.IgnoreFields(secrets.ReadUsage{}, "Sel"))
+}
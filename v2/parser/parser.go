@@ -160,6 +160,7 @@ func newUsageResolver() *usage.Resolver {
 	usage.RegisterUsageResolver[*pubsub.Topic](r, pubsub.ResolveTopicUsage)
 	usage.RegisterUsageResolver[*sqldb.Database](r, sqldb.ResolveDatabaseUsage)
 	usage.RegisterUsageResolver[*objects.Bucket](r, objects.ResolveBucketUsage)
+	usage.RegisterUsageResolver[*secrets.Secrets](r, secrets.ResolveSecretsUsage)
 
 	// API Framework
 	usage.RegisterUsageResolver[*api.Endpoint](r, api.ResolveEndpointUsage)
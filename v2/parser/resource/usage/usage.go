@@ -272,7 +272,7 @@ func (p *usageParser) classifyExpr(file *pkginfo.File, bind resource.Bind, stack
 
 			// Check if this is a method call
 			if idx >= 2 {
-				if call, ok := stack[idx-2].(*ast.CallExpr); ok {
+				if call, ok := stack[idx-2].(*ast.CallExpr); ok && call.Fun == sel {
 					return &MethodCall{
 						File:   file,
 						Bind:   bind,